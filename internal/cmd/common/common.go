@@ -1,6 +1,9 @@
 package common
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Represents an enum of valid values for the format of the output for this CLI execution
 type OutputFormat int
@@ -36,6 +39,17 @@ const (
 	OutputFlagShort     = "o"
 	OutputConfigPath    = OutputFlagName
 
+	// text layouts of the --output flag, printed as TEXT
+	TableOutputLayout    = "table"
+	WideOutputLayout     = "wide"
+	NameOutputLayout     = "name"
+	CSVOutputLayout      = "csv"
+	JSONPathOutputLayout = "jsonpath"
+	JSONPathOutputPrefix = JSONPathOutputLayout + "="
+	// CustomColumnsOutputLayout is a table of HEADER:<jsonpath> columns
+	CustomColumnsOutputLayout = "custom-columns"
+	CustomColumnsOutputPrefix = CustomColumnsOutputLayout + "="
+
 	// related to the --color flag
 	ColorFlagName    = "color"
 	ColorConfigPath  = ColorFlagName
@@ -61,23 +75,87 @@ const (
 	// related to directing logs to a file
 	LogFileFlagName   = "log-file"
 	LogFileConfigPath = LogFileFlagName
+
+	// related to the --log-format flag
+	LogFormatFlagName   = "log-format"
+	LogFormatConfigPath = LogFormatFlagName
+	LogFormatText       = "text"
+	LogFormatJSON       = "json"
+
+	// related to the --timeout flag limiting each Konnect request
+	TimeoutFlagName   = "timeout"
+	TimeoutConfigPath = "konnect." + TimeoutFlagName
+
+	// related to the read-through cache of Konnect reads
+	CacheTTLFlagName   = "cache-ttl"
+	CacheTTLConfigPath = "konnect." + CacheTTLFlagName
+	RefreshFlagName    = "refresh"
+	RefreshConfigPath  = "konnect." + RefreshFlagName
+
+	// related to reaching Konnect through egress proxies and TLS interception
+	ProxyFlagName        = "proxy"
+	ProxyConfigPath      = "konnect." + ProxyFlagName
+	CACertFlagName       = "ca-cert"
+	CACertConfigPath     = "konnect." + CACertFlagName
+	ClientCertFlagName   = "client-cert"
+	ClientCertConfigPath = "konnect." + ClientCertFlagName
+	ClientKeyFlagName    = "client-key"
+	ClientKeyConfigPath  = "konnect." + ClientKeyFlagName
 )
 
 func (of OutputFormat) String() string {
 	return [...]string{"json", "yaml", "text"}[of]
 }
 
+// OutputFormats lists the values accepted by the --output flag
+var OutputFormats = []string{
+	"json", "yaml", "text", TableOutputLayout, WideOutputLayout, NameOutputLayout, CSVOutputLayout,
+	JSONPathOutputPrefix + "<template>", CustomColumnsOutputPrefix + "<HEADER:.field,...>",
+}
+
+// OutputFormatStringToIota parses an --output value. The table, wide, name, csv,
+// jsonpath=<template> and custom-columns=<spec> layouts are variants of TEXT, see
+// OutputLayout.
 func OutputFormatStringToIota(format string) (OutputFormat, error) {
 	switch format {
 	case "json":
 		return JSON, nil
 	case "yaml":
 		return YAML, nil
-	case "text":
+	case "text", TableOutputLayout, WideOutputLayout, NameOutputLayout, CSVOutputLayout:
+		return TEXT, nil
+	}
+	if template, ok := strings.CutPrefix(format, JSONPathOutputPrefix); ok {
+		if strings.TrimSpace(template) == "" {
+			return TEXT, fmt.Errorf("invalid output format %q, %s requires a template such as %s{.items[*].name}",
+				format, JSONPathOutputPrefix, JSONPathOutputPrefix)
+		}
 		return TEXT, nil
+	}
+	if spec, ok := strings.CutPrefix(format, CustomColumnsOutputPrefix); ok {
+		if strings.TrimSpace(spec) == "" {
+			return TEXT, fmt.Errorf("invalid output format %q, %s requires columns such as %sNAME:.name,ID:.id",
+				format, CustomColumnsOutputPrefix, CustomColumnsOutputPrefix)
+		}
+		return TEXT, nil
+	}
+	return TEXT, fmt.Errorf("invalid output format %q, must be one of %v", format, OutputFormats)
+}
+
+// OutputLayout returns the text layout of an --output value and, for jsonpath and
+// custom-columns, its template. text is reported as table.
+func OutputLayout(format string) (layout string, template string) {
+	if template, ok := strings.CutPrefix(format, JSONPathOutputPrefix); ok {
+		return JSONPathOutputLayout, template
+	}
+	if spec, ok := strings.CutPrefix(format, CustomColumnsOutputPrefix); ok {
+		return CustomColumnsOutputLayout, spec
+	}
+	switch format {
+	case WideOutputLayout, NameOutputLayout, CSVOutputLayout:
+		return format, ""
 	default:
-		allowed := []string{"json", "yaml", "text"}
-		return TEXT, fmt.Errorf("invalid output format %q, must be one of %v", format, allowed)
+		return TableOutputLayout, ""
 	}
 }
 
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/common"
 )
 
 type FlagEnum struct {
@@ -39,3 +41,29 @@ func (a *FlagEnum) Set(p string) error {
 func (a *FlagEnum) Type() string {
 	return "string"
 }
+
+// OutputFormatFlag is the value of the --output flag, which accepts the output formats
+// and a jsonpath=<template> expression
+type OutputFormatFlag struct {
+	Value string
+}
+
+func NewOutputFormatFlag(d string) *OutputFormatFlag {
+	return &OutputFormatFlag{Value: d}
+}
+
+func (a OutputFormatFlag) String() string {
+	return a.Value
+}
+
+func (a *OutputFormatFlag) Set(p string) error {
+	if _, err := common.OutputFormatStringToIota(p); err != nil {
+		return err
+	}
+	a.Value = p
+	return nil
+}
+
+func (a *OutputFormatFlag) Type() string {
+	return "string"
+}
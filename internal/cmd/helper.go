@@ -160,6 +160,15 @@ type ExecutionError struct {
 	Attrs []any
 }
 
+// ExitCodeError ends the command with a documented exit code other than 1 for an outcome
+// that is not a failure, such as a plan with pending changes. Nothing is logged.
+type ExitCodeError struct {
+	// Code is the process exit code
+	Code int
+	// Msg describes the outcome
+	Msg string
+}
+
 func (e *ConfigurationError) Error() string {
 	return e.Err.Error()
 }
@@ -168,6 +177,20 @@ func (e *ExecutionError) Error() string {
 	return e.Err.Error()
 }
 
+func (e *ExitCodeError) Error() string {
+	return e.Msg
+}
+
+// PrepareExitCodeError builds an ExitCodeError AND turns off error and usage output for
+// the command
+func PrepareExitCodeError(helper Helper, code int, msg string) *ExitCodeError {
+	if helper != nil {
+		helper.GetCmd().SilenceUsage = true
+		helper.GetCmd().SilenceErrors = true
+	}
+	return &ExitCodeError{Code: code, Msg: msg}
+}
+
 // Will try and json unmarshal an error string into a slice of interfaces
 // that match the slog algorithm for varadic parameters (alternating key value pairs)
 func TryConvertErrorToAttrs(err error) []any {
@@ -0,0 +1,460 @@
+// Package jsonpath evaluates kubectl style JSONPath templates such as
+// '{.items[*].name}' or '{range .items[*]}{.id}{"\t"}{.name}{"\n"}{end}'.
+//
+// A template is literal text with expressions in braces. An expression is a path
+// ($, .field, ['field'], ..field, [n], [start:end], [*] and .*), a quoted string
+// literal, or a range over a path closed by {end}. Paths inside a range are
+// evaluated against the current element. Missing fields yield no value.
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template is a parsed JSONPath template
+type Template struct {
+	nodes []node
+}
+
+type node interface{}
+
+type textNode string
+
+type pathNode []segment
+
+type rangeNode struct {
+	path  pathNode
+	nodes []node
+}
+
+type segmentKind int
+
+const (
+	fieldSegment segmentKind = iota
+	recursiveSegment
+	wildcardSegment
+	indexSegment
+	sliceSegment
+)
+
+type segment struct {
+	kind       segmentKind
+	name       string
+	index      int
+	start, end *int
+}
+
+// Parse parses a template. A template without braces is treated as a single expression.
+func Parse(template string) (*Template, error) {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+	nodes, _, closed, err := parseNodes(template)
+	if err != nil {
+		return nil, err
+	}
+	if closed {
+		return nil, fmt.Errorf("invalid jsonpath template: {end} without {range}")
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// parseNodes parses nodes until the end of the template or an {end}, returning the
+// text after the {end} and whether an {end} was found
+func parseNodes(template string) ([]node, string, bool, error) {
+	var nodes []node
+	for template != "" {
+		open := strings.IndexByte(template, '{')
+		if open < 0 {
+			nodes = append(nodes, textNode(template))
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, textNode(template[:open]))
+		}
+		closing := findClosingBrace(template, open+1)
+		if closing < 0 {
+			return nil, "", false, fmt.Errorf("invalid jsonpath template %q: unclosed {", template)
+		}
+		expr := strings.TrimSpace(template[open+1 : closing])
+		template = template[closing+1:]
+
+		switch {
+		case expr == "end":
+			return nodes, template, true, nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, "", false, err
+			}
+			body, rest, closed, err := parseNodes(template)
+			if err != nil {
+				return nil, "", false, err
+			}
+			if !closed {
+				return nil, "", false, fmt.Errorf("invalid jsonpath template: {range %s} without {end}", path)
+			}
+			nodes = append(nodes, rangeNode{path: path, nodes: body})
+			template = rest
+		case strings.HasPrefix(expr, `"`) || strings.HasPrefix(expr, "'"):
+			text, err := unquote(expr)
+			if err != nil {
+				return nil, "", false, err
+			}
+			nodes = append(nodes, textNode(text))
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, "", false, err
+			}
+			nodes = append(nodes, path)
+		}
+	}
+	return nodes, "", false, nil
+}
+
+// findClosingBrace returns the index of the brace closing an expression starting
+// at from, skipping braces in quoted strings
+func findClosingBrace(s string, from int) int {
+	var quote byte
+	for i := from; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(expr string) (string, error) {
+	if strings.HasPrefix(expr, "'") {
+		if len(expr) < 2 || !strings.HasSuffix(expr, "'") {
+			return "", fmt.Errorf("invalid jsonpath string literal %s", expr)
+		}
+		expr = `"` + strings.ReplaceAll(expr[1:len(expr)-1], `"`, `\"`) + `"`
+	}
+	text, err := strconv.Unquote(expr)
+	if err != nil {
+		return "", fmt.Errorf("invalid jsonpath string literal %s", expr)
+	}
+	return text, nil
+}
+
+func parsePath(expr string) (pathNode, error) {
+	original := expr
+	expr = strings.TrimPrefix(expr, "$")
+	var path pathNode
+	for expr != "" {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := splitName(expr[2:])
+			if name == "" {
+				return nil, fmt.Errorf("invalid jsonpath %q: .. requires a field name", original)
+			}
+			path = append(path, segment{kind: recursiveSegment, name: name})
+			expr = rest
+		case strings.HasPrefix(expr, ".*"):
+			path = append(path, segment{kind: wildcardSegment})
+			expr = expr[2:]
+		case strings.HasPrefix(expr, "."):
+			name, rest := splitName(expr[1:])
+			if name == "" {
+				if rest == "" {
+					// "." alone is the current value
+					return path, nil
+				}
+				if rest[0] == '[' {
+					expr = rest
+					continue
+				}
+				return nil, fmt.Errorf("invalid jsonpath %q: expected a field name", original)
+			}
+			path = append(path, segment{kind: fieldSegment, name: name})
+			expr = rest
+		case strings.HasPrefix(expr, "["):
+			end := strings.IndexByte(expr, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid jsonpath %q: unclosed [", original)
+			}
+			seg, err := parseBracket(strings.TrimSpace(expr[1:end]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid jsonpath %q: %w", original, err)
+			}
+			path = append(path, seg)
+			expr = expr[end+1:]
+		default:
+			name, rest := splitName(expr)
+			if name == "" || len(path) > 0 {
+				return nil, fmt.Errorf("invalid jsonpath %q: unexpected %q", original, expr)
+			}
+			path = append(path, segment{kind: fieldSegment, name: name})
+			expr = rest
+		}
+	}
+	return path, nil
+}
+
+func splitName(expr string) (string, string) {
+	i := 0
+	for i < len(expr) && expr[i] != '.' && expr[i] != '[' && expr[i] != ' ' {
+		i++
+	}
+	return expr[:i], expr[i:]
+}
+
+func parseBracket(inner string) (segment, error) {
+	switch {
+	case inner == "*":
+		return segment{kind: wildcardSegment}, nil
+	case strings.HasPrefix(inner, "?"):
+		return segment{}, fmt.Errorf("filter expressions are not supported")
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		name, err := unquote(inner)
+		if err != nil {
+			return segment{}, err
+		}
+		return segment{kind: fieldSegment, name: name}, nil
+	case strings.Contains(inner, ":"):
+		parts := strings.SplitN(inner, ":", 2)
+		seg := segment{kind: sliceSegment}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return segment{}, fmt.Errorf("invalid slice [%s]", inner)
+			}
+			if i == 0 {
+				seg.start = &n
+			} else {
+				seg.end = &n
+			}
+		}
+		return seg, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid index [%s]", inner)
+		}
+		return segment{kind: indexSegment, index: n}, nil
+	}
+}
+
+func (p pathNode) String() string {
+	var b strings.Builder
+	for _, seg := range p {
+		switch seg.kind {
+		case fieldSegment:
+			b.WriteString("." + seg.name)
+		case recursiveSegment:
+			b.WriteString(".." + seg.name)
+		case wildcardSegment:
+			b.WriteString("[*]")
+		case indexSegment:
+			fmt.Fprintf(&b, "[%d]", seg.index)
+		case sliceSegment:
+			b.WriteString("[")
+			if seg.start != nil {
+				b.WriteString(strconv.Itoa(*seg.start))
+			}
+			b.WriteString(":")
+			if seg.end != nil {
+				b.WriteString(strconv.Itoa(*seg.end))
+			}
+			b.WriteString("]")
+		}
+	}
+	return b.String()
+}
+
+// Execute writes the template evaluated against data, which is normalized through
+// JSON so struct fields are addressed by their JSON names
+func (t *Template) Execute(w io.Writer, data any) error {
+	normalized, err := normalize(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := execute(&buf, t.nodes, normalized); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func execute(buf *bytes.Buffer, nodes []node, current any) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			buf.WriteString(string(n))
+		case pathNode:
+			values := n.eval(current)
+			for i, value := range values {
+				if i > 0 {
+					buf.WriteByte(' ')
+				}
+				if err := writeValue(buf, value); err != nil {
+					return err
+				}
+			}
+		case rangeNode:
+			values := n.path.eval(current)
+			if len(values) == 1 {
+				if items, ok := values[0].([]any); ok {
+					values = items
+				}
+			}
+			for _, value := range values {
+				if err := execute(buf, n.nodes, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p pathNode) eval(current any) []any {
+	values := []any{current}
+	for _, seg := range p {
+		var next []any
+		for _, value := range values {
+			next = append(next, seg.apply(value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func (s segment) apply(value any) []any {
+	switch s.kind {
+	case fieldSegment:
+		if object, ok := value.(map[string]any); ok {
+			if field, ok := object[s.name]; ok {
+				return []any{field}
+			}
+		}
+	case recursiveSegment:
+		return collect(value, s.name, nil)
+	case wildcardSegment:
+		switch v := value.(type) {
+		case []any:
+			return v
+		case map[string]any:
+			keys := sortedKeys(v)
+			values := make([]any, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, v[key])
+			}
+			return values
+		}
+	case indexSegment:
+		if items, ok := value.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(items)
+			}
+			if i >= 0 && i < len(items) {
+				return []any{items[i]}
+			}
+		}
+	case sliceSegment:
+		if items, ok := value.([]any); ok {
+			start, end := 0, len(items)
+			if s.start != nil {
+				start = clampIndex(*s.start, len(items))
+			}
+			if s.end != nil {
+				end = clampIndex(*s.end, len(items))
+			}
+			if start < end {
+				return items[start:end]
+			}
+		}
+	}
+	return nil
+}
+
+// collect returns the values of the name fields found anywhere below value
+func collect(value any, name string, found []any) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if field, ok := v[name]; ok {
+			found = append(found, field)
+		}
+		for _, key := range sortedKeys(v) {
+			found = collect(v[key], name, found)
+		}
+	case []any:
+		for _, item := range v {
+			found = collect(item, name, found)
+		}
+	}
+	return found
+}
+
+func clampIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	return max(0, min(i, length))
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeValue(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		buf.WriteString(v)
+	case json.Number:
+		buf.WriteString(v.String())
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
+
+func normalize(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var normalized any
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
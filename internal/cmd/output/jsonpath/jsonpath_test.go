@@ -0,0 +1,68 @@
+package jsonpath
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type apiRecord struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Count  int               `json:"count"`
+}
+
+func render(t *testing.T, template string, data any) string {
+	t.Helper()
+	tpl, err := Parse(template)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tpl.Execute(&buf, data))
+	return buf.String()
+}
+
+func TestExecute(t *testing.T) {
+	data := map[string]any{"items": []apiRecord{
+		{ID: "1", Name: "orders", Labels: map[string]string{"team": "a"}, Count: 2},
+		{ID: "2", Name: "payments", Count: 5},
+	}}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"{.items[*].name}", "orders payments"},
+		{".items[0].name", "orders"},
+		{"{.items[-1].id}", "2"},
+		{"{.items[0:1].name}", "orders"},
+		{"{$.items[1].count}", "5"},
+		{"{.items[0].labels.team}", "a"},
+		{"{.items[0]['labels']['team']}", "a"},
+		{"{..name}", "orders payments"},
+		{"{.items[0].labels}", `{"team":"a"}`},
+		{"{.items[*].missing}", ""},
+		{`{range .items[*]}{.id}{"\t"}{.name}{"\n"}{end}`, "1\torders\n2\tpayments\n"},
+		{`{range .items}[{.name}]{end}`, "[orders][payments]"},
+		{"name: {.items[0].name}", "name: orders"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.template, func(t *testing.T) {
+			require.Equal(t, tc.want, render(t, tc.template, data))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, template := range []string{
+		"{.items[*].name",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		"{.items[?(@.name=='a')]}",
+		"{.items[x]}",
+	} {
+		_, err := Parse(template)
+		require.Error(t, err, template)
+	}
+}
@@ -0,0 +1,346 @@
+package tableview
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/output/jsonpath"
+	kongctlconfig "github.com/kong/kongctl/internal/config"
+	"github.com/segmentio/cli"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// NoHeadersFlagName omits the header row of table and wide output
+	NoHeadersFlagName = "no-headers"
+	// SortByFlagName orders listed resources by a JSONPath expression
+	SortByFlagName = "sort-by"
+)
+
+// AddFlags adds the flags controlling the text layouts of --output and the order of lists
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Bool(NoHeadersFlagName, false,
+		"Omit the header row of --output table, --output wide, --output csv and --output custom-columns.")
+	flags.String(SortByFlagName, "",
+		`Order listed resources by a JSONPath expression evaluated against each of them (e.g. .updated_at).
+Numbers sort numerically, other values as text, and resources without the field last.`)
+}
+
+// textLayout is the text layout requested with --output and --no-headers
+type textLayout struct {
+	name      string
+	template  string
+	noHeaders bool
+}
+
+// namePreference are the fields naming a record in --output name, in order
+var namePreference = []string{"name", "title", "slug", "email", "version", "id"}
+
+func resolveTextLayout(helper cmdpkg.Helper, cfg kongctlconfig.Hook) textLayout {
+	name, template := cmdCommon.OutputLayout(cfg.GetString(cmdCommon.OutputConfigPath))
+	layout := textLayout{name: name, template: template}
+	if command := helper.GetCmd(); command != nil {
+		layout.noHeaders, _ = command.Flags().GetBool(NoHeadersFlagName)
+	}
+	return layout
+}
+
+// renderText writes display as a table, or the records of raw in the wide, name,
+// csv or jsonpath layouts
+func renderText(out io.Writer, layout textLayout, printer cli.PrintFlusher, display, raw any) error {
+	switch layout.name {
+	case cmdCommon.WideOutputLayout:
+		records, err := jsonRecords(raw)
+		if err != nil {
+			return err
+		}
+		return writeWideTable(out, records, layout.noHeaders)
+	case cmdCommon.CSVOutputLayout:
+		records, err := jsonRecords(raw)
+		if err != nil {
+			return err
+		}
+		return writeCSV(out, records, layout.noHeaders)
+	case cmdCommon.NameOutputLayout:
+		records, err := jsonRecords(raw)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if name := recordName(record); name != "" {
+				fmt.Fprintln(out, name)
+			}
+		}
+		return nil
+	case cmdCommon.JSONPathOutputLayout:
+		tpl, err := jsonpath.Parse(layout.template)
+		if err != nil {
+			return &cmdpkg.ConfigurationError{Err: err}
+		}
+		// Lists are addressed as .items, as in kubectl
+		data := raw
+		if v := reflect.ValueOf(raw); v.Kind() == reflect.Slice {
+			data = map[string]any{"items": raw}
+		}
+		return tpl.Execute(out, data)
+	case cmdCommon.CustomColumnsOutputLayout:
+		columns, err := parseCustomColumns(layout.template)
+		if err != nil {
+			return &cmdpkg.ConfigurationError{Err: err}
+		}
+		records, err := jsonRecords(raw)
+		if err != nil {
+			return err
+		}
+		return writeCustomColumns(out, columns, records, layout.noHeaders)
+	}
+
+	if !layout.noHeaders {
+		if printer != nil {
+			printer.Print(display)
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	table, err := cli.Format(cmdCommon.TEXT.String(), &buf)
+	if err != nil {
+		return err
+	}
+	table.Print(display)
+	table.Flush()
+	_, body, _ := bytes.Cut(buf.Bytes(), []byte("\n"))
+	_, err = out.Write(body)
+	return err
+}
+
+// jsonRecords returns raw as JSON objects, one per listed resource
+func jsonRecords(raw any) ([]map[string]any, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		records := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			if record, ok := item.(map[string]any); ok {
+				records = append(records, record)
+			}
+		}
+		return records, nil
+	default:
+		return nil, nil
+	}
+}
+
+func recordName(record map[string]any) string {
+	for _, key := range namePreference {
+		if value, ok := record[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// writeWideTable writes every field of the records holding a scalar, a list of
+// scalars or a map of scalars, without abbreviating values. Name and ID lead.
+func writeWideTable(out io.Writer, records []map[string]any, noHeaders bool) error {
+	keys := wideColumns(records)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	buffered := bufio.NewWriter(out)
+	tw := tabwriter.NewWriter(buffered, 0, 4, 2, ' ', tabwriter.DiscardEmptyColumns)
+	if !noHeaders {
+		headers := make([]string, len(keys))
+		for i, key := range keys {
+			headers[i] = strings.ToUpper(strings.NewReplacer("_", " ", "-", " ").Replace(key))
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, record := range records {
+		cells := make([]string, len(keys))
+		for i, key := range keys {
+			cells[i], _ = wideCell(record[key])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// writeCSV writes the columns of the wide layout as CSV, headed by the field names
+func writeCSV(out io.Writer, records []map[string]any, noHeaders bool) error {
+	keys := wideColumns(records)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(out)
+	if !noHeaders {
+		if err := writer.Write(keys); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		cells := make([]string, len(keys))
+		for i, key := range keys {
+			cells[i], _ = wideCell(record[key])
+		}
+		if err := writer.Write(cells); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// customColumn is a column of the custom-columns layout
+type customColumn struct {
+	header string
+	path   *jsonpath.Template
+}
+
+// parseCustomColumns parses HEADER:<jsonpath> columns separated by commas
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, ok := strings.Cut(strings.TrimSpace(part), ":")
+		header, expr = strings.TrimSpace(header), strings.TrimSpace(expr)
+		if !ok || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:<jsonpath> such as NAME:.name", part)
+		}
+		tpl, err := jsonpath.Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom column %q: %w", part, err)
+		}
+		columns = append(columns, customColumn{header: header, path: tpl})
+	}
+	return columns, nil
+}
+
+// writeCustomColumns writes a table of the columns, showing <none> for fields a
+// record lacks
+func writeCustomColumns(out io.Writer, columns []customColumn, records []map[string]any, noHeaders bool) error {
+	buffered := bufio.NewWriter(out)
+	tw := tabwriter.NewWriter(buffered, 0, 4, 2, ' ', 0)
+	if !noHeaders {
+		headers := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = column.header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, record := range records {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			var cell strings.Builder
+			if err := column.path.Execute(&cell, record); err != nil {
+				return err
+			}
+			cells[i] = strings.NewReplacer("\n", " ", "\t", " ").Replace(cell.String())
+			if cells[i] == "" {
+				cells[i] = "<none>"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// wideColumns returns the fields of the records that fit a table cell, name and ID first
+func wideColumns(records []map[string]any) []string {
+	columns := map[string]bool{}
+	for _, record := range records {
+		for key, value := range record {
+			if _, ok := wideCell(value); ok {
+				columns[key] = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(columns))
+	for key := range columns {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := wideColumnRank(keys[i]), wideColumnRank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func wideColumnRank(key string) int {
+	switch key {
+	case "name":
+		return 0
+	case "id":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// wideCell formats a field of a record for the wide layout, reporting false for
+// values that do not fit a table cell
+func wideCell(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return strings.ReplaceAll(v, "\n", " "), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return fmt.Sprint(v), true
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			cell, ok := wideCell(item)
+			if !ok {
+				return "", false
+			}
+			items = append(items, cell)
+		}
+		return strings.Join(items, ","), true
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			cell, ok := wideCell(item)
+			if !ok {
+				return "", false
+			}
+			pairs = append(pairs, key+"="+cell)
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ","), true
+	default:
+		return "", false
+	}
+}
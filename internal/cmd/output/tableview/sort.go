@@ -0,0 +1,94 @@
+package tableview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/output/jsonpath"
+)
+
+// sortKey is the value a record is ordered by
+type sortKey struct {
+	text    string
+	number  float64
+	numeric bool
+	missing bool
+}
+
+func (k sortKey) less(other sortKey) bool {
+	switch {
+	case k.missing || other.missing:
+		return !k.missing && other.missing
+	case k.numeric && other.numeric:
+		return k.number < other.number
+	default:
+		return k.text < other.text
+	}
+}
+
+// sortByExpression orders the records of raw by the value of the JSONPath expression
+// expr, keeping the order of records with equal values. display holds the rows of
+// raw for the table layout and is ordered the same way when it has as many. Values
+// other than lists are returned unchanged.
+func sortByExpression(expr string, display, raw any) (any, any, error) {
+	tpl, err := jsonpath.Parse(expr)
+	if err != nil {
+		return nil, nil, &cmdpkg.ConfigurationError{
+			Err: fmt.Errorf("invalid --%s expression: %w", SortByFlagName, err),
+		}
+	}
+	records := reflect.ValueOf(raw)
+	if records.Kind() != reflect.Slice || records.Len() < 2 {
+		return display, raw, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var items []any
+	if err := decoder.Decode(&items); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]sortKey, len(items))
+	for i, item := range items {
+		var value strings.Builder
+		if err := tpl.Execute(&value, item); err != nil {
+			return nil, nil, err
+		}
+		keys[i] = sortKey{text: value.String(), missing: value.Len() == 0}
+		if number, err := strconv.ParseFloat(keys[i].text, 64); err == nil {
+			keys[i].number, keys[i].numeric = number, true
+		}
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]].less(keys[order[j]])
+	})
+
+	if rows := reflect.ValueOf(display); rows.Kind() == reflect.Slice && rows.Len() == records.Len() {
+		display = reorder(rows, order)
+	}
+	return display, reorder(records, order), nil
+}
+
+// reorder returns a copy of the slice with the elements at the indexes of order
+func reorder(slice reflect.Value, order []int) any {
+	sorted := reflect.MakeSlice(slice.Type(), len(order), len(order))
+	for i, index := range order {
+		sorted.Index(i).Set(slice.Index(index))
+	}
+	return sorted.Interface()
+}
@@ -0,0 +1,107 @@
+package tableview
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	jqoutput "github.com/kong/kongctl/internal/cmd/output/jq"
+	"github.com/segmentio/cli"
+)
+
+// Stream renders a list page by page as the pages arrive, so a list longer than
+// memory allows is never held at once. Text layouts print their header before the
+// first page only; JSON and YAML print one document per resource.
+type Stream struct {
+	out     io.Writer
+	outType cmdCommon.OutputFormat
+	layout  textLayout
+	printer cli.PrintFlusher
+	pages   int
+}
+
+// NewStream returns a Stream writing to the output of the command. Options that
+// need the whole list, --sort-by, --jq and the jsonpath layout, are rejected.
+func NewStream(helper cmdpkg.Helper, outType cmdCommon.OutputFormat) (*Stream, error) {
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	s := &Stream{
+		out:     helper.GetStreams().Out,
+		outType: outType,
+		layout:  resolveTextLayout(helper, cfg),
+	}
+
+	unsupported := func(option string) error {
+		return &cmdpkg.ConfigurationError{Err: fmt.Errorf("%s is not supported when streaming a list", option)}
+	}
+	if sortBy, _ := helper.GetCmd().Flags().GetString(SortByFlagName); strings.TrimSpace(sortBy) != "" {
+		return nil, unsupported("--" + SortByFlagName)
+	}
+	settings, err := jqoutput.ResolveSettings(helper.GetCmd(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	if jqoutput.HasFilter(settings) {
+		return nil, unsupported("--" + jqoutput.FlagName)
+	}
+
+	switch outType {
+	case cmdCommon.TEXT:
+		if s.layout.name == cmdCommon.JSONPathOutputLayout {
+			return nil, unsupported("--output jsonpath")
+		}
+	case cmdCommon.JSON, cmdCommon.YAML:
+		if s.printer, err = cli.Format(outType.String(), s.out); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("tableview: unsupported output format %s", outType.String())
+	}
+	return s, nil
+}
+
+// WritePage renders one page of a list, display being its records for the table
+// and raw the resources of the page
+func (s *Stream) WritePage(display, raw any) error {
+	defer func() { s.pages++ }()
+
+	if s.printer != nil {
+		v := reflect.ValueOf(raw)
+		if v.Kind() != reflect.Slice {
+			s.printer.Print(raw)
+			return nil
+		}
+		for i := range v.Len() {
+			s.printer.Print(v.Index(i).Interface())
+		}
+		return nil
+	}
+
+	layout := s.layout
+	if s.pages > 0 {
+		layout.noHeaders = true
+	}
+	if layout.name != cmdCommon.TableOutputLayout || layout.noHeaders {
+		return renderText(s.out, layout, nil, display, raw)
+	}
+	// Each page is flushed as it is written, so columns are aligned per page
+	table, err := cli.Format(cmdCommon.TEXT.String(), s.out)
+	if err != nil {
+		return err
+	}
+	table.Print(display)
+	table.Flush()
+	return nil
+}
+
+// Close flushes the output of the stream
+func (s *Stream) Close() {
+	if s.printer != nil {
+		s.printer.Flush()
+	}
+}
@@ -2148,11 +2148,28 @@ func RenderForFormat(
 	title string,
 	extraOpts ...Option,
 ) error {
+	raw = emptySliceIfNil(raw)
+
+	layout := textLayout{name: cmdCommon.TableOutputLayout}
 	if helper != nil {
 		cfg, err := helper.GetConfig()
 		if err != nil {
 			return err
 		}
+		layout = resolveTextLayout(helper, cfg)
+
+		if command := helper.GetCmd(); command != nil {
+			if sortBy, _ := command.Flags().GetString(SortByFlagName); strings.TrimSpace(sortBy) != "" {
+				if interactive {
+					return &cmdpkg.ConfigurationError{
+						Err: fmt.Errorf("--%s is not supported for interactive output", SortByFlagName),
+					}
+				}
+				if display, raw, err = sortByExpression(sortBy, display, raw); err != nil {
+					return err
+				}
+			}
+		}
 
 		settings, err := jqoutput.ResolveSettings(helper.GetCmd(), cfg)
 		if err != nil {
@@ -2195,10 +2212,7 @@ func RenderForFormat(
 
 	switch outType {
 	case cmdCommon.TEXT:
-		if printer != nil {
-			printer.Print(display)
-		}
-		return nil
+		return renderText(streams.Out, layout, printer, display, raw)
 	case cmdCommon.JSON, cmdCommon.YAML:
 		if printer != nil {
 			printer.Print(raw)
@@ -2209,6 +2223,16 @@ func RenderForFormat(
 	}
 }
 
+// emptySliceIfNil replaces a nil slice with an empty one, so an empty result set
+// renders as [] in JSON and YAML output rather than null
+func emptySliceIfNil(raw any) any {
+	v := reflect.ValueOf(raw)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	return raw
+}
+
 type detailView struct {
 	id              int
 	table           *table.Model
@@ -8,9 +8,11 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/segmentio/cli"
 	"github.com/stretchr/testify/require"
 
 	cmd "github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/iostreams"
 	"github.com/kong/kongctl/internal/theme"
 )
@@ -390,3 +392,173 @@ func TestFilterPreviewDetailItems_RemovesChildRows(t *testing.T) {
 	require.Len(t, filtered, 1)
 	require.Equal(t, "id", filtered[0].Label)
 }
+
+func TestRenderForFormat_EmptyListRendersEmptyArray(t *testing.T) {
+	for _, format := range []cmdCommon.OutputFormat{cmdCommon.JSON, cmdCommon.YAML} {
+		t.Run(format.String(), func(t *testing.T) {
+			streams, _, out, _ := iostreams.NewTestIOStreams()
+			printer, err := cli.Format(format.String(), out)
+			require.NoError(t, err)
+
+			var records []sampleRecord
+			require.NoError(t, RenderForFormat(nil, false, format, printer, streams, records, records, ""))
+			printer.Flush()
+
+			require.Equal(t, "[]", strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+type rawRecord struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Config struct {
+		Endpoint string `json:"endpoint"`
+	} `json:"config"`
+}
+
+func TestRenderText_Layouts(t *testing.T) {
+	raw := []rawRecord{
+		{ID: "4f8a2c1e-0000-4000-8000-000000000001", Name: "orders", Labels: map[string]string{"team": "a"}},
+		{ID: "4f8a2c1e-0000-4000-8000-000000000002", Name: "payments"},
+	}
+	raw[0].Config.Endpoint = "https://cp.example.com"
+	display := []sampleRecord{{ID: "4f8…001", DisplayName: "orders"}, {ID: "4f8…002", DisplayName: "payments"}}
+
+	tests := []struct {
+		name   string
+		layout textLayout
+		want   string
+	}{
+		{"name", textLayout{name: cmdCommon.NameOutputLayout}, "orders\npayments\n"},
+		{"jsonpath", textLayout{name: cmdCommon.JSONPathOutputLayout, template: "{.items[*].name}"}, "orders payments"},
+		{"wide", textLayout{name: cmdCommon.WideOutputLayout}, "" +
+			"NAME      ID                                    CONFIG                           LABELS\n" +
+			"orders    4f8a2c1e-0000-4000-8000-000000000001  endpoint=https://cp.example.com  team=a\n" +
+			"payments  4f8a2c1e-0000-4000-8000-000000000002  endpoint=\n"},
+		{"csv", textLayout{name: cmdCommon.CSVOutputLayout}, "" +
+			"name,id,config,labels\n" +
+			"orders,4f8a2c1e-0000-4000-8000-000000000001,endpoint=https://cp.example.com,team=a\n" +
+			"payments,4f8a2c1e-0000-4000-8000-000000000002,endpoint=,\n"},
+		{"table without headers", textLayout{name: cmdCommon.TableOutputLayout, noHeaders: true}, "" +
+			"4f8…001  orders\n" +
+			"4f8…002  payments\n"},
+		{"custom-columns", textLayout{
+			name: cmdCommon.CustomColumnsOutputLayout, template: "NAME:.name,TEAM:.labels.team,ENDPOINT:{.config.endpoint}",
+		}, "" +
+			"NAME      TEAM    ENDPOINT\n" +
+			"orders    a       https://cp.example.com\n" +
+			"payments  <none>  <none>\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+			require.NoError(t, renderText(&out, tc.layout, nil, display, raw))
+			require.Equal(t, tc.want, trimLines(out.String()))
+		})
+	}
+}
+
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestOutputLayout(t *testing.T) {
+	layout, template := cmdCommon.OutputLayout("jsonpath={.items[*].id}")
+	require.Equal(t, cmdCommon.JSONPathOutputLayout, layout)
+	require.Equal(t, "{.items[*].id}", template)
+
+	layout, _ = cmdCommon.OutputLayout("text")
+	require.Equal(t, cmdCommon.TableOutputLayout, layout)
+
+	_, err := cmdCommon.OutputFormatStringToIota("jsonpath=")
+	require.Error(t, err)
+	format, err := cmdCommon.OutputFormatStringToIota("wide")
+	require.NoError(t, err)
+	require.Equal(t, cmdCommon.TEXT, format)
+
+	layout, template = cmdCommon.OutputLayout("custom-columns=NAME:.name")
+	require.Equal(t, cmdCommon.CustomColumnsOutputLayout, layout)
+	require.Equal(t, "NAME:.name", template)
+	_, err = cmdCommon.OutputFormatStringToIota("custom-columns=")
+	require.Error(t, err)
+}
+
+func TestRenderText_InvalidCustomColumns(t *testing.T) {
+	for _, spec := range []string{"NAME", "NAME:", ":.name", "NAME:.name,ID:{.id"} {
+		var out strings.Builder
+		err := renderText(&out, textLayout{name: cmdCommon.CustomColumnsOutputLayout, template: spec}, nil, nil,
+			[]rawRecord{{Name: "orders"}})
+		var cfgErr *cmd.ConfigurationError
+		require.ErrorAs(t, err, &cfgErr, spec)
+	}
+}
+
+func TestSortByExpression(t *testing.T) {
+	type record struct {
+		Name  string `json:"name"`
+		Count *int   `json:"count,omitempty"`
+	}
+	count := func(n int) *int { return &n }
+	raw := []record{{"c", count(10)}, {"a", count(9)}, {"b", nil}, {"d", count(10)}}
+	display := []string{"row c", "row a", "row b", "row d"}
+
+	sortedDisplay, sortedRaw, err := sortByExpression(".count", display, raw)
+	require.NoError(t, err)
+	require.Equal(t, []string{"row a", "row c", "row d", "row b"}, sortedDisplay)
+	require.Equal(t, []record{raw[1], raw[0], raw[3], raw[2]}, sortedRaw)
+	require.Equal(t, []string{"row c", "row a", "row b", "row d"}, display, "the input is unchanged")
+
+	sortedDisplay, sortedRaw, err = sortByExpression("{.name}", "table", raw)
+	require.NoError(t, err)
+	require.Equal(t, "table", sortedDisplay)
+	require.Equal(t, []record{raw[1], raw[2], raw[0], raw[3]}, sortedRaw)
+
+	_, single, err := sortByExpression(".name", nil, raw[0])
+	require.NoError(t, err)
+	require.Equal(t, raw[0], single)
+
+	_, _, err = sortByExpression("{.name", display, raw)
+	var cfgErr *cmd.ConfigurationError
+	require.ErrorAs(t, err, &cfgErr)
+}
+
+func TestStream_WritePage(t *testing.T) {
+	pages := [][]sampleRecord{
+		{{ID: "1", DisplayName: "orders"}},
+		{{ID: "2", DisplayName: "payments"}, {ID: "3", DisplayName: "users"}},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var out strings.Builder
+		stream := &Stream{out: &out, outType: cmdCommon.TEXT, layout: textLayout{name: cmdCommon.TableOutputLayout}}
+		for _, page := range pages {
+			require.NoError(t, stream.WritePage(page, page))
+		}
+		stream.Close()
+
+		lines := strings.Split(strings.TrimSpace(trimLines(out.String())), "\n")
+		require.Len(t, lines, 4, "the header is printed once")
+		require.Contains(t, lines[0], "DISPLAY NAME")
+		require.Equal(t, []string{"3", "users"}, strings.Fields(lines[3]))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out strings.Builder
+		printer, err := cli.Format(cmdCommon.JSON.String(), &out)
+		require.NoError(t, err)
+		stream := &Stream{out: &out, outType: cmdCommon.JSON, printer: printer}
+		for _, page := range pages {
+			require.NoError(t, stream.WritePage(page, page))
+		}
+		stream.Close()
+
+		require.Equal(t, 3, strings.Count(out.String(), `"ID"`), "each resource is a document")
+		require.NotContains(t, out.String(), "[")
+	})
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/common"
+	kongctlconfig "github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/profile"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const Use = "config"
+
+var (
+	configShort = i18n.T("root.config.configShort", "Manage CLI profiles and configuration")
+	configLong  = normalizers.LongDesc(i18n.T("root.config.configLong",
+		`The config command lists the profiles of the configuration file, selects the profile
+		used by default and sets configuration values of a profile.
+
+		Each profile holds its own Konnect token, base URL, default control plane and output
+		settings. A command uses the profile given with --profile, then the one in the
+		KONGCTL_PROFILE environment variable, then the one selected with use-profile.`))
+	configExamples = normalizers.Examples(i18n.T("root.config.configExamples",
+		fmt.Sprintf(`
+		# Use the prod profile unless --profile or KONGCTL_PROFILE selects another one
+		%[1]s config use-profile prod
+		# List the profiles
+		%[1]s config get-profiles
+		# Set the Konnect region of the staging profile
+		%[1]s config set konnect.region eu --profile staging
+		`, meta.CLIName)))
+)
+
+// profileEntry is a profile listed by get-profiles
+type profileEntry struct {
+	Name    string `json:"name"    yaml:"name"`
+	Current bool   `json:"current" yaml:"current"`
+}
+
+// NewConfigCmd builds the config command and its subcommands
+func NewConfigCmd() *cobra.Command {
+	rv := &cobra.Command{
+		Use:     Use,
+		Short:   configShort,
+		Long:    configLong,
+		Example: configExamples,
+	}
+	rv.AddCommand(newUseProfileCmd(), newGetProfilesCmd(), newSetCmd())
+	return rv
+}
+
+func newUseProfileCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-profile <profile>",
+		Short: i18n.T("root.config.useProfileShort", "Select the profile used by default"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			cfg, err := profiledConfig(cmd.BuildHelper(c, args))
+			if err != nil {
+				return err
+			}
+			if err := cfg.SetCurrentProfile(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.OutOrStdout(), "Switched to profile %q\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newGetProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-profiles",
+		Short: i18n.T("root.config.getProfilesShort", "List the profiles of the configuration file"),
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			helper := cmd.BuildHelper(c, args)
+			cfg, err := profiledConfig(helper)
+			if err != nil {
+				return err
+			}
+			outType, err := helper.GetOutputFormat()
+			if err != nil {
+				return err
+			}
+
+			names := cfg.Profiles()
+			if len(names) == 0 {
+				names = []string{profile.DefaultProfile}
+			}
+			entries := make([]profileEntry, 0, len(names))
+			for _, name := range names {
+				entries = append(entries, profileEntry{Name: name, Current: name == cfg.GetProfile()})
+			}
+
+			if outType == common.TEXT {
+				printProfiles(c.OutOrStdout(), entries)
+				return nil
+			}
+			p, err := cli.Format(outType.String(), helper.GetStreams().Out)
+			if err != nil {
+				return err
+			}
+			defer p.Flush()
+			p.Print(entries)
+			return nil
+		},
+	}
+}
+
+func newSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: i18n.T("root.config.setShort", "Set a configuration value of the profile"),
+		Long: normalizers.LongDesc(i18n.T("root.config.setLong",
+			`Set a configuration value in the section of the profile in the configuration file.
+			Keys are the config paths listed in the help of each flag, such as konnect.region.`)),
+		Args: cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			cfg, err := profiledConfig(cmd.BuildHelper(c, args))
+			if err != nil {
+				return err
+			}
+			if err := cfg.SetProfileValue(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.OutOrStdout(), "Set %s in profile %q\n", args[0], cfg.GetProfile())
+			return nil
+		},
+	}
+}
+
+func profiledConfig(helper cmd.Helper) (*kongctlconfig.ProfiledConfig, error) {
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	profiled, ok := cfg.(*kongctlconfig.ProfiledConfig)
+	if !ok {
+		return nil, fmt.Errorf("the configuration is not loaded from a configuration file")
+	}
+	return profiled, nil
+}
+
+// printProfiles lists the profiles, marking the profile of the command
+func printProfiles(out io.Writer, entries []profileEntry) {
+	for _, entry := range entries {
+		marker := " "
+		if entry.Current {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "%s %s\n", marker, entry.Name)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	cmdpkg "github.com/kong/kongctl/internal/cmd"
 	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
 	adoptCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/adopt/common"
+	apiCmd "github.com/kong/kongctl/internal/cmd/root/products/konnect/api"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/config"
@@ -53,6 +54,7 @@ func NewAPICmd(
 	if parentPreRun != nil {
 		cmd.PreRunE = parentPreRun
 	}
+	cmd.ValidArgsFunction = common.CompleteResourceNames("api", apiCmd.ListNames)
 
 	cmd.Flags().String(adoptCommon.NamespaceFlagName, "", "Namespace label to apply to the resource")
 	if err := cmd.MarkFlagRequired(adoptCommon.NamespaceFlagName); err != nil {
@@ -11,6 +11,7 @@ import (
 	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
 	adoptCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/adopt/common"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	portalCmd "github.com/kong/kongctl/internal/cmd/root/products/konnect/portal"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/declarative/labels"
@@ -53,6 +54,7 @@ func NewPortalCmd(
 	if parentPreRun != nil {
 		cmd.PreRunE = parentPreRun
 	}
+	cmd.ValidArgsFunction = common.CompleteResourceNames("portal", portalCmd.ListNames)
 
 	cmd.Flags().String(adoptCommon.NamespaceFlagName, "", "Namespace label to apply to the resource")
 	if err := cmd.MarkFlagRequired(adoptCommon.NamespaceFlagName); err != nil {
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CommandName = "analytics"
+)
+
+var (
+	analyticsUse = CommandName
+
+	analyticsShort = i18n.T("root.products.konnect.analytics.analyticsShort",
+		"Summarize API usage recorded by Konnect Analytics")
+
+	analyticsLong = normalizers.LongDesc(i18n.T("root.products.konnect.analytics.analyticsLong",
+		`The analytics command summarizes the requests Konnect Analytics recorded for APIs.`))
+
+	analyticsExample = normalizers.Examples(i18n.T("root.products.konnect.analytics.analyticsExample",
+		fmt.Sprintf(`
+	# Summarize the usage of an API over the last 7 days
+	%[1]s get analytics --api orders --last 7d
+	`, meta.CLIName)))
+)
+
+func NewAnalyticsCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) (*cobra.Command, error) {
+	baseCmd := cobra.Command{
+		Use:     analyticsUse,
+		Short:   analyticsShort,
+		Long:    analyticsLong,
+		Example: analyticsExample,
+	}
+
+	if verb == verbs.Get {
+		return newGetAnalyticsCmd(verb, &baseCmd, addParentFlags, parentPreRun).Command, nil
+	}
+	return &baseCmd, nil
+}
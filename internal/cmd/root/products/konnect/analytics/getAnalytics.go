@@ -0,0 +1,277 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/analytics"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	apiFlagName    = "api"
+	portalFlagName = "portal"
+	lastFlagName   = "last"
+)
+
+var (
+	getAnalyticsShort = i18n.T("root.products.konnect.analytics.getAnalyticsShort",
+		"Summarize API request counts, latency and error rates")
+	getAnalyticsLong = normalizers.LongDesc(i18n.T("root.products.konnect.analytics.getAnalyticsLong",
+		`Use the get verb with the analytics command to summarize the requests Konnect Analytics
+recorded for each API over a recent time range: the request count, the share of 4xx and 5xx
+responses, and the average and 99th percentile response latency.
+
+The summary is read from Konnect Analytics, which requires an Advanced Analytics entitlement.
+kongctl does not collect or send usage data of its own.`))
+	getAnalyticsExample = normalizers.Examples(i18n.T("root.products.konnect.analytics.getAnalyticsExample",
+		fmt.Sprintf(`
+	# Summarize the usage of every API over the last 7 days
+	%[1]s get analytics
+	# Summarize one API over the last 24 hours
+	%[1]s get analytics --api orders --last 24h
+	# Summarize the requests made through a portal, exported as CSV
+	%[1]s get analytics --portal developer-portal -o csv > usage.csv
+	`, meta.CLIName)))
+)
+
+// usageRecord is the table row of an API's usage
+type usageRecord struct {
+	API          string
+	Requests     string
+	ErrorRate    string
+	ClientErrors string
+	ServerErrors string
+	AvgLatency   string
+	P99Latency   string
+}
+
+func usageToRecord(u analytics.Usage) usageRecord {
+	name := u.APIName
+	if name == "" || name == u.APIID {
+		name = util.AbbreviateUUID(u.APIID)
+	}
+	return usageRecord{
+		API:          name,
+		Requests:     fmt.Sprint(u.Requests),
+		ErrorRate:    fmt.Sprintf("%.2f%%", u.ErrorRate*100),
+		ClientErrors: fmt.Sprint(u.ClientErrors),
+		ServerErrors: fmt.Sprint(u.ServerErrors),
+		AvgLatency:   fmt.Sprintf("%.0fms", u.LatencyAverageMs),
+		P99Latency:   fmt.Sprintf("%.0fms", u.LatencyP99Ms),
+	}
+}
+
+type getAnalyticsCmd struct {
+	*cobra.Command
+}
+
+func (c *getAnalyticsCmd) validate(helper cmd.Helper) error {
+	if len(helper.GetArgs()) > 0 {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("the analytics command does not accept arguments, select APIs with --%s", apiFlagName),
+		}
+	}
+	last, _ := helper.GetCmd().Flags().GetString(lastFlagName)
+	if err := analytics.ValidateTimeRange(last); err != nil {
+		return &cmd.ConfigurationError{Err: fmt.Errorf("--%s: %w", lastFlagName, err)}
+	}
+	return nil
+}
+
+func (c *getAnalyticsCmd) runE(cobraCmd *cobra.Command, args []string) error {
+	helper := cmd.BuildHelper(cobraCmd, args)
+	if err := c.validate(helper); err != nil {
+		return err
+	}
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	baseURL, err := common.ResolveBaseURL(cfg)
+	if err != nil {
+		return cmd.PrepareExecutionError("failed to resolve Konnect base URL", err, helper.GetCmd())
+	}
+	token, err := common.GetAccessToken(cfg, logger)
+	if err != nil {
+		return cmd.PrepareExecutionError("failed to resolve Konnect access token", err, helper.GetCmd())
+	}
+
+	apiRefs, _ := cobraCmd.Flags().GetStringSlice(apiFlagName)
+	portalRefs, _ := cobraCmd.Flags().GetStringSlice(portalFlagName)
+	last, _ := cobraCmd.Flags().GetString(lastFlagName)
+	req := analytics.UsageRequest{TimeRange: last}
+
+	if len(apiRefs) > 0 || len(portalRefs) > 0 {
+		sdk, err := helper.GetKonnectSDK(cfg, logger)
+		if err != nil {
+			return err
+		}
+		for _, ref := range apiRefs {
+			id, err := resolveAPIID(strings.TrimSpace(ref), sdk.GetAPIAPI(), helper)
+			if err != nil {
+				return err
+			}
+			req.APIIDs = append(req.APIIDs, id)
+		}
+		for _, ref := range portalRefs {
+			id, err := resolvePortalID(strings.TrimSpace(ref), sdk.GetPortalAPI(), helper)
+			if err != nil {
+				return err
+			}
+			req.PortalIDs = append(req.PortalIDs, id)
+		}
+	}
+
+	ctx := helper.GetContext()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := &analytics.Client{Doer: httpclient.NewLoggingHTTPClient(logger), BaseURL: baseURL, Token: token}
+	usages, err := client.Usage(ctx, req)
+	if err != nil {
+		return cmd.PrepareExecutionError("failed to retrieve API analytics", err, helper.GetCmd())
+	}
+
+	records := make([]usageRecord, 0, len(usages))
+	for _, usage := range usages {
+		records = append(records, usageToRecord(usage))
+	}
+
+	return tableview.RenderForFormat(helper,
+		false,
+		outType,
+		printer,
+		helper.GetStreams(),
+		records,
+		usages,
+		"API Analytics",
+		tableview.WithRootLabel(helper.GetCmd().Name()),
+	)
+}
+
+// resolveAPIID returns the ID of the API named or identified by ref
+func resolveAPIID(ref string, apiAPI helpers.APIAPI, helper cmd.Helper) (string, error) {
+	if util.IsValidUUID(ref) {
+		return ref, nil
+	}
+	res, err := apiAPI.ListApis(helper.GetContext(), kkOps.ListApisRequest{
+		PageSize: kk.Int64(common.DefaultRequestPageSize),
+		Filter: &kkComps.APIFilterParameters{
+			Name: &kkComps.StringFieldFilter{Eq: kk.String(ref)},
+		},
+	})
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return "", cmd.PrepareExecutionError("Failed to list APIs", err, helper.GetCmd(), attrs...)
+	}
+	var matches []kkComps.APIResponseSchema
+	if res.GetListAPIResponse() != nil {
+		for _, api := range res.GetListAPIResponse().Data {
+			if api.Name == ref {
+				matches = append(matches, api)
+			}
+		}
+	}
+	api, err := common.SelectByName(helper, "API", ref, matches, func(api kkComps.APIResponseSchema) string {
+		return api.ID
+	})
+	if err != nil {
+		return "", err
+	}
+	return api.ID, nil
+}
+
+// resolvePortalID returns the ID of the portal named or identified by ref
+func resolvePortalID(ref string, portalAPI helpers.PortalAPI, helper cmd.Helper) (string, error) {
+	if util.IsValidUUID(ref) {
+		return ref, nil
+	}
+	res, err := portalAPI.ListPortals(helper.GetContext(), kkOps.ListPortalsRequest{
+		PageSize: kk.Int64(common.DefaultRequestPageSize),
+		Filter: &kkComps.PortalFilterParameters{
+			Name: &kkComps.StringFieldFilter{Eq: kk.String(ref)},
+		},
+	})
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return "", cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
+	}
+	var matches []kkComps.ListPortalsResponsePortal
+	if res.GetListPortalsResponse() != nil {
+		for _, portal := range res.GetListPortalsResponse().Data {
+			if portal.Name == ref {
+				matches = append(matches, portal)
+			}
+		}
+	}
+	portal, err := common.SelectByName(helper, "portal", ref, matches, func(p kkComps.ListPortalsResponsePortal) string {
+		return p.ID
+	})
+	if err != nil {
+		return "", err
+	}
+	return portal.ID, nil
+}
+
+func newGetAnalyticsCmd(
+	verb verbs.VerbValue,
+	baseCmd *cobra.Command,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *getAnalyticsCmd {
+	rv := &getAnalyticsCmd{Command: baseCmd}
+	rv.Short = getAnalyticsShort
+	rv.Long = getAnalyticsLong
+	rv.Example = getAnalyticsExample
+	rv.RunE = rv.runE
+	if parentPreRun != nil {
+		rv.PreRunE = parentPreRun
+	}
+
+	rv.Flags().StringSlice(apiFlagName, nil,
+		"Only summarize these APIs, by name or ID (repeatable). Defaults to every API.")
+	rv.Flags().StringSlice(portalFlagName, nil,
+		"Only summarize the requests made through these portals, by name or ID (repeatable).")
+	rv.Flags().String(lastFlagName, analytics.DefaultTimeRange,
+		fmt.Sprintf("Time range to summarize, up to now. One of: %s.", strings.Join(analytics.TimeRanges, ", ")))
+
+	if addParentFlags != nil {
+		addParentFlags(verb, rv.Command)
+	}
+
+	return rv
+}
@@ -35,6 +35,8 @@ var (
 	%[1]s get api publications --api-id <api-id>
 	# List API implementations for a specific API
 	%[1]s get api implementations --api-id <api-id>
+	# Delete an API along with its publications, documents and versions
+	%[1]s delete api <id|name> --cascade
 	# List APIs using explicit konnect product
 	%[1]s get konnect apis
 	`, meta.CLIName)))
@@ -56,6 +58,9 @@ func NewAPICmd(verb verbs.VerbValue,
 	if verb == verbs.Get || verb == verbs.List {
 		return newGetAPICmd(verb, &baseCmd, addParentFlags, parentPreRun).Command, nil
 	}
+	if verb == verbs.Delete {
+		return newDeleteAPICmd(verb, &baseCmd, addParentFlags, parentPreRun).Command, nil
+	}
 
 	// Return base command for unsupported verbs
 	return &baseCmd, nil
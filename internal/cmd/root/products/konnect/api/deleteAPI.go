@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteAPIShort = i18n.T("root.products.konnect.api.deleteAPIShort",
+		"Delete a Konnect API")
+	deleteAPILong = i18n.T("root.products.konnect.api.deleteAPILong",
+		`Delete an API by ID or name.
+
+If the API has publications, documents or versions, the deletion is refused and
+the dependent resources are listed. Use --cascade to delete them first, in the
+order publications, documents (children before their parents) and versions, and
+then the API.
+
+Use --approve to skip the confirmation prompt.`)
+	deleteAPIExample = normalizers.Examples(
+		i18n.T("root.products.konnect.api.deleteAPIExamples",
+			fmt.Sprintf(`
+	# Delete an API by ID
+	%[1]s delete api 12345678-1234-1234-1234-123456789012
+
+	# Delete an API by name
+	%[1]s delete api my-api
+
+	# Delete an API along with its publications, documents and versions
+	%[1]s delete api my-api --cascade
+
+	# Delete without confirmation prompt
+	%[1]s delete api my-api --approve
+
+	`, meta.CLIName)))
+)
+
+type deleteAPICmd struct {
+	*cobra.Command
+}
+
+func (c *deleteAPICmd) validate(helper cmd.Helper) error {
+	args := helper.GetArgs()
+	if len(args) == 0 {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("API ID or name is required"),
+		}
+	}
+	if len(args) > 1 {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("too many arguments. Deleting an API requires exactly 1 argument (name or ID)"),
+		}
+	}
+	return nil
+}
+
+func (c *deleteAPICmd) runE(cobraCmd *cobra.Command, args []string) error {
+	var e error
+	helper := cmd.BuildHelper(cobraCmd, args)
+	if e = c.validate(helper); e != nil {
+		return e
+	}
+
+	logger, e := helper.GetLogger()
+	if e != nil {
+		return e
+	}
+
+	cfg, e := helper.GetConfig()
+	if e != nil {
+		return e
+	}
+
+	sdk, e := helper.GetKonnectSDK(cfg, logger)
+	if e != nil {
+		return e
+	}
+
+	identifier := strings.TrimSpace(args[0])
+	var api *kkComps.APIResponseSchema
+	if util.IsValidUUID(identifier) {
+		api, e = runGet(identifier, sdk.GetAPIAPI(), helper)
+	} else {
+		logger.Debug(fmt.Sprintf("Resolving API name '%s' to ID", identifier))
+		api, e = runListByName(identifier, sdk.GetAPIAPI(), helper, cfg)
+	}
+	if e != nil {
+		return e
+	}
+	if api == nil {
+		return cmd.PrepareExecutionErrorMsg(helper, fmt.Sprintf("API not found: %s", identifier))
+	}
+	apiID, apiName := api.ID, api.Name
+
+	cascade := common.CascadeEnabled(cobraCmd)
+	description := fmt.Sprintf("API %q", apiName)
+
+	dependents, e := apiDependents(helper, sdk, apiID, cfg)
+	if e != nil {
+		return e
+	}
+	if len(dependents) > 0 && !cascade {
+		return cmd.PrepareExecutionErrorMsg(helper, common.DependencyError(description, dependents).Error(),
+			"suggestion", "Use --cascade to delete the dependent resources along with the API")
+	}
+
+	warnings := []string{
+		fmt.Sprintf("  %-5s %s", "Name:", apiName),
+		fmt.Sprintf("  %-5s %s", "ID:", apiID),
+	}
+	warnings = append(warnings, common.DependencyReport(description, dependents)...)
+
+	if err := cmd.ConfirmDelete(helper, description, warnings...); err != nil {
+		return err
+	}
+
+	deleted, err := common.DeleteDependents(helper.GetContext(), dependents)
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return cmd.PrepareExecutionError("Failed to delete API dependents", err, helper.GetCmd(), attrs...)
+	}
+
+	logger.Info(fmt.Sprintf("Deleting API '%s' (ID: %s)", apiName, apiID))
+	if _, err = sdk.GetAPIAPI().DeleteAPI(helper.GetContext(), apiID); err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		attrs = common.AppendAPIErrorAttrs(attrs, common.ParseAPIErrorDetails(err))
+		msg := common.BuildDetailedMessage("Failed to delete API", attrs, err)
+		return cmd.PrepareExecutionError(msg, err, helper.GetCmd(), attrs...)
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	if outType == cmdCommon.TEXT {
+		for _, dependent := range deleted {
+			fmt.Fprintf(helper.GetStreams().Out, "Deleted %s\n", dependent)
+		}
+		fmt.Fprintf(helper.GetStreams().Out, "API '%s' deleted successfully\n", apiName)
+		return nil
+	}
+
+	response := map[string]any{
+		"id":      apiID,
+		"name":    apiName,
+		"status":  "deleted",
+		"message": fmt.Sprintf("API '%s' deleted successfully", apiName),
+	}
+	if cascade {
+		response["deleted_dependents"] = deleted
+	}
+	printer.Print(response)
+
+	return nil
+}
+
+// apiDependents returns the publications, documents and versions of the API in the
+// order they have to be deleted, child documents before their parents
+func apiDependents(
+	helper cmd.Helper,
+	sdk helpers.SDKAPI,
+	apiID string,
+	cfg config.Hook,
+) ([]common.Dependent, error) {
+	var dependents []common.Dependent
+
+	publications, err := fetchPublications(helper, sdk.GetAPIPublicationAPI(), apiID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, publication := range publications {
+		portalID := publication.GetPortalID()
+		dependents = append(dependents, common.NewDependent("publication", portalID, "",
+			func(ctx context.Context) error {
+				_, err := sdk.GetAPIPublicationAPI().DeletePublication(ctx, apiID, portalID)
+				return err
+			}))
+	}
+
+	documents, err := fetchDocumentSummaries(helper, sdk.GetAPIDocumentAPI(), apiID)
+	if err != nil {
+		return nil, err
+	}
+	var walk func(doc kkComps.APIDocumentSummaryWithChildren)
+	walk = func(doc kkComps.APIDocumentSummaryWithChildren) {
+		for _, child := range doc.Children {
+			walk(child)
+		}
+		docID := doc.ID
+		dependents = append(dependents, common.NewDependent("document", docID, doc.Slug,
+			func(ctx context.Context) error {
+				_, err := sdk.GetAPIDocumentAPI().DeleteAPIDocument(ctx, apiID, docID)
+				return err
+			}))
+	}
+	for _, doc := range documents {
+		walk(doc)
+	}
+
+	versions, err := fetchVersionSummaries(helper, sdk.GetAPIVersionAPI(), apiID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		versionID := version.GetID()
+		dependents = append(dependents, common.NewDependent("version", versionID, version.GetVersion(),
+			func(ctx context.Context) error {
+				_, err := sdk.GetAPIVersionAPI().DeleteAPIVersion(ctx, apiID, versionID)
+				return err
+			}))
+	}
+
+	return dependents, nil
+}
+
+func newDeleteAPICmd(
+	verb verbs.VerbValue,
+	baseCmd *cobra.Command,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *deleteAPICmd {
+	rv := deleteAPICmd{
+		Command: baseCmd,
+	}
+
+	rv.Short = deleteAPIShort
+	rv.Long = deleteAPILong
+	rv.Example = deleteAPIExample
+	rv.Args = cobra.ExactArgs(1)
+	rv.ValidArgsFunction = common.CompleteResourceNames("api", ListNames)
+
+	if parentPreRun != nil {
+		rv.PreRunE = parentPreRun
+	}
+	rv.RunE = rv.runE
+
+	if addParentFlags != nil {
+		addParentFlags(verb, rv.Command)
+	}
+	common.AddCascadeFlag(rv.Command, "API")
+
+	return &rv
+}
@@ -40,8 +40,23 @@ var (
 	%[1]s get api 22cd8a0b-72e7-4212-9099-0764f8e9c5ac
 	# Get details for an API with a specific name
 	%[1]s get api my-api
+	# List the versions and publications of an API by name
+	%[1]s get api my-api versions
+	%[1]s get api my-api publications
 	# Get all the APIs using command aliases
 	%[1]s get apis
+	# List APIs along with APIs recently deleted by kongctl
+	%[1]s get apis --include-deleted
+	# Count APIs grouped by the team label
+	%[1]s get apis --count-by label:team
+	# List the production APIs of the payments team
+	%[1]s get apis --label-selector team=payments,env=prod
+	# List the APIs of every namespace with the namespace owning each of them
+	%[1]s get apis --all-namespaces
+	# List the APIs published to a portal
+	%[1]s get apis --portal developer-portal
+	# Print the APIs of a large organization page by page as they are listed
+	%[1]s get apis --stream --page-size 100 -o json
 	`, meta.CLIName)))
 )
 
@@ -284,60 +299,43 @@ type getAPICmd struct {
 func runListByName(name string, kkClient helpers.APIAPI, helper cmd.Helper,
 	cfg config.Hook,
 ) (*kkComps.APIResponseSchema, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.APIResponseSchema
-
-	for {
-		req := kkOps.ListApisRequest{
-			PageSize:   kk.Int64(requestPageSize),
-			PageNumber: kk.Int64(pageNumber),
-		}
-
-		// Note: The SDK's ListApisRequest doesn't support include parameter
-		// Version and publication information would require separate API calls
-
-		res, err := kkClient.ListApis(helper.GetContext(), req)
-		if err != nil {
-			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list APIs", err, helper.GetCmd(), attrs...)
-		}
-
-		// Filter by name since SDK doesn't support name filtering for APIs
-		for _, api := range res.ListAPIResponse.Data {
-			if api.Name == name {
-				return &api, nil
-			}
-		}
-
-		allData = append(allData, res.ListAPIResponse.Data...)
-		totalItems := res.ListAPIResponse.Meta.Page.Total
+	allData, err := runList(kkClient, helper, cfg, 0, "")
+	if err != nil {
+		return nil, err
+	}
 
-		if len(allData) >= int(totalItems) {
-			break
+	// Filter by name since SDK doesn't support name filtering for APIs
+	var matches []kkComps.APIResponseSchema
+	for _, api := range allData {
+		if api.Name == name {
+			matches = append(matches, api)
 		}
-
-		pageNumber++
 	}
-
-	return nil, cmd.PrepareExecutionErrorMsg(helper,
-		fmt.Sprintf("API with name %s not found", name))
+	return common.SelectByName(helper, "API", name, matches, func(api kkComps.APIResponseSchema) string {
+		return api.ID
+	})
 }
 
+// runList lists the APIs, only those named nameFilter when it is not empty
 func runList(kkClient helpers.APIAPI, helper cmd.Helper,
-	cfg config.Hook,
+	cfg config.Hook, limit int, nameFilter string,
 ) ([]kkComps.APIResponseSchema, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.APIResponseSchema
+	return common.ListPages(cfg, limit, listPages(kkClient, helper, nameFilter))
+}
 
-	for {
+// listPages fetches the pages of the APIs, only those named nameFilter when it is not empty
+func listPages(kkClient helpers.APIAPI, helper cmd.Helper, nameFilter string,
+) common.PageFetcher[kkComps.APIResponseSchema] {
+	return func(pageSize, pageNumber int64) ([]kkComps.APIResponseSchema, float64, error) {
 		req := kkOps.ListApisRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
+		if nameFilter != "" {
+			req.Filter = &kkComps.APIFilterParameters{
+				Name: &kkComps.StringFieldFilter{Eq: kk.String(nameFilter)},
+			}
+		}
 
 		// Note: The SDK's ListApisRequest doesn't support include parameter
 		// Version and publication information would require separate API calls
@@ -345,20 +343,35 @@ func runList(kkClient helpers.APIAPI, helper cmd.Helper,
 		res, err := kkClient.ListApis(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list APIs", err, helper.GetCmd(), attrs...)
-		}
-
-		allData = append(allData, res.ListAPIResponse.Data...)
-		totalItems := res.ListAPIResponse.Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
+			return nil, 0, cmd.PrepareExecutionError("Failed to list APIs", err, helper.GetCmd(), attrs...)
 		}
-
-		pageNumber++
+		return res.ListAPIResponse.Data, res.ListAPIResponse.Meta.Page.Total, nil
 	}
+}
 
-	return allData, nil
+// ListNames lists the names of the APIs for shell completion
+func ListNames(helper cmd.Helper) ([]string, error) {
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return nil, err
+	}
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	all, err := runList(sdk.GetAPIAPI(), helper, cfg, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(all))
+	for _, item := range all {
+		names = append(names, item.Name)
+	}
+	return names, nil
 }
 
 func runGet(id string, kkClient helpers.APIAPI, helper cmd.Helper,
@@ -396,12 +409,57 @@ func (c *getAPICmd) validate(helper cmd.Helper) error {
 }
 
 func (c *getAPICmd) runE(cobraCmd *cobra.Command, args []string) error {
+	// 'get api <name|id> versions' lists the versions of one API
+	if handled, e := common.RunChildCommand(cobraCmd, args, apiIDFlagName, apiNameFlagName); handled {
+		return e
+	}
+
 	var e error
 	helper := cmd.BuildHelper(cobraCmd, args)
 	if e = c.validate(helper); e != nil {
 		return e
 	}
 
+	includeDeleted, e := common.IncludeDeleted(helper)
+	if e != nil {
+		return e
+	}
+
+	count, countBy, e := common.CountOptions(helper)
+	if e != nil {
+		return e
+	}
+
+	namespace, e := common.NamespaceFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	selector, e := common.LabelSelectorFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	fieldSelector, e := common.FieldSelectorFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	portalRef, e := portalFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	limit, e := common.ListLimit(helper)
+	if e != nil {
+		return e
+	}
+
+	stream, offset, e := common.StreamOptions(helper, "apis")
+	if e != nil {
+		return e
+	}
+
 	logger, e := helper.GetLogger()
 	if e != nil {
 		return e
@@ -487,12 +545,81 @@ func (c *getAPICmd) runE(cobraCmd *cobra.Command, args []string) error {
 		)
 	}
 
-	apis, e := runList(sdk.GetAPIAPI(), helper, cfg)
+	var portalID string
+	if portalRef != "" {
+		if portalID, e = resolvePortalID(portalRef, sdk.GetPortalAPI(), helper); e != nil {
+			return e
+		}
+	}
+	// Konnect filters by name, the other fields are matched as each page is listed
+	var filter common.PageFilter[kkComps.APIResponseSchema]
+	if namespace != "" || selector != nil || fieldSelector != nil || portalID != "" {
+		filter = func(apis []kkComps.APIResponseSchema) ([]kkComps.APIResponseSchema, error) {
+			if portalID != "" {
+				apis = filterAPIsByPortal(apis, portalID)
+			}
+			apis, err := common.FilterByNamespace(apis, namespace)
+			if err != nil {
+				return nil, err
+			}
+			if apis, err = common.FilterByLabelSelector(apis, selector); err != nil {
+				return nil, err
+			}
+			return common.FilterByFieldSelector(apis, fieldSelector)
+		}
+	}
+	nameFilter, _ := fieldSelector.NameEquals()
+	fetch := listPages(sdk.GetAPIAPI(), helper, nameFilter)
+	rng := common.PageRange{Limit: limit, Offset: offset}
+
+	if stream {
+		return common.StreamList(helper, cfg, outType, "apis", rng, fetch, filter,
+			func(apis []kkComps.APIResponseSchema) any { return apiDisplayRecords(apis) })
+	}
+
+	var apis []kkComps.APIResponseSchema
+	listed, e := common.StreamPages(cfg, rng, fetch, filter, func(page []kkComps.APIResponseSchema) error {
+		apis = append(apis, page...)
+		return nil
+	})
 	if e != nil {
 		return e
 	}
+	if offset == 0 {
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, listed.Listed)
+	}
+
+	if count {
+		summary, e := common.CountResources(apis, countBy)
+		if e != nil {
+			return e
+		}
+		return common.RenderCounts(helper, outType, printer, summary)
+	}
+
+	if includeDeleted {
+		ids := make([]string, 0, len(apis))
+		displayRecords := make([]textDisplayRecord, 0, len(apis))
+		for i := range apis {
+			ids = append(ids, apis[i].ID)
+			displayRecords = append(displayRecords, apiToDisplayRecord(&apis[i]))
+		}
+		deleted, e := common.ListDeletedResources(helper, "api", ids)
+		if e != nil {
+			return e
+		}
+		display, e := common.WithNamespaceColumn(helper, displayRecords, apis)
+		if e != nil {
+			return e
+		}
+		return common.RenderListWithDeleted(helper, outType, printer, display, apis, deleted)
+	}
 
-	return renderAPIList(helper, helper.GetCmd().Name(), outType, printer, apis)
+	if e = renderAPIList(helper, helper.GetCmd().Name(), outType, printer, apis); e != nil {
+		return e
+	}
+	common.PrintContinueHint(helper, "apis", listed.Next)
+	return nil
 }
 
 func renderAPIList(
@@ -502,9 +629,9 @@ func renderAPIList(
 	printer cli.PrintFlusher,
 	apis []kkComps.APIResponseSchema,
 ) error {
-	displayRecords := make([]textDisplayRecord, 0, len(apis))
-	for i := range apis {
-		displayRecords = append(displayRecords, apiToDisplayRecord(&apis[i]))
+	display, err := common.WithNamespaceColumn(helper, apiDisplayRecords(apis), apis)
+	if err != nil {
+		return err
 	}
 
 	childView := buildAPIChildView(apis)
@@ -526,13 +653,21 @@ func renderAPIList(
 		outType,
 		printer,
 		helper.GetStreams(),
-		displayRecords,
+		display,
 		apis,
 		"",
 		options...,
 	)
 }
 
+func apiDisplayRecords(apis []kkComps.APIResponseSchema) []textDisplayRecord {
+	displayRecords := make([]textDisplayRecord, 0, len(apis))
+	for i := range apis {
+		displayRecords = append(displayRecords, apiToDisplayRecord(&apis[i]))
+	}
+	return displayRecords
+}
+
 func buildAPIChildView(apis []kkComps.APIResponseSchema) tableview.ChildView {
 	tableRows := make([]table.Row, 0, len(apis))
 	for i := range apis {
@@ -578,11 +713,20 @@ func newGetAPICmd(verb verbs.VerbValue,
 		rv.PreRunE = parentPreRun
 	}
 	rv.RunE = rv.runE
+	rv.ValidArgsFunction = common.CompleteResourceNames("api", ListNames)
 
 	// Ensure parent-level flags are available on this command
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddIncludeDeletedFlag(rv.Command)
+	common.AddCountFlags(rv.Command)
+	common.AddNamespaceFilterFlag(rv.Command)
+	common.AddLabelSelectorFlag(rv.Command)
+	common.AddFieldSelectorFlag(rv.Command)
+	common.AddLimitFlag(rv.Command)
+	common.AddStreamFlags(rv.Command)
+	addPortalFilterFlag(rv.Command)
 
 	if documentsCmd := newGetAPIDocumentsCmd(verb, addParentFlags, parentPreRun); documentsCmd != nil {
 		rv.AddCommand(documentsCmd)
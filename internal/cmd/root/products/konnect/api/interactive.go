@@ -24,7 +24,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, err
 	}
 
-	apis, err := runList(sdk.GetAPIAPI(), helper, cfg)
+	apis, err := runList(sdk.GetAPIAPI(), helper, cfg, 0, "")
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
@@ -202,18 +202,25 @@ func loadAPIPublications(_ context.Context, helper cmd.Helper, parent any) (tabl
 		return tableview.ChildView{}, err
 	}
 
+	var names *publicationNames
+	if len(publications) > 0 {
+		if names, err = fetchPublicationNames(helper, sdk, cfg); err != nil {
+			return tableview.ChildView{}, err
+		}
+	}
+
 	rows := make([]table.Row, 0, len(publications))
 	for i := range publications {
-		record := publicationToRecord(publications[i])
+		record := publicationToRecord(publications[i], names)
 		upperVisibility := strings.ToUpper(record.Visibility)
-		rows = append(rows, table.Row{util.AbbreviateUUID(record.PortalID), upperVisibility})
+		rows = append(rows, table.Row{record.PortalName, upperVisibility})
 	}
 
 	detail := func(index int) string {
 		if index < 0 || index >= len(publications) {
 			return ""
 		}
-		return publicationDetailView(&publications[index])
+		return publicationDetailView(&publications[index], names)
 	}
 
 	return tableview.ChildView{
@@ -11,6 +11,7 @@ import (
 	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/cmd/output/tableview"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
@@ -30,7 +31,9 @@ const (
 
 type apiPublicationRecord struct {
 	PortalID         string
+	PortalName       string
 	Visibility       string
+	AuthStrategies   string
 	AuthStrategyIDs  string
 	LocalCreatedTime string
 	LocalUpdatedTime string
@@ -42,7 +45,8 @@ var (
 	publicationsShort = i18n.T("root.products.konnect.api.publicationsShort",
 		"Manage API publications for a Konnect API")
 	publicationsLong = normalizers.LongDesc(i18n.T("root.products.konnect.api.publicationsLong",
-		`Use the publications command to list API publications for a specific Konnect API.`))
+		`Use the publications command to list API publications for a specific Konnect API.
+Text output names the portals and auth strategies of the publications.`))
 	publicationsExample = normalizers.Examples(
 		i18n.T("root.products.konnect.api.publicationsExamples",
 			fmt.Sprintf(`
@@ -172,19 +176,27 @@ func (h apiPublicationsHandler) run(args []string) error {
 		publications = filtered
 	}
 
+	// Names are only shown by text output, the other formats keep the publications as listed
+	var names *publicationNames
+	if outType == cmdCommon.TEXT && len(publications) > 0 {
+		if names, err = fetchPublicationNames(helper, sdk, cfg); err != nil {
+			return err
+		}
+	}
+
 	records := make([]apiPublicationRecord, 0, len(publications))
 	rows := make([]table.Row, 0, len(publications))
 	for _, publication := range publications {
-		record := publicationToRecord(publication)
+		record := publicationToRecord(publication, names)
 		records = append(records, record)
-		rows = append(rows, table.Row{util.AbbreviateUUID(record.PortalID), strings.ToUpper(record.Visibility)})
+		rows = append(rows, table.Row{record.PortalName, strings.ToUpper(record.Visibility)})
 	}
 
 	detailFn := func(index int) string {
 		if index < 0 || index >= len(publications) {
 			return ""
 		}
-		return publicationDetailView(&publications[index])
+		return publicationDetailView(&publications[index], names)
 	}
 
 	return tableview.RenderForFormat(helper,
@@ -283,27 +295,33 @@ func filterPublicationsByPortal(
 	return matches
 }
 
-func publicationToRecord(publication kkComps.APIPublicationListItem) apiPublicationRecord {
+// publicationToRecord builds the text record of a publication, naming its portal and
+// auth strategies with names when it is not nil
+func publicationToRecord(publication kkComps.APIPublicationListItem, names *publicationNames) apiPublicationRecord {
 	visibility := "n/a"
 	if publication.GetVisibility() != nil {
 		visibility = string(*publication.GetVisibility())
 	}
 
+	authStrategyIDs := "n/a"
 	authStrategies := "n/a"
 	if ids := publication.GetAuthStrategyIds(); len(ids) > 0 {
-		authStrategies = strings.Join(ids, ", ")
+		authStrategyIDs = strings.Join(ids, ", ")
+		authStrategies = strings.Join(authStrategyNames(ids, names), ", ")
 	}
 
 	return apiPublicationRecord{
 		PortalID:         publication.GetPortalID(),
+		PortalName:       names.portal(publication.GetPortalID()),
 		Visibility:       visibility,
-		AuthStrategyIDs:  authStrategies,
+		AuthStrategies:   authStrategies,
+		AuthStrategyIDs:  authStrategyIDs,
 		LocalCreatedTime: publication.GetCreatedAt().In(time.Local).Format("2006-01-02 15:04:05"),
 		LocalUpdatedTime: publication.GetUpdatedAt().In(time.Local).Format("2006-01-02 15:04:05"),
 	}
 }
 
-func publicationDetailView(publication *kkComps.APIPublicationListItem) string {
+func publicationDetailView(publication *kkComps.APIPublicationListItem, names *publicationNames) string {
 	if publication == nil {
 		return ""
 	}
@@ -313,13 +331,17 @@ func publicationDetailView(publication *kkComps.APIPublicationListItem) string {
 		visibility = string(*publication.GetVisibility())
 	}
 
+	authStrategyIDs := valueNA
 	authStrategies := valueNA
 	if ids := publication.GetAuthStrategyIds(); len(ids) > 0 {
-		authStrategies = strings.Join(ids, ", ")
+		authStrategyIDs = strings.Join(ids, ", ")
+		authStrategies = strings.Join(authStrategyNames(ids, names), ", ")
 	}
 
 	fields := map[string]string{
-		"auth_strategy_ids": authStrategies,
+		"auth_strategies":   authStrategies,
+		"auth_strategy_ids": authStrategyIDs,
+		"portal_name":       names.portal(publication.GetPortalID()),
 		"created_at":        publication.GetCreatedAt().In(time.Local).Format("2006-01-02 15:04:05"),
 		"visibility":        visibility,
 		"updated_at":        publication.GetUpdatedAt().In(time.Local).Format("2006-01-02 15:04:05"),
@@ -340,3 +362,12 @@ func publicationDetailView(publication *kkComps.APIPublicationListItem) string {
 
 	return strings.TrimRight(b.String(), "\n")
 }
+
+// authStrategyNames names the auth strategies with ids
+func authStrategyNames(ids []string, names *publicationNames) []string {
+	resolved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		resolved = append(resolved, names.authStrategy(id))
+	}
+	return resolved
+}
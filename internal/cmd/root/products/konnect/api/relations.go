@@ -0,0 +1,177 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	portalFilterFlagName = "portal"
+)
+
+func addPortalFilterFlag(command *cobra.Command) {
+	command.Flags().String(portalFilterFlagName, "",
+		"Only list the APIs published to this portal, by name or ID (list only)")
+}
+
+// portalFilter returns the portal passed with --portal, or "" when listing is not
+// filtered by portal
+func portalFilter(helper cmd.Helper) (string, error) {
+	flag := helper.GetCmd().Flags().Lookup(portalFilterFlagName)
+	if flag == nil {
+		return "", nil
+	}
+	portal := strings.TrimSpace(flag.Value.String())
+	if portal != "" && len(helper.GetArgs()) > 0 {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing APIs", portalFilterFlagName),
+		}
+	}
+	return portal, nil
+}
+
+// resolvePortalID returns the ID of the portal named or identified by ref
+func resolvePortalID(ref string, portalAPI helpers.PortalAPI, helper cmd.Helper) (string, error) {
+	if util.IsValidUUID(ref) {
+		return ref, nil
+	}
+	if portalAPI == nil {
+		return "", &cmd.ExecutionError{
+			Msg: "Portals client is not available",
+			Err: fmt.Errorf("portals client not configured"),
+		}
+	}
+
+	res, err := portalAPI.ListPortals(helper.GetContext(), kkOps.ListPortalsRequest{
+		PageSize: kk.Int64(common.DefaultRequestPageSize),
+		Filter: &kkComps.PortalFilterParameters{
+			Name: &kkComps.StringFieldFilter{Eq: kk.String(ref)},
+		},
+	})
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return "", cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
+	}
+	var matches []kkComps.ListPortalsResponsePortal
+	if res.GetListPortalsResponse() != nil {
+		for _, portal := range res.GetListPortalsResponse().Data {
+			if portal.Name == ref {
+				matches = append(matches, portal)
+			}
+		}
+	}
+	portal, err := common.SelectByName(helper, "portal", ref, matches, func(p kkComps.ListPortalsResponsePortal) string {
+		return p.ID
+	})
+	if err != nil {
+		return "", err
+	}
+	return portal.ID, nil
+}
+
+// filterAPIsByPortal returns the APIs published to the portal with portalID
+func filterAPIsByPortal(apis []kkComps.APIResponseSchema, portalID string) []kkComps.APIResponseSchema {
+	matches := make([]kkComps.APIResponseSchema, 0, len(apis))
+	for _, api := range apis {
+		for _, portal := range api.GetPortals() {
+			if portal.ID == portalID {
+				matches = append(matches, api)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// publicationNames resolves the portal and auth strategy IDs of publications to names
+type publicationNames struct {
+	portals        map[string]string
+	authStrategies map[string]string
+}
+
+// fetchPublicationNames lists the portals and auth strategies of the organization to
+// name those referenced by publications
+func fetchPublicationNames(helper cmd.Helper, sdk helpers.SDKAPI, cfg config.Hook) (*publicationNames, error) {
+	names := &publicationNames{
+		portals:        make(map[string]string),
+		authStrategies: make(map[string]string),
+	}
+
+	if portalAPI := sdk.GetPortalAPI(); portalAPI != nil {
+		portals, err := common.ListPages(cfg, 0,
+			func(pageSize, pageNumber int64) ([]kkComps.ListPortalsResponsePortal, float64, error) {
+				res, err := portalAPI.ListPortals(helper.GetContext(), kkOps.ListPortalsRequest{
+					PageSize:   kk.Int64(pageSize),
+					PageNumber: kk.Int64(pageNumber),
+				})
+				if err != nil {
+					attrs := cmd.TryConvertErrorToAttrs(err)
+					return nil, 0, cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
+				}
+				return res.GetListPortalsResponse().Data, res.GetListPortalsResponse().Meta.Page.Total, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		for _, portal := range portals {
+			names.portals[portal.ID] = portal.Name
+		}
+	}
+
+	if authAPI := sdk.GetAppAuthStrategiesAPI(); authAPI != nil {
+		strategies, err := common.ListPages(cfg, 0,
+			func(pageSize, pageNumber int64) ([]kkComps.AppAuthStrategy, float64, error) {
+				res, err := authAPI.ListAppAuthStrategies(helper.GetContext(), kkOps.ListAppAuthStrategiesRequest{
+					PageSize:   kk.Int64(pageSize),
+					PageNumber: kk.Int64(pageNumber),
+				})
+				if err != nil {
+					attrs := cmd.TryConvertErrorToAttrs(err)
+					return nil, 0, cmd.PrepareExecutionError("Failed to list auth strategies", err, helper.GetCmd(), attrs...)
+				}
+				data := res.GetListAppAuthStrategiesResponse()
+				return data.Data, data.Meta.Page.Total, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		for _, strategy := range strategies {
+			switch {
+			case strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse != nil:
+				keyAuth := strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse
+				names.authStrategies[keyAuth.ID] = keyAuth.Name
+			case strategy.AppAuthStrategyOpenIDConnectResponseAppAuthStrategyOpenIDConnectResponse != nil:
+				openID := strategy.AppAuthStrategyOpenIDConnectResponseAppAuthStrategyOpenIDConnectResponse
+				names.authStrategies[openID.ID] = openID.Name
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// portal returns the name of the portal with id, or its abbreviated ID when it is unknown
+func (n *publicationNames) portal(id string) string {
+	if n != nil && n.portals[id] != "" {
+		return n.portals[id]
+	}
+	return util.AbbreviateUUID(id)
+}
+
+// authStrategy returns the name of the auth strategy with id, or id when it is unknown
+func (n *publicationNames) authStrategy(id string) string {
+	if n != nil && n.authStrategies[id] != "" {
+		return n.authStrategies[id]
+	}
+	return id
+}
@@ -0,0 +1,497 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/specrev"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	undoFlagName       = "undo"
+	rollbackToFlagName = "to"
+)
+
+var (
+	versionListShort = i18n.T("root.products.konnect.api.versionListShort",
+		"List the versions of an API with their lifecycle status")
+	versionListLong = normalizers.LongDesc(i18n.T("root.products.konnect.api.versionListLong",
+		`List the versions of a Konnect API. Each version reports whether it is the current
+version portals show, the portals the API is published to, whether it is deprecated, and
+how many spec revisions kongctl keeps locally to roll it back.`))
+
+	versionPromoteShort = i18n.T("root.products.konnect.api.versionPromoteShort",
+		"Make a version the current version of an API")
+	versionPromoteLong = normalizers.LongDesc(i18n.T("root.products.konnect.api.versionPromoteLong",
+		`Make a version, given by ID or version string, the current version of its API, which
+is the version the portals the API is published to show. Deprecated versions cannot be
+promoted until their deprecation is undone.`))
+
+	versionDeprecateShort = i18n.T("root.products.konnect.api.versionDeprecateShort",
+		"Mark a version of an API deprecated")
+	versionDeprecateLong = normalizers.LongDesc(i18n.T("root.products.konnect.api.versionDeprecateLong",
+		`Mark a version, given by ID or version string, deprecated. API versions have no
+deprecation field, so the mark is a KONGCTL-deprecated-<version-id> label on the API.
+The current version cannot be deprecated; promote another version first.`))
+
+	versionRollbackShort = i18n.T("root.products.konnect.api.versionRollbackShort",
+		"Restore a spec previously uploaded to a version")
+	versionRollbackLong = normalizers.LongDesc(i18n.T("root.products.konnect.api.versionRollbackLong",
+		`Upload again a spec previously uploaded to a version, given by ID or version string.
+kongctl keeps each spec it uploads with apply, sync or rollback in the api-spec-revisions
+directory of its config directory, addressed by the hash of its content. Without --to,
+the latest revision that differs from the live spec is restored.
+
+Roll the declarative configuration back too, or the next sync uploads its spec again.`))
+
+	versionLifecycleExamples = normalizers.Examples(
+		i18n.T("root.products.konnect.api.versionLifecycleExamples",
+			fmt.Sprintf(`
+# List the versions of an API with their status
+%[1]s api-version list --api-name my-api
+# Make version 2.0.0 the version portals show
+%[1]s api-version promote --api-name my-api 2.0.0
+# Deprecate version 1.0.0, and undo it
+%[1]s api-version deprecate --api-name my-api 1.0.0
+%[1]s api-version deprecate --api-name my-api 1.0.0 --undo
+# Restore the spec of version 2.0.0 uploaded before the live one
+%[1]s api-version rollback --api-name my-api 2.0.0
+# Restore a specific revision, by a prefix of its hash
+%[1]s api-version rollback --api-name my-api 2.0.0 --to 1f3a9c
+`, meta.CLIName)))
+)
+
+type apiVersionStatus struct {
+	ID         string `json:"id"`
+	Version    string `json:"version"`
+	Current    bool   `json:"current"`
+	Deprecated bool   `json:"deprecated"`
+	// PublishedTo lists the portals showing the version, as portals show the current
+	// version of the APIs published to them
+	PublishedTo []string           `json:"published_to"`
+	SpecHash    string             `json:"spec_hash,omitempty"`
+	Revisions   []specrev.Revision `json:"revisions"`
+}
+
+type apiVersionStatusRecord struct {
+	ID          string
+	Version     string
+	Current     string
+	Deprecated  string
+	PublishedTo string
+	Revisions   string
+}
+
+// NewVersionLifecycleCmds returns the commands of the api-version verb
+func NewVersionLifecycleCmds() []*cobra.Command {
+	newCmd := func(use, short, long string, args cobra.PositionalArgs, run func(*cobra.Command, []string) error,
+	) *cobra.Command {
+		c := &cobra.Command{
+			Use:     use,
+			Short:   short,
+			Long:    long,
+			Example: versionLifecycleExamples,
+			Args:    args,
+			PreRunE: bindAPIChildFlags,
+			RunE:    run,
+		}
+		addAPIChildFlags(c)
+		return c
+	}
+
+	listCmd := newCmd("list", versionListShort, versionListLong, cobra.NoArgs, runVersionList)
+	promoteCmd := newCmd("promote <version>", versionPromoteShort, versionPromoteLong, cobra.ExactArgs(1),
+		runVersionPromote)
+	deprecateCmd := newCmd("deprecate <version>", versionDeprecateShort, versionDeprecateLong, cobra.ExactArgs(1),
+		runVersionDeprecate)
+	deprecateCmd.Flags().Bool(undoFlagName, false, "Remove the deprecation of the version.")
+	rollbackCmd := newCmd("rollback <version>", versionRollbackShort, versionRollbackLong, cobra.ExactArgs(1),
+		runVersionRollback)
+	rollbackCmd.Flags().String(rollbackToFlagName, "",
+		"The hash, or a prefix of it, of the revision to restore, as listed by -o json.")
+
+	return []*cobra.Command{listCmd, promoteCmd, deprecateCmd, rollbackCmd}
+}
+
+// apiVersionTarget is the API a lifecycle command acts on, with its versions
+type apiVersionTarget struct {
+	helper     cmd.Helper
+	outType    cmdCommon.OutputFormat
+	apiAPI     helpers.APIAPI
+	versionAPI helpers.APIVersionAPI
+	api        *kkComps.APIResponseSchema
+	versions   []kkComps.ListAPIVersionResponseAPIVersionSummary
+}
+
+func resolveAPIVersionTarget(c *cobra.Command, args []string) (*apiVersionTarget, error) {
+	helper := cmd.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return nil, err
+	}
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return nil, err
+	}
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	apiID, apiName := getAPIIdentifiers(cfg)
+	if apiID == "" && apiName == "" {
+		return nil, &cmd.ConfigurationError{
+			Err: fmt.Errorf("an API identifier is required. Provide --%s or --%s", apiIDFlagName, apiNameFlagName),
+		}
+	}
+	apiAPI, versionAPI := sdk.GetAPIAPI(), sdk.GetAPIVersionAPI()
+	if apiAPI == nil || versionAPI == nil {
+		return nil, &cmd.ExecutionError{
+			Msg: "API versions client is not available",
+			Err: fmt.Errorf("api versions client not configured"),
+		}
+	}
+	if apiID == "" {
+		apiID, err = resolveAPIIDByName(apiName, apiAPI, helper, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := apiAPI.FetchAPI(helper.GetContext(), apiID)
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return nil, cmd.PrepareExecutionError("Failed to get API", err, helper.GetCmd(), attrs...)
+	}
+	if res.GetAPIResponseSchema() == nil {
+		return nil, &cmd.ExecutionError{
+			Msg: "API response was empty",
+			Err: fmt.Errorf("no API returned for id %s", apiID),
+		}
+	}
+
+	versions, err := fetchVersionSummaries(helper, versionAPI, apiID, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiVersionTarget{
+		helper:     helper,
+		outType:    outType,
+		apiAPI:     apiAPI,
+		versionAPI: versionAPI,
+		api:        res.GetAPIResponseSchema(),
+		versions:   versions,
+	}, nil
+}
+
+// version returns the version given by ID or version string
+func (t *apiVersionTarget) version(identifier string) (*kkComps.ListAPIVersionResponseAPIVersionSummary, error) {
+	identifier = strings.TrimSpace(identifier)
+	for i := range t.versions {
+		if t.versions[i].GetID() == identifier {
+			return &t.versions[i], nil
+		}
+	}
+	if match := findVersionByString(t.versions, identifier); match != nil {
+		return match, nil
+	}
+	return nil, &cmd.ConfigurationError{
+		Err: fmt.Errorf("version %q not found in API %q", identifier, t.api.GetName()),
+	}
+}
+
+// isCurrent reports whether version is the current version of the API
+func (t *apiVersionTarget) isCurrent(version *kkComps.ListAPIVersionResponseAPIVersionSummary) bool {
+	if current := t.api.GetCurrentVersionSummary(); current != nil && current.GetID() != nil {
+		return *current.GetID() == version.GetID()
+	}
+	return t.api.GetVersion() != nil && *t.api.GetVersion() == version.GetVersion()
+}
+
+func (t *apiVersionTarget) isDeprecated(versionID string) bool {
+	return t.api.GetLabels()[labels.DeprecatedKey(versionID)] == labels.TrueValue
+}
+
+// updateLabels sets or, when value is nil, removes a label of the API
+func (t *apiVersionTarget) updateLabels(key string, value *string, action string) error {
+	_, err := t.apiAPI.UpdateAPI(t.helper.GetContext(), t.api.GetID(), kkComps.UpdateAPIRequest{
+		Labels: map[string]*string{key: value},
+	})
+	return t.executionError(action, err)
+}
+
+func (t *apiVersionTarget) executionError(action string, err error) error {
+	if err == nil {
+		return nil
+	}
+	attrs := cmd.TryConvertErrorToAttrs(err)
+	details := konnectCommon.ParseAPIErrorDetails(err)
+	attrs = konnectCommon.AppendAPIErrorAttrs(attrs, details)
+	msg := konnectCommon.BuildDetailedMessage("Failed to "+action, attrs, err)
+	return cmd.PrepareExecutionError(msg, err, t.helper.GetCmd(), attrs...)
+}
+
+func (t *apiVersionTarget) printResult(message string, result any) error {
+	if t.outType == cmdCommon.TEXT {
+		fmt.Fprintln(t.helper.GetStreams().Out, message)
+		return nil
+	}
+	printer, err := cli.Format(t.outType.String(), t.helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+	printer.Print(result)
+	return nil
+}
+
+func specRevisionStore() (*specrev.Store, error) {
+	dir, err := specrev.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return specrev.NewStore(dir), nil
+}
+
+func runVersionList(c *cobra.Command, args []string) error {
+	target, err := resolveAPIVersionTarget(c, args)
+	if err != nil {
+		return err
+	}
+	store, err := specRevisionStore()
+	if err != nil {
+		return err
+	}
+
+	portals := make([]string, 0, len(target.api.Portals))
+	for _, portal := range target.api.Portals {
+		portals = append(portals, portal.GetName())
+	}
+
+	statuses := make([]apiVersionStatus, 0, len(target.versions))
+	records := make([]apiVersionStatusRecord, 0, len(target.versions))
+	rows := make([]table.Row, 0, len(target.versions))
+	for i := range target.versions {
+		version := &target.versions[i]
+		revisions, err := store.List(target.api.GetID(), version.GetID())
+		if err != nil {
+			return err
+		}
+		status := apiVersionStatus{
+			ID:          version.GetID(),
+			Version:     version.GetVersion(),
+			Current:     target.isCurrent(version),
+			Deprecated:  target.isDeprecated(version.GetID()),
+			PublishedTo: []string{},
+			SpecHash:    target.api.GetLabels()[labels.SpecHashKey(version.GetID())],
+			Revisions:   revisions,
+		}
+		if status.Revisions == nil {
+			status.Revisions = []specrev.Revision{}
+		}
+		if status.Current {
+			status.PublishedTo = portals
+		}
+		statuses = append(statuses, status)
+
+		record := apiVersionStatusRecord{
+			ID:          status.ID,
+			Version:     status.Version,
+			Current:     strconv.FormatBool(status.Current),
+			Deprecated:  strconv.FormatBool(status.Deprecated),
+			PublishedTo: valueNA,
+			Revisions:   strconv.Itoa(len(revisions)),
+		}
+		if len(status.PublishedTo) > 0 {
+			record.PublishedTo = strings.Join(status.PublishedTo, ", ")
+		}
+		records = append(records, record)
+		rows = append(rows, table.Row{
+			record.Version, util.AbbreviateUUID(record.ID), record.Current, record.Deprecated,
+			record.PublishedTo, record.Revisions,
+		})
+	}
+
+	printer, err := cli.Format(target.outType.String(), target.helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	return tableview.RenderForFormat(target.helper,
+		false,
+		target.outType,
+		printer,
+		target.helper.GetStreams(),
+		records,
+		statuses,
+		"",
+		tableview.WithTitle("Versions"),
+		tableview.WithCustomTable([]string{"VERSION", "ID", "CURRENT", "DEPRECATED", "PUBLISHED TO", "REVISIONS"}, rows),
+		tableview.WithRootLabel(target.helper.GetCmd().Name()),
+	)
+}
+
+func runVersionPromote(c *cobra.Command, args []string) error {
+	target, err := resolveAPIVersionTarget(c, args)
+	if err != nil {
+		return err
+	}
+	version, err := target.version(args[0])
+	if err != nil {
+		return err
+	}
+	if target.isDeprecated(version.GetID()) {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("version %q is deprecated; undo it with deprecate --%s before promoting it",
+				version.GetVersion(), undoFlagName),
+		}
+	}
+
+	versionString := version.GetVersion()
+	res, err := target.apiAPI.UpdateAPI(target.helper.GetContext(), target.api.GetID(),
+		kkComps.UpdateAPIRequest{Version: &versionString})
+	if err := target.executionError("promote API version", err); err != nil {
+		return err
+	}
+	return target.printResult(
+		fmt.Sprintf("API %q now shows version %q", target.api.GetName(), versionString),
+		res.GetAPIResponseSchema())
+}
+
+func runVersionDeprecate(c *cobra.Command, args []string) error {
+	target, err := resolveAPIVersionTarget(c, args)
+	if err != nil {
+		return err
+	}
+	version, err := target.version(args[0])
+	if err != nil {
+		return err
+	}
+	undo, _ := c.Flags().GetBool(undoFlagName)
+
+	key := labels.DeprecatedKey(version.GetID())
+	status := apiVersionStatus{ID: version.GetID(), Version: version.GetVersion(), Deprecated: !undo}
+	if undo {
+		if err := target.updateLabels(key, nil, "undo API version deprecation"); err != nil {
+			return err
+		}
+		return target.printResult(fmt.Sprintf("API version %q is no longer deprecated", version.GetVersion()), status)
+	}
+
+	if target.isCurrent(version) {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("version %q is the current version of API %q; promote another version first",
+				version.GetVersion(), target.api.GetName()),
+		}
+	}
+	value := labels.TrueValue
+	if err := target.updateLabels(key, &value, "deprecate API version"); err != nil {
+		return err
+	}
+	return target.printResult(fmt.Sprintf("API version %q deprecated", version.GetVersion()), status)
+}
+
+func runVersionRollback(c *cobra.Command, args []string) error {
+	target, err := resolveAPIVersionTarget(c, args)
+	if err != nil {
+		return err
+	}
+	version, err := target.version(args[0])
+	if err != nil {
+		return err
+	}
+	store, err := specRevisionStore()
+	if err != nil {
+		return err
+	}
+
+	ctx := target.helper.GetContext()
+	apiID := target.api.GetID()
+	res, err := target.versionAPI.FetchAPIVersion(ctx, apiID, version.GetID())
+	if err := target.executionError("get API version", err); err != nil {
+		return err
+	}
+	live := ""
+	if detail := res.GetAPIVersionResponse(); detail != nil && detail.Spec != nil && detail.Spec.Content != nil {
+		live = *detail.Spec.Content
+	}
+	liveHash := labels.SpecHash(live)
+
+	revisions, err := store.List(apiID, version.GetID())
+	if err != nil {
+		return err
+	}
+	var revision specrev.Revision
+	if to, _ := c.Flags().GetString(rollbackToFlagName); to != "" {
+		revision, err = specrev.Find(revisions, to)
+		if err != nil {
+			return &cmd.ConfigurationError{Err: err}
+		}
+	} else {
+		var ok bool
+		if revision, ok = specrev.Previous(revisions, liveHash); !ok {
+			return &cmd.ConfigurationError{
+				Err: fmt.Errorf("no earlier spec of version %q is kept locally; revisions are recorded by "+
+					"apply, sync and rollback", version.GetVersion()),
+			}
+		}
+	}
+	if revision.Hash == liveHash {
+		return target.printResult(
+			fmt.Sprintf("API version %q already has spec revision %s", version.GetVersion(), revision.Hash), revision)
+	}
+	content, err := store.Content(revision.Hash)
+	if err != nil {
+		return &cmd.ConfigurationError{Err: err}
+	}
+
+	// Keep the live spec, so the rollback can be undone
+	now := time.Now().UTC()
+	if live != "" {
+		if _, err := store.Record(apiID, version.GetID(), version.GetVersion(), live, now); err != nil {
+			return err
+		}
+	}
+
+	_, err = target.versionAPI.UpdateAPIVersion(ctx, kkOps.UpdateAPIVersionRequest{
+		APIID:      apiID,
+		VersionID:  version.GetID(),
+		APIVersion: kkComps.APIVersion{Spec: &kkComps.APIVersionSpec{Content: &content}},
+	})
+	if err := target.executionError("roll back API version", err); err != nil {
+		return err
+	}
+	hash := revision.Hash
+	if err := target.updateLabels(labels.SpecHashKey(version.GetID()), &hash, "record API version spec hash"); err != nil {
+		return err
+	}
+	if revision, err = store.Record(apiID, version.GetID(), version.GetVersion(), content, now); err != nil {
+		return err
+	}
+
+	return target.printResult(
+		fmt.Sprintf("API version %q rolled back to spec revision %s", version.GetVersion(), revision.Hash), revision)
+}
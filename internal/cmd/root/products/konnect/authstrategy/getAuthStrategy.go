@@ -43,6 +43,10 @@ var (
 	%[1]s get auth-strategies --type key_auth
 	# Get all the auth strategies using command aliases
 	%[1]s get as
+	# List auth strategies along with strategies recently deleted by kongctl
+	%[1]s get auth-strategies --include-deleted
+	# Count auth strategies by strategy type
+	%[1]s get auth-strategies --count-by strategy_type
 	`, meta.CLIName)))
 )
 
@@ -231,93 +235,53 @@ type getAuthStrategyCmd struct {
 func runListByName(name string, strategyType string, kkClient helpers.AppAuthStrategiesAPI, helper cmd.Helper,
 	cfg config.Hook,
 ) (*kkComps.AppAuthStrategy, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.AppAuthStrategy
-
-	for {
-		req := kkOps.ListAppAuthStrategiesRequest{
-			PageSize:   kk.Int64(requestPageSize),
-			PageNumber: kk.Int64(pageNumber),
-		}
-
-		// Apply type filter if specified
-		if strategyType != "" {
-			req.Filter = &kkOps.QueryParamFilter{
-				StrategyType: &kkComps.StringFieldFilter{
-					Eq: kk.String(strategyType),
-				},
-			}
-		}
-
-		res, err := kkClient.ListAppAuthStrategies(helper.GetContext(), req)
-		if err != nil {
-			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list auth strategies", err, helper.GetCmd(), attrs...)
-		}
-
-		// Filter by name since SDK doesn't support name filtering directly
-		for _, strategy := range res.GetListAppAuthStrategiesResponse().Data {
-			strategyName := getStrategyName(strategy)
-			if strategyName == name {
-				return &strategy, nil
-			}
-		}
-
-		allData = append(allData, res.GetListAppAuthStrategiesResponse().Data...)
-		totalItems := res.GetListAppAuthStrategiesResponse().Meta.Page.Total
+	allData, err := runList(strategyType, kkClient, helper, cfg, 0, "")
+	if err != nil {
+		return nil, err
+	}
 
-		if len(allData) >= int(totalItems) {
-			break
+	// Filter by name since SDK doesn't support name filtering directly
+	var matches []kkComps.AppAuthStrategy
+	for _, strategy := range allData {
+		if getStrategyName(strategy) == name {
+			matches = append(matches, strategy)
 		}
-
-		pageNumber++
 	}
-
-	return nil, fmt.Errorf("auth strategy with name %s not found", name)
+	return common.SelectByName(helper, "auth strategy", name, matches, getStrategyID)
 }
 
+// runList lists the auth strategies of strategyType, only those named nameFilter when
+// it is not empty
 func runList(strategyType string, kkClient helpers.AppAuthStrategiesAPI, helper cmd.Helper,
-	cfg config.Hook,
+	cfg config.Hook, limit int, nameFilter string,
 ) ([]kkComps.AppAuthStrategy, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.AppAuthStrategy
-
-	for {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.AppAuthStrategy, float64, error) {
 		req := kkOps.ListAppAuthStrategiesRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
 
-		// Apply type filter if specified
+		// Apply type and name filters if specified
+		if strategyType != "" || nameFilter != "" {
+			req.Filter = &kkOps.QueryParamFilter{}
+		}
 		if strategyType != "" {
-			req.Filter = &kkOps.QueryParamFilter{
-				StrategyType: &kkComps.StringFieldFilter{
-					Eq: kk.String(strategyType),
-				},
+			req.Filter.StrategyType = &kkComps.StringFieldFilter{
+				Eq: kk.String(strategyType),
 			}
 		}
+		if nameFilter != "" {
+			req.Filter.Name = &kkComps.StringFieldFilter{Eq: kk.String(nameFilter)}
+		}
 
 		res, err := kkClient.ListAppAuthStrategies(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list auth strategies", err, helper.GetCmd(), attrs...)
-		}
-
-		allData = append(allData, res.GetListAppAuthStrategiesResponse().Data...)
-		totalItems := res.GetListAppAuthStrategiesResponse().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
+			return nil, 0, cmd.PrepareExecutionError("Failed to list auth strategies", err, helper.GetCmd(), attrs...)
 		}
-
-		pageNumber++
+		return res.GetListAppAuthStrategiesResponse().Data, res.GetListAppAuthStrategiesResponse().Meta.Page.Total, nil
 	}
-
-	return allData, nil
+	return common.ListPages(cfg, limit, fetch)
 }
 
 func runGet(id string, kkClient helpers.AppAuthStrategiesAPI, helper cmd.Helper,
@@ -401,6 +365,16 @@ func runGet(id string, kkClient helpers.AppAuthStrategiesAPI, helper cmd.Helper,
 	return &strategy, nil
 }
 
+// Helper function to get strategy ID from union type
+func getStrategyID(strategy kkComps.AppAuthStrategy) string {
+	if strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse != nil {
+		return strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse.ID
+	} else if strategy.AppAuthStrategyOpenIDConnectResponseAppAuthStrategyOpenIDConnectResponse != nil {
+		return strategy.AppAuthStrategyOpenIDConnectResponseAppAuthStrategyOpenIDConnectResponse.ID
+	}
+	return ""
+}
+
 // Helper function to get strategy name from union type
 func getStrategyName(strategy kkComps.AppAuthStrategy) string {
 	if strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse != nil {
@@ -446,6 +420,36 @@ func (c *getAuthStrategyCmd) runE(cobraCmd *cobra.Command, args []string) error
 		return e
 	}
 
+	includeDeleted, e := common.IncludeDeleted(helper)
+	if e != nil {
+		return e
+	}
+
+	count, countBy, e := common.CountOptions(helper)
+	if e != nil {
+		return e
+	}
+
+	namespace, e := common.NamespaceFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	selector, e := common.LabelSelectorFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	fieldSelector, e := common.FieldSelectorFilter(helper)
+	if e != nil {
+		return e
+	}
+
+	limit, e := common.ListLimit(helper)
+	if e != nil {
+		return e
+	}
+
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
@@ -515,14 +519,56 @@ func (c *getAuthStrategyCmd) runE(cobraCmd *cobra.Command, args []string) error
 		)
 	}
 
-	strategies, err := runList(c.strategyType, sdk.GetAppAuthStrategiesAPI(), helper, cfg)
+	// Filtered lists are limited once filtered
+	fetchLimit := limit
+	if namespace != "" || selector != nil || fieldSelector != nil {
+		fetchLimit = 0
+	}
+	// Konnect filters by name, the other fields are matched once listed
+	nameFilter, _ := fieldSelector.NameEquals()
+	strategies, err := runList(c.strategyType, sdk.GetAppAuthStrategiesAPI(), helper, cfg, fetchLimit, nameFilter)
 	if err != nil {
 		return err
 	}
-	displayRecords := make([]textDisplayRecord, len(strategies))
-	for i := range strategies {
-		displayRecords[i] = authStrategyToDisplayRecord(strategies[i])
+	if c.strategyType == "" {
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, len(strategies))
 	}
+	if strategies, err = common.FilterByNamespace(strategies, namespace); err != nil {
+		return err
+	}
+	if strategies, err = common.FilterByLabelSelector(strategies, selector); err != nil {
+		return err
+	}
+	if strategies, err = common.FilterByFieldSelector(strategies, fieldSelector); err != nil {
+		return err
+	}
+	strategies = common.LimitResults(strategies, limit)
+
+	if count {
+		summary, err := common.CountResources(strategies, countBy)
+		if err != nil {
+			return err
+		}
+		return common.RenderCounts(helper, outType, printer, summary)
+	}
+	if includeDeleted {
+		ids := make([]string, 0, len(strategies))
+		displayRecords := make([]textDisplayRecord, 0, len(strategies))
+		for i := range strategies {
+			ids = append(ids, extractAuthStrategyVariant(strategies[i]).id)
+			displayRecords = append(displayRecords, authStrategyToDisplayRecord(strategies[i]))
+		}
+		deleted, err := common.ListDeletedResources(helper, "application_auth_strategy", ids)
+		if err != nil {
+			return err
+		}
+		display, err := common.WithNamespaceColumn(helper, displayRecords, strategies)
+		if err != nil {
+			return err
+		}
+		return common.RenderListWithDeleted(helper, outType, printer, display, strategies, deleted)
+	}
+
 	return renderAuthStrategyList(helper, helper.GetCmd().Name(), outType, printer, strategies)
 }
 
@@ -537,6 +583,11 @@ func renderAuthStrategyList(
 		displayRecords = append(displayRecords, authStrategyToDisplayRecord(strategies[i]))
 	}
 
+	display, err := common.WithNamespaceColumn(helper, displayRecords, strategies)
+	if err != nil {
+		return err
+	}
+
 	childView := buildAuthStrategyChildView(strategies)
 
 	options := []tableview.Option{
@@ -558,7 +609,7 @@ func renderAuthStrategyList(
 		outType,
 		printer,
 		helper.GetStreams(),
-		displayRecords,
+		display,
 		strategies,
 		"",
 		options...,
@@ -620,6 +671,12 @@ func newGetAuthStrategyCmd(verb verbs.VerbValue,
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddIncludeDeletedFlag(rv.Command)
+	common.AddCountFlags(rv.Command)
+	common.AddNamespaceFilterFlag(rv.Command)
+	common.AddLabelSelectorFlag(rv.Command)
+	common.AddFieldSelectorFlag(rv.Command)
+	common.AddLimitFlag(rv.Command)
 
 	return &rv
 }
@@ -24,7 +24,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, err
 	}
 
-	strategies, err := runList("", sdk.GetAppAuthStrategiesAPI(), helper, cfg)
+	strategies, err := runList("", sdk.GetAppAuthStrategiesAPI(), helper, cfg, 0, "")
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
@@ -13,6 +13,7 @@ import (
 	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/konnect/helpers"
 	"github.com/kong/kongctl/internal/util"
 	"github.com/kong/kongctl/internal/util/i18n"
@@ -55,6 +56,7 @@ func newGetServiceCmd(
 	}
 
 	addParentFlags(verb, cmd)
+	common.AddLimitFlag(cmd)
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		if err := parentPreRun(cmd, args); err != nil {
 			return err
@@ -91,6 +93,11 @@ func (c *getServiceCmd) run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	limit, err := common.ListLimit(helper)
+	if err != nil {
+		return err
+	}
+
 	cfg, err := helper.GetConfig()
 	if err != nil {
 		return err
@@ -125,10 +132,11 @@ func (c *getServiceCmd) run(_ *cobra.Command, args []string) error {
 	streams := helper.GetStreams()
 
 	if len(args) == 0 {
-		services, listErr := listAllCatalogServices(ctx, api, int64(pageSize))
+		services, listErr := listAllCatalogServices(ctx, api, cfg, limit)
 		if listErr != nil {
 			return cmd.PrepareExecutionError("Failed to list catalog services", listErr, helper.GetCmd())
 		}
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, len(services))
 		return renderCatalogServices(outFormat, services, streams.Out)
 	}
 
@@ -148,12 +156,10 @@ func (c *getServiceCmd) run(_ *cobra.Command, args []string) error {
 func listAllCatalogServices(
 	ctx context.Context,
 	api helpers.CatalogServicesAPI,
-	pageSize int64,
+	cfg config.Hook,
+	limit int,
 ) ([]kkComps.CatalogService, error) {
-	result := make([]kkComps.CatalogService, 0)
-
-	pageNumber := int64(1)
-	for {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.CatalogService, float64, error) {
 		req := kkOps.ListCatalogServicesRequest{
 			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
@@ -161,25 +167,16 @@ func listAllCatalogServices(
 
 		resp, err := api.ListCatalogServices(ctx, req)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		if resp.ListCatalogServicesResponse == nil {
-			break
+			return nil, 0, nil
 		}
 
-		data := resp.ListCatalogServicesResponse.GetData()
-		result = append(result, data...)
-
-		total := resp.ListCatalogServicesResponse.Meta.Page.Total
-		if float64(pageNumber*pageSize) >= total || len(data) == 0 {
-			break
-		}
-
-		pageNumber++
+		return resp.ListCatalogServicesResponse.GetData(), resp.ListCatalogServicesResponse.Meta.Page.Total, nil
 	}
-
-	return result, nil
+	return common.ListPages(cfg, limit, fetch)
 }
 
 func fetchCatalogService(
@@ -12,7 +12,6 @@ import (
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
 	"github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/output/tableview"
-	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/navigator"
 	"github.com/kong/kongctl/internal/util"
 )
@@ -40,8 +39,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, fmt.Errorf("catalog services API not configured")
 	}
 
-	pageSize := cfg.GetInt(common.RequestPageSizeConfigPath)
-	services, err := listAllCatalogServices(context.Background(), api, int64(pageSize))
+	services, err := listAllCatalogServices(context.Background(), api, cfg, 0)
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
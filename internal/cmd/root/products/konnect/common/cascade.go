@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const CascadeFlagName = "cascade"
+
+// AddCascadeFlag registers the --cascade flag on a delete command
+func AddCascadeFlag(command *cobra.Command, parent string) {
+	command.Flags().Bool(CascadeFlagName, false,
+		fmt.Sprintf("Delete the resources depending on the %s before deleting it (not configurable)", parent))
+}
+
+// CascadeEnabled reports whether --cascade was passed
+func CascadeEnabled(command *cobra.Command) bool {
+	cascade, _ := command.Flags().GetBool(CascadeFlagName)
+	return cascade
+}
+
+// Dependent is a child resource that has to be deleted before its parent
+type Dependent struct {
+	Kind   string `json:"kind"   yaml:"kind"`
+	ID     string `json:"id"     yaml:"id"`
+	Name   string `json:"name"   yaml:"name"`
+	delete func(ctx context.Context) error
+}
+
+// NewDependent returns a dependent deleted by calling del
+func NewDependent(kind, id, name string, del func(ctx context.Context) error) Dependent {
+	return Dependent{Kind: kind, ID: id, Name: name, delete: del}
+}
+
+func (d Dependent) String() string {
+	if d.Name == "" || d.Name == d.ID {
+		return fmt.Sprintf("%s %s", d.Kind, d.ID)
+	}
+	return fmt.Sprintf("%s %q (%s)", d.Kind, d.Name, d.ID)
+}
+
+// DependencyReport describes the dependents of parent grouped by kind, in the
+// order they would be deleted
+func DependencyReport(parent string, dependents []Dependent) []string {
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	var kinds []string
+	byKind := map[string][]Dependent{}
+	for _, dependent := range dependents {
+		if _, ok := byKind[dependent.Kind]; !ok {
+			kinds = append(kinds, dependent.Kind)
+		}
+		byKind[dependent.Kind] = append(byKind[dependent.Kind], dependent)
+	}
+
+	lines := []string{fmt.Sprintf("%s has %d dependent resource(s):", parent, len(dependents))}
+	for _, kind := range kinds {
+		lines = append(lines, fmt.Sprintf("  %s (%d):", kind, len(byKind[kind])))
+		for _, dependent := range byKind[kind] {
+			if dependent.Name == "" || dependent.Name == dependent.ID {
+				lines = append(lines, "    - "+dependent.ID)
+			} else {
+				lines = append(lines, fmt.Sprintf("    - %s (%s)", dependent.Name, dependent.ID))
+			}
+		}
+	}
+	return lines
+}
+
+// DependencyError is returned when a resource with dependents is deleted without --cascade
+func DependencyError(parent string, dependents []Dependent) error {
+	report := DependencyReport(parent, dependents)
+	report = append(report, fmt.Sprintf("Use --%s to delete them along with the %s.", CascadeFlagName, parent))
+	return fmt.Errorf("%s", strings.Join(report, "\n"))
+}
+
+// DeleteDependents deletes the dependents in order, stopping at the first failure.
+// It returns the dependents that were deleted.
+func DeleteDependents(ctx context.Context, dependents []Dependent) ([]Dependent, error) {
+	deleted := make([]Dependent, 0, len(dependents))
+	for _, dependent := range dependents {
+		if err := dependent.delete(ctx); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s (%d of %d dependents deleted): %w",
+				dependent, len(deleted), len(dependents), err)
+		}
+		deleted = append(deleted, dependent)
+	}
+	return deleted, nil
+}
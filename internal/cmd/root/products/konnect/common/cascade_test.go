@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyReport(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	dependents := []Dependent{
+		NewDependent("publication", "p1", "", noop),
+		NewDependent("document", "d2", "getting-started", noop),
+		NewDependent("document", "d1", "guides", noop),
+		NewDependent("version", "v1", "1.0.0", noop),
+	}
+
+	require.Nil(t, DependencyReport(`API "orders"`, nil))
+	require.Equal(t, []string{
+		`API "orders" has 4 dependent resource(s):`,
+		"  publication (1):",
+		"    - p1",
+		"  document (2):",
+		"    - getting-started (d2)",
+		"    - guides (d1)",
+		"  version (1):",
+		"    - 1.0.0 (v1)",
+	}, DependencyReport(`API "orders"`, dependents))
+
+	err := DependencyError(`API "orders"`, dependents)
+	require.True(t, strings.HasSuffix(err.Error(), `Use --cascade to delete them along with the API "orders".`))
+}
+
+func TestDeleteDependents(t *testing.T) {
+	var order []string
+	deleteFn := func(id string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, id)
+			return err
+		}
+	}
+
+	t.Run("deletes in order", func(t *testing.T) {
+		order = nil
+		deleted, err := DeleteDependents(context.Background(), []Dependent{
+			NewDependent("page", "child", "", deleteFn("child", nil)),
+			NewDependent("page", "parent", "", deleteFn("parent", nil)),
+		})
+		require.NoError(t, err)
+		require.Len(t, deleted, 2)
+		require.Equal(t, []string{"child", "parent"}, order)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		order = nil
+		deleted, err := DeleteDependents(context.Background(), []Dependent{
+			NewDependent("page", "child", "", deleteFn("child", nil)),
+			NewDependent("page", "parent", "", deleteFn("parent", errors.New("conflict"))),
+			NewDependent("page", "other", "", deleteFn("other", nil)),
+		})
+		require.ErrorContains(t, err, "failed to delete page parent (1 of 3 dependents deleted): conflict")
+		require.Len(t, deleted, 1)
+		require.Equal(t, []string{"child", "parent"}, order)
+	})
+}
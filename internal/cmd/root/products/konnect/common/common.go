@@ -3,12 +3,18 @@ package common
 import (
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	cmdcommon "github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/konnect/auth"
 	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 	"github.com/kong/kongctl/internal/meta"
 )
 
@@ -29,6 +35,9 @@ const (
 	RefreshPathDefault  = "/kauth/api/v1/refresh"
 	RefreshPathFlagName = "refresh-path"
 
+	LogoutPathDefault  = "/kauth/api/v1/logout"
+	LogoutPathFlagName = "logout-path"
+
 	MachineClientIDDefault  = "344f59db-f401-4ce7-9407-00a0823fbacf"
 	MachineClientIDFlagName = "machine-client-id"
 
@@ -38,6 +47,8 @@ const (
 
 	RequestPageSizeFlagName = "page-size"
 	DefaultRequestPageSize  = 10
+
+	MaxRetriesFlagName = "max-retries"
 )
 
 var (
@@ -51,9 +62,11 @@ var (
 	AuthMachineClientIDConfigPath = "konnect." + MachineClientIDFlagName
 	TokenURLPathConfigPath        = "konnect." + TokenPathFlagName
 	RefreshPathConfigPath         = "konnect." + RefreshPathFlagName
+	LogoutPathConfigPath          = "konnect." + LogoutPathFlagName
 
 	MachineClientIDConfigPath = "konnect." + MachineClientIDFlagName
 	RequestPageSizeConfigPath = "konnect." + RequestPageSizeFlagName
+	MaxRetriesConfigPath      = "konnect." + MaxRetriesFlagName
 
 	RegionConfigPath = "konnect." + RegionFlagName
 )
@@ -159,8 +172,20 @@ func KonnectSDKFactory(cfg config.Hook, logger *slog.Logger) (helpers.SDKAPI, er
 	if err != nil {
 		return nil, err
 	}
+	if logger != nil {
+		logger.Info("Using Konnect API base URL", "base_url", baseURL, "region", RegionOf(cfg, baseURL))
+	}
 
-	sdk, err := auth.GetAuthenticatedClient(baseURL, token, logger)
+	maxRetries := max(cfg.GetIntOrElse(MaxRetriesConfigPath, httpclient.DefaultMaxRetries), 0)
+	timeout, err := ResolveTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := ResolveCacheOptions(cfg, baseURL, token)
+	if err != nil {
+		return nil, err
+	}
+	sdk, err := auth.GetAuthenticatedClient(baseURL, token, maxRetries, timeout, cache, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +195,83 @@ func KonnectSDKFactory(cfg config.Hook, logger *slog.Logger) (helpers.SDKAPI, er
 	}, nil
 }
 
+// SimulatorSDKFactory returns a factory of SDKs whose requests the simulator answers.
+// They need neither an access token nor network access.
+func SimulatorSDKFactory(simulator *httpclient.Simulator) helpers.SDKAPIFactory {
+	return func(_ config.Hook, logger *slog.Logger) (helpers.SDKAPI, error) {
+		if logger != nil {
+			logger.Info("Using the Konnect simulator, no requests reach Konnect")
+		}
+		sdk := kk.New(
+			kk.WithServerURL(httpclient.SimulatorURL),
+			kk.WithSecurity(kkComps.Security{PersonalAccessToken: kk.String("simulated")}),
+			kk.WithClient(httpclient.NewRecordingClient(simulator)),
+		)
+		return &helpers.KonnectSDK{SDK: sdk}, nil
+	}
+}
+
+// ReplaySDKFactory returns a factory of SDKs whose requests the replayer answers from
+// a recording made with --record. They need neither an access token nor network access.
+func ReplaySDKFactory(replayer *httpclient.Replayer) helpers.SDKAPIFactory {
+	return func(_ config.Hook, _ *slog.Logger) (helpers.SDKAPI, error) {
+		sdk := kk.New(
+			kk.WithServerURL(httpclient.SimulatorURL),
+			kk.WithSecurity(kkComps.Security{PersonalAccessToken: kk.String("replayed")}),
+			kk.WithClient(replayer),
+		)
+		return &helpers.KonnectSDK{SDK: sdk}, nil
+	}
+}
+
+// ResolveTimeout returns the time limit of each Konnect request, httpclient.DefaultTimeout
+// unless set with --timeout or in the config file
+func ResolveTimeout(cfg config.Hook) (time.Duration, error) {
+	value := strings.TrimSpace(cfg.GetString(cmdcommon.TimeoutConfigPath))
+	if value == "" {
+		return httpclient.DefaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", cmdcommon.TimeoutConfigPath, value, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: timeout must be positive", cmdcommon.TimeoutConfigPath, value)
+	}
+	return timeout, nil
+}
+
+// ResolveCacheOptions returns how Konnect reads of baseURL with token are cached, set
+// with --cache-ttl and --refresh or in the config file. Responses are kept under the
+// config directory.
+func ResolveCacheOptions(cfg config.Hook, baseURL, token string) (httpclient.CacheOptions, error) {
+	value := strings.TrimSpace(cfg.GetString(cmdcommon.CacheTTLConfigPath))
+	if value == "" {
+		return httpclient.CacheOptions{}, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return httpclient.CacheOptions{}, fmt.Errorf("invalid %s %q: %w", cmdcommon.CacheTTLConfigPath, value, err)
+	}
+	if ttl < 0 {
+		return httpclient.CacheOptions{}, fmt.Errorf("invalid %s %q: ttl must not be negative",
+			cmdcommon.CacheTTLConfigPath, value)
+	}
+	if ttl == 0 {
+		return httpclient.CacheOptions{}, nil
+	}
+	configDir, err := config.GetDefaultConfigPath()
+	if err != nil {
+		return httpclient.CacheOptions{}, fmt.Errorf("resolve cache directory: %w", err)
+	}
+	return httpclient.CacheOptions{
+		TTL:     ttl,
+		Dir:     filepath.Join(configDir, "cache", "konnect"),
+		Scope:   httpclient.CacheScope(baseURL, token),
+		Refresh: cfg.GetBool(cmdcommon.RefreshConfigPath),
+	}, nil
+}
+
 // GetSDKFactory returns the SDK factory to use, checking for test overrides
 func GetSDKFactory() helpers.SDKAPIFactory {
 	if helpers.DefaultSDKFactory != nil {
@@ -3,7 +3,10 @@ package common
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	cmdcommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 	configtest "github.com/kong/kongctl/test/config"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
@@ -110,3 +113,47 @@ func TestResolveBaseURL(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestResolveTimeout(t *testing.T) {
+	cfg, store := newTestConfig(nil)
+	timeout, err := ResolveTimeout(cfg)
+	require.NoError(t, err)
+	require.Equal(t, httpclient.DefaultTimeout, timeout)
+
+	store[cmdcommon.TimeoutConfigPath] = "2m30s"
+	timeout, err = ResolveTimeout(cfg)
+	require.NoError(t, err)
+	require.Equal(t, 150*time.Second, timeout)
+
+	for _, invalid := range []string{"soon", "0s", "-1s"} {
+		store[cmdcommon.TimeoutConfigPath] = invalid
+		_, err = ResolveTimeout(cfg)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestResolveCacheOptions(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	cfg, store := newTestConfig(nil)
+	cache, err := ResolveCacheOptions(cfg, BaseURLDefault, "token")
+	require.NoError(t, err)
+	require.False(t, cache.Enabled())
+
+	store[cmdcommon.CacheTTLConfigPath] = "10m"
+	cache, err = ResolveCacheOptions(cfg, BaseURLDefault, "token")
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, cache.TTL)
+	require.Equal(t, httpclient.CacheScope(BaseURLDefault, "token"), cache.Scope)
+	require.NotEmpty(t, cache.Dir)
+
+	store[cmdcommon.CacheTTLConfigPath] = "0s"
+	cache, err = ResolveCacheOptions(cfg, BaseURLDefault, "token")
+	require.NoError(t, err)
+	require.False(t, cache.Enabled())
+
+	for _, invalid := range []string{"soon", "-1m"} {
+		store[cmdcommon.CacheTTLConfigPath] = invalid
+		_, err = ResolveCacheOptions(cfg, BaseURLDefault, "token")
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionCacheTTL is how long resource names listed for shell completion are
+	// reused, so pressing tab repeatedly does not query Konnect every time
+	completionCacheTTL = 30 * time.Second
+	// completionTimeout bounds the Konnect requests of a completion, an unresponsive
+	// Konnect must not hang the shell
+	completionTimeout = 5 * time.Second
+)
+
+// completionCacheDir is the directory the completion cache is kept in
+var completionCacheDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, meta.CLIName, "completion"), nil
+}
+
+// ListNamesFunc lists the names of the resources of a type to complete
+type ListNamesFunc func(helper cmd.Helper) ([]string, error)
+
+// CompleteResourceNames returns a completion function offering the names of the
+// resources of resourceType in Konnect for the first argument of a command. The
+// names are cached per profile and Konnect URL for a short time. Any failure, such
+// as missing credentials, completes nothing instead of surfacing in the shell.
+func CompleteResourceNames(resourceType string, list ListNamesFunc) cobra.CompletionFunc {
+	return func(c *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names, err := resourceNames(c, args, resourceType, list)
+		if err != nil {
+			cobra.CompDebugln("failed to list "+resourceType+" names: "+err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := make([]cobra.Completion, 0, len(names))
+		for _, name := range names {
+			if strings.HasPrefix(name, toComplete) {
+				completions = append(completions, name)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func resourceNames(c *cobra.Command, args []string, resourceType string, list ListNamesFunc) ([]string, error) {
+	// Completion skips the pre-run of the command, which prepares the Konnect
+	// context and binds the Konnect flags
+	if c.PreRunE != nil {
+		if err := c.PreRunE(c, args); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), completionTimeout)
+	defer cancel()
+	c.SetContext(ctx)
+
+	helper := cmd.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := ResolveBaseURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cachePath := completionCachePath(cfg.GetProfile(), baseURL, resourceType)
+	if names, ok := readCompletionCache(cachePath); ok {
+		return names, nil
+	}
+
+	names, err := list(helper)
+	if err != nil {
+		return nil, err
+	}
+	writeCompletionCache(cachePath, names)
+	return names, nil
+}
+
+type completionCache struct {
+	ListedAt time.Time `json:"listed_at"`
+	Names    []string  `json:"names"`
+}
+
+// completionCachePath returns the cache file of the names of a resource type, or ""
+// when there is no cache directory
+func completionCachePath(profile, baseURL, resourceType string) string {
+	dir, err := completionCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(profile + "\n" + baseURL))
+	return filepath.Join(dir, resourceType+"-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+func readCompletionCache(path string) ([]string, bool) {
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache completionCache
+	if err := json.Unmarshal(data, &cache); err != nil || time.Since(cache.ListedAt) > completionCacheTTL {
+		return nil, false
+	}
+	return cache.Names, true
+}
+
+// writeCompletionCache caches listed names. The cache only saves requests, so a
+// failed write is ignored.
+func writeCompletionCache(path string, names []string) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(completionCache{ListedAt: time.Now(), Names: names})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/config"
+	testConfig "github.com/kong/kongctl/test/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteResourceNames(t *testing.T) {
+	cacheDir := t.TempDir()
+	orig := completionCacheDir
+	completionCacheDir = func() (string, error) { return cacheDir, nil }
+	t.Cleanup(func() { completionCacheDir = orig })
+
+	newCommand := func(profile string) *cobra.Command {
+		hook := &testConfig.MockConfigHook{
+			GetStringMock:  func(string) string { return "https://eu.api.konghq.com" },
+			GetProfileMock: func() string { return profile },
+		}
+		command := &cobra.Command{Use: "portal"}
+		command.SetContext(context.WithValue(context.Background(), config.ConfigKey, config.Hook(hook)))
+		return command
+	}
+
+	listed := 0
+	complete := CompleteResourceNames("portal", func(cmd.Helper) ([]string, error) {
+		listed++
+		return []string{"dev-portal", "partners", "public"}, nil
+	})
+
+	completions, directive := complete(newCommand("default"), nil, "p")
+	require.Equal(t, []cobra.Completion{"partners", "public"}, completions)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = complete(newCommand("default"), nil, "")
+	require.Equal(t, []cobra.Completion{"dev-portal", "partners", "public"}, completions)
+	require.Equal(t, 1, listed, "names are cached")
+
+	complete(newCommand("prod"), nil, "")
+	require.Equal(t, 2, listed, "the cache is kept per profile")
+
+	completions, _ = complete(newCommand("default"), []string{"dev-portal"}, "")
+	require.Empty(t, completions, "only the first argument is a name")
+
+	failing := CompleteResourceNames("api", func(cmd.Helper) ([]string, error) {
+		return nil, errors.New("no access token")
+	})
+	completions, directive = failing(newCommand("default"), nil, "")
+	require.Empty(t, completions)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
@@ -0,0 +1,245 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CountFlagName   = "count"
+	CountByFlagName = "count-by"
+
+	countByLabelPrefix = "label:"
+	countByFieldPrefix = "field:"
+)
+
+// AddCountFlags registers the --count and --count-by flags on a get command.
+// Commands that are built more than once on the same base command keep the first flags.
+func AddCountFlags(command *cobra.Command) {
+	if command.Flags().Lookup(CountFlagName) != nil {
+		return
+	}
+	command.Flags().Bool(CountFlagName, false,
+		"Print the number of resources instead of listing them (list only)")
+	command.Flags().String(CountByFlagName, "",
+		`Count resources grouped by a label ("label:<key>") or a field ("<field>" or "field:<path>"). Implies --count`)
+}
+
+// CountOptions returns whether counts were requested and the optional group-by
+// expression. Counting only applies when listing, so combining it with a name or ID
+// argument or with --include-deleted is rejected.
+func CountOptions(helper cmd.Helper) (bool, string, error) {
+	flags := helper.GetCmd().Flags()
+
+	groupBy := ""
+	if flag := flags.Lookup(CountByFlagName); flag != nil {
+		groupBy = strings.TrimSpace(flag.Value.String())
+	}
+	count := groupBy != ""
+	if flag := flags.Lookup(CountFlagName); flag != nil && flag.Value.String() == "true" {
+		count = true
+	}
+	if !count {
+		return false, "", nil
+	}
+
+	if len(helper.GetArgs()) > 0 {
+		return false, "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s and --%s are only supported when listing resources", CountFlagName, CountByFlagName),
+		}
+	}
+	if flag := flags.Lookup(IncludeDeletedFlagName); flag != nil && flag.Value.String() == "true" {
+		return false, "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s cannot be combined with --%s", CountFlagName, IncludeDeletedFlagName),
+		}
+	}
+	if groupBy != "" {
+		if _, _, err := parseCountBy(groupBy); err != nil {
+			return false, "", &cmd.ConfigurationError{Err: err}
+		}
+	}
+	return true, groupBy, nil
+}
+
+// CountSummary is the aggregate rendered by --count
+type CountSummary struct {
+	Total   int          `json:"total"              yaml:"total"`
+	GroupBy string       `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Groups  []CountGroup `json:"groups,omitempty"   yaml:"groups,omitempty"`
+}
+
+// CountGroup is the number of resources sharing a value. Value is nil for resources
+// without the label or field.
+type CountGroup struct {
+	Value *string `json:"value" yaml:"value"`
+	Count int     `json:"count" yaml:"count"`
+}
+
+// CountResources counts items, a slice of SDK resources, optionally grouping them by
+// a label or field of their JSON representation. Groups are ordered by descending
+// count, then by value, with resources lacking a value last.
+func CountResources(items any, groupBy string) (CountSummary, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return CountSummary{}, fmt.Errorf("failed to encode resources: %w", err)
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return CountSummary{}, fmt.Errorf("failed to decode resources: %w", err)
+	}
+
+	summary := CountSummary{Total: len(objects)}
+	if groupBy == "" {
+		return summary, nil
+	}
+
+	isLabel, path, err := parseCountBy(groupBy)
+	if err != nil {
+		return CountSummary{}, err
+	}
+	summary.GroupBy = groupBy
+
+	counts := make(map[string]int)
+	missing := 0
+	for _, object := range objects {
+		var value any
+		var ok bool
+		if isLabel {
+			value, ok = lookupPath(object, []string{"labels", path[0]})
+		} else {
+			value, ok = lookupPath(object, path)
+		}
+		if !ok {
+			missing++
+			continue
+		}
+		counts[countValue(value)]++
+	}
+
+	summary.Groups = make([]CountGroup, 0, len(counts)+1)
+	for value, count := range counts {
+		summary.Groups = append(summary.Groups, CountGroup{Value: &value, Count: count})
+	}
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		if summary.Groups[i].Count != summary.Groups[j].Count {
+			return summary.Groups[i].Count > summary.Groups[j].Count
+		}
+		return *summary.Groups[i].Value < *summary.Groups[j].Value
+	})
+	if missing > 0 {
+		summary.Groups = append(summary.Groups, CountGroup{Count: missing})
+	}
+	return summary, nil
+}
+
+type countDisplayRecord struct {
+	Value string
+	Count int
+}
+
+type totalDisplayRecord struct {
+	Total int
+}
+
+// RenderCounts renders a count summary. Text output prints a table of groups, or the
+// total when no grouping was requested; JSON and YAML output print the summary.
+func RenderCounts(
+	helper cmd.Helper,
+	outType cmdCommon.OutputFormat,
+	printer cli.PrintFlusher,
+	summary CountSummary,
+) error {
+	var display any = totalDisplayRecord{Total: summary.Total}
+	if summary.GroupBy != "" {
+		records := make([]countDisplayRecord, 0, len(summary.Groups))
+		for _, group := range summary.Groups {
+			value := "(none)"
+			if group.Value != nil {
+				value = *group.Value
+			}
+			records = append(records, countDisplayRecord{Value: value, Count: group.Count})
+		}
+		display = records
+	}
+
+	if err := tableview.RenderForFormat(helper,
+		false,
+		outType,
+		printer,
+		helper.GetStreams(),
+		display,
+		summary,
+		"",
+	); err != nil {
+		return err
+	}
+
+	if outType == cmdCommon.TEXT && summary.GroupBy != "" {
+		printer.Flush()
+		fmt.Fprintf(helper.GetStreams().Out, "\nTotal: %d\n", summary.Total)
+	}
+	return nil
+}
+
+// parseCountBy splits a --count-by expression into a label key or a field path
+func parseCountBy(groupBy string) (bool, []string, error) {
+	if key, ok := strings.CutPrefix(groupBy, countByLabelPrefix); ok {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return false, nil, fmt.Errorf("--%s %q is missing a label key", CountByFlagName, groupBy)
+		}
+		return true, []string{key}, nil
+	}
+
+	field := strings.TrimPrefix(groupBy, countByFieldPrefix)
+	path := strings.Split(field, ".")
+	for _, part := range path {
+		if strings.TrimSpace(part) == "" {
+			return false, nil, fmt.Errorf("--%s %q is not a valid field path", CountByFlagName, groupBy)
+		}
+	}
+	return false, path, nil
+}
+
+// lookupPath returns the value at path in object. Null values count as missing.
+func lookupPath(object map[string]any, path []string) (any, bool) {
+	var current any = object
+	for _, part := range path {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// countValue formats a grouped value; lists and objects are grouped by their JSON
+func countValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
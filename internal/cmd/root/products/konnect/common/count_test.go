@@ -0,0 +1,104 @@
+package common
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func countTestAPIs() []kkComps.APIResponseSchema {
+	version := "v1"
+	return []kkComps.APIResponseSchema{
+		{ID: "1", Name: "orders", Version: &version, Labels: map[string]string{"team": "payments"}},
+		{ID: "2", Name: "refunds", Labels: map[string]string{"team": "payments"}},
+		{ID: "3", Name: "search", Version: &version, Labels: map[string]string{"team": "discovery"}},
+		{ID: "4", Name: "legacy"},
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestCountResources(t *testing.T) {
+	t.Run("total", func(t *testing.T) {
+		summary, err := CountResources(countTestAPIs(), "")
+		require.NoError(t, err)
+		require.Equal(t, CountSummary{Total: 4}, summary)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		summary, err := CountResources([]kkComps.APIResponseSchema{}, "label:team")
+		require.NoError(t, err)
+		require.Equal(t, 0, summary.Total)
+		require.Empty(t, summary.Groups)
+	})
+
+	t.Run("group by label", func(t *testing.T) {
+		summary, err := CountResources(countTestAPIs(), "label:team")
+		require.NoError(t, err)
+		require.Equal(t, CountSummary{
+			Total:   4,
+			GroupBy: "label:team",
+			Groups: []CountGroup{
+				{Value: strPtr("payments"), Count: 2},
+				{Value: strPtr("discovery"), Count: 1},
+				{Count: 1}, // no team label
+			},
+		}, summary)
+	})
+
+	t.Run("group by field", func(t *testing.T) {
+		for _, groupBy := range []string{"version", "field:version"} {
+			summary, err := CountResources(countTestAPIs(), groupBy)
+			require.NoError(t, err)
+			require.Equal(t, []CountGroup{
+				{Value: strPtr("v1"), Count: 2},
+				{Count: 2},
+			}, summary.Groups)
+		}
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := CountResources(countTestAPIs(), "label:")
+		require.ErrorContains(t, err, "missing a label key")
+		_, err = CountResources(countTestAPIs(), "config..type")
+		require.ErrorContains(t, err, "not a valid field path")
+	})
+}
+
+func TestCountOptions(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddIncludeDeletedFlag(command)
+		AddCountFlags(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	count, groupBy, err := CountOptions(newHelper(t, nil))
+	require.NoError(t, err)
+	require.False(t, count)
+	require.Empty(t, groupBy)
+
+	count, groupBy, err = CountOptions(newHelper(t, nil, "--count"))
+	require.NoError(t, err)
+	require.True(t, count)
+	require.Empty(t, groupBy)
+
+	count, groupBy, err = CountOptions(newHelper(t, nil, "--count-by", "label:team"))
+	require.NoError(t, err)
+	require.True(t, count, "--count-by implies --count")
+	require.Equal(t, "label:team", groupBy)
+
+	_, _, err = CountOptions(newHelper(t, []string{"orders"}, "--count"))
+	require.ErrorContains(t, err, "only supported when listing")
+
+	_, _, err = CountOptions(newHelper(t, nil, "--count", "--include-deleted"))
+	require.ErrorContains(t, err, "cannot be combined with --include-deleted")
+
+	_, _, err = CountOptions(newHelper(t, nil, "--count-by", "label:"))
+	require.ErrorContains(t, err, "missing a label key")
+}
@@ -0,0 +1,146 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/kong/kongctl/internal/declarative/audit"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const IncludeDeletedFlagName = "include-deleted"
+
+// AddIncludeDeletedFlag registers the --include-deleted flag on a get command
+func AddIncludeDeletedFlag(command *cobra.Command) {
+	command.Flags().Bool(IncludeDeletedFlagName, false,
+		"Also list recently deleted resources recorded in the kongctl audit log (list only)")
+}
+
+// IncludeDeleted reports whether --include-deleted was passed. The flag is only
+// meaningful when listing, so combining it with a name or ID argument is rejected.
+func IncludeDeleted(helper cmd.Helper) (bool, error) {
+	flag := helper.GetCmd().Flags().Lookup(IncludeDeletedFlagName)
+	if flag == nil || flag.Value.String() != "true" {
+		return false, nil
+	}
+	if len(helper.GetArgs()) > 0 {
+		return false, &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", IncludeDeletedFlagName),
+		}
+	}
+	return true, nil
+}
+
+// deletedHistorySources returns the history sources consulted for deleted resources of
+// the organization of scope. Konnect does not expose deletion history for declarative
+// resources, so the kongctl audit log is the only source today.
+var deletedHistorySources = func(scope audit.Scope) ([]audit.HistorySource, error) {
+	path, err := audit.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return []audit.HistorySource{audit.NewLocalHistory(audit.NewLog(path), scope)}, nil
+}
+
+// ListDeletedResources returns recently deleted resources of resourceType that are not
+// in activeIDs, from the organization of the profile in use
+func ListDeletedResources(helper cmd.Helper, resourceType string, activeIDs []string) ([]audit.Entry, error) {
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := ResolveBaseURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := deletedHistorySources(audit.Scope{Profile: cfg.GetProfile(), BaseURL: baseURL})
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]bool, len(activeIDs))
+	for _, id := range activeIDs {
+		active[id] = true
+	}
+
+	entries, err := audit.ListDeleted(helper.GetContext(), sources, resourceType, active)
+	if errors.Is(err, audit.ErrHistoryUnavailable) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, cmd.PrepareExecutionErrorWithHelper(helper, "Failed to read deletion history", err)
+	}
+	return entries, nil
+}
+
+type deletedDisplayRecord struct {
+	ID               string
+	Name             string
+	Ref              string
+	DeletedBy        string
+	LocalDeletedTime string
+}
+
+type listWithDeleted struct {
+	Items   any           `json:"items"   yaml:"items"`
+	Deleted []audit.Entry `json:"deleted" yaml:"deleted"`
+}
+
+// RenderListWithDeleted renders the active resources followed by the deleted ones.
+// Text output prints a second table; JSON and YAML output wrap both lists in one document.
+func RenderListWithDeleted(
+	helper cmd.Helper,
+	outType cmdCommon.OutputFormat,
+	printer cli.PrintFlusher,
+	display any,
+	raw any,
+	deleted []audit.Entry,
+) error {
+	if outType != cmdCommon.TEXT {
+		if deleted == nil {
+			deleted = []audit.Entry{}
+		}
+		return tableview.RenderForFormat(helper,
+			false,
+			outType,
+			printer,
+			helper.GetStreams(),
+			display,
+			listWithDeleted{Items: raw, Deleted: deleted},
+			"",
+		)
+	}
+
+	printer.Print(display)
+	printer.Flush()
+
+	if len(deleted) == 0 {
+		fmt.Fprintln(helper.GetStreams().Out, "\nNo recently deleted resources recorded.")
+		return nil
+	}
+
+	records := make([]deletedDisplayRecord, 0, len(deleted))
+	for _, entry := range deleted {
+		id := "n/a"
+		if entry.ResourceID != "" {
+			id = util.AbbreviateUUID(entry.ResourceID)
+		}
+		records = append(records, deletedDisplayRecord{
+			ID:               id,
+			Name:             entry.ResourceName,
+			Ref:              entry.ResourceRef,
+			DeletedBy:        "kongctl " + entry.Command,
+			LocalDeletedTime: entry.Timestamp.In(time.Local).Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	fmt.Fprintln(helper.GetStreams().Out, "\nRecently deleted:")
+	printer.Print(records)
+	return nil
+}
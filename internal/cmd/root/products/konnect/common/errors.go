@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 )
 
 // APIErrorDetails captures common fields returned by Konnect error payloads.
@@ -16,6 +17,8 @@ type APIErrorDetails struct {
 	Title             string                `json:"title"`
 	Instance          string                `json:"instance"`
 	InvalidParameters []APIInvalidParameter `json:"invalid_parameters"`
+	// RequestID is read from the response headers rather than the payload
+	RequestID string `json:"-"`
 }
 
 // APIInvalidParameter describes a field-level validation failure.
@@ -32,10 +35,17 @@ func ParseAPIErrorDetails(err error) *APIErrorDetails {
 
 	var apiErr *sdkerrors.SDKError
 	if errors.As(err, &apiErr) {
-		if details := decodeAPIErrorBody(apiErr.Body); details != nil {
+		details := decodeAPIErrorBody(apiErr.Body)
+		if details == nil && apiErr.RawResponse != nil {
+			details = &APIErrorDetails{}
+		}
+		if details != nil {
 			if details.Status == 0 {
 				details.Status = apiErr.StatusCode
 			}
+			if apiErr.RawResponse != nil {
+				details.RequestID = httpclient.RequestID(apiErr.RawResponse.Header)
+			}
 			return details
 		}
 	}
@@ -80,6 +90,9 @@ func AppendAPIErrorAttrs(attrs []any, details *APIErrorDetails) []any {
 	if instance := strings.TrimSpace(details.Instance); instance != "" {
 		attrs = AppendIfMissingAttr(attrs, "instance", instance)
 	}
+	if requestID := strings.TrimSpace(details.RequestID); requestID != "" {
+		attrs = AppendIfMissingAttr(attrs, "request_id", requestID)
+	}
 	if formatted := formatInvalidParameters(details.InvalidParameters); formatted != "" {
 		attrs = AppendIfMissingAttr(attrs, "invalid_parameters", formatted)
 	}
@@ -0,0 +1,157 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+)
+
+const FieldSelectorFlagName = "field-selector"
+
+// fieldRequirement is one constraint of a field selector
+type fieldRequirement struct {
+	path   []string
+	negate bool
+	value  string
+}
+
+// FieldSelector is a set of constraints on the fields of a resource that must all hold
+type FieldSelector []fieldRequirement
+
+// ParseFieldSelector parses a comma separated list of field=value, field==value and
+// field!=value constraints. Fields are JSON field names of the resource, nested
+// fields are separated by dots, e.g. config.cluster_type.
+func ParseFieldSelector(selector string) (FieldSelector, error) {
+	var result FieldSelector
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		var requirement fieldRequirement
+		if field, value, found := strings.Cut(term, "!="); found {
+			requirement = fieldRequirement{path: strings.Split(strings.TrimSpace(field), "."), negate: true, value: value}
+		} else if field, value, found := strings.Cut(term, "="); found {
+			requirement = fieldRequirement{
+				path:  strings.Split(strings.TrimSpace(field), "."),
+				value: strings.TrimPrefix(value, "="),
+			}
+		} else {
+			return nil, fmt.Errorf("invalid field selector term %q: use field=value or field!=value", term)
+		}
+		requirement.value = strings.TrimSpace(requirement.value)
+		for _, part := range requirement.path {
+			if part == "" || strings.ContainsAny(part, " =!") {
+				return nil, fmt.Errorf("invalid field selector term %q: use field=value or field!=value", term)
+			}
+		}
+		result = append(result, requirement)
+	}
+	return result, nil
+}
+
+// NameEquals returns the value of a name=value constraint, which list commands pass
+// to Konnect to filter server-side
+func (s FieldSelector) NameEquals() (string, bool) {
+	for _, requirement := range s {
+		if !requirement.negate && len(requirement.path) == 1 && requirement.path[0] == "name" {
+			return requirement.value, true
+		}
+	}
+	return "", false
+}
+
+// Matches reports whether the JSON fields of the resource satisfy every constraint.
+// A missing field has the empty value.
+func (s FieldSelector) Matches(fields map[string]any) bool {
+	for _, requirement := range s {
+		value := fieldValue(fields, requirement.path)
+		if (value == requirement.value) == requirement.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldValue(fields map[string]any, path []string) string {
+	var current any = fields
+	for _, part := range path {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return ""
+		}
+		current = object[part]
+	}
+	switch v := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number, bool:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// AddFieldSelectorFlag registers the --field-selector flag on a get command.
+// Commands that are built more than once on the same base command keep the first flag.
+func AddFieldSelectorFlag(command *cobra.Command) {
+	if command.Flags().Lookup(FieldSelectorFlagName) != nil {
+		return
+	}
+	command.Flags().String(FieldSelectorFlagName, "",
+		"Only list resources whose fields match, e.g. name=payments,config.cluster_type!=CLUSTER_TYPE_SERVERLESS "+
+			"(list only)")
+}
+
+// FieldSelectorFilter returns the selector passed with --field-selector, or nil when
+// listing is not filtered. Like --namespace, it cannot be combined with a name or ID.
+func FieldSelectorFilter(helper cmd.Helper) (FieldSelector, error) {
+	flag := helper.GetCmd().Flags().Lookup(FieldSelectorFlagName)
+	if flag == nil || strings.TrimSpace(flag.Value.String()) == "" {
+		return nil, nil
+	}
+	if len(helper.GetArgs()) > 0 {
+		return nil, &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", FieldSelectorFlagName),
+		}
+	}
+	selector, err := ParseFieldSelector(flag.Value.String())
+	if err != nil {
+		return nil, &cmd.ConfigurationError{Err: err}
+	}
+	return selector, nil
+}
+
+// FilterByFieldSelector returns the items, SDK resources, whose JSON fields match the
+// selector. An empty selector returns all items.
+func FilterByFieldSelector[T any](items []T, selector FieldSelector) ([]T, error) {
+	if len(selector) == 0 {
+		return items, nil
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode resource: %w", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var fields map[string]any
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("failed to decode resource: %w", err)
+		}
+		if selector.Matches(fields) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldSelector(t *testing.T) {
+	selector, err := ParseFieldSelector("name=orders, config.cluster_type!=CLUSTER_TYPE_CONTROL_PLANE,version==v1")
+	require.NoError(t, err)
+	require.Equal(t, FieldSelector{
+		{path: []string{"name"}, value: "orders"},
+		{path: []string{"config", "cluster_type"}, negate: true, value: "CLUSTER_TYPE_CONTROL_PLANE"},
+		{path: []string{"version"}, value: "v1"},
+	}, selector)
+
+	name, ok := selector.NameEquals()
+	require.True(t, ok)
+	require.Equal(t, "orders", name)
+
+	for _, invalid := range []string{"name", "=orders", "config..type=x", "display name=orders"} {
+		_, err := ParseFieldSelector(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFilterByFieldSelector(t *testing.T) {
+	hybrid := kkComps.ControlPlaneConfig{ClusterType: kkComps.ControlPlaneClusterTypeClusterTypeControlPlane}
+	serverless := kkComps.ControlPlaneConfig{ClusterType: kkComps.ControlPlaneClusterTypeClusterTypeServerless}
+	cps := []kkComps.ControlPlane{
+		{ID: "1", Name: "prod", Config: hybrid},
+		{ID: "2", Name: "dev", Config: serverless},
+	}
+
+	tests := map[string][]string{
+		"name=prod":  {"prod"},
+		"name!=prod": {"dev"},
+		"config.cluster_type=CLUSTER_TYPE_CONTROL_PLANE":          {"prod"},
+		"config.cluster_type!=CLUSTER_TYPE_CONTROL_PLANE":         {"dev"},
+		"name=dev,config.cluster_type=CLUSTER_TYPE_CONTROL_PLANE": {},
+		"description=": {"prod", "dev"},
+	}
+	for raw, want := range tests {
+		selector, err := ParseFieldSelector(raw)
+		require.NoError(t, err)
+		filtered, err := FilterByFieldSelector(cps, selector)
+		require.NoError(t, err)
+		names := []string{}
+		for _, cp := range filtered {
+			names = append(names, cp.Name)
+		}
+		require.Equal(t, want, names, raw)
+	}
+}
+
+func TestFieldSelectorFilter(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddFieldSelectorFlag(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	selector, err := FieldSelectorFilter(newHelper(t, nil))
+	require.NoError(t, err)
+	require.Nil(t, selector)
+
+	selector, err = FieldSelectorFilter(newHelper(t, nil, "--field-selector", "name=orders"))
+	require.NoError(t, err)
+	require.Len(t, selector, 1)
+
+	_, err = FieldSelectorFilter(newHelper(t, []string{"orders"}, "--field-selector", "name=orders"))
+	require.ErrorContains(t, err, "only supported when listing")
+}
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+)
+
+const (
+	LabelSelectorFlagName = "label-selector"
+	// SelectorFlagName and SelectorFlagShort spell --label-selector as kubectl does
+	SelectorFlagName  = "selector"
+	SelectorFlagShort = "l"
+)
+
+type labelOperator int
+
+const (
+	labelEquals labelOperator = iota
+	labelNotEquals
+	labelExists
+)
+
+// labelRequirement is one constraint of a label selector
+type labelRequirement struct {
+	key      string
+	operator labelOperator
+	value    string
+}
+
+// LabelSelector is a set of label constraints that must all hold
+type LabelSelector []labelRequirement
+
+// ParseLabelSelector parses a comma separated list of key=value, key!=value and
+// key (label exists) constraints
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var result LabelSelector
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		requirement := labelRequirement{operator: labelExists, key: term}
+		if key, value, found := strings.Cut(term, "!="); found {
+			requirement = labelRequirement{key: key, operator: labelNotEquals, value: value}
+		} else if key, value, found := strings.Cut(term, "="); found {
+			requirement = labelRequirement{key: key, operator: labelEquals, value: strings.TrimPrefix(value, "=")}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if requirement.key == "" || strings.ContainsAny(requirement.key, " =!") {
+			return nil, fmt.Errorf("invalid label selector term %q: use key=value, key!=value or key", term)
+		}
+		result = append(result, requirement)
+	}
+	return result, nil
+}
+
+// Matches reports whether the labels satisfy every constraint of the selector
+func (s LabelSelector) Matches(itemLabels map[string]string) bool {
+	for _, requirement := range s {
+		value, ok := itemLabels[requirement.key]
+		switch requirement.operator {
+		case labelEquals:
+			if !ok || value != requirement.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == requirement.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AddLabelSelectorFlag registers the --label-selector flag on a get command.
+// Commands that are built more than once on the same base command keep the first flag.
+func AddLabelSelectorFlag(command *cobra.Command) {
+	if command.Flags().Lookup(LabelSelectorFlagName) != nil {
+		return
+	}
+	command.Flags().String(LabelSelectorFlagName, "",
+		"Only list resources whose labels match, e.g. team=payments,env!=dev,owner (list only)")
+	command.Flags().StringP(SelectorFlagName, SelectorFlagShort, "",
+		fmt.Sprintf("Same as --%s", LabelSelectorFlagName))
+	command.MarkFlagsMutuallyExclusive(LabelSelectorFlagName, SelectorFlagName)
+}
+
+// LabelSelectorFilter returns the selector passed with --label-selector, or nil when
+// listing is not filtered. Like --namespace, it cannot be combined with a name or ID.
+func LabelSelectorFilter(helper cmd.Helper) (LabelSelector, error) {
+	flag := helper.GetCmd().Flags().Lookup(LabelSelectorFlagName)
+	if alias := helper.GetCmd().Flags().Lookup(SelectorFlagName); alias != nil && alias.Changed {
+		flag = alias
+	}
+	if flag == nil || strings.TrimSpace(flag.Value.String()) == "" {
+		return nil, nil
+	}
+	if len(helper.GetArgs()) > 0 {
+		return nil, &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", flag.Name),
+		}
+	}
+	selector, err := ParseLabelSelector(flag.Value.String())
+	if err != nil {
+		return nil, &cmd.ConfigurationError{Err: err}
+	}
+	return selector, nil
+}
+
+// FilterByLabelSelector returns the items, SDK resources with labels, whose labels
+// match the selector. An empty selector returns all items.
+func FilterByLabelSelector[T any](items []T, selector LabelSelector) ([]T, error) {
+	if len(selector) == 0 {
+		return items, nil
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		itemLabels, err := resourceLabels(item)
+		if err != nil {
+			return nil, err
+		}
+		if selector.Matches(itemLabels) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
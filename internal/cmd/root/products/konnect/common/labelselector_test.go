@@ -0,0 +1,93 @@
+package common
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := ParseLabelSelector("team=payments, env!=dev,owner,tier==gold")
+	require.NoError(t, err)
+	require.Equal(t, LabelSelector{
+		{key: "team", operator: labelEquals, value: "payments"},
+		{key: "env", operator: labelNotEquals, value: "dev"},
+		{key: "owner", operator: labelExists},
+		{key: "tier", operator: labelEquals, value: "gold"},
+	}, selector)
+
+	for _, invalid := range []string{"=payments", "!=dev", "team name=payments"} {
+		_, err := ParseLabelSelector(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	itemLabels := map[string]string{"team": "payments", "env": "prod"}
+
+	tests := map[string]bool{
+		"team=payments":          true,
+		"team=payments,env=prod": true,
+		"team=payments,env=dev":  false,
+		"env!=dev":               true,
+		"env!=prod":              false,
+		"owner!=alice":           true,
+		"team":                   true,
+		"owner":                  false,
+	}
+	for raw, want := range tests {
+		selector, err := ParseLabelSelector(raw)
+		require.NoError(t, err)
+		require.Equal(t, want, selector.Matches(itemLabels), raw)
+	}
+}
+
+func TestFilterByLabelSelector(t *testing.T) {
+	apis := []kkComps.APIResponseSchema{
+		{ID: "1", Name: "orders", Labels: map[string]string{"team": "payments", "env": "prod"}},
+		{ID: "2", Name: "refunds", Labels: map[string]string{"team": "payments", "env": "dev"}},
+		{ID: "3", Name: "legacy"},
+	}
+
+	selector, err := ParseLabelSelector("team=payments,env=prod")
+	require.NoError(t, err)
+	filtered, err := FilterByLabelSelector(apis, selector)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "orders", filtered[0].Name)
+
+	filtered, err = FilterByLabelSelector(apis, nil)
+	require.NoError(t, err)
+	require.Equal(t, apis, filtered)
+}
+
+func TestLabelSelectorFilter(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddLabelSelectorFlag(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	selector, err := LabelSelectorFilter(newHelper(t, nil))
+	require.NoError(t, err)
+	require.Nil(t, selector)
+
+	selector, err = LabelSelectorFilter(newHelper(t, nil, "--label-selector", "team=payments"))
+	require.NoError(t, err)
+	require.Len(t, selector, 1)
+
+	_, err = LabelSelectorFilter(newHelper(t, []string{"orders"}, "--label-selector", "team=payments"))
+	require.ErrorContains(t, err, "only supported when listing")
+
+	_, err = LabelSelectorFilter(newHelper(t, nil, "--label-selector", "=payments"))
+	require.Error(t, err)
+
+	selector, err = LabelSelectorFilter(newHelper(t, nil, "-l", "team=payments,env=prod"))
+	require.NoError(t, err)
+	require.Len(t, selector, 2)
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SelectByName returns the only resource among matches, the resources of resourceType
+// named name. A name matching nothing is a not found error, and a name matching several
+// resources is an error listing their IDs, so that one can be selected by ID instead.
+func SelectByName[T any](helper cmd.Helper, resourceType, name string, matches []T, id func(T) string) (*T, error) {
+	switch len(matches) {
+	case 0:
+		return nil, cmd.PrepareExecutionErrorMsg(helper, fmt.Sprintf("%s %q not found", resourceType, name))
+	case 1:
+		return &matches[0], nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, id(match))
+	}
+	return nil, cmd.PrepareExecutionErrorMsg(helper,
+		fmt.Sprintf("%s name %q is ambiguous, it matches %d resources: %s. Get one by ID instead",
+			resourceType, name, len(matches), strings.Join(ids, ", ")))
+}
+
+// RunChildCommand runs the child of a get command named by the second argument for the
+// parent resource named or identified by the first, e.g. `get api orders versions 1.0.0`.
+// The parent is passed to the child in its idFlag or nameFlag, and flags set on the
+// parent command, such as --output, carry over. It reports false, without running
+// anything, when args do not name a child command.
+func RunChildCommand(parent *cobra.Command, args []string, idFlag, nameFlag string) (bool, error) {
+	if len(args) < 2 {
+		return false, nil
+	}
+	var child *cobra.Command
+	for _, candidate := range parent.Commands() {
+		if candidate.Name() == args[1] || candidate.HasAlias(args[1]) {
+			child = candidate
+			break
+		}
+	}
+	if child == nil || child.RunE == nil {
+		return false, nil
+	}
+
+	var err error
+	parent.Flags().Visit(func(flag *pflag.Flag) {
+		target := child.Flags().Lookup(flag.Name)
+		if err != nil || target == nil || target == flag {
+			return
+		}
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			if targetValues, ok := target.Value.(pflag.SliceValue); ok {
+				err = targetValues.Replace(values.GetSlice())
+				target.Changed = true
+				return
+			}
+		}
+		err = child.Flags().Set(flag.Name, flag.Value.String())
+	})
+	if err != nil {
+		return true, err
+	}
+
+	parentRef := strings.TrimSpace(args[0])
+	parentFlag := nameFlag
+	if util.IsValidUUID(parentRef) {
+		parentFlag = idFlag
+	}
+	if err := child.Flags().Set(parentFlag, parentRef); err != nil {
+		return true, err
+	}
+
+	childArgs := args[2:]
+	child.SetContext(parent.Context())
+	// Cobra reports errors for the parent, the command it executed
+	defer func() {
+		parent.SilenceUsage = parent.SilenceUsage || child.SilenceUsage
+		parent.SilenceErrors = parent.SilenceErrors || child.SilenceErrors
+	}()
+	if child.PreRunE != nil {
+		if err := child.PreRunE(child, childArgs); err != nil {
+			return true, err
+		}
+	}
+	return true, child.RunE(child, childArgs)
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectByName(t *testing.T) {
+	helper := cmd.BuildHelper(&cobra.Command{Use: "portals"}, nil)
+	portalID := func(portal kkComps.ListPortalsResponsePortal) string { return portal.ID }
+
+	portal, err := SelectByName(helper, "portal", "dev", []kkComps.ListPortalsResponsePortal{{ID: "p-1", Name: "dev"}},
+		portalID)
+	require.NoError(t, err)
+	assert.Equal(t, "p-1", portal.ID)
+
+	_, err = SelectByName(helper, "portal", "dev", nil, portalID)
+	require.EqualError(t, err, `portal "dev" not found`)
+
+	_, err = SelectByName(helper, "portal", "dev", []kkComps.ListPortalsResponsePortal{
+		{ID: "p-1", Name: "dev"}, {ID: "p-2", Name: "dev"},
+	}, portalID)
+	require.EqualError(t, err, `portal name "dev" is ambiguous, it matches 2 resources: p-1, p-2. Get one by ID instead`)
+}
+
+func TestRunChildCommand(t *testing.T) {
+	newCommands := func() (*cobra.Command, *cobra.Command, *[]string) {
+		var childArgs []string
+		parent := &cobra.Command{Use: "api", RunE: func(*cobra.Command, []string) error { return nil }}
+		parent.Flags().String("output", "text", "")
+		child := &cobra.Command{
+			Use:     "versions",
+			Aliases: []string{"vs"},
+			RunE: func(_ *cobra.Command, args []string) error {
+				childArgs = args
+				return nil
+			},
+		}
+		child.Flags().String("output", "text", "")
+		child.Flags().String("api-id", "", "")
+		child.Flags().String("api-name", "", "")
+		parent.AddCommand(child)
+		return parent, child, &childArgs
+	}
+
+	t.Run("runs the child for a named parent", func(t *testing.T) {
+		parent, child, childArgs := newCommands()
+		require.NoError(t, parent.Flags().Parse([]string{"--output", "json"}))
+
+		handled, err := RunChildCommand(parent, []string{"orders", "vs", "1.0.0"}, "api-id", "api-name")
+		require.NoError(t, err)
+		assert.True(t, handled)
+		assert.Equal(t, []string{"1.0.0"}, *childArgs)
+		assert.Equal(t, "orders", child.Flags().Lookup("api-name").Value.String())
+		assert.Equal(t, "json", child.Flags().Lookup("output").Value.String())
+	})
+
+	t.Run("passes an ID in the ID flag", func(t *testing.T) {
+		parent, child, _ := newCommands()
+		id := "22cd8a0b-72e7-4212-9099-0764f8e9c5ac"
+
+		handled, err := RunChildCommand(parent, []string{id, "versions"}, "api-id", "api-name")
+		require.NoError(t, err)
+		assert.True(t, handled)
+		assert.Equal(t, id, child.Flags().Lookup("api-id").Value.String())
+		assert.Empty(t, child.Flags().Lookup("api-name").Value.String())
+	})
+
+	t.Run("ignores arguments that are not a child", func(t *testing.T) {
+		parent, _, childArgs := newCommands()
+
+		for _, args := range [][]string{{"orders"}, {"orders", "payments"}} {
+			handled, err := RunChildCommand(parent, args, "api-id", "api-name")
+			require.NoError(t, err)
+			assert.False(t, handled)
+		}
+		assert.Nil(t, *childArgs)
+	})
+}
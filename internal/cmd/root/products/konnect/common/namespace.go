@@ -0,0 +1,153 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/validator"
+	"github.com/spf13/cobra"
+)
+
+const (
+	NamespaceFlagName     = "namespace"
+	AllNamespacesFlagName = "all-namespaces"
+)
+
+// AddNamespaceFilterFlag registers the --namespace and --all-namespaces flags on a get
+// command. Commands that are built more than once on the same base command keep the first flags.
+func AddNamespaceFilterFlag(command *cobra.Command) {
+	if command.Flags().Lookup(NamespaceFlagName) != nil {
+		return
+	}
+	command.Flags().String(NamespaceFlagName, "",
+		"Only list resources managed by kongctl in this namespace (list only)")
+	command.Flags().BoolP(AllNamespacesFlagName, "A", false,
+		"List resources of every namespace with the namespace owning each of them (list only)")
+	command.MarkFlagsMutuallyExclusive(NamespaceFlagName, AllNamespacesFlagName)
+}
+
+// NamespaceFilter returns the namespace passed with --namespace, or "" when listing
+// is not filtered. The filter only applies when listing, so combining it with a name
+// or ID argument is rejected.
+func NamespaceFilter(helper cmd.Helper) (string, error) {
+	if allNamespaces(helper) && len(helper.GetArgs()) > 0 {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", AllNamespacesFlagName),
+		}
+	}
+
+	flag := helper.GetCmd().Flags().Lookup(NamespaceFlagName)
+	if flag == nil {
+		return "", nil
+	}
+	namespace := strings.TrimSpace(flag.Value.String())
+	if namespace == "" {
+		return "", nil
+	}
+	if len(helper.GetArgs()) > 0 {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", NamespaceFlagName),
+		}
+	}
+	if err := validator.NewNamespaceValidator().ValidateNamespace(namespace); err != nil {
+		return "", &cmd.ConfigurationError{Err: err}
+	}
+	return namespace, nil
+}
+
+// FilterByNamespace returns the items, SDK resources with labels, whose kongctl
+// namespace label is namespace. An empty namespace returns all items.
+func FilterByNamespace[T any](items []T, namespace string) ([]T, error) {
+	if namespace == "" {
+		return items, nil
+	}
+
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		itemLabels, err := resourceLabels(item)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := itemLabels[labels.NamespaceKey]; ok && value == namespace {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+func allNamespaces(helper cmd.Helper) bool {
+	flag := helper.GetCmd().Flags().Lookup(AllNamespacesFlagName)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// WithNamespaceColumn prepends a Namespace column, the kongctl namespace owning each
+// resource or n/a for unmanaged resources, to the text display records when
+// --all-namespaces is passed. display is a slice of structs and raw the slice of SDK
+// resources they were built from, in the same order.
+func WithNamespaceColumn(helper cmd.Helper, display any, raw any) (any, error) {
+	if !allNamespaces(helper) {
+		return display, nil
+	}
+
+	records := reflect.ValueOf(display)
+	resources := reflect.ValueOf(raw)
+	if records.Kind() != reflect.Slice || records.Type().Elem().Kind() != reflect.Struct ||
+		resources.Kind() != reflect.Slice || records.Len() != resources.Len() {
+		return display, nil
+	}
+
+	recordType := records.Type().Elem()
+	fields := []reflect.StructField{{Name: "Namespace", Type: reflect.TypeOf("")}}
+	for i := range recordType.NumField() {
+		if field := recordType.Field(i); field.IsExported() && field.Name != "Namespace" {
+			fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+		}
+	}
+	namespacedType := reflect.StructOf(fields)
+
+	namespaced := reflect.MakeSlice(reflect.SliceOf(namespacedType), 0, records.Len())
+	for i := range records.Len() {
+		itemLabels, err := resourceLabels(resources.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		namespace := itemLabels[labels.NamespaceKey]
+		if namespace == "" {
+			namespace = "n/a"
+		}
+
+		row := reflect.New(namespacedType).Elem()
+		row.Field(0).SetString(namespace)
+		for j := 1; j < len(fields); j++ {
+			row.Field(j).Set(records.Index(i).FieldByName(fields[j].Name))
+		}
+		namespaced = reflect.Append(namespaced, row)
+	}
+	return namespaced.Interface(), nil
+}
+
+// resourceLabels returns the labels of an SDK resource. SDK types model labels as
+// map[string]string or map[string]*string, so they are read from the JSON encoding.
+func resourceLabels(item any) (map[string]string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %w", err)
+	}
+	var object struct {
+		Labels map[string]*string `json:"labels"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to decode resource: %w", err)
+	}
+	result := make(map[string]string, len(object.Labels))
+	for key, value := range object.Labels {
+		if value != nil {
+			result[key] = *value
+		}
+	}
+	return result, nil
+}
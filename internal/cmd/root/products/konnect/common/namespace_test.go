@@ -0,0 +1,92 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterByNamespace(t *testing.T) {
+	apis := []kkComps.APIResponseSchema{
+		{ID: "1", Name: "orders", Labels: map[string]string{labels.NamespaceKey: "team-a"}},
+		{ID: "2", Name: "refunds", Labels: map[string]string{labels.NamespaceKey: "team-b"}},
+		{ID: "3", Name: "legacy"},
+	}
+
+	filtered, err := FilterByNamespace(apis, "team-a")
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "orders", filtered[0].Name)
+
+	filtered, err = FilterByNamespace(apis, "team-c")
+	require.NoError(t, err)
+	require.Empty(t, filtered)
+
+	filtered, err = FilterByNamespace(apis, "")
+	require.NoError(t, err)
+	require.Equal(t, apis, filtered)
+}
+
+func TestNamespaceFilter(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddNamespaceFilterFlag(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	namespace, err := NamespaceFilter(newHelper(t, nil))
+	require.NoError(t, err)
+	require.Empty(t, namespace)
+
+	namespace, err = NamespaceFilter(newHelper(t, nil, "--namespace", "team-a"))
+	require.NoError(t, err)
+	require.Equal(t, "team-a", namespace)
+
+	_, err = NamespaceFilter(newHelper(t, []string{"orders"}, "--namespace", "team-a"))
+	require.ErrorContains(t, err, "only supported when listing")
+
+	_, err = NamespaceFilter(newHelper(t, nil, "--namespace", "Team A"))
+	require.Error(t, err)
+
+	_, err = NamespaceFilter(newHelper(t, []string{"orders"}, "--all-namespaces"))
+	require.ErrorContains(t, err, "only supported when listing")
+}
+
+func TestWithNamespaceColumn(t *testing.T) {
+	type record struct {
+		ID   string
+		Name string
+	}
+	apis := []kkComps.APIResponseSchema{
+		{ID: "1", Name: "orders", Labels: map[string]string{labels.NamespaceKey: "team-a"}},
+		{ID: "3", Name: "legacy"},
+	}
+	records := []record{{ID: "1", Name: "orders"}, {ID: "3", Name: "legacy"}}
+
+	newHelper := func(t *testing.T, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddNamespaceFilterFlag(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, nil)
+	}
+
+	display, err := WithNamespaceColumn(newHelper(t), records, apis)
+	require.NoError(t, err)
+	require.Equal(t, records, display)
+
+	display, err = WithNamespaceColumn(newHelper(t, "-A"), records, apis)
+	require.NoError(t, err)
+	data, err := json.Marshal(display)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`[{"Namespace":"team-a","ID":"1","Name":"orders"},{"Namespace":"n/a","ID":"3","Name":"legacy"}]`,
+		string(data))
+}
@@ -0,0 +1,162 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const LimitFlagName = "limit"
+
+// AddLimitFlag registers the --limit flag on a get command.
+// Commands that are built more than once on the same base command keep the first flag.
+func AddLimitFlag(command *cobra.Command) {
+	if command.Flags().Lookup(LimitFlagName) != nil {
+		return
+	}
+	command.Flags().Int(LimitFlagName, 0,
+		"Maximum number of resources to list, 0 lists all of them (list only)")
+}
+
+// ListLimit returns the limit passed with --limit, or 0 when listing is not limited.
+// Like --label-selector, it cannot be combined with a name or ID, nor with --count.
+func ListLimit(helper cmd.Helper) (int, error) {
+	flags := helper.GetCmd().Flags()
+	flag := flags.Lookup(LimitFlagName)
+	if flag == nil || !flag.Changed {
+		return 0, nil
+	}
+	limit, err := flags.GetInt(LimitFlagName)
+	if err != nil {
+		return 0, &cmd.ConfigurationError{Err: err}
+	}
+	switch {
+	case limit < 0:
+		return 0, &cmd.ConfigurationError{Err: fmt.Errorf("--%s must be 0 or greater", LimitFlagName)}
+	case len(helper.GetArgs()) > 0:
+		return 0, &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", LimitFlagName),
+		}
+	}
+	for _, name := range []string{CountFlagName, CountByFlagName} {
+		if count := flags.Lookup(name); count != nil && count.Changed {
+			return 0, &cmd.ConfigurationError{
+				Err: fmt.Errorf("--%s cannot be combined with --%s", LimitFlagName, name),
+			}
+		}
+	}
+	return limit, nil
+}
+
+// LimitResults returns the first limit items, or every item for a limit of 0
+func LimitResults[T any](items []T, limit int) []T {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// PageFetcher fetches one page of a Konnect list operation, returning the items of
+// the page and the total number of items the API reports across all pages
+type PageFetcher[T any] func(pageSize, pageNumber int64) ([]T, float64, error)
+
+// ListPages collects the items of a page number paginated Konnect list operation,
+// requesting pages of the configured --page-size. It stops once it has the total
+// the API reports, on an empty page, on a short page when the API reports no total,
+// or, for a limit above 0, once it has limit items.
+func ListPages[T any](cfg config.Hook, limit int, fetch PageFetcher[T]) ([]T, error) {
+	var all []T
+	_, err := StreamPages(cfg, PageRange{Limit: limit}, fetch, nil, func(items []T) error {
+		all = append(all, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// PageRange is the part of a list StreamPages reads
+type PageRange struct {
+	// Limit is the maximum number of items to emit, 0 emits all of them
+	Limit int
+	// Offset is the number of items at the start of the list to skip, as continued
+	// with --continue
+	Offset int
+}
+
+// PageFilter keeps the items of a page matching the filters of a command
+type PageFilter[T any] func([]T) ([]T, error)
+
+// PageStream is the outcome of StreamPages
+type PageStream struct {
+	// Listed is the number of items read from Konnect, before filtering
+	Listed int
+	// Next is the offset the list continues from, or 0 when every item was read
+	Next int
+}
+
+// StreamPages reads the items of a page number paginated Konnect list operation from
+// the offset of the range, passing the items of each page kept by filter to emit as
+// the page arrives. Only one page is held at a time. Without a filter, pages are
+// reduced to the limit, as there is no need to read more items than are emitted.
+func StreamPages[T any](
+	cfg config.Hook, rng PageRange, fetch PageFetcher[T], filter PageFilter[T], emit func([]T) error,
+) (PageStream, error) {
+	pageSize := int64(cfg.GetInt(RequestPageSizeConfigPath))
+	if pageSize < 1 {
+		pageSize = int64(DefaultRequestPageSize)
+	}
+	if filter == nil && rng.Limit > 0 && int64(rng.Limit) < pageSize {
+		pageSize = int64(rng.Limit)
+	}
+
+	var result PageStream
+	emitted := 0
+	offset := int64(max(rng.Offset, 0))
+	for pageNumber := offset/pageSize + 1; ; pageNumber++ {
+		items, total, err := fetch(pageSize, pageNumber)
+		if err != nil {
+			return result, err
+		}
+		start := (pageNumber - 1) * pageSize
+		skip := min(int(offset-start), len(items))
+		if skip < 0 {
+			skip = 0
+		}
+		end := start + int64(len(items))
+		last := len(items) == 0 ||
+			total > 0 && float64(end) >= total ||
+			total <= 0 && int64(len(items)) < pageSize
+
+		var kept []T
+		for i := skip; i < len(items); i++ {
+			item := items[i : i+1]
+			if filter != nil {
+				if item, err = filter(item); err != nil {
+					return result, err
+				}
+			}
+			result.Listed++
+			kept = append(kept, item...)
+			emitted += len(item)
+			if rng.Limit > 0 && emitted >= rng.Limit {
+				if i+1 < len(items) || !last {
+					result.Next = int(start) + i + 1
+				}
+				last = true
+				break
+			}
+		}
+		if len(kept) > 0 {
+			if err := emit(kept); err != nil {
+				return result, err
+			}
+		}
+		if last {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd"
+	configtest "github.com/kong/kongctl/test/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedItems serves items in pages, reporting total as the API total, and records the
+// page sizes requested
+func pagedItems(items []int, total float64, sizes *[]int64) PageFetcher[int] {
+	return func(pageSize, pageNumber int64) ([]int, float64, error) {
+		*sizes = append(*sizes, pageSize)
+		start := min(int((pageNumber-1)*pageSize), len(items))
+		end := min(start+int(pageSize), len(items))
+		return items[start:end], total, nil
+	}
+}
+
+func TestListPages(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	newConfig := func(pageSize int) *configtest.MockConfigHook {
+		cfg, _ := newTestConfig(nil)
+		cfg.GetIntMock = func(string) int { return pageSize }
+		return cfg
+	}
+
+	t.Run("stops at the reported total", func(t *testing.T) {
+		var sizes []int64
+		all, err := ListPages(newConfig(3), 0, pagedItems(items, 7, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, items, all)
+		require.Equal(t, []int64{3, 3, 3}, sizes)
+	})
+
+	t.Run("stops on a short page without a total", func(t *testing.T) {
+		var sizes []int64
+		all, err := ListPages(newConfig(3), 0, pagedItems(items, 0, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, items, all)
+		require.Len(t, sizes, 3)
+	})
+
+	t.Run("stops on an empty page", func(t *testing.T) {
+		var sizes []int64
+		all, err := ListPages(newConfig(7), 0, pagedItems(items, 20, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, items, all)
+		require.Len(t, sizes, 2)
+	})
+
+	t.Run("defaults the page size", func(t *testing.T) {
+		var sizes []int64
+		_, err := ListPages(newConfig(0), 0, pagedItems(items, 7, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, []int64{int64(DefaultRequestPageSize)}, sizes)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		var sizes []int64
+		all, err := ListPages(newConfig(3), 5, pagedItems(items, 7, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3, 4, 5}, all)
+		require.Len(t, sizes, 2)
+
+		sizes = nil
+		all, err = ListPages(newConfig(10), 2, pagedItems(items, 7, &sizes))
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, all)
+		require.Equal(t, []int64{2}, sizes, "the page size is reduced to the limit")
+	})
+
+	t.Run("returns fetch errors", func(t *testing.T) {
+		_, err := ListPages(newConfig(3), 0, func(int64, int64) ([]int, float64, error) {
+			return nil, 0, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestStreamPages(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	cfg, _ := newTestConfig(nil)
+	cfg.GetIntMock = func(string) int { return 3 }
+	collect := func(rng PageRange, filter PageFilter[int]) ([][]int, PageStream, []int64) {
+		var pages [][]int
+		var sizes []int64
+		result, err := StreamPages(cfg, rng, pagedItems(items, 7, &sizes), filter, func(page []int) error {
+			pages = append(pages, page)
+			return nil
+		})
+		require.NoError(t, err)
+		return pages, result, sizes
+	}
+	odd := func(page []int) ([]int, error) {
+		var kept []int
+		for _, item := range page {
+			if item%2 == 1 {
+				kept = append(kept, item)
+			}
+		}
+		return kept, nil
+	}
+
+	t.Run("emits each page as it is read", func(t *testing.T) {
+		pages, result, _ := collect(PageRange{}, nil)
+		require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, pages)
+		require.Equal(t, PageStream{Listed: 7}, result)
+	})
+
+	t.Run("filters each page", func(t *testing.T) {
+		pages, result, _ := collect(PageRange{}, odd)
+		require.Equal(t, [][]int{{1, 3}, {5}, {7}}, pages)
+		require.Zero(t, result.Next)
+	})
+
+	t.Run("continues after the limit", func(t *testing.T) {
+		pages, result, _ := collect(PageRange{Limit: 2}, odd)
+		require.Equal(t, [][]int{{1, 3}}, pages)
+		require.Equal(t, 3, result.Next, "the list continues after the last item emitted")
+
+		pages, result, _ = collect(PageRange{Limit: 2, Offset: result.Next}, odd)
+		require.Equal(t, [][]int{{5}, {7}}, pages)
+		require.Zero(t, result.Next, "the list has no more items")
+	})
+
+	t.Run("starts at the offset", func(t *testing.T) {
+		pages, result, sizes := collect(PageRange{Offset: 4}, nil)
+		require.Equal(t, [][]int{{5, 6}, {7}}, pages)
+		require.Equal(t, 3, result.Listed)
+		require.Len(t, sizes, 2, "pages before the offset are not read")
+	})
+
+	t.Run("reports no continuation when the limit ends the list", func(t *testing.T) {
+		_, result, _ := collect(PageRange{Limit: 7}, nil)
+		require.Zero(t, result.Next)
+
+		_, result, _ = collect(PageRange{Limit: 6}, nil)
+		require.Equal(t, 6, result.Next)
+	})
+
+	t.Run("returns emit errors", func(t *testing.T) {
+		var sizes []int64
+		_, err := StreamPages(cfg, PageRange{}, pagedItems(items, 7, &sizes), nil, func([]int) error {
+			return errors.New("closed")
+		})
+		require.EqualError(t, err, "closed")
+		require.Len(t, sizes, 1)
+	})
+}
+
+func TestListLimit(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "portals"}
+		AddCountFlags(command)
+		AddLimitFlag(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	limit, err := ListLimit(newHelper(t, nil))
+	require.NoError(t, err)
+	require.Zero(t, limit)
+
+	limit, err = ListLimit(newHelper(t, nil, "--limit", "5"))
+	require.NoError(t, err)
+	require.Equal(t, 5, limit)
+
+	_, err = ListLimit(newHelper(t, nil, "--limit", "-1"))
+	require.ErrorContains(t, err, "must be 0 or greater")
+
+	_, err = ListLimit(newHelper(t, []string{"dev"}, "--limit", "5"))
+	require.ErrorContains(t, err, "only supported when listing")
+
+	_, err = ListLimit(newHelper(t, nil, "--limit", "5", "--count"))
+	require.ErrorContains(t, err, "cannot be combined with --count")
+
+	var configErr *cmd.ConfigurationError
+	require.ErrorAs(t, err, &configErr)
+}
+
+func TestLimitResults(t *testing.T) {
+	require.Equal(t, []int{1, 2}, LimitResults([]int{1, 2, 3}, 2))
+	require.Equal(t, []int{1, 2, 3}, LimitResults([]int{1, 2, 3}, 0))
+	require.Equal(t, []int{1}, LimitResults([]int{1}, 5))
+}
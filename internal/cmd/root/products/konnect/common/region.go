@@ -0,0 +1,39 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	cmdcommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/config"
+)
+
+// RegionOf describes the Konnect region requests to baseURL go to, naming the default
+// US region when neither a region nor a base URL is configured
+func RegionOf(cfg config.Hook, baseURL string) string {
+	if region := strings.ToLower(strings.TrimSpace(cfg.GetString(RegionConfigPath))); region != "" {
+		return region
+	}
+	if baseURL == BaseURLDefault {
+		return "us (default)"
+	}
+	return "custom base URL"
+}
+
+// WarnIfEmptyInDefaultRegion hints on stderr, for text output, that an empty list may
+// come from the default US region not being the region of the organization. Konnect
+// answers requests for another region's organization with empty lists, not errors.
+func WarnIfEmptyInDefaultRegion(helper cmd.Helper, cfg config.Hook, count int) {
+	if count > 0 || strings.TrimSpace(cfg.GetString(RegionConfigPath)) != "" ||
+		cfg.GetString(BaseURLConfigPath) != BaseURLDefault {
+		return
+	}
+	if format, err := helper.GetOutputFormat(); err != nil || format != cmdcommon.TEXT {
+		return
+	}
+	fmt.Fprintf(helper.GetStreams().ErrOut,
+		"No resources found at %s, the default us region. If your organization is in another "+
+			"Konnect region, select it with --%s, for example --%s eu.\n",
+		BaseURLDefault, RegionFlagName, RegionFlagName)
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+
+	cmdcommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/iostreams"
+	cmdtest "github.com/kong/kongctl/test/cmd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionOf(t *testing.T) {
+	cfg, _ := newTestConfig(map[string]string{RegionConfigPath: "EU"})
+	require.Equal(t, "eu", RegionOf(cfg, "https://eu.api.konghq.com"))
+
+	cfg, _ = newTestConfig(nil)
+	require.Equal(t, "us (default)", RegionOf(cfg, BaseURLDefault))
+	require.Equal(t, "custom base URL", RegionOf(cfg, "https://konnect.example.com"))
+}
+
+func TestWarnIfEmptyInDefaultRegion(t *testing.T) {
+	warn := func(values map[string]string, format cmdcommon.OutputFormat, count int) string {
+		streams, _, _, errOut := iostreams.NewTestIOStreams()
+		helper := &cmdtest.MockHelper{
+			GetStreamsMock:      func() *iostreams.IOStreams { return streams },
+			GetOutputFormatMock: func() (cmdcommon.OutputFormat, error) { return format, nil },
+		}
+		cfg, _ := newTestConfig(values)
+		WarnIfEmptyInDefaultRegion(helper, cfg, count)
+		return errOut.String()
+	}
+	defaulted := map[string]string{BaseURLConfigPath: BaseURLDefault}
+
+	require.Contains(t, warn(defaulted, cmdcommon.TEXT, 0), "--region eu")
+	require.Empty(t, warn(defaulted, cmdcommon.TEXT, 3), "lists with results need no hint")
+	require.Empty(t, warn(defaulted, cmdcommon.JSON, 0), "machine-readable output stays quiet")
+	require.Empty(t, warn(map[string]string{RegionConfigPath: "us", BaseURLConfigPath: BaseURLDefault},
+		cmdcommon.TEXT, 0), "an explicit region is not questioned")
+	require.Empty(t, warn(map[string]string{BaseURLConfigPath: "https://konnect.example.com"}, cmdcommon.TEXT, 0))
+}
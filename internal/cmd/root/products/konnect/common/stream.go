@@ -0,0 +1,143 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	StreamFlagName   = "stream"
+	ContinueFlagName = "continue"
+)
+
+// AddStreamFlags registers the --stream and --continue flags on a get command.
+// Commands that are built more than once on the same base command keep the first flags.
+func AddStreamFlags(command *cobra.Command) {
+	if command.Flags().Lookup(StreamFlagName) != nil {
+		return
+	}
+	command.Flags().Bool(StreamFlagName, false,
+		"Print each page of resources as it arrives rather than once all pages are read (list only)")
+	command.Flags().String(ContinueFlagName, "",
+		"Continue a list stopped by --limit from the token it printed (list only)")
+}
+
+// continueToken is the position of a list encoded in a --continue token. Lists continue
+// by position, so resources created or deleted in between can shift the next page.
+type continueToken struct {
+	Resource string `json:"resource"`
+	Offset   int    `json:"offset"`
+}
+
+// EncodeContinueToken returns the --continue token resuming a list of resource at offset
+func EncodeContinueToken(resource string, offset int) string {
+	data, _ := json.Marshal(continueToken{Resource: resource, Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeContinueToken(resource, token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	var decoded continueToken
+	if err == nil {
+		err = json.Unmarshal(data, &decoded)
+	}
+	if err != nil || decoded.Offset < 0 {
+		return 0, fmt.Errorf("invalid --%s token", ContinueFlagName)
+	}
+	if decoded.Resource != resource {
+		return 0, fmt.Errorf("the --%s token continues a list of %s, not %s",
+			ContinueFlagName, decoded.Resource, resource)
+	}
+	return decoded.Offset, nil
+}
+
+// StreamOptions returns whether --stream was passed and the offset of the --continue
+// token for a list of resource. Like --limit, neither can be combined with a name or
+// ID, nor with --count or --include-deleted.
+func StreamOptions(helper cmd.Helper, resource string) (bool, int, error) {
+	flags := helper.GetCmd().Flags()
+	stream, _ := flags.GetBool(StreamFlagName)
+	token, _ := flags.GetString(ContinueFlagName)
+	if !stream && token == "" {
+		return false, 0, nil
+	}
+
+	name := StreamFlagName
+	if !stream {
+		name = ContinueFlagName
+	}
+	if len(helper.GetArgs()) > 0 {
+		return false, 0, &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing resources", name),
+		}
+	}
+	for _, other := range []string{CountFlagName, CountByFlagName, IncludeDeletedFlagName} {
+		if flag := flags.Lookup(other); flag != nil && flag.Changed {
+			return false, 0, &cmd.ConfigurationError{
+				Err: fmt.Errorf("--%s cannot be combined with --%s", name, other),
+			}
+		}
+	}
+
+	offset := 0
+	if token != "" {
+		var err error
+		if offset, err = decodeContinueToken(resource, token); err != nil {
+			return false, 0, &cmd.ConfigurationError{Err: err}
+		}
+	}
+	return stream, offset, nil
+}
+
+// PrintContinueHint tells the user how to continue a list of resource that stopped at
+// --limit, when the list has more items
+func PrintContinueHint(helper cmd.Helper, resource string, next int) {
+	if next <= 0 {
+		return
+	}
+	fmt.Fprintf(helper.GetStreams().ErrOut, "More %s are available, continue the list with --%s %s\n",
+		resource, ContinueFlagName, EncodeContinueToken(resource, next))
+}
+
+// StreamList prints a list of resource page by page as StreamPages reads it, display
+// returning the table records of a page. Once listed, it prints how to continue a
+// list that stopped at --limit.
+func StreamList[T any](
+	helper cmd.Helper,
+	cfg config.Hook,
+	outType cmdCommon.OutputFormat,
+	resource string,
+	rng PageRange,
+	fetch PageFetcher[T],
+	filter PageFilter[T],
+	display func([]T) any,
+) error {
+	stream, err := tableview.NewStream(helper, outType)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	result, err := StreamPages(cfg, rng, fetch, filter, func(items []T) error {
+		records, err := WithNamespaceColumn(helper, display(items), items)
+		if err != nil {
+			return err
+		}
+		return stream.WritePage(records, items)
+	})
+	if err != nil {
+		return err
+	}
+	if rng.Offset == 0 {
+		WarnIfEmptyInDefaultRegion(helper, cfg, result.Listed)
+	}
+	PrintContinueHint(helper, resource, result.Next)
+	return nil
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamOptions(t *testing.T) {
+	newHelper := func(t *testing.T, args []string, flags ...string) cmd.Helper {
+		t.Helper()
+		command := &cobra.Command{Use: "apis"}
+		AddCountFlags(command)
+		AddIncludeDeletedFlag(command)
+		AddStreamFlags(command)
+		require.NoError(t, command.Flags().Parse(flags))
+		return cmd.BuildHelper(command, args)
+	}
+
+	stream, offset, err := StreamOptions(newHelper(t, nil), "apis")
+	require.NoError(t, err)
+	require.False(t, stream)
+	require.Zero(t, offset)
+
+	token := EncodeContinueToken("apis", 200)
+	stream, offset, err = StreamOptions(newHelper(t, nil, "--stream", "--continue", token), "apis")
+	require.NoError(t, err)
+	require.True(t, stream)
+	require.Equal(t, 200, offset)
+
+	_, _, err = StreamOptions(newHelper(t, nil, "--continue", token), "portals")
+	require.ErrorContains(t, err, "continues a list of apis, not portals")
+
+	_, _, err = StreamOptions(newHelper(t, nil, "--continue", "not-a-token"), "apis")
+	require.ErrorContains(t, err, "invalid --continue token")
+
+	_, _, err = StreamOptions(newHelper(t, []string{"orders"}, "--stream"), "apis")
+	require.ErrorContains(t, err, "only supported when listing")
+
+	_, _, err = StreamOptions(newHelper(t, nil, "--stream", "--count"), "apis")
+	require.ErrorContains(t, err, "--stream cannot be combined with --count")
+
+	_, _, err = StreamOptions(newHelper(t, nil, "--continue", token, "--include-deleted"), "apis")
+	require.ErrorContains(t, err, "--continue cannot be combined with --include-deleted")
+
+	var configErr *cmd.ConfigurationError
+	require.ErrorAs(t, err, &configErr)
+}
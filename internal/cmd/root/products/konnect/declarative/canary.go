@@ -0,0 +1,180 @@
+package declarative
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/canary"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	canaryFlagName        = "canary"
+	canaryPromoteFlagName = "canary-promote"
+	canaryStateFlagName   = "canary-state"
+)
+
+func addCanaryFlags(cmd *cobra.Command) {
+	cmd.Flags().String(canaryFlagName, "",
+		"Path to a canary spec that rolls a plugin config change out to a percentage of its routes and services")
+	cmd.Flags().Bool(canaryPromoteFlagName, false,
+		"Promote a verified canary to every route and service (requires --canary)")
+	cmd.Flags().String(canaryStateFlagName, "",
+		"Path to the canary state file (defaults to canary-state.json in the kongctl config directory)")
+}
+
+// canaryRequested reports whether apply should run a canary step instead of a plan
+func canaryRequested(command *cobra.Command) (bool, error) {
+	specPath, _ := command.Flags().GetString(canaryFlagName)
+	promote, _ := command.Flags().GetBool(canaryPromoteFlagName)
+	if specPath == "" {
+		if promote {
+			return false, fmt.Errorf("--%s requires --%s", canaryPromoteFlagName, canaryFlagName)
+		}
+		return false, nil
+	}
+
+	for _, name := range []string{"plan", "filename", fromOCIFlagName, profilesFlagName} {
+		if command.Flags().Changed(name) {
+			return false, fmt.Errorf("--%s cannot be combined with --%s", canaryFlagName, name)
+		}
+	}
+	return true, nil
+}
+
+// runCanary runs the next step of the canary rollout described by --canary: the canary
+// itself, or with --canary-promote the remaining routes and services
+func runCanary(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	specPath, _ := command.Flags().GetString(canaryFlagName)
+	promote, _ := command.Flags().GetBool(canaryPromoteFlagName)
+	statePath, _ := command.Flags().GetString(canaryStateFlagName)
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	autoApprove, _ := command.Flags().GetBool("auto-approve")
+	outputFormat, _ := command.Flags().GetString("output")
+
+	spec, err := canary.LoadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	if statePath == "" {
+		if statePath, err = canary.DefaultStatePath(); err != nil {
+			return err
+		}
+	}
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+	kkClient, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+	konnectSDK, ok := kkClient.(*helpers.KonnectSDK)
+	if !ok || konnectSDK.SDK == nil {
+		return fmt.Errorf("konnect SDK is not available")
+	}
+
+	controlPlaneID, err := helpers.GetControlPlaneID(ctx, kkClient.GetControlPlaneAPI(), spec.ControlPlane)
+	if err != nil {
+		return fmt.Errorf("failed to find control plane %q: %w", spec.ControlPlane, err)
+	}
+	runner := canary.NewRunner(konnectSDK.SDK.Plugins, canary.NewStore(statePath), controlPlaneID)
+
+	step := "canary"
+	if promote {
+		step = "promotion"
+	}
+	if dryRun || (!autoApprove && outputFormat == textOutputFormat) {
+		preview, err := runner.Preview(ctx, spec)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return outputCanaryState(command, outputFormat, preview, step, true)
+		}
+		printCanarySelection(command.OutOrStderr(), preview, promote)
+		if !confirmCanary(command.InOrStdin(), command.OutOrStderr()) {
+			fmt.Fprintln(command.OutOrStderr(), "Canary cancelled.")
+			return nil
+		}
+	}
+
+	var state *canary.State
+	if promote {
+		state, err = runner.Promote(ctx, spec)
+	} else {
+		state, err = runner.Start(ctx, spec)
+	}
+	if err != nil {
+		return err
+	}
+	return outputCanaryState(command, outputFormat, state, step, false)
+}
+
+func printCanarySelection(out io.Writer, state *canary.State, promote bool) {
+	fmt.Fprintf(out, "Canary %s: %s plugin, %d%% of targets (phase: %s)\n",
+		state.Name, state.Plugin, state.Percentage, state.Phase)
+	for _, target := range state.Targets {
+		if !promote && !target.Canary {
+			continue
+		}
+		status := "pending"
+		if target.Applied {
+			status = "applied"
+		}
+		fmt.Fprintf(out, "  - %s (plugin %s): %s\n", target.Key(), target.PluginID, status)
+	}
+}
+
+func confirmCanary(in io.Reader, out io.Writer) bool {
+	fmt.Fprint(out, "\nDo you want to continue? Type 'yes' to confirm: ")
+	response, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.TrimSpace(strings.ToLower(response)) == "yes"
+}
+
+func outputCanaryState(command *cobra.Command, outputFormat string, state *canary.State, step string,
+	dryRun bool,
+) error {
+	out := command.OutOrStdout()
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(state)
+	case "yaml":
+		data, err := yaml.Marshal(state)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	if dryRun {
+		fmt.Fprintf(out, "Dry run: the %s would change the pending targets below\n", step)
+		printCanarySelection(out, state, step != "canary")
+		return nil
+	}
+
+	_, applied := state.Counts()
+	fmt.Fprintf(out, "Canary %s %s: %d of %d targets applied (phase: %s)\n",
+		state.Name, step, applied, len(state.Targets), state.Phase)
+	if state.Phase == canary.PhaseVerified {
+		fmt.Fprintf(out, "Run apply again with --%s to roll the change out to the remaining targets\n",
+			canaryPromoteFlagName)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/gitscope"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/spf13/cobra"
+)
+
+// changedSinceFlagName is the CLI flag scoping a run to files changed since a git revision
+const changedSinceFlagName = "changed-since"
+
+func addChangedSinceFlag(cmd *cobra.Command) {
+	cmd.Flags().String(changedSinceFlagName, "",
+		`Git revision (e.g. origin/main); only plan resources defined in configuration files
+changed since this revision, plus the resources they depend on. In sync mode only
+resources removed from those files are deleted.`)
+}
+
+// scopeToChangedFiles restricts the plan to configuration files changed since the
+// --changed-since revision. It does nothing when the flag is unset.
+func scopeToChangedFiles(
+	ctx context.Context,
+	command *cobra.Command,
+	ldr *loader.Loader,
+	sources []loader.Source,
+	resourceSet *resources.ResourceSet,
+	opts *planner.Options,
+) error {
+	revision, _ := command.Flags().GetString(changedSinceFlagName)
+	if revision == "" {
+		return nil
+	}
+
+	scope, err := gitscope.New(ctx, revision, sources, resourceSet, ldr.RefSources())
+	if err != nil {
+		return fmt.Errorf("failed to determine configuration changed since %s: %w", revision, err)
+	}
+
+	// Resources removed along with a whole file may live in namespaces no other file uses
+	for _, namespace := range scope.Namespaces {
+		resourceSet.AddDefaultNamespace(namespace)
+	}
+	opts.IncludeChange = scope.Includes
+
+	fmt.Fprintf(command.ErrOrStderr(), "Scoping plan to %d configuration file(s) changed since %s\n",
+		len(scope.Files), revision)
+	return nil
+}
@@ -0,0 +1,81 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// conflictStrategyFlagName is the CLI flag choosing how changes made in Konnect are handled
+	conflictStrategyFlagName = "conflict-strategy"
+	// conflictStrategyConfigPath is the config path backing the conflict-strategy flag
+	conflictStrategyConfigPath = "konnect.declarative." + conflictStrategyFlagName
+)
+
+func addConflictStrategyFlag(cmd *cobra.Command) {
+	cmd.Flags().String(conflictStrategyFlagName, string(drift.ConflictOurs),
+		fmt.Sprintf(`How to handle fields changed in Konnect since the last apply while the configuration
+kept the applied value: ours applies the configuration over them, theirs keeps them and
+fail stops without changing anything.
+- Config path: [ %s ]`, conflictStrategyConfigPath))
+}
+
+// resolveConflicts handles the changes of a generated plan that revert changes made in
+// Konnect since the last apply, using the configured conflict strategy. With ours they
+// are applied as planned and reported, so changes made in Konnect are not reverted silently.
+func resolveConflicts(command *cobra.Command, cfg config.Hook, plan *planner.Plan) error {
+	value, err := resolveFlagOrConfig(command, cfg, conflictStrategyFlagName, conflictStrategyConfigPath)
+	if err != nil {
+		return err
+	}
+	strategy, err := drift.ParseConflictStrategy(value)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", conflictStrategyFlagName, err)
+	}
+
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+	applied, err := drift.NewStore(path).Load(cfg.GetProfile())
+	if err != nil {
+		return err
+	}
+	conflicts, err := drift.ResolveConflicts(plan, applied, strategy)
+	if err != nil || len(conflicts) == 0 {
+		return err
+	}
+
+	reverted := conflicts
+	if strategy == drift.ConflictTheirs {
+		var kept []drift.Difference
+		reverted = nil
+		for _, conflict := range conflicts {
+			if conflict.Field != "" && conflict.Field != drift.ProtectedField {
+				kept = append(kept, conflict)
+			} else {
+				reverted = append(reverted, conflict)
+			}
+		}
+		if len(kept) > 0 {
+			fmt.Fprintf(command.ErrOrStderr(), "Keeping %d field(s) changed in Konnect since the last apply: %s\n",
+				len(kept), strings.Join(drift.DescribeConflicts(kept), ", "))
+		}
+	}
+	if len(reverted) > 0 {
+		hint := "theirs to keep the changed fields or fail"
+		if strategy == drift.ConflictTheirs {
+			hint = "fail"
+		}
+		fmt.Fprintf(command.ErrOrStderr(),
+			"Warning: reverting %d difference(s) made in Konnect since the last apply: %s\n"+
+				"Use --%s %s to stop instead.\n",
+			len(reverted), strings.Join(drift.DescribeConflicts(reverted), ", "), conflictStrategyFlagName, hint)
+	}
+	return nil
+}
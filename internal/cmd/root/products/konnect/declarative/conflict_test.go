@@ -0,0 +1,57 @@
+package declarative
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	utilviper "github.com/kong/kongctl/internal/util/viper"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveConflicts_WarnsOnStderrFromStateDir(t *testing.T) {
+	stateDir := t.TempDir()
+	t.Setenv(config.StateDirEnv, stateDir)
+
+	// The description was applied before and changed in Konnect since
+	applied := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	applied.AddChange(planner.PlannedChange{
+		ID:           "1:c:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"description": "Developer portal"},
+	})
+	store := drift.NewStore(filepath.Join(stateDir, "last-applied.json"))
+	require.NoError(t, store.Record("default", applied, &executor.ExecutionResult{
+		ChangesApplied: []executor.AppliedChange{{ChangeID: "1:c:portal:dev", ResourceID: "portal-1"}},
+	}, time.Now()))
+
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		ResourceID:   "portal-1",
+		Action:       planner.ActionUpdate,
+		Fields:       map[string]any{"description": "Developer portal"},
+	})
+
+	command := &cobra.Command{}
+	addConflictStrategyFlag(command)
+	var stdout, stderr bytes.Buffer
+	command.SetOut(&stdout)
+	command.SetErr(&stderr)
+	cfg := config.BuildProfiledConfig("default", "nonexistent.yaml", utilviper.NewViper("nonexistent.yaml"))
+
+	require.NoError(t, resolveConflicts(command, cfg, plan))
+	assert.Empty(t, stdout.String(), "structured output on stdout must stay parseable")
+	assert.Contains(t, stderr.String(), "Warning: reverting 1 difference(s)")
+}
@@ -3,6 +3,7 @@ package declarative
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,15 +11,21 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kong/kongctl/internal/cmd"
 	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/dump"
 	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/audit"
 	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/deck"
 	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/hooks"
 	"github.com/kong/kongctl/internal/declarative/loader"
 	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/state"
 	"github.com/kong/kongctl/internal/declarative/validator"
@@ -38,6 +45,10 @@ const (
 	planFileKey contextKey = "plan_file"
 	// textOutputFormat is the string constant for text output format
 	textOutputFormat = "text"
+	// ndjsonOutputFormat streams execution events as newline-delimited JSON
+	ndjsonOutputFormat = "ndjson"
+	// sarifOutputFormat is the string constant for SARIF output format
+	sarifOutputFormat = "sarif"
 	// requireNamespaceFlagName is the CLI flag for specific namespace enforcement
 	requireNamespaceFlagName = "require-namespace"
 	// requireNamespaceConfigPath is the config path backing the namespace flag
@@ -50,6 +61,31 @@ const (
 	requireAnyNamespaceFlagName = "require-any-namespace"
 	// requireAnyNamespaceConfigPath is the config path backing the any namespace flag
 	requireAnyNamespaceConfigPath = "konnect.declarative." + requireAnyNamespaceFlagName
+	// writeIDsFlagName is the CLI flag for the ref to ID mapping file
+	writeIDsFlagName = "write-ids"
+	// reportFlagName is the CLI flag for the per-operation apply report file
+	reportFlagName = "report"
+	// defaultLabelFlagName is the CLI flag for labels applied to every managed resource
+	defaultLabelFlagName = "default-label"
+	// defaultLabelConfigPath is the config path backing the default-label flag
+	defaultLabelConfigPath = "konnect.declarative." + defaultLabelFlagName
+	// forceFlagName is the CLI flag executing a saved plan despite a state change
+	forceFlagName = "force"
+	// rollbackOnErrorFlagName is the CLI flag undoing the applied changes when a change fails
+	rollbackOnErrorFlagName = "rollback-on-error"
+	// detailedExitCodeFlagName is the plan flag reporting pending changes in the exit code
+	detailedExitCodeFlagName = "detailed-exitcode"
+	summaryOnlyFlagName      = "summary-only"
+	// planChangesExitCode is the exit code of plan --detailed-exitcode for a plan with changes
+	planChangesExitCode = 2
+	// namespaceFlagName is the CLI flag for the active namespace
+	namespaceFlagName = "namespace"
+	// namespaceConfigPath is the config path backing the namespace flag
+	namespaceConfigPath = "konnect.declarative." + namespaceFlagName
+	// offlineFlagName is the CLI flag rejecting remote !file sources
+	offlineFlagName = "offline"
+	// offlineConfigPath is the config path backing the offline flag
+	offlineConfigPath = "konnect.declarative." + offlineFlagName
 )
 
 func addBaseDirFlag(cmd *cobra.Command) {
@@ -59,6 +95,75 @@ Defaults to each -f source root (file: its parent dir, dir: the directory itself
 - Config path: [ %s ]`, baseDirConfigPath))
 }
 
+func addOfflineFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(offlineFlagName, false,
+		fmt.Sprintf(`Fail when a !file tag loads an http:// or https:// URL, for reproducible builds.
+- Config path: [ %s ]`, offlineConfigPath))
+}
+
+// resolveOffline returns the offline flag, or the config file value when unset
+func resolveOffline(command *cobra.Command, cfg config.Hook) bool {
+	if command.Flags().Changed(offlineFlagName) {
+		offline, _ := command.Flags().GetBool(offlineFlagName)
+		return offline
+	}
+	return cfg != nil && cfg.GetBool(offlineConfigPath)
+}
+
+func addNamespaceFlag(cmd *cobra.Command) {
+	cmd.Flags().String(namespaceFlagName, "",
+		fmt.Sprintf(`Active namespace. Resources without a namespace are placed in it, resources of other
+namespaces are rejected, and sync only deletes resources of this namespace.
+- Config path: [ %s ]`, namespaceConfigPath))
+}
+
+// resolveNamespace returns the active namespace from the flag, or the config file when unset
+func resolveNamespace(command *cobra.Command, cfg config.Hook) (string, error) {
+	namespace, err := resolveFlagOrConfig(command, cfg, namespaceFlagName, namespaceConfigPath)
+	if err != nil {
+		return "", err
+	}
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return "", nil
+	}
+	if err := validator.NewNamespaceValidator().ValidateNamespace(namespace); err != nil {
+		return "", &cmd.ConfigurationError{Err: fmt.Errorf("invalid --%s: %w", namespaceFlagName, err)}
+	}
+	return namespace, nil
+}
+
+func addDefaultLabelFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(defaultLabelFlagName, nil,
+		fmt.Sprintf(`Label (key=value) added to every managed resource that supports labels (can specify multiple).
+Labels set on a resource or in _defaults.labels take precedence.
+- Config path: [ %s ]`, defaultLabelConfigPath))
+}
+
+// resolveDefaultLabels returns the default labels from the flag, or the config file when unset
+func resolveDefaultLabels(command *cobra.Command, cfg config.Hook) (map[string]string, error) {
+	if command.Flags().Lookup(defaultLabelFlagName) == nil {
+		return nil, nil
+	}
+	var entries []string
+	if command.Flags().Changed(defaultLabelFlagName) {
+		entries, _ = command.Flags().GetStringSlice(defaultLabelFlagName)
+	} else if cfg != nil {
+		entries = cfg.GetStringSlice(defaultLabelConfigPath)
+	}
+
+	defaults := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --%s %q: expected key=value", defaultLabelFlagName, entry)
+		}
+		defaults[key] = strings.TrimSpace(value)
+	}
+	return defaults, nil
+}
+
 func addRequireNamespaceFlags(cmd *cobra.Command) {
 	// Add require-any-namespace flag (bool)
 	cmd.Flags().Bool(requireAnyNamespaceFlagName, false,
@@ -138,14 +243,33 @@ func newDeclarativeLoader(command *cobra.Command, cfg config.Hook) (*loader.Load
 	if err != nil {
 		return nil, err
 	}
-	if baseDir == "" {
-		return loader.New(), nil
+	defaultLabels, err := resolveDefaultLabels(command, cfg)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := resolveVariables(command, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ldr := loader.New()
+	if baseDir != "" {
+		baseDir, err = normalizeBaseDir(baseDir)
+		if err != nil {
+			return nil, err
+		}
+		ldr = loader.NewWithBaseDir(baseDir)
 	}
-	baseDir, err = normalizeBaseDir(baseDir)
+	ldr.SetDefaultLabels(defaultLabels)
+	ldr.SetVariables(variables)
+	ldr.SetOffline(resolveOffline(command, cfg))
+	namespace, err := resolveNamespace(command, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return loader.NewWithBaseDir(baseDir), nil
+	ldr.SetNamespace(namespace)
+	scopeLoaderToRegion(command, cfg, ldr)
+	return ldr, nil
 }
 
 func parseNamespaceRequirement(
@@ -259,6 +383,21 @@ func NewDeclarativeCmd(verb verbs.VerbValue) (*cobra.Command, error) {
 	if verb == verbs.Delete {
 		return newDeclarativeDeleteCmd(), nil
 	}
+	if verb == verbs.Validate {
+		return newDeclarativeValidateCmd(), nil
+	}
+	if verb == verbs.Drift {
+		return newDeclarativeDriftCmd(), nil
+	}
+	if verb == verbs.Import {
+		return newDeclarativeImportCmd(), nil
+	}
+	if verb == verbs.State {
+		return newDeclarativeStateListCmd(), nil
+	}
+	if verb == verbs.Doctor {
+		return newDeclarativeDoctorCmd(), nil
+	}
 
 	// Unsupported verbs
 	return nil, fmt.Errorf("verb %s does not support declarative configuration", verb)
@@ -272,7 +411,9 @@ func newDeclarativePlanCmd() *cobra.Command {
 
 The plan artifact represents the desired state of Konnect resources and can be used
 for review, approval workflows, or as input to sync operations.`,
-		RunE: runPlan,
+		RunE: withRecording("plan", func(command *cobra.Command, args []string) error {
+			return runPerRegion(command, args, runPlan)
+		}),
 	}
 
 	// Add declarative config flags
@@ -281,8 +422,38 @@ for review, approval workflows, or as input to sync operations.`,
 	cmd.Flags().BoolP("recursive", "R", false,
 		"Process the directory used in -f, --filename recursively")
 	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addRiskPolicyFlag(cmd)
+	addPlanPolicyFlag(cmd)
+	addResourceLimitFlag(cmd)
+	addPolicyFileFlag(cmd)
+	addLintRulesetFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addIgnoreResourceFlag(cmd)
+	addOTelEndpointFlag(cmd)
+	addChangedSinceFlag(cmd)
+	addIgnoreFieldFlag(cmd)
+	addMatchByNameFlag(cmd)
+	addConfirmDeleteProtectedFlag(cmd, false)
+	addConflictStrategyFlag(cmd)
+	addExplainFlag(cmd)
+	addSimulateFlag(cmd, "Generate the plan against an in-memory simulator of Konnect.")
+	addRecordFlag(cmd)
+	addTargetFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+	addParallelismFlag(cmd)
+	addSignFlags(cmd)
 	cmd.Flags().String("output-file", "", "Save plan artifact to file")
 	cmd.Flags().String("mode", "sync", "Plan generation mode (sync|apply|delete)")
+	cmd.Flags().Bool(detailedExitCodeFlagName, false,
+		fmt.Sprintf("Exit with %d when the plan has changes, 0 when it has none and 1 on errors",
+			planChangesExitCode))
+	cmd.Flags().Bool(summaryOnlyFlagName, false,
+		"Output only the plan summary and the resource, ref and action of each change. "+
+			"The full plan is still saved to --output-file")
+	addDependencyGraphFlags(cmd)
 	addRequireNamespaceFlags(cmd)
 
 	return cmd
@@ -297,6 +468,7 @@ func runPlan(command *cobra.Command, args []string) error {
 	recursive, _ := command.Flags().GetBool("recursive")
 	mode, _ := command.Flags().GetString("mode")
 	outputFile, _ := command.Flags().GetString("output-file")
+	outputFile = regionalPlanFile(command, outputFile)
 
 	// Validate mode
 	var planMode planner.PlanMode
@@ -320,12 +492,27 @@ func runPlan(command *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
 	// Get logger
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
 	}
 
+	if showGraph, _ := command.Flags().GetBool(showDependencyGraphFlagName); showGraph {
+		return runDependencyGraph(command, cfg, filenames, recursive)
+	}
+
+	// Simulated plans are generated against an empty organization
+	if startSimulation(command) != nil {
+		fmt.Fprintln(command.ErrOrStderr(), "Planning against the Konnect simulator, every resource is new.")
+	}
+
 	// Get Konnect SDK
 	kkClient, err := helper.GetKonnectSDK(cfg, logger)
 	if err != nil {
@@ -348,10 +535,10 @@ func runPlan(command *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	resourceSet, err := ldr.LoadFromSources(sources, recursive)
+	resourceSet, err := ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
 	if err != nil {
 		// Provide more helpful error message for common cases
-		if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML files found") {
+		if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML or JSON files found") {
 			return fmt.Errorf(
 				"no configuration files found in current directory. Use -f to specify files or directories",
 			)
@@ -365,6 +552,12 @@ func runPlan(command *cobra.Command, args []string) error {
 	if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
 		return err
 	}
+	if err := checkPolicy(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+		return err
+	}
+	if err := checkLint(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+		return err
+	}
 
 	if totalResources == 0 {
 		// Check if we're using default directory (no explicit sources)
@@ -447,26 +640,79 @@ func runPlan(command *cobra.Command, args []string) error {
 		}
 	}
 
-	deckOpts, err := deckPlanOptions(resourceSet, cfg, logger)
+	deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	parallelism, err := resolveParallelism(command, cfg)
 	if err != nil {
 		return err
 	}
 
 	// Generate plan
 	opts := planner.Options{
-		Mode:      planMode,
-		Generator: generator,
-		Deck:      deckOpts,
+		Mode:        planMode,
+		Generator:   generator,
+		Deck:        deckOpts,
+		Parallelism: parallelism,
+	}
+	if err := ignoreFields(command, cfg, &opts); err != nil {
+		return err
+	}
+	matchByName(command, cfg, &opts)
+	if err := movedResources(cfg, resourceSet, &opts); err != nil {
+		return err
+	}
+	confirmProtectedDeletes(command, &opts)
+	if err := scopeToChangedFiles(ctx, command, ldr, sources, resourceSet, &opts); err != nil {
+		return err
+	}
+	if err := scopeToTargets(command, resourceSet, &opts); err != nil {
+		return err
 	}
 	plan, err := p.GeneratePlan(ctx, resourceSet, opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate plan: %w", err)
 	}
+	if err := countPlanResources(ctx, command, cfg, stateClient, logger, plan); err != nil {
+		return err
+	}
+	recordPlanRegion(command, plan)
+	if err := resolveConflicts(command, cfg, plan); err != nil {
+		return err
+	}
+	if err := explainPlan(ctx, command, cfg, stateClient, plan); err != nil {
+		return err
+	}
 
 	if err := normalizeDeckBaseDirs(plan, outputFile); err != nil {
 		return err
 	}
 
+	if err := applyRiskPolicy(command, cfg, plan); err != nil {
+		return err
+	}
+	if err := checkPlanPolicy(command, cfg, plan, command.ErrOrStderr()); err != nil {
+		return err
+	}
+
+	if err := recordSensitiveFields(command, cfg, plan); err != nil {
+		return err
+	}
+
+	if err := recordIgnoredResources(command, cfg, plan); err != nil {
+		return err
+	}
+
+	if err := plan.Stamp(ctx, stateClient); err != nil {
+		return fmt.Errorf("failed to record plan state: %w", err)
+	}
+
+	if err := signPlan(ctx, command, cfg, kkClient, plan); err != nil {
+		return err
+	}
+
 	// Marshal plan to JSON
 	planJSON, err := json.MarshalIndent(plan, "", "  ")
 	if err != nil {
@@ -479,11 +725,22 @@ func runPlan(command *cobra.Command, args []string) error {
 		if err := os.WriteFile(outputFile, planJSON, 0o600); err != nil {
 			return fmt.Errorf("failed to write plan file: %w", err)
 		}
-	} else {
+	}
+	if summaryOnly, _ := command.Flags().GetBool(summaryOnlyFlagName); summaryOnly {
+		summaryJSON, err := json.MarshalIndent(plan.Compact(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal plan summary: %w", err)
+		}
+		fmt.Fprintln(command.OutOrStdout(), string(summaryJSON))
+	} else if outputFile == "" {
 		// Output to stdout
 		fmt.Fprintln(command.OutOrStdout(), string(planJSON))
 	}
 
+	if detailed, _ := command.Flags().GetBool(detailedExitCodeFlagName); detailed && !plan.IsEmpty() {
+		return cmd.PrepareExitCodeError(helper, planChangesExitCode,
+			fmt.Sprintf("plan has %d change(s)", len(plan.Changes)))
+	}
 	return nil
 }
 
@@ -538,6 +795,7 @@ func normalizeDeckBaseDirs(plan *planner.Plan, outputFile string) error {
 }
 
 func deckPlanOptions(
+	command *cobra.Command,
 	resourceSet *resources.ResourceSet,
 	cfg config.Hook,
 	logger *slog.Logger,
@@ -545,6 +803,10 @@ func deckPlanOptions(
 	if !resourceSetHasDeckConfig(resourceSet) {
 		return planner.DeckOptions{}, nil
 	}
+	// Simulations never run deck against Konnect
+	if simulatorOf(command) != nil {
+		return planner.DeckOptions{Runner: deck.NewDryRunRunner()}, nil
+	}
 
 	token, err := konnectcommon.GetAccessToken(cfg, logger)
 	if err != nil {
@@ -574,6 +836,47 @@ func resourceSetHasDeckConfig(resourceSet *resources.ResourceSet) bool {
 	return false
 }
 
+// addForceFlag adds the flag applying a saved plan whose state hash no longer matches
+func addForceFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(forceFlagName, false,
+		"Execute a saved plan even when Konnect state changed since the plan was generated")
+}
+
+// addRollbackOnErrorFlag adds the flag undoing the changes of a run that failed midway
+func addRollbackOnErrorFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(rollbackOnErrorFlagName, false,
+		`When a change fails or the run is interrupted, undo the changes applied so far:
+created resources are deleted and updated fields restored. Best effort; deleted
+resources are not restored and the changes not undone are reported.`)
+}
+
+// checkSavedPlanState refuses a saved plan computed against a different live state,
+// unless forced
+func checkSavedPlanState(command *cobra.Command, stateClient *state.Client, plan *planner.Plan) error {
+	if force, _ := command.Flags().GetBool(forceFlagName); force {
+		return nil
+	}
+	if err := plan.CheckState(command.Context(), stateClient); err != nil {
+		if errors.Is(err, planner.ErrStalePlan) {
+			return fmt.Errorf("%w; generate a new plan or pass --%s to execute it anyway", err, forceFlagName)
+		}
+		return fmt.Errorf("failed to verify plan state: %w", err)
+	}
+	return nil
+}
+
+// requireTerminalInput fails when the confirmation prompt would read from a stdin
+// that is not a terminal, e.g. in CI, where waiting for an answer would hang.
+// Readers set on the command other than files, as in tests, are allowed.
+func requireTerminalInput(command *cobra.Command) error {
+	file, ok := command.InOrStdin().(*os.File)
+	if !ok || diffTerminalDetector(file.Fd()) {
+		return nil
+	}
+	return fmt.Errorf("cannot prompt for confirmation: stdin is not a terminal. " +
+		"Use --auto-approve to skip confirmation in non-interactive environments")
+}
+
 func resolvePlanBaseDir(planFile string) string {
 	planFile = strings.TrimSpace(planFile)
 	if planFile == "" {
@@ -605,6 +908,12 @@ func runDiff(command *cobra.Command, args []string) error {
 		return err
 	}
 
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
+
 	nsValidator, requirement, err := resolveNamespaceRequirement(command, cfg)
 	if err != nil {
 		return err
@@ -617,6 +926,20 @@ func runDiff(command *cobra.Command, args []string) error {
 			requireNamespaceFlagName,
 		)
 	}
+	if planFile != "" && command.Flags().Changed(namespaceFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; the plan file records its namespaces", namespaceFlagName)
+	}
+	if planFile != "" && command.Flags().Changed(targetFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; target resources when generating the plan",
+			targetFlagName)
+	}
+	if planFile != "" && command.Flags().Changed(diffModeFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; the plan file records its mode", diffModeFlagName)
+	}
+	planMode, err := diffPlanMode(command)
+	if err != nil {
+		return err
+	}
 
 	var plan *planner.Plan
 
@@ -649,9 +972,9 @@ func runDiff(command *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		resourceSet, err := ldr.LoadFromSources(sources, recursive)
+		resourceSet, err := ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
 		if err != nil {
-			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML files found") {
+			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML or JSON files found") {
 				return fmt.Errorf("no configuration files found. Use -f to specify files or --plan to use existing plan")
 			}
 			return fmt.Errorf("failed to load configuration: %w", err)
@@ -660,6 +983,12 @@ func runDiff(command *cobra.Command, args []string) error {
 		if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
 			return err
 		}
+		if err := checkPolicy(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+			return err
+		}
+		if err := checkLint(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+			return err
+		}
 
 		totalResources := resourceSet.ResourceCount()
 		if totalResources == 0 {
@@ -671,19 +1000,62 @@ func runDiff(command *cobra.Command, args []string) error {
 
 		stateClient := createStateClient(kkClient)
 		p := planner.NewPlanner(stateClient, logger)
-		deckOpts, err := deckPlanOptions(resourceSet, cfg, logger)
+		deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
+		if err != nil {
+			return err
+		}
+		parallelism, err := resolveParallelism(command, cfg)
 		if err != nil {
 			return err
 		}
 		opts := planner.Options{
-			Mode:      planner.PlanModeSync,
-			Generator: generator,
-			Deck:      deckOpts,
+			Mode:        planMode,
+			Generator:   generator,
+			Deck:        deckOpts,
+			Parallelism: parallelism,
+		}
+		if err := ignoreFields(command, cfg, &opts); err != nil {
+			return err
+		}
+		matchByName(command, cfg, &opts)
+		if err := movedResources(cfg, resourceSet, &opts); err != nil {
+			return err
+		}
+		if err := scopeToChangedFiles(ctx, command, ldr, sources, resourceSet, &opts); err != nil {
+			return err
+		}
+		if err := scopeToTargets(command, resourceSet, &opts); err != nil {
+			return err
 		}
 		plan, err = p.GeneratePlan(ctx, resourceSet, opts)
 		if err != nil {
 			return fmt.Errorf("failed to generate plan: %w", err)
 		}
+		if err := countPlanResources(ctx, command, cfg, stateClient, logger, plan); err != nil {
+			return err
+		}
+		if err := resolveConflicts(command, cfg, plan); err != nil {
+			return err
+		}
+		if err := explainPlan(ctx, command, cfg, stateClient, plan); err != nil {
+			return err
+		}
+	}
+
+	if err := applyRiskPolicy(command, cfg, plan); err != nil {
+		return err
+	}
+	if err := checkPlanPolicy(command, cfg, plan, command.ErrOrStderr()); err != nil {
+		return err
+	}
+
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	plan = redactor.Plan(plan)
+	if plan, err = ignorePlanResources(command, cfg, plan); err != nil {
+		return err
 	}
 
 	// Display diff based on output format
@@ -695,7 +1067,9 @@ func runDiff(command *cobra.Command, args []string) error {
 		// JSON output
 		encoder := json.NewEncoder(command.OutOrStdout())
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(plan)
+		if err := encoder.Encode(plan); err != nil {
+			return err
+		}
 
 	case "yaml":
 		// YAML output
@@ -704,22 +1078,29 @@ func runDiff(command *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to marshal plan to YAML: %w", err)
 		}
 		fmt.Fprintln(command.OutOrStdout(), string(yamlData))
-		return nil
 
 	case textOutputFormat:
 		// Human-readable text output
-		return displayTextDiff(command, plan, fullContent)
+		if err := displayTextDiff(command, plan, fullContent, newDiffColors(command, cfg)); err != nil {
+			return err
+		}
 
 	default:
 		return fmt.Errorf("unsupported output format: %s (use text, json, or yaml)", outputFormat)
 	}
+
+	if exitCode, _ := command.Flags().GetBool(diffExitCodeFlagName); exitCode && !plan.IsEmpty() {
+		return cmd.PrepareExecutionErrorMsg(helper,
+			fmt.Sprintf("changes detected: %d planned change(s)", len(plan.Changes)))
+	}
+	return nil
 }
 
-func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent bool) error {
+func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent bool, colors diffColors) error {
 	out := command.OutOrStdout()
 
 	// Handle empty plan
-	if plan.IsEmpty() {
+	if plan.IsEmpty() && plan.Summary.IgnoredChanges == 0 {
 		fmt.Fprintln(out, "No changes detected. Konnect is up to date.")
 		return nil
 	}
@@ -729,6 +1110,7 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 	updateCount := plan.Summary.ByAction[planner.ActionUpdate]
 	deleteCount := plan.Summary.ByAction[planner.ActionDelete]
 	externalToolCount := plan.Summary.ByAction[planner.ActionExternalTool]
+	switchCount := plan.Summary.ByAction[planner.ActionSwitch]
 
 	summaryParts := []string{
 		fmt.Sprintf("%d to add", createCount),
@@ -740,7 +1122,17 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 	if externalToolCount > 0 {
 		summaryParts = append(summaryParts, fmt.Sprintf("%d external tool step", externalToolCount))
 	}
-	fmt.Fprintf(out, "Plan: %s\n\n", strings.Join(summaryParts, ", "))
+	if switchCount > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("%d to switch", switchCount))
+	}
+	fmt.Fprintf(out, "Plan: %s\n", strings.Join(summaryParts, ", "))
+	if plan.Summary.Risk != nil {
+		fmt.Fprintf(out, "Risk: %s (score %d)\n", plan.Summary.Risk.Level, plan.Summary.Risk.Score)
+	}
+	if plan.Summary.IgnoredChanges > 0 {
+		fmt.Fprintf(out, "Ignored: %d change(s) not shown\n", plan.Summary.IgnoredChanges)
+	}
+	fmt.Fprintln(out)
 
 	// Display warnings if any
 	if len(plan.Warnings) > 0 {
@@ -796,12 +1188,12 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 
 			switch change.Action {
 			case planner.ActionCreate:
-				fmt.Fprintf(out, "+ [%s] %s %q will be created\n",
-					change.ID, change.ResourceType, change.ResourceRef)
+				fmt.Fprintln(out, colors.action(change.Action, fmt.Sprintf("+ [%s] %s %q will be created",
+					change.ID, change.ResourceType, change.ResourceRef)))
 
 				// Show key fields
-				for field, value := range change.Fields {
-					displayField(out, field, value, "  ", fullContent)
+				for _, field := range sortedFieldNames(change.Fields) {
+					displayField(out, field, change.Fields[field], "  ", fullContent)
 				}
 
 				// Show protection status
@@ -814,8 +1206,11 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 				}
 
 			case planner.ActionUpdate:
-				fmt.Fprintf(out, "~ [%s] %s %q will be updated\n",
-					change.ID, change.ResourceType, change.ResourceRef)
+				fmt.Fprintln(out, colors.action(change.Action, fmt.Sprintf("~ [%s] %s %q will be updated",
+					change.ID, change.ResourceType, change.ResourceRef)))
+				if change.MovedFrom != "" {
+					fmt.Fprintf(out, "  moved from: %s\n", change.MovedFrom)
+				}
 
 				// Check if this is a protection change
 				if pc, ok := change.Protection.(planner.ProtectionChange); ok {
@@ -833,14 +1228,15 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 				}
 
 				// Show field changes
-				for field, value := range change.Fields {
+				for _, field := range sortedFieldNames(change.Fields) {
+					value := change.Fields[field]
 					if fc, ok := value.(planner.FieldChange); ok {
-						fmt.Fprintf(out, "  %s: %v → %v\n", field, fc.Old, fc.New)
+						fmt.Fprintf(out, "  %s: %s\n", field, colors.change(fc.Old, fc.New))
 					} else if fc, ok := value.(map[string]any); ok {
 						// Handle FieldChange that was unmarshaled from JSON
 						if oldVal, hasOld := fc["old"]; hasOld {
 							if newVal, hasNew := fc["new"]; hasNew {
-								fmt.Fprintf(out, "  %s: %v → %v\n", field, oldVal, newVal)
+								fmt.Fprintf(out, "  %s: %s\n", field, colors.change(oldVal, newVal))
 								continue
 							}
 						}
@@ -850,20 +1246,33 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 						displayField(out, field, value, "  ", fullContent)
 					}
 				}
+				renderExplanation(out, *change, "  ", colors)
 
 			case planner.ActionDelete:
-				// DELETE action (future implementation)
-				fmt.Fprintf(out, "- [%s] %s %q will be deleted\n",
-					change.ID, change.ResourceType, change.ResourceRef)
+				fmt.Fprintln(out, colors.action(change.Action, fmt.Sprintf("- [%s] %s %q will be deleted",
+					change.ID, change.ResourceType, change.ResourceRef)))
 			case planner.ActionExternalTool:
-				fmt.Fprintf(out, "> [%s] %s %q will run external tool steps\n",
-					change.ID, change.ResourceType, change.ResourceRef)
+				fmt.Fprintln(out, colors.action(change.Action, fmt.Sprintf("> [%s] %s %q will run external tool steps",
+					change.ID, change.ResourceType, change.ResourceRef)))
 
-				for field, value := range change.Fields {
-					displayField(out, field, value, "  ", fullContent)
+				for _, field := range sortedFieldNames(change.Fields) {
+					displayField(out, field, change.Fields[field], "  ", fullContent)
+				}
+			case planner.ActionSwitch:
+				fmt.Fprintln(out, colors.action(change.Action, fmt.Sprintf(
+					"^ [%s] %s %q will be staged as %v and switched to %v",
+					change.ID, change.ResourceType, change.ResourceRef,
+					change.Fields["stage_visibility"], change.Fields["visibility"])))
+
+				for _, field := range sortedFieldNames(change.Fields) {
+					displayField(out, field, change.Fields[field], "  ", fullContent)
 				}
 			}
 
+			if change.Risk != nil && change.Risk.Level != planner.RiskLevelNone {
+				fmt.Fprintf(out, "  risk: %s (%s)\n", change.Risk.Level, strings.Join(change.Risk.Reasons, ", "))
+			}
+
 			// Show dependencies
 			if len(change.DependsOn) > 0 {
 				fmt.Fprintf(out, "  depends on: %v\n", change.DependsOn)
@@ -908,7 +1317,9 @@ func displayTextDiff(command *cobra.Command, plan *planner.Plan, fullContent boo
 func displayField(out io.Writer, field string, value any, indent string, fullContent bool) {
 	switch v := value.(type) {
 	case string:
-		if v != "" {
+		if v == planner.SpecContentUnchanged {
+			fmt.Fprintf(out, "%s%s: %s\n", indent, field, v)
+		} else if v != "" {
 			// Check if string is large and should be summarized
 			const maxDisplayLength = 500
 			if !fullContent && len(v) > maxDisplayLength {
@@ -957,7 +1368,9 @@ func newDeclarativeSyncCmd() *cobra.Command {
 Sync analyzes the current state of Konnect resources, compares it with the desired
 state defined in the configuration files, and applies the necessary changes to
 achieve the desired state.`,
-		RunE: runSync,
+		RunE: func(command *cobra.Command, args []string) error {
+			return runPerRegion(command, args, runSync)
+		},
 	}
 
 	// Add declarative config flags (matching apply command pattern)
@@ -966,11 +1379,40 @@ achieve the desired state.`,
 	cmd.Flags().BoolP("recursive", "R", false,
 		"Process the directory used in -f, --filename recursively")
 	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addRiskPolicyFlag(cmd)
+	addPlanPolicyFlag(cmd)
+	addResourceLimitFlag(cmd)
+	addPolicyFileFlag(cmd)
+	addLintRulesetFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addOTelEndpointFlag(cmd)
+	addChangedSinceFlag(cmd)
+	addIgnoreFieldFlag(cmd)
+	addMatchByNameFlag(cmd)
+	addConflictStrategyFlag(cmd)
+	addTargetFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+	addNoHooksFlag(cmd)
 	cmd.Flags().String("plan", "", "Path to existing plan file")
+	addForceFlag(cmd)
+	addVerifyFlags(cmd)
 	cmd.Flags().Bool("dry-run", false, "Preview changes without applying them")
 	cmd.Flags().Bool("auto-approve", false, "Skip confirmation prompt")
-	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text|json|yaml)")
+	addLockFlags(cmd)
+	addTimeoutFlags(cmd)
+	addParallelismFlag(cmd)
+	addProgressFlag(cmd)
+	addRollbackOnErrorFlag(cmd)
+	addAutoApproveMaxRiskFlag(cmd)
+	cmd.Flags().StringP("output", "o", textOutputFormat,
+		"Output format (text|json|yaml|ndjson). ndjson streams a JSON event per line as changes run")
 	cmd.Flags().String("execution-report-file", "", "Save execution report as JSON to file")
+	cmd.Flags().String(writeIDsFlagName, "", "Merge the ref to Konnect ID mapping of applied changes into a JSON file")
+	cmd.Flags().String(reportFlagName, "",
+		"Write a JSON report of every operation with its status, error, resource ID and timing to file")
 	addRequireNamespaceFlags(cmd)
 
 	return cmd
@@ -985,7 +1427,9 @@ defined in declarative configuration files and display the differences.
 
 The diff output shows what changes would be made without actually applying them,
 useful for reviewing changes before synchronization.`,
-		RunE: runDiff,
+		RunE: func(command *cobra.Command, args []string) error {
+			return runPerRegion(command, args, runDiff)
+		},
 	}
 
 	// Add declarative config flags
@@ -994,9 +1438,30 @@ useful for reviewing changes before synchronization.`,
 	cmd.Flags().BoolP("recursive", "R", false,
 		"Process the directory used in -f, --filename recursively")
 	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addRiskPolicyFlag(cmd)
+	addPlanPolicyFlag(cmd)
+	addResourceLimitFlag(cmd)
+	addPolicyFileFlag(cmd)
+	addLintRulesetFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addIgnoreResourceFlag(cmd)
+	addSummarizeIgnoredFlag(cmd)
+	addOTelEndpointFlag(cmd)
+	addChangedSinceFlag(cmd)
+	addIgnoreFieldFlag(cmd)
+	addMatchByNameFlag(cmd)
+	addConflictStrategyFlag(cmd)
+	addExplainFlag(cmd)
+	addTargetFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+	addParallelismFlag(cmd)
 	cmd.Flags().String("plan", "", "Path to existing plan file to display")
 	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text, json, or yaml)")
 	cmd.Flags().Bool("full-content", false, "Display full content for large fields instead of summary")
+	addDiffFlags(cmd)
 	addRequireNamespaceFlags(cmd)
 
 	return cmd
@@ -1006,33 +1471,78 @@ func newDeclarativeExportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "konnect",
 		Short: "Export current Konnect state as declarative configuration",
-		Long: `Export the current state of Konnect resources as declarative configuration files.
+		Long: `Export the current state of Konnect resources as declarative configuration.
+
+This command retrieves portals, APIs, with the versions and publications of
+each API, and application auth strategies from Konnect and writes them as a
+declarative configuration file.
+Refs are derived from resource names and server-managed fields such as IDs
+are omitted, so applying the file to the same organization plans no changes.
+
+With --format terraform-import, Terraform import blocks are written instead, or
+terraform import commands with --format terraform-import-commands, mapping the
+IDs of the resources kongctl manages to terraform-provider-konnect addresses
+named after their refs.`,
+		RunE: runExport,
+	}
+
+	cmd.Flags().StringP("output", "o", "", "File to write the configuration to (default stdout)")
+	cmd.Flags().String("format", dump.ExportFormatDeclarative,
+		fmt.Sprintf(`Format to export (%s).
+The terraform formats map the kongctl managed resources to terraform-provider-konnect addresses.`,
+			strings.Join(dump.ExportFormats, ", ")))
+	cmd.Flags().String("spec-dir", "",
+		`Directory to write API version specs to, loaded by the configuration with !file.
+Must be inside the directory of the output file. Specs are inlined when unset.`)
+	cmd.Flags().String("resources", "",
+		fmt.Sprintf("Comma-separated list of resource types to export (%s; default all)",
+			strings.Join(dump.ExportResourceTypes, ", ")))
+	cmd.Flags().Int(
+		konnectcommon.RequestPageSizeFlagName,
+		konnectcommon.DefaultRequestPageSize,
+		fmt.Sprintf(`Max number of results to include per response page.
+- Config path: [ %s ]`, konnectcommon.RequestPageSizeConfigPath))
 
-This command retrieves the current configuration from Konnect and generates
-declarative configuration files that can be version controlled, modified,
-and applied to other environments.`,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return fmt.Errorf("export command not yet implemented")
-		},
-	}
+	return cmd
+}
 
-	// Add declarative config flags
-	cmd.Flags().StringP("output", "o", "./exported-config", "Directory to export configuration files")
-	cmd.Flags().String("resources", "", "Comma-separated list of resource types to export")
+func runExport(command *cobra.Command, args []string) error {
+	command.SilenceUsage = true
 
-	return cmd
+	outputFile, _ := command.Flags().GetString("output")
+	specDir, _ := command.Flags().GetString("spec-dir")
+	resourcesFlag, _ := command.Flags().GetString("resources")
+	resourceTypes, err := dump.ParseExportResources(resourcesFlag)
+	if err != nil {
+		return err
+	}
+	formatFlag, _ := command.Flags().GetString("format")
+	format, err := dump.ParseExportFormat(formatFlag)
+	if err != nil {
+		return err
+	}
+	if specDir != "" && format != dump.ExportFormatDeclarative {
+		return fmt.Errorf("--spec-dir is only supported with --format %s", dump.ExportFormatDeclarative)
+	}
+
+	return dump.RunDeclarativeExport(cmd.BuildHelper(command, args), dump.ExportOptions{
+		Resources:  resourceTypes,
+		OutputFile: outputFile,
+		SpecDir:    specDir,
+		Format:     format,
+	})
 }
 
 func runApply(command *cobra.Command, args []string) error {
 	// Silence usage for all runtime errors (command syntax is already valid at this point)
 	command.SilenceUsage = true
 
-	ctx := command.Context()
-	planFile, _ := command.Flags().GetString("plan")
+	// A simulation executes like a dry run, against the simulator instead of Konnect
+	simulator := startSimulation(command)
 	dryRun, _ := command.Flags().GetBool("dry-run")
+	dryRun = dryRun || simulator != nil
 	autoApprove, _ := command.Flags().GetBool("auto-approve")
 	outputFormat, _ := command.Flags().GetString("output")
-	filenames, _ := command.Flags().GetStringSlice("filename")
 
 	// Early check for non-text output without auto-approve
 	if !dryRun && !autoApprove && outputFormat != textOutputFormat {
@@ -1040,10 +1550,59 @@ func runApply(command *cobra.Command, args []string) error {
 			"(interactive confirmation not available with structured output)", outputFormat)
 	}
 
+	if _, err := interactiveRequested(command); err != nil {
+		return err
+	}
+	if watch, err := watchRequested(command); err != nil {
+		return err
+	} else if watch {
+		if simulator != nil {
+			return simulationConflict(watchFlagName)
+		}
+		return runWatch(command, args)
+	}
+	if useCanary, err := canaryRequested(command); err != nil {
+		return err
+	} else if useCanary {
+		if simulator != nil {
+			return simulationConflict(canaryFlagName)
+		}
+		return runCanary(command, args)
+	}
+	if useProfiles, err := profilesRequested(command); err != nil {
+		return err
+	} else if useProfiles {
+		if simulator != nil {
+			return simulationConflict(profilesFlagName)
+		}
+		return runProfilesApply(command, args)
+	}
+	return runPerRegion(command, args, applyOnce)
+}
+
+// applyOnce plans and applies the configuration, or applies the --plan artifact
+func applyOnce(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	planFile, _ := command.Flags().GetString("plan")
+	simulator := simulatorOf(command)
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	dryRun = dryRun || simulator != nil
+	autoApprove, _ := command.Flags().GetBool("auto-approve")
+	outputFormat, _ := command.Flags().GetString("output")
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	interactive, _ := command.Flags().GetBool(interactiveFlagName)
+
+	fromOCI, _ := command.Flags().GetString(fromOCIFlagName)
+	if fromOCI != "" {
+		if err := checkFromOCIFlags(command); err != nil {
+			return err
+		}
+	}
+
 	// Early check for stdin usage without auto-approve
 	// Only fail if we can't access /dev/tty for interactive input
 	var usingStdinForInput bool
-	if !dryRun && !autoApprove {
+	if interactive || (!dryRun && !autoApprove) {
 		// Check if stdin will be used for plan or configuration
 		if planFile == "-" {
 			usingStdinForInput = true
@@ -1066,6 +1625,8 @@ func runApply(command *cobra.Command, args []string) error {
 					"Use --auto-approve to skip confirmation when piping commands")
 			}
 			tty.Close()
+		} else if err := requireTerminalInput(command); err != nil {
+			return err
 		}
 	}
 
@@ -1078,6 +1639,20 @@ func runApply(command *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	timeouts, err := resolveTimeouts(command, cfg)
+	if err != nil {
+		return err
+	}
+	parallelism, err := resolveParallelism(command, cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
 	// Get logger
 	logger, err := helper.GetLogger()
 	if err != nil {
@@ -1090,20 +1665,43 @@ func runApply(command *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize Konnect client: %w", err)
 	}
 
-	nsValidator, requirement, err := resolveNamespaceRequirement(command, cfg)
+	_, requirement, err := resolveNamespaceRequirement(command, cfg)
 	if err != nil {
 		return err
 	}
 
 	// Load or generate plan
 	var plan *planner.Plan
+	// fileHooks are the hooks the configuration files declare
+	var fileHooks *hooks.Config
 	if requirement.Mode != validator.NamespaceRequirementNone && planFile != "" {
 		return fmt.Errorf(
 			"--%s cannot be used together with --plan; generate the plan with namespace enforcement enabled instead",
 			requireNamespaceFlagName,
 		)
 	}
-	if planFile != "" {
+	if planFile != "" && command.Flags().Changed(namespaceFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; the plan file records its namespaces", namespaceFlagName)
+	}
+	if planFile != "" && command.Flags().Changed(targetFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; target resources when generating the plan",
+			targetFlagName)
+	}
+	stateFile := resolveStateFile(command)
+	checkpoint, resumedPlan, err := loadResumedApply(command, stateFile)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		if err := checkPlanRegion(command, cfg, resumedPlan); err != nil {
+			return err
+		}
+		if outputFormat == textOutputFormat {
+			fmt.Fprintf(command.OutOrStderr(), "Resuming apply from: %s (%d of %d changes remaining)\n",
+				stateFile, checkpoint.Remaining(), len(resumedPlan.Changes))
+		}
+		plan = resumedPlan
+	} else if planFile != "" {
 		// Show plan source information early
 		if outputFormat == textOutputFormat {
 			if planFile == "-" {
@@ -1113,90 +1711,63 @@ func runApply(command *cobra.Command, args []string) error {
 			}
 		}
 
+		if err := checkPreflight(ctx, command, cfg, kkClient, nil, false, command.ErrOrStderr()); err != nil {
+			return err
+		}
 		// Load existing plan
-		plan, err = common.LoadPlan(planFile, command.InOrStdin())
+		plan, err = loadSavedPlan(command, cfg, kkClient, planFile)
 		if err != nil {
 			return err
 		}
 	} else {
-
-		// Generate plan from configuration files
-		recursive, _ := command.Flags().GetBool("recursive")
-
-		// Parse sources from filenames
-		sources, err := loader.ParseSources(filenames)
-		if err != nil {
-			return fmt.Errorf("failed to parse sources: %w", err)
+		if fromOCI != "" {
+			var logOut io.Writer
+			if outputFormat == textOutputFormat {
+				logOut = command.OutOrStderr()
+			}
+			bundle, cleanup, err := pullOCIBundle(ctx, fromOCI, logOut)
+			if err != nil {
+				return err
+			}
+			// decK files in the bundle are read when the plan executes
+			defer cleanup()
+			filenames = []string{bundle.Dir}
 		}
 
-		// Load configuration
-		ldr, err := newDeclarativeLoader(command, cfg)
-		if err != nil {
-			return err
-		}
-		resourceSet, err := ldr.LoadFromSources(sources, recursive)
-		if err != nil {
-			// Provide more helpful error message for common cases
-			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML files found") {
-				return fmt.Errorf("no configuration files found in current directory. Use -f to specify files or directories")
-			}
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-
-		if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
-			return err
-		}
-
-		// Check if configuration is empty
-		totalResources := resourceSet.ResourceCount()
-
-		if totalResources == 0 {
-			// Check if we're using default directory (no explicit sources)
-			if len(filenames) == 0 {
-				return fmt.Errorf("no configuration files found in current directory. Use -f to specify files or directories")
-			}
-			return fmt.Errorf("no resources found in configuration files")
-		}
-
-		// Create planner
-		stateClient := createStateClient(kkClient)
-		p := planner.NewPlanner(stateClient, logger)
-		deckOpts, err := deckPlanOptions(resourceSet, cfg, logger)
+		// Generate plan from configuration files
+		plan, fileHooks, err = planApply(ctx, command, cfg, kkClient, logger, filenames, generator, parallelism)
 		if err != nil {
 			return err
 		}
+	}
 
-		// Generate plan in apply mode
-		opts := planner.Options{
-			Mode:      planner.PlanModeApply,
-			Generator: generator,
-			Deck:      deckOpts,
-		}
-		plan, err = p.GeneratePlan(ctx, resourceSet, opts)
-		if err != nil {
-			return fmt.Errorf("failed to generate plan: %w", err)
-		}
+	redactor, err := checkApplyPlan(command, cfg, plan, autoApprove, dryRun)
+	if err != nil {
+		return err
 	}
+	displayPlan := redactor.Plan(plan)
 
-	// Store plan in context for output formatting
-	ctx = context.WithValue(ctx, currentPlanKey, plan)
+	// Store the redacted plan in context for output formatting
+	ctx = context.WithValue(ctx, currentPlanKey, displayPlan)
 	// Store plan file path if provided
 	if planFile != "" {
 		ctx = context.WithValue(ctx, planFileKey, planFile)
 	}
 	command.SetContext(ctx)
 
-	// Validate plan for apply
-	if err := validateApplyPlan(plan, command); err != nil {
-		return err
-	}
-
 	// Check if plan is empty (no changes needed)
 	if plan.IsEmpty() {
+		if err := writeReport(command, plan, &executor.ExecutionResult{DryRun: dryRun}); err != nil {
+			return err
+		}
 		if outputFormat == textOutputFormat {
 			fmt.Fprintln(command.OutOrStderr(), "No changes needed. Resources match configuration.")
 			return nil
 		}
+		if outputFormat == ndjsonOutputFormat {
+			writeEmptyExecutionEvents(command, plan, dryRun)
+			return nil
+		}
 		// Use consistent output format with empty result
 		emptyResult := &executor.ExecutionResult{
 			SuccessCount:   0,
@@ -1208,25 +1779,47 @@ func runApply(command *cobra.Command, args []string) error {
 		return outputExecutionResult(command, emptyResult, outputFormat)
 	}
 
+	unlock, err := lockNamespaces(ctx, command, cfg, kkClient, logger, plan, dryRun)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Show plan summary for text format (both regular and dry-run)
 	if outputFormat == textOutputFormat {
-		common.DisplayPlanSummary(plan, command.OutOrStderr())
+		common.DisplayPlanSummary(displayPlan, command.OutOrStderr())
 
-		// Show confirmation prompt for non-dry-run, non-auto-approve
-		if !dryRun && !autoApprove {
-			// If we're using stdin for input, use /dev/tty for confirmation
-			inputReader := command.InOrStdin()
-			if usingStdinForInput {
-				tty, err := os.Open("/dev/tty")
-				if err != nil {
-					// This shouldn't happen as we checked earlier
-					return fmt.Errorf("cannot open terminal for confirmation: %w", err)
-				}
-				defer tty.Close()
-				inputReader = tty
+		// If we're using stdin for input, use /dev/tty for confirmation
+		inputReader := command.InOrStdin()
+		if usingStdinForInput && (interactive || (!dryRun && !autoApprove)) {
+			tty, err := os.Open("/dev/tty")
+			if err != nil {
+				// This shouldn't happen as we checked earlier
+				return fmt.Errorf("cannot open terminal for confirmation: %w", err)
 			}
+			defer tty.Close()
+			inputReader = tty
+		}
 
-			if !common.ConfirmExecution(plan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
+		if interactive {
+			// Reviewing every change replaces the confirmation prompt
+			skipped, err := common.ReviewChanges(displayPlan, command.OutOrStderr(), inputReader)
+			if err != nil {
+				return err
+			}
+			plan = plan.WithoutChanges(skipped)
+			displayPlan = redactor.Plan(plan)
+			ctx = context.WithValue(ctx, currentPlanKey, displayPlan)
+			command.SetContext(ctx)
+			if plan.IsEmpty() {
+				fmt.Fprintln(command.OutOrStderr(), "No changes selected. Nothing to apply.")
+				return nil
+			}
+			fmt.Fprintf(command.OutOrStderr(), "\nApplying %d change(s), %d skipped.\n",
+				len(plan.Changes), len(skipped))
+		} else if !dryRun && !autoApprove {
+			// Show confirmation prompt for non-dry-run, non-auto-approve
+			if !common.ConfirmExecution(displayPlan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
 				return fmt.Errorf("apply cancelled")
 			}
 		}
@@ -1235,39 +1828,54 @@ func runApply(command *cobra.Command, args []string) error {
 		fmt.Fprintln(command.OutOrStderr())
 	}
 
-	// Create executor
-	stateClient := createStateClient(kkClient)
-
 	var reporter executor.ProgressReporter
-	if outputFormat == textOutputFormat {
-		reporter = executor.NewConsoleReporterWithOptions(command.OutOrStderr(), dryRun)
-	}
-
-	token, err := konnectcommon.GetAccessToken(cfg, logger)
-	if err != nil {
-		return err
-	}
-	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	switch outputFormat {
+	case textOutputFormat:
+		if reporter, err = newProgressReporter(command, cfg, command.OutOrStderr(), dryRun); err != nil {
+			return err
+		}
+	case ndjsonOutputFormat:
+		reporter = executor.NewEventReporter(command.OutOrStdout(), dryRun)
+	}
+
+	result, postHooks, err := executeApply(ctx, command, cfg, kkClient, logger, plan, applyExecution{
+		redactor:  redactor,
+		fileHooks: fileHooks,
+		reporter:  reporter,
+		dryRun:    dryRun,
+		simulated: simulator != nil,
+		options: executor.Options{
+			PlanBaseDir: resolvePlanBaseDir(planFile),
+			Timeouts:    timeouts,
+			Parallelism: parallelism,
+			StateFile:   stateFile,
+			Resume:      checkpoint,
+		},
+	})
 	if err != nil {
 		return err
 	}
 
-	exec := executor.NewWithOptions(stateClient, reporter, dryRun, executor.Options{
-		KonnectToken:   token,
-		KonnectBaseURL: baseURL,
-		Mode:           planner.PlanModeApply,
-		PlanBaseDir:    resolvePlanBaseDir(planFile),
-	})
-
-	// Execute plan
-	result := exec.Execute(ctx, plan)
-
 	// Output results based on format
 	outputErr := outputExecutionResult(command, result, outputFormat)
 	if outputErr != nil {
 		return outputErr
 	}
+	if dryRun && outputFormat == textOutputFormat {
+		printIntendedOperations(command.OutOrStdout(), result)
+		if simulator != nil {
+			printSimulationReport(command.OutOrStdout(), simulator)
+		}
+	}
 
+	if (result.Canceled || result.HasErrors()) && stateFile != "" && !dryRun && outputFormat == textOutputFormat {
+		fmt.Fprintf(command.OutOrStderr(), "\nResume the apply with: kongctl apply --%s --%s %s\n",
+			resumeFlagName, stateFileFlagName, stateFile)
+	}
+	runHooks(command, postHooks, "apply", cfg.GetProfile(), plan, result)
+	if result.Canceled {
+		return fmt.Errorf("execution canceled after %d change(s): %w", result.SuccessCount, context.Canceled)
+	}
 	if result.HasErrors() {
 		return fmt.Errorf("execution completed with %d errors", result.FailureCount)
 	}
@@ -1275,6 +1883,208 @@ func runApply(command *cobra.Command, args []string) error {
 	return nil
 }
 
+// planApply loads the configuration of filenames and plans it in apply mode. The
+// configuration is checked by the preflight, namespace, policy and lint checks first,
+// and the plan is checked for changes made in Konnect since the last apply. The hooks
+// declared by the configuration files are returned with the plan.
+func planApply(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	kkClient helpers.SDKAPI,
+	logger *slog.Logger,
+	filenames []string,
+	generator string,
+	parallelism int,
+) (*planner.Plan, *hooks.Config, error) {
+	recursive, _ := command.Flags().GetBool("recursive")
+	nsValidator, requirement, err := resolveNamespaceRequirement(command, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse sources from filenames
+	sources, err := loader.ParseSources(filenames)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse sources: %w", err)
+	}
+
+	if err := checkPreflight(ctx, command, cfg, kkClient, sources, recursive, command.ErrOrStderr()); err != nil {
+		return nil, nil, err
+	}
+
+	// Load configuration
+	ldr, err := newDeclarativeLoader(command, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	resourceSet, err := ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
+	if err != nil {
+		// Provide more helpful error message for common cases
+		if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML or JSON files found") {
+			return nil, nil, fmt.Errorf(
+				"no configuration files found in current directory. Use -f to specify files or directories")
+		}
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
+		return nil, nil, err
+	}
+	if err := checkPolicy(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+		return nil, nil, err
+	}
+	if err := checkLint(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+		return nil, nil, err
+	}
+
+	// Check if configuration is empty
+	if resourceSet.ResourceCount() == 0 {
+		// Check if we're using default directory (no explicit sources)
+		if len(filenames) == 0 {
+			return nil, nil, fmt.Errorf(
+				"no configuration files found in current directory. Use -f to specify files or directories")
+		}
+		return nil, nil, fmt.Errorf("no resources found in configuration files")
+	}
+
+	// Create planner
+	stateClient := createStateClient(kkClient)
+	p := planner.NewPlanner(stateClient, logger)
+	deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate plan in apply mode
+	opts := planner.Options{
+		Mode:        planner.PlanModeApply,
+		Generator:   generator,
+		Deck:        deckOpts,
+		Parallelism: parallelism,
+	}
+	if err := ignoreFields(command, cfg, &opts); err != nil {
+		return nil, nil, err
+	}
+	matchByName(command, cfg, &opts)
+	if err := movedResources(cfg, resourceSet, &opts); err != nil {
+		return nil, nil, err
+	}
+	if err := scopeToChangedFiles(ctx, command, ldr, sources, resourceSet, &opts); err != nil {
+		return nil, nil, err
+	}
+	if err := scopeToTargets(command, resourceSet, &opts); err != nil {
+		return nil, nil, err
+	}
+	plan, err := p.GeneratePlan(ctx, resourceSet, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate plan: %w", err)
+	}
+	if err := countPlanResources(ctx, command, cfg, stateClient, logger, plan); err != nil {
+		return nil, nil, err
+	}
+	if err := resolveConflicts(command, cfg, plan); err != nil {
+		return nil, nil, err
+	}
+	return plan, ldr.Hooks(), nil
+}
+
+// checkApplyPlan runs the risk and policy checks of a plan about to be applied and
+// returns the redactor for displaying it
+func checkApplyPlan(
+	command *cobra.Command, cfg config.Hook, plan *planner.Plan, autoApprove, dryRun bool,
+) (*redact.Redactor, error) {
+	if err := applyRiskPolicy(command, cfg, plan); err != nil {
+		return nil, err
+	}
+	if err := checkPlanPolicy(command, cfg, plan, command.ErrOrStderr()); err != nil {
+		return nil, err
+	}
+	if err := checkAutoApproveRisk(command, cfg, plan, autoApprove, dryRun); err != nil {
+		return nil, err
+	}
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateApplyPlan(plan, command); err != nil {
+		return nil, err
+	}
+	return redactor, nil
+}
+
+// applyExecution holds what executeApply needs besides the plan
+type applyExecution struct {
+	redactor *redact.Redactor
+	// fileHooks are the hooks the configuration files declare
+	fileHooks *hooks.Config
+	reporter  executor.ProgressReporter
+	dryRun    bool
+	// simulated executions run against the simulator and need no token
+	simulated bool
+	// options are completed with the Konnect credentials, mode and rollback setting
+	options executor.Options
+}
+
+// executeApply executes an apply plan and records its outcome in the audit log, the
+// last applied configuration, and the --write-ids and --report files. The hooks to
+// notify of the outcome are returned, so callers run them once results are shown.
+func executeApply(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	kkClient helpers.SDKAPI,
+	logger *slog.Logger,
+	plan *planner.Plan,
+	execution applyExecution,
+) (*executor.ExecutionResult, *hooks.Config, error) {
+	var token string
+	var err error
+	if !execution.simulated {
+		if token, err = konnectcommon.GetAccessToken(cfg, logger); err != nil {
+			return nil, nil, err
+		}
+	}
+	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := execution.options
+	opts.KonnectToken = token
+	opts.KonnectBaseURL = baseURL
+	opts.Mode = planner.PlanModeApply
+	opts.RollbackOnError, _ = command.Flags().GetBool(rollbackOnErrorFlagName)
+	// A dry run executes the plan with Konnect writes and deck commands recorded
+	var dryRunRecording *applyDryRun
+	execCtx := ctx
+	if execution.dryRun {
+		dryRunRecording = newApplyDryRun(logger)
+		execCtx = dryRunRecording.context(ctx)
+		opts.ExecuteDryRun = true
+		opts.DeckRunner = dryRunRecording.deck
+	}
+	postHooks, err := resolveHooks(command, cfg, execution.fileHooks)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateClient := createStateClient(kkClient)
+	exec := executor.NewWithOptions(stateClient, execution.reporter, execution.dryRun, opts)
+
+	result := exec.Execute(execCtx, plan)
+	if dryRunRecording != nil {
+		dryRunRecording.record(result, execution.redactor)
+	}
+	recordAuditLog(logger, cfg, "apply", result)
+	recordLastApplied(logger, cfg.GetProfile(), plan, result)
+	if err := writeIDMapping(command, result); err != nil {
+		return nil, nil, err
+	}
+	if err := writeReport(command, plan, result); err != nil {
+		return nil, nil, err
+	}
+	return result, postHooks, nil
+}
+
 func validateApplyPlan(plan *planner.Plan, command *cobra.Command) error {
 	// Check if plan contains DELETE operations
 	for _, change := range plan.Changes {
@@ -1294,13 +2104,80 @@ func validateApplyPlan(plan *planner.Plan, command *cobra.Command) error {
 	return nil
 }
 
+// writeIDMapping persists the ref to ID mapping of applied changes when --write-ids is set
+func writeIDMapping(command *cobra.Command, result *executor.ExecutionResult) error {
+	path, _ := command.Flags().GetString(writeIDsFlagName)
+	if strings.TrimSpace(path) == "" {
+		return nil
+	}
+	return executor.WriteIDMapping(path, result)
+}
+
+// writeReport writes the per-operation report of an execution when --report is set
+func writeReport(command *cobra.Command, plan *planner.Plan, result *executor.ExecutionResult) error {
+	path, _ := command.Flags().GetString(reportFlagName)
+	if strings.TrimSpace(path) == "" {
+		return nil
+	}
+	return executor.WriteReport(path, plan, result)
+}
+
+// recordAuditLog appends the changes applied by a command to the kongctl audit log.
+// The log backs `get --include-deleted`; failing to write it must not fail the command.
+// Entries record the profile and Konnect API they were applied with, so deletions are
+// only reported for the organization they happened in.
+func recordAuditLog(logger *slog.Logger, cfg config.Hook, commandName string, result *executor.ExecutionResult) {
+	if result == nil || result.DryRun || len(result.ChangesApplied) == 0 {
+		return
+	}
+
+	path, err := audit.DefaultPath()
+	if err != nil {
+		logger.Warn("Unable to resolve audit log path", "error", err)
+		return
+	}
+	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	if err != nil {
+		logger.Warn("Unable to resolve the Konnect API of the audit log entries", "error", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	entries := make([]audit.Entry, 0, len(result.ChangesApplied))
+	for _, change := range result.ChangesApplied {
+		entries = append(entries, audit.Entry{
+			Timestamp:    now,
+			Command:      commandName,
+			ResourceType: change.ResourceType,
+			ResourceRef:  change.ResourceRef,
+			ResourceName: change.ResourceName,
+			ResourceID:   change.ResourceID,
+			Action:       change.Action,
+			Profile:      cfg.GetProfile(),
+			BaseURL:      baseURL,
+		})
+	}
+
+	if err := audit.NewLog(path).Append(entries...); err != nil {
+		logger.Warn("Unable to write audit log", "path", path, "error", err)
+	}
+}
+
+// writeEmptyExecutionEvents writes the event stream of a plan without changes, which
+// still starts and finishes
+func writeEmptyExecutionEvents(command *cobra.Command, plan *planner.Plan, dryRun bool) {
+	reporter := executor.NewEventReporter(command.OutOrStdout(), dryRun)
+	reporter.StartExecution(plan)
+	reporter.FinishExecution(&executor.ExecutionResult{DryRun: dryRun})
+}
+
 // Displays an output for the execution of an apply or sync command.
 // The returned error indicates if the function itself succeeded or not, not if the execution result had errors
 func outputExecutionResult(command *cobra.Command,
 	result *executor.ExecutionResult, format string,
 ) error {
-	// Human-readable output already handled by progress reporter
-	if format == "text" {
+	// Human-readable output and event streams are written by the progress reporter
+	if format == textOutputFormat || format == ndjsonOutputFormat {
 		return nil
 	}
 
@@ -1323,6 +2200,12 @@ func outputExecutionResult(command *cobra.Command,
 		if len(result.ValidationResults) > 0 {
 			execution["validation_results"] = result.ValidationResults
 		}
+		if len(result.IntendedRequests) > 0 {
+			execution["intended_requests"] = result.IntendedRequests
+		}
+		if len(result.IntendedDeckCommands) > 0 {
+			execution["intended_deck_commands"] = result.IntendedDeckCommands
+		}
 	} else {
 		if len(result.ChangesApplied) > 0 {
 			execution["applied_changes"] = result.ChangesApplied
@@ -1333,6 +2216,9 @@ func outputExecutionResult(command *cobra.Command,
 	if len(result.Errors) > 0 {
 		execution["errors"] = result.Errors
 	}
+	if len(result.ChangesNotStarted) > 0 {
+		execution["not_started_changes"] = result.ChangesNotStarted
+	}
 
 	// Build the summary section
 	summary := map[string]any{
@@ -1342,6 +2228,9 @@ func outputExecutionResult(command *cobra.Command,
 		"skipped":       result.SkippedCount,
 		"status":        "success",
 	}
+	if len(result.ChangesNotStarted) > 0 {
+		summary["not_started"] = len(result.ChangesNotStarted)
+	}
 
 	if result.TotalChanges() == 0 {
 		summary["message"] = "No changes needed. All resources match the desired configuration."
@@ -1453,7 +2342,7 @@ func newDeclarativeApplyCmd() *cobra.Command {
 The apply command provides a safe way to apply configuration changes by only
 performing CREATE and UPDATE operations. Use the sync command if you need to
 delete resources.`,
-		RunE: runApply,
+		RunE: withRecording("apply", runApply),
 	}
 
 	// Add declarative config flags
@@ -1462,12 +2351,52 @@ delete resources.`,
 	cmd.Flags().BoolP("recursive", "R", false,
 		"Process the directory used in -f, --filename recursively")
 	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addRiskPolicyFlag(cmd)
+	addPlanPolicyFlag(cmd)
+	addResourceLimitFlag(cmd)
+	addPolicyFileFlag(cmd)
+	addLintRulesetFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addOTelEndpointFlag(cmd)
+	addChangedSinceFlag(cmd)
+	addIgnoreFieldFlag(cmd)
+	addMatchByNameFlag(cmd)
+	addConflictStrategyFlag(cmd)
+	addSimulateFlag(cmd, `Execute the plan against an in-memory simulator of Konnect, like a dry run. Request bodies
+are validated against the Konnect API schemas and must reference existing resources.`)
+	addRecordFlag(cmd)
+	addTargetFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+	addNoHooksFlag(cmd)
 	cmd.Flags().String("plan", "", "Path to existing plan file")
+	addForceFlag(cmd)
+	addConfirmDeleteProtectedFlag(cmd, true)
+	addVerifyFlags(cmd)
+	addPreflightFlag(cmd)
 	cmd.Flags().Bool("dry-run", false, "Preview changes without applying")
 	cmd.Flags().Bool("auto-approve", false, "Skip confirmation prompt")
-	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text|json|yaml)")
+	addLockFlags(cmd)
+	addInteractiveFlag(cmd)
+	addTimeoutFlags(cmd)
+	addParallelismFlag(cmd)
+	addProgressFlag(cmd)
+	addRollbackOnErrorFlag(cmd)
+	addResumeFlags(cmd)
+	addAutoApproveMaxRiskFlag(cmd)
+	cmd.Flags().StringP("output", "o", textOutputFormat,
+		"Output format (text|json|yaml|ndjson). ndjson streams a JSON event per line as changes run")
 	cmd.Flags().String("execution-report-file", "", "Save execution report as JSON to file")
+	cmd.Flags().String(writeIDsFlagName, "", "Merge the ref to Konnect ID mapping of applied changes into a JSON file")
+	cmd.Flags().String(reportFlagName, "",
+		"Write a JSON report of every operation with its status, error, resource ID and timing to file")
 	addRequireNamespaceFlags(cmd)
+	addCanaryFlags(cmd)
+	addFromOCIFlag(cmd)
+	addProfilesFlags(cmd)
+	addWatchFlags(cmd)
 
 	return cmd
 }
@@ -1494,12 +2423,29 @@ This is equivalent to running:
 	cmd.Flags().BoolP("recursive", "R", false,
 		"Process the directory used in -f, --filename recursively")
 	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addRiskPolicyFlag(cmd)
+	addPlanPolicyFlag(cmd)
+	addResourceLimitFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addOTelEndpointFlag(cmd)
 	cmd.Flags().String("plan", "", "Path to existing delete plan file")
+	addForceFlag(cmd)
+	addConfirmDeleteProtectedFlag(cmd, true)
+	addVerifyFlags(cmd)
 	cmd.Flags().Bool("dry-run", false, "Preview deletions without executing them")
 	cmd.Flags().Bool("auto-approve", false, "Skip confirmation prompt")
-	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text|json|yaml)")
+	addLockFlags(cmd)
+	addTimeoutFlags(cmd)
+	addParallelismFlag(cmd)
+	addProgressFlag(cmd)
+	addAutoApproveMaxRiskFlag(cmd)
+	cmd.Flags().StringP("output", "o", textOutputFormat,
+		"Output format (text|json|yaml|ndjson). ndjson streams a JSON event per line as changes run")
 	cmd.Flags().String("execution-report-file", "", "Save execution report as JSON to file")
 	addRequireNamespaceFlags(cmd)
+	addDeletePreviewFlags(cmd)
 
 	return cmd
 }
@@ -1514,16 +2460,20 @@ func runDelete(command *cobra.Command, args []string) error {
 	autoApprove, _ := command.Flags().GetBool("auto-approve")
 	outputFormat, _ := command.Flags().GetString("output")
 	filenames, _ := command.Flags().GetStringSlice("filename")
+	preview, previewOnly, err := previewRequested(command)
+	if err != nil {
+		return err
+	}
 
 	// Early check for non-text output without auto-approve
-	if !dryRun && !autoApprove && outputFormat != textOutputFormat {
+	if !dryRun && !preview && !autoApprove && outputFormat != textOutputFormat {
 		return fmt.Errorf("cannot use %s output format without --auto-approve or --dry-run flag "+
 			"(interactive confirmation not available with structured output)", outputFormat)
 	}
 
 	// Early check for stdin usage without auto-approve
 	var usingStdinForInput bool
-	if !dryRun && !autoApprove {
+	if !dryRun && !preview && !autoApprove {
 		if planFile == "-" {
 			usingStdinForInput = true
 		} else if planFile == "" {
@@ -1543,6 +2493,8 @@ func runDelete(command *cobra.Command, args []string) error {
 					"Use --auto-approve to skip confirmation when piping commands")
 			}
 			tty.Close()
+		} else if err := requireTerminalInput(command); err != nil {
+			return err
 		}
 	}
 
@@ -1555,6 +2507,20 @@ func runDelete(command *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	timeouts, err := resolveTimeouts(command, cfg)
+	if err != nil {
+		return err
+	}
+	parallelism, err := resolveParallelism(command, cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
 
 	// Get logger
 	logger, err := helper.GetLogger()
@@ -1575,6 +2541,7 @@ func runDelete(command *cobra.Command, args []string) error {
 
 	// Load or generate plan
 	var plan *planner.Plan
+	var resourceSet *resources.ResourceSet
 	if requirement.Mode != validator.NamespaceRequirementNone && planFile != "" {
 		return fmt.Errorf(
 			"--%s cannot be used together with --plan; "+
@@ -1582,6 +2549,13 @@ func runDelete(command *cobra.Command, args []string) error {
 			requireNamespaceFlagName,
 		)
 	}
+	if planFile != "" && command.Flags().Changed(namespaceFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; the plan file records its namespaces", namespaceFlagName)
+	}
+	if planFile != "" && command.Flags().Changed(targetFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; target resources when generating the plan",
+			targetFlagName)
+	}
 	if planFile != "" {
 		if outputFormat == textOutputFormat {
 			if planFile == "-" {
@@ -1591,7 +2565,7 @@ func runDelete(command *cobra.Command, args []string) error {
 			}
 		}
 
-		plan, err = common.LoadPlan(planFile, command.InOrStdin())
+		plan, err = loadSavedPlan(command, cfg, kkClient, planFile)
 		if err != nil {
 			return err
 		}
@@ -1608,9 +2582,9 @@ func runDelete(command *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		resourceSet, err := ldr.LoadFromSources(sources, recursive)
+		resourceSet, err = ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
 		if err != nil {
-			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML files found") {
+			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML or JSON files found") {
 				return fmt.Errorf(
 					"no configuration files found in current directory. " +
 						"Use -f to specify files or directories")
@@ -1635,25 +2609,56 @@ func runDelete(command *cobra.Command, args []string) error {
 		// Create planner
 		stateClient := createStateClient(kkClient)
 		p := planner.NewPlanner(stateClient, logger)
-		deckOpts, err := deckPlanOptions(resourceSet, cfg, logger)
+		deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
 		if err != nil {
 			return err
 		}
 
 		// Generate plan in delete mode
 		opts := planner.Options{
-			Mode:      planner.PlanModeDelete,
-			Generator: generator,
-			Deck:      deckOpts,
+			Mode:        planner.PlanModeDelete,
+			Generator:   generator,
+			Deck:        deckOpts,
+			Parallelism: parallelism,
+		}
+		confirmProtectedDeletes(command, &opts)
+		if err := movedResources(cfg, resourceSet, &opts); err != nil {
+			return err
 		}
 		plan, err = p.GeneratePlan(ctx, resourceSet, opts)
 		if err != nil {
 			return fmt.Errorf("failed to generate plan: %w", err)
 		}
+		if err := countPlanResources(ctx, command, cfg, stateClient, logger, plan); err != nil {
+			return err
+		}
+	}
+
+	if err := applyRiskPolicy(command, cfg, plan); err != nil {
+		return err
+	}
+	if err := checkPlanPolicy(command, cfg, plan, command.ErrOrStderr()); err != nil {
+		return err
+	}
+	if preview {
+		// Nothing is deleted, so the risk gates for executing the plan do not apply
+		return outputDeletePreview(ctx, command, plan, resourceSet, createStateClient(kkClient),
+			previewOnly, outputFormat)
+	}
+	if err := checkProtectedDeletes(command, plan); err != nil {
+		return err
+	}
+	if err := checkAutoApproveRisk(command, cfg, plan, autoApprove, dryRun); err != nil {
+		return err
 	}
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	displayPlan := redactor.Plan(plan)
 
-	// Store plan in context for output formatting
-	ctx = context.WithValue(ctx, currentPlanKey, plan)
+	// Store the redacted plan in context for output formatting
+	ctx = context.WithValue(ctx, currentPlanKey, displayPlan)
 	if planFile != "" {
 		ctx = context.WithValue(ctx, planFileKey, planFile)
 	}
@@ -1666,6 +2671,10 @@ func runDelete(command *cobra.Command, args []string) error {
 				"No changes needed. No matching resources found to delete.")
 			return nil
 		}
+		if outputFormat == ndjsonOutputFormat {
+			writeEmptyExecutionEvents(command, plan, dryRun)
+			return nil
+		}
 		emptyResult := &executor.ExecutionResult{
 			SuccessCount:   0,
 			FailureCount:   0,
@@ -1676,9 +2685,15 @@ func runDelete(command *cobra.Command, args []string) error {
 		return outputExecutionResult(command, emptyResult, outputFormat)
 	}
 
+	unlock, err := lockNamespaces(ctx, command, cfg, kkClient, logger, plan, dryRun)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Show plan summary for text format
 	if outputFormat == textOutputFormat {
-		common.DisplayPlanSummary(plan, command.OutOrStderr())
+		common.DisplayPlanSummary(displayPlan, command.OutOrStderr())
 
 		if !dryRun && !autoApprove {
 			inputReader := command.InOrStdin()
@@ -1691,7 +2706,7 @@ func runDelete(command *cobra.Command, args []string) error {
 				inputReader = tty
 			}
 
-			if !common.ConfirmExecution(plan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
+			if !common.ConfirmExecution(displayPlan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
 				return fmt.Errorf("delete cancelled")
 			}
 		}
@@ -1703,8 +2718,13 @@ func runDelete(command *cobra.Command, args []string) error {
 	stateClient := createStateClient(kkClient)
 
 	var reporter executor.ProgressReporter
-	if outputFormat == textOutputFormat {
-		reporter = executor.NewConsoleReporterWithOptions(command.OutOrStderr(), dryRun)
+	switch outputFormat {
+	case textOutputFormat:
+		if reporter, err = newProgressReporter(command, cfg, command.OutOrStderr(), dryRun); err != nil {
+			return err
+		}
+	case ndjsonOutputFormat:
+		reporter = executor.NewEventReporter(command.OutOrStdout(), dryRun)
 	}
 
 	token, err := konnectcommon.GetAccessToken(cfg, logger)
@@ -1721,16 +2741,23 @@ func runDelete(command *cobra.Command, args []string) error {
 		KonnectBaseURL: baseURL,
 		Mode:           planner.PlanModeDelete,
 		PlanBaseDir:    resolvePlanBaseDir(planFile),
+		Timeouts:       timeouts,
+		Parallelism:    parallelism,
 	})
 
 	// Execute plan
 	result := exec.Execute(ctx, plan)
+	recordAuditLog(logger, cfg, "delete", result)
+	recordLastApplied(logger, cfg.GetProfile(), plan, result)
 
 	// Output results based on format
 	outputErr := outputExecutionResult(command, result, outputFormat)
 	if outputErr != nil {
 		return outputErr
 	}
+	if result.Canceled {
+		return fmt.Errorf("execution canceled after %d change(s): %w", result.SuccessCount, context.Canceled)
+	}
 	if result.HasErrors() {
 		return fmt.Errorf("execution completed with %d errors", result.FailureCount)
 	}
@@ -1781,6 +2808,8 @@ func runSync(command *cobra.Command, args []string) error {
 					"Use --auto-approve to skip confirmation when piping commands")
 			}
 			tty.Close()
+		} else if err := requireTerminalInput(command); err != nil {
+			return err
 		}
 	}
 
@@ -1793,6 +2822,20 @@ func runSync(command *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	timeouts, err := resolveTimeouts(command, cfg)
+	if err != nil {
+		return err
+	}
+	parallelism, err := resolveParallelism(command, cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
 
 	// Get logger
 	logger, err := helper.GetLogger()
@@ -1813,12 +2856,21 @@ func runSync(command *cobra.Command, args []string) error {
 
 	// Load or generate plan
 	var plan *planner.Plan
+	// fileHooks are the hooks the configuration files declare
+	var fileHooks *hooks.Config
 	if requirement.Mode != validator.NamespaceRequirementNone && planFile != "" {
 		return fmt.Errorf(
 			"--%s cannot be used together with --plan; generate the plan with namespace enforcement enabled instead",
 			requireNamespaceFlagName,
 		)
 	}
+	if planFile != "" && command.Flags().Changed(namespaceFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; the plan file records its namespaces", namespaceFlagName)
+	}
+	if planFile != "" && command.Flags().Changed(targetFlagName) {
+		return fmt.Errorf("--%s cannot be used together with --plan; target resources when generating the plan",
+			targetFlagName)
+	}
 	if planFile != "" {
 		// Show plan source information early
 		if outputFormat == textOutputFormat {
@@ -1830,7 +2882,7 @@ func runSync(command *cobra.Command, args []string) error {
 		}
 
 		// Load existing plan
-		plan, err = common.LoadPlan(planFile, command.InOrStdin())
+		plan, err = loadSavedPlan(command, cfg, kkClient, planFile)
 		if err != nil {
 			return err
 		}
@@ -1850,18 +2902,25 @@ func runSync(command *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		resourceSet, err := ldr.LoadFromSources(sources, recursive)
+		resourceSet, err := ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
 		if err != nil {
 			// Provide more helpful error message for common cases
-			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML files found") {
+			if len(filenames) == 0 && strings.Contains(err.Error(), "no YAML or JSON files found") {
 				return fmt.Errorf("no configuration files found in current directory. Use -f to specify files or directories")
 			}
 			return fmt.Errorf("failed to load configuration: %w", err)
 		}
+		fileHooks = ldr.Hooks()
 
 		if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
 			return err
 		}
+		if err := checkPolicy(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+			return err
+		}
+		if err := checkLint(command, cfg, resourceSet, command.ErrOrStderr()); err != nil {
+			return err
+		}
 
 		// Check if configuration is empty
 		totalResources := resourceSet.ResourceCount()
@@ -1894,25 +2953,64 @@ func runSync(command *cobra.Command, args []string) error {
 		// Create planner
 		stateClient := createStateClient(kkClient)
 		p := planner.NewPlanner(stateClient, logger)
-		deckOpts, err := deckPlanOptions(resourceSet, cfg, logger)
+		deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
 		if err != nil {
 			return err
 		}
 
 		// Generate plan in sync mode
 		opts := planner.Options{
-			Mode:      planner.PlanModeSync,
-			Generator: generator,
-			Deck:      deckOpts,
+			Mode:        planner.PlanModeSync,
+			Generator:   generator,
+			Deck:        deckOpts,
+			Parallelism: parallelism,
+		}
+		if err := ignoreFields(command, cfg, &opts); err != nil {
+			return err
+		}
+		matchByName(command, cfg, &opts)
+		if err := movedResources(cfg, resourceSet, &opts); err != nil {
+			return err
+		}
+		confirmProtectedDeletes(command, &opts)
+		if err := scopeToChangedFiles(ctx, command, ldr, sources, resourceSet, &opts); err != nil {
+			return err
+		}
+		if err := scopeToTargets(command, resourceSet, &opts); err != nil {
+			return err
 		}
 		plan, err = p.GeneratePlan(ctx, resourceSet, opts)
 		if err != nil {
 			return fmt.Errorf("failed to generate plan: %w", err)
 		}
+		if err := countPlanResources(ctx, command, cfg, stateClient, logger, plan); err != nil {
+			return err
+		}
+		if err := resolveConflicts(command, cfg, plan); err != nil {
+			return err
+		}
+	}
+
+	if err := applyRiskPolicy(command, cfg, plan); err != nil {
+		return err
+	}
+	if err := checkPlanPolicy(command, cfg, plan, command.ErrOrStderr()); err != nil {
+		return err
+	}
+	if err := checkProtectedDeletes(command, plan); err != nil {
+		return err
+	}
+	if err := checkAutoApproveRisk(command, cfg, plan, autoApprove, dryRun); err != nil {
+		return err
 	}
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	displayPlan := redactor.Plan(plan)
 
-	// Store plan in context for output formatting
-	ctx = context.WithValue(ctx, currentPlanKey, plan)
+	// Store the redacted plan in context for output formatting
+	ctx = context.WithValue(ctx, currentPlanKey, displayPlan)
 	// Store plan file path if provided
 	if planFile != "" {
 		ctx = context.WithValue(ctx, planFileKey, planFile)
@@ -1921,10 +3019,17 @@ func runSync(command *cobra.Command, args []string) error {
 
 	// Check if plan is empty (no changes needed)
 	if plan.IsEmpty() {
+		if err := writeReport(command, plan, &executor.ExecutionResult{DryRun: dryRun}); err != nil {
+			return err
+		}
 		if outputFormat == textOutputFormat {
 			fmt.Fprintln(command.OutOrStderr(), "No changes needed. Resources match configuration.")
 			return nil
 		}
+		if outputFormat == ndjsonOutputFormat {
+			writeEmptyExecutionEvents(command, plan, dryRun)
+			return nil
+		}
 		// Use consistent output format with empty result
 		emptyResult := &executor.ExecutionResult{
 			SuccessCount:   0,
@@ -1936,9 +3041,15 @@ func runSync(command *cobra.Command, args []string) error {
 		return outputExecutionResult(command, emptyResult, outputFormat)
 	}
 
+	unlock, err := lockNamespaces(ctx, command, cfg, kkClient, logger, plan, dryRun)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Show plan summary for text format (both regular and dry-run)
 	if outputFormat == textOutputFormat {
-		common.DisplayPlanSummary(plan, command.OutOrStderr())
+		common.DisplayPlanSummary(displayPlan, command.OutOrStderr())
 
 		// Show confirmation prompt for non-dry-run, non-auto-approve
 		if !dryRun && !autoApprove {
@@ -1954,7 +3065,7 @@ func runSync(command *cobra.Command, args []string) error {
 				inputReader = tty
 			}
 
-			if !common.ConfirmExecution(plan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
+			if !common.ConfirmExecution(displayPlan, command.OutOrStdout(), command.OutOrStderr(), inputReader) {
 				return fmt.Errorf("sync cancelled")
 			}
 		}
@@ -1967,8 +3078,13 @@ func runSync(command *cobra.Command, args []string) error {
 	stateClient := createStateClient(kkClient)
 
 	var reporter executor.ProgressReporter
-	if outputFormat == textOutputFormat {
-		reporter = executor.NewConsoleReporterWithOptions(command.OutOrStderr(), dryRun)
+	switch outputFormat {
+	case textOutputFormat:
+		if reporter, err = newProgressReporter(command, cfg, command.OutOrStderr(), dryRun); err != nil {
+			return err
+		}
+	case ndjsonOutputFormat:
+		reporter = executor.NewEventReporter(command.OutOrStdout(), dryRun)
 	}
 
 	token, err := konnectcommon.GetAccessToken(cfg, logger)
@@ -1980,21 +3096,42 @@ func runSync(command *cobra.Command, args []string) error {
 		return err
 	}
 
+	postHooks, err := resolveHooks(command, cfg, fileHooks)
+	if err != nil {
+		return err
+	}
+
+	rollbackOnError, _ := command.Flags().GetBool(rollbackOnErrorFlagName)
 	exec := executor.NewWithOptions(stateClient, reporter, dryRun, executor.Options{
-		KonnectToken:   token,
-		KonnectBaseURL: baseURL,
-		Mode:           planner.PlanModeSync,
-		PlanBaseDir:    resolvePlanBaseDir(planFile),
+		KonnectToken:    token,
+		KonnectBaseURL:  baseURL,
+		Mode:            planner.PlanModeSync,
+		PlanBaseDir:     resolvePlanBaseDir(planFile),
+		Timeouts:        timeouts,
+		Parallelism:     parallelism,
+		RollbackOnError: rollbackOnError,
 	})
 
 	// Execute plan
 	result := exec.Execute(ctx, plan)
+	recordAuditLog(logger, cfg, "sync", result)
+	recordLastApplied(logger, cfg.GetProfile(), plan, result)
+	if err := writeIDMapping(command, result); err != nil {
+		return err
+	}
+	if err := writeReport(command, plan, result); err != nil {
+		return err
+	}
 
 	// Output results based on format
 	outputErr := outputExecutionResult(command, result, outputFormat)
 	if outputErr != nil {
 		return outputErr
 	}
+	runHooks(command, postHooks, "sync", cfg.GetProfile(), plan, result)
+	if result.Canceled {
+		return fmt.Errorf("execution canceled after %d change(s): %w", result.SuccessCount, context.Canceled)
+	}
 	if result.HasErrors() {
 		return fmt.Errorf("execution completed with %d errors", result.FailureCount)
 	}
@@ -2004,39 +3141,5 @@ func runSync(command *cobra.Command, args []string) error {
 
 // createStateClient creates a new state client with all necessary APIs
 func createStateClient(kkClient helpers.SDKAPI) *state.Client {
-	return state.NewClient(state.ClientConfig{
-		// Core APIs
-		PortalAPI:             kkClient.GetPortalAPI(),
-		APIAPI:                kkClient.GetAPIAPI(),
-		AppAuthAPI:            kkClient.GetAppAuthStrategiesAPI(),
-		ControlPlaneAPI:       kkClient.GetControlPlaneAPI(),
-		ControlPlaneGroupsAPI: kkClient.GetControlPlaneGroupsAPI(),
-		GatewayServiceAPI:     kkClient.GetGatewayServiceAPI(),
-		CatalogServiceAPI:     kkClient.GetCatalogServicesAPI(),
-
-		// Portal child resource APIs
-		PortalPageAPI:          kkClient.GetPortalPageAPI(),
-		PortalAuthSettingsAPI:  kkClient.GetPortalAuthSettingsAPI(),
-		PortalCustomizationAPI: kkClient.GetPortalCustomizationAPI(),
-		PortalCustomDomainAPI:  kkClient.GetPortalCustomDomainAPI(),
-		PortalSnippetAPI:       kkClient.GetPortalSnippetAPI(),
-		PortalTeamAPI:          kkClient.GetPortalTeamAPI(),
-		PortalTeamRolesAPI:     kkClient.GetPortalTeamRolesAPI(),
-		PortalEmailsAPI:        kkClient.GetPortalEmailsAPI(),
-		AssetsAPI:              kkClient.GetAssetsAPI(),
-
-		// API child resource APIs
-		APIVersionAPI:        kkClient.GetAPIVersionAPI(),
-		APIPublicationAPI:    kkClient.GetAPIPublicationAPI(),
-		APIImplementationAPI: kkClient.GetAPIImplementationAPI(),
-		APIDocumentAPI:       kkClient.GetAPIDocumentAPI(),
-
-		// Event Gateway APIs
-		EGWControlPlaneAPI:            kkClient.GetEventGatewayControlPlaneAPI(),
-		EventGatewayBackendClusterAPI: kkClient.GetEventGatewayBackendClusterAPI(),
-		EventGatewayVirtualClusterAPI: kkClient.GetEventGatewayVirtualClusterAPI(),
-
-		// Organization APIs
-		OrganizationTeamAPI: kkClient.GetOrganizationTeamAPI(),
-	})
+	return state.NewClientForSDK(kkClient)
 }
@@ -0,0 +1,75 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/spf13/cobra"
+)
+
+const (
+	showDependencyGraphFlagName = "show-dependency-graph"
+	graphFormatFlagName         = "graph-format"
+
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+func addDependencyGraphFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(showDependencyGraphFlagName, false,
+		`Write the dependency graph resolved from the configuration instead of a plan.
+Nodes are resources by ref and edges the fields referencing other resources.
+Resources that depend on each other are highlighted. Konnect is not contacted.`)
+	cmd.Flags().String(graphFormatFlagName, graphFormatDOT,
+		fmt.Sprintf("Format of --%s (%s|%s)", showDependencyGraphFlagName, graphFormatDOT, graphFormatJSON))
+}
+
+// runDependencyGraph writes the dependency graph of the configuration to stdout. When
+// references can't be resolved, e.g. because of a circular reference, the graph is
+// still written before the error is returned.
+func runDependencyGraph(command *cobra.Command, cfg config.Hook, filenames []string, recursive bool) error {
+	format, _ := command.Flags().GetString(graphFormatFlagName)
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != graphFormatDOT && format != graphFormatJSON {
+		return fmt.Errorf("invalid --%s %q: must be %s or %s", graphFormatFlagName, format, graphFormatDOT,
+			graphFormatJSON)
+	}
+
+	sources, err := loader.ParseSources(filenames)
+	if err != nil {
+		return fmt.Errorf("failed to parse sources: %w", err)
+	}
+	ldr, err := newDeclarativeLoader(command, cfg)
+	if err != nil {
+		return err
+	}
+	ldr.RecordDependencyGraph()
+	_, loadErr := ldr.LoadFromSourcesWithContext(command.Context(), sources, recursive)
+
+	graph := ldr.DependencyGraph()
+	if graph == nil {
+		return fmt.Errorf("failed to load configuration: %w", loadErr)
+	}
+	switch format {
+	case graphFormatJSON:
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(graph)
+	default:
+		err = graph.WriteDOT(command.OutOrStdout())
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, cycle := range graph.Cycles {
+		fmt.Fprintf(command.ErrOrStderr(), "Circular dependency between: %s\n", strings.Join(cycle, ", "))
+	}
+	if loadErr != nil {
+		return fmt.Errorf("failed to load configuration: %w", loadErr)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	cmdcommon "github.com/kong/kongctl/internal/cmd/common"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffModeFlagName     = "mode"
+	diffNoColorFlagName  = "no-color"
+	diffExitCodeFlagName = "exit-code"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+func addDiffFlags(cmd *cobra.Command) {
+	cmd.Flags().String(diffModeFlagName, "sync",
+		"Plan generation mode (sync|apply); deletions are only shown in sync mode")
+	cmd.Flags().Bool(diffNoColorFlagName, false, "Disable colors in text output")
+	cmd.Flags().Bool(diffExitCodeFlagName, false,
+		"Exit with a non-zero status when changes are detected, e.g. to gate merges in CI")
+}
+
+// diffPlanMode returns the plan mode selected with --mode
+func diffPlanMode(command *cobra.Command) (planner.PlanMode, error) {
+	mode, _ := command.Flags().GetString(diffModeFlagName)
+	switch mode {
+	case "sync":
+		return planner.PlanModeSync, nil
+	case "apply":
+		return planner.PlanModeApply, nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: must be 'sync' or 'apply'", mode)
+	}
+}
+
+// diffColors paints the text diff when color output is enabled
+type diffColors struct {
+	enabled bool
+}
+
+// newDiffColors enables colors when --no-color is unset and the --color setting
+// allows them for the command output
+func newDiffColors(command *cobra.Command, cfg config.Hook) diffColors {
+	if noColor, _ := command.Flags().GetBool(diffNoColorFlagName); noColor {
+		return diffColors{}
+	}
+
+	modeStr := strings.ToLower(strings.TrimSpace(cfg.GetString(cmdcommon.ColorConfigPath)))
+	mode, err := cmdcommon.ColorModeStringToIota(modeStr)
+	if err != nil {
+		mode = cmdcommon.ColorModeAuto
+	}
+
+	switch mode {
+	case cmdcommon.ColorModeAlways:
+		return diffColors{enabled: true}
+	case cmdcommon.ColorModeNever:
+		return diffColors{}
+	default:
+		if _, disabled := os.LookupEnv("NO_COLOR"); disabled {
+			return diffColors{}
+		}
+		return diffColors{enabled: isDiffTerminal(command.OutOrStdout())}
+	}
+}
+
+var diffTerminalDetector = func(fd uintptr) bool {
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+func isDiffTerminal(out io.Writer) bool {
+	type fdWriter interface {
+		Fd() uintptr
+	}
+	if fw, ok := out.(fdWriter); ok {
+		return diffTerminalDetector(fw.Fd())
+	}
+	return false
+}
+
+// action paints s in the color of the planned action
+func (c diffColors) action(action planner.ActionType, s string) string {
+	switch action {
+	case planner.ActionCreate:
+		return c.paint(ansiGreen, s)
+	case planner.ActionUpdate:
+		return c.paint(ansiYellow, s)
+	case planner.ActionDelete:
+		return c.paint(ansiRed, s)
+	default:
+		return c.paint(ansiCyan, s)
+	}
+}
+
+// change renders a field change as old → new, with the old value red and the new value green
+func (c diffColors) change(oldValue, newValue any) string {
+	return c.paint(ansiRed, fmt.Sprintf("%v", oldValue)) + " → " + c.paint(ansiGreen, fmt.Sprintf("%v", newValue))
+}
+
+func (c diffColors) paint(color, s string) string {
+	if !c.enabled {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// sortedFieldNames returns the field names of a change in a stable order for display
+func sortedFieldNames(fields map[string]any) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,258 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/kong/kongctl/internal/declarative/specrev"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// assumeAppliedFlagName is the CLI flag reporting every difference as drift
+const assumeAppliedFlagName = "assume-applied"
+
+func newDeclarativeDriftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "konnect",
+		Short: "Report drift between live Konnect state and the last applied configuration",
+		Long: `Compare the live state of kongctl managed Konnect resources with declarative
+configuration files and report the fields that differ.
+
+A difference is drift when the field was changed in Konnect while the configuration
+still holds the value kongctl last applied, and a pending change when the
+configuration changed since it was last applied. What was last applied is recorded
+by apply and sync in the kongctl config directory.`,
+		RunE: runDrift,
+	}
+
+	cmd.Flags().StringSliceP("filename", "f", []string{},
+		"Filename or directory to files to compare with Konnect (can specify multiple)")
+	cmd.Flags().BoolP("recursive", "R", false,
+		"Process the directory used in -f, --filename recursively")
+	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addSensitiveFieldsFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text, json, yaml, or sarif)")
+	cmd.Flags().Bool(diffExitCodeFlagName, false,
+		"Exit with a non-zero status when drift is detected, e.g. to alert from a scheduled job")
+	cmd.Flags().Bool(assumeAppliedFlagName, false,
+		`Report every difference as drift, treating the configuration as applied. For
+configuration applied as soon as it changes, e.g. from a CI job without the record of the applies.`)
+	addRequireNamespaceFlags(cmd)
+
+	return cmd
+}
+
+func runDrift(command *cobra.Command, args []string) error {
+	// Silence usage for all runtime errors (command syntax is already valid at this point)
+	command.SilenceUsage = true
+
+	ctx := command.Context()
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	nsValidator, requirement, err := resolveNamespaceRequirement(command, cfg)
+	if err != nil {
+		return err
+	}
+
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	recursive, _ := command.Flags().GetBool("recursive")
+	sources, err := loader.ParseSources(filenames)
+	if err != nil {
+		return fmt.Errorf("failed to parse sources: %w", err)
+	}
+	ldr, err := newDeclarativeLoader(command, cfg)
+	if err != nil {
+		return err
+	}
+	resourceSet, err := ldr.LoadFromSourcesWithContext(ctx, sources, recursive)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := nsValidator.ValidateNamespaceRequirement(resourceSet, requirement); err != nil {
+		return err
+	}
+	if resourceSet.ResourceCount() == 0 {
+		return fmt.Errorf("no resources found in configuration files")
+	}
+
+	kkClient, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+	deckOpts, err := deckPlanOptions(command, resourceSet, cfg, logger)
+	if err != nil {
+		return err
+	}
+	// Apply mode leaves out unmanaged resources missing from the configuration
+	plan, err := planner.NewPlanner(createStateClient(kkClient), logger).GeneratePlan(ctx, resourceSet,
+		planner.Options{Mode: planner.PlanModeApply, Generator: planGenerator(helper), Deck: deckOpts})
+	if err != nil {
+		return fmt.Errorf("failed to generate plan: %w", err)
+	}
+
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+	applied, err := drift.NewStore(path).Load(cfg.GetProfile())
+	if err != nil {
+		return err
+	}
+	report := drift.Detect(plan, applied)
+	if assumeApplied, _ := command.Flags().GetBool(assumeAppliedFlagName); assumeApplied {
+		report.AssumeApplied()
+	}
+
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	redactDifferences(redactor, report.Drift)
+	redactDifferences(redactor, report.Pending)
+
+	outputFormat, _ := command.Flags().GetString("output")
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal drift report to YAML: %w", err)
+		}
+		fmt.Fprint(command.OutOrStdout(), string(data))
+	case sarifOutputFormat:
+		buildInfo, err := helper.GetBuildInfo()
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report.SARIF(buildInfo.Version, sarifLocator(ldr))); err != nil {
+			return err
+		}
+	case textOutputFormat:
+		displayTextDrift(command.OutOrStdout(), report)
+	default:
+		return fmt.Errorf("unsupported output format: %s (use text, json, yaml, or sarif)", outputFormat)
+	}
+
+	if exitCode, _ := command.Flags().GetBool(diffExitCodeFlagName); exitCode && report.HasDrift() {
+		return cmd.PrepareExecutionErrorMsg(helper,
+			fmt.Sprintf("drift detected: %d difference(s) made in Konnect", len(report.Drift)))
+	}
+	return nil
+}
+
+// redactDifferences redacts the configured values of sensitive fields
+func redactDifferences(redactor *redact.Redactor, differences []drift.Difference) {
+	for i := range differences {
+		if field := differences[i].Field; field != "" {
+			differences[i].Desired = redactor.Fields(map[string]any{field: differences[i].Desired})[field]
+		}
+	}
+}
+
+// sarifLocator locates resources in their configuration files, with paths relative
+// to the working directory as code scanning services expect paths relative to the
+// repository checkout. Resources read from stdin have no location.
+func sarifLocator(ldr *loader.Loader) drift.Locator {
+	wd, _ := os.Getwd()
+	return func(ref string) (string, int) {
+		path, line := ldr.RefDefinition(ref)
+		if path == "" || path == "stdin" {
+			return "", 0
+		}
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		return filepath.ToSlash(path), line
+	}
+}
+
+func displayTextDrift(out io.Writer, report *drift.Report) {
+	if report.IsEmpty() {
+		fmt.Fprintln(out, "No drift detected. Konnect matches the configuration.")
+		return
+	}
+
+	if report.HasDrift() {
+		fmt.Fprintf(out, "Drift: %d difference(s) made in Konnect since the last apply\n", len(report.Drift))
+		for _, difference := range report.Drift {
+			symbol := "~"
+			if difference.Field == "" {
+				symbol = "-"
+			}
+			fmt.Fprintf(out, "  %s %s\n", symbol, difference.Describe())
+		}
+	} else {
+		fmt.Fprintln(out, "No drift detected.")
+	}
+
+	if len(report.Pending) > 0 {
+		fmt.Fprintf(out, "\nPending: %d change(s) in the configuration not applied yet\n", len(report.Pending))
+		for _, difference := range report.Pending {
+			symbol := "~"
+			if difference.Field == "" {
+				symbol = "+"
+			}
+			fmt.Fprintf(out, "  %s %s\n", symbol, difference.Describe())
+		}
+	}
+}
+
+// recordLastApplied records the fields applied by a command, so drift can tell changes
+// made in Konnect from changes made in the configuration. Failing to write them must
+// not fail the command.
+func recordLastApplied(logger *slog.Logger, profile string, plan *planner.Plan, result *executor.ExecutionResult) {
+	path, err := drift.DefaultPath()
+	if err != nil {
+		logger.Warn("Unable to resolve last applied configuration path", "error", err)
+		return
+	}
+	if err := drift.NewStore(path).Record(profile, plan, result, time.Now().UTC()); err != nil {
+		logger.Warn("Unable to record last applied configuration", "path", path, "error", err)
+	}
+	recordSpecRevisions(logger, plan, result)
+}
+
+// recordSpecRevisions keeps the API version specs a command uploaded, so
+// kongctl api-version rollback can restore them
+func recordSpecRevisions(logger *slog.Logger, plan *planner.Plan, result *executor.ExecutionResult) {
+	dir, err := specrev.DefaultDir()
+	if err != nil {
+		logger.Warn("Unable to resolve spec revisions path", "error", err)
+		return
+	}
+	if err := specrev.NewStore(dir).RecordApplied(plan, result, time.Now().UTC()); err != nil {
+		logger.Warn("Unable to record API version spec revisions", "path", dir, "error", err)
+	}
+}
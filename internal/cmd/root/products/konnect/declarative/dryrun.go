@@ -0,0 +1,74 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/deck"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+)
+
+// applyDryRun executes an apply without changing Konnect. Reads reach Konnect so
+// references resolve as they would in an apply, while writes and deck commands
+// are recorded for display.
+type applyDryRun struct {
+	requests *httpclient.DryRunRecorder
+	deck     *deck.DryRunRunner
+}
+
+func newApplyDryRun(logger *slog.Logger) *applyDryRun {
+	return &applyDryRun{
+		requests: httpclient.NewDryRunRecorder(logger),
+		deck:     deck.NewDryRunRunner(),
+	}
+}
+
+// context returns the execution context whose Konnect writes are recorded
+func (d *applyDryRun) context(ctx context.Context) context.Context {
+	return httpclient.WithDryRunRecorder(ctx, d.requests)
+}
+
+// record adds the recorded operations to the result, redacting sensitive payload values
+func (d *applyDryRun) record(result *executor.ExecutionResult, redactor *redact.Redactor) {
+	for _, req := range d.requests.Requests() {
+		if payload, ok := req.Payload.(map[string]any); ok {
+			req.Payload = redactor.Fields(payload)
+		}
+		result.IntendedRequests = append(result.IntendedRequests, req)
+	}
+	result.IntendedDeckCommands = d.deck.Commands()
+}
+
+// printIntendedOperations lists the requests and deck commands of an executed dry run
+func printIntendedOperations(out io.Writer, result *executor.ExecutionResult) {
+	if len(result.IntendedRequests) == 0 && len(result.IntendedDeckCommands) == 0 {
+		fmt.Fprintln(out, "\nNo Konnect requests would be sent.")
+		return
+	}
+
+	if len(result.IntendedRequests) > 0 {
+		fmt.Fprintln(out, "\nKonnect requests that would be sent:")
+		for i, req := range result.IntendedRequests {
+			line := fmt.Sprintf("  %d. %s %s", i+1, req.Method, req.Path)
+			if req.Payload != nil {
+				if payload, err := json.Marshal(req.Payload); err == nil {
+					line += " " + string(payload)
+				}
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+
+	if len(result.IntendedDeckCommands) > 0 {
+		fmt.Fprintln(out, "\ndeck commands that would run:")
+		for i, args := range result.IntendedDeckCommands {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, strings.Join(args, " "))
+		}
+	}
+}
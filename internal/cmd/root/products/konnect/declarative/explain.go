@@ -0,0 +1,69 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/spf13/cobra"
+)
+
+// explainFlagName is the CLI flag recording why each update was planned
+const explainFlagName = "explain"
+
+func addExplainFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(explainFlagName, false,
+		`Record for each update the fields that differ, their live and configured values, and
+whether the difference comes from the configuration (config), from a change made in
+Konnect since the last apply (drift) or only from a default Konnect fills in (default).`)
+}
+
+// explainPlan records the explanation of the plan's updates when --explain is set
+func explainPlan(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	client *state.Client,
+	plan *planner.Plan,
+) error {
+	if explain, _ := command.Flags().GetBool(explainFlagName); !explain {
+		return nil
+	}
+	if err := plan.Explain(ctx, client); err != nil {
+		return fmt.Errorf("failed to explain plan: %w", err)
+	}
+
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+	applied, err := drift.NewStore(path).Load(cfg.GetProfile())
+	if err != nil {
+		return err
+	}
+	drift.ExplainOrigins(plan, applied)
+	return nil
+}
+
+// renderExplanation writes why each field of a change was planned
+func renderExplanation(out io.Writer, change planner.PlannedChange, indent string, colors diffColors) {
+	if len(change.Explanation) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "%swhy:\n", indent)
+	for _, explanation := range change.Explanation {
+		oldValue := explanation.Old
+		switch {
+		case explanation.OldUnknown:
+			oldValue = "(not read)"
+		case oldValue == nil:
+			oldValue = "(unset)"
+		}
+		fmt.Fprintf(out, "%s  %s: %s (%s)\n", indent, explanation.Field,
+			colors.change(oldValue, explanation.New), explanation.Origin)
+	}
+}
@@ -0,0 +1,143 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/hooks"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// hooksConfigPath is the config path of the hooks of the profile
+	hooksConfigPath = "konnect.declarative.hooks"
+	// noHooksFlagName is the CLI flag skipping the hooks
+	noHooksFlagName = "no-hooks"
+)
+
+func addNoHooksFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(noHooksFlagName, false,
+		fmt.Sprintf(`Skip the hooks notified of the outcome of the command, those of the configuration files
+and of the profile.
+- Config path of the profile hooks: [ %s ]`, hooksConfigPath))
+}
+
+// resolveHooks returns the hooks of the profile followed by those the configuration
+// files declare, or nil when they are skipped. Invalid hooks fail the command before
+// anything is changed.
+func resolveHooks(command *cobra.Command, cfg config.Hook, fileHooks *hooks.Config) (*hooks.Config, error) {
+	if skip, _ := command.Flags().GetBool(noHooksFlagName); skip {
+		return nil, nil
+	}
+
+	resolved := &hooks.Config{}
+	if profileHooks := cfg.Get(hooksConfigPath); profileHooks != nil {
+		data, err := yaml.Marshal(profileHooks)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", hooksConfigPath, err)
+		}
+		if err := yaml.UnmarshalStrict(data, resolved); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", hooksConfigPath, err)
+		}
+		if err := resolved.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", hooksConfigPath, err)
+		}
+	}
+	resolved.Merge(fileHooks)
+	return resolved, nil
+}
+
+// hookSummary is the JSON summary of an execution the hooks receive
+type hookSummary struct {
+	Event   hooks.Event `json:"event"`
+	Command string      `json:"command"`
+	Profile string      `json:"profile,omitempty"`
+	// Error is the error the command fails with, on failure
+	Error string `json:"error,omitempty"`
+	// Report lists the changes of the execution with their outcome and duration
+	Report *executor.Report `json:"report"`
+}
+
+// runHooks notifies the hooks of the outcome of the execution of plan. Dry runs are
+// not notified. Failing hooks are reported as warnings and do not fail the command.
+func runHooks(command *cobra.Command, postHooks *hooks.Config, commandName, profile string,
+	plan *planner.Plan, result *executor.ExecutionResult,
+) {
+	if postHooks.IsEmpty() || result == nil || result.DryRun {
+		return
+	}
+
+	summary := &hookSummary{
+		Event:   hooks.EventSuccess,
+		Command: commandName,
+		Profile: profile,
+		Report:  executor.BuildReport(plan, result),
+	}
+	if result.Canceled {
+		summary.Event = hooks.EventFailure
+		summary.Error = fmt.Sprintf("execution canceled after %d change(s)", result.SuccessCount)
+	} else if result.HasErrors() {
+		summary.Event = hooks.EventFailure
+		summary.Error = fmt.Sprintf("execution completed with %d errors", result.FailureCount)
+	}
+	notification := &hooks.Notification{
+		Event:   summary.Event,
+		Command: commandName,
+		Summary: summary,
+		Text:    hookText(summary),
+	}
+
+	// Hooks report cancellations too, so they outlive the context of the command
+	for _, err := range postHooks.Run(context.WithoutCancel(command.Context()), notification) {
+		fmt.Fprintf(command.ErrOrStderr(), "Warning: %v\n", err)
+	}
+}
+
+// hookText is the message of slack hooks: the outcome and counts of the execution,
+// followed by its first failed changes
+func hookText(summary *hookSummary) string {
+	const maxFailures = 10
+	report := summary.Report
+
+	outcome := "succeeded"
+	if summary.Event == hooks.EventFailure {
+		outcome = "failed"
+	}
+	var text strings.Builder
+	fmt.Fprintf(&text, "kongctl %s %s", summary.Command, outcome)
+	if summary.Profile != "" {
+		fmt.Fprintf(&text, " (profile %s)", summary.Profile)
+	}
+	fmt.Fprintf(&text, ": %d of %d change(s) applied", report.Summary.Succeeded, report.Summary.Total)
+	if report.Summary.Failed > 0 {
+		fmt.Fprintf(&text, ", %d failed", report.Summary.Failed)
+	}
+	if report.Summary.NotStarted > 0 {
+		fmt.Fprintf(&text, ", %d not started", report.Summary.NotStarted)
+	}
+	fmt.Fprintf(&text, " in %s", time.Duration(report.DurationMS)*time.Millisecond)
+
+	listed := 0
+	for _, operation := range report.Operations {
+		if operation.Status != executor.OperationFailed {
+			continue
+		}
+		if listed == maxFailures {
+			fmt.Fprintf(&text, "\n• and %d more", report.Summary.Failed-listed)
+			break
+		}
+		listed++
+		fmt.Fprintf(&text, "\n• %s %s %q: %s", operation.Action, operation.ResourceType,
+			operation.ResourceName, operation.Error)
+	}
+	if listed == 0 && summary.Error != "" {
+		fmt.Fprintf(&text, "\n%s", summary.Error)
+	}
+	return text.String()
+}
@@ -0,0 +1,140 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ignoreResourceFlagName is the CLI flag for resources hidden from plan output
+	ignoreResourceFlagName = "ignore-resource"
+	// ignoreResourceConfigPath is the config path backing the ignore-resource flag
+	ignoreResourceConfigPath = "konnect.declarative." + ignoreResourceFlagName
+	// summarizeIgnoredFlagName is the CLI flag that keeps ignored changes in the summary
+	summarizeIgnoredFlagName = "summarize-ignored"
+	// summarizeIgnoredConfigPath is the config path backing the summarize-ignored flag
+	summarizeIgnoredConfigPath = "konnect.declarative." + summarizeIgnoredFlagName
+	// ignoreFieldFlagName is the CLI flag for fields whose differences alone are not planned
+	ignoreFieldFlagName = "ignore-field"
+	// ignoreFieldConfigPath is the config path backing the ignore-field flag
+	ignoreFieldConfigPath = "konnect.declarative." + ignoreFieldFlagName
+)
+
+func addIgnoreResourceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(ignoreResourceFlagName, nil,
+		fmt.Sprintf(`Resource (type:ref, e.g. api:orders) whose changes are hidden from plan output (can specify multiple).
+A ref of "*" matches every resource of the type. Hidden changes are still planned and applied.
+- Config path: [ %s ]`, ignoreResourceConfigPath))
+}
+
+func addSummarizeIgnoredFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(summarizeIgnoredFlagName, false,
+		fmt.Sprintf(`Keep changes hidden by --%s in the summary counts.
+- Config path: [ %s ]`, ignoreResourceFlagName, summarizeIgnoredConfigPath))
+}
+
+func addIgnoreFieldFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(ignoreFieldFlagName, nil,
+		fmt.Sprintf(`Field (type.ref.path, e.g. apis.*.created_at) whose differences alone never plan an update
+(can specify multiple). "*" matches any resource type, ref or path segment. Updates that
+change other fields are planned as usual.
+- Config path: [ %s ]`, ignoreFieldConfigPath))
+}
+
+// ignoreFields adds the ignored fields of the command, from the flag or the config file
+// when unset, to the planner options
+func ignoreFields(command *cobra.Command, cfg config.Hook, opts *planner.Options) error {
+	if command.Flags().Lookup(ignoreFieldFlagName) == nil {
+		return nil
+	}
+	var values []string
+	if command.Flags().Changed(ignoreFieldFlagName) {
+		values, _ = command.Flags().GetStringSlice(ignoreFieldFlagName)
+	} else if cfg != nil {
+		values = cfg.GetStringSlice(ignoreFieldConfigPath)
+	}
+
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		field, err := planner.ParseIgnoredField(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", ignoreFieldFlagName, err)
+		}
+		opts.IgnoreFields = append(opts.IgnoreFields, field)
+	}
+	return nil
+}
+
+// resolveIgnoredResources returns the ignored resources of the command, from the flag or
+// the config file when unset, combined with those recorded in the plan when it was generated
+func resolveIgnoredResources(
+	command *cobra.Command, cfg config.Hook, plan *planner.Plan,
+) ([]planner.IgnoredResource, error) {
+	if command.Flags().Lookup(ignoreResourceFlagName) == nil {
+		return nil, nil
+	}
+	var values []string
+	if command.Flags().Changed(ignoreResourceFlagName) {
+		values, _ = command.Flags().GetStringSlice(ignoreResourceFlagName)
+	} else if cfg != nil {
+		values = cfg.GetStringSlice(ignoreResourceConfigPath)
+	}
+	if plan != nil {
+		values = append(values, plan.Metadata.IgnoredResources...)
+	}
+
+	var ignored []planner.IgnoredResource
+	seen := make(map[planner.IgnoredResource]bool)
+	for _, value := range values {
+		resource, err := planner.ParseIgnoredResource(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", ignoreResourceFlagName, err)
+		}
+		if !seen[resource] {
+			seen[resource] = true
+			ignored = append(ignored, resource)
+		}
+	}
+	return ignored, nil
+}
+
+// recordIgnoredResources validates the ignored resources and stores them in the plan
+// metadata, so commands that later display the plan artifact hide them too
+func recordIgnoredResources(command *cobra.Command, cfg config.Hook, plan *planner.Plan) error {
+	ignored, err := resolveIgnoredResources(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	plan.Metadata.IgnoredResources = nil
+	for _, resource := range ignored {
+		plan.Metadata.IgnoredResources = append(plan.Metadata.IgnoredResources, resource.String())
+	}
+	return nil
+}
+
+// ignorePlanResources returns the display copy of plan without the ignored resources
+func ignorePlanResources(command *cobra.Command, cfg config.Hook, plan *planner.Plan) (*planner.Plan, error) {
+	ignored, err := resolveIgnoredResources(command, cfg, plan)
+	if err != nil {
+		return nil, err
+	}
+	return plan.WithoutResources(ignored, resolveSummarizeIgnored(command, cfg)), nil
+}
+
+// resolveSummarizeIgnored returns the summarize-ignored flag, or the config file when unset
+func resolveSummarizeIgnored(command *cobra.Command, cfg config.Hook) bool {
+	if command.Flags().Lookup(summarizeIgnoredFlagName) == nil {
+		return false
+	}
+	if command.Flags().Changed(summarizeIgnoredFlagName) {
+		summarize, _ := command.Flags().GetBool(summarizeIgnoredFlagName)
+		return summarize
+	}
+	return cfg != nil && cfg.GetBool(summarizeIgnoredConfigPath)
+}
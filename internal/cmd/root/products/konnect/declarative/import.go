@@ -0,0 +1,121 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/importer"
+	"github.com/kong/kongctl/internal/declarative/validator"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+func newDeclarativeImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "konnect",
+		Short: "Import an existing Konnect resource into declarative management",
+		Long: `Bring a resource created outside kongctl under management so that apply and
+sync update it instead of creating it again.
+
+The resource is found by name and labeled with the kongctl namespace. Other fields
+are not changed. Add the resource to the configuration with the same name and ref;
+the next plan then only contains the fields that differ.`,
+		RunE: runImport,
+	}
+
+	cmd.Flags().String("resource", "",
+		fmt.Sprintf("Type of the resource to import (%s)", strings.Join(importer.ResourceTypes, ", ")))
+	cmd.Flags().String("name", "", "Name of the portal or API to import")
+	cmd.Flags().String("ref", "", "Ref of the resource in the declarative configuration")
+	cmd.Flags().String("namespace", "", "Namespace to manage the resource in (default \"default\")")
+	cmd.Flags().String("api", "", "Name of the API of the publication to import")
+	cmd.Flags().String("portal", "", "Name of the portal of the publication to import")
+	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text, json, or yaml)")
+	_ = cmd.MarkFlagRequired("resource")
+	_ = cmd.MarkFlagRequired("ref")
+
+	return cmd
+}
+
+func runImport(command *cobra.Command, args []string) error {
+	req := importer.Request{}
+	req.ResourceType, _ = command.Flags().GetString("resource")
+	req.Name, _ = command.Flags().GetString("name")
+	req.Ref, _ = command.Flags().GetString("ref")
+	req.Namespace, _ = command.Flags().GetString("namespace")
+	req.API, _ = command.Flags().GetString("api")
+	req.Portal, _ = command.Flags().GetString("portal")
+	req.ResourceType = strings.ReplaceAll(strings.TrimSpace(req.ResourceType), "-", "_")
+
+	if req.ResourceType != importer.ResourceTypeAPIPublication && req.Name == "" {
+		return fmt.Errorf("--name is required to import a %s", req.ResourceType)
+	}
+	if req.Namespace != "" {
+		if err := validator.NewNamespaceValidator().ValidateNamespace(req.Namespace); err != nil {
+			return &cmd.ConfigurationError{Err: err}
+		}
+	}
+	outputFormat, _ := command.Flags().GetString("output")
+	if outputFormat != textOutputFormat && outputFormat != "json" && outputFormat != "yaml" {
+		return fmt.Errorf("unsupported output format: %s (use text, json, or yaml)", outputFormat)
+	}
+
+	// Silence usage for all runtime errors (command syntax is already valid at this point)
+	command.SilenceUsage = true
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+	kkClient, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	result, err := importer.New(createStateClient(kkClient), drift.NewStore(path), cfg.GetProfile()).
+		Import(command.Context(), req)
+	if err != nil {
+		return cmd.PrepareExecutionErrorMsg(helper, err.Error())
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal import result to YAML: %w", err)
+		}
+		fmt.Fprint(command.OutOrStdout(), string(data))
+	default:
+		displayTextImport(command.OutOrStdout(), result)
+	}
+	return nil
+}
+
+func displayTextImport(out io.Writer, result *importer.Result) {
+	if result.AlreadyManaged {
+		fmt.Fprintf(out, "%s %q (%s) is already managed in namespace %q; recorded as %s\n",
+			result.ResourceType, result.Name, result.ID, result.Namespace, result.Ref)
+	} else {
+		fmt.Fprintf(out, "Imported %s %q (%s) into namespace %q as %s\n",
+			result.ResourceType, result.Name, result.ID, result.Namespace, result.Ref)
+	}
+	fmt.Fprintf(out, "Add it to the configuration with ref %q so apply updates it instead of creating it.\n",
+		result.Ref)
+}
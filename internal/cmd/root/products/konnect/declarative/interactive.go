@@ -0,0 +1,34 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// interactiveFlagName is the CLI flag reviewing each planned change before it is applied
+const interactiveFlagName = "interactive"
+
+func addInteractiveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolP(interactiveFlagName, "i", false,
+		`Review each planned change with its fields and choose to apply it, skip it or abort.
+Skipping a change also skips the changes that depend on it.`)
+}
+
+// interactiveRequested reports whether --interactive is set, and validates the flags
+// combined with it
+func interactiveRequested(command *cobra.Command) (bool, error) {
+	if interactive, _ := command.Flags().GetBool(interactiveFlagName); !interactive {
+		return false, nil
+	}
+
+	for _, name := range []string{"auto-approve", watchFlagName, canaryFlagName, profilesFlagName} {
+		if command.Flags().Changed(name) {
+			return false, fmt.Errorf("--%s cannot be combined with --%s", interactiveFlagName, name)
+		}
+	}
+	if outputFormat, _ := command.Flags().GetString("output"); outputFormat != textOutputFormat {
+		return false, fmt.Errorf("--%s requires the %s output format", interactiveFlagName, textOutputFormat)
+	}
+	return true, nil
+}
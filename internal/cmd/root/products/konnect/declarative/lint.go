@@ -0,0 +1,44 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/lint"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// lintRulesetFlagName is the CLI flag for the ruleset API version specs are linted with
+	lintRulesetFlagName = "lint-ruleset"
+	// lintRulesetConfigPath is the config path backing the lint-ruleset flag
+	lintRulesetConfigPath = "konnect.declarative." + lintRulesetFlagName
+)
+
+func addLintRulesetFlag(cmd *cobra.Command) {
+	cmd.Flags().String(lintRulesetFlagName, "",
+		fmt.Sprintf(`Path to a Spectral-compatible ruleset the OpenAPI specs of API versions are linted with
+before a plan is generated. Violations of error severity fail the command.
+- Config path: [ %s ]`, lintRulesetConfigPath))
+}
+
+// checkLint lints the API version specs of the loaded configuration with the configured
+// ruleset, if any. Other violations are written to warnOut; error violations fail the command.
+func checkLint(command *cobra.Command, cfg config.Hook, rs *resources.ResourceSet, warnOut io.Writer) error {
+	path, err := resolveFlagOrConfig(command, cfg, lintRulesetFlagName, lintRulesetConfigPath)
+	if err != nil || path == "" {
+		return err
+	}
+
+	ruleset, err := lint.Load(path)
+	if err != nil {
+		return err
+	}
+	warnings, err := ruleset.Check(rs)
+	for _, warning := range warnings {
+		fmt.Fprintf(warnOut, "Lint %s: %s\n", warning.Severity, warning)
+	}
+	return err
+}
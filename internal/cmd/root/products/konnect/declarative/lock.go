@@ -0,0 +1,132 @@
+package declarative
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/lock"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// lockFlagName is the CLI flag locking the namespaces of a plan while it is applied
+	lockFlagName = "lock"
+	// lockConfigPath is the config path backing the lock flag
+	lockConfigPath = "konnect.declarative." + lockFlagName
+	// forceUnlockFlagName is the CLI flag removing the locks left on the namespaces of a plan
+	forceUnlockFlagName = "force-unlock"
+)
+
+func addLockFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(lockFlagName, false,
+		fmt.Sprintf(`Lock the namespaces of the plan in Konnect while it is applied, so another kongctl
+run against the same namespaces fails fast with the holder and age of the lock.
+The lock is an organization team named kongctl-lock-<namespace>.
+- Config path: [ %s ]`, lockConfigPath))
+	cmd.Flags().Bool(forceUnlockFlagName, false,
+		"Remove the locks held on the namespaces of the plan, e.g. after an interrupted run, before locking them.")
+}
+
+// lockRequested reports whether the namespaces of the plan are locked, from the flag
+// or the config file when unset. --force-unlock implies --lock.
+func lockRequested(command *cobra.Command, cfg config.Hook) bool {
+	if command.Flags().Lookup(lockFlagName) == nil {
+		return false
+	}
+	if forceUnlock, _ := command.Flags().GetBool(forceUnlockFlagName); forceUnlock {
+		return true
+	}
+	if command.Flags().Changed(lockFlagName) {
+		enabled, _ := command.Flags().GetBool(lockFlagName)
+		return enabled
+	}
+	return cfg != nil && cfg.GetBool(lockConfigPath)
+}
+
+// lockNamespaces locks the namespaces of the plan when requested and returns the
+// function releasing the locks. Dry runs take no locks.
+func lockNamespaces(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	kkClient helpers.SDKAPI,
+	logger *slog.Logger,
+	plan *planner.Plan,
+	dryRun bool,
+) (func(), error) {
+	noop := func() {}
+	if dryRun || !lockRequested(command, cfg) {
+		return noop, nil
+	}
+
+	teamAPI := kkClient.GetOrganizationTeamAPI()
+	if teamAPI == nil {
+		return noop, fmt.Errorf("--%s requires the organization team API", lockFlagName)
+	}
+	locker := lock.NewLocker(teamAPI, lock.DefaultHolder(), command.CommandPath())
+	forceUnlock, _ := command.Flags().GetBool(forceUnlockFlagName)
+
+	var held []*lock.Lock
+	release := func() {
+		// Release even when the run was canceled
+		releaseCtx := context.WithoutCancel(ctx)
+		for i := len(held) - 1; i >= 0; i-- {
+			if err := locker.Release(releaseCtx, held[i]); err != nil {
+				logger.Warn("Failed to release namespace lock", "namespace", held[i].Namespace, "error", err)
+			}
+		}
+	}
+
+	for _, namespace := range planNamespaces(plan) {
+		if forceUnlock {
+			removed, err := locker.ForceUnlock(ctx, namespace)
+			if err != nil {
+				release()
+				return noop, err
+			}
+			for _, stale := range removed {
+				fmt.Fprintf(command.ErrOrStderr(), "Removed lock on namespace %q held by %s since %s\n",
+					namespace, stale.Holder, stale.AcquiredAt.Local().Format("2006-01-02 15:04:05"))
+			}
+		}
+
+		acquired, err := locker.Acquire(ctx, namespace)
+		if err != nil {
+			release()
+			var heldErr *lock.HeldError
+			if errors.As(err, &heldErr) {
+				return noop, fmt.Errorf("%w; if that run is no longer active, remove the lock with --%s",
+					err, forceUnlockFlagName)
+			}
+			return noop, err
+		}
+		held = append(held, acquired)
+		logger.Debug("Locked namespace", "namespace", namespace, "lock_id", acquired.ID)
+	}
+	return release, nil
+}
+
+// planNamespaces returns the namespaces the changes of a plan belong to, sorted so
+// concurrent runs lock them in the same order
+func planNamespaces(plan *planner.Plan) []string {
+	set := make(map[string]bool)
+	for _, change := range plan.Changes {
+		namespace := change.Namespace
+		if namespace == "" {
+			namespace = planner.DefaultNamespace
+		}
+		set[namespace] = true
+	}
+	namespaces := make([]string, 0, len(set))
+	for namespace := range set {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -0,0 +1,36 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// matchByNameFlagName is the CLI flag adopting unmanaged resources by name
+	matchByNameFlagName = "match-by-name"
+	// matchByNameConfigPath is the config path backing the match-by-name flag
+	matchByNameConfigPath = "konnect.declarative." + matchByNameFlagName
+)
+
+func addMatchByNameFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(matchByNameFlagName, false,
+		fmt.Sprintf(`Adopt portals and APIs created outside kongctl that are named like a resource of the
+configuration: they are updated and labeled with its namespace instead of created again.
+- Config path: [ %s ]`, matchByNameConfigPath))
+}
+
+// matchByName enables adoption by name in the planner options, from the flag or the
+// config file when unset
+func matchByName(command *cobra.Command, cfg config.Hook, opts *planner.Options) {
+	if command.Flags().Lookup(matchByNameFlagName) == nil {
+		return
+	}
+	if command.Flags().Changed(matchByNameFlagName) {
+		opts.MatchByName, _ = command.Flags().GetBool(matchByNameFlagName)
+	} else if cfg != nil {
+		opts.MatchByName = cfg.GetBool(matchByNameConfigPath)
+	}
+}
@@ -0,0 +1,28 @@
+package declarative
+
+import (
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// movedResources lets the planner find the resources the moved entries of the
+// configuration rename by the IDs last applied for the profile from this machine
+func movedResources(cfg config.Hook, rs *resources.ResourceSet, opts *planner.Options) error {
+	if cfg == nil || len(rs.Moved) == 0 {
+		return nil
+	}
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+	applied, err := drift.NewStore(path).Load(cfg.GetProfile())
+	if err != nil {
+		return err
+	}
+	opts.AppliedID = func(resourceType, ref string) string {
+		return applied[drift.Key(resourceType, ref)].ResourceID
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kong/kongctl/internal/declarative/oci"
+	"github.com/spf13/cobra"
+)
+
+// fromOCIFlagName is the CLI flag naming an OCI artifact holding the configuration
+const fromOCIFlagName = "from-oci"
+
+func addFromOCIFlag(cmd *cobra.Command) {
+	cmd.Flags().String(fromOCIFlagName, "",
+		`Pull the configuration from a config bundle stored as an OCI artifact (e.g. registry.example.com/configs/portal:v1).
+!file tags resolve within the bundle. Credentials come from KONGCTL_OCI_USERNAME and KONGCTL_OCI_PASSWORD
+or the Docker configuration.`)
+}
+
+// checkFromOCIFlags rejects flags that choose another configuration source
+func checkFromOCIFlags(command *cobra.Command) error {
+	for _, name := range []string{"filename", "plan", baseDirFlagName, changedSinceFlagName} {
+		if command.Flags().Changed(name) {
+			return fmt.Errorf("--%s cannot be combined with --%s", fromOCIFlagName, name)
+		}
+	}
+	return nil
+}
+
+// pullOCIBundle pulls the config bundle ref points to into a temporary directory. The
+// returned cleanup removes the directory.
+func pullOCIBundle(ctx context.Context, ref string, logOut io.Writer) (*oci.Bundle, func(), error) {
+	parsed, err := oci.ParseReference(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "kongctl-oci-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	bundle, err := oci.NewClient(nil, nil).Pull(ctx, parsed, dir)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to pull config bundle: %w", err)
+	}
+	if logOut != nil {
+		fmt.Fprintf(logOut, "Using configuration from: %s@%s\n", bundle.Reference, bundle.Digest)
+	}
+	return bundle, cleanup, nil
+}
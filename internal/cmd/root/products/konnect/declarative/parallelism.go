@@ -0,0 +1,42 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// parallelismFlagName is the CLI flag for how many Konnect requests run at once
+	parallelismFlagName = "parallelism"
+	// parallelismConfigPath is the config path backing the parallelism flag
+	parallelismConfigPath = "konnect.declarative." + parallelismFlagName
+)
+
+func addParallelismFlag(cmd *cobra.Command) {
+	cmd.Flags().Int(parallelismFlagName, executor.DefaultParallelism,
+		fmt.Sprintf(`Number of Konnect requests to run at once: reads of current state while planning, and `+
+			`independent changes while executing. Changes still wait for the changes they depend on.
+- Config path: [ %s ]`, parallelismConfigPath))
+}
+
+// resolveParallelism reads the parallelism from the flag, or the config file when unset
+func resolveParallelism(command *cobra.Command, cfg config.Hook) (int, error) {
+	if command.Flags().Lookup(parallelismFlagName) == nil {
+		return executor.DefaultParallelism, nil
+	}
+
+	parallelism, err := command.Flags().GetInt(parallelismFlagName)
+	if err != nil {
+		return 0, err
+	}
+	if !command.Flags().Changed(parallelismFlagName) && cfg != nil {
+		parallelism = cfg.GetIntOrElse(parallelismConfigPath, executor.DefaultParallelism)
+	}
+	if parallelism < 1 {
+		return 0, fmt.Errorf("invalid --%s %d: must be at least 1", parallelismFlagName, parallelism)
+	}
+	return parallelism, nil
+}
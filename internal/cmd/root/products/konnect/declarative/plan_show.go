@@ -0,0 +1,228 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+// planShowDetailsFlagName is the CLI flag adding the fields of each change to plan show
+const planShowDetailsFlagName = "details"
+
+// NewPlanShowCmd creates the command rendering a saved plan for review
+func NewPlanShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <plan-file>",
+		Short: "Show a summary of a saved plan",
+		Long: `Render a plan file written by plan as a concise summary: the change counts,
+the risk and warnings, and one line per change in execution order naming the
+fields it updates. Use --details to also show the field values, as old → new for
+updates. Sensitive fields are redacted. Use - to read the plan from stdin.`,
+		Example: `  # Summarize a plan for a pull request comment
+  kongctl plan show plan.json --no-color
+
+  # Review the field changes
+  kongctl plan show plan.json --details`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPlanShow,
+	}
+	cmd.Flags().Bool(planShowDetailsFlagName, false, "Show the fields each change sets")
+	cmd.Flags().Bool(diffNoColorFlagName, false, "Disable colors in text output")
+	addSensitiveFieldsFlag(cmd)
+	return cmd
+}
+
+func runPlanShow(command *cobra.Command, args []string) error {
+	command.SilenceUsage = true
+
+	cfg, err := cmd.BuildHelper(command, args).GetConfig()
+	if err != nil {
+		return err
+	}
+	plan, err := common.LoadPlan(args[0], command.InOrStdin())
+	if err != nil {
+		return err
+	}
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	details, _ := command.Flags().GetBool(planShowDetailsFlagName)
+	renderPlanSummary(command.OutOrStdout(), redactor.Plan(plan), details, newDiffColors(command, cfg))
+	return nil
+}
+
+// renderPlanSummary writes the header, counts and change list of plan. With details,
+// the fields of each change follow it.
+func renderPlanSummary(out io.Writer, plan *planner.Plan, details bool, colors diffColors) {
+	meta := plan.Metadata
+	header := []string{fmt.Sprintf("%s mode", meta.Mode)}
+	if meta.PlanID != "" {
+		header = append([]string{"Plan " + meta.PlanID}, header...)
+	}
+	if !meta.GeneratedAt.IsZero() {
+		header = append(header, "generated "+meta.GeneratedAt.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	if meta.Generator != "" {
+		header = append(header, "by "+meta.Generator)
+	}
+	fmt.Fprintln(out, strings.Join(header, ", "))
+
+	if plan.IsEmpty() {
+		fmt.Fprintln(out, "No changes.")
+		return
+	}
+
+	counts := []string{
+		colors.action(planner.ActionCreate, fmt.Sprintf("%d to add", plan.Summary.ByAction[planner.ActionCreate])),
+		colors.action(planner.ActionUpdate, fmt.Sprintf("%d to change", plan.Summary.ByAction[planner.ActionUpdate])),
+		colors.action(planner.ActionDelete, fmt.Sprintf("%d to destroy", plan.Summary.ByAction[planner.ActionDelete])),
+	}
+	if n := plan.Summary.ByAction[planner.ActionExternalTool]; n > 0 {
+		counts = append(counts, fmt.Sprintf("%d external tool step", n))
+	}
+	if n := plan.Summary.ByAction[planner.ActionSwitch]; n > 0 {
+		counts = append(counts, fmt.Sprintf("%d to switch", n))
+	}
+	fmt.Fprintf(out, "Changes: %s\n", strings.Join(counts, ", "))
+	if risk := plan.Summary.Risk; risk != nil {
+		fmt.Fprintf(out, "Risk: %s (score %d)\n", risk.Level, risk.Score)
+	}
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(out, "Warning: [%s] %s\n", warning.ChangeID, warning.Message)
+	}
+	fmt.Fprintln(out)
+
+	for _, change := range changesInOrder(plan) {
+		line := fmt.Sprintf("%s %s %s", actionSymbol(change.Action), change.ResourceType, changeName(change))
+		if change.Action == planner.ActionUpdate && !details {
+			if fields := updatedFields(change); len(fields) > 0 {
+				line += " (" + strings.Join(fields, ", ") + ")"
+			}
+		}
+		if change.Risk != nil && change.Risk.Level != planner.RiskLevelNone {
+			line += fmt.Sprintf(" [risk: %s]", change.Risk.Level)
+		}
+		fmt.Fprintln(out, colors.action(change.Action, line))
+
+		if details {
+			renderChangeFields(out, change, colors)
+			renderExplanation(out, change, "    ", colors)
+		}
+	}
+}
+
+// changesInOrder returns the changes of plan in execution order, followed by any
+// change missing from it
+func changesInOrder(plan *planner.Plan) []planner.PlannedChange {
+	position := make(map[string]int, len(plan.ExecutionOrder))
+	for i, id := range plan.ExecutionOrder {
+		position[id] = i
+	}
+	changes := slices.Clone(plan.Changes)
+	slices.SortStableFunc(changes, func(a, b planner.PlannedChange) int {
+		pa, okA := position[a.ID]
+		pb, okB := position[b.ID]
+		switch {
+		case okA && okB:
+			return pa - pb
+		case okA:
+			return -1
+		case okB:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return changes
+}
+
+func actionSymbol(action planner.ActionType) string {
+	switch action {
+	case planner.ActionCreate:
+		return "+"
+	case planner.ActionUpdate:
+		return "~"
+	case planner.ActionDelete:
+		return "-"
+	case planner.ActionSwitch:
+		return "^"
+	case planner.ActionExternalTool:
+		return ">"
+	default:
+		return "?"
+	}
+}
+
+// changeName names the resource of a change by its ref, or its name when the ref is unknown
+func changeName(change planner.PlannedChange) string {
+	if change.ResourceRef != "" && change.ResourceRef != "[unknown]" {
+		return change.ResourceRef
+	}
+	if name, ok := change.Fields["name"].(string); ok && name != "" {
+		return name
+	}
+	return change.ResourceRef
+}
+
+// updatedFields returns the names of the fields an update changes, leaving out the
+// internal fields and the fields that only identify the resource
+func updatedFields(change planner.PlannedChange) []string {
+	var fields []string
+	for _, name := range sortedFieldNames(change.Fields) {
+		if !strings.HasPrefix(name, "_") && name != "current_labels" && !slices.Contains(change.IdentityFields, name) {
+			fields = append(fields, name)
+		}
+	}
+	if protection, ok := protectionChangeOf(change.Protection); ok && protection.Old != protection.New {
+		fields = append(fields, "protection")
+	}
+	return fields
+}
+
+// protectionChangeOf returns the protection change of an update, which plans read
+// from JSON hold as a map
+func protectionChangeOf(protection any) (planner.ProtectionChange, bool) {
+	switch p := protection.(type) {
+	case planner.ProtectionChange:
+		return p, true
+	case map[string]any:
+		oldValue, hasOld := p["old"].(bool)
+		newValue, hasNew := p["new"].(bool)
+		return planner.ProtectionChange{Old: oldValue, New: newValue}, hasOld && hasNew
+	default:
+		return planner.ProtectionChange{}, false
+	}
+}
+
+func renderChangeFields(out io.Writer, change planner.PlannedChange, colors diffColors) {
+	const indent = "    "
+	for _, name := range updatedFields(change) {
+		if name == "protection" {
+			protection, _ := protectionChangeOf(change.Protection)
+			fmt.Fprintf(out, "%sprotection: %s\n", indent, colors.change(protection.Old, protection.New))
+			continue
+		}
+		value := change.Fields[name]
+		if fc, ok := value.(planner.FieldChange); ok {
+			fmt.Fprintf(out, "%s%s: %s\n", indent, name, colors.change(fc.Old, fc.New))
+			continue
+		}
+		if fc, ok := value.(map[string]any); ok && len(fc) == 2 {
+			oldValue, hasOld := fc["old"]
+			newValue, hasNew := fc["new"]
+			if hasOld && hasNew {
+				fmt.Fprintf(out, "%s%s: %s\n", indent, name, colors.change(oldValue, newValue))
+				continue
+			}
+		}
+		displayField(out, name, value, indent, false)
+	}
+}
@@ -0,0 +1,164 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/policy"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// policyFileFlagName is the CLI flag for the governance policy file
+	policyFileFlagName = "policy-file"
+	// policyFileConfigPath is the config path backing the policy-file flag
+	policyFileConfigPath = "konnect.declarative." + policyFileFlagName
+	// planPolicyFlagName is the CLI flag for the policies planned changes must satisfy
+	planPolicyFlagName = "policy"
+	// planPolicyConfigPath is the config path backing the policy flag
+	planPolicyConfigPath = "konnect.declarative." + planPolicyFlagName
+)
+
+func addPolicyFileFlag(cmd *cobra.Command) {
+	cmd.Flags().String(policyFileFlagName, "",
+		fmt.Sprintf(`Path to a YAML or JSON policy whose rules the configuration must satisfy before a plan is generated.
+- Config path: [ %s ]`, policyFileConfigPath))
+}
+
+func addPlanPolicyFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(planPolicyFlagName, nil,
+		fmt.Sprintf(`Path to a YAML or JSON policy file, or a directory of them, whose rules every planned change
+must satisfy (can specify multiple). Plans with error violations fail.
+- Config path: [ %s ]`, planPolicyConfigPath))
+}
+
+// loadPolicy reads and validates a policy file
+func loadPolicy(path string) (*policy.Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var p policy.Policy
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// checkPolicy evaluates the configured policy, if any, against the loaded configuration.
+// Warnings are written to warnOut; error violations fail the command.
+func checkPolicy(command *cobra.Command, cfg config.Hook, rs *resources.ResourceSet, warnOut io.Writer) error {
+	path, err := resolveFlagOrConfig(command, cfg, policyFileFlagName, policyFileConfigPath)
+	if err != nil || path == "" {
+		return err
+	}
+
+	p, err := loadPolicy(path)
+	if err != nil {
+		return err
+	}
+	warnings, err := p.Check(rs)
+	for _, warning := range warnings {
+		fmt.Fprintf(warnOut, "Policy warning: %s\n", warning)
+	}
+	return err
+}
+
+// policyFiles returns the policy files of paths. Directories contribute their YAML and
+// JSON files, in name order.
+func policyFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy directory: %w", err)
+		}
+		var dirFiles []string
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml" || ext == ".json") {
+				dirFiles = append(dirFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+		if len(dirFiles) == 0 {
+			return nil, fmt.Errorf("no YAML or JSON policy files found in %s", path)
+		}
+		slices.Sort(dirFiles)
+		files = append(files, dirFiles...)
+	}
+	return files, nil
+}
+
+// loadPlanPolicy reads, merges and validates the plan policy files of paths
+func loadPlanPolicy(paths []string) (*policy.PlanPolicy, error) {
+	files, err := policyFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &policy.PlanPolicy{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy: %w", err)
+		}
+		var p policy.PlanPolicy
+		if err := yaml.UnmarshalStrict(data, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse policy %s: %w", file, err)
+		}
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid policy %s: %w", file, err)
+		}
+		merged.Merge(&p)
+	}
+	// Rule names must also be unique across files
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policies: %w", err)
+	}
+	return merged, nil
+}
+
+// checkPlanPolicy evaluates the configured plan policies, if any, against the changes of
+// plan. Warnings are written to warnOut; error violations fail the command.
+func checkPlanPolicy(command *cobra.Command, cfg config.Hook, plan *planner.Plan, warnOut io.Writer) error {
+	var paths []string
+	if flag := command.Flags().Lookup(planPolicyFlagName); flag != nil && flag.Changed {
+		paths, _ = command.Flags().GetStringSlice(planPolicyFlagName)
+	} else if cfg != nil {
+		paths = cfg.GetStringSlice(planPolicyConfigPath)
+	}
+	paths = slices.DeleteFunc(paths, func(path string) bool { return strings.TrimSpace(path) == "" })
+	if len(paths) == 0 {
+		return nil
+	}
+
+	p, err := loadPlanPolicy(paths)
+	if err != nil {
+		return err
+	}
+	warnings, err := p.Check(plan)
+	for _, warning := range warnings {
+		fmt.Fprintf(warnOut, "Policy warning: %s\n", warning)
+	}
+	return err
+}
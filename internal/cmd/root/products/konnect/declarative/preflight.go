@@ -0,0 +1,148 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kong/kongctl/internal/cmd"
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/preflight"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+)
+
+const preflightFlagName = "preflight"
+
+func addPreflightFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(preflightFlagName, false,
+		"Check the configuration, the Konnect region, the access token and its permissions before planning, "+
+			"reporting every problem at once (see 'doctor')")
+}
+
+func newDeclarativeDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "konnect",
+		Short: "Check that the environment can apply declarative configuration to Konnect",
+		Long: `Check that the environment can apply declarative configuration to Konnect.
+
+Konnect is contacted in the region of the profile to check that it is reachable and
+accepts the access token, and that the organization of the token is active. With -f,
+the configuration is validated as by 'validate', including its refs and labels, and
+one resource of each kind it declares is listed to check that the token may manage
+them and that the organization has the feature, such as portals, enabled. Every
+problem is reported at once, and the command exits with a non-zero status if any are
+found. 'apply --preflight' runs the same checks before planning.`,
+		RunE: runDoctor,
+	}
+
+	cmd.Flags().StringSliceP("filename", "f", []string{},
+		"Filename or directory to files to check (can specify multiple)")
+	cmd.Flags().BoolP("recursive", "R", false,
+		"Process the directory used in -f, --filename recursively")
+	addBaseDirFlag(cmd)
+	addNamespaceFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+
+	return cmd
+}
+
+func runDoctor(command *cobra.Command, args []string) error {
+	// Silence usage for all runtime errors (command syntax is already valid at this point)
+	command.SilenceUsage = true
+
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	recursive, _ := command.Flags().GetBool("recursive")
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+	// A missing token is one of the problems reported
+	kkClient, sdkErr := helper.GetKonnectSDK(cfg, logger)
+	if sdkErr != nil {
+		kkClient = nil
+	}
+
+	var sources []loader.Source
+	if len(filenames) > 0 {
+		if sources, err = loader.ParseSources(filenames); err != nil {
+			return fmt.Errorf("failed to parse sources: %w", err)
+		}
+	}
+	report, err := preflightReport(command.Context(), command, cfg, kkClient, sdkErr, sources, recursive)
+	if err != nil {
+		return err
+	}
+
+	report.Write(command.OutOrStdout())
+	if problems := report.Problems(); problems > 0 {
+		return cmd.PrepareExecutionErrorMsg(helper, fmt.Sprintf("preflight failed: %d problem(s) found", problems))
+	}
+	return nil
+}
+
+// checkPreflight runs the preflight checks of apply --preflight and stops the apply
+// when they find a problem. Without sources, as for a saved plan, only the
+// environment is checked.
+func checkPreflight(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	kkClient helpers.SDKAPI,
+	sources []loader.Source,
+	recursive bool,
+	out io.Writer,
+) error {
+	if enabled, _ := command.Flags().GetBool(preflightFlagName); !enabled {
+		return nil
+	}
+	report, err := preflightReport(ctx, command, cfg, kkClient, nil, sources, recursive)
+	if err != nil {
+		return err
+	}
+	report.Write(out)
+	if problems := report.Problems(); problems > 0 {
+		return fmt.Errorf("preflight checks found %d problem(s); nothing was planned or applied", problems)
+	}
+	return nil
+}
+
+// preflightReport validates the configuration of sources, when there are any, and
+// checks the environment it is applied to
+func preflightReport(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	kkClient helpers.SDKAPI,
+	sdkErr error,
+	sources []loader.Source,
+	recursive bool,
+) (*preflight.Report, error) {
+	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts := preflight.Options{BaseURL: baseURL, SDK: kkClient, SDKError: sdkErr}
+
+	if len(sources) > 0 {
+		ldr, err := newDeclarativeLoader(command, cfg)
+		if err != nil {
+			return nil, err
+		}
+		resourceSet, issues := ldr.Validate(ctx, sources, recursive)
+		opts.Resources = resourceSet
+		for _, issue := range issues {
+			opts.Issues = append(opts.Issues, issue.String())
+		}
+	}
+	return preflight.Run(ctx, opts), nil
+}
@@ -0,0 +1,88 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/impact"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	previewFlagName     = "preview"
+	previewTypeFlagName = "type"
+	previewRefFlagName  = "ref"
+)
+
+func addDeletePreviewFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(previewFlagName, false,
+		"Show what the deletions cascade to and what still references them, without deleting anything")
+	cmd.Flags().String(previewTypeFlagName, "",
+		fmt.Sprintf("Resource type of the single resource to preview, e.g. portal (requires --%s)", previewFlagName))
+	cmd.Flags().String(previewRefFlagName, "",
+		fmt.Sprintf("Ref of the single resource to preview (requires --%s)", previewFlagName))
+}
+
+// previewRequested reports whether delete should only preview its impact, and returns
+// the single resource to preview when --type and --ref are set
+func previewRequested(command *cobra.Command) (bool, *resources.ResourceRef, error) {
+	preview, _ := command.Flags().GetBool(previewFlagName)
+	resourceType, _ := command.Flags().GetString(previewTypeFlagName)
+	ref, _ := command.Flags().GetString(previewRefFlagName)
+
+	if !preview {
+		for _, name := range []string{previewTypeFlagName, previewRefFlagName} {
+			if command.Flags().Changed(name) {
+				return false, nil, fmt.Errorf("--%s requires --%s", name, previewFlagName)
+			}
+		}
+		return false, nil, nil
+	}
+	if (resourceType == "") != (ref == "") {
+		return false, nil, fmt.Errorf("--%s and --%s must be used together", previewTypeFlagName, previewRefFlagName)
+	}
+	if resourceType == "" {
+		return true, nil, nil
+	}
+	if !resources.IsRegistered(resources.ResourceType(resourceType)) {
+		return false, nil, fmt.Errorf("invalid --%s: unknown resource type %q", previewTypeFlagName, resourceType)
+	}
+	return true, &resources.ResourceRef{Kind: resourceType, Ref: ref}, nil
+}
+
+// outputDeletePreview writes the cascade and blocking relationships of the delete plan
+func outputDeletePreview(
+	ctx context.Context,
+	command *cobra.Command,
+	plan *planner.Plan,
+	rs *resources.ResourceSet,
+	stateClient *state.Client,
+	only *resources.ResourceRef,
+	outputFormat string,
+) error {
+	preview, err := impact.Build(ctx, plan, rs, stateClient, only)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(preview)
+	case "yaml":
+		data, err := yaml.Marshal(preview)
+		if err != nil {
+			return fmt.Errorf("failed to marshal delete preview to YAML: %w", err)
+		}
+		fmt.Fprint(command.OutOrStdout(), string(data))
+	default:
+		preview.WriteText(command.OutOrStdout())
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/multiprofile"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	profilesFlagName      = "profiles"
+	failFastFlagName      = "fail-fast"
+	maxConcurrentFlagName = "max-concurrent-profiles"
+)
+
+func addProfilesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(profilesFlagName, nil,
+		"Apply the configuration to each of these profiles concurrently (e.g. prod-us,prod-eu)")
+	cmd.Flags().Bool(failFastFlagName, false,
+		fmt.Sprintf("Stop applying to the other profiles once one fails (requires --%s)", profilesFlagName))
+	cmd.Flags().Int(maxConcurrentFlagName, 0,
+		fmt.Sprintf("Maximum number of profiles applied at the same time, 0 for all (requires --%s)", profilesFlagName))
+}
+
+// profilesRequested reports whether apply should run against several profiles, and
+// rejects flags that only make sense for a single organization
+func profilesRequested(command *cobra.Command) (bool, error) {
+	if !command.Flags().Changed(profilesFlagName) {
+		for _, name := range []string{failFastFlagName, maxConcurrentFlagName} {
+			if command.Flags().Changed(name) {
+				return false, fmt.Errorf("--%s requires --%s", name, profilesFlagName)
+			}
+		}
+		return false, nil
+	}
+	if maxConcurrent, _ := command.Flags().GetInt(maxConcurrentFlagName); maxConcurrent < 0 {
+		return false, fmt.Errorf("invalid --%s %d: must not be negative", maxConcurrentFlagName, maxConcurrent)
+	}
+
+	for _, name := range []string{"plan", "execution-report-file", writeIDsFlagName, reportFlagName} {
+		if command.Flags().Changed(name) {
+			return false, fmt.Errorf("--%s cannot be combined with --%s", profilesFlagName, name)
+		}
+	}
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	autoApprove, _ := command.Flags().GetBool("auto-approve")
+	if !dryRun && !autoApprove {
+		return false, fmt.Errorf("--%s requires --auto-approve or --dry-run "+
+			"(changes to several organizations cannot be confirmed interactively)", profilesFlagName)
+	}
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	if slices.Contains(filenames, "-") {
+		return false, fmt.Errorf("--%s cannot read configuration from stdin", profilesFlagName)
+	}
+	if outputFormat, _ := command.Flags().GetString("output"); outputFormat == ndjsonOutputFormat {
+		return false, fmt.Errorf("--%s does not support %s output", profilesFlagName, ndjsonOutputFormat)
+	}
+	return true, nil
+}
+
+// runProfilesApply applies the configuration to every profile of --profiles
+// concurrently and reports the outcome of each
+func runProfilesApply(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	dryRun, _ := command.Flags().GetBool("dry-run")
+	failFast, _ := command.Flags().GetBool(failFastFlagName)
+	maxConcurrent, _ := command.Flags().GetInt(maxConcurrentFlagName)
+	outputFormat, _ := command.Flags().GetString("output")
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	values, _ := command.Flags().GetStringSlice(profilesFlagName)
+
+	profiles, err := multiprofile.ParseProfiles(values)
+	if err != nil {
+		return &cmd.ConfigurationError{Err: fmt.Errorf("invalid --%s: %w", profilesFlagName, err)}
+	}
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	profiled, ok := cfg.(*config.ProfiledConfig)
+	if !ok {
+		return fmt.Errorf("--%s is not supported with this configuration", profilesFlagName)
+	}
+	for _, profile := range profiles {
+		if err := profiled.ForProfile(profile).ValidateProfile(); err != nil {
+			return &cmd.ConfigurationError{Err: fmt.Errorf("invalid --%s: %w", profilesFlagName, err)}
+		}
+	}
+
+	ctx, finishTracing, err := startTracing(ctx, command, cfg, helper)
+	if err != nil {
+		return err
+	}
+	defer finishTracing()
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	var logOut io.Writer
+	if outputFormat == textOutputFormat {
+		logOut = command.OutOrStderr()
+	}
+	if fromOCI, _ := command.Flags().GetString(fromOCIFlagName); fromOCI != "" {
+		if err := checkFromOCIFlags(command); err != nil {
+			return err
+		}
+		bundle, cleanup, err := pullOCIBundle(ctx, fromOCI, logOut)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		filenames = []string{bundle.Dir}
+	}
+	if logOut != nil {
+		if maxConcurrent > 0 && maxConcurrent < len(profiles) {
+			fmt.Fprintf(logOut, "Applying to %d profiles, %d at a time...\n", len(profiles), maxConcurrent)
+		} else {
+			fmt.Fprintf(logOut, "Applying to %d profiles concurrently...\n", len(profiles))
+		}
+	}
+
+	report := multiprofile.Run(ctx, profiles, multiprofile.Options{FailFast: failFast, MaxConcurrent: maxConcurrent},
+		func(ctx context.Context, profile string) (*executor.ExecutionResult, error) {
+			profileCfg := cfg
+			if profile != cfg.GetProfile() {
+				profileCfg = profiled.ForProfile(profile)
+			}
+			return applyToProfile(ctx, command, helper, profileCfg, logger.With("profile", profile), filenames, dryRun)
+		})
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal profile results to YAML: %w", err)
+		}
+		fmt.Fprint(command.OutOrStdout(), string(data))
+	default:
+		report.WriteText(command.OutOrStdout())
+	}
+	return report.Err()
+}
+
+// applyToProfile plans and executes the configuration against the organization of
+// one profile, with the checks and records of a single apply. Progress is not
+// reported while profiles run concurrently.
+func applyToProfile(
+	ctx context.Context,
+	command *cobra.Command,
+	helper cmd.Helper,
+	cfg config.Hook,
+	logger *slog.Logger,
+	filenames []string,
+	dryRun bool,
+) (*executor.ExecutionResult, error) {
+	autoApprove, _ := command.Flags().GetBool("auto-approve")
+
+	timeouts, err := resolveTimeouts(command, cfg)
+	if err != nil {
+		return nil, err
+	}
+	parallelism, err := resolveParallelism(command, cfg)
+	if err != nil {
+		return nil, err
+	}
+	kkClient, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+
+	plan, fileHooks, err := planApply(ctx, command, cfg, kkClient, logger, filenames, planGenerator(helper), parallelism)
+	if err != nil {
+		return nil, err
+	}
+	redactor, err := checkApplyPlan(command, cfg, plan, autoApprove, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if plan.IsEmpty() {
+		return &executor.ExecutionResult{DryRun: dryRun, ChangesApplied: []executor.AppliedChange{}}, nil
+	}
+
+	unlock, err := lockNamespaces(ctx, command, cfg, kkClient, logger, plan, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	result, postHooks, err := executeApply(ctx, command, cfg, kkClient, logger, plan, applyExecution{
+		redactor:  redactor,
+		fileHooks: fileHooks,
+		dryRun:    dryRun,
+		options: executor.Options{
+			PlanBaseDir: resolvePlanBaseDir(""),
+			Timeouts:    timeouts,
+			Parallelism: parallelism,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	runHooks(command, postHooks, "apply", cfg.GetProfile(), plan, result)
+	return result, nil
+}
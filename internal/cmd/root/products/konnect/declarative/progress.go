@@ -0,0 +1,61 @@
+package declarative
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// progressFlagName is the CLI flag for how execution progress is shown
+	progressFlagName = "progress"
+	// progressConfigPath is the config path backing the progress flag
+	progressConfigPath = "konnect.declarative." + progressFlagName
+
+	progressAuto  = "auto"
+	progressBar   = "bar"
+	progressLines = "lines"
+	progressPlain = "plain"
+)
+
+func addProgressFlag(cmd *cobra.Command) {
+	cmd.Flags().String(progressFlagName, progressAuto,
+		fmt.Sprintf(`How to show execution progress: %q draws a progress bar on a terminal and writes a line `+
+			`per change otherwise, %q and %q force either, and %q keeps the compact per-change output. `+
+			`All but %q end with a summary table per resource type.
+- Config path: [ %s ]`,
+			progressAuto, progressBar, progressLines, progressPlain, progressPlain, progressConfigPath))
+}
+
+// newProgressReporter creates the reporter showing execution progress on out
+func newProgressReporter(
+	command *cobra.Command, cfg config.Hook, out io.Writer, dryRun bool,
+) (executor.ProgressReporter, error) {
+	mode := progressAuto
+	if command.Flags().Lookup(progressFlagName) != nil {
+		mode, _ = command.Flags().GetString(progressFlagName)
+		if !command.Flags().Changed(progressFlagName) && cfg != nil {
+			if configured := cfg.GetString(progressConfigPath); configured != "" {
+				mode = configured
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case progressAuto:
+		return executor.NewLiveReporter(out, isDiffTerminal(out), dryRun), nil
+	case progressBar:
+		return executor.NewLiveReporter(out, true, dryRun), nil
+	case progressLines:
+		return executor.NewLiveReporter(out, false, dryRun), nil
+	case progressPlain:
+		return executor.NewConsoleReporterWithOptions(out, dryRun), nil
+	default:
+		return nil, fmt.Errorf("invalid --%s %q: must be one of %s, %s, %s or %s",
+			progressFlagName, mode, progressAuto, progressBar, progressLines, progressPlain)
+	}
+}
@@ -0,0 +1,56 @@
+package declarative
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+// confirmDeleteProtectedFlagName is the CLI flag naming protected resources to delete anyway
+const confirmDeleteProtectedFlagName = "confirm-delete-protected"
+
+// addConfirmDeleteProtectedFlag adds the flag confirming the deletion of protected
+// resources. Commands that delete resources also require --force.
+func addConfirmDeleteProtectedFlag(cmd *cobra.Command, requireForce bool) {
+	usage := "Delete these protected resources (kongctl.protected: true) anyway, by name"
+	if requireForce {
+		usage += fmt.Sprintf(" (requires --%s)", forceFlagName)
+	}
+	cmd.Flags().StringSlice(confirmDeleteProtectedFlagName, nil, usage)
+}
+
+// confirmProtectedDeletes passes the protected resources confirmed for deletion to
+// the planner
+func confirmProtectedDeletes(command *cobra.Command, opts *planner.Options) {
+	opts.ConfirmedProtectedDeletes, _ = command.Flags().GetStringSlice(confirmDeleteProtectedFlagName)
+}
+
+// checkProtectedDeletes refuses to execute a plan deleting protected resources unless
+// --force is set and every one of them is named by --confirm-delete-protected
+func checkProtectedDeletes(command *cobra.Command, plan *planner.Plan) error {
+	confirmed, _ := command.Flags().GetStringSlice(confirmDeleteProtectedFlagName)
+	force, _ := command.Flags().GetBool(forceFlagName)
+	if len(confirmed) > 0 && !force {
+		return fmt.Errorf("--%s requires --%s", confirmDeleteProtectedFlagName, forceFlagName)
+	}
+
+	var unconfirmed []string
+	for _, change := range plan.Changes {
+		if !change.DeleteProtected {
+			continue
+		}
+		name := common.ExtractResourceName(change.Fields)
+		if !force || !slices.Contains(confirmed, name) {
+			unconfirmed = append(unconfirmed, fmt.Sprintf("%s %q", change.ResourceType, name))
+		}
+	}
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("plan deletes protected resources: %s; pass --%s and --%s with their names to delete them",
+		strings.Join(unconfirmed, ", "), forceFlagName, confirmDeleteProtectedFlagName)
+}
@@ -0,0 +1,46 @@
+package declarative
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+	"github.com/spf13/cobra"
+)
+
+// recordFlagName is the CLI flag recording the Konnect interactions of plan and apply
+const recordFlagName = "record"
+
+func addRecordFlag(cmd *cobra.Command) {
+	cmd.Flags().String(recordFlagName, "", `Record the Konnect requests of the command and their responses to
+<dir>/<command>.json, for replay in tests without network access. Headers are not
+recorded and the values of credential fields are redacted, but the recording holds
+the data of the organization; review it before committing it.`)
+}
+
+// withRecording runs the command recording its Konnect interactions when --record is
+// passed. The recording is written even when the command fails, as failures are what
+// regression tests replay most.
+func withRecording(name string, run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(command *cobra.Command, args []string) error {
+		dir, _ := command.Flags().GetString(recordFlagName)
+		if dir == "" {
+			return run(command, args)
+		}
+
+		recorder := httpclient.NewInteractionRecorder()
+		command.SetContext(httpclient.WithInteractionRecorder(command.Context(), recorder))
+		runErr := run(command, args)
+
+		path := filepath.Join(dir, name+".json")
+		if err := recorder.Save(path); err != nil {
+			if runErr != nil {
+				return runErr
+			}
+			return err
+		}
+		fmt.Fprintf(command.ErrOrStderr(), "Recorded %d Konnect interaction(s) to %s\n",
+			len(recorder.Cassette().Interactions), path)
+		return runErr
+	}
+}
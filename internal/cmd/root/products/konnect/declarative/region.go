@@ -0,0 +1,245 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+// regionPass is one run of a command over the resources of one Konnect region of a
+// configuration whose files set _defaults.region
+type regionPass struct {
+	// region is the region of the pass, "" for the region of the profile
+	region string
+	// defaultRegion is the region of the profile, "" for a custom base URL
+	defaultRegion string
+	// multiple is set when the configuration spans several regions
+	multiple bool
+}
+
+type regionPassKey struct{}
+
+// regionPassOf returns the region pass the command runs in, or nil
+func regionPassOf(command *cobra.Command) *regionPass {
+	if command.Context() == nil {
+		return nil
+	}
+	pass, _ := command.Context().Value(regionPassKey{}).(*regionPass)
+	return pass
+}
+
+// profileRegion returns the Konnect region of the profile, or "" when the profile
+// sets a custom base URL without a region
+func profileRegion(cfg config.Hook) string {
+	if region := strings.ToLower(strings.TrimSpace(cfg.GetString(konnectcommon.RegionConfigPath))); region != "" {
+		return region
+	}
+	if baseURL := strings.TrimSpace(cfg.GetString(konnectcommon.BaseURLConfigPath)); baseURL != "" &&
+		baseURL != konnectcommon.BaseURLDefault {
+		return ""
+	}
+	return "us"
+}
+
+// scopeLoaderToRegion keeps the resources of the region of the pass the command runs
+// in. Outside a pass, the loader rejects resources of other regions.
+func scopeLoaderToRegion(command *cobra.Command, cfg config.Hook, ldr *loader.Loader) {
+	pass := regionPassOf(command)
+	if pass == nil {
+		ldr.SetDefaultRegion(profileRegion(cfg))
+		return
+	}
+	ldr.SetDefaultRegion(pass.defaultRegion)
+	ldr.SelectRegion(pass.region)
+}
+
+// runPerRegion runs a command once for each Konnect region of the configuration,
+// against the endpoint of the region, when its files set _defaults.region. A --plan
+// artifact runs against the region it was generated for.
+func runPerRegion(command *cobra.Command, args []string, run func(*cobra.Command, []string) error) error {
+	if regionPassOf(command) != nil {
+		return run(command, args)
+	}
+
+	regions, err := commandRegions(command, args)
+	if err != nil {
+		return err
+	}
+	if regions == nil {
+		return run(command, args)
+	}
+
+	cfg, err := cmd.BuildHelper(command, args).GetConfig()
+	if err != nil {
+		return err
+	}
+	defaultRegion := profileRegion(cfg)
+	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	if err != nil {
+		return err
+	}
+	configuredRegion := cfg.GetString(konnectcommon.RegionConfigPath)
+	ctx := command.Context()
+	defer func() {
+		cfg.SetString(konnectcommon.RegionConfigPath, configuredRegion)
+		cfg.SetString(konnectcommon.BaseURLConfigPath, baseURL)
+		command.SetContext(ctx)
+	}()
+
+	var exitErr *cmd.ExitCodeError
+	for _, region := range regions {
+		pass := &regionPass{region: region, defaultRegion: defaultRegion, multiple: len(regions) > 1}
+		name := konnectcommon.RegionOf(cfg, baseURL)
+		cfg.SetString(konnectcommon.RegionConfigPath, configuredRegion)
+		cfg.SetString(konnectcommon.BaseURLConfigPath, baseURL)
+		if region != "" {
+			regionURL, err := konnectcommon.BuildBaseURLFromRegion(region)
+			if err != nil {
+				return err
+			}
+			cfg.SetString(konnectcommon.RegionConfigPath, region)
+			cfg.SetString(konnectcommon.BaseURLConfigPath, regionURL)
+			name = region
+		}
+		if pass.multiple {
+			fmt.Fprintf(command.ErrOrStderr(), "=== Konnect region: %s ===\n", name)
+		}
+
+		command.SetContext(context.WithValue(ctx, regionPassKey{}, pass))
+		err := run(command, args)
+		// A detailed exit code reports the changes of one region, the others still run
+		var regionExit *cmd.ExitCodeError
+		if errors.As(err, &regionExit) {
+			exitErr = regionExit
+			continue
+		}
+		if err != nil {
+			if pass.multiple {
+				return fmt.Errorf("konnect region %s: %w", name, err)
+			}
+			return err
+		}
+	}
+	if exitErr != nil {
+		return exitErr
+	}
+	return nil
+}
+
+// commandRegions returns the regions a command runs in, or nil to run it once as
+// usual: for the region of a --plan artifact or a resumed apply, or for the regions
+// of the configuration when one of its files sets _defaults.region
+func commandRegions(command *cobra.Command, args []string) ([]string, error) {
+	if resume, _ := command.Flags().GetBool(resumeFlagName); resume {
+		checkpoint, err := executor.LoadCheckpoint(resolveStateFile(command))
+		if err != nil || checkpoint.Plan.Metadata.Region == "" {
+			// Errors are reported when the apply loads the state file
+			return nil, nil
+		}
+		return []string{checkpoint.Plan.Metadata.Region}, nil
+	}
+	if planFlag := command.Flags().Lookup("plan"); planFlag != nil && planFlag.Value.String() != "" {
+		region := savedPlanRegion(planFlag.Value.String())
+		if region == "" {
+			return nil, nil
+		}
+		return []string{region}, nil
+	}
+
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	recursive, _ := command.Flags().GetBool("recursive")
+	sources, err := loader.ParseSources(filenames)
+	if err != nil || !loader.DeclaresRegions(sources, recursive) {
+		// Errors are reported when the command loads the configuration
+		return nil, nil
+	}
+
+	cfg, err := cmd.BuildHelper(command, args).GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	ldr, err := newDeclarativeLoader(command, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Loading every region finds the regions of resources whose parent sets the region
+	ldr.SelectRegion("")
+	if _, err := ldr.LoadFromSourcesWithContext(command.Context(), sources, recursive); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	regions := ldr.Regions()
+	if len(regions) == 0 {
+		regions = []string{""}
+	}
+	return regions, nil
+}
+
+// savedPlanRegion returns the region recorded in a plan file. Plans read from stdin
+// and files that cannot be read report no region; loading the plan checks them.
+func savedPlanRegion(planFile string) string {
+	if planFile == "-" {
+		return ""
+	}
+	data, err := os.ReadFile(planFile)
+	if err != nil {
+		return ""
+	}
+	var plan struct {
+		Metadata struct {
+			Region string `json:"region"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return ""
+	}
+	return plan.Metadata.Region
+}
+
+// recordPlanRegion records the region of the pass in a plan generated for a region
+// other than the region of the profile
+func recordPlanRegion(command *cobra.Command, plan *planner.Plan) {
+	if pass := regionPassOf(command); pass != nil && pass.region != "" {
+		plan.Metadata.Region = pass.region
+	}
+}
+
+// checkPlanRegion rejects a saved plan generated for another region than the region
+// the command runs against
+func checkPlanRegion(command *cobra.Command, cfg config.Hook, plan *planner.Plan) error {
+	if plan.Metadata.Region == "" {
+		return nil
+	}
+	region := profileRegion(cfg)
+	if pass := regionPassOf(command); pass != nil && pass.region != "" {
+		region = pass.region
+	}
+	if plan.Metadata.Region != region {
+		return fmt.Errorf("plan %s was generated for Konnect region %s, not %s; select the region with --%s",
+			plan.Metadata.PlanID, plan.Metadata.Region, region, konnectcommon.RegionFlagName)
+	}
+	return nil
+}
+
+// regionalPlanFile returns the file a plan is written to. Each region of a
+// configuration that spans regions gets its own plan, named after the region,
+// such as plan.eu.json for plan.json.
+func regionalPlanFile(command *cobra.Command, outputFile string) string {
+	pass := regionPassOf(command)
+	if outputFile == "" || pass == nil || !pass.multiple || pass.region == "" {
+		return outputFile
+	}
+	ext := filepath.Ext(outputFile)
+	return strings.TrimSuffix(outputFile, ext) + "." + pass.region + ext
+}
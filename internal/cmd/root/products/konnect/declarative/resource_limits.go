@@ -0,0 +1,88 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// resourceLimitFlagName is the CLI flag for the known resource entitlements of the organization
+	resourceLimitFlagName = "resource-limit"
+	// resourceLimitConfigPath is the config path backing the resource-limit flag
+	resourceLimitConfigPath = "konnect.declarative." + resourceLimitFlagName
+)
+
+func addResourceLimitFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(resourceLimitFlagName, nil,
+		fmt.Sprintf(`Number of resources of a type (type=count, e.g. portal=2) the organization is entitled to
+(can specify multiple). Plans warn when applying them would exceed a limit. Types: %s.
+- Config path: [ %s ]`, strings.Join(planner.LimitedResourceTypes, ", "), resourceLimitConfigPath))
+}
+
+// resolveResourceLimits returns the resource limits of the command, from the flag or the
+// config file when unset
+func resolveResourceLimits(command *cobra.Command, cfg config.Hook) (map[string]int, error) {
+	var values []string
+	if flag := command.Flags().Lookup(resourceLimitFlagName); flag != nil && flag.Changed {
+		values, _ = command.Flags().GetStringSlice(resourceLimitFlagName)
+	} else if cfg != nil {
+		values = cfg.GetStringSlice(resourceLimitConfigPath)
+	}
+
+	limits := make(map[string]int)
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		resourceType, count, ok := strings.Cut(value, "=")
+		resourceType = strings.TrimSpace(resourceType)
+		if !ok || !slices.Contains(planner.LimitedResourceTypes, resourceType) {
+			return nil, fmt.Errorf("invalid --%s %q: expected type=count with a type of %s",
+				resourceLimitFlagName, value, strings.Join(planner.LimitedResourceTypes, ", "))
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid --%s %q: the count must be a non-negative integer",
+				resourceLimitFlagName, value)
+		}
+		limits[resourceType] = limit
+	}
+	return limits, nil
+}
+
+// countPlanResources records the resource counts of the organization in the plan summary
+// and warns about the limits applying the plan would exceed. Counting is best effort: a
+// failure is logged and leaves the summary without counts.
+func countPlanResources(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	counter planner.ResourceCounter,
+	logger *slog.Logger,
+	plan *planner.Plan,
+) error {
+	limits, err := resolveResourceLimits(command, cfg)
+	if err != nil {
+		return err
+	}
+	if err := planner.CountResources(ctx, counter, plan, limits); err != nil {
+		logger.Warn("Resource counts are not available", "error", err)
+		return nil
+	}
+
+	for _, resourceType := range plan.ExceededLimits() {
+		count := plan.Summary.ResourceCounts[resourceType]
+		fmt.Fprintf(command.ErrOrStderr(),
+			"Warning: applying this plan would exceed the %s limit of the organization (%d planned, limit %d)\n",
+			resourceType, count.Planned, *count.Limit)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// stateFileFlagName is the CLI flag for the file an apply checkpoints its progress to
+	stateFileFlagName = "state-file"
+	// resumeFlagName is the CLI flag resuming the apply recorded in the state file
+	resumeFlagName = "resume"
+
+	defaultStateFile = "apply.state"
+)
+
+func addResumeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(stateFileFlagName, "",
+		fmt.Sprintf(`Record the plan and each confirmed change in this file while applying, so an apply that `+
+			`fails or is canceled can continue with --%s. Defaults to %s with --%s.`,
+			resumeFlagName, defaultStateFile, resumeFlagName))
+	cmd.Flags().Bool(resumeFlagName, false,
+		`Resume the apply recorded in the state file: the changes it completed are skipped, the failed `+
+			`change is retried, and a create that reached Konnect before the failure is recognized by `+
+			`its management labels instead of being created twice.`)
+}
+
+// resolveStateFile returns the state file of an apply, or "" when it records none
+func resolveStateFile(command *cobra.Command) string {
+	if command.Flags().Lookup(stateFileFlagName) == nil {
+		return ""
+	}
+	stateFile, _ := command.Flags().GetString(stateFileFlagName)
+	if resume, _ := command.Flags().GetBool(resumeFlagName); resume && stateFile == "" {
+		return defaultStateFile
+	}
+	return stateFile
+}
+
+// loadResumedApply reads the state file of the apply to resume, or returns nil
+// when the apply does not resume
+func loadResumedApply(command *cobra.Command, stateFile string) (*executor.Checkpoint, *planner.Plan, error) {
+	if resume, _ := command.Flags().GetBool(resumeFlagName); !resume {
+		return nil, nil, nil
+	}
+	for _, name := range []string{"plan", "filename", fromOCIFlagName, targetFlagName, namespaceFlagName} {
+		if command.Flags().Changed(name) {
+			return nil, nil, fmt.Errorf("--%s cannot be used together with --%s; the state file records the plan",
+				name, resumeFlagName)
+		}
+	}
+	if dryRun, _ := command.Flags().GetBool("dry-run"); dryRun {
+		return nil, nil, fmt.Errorf("--dry-run cannot be used together with --%s", resumeFlagName)
+	}
+
+	checkpoint, err := executor.LoadCheckpoint(stateFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if checkpoint.Finished {
+		return nil, nil, fmt.Errorf("state file %s records a finished apply, there is nothing to resume", stateFile)
+	}
+	plan, err := checkpoint.ResumePlan()
+	if err != nil {
+		return nil, nil, err
+	}
+	return checkpoint, plan, nil
+}
@@ -0,0 +1,117 @@
+package declarative
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// riskPolicyFlagName is the CLI flag for the risk policy file
+	riskPolicyFlagName = "risk-policy"
+	// riskPolicyConfigPath is the config path backing the risk-policy flag
+	riskPolicyConfigPath = "konnect.declarative." + riskPolicyFlagName
+	// autoApproveMaxRiskFlagName is the CLI flag for the auto-approve risk threshold
+	autoApproveMaxRiskFlagName = "auto-approve-max-risk"
+	// autoApproveMaxRiskConfigPath is the config path backing the auto-approve-max-risk flag
+	autoApproveMaxRiskConfigPath = "konnect.declarative." + autoApproveMaxRiskFlagName
+)
+
+func addRiskPolicyFlag(cmd *cobra.Command) {
+	cmd.Flags().String(riskPolicyFlagName, "",
+		fmt.Sprintf(`Path to a YAML or JSON risk policy used to annotate planned changes with risk levels.
+- Config path: [ %s ]`, riskPolicyConfigPath))
+}
+
+func addAutoApproveMaxRiskFlag(cmd *cobra.Command) {
+	cmd.Flags().String(autoApproveMaxRiskFlagName, "",
+		fmt.Sprintf(`Highest plan risk level (none, low, medium, high, critical) allowed with --auto-approve.
+Riskier plans require interactive approval. Requires a risk policy.
+- Config path: [ %s ]`, autoApproveMaxRiskConfigPath))
+}
+
+// resolveFlagOrConfig returns the flag value when set, otherwise the config value
+func resolveFlagOrConfig(command *cobra.Command, cfg config.Hook, flagName, configPath string) (string, error) {
+	if command.Flags().Lookup(flagName) == nil {
+		return "", nil
+	}
+	if command.Flags().Changed(flagName) {
+		value, err := command.Flags().GetString(flagName)
+		if err != nil {
+			return "", err
+		}
+		if err := validateNonEmpty(value, flagName); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(value), nil
+	}
+	if cfg == nil {
+		return "", nil
+	}
+	return strings.TrimSpace(cfg.GetString(configPath)), nil
+}
+
+// loadRiskPolicy reads and validates a risk policy file
+func loadRiskPolicy(path string) (*planner.RiskPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk policy: %w", err)
+	}
+
+	var policy planner.RiskPolicy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse risk policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// applyRiskPolicy annotates the plan using the configured risk policy, if any
+func applyRiskPolicy(command *cobra.Command, cfg config.Hook, plan *planner.Plan) error {
+	path, err := resolveFlagOrConfig(command, cfg, riskPolicyFlagName, riskPolicyConfigPath)
+	if err != nil || path == "" {
+		return err
+	}
+
+	policy, err := loadRiskPolicy(path)
+	if err != nil {
+		return err
+	}
+	policy.Annotate(plan)
+	return nil
+}
+
+// checkAutoApproveRisk blocks unattended execution of plans above the configured risk threshold
+func checkAutoApproveRisk(
+	command *cobra.Command,
+	cfg config.Hook,
+	plan *planner.Plan,
+	autoApprove bool,
+	dryRun bool,
+) error {
+	value, err := resolveFlagOrConfig(command, cfg, autoApproveMaxRiskFlagName, autoApproveMaxRiskConfigPath)
+	if err != nil || value == "" {
+		return err
+	}
+
+	maxLevel, err := planner.ParseRiskLevel(value)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", autoApproveMaxRiskFlagName, err)
+	}
+
+	if !autoApprove || dryRun || plan.IsEmpty() {
+		return nil
+	}
+	if plan.Summary.Risk == nil {
+		return fmt.Errorf("--%s requires a plan annotated with a risk policy; set --%s",
+			autoApproveMaxRiskFlagName, riskPolicyFlagName)
+	}
+	return planner.CheckAutoApproveRisk(plan, maxLevel)
+}
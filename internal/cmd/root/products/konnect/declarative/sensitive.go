@@ -0,0 +1,72 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// sensitiveFieldsFlagName is the CLI flag for additional sensitive field paths
+	sensitiveFieldsFlagName = "sensitive-fields"
+	// sensitiveFieldsConfigPath is the config path backing the sensitive-fields flag
+	sensitiveFieldsConfigPath = "konnect.declarative." + sensitiveFieldsFlagName
+)
+
+func addSensitiveFieldsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(sensitiveFieldsFlagName, nil,
+		fmt.Sprintf(`Field paths (e.g. config.hmac_secret) whose values are redacted in plan output, in addition to
+built-in sensitive fields such as passwords and tokens. "*" matches any single path segment.
+- Config path: [ %s ]`, sensitiveFieldsConfigPath))
+}
+
+// resolveSensitiveFields returns the sensitive field paths from the flag, or the config
+// file when unset
+func resolveSensitiveFields(command *cobra.Command, cfg config.Hook) []string {
+	if command.Flags().Lookup(sensitiveFieldsFlagName) == nil {
+		return nil
+	}
+	var paths []string
+	if command.Flags().Changed(sensitiveFieldsFlagName) {
+		paths, _ = command.Flags().GetStringSlice(sensitiveFieldsFlagName)
+	} else if cfg != nil {
+		paths = cfg.GetStringSlice(sensitiveFieldsConfigPath)
+	}
+
+	trimmed := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			trimmed = append(trimmed, path)
+		}
+	}
+	return trimmed
+}
+
+// newPlanRedactor builds the redactor for displaying plan, combining the paths of the
+// command with those recorded in the plan when it was generated
+func newPlanRedactor(command *cobra.Command, cfg config.Hook, plan *planner.Plan) (*redact.Redactor, error) {
+	paths := resolveSensitiveFields(command, cfg)
+	if plan != nil {
+		paths = append(paths, plan.Metadata.SensitiveFields...)
+	}
+	redactor, err := redact.New(paths)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", sensitiveFieldsFlagName, err)
+	}
+	return redactor, nil
+}
+
+// recordSensitiveFields validates the sensitive field paths and stores them in the plan
+// metadata, so commands that later display the plan artifact redact them too
+func recordSensitiveFields(command *cobra.Command, cfg config.Hook, plan *planner.Plan) error {
+	redactor, err := newPlanRedactor(command, cfg, plan)
+	if err != nil {
+		return err
+	}
+	plan.Metadata.SensitiveFields = redactor.Paths()
+	return nil
+}
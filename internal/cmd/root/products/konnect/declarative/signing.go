@@ -0,0 +1,169 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// signFlagName is the CLI flag that signs the plan artifact
+	signFlagName = "sign"
+	// signingKeyFlagName is the CLI flag for the private key that signs plans
+	signingKeyFlagName = "signing-key"
+	// signingKeyConfigPath is the config path backing the signing-key flag
+	signingKeyConfigPath = "konnect.declarative." + signingKeyFlagName
+	// verifyFlagName is the CLI flag that requires a saved plan to be signed
+	verifyFlagName = "verify"
+	// verifyConfigPath is the config path backing the verify flag
+	verifyConfigPath = "konnect.declarative." + verifyFlagName
+	// verificationKeyFlagName is the CLI flag for the public key that verifies plans
+	verificationKeyFlagName = "verification-key"
+	// verificationKeyConfigPath is the config path backing the verification-key flag
+	verificationKeyConfigPath = "konnect.declarative." + verificationKeyFlagName
+)
+
+func addSignFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(signFlagName, false,
+		fmt.Sprintf(`Sign the plan with the --%s and record the Konnect organization it targets,
+so apply --verify refuses the plan when it is modified or applied to another organization.`,
+			signingKeyFlagName))
+	cmd.Flags().String(signingKeyFlagName, "",
+		fmt.Sprintf(`Path to the PEM encoded Ed25519 private key that signs plans.
+- Config path: [ %s ]`, signingKeyConfigPath))
+}
+
+func addVerifyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(verifyFlagName, false,
+		fmt.Sprintf(`Refuse a --plan that is unsigned, was modified after it was signed or targets another
+Konnect organization. The signature is verified with the --%s.
+- Config path: [ %s ]`, verificationKeyFlagName, verifyConfigPath))
+	cmd.Flags().String(verificationKeyFlagName, "",
+		fmt.Sprintf(`Path to the PEM encoded Ed25519 public key that verifies signed plans.
+- Config path: [ %s ]`, verificationKeyConfigPath))
+}
+
+// resolveKeyPath returns the key path from the flag, or the config file when unset
+func resolveKeyPath(command *cobra.Command, cfg config.Hook, flagName, configPath string) string {
+	if command.Flags().Changed(flagName) {
+		path, _ := command.Flags().GetString(flagName)
+		return strings.TrimSpace(path)
+	}
+	if cfg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cfg.GetString(configPath))
+}
+
+// signPlan signs the plan when --sign is set, recording the organization of the
+// Konnect client first so the signature covers it
+func signPlan(ctx context.Context, command *cobra.Command, cfg config.Hook, kkClient helpers.SDKAPI,
+	plan *planner.Plan,
+) error {
+	if sign, _ := command.Flags().GetBool(signFlagName); !sign {
+		return nil
+	}
+	keyPath := resolveKeyPath(command, cfg, signingKeyFlagName, signingKeyConfigPath)
+	if keyPath == "" {
+		return &cmdpkg.ConfigurationError{
+			Err: fmt.Errorf("--%s requires --%s or %s", signFlagName, signingKeyFlagName, signingKeyConfigPath),
+		}
+	}
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read signing key: %w", err)
+	}
+	key, err := planner.ParseSigningKey(keyData)
+	if err != nil {
+		return err
+	}
+
+	orgID, err := currentOrgID(ctx, kkClient)
+	if err != nil {
+		return err
+	}
+	plan.Metadata.OrgID = orgID
+	return plan.Sign(key)
+}
+
+// loadSavedPlan loads a --plan artifact. With --verify the plan must be signed by the
+// verification key and target the organization of the Konnect client.
+func loadSavedPlan(command *cobra.Command, cfg config.Hook, kkClient helpers.SDKAPI,
+	planFile string,
+) (*planner.Plan, error) {
+	planData, err := common.ReadPlanData(planFile, command.InOrStdin())
+	if err != nil {
+		return nil, err
+	}
+
+	verify, _ := command.Flags().GetBool(verifyFlagName)
+	if !command.Flags().Changed(verifyFlagName) && cfg != nil {
+		verify = cfg.GetBool(verifyConfigPath)
+	}
+	if verify {
+		if err := verifyPlanData(command, cfg, planData); err != nil {
+			return nil, err
+		}
+	}
+
+	plan, err := common.ParsePlan(planData)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlanRegion(command, cfg, plan); err != nil {
+		return nil, err
+	}
+	if verify {
+		orgID, err := currentOrgID(command.Context(), kkClient)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Metadata.OrgID != orgID {
+			return nil, fmt.Errorf("%w: plan %s was generated against organization %s, not %s",
+				planner.ErrInvalidSignature, plan.Metadata.PlanID, plan.Metadata.OrgID, orgID)
+		}
+	}
+	if err := checkSavedPlanState(command, createStateClient(kkClient), plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func verifyPlanData(command *cobra.Command, cfg config.Hook, planData []byte) error {
+	keyPath := resolveKeyPath(command, cfg, verificationKeyFlagName, verificationKeyConfigPath)
+	if keyPath == "" {
+		return &cmdpkg.ConfigurationError{
+			Err: fmt.Errorf("--%s requires --%s or %s", verifyFlagName, verificationKeyFlagName,
+				verificationKeyConfigPath),
+		}
+	}
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to read verification key: %w", err)
+	}
+	key, err := planner.ParseVerificationKey(keyData)
+	if err != nil {
+		return err
+	}
+	return planner.VerifyPlanSignature(planData, key)
+}
+
+func currentOrgID(ctx context.Context, kkClient helpers.SDKAPI) (string, error) {
+	res, err := kkClient.GetMeAPI().GetOrganizationsMe(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current organization: %w", err)
+	}
+	org := res.GetMeOrganization()
+	if org == nil || org.GetID() == nil {
+		return "", fmt.Errorf("failed to get current organization: no organization in response")
+	}
+	return *org.GetID(), nil
+}
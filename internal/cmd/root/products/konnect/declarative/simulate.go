@@ -0,0 +1,68 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+	"github.com/spf13/cobra"
+)
+
+// simulateFlagName is the CLI flag running plan and apply against an in-memory Konnect
+const simulateFlagName = "simulate"
+
+func addSimulateFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().Bool(simulateFlagName, false, usage+`
+The simulated organization starts empty, so plans create every resource. No access
+token or network access is needed.`)
+}
+
+// simulating reports whether --simulate was passed
+func simulating(command *cobra.Command) bool {
+	if command.Flags().Lookup(simulateFlagName) == nil {
+		return false
+	}
+	simulate, _ := command.Flags().GetBool(simulateFlagName)
+	return simulate
+}
+
+type simulatorKey struct{}
+
+// startSimulation makes the Konnect SDK of the command answer from a new simulator,
+// returning nil unless --simulate was passed
+func startSimulation(command *cobra.Command) *httpclient.Simulator {
+	if !simulating(command) {
+		return nil
+	}
+	simulator := httpclient.NewSimulator()
+	ctx := context.WithValue(command.Context(), helpers.SDKAPIFactoryKey,
+		konnectcommon.SimulatorSDKFactory(simulator))
+	command.SetContext(context.WithValue(ctx, simulatorKey{}, simulator))
+	return simulator
+}
+
+// simulatorOf returns the simulator started for the command, or nil
+func simulatorOf(command *cobra.Command) *httpclient.Simulator {
+	if command.Context() == nil {
+		return nil
+	}
+	simulator, _ := command.Context().Value(simulatorKey{}).(*httpclient.Simulator)
+	return simulator
+}
+
+// simulationConflict is returned for apply modes a simulation cannot run
+func simulationConflict(flag string) error {
+	return fmt.Errorf("--%s cannot be used together with --%s", simulateFlagName, flag)
+}
+
+// printSimulationReport summarizes the requests the simulator answered and the ones it rejected
+func printSimulationReport(out io.Writer, simulator *httpclient.Simulator) {
+	violations := simulator.Violations()
+	fmt.Fprintf(out, "\nSimulated %d Konnect request(s), %d rejected.\n", simulator.RequestCount(), len(violations))
+	for i, violation := range violations {
+		fmt.Fprintf(out, "  %d. %s\n", i+1, violation)
+	}
+}
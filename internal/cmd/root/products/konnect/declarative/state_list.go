@@ -0,0 +1,137 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/inventory"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// stateNamespaceFlagName is the CLI flag selecting the namespaces to list
+	stateNamespaceFlagName = "namespace"
+	// orphanedFlagName is the CLI flag listing only the orphaned resources
+	orphanedFlagName = "orphaned"
+)
+
+func newDeclarativeStateListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "konnect",
+		Short: "List the Konnect resources managed by kongctl",
+		Long: `List the Konnect resources carrying kongctl's namespace label, with the ref,
+time and configuration hash they were last applied with by the current profile.
+
+Resources labeled by kongctl that the profile has no record of applying are
+reported as orphaned, e.g. resources left behind by a removed configuration or
+applied from another machine. What was last applied is recorded by apply and sync
+in the kongctl config directory.`,
+		Args: cobra.NoArgs,
+		RunE: runStateList,
+	}
+
+	cmd.Flags().StringSlice(stateNamespaceFlagName, nil,
+		"Only list the resources of these namespaces (can specify multiple, default all)")
+	cmd.Flags().Bool(orphanedFlagName, false, "Only list the orphaned resources")
+	cmd.Flags().StringP("output", "o", textOutputFormat, "Output format (text, json, or yaml)")
+	cmd.Flags().Bool(diffExitCodeFlagName, false,
+		"Exit with a non-zero status when orphaned resources are found, e.g. to alert from a scheduled job")
+
+	return cmd
+}
+
+func runStateList(command *cobra.Command, args []string) error {
+	outputFormat, _ := command.Flags().GetString("output")
+	if outputFormat != textOutputFormat && outputFormat != "json" && outputFormat != "yaml" {
+		return fmt.Errorf("unsupported output format: %s (use text, json, or yaml)", outputFormat)
+	}
+	namespaces, _ := command.Flags().GetStringSlice(stateNamespaceFlagName)
+	orphanedOnly, _ := command.Flags().GetBool(orphanedFlagName)
+
+	// Silence usage for all runtime errors (command syntax is already valid at this point)
+	command.SilenceUsage = true
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+	kkClient, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+
+	path, err := drift.DefaultPath()
+	if err != nil {
+		return err
+	}
+	applied, err := drift.NewStore(path).Load(cfg.GetProfile())
+	if err != nil {
+		return err
+	}
+	result, err := inventory.Build(command.Context(), createStateClient(kkClient), cfg.GetProfile(), applied,
+		inventory.Options{Namespaces: namespaces, OrphanedOnly: orphanedOnly})
+	if err != nil {
+		return cmd.PrepareExecutionErrorMsg(helper, err.Error())
+	}
+
+	switch outputFormat {
+	case "json":
+		encoder := json.NewEncoder(command.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal managed resources to YAML: %w", err)
+		}
+		fmt.Fprint(command.OutOrStdout(), string(data))
+	default:
+		displayTextInventory(command.OutOrStdout(), result)
+	}
+
+	if exitCode, _ := command.Flags().GetBool(diffExitCodeFlagName); exitCode && result.Orphans() > 0 {
+		return cmd.PrepareExecutionErrorMsg(helper,
+			fmt.Sprintf("%d orphaned resource(s) found", result.Orphans()))
+	}
+	return nil
+}
+
+func displayTextInventory(out io.Writer, result *inventory.Inventory) {
+	if len(result.Resources) == 0 {
+		fmt.Fprintln(out, "No managed resources found.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tNAMESPACE\tNAME\tREF\tID\tLAST APPLIED\tCONFIG HASH")
+	for _, resource := range result.Resources {
+		ref, appliedAt, configHash := resource.Ref, "-", resource.ConfigHash
+		if resource.Orphaned {
+			ref = "(orphaned)"
+		}
+		if resource.AppliedAt != nil {
+			appliedAt = resource.AppliedAt.Local().Format(time.RFC3339)
+		}
+		if configHash == "" {
+			configHash = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", resource.ResourceType, resource.Namespace,
+			resource.Name, ref, resource.ID, appliedAt, configHash)
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintf(out, "\n%d managed resource(s), %d orphaned\n", len(result.Resources), result.Orphans())
+}
@@ -0,0 +1,106 @@
+package declarative
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/gitscope"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/spf13/cobra"
+)
+
+// targetFlagName is the CLI flag restricting a run to selected resources
+const targetFlagName = "target"
+
+func addTargetFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(targetFlagName, nil,
+		`Only plan the resource ref or type:ref (e.g. api:example-api), its child resources
+and the resources it depends on. Can be repeated or comma separated; targets are
+combined. Resources missing from the configuration are not deleted.`)
+}
+
+// scopeToTargets restricts the plan to the resources selected with --target. It does
+// nothing when the flag is unset, and narrows any scope already set in opts.
+func scopeToTargets(command *cobra.Command, resourceSet *resources.ResourceSet, opts *planner.Options) error {
+	targets, _ := command.Flags().GetStringSlice(targetFlagName)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	refs, err := resolveTargets(resourceSet, targets)
+	if err != nil {
+		return err
+	}
+
+	previous := opts.IncludeChange
+	opts.IncludeChange = func(change planner.PlannedChange) bool {
+		if previous != nil && !previous(change) {
+			return false
+		}
+		return change.Action != planner.ActionDelete && refs[change.ResourceRef]
+	}
+
+	fmt.Fprintf(command.ErrOrStderr(), "Targeting %d resource(s) and their dependencies (%d in total)\n",
+		len(targets), len(refs))
+	return nil
+}
+
+// resolveTargets returns the refs of the targeted resources, their children and
+// everything they depend on
+func resolveTargets(resourceSet *resources.ResourceSet, targets []string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	for _, target := range targets {
+		resourceType, ref, typed := strings.Cut(strings.TrimSpace(target), ":")
+		if !typed {
+			// A bare ref targets the resource of any type
+			resourceType, ref = "", resourceType
+		}
+		if ref == "" || (typed && resourceType == "") {
+			return nil, fmt.Errorf("invalid --%s %q: use ref or type:ref, e.g. api:example-api", targetFlagName, target)
+		}
+		resource, ok := resourceSet.GetResourceByRef(ref)
+		if !ok {
+			return nil, fmt.Errorf("--%s %s: no resource with ref %q in the configuration", targetFlagName, target, ref)
+		}
+		if typed && string(resource.GetType()) != resourceType {
+			return nil, fmt.Errorf("--%s %s: resource %q has type %s, not %s",
+				targetFlagName, target, ref, resource.GetType(), resourceType)
+		}
+		selected[ref] = true
+	}
+
+	// Children are part of their parent, so targeting an api includes its versions,
+	// documents and publications
+	for added := true; added; {
+		added = false
+		resourceSet.ForEachResource(func(resource resources.Resource) bool {
+			if selected[resource.GetRef()] {
+				return true
+			}
+			if parent := parentRef(resource); parent != "" && selected[parent] {
+				selected[resource.GetRef()] = true
+				added = true
+			}
+			return true
+		})
+	}
+
+	refs := make([]string, 0, len(selected))
+	for ref := range selected {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+	return gitscope.ExpandDependencies(resourceSet, refs), nil
+}
+
+// parentRef returns the ref of the parent of a child resource, or ""
+func parentRef(resource resources.Resource) string {
+	if child, ok := resource.(resources.ResourceWithParent); ok {
+		if parent := child.GetParentRef(); parent != nil {
+			return parent.Ref
+		}
+	}
+	return ""
+}
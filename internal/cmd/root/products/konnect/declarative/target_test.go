@@ -0,0 +1,50 @@
+package declarative
+
+import (
+	"slices"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTargets_IncludesChildren(t *testing.T) {
+	// Pages and publications have several ref fields besides their parent
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{CreatePortal: kkComps.CreatePortal{Name: "Developer Portal"}, BaseResource: resources.BaseResource{Ref: "dev"}},
+			{CreatePortal: kkComps.CreatePortal{Name: "Partners"}, BaseResource: resources.BaseResource{Ref: "partners"}},
+		},
+		PortalPages: []resources.PortalPageResource{
+			{Ref: "guides", Portal: "dev"},
+			{Ref: "getting-started", Portal: "dev", ParentPageRef: "guides"},
+			{Ref: "partner-terms", Portal: "partners"},
+		},
+		PortalCustomizations: []resources.PortalCustomizationResource{{Ref: "dev-theme", Portal: "dev"}},
+		APIs: []resources.APIResource{
+			{CreateAPIRequest: kkComps.CreateAPIRequest{Name: "Orders"}, BaseResource: resources.BaseResource{Ref: "orders"}},
+		},
+		APIVersions:     []resources.APIVersionResource{{Ref: "orders-v1", API: "orders"}},
+		APIPublications: []resources.APIPublicationResource{{Ref: "orders-on-dev", API: "orders", PortalID: "dev"}},
+	}
+	sorted := func(refs map[string]bool) []string {
+		var keys []string
+		for ref := range refs {
+			keys = append(keys, ref)
+		}
+		slices.Sort(keys)
+		return keys
+	}
+
+	refs, err := resolveTargets(rs, []string{"portal:dev"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "dev-theme", "getting-started", "guides"}, sorted(refs),
+		"publications to the portal belong to their API")
+
+	refs, err = resolveTargets(rs, []string{"api:orders"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "orders", "orders-on-dev", "orders-v1"}, sorted(refs),
+		"the publication's portal is a dependency, not its parent")
+}
@@ -0,0 +1,92 @@
+package declarative
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// operationTimeoutFlagName is the CLI flag for the default timeout of each change
+	operationTimeoutFlagName = "operation-timeout"
+	// operationTimeoutConfigPath is the config path backing the operation-timeout flag
+	operationTimeoutConfigPath = "konnect.declarative." + operationTimeoutFlagName
+	// resourceTimeoutFlagName is the CLI flag for per resource type timeouts
+	resourceTimeoutFlagName = "resource-timeout"
+	// resourceTimeoutConfigPath is the config path backing the resource-timeout flag
+	resourceTimeoutConfigPath = "konnect.declarative." + resourceTimeoutFlagName
+)
+
+func addTimeoutFlags(cmd *cobra.Command) {
+	cmd.Flags().String(operationTimeoutFlagName, "",
+		fmt.Sprintf(`Default time limit for each change (e.g. 30s). Without it, each request is limited by --timeout.
+- Config path: [ %s ]`, operationTimeoutConfigPath))
+	cmd.Flags().StringSlice(resourceTimeoutFlagName, nil,
+		fmt.Sprintf(`Time limit for changes to a resource type, overriding --%s (e.g. api_version=10m).
+Accepts comma-separated list or repeated flags.
+- Config path: [ %s ]`, operationTimeoutFlagName, resourceTimeoutConfigPath))
+}
+
+// resolveTimeouts reads the change timeouts from the flags, or the config file when unset
+func resolveTimeouts(command *cobra.Command, cfg config.Hook) (executor.Timeouts, error) {
+	var timeouts executor.Timeouts
+	if command.Flags().Lookup(operationTimeoutFlagName) == nil {
+		return timeouts, nil
+	}
+
+	value, err := resolveFlagOrConfig(command, cfg, operationTimeoutFlagName, operationTimeoutConfigPath)
+	if err != nil {
+		return timeouts, err
+	}
+	if value != "" {
+		if timeouts.Default, err = parseTimeout(value); err != nil {
+			return timeouts, fmt.Errorf("invalid --%s: %w", operationTimeoutFlagName, err)
+		}
+	}
+
+	var entries []string
+	if command.Flags().Changed(resourceTimeoutFlagName) {
+		entries, _ = command.Flags().GetStringSlice(resourceTimeoutFlagName)
+	} else if cfg != nil {
+		entries = cfg.GetStringSlice(resourceTimeoutConfigPath)
+	}
+	for _, entry := range entries {
+		resourceType, duration, ok := strings.Cut(entry, "=")
+		resourceType = strings.TrimSpace(resourceType)
+		if !ok || resourceType == "" {
+			return timeouts, fmt.Errorf("invalid --%s %q: expected resource_type=duration",
+				resourceTimeoutFlagName, entry)
+		}
+		if resourceType != planner.ResourceTypeDeck &&
+			!resources.IsRegistered(resources.ResourceType(resourceType)) {
+			return timeouts, fmt.Errorf("invalid --%s %q: unknown resource type %q",
+				resourceTimeoutFlagName, entry, resourceType)
+		}
+		timeout, err := parseTimeout(duration)
+		if err != nil {
+			return timeouts, fmt.Errorf("invalid --%s %q: %w", resourceTimeoutFlagName, entry, err)
+		}
+		if timeouts.ByResourceType == nil {
+			timeouts.ByResourceType = make(map[string]time.Duration)
+		}
+		timeouts.ByResourceType[resourceType] = timeout
+	}
+	return timeouts, nil
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive, got %s", value)
+	}
+	return timeout, nil
+}
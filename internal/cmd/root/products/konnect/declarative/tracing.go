@@ -0,0 +1,63 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/tracing"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// otelEndpointFlagName is the CLI flag for the OTLP trace endpoint
+	otelEndpointFlagName = "otel-endpoint"
+	// otelEndpointConfigPath is the config path backing the otel-endpoint flag
+	otelEndpointConfigPath = "konnect.declarative." + otelEndpointFlagName
+)
+
+func addOTelEndpointFlag(cmd *cobra.Command) {
+	cmd.Flags().String(otelEndpointFlagName, "",
+		fmt.Sprintf(`OTLP/HTTP endpoint (e.g. http://localhost:4318) to export OpenTelemetry traces of the run to.
+Tracing is disabled when unset.
+- Config path: [ %s ]`, otelEndpointConfigPath))
+}
+
+// startTracing enables span export when an OTLP endpoint is configured and starts the
+// command's root span. The returned function ends the span and flushes the exporter.
+func startTracing(
+	ctx context.Context,
+	command *cobra.Command,
+	cfg config.Hook,
+	helper cmd.Helper,
+) (context.Context, func(), error) {
+	endpoint, err := resolveFlagOrConfig(command, cfg, otelEndpointFlagName, otelEndpointConfigPath)
+	if err != nil || endpoint == "" {
+		return ctx, func() {}, err
+	}
+
+	version := "dev"
+	if buildInfo, err := helper.GetBuildInfo(); err == nil && buildInfo != nil &&
+		strings.TrimSpace(buildInfo.Version) != "" {
+		version = strings.TrimSpace(buildInfo.Version)
+	}
+
+	shutdown, err := tracing.Setup(ctx, endpoint, version)
+	if err != nil {
+		return ctx, func() {}, err
+	}
+
+	ctx, span := tracing.Start(ctx, meta.CLIName+" "+command.Name())
+	return ctx, func() {
+		span.End()
+		if err := shutdown(context.WithoutCancel(ctx)); err != nil {
+			if logger, logErr := helper.GetLogger(); logErr == nil {
+				logger.Warn("Failed to export traces", slog.String("endpoint", endpoint), slog.Any("error", err))
+			}
+		}
+	}, nil
+}
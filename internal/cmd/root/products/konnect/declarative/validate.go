@@ -0,0 +1,74 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/spf13/cobra"
+)
+
+func newDeclarativeValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "konnect",
+		Short: "Validate declarative configuration for Konnect without contacting Konnect",
+		Long: `Validate declarative configuration files for Konnect without making any Konnect API requests.
+
+Each file is first checked against the JSON Schema of the configuration format, which
+reports unknown fields, wrong types, missing required fields, invalid refs and label keys
+at their line and column. The files are then parsed, YAML tags such as !file, !env and
+!ref are expanded, references are resolved and every resource is validated. All problems
+are reported at once, and the command exits with a non-zero status if any are found.`,
+		RunE: runValidate,
+	}
+
+	cmd.Flags().StringSliceP("filename", "f", []string{},
+		"Filename or directory to files to validate (can specify multiple)")
+	cmd.Flags().BoolP("recursive", "R", false,
+		"Process the directory used in -f, --filename recursively")
+	addBaseDirFlag(cmd)
+	addOfflineFlag(cmd)
+	addNamespaceFlag(cmd)
+	addDefaultLabelFlag(cmd)
+	addVarFileFlag(cmd)
+
+	return cmd
+}
+
+func runValidate(command *cobra.Command, args []string) error {
+	// Silence usage for all runtime errors (command syntax is already valid at this point)
+	command.SilenceUsage = true
+
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	recursive, _ := command.Flags().GetBool("recursive")
+
+	helper := cmd.BuildHelper(command, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	sources, err := loader.ParseSources(filenames)
+	if err != nil {
+		return fmt.Errorf("failed to parse sources: %w", err)
+	}
+
+	ldr, err := newDeclarativeLoader(command, cfg)
+	if err != nil {
+		return err
+	}
+	resourceSet, issues := ldr.Validate(command.Context(), sources, recursive)
+
+	out := command.OutOrStdout()
+	if len(issues) == 0 {
+		fmt.Fprintf(out, "Configuration is valid: %d resource(s) checked\n", resourceSet.ResourceCount())
+		return nil
+	}
+
+	fmt.Fprintf(out, "Configuration is invalid:\n")
+	for _, issue := range issues {
+		fmt.Fprintf(out, "  - %s\n", issue)
+	}
+	return cmd.PrepareExecutionErrorMsg(helper,
+		fmt.Sprintf("validation failed: %d problem(s) found", len(issues)))
+}
@@ -0,0 +1,48 @@
+package declarative
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// varFileFlagName is the CLI flag for the files of variable values
+	varFileFlagName = "var-file"
+	// varFileConfigPath is the config path backing the var-file flag
+	varFileConfigPath = "konnect.declarative." + varFileFlagName
+)
+
+func addVarFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice(varFileFlagName, nil,
+		fmt.Sprintf(`YAML or JSON file of values for the ${var.name} references of the configuration
+(can specify multiple). Values of later files, and of any file over the variables sections, take precedence.
+- Config path: [ %s ]`, varFileConfigPath))
+}
+
+// resolveVariables returns the variable values of the files of the flag, or of the
+// config file when unset
+func resolveVariables(command *cobra.Command, cfg config.Hook) (map[string]any, error) {
+	if command.Flags().Lookup(varFileFlagName) == nil {
+		return nil, nil
+	}
+	var paths []string
+	if command.Flags().Changed(varFileFlagName) {
+		paths, _ = command.Flags().GetStringSlice(varFileFlagName)
+	} else if cfg != nil {
+		paths = cfg.GetStringSlice(varFileConfigPath)
+	}
+
+	variables := make(map[string]any)
+	for _, path := range paths {
+		values, err := loader.LoadVariableFile(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(variables, values)
+	}
+	return variables, nil
+}
@@ -0,0 +1,178 @@
+package declarative
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	watchFlagName         = "watch"
+	watchIntervalFlagName = "watch-interval"
+	// watchDebounce is how long apply waits after a file event for further events, so
+	// an editor saving several files triggers a single reconciliation
+	watchDebounce = 500 * time.Millisecond
+)
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(watchFlagName, false,
+		`Keep running and apply the configuration again whenever its files change (requires --auto-approve).
+Stop with Ctrl-C.`)
+	cmd.Flags().Duration(watchIntervalFlagName, 0,
+		fmt.Sprintf("Also apply the configuration every interval (e.g. 5m) while --%s runs, "+
+			"reverting drift made in Konnect", watchFlagName))
+}
+
+// watchRequested reports whether apply should keep running and reconcile on changes
+func watchRequested(command *cobra.Command) (bool, error) {
+	if watch, _ := command.Flags().GetBool(watchFlagName); !watch {
+		if command.Flags().Changed(watchIntervalFlagName) {
+			return false, fmt.Errorf("--%s requires --%s", watchIntervalFlagName, watchFlagName)
+		}
+		return false, nil
+	}
+
+	for _, name := range []string{"plan", "dry-run", fromOCIFlagName, canaryFlagName, profilesFlagName} {
+		if command.Flags().Changed(name) {
+			return false, fmt.Errorf("--%s cannot be combined with --%s", watchFlagName, name)
+		}
+	}
+	if autoApprove, _ := command.Flags().GetBool("auto-approve"); !autoApprove {
+		return false, fmt.Errorf("--%s requires --auto-approve "+
+			"(changes cannot be confirmed interactively while watching)", watchFlagName)
+	}
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	if slices.Contains(filenames, "-") {
+		return false, fmt.Errorf("--%s cannot read configuration from stdin", watchFlagName)
+	}
+	if interval, _ := command.Flags().GetDuration(watchIntervalFlagName); interval < 0 {
+		return false, fmt.Errorf("invalid --%s %s: must not be negative", watchIntervalFlagName, interval)
+	}
+	return true, nil
+}
+
+// runWatch applies the configuration, then applies it again whenever the watched
+// files change or --watch-interval elapses, until the command is interrupted. A
+// failed reconciliation is reported and retried on the next change or interval.
+func runWatch(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	filenames, _ := command.Flags().GetStringSlice("filename")
+	recursive, _ := command.Flags().GetBool("recursive")
+	interval, _ := command.Flags().GetDuration(watchIntervalFlagName)
+	out := command.OutOrStderr()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch configuration files: %w", err)
+	}
+	defer watcher.Close()
+	dirs, err := watchDirs(filenames, recursive)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	reconcile := func(reason string) {
+		fmt.Fprintf(out, "Reconciling (%s) at %s\n", reason, time.Now().Format(time.RFC3339))
+		if err := applyOnce(command, args); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(out, "Reconciliation failed: %v\n", err)
+		}
+	}
+
+	reconcile("start")
+	fmt.Fprintf(out, "Watching %s for changes\n", strings.Join(dirs, ", "))
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod || strings.HasPrefix(filepath.Base(event.Name), ".") {
+				continue
+			}
+			if recursive && event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watcher.Add(event.Name)
+				}
+			}
+			debounce = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(out, "Watch error: %v\n", err)
+		case <-debounce:
+			debounce = nil
+			reconcile("configuration changed")
+		case <-tick:
+			reconcile("interval")
+		}
+	}
+}
+
+// watchDirs returns the directories holding the configuration files: the directories
+// given with -f, with their subdirectories when recursive, and the directories of the
+// files given with -f. The current directory is used when no -f is given.
+func watchDirs(filenames []string, recursive bool) ([]string, error) {
+	if len(filenames) == 0 {
+		filenames = []string{"."}
+	}
+	var dirs []string
+	add := func(dir string) {
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, filename := range filenames {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch %s: %w", filename, err)
+		}
+		if !info.IsDir() {
+			add(filepath.Dir(filename))
+			continue
+		}
+		if !recursive {
+			add(filepath.Clean(filename))
+			continue
+		}
+		err = filepath.WalkDir(filename, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() {
+				return nil
+			}
+			if path != filename && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			add(filepath.Clean(path))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch %s: %w", filename, err)
+		}
+	}
+	return dirs, nil
+}
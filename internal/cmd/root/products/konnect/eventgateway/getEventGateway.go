@@ -96,25 +96,30 @@ type getEventGatewayControlPlaneCmd struct {
 func runListByName(name string, kkClient helpers.EGWControlPlaneAPI, helper cmd.Helper,
 	cfg config.Hook,
 ) (*kkComps.EventGatewayInfo, error) {
-	allEventGateways, err := runList(kkClient, helper, cfg)
+	allEventGateways, err := runList(kkClient, helper, cfg, 0)
 	if err != nil {
 		return nil, err
 	}
 
+	var matches []kkComps.EventGatewayInfo
 	for _, eventGateway := range allEventGateways {
 		if eventGateway.Name == name {
-			return &eventGateway, nil
+			matches = append(matches, eventGateway)
 		}
 	}
 
-	return nil, cmd.PrepareExecutionErrorMsg(helper,
-		fmt.Sprintf("Event Gateway Control Plane with name %s not found", name))
+	return common.SelectByName(helper, "event gateway", name, matches, func(eventGateway kkComps.EventGatewayInfo) string {
+		return eventGateway.ID
+	})
 }
 
 func runList(kkClient helpers.EGWControlPlaneAPI, helper cmd.Helper,
-	cfg config.Hook,
+	cfg config.Hook, limit int,
 ) ([]kkComps.EventGatewayInfo, error) {
 	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
+	if limit > 0 && int64(limit) < requestPageSize {
+		requestPageSize = int64(limit)
+	}
 
 	var allData []kkComps.EventGatewayInfo
 	var pageAfter *string
@@ -136,7 +141,10 @@ func runList(kkClient helpers.EGWControlPlaneAPI, helper cmd.Helper,
 
 		allData = append(allData, res.ListEventGatewaysResponse.Data...)
 
-		if res.ListEventGatewaysResponse.Meta.Page.Next == nil {
+		if res.ListEventGatewaysResponse.Meta.Page.Next == nil || len(res.ListEventGatewaysResponse.Data) == 0 {
+			break
+		}
+		if limit > 0 && len(allData) >= limit {
 			break
 		}
 
@@ -149,7 +157,7 @@ func runList(kkClient helpers.EGWControlPlaneAPI, helper cmd.Helper,
 		pageAfter = kk.String(values.Get("page[after]"))
 	}
 
-	return allData, nil
+	return common.LimitResults(allData, limit), nil
 }
 
 func runGet(id string, kkClient helpers.EGWControlPlaneAPI, helper cmd.Helper,
@@ -195,6 +203,11 @@ func (c *getEventGatewayControlPlaneCmd) runE(cobraCmd *cobra.Command, args []st
 		return e
 	}
 
+	limit, e := common.ListLimit(helper)
+	if e != nil {
+		return e
+	}
+
 	logger, e := helper.GetLogger()
 	if e != nil {
 		return e
@@ -281,10 +294,11 @@ func (c *getEventGatewayControlPlaneCmd) runE(cobraCmd *cobra.Command, args []st
 		)
 	}
 
-	eventGatewayControlPlanes, e := runList(sdk.GetEventGatewayControlPlaneAPI(), helper, cfg)
+	eventGatewayControlPlanes, e := runList(sdk.GetEventGatewayControlPlaneAPI(), helper, cfg, limit)
 	if e != nil {
 		return e
 	}
+	common.WarnIfEmptyInDefaultRegion(helper, cfg, len(eventGatewayControlPlanes))
 
 	return renderEventGatewayControlPlaneList(
 		helper,
@@ -498,6 +512,7 @@ func newGetEventGatewayControlPlaneCmd(verb verbs.VerbValue,
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddLimitFlag(rv.Command)
 
 	// Add child commands
 	backendClustersCmd := newGetEventGatewayBackendClustersCmd(verb, addParentFlags, parentPreRun)
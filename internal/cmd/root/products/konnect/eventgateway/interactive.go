@@ -24,7 +24,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, err
 	}
 
-	gateways, err := runList(sdk.GetEventGatewayControlPlaneAPI(), helper, cfg)
+	gateways, err := runList(sdk.GetEventGatewayControlPlaneAPI(), helper, cfg, 0)
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
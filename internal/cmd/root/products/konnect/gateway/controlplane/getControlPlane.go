@@ -41,6 +41,8 @@ var (
 	%[1]s get konnect gateway control-plane my-control-plane 
 	# Get all the control planes for the authorized user using command aliases
 	%[1]s get k gw cps
+	# Count control planes grouped by cluster type
+	%[1]s get konnect gateway control-planes --count-by config.cluster_type
 	`, meta.CLIName)))
 )
 
@@ -181,14 +183,9 @@ type getControlPlaneCmd struct {
 func runListByName(name string, kkClient helpers.ControlPlaneAPI, helper cmd.Helper,
 	cfg config.Hook,
 ) (*kkComps.ControlPlane, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.ControlPlane
-
-	for {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.ControlPlane, float64, error) {
 		req := kkOps.ListControlPlanesRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 			Filter: &kkComps.ControlPlaneFilterParameters{
 				Name: &kkComps.ControlPlaneFilterParametersName{
@@ -200,58 +197,43 @@ func runListByName(name string, kkClient helpers.ControlPlaneAPI, helper cmd.Hel
 		res, err := kkClient.ListControlPlanes(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list Control Planes", err, helper.GetCmd(), attrs...)
+			return nil, 0, cmd.PrepareExecutionError("Failed to list Control Planes", err, helper.GetCmd(), attrs...)
 		}
-
-		allData = append(allData, res.GetListControlPlanesResponse().Data...)
-		totalItems := res.GetListControlPlanesResponse().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
-		}
-
-		pageNumber++
+		return res.GetListControlPlanesResponse().Data, res.GetListControlPlanesResponse().Meta.Page.Total, nil
 	}
-
-	// Making the determination to always take the first element in a list of return values.
-	//    It's possible this logic is flawed ?
-	if len(allData) > 0 {
-		return &allData[0], nil
+	allData, err := common.ListPages(cfg, 0, fetch)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("control plane with name %s not found", name)
+
+	return common.SelectByName(helper, "control plane", name, allData, func(cp kkComps.ControlPlane) string {
+		return cp.ID
+	})
 }
 
+// runList lists the control planes, only those named nameFilter when it is not empty
 func runList(kkClient helpers.ControlPlaneAPI, helper cmd.Helper,
-	cfg config.Hook,
+	cfg config.Hook, limit int, nameFilter string,
 ) ([]kkComps.ControlPlane, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-
-	var allData []kkComps.ControlPlane
-
-	for {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.ControlPlane, float64, error) {
 		req := kkOps.ListControlPlanesRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
+		if nameFilter != "" {
+			req.Filter = &kkComps.ControlPlaneFilterParameters{
+				Name: &kkComps.ControlPlaneFilterParametersName{Eq: kk.String(nameFilter)},
+			}
+		}
 
 		res, err := kkClient.ListControlPlanes(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list Control Planes", err, helper.GetCmd(), attrs...)
+			return nil, 0, cmd.PrepareExecutionError("Failed to list Control Planes", err, helper.GetCmd(), attrs...)
 		}
-
-		allData = append(allData, res.GetListControlPlanesResponse().Data...)
-		totalItems := res.GetListControlPlanesResponse().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
-		}
-
-		pageNumber++
+		return res.GetListControlPlanesResponse().Data, res.GetListControlPlanesResponse().Meta.Page.Total, nil
 	}
-
-	return allData, nil
+	return common.ListPages(cfg, limit, fetch)
 }
 
 func runGet(id string, kkClient helpers.ControlPlaneAPI, helper cmd.Helper,
@@ -292,6 +274,31 @@ func (c *getControlPlaneCmd) runE(cobraCmd *cobra.Command, args []string) error
 		return err
 	}
 
+	count, countBy, err := common.CountOptions(helper)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := common.NamespaceFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	selector, err := common.LabelSelectorFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	fieldSelector, err := common.FieldSelectorFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	limit, err := common.ListLimit(helper)
+	if err != nil {
+		return err
+	}
+
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
@@ -356,10 +363,36 @@ func (c *getControlPlaneCmd) runE(cobraCmd *cobra.Command, args []string) error
 	}
 
 	// list all control planes
-	cps, err := runList(sdk.GetControlPlaneAPI(), helper, cfg)
+	// Filtered lists are limited once filtered
+	fetchLimit := limit
+	if namespace != "" || selector != nil || fieldSelector != nil {
+		fetchLimit = 0
+	}
+	// Konnect filters by name, the other fields are matched once listed
+	nameFilter, _ := fieldSelector.NameEquals()
+	cps, err := runList(sdk.GetControlPlaneAPI(), helper, cfg, fetchLimit, nameFilter)
 	if err != nil {
 		return err
 	}
+	common.WarnIfEmptyInDefaultRegion(helper, cfg, len(cps))
+	if cps, err = common.FilterByNamespace(cps, namespace); err != nil {
+		return err
+	}
+	if cps, err = common.FilterByLabelSelector(cps, selector); err != nil {
+		return err
+	}
+	if cps, err = common.FilterByFieldSelector(cps, fieldSelector); err != nil {
+		return err
+	}
+	cps = common.LimitResults(cps, limit)
+
+	if count {
+		summary, err := common.CountResources(cps, countBy)
+		if err != nil {
+			return err
+		}
+		return common.RenderCounts(helper, outType, printer, summary)
+	}
 
 	return renderControlPlaneList(helper, helper.GetCmd().Name(), outType, printer, cps)
 }
@@ -375,6 +408,11 @@ func renderControlPlaneList(
 		displayRecords = append(displayRecords, controlPlaneToDisplayRecord(&cps[i]))
 	}
 
+	display, err := common.WithNamespaceColumn(helper, displayRecords, cps)
+	if err != nil {
+		return err
+	}
+
 	childView := buildControlPlaneChildView(cps)
 
 	options := []tableview.Option{
@@ -394,7 +432,7 @@ func renderControlPlaneList(
 		outType,
 		printer,
 		helper.GetStreams(),
-		displayRecords,
+		display,
 		cps,
 		"",
 		options...,
@@ -450,6 +488,11 @@ func newGetControlPlaneCmd(verb verbs.VerbValue,
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddCountFlags(rv.Command)
+	common.AddNamespaceFilterFlag(rv.Command)
+	common.AddLabelSelectorFlag(rv.Command)
+	common.AddFieldSelectorFlag(rv.Command)
+	common.AddLimitFlag(rv.Command)
 
 	return &rv
 }
@@ -24,7 +24,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, err
 	}
 
-	cps, err := runList(sdk.GetControlPlaneAPI(), helper, cfg)
+	cps, err := runList(sdk.GetControlPlaneAPI(), helper, cfg, 0, "")
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
@@ -7,6 +7,7 @@ import (
 	cmdpkg "github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/root/products"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/adopt"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/analytics"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/api"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/authstrategy"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
@@ -14,11 +15,13 @@ import (
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/eventgateway"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/gateway"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/me"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/notifications"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/organization"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/portal"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/regions"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 	"github.com/kong/kongctl/internal/meta"
 	"github.com/kong/kongctl/internal/util/i18n"
 	"github.com/kong/kongctl/internal/util/normalizers"
@@ -40,7 +43,7 @@ var (
 )
 
 func addFlags(verb verbs.VerbValue, cmd *cobra.Command) {
-	if verb != verbs.Login && verb != verbs.Logout {
+	if verb != verbs.Login && verb != verbs.Logout && verb != verbs.Validate {
 		cmd.Flags().String(common.BaseURLFlagName, "",
 			fmt.Sprintf(`Base URL for Konnect API requests.
 - Config path: [ %s ]
@@ -53,12 +56,17 @@ func addFlags(verb verbs.VerbValue, cmd *cobra.Command) {
 		)
 	}
 
-	if verb != verbs.Login && verb != verbs.Logout {
+	if verb != verbs.Login && verb != verbs.Logout && verb != verbs.Validate {
 		cmd.Flags().String(common.PATFlagName, "",
 			fmt.Sprintf(`Konnect Personal Access Token (PAT) used to authenticate the CLI. 
 Setting this value overrides tokens obtained from the login command.
 - Config path: [ %s ]`,
 				common.PATConfigPath))
+		cmd.Flags().Int(common.MaxRetriesFlagName, httpclient.DefaultMaxRetries,
+			fmt.Sprintf(`Max number of retries of a Konnect request that was rate limited (429)
+or failed with a transient server error (5xx). Use 0 to disable retries.
+- Config path: [ %s ]`,
+				common.MaxRetriesConfigPath))
 	}
 
 	if verb == verbs.Get || verb == verbs.List {
@@ -102,6 +110,14 @@ func bindFlags(c *cobra.Command, args []string) error {
 		}
 	}
 
+	f = c.Flags().Lookup(common.MaxRetriesFlagName)
+	if f != nil { // might not be present depending on verb
+		err = cfg.BindFlag(common.MaxRetriesConfigPath, f)
+		if err != nil {
+			return err
+		}
+	}
+
 	f = c.Flags().Lookup(common.RequestPageSizeFlagName)
 	if f != nil { // might not be present depending on verb
 		err = cfg.BindFlag(common.RequestPageSizeConfigPath, f)
@@ -154,7 +170,9 @@ func NewKonnectCmd(verb verbs.VerbValue) (*cobra.Command, error) {
 	}
 
 	// Handle declarative configuration verbs
-	if verb == verbs.Plan || verb == verbs.Sync || verb == verbs.Diff || verb == verbs.Export || verb == verbs.Apply {
+	if verb == verbs.Plan || verb == verbs.Sync || verb == verbs.Diff || verb == verbs.Export || verb == verbs.Apply ||
+		verb == verbs.Validate || verb == verbs.Drift || verb == verbs.Import ||
+		verb == verbs.State || verb == verbs.Doctor {
 		c, e := declarative.NewDeclarativeCmd(verb)
 		if e != nil {
 			return nil, e
@@ -212,6 +230,16 @@ func NewKonnectCmd(verb verbs.VerbValue) (*cobra.Command, error) {
 		return cmd, nil
 	}
 
+	// Approve and revoke only apply to portal resources
+	if verb == verbs.Approve || verb == verbs.Revoke {
+		pc, e := portal.NewPortalCmd(verb, addFlags, preRunE)
+		if e != nil {
+			return nil, e
+		}
+		cmd.AddCommand(pc)
+		return cmd, nil
+	}
+
 	// For all other verbs, build the standard command tree
 
 	c, e := gateway.NewGatewayCmd(verb, addFlags, preRunE)
@@ -263,6 +291,18 @@ func NewKonnectCmd(verb verbs.VerbValue) (*cobra.Command, error) {
 	}
 	cmd.AddCommand(rc)
 
+	anc, e := analytics.NewAnalyticsCmd(verb, addFlags, preRunE)
+	if e != nil {
+		return nil, e
+	}
+	cmd.AddCommand(anc)
+
+	nc, e := notifications.NewNotificationsCmd(verb, addFlags, preRunE)
+	if e != nil {
+		return nil, e
+	}
+	cmd.AddCommand(nc)
+
 	// Add EventGateway command
 	egcpc, e := eventgateway.NewEventGatewayCmd(verb, addFlags, preRunE)
 	if e != nil {
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os/exec"
+	"runtime"
 	"time"
 
 	"github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/konnect/auth"
 	"github.com/kong/kongctl/internal/meta"
 	"github.com/kong/kongctl/internal/util"
@@ -30,6 +33,11 @@ var (
 	httpClient *http.Client
 )
 
+const (
+	noBrowserFlagName       = "no-browser"
+	credentialStoreFlagName = "credential-store"
+)
+
 type loginKonnectCmd struct {
 	*cobra.Command
 }
@@ -51,6 +59,39 @@ func displayUserInstructions(resp auth.DeviceCodeResponse) {
 	fmt.Println(userResp)
 }
 
+// openBrowser opens the URL in the default browser. Failures are ignored, as the
+// instructions printed before let the user open it themselves.
+func openBrowser(url string) error {
+	var command *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		command = exec.Command("open", url)
+	case "windows":
+		command = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		command = exec.Command("xdg-open", url)
+	}
+	if err := command.Start(); err != nil {
+		return err
+	}
+	go func() { _ = command.Wait() }()
+	return nil
+}
+
+// saveCredentialStore records a --credential-store given to login in the profile, so
+// later commands read the tokens from the same store
+func (c *loginKonnectCmd) saveCredentialStore(cfg config.Hook) error {
+	if !c.Flags().Changed(credentialStoreFlagName) {
+		return nil
+	}
+	store := cfg.GetString(auth.CredentialStoreConfigPath)
+	profiled, ok := cfg.(interface{ SetProfileValue(string, any) error })
+	if !ok {
+		return nil
+	}
+	return profiled.SetProfileValue(auth.CredentialStoreConfigPath, store)
+}
+
 func (c *loginKonnectCmd) run(helper cmd.Helper) error {
 	logger, err := helper.GetLogger()
 	if err != nil {
@@ -89,6 +130,11 @@ func (c *loginKonnectCmd) run(helper cmd.Helper) error {
 	}
 
 	displayUserInstructions(resp)
+	if noBrowser, _ := c.Flags().GetBool(noBrowserFlagName); !noBrowser {
+		if err := openBrowser(resp.VerificationURIComplete); err != nil {
+			logger.Debug("Failed to open browser", "error", err)
+		}
+	}
 
 	expiresAt := time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
 	// poll for token while the user completes authorizing the request
@@ -121,6 +167,9 @@ func (c *loginKonnectCmd) run(helper cmd.Helper) error {
 			if err := auth.SaveAccessToken(cfg, pollResp); err != nil {
 				return cmd.PrepareExecutionErrorWithHelper(helper, "failed to save tokens", err)
 			}
+			if err := c.saveCredentialStore(cfg); err != nil {
+				return cmd.PrepareExecutionErrorWithHelper(helper, "failed to save credential store", err)
+			}
 			break
 		}
 	}
@@ -165,6 +214,12 @@ func (c *loginKonnectCmd) preRunE(cobraCmd *cobra.Command, args []string) error
 		return err
 	}
 
+	f = c.Flags().Lookup(credentialStoreFlagName)
+	err = cfg.BindFlag(auth.CredentialStoreConfigPath, f)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -223,6 +278,17 @@ func newLoginKonnectCmd(verb verbs.VerbValue,
 -`, // (default ...)
 			common.TokenURLPathConfigPath))
 
+	rv.Flags().Bool(noBrowserFlagName, false,
+		"Print the authorization URL without opening it in the default browser.")
+
+	rv.Flags().String(credentialStoreFlagName, auth.CredentialStoreFile,
+		fmt.Sprintf(`Where to save the Konnect tokens: %[1]s, next to the configuration file, %[2]s,
+the macOS keychain, the Windows Credential Manager or the Secret Service on Linux, or %[3]s,
+a file encrypted with the passphrase in %[4]s. A value given to login is saved in the profile.
+- Config path: [ %[5]s ]`,
+			auth.CredentialStoreFile, auth.CredentialStoreKeychain, auth.CredentialStoreEncryptedFile,
+			auth.CredentialPassphraseEnv, auth.CredentialStoreConfigPath))
+
 	rv.PersistentPreRunE = func(c *cobra.Command, args []string) error {
 		e := parentPreRun(c, args)
 		if e != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/konnect/auth"
 	"github.com/kong/kongctl/internal/meta"
@@ -15,7 +16,7 @@ import (
 var (
 	logoutKonnectShort = i18n.T("root.products.konnect.logoutKonnectShort", "Logout from Konnect")
 	logoutKonnectLong  = i18n.T("root.products.konnect.logoutKonnectLong",
-		"Remove persisted Konnect authentication tokens for the active profile.")
+		"Revoke the Konnect session of the active profile and remove its persisted authentication tokens.")
 	logoutKonnectExample = normalizers.Examples(
 		i18n.T("root.products.konnect.logoutKonnectExample",
 			fmt.Sprintf(`
@@ -45,6 +46,20 @@ func (c *logoutKonnectCmd) run(helper cmd.Helper) error {
 	streams := helper.GetStreams()
 	profileName := cfg.GetProfile()
 
+	baseURL, err := common.ResolveBaseURL(cfg)
+	if err != nil {
+		return err
+	}
+	logoutPath := cfg.GetString(common.LogoutPathConfigPath)
+	if logoutPath == "" {
+		logoutPath = common.LogoutPathDefault
+	}
+	// Revocation is best effort: the local tokens are removed even when Konnect
+	// cannot be reached
+	if _, err := auth.RevokeAccessToken(cfg, baseURL+logoutPath); err != nil {
+		fmt.Fprintf(streams.ErrOut, "Warning: failed to revoke the Konnect session: %v\n", err)
+	}
+
 	removed, err := auth.DeleteAccessToken(cfg)
 	if err != nil {
 		return cmd.PrepareExecutionErrorWithHelper(helper,
@@ -84,8 +99,21 @@ func newLogoutKonnectCmd(verb verbs.VerbValue,
 
 	addParentFlags(verb, rv.Command)
 
+	rv.Flags().String(common.LogoutPathFlagName, common.LogoutPathDefault,
+		fmt.Sprintf(`URL path used to revoke the Konnect session on logout.
+- Config path: [ %s ]
+-`, // (default ...)
+			common.LogoutPathConfigPath))
+
 	rv.PersistentPreRunE = func(c *cobra.Command, args []string) error {
-		return parentPreRun(c, args)
+		if err := parentPreRun(c, args); err != nil {
+			return err
+		}
+		cfg, err := cmd.BuildHelper(c, args).GetConfig()
+		if err != nil {
+			return err
+		}
+		return cfg.BindFlag(common.LogoutPathConfigPath, c.Flags().Lookup(common.LogoutPathFlagName))
 	}
 
 	rv.RunE = rv.runE
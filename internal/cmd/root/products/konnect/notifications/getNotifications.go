@@ -0,0 +1,234 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	getNotificationsShort = i18n.T("root.products.konnect.notifications.getNotificationsShort",
+		"List notification events, or the subscriptions to an event")
+	getNotificationsLong = i18n.T("root.products.konnect.notifications.getNotificationsLong",
+		`Use the get verb with the notifications command to list the notification events of the current user.
+Pass an event ID to list the subscriptions of the user to that event. Subscriptions are managed
+declaratively with the notification_subscriptions resource.`)
+	getNotificationsExample = normalizers.Examples(i18n.T("root.products.konnect.notifications.getNotificationsExample",
+		fmt.Sprintf(`
+	# List notification events
+	%[1]s get notifications
+	# List the subscriptions to an event
+	%[1]s get notifications app-registration-requested
+	`, meta.CLIName)))
+)
+
+type eventRecord struct {
+	ID            string
+	Title         string
+	Namespace     string
+	Subscriptions string
+}
+
+type subscriptionRecord struct {
+	ID       string
+	Name     string
+	Enabled  string
+	Channels string
+	Regions  string
+	Entities string
+}
+
+func eventToRecord(e kkComps.UserConfiguration) eventRecord {
+	count := int64(0)
+	if e.EventSubscriptionCount != nil {
+		count = *e.EventSubscriptionCount
+	}
+	return eventRecord{
+		ID:            e.EventID,
+		Title:         e.EventTitle,
+		Namespace:     string(e.EventNamespace),
+		Subscriptions: fmt.Sprintf("%d", count),
+	}
+}
+
+func subscriptionToRecord(s kkComps.EventSubscriptionResponse) subscriptionRecord {
+	channels := make([]string, 0, len(s.Channels))
+	for _, channel := range s.Channels {
+		if channel.Enabled {
+			channels = append(channels, string(channel.Type))
+		}
+	}
+	regions := make([]string, 0, len(s.Regions))
+	for _, region := range s.Regions {
+		regions = append(regions, string(region))
+	}
+	entities := make([]string, 0, len(s.Entities))
+	for _, entity := range s.Entities {
+		entities = append(entities, util.AbbreviateUUID(entity))
+	}
+
+	return subscriptionRecord{
+		ID:       util.AbbreviateUUID(s.ID),
+		Name:     s.Name,
+		Enabled:  fmt.Sprintf("%t", s.Enabled),
+		Channels: strings.Join(channels, ", "),
+		Regions:  strings.Join(regions, ", "),
+		Entities: strings.Join(entities, ", "),
+	}
+}
+
+type getNotificationsCmd struct {
+	*cobra.Command
+}
+
+func runListEvents(kkClient helpers.NotificationsAPI, helper cmd.Helper) ([]kkComps.UserConfiguration, error) {
+	res, err := kkClient.ListUserConfigurations(helper.GetContext(), nil)
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return nil, cmd.PrepareExecutionError("Failed to list notification events", err, helper.GetCmd(), attrs...)
+	}
+	if res.UserConfigurationListResponse == nil {
+		return []kkComps.UserConfiguration{}, nil
+	}
+	return res.UserConfigurationListResponse.Data, nil
+}
+
+func runListSubscriptions(
+	kkClient helpers.NotificationsAPI, helper cmd.Helper, eventID string,
+) ([]kkComps.EventSubscriptionResponse, error) {
+	res, err := kkClient.ListEventSubscriptions(helper.GetContext(), eventID)
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return nil, cmd.PrepareExecutionError(
+			fmt.Sprintf("Failed to list subscriptions to notification event %s", eventID),
+			err, helper.GetCmd(), attrs...)
+	}
+	if res.EventSubscriptionListResponse == nil {
+		return []kkComps.EventSubscriptionResponse{}, nil
+	}
+	return res.EventSubscriptionListResponse.Data, nil
+}
+
+func (c *getNotificationsCmd) validate(helper cmd.Helper) error {
+	if len(helper.GetArgs()) > 1 {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("too many arguments. Listing notification events requires 0 or 1 arguments (event ID)"),
+		}
+	}
+	return nil
+}
+
+func (c *getNotificationsCmd) runE(cobraCmd *cobra.Command, args []string) error {
+	helper := cmd.BuildHelper(cobraCmd, args)
+	if err := c.validate(helper); err != nil {
+		return err
+	}
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	api := sdk.GetNotificationsAPI()
+	if api == nil {
+		return cmd.PrepareExecutionError("Notifications API is not available",
+			fmt.Errorf("notifications API client not configured"), helper.GetCmd())
+	}
+
+	if len(helper.GetArgs()) == 1 {
+		eventID := strings.TrimSpace(helper.GetArgs()[0])
+		subscriptions, err := runListSubscriptions(api, helper, eventID)
+		if err != nil {
+			return err
+		}
+
+		records := make([]subscriptionRecord, 0, len(subscriptions))
+		for _, subscription := range subscriptions {
+			records = append(records, subscriptionToRecord(subscription))
+		}
+		return tableview.RenderForFormat(helper,
+			false,
+			outType,
+			printer,
+			helper.GetStreams(),
+			records,
+			subscriptions,
+			"Notification Subscriptions",
+			tableview.WithRootLabel(helper.GetCmd().Name()),
+		)
+	}
+
+	events, err := runListEvents(api, helper)
+	if err != nil {
+		return err
+	}
+
+	records := make([]eventRecord, 0, len(events))
+	for _, event := range events {
+		records = append(records, eventToRecord(event))
+	}
+	return tableview.RenderForFormat(helper,
+		false,
+		outType,
+		printer,
+		helper.GetStreams(),
+		records,
+		events,
+		"Notification Events",
+		tableview.WithRootLabel(helper.GetCmd().Name()),
+	)
+}
+
+func newGetNotificationsCmd(
+	verb verbs.VerbValue,
+	baseCmd *cobra.Command,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *getNotificationsCmd {
+	rv := &getNotificationsCmd{Command: baseCmd}
+	rv.Short = getNotificationsShort
+	rv.Long = getNotificationsLong
+	rv.Example = getNotificationsExample
+	rv.RunE = rv.runE
+	if parentPreRun != nil {
+		rv.PreRunE = parentPreRun
+	}
+
+	if addParentFlags != nil {
+		addParentFlags(verb, rv.Command)
+	}
+
+	return rv
+}
@@ -0,0 +1,53 @@
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	CommandName = "notifications"
+)
+
+var (
+	notificationsUse = CommandName
+
+	notificationsShort = i18n.T("root.products.konnect.notifications.notificationsShort",
+		"List Konnect notification events and subscriptions")
+
+	notificationsLong = normalizers.LongDesc(i18n.T("root.products.konnect.notifications.notificationsLong",
+		`The notifications command lists the Konnect notification events the current user can subscribe to,
+and the subscriptions of the user to an event.`))
+
+	notificationsExample = normalizers.Examples(i18n.T("root.products.konnect.notifications.notificationsExample",
+		fmt.Sprintf(`
+	# List notification events
+	%[1]s get notifications
+	# List the subscriptions to an event
+	%[1]s get notifications app-registration-requested
+	`, meta.CLIName)))
+)
+
+func NewNotificationsCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) (*cobra.Command, error) {
+	baseCmd := cobra.Command{
+		Use:     notificationsUse + " [event-id]",
+		Short:   notificationsShort,
+		Long:    notificationsLong,
+		Example: notificationsExample,
+		Aliases: []string{"notification"},
+	}
+
+	if verb == verbs.Get {
+		return newGetNotificationsCmd(verb, &baseCmd, addParentFlags, parentPreRun).Command, nil
+	}
+	return &baseCmd, nil
+}
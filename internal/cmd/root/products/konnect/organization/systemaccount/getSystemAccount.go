@@ -44,6 +44,7 @@ func newGetSystemAccountCmd(
 	if addParentFlags != nil {
 		addParentFlags(verb, cmd.Command)
 	}
+	common.AddLimitFlag(cmd.Command)
 
 	cmd.RunE = cmd.runE
 
@@ -134,6 +135,11 @@ func renderSystemAccountsList(
 func (s *getSystemAccountCmd) runE(c *cobra.Command, args []string) error {
 	helper := cmd.BuildHelper(c, args)
 
+	limit, err := common.ListLimit(helper)
+	if err != nil {
+		return err
+	}
+
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
@@ -162,10 +168,11 @@ func (s *getSystemAccountCmd) runE(c *cobra.Command, args []string) error {
 
 	// No args: list all
 	if len(args) == 0 {
-		systemAccounts, err := runList(sdk.GetSystemAccountAPI(), helper, cfg)
+		systemAccounts, err := runList(sdk.GetSystemAccountAPI(), helper, cfg, limit)
 		if err != nil {
 			return err
 		}
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, len(systemAccounts))
 
 		return renderSystemAccountsList(helper, helper.GetCmd().Name(), outType, printer, systemAccounts)
 	}
@@ -218,38 +225,23 @@ func runGet(id string, kkClient helpers.SystemAccountAPI, helper cmd.Helper) (*k
 	return res.GetSystemAccount(), nil
 }
 
-func runList(kkClient helpers.SystemAccountAPI, helper cmd.Helper, cfg config.Hook) ([]kkComps.SystemAccount, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-	if requestPageSize < 1 {
-		requestPageSize = int64(common.DefaultRequestPageSize)
-	}
-
-	var allData []kkComps.SystemAccount
-
-	for {
+func runList(kkClient helpers.SystemAccountAPI, helper cmd.Helper, cfg config.Hook,
+	limit int,
+) ([]kkComps.SystemAccount, error) {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.SystemAccount, float64, error) {
 		req := kkOps.GetSystemAccountsRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
 
 		res, err := kkClient.ListSystemAccounts(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list System Accounts", err, helper.GetCmd(), attrs...)
+			return nil, 0, cmd.PrepareExecutionError("Failed to list System Accounts", err, helper.GetCmd(), attrs...)
 		}
-
-		allData = append(allData, res.GetSystemAccountCollection().Data...)
-		totalItems := res.GetSystemAccountCollection().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
-		}
-
-		pageNumber++
+		return res.GetSystemAccountCollection().Data, res.GetSystemAccountCollection().Meta.Page.Total, nil
 	}
-
-	return allData, nil
+	return common.ListPages(cfg, limit, fetch)
 }
 
 func runListByName(name string, kkClient helpers.SystemAccountAPI, helper cmd.Helper,
@@ -286,10 +278,12 @@ func runListByName(name string, kkClient helpers.SystemAccountAPI, helper cmd.He
 		pageNumber++
 	}
 
-	if len(allData) > 0 {
-		return &allData[0], nil
-	}
-	return nil, fmt.Errorf("system account with name %s not found", name)
+	return common.SelectByName(helper, "system account", name, allData, func(account kkComps.SystemAccount) string {
+		if account.ID == nil {
+			return ""
+		}
+		return *account.ID
+	})
 }
 
 func buildSystemAccountChildView(accounts []kkComps.SystemAccount) tableview.ChildView {
@@ -34,7 +34,7 @@ func loadOrganizationSystemAccounts(_ context.Context, helper cmd.Helper, parent
 		return tableview.ChildView{}, err
 	}
 
-	accounts, err := runList(sdk.GetSystemAccountAPI(), helper, cfg)
+	accounts, err := runList(sdk.GetSystemAccountAPI(), helper, cfg, 0)
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
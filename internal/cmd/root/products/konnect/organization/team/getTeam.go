@@ -47,6 +47,7 @@ func newGetTeamCmd(
 
 	// Add skip-system-teams flag for list/get operations
 	cmd.Flags().Bool(skipSystemTeamsFlagName, false, "Skip system teams in the output")
+	common.AddLimitFlag(cmd.Command)
 
 	cmd.RunE = cmd.runE
 
@@ -137,6 +138,11 @@ func renderTeamsList(
 func (t *getTeamCmd) runE(c *cobra.Command, args []string) error {
 	helper := cmd.BuildHelper(c, args)
 
+	limit, err := common.ListLimit(helper)
+	if err != nil {
+		return err
+	}
+
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
@@ -168,10 +174,11 @@ func (t *getTeamCmd) runE(c *cobra.Command, args []string) error {
 
 	// No args: list all
 	if len(args) == 0 {
-		teams, err := runList(sdk.GetOrganizationTeamAPI(), helper, cfg, skipSystemTeams)
+		teams, err := runList(sdk.GetOrganizationTeamAPI(), helper, cfg, skipSystemTeams, limit)
 		if err != nil {
 			return err
 		}
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, len(teams))
 
 		return renderTeamsList(helper, helper.GetCmd().Name(), outType, printer, teams)
 	}
@@ -227,49 +234,41 @@ func runGet(id string, kkClient helpers.OrganizationTeamAPI, helper cmd.Helper)
 }
 
 func runList(kkClient helpers.OrganizationTeamAPI, helper cmd.Helper,
-	cfg config.Hook, skipSystemTeams bool,
+	cfg config.Hook, skipSystemTeams bool, limit int,
 ) ([]kkComps.Team, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-	if requestPageSize < 1 {
-		requestPageSize = int64(common.DefaultRequestPageSize)
-	}
-
-	var allData []kkComps.Team
-	var totalFetched int
-
-	for {
+	fetch := func(pageSize, pageNumber int64) ([]kkComps.Team, float64, error) {
 		req := kkOps.ListTeamsRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
 
 		res, err := kkClient.ListOrganizationTeams(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list Teams", err, helper.GetCmd(), attrs...)
+			return nil, 0, cmd.PrepareExecutionError("Failed to list Teams", err, helper.GetCmd(), attrs...)
 		}
+		return res.GetTeamCollection().Data, res.GetTeamCollection().Meta.Page.Total, nil
+	}
 
-		data := res.GetTeamCollection().Data
-		totalFetched += len(data)
-
-		// Filter out system teams if flag is set
-		for _, team := range data {
-			if skipSystemTeams && team.SystemTeam != nil && *team.SystemTeam {
-				continue
-			}
-			allData = append(allData, team)
-		}
+	// System teams are skipped once listed, so the limit applies after skipping them
+	fetchLimit := limit
+	if skipSystemTeams {
+		fetchLimit = 0
+	}
+	teams, err := common.ListPages(cfg, fetchLimit, fetch)
+	if err != nil {
+		return nil, err
+	}
 
-		totalItems := res.GetTeamCollection().Meta.Page.Total
-		if totalFetched >= int(totalItems) {
-			break
+	var allData []kkComps.Team
+	for _, team := range teams {
+		if skipSystemTeams && team.SystemTeam != nil && *team.SystemTeam {
+			continue
 		}
-
-		pageNumber++
+		allData = append(allData, team)
 	}
 
-	return allData, nil
+	return common.LimitResults(allData, limit), nil
 }
 
 func runListByName(name string, kkClient helpers.OrganizationTeamAPI, helper cmd.Helper,
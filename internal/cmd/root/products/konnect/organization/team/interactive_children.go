@@ -34,7 +34,7 @@ func loadOrganizationTeams(_ context.Context, helper cmd.Helper, parent any) (ta
 		return tableview.ChildView{}, err
 	}
 
-	teams, err := runList(sdk.GetOrganizationTeamAPI(), helper, cfg, false)
+	teams, err := runList(sdk.GetOrganizationTeamAPI(), helper, cfg, false, 0)
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
@@ -0,0 +1,179 @@
+package portal
+
+import (
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	registrationCommandName = "application-registration"
+)
+
+var (
+	revokeRegistrationShort = i18n.T("root.products.konnect.portal.revokeRegistrationShort",
+		"Revoke an application registration of a Konnect portal")
+	revokeRegistrationLong = normalizers.LongDesc(i18n.T("root.products.konnect.portal.revokeRegistrationLong",
+		`Use the revoke verb to withdraw the access an application registration grants to an API.
+The registration is kept with the revoked status and can be approved again from Konnect.`))
+	revokeRegistrationExample = normalizers.Examples(
+		i18n.T("root.products.konnect.portal.revokeRegistrationExamples",
+			fmt.Sprintf(`
+# Revoke a registration by ID
+%[1]s revoke application-registration --portal-id <portal-id> <registration-id>
+# Revoke a registration of a known application in a portal given by name
+%[1]s revoke application-registration --portal-name my-portal --application-id <app-id> <registration-id>
+`, meta.CLIName)))
+)
+
+func newRevokePortalApplicationRegistrationCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s <registration-id>", registrationCommandName),
+		Short:   revokeRegistrationShort,
+		Long:    revokeRegistrationLong,
+		Example: revokeRegistrationExample,
+		Aliases: []string{
+			"application-registrations",
+			"registration",
+			"registrations",
+		},
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if parentPreRun != nil {
+				if err := parentPreRun(cmd, args); err != nil {
+					return err
+				}
+			}
+			return bindPortalChildFlags(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler := portalApplicationRegistrationRevokeHandler{cmd: cmd}
+			return handler.run(args)
+		},
+	}
+
+	addPortalChildFlags(cmd)
+	cmd.Flags().String(applicationIDFlagName, "",
+		"The ID of the application owning the registration (looked up from the registration when omitted)")
+
+	if addParentFlags != nil {
+		addParentFlags(verb, cmd)
+	}
+
+	return cmd
+}
+
+type portalApplicationRegistrationRevokeHandler struct {
+	cmd *cobra.Command
+}
+
+func (h portalApplicationRegistrationRevokeHandler) run(args []string) error {
+	helper := cmd.BuildHelper(h.cmd, args)
+
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	portalID, err := requirePortalID(helper, sdk, cfg)
+	if err != nil {
+		return err
+	}
+
+	regAPI := sdk.GetPortalApplicationRegistrationAPI()
+	if regAPI == nil {
+		return &cmd.ExecutionError{
+			Msg: "Portal application registrations client is not available",
+			Err: fmt.Errorf("portal application registrations client not configured"),
+		}
+	}
+
+	registrationID := strings.TrimSpace(args[0])
+	if registrationID == "" {
+		return &cmd.ConfigurationError{Err: fmt.Errorf("registration identifier is required")}
+	}
+
+	applicationID, _ := h.cmd.Flags().GetString(applicationIDFlagName)
+	applicationID = strings.TrimSpace(applicationID)
+	if applicationID == "" {
+		regs, err := fetchPortalApplicationRegistrations(helper, regAPI, portalID, cfg, registrationFilters{})
+		if err != nil {
+			return err
+		}
+		match := findRegistrationByID(regs, registrationID)
+		if match == nil {
+			return &cmd.ConfigurationError{Err: fmt.Errorf("registration %q not found", registrationID)}
+		}
+		applicationID = match.GetApplication().ID
+	}
+
+	if applicationID == "" {
+		return &cmd.ExecutionError{
+			Msg: "Application identifier for registration could not be determined",
+			Err: fmt.Errorf("missing application id for registration %s", registrationID),
+		}
+	}
+
+	status := kkComps.ApplicationRegistrationStatusRevoked
+	res, err := regAPI.UpdateApplicationRegistration(helper.GetContext(), kkOps.UpdateApplicationRegistrationRequest{
+		PortalID:       portalID,
+		ApplicationID:  applicationID,
+		RegistrationID: registrationID,
+		UpdateApplicationRegistrationRequest: kkComps.UpdateApplicationRegistrationRequest{
+			Status: &status,
+		},
+	})
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		details := konnectCommon.ParseAPIErrorDetails(err)
+		attrs = konnectCommon.AppendAPIErrorAttrs(attrs, details)
+		msg := konnectCommon.BuildDetailedMessage("Failed to revoke portal application registration", attrs, err)
+		return cmd.PrepareExecutionError(msg, err, helper.GetCmd(), attrs...)
+	}
+
+	if outType == cmdCommon.TEXT {
+		fmt.Fprintf(helper.GetStreams().Out, "Portal application registration %q revoked\n", registrationID)
+		return nil
+	}
+
+	if registration := res.GetGetApplicationRegistrationResponse(); registration != nil {
+		printer.Print(registration)
+	}
+	return nil
+}
@@ -78,3 +78,29 @@ func resolvePortalIDByName(
 
 	return portal.ID, nil
 }
+
+// requirePortalID returns the ID of the portal selected with the portal child flags,
+// looking it up when the portal is given by name
+func requirePortalID(helper cmd.Helper, sdk helpers.SDKAPI, cfg config.Hook) (string, error) {
+	portalID, portalName := getPortalIdentifiers(cfg)
+	if portalID != "" && portalName != "" {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("only one of --%s or --%s can be provided", portalIDFlagName, portalNameFlagName),
+		}
+	}
+
+	if portalID == "" && portalName == "" {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf(
+				"a portal identifier is required. Provide --%s or --%s",
+				portalIDFlagName,
+				portalNameFlagName,
+			),
+		}
+	}
+
+	if portalID != "" {
+		return portalID, nil
+	}
+	return resolvePortalIDByName(portalName, sdk.GetPortalAPI(), helper, cfg)
+}
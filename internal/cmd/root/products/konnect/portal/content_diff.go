@@ -0,0 +1,353 @@
+package portal
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	contentActionCreate = "create"
+	contentActionUpdate = "update"
+	contentActionMove   = "move"
+	contentActionDelete = "delete"
+
+	// contentHomePath is the path of the page read from the index file at the root of
+	// the content directory
+	contentHomePath = "/"
+)
+
+var contentSlugPattern = regexp.MustCompile(`^[\w-]+$`)
+
+// contentPage is a portal page read from a content directory. Path joins the slugs
+// of the page and its parents with slashes.
+type contentPage struct {
+	Path        string
+	Slug        string
+	ParentPath  string
+	File        string
+	Title       string
+	Description string
+	Visibility  string
+	Status      string
+	Content     string
+}
+
+// contentFrontMatter holds the page fields that can be set in the front matter of a
+// content file
+type contentFrontMatter struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+	Status      string `json:"status"`
+}
+
+// remoteContentPage is a page of the portal
+type remoteContentPage struct {
+	ID          string
+	Path        string
+	Slug        string
+	ParentPath  string
+	Title       string
+	Description string
+	Visibility  string
+	Status      string
+	Content     string
+}
+
+// contentChange is a change of a portal page needed to match the content directory
+type contentChange struct {
+	Action   string   `json:"action"`
+	Path     string   `json:"path"`
+	FromPath string   `json:"from_path,omitempty"`
+	File     string   `json:"file,omitempty"`
+	Fields   []string `json:"fields,omitempty"`
+
+	page   *contentPage
+	remote *remoteContentPage
+}
+
+func (c contentChange) String() string {
+	switch c.Action {
+	case contentActionCreate:
+		return fmt.Sprintf("+ create %s (%s)", c.Path, c.File)
+	case contentActionUpdate:
+		return fmt.Sprintf("~ update %s (%s)", c.Path, strings.Join(c.Fields, ", "))
+	case contentActionMove:
+		return fmt.Sprintf("> move %s -> %s (%s)", c.FromPath, c.Path, strings.Join(c.Fields, ", "))
+	default:
+		return fmt.Sprintf("- delete %s", c.Path)
+	}
+}
+
+// readContentDir reads the markdown and MDC files of dir as portal pages. A file is
+// the page named by its path: guides/install.md is the page install below the page
+// guides, whose content is guides.md or guides/index.md. The index file at the root
+// of dir is the home page of the portal.
+func readContentDir(dir string) ([]contentPage, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	pages := make(map[string]*contentPage)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(d.Name())
+		if d.IsDir() || (ext != ".md" && ext != ".mdc") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		segments := strings.Split(strings.TrimSuffix(rel, ext), "/")
+		if segments[len(segments)-1] == "index" {
+			segments = segments[:len(segments)-1]
+		}
+		for _, segment := range segments {
+			if !contentSlugPattern.MatchString(segment) {
+				return fmt.Errorf(
+					"%s: invalid slug %q: slugs must contain only letters, numbers, underscores, and hyphens",
+					rel, segment)
+			}
+		}
+
+		page := &contentPage{Path: contentHomePath, Slug: contentHomePath, File: rel}
+		if len(segments) > 0 {
+			page.Path = strings.Join(segments, "/")
+			page.Slug = segments[len(segments)-1]
+			page.ParentPath = strings.Join(segments[:len(segments)-1], "/")
+		}
+		if existing, ok := pages[page.Path]; ok {
+			return fmt.Errorf("%s and %s are both the page %s", existing.File, rel, page.Path)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := parseContentPage(page, string(data)); err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		pages[page.Path] = page
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]contentPage, 0, len(pages))
+	for _, page := range pages {
+		if page.ParentPath != "" && pages[page.ParentPath] == nil {
+			return nil, fmt.Errorf("%s: parent page %s has no %s.md or %s/index.md file",
+				page.File, page.ParentPath, page.ParentPath, page.ParentPath)
+		}
+		result = append(result, *page)
+	}
+	sortContentPaths(result, func(p contentPage) string { return p.Path }, false)
+	return result, nil
+}
+
+// parseContentPage sets the content of the page and the fields given by its front
+// matter. The front matter stays part of the content.
+func parseContentPage(page *contentPage, data string) error {
+	page.Content = data
+
+	rest, ok := strings.CutPrefix(strings.ReplaceAll(data, "\r\n", "\n"), "---\n")
+	if !ok {
+		return nil
+	}
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return fmt.Errorf("front matter is not terminated by ---")
+	}
+
+	var matter contentFrontMatter
+	if err := yaml.Unmarshal([]byte(rest[:end]), &matter); err != nil {
+		return fmt.Errorf("invalid front matter: %w", err)
+	}
+	if matter.Visibility != "" && matter.Visibility != string(kkComps.PageVisibilityStatusPublic) &&
+		matter.Visibility != string(kkComps.PageVisibilityStatusPrivate) {
+		return fmt.Errorf("invalid visibility %q: must be public or private", matter.Visibility)
+	}
+	if matter.Status != "" && matter.Status != string(kkComps.PublishedStatusPublished) &&
+		matter.Status != string(kkComps.PublishedStatusUnpublished) {
+		return fmt.Errorf("invalid status %q: must be published or unpublished", matter.Status)
+	}
+
+	page.Title = matter.Title
+	page.Description = matter.Description
+	page.Visibility = matter.Visibility
+	page.Status = matter.Status
+	return nil
+}
+
+// remoteContentPages flattens the page tree of a portal, computing the path of each page
+func remoteContentPages(pages []kkComps.PortalPageInfo) []remoteContentPage {
+	var result []remoteContentPage
+	var walk func(page kkComps.PortalPageInfo, parentPath string)
+
+	walk = func(page kkComps.PortalPageInfo, parentPath string) {
+		path := page.GetSlug()
+		if parentPath != "" && parentPath != contentHomePath {
+			path = parentPath + "/" + strings.TrimPrefix(path, "/")
+		}
+		remote := remoteContentPage{
+			ID:         page.GetID(),
+			Path:       path,
+			Slug:       page.GetSlug(),
+			ParentPath: parentPath,
+			Title:      page.GetTitle(),
+			Visibility: string(page.GetVisibility()),
+			Status:     string(page.GetStatus()),
+		}
+		if page.GetDescription() != nil {
+			remote.Description = *page.GetDescription()
+		}
+		result = append(result, remote)
+		for _, child := range page.Children {
+			walk(child, path)
+		}
+	}
+
+	for _, page := range pages {
+		walk(page, "")
+	}
+	return result
+}
+
+// diffPortalContent computes the changes making the portal pages match the local
+// pages. A local page below another page without a remote page of the same path is a
+// move of the only remote page of its slug that no local page matches, and any other
+// local page without a remote page is a create. Creates, updates and moves come
+// parents first, followed by deletes children first.
+func diffPortalContent(local []contentPage, remote []remoteContentPage) []contentChange {
+	remoteByPath := make(map[string]*remoteContentPage, len(remote))
+	for i := range remote {
+		remoteByPath[remote[i].Path] = &remote[i]
+	}
+	localByPath := make(map[string]bool, len(local))
+	for _, page := range local {
+		localByPath[page.Path] = true
+	}
+
+	unmatchedBySlug := make(map[string][]*remoteContentPage)
+	for i := range remote {
+		if !localByPath[remote[i].Path] {
+			unmatchedBySlug[remote[i].Slug] = append(unmatchedBySlug[remote[i].Slug], &remote[i])
+		}
+	}
+	missingBySlug := make(map[string]int)
+	for _, page := range local {
+		if remoteByPath[page.Path] == nil {
+			missingBySlug[page.Slug]++
+		}
+	}
+
+	sortContentPaths(local, func(p contentPage) string { return p.Path }, false)
+	changes := []contentChange{}
+	moved := make(map[string]bool)
+	for i := range local {
+		page := &local[i]
+		if existing := remoteByPath[page.Path]; existing != nil {
+			if fields := changedContentFields(page, existing); len(fields) > 0 {
+				changes = append(changes, contentChange{
+					Action: contentActionUpdate, Path: page.Path, File: page.File, Fields: fields,
+					page: page, remote: existing,
+				})
+			}
+			continue
+		}
+
+		candidates := unmatchedBySlug[page.Slug]
+		// Pages cannot be moved to the top level, as the parent of a page cannot be unset
+		if page.ParentPath != "" && len(candidates) == 1 && missingBySlug[page.Slug] == 1 {
+			existing := candidates[0]
+			moved[existing.ID] = true
+			changes = append(changes, contentChange{
+				Action: contentActionMove, Path: page.Path, FromPath: existing.Path, File: page.File,
+				Fields: append([]string{"parent"}, changedContentFields(page, existing)...),
+				page:   page, remote: existing,
+			})
+			continue
+		}
+
+		changes = append(changes, contentChange{Action: contentActionCreate, Path: page.Path, File: page.File, page: page})
+	}
+
+	var deletes []contentChange
+	for i := range remote {
+		if localByPath[remote[i].Path] || moved[remote[i].ID] {
+			continue
+		}
+		deletes = append(deletes, contentChange{Action: contentActionDelete, Path: remote[i].Path, remote: &remote[i]})
+	}
+	sortContentPaths(deletes, func(c contentChange) string { return c.Path }, true)
+	return append(changes, deletes...)
+}
+
+// changedContentFields lists the fields of the remote page that differ from the local
+// page. Fields the local page leaves unset are not compared.
+func changedContentFields(page *contentPage, remote *remoteContentPage) []string {
+	var fields []string
+	if page.Title != "" && page.Title != remote.Title {
+		fields = append(fields, "title")
+	}
+	if page.Description != "" && page.Description != remote.Description {
+		fields = append(fields, "description")
+	}
+	if page.Visibility != "" && page.Visibility != remote.Visibility {
+		fields = append(fields, "visibility")
+	}
+	if page.Status != "" && page.Status != remote.Status {
+		fields = append(fields, "status")
+	}
+	if strings.TrimRight(page.Content, "\n") != strings.TrimRight(remote.Content, "\n") {
+		fields = append(fields, "content")
+	}
+	return fields
+}
+
+// sortContentPaths sorts items by the depth of their path, parents first unless
+// childrenFirst is set, and by path within a depth
+func sortContentPaths[T any](items []T, path func(T) string, childrenFirst bool) {
+	sort.SliceStable(items, func(i, j int) bool {
+		pi, pj := path(items[i]), path(items[j])
+		di, dj := contentPathDepth(pi), contentPathDepth(pj)
+		if di != dj {
+			if childrenFirst {
+				return di > dj
+			}
+			return di < dj
+		}
+		return pi < pj
+	})
+}
+
+func contentPathDepth(path string) int {
+	if path == contentHomePath {
+		return 0
+	}
+	return strings.Count(path, "/")
+}
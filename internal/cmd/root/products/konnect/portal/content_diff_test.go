@@ -0,0 +1,155 @@
+package portal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/stretchr/testify/require"
+)
+
+func writeContentFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReadContentDir(t *testing.T) {
+	dir := t.TempDir()
+	writeContentFile(t, dir, "index.md", "# Welcome\n")
+	writeContentFile(t, dir, "guides/index.md", "---\ntitle: Guides\nvisibility: private\n---\n# Guides\n")
+	writeContentFile(t, dir, "guides/install.mdc", "Install it\n")
+	writeContentFile(t, dir, "about.md", "About\n")
+	writeContentFile(t, dir, "notes.txt", "ignored")
+	writeContentFile(t, dir, ".drafts/draft.md", "ignored")
+
+	pages, err := readContentDir(dir)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, page := range pages {
+		paths = append(paths, page.Path)
+	}
+	require.Equal(t, []string{"/", "about", "guides", "guides/install"}, paths)
+
+	guides := pages[2]
+	require.Equal(t, "guides/index.md", guides.File)
+	require.Equal(t, "Guides", guides.Title)
+	require.Equal(t, "private", guides.Visibility)
+	require.Contains(t, guides.Content, "title: Guides")
+
+	install := pages[3]
+	require.Equal(t, "install", install.Slug)
+	require.Equal(t, "guides", install.ParentPath)
+	require.Empty(t, install.Title)
+}
+
+func TestReadContentDirErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name:  "missing parent page",
+			files: map[string]string{"guides/install.md": "Install"},
+			err:   "parent page guides has no guides.md or guides/index.md file",
+		},
+		{
+			name:  "duplicate page",
+			files: map[string]string{"guides.md": "Guides", "guides/index.md": "Guides"},
+			err:   "are both the page guides",
+		},
+		{
+			name:  "invalid slug",
+			files: map[string]string{"getting started.md": "Start"},
+			err:   `invalid slug "getting started"`,
+		},
+		{
+			name:  "invalid status",
+			files: map[string]string{"about.md": "---\nstatus: draft\n---\nAbout"},
+			err:   `invalid status "draft"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeContentFile(t, dir, name, content)
+			}
+			_, err := readContentDir(dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestRemoteContentPages(t *testing.T) {
+	pages := remoteContentPages([]kkComps.PortalPageInfo{
+		{ID: "home", Slug: "/"},
+		{ID: "guides", Slug: "guides", Children: []kkComps.PortalPageInfo{{ID: "install", Slug: "install"}}},
+	})
+
+	require.Len(t, pages, 3)
+	require.Equal(t, "/", pages[0].Path)
+	require.Equal(t, "guides/install", pages[2].Path)
+	require.Equal(t, "guides", pages[2].ParentPath)
+}
+
+func TestDiffPortalContent(t *testing.T) {
+	local := []contentPage{
+		{Path: "/", Slug: "/", Content: "Welcome\n"},
+		{Path: "guides", Slug: "guides", Title: "Guides", Content: "Guides"},
+		{Path: "guides/install", Slug: "install", ParentPath: "guides", Title: "install", Content: "Install"},
+		{
+			Path: "guides/upgrade", Slug: "upgrade", ParentPath: "guides", File: "guides/upgrade.md",
+			Title: "upgrade", Content: "Upgrade",
+		},
+	}
+	remote := []remoteContentPage{
+		{ID: "home", Path: "/", Slug: "/", Title: "Home", Content: "Welcome"},
+		{ID: "guides", Path: "guides", Slug: "guides", Title: "Guides", Content: "Old guides"},
+		{ID: "old", Path: "old", Slug: "old", Title: "old"},
+		{ID: "install", Path: "old/install", Slug: "install", ParentPath: "old", Title: "install", Content: "Install"},
+		{ID: "legacy", Path: "old/legacy", Slug: "legacy", ParentPath: "old", Title: "legacy"},
+	}
+
+	changes := diffPortalContent(local, remote)
+
+	var summary []string
+	for _, change := range changes {
+		summary = append(summary, change.String())
+	}
+	require.Equal(t, []string{
+		"~ update guides (content)",
+		"> move old/install -> guides/install (parent)",
+		"+ create guides/upgrade (guides/upgrade.md)",
+		"- delete old/legacy",
+		"- delete old",
+	}, summary)
+}
+
+func TestDiffPortalContentAmbiguousMove(t *testing.T) {
+	local := []contentPage{
+		{Path: "a", Slug: "a", Title: "a"},
+		{Path: "a/intro", Slug: "intro", ParentPath: "a", Title: "intro"},
+		{Path: "b", Slug: "b", Title: "b"},
+		{Path: "b/intro", Slug: "intro", ParentPath: "b", Title: "intro"},
+	}
+	remote := []remoteContentPage{
+		{ID: "a", Path: "a", Slug: "a", Title: "a"},
+		{ID: "b", Path: "b", Slug: "b", Title: "b"},
+		{ID: "intro", Path: "intro", Slug: "intro", Title: "intro"},
+	}
+
+	changes := diffPortalContent(local, remote)
+
+	require.Len(t, changes, 3)
+	require.Equal(t, contentActionCreate, changes[0].Action)
+	require.Equal(t, contentActionCreate, changes[1].Action)
+	require.Equal(t, contentActionDelete, changes[2].Action)
+	require.Equal(t, "intro", changes[2].Path)
+}
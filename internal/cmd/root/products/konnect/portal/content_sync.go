@@ -0,0 +1,358 @@
+package portal
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	contentCommandName = "content"
+
+	contentDirFlagName         = "dir"
+	contentDryRunFlagName      = "dry-run"
+	contentAutoApproveFlagName = "auto-approve"
+)
+
+var (
+	syncContentShort = i18n.T("root.products.konnect.portal.syncContentShort",
+		"Synchronize the pages of a Konnect portal with a content directory")
+	syncContentLong = normalizers.LongDesc(i18n.T("root.products.konnect.portal.syncContentLong",
+		`Use the sync verb to make the pages of a Konnect portal match a directory of markdown (.md)
+and MDC (.mdc) files. Pages are created, updated, moved and DELETED as needed.
+
+Each file is the page named by its path: guides/install.md is the page install below the
+page guides, whose content is guides.md or guides/index.md. The index file at the root of
+the directory is the home page of the portal. The optional front matter of a file sets the
+title, description, visibility and status of its page.`))
+	syncContentExample = normalizers.Examples(
+		i18n.T("root.products.konnect.portal.syncContentExamples",
+			fmt.Sprintf(`
+# Preview the page changes for a portal given by name
+%[1]s sync portal content --portal-name my-portal --dir ./site --dry-run
+# Synchronize the pages without confirmation
+%[1]s sync portal content --portal-id <portal-id> --dir ./site --auto-approve
+`, meta.CLIName)))
+)
+
+func newSyncPortalContentCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     contentCommandName,
+		Short:   syncContentShort,
+		Long:    syncContentLong,
+		Example: syncContentExample,
+		Aliases: []string{"pages"},
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if parentPreRun != nil {
+				if err := parentPreRun(cmd, args); err != nil {
+					return err
+				}
+			}
+			return bindPortalChildFlags(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler := portalContentSyncHandler{cmd: cmd}
+			return handler.run(args)
+		},
+	}
+
+	addPortalChildFlags(cmd)
+	cmd.Flags().String(contentDirFlagName, "", "Directory of the markdown and MDC files of the portal pages")
+	_ = cmd.MarkFlagRequired(contentDirFlagName)
+	cmd.Flags().Bool(contentDryRunFlagName, false, "Preview the page changes without applying them")
+	cmd.Flags().Bool(contentAutoApproveFlagName, false, "Skip confirmation prompt")
+
+	if addParentFlags != nil {
+		addParentFlags(verb, cmd)
+	}
+
+	return cmd
+}
+
+type portalContentSyncHandler struct {
+	cmd *cobra.Command
+}
+
+// portalContentSyncResult is the output of the command for structured output formats
+type portalContentSyncResult struct {
+	PortalID string          `json:"portal_id"`
+	DryRun   bool            `json:"dry_run"`
+	Applied  bool            `json:"applied"`
+	Changes  []contentChange `json:"changes"`
+}
+
+func (h portalContentSyncHandler) run(args []string) error {
+	helper := cmd.BuildHelper(h.cmd, args)
+
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	dir, _ := h.cmd.Flags().GetString(contentDirFlagName)
+	dryRun, _ := h.cmd.Flags().GetBool(contentDryRunFlagName)
+	autoApprove, _ := h.cmd.Flags().GetBool(contentAutoApproveFlagName)
+	if !dryRun && !autoApprove && outType != cmdCommon.TEXT {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s or --%s is required with %s output",
+				contentAutoApproveFlagName, contentDryRunFlagName, outType),
+		}
+	}
+
+	local, err := readContentDir(dir)
+	if err != nil {
+		return &cmd.ConfigurationError{Err: fmt.Errorf("failed to read content directory: %w", err)}
+	}
+
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	portalID, err := requirePortalID(helper, sdk, cfg)
+	if err != nil {
+		return err
+	}
+
+	pageAPI := sdk.GetPortalPageAPI()
+	if pageAPI == nil {
+		return &cmd.ExecutionError{
+			Msg: "Portal pages client is not available",
+			Err: fmt.Errorf("portal pages client not configured"),
+		}
+	}
+
+	remote, err := fetchRemoteContentPages(helper, pageAPI, portalID, local)
+	if err != nil {
+		return err
+	}
+
+	changes := diffPortalContent(local, remote)
+	result := portalContentSyncResult{PortalID: portalID, DryRun: dryRun, Changes: changes}
+	out := helper.GetStreams().Out
+
+	if outType == cmdCommon.TEXT {
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "Portal pages are up to date")
+			return nil
+		}
+		fmt.Fprintf(out, "Page changes for portal %s:\n", portalID)
+		for _, change := range changes {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+		fmt.Fprintln(out, contentChangeSummary(changes))
+		if dryRun {
+			return nil
+		}
+		if !autoApprove {
+			fmt.Fprint(out, "\nDo you want to continue? Type 'yes' to confirm: ")
+			response, _ := bufio.NewReader(helper.GetStreams().In).ReadString('\n')
+			if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+				fmt.Fprintln(out, "Sync cancelled")
+				return nil
+			}
+		}
+	}
+
+	if !dryRun && len(changes) > 0 {
+		if err := applyContentChanges(helper, pageAPI, portalID, remote, changes); err != nil {
+			return err
+		}
+		result.Applied = true
+	}
+
+	if outType == cmdCommon.TEXT {
+		fmt.Fprintf(out, "Portal pages synchronized: %d changes applied\n", len(changes))
+		return nil
+	}
+
+	printer, err := cli.Format(outType.String(), out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+	printer.Print(result)
+	return nil
+}
+
+// fetchRemoteContentPages lists the pages of the portal, fetching the content of each
+// page that may match a local page
+func fetchRemoteContentPages(
+	helper cmd.Helper,
+	pageAPI helpers.PortalPageAPI,
+	portalID string,
+	local []contentPage,
+) ([]remoteContentPage, error) {
+	summaries, err := fetchPortalPageSummaries(helper, pageAPI, portalID)
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make(map[string]bool, len(local))
+	for _, page := range local {
+		slugs[page.Slug] = true
+	}
+
+	remote := remoteContentPages(summaries)
+	for i := range remote {
+		if !slugs[remote[i].Slug] {
+			continue
+		}
+		res, err := pageAPI.GetPortalPage(helper.GetContext(), portalID, remote[i].ID)
+		if err != nil {
+			attrs := cmd.TryConvertErrorToAttrs(err)
+			return nil, cmd.PrepareExecutionError(
+				fmt.Sprintf("Failed to get portal page %s", remote[i].Path), err, helper.GetCmd(), attrs...)
+		}
+		if page := res.GetPortalPageResponse(); page != nil {
+			remote[i].Content = page.GetContent()
+		}
+	}
+	return remote, nil
+}
+
+// applyContentChanges applies the changes in order, giving pages the IDs of parents
+// created before them
+func applyContentChanges(
+	helper cmd.Helper,
+	pageAPI helpers.PortalPageAPI,
+	portalID string,
+	remote []remoteContentPage,
+	changes []contentChange,
+) error {
+	pageIDs := make(map[string]string, len(remote))
+	for _, page := range remote {
+		pageIDs[page.Path] = page.ID
+	}
+	for _, change := range changes {
+		if change.Action == contentActionMove {
+			pageIDs[change.Path] = change.remote.ID
+		}
+	}
+
+	ctx := helper.GetContext()
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case contentActionCreate:
+			var res *kkOps.CreatePortalPageResponse
+			res, err = pageAPI.CreatePortalPage(ctx, portalID, newCreatePortalPageRequest(change.page, pageIDs))
+			if err == nil && res.GetPortalPageResponse() != nil {
+				pageIDs[change.Path] = res.GetPortalPageResponse().GetID()
+			}
+		case contentActionUpdate, contentActionMove:
+			_, err = pageAPI.UpdatePortalPage(ctx, kkOps.UpdatePortalPageRequest{
+				PortalID:                portalID,
+				PageID:                  change.remote.ID,
+				UpdatePortalPageRequest: newUpdatePortalPageRequest(change, pageIDs),
+			})
+		case contentActionDelete:
+			_, err = pageAPI.DeletePortalPage(ctx, portalID, change.remote.ID)
+		}
+		if err != nil {
+			attrs := cmd.TryConvertErrorToAttrs(err)
+			details := konnectCommon.ParseAPIErrorDetails(err)
+			attrs = konnectCommon.AppendAPIErrorAttrs(attrs, details)
+			msg := konnectCommon.BuildDetailedMessage(
+				fmt.Sprintf("Failed to %s portal page %s", change.Action, change.Path), attrs, err)
+			return cmd.PrepareExecutionError(msg, err, helper.GetCmd(), attrs...)
+		}
+	}
+	return nil
+}
+
+func newCreatePortalPageRequest(page *contentPage, pageIDs map[string]string) kkComps.CreatePortalPageRequest {
+	visibility := kkComps.PageVisibilityStatusPublic
+	if page.Visibility != "" {
+		visibility = kkComps.PageVisibilityStatus(page.Visibility)
+	}
+	status := kkComps.PublishedStatusPublished
+	if page.Status != "" {
+		status = kkComps.PublishedStatus(page.Status)
+	}
+
+	// Pages are titled by their slug unless the front matter sets a title
+	title := page.Title
+	if title == "" {
+		title = page.Slug
+	}
+
+	req := kkComps.CreatePortalPageRequest{
+		Slug:       page.Slug,
+		Title:      &title,
+		Content:    page.Content,
+		Visibility: &visibility,
+		Status:     &status,
+	}
+	if page.Description != "" {
+		req.Description = &page.Description
+	}
+	if page.ParentPath != "" {
+		parentID := pageIDs[page.ParentPath]
+		req.ParentPageID = &parentID
+	}
+	return req
+}
+
+func newUpdatePortalPageRequest(change contentChange, pageIDs map[string]string) kkComps.UpdatePortalPageRequest {
+	page := change.page
+	var req kkComps.UpdatePortalPageRequest
+	for _, field := range change.Fields {
+		switch field {
+		case "parent":
+			parentID := pageIDs[page.ParentPath]
+			req.ParentPageID = &parentID
+		case "title":
+			req.Title = &page.Title
+		case "description":
+			req.Description = &page.Description
+		case "visibility":
+			visibility := kkComps.VisibilityStatus(page.Visibility)
+			req.Visibility = &visibility
+		case "status":
+			status := kkComps.PublishedStatus(page.Status)
+			req.Status = &status
+		case "content":
+			req.Content = &page.Content
+		}
+	}
+	return req
+}
+
+func contentChangeSummary(changes []contentChange) string {
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Action]++
+	}
+	return fmt.Sprintf("%d to create, %d to update, %d to move, %d to delete",
+		counts[contentActionCreate], counts[contentActionUpdate], counts[contentActionMove],
+		counts[contentActionDelete])
+}
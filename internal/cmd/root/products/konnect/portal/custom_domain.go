@@ -0,0 +1,200 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkErrors "github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const customDomainCommandName = "custom-domain"
+
+var (
+	customDomainUse = customDomainCommandName
+
+	customDomainShort = i18n.T("root.products.konnect.portal.customDomainShort",
+		"Retrieve the custom domain of a portal and its setup status")
+	customDomainLong = normalizers.LongDesc(i18n.T("root.products.konnect.portal.customDomainLong",
+		`Use the custom-domain command to fetch the custom domain of a Konnect portal, including
+whether the CNAME record pointing the hostname at the portal's default domain has been
+verified and the status of its TLS certificate.`))
+	customDomainExample = normalizers.Examples(
+		i18n.T("root.products.konnect.portal.customDomainExamples",
+			fmt.Sprintf(`
+# Get the custom domain of a portal by ID
+%[1]s get portal custom-domain --portal-id <portal-id>
+# Get the custom domain of a portal by name
+%[1]s get portal custom-domain --portal-name my-portal
+`, meta.CLIName)))
+)
+
+// portalCustomDomainView is the custom domain of a portal, with the default domain the
+// CNAME record of its hostname must point to
+type portalCustomDomainView struct {
+	PortalID    string                     `json:"portal_id"    yaml:"portal_id"`
+	CNAMETarget string                     `json:"cname_target" yaml:"cname_target"`
+	Domain      kkComps.PortalCustomDomain `json:"custom_domain" yaml:"custom_domain"`
+}
+
+func newGetPortalCustomDomainCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     customDomainUse,
+		Short:   customDomainShort,
+		Long:    customDomainLong,
+		Example: customDomainExample,
+		Aliases: []string{"custom-domains", "domain"},
+		PreRunE: func(c *cobra.Command, args []string) error {
+			if parentPreRun != nil {
+				if err := parentPreRun(c, args); err != nil {
+					return err
+				}
+			}
+			return bindPortalChildFlags(c, args)
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			return runGetPortalCustomDomain(c, args)
+		},
+	}
+
+	addPortalChildFlags(cmd)
+
+	if addParentFlags != nil {
+		addParentFlags(verb, cmd)
+	}
+
+	return cmd
+}
+
+func runGetPortalCustomDomain(c *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, ", "))
+	}
+
+	helper := cmd.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	portalID, portalName := getPortalIdentifiers(cfg)
+	if portalID == "" && portalName == "" {
+		return &cmd.ConfigurationError{
+			Err: fmt.Errorf("either --%s or --%s is required", portalIDFlagName, portalNameFlagName),
+		}
+	}
+
+	if portalID == "" {
+		portalID, err = resolvePortalIDByName(portalName, sdk.GetPortalAPI(), helper, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	domainAPI := sdk.GetPortalCustomDomainAPI()
+	if domainAPI == nil {
+		return &cmd.ExecutionError{
+			Msg: "Portal custom domain client is not available",
+			Err: fmt.Errorf("portal custom domain client not configured"),
+		}
+	}
+
+	res, err := domainAPI.GetPortalCustomDomain(helper.GetContext(), portalID)
+	if err != nil {
+		var notFound *kkErrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return cmd.PrepareExecutionErrorMsg(helper, "the portal has no custom domain")
+		}
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return cmd.PrepareExecutionError("Failed to get portal custom domain", err, helper.GetCmd(), attrs...)
+	}
+	if res.PortalCustomDomain == nil {
+		return &cmd.ExecutionError{
+			Msg: "Failed to get portal custom domain",
+			Err: fmt.Errorf("empty response from Konnect"),
+		}
+	}
+
+	view := portalCustomDomainView{PortalID: portalID, Domain: *res.PortalCustomDomain}
+	if portalRes, err := sdk.GetPortalAPI().GetPortal(helper.GetContext(), portalID); err == nil &&
+		portalRes.GetPortalResponse() != nil {
+		view.CNAMETarget = portalRes.GetPortalResponse().GetDefaultDomain()
+	}
+
+	return tableview.RenderForFormat(helper,
+		false,
+		outType,
+		printer,
+		helper.GetStreams(),
+		portalCustomDomainToRecord(view),
+		view,
+		"",
+		tableview.WithRootLabel(helper.GetCmd().Name()),
+	)
+}
+
+func portalCustomDomainToRecord(view portalCustomDomainView) any {
+	domain := view.Domain
+	ssl := domain.GetSsl()
+
+	return struct {
+		Hostname           string `json:"hostname"`
+		Enabled            string `json:"enabled"`
+		CNAMEStatus        string `json:"cname_status"`
+		CNAMETarget        string `json:"cname_target"`
+		VerificationMethod string `json:"ssl.domain_verification_method"`
+		VerificationStatus string `json:"ssl.verification_status"`
+		ValidationErrors   string `json:"ssl.validation_errors"`
+		CertificateExpires string `json:"ssl.expires_at"`
+	}{
+		Hostname:           domain.GetHostname(),
+		Enabled:            fmt.Sprintf("%v", domain.GetEnabled()),
+		CNAMEStatus:        fmt.Sprintf("%v", valueOrNAString(string(domain.GetCnameStatus()))),
+		CNAMETarget:        fmt.Sprintf("%v", valueOrNAString(view.CNAMETarget)),
+		VerificationMethod: fmt.Sprintf("%v", valueOrNAString(string(ssl.DomainVerificationMethod))),
+		VerificationStatus: fmt.Sprintf("%v", valueOrNAString(string(ssl.VerificationStatus))),
+		ValidationErrors:   fmt.Sprintf("%v", sliceOrNA(ssl.ValidationErrors)),
+		CertificateExpires: formatOptionalTime(ssl.ExpiresAt),
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return valueNA
+	}
+	return t.In(time.Local).Format("2006-01-02 15:04:05")
+}
@@ -1,6 +1,7 @@
 package portal
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -27,8 +28,10 @@ var (
 	deletePortalLong = i18n.T("root.products.konnect.portal.deletePortalLong",
 		`Delete a portal by ID or name.
 
-If the portal has published APIs, the deletion will fail unless the --force flag is used.
-Using --force will delete the portal along with all API publications.
+If the portal has API publications or pages, the deletion is refused and the
+dependent resources are listed. Use --cascade to delete the API publications and
+pages first, children before their parents, and then the portal. Alternatively,
+--force asks Konnect to delete the portal along with all API publications.
 
 Use --approve to skip the confirmation prompt.`)
 	deletePortalExample = normalizers.Examples(
@@ -40,6 +43,9 @@ Use --approve to skip the confirmation prompt.`)
 	# Delete a portal by name
 	%[1]s delete portal my-portal
 
+	# Delete a portal after deleting its API publications and pages
+	%[1]s delete portal my-portal --cascade
+
 	# Force delete a portal with published APIs
 	%[1]s delete portal my-portal --force
 
@@ -122,28 +128,43 @@ func (c *deletePortalCmd) runE(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	forceDelete := cmd.DeleteForceEnabled(helper)
+	cascade := common.CascadeEnabled(cobraCmd)
+	description := fmt.Sprintf("portal %q", portalName)
+
+	// With --force Konnect removes the publications itself
+	var dependents []common.Dependent
+	if cascade || !forceDelete {
+		dependents, e = portalDependents(helper, sdk, portalID, cfg)
+		if e != nil {
+			return e
+		}
+	}
+	if len(dependents) > 0 && !cascade {
+		return cmd.PrepareExecutionErrorMsg(helper, common.DependencyError(description, dependents).Error(),
+			"suggestion", "Use --cascade to delete the dependent resources along with the portal")
+	}
+
 	labelWidth := len("Name")
 	warnings := []string{
 		formatPortalDetail("Name", portalName, labelWidth),
 		formatPortalDetail("ID", portalID, labelWidth),
 	}
-	if !forceDelete {
-		warnings = append(warnings,
-			"Note: If this portal has published APIs, use --force to delete them along with the portal.")
-	}
+	warnings = append(warnings, common.DependencyReport(description, dependents)...)
 
-	if err := cmd.ConfirmDelete(
-		helper,
-		fmt.Sprintf("portal %q", portalName),
-		warnings...,
-	); err != nil {
+	if err := cmd.ConfirmDelete(helper, description, warnings...); err != nil {
 		return err
 	}
 
+	deleted, err := common.DeleteDependents(helper.GetContext(), dependents)
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		return cmd.PrepareExecutionError("Failed to delete portal dependents", err, helper.GetCmd(), attrs...)
+	}
+
 	// Delete the portal
 	logger.Info(fmt.Sprintf("Deleting portal '%s' (ID: %s)", portalName, portalID))
 
-	_, err := sdk.GetPortalAPI().DeletePortal(helper.GetContext(), portalID, forceDelete)
+	_, err = sdk.GetPortalAPI().DeletePortal(helper.GetContext(), portalID, forceDelete)
 	if err != nil {
 		attrs := cmd.TryConvertErrorToAttrs(err)
 		apiErrDetails := common.ParseAPIErrorDetails(err)
@@ -176,9 +197,15 @@ func (c *deletePortalCmd) runE(cobraCmd *cobra.Command, args []string) error {
 		"status":  "deleted",
 		"message": fmt.Sprintf("Portal '%s' deleted successfully", portalName),
 	}
+	if cascade {
+		response["deleted_dependents"] = deleted
+	}
 
 	if outType == cmdCommon.TEXT {
-		// For text output, just print the success message
+		// For text output, just print the success messages
+		for _, dependent := range deleted {
+			fmt.Fprintf(helper.GetStreams().Out, "Deleted %s\n", dependent)
+		}
 		fmt.Fprintf(helper.GetStreams().Out, "Portal '%s' deleted successfully\n", portalName)
 	} else {
 		// For JSON/YAML output, print the structured response
@@ -245,6 +272,101 @@ func (c *deletePortalCmd) resolvePortalByName(
 	return nil, cmd.PrepareExecutionErrorMsg(helper, fmt.Sprintf("portal not found: %s", name))
 }
 
+// portalDependents returns the API publications and pages of the portal in the order
+// they have to be deleted, child pages before their parents
+func portalDependents(
+	helper cmd.Helper,
+	sdk helpers.SDKAPI,
+	portalID string,
+	cfg config.Hook,
+) ([]common.Dependent, error) {
+	var dependents []common.Dependent
+
+	publications, err := fetchPortalPublications(helper, sdk.GetAPIPublicationAPI(), portalID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, publication := range publications {
+		apiID := publication.GetAPIID()
+		dependents = append(dependents, common.NewDependent("API publication", apiID, "",
+			func(ctx context.Context) error {
+				_, err := sdk.GetAPIPublicationAPI().DeletePublication(ctx, apiID, portalID)
+				return err
+			}))
+	}
+
+	pages, err := fetchPortalPageSummaries(helper, sdk.GetPortalPageAPI(), portalID)
+	if err != nil {
+		return nil, err
+	}
+	var walk func(page kkComps.PortalPageInfo)
+	walk = func(page kkComps.PortalPageInfo) {
+		for _, child := range page.Children {
+			walk(child)
+		}
+		pageID := page.GetID()
+		dependents = append(dependents, common.NewDependent("page", pageID, page.GetSlug(),
+			func(ctx context.Context) error {
+				_, err := sdk.GetPortalPageAPI().DeletePortalPage(ctx, portalID, pageID)
+				return err
+			}))
+	}
+	for _, page := range pages {
+		walk(page)
+	}
+
+	return dependents, nil
+}
+
+func fetchPortalPublications(
+	helper cmd.Helper,
+	publicationAPI helpers.APIPublicationAPI,
+	portalID string,
+	cfg config.Hook,
+) ([]kkComps.APIPublicationListItem, error) {
+	var pageNumber int64 = 1
+	pageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
+	if pageSize < 1 {
+		pageSize = int64(common.DefaultRequestPageSize)
+	}
+
+	var all []kkComps.APIPublicationListItem
+
+	filter := &kkComps.APIPublicationFilterParameters{
+		PortalID: &kkComps.UUIDFieldFilter{Eq: kk.String(portalID)},
+	}
+
+	for {
+		req := kkOps.ListAPIPublicationsRequest{
+			PageSize:   kk.Int64(pageSize),
+			PageNumber: kk.Int64(pageNumber),
+			Filter:     filter,
+		}
+
+		res, err := publicationAPI.ListAPIPublications(helper.GetContext(), req)
+		if err != nil {
+			attrs := cmd.TryConvertErrorToAttrs(err)
+			return nil, cmd.PrepareExecutionError("Failed to list API publications", err, helper.GetCmd(), attrs...)
+		}
+
+		if res.GetListAPIPublicationResponse() == nil {
+			break
+		}
+
+		data := res.GetListAPIPublicationResponse().GetData()
+		all = append(all, data...)
+
+		total := int(res.GetListAPIPublicationResponse().GetMeta().Page.Total)
+		if total == 0 || len(all) >= total || len(data) == 0 {
+			break
+		}
+
+		pageNumber++
+	}
+
+	return all, nil
+}
+
 func shouldSuggestForce(details *common.APIErrorDetails, err error) bool {
 	if details != nil {
 		for _, param := range details.InvalidParameters {
@@ -278,6 +400,7 @@ func newDeletePortalCmd(
 	rv.Long = deletePortalLong
 	rv.Example = deletePortalExample
 	rv.Args = cobra.ExactArgs(1)
+	rv.ValidArgsFunction = common.CompleteResourceNames("portal", ListNames)
 
 	if parentPreRun != nil {
 		rv.PreRunE = parentPreRun
@@ -287,6 +410,7 @@ func newDeletePortalCmd(
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddCascadeFlag(rv.Command, "portal")
 
 	if applicationsCmd := newDeletePortalApplicationsCmd(verb, addParentFlags, parentPreRun); applicationsCmd != nil {
 		rv.AddCommand(applicationsCmd)
@@ -0,0 +1,163 @@
+package portal
+
+import (
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	cmdCommon "github.com/kong/kongctl/internal/cmd/common"
+	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/segmentio/cli"
+	"github.com/spf13/cobra"
+)
+
+const (
+	developerCommandName = "developer"
+)
+
+var (
+	approveDeveloperShort = i18n.T("root.products.konnect.portal.approveDeveloperShort",
+		"Approve a developer of a Konnect portal")
+	approveDeveloperLong = normalizers.LongDesc(i18n.T("root.products.konnect.portal.approveDeveloperLong",
+		`Use the approve verb to grant a developer that signed up to a Konnect portal access to it.
+The developer can be given by ID or by email.`))
+	approveDeveloperExample = normalizers.Examples(
+		i18n.T("root.products.konnect.portal.approveDeveloperExamples",
+			fmt.Sprintf(`
+# Approve a developer by ID
+%[1]s approve developer --portal-id <portal-id> <developer-id>
+# Approve a developer by email in a portal given by name
+%[1]s approve developer --portal-name my-portal dev@example.com
+`, meta.CLIName)))
+)
+
+func newApprovePortalDeveloperCmd(
+	verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s <developer-id|email>", developerCommandName),
+		Short:   approveDeveloperShort,
+		Long:    approveDeveloperLong,
+		Example: approveDeveloperExample,
+		Aliases: []string{"developers", "dev", "devs"},
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if parentPreRun != nil {
+				if err := parentPreRun(cmd, args); err != nil {
+					return err
+				}
+			}
+			return bindPortalChildFlags(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handler := portalDeveloperApproveHandler{cmd: cmd}
+			return handler.run(args)
+		},
+	}
+
+	addPortalChildFlags(cmd)
+
+	if addParentFlags != nil {
+		addParentFlags(verb, cmd)
+	}
+
+	return cmd
+}
+
+type portalDeveloperApproveHandler struct {
+	cmd *cobra.Command
+}
+
+func (h portalDeveloperApproveHandler) run(args []string) error {
+	helper := cmd.BuildHelper(h.cmd, args)
+
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	outType, err := helper.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+
+	printer, err := cli.Format(outType.String(), helper.GetStreams().Out)
+	if err != nil {
+		return err
+	}
+	defer printer.Flush()
+
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	portalID, err := requirePortalID(helper, sdk, cfg)
+	if err != nil {
+		return err
+	}
+
+	devAPI := sdk.GetPortalDeveloperAPI()
+	if devAPI == nil {
+		return &cmd.ExecutionError{
+			Msg: "Portal developers client is not available",
+			Err: fmt.Errorf("portal developers client not configured"),
+		}
+	}
+
+	identifier := strings.TrimSpace(args[0])
+	if identifier == "" {
+		return &cmd.ConfigurationError{Err: fmt.Errorf("developer identifier is required")}
+	}
+
+	developerID := identifier
+	if !util.IsValidUUID(identifier) {
+		developers, err := fetchPortalDevelopers(helper, devAPI, portalID, cfg)
+		if err != nil {
+			return err
+		}
+		match := findDeveloperByEmailOrID(developers, identifier)
+		if match == nil {
+			return &cmd.ConfigurationError{Err: fmt.Errorf("developer %q not found", identifier)}
+		}
+		developerID = match.GetID()
+	}
+
+	status := kkComps.DeveloperStatusApproved
+	res, err := devAPI.UpdateDeveloper(helper.GetContext(), kkOps.UpdateDeveloperRequest{
+		PortalID:               portalID,
+		DeveloperID:            developerID,
+		UpdateDeveloperRequest: kkComps.UpdateDeveloperRequest{Status: &status},
+	})
+	if err != nil {
+		attrs := cmd.TryConvertErrorToAttrs(err)
+		details := konnectCommon.ParseAPIErrorDetails(err)
+		attrs = konnectCommon.AppendAPIErrorAttrs(attrs, details)
+		msg := konnectCommon.BuildDetailedMessage("Failed to approve portal developer", attrs, err)
+		return cmd.PrepareExecutionError(msg, err, helper.GetCmd(), attrs...)
+	}
+
+	if outType == cmdCommon.TEXT {
+		fmt.Fprintf(helper.GetStreams().Out, "Portal developer %q approved\n", identifier)
+		return nil
+	}
+
+	if developer := res.GetPortalDeveloper(); developer != nil {
+		printer.Print(developer)
+	}
+	return nil
+}
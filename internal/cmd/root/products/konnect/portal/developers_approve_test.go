@@ -0,0 +1,41 @@
+package portal
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeveloperCmd(t *testing.T) {
+	getCmd, err := NewDeveloperCmd(verbs.Get, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, developersCommandName, getCmd.Name())
+
+	approveCmd, err := NewDeveloperCmd(verbs.Approve, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, developerCommandName, approveCmd.Name())
+	require.Error(t, approveCmd.Args(approveCmd, nil))
+	require.NotNil(t, approveCmd.Flags().Lookup(portalIDFlagName))
+
+	_, err = NewDeveloperCmd(verbs.Revoke, nil, nil)
+	require.Error(t, err)
+}
+
+func TestNewPortalCmdPortalAccessVerbs(t *testing.T) {
+	approveCmd, err := NewPortalCmd(verbs.Approve, nil, nil)
+	require.NoError(t, err)
+	child, _, err := approveCmd.Find([]string{"developer"})
+	require.NoError(t, err)
+	require.Equal(t, developerCommandName, child.Name())
+
+	revokeCmd, err := NewPortalCmd(verbs.Revoke, nil, nil)
+	require.NoError(t, err)
+	child, _, err = revokeCmd.Find([]string{"registration"})
+	require.NoError(t, err)
+	require.Equal(t, registrationCommandName, child.Name())
+	require.NotNil(t, child.Flags().Lookup(applicationIDFlagName))
+
+	_, err = NewApplicationRegistrationCmd(verbs.Delete, nil, nil)
+	require.Error(t, err)
+}
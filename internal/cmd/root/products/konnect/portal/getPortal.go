@@ -41,6 +41,14 @@ var (
 	%[1]s get portal my-portal 
 	# Get all the portals using command aliases
 	%[1]s get ps
+	# List portals along with portals recently deleted by kongctl
+	%[1]s get portals --include-deleted
+	# Count the portals in the organization
+	%[1]s get portals --count
+	# List the portals kongctl manages in the team-a namespace
+	%[1]s get portals --namespace team-a
+	# List the portals an API is published to
+	%[1]s get portals --api my-api
 	`, meta.CLIName)))
 )
 
@@ -240,80 +248,76 @@ type getPortalCmd struct {
 func runListByName(name string, kkClient helpers.PortalAPI, helper cmd.Helper,
 	cfg config.Hook,
 ) (*kkComps.ListPortalsResponsePortal, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-	if requestPageSize < 1 {
-		requestPageSize = int64(common.DefaultRequestPageSize)
+	allData, err := runList(kkClient, helper, cfg, 0, "")
+	if err != nil {
+		return nil, err
 	}
 
-	var allData []kkComps.ListPortalsResponsePortal
-
-	for {
-		req := kkOps.ListPortalsRequest{
-			PageSize:   kk.Int64(requestPageSize),
-			PageNumber: kk.Int64(pageNumber),
-		}
-
-		res, err := kkClient.ListPortals(helper.GetContext(), req)
-		if err != nil {
-			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
-		}
-
-		// Filter by name since SDK doesn't support name filtering for portals
-		for _, portal := range res.GetListPortalsResponse().Data {
-			if portal.Name == name {
-				return &portal, nil
-			}
-		}
-
-		allData = append(allData, res.GetListPortalsResponse().Data...)
-		totalItems := res.GetListPortalsResponse().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
+	// Filter by name since SDK doesn't support name filtering for portals
+	var matches []kkComps.ListPortalsResponsePortal
+	for _, portal := range allData {
+		if portal.Name == name {
+			matches = append(matches, portal)
 		}
-
-		pageNumber++
 	}
-
-	return nil, fmt.Errorf("portal with name %s not found", name)
+	return common.SelectByName(helper, "portal", name, matches, func(portal kkComps.ListPortalsResponsePortal) string {
+		return portal.ID
+	})
 }
 
+// runList lists the portals, only those named nameFilter when it is not empty
 func runList(kkClient helpers.PortalAPI, helper cmd.Helper,
-	cfg config.Hook,
+	cfg config.Hook, limit int, nameFilter string,
 ) ([]kkComps.ListPortalsResponsePortal, error) {
-	var pageNumber int64 = 1
-	requestPageSize := int64(cfg.GetInt(common.RequestPageSizeConfigPath))
-	if requestPageSize < 1 {
-		requestPageSize = int64(common.DefaultRequestPageSize)
-	}
-
-	var allData []kkComps.ListPortalsResponsePortal
+	return common.ListPages(cfg, limit, listPages(kkClient, helper, nameFilter))
+}
 
-	for {
+// listPages fetches the pages of the portals, only those named nameFilter when it is not empty
+func listPages(kkClient helpers.PortalAPI, helper cmd.Helper, nameFilter string,
+) common.PageFetcher[kkComps.ListPortalsResponsePortal] {
+	return func(pageSize, pageNumber int64) ([]kkComps.ListPortalsResponsePortal, float64, error) {
 		req := kkOps.ListPortalsRequest{
-			PageSize:   kk.Int64(requestPageSize),
+			PageSize:   kk.Int64(pageSize),
 			PageNumber: kk.Int64(pageNumber),
 		}
+		if nameFilter != "" {
+			req.Filter = &kkComps.PortalFilterParameters{
+				Name: &kkComps.StringFieldFilter{Eq: kk.String(nameFilter)},
+			}
+		}
 
 		res, err := kkClient.ListPortals(helper.GetContext(), req)
 		if err != nil {
 			attrs := cmd.TryConvertErrorToAttrs(err)
-			return nil, cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
+			return nil, 0, cmd.PrepareExecutionError("Failed to list Portals", err, helper.GetCmd(), attrs...)
 		}
-
-		allData = append(allData, res.GetListPortalsResponse().Data...)
-		totalItems := res.GetListPortalsResponse().Meta.Page.Total
-
-		if len(allData) >= int(totalItems) {
-			break
-		}
-
-		pageNumber++
+		return res.GetListPortalsResponse().Data, res.GetListPortalsResponse().Meta.Page.Total, nil
 	}
+}
 
-	return allData, nil
+// ListNames lists the names of the portals for shell completion
+func ListNames(helper cmd.Helper) ([]string, error) {
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return nil, err
+	}
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	all, err := runList(sdk.GetPortalAPI(), helper, cfg, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(all))
+	for _, item := range all {
+		names = append(names, item.Name)
+	}
+	return names, nil
 }
 
 func runGet(id string, kkClient helpers.PortalAPI, helper cmd.Helper,
@@ -354,6 +358,46 @@ func (c *getPortalCmd) runE(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	includeDeleted, err := common.IncludeDeleted(helper)
+	if err != nil {
+		return err
+	}
+
+	count, countBy, err := common.CountOptions(helper)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := common.NamespaceFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	selector, err := common.LabelSelectorFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	fieldSelector, err := common.FieldSelectorFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	apiRef, err := apiFilter(helper)
+	if err != nil {
+		return err
+	}
+
+	limit, err := common.ListLimit(helper)
+	if err != nil {
+		return err
+	}
+
+	stream, offset, err := common.StreamOptions(helper, "portals")
+	if err != nil {
+		return err
+	}
+
 	logger, err := helper.GetLogger()
 	if err != nil {
 		return err
@@ -391,36 +435,12 @@ func (c *getPortalCmd) runE(cobraCmd *cobra.Command, args []string) error {
 
 		if !isUUID {
 			// If the ID is not a UUID, then it is a name
-			// search for the portal by name
+			// search for the portal by name, then get its full details by ID
 			portal, err := runListByName(id, sdk.GetPortalAPI(), helper, cfg)
 			if err != nil {
 				return err
 			}
-
-			detailFn := func(index int) string {
-				if index != 0 {
-					return ""
-				}
-				return portalListDetailView(portal)
-			}
-			return tableview.RenderForFormat(helper,
-				false,
-				outType,
-				printer,
-				helper.GetStreams(),
-				portalToDisplayRecord(portal),
-				portal,
-				"",
-				tableview.WithRootLabel(helper.GetCmd().Name()),
-				tableview.WithDetailRenderer(detailFn),
-				tableview.WithDetailHelper(helper),
-				tableview.WithDetailContext("portal", func(index int) any {
-					if index != 0 {
-						return nil
-					}
-					return portal
-				}),
-			)
+			id = portal.ID
 		}
 		portalResponse, err := runGet(id, sdk.GetPortalAPI(), helper)
 		if err != nil {
@@ -452,12 +472,81 @@ func (c *getPortalCmd) runE(cobraCmd *cobra.Command, args []string) error {
 		)
 	}
 
-	portals, err := runList(sdk.GetPortalAPI(), helper, cfg)
+	var publishedTo map[string]bool
+	if apiRef != "" {
+		if publishedTo, err = publishedPortalIDs(apiRef, sdk.GetAPIAPI(), helper); err != nil {
+			return err
+		}
+	}
+	// Konnect filters by name, the other fields are matched as each page is listed
+	var filter common.PageFilter[kkComps.ListPortalsResponsePortal]
+	if namespace != "" || selector != nil || fieldSelector != nil || publishedTo != nil {
+		filter = func(portals []kkComps.ListPortalsResponsePortal) ([]kkComps.ListPortalsResponsePortal, error) {
+			if publishedTo != nil {
+				portals = filterPortalsByID(portals, publishedTo)
+			}
+			portals, err := common.FilterByNamespace(portals, namespace)
+			if err != nil {
+				return nil, err
+			}
+			if portals, err = common.FilterByLabelSelector(portals, selector); err != nil {
+				return nil, err
+			}
+			return common.FilterByFieldSelector(portals, fieldSelector)
+		}
+	}
+	nameFilter, _ := fieldSelector.NameEquals()
+	fetch := listPages(sdk.GetPortalAPI(), helper, nameFilter)
+	rng := common.PageRange{Limit: limit, Offset: offset}
+
+	if stream {
+		return common.StreamList(helper, cfg, outType, "portals", rng, fetch, filter,
+			func(portals []kkComps.ListPortalsResponsePortal) any { return portalDisplayRecords(portals) })
+	}
+
+	var portals []kkComps.ListPortalsResponsePortal
+	listed, err := common.StreamPages(cfg, rng, fetch, filter, func(page []kkComps.ListPortalsResponsePortal) error {
+		portals = append(portals, page...)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
+	if offset == 0 {
+		common.WarnIfEmptyInDefaultRegion(helper, cfg, listed.Listed)
+	}
 
-	return renderPortalList(helper, helper.GetCmd().Name(), outType, printer, portals)
+	if count {
+		summary, err := common.CountResources(portals, countBy)
+		if err != nil {
+			return err
+		}
+		return common.RenderCounts(helper, outType, printer, summary)
+	}
+
+	if includeDeleted {
+		ids := make([]string, 0, len(portals))
+		displayRecords := make([]textDisplayRecord, 0, len(portals))
+		for i := range portals {
+			ids = append(ids, portals[i].GetID())
+			displayRecords = append(displayRecords, portalToDisplayRecord(&portals[i]))
+		}
+		deleted, err := common.ListDeletedResources(helper, "portal", ids)
+		if err != nil {
+			return err
+		}
+		display, err := common.WithNamespaceColumn(helper, displayRecords, portals)
+		if err != nil {
+			return err
+		}
+		return common.RenderListWithDeleted(helper, outType, printer, display, portals, deleted)
+	}
+
+	if err = renderPortalList(helper, helper.GetCmd().Name(), outType, printer, portals); err != nil {
+		return err
+	}
+	common.PrintContinueHint(helper, "portals", listed.Next)
+	return nil
 }
 
 func renderPortalList(
@@ -467,9 +556,9 @@ func renderPortalList(
 	printer cli.PrintFlusher,
 	portals []kkComps.ListPortalsResponsePortal,
 ) error {
-	displayRecords := make([]textDisplayRecord, 0, len(portals))
-	for i := range portals {
-		displayRecords = append(displayRecords, portalToDisplayRecord(&portals[i]))
+	display, err := common.WithNamespaceColumn(helper, portalDisplayRecords(portals), portals)
+	if err != nil {
+		return err
 	}
 
 	childView := buildPortalChildView(portals)
@@ -491,13 +580,21 @@ func renderPortalList(
 		outType,
 		printer,
 		helper.GetStreams(),
-		displayRecords,
+		display,
 		portals,
 		"",
 		options...,
 	)
 }
 
+func portalDisplayRecords(portals []kkComps.ListPortalsResponsePortal) []textDisplayRecord {
+	displayRecords := make([]textDisplayRecord, 0, len(portals))
+	for i := range portals {
+		displayRecords = append(displayRecords, portalToDisplayRecord(&portals[i]))
+	}
+	return displayRecords
+}
+
 func buildPortalChildView(portals []kkComps.ListPortalsResponsePortal) tableview.ChildView {
 	tableRows := make([]table.Row, 0, len(portals))
 	for i := range portals {
@@ -543,10 +640,19 @@ func newGetPortalCmd(verb verbs.VerbValue,
 		rv.PreRunE = parentPreRun
 	}
 	rv.RunE = rv.runE
+	rv.ValidArgsFunction = common.CompleteResourceNames("portal", ListNames)
 
 	if addParentFlags != nil {
 		addParentFlags(verb, rv.Command)
 	}
+	common.AddIncludeDeletedFlag(rv.Command)
+	common.AddCountFlags(rv.Command)
+	common.AddNamespaceFilterFlag(rv.Command)
+	common.AddLabelSelectorFlag(rv.Command)
+	common.AddFieldSelectorFlag(rv.Command)
+	common.AddLimitFlag(rv.Command)
+	common.AddStreamFlags(rv.Command)
+	addAPIFilterFlag(rv.Command)
 
 	if pagesCmd := newGetPortalPagesCmd(verb, addParentFlags, parentPreRun); pagesCmd != nil {
 		rv.AddCommand(pagesCmd)
@@ -592,5 +698,9 @@ func newGetPortalCmd(verb verbs.VerbValue,
 		rv.AddCommand(emailDomainsCmd)
 	}
 
+	if customDomainCmd := newGetPortalCustomDomainCmd(verb, addParentFlags, parentPreRun); customDomainCmd != nil {
+		rv.AddCommand(customDomainCmd)
+	}
+
 	return &rv
 }
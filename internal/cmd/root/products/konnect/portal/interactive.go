@@ -24,7 +24,7 @@ func BuildListView(helper cmd.Helper) (tableview.ChildView, error) {
 		return tableview.ChildView{}, err
 	}
 
-	portals, err := runList(sdk.GetPortalAPI(), helper, cfg)
+	portals, err := runList(sdk.GetPortalAPI(), helper, cfg, 0, "")
 	if err != nil {
 		return tableview.ChildView{}, err
 	}
@@ -33,6 +33,12 @@ var (
 %[1]s get portal applications --portal-id <portal-id>
 # List portals using explicit konnect product
 %[1]s get konnect portals
+# Approve a portal developer
+%[1]s approve portal developer --portal-id <portal-id> dev@example.com
+# Revoke a portal application registration
+%[1]s revoke portal application-registration --portal-id <portal-id> <registration-id>
+# Synchronize the portal pages with a directory of markdown files
+%[1]s sync portal content --portal-id <portal-id> --dir ./site
 `, meta.CLIName)))
 )
 
@@ -55,7 +61,54 @@ func NewPortalCmd(verb verbs.VerbValue,
 	if verb == verbs.Delete {
 		return newDeletePortalCmd(verb, &baseCmd, addParentFlags, parentPreRun).Command, nil
 	}
+	if verb == verbs.Approve {
+		baseCmd.AddCommand(newApprovePortalDeveloperCmd(verb, addParentFlags, parentPreRun))
+	}
+	if verb == verbs.Revoke {
+		baseCmd.AddCommand(newRevokePortalApplicationRegistrationCmd(verb, addParentFlags, parentPreRun))
+	}
+	if verb == verbs.Sync {
+		baseCmd.AddCommand(newSyncPortalContentCmd(verb, addParentFlags, parentPreRun))
+	}
 
 	// Return base command for unsupported verbs
 	return &baseCmd, nil
 }
+
+// NewDeveloperCmd creates the portal developers command for verbs that work on
+// developers directly, such as get developers and approve developer
+func NewDeveloperCmd(verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) (*cobra.Command, error) {
+	if verb == verbs.Get || verb == verbs.List {
+		return newGetPortalDevelopersCmd(verb, addParentFlags, parentPreRun), nil
+	}
+	if verb == verbs.Approve {
+		return newApprovePortalDeveloperCmd(verb, addParentFlags, parentPreRun), nil
+	}
+	return nil, fmt.Errorf("portal developers do not support the %s verb", verb)
+}
+
+// NewApplicationCmd creates the portal applications command for get applications
+func NewApplicationCmd(verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) (*cobra.Command, error) {
+	if verb != verbs.Get && verb != verbs.List {
+		return nil, fmt.Errorf("portal applications do not support the %s verb", verb)
+	}
+	return newGetPortalApplicationsCmd(verb, addParentFlags, parentPreRun), nil
+}
+
+// NewApplicationRegistrationCmd creates the portal application registration command
+// for revoke application-registration
+func NewApplicationRegistrationCmd(verb verbs.VerbValue,
+	addParentFlags func(verbs.VerbValue, *cobra.Command),
+	parentPreRun func(*cobra.Command, []string) error,
+) (*cobra.Command, error) {
+	if verb != verbs.Revoke {
+		return nil, fmt.Errorf("portal application registrations do not support the %s verb", verb)
+	}
+	return newRevokePortalApplicationRegistrationCmd(verb, addParentFlags, parentPreRun), nil
+}
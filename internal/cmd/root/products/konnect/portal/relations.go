@@ -0,0 +1,105 @@
+package portal
+
+import (
+	"fmt"
+	"strings"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	apiFilterFlagName = "api"
+)
+
+func addAPIFilterFlag(command *cobra.Command) {
+	command.Flags().String(apiFilterFlagName, "",
+		"Only list the portals this API is published to, by name or ID (list only)")
+}
+
+// apiFilter returns the API passed with --api, or "" when listing is not filtered by API
+func apiFilter(helper cmd.Helper) (string, error) {
+	flag := helper.GetCmd().Flags().Lookup(apiFilterFlagName)
+	if flag == nil {
+		return "", nil
+	}
+	api := strings.TrimSpace(flag.Value.String())
+	if api != "" && len(helper.GetArgs()) > 0 {
+		return "", &cmd.ConfigurationError{
+			Err: fmt.Errorf("--%s is only supported when listing portals", apiFilterFlagName),
+		}
+	}
+	return api, nil
+}
+
+// publishedPortalIDs returns the IDs of the portals the API named or identified by ref
+// is published to
+func publishedPortalIDs(ref string, apiAPI helpers.APIAPI, helper cmd.Helper) (map[string]bool, error) {
+	if apiAPI == nil {
+		return nil, &cmd.ExecutionError{
+			Msg: "APIs client is not available",
+			Err: fmt.Errorf("apis client not configured"),
+		}
+	}
+
+	var api *kkComps.APIResponseSchema
+	if util.IsValidUUID(ref) {
+		res, err := apiAPI.FetchAPI(helper.GetContext(), ref)
+		if err != nil {
+			attrs := cmd.TryConvertErrorToAttrs(err)
+			return nil, cmd.PrepareExecutionError("Failed to get API", err, helper.GetCmd(), attrs...)
+		}
+		api = res.GetAPIResponseSchema()
+	} else {
+		res, err := apiAPI.ListApis(helper.GetContext(), kkOps.ListApisRequest{
+			PageSize: kk.Int64(common.DefaultRequestPageSize),
+			Filter: &kkComps.APIFilterParameters{
+				Name: &kkComps.StringFieldFilter{Eq: kk.String(ref)},
+			},
+		})
+		if err != nil {
+			attrs := cmd.TryConvertErrorToAttrs(err)
+			return nil, cmd.PrepareExecutionError("Failed to list APIs", err, helper.GetCmd(), attrs...)
+		}
+		var matches []kkComps.APIResponseSchema
+		if res.ListAPIResponse != nil {
+			for _, candidate := range res.ListAPIResponse.Data {
+				if candidate.Name == ref {
+					matches = append(matches, candidate)
+				}
+			}
+		}
+		if api, err = common.SelectByName(helper, "API", ref, matches, func(a kkComps.APIResponseSchema) string {
+			return a.ID
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make(map[string]bool)
+	if api != nil {
+		for _, portal := range api.GetPortals() {
+			ids[portal.ID] = true
+		}
+	}
+	return ids, nil
+}
+
+// filterPortalsByID returns the portals whose ID is in ids
+func filterPortalsByID(
+	portals []kkComps.ListPortalsResponsePortal, ids map[string]bool,
+) []kkComps.ListPortalsResponsePortal {
+	matches := make([]kkComps.ListPortalsResponsePortal, 0, len(ids))
+	for _, portal := range portals {
+		if ids[portal.ID] {
+			matches = append(matches, portal)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,23 @@
+package portal
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterPortalsByID(t *testing.T) {
+	portals := []kkComps.ListPortalsResponsePortal{
+		{ID: "portal-1", Name: "internal"},
+		{ID: "portal-2", Name: "partners"},
+		{ID: "portal-3", Name: "public"},
+	}
+
+	filtered := filterPortalsByID(portals, map[string]bool{"portal-1": true, "portal-3": true})
+	require.Len(t, filtered, 2)
+	require.Equal(t, "internal", filtered[0].Name)
+	require.Equal(t, "public", filtered[1].Name)
+
+	require.Empty(t, filterPortalsByID(portals, map[string]bool{}))
+}
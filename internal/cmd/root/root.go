@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -12,25 +13,45 @@ import (
 	"github.com/kong/kongctl/internal/build"
 	"github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/common"
+	configCmd "github.com/kong/kongctl/internal/cmd/root/config"
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	schemaCmd "github.com/kong/kongctl/internal/cmd/root/schema"
+	serveCmd "github.com/kong/kongctl/internal/cmd/root/serve"
+	updateCmd "github.com/kong/kongctl/internal/cmd/root/update"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/adopt"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/api"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/apiversion"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/apply"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/approve"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/convert"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/del"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/diff"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/doctor"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/drift"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/dump"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/export"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/generate"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/get"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/help"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/imp"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/initialize"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/kai"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/list"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/login"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/logout"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/migrateconfig"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/patch"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/plan"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/revoke"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/state"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/sync"
+	"github.com/kong/kongctl/internal/cmd/root/verbs/validate"
 	"github.com/kong/kongctl/internal/cmd/root/verbs/view"
 	"github.com/kong/kongctl/internal/cmd/root/version"
 	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/iostreams"
+	"github.com/kong/kongctl/internal/konnect/auth"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 	"github.com/kong/kongctl/internal/log"
 	"github.com/kong/kongctl/internal/meta"
 	"github.com/kong/kongctl/internal/profile"
@@ -59,17 +80,14 @@ var (
 	configFilePath = ""
 	// Stores the global runtime value for the configured profile
 	currProfile = profile.DefaultProfile
+	// Whether currProfile was set by the KONGCTL_PROFILE environment variable
+	profileFromEnv = false
 
 	currConfig config.Hook
 	streams    *iostreams.IOStreams
 	pMgr       profile.Manager
 
-	outputFormat = cmd.NewEnum([]string{
-		common.JSON.String(),
-		common.YAML.String(),
-		common.TEXT.String(),
-	},
-		common.TEXT.String())
+	outputFormat = cmd.NewOutputFormatFlag(common.TEXT.String())
 
 	logLevel = cmd.NewEnum([]string{
 		common.TRACE.String(),
@@ -80,6 +98,8 @@ var (
 	},
 		common.ERROR.String())
 
+	logFormat = cmd.NewEnum([]string{common.LogFormatText, common.LogFormatJSON}, common.LogFormatText)
+
 	buildInfo *build.Info
 
 	logger      *slog.Logger
@@ -125,12 +145,44 @@ func mergedFlagUsages(cmd *cobra.Command) string {
 	return strings.TrimRight(flags.FlagUsages(), "\n")
 }
 
+// validateProfile fails commands run with a profile that is not defined, so a typo in
+// --profile can't send requests to the default Konnect organization. Logging in creates
+// the profile, so login and logout accept any profile, as does a profile with a saved token.
+// The config command accepts any profile too, so a missing profile can be set up or replaced.
+func validateProfile(cmd *cobra.Command) error {
+	verb := cmd
+	for verb.HasParent() && verb.Parent().HasParent() {
+		verb = verb.Parent()
+	}
+	switch verb.Name() {
+	case login.Verb.String(), logout.Verb.String(), configCmd.Use, "help", "completion",
+		cobra.ShellCompRequestCmd:
+		return nil
+	}
+	profiled, ok := currConfig.(*config.ProfiledConfig)
+	if !ok {
+		return nil
+	}
+	if err := profiled.ValidateProfile(); err != nil && !auth.HasAccessToken(profiled) {
+		return err
+	}
+	return nil
+}
+
 func newRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   meta.CLIName,
 		Short: rootShort,
 		Long:  rootLong,
-		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateProfile(cmd); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			if err := configureHTTPTransport(currConfig); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
 			ctx := context.WithValue(cmd.Context(), config.ConfigKey, currConfig)
 			ctx = context.WithValue(ctx, iostreams.StreamsKey, streams)
 			ctx = context.WithValue(ctx, profile.ProfileManagerKey, pMgr)
@@ -138,6 +190,7 @@ func newRootCmd() *cobra.Command {
 			ctx = context.WithValue(ctx, log.LoggerKey, logger)
 			ctx = theme.ContextWithPalette(ctx, theme.Current())
 			cmd.SetContext(ctx)
+			return nil
 		},
 	}
 
@@ -151,6 +204,7 @@ func newRootCmd() *cobra.Command {
 
 	// parses all flags not just the target command
 	rootCmd.TraverseChildren = true
+	rootCmd.SetGlobalNormalizationFunc(normalizeFlagAliases)
 
 	rootCmd.PersistentFlags().StringVar(&configFilePath, common.ConfigFilePathFlagName,
 		defaultConfigFilePath,
@@ -168,7 +222,7 @@ func newRootCmd() *cobra.Command {
 		fmt.Sprintf(`Configures the format of data written to STDOUT.
 - Config path: [ %s ]
 - Allowed    : [ %s ]`,
-			common.OutputConfigPath, strings.Join(outputFormat.Allowed, "|")))
+			common.OutputConfigPath, strings.Join(common.OutputFormats, "|")))
 
 	rootCmd.PersistentFlags().Var(logLevel, common.LogLevelFlagName,
 		fmt.Sprintf(`Configures the logging level. Execution logs are written to STDERR.
@@ -177,10 +231,55 @@ func newRootCmd() *cobra.Command {
 			common.LogLevelConfigPath, strings.Join(logLevel.Allowed, "|")))
 
 	rootCmd.PersistentFlags().StringVar(&logFilePath, common.LogFileFlagName, "",
-		fmt.Sprintf(`Write execution logs to the specified file instead of STDERR.
+		fmt.Sprintf(`Write execution logs to the specified file, or to STDERR with "-".
 - Config path: [ %s ]`,
 			common.LogFileConfigPath))
 
+	rootCmd.PersistentFlags().Var(logFormat, common.LogFormatFlagName,
+		fmt.Sprintf(`Configures the format of execution logs, json writing one object per line.
+- Config path: [ %s ]
+- Allowed    : [ %s ]`,
+			common.LogFormatConfigPath, strings.Join(logFormat.Allowed, "|")))
+
+	rootCmd.PersistentFlags().Duration(common.TimeoutFlagName, httpclient.DefaultTimeout,
+		fmt.Sprintf(`Time limit for each Konnect request (e.g. 2m for large spec uploads).
+Declarative changes with an --operation-timeout are limited by that timeout instead.
+- Config path: [ %s ]`,
+			common.TimeoutConfigPath))
+
+	rootCmd.PersistentFlags().Duration(common.CacheTTLFlagName, 0,
+		fmt.Sprintf(`Reuse the responses of Konnect reads for this long (e.g. 10m), in memory and on disk.
+Writes clear the cache. Disabled when 0.
+- Config path: [ %s ]`,
+			common.CacheTTLConfigPath))
+
+	rootCmd.PersistentFlags().Bool(common.RefreshFlagName, false,
+		fmt.Sprintf(`Read from Konnect instead of the cache enabled by --%s, and cache what is read.
+- Config path: [ %s ]`,
+			common.CacheTTLFlagName, common.RefreshConfigPath))
+
+	rootCmd.PersistentFlags().String(common.ProxyFlagName, "",
+		fmt.Sprintf(`URL of the proxy for Konnect requests (e.g. http://proxy.example.com:3128).
+Hosts listed in NO_PROXY bypass it. Without one, HTTPS_PROXY and HTTP_PROXY are used.
+- Config path: [ %s ]`,
+			common.ProxyConfigPath))
+
+	rootCmd.PersistentFlags().String(common.CACertFlagName, "",
+		fmt.Sprintf(`Path to a PEM bundle of CA certificates trusted in addition to the system ones,
+such as the CA of a proxy intercepting TLS.
+- Config path: [ %s ]`,
+			common.CACertConfigPath))
+
+	rootCmd.PersistentFlags().String(common.ClientCertFlagName, "",
+		fmt.Sprintf(`Path to a PEM client certificate presented for mutual TLS. Requires --%s.
+- Config path: [ %s ]`,
+			common.ClientKeyFlagName, common.ClientCertConfigPath))
+
+	rootCmd.PersistentFlags().String(common.ClientKeyFlagName, "",
+		fmt.Sprintf(`Path to the PEM private key of the --%s client certificate.
+- Config path: [ %s ]`,
+			common.ClientCertFlagName, common.ClientKeyConfigPath))
+
 	themeFlag := theme.NewFlag(common.DefaultColorTheme)
 	rootCmd.PersistentFlags().Var(themeFlag, common.ColorThemeFlagName,
 		fmt.Sprintf(`Configures the CLI UI/theme (prompt, tables, TUI elements).
@@ -194,9 +293,26 @@ func newRootCmd() *cobra.Command {
 	return rootCmd
 }
 
+// flagAliases maps alternative flag names accepted by every command to the flag they stand for
+var flagAliases = map[string]string{
+	"konnect-region": konnectcommon.RegionFlagName,
+}
+
+// normalizeFlagAliases resolves the flagAliases of flag names
+func normalizeFlagAliases(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if target, ok := flagAliases[name]; ok {
+		name = target
+	}
+	return pflag.NormalizedName(name)
+}
+
 // addCommands adds the root subcommands to the command.
 func addCommands() error {
 	rootCmd.AddCommand(version.NewVersionCmd())
+	rootCmd.AddCommand(updateCmd.NewUpdateCmd())
+	rootCmd.AddCommand(configCmd.NewConfigCmd())
+	rootCmd.AddCommand(schemaCmd.NewSchemaCmd())
+	rootCmd.AddCommand(serveCmd.NewServeCmd())
 
 	command, err := api.NewAPICmd()
 	if err != nil {
@@ -234,6 +350,24 @@ func addCommands() error {
 	}
 	rootCmd.AddCommand(command)
 
+	command, err = approve.NewApproveCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = revoke.NewRevokeCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = apiversion.NewAPIVersionCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
 	command, err = login.NewLoginCmd()
 	if err != nil {
 		return err
@@ -252,6 +386,24 @@ func addCommands() error {
 	}
 	rootCmd.AddCommand(command)
 
+	command, err = migrateconfig.NewMigrateConfigCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = convert.NewConvertCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = generate.NewGenerateCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
 	command, err = patch.NewPatchCmd()
 	if err != nil {
 		return err
@@ -276,11 +428,47 @@ func addCommands() error {
 	}
 	rootCmd.AddCommand(command)
 
-	//command, err = export.NewExportCmd()
-	//if err != nil {
-	//	return err
-	//}
-	//rootCmd.AddCommand(command)
+	command, err = export.NewExportCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = validate.NewValidateCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = doctor.NewDoctorCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = drift.NewDriftCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = imp.NewImportCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = state.NewStateCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
+
+	command, err = initialize.NewInitCmd()
+	if err != nil {
+		return err
+	}
+	rootCmd.AddCommand(command)
 
 	command, err = apply.NewApplyCmd()
 	if err != nil {
@@ -341,6 +529,7 @@ func init() {
 	profileEnvVar, found := os.LookupEnv(fmt.Sprintf("%s_PROFILE", strings.ToUpper(meta.CLIName)))
 	if found {
 		currProfile = profileEnvVar
+		profileFromEnv = true
 	}
 
 	// Remove Event Gateway commands from root when not explicitly enabled.
@@ -414,8 +603,40 @@ func bindFlags(config config.Hook) {
 	f = rootCmd.Flags().Lookup(common.LogFileFlagName)
 	util.CheckError(config.BindFlag(common.LogFileConfigPath, f))
 
+	f = rootCmd.Flags().Lookup(common.LogFormatFlagName)
+	util.CheckError(config.BindFlag(common.LogFormatConfigPath, f))
+
 	f = rootCmd.Flags().Lookup(common.ColorThemeFlagName)
 	util.CheckError(config.BindFlag(common.ColorThemeConfigPath, f))
+
+	f = rootCmd.Flags().Lookup(common.TimeoutFlagName)
+	util.CheckError(config.BindFlag(common.TimeoutConfigPath, f))
+
+	for flagName, configPath := range map[string]string{
+		common.CacheTTLFlagName:   common.CacheTTLConfigPath,
+		common.RefreshFlagName:    common.RefreshConfigPath,
+		common.ProxyFlagName:      common.ProxyConfigPath,
+		common.CACertFlagName:     common.CACertConfigPath,
+		common.ClientCertFlagName: common.ClientCertConfigPath,
+		common.ClientKeyFlagName:  common.ClientKeyConfigPath,
+	} {
+		f = rootCmd.Flags().Lookup(flagName)
+		util.CheckError(config.BindFlag(configPath, f))
+	}
+}
+
+// configureHTTPTransport applies the proxy and TLS settings to every HTTP request
+func configureHTTPTransport(cfg config.Hook) error {
+	err := httpclient.ConfigureDefaultTransport(httpclient.TransportOptions{
+		Proxy:          strings.TrimSpace(cfg.GetString(common.ProxyConfigPath)),
+		CACertFile:     strings.TrimSpace(cfg.GetString(common.CACertConfigPath)),
+		ClientCertFile: strings.TrimSpace(cfg.GetString(common.ClientCertConfigPath)),
+		ClientKeyFile:  strings.TrimSpace(cfg.GetString(common.ClientKeyConfigPath)),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid HTTP transport configuration: %w", err)
+	}
+	return nil
 }
 
 func initConfig() {
@@ -424,6 +645,14 @@ func initConfig() {
 	}
 	config, e1 := config.GetConfig(configFilePath, currProfile, defaultConfigFilePath)
 	util.CheckError(e1)
+	// The profile saved by `config use-profile` applies when neither the flag nor the
+	// environment variable selects one
+	if !rootCmd.PersistentFlags().Changed(common.ProfileFlagName) && !profileFromEnv {
+		if current := config.CurrentProfile(); current != "" && current != currProfile {
+			config = config.ForProfile(current)
+			currProfile = current
+		}
+	}
 	currConfig = config
 
 	pMgr = profile.NewManager(config.Viper)
@@ -462,8 +691,22 @@ func initConfig() {
 		config.SetString(common.LogFileConfigPath, logPath)
 	}
 
+	jsonLogs := strings.TrimSpace(config.GetString(common.LogFormatConfigPath)) == common.LogFormatJSON
+	newHandler := func(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+		if jsonLogs {
+			return slog.NewJSONHandler(w, opts)
+		}
+		return slog.NewTextHandler(w, opts)
+	}
+
 	var handler slog.Handler
-	if logPath != "" {
+	switch logPath {
+	case "-":
+		// Errors are already part of the logs written to STDERR
+		handler = newHandler(streams.ErrOut, loggerOpts)
+	case "":
+		handler = log.NewFriendlyErrorHandler(streams.ErrOut)
+	default:
 		if dir := filepath.Dir(logPath); dir != "" && dir != "." {
 			err := os.MkdirAll(dir, 0o755)
 			util.CheckError(err)
@@ -471,12 +714,10 @@ func initConfig() {
 		file, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
 		util.CheckError(err)
 		logFile = file
-		fileHandler := slog.NewTextHandler(file, loggerOpts)
+		fileHandler := newHandler(file, loggerOpts)
 		errorHandler := log.NewFriendlyErrorHandler(streams.ErrOut)
 
 		handler = log.NewDualHandler(fileHandler, errorHandler)
-	} else {
-		handler = log.NewFriendlyErrorHandler(streams.ErrOut)
 	}
 
 	logger = slog.New(handler)
@@ -504,6 +745,12 @@ func Execute(ctx context.Context, s *iostreams.IOStreams, bi *build.Info) {
 		// If it was a configuration error, we want the cobra framework to also
 		// show the usage information, so we don't also print the error here
 		var executionError *cmd.ExecutionError
+		var exitCodeError *cmd.ExitCodeError
+		if errors.As(err, &exitCodeError) {
+			logger.Debug(exitCodeError.Msg, "exit_code", exitCodeError.Code)
+			closeLogFile()
+			os.Exit(exitCodeError.Code)
+		}
 		if errors.Is(err, context.Canceled) {
 			logger.Info("Operation canceled")
 		} else if errors.As(err, &executionError) {
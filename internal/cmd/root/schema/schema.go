@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const Use = "schema"
+
+var (
+	schemaShort = i18n.T("root.schema.schemaShort", "Work with the JSON Schema of declarative configuration")
+	schemaLong  = normalizers.LongDesc(i18n.T("root.schema.schemaLong",
+		`The schema command prints the JSON Schema of declarative configuration files.
+
+The schema describes every resource type with the descriptions of the Konnect SDK,
+lists the YAML tags kongctl expands, and is versioned with kongctl. Editors such as
+VS Code with the YAML extension use it to complete and check files as they are written.`))
+	schemaExamples = normalizers.Examples(i18n.T("root.schema.schemaExamples",
+		fmt.Sprintf(`
+		# Print the schema
+		%[1]s schema dump
+		# Write the schema to a file for an editor to use
+		%[1]s schema dump --output-file kongctl.schema.json
+		`, meta.CLIName)))
+)
+
+// NewSchemaCmd builds the schema command and its subcommands
+func NewSchemaCmd() *cobra.Command {
+	rv := &cobra.Command{
+		Use:     Use,
+		Short:   schemaShort,
+		Long:    schemaLong,
+		Example: schemaExamples,
+	}
+	rv.AddCommand(newDumpCmd())
+	return rv
+}
+
+func newDumpCmd() *cobra.Command {
+	var outputFile string
+	rv := &cobra.Command{
+		Use:   "dump",
+		Short: i18n.T("root.schema.dumpShort", "Print the JSON Schema of declarative configuration files"),
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			bi, err := cmd.BuildHelper(c, args).GetBuildInfo()
+			if err != nil {
+				return err
+			}
+			data, err := dump(bi.Version)
+			if err != nil {
+				return err
+			}
+			if outputFile == "" {
+				_, err = c.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+				return fmt.Errorf("failed to write schema to %s: %w", outputFile, err)
+			}
+			return nil
+		},
+	}
+	rv.Flags().StringVar(&outputFile, "output-file", "", "File to write the schema to instead of stdout")
+	return rv
+}
+
+// dump renders the schema of declarative configuration for a kongctl version
+func dump(version string) ([]byte, error) {
+	versioned := *loader.Schema()
+	versioned.Version = version
+	data, err := versioned.JSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render schema: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,144 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/cmd"
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Use = "serve"
+
+	listenFlagName        = "listen"
+	authTokenFileFlagName = "auth-token-file"
+	// AuthTokenConfigPath is the config path of the bearer token clients authenticate with
+	AuthTokenConfigPath = "serve.auth-token"
+
+	defaultListenAddress = "127.0.0.1:8080"
+	readHeaderTimeout    = 10 * time.Second
+	shutdownTimeout      = 30 * time.Second
+)
+
+var (
+	serveShort = i18n.T("root.serve.serveShort", "Serve validate, plan and apply as an HTTP API")
+	serveLong  = normalizers.LongDesc(i18n.T("root.serve.serveLong",
+		`The serve command runs an HTTP server that validates, plans and applies declarative
+configuration submitted by other programs, such as internal developer platforms.
+
+Clients authenticate with a bearer token, read from --auth-token-file or the
+serve.auth-token config path, and send the Konnect access token of each request in
+the X-Konnect-Token header. Every request loads its files in a directory of its own.
+Submitted files cannot read the environment, secrets, remote sources or files of the
+server.`))
+	serveExamples = normalizers.Examples(i18n.T("root.serve.serveExamples",
+		fmt.Sprintf(`
+		# Serve on localhost:8080 with the token of a file
+		%[1]s serve --auth-token-file /etc/kongctl/serve-token
+		# Serve on every interface, with the token of an environment variable
+		KONGCTL_DEFAULT_SERVE_AUTH_TOKEN=secret %[1]s serve --listen :8080
+		`, meta.CLIName)))
+)
+
+// NewServeCmd builds the serve command
+func NewServeCmd() *cobra.Command {
+	rv := &cobra.Command{
+		Use:     Use,
+		Short:   serveShort,
+		Long:    serveLong,
+		Example: serveExamples,
+		Args:    cobra.NoArgs,
+		RunE:    runServe,
+	}
+	rv.Flags().String(listenFlagName, defaultListenAddress, "Address to listen on")
+	rv.Flags().String(authTokenFileFlagName, "",
+		fmt.Sprintf("File holding the bearer token of clients, instead of the %s config path", AuthTokenConfigPath))
+	return rv
+}
+
+func runServe(c *cobra.Command, args []string) error {
+	c.SilenceUsage = true
+	helper := cmd.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+
+	authToken, err := resolveAuthToken(c, cfg.GetString(AuthTokenConfigPath))
+	if err != nil {
+		return err
+	}
+	baseURL, err := konnectcommon.ResolveBaseURL(cfg)
+	if err != nil {
+		return err
+	}
+	generator := meta.CLIName + "/dev"
+	if bi, err := helper.GetBuildInfo(); err == nil && bi != nil && strings.TrimSpace(bi.Version) != "" {
+		generator = meta.CLIName + "/" + strings.TrimSpace(bi.Version)
+	}
+
+	listen, _ := c.Flags().GetString(listenFlagName)
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listen, err)
+	}
+	server := &http.Server{
+		Handler:           NewServer(authToken, baseURL, generator, logger).Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	fmt.Fprintf(c.ErrOrStderr(), "Serving the %s API on http://%s\n", meta.CLIName, listener.Addr())
+
+	ctx := c.Context()
+	errs := make(chan error, 1)
+	go func() { errs <- server.Serve(listener) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Requests in flight finish before the server stops
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to stop the server: %w", err)
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// resolveAuthToken returns the bearer token of clients, from --auth-token-file or
+// the configured token
+func resolveAuthToken(c *cobra.Command, configured string) (string, error) {
+	token := strings.TrimSpace(configured)
+	if path, _ := c.Flags().GetString(authTokenFileFlagName); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read --%s: %w", authTokenFileFlagName, err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return "", &cmd.ConfigurationError{Err: fmt.Errorf(
+			"a bearer token for clients is required: set --%s or the %s config path",
+			authTokenFileFlagName, AuthTokenConfigPath)}
+	}
+	return token, nil
+}
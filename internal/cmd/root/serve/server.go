@@ -0,0 +1,408 @@
+package serve
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/pkg/declarative"
+)
+
+const (
+	// maxRequestBytes bounds the size of a request body
+	maxRequestBytes = 10 << 20
+
+	// KonnectTokenHeader carries the Konnect access token of a request
+	KonnectTokenHeader = "X-Konnect-Token"
+	// KonnectRegionHeader selects the Konnect region of a request instead of the one
+	// of the server
+	KonnectRegionHeader = "X-Konnect-Region"
+)
+
+// Server answers the validate, plan and apply requests of the HTTP API. Each request
+// loads its configuration in its own directory and reaches Konnect with its own
+// credentials, so concurrent requests share nothing.
+type Server struct {
+	// authToken is the bearer token clients authenticate with
+	authToken string
+	// baseURL is the Konnect API of requests that do not select a region
+	baseURL   string
+	generator string
+	logger    *slog.Logger
+}
+
+// NewServer creates a server authenticating clients with authToken
+func NewServer(authToken, baseURL, generator string, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &Server{authToken: authToken, baseURL: baseURL, generator: generator, logger: logger}
+}
+
+// configRequest is the declarative configuration submitted with a request
+type configRequest struct {
+	// Files maps paths, relative to the configuration directory, to their content.
+	// Files other than YAML and JSON can be read with !file tags.
+	Files map[string]string `json:"files"`
+	// Sources are the files loaded as configuration, every YAML and JSON file of Files
+	// when empty
+	Sources       []string          `json:"sources,omitempty"`
+	Namespace     string            `json:"namespace,omitempty"`
+	DefaultLabels map[string]string `json:"default_labels,omitempty"`
+	Variables     map[string]any    `json:"variables,omitempty"`
+}
+
+type planRequest struct {
+	configRequest
+	// Mode is apply, sync or delete, apply when empty
+	Mode string `json:"mode,omitempty"`
+}
+
+type applyRequest struct {
+	planRequest
+	// Plan is a plan to apply instead of planning the configuration files
+	Plan   *declarative.Plan `json:"plan,omitempty"`
+	DryRun bool              `json:"dry_run,omitempty"`
+}
+
+type validateResponse struct {
+	Valid     bool `json:"valid"`
+	Resources int  `json:"resources"`
+}
+
+type planResponse struct {
+	Plan *declarative.Plan `json:"plan"`
+}
+
+type applyResponse struct {
+	Plan   *declarative.Plan   `json:"plan"`
+	Result *declarative.Result `json:"result"`
+	Error  string              `json:"error,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// requestError is an error answered with a status other than 500
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(format string, args ...any) error {
+	return &requestError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// Handler returns the handler of the HTTP API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("POST /v1/validate", s.authenticated(s.handleValidate))
+	mux.Handle("POST /v1/plan", s.authenticated(s.handlePlan))
+	mux.Handle("POST /v1/apply", s.authenticated(s.handleApply))
+	return s.logged(mux)
+}
+
+// authenticated rejects requests without the bearer token of the server, and answers
+// the errors of handle
+func (s *Server) authenticated(handle func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "missing or invalid bearer token"})
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+		if err := handle(w, r); err != nil {
+			status := http.StatusInternalServerError
+			var reqErr *requestError
+			if errors.As(err, &reqErr) {
+				status = reqErr.status
+			}
+			writeJSON(w, status, errorResponse{Error: err.Error()})
+		}
+	})
+}
+
+// statusRecorder remembers the status of a response for the request log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *Server) logged(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		s.logger.Info("request served", "method", r.Method, "path", r.URL.Path,
+			"status", recorder.status, "duration", time.Since(start))
+	})
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) error {
+	var req configRequest
+	if err := decodeRequest(r, &req); err != nil {
+		return err
+	}
+	dir, err := newConfigDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	rs, err := s.load(r, dir, req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, validateResponse{Valid: true, Resources: rs.ResourceCount()})
+	return nil
+}
+
+func (s *Server) handlePlan(w http.ResponseWriter, r *http.Request) error {
+	var req planRequest
+	if err := decodeRequest(r, &req); err != nil {
+		return err
+	}
+	engine, err := s.engine(r)
+	if err != nil {
+		return err
+	}
+	dir, err := newConfigDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	plan, err := s.plan(r, engine, dir, req)
+	if err != nil {
+		return err
+	}
+	writeJSON(w, http.StatusOK, planResponse{Plan: plan})
+	return nil
+}
+
+func (s *Server) handleApply(w http.ResponseWriter, r *http.Request) error {
+	var req applyRequest
+	if err := decodeRequest(r, &req); err != nil {
+		return err
+	}
+	engine, err := s.engine(r)
+	if err != nil {
+		return err
+	}
+
+	// The files stay until the plan is applied, decK runs read them
+	dir, err := newConfigDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	plan := req.Plan
+	switch {
+	case plan != nil && len(req.Files) > 0:
+		return badRequest("set either plan or files, not both")
+	case plan != nil:
+		// decK steps read files of the server, they only run with the files of the request
+		for _, change := range plan.Changes {
+			if change.ResourceType == planner.ResourceTypeDeck {
+				return badRequest("plans with decK steps cannot be submitted, submit their files instead")
+			}
+		}
+		if err := executor.CheckUntrustedPlan(plan); err != nil {
+			return badRequest("%w", err)
+		}
+	default:
+		if plan, err = s.plan(r, engine, dir, req.planRequest); err != nil {
+			return err
+		}
+	}
+
+	result, err := engine.Apply(r.Context(), plan, declarative.ApplyOptions{
+		DryRun: req.DryRun,
+		// Plans of the request files are checked again before they run
+		Untrusted: true,
+	})
+	resp := applyResponse{Plan: plan, Result: result}
+	status := http.StatusOK
+	if err != nil {
+		resp.Error = err.Error()
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, resp)
+	return nil
+}
+
+// engine creates an engine with the Konnect credentials of a request
+func (s *Server) engine(r *http.Request) (*declarative.Engine, error) {
+	token := strings.TrimSpace(r.Header.Get(KonnectTokenHeader))
+	if token == "" {
+		return nil, badRequest("the %s header with a Konnect access token is required", KonnectTokenHeader)
+	}
+	baseURL := s.baseURL
+	if region := strings.TrimSpace(r.Header.Get(KonnectRegionHeader)); region != "" {
+		var err error
+		if baseURL, err = konnectcommon.BuildBaseURLFromRegion(region); err != nil {
+			return nil, badRequest("%s: %w", KonnectRegionHeader, err)
+		}
+	}
+	return declarative.New(declarative.Config{
+		Token:     token,
+		BaseURL:   baseURL,
+		Logger:    s.logger,
+		Generator: s.generator,
+	})
+}
+
+func (s *Server) plan(
+	r *http.Request,
+	engine *declarative.Engine,
+	dir string,
+	req planRequest,
+) (*declarative.Plan, error) {
+	var mode declarative.PlanMode
+	switch req.Mode {
+	case "", string(declarative.ModeApply):
+		mode = declarative.ModeApply
+	case string(declarative.ModeSync):
+		mode = declarative.ModeSync
+	case string(declarative.ModeDelete):
+		mode = declarative.ModeDelete
+	default:
+		return nil, badRequest("invalid mode %q: must be 'apply', 'sync', or 'delete'", req.Mode)
+	}
+
+	rs, err := s.load(r, dir, req.configRequest)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := engine.Plan(r.Context(), rs, declarative.PlanOptions{Mode: mode})
+	if err != nil {
+		return nil, &requestError{status: http.StatusUnprocessableEntity, err: err}
+	}
+	return plan, nil
+}
+
+// newConfigDir creates the directory of the configuration files of a request
+func newConfigDir() (string, error) {
+	dir, err := os.MkdirTemp("", "kongctl-serve-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create configuration directory: %w", err)
+	}
+	return dir, nil
+}
+
+// load writes the files of a request to its directory and loads them as untrusted
+// configuration
+func (s *Server) load(r *http.Request, dir string, req configRequest) (*declarative.ResourceSet, error) {
+	if len(req.Files) == 0 {
+		return nil, badRequest("no configuration files in the request")
+	}
+	if err := writeFiles(dir, req.Files); err != nil {
+		return nil, err
+	}
+	sources := req.Sources
+	if len(sources) == 0 {
+		for name := range req.Files {
+			if loader.ValidateConfigFile(name) {
+				sources = append(sources, name)
+			}
+		}
+		slices.Sort(sources)
+	}
+	paths := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if _, ok := req.Files[source]; !ok {
+			return nil, badRequest("source %q is not one of the files", source)
+		}
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(source)))
+	}
+	if len(paths) == 0 {
+		return nil, badRequest("no YAML or JSON files in the request")
+	}
+
+	rs, err := declarative.Load(r.Context(), paths, declarative.LoadOptions{
+		BaseDir:       dir,
+		Namespace:     req.Namespace,
+		DefaultLabels: req.DefaultLabels,
+		Variables:     req.Variables,
+		Untrusted:     true,
+	})
+	if err != nil {
+		// Paths are reported relative to the configuration directory
+		return nil, badRequest("%s", strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""))
+	}
+	return rs, nil
+}
+
+// writeFiles writes the files of a request inside dir
+func writeFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		local := filepath.FromSlash(name)
+		if !filepath.IsLocal(local) {
+			return badRequest("file name %q must be a relative path inside the configuration", name)
+		}
+		path := filepath.Join(dir, local)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func decodeRequest(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return &requestError{
+				status: http.StatusRequestEntityTooLarge,
+				err:    fmt.Errorf("request body exceeds %d bytes", maxErr.Limit),
+			}
+		}
+		return badRequest("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// A failed write means the client went away
+	_ = json.NewEncoder(w).Encode(v)
+}
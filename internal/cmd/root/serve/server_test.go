@@ -0,0 +1,224 @@
+package serve
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const portalConfig = "portals:\n  - ref: dev\n    name: Developer Portal\n"
+
+// fakeKonnect serves an organization without resources and records the tokens of
+// its requests
+type fakeKonnect struct {
+	*httptest.Server
+	mu     sync.Mutex
+	tokens map[string]bool
+}
+
+func newFakeKonnect(t *testing.T) *fakeKonnect {
+	t.Helper()
+	fake := &fakeKonnect{tokens: make(map[string]bool)}
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		fake.tokens[r.Header.Get("Authorization")] = true
+		fake.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [], "meta": {"page": {"number": 1, "size": 100, "total": 0}}}`))
+	}))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func post(
+	t *testing.T,
+	handler http.Handler,
+	path string,
+	headers map[string]string,
+	body any,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Authorization", "Bearer client-token")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_RequiresBearerToken(t *testing.T) {
+	handler := NewServer("client-token", "", "test", nil).Handler()
+
+	for _, header := range []string{"", "Bearer wrong", "client-token"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/validate", bytes.NewReader([]byte(`{}`)))
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_Validate(t *testing.T) {
+	handler := NewServer("client-token", "", "test", nil).Handler()
+
+	tests := []struct {
+		name   string
+		files  map[string]string
+		status int
+		body   string
+	}{
+		{
+			name:   "valid",
+			files:  map[string]string{"portal.yaml": portalConfig},
+			status: http.StatusOK,
+			body:   `"resources":1`,
+		},
+		{
+			name: "file tag inside the configuration",
+			files: map[string]string{
+				"portal.yaml":   portalConfig + "    description: !file docs/about.md\n",
+				"docs/about.md": "About",
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "no files",
+			status: http.StatusBadRequest,
+			body:   "no configuration files",
+		},
+		{
+			name:   "file outside the configuration",
+			files:  map[string]string{"../portal.yaml": portalConfig},
+			status: http.StatusBadRequest,
+			body:   "must be a relative path inside the configuration",
+		},
+		{
+			name:   "environment variable",
+			files:  map[string]string{"portal.yaml": "portals:\n  - ref: dev\n    name: !env HOME\n"},
+			status: http.StatusBadRequest,
+			body:   "unsupported YAML tag: !env",
+		},
+		{
+			name:   "secret placeholder",
+			files:  map[string]string{"portal.yaml": portalConfig + "    description: __SECRET__:env://KONNECT_PAT\n"},
+			status: http.StatusBadRequest,
+			body:   "secret placeholders are not allowed in untrusted configuration",
+		},
+		{
+			name: "publication switch verify command",
+			files: map[string]string{"api.yaml": portalConfig + `apis:
+  - ref: orders
+    name: Orders API
+    publications:
+      - ref: orders-pub
+        portal_id: dev
+        _switch:
+          verify: ["sh", "-c", "id"]
+`},
+			status: http.StatusBadRequest,
+			body:   "_switch.verify commands are not allowed in untrusted configuration",
+		},
+		{
+			name:   "custom resource",
+			files:  map[string]string{"custom.yaml": "custom_resources:\n  - ref: flags\n    kind: feature_flag\n"},
+			status: http.StatusBadRequest,
+			body:   "custom resources are not allowed in untrusted configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(t, handler, "/v1/validate", nil, map[string]any{"files": tt.files})
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestServer_PlanAndApply(t *testing.T) {
+	konnect := newFakeKonnect(t)
+	handler := NewServer("client-token", konnect.URL, "test", nil).Handler()
+	files := map[string]any{"files": map[string]string{"portal.yaml": portalConfig}}
+
+	rec := post(t, handler, "/v1/plan", nil, files)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), KonnectTokenHeader)
+
+	rec = post(t, handler, "/v1/plan", map[string]string{KonnectTokenHeader: "kpat_one"}, files)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var planResp planResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &planResp))
+	require.Len(t, planResp.Plan.Changes, 1)
+	assert.Equal(t, "dev", planResp.Plan.Changes[0].ResourceRef)
+	assert.True(t, konnect.tokens["Bearer kpat_one"])
+
+	rec = post(t, handler, "/v1/apply", map[string]string{KonnectTokenHeader: "kpat_two"},
+		map[string]any{"plan": planResp.Plan, "dry_run": true})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var applyResp applyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &applyResp))
+	assert.True(t, applyResp.Result.DryRun)
+	assert.Equal(t, 1, applyResp.Result.SkippedCount)
+
+	rec = post(t, handler, "/v1/plan", map[string]string{KonnectTokenHeader: "kpat_one"},
+		map[string]any{"files": map[string]string{"portal.yaml": portalConfig}, "mode": "replace"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `invalid mode \"replace\"`)
+}
+
+func TestServer_ApplyRejectsSubmittedDeckSteps(t *testing.T) {
+	handler := NewServer("client-token", "", "test", nil).Handler()
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1-e-_deck",
+		ResourceType: planner.ResourceTypeDeck,
+		Action:       planner.ActionExternalTool,
+	})
+
+	rec := post(t, handler, "/v1/apply", map[string]string{KonnectTokenHeader: "kpat_one"}, map[string]any{"plan": plan})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "decK steps")
+}
+
+func TestServer_ApplyRejectsSubmittedHostAccess(t *testing.T) {
+	handler := NewServer("client-token", "", "test", nil).Handler()
+
+	tests := map[string]map[string]any{
+		"verify command":     {"verify": []string{"sh", "-c", "id"}},
+		"secret placeholder": {"description": "__SECRET__:file:///etc/passwd"},
+	}
+	for name, fields := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+			plan.AddChange(planner.PlannedChange{
+				ID:           "1:c:portal:dev",
+				ResourceType: planner.ResourceTypePortal,
+				ResourceRef:  "dev",
+				Action:       planner.ActionCreate,
+				Fields:       fields,
+			})
+
+			rec := post(t, handler, "/v1/apply", map[string]string{KonnectTokenHeader: "kpat_one"},
+				map[string]any{"plan": plan})
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "not allowed in untrusted plans")
+		})
+	}
+}
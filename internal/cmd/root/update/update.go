@@ -0,0 +1,120 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/update"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const Use = "update"
+
+var (
+	updateShort = i18n.T("root.update.updateShort",
+		fmt.Sprintf("Update %s to the newest release", meta.CLIName))
+	updateLong = normalizers.LongDesc(i18n.T("root.update.updateLong",
+		fmt.Sprintf(`The update command replaces the running %[1]s binary with the binary of a
+GitHub release, the newest one unless --version names another.
+
+The release archive is verified against the checksums.txt of the release before the
+binary is replaced. A %[1]s installed by a package manager such as Homebrew is
+upgraded through the package manager instead.`, meta.CLIName)))
+	updateExamples = normalizers.Examples(i18n.T("root.update.updateExamples",
+		fmt.Sprintf(`
+		# Update to the newest release
+		%[1]s update
+		# Install a specific release
+		%[1]s update --version 0.3.0
+		`, meta.CLIName)))
+)
+
+var (
+	// newReleaseClient builds the client releases are looked up and downloaded with
+	newReleaseClient = func() *update.Client {
+		return &update.Client{}
+	}
+	// executablePath returns the path of the binary to replace
+	executablePath = os.Executable
+)
+
+// NewUpdateCmd builds the update command
+func NewUpdateCmd() *cobra.Command {
+	var version string
+	var force bool
+	rv := &cobra.Command{
+		Use:     Use,
+		Short:   updateShort,
+		Long:    updateLong,
+		Example: updateExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			helper := cmd.BuildHelper(c, args)
+			bi, err := helper.GetBuildInfo()
+			if err != nil {
+				return err
+			}
+			return run(helper.GetContext(), helper.GetStreams().Out, bi.Version, version, force)
+		},
+	}
+	rv.Flags().StringVar(&version, "version", "", "Release to install instead of the newest one, such as 0.3.0")
+	rv.Flags().BoolVar(&force, "force", false,
+		"Replace the binary even when it is a development build, installed by a package manager, "+
+			"or already at the release")
+	return rv
+}
+
+// run replaces the binary of the current version with the binary of the requested
+// release, or of the newest release when version is empty
+func run(ctx context.Context, out io.Writer, current, version string, force bool) error {
+	if !force && !update.IsRelease(current) {
+		return fmt.Errorf("%s %s is a development build; use --force to replace it with a release",
+			meta.CLIName, current)
+	}
+	executable, err := executablePath()
+	if err != nil {
+		return fmt.Errorf("failed to locate the %s binary: %w", meta.CLIName, err)
+	}
+	if manager := update.ManagedBy(executable); manager != "" && !force {
+		return fmt.Errorf("%s was installed by %s; upgrade it through %s, or use --force to replace it",
+			meta.CLIName, manager, manager)
+	}
+
+	client := newReleaseClient()
+	var release *update.Release
+	if version != "" {
+		release, err = client.ReleaseByVersion(ctx, version)
+	} else {
+		release, err = client.LatestRelease(ctx, false)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find release: %w", err)
+	}
+
+	// Without --version only a newer release is installed, with it any other release is
+	upToDate := !update.IsNewer(current, release.Version())
+	if version != "" {
+		upToDate = upToDate && !update.IsNewer(release.Version(), current)
+	}
+	if upToDate && !force {
+		_, err = fmt.Fprintf(out, "%s %s is up to date\n", meta.CLIName, current)
+		return err
+	}
+
+	binary, err := client.DownloadBinary(ctx, release)
+	if err != nil {
+		return err
+	}
+	if err := update.ReplaceExecutable(executable, binary); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Updated %s from %s to %s\n", meta.CLIName, current, release.Version())
+	return err
+}
@@ -0,0 +1,119 @@
+package apiversion
+
+import (
+	"context"
+	"fmt"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/root/products"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/api"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.APIVersion
+)
+
+var (
+	apiVersionUse = Verb.String()
+
+	apiVersionShort = i18n.T("root.verbs.apiversion.apiVersionShort",
+		"Manage the lifecycle of API versions")
+
+	apiVersionLong = normalizers.LongDesc(i18n.T("root.verbs.apiversion.apiVersionLong",
+		`Use api-version to list the versions of a Konnect API with their publication status,
+promote the version portals show, deprecate versions, and roll a version's spec back to
+one uploaded earlier.
+
+Further sub-commands are required to determine the action to take.`))
+
+	apiVersionExamples = normalizers.Examples(i18n.T("root.verbs.apiversion.apiVersionExamples",
+		fmt.Sprintf(`
+		# List the versions of an API
+		%[1]s api-version list --api-name my-api
+		# Roll back the spec of version 2.0.0
+		%[1]s api-version rollback --api-name my-api 2.0.0
+		`, meta.CLIName)))
+)
+
+func NewAPIVersionCmd() (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:     apiVersionUse,
+		Short:   apiVersionShort,
+		Long:    apiVersionLong,
+		Example: apiVersionExamples,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			ctx := c.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx = context.WithValue(ctx, verbs.Verb, Verb)
+			ctx = context.WithValue(ctx, products.Product, konnect.Product)
+			ctx = context.WithValue(ctx, helpers.SDKAPIFactoryKey, common.GetSDKFactory())
+			c.SetContext(ctx)
+			return bindKonnectFlags(c, args)
+		},
+	}
+
+	// Add Konnect-specific flags as persistent flags so they appear in help
+	cmd.PersistentFlags().String(common.BaseURLFlagName, "",
+		fmt.Sprintf(`Base URL for Konnect API requests.
+- Config path: [ %s ]
+- Default   : [ %s ]`,
+			common.BaseURLConfigPath, common.BaseURLDefault))
+
+	cmd.PersistentFlags().String(common.RegionFlagName, "",
+		fmt.Sprintf(`Konnect region identifier (for example "eu"). Used to construct the base URL when --%s is not provided.
+- Config path: [ %s ]`,
+			common.BaseURLFlagName, common.RegionConfigPath),
+	)
+
+	cmd.PersistentFlags().String(common.PATFlagName, "",
+		fmt.Sprintf(`Konnect Personal Access Token (PAT) used to authenticate the CLI.
+Setting this value overrides tokens obtained from the login command.
+- Config path: [ %s ]`,
+			common.PATConfigPath))
+
+	cmd.AddCommand(api.NewVersionLifecycleCmds()...)
+
+	return cmd, nil
+}
+
+// bindKonnectFlags binds Konnect-specific flags to configuration
+func bindKonnectFlags(c *cobra.Command, args []string) error {
+	helper := cmdpkg.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	if f := c.Flags().Lookup(common.BaseURLFlagName); f != nil {
+		if err := cfg.BindFlag(common.BaseURLConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	if f := c.Flags().Lookup(common.RegionFlagName); f != nil {
+		if err := cfg.BindFlag(common.RegionConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	if f := c.Flags().Lookup(common.PATFlagName); f != nil {
+		if err := cfg.BindFlag(common.PATConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package apiversion
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIVersionCmd(t *testing.T) {
+	cmd, err := NewAPIVersionCmd()
+	require.NoError(t, err)
+	assert.Equal(t, "api-version", cmd.Use)
+	assert.Equal(t, verbs.APIVersion, Verb)
+
+	for _, name := range []string{"list", "promote", "deprecate", "rollback"} {
+		sub, _, err := cmd.Find([]string{name})
+		require.NoError(t, err)
+		assert.Equal(t, name, sub.Name())
+		assert.NotNil(t, sub.Flags().Lookup("api-id"), "expected --api-id on %s", name)
+		assert.NotNil(t, sub.Flags().Lookup("api-name"), "expected --api-name on %s", name)
+	}
+
+	rollback, _, err := cmd.Find([]string{"rollback"})
+	require.NoError(t, err)
+	assert.NotNil(t, rollback.Flags().Lookup("to"))
+	require.Error(t, rollback.Args(rollback, nil))
+
+	deprecate, _, err := cmd.Find([]string{"deprecate"})
+	require.NoError(t, err)
+	assert.NotNil(t, deprecate.Flags().Lookup("undo"))
+}
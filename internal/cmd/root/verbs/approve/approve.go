@@ -0,0 +1,133 @@
+package approve
+
+import (
+	"context"
+	"fmt"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	"github.com/kong/kongctl/internal/cmd/root/products"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/portal"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Approve
+)
+
+var (
+	approveUse = Verb.String()
+
+	approveShort = i18n.T("root.verbs.approve.approveShort", "Approve pending requests")
+
+	approveLong = normalizers.LongDesc(i18n.T("root.verbs.approve.approveLong",
+		`Use approve to grant pending access requests, such as developers that signed up to a Konnect portal.
+
+Further sub-commands are required to determine which resource to approve.`))
+
+	approveExamples = normalizers.Examples(i18n.T("root.verbs.approve.approveExamples",
+		fmt.Sprintf(`
+		# Approve a portal developer by email (Konnect-first)
+		%[1]s approve developer --portal-name my-portal dev@example.com
+		# Approve a portal developer by ID (explicit)
+		%[1]s approve konnect portal developer --portal-id <portal-id> <developer-id>
+		`, meta.CLIName)))
+)
+
+func NewApproveCmd() (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:     approveUse,
+		Short:   approveShort,
+		Long:    approveLong,
+		Example: approveExamples,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			ctx := c.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx = context.WithValue(ctx, verbs.Verb, Verb)
+			ctx = context.WithValue(ctx, products.Product, konnect.Product)
+			ctx = context.WithValue(ctx, helpers.SDKAPIFactoryKey, common.GetSDKFactory())
+			c.SetContext(ctx)
+			return bindKonnectFlags(c, args)
+		},
+	}
+
+	// Add Konnect-specific flags as persistent flags so they appear in help
+	cmd.PersistentFlags().String(common.BaseURLFlagName, "",
+		fmt.Sprintf(`Base URL for Konnect API requests.
+- Config path: [ %s ]
+- Default   : [ %s ]`,
+			common.BaseURLConfigPath, common.BaseURLDefault))
+
+	cmd.PersistentFlags().String(common.RegionFlagName, "",
+		fmt.Sprintf(`Konnect region identifier (for example "eu"). Used to construct the base URL when --%s is not provided.
+- Config path: [ %s ]`,
+			common.BaseURLFlagName, common.RegionConfigPath),
+	)
+
+	cmd.PersistentFlags().String(common.PATFlagName, "",
+		fmt.Sprintf(`Konnect Personal Access Token (PAT) used to authenticate the CLI.
+Setting this value overrides tokens obtained from the login command.
+- Config path: [ %s ]`,
+			common.PATConfigPath))
+
+	c, e := konnect.NewKonnectCmd(Verb)
+	if e != nil {
+		return nil, e
+	}
+	cmd.AddCommand(c)
+
+	// Add portal commands directly for Konnect-first pattern
+	portalCmd, err := portal.NewPortalCmd(Verb, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(portalCmd)
+
+	developerCmd, err := portal.NewDeveloperCmd(Verb, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(developerCmd)
+
+	return cmd, nil
+}
+
+// bindKonnectFlags binds Konnect-specific flags to configuration
+func bindKonnectFlags(c *cobra.Command, args []string) error {
+	helper := cmdpkg.BuildHelper(c, args)
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	if f := c.Flags().Lookup(common.BaseURLFlagName); f != nil {
+		if err := cfg.BindFlag(common.BaseURLConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	if f := c.Flags().Lookup(common.RegionFlagName); f != nil {
+		if err := cfg.BindFlag(common.RegionConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	if f := c.Flags().Lookup(common.PATFlagName); f != nil {
+		if err := cfg.BindFlag(common.PATConfigPath, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
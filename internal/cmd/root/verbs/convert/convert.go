@@ -0,0 +1,57 @@
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Convert
+)
+
+var (
+	convertUse = Verb.String()
+
+	convertShort = i18n.T("root.verbs.convert.convertShort", "Convert configuration of other tools")
+
+	convertLong = normalizers.LongDesc(i18n.T("root.verbs.convert.convertLong",
+		`Use convert to translate the configuration of other tools into declarative configuration.
+
+Converted configuration is written to stdout or a file, ready to review and apply.
+No requests are made to Konnect.`))
+
+	convertExamples = normalizers.Examples(i18n.T("root.verbs.convert.convertExamples",
+		fmt.Sprintf(`
+        # Convert the services of a decK state file
+        %[1]s convert deck -f kong.yaml
+
+        # Convert them to a control plane named prod-cp, written to a file
+        %[1]s convert deck -f kong.yaml --control-plane prod-cp --output-file control-planes.yaml
+        `, meta.CLIName)))
+)
+
+func NewConvertCmd() (*cobra.Command, error) {
+	convertCommand := &cobra.Command{
+		Use:     convertUse,
+		Short:   convertShort,
+		Long:    convertLong,
+		Example: convertExamples,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	convertCommand.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+	}
+
+	convertCommand.AddCommand(newDeckCmd())
+
+	return convertCommand, nil
+}
@@ -0,0 +1,43 @@
+package convert
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConvertCmd(t *testing.T) {
+	cmd, err := NewConvertCmd()
+	require.NoError(t, err)
+
+	assert.Equal(t, "convert", cmd.Use)
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "deck", subcommands[0].Name())
+	for _, name := range []string{"filename", "control-plane", "output-file"} {
+		assert.NotNil(t, subcommands[0].Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
+
+func TestDeckCmd_Stdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := newDeckCmd()
+	cmd.SetIn(strings.NewReader(`_format_version: "3.0"
+services:
+  - name: billing
+    url: http://billing.internal
+    routes:
+      - paths: [/billing]
+`))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"-f", "-", "--control-plane", "prod-cp"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, stdout.String(), "# Converted from stdin by kongctl convert deck")
+	assert.Contains(t, stdout.String(), "host: billing.internal")
+	assert.Contains(t, stderr.String(), "not converted, as kongctl does not manage them: 1 routes")
+}
@@ -0,0 +1,103 @@
+package convert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/scaffold"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deckShort = i18n.T("root.verbs.convert.deckShort", "Convert decK state into declarative configuration")
+
+	deckLong = normalizers.LongDesc(i18n.T("root.verbs.convert.deckLong",
+		`Convert a decK state file into a control plane with its gateway services.
+
+The control plane is named after _konnect.control_plane_name of the state unless
+--control-plane is given. Services keep their fields, a url is split into protocol,
+host, port and path, and the select tags of the state are added to their tags.
+Routes, plugins, consumers and the other entities kongctl does not manage are not
+converted and are listed in a warning; keep them in decK, with _deck on the control
+plane to run it from kongctl.`))
+
+	deckExamples = normalizers.Examples(i18n.T("root.verbs.convert.deckExamples",
+		fmt.Sprintf(`
+        # Print the converted configuration
+        %[1]s convert deck -f kong.yaml
+
+        # Read the state from stdin and write the configuration to a file
+        deck gateway dump -o - | %[1]s convert deck -f - --control-plane prod-cp --output-file prod-cp.yaml
+        `, meta.CLIName)))
+)
+
+func newDeckCmd() *cobra.Command {
+	var (
+		filename   string
+		opts       scaffold.DeckOptions
+		outputFile string
+	)
+	rv := &cobra.Command{
+		Use:     "deck",
+		Short:   deckShort,
+		Long:    deckLong,
+		Example: deckExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			c.SilenceUsage = true
+			state, err := readState(c, filename)
+			if err != nil {
+				return err
+			}
+			opts.Source = filepath.Base(filename)
+			if filename == "-" {
+				opts.Source = "stdin"
+			}
+			conversion, err := scaffold.FromDeck(state, opts)
+			if err != nil {
+				return fmt.Errorf("failed to convert %s: %w", opts.Source, err)
+			}
+			if outputFile == "" {
+				_, err = c.OutOrStdout().Write(conversion.Config)
+			} else if err = os.WriteFile(outputFile, conversion.Config, 0o600); err != nil {
+				err = fmt.Errorf("failed to write configuration to %s: %w", outputFile, err)
+			}
+			if err != nil {
+				return err
+			}
+			if len(conversion.Skipped) > 0 {
+				fmt.Fprintf(c.ErrOrStderr(), "Warning: not converted, as kongctl does not manage them: %s\n",
+					strings.Join(conversion.Skipped, ", "))
+				fmt.Fprintln(c.ErrOrStderr(), "Keep them in decK, with _deck on the control plane to run it from kongctl.")
+			}
+			return nil
+		},
+	}
+	rv.Flags().StringVarP(&filename, "filename", "f", "", "decK state file to convert, - for stdin")
+	rv.Flags().StringVar(&opts.ControlPlane, "control-plane", "",
+		"Name of the control plane (default _konnect.control_plane_name of the state)")
+	rv.Flags().StringVar(&outputFile, "output-file", "", "File to write the configuration to instead of stdout")
+	_ = rv.MarkFlagRequired("filename")
+	return rv
+}
+
+func readState(c *cobra.Command, filename string) ([]byte, error) {
+	if filename == "-" {
+		data, err := io.ReadAll(c.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state from stdin: %w", err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	return data, nil
+}
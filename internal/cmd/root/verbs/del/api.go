@@ -0,0 +1,70 @@
+package del
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/api"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+)
+
+// NewDirectAPICmd creates an API command that works at the root level (Konnect-first)
+func NewDirectAPICmd() (*cobra.Command, error) {
+	// Define the addFlags function to add Konnect-specific flags
+	addFlags := func(_ verbs.VerbValue, cmd *cobra.Command) {
+		cmd.Flags().String(common.BaseURLFlagName, "",
+			fmt.Sprintf(`Base URL for Konnect API requests.
+- Config path: [ %s ]
+- Default   : [ %s ]`,
+				common.BaseURLConfigPath, common.BaseURLDefault))
+
+		cmd.Flags().String(common.RegionFlagName, "",
+			fmt.Sprintf(`Konnect region identifier (for example "eu"). Used to construct the base URL when --%s is not provided.
+- Config path: [ %s ]`,
+				common.BaseURLFlagName, common.RegionConfigPath),
+		)
+
+		cmd.Flags().String(common.PATFlagName, "",
+			fmt.Sprintf(`Konnect Personal Access Token (PAT) used to authenticate the CLI.
+Setting this value overrides tokens obtained from the login command.
+- Config path: [ %s ]`,
+				common.PATConfigPath))
+	}
+
+	// Define the preRunE function to set up Konnect context
+	preRunE := func(c *cobra.Command, args []string) error {
+		ctx := c.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx = context.WithValue(ctx, products.Product, konnect.Product)
+		ctx = context.WithValue(ctx, helpers.SDKAPIFactoryKey, helpers.SDKAPIFactory(common.KonnectSDKFactory))
+		c.SetContext(ctx)
+
+		// Bind flags
+		return bindKonnectFlags(c, args)
+	}
+
+	// Create the API command using the existing api package
+	apiCmd, err := api.NewAPICmd(Verb, addFlags, preRunE)
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the example to show direct usage without "konnect"
+	apiCmd.Example = `  # Delete an API by ID
+  kongctl delete api 12345678-1234-1234-1234-123456789012
+  # Delete an API by name
+  kongctl delete api my-api
+  # Delete an API along with its publications, documents and versions
+  kongctl delete api my-api --cascade
+  # Delete without confirmation prompt
+  kongctl delete api my-api --approve`
+
+	return apiCmd, nil
+}
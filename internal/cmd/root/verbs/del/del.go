@@ -50,6 +50,8 @@ Output can be formatted in multiple ways to aid in further processing.`))
 		%[1]s delete portal 12345678-1234-1234-1234-123456789012
 		# Delete a Konnect portal by name
 		%[1]s delete portal my-portal
+		# Delete a Konnect API along with its publications, documents and versions
+		%[1]s delete api my-api --cascade
 		`, meta.CLIName)))
 )
 
@@ -143,6 +145,13 @@ Setting this value overrides tokens obtained from the login command.
 	}
 	cmd.AddCommand(portalCmd)
 
+	// Add API command directly for Konnect-first pattern
+	apiCmd, err := NewDirectAPICmd()
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(apiCmd)
+
 	return cmd, nil
 }
 
@@ -28,7 +28,8 @@ var (
 	diffExamples = normalizers.Examples(i18n.T("root.verbs.diff.diffExamples",
 		fmt.Sprintf(`  %[1]s diff -f api.yaml
   %[1]s diff --plan plan.json
-  %[1]s diff -f config.yaml --format json
+  %[1]s diff -f config.yaml --output json
+  %[1]s diff -f config.yaml --mode apply --no-color --exit-code
 
 Use "%[1]s help diff" for detailed documentation`, meta.CLIName)))
 )
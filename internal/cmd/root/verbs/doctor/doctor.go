@@ -0,0 +1,75 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Doctor
+)
+
+var (
+	doctorUse = Verb.String()
+
+	doctorShort = i18n.T("root.verbs.doctor.doctorShort",
+		"Check that the environment can apply declarative configuration")
+
+	doctorLong = normalizers.LongDesc(i18n.T("root.verbs.doctor.doctorLong",
+		`Run the preflight checks of an apply without planning or changing anything.
+
+Konnect is contacted in the region of the profile to check that it is reachable, that
+it accepts the access token and that the organization of the token is active. With -f,
+the configuration is also validated, refs and labels included, and one resource of each
+kind it declares is listed, to check that the token may manage them and that the
+organization has the feature enabled. Every problem is reported at once, rather than
+failing on the first Konnect request of an apply, and the command exits with a non-zero
+status if any are found. 'apply --preflight' runs the same checks before planning.`))
+
+	doctorExamples = normalizers.Examples(i18n.T("root.verbs.doctor.doctorExamples",
+		fmt.Sprintf(`  # Check the access token and region of the profile
+  %[1]s doctor
+  # Check that a configuration can be applied to the organization of the prod-eu profile
+  %[1]s doctor -f ./config -R --profile prod-eu
+
+Use "%[1]s help doctor" for detailed documentation`, meta.CLIName)))
+)
+
+func NewDoctorCmd() (*cobra.Command, error) {
+	// Create the konnect subcommand first to get its implementation
+	konnectCmd, err := konnect.NewKonnectCmd(Verb)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &cobra.Command{
+		Use:     doctorUse,
+		Short:   doctorShort,
+		Long:    doctorLong,
+		Example: doctorExamples,
+		// Use the konnect command's RunE directly for Konnect-first pattern
+		RunE: konnectCmd.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
+	}
+
+	// Copy flags from konnect command to parent
+	cmd.Flags().AddFlagSet(konnectCmd.Flags())
+
+	// Also add konnect as a subcommand for explicit usage
+	cmd.AddCommand(konnectCmd)
+
+	return cmd, nil
+}
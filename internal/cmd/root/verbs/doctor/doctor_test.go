@@ -0,0 +1,24 @@
+package doctor
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDoctorCmd(t *testing.T) {
+	cmd, err := NewDoctorCmd()
+	require.NoError(t, err)
+
+	assert.Equal(t, verbs.Doctor.String(), cmd.Use)
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "konnect", subcommands[0].Name())
+
+	// The checks contact Konnect, so connection flags are offered
+	for _, name := range []string{"filename", "recursive", "pat", "base-url", "region"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
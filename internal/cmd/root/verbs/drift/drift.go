@@ -0,0 +1,73 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Drift
+)
+
+var (
+	driftUse = Verb.String()
+
+	driftShort = i18n.T("root.verbs.drift.driftShort",
+		"Report differences made in Konnect since the configuration was last applied")
+
+	driftLong = normalizers.LongDesc(i18n.T("root.verbs.drift.driftLong",
+		`Compare the live state of kongctl managed resources with the declarative configuration.
+
+Each differing field is reported as drift when it was changed in Konnect while the
+configuration kept the value last applied, or as a pending change when the
+configuration changed since it was last applied. Only resources carrying kongctl's
+management labels are considered.`))
+
+	driftExamples = normalizers.Examples(i18n.T("root.verbs.drift.driftExamples",
+		fmt.Sprintf(`  %[1]s drift -f config.yaml
+  %[1]s drift -f ./config -R --exit-code
+  %[1]s drift -f config.yaml -o json
+  %[1]s drift -f ./config -R -o sarif > drift.sarif
+
+Use "%[1]s help drift" for detailed documentation`, meta.CLIName)))
+)
+
+func NewDriftCmd() (*cobra.Command, error) {
+	// Create the konnect subcommand first to get its implementation
+	konnectCmd, err := konnect.NewKonnectCmd(Verb)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &cobra.Command{
+		Use:     driftUse,
+		Short:   driftShort,
+		Long:    driftLong,
+		Example: driftExamples,
+		// Use the konnect command's RunE directly for Konnect-first pattern
+		RunE: konnectCmd.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			// Also run the konnect command's PersistentPreRunE to set up SDKAPIFactory
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
+	}
+
+	// Copy flags from konnect command to parent
+	cmd.Flags().AddFlagSet(konnectCmd.Flags())
+
+	// Also add konnect as a subcommand for explicit usage
+	cmd.AddCommand(konnectCmd)
+
+	return cmd, nil
+}
@@ -0,0 +1,37 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDriftCmd(t *testing.T) {
+	cmd, err := NewDriftCmd()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, "drift", cmd.Use)
+	assert.Contains(t, cmd.Example, meta.CLIName)
+
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "konnect", subcommands[0].Name())
+}
+
+func TestDriftCmdVerb(t *testing.T) {
+	assert.Equal(t, verbs.Drift, Verb)
+	assert.Equal(t, "drift", Verb.String())
+}
+
+func TestDriftCmdFlags(t *testing.T) {
+	cmd, err := NewDriftCmd()
+	require.NoError(t, err)
+
+	for _, name := range []string{"filename", "recursive", "exit-code", "output", "pat", "base-url"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	cmdpkg "github.com/kong/kongctl/internal/cmd"
 	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	decllabels "github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/migrate"
 	declresources "github.com/kong/kongctl/internal/declarative/resources"
 	declstate "github.com/kong/kongctl/internal/declarative/state"
 	"github.com/kong/kongctl/internal/konnect/helpers"
@@ -39,6 +40,7 @@ var declarativeAllowedResources = map[string]struct{}{
 	"control_planes":              {},
 	"event_gateways":              {},
 	"organization.teams":          {},
+	"notification_subscriptions":  {},
 }
 
 func newDeclarativeCmd() *cobra.Command {
@@ -66,7 +68,8 @@ func newDeclarativeCmd() *cobra.Command {
 
 	cmd.Flags().String("resources", "",
 		"Comma separated list of resource types to dump "+
-			"(portals, apis, application_auth_strategies, control_planes, event_gateways, organization.teams).")
+			"(portals, apis, application_auth_strategies, control_planes, event_gateways, organization.teams, "+
+			"notification_subscriptions).")
 	_ = cmd.MarkFlagRequired("resources")
 
 	cmd.Flags().BoolVar(&opts.includeChildResources, "include-child-resources", false,
@@ -168,6 +171,7 @@ func runDeclarativeDump(helper cmdpkg.Helper, opts declarativeOptions) error {
 			PortalTeamAPI:                 sdk.GetPortalTeamAPI(),
 			PortalTeamRolesAPI:            sdk.GetPortalTeamRolesAPI(),
 			PortalEmailsAPI:               sdk.GetPortalEmailsAPI(),
+			PortalAuditLogsAPI:            sdk.GetPortalAuditLogsAPI(),
 			AssetsAPI:                     sdk.GetAssetsAPI(),
 			APIVersionAPI:                 sdk.GetAPIVersionAPI(),
 			APIPublicationAPI:             sdk.GetAPIPublicationAPI(),
@@ -177,6 +181,8 @@ func runDeclarativeDump(helper cmdpkg.Helper, opts declarativeOptions) error {
 			EventGatewayBackendClusterAPI: sdk.GetEventGatewayBackendClusterAPI(),
 			EventGatewayVirtualClusterAPI: sdk.GetEventGatewayVirtualClusterAPI(),
 			OrganizationTeamAPI:           sdk.GetOrganizationTeamAPI(),
+			OrganizationTeamRolesAPI:      sdk.GetOrganizationTeamRolesAPI(),
+			OrganizationTeamMembershipAPI: sdk.GetOrganizationTeamMembershipAPI(),
 		})
 	}
 
@@ -264,12 +270,19 @@ func runDeclarativeDump(helper cmdpkg.Helper, opts declarativeOptions) error {
 				resourceSet.Organization = &declresources.OrganizationResource{}
 			}
 			resourceSet.Organization.Teams = append(resourceSet.Organization.Teams, teams...)
+		case "notification_subscriptions":
+			subscriptions, err := collectDeclarativeNotificationSubscriptions(ctx, sdk.GetNotificationsAPI())
+			if err != nil {
+				return err
+			}
+			resourceSet.NotificationSubscriptions = append(resourceSet.NotificationSubscriptions, subscriptions...)
 		}
 	}
 
 	resourceSet.AddDefaultNamespace(opts.defaultNamespace)
 
 	output := declarativeDumpOutput{
+		Version:     migrate.CurrentVersion,
 		Defaults:    buildDeclarativeDefaults(opts.defaultNamespace),
 		ResourceSet: resourceSet,
 	}
@@ -291,6 +304,7 @@ func runDeclarativeDump(helper cmdpkg.Helper, opts declarativeOptions) error {
 }
 
 type declarativeDumpOutput struct {
+	Version                   int                         `json:"_version"            yaml:"_version"`
 	Defaults                  *declresources.FileDefaults `json:"_defaults,omitempty" yaml:"_defaults,omitempty"`
 	declresources.ResourceSet `json:",inline" yaml:",inline"`
 }
@@ -312,6 +326,15 @@ func collectDeclarativePortals(
 	ctx context.Context,
 	portalAPI helpers.PortalAPI,
 	requestPageSize int64,
+) ([]declresources.PortalResource, error) {
+	return listDeclarativePortals(ctx, portalAPI, requestPageSize, mapPortalToDeclarativeResource)
+}
+
+func listDeclarativePortals(
+	ctx context.Context,
+	portalAPI helpers.PortalAPI,
+	requestPageSize int64,
+	mapPortal func(kkComps.ListPortalsResponsePortal) declresources.PortalResource,
 ) ([]declresources.PortalResource, error) {
 	if portalAPI == nil {
 		return nil, fmt.Errorf("portal API client is not configured")
@@ -335,7 +358,7 @@ func collectDeclarativePortals(
 		}
 
 		for _, portal := range resp.ListPortalsResponse.Data {
-			results = append(results, mapPortalToDeclarativeResource(portal))
+			results = append(results, mapPortal(portal))
 		}
 
 		return true, nil
@@ -355,6 +378,15 @@ func collectDeclarativeAPIs(
 	ctx context.Context,
 	apiClient helpers.APIAPI,
 	requestPageSize int64,
+) ([]declresources.APIResource, error) {
+	return listDeclarativeAPIs(ctx, apiClient, requestPageSize, mapAPIToDeclarativeResource)
+}
+
+func listDeclarativeAPIs(
+	ctx context.Context,
+	apiClient helpers.APIAPI,
+	requestPageSize int64,
+	mapAPI func(kkComps.APIResponseSchema) declresources.APIResource,
 ) ([]declresources.APIResource, error) {
 	if apiClient == nil {
 		return nil, fmt.Errorf("API client is not configured")
@@ -378,7 +410,7 @@ func collectDeclarativeAPIs(
 		}
 
 		for _, api := range resp.ListAPIResponse.Data {
-			results = append(results, mapAPIToDeclarativeResource(api))
+			results = append(results, mapAPI(api))
 		}
 
 		params := paginationParams{
@@ -503,6 +535,80 @@ func collectDeclarativeOrganizationTeams(
 	return results, nil
 }
 
+// collectDeclarativeNotificationSubscriptions lists the subscriptions of the current user
+// to every notification event. Konnect does not paginate these lists.
+func collectDeclarativeNotificationSubscriptions(
+	ctx context.Context,
+	notificationsClient helpers.NotificationsAPI,
+) ([]declresources.NotificationSubscriptionResource, error) {
+	if notificationsClient == nil {
+		return nil, fmt.Errorf("notifications client is not configured")
+	}
+
+	eventsResp, err := notificationsClient.ListUserConfigurations(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification events: %w", err)
+	}
+	if eventsResp == nil || eventsResp.UserConfigurationListResponse == nil {
+		return nil, nil
+	}
+
+	var results []declresources.NotificationSubscriptionResource
+	for _, event := range eventsResp.UserConfigurationListResponse.Data {
+		if event.EventSubscriptionCount != nil && *event.EventSubscriptionCount == 0 {
+			continue
+		}
+
+		resp, err := notificationsClient.ListEventSubscriptions(ctx, event.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subscriptions to notification event %s: %w", event.EventID, err)
+		}
+		if resp == nil || resp.EventSubscriptionListResponse == nil {
+			continue
+		}
+
+		for _, subscription := range resp.EventSubscriptionListResponse.Data {
+			results = append(results, mapNotificationSubscriptionToDeclarativeResource(event.EventID, subscription))
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].EventID != results[j].EventID {
+			return results[i].EventID < results[j].EventID
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	return results, nil
+}
+
+func mapNotificationSubscriptionToDeclarativeResource(
+	eventID string,
+	subscription kkComps.EventSubscriptionResponse,
+) declresources.NotificationSubscriptionResource {
+	enabled := subscription.Enabled
+	result := declresources.NotificationSubscriptionResource{
+		Ref:      subscription.ID,
+		EventID:  eventID,
+		Name:     subscription.Name,
+		Enabled:  &enabled,
+		Entities: append([]string{}, subscription.Entities...),
+	}
+
+	for _, channel := range subscription.Channels {
+		channelEnabled := channel.Enabled
+		result.Channels = append(result.Channels, declresources.NotificationChannelResource{
+			Type:    string(channel.Type),
+			Enabled: &channelEnabled,
+		})
+	}
+	for _, region := range subscription.Regions {
+		result.Regions = append(result.Regions, string(region))
+	}
+
+	return result
+}
+
 func mapPortalToDeclarativeResource(portal kkComps.ListPortalsResponsePortal) declresources.PortalResource {
 	result := declresources.PortalResource{
 		BaseResource: declresources.BaseResource{Ref: portal.GetID()},
@@ -602,18 +708,26 @@ func mapOrganizationTeamToDeclarativeResource(team kkComps.Team) declresources.O
 		result.Labels = labels
 	}
 
-	if ns := strings.TrimSpace(team.Labels[decllabels.NamespaceKey]); ns != "" {
-		result.Kongctl = &declresources.KongctlMeta{Namespace: stringPointer(ns)}
+	result.Kongctl = kongctlMetaFromLabels(team.Labels)
+
+	return result
+}
+
+// kongctlMetaFromLabels returns the namespace and protection recorded in the kongctl
+// labels of a resource, or nil when it has neither
+func kongctlMetaFromLabels(resourceLabels map[string]string) *declresources.KongctlMeta {
+	var meta *declresources.KongctlMeta
+	if ns := strings.TrimSpace(resourceLabels[decllabels.NamespaceKey]); ns != "" {
+		meta = &declresources.KongctlMeta{Namespace: stringPointer(ns)}
 	}
-	if team.Labels[decllabels.ProtectedKey] == decllabels.TrueValue {
-		if result.Kongctl == nil {
-			result.Kongctl = &declresources.KongctlMeta{}
+	if resourceLabels[decllabels.ProtectedKey] == decllabels.TrueValue {
+		if meta == nil {
+			meta = &declresources.KongctlMeta{}
 		}
 		protected := true
-		result.Kongctl.Protected = &protected
+		meta.Protected = &protected
 	}
-
-	return result
+	return meta
 }
 
 func normalizeAPIResource(api *declresources.APIResource) {
@@ -673,6 +787,15 @@ func collectDeclarativeAuthStrategies(
 	ctx context.Context,
 	api helpers.AppAuthStrategiesAPI,
 	requestPageSize int64,
+) ([]declresources.ApplicationAuthStrategyResource, error) {
+	return listDeclarativeAuthStrategies(ctx, api, requestPageSize, mapAuthStrategyToDeclarativeResource)
+}
+
+func listDeclarativeAuthStrategies(
+	ctx context.Context,
+	api helpers.AppAuthStrategiesAPI,
+	requestPageSize int64,
+	mapStrategy func(kkComps.AppAuthStrategy) (declresources.ApplicationAuthStrategyResource, error),
 ) ([]declresources.ApplicationAuthStrategyResource, error) {
 	if api == nil {
 		return nil, fmt.Errorf("application auth strategies API is not configured")
@@ -697,7 +820,7 @@ func collectDeclarativeAuthStrategies(
 		}
 
 		for _, strategy := range resp.ListAppAuthStrategiesResponse.Data {
-			mapped, mapErr := mapAuthStrategyToDeclarativeResource(strategy)
+			mapped, mapErr := mapStrategy(strategy)
 			if mapErr != nil {
 				return false, mapErr
 			}
@@ -76,6 +76,12 @@ func populatePortalChildren(
 			portal.EmailConfig = emailConfig
 		}
 
+		if webhook, err := buildPortalAuditLogWebhook(ctx, client, portalID); err != nil {
+			logWarn(logger, "failed to load portal audit log webhook", portalID, portal.Name, err)
+		} else if webhook != nil {
+			portal.AuditLogWebhook = webhook
+		}
+
 		if emailTemplates, err := buildPortalEmailTemplates(ctx, client, portalID); err != nil {
 			logWarn(logger, "failed to load portal email templates", portalID, portal.Name, err)
 		} else if len(emailTemplates) > 0 {
@@ -596,6 +602,34 @@ func buildPortalEmailConfig(
 	return &resource, nil
 }
 
+func buildPortalAuditLogWebhook(
+	ctx context.Context,
+	client *declstate.Client,
+	portalID string,
+) (*declresources.PortalAuditLogWebhookResource, error) {
+	webhook, err := client.GetPortalAuditLogWebhook(ctx, portalID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// An unconfigured webhook is reported as disabled without a destination
+	if webhook == nil || ((webhook.Enabled == nil || !*webhook.Enabled) && webhook.AuditLogDestinationID == nil) {
+		return nil, nil
+	}
+
+	resource := declresources.PortalAuditLogWebhookResource{
+		Ref: buildChildRef("portal-audit-log-webhook", portalID),
+		UpdatePortalAuditLogWebhook: kkComps.UpdatePortalAuditLogWebhook{
+			Enabled:               webhook.Enabled,
+			AuditLogDestinationID: webhook.AuditLogDestinationID,
+		},
+	}
+
+	return &resource, nil
+}
+
 func buildPortalEmailTemplates(
 	ctx context.Context,
 	client *declstate.Client,
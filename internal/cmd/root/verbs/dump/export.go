@@ -0,0 +1,320 @@
+package dump
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	konnectCommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	declresources "github.com/kong/kongctl/internal/declarative/resources"
+	declstate "github.com/kong/kongctl/internal/declarative/state"
+	"sigs.k8s.io/yaml"
+)
+
+// ExportOptions configures RunDeclarativeExport
+type ExportOptions struct {
+	// Resources lists the resource types to export; all supported types when empty
+	Resources []string
+	// OutputFile receives the configuration; stdout when empty
+	OutputFile string
+	// SpecDir receives the API version specs, loaded back with !file; specs are
+	// inlined when empty
+	SpecDir string
+	// Format is one of ExportFormats; declarative configuration when empty
+	Format string
+}
+
+// ExportResourceTypes are the resource types kongctl export supports
+var ExportResourceTypes = []string{"portals", "apis", "application_auth_strategies"}
+
+var exportAllowedResources = map[string]struct{}{
+	"portals":                     {},
+	"apis":                        {},
+	"application_auth_strategies": {},
+}
+
+// ParseExportResources normalizes the --resources value of kongctl export
+func ParseExportResources(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return ExportResourceTypes, nil
+	}
+	return normalizeResourceList(value, exportAllowedResources)
+}
+
+// RunDeclarativeExport writes the portals, APIs and application auth strategies of the
+// Konnect organization, with the versions and publications of each API, as declarative
+// configuration that applies to the same organization without changes. The terraform
+// formats write the imports of the resources kongctl manages instead.
+func RunDeclarativeExport(helper cmdpkg.Helper, opts ExportOptions) error {
+	logger, err := helper.GetLogger()
+	if err != nil {
+		return err
+	}
+	cfg, err := helper.GetConfig()
+	if err != nil {
+		return err
+	}
+	sdk, err := helper.GetKonnectSDK(cfg, logger)
+	if err != nil {
+		return err
+	}
+	stateClient := declstate.NewClient(declstate.ClientConfig{
+		APIAPI:            sdk.GetAPIAPI(),
+		APIVersionAPI:     sdk.GetAPIVersionAPI(),
+		APIPublicationAPI: sdk.GetAPIPublicationAPI(),
+	})
+
+	ctx := helper.GetContext()
+	requestPageSize := int64(cfg.GetIntOrElse(
+		konnectCommon.RequestPageSizeConfigPath,
+		konnectCommon.DefaultRequestPageSize))
+
+	resourceSet := declresources.ResourceSet{}
+	for _, resource := range opts.Resources {
+		switch resource {
+		case "portals":
+			portals, err := listDeclarativePortals(ctx, sdk.GetPortalAPI(), requestPageSize, mapPortalToExportResource)
+			if err != nil {
+				return err
+			}
+			resourceSet.Portals = append(resourceSet.Portals, portals...)
+		case "apis":
+			apis, err := listDeclarativeAPIs(ctx, sdk.GetAPIAPI(), requestPageSize, mapAPIToExportResource)
+			if err != nil {
+				return err
+			}
+			for i := range apis {
+				api := &apis[i]
+				if api.Versions, err = buildAPIVersions(ctx, logger, stateClient, api.Ref, api.Name); err != nil {
+					return fmt.Errorf("failed to load versions of API %q: %w", api.Name, err)
+				}
+				if api.Publications, err = buildAPIPublications(ctx, stateClient, api.Ref); err != nil {
+					return fmt.Errorf("failed to load publications of API %q: %w", api.Name, err)
+				}
+			}
+			resourceSet.APIs = append(resourceSet.APIs, apis...)
+		case "application_auth_strategies":
+			strategies, err := listDeclarativeAuthStrategies(ctx, sdk.GetAppAuthStrategiesAPI(), requestPageSize,
+				mapAuthStrategyToExportResource)
+			if err != nil {
+				return err
+			}
+			resourceSet.ApplicationAuthStrategies = append(resourceSet.ApplicationAuthStrategies, strategies...)
+		}
+	}
+	terraform := opts.Format == ExportFormatTerraformImport || opts.Format == ExportFormatTerraformImportCommands
+	var ids exportIDs
+	if terraform {
+		ids = collectExportIDs(&resourceSet)
+	}
+	assignExportRefs(&resourceSet)
+	if terraform {
+		imports, unmanaged := terraformImports(&resourceSet, ids)
+		writer, cleanup, err := getDumpWriter(helper, opts.OutputFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = cleanup()
+		}()
+		if err := writeTerraformImports(writer, opts.Format, imports); err != nil {
+			return err
+		}
+		warnUnmanagedTerraformImports(helper.GetStreams().ErrOut, unmanaged)
+		return nil
+	}
+	if opts.SpecDir != "" {
+		if err := writeExportSpecs(&resourceSet, opts.SpecDir, opts.OutputFile); err != nil {
+			return err
+		}
+	}
+
+	writer, cleanup, err := getDumpWriter(helper, opts.OutputFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cleanup()
+	}()
+
+	yamlBytes, err := yaml.Marshal(resourceSet)
+	if err != nil {
+		return fmt.Errorf("failed to marshal declarative configuration: %w", err)
+	}
+	if opts.SpecDir != "" {
+		if yamlBytes, err = tagExportSpecFiles(yamlBytes); err != nil {
+			return fmt.Errorf("failed to marshal declarative configuration: %w", err)
+		}
+	}
+	if _, err := writer.Write(yamlBytes); err != nil {
+		return fmt.Errorf("failed to write declarative configuration: %w", err)
+	}
+
+	warnUnmanagedExports(helper.GetStreams().ErrOut, &resourceSet)
+	return nil
+}
+
+// mapPortalToExportResource keeps the kongctl namespace of the portal, so the
+// planner matches the exported portal to the live one
+func mapPortalToExportResource(portal kkComps.ListPortalsResponsePortal) declresources.PortalResource {
+	result := mapPortalToDeclarativeResource(portal)
+	result.Kongctl = kongctlMetaFromLabels(portal.GetLabels())
+	return result
+}
+
+// mapAPIToExportResource keeps the kongctl namespace of the API, so the planner
+// matches the exported API to the live one
+func mapAPIToExportResource(api kkComps.APIResponseSchema) declresources.APIResource {
+	result := mapAPIToDeclarativeResource(api)
+	result.Kongctl = kongctlMetaFromLabels(api.Labels)
+	return result
+}
+
+// mapAuthStrategyToExportResource keeps the kongctl namespace of the auth strategy,
+// so the planner matches the exported strategy to the live one
+func mapAuthStrategyToExportResource(
+	strategy kkComps.AppAuthStrategy,
+) (declresources.ApplicationAuthStrategyResource, error) {
+	result, err := mapAuthStrategyToDeclarativeResource(strategy)
+	if err != nil {
+		return result, err
+	}
+	labels := strategy.AppAuthStrategyKeyAuthResponseAppAuthStrategyKeyAuthResponse.GetLabels()
+	if strategy.Type == kkComps.AppAuthStrategyTypeOpenidConnect {
+		labels = strategy.AppAuthStrategyOpenIDConnectResponseAppAuthStrategyOpenIDConnectResponse.GetLabels()
+	}
+	result.Kongctl = kongctlMetaFromLabels(labels)
+	return result, nil
+}
+
+// assignExportRefs replaces the Konnect IDs used as refs while collecting with refs
+// derived from resource names, and points publications at the ref of their portal
+// and auth strategies. Publications to portals outside the export reference an
+// _external portal; auth strategies outside the export are kept by ID.
+func assignExportRefs(rs *declresources.ResourceSet) {
+	refs := exportRefs{seen: make(map[string]bool)}
+
+	strategyRefs := make(map[string]string, len(rs.ApplicationAuthStrategies))
+	for i := range rs.ApplicationAuthStrategies {
+		strategy := &rs.ApplicationAuthStrategies[i]
+		ref := refs.claim("auth-strategy", strategy.GetMoniker())
+		strategyRefs[strategy.Ref] = ref
+		strategy.Ref = ref
+	}
+
+	portalRefs := make(map[string]string, len(rs.Portals))
+	for i := range rs.Portals {
+		portal := &rs.Portals[i]
+		ref := refs.claim("portal", portal.Name)
+		portalRefs[portal.Ref] = ref
+		portal.Ref = ref
+	}
+
+	for i := range rs.APIs {
+		api := &rs.APIs[i]
+		api.Ref = refs.claim("api", api.Name)
+
+		for j := range api.Versions {
+			version := &api.Versions[j]
+			version.Ref = refs.claim("version", api.Ref+"-"+getString(version.Version))
+		}
+
+		for j := range api.Publications {
+			publication := &api.Publications[j]
+			portalRef, ok := portalRefs[publication.PortalID]
+			if !ok {
+				portalRef = buildChildRef("portal", publication.PortalID)
+				rs.Portals = append(rs.Portals, declresources.PortalResource{
+					BaseResource: declresources.BaseResource{Ref: portalRef},
+					External:     &declresources.ExternalBlock{ID: publication.PortalID},
+				})
+				refs.seen[portalRef] = true
+				portalRefs[publication.PortalID] = portalRef
+			}
+			publication.PortalID = portalRef
+			publication.Ref = refs.claim("publication", api.Ref+"-"+portalRef)
+			for k, strategyID := range publication.AuthStrategyIds {
+				if ref, ok := strategyRefs[strategyID]; ok {
+					publication.AuthStrategyIds[k] = ref
+				}
+			}
+		}
+	}
+}
+
+// exportRefs hands out refs that are unique across the export
+type exportRefs struct {
+	seen map[string]bool
+}
+
+// claim derives a ref from name, numbering it when an earlier resource holds it
+func (r exportRefs) claim(kind, name string) string {
+	base := nameToRef(name)
+	if len(base) < declresources.MinRefLength {
+		base = nameToRef(kind + "-" + base)
+	}
+
+	ref := base
+	for n := 2; r.seen[ref]; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		ref = strings.TrimRight(base[:min(len(base), declresources.MaxRefLength-len(suffix))], "-") + suffix
+	}
+	r.seen[ref] = true
+	return ref
+}
+
+// nameToRef lowercases name and joins its words with hyphens, e.g. "Orders API v2" to "orders-api-v2"
+func nameToRef(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	ref := b.String()
+	if len(ref) > declresources.MaxRefLength {
+		ref = strings.TrimRight(ref[:declresources.MaxRefLength], "-")
+	}
+	return ref
+}
+
+// warnUnmanagedExports reports exported resources without a kongctl namespace label.
+// The planner only matches labeled resources, so applying the export would try to
+// create them again until they are adopted.
+func warnUnmanagedExports(out io.Writer, rs *declresources.ResourceSet) {
+	var unmanaged []string
+	for _, portal := range rs.Portals {
+		if portal.External == nil && (portal.Kongctl == nil || portal.Kongctl.Namespace == nil) {
+			unmanaged = append(unmanaged, fmt.Sprintf("portal %q", portal.Name))
+		}
+	}
+	for _, api := range rs.APIs {
+		if api.Kongctl == nil || api.Kongctl.Namespace == nil {
+			unmanaged = append(unmanaged, fmt.Sprintf("api %q", api.Name))
+		}
+	}
+	for _, strategy := range rs.ApplicationAuthStrategies {
+		if strategy.Kongctl == nil || strategy.Kongctl.Namespace == nil {
+			unmanaged = append(unmanaged, fmt.Sprintf("application_auth_strategy %q", strategy.GetMoniker()))
+		}
+	}
+	if len(unmanaged) == 0 || out == nil {
+		return
+	}
+
+	fmt.Fprintf(out, "Warning: %d exported resources are not managed by kongctl yet: %s\n",
+		len(unmanaged), strings.Join(unmanaged, ", "))
+	fmt.Fprintln(out, "Run 'kongctl adopt' on them before applying the exported configuration.")
+}
@@ -0,0 +1,103 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cmdpkg "github.com/kong/kongctl/internal/cmd"
+	declresources "github.com/kong/kongctl/internal/declarative/resources"
+	yamlv3 "gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to write !file tags
+)
+
+// exportSpecFilePrefix marks a spec written to a file until the spec is replaced by a
+// !file tag in the marshaled configuration
+const exportSpecFilePrefix = "__EXPORT_FILE__:"
+
+// writeExportSpecs writes the spec of every API version to specDir, named after the
+// version ref, and points the version at the file. Paths are relative to the directory
+// of the configuration, which bounds !file paths when it is loaded.
+func writeExportSpecs(rs *declresources.ResourceSet, specDir, outputFile string) error {
+	configDir := "."
+	if outputFile != "" {
+		configDir = filepath.Dir(outputFile)
+	}
+	absConfigDir, err := filepath.Abs(configDir)
+	if err != nil {
+		return err
+	}
+	absSpecDir, err := filepath.Abs(specDir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(absConfigDir, absSpecDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return &cmdpkg.ConfigurationError{
+			Err: fmt.Errorf("--spec-dir %s must be inside %s, the directory of the exported configuration",
+				specDir, absConfigDir),
+		}
+	}
+
+	if err := os.MkdirAll(absSpecDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create spec directory: %w", err)
+	}
+	for i := range rs.APIs {
+		for j := range rs.APIs[i].Versions {
+			version := &rs.APIs[i].Versions[j]
+			content := version.Spec.Content
+			if content == nil || strings.TrimSpace(*content) == "" {
+				continue
+			}
+			name := version.Ref + ".yaml"
+			if strings.HasPrefix(strings.TrimSpace(*content), "{") {
+				name = version.Ref + ".json"
+			}
+			if err := os.WriteFile(filepath.Join(absSpecDir, name), []byte(*content), 0o600); err != nil {
+				return fmt.Errorf("failed to write spec of API version %q: %w", version.Ref, err)
+			}
+			path := "./" + filepath.ToSlash(filepath.Join(rel, name))
+			version.Spec.Content = stringPointer(exportSpecFilePrefix + path)
+		}
+	}
+	return nil
+}
+
+// tagExportSpecFiles replaces the specs written to files with !file tags
+func tagExportSpecFiles(data []byte) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	tagSpecFileNodes(&doc)
+
+	var out bytes.Buffer
+	encoder := yamlv3.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func tagSpecFileNodes(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != "spec" || value.Kind != yamlv3.MappingNode || len(value.Content) != 2 ||
+				value.Content[0].Value != "content" {
+				continue
+			}
+			if path, ok := strings.CutPrefix(value.Content[1].Value, exportSpecFilePrefix); ok {
+				node.Content[i+1] = &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!file", Value: path}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		tagSpecFileNodes(child)
+	}
+}
@@ -0,0 +1,193 @@
+package dump
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	declresources "github.com/kong/kongctl/internal/declarative/resources"
+)
+
+const (
+	// ExportFormatDeclarative writes kongctl declarative configuration
+	ExportFormatDeclarative = "declarative"
+	// ExportFormatTerraformImport writes Terraform import blocks
+	ExportFormatTerraformImport = "terraform-import"
+	// ExportFormatTerraformImportCommands writes terraform import commands
+	ExportFormatTerraformImportCommands = "terraform-import-commands"
+)
+
+// ExportFormats are the formats kongctl export writes
+var ExportFormats = []string{
+	ExportFormatDeclarative, ExportFormatTerraformImport, ExportFormatTerraformImportCommands,
+}
+
+// ParseExportFormat validates the --format value of kongctl export
+func ParseExportFormat(value string) (string, error) {
+	format := strings.TrimSpace(value)
+	if format == "" {
+		return ExportFormatDeclarative, nil
+	}
+	for _, known := range ExportFormats {
+		if format == known {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid format %q, must be one of %s", value, strings.Join(ExportFormats, ", "))
+}
+
+// terraformImport maps a Konnect resource to its terraform-provider-konnect address
+type terraformImport struct {
+	// resourceType is a key of resourceTypeMap
+	resourceType string
+	// name is the kongctl ref of the resource
+	name string
+	// id identifies the resource to the provider, as a string or a jsonencode object
+	id map[string]string
+}
+
+// exportIDs are the Konnect IDs the export collected resources under, by position,
+// kept before assignExportRefs replaces them with refs
+type exportIDs struct {
+	portals, apis, strategies []string
+	versions                  [][]string
+	publicationPortals        [][]string
+}
+
+func collectExportIDs(rs *declresources.ResourceSet) exportIDs {
+	ids := exportIDs{}
+	for _, portal := range rs.Portals {
+		ids.portals = append(ids.portals, portal.Ref)
+	}
+	for _, strategy := range rs.ApplicationAuthStrategies {
+		ids.strategies = append(ids.strategies, strategy.Ref)
+	}
+	for _, api := range rs.APIs {
+		ids.apis = append(ids.apis, api.Ref)
+		versions := make([]string, 0, len(api.Versions))
+		for _, version := range api.Versions {
+			versions = append(versions, version.Ref)
+		}
+		ids.versions = append(ids.versions, versions)
+		portals := make([]string, 0, len(api.Publications))
+		for _, publication := range api.Publications {
+			portals = append(portals, publication.PortalID)
+		}
+		ids.publicationPortals = append(ids.publicationPortals, portals)
+	}
+	return ids
+}
+
+func isManagedExport(meta *declresources.KongctlMeta) bool {
+	return meta != nil && meta.Namespace != nil
+}
+
+// terraformImports lists the imports of the resources kongctl manages, named after
+// the refs the declarative export gives them, and the resources left out as not
+// managed. Versions and publications are imported with their API.
+func terraformImports(rs *declresources.ResourceSet, ids exportIDs) ([]terraformImport, []string) {
+	var imports []terraformImport
+	var unmanaged []string
+
+	for i, portal := range rs.Portals[:len(ids.portals)] {
+		if !isManagedExport(portal.Kongctl) {
+			unmanaged = append(unmanaged, fmt.Sprintf("portal %q", portal.Name))
+			continue
+		}
+		imports = append(imports, terraformImport{
+			resourceType: "portal", name: portal.Ref, id: map[string]string{"id": ids.portals[i]},
+		})
+	}
+
+	for i, api := range rs.APIs {
+		if !isManagedExport(api.Kongctl) {
+			unmanaged = append(unmanaged, fmt.Sprintf("api %q", api.Name))
+			continue
+		}
+		imports = append(imports, terraformImport{
+			resourceType: "api", name: api.Ref, id: map[string]string{"id": ids.apis[i]},
+		})
+		for j, version := range api.Versions {
+			imports = append(imports, terraformImport{
+				resourceType: "api_specification", name: version.Ref,
+				id: map[string]string{"id": ids.versions[i][j], "api_id": ids.apis[i]},
+			})
+		}
+		for j, publication := range api.Publications {
+			imports = append(imports, terraformImport{
+				resourceType: "api_publication", name: publication.Ref,
+				id: map[string]string{"api_id": ids.apis[i], "portal_id": ids.publicationPortals[i][j]},
+			})
+		}
+	}
+
+	for i, strategy := range rs.ApplicationAuthStrategies {
+		if !isManagedExport(strategy.Kongctl) {
+			unmanaged = append(unmanaged, fmt.Sprintf("application_auth_strategy %q", strategy.GetMoniker()))
+			continue
+		}
+		imports = append(imports, terraformImport{
+			resourceType: "app-auth-strategies", name: strategy.Ref, id: map[string]string{"id": ids.strategies[i]},
+		})
+	}
+	return imports, unmanaged
+}
+
+// address returns the Terraform address of the resource
+func (t terraformImport) address() string {
+	return resourceTypeMap[t.resourceType] + "." + sanitizeTerraformResourceName(t.name)
+}
+
+// importID renders the ID the provider imports the resource by: the ID itself, or a
+// JSON object for resources identified by their parent
+func (t terraformImport) importID() string {
+	if len(t.id) == 1 {
+		if id, ok := t.id["id"]; ok {
+			return id
+		}
+	}
+	var fields []string
+	for _, key := range []string{"id", "api_id", "portal_id"} {
+		if value, ok := t.id[key]; ok {
+			fields = append(fields, fmt.Sprintf("%q:%q", key, value))
+		}
+	}
+	return "{" + strings.Join(fields, ",") + "}"
+}
+
+func writeTerraformImports(out io.Writer, format string, imports []terraformImport) error {
+	for _, imp := range imports {
+		var err error
+		if format == ExportFormatTerraformImportCommands {
+			_, err = fmt.Fprintf(out, "terraform import '%s' '%s'\n", imp.address(), imp.importID())
+		} else {
+			_, err = fmt.Fprintln(out, imp.block())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write terraform imports: %w", err)
+		}
+	}
+	return nil
+}
+
+// block renders the import block of the resource like kongctl dump tf-import
+func (t terraformImport) block() string {
+	switch {
+	case t.resourceType == "api_publication":
+		return formatTerraformImportForAPIPublication(t.resourceType, t.name, t.id["api_id"], t.id["portal_id"])
+	case t.id["api_id"] != "":
+		return formatTerraformImport(t.resourceType, t.name, t.id["id"], "api_id", t.id["api_id"])
+	default:
+		return formatTerraformImport(t.resourceType, t.name, t.id["id"], "", "")
+	}
+}
+
+// warnUnmanagedTerraformImports reports the exported resources left out of the
+// imports because kongctl does not manage them
+func warnUnmanagedTerraformImports(out io.Writer, unmanaged []string) {
+	if len(unmanaged) == 0 || out == nil {
+		return
+	}
+	fmt.Fprintf(out, "Warning: %d resources are not managed by kongctl and were not imported: %s\n",
+		len(unmanaged), strings.Join(unmanaged, ", "))
+}
@@ -0,0 +1,71 @@
+package dump
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	if format, err := ParseExportFormat(""); err != nil || format != ExportFormatDeclarative {
+		t.Fatalf("expected declarative by default, got %q, %v", format, err)
+	}
+	if format, err := ParseExportFormat("terraform-import"); err != nil || format != ExportFormatTerraformImport {
+		t.Fatalf("expected terraform-import, got %q, %v", format, err)
+	}
+	if _, err := ParseExportFormat("hcl"); err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+}
+
+func TestTerraformImports(t *testing.T) {
+	rs := exportedResourceSet()
+	ids := collectExportIDs(&rs)
+	assignExportRefs(&rs)
+
+	imports, unmanaged := terraformImports(&rs, ids)
+
+	var out bytes.Buffer
+	if err := writeTerraformImports(&out, ExportFormatTerraformImportCommands, imports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := strings.Join([]string{
+		"terraform import 'konnect_portal.developer_portal' 'portal-1'",
+		"terraform import 'konnect_api.orders' 'api-1'",
+		`terraform import 'konnect_api_specification.orders_1_0_0' '{"id":"version-1","api_id":"api-1"}'`,
+		`terraform import 'konnect_api_publication.orders_developer_portal' '{"api_id":"api-1","portal_id":"portal-1"}'`,
+		`terraform import 'konnect_api_publication.orders_portal_cb0784e50058' ` +
+			`'{"api_id":"api-1","portal_id":"0b9a2f0e-72e7-4212-9099-0764f8e9c5ac"}'`,
+		"terraform import 'konnect_application_auth_strategy.key_auth' 'strategy-1'",
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Fatalf("unexpected commands:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	// The external portal added for the publication is not a resource of the export,
+	// and the API without a namespace is not managed by kongctl
+	if len(unmanaged) != 1 || unmanaged[0] != `api "orders"` {
+		t.Fatalf("expected the unlabeled API to be reported, got %v", unmanaged)
+	}
+}
+
+func TestTerraformImports_Blocks(t *testing.T) {
+	rs := exportedResourceSet()
+	ids := collectExportIDs(&rs)
+	assignExportRefs(&rs)
+	imports, _ := terraformImports(&rs, ids)
+
+	var out bytes.Buffer
+	if err := writeTerraformImports(&out, ExportFormatTerraformImport, imports[:3]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"  to = konnect_portal.developer_portal\n  provider = konnect-beta\n  id = \"portal-1\"",
+		"  to = konnect_api_specification.orders_1_0_0",
+		"\"api_id\": \"api-1\"",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in import blocks:\n%s", want, out.String())
+		}
+	}
+}
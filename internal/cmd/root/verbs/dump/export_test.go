@@ -0,0 +1,248 @@
+package dump
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+
+	decllabels "github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	declresources "github.com/kong/kongctl/internal/declarative/resources"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNameToRef(t *testing.T) {
+	tests := map[string]string{
+		"Developer Portal":  "developer-portal",
+		"Orders API v2":     "orders-api-v2",
+		"  payments--API  ": "payments-api",
+		"orders-1.0.0":      "orders-1-0-0",
+		"!!!":               "",
+	}
+	for name, want := range tests {
+		if got := nameToRef(name); got != want {
+			t.Errorf("nameToRef(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	long := strings.Repeat("a", declresources.MaxRefLength+10)
+	if got := nameToRef(long); len(got) != declresources.MaxRefLength {
+		t.Fatalf("expected ref truncated to %d characters, got %d", declresources.MaxRefLength, len(got))
+	}
+}
+
+func TestMapPortalToExportResource_KeepsKongctlMetadata(t *testing.T) {
+	portal := kkComps.ListPortalsResponsePortal{
+		ID:   "portal-id",
+		Name: "Developer Portal",
+		Labels: map[string]string{
+			decllabels.NamespaceKey: "team-alpha",
+			decllabels.ProtectedKey: decllabels.TrueValue,
+			"env":                   "prod",
+		},
+	}
+
+	resource := mapPortalToExportResource(portal)
+
+	if resource.Kongctl == nil || resource.Kongctl.Namespace == nil || *resource.Kongctl.Namespace != "team-alpha" {
+		t.Fatalf("expected namespace team-alpha, got %+v", resource.Kongctl)
+	}
+	if resource.Kongctl.Protected == nil || !*resource.Kongctl.Protected {
+		t.Fatalf("expected protected metadata to be preserved")
+	}
+	if val, ok := resource.Labels["env"]; !ok || val == nil || *val != "prod" {
+		t.Fatalf("expected user labels to be preserved, got %v", resource.Labels)
+	}
+	if _, ok := resource.Labels[decllabels.NamespaceKey]; ok {
+		t.Fatalf("expected kongctl labels to be stripped from user labels")
+	}
+}
+
+func exportedResourceSet() declresources.ResourceSet {
+	namespace := "default"
+	meta := &declresources.KongctlMeta{Namespace: &namespace}
+	v1 := "1.0.0"
+	spec := `{"openapi":"3.0.0","info":{"title":"Orders","version":"1.0.0"},"paths":{}}`
+
+	return declresources.ResourceSet{
+		Portals: []declresources.PortalResource{
+			{
+				BaseResource: declresources.BaseResource{Ref: "portal-1", Kongctl: meta},
+				CreatePortal: kkComps.CreatePortal{Name: "Developer Portal"},
+			},
+		},
+		APIs: []declresources.APIResource{
+			{
+				BaseResource:     declresources.BaseResource{Ref: "api-1", Kongctl: meta},
+				CreateAPIRequest: kkComps.CreateAPIRequest{Name: "Orders"},
+				Versions: []declresources.APIVersionResource{{
+					Ref: "version-1",
+					CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+						Version: &v1,
+						Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &spec},
+					},
+				}},
+				Publications: []declresources.APIPublicationResource{
+					{
+						Ref: "publication-1", PortalID: "portal-1",
+						APIPublication: kkComps.APIPublication{AuthStrategyIds: []string{"strategy-1"}},
+					},
+					{
+						Ref: "publication-2", PortalID: "0b9a2f0e-72e7-4212-9099-0764f8e9c5ac",
+						APIPublication: kkComps.APIPublication{
+							AuthStrategyIds: []string{"5d3c2b1a-0000-4000-8000-000000000000"},
+						},
+					},
+				},
+			},
+			{
+				BaseResource:     declresources.BaseResource{Ref: "api-2"},
+				CreateAPIRequest: kkComps.CreateAPIRequest{Name: "orders"},
+			},
+		},
+		ApplicationAuthStrategies: []declresources.ApplicationAuthStrategyResource{
+			{
+				BaseResource: declresources.BaseResource{Ref: "strategy-1", Kongctl: meta},
+				CreateAppAuthStrategyRequest: kkComps.CreateCreateAppAuthStrategyRequestKeyAuth(
+					kkComps.AppAuthStrategyKeyAuthRequest{
+						Name:        "Key Auth",
+						DisplayName: "Key Auth",
+						Configs: kkComps.AppAuthStrategyKeyAuthRequestConfigs{
+							KeyAuth: kkComps.AppAuthStrategyConfigKeyAuth{KeyNames: []string{"apikey"}},
+						},
+					}),
+			},
+		},
+	}
+}
+
+func TestAssignExportRefs(t *testing.T) {
+	rs := exportedResourceSet()
+	assignExportRefs(&rs)
+
+	if rs.Portals[0].Ref != "developer-portal" {
+		t.Fatalf("expected portal ref derived from name, got %q", rs.Portals[0].Ref)
+	}
+	if rs.APIs[0].Ref != "orders" || rs.APIs[1].Ref != "orders-2" {
+		t.Fatalf("expected unique API refs orders and orders-2, got %q and %q", rs.APIs[0].Ref, rs.APIs[1].Ref)
+	}
+	if got := rs.APIs[0].Versions[0].Ref; got != "orders-1-0-0" {
+		t.Fatalf("expected version ref orders-1-0-0, got %q", got)
+	}
+
+	publications := rs.APIs[0].Publications
+	if publications[0].PortalID != "developer-portal" || publications[0].Ref != "orders-developer-portal" {
+		t.Fatalf("expected publication to reference the exported portal, got %+v", publications[0])
+	}
+
+	if len(rs.Portals) != 2 {
+		t.Fatalf("expected an external portal for the publication outside the export, got %d portals", len(rs.Portals))
+	}
+	external := rs.Portals[1]
+	if external.External == nil || external.External.ID != "0b9a2f0e-72e7-4212-9099-0764f8e9c5ac" {
+		t.Fatalf("expected external portal by ID, got %+v", external.External)
+	}
+	if publications[1].PortalID != external.Ref {
+		t.Fatalf("expected publication to reference external portal %q, got %q", external.Ref, publications[1].PortalID)
+	}
+}
+
+func TestAssignExportRefs_AuthStrategies(t *testing.T) {
+	rs := exportedResourceSet()
+	assignExportRefs(&rs)
+
+	if got := rs.ApplicationAuthStrategies[0].Ref; got != "key-auth" {
+		t.Fatalf("expected auth strategy ref derived from name, got %q", got)
+	}
+	publications := rs.APIs[0].Publications
+	if ids := publications[0].AuthStrategyIds; len(ids) != 1 || ids[0] != "key-auth" {
+		t.Fatalf("expected the exported strategy referenced by ref, got %v", ids)
+	}
+	if ids := publications[1].AuthStrategyIds; len(ids) != 1 || ids[0] != "5d3c2b1a-0000-4000-8000-000000000000" {
+		t.Fatalf("expected a strategy outside the export kept by ID, got %v", ids)
+	}
+}
+
+func TestWriteExportSpecs(t *testing.T) {
+	rs := exportedResourceSet()
+	assignExportRefs(&rs)
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "export.yaml")
+	if err := writeExportSpecs(&rs, filepath.Join(dir, "specs"), output); err != nil {
+		t.Fatalf("failed to write specs: %v", err)
+	}
+	spec, err := os.ReadFile(filepath.Join(dir, "specs", "orders-1-0-0.json"))
+	if err != nil || !bytes.Contains(spec, []byte(`"openapi":"3.0.0"`)) {
+		t.Fatalf("expected the spec written to specs/orders-1-0-0.json, got %q (%v)", spec, err)
+	}
+
+	data, err := yaml.Marshal(rs)
+	if err != nil {
+		t.Fatalf("failed to marshal export: %v", err)
+	}
+	if data, err = tagExportSpecFiles(data); err != nil {
+		t.Fatalf("failed to tag spec files: %v", err)
+	}
+	if !bytes.Contains(data, []byte("spec: !file ./specs/orders-1-0-0.json")) {
+		t.Fatalf("expected the version to load its spec with !file:\n%s", data)
+	}
+	if err := os.WriteFile(output, data, 0o600); err != nil {
+		t.Fatalf("failed to write export: %v", err)
+	}
+	loaded, err := loader.New().LoadFromSources([]loader.Source{{Path: output, Type: loader.SourceTypeFile}}, false)
+	if err != nil {
+		t.Fatalf("expected the export to load as declarative configuration: %v\n%s", err, data)
+	}
+	if len(loaded.APIVersions) != 1 || loaded.APIVersions[0].Spec.Content == nil ||
+		!strings.Contains(*loaded.APIVersions[0].Spec.Content, "Orders") {
+		t.Fatalf("expected the spec loaded from its file, got %+v", loaded.APIVersions)
+	}
+
+	if err := writeExportSpecs(&rs, t.TempDir(), output); err == nil ||
+		!strings.Contains(err.Error(), "must be inside") {
+		t.Fatalf("expected a spec directory outside the configuration directory to fail, got %v", err)
+	}
+}
+
+func TestAssignExportRefs_OutputLoads(t *testing.T) {
+	rs := exportedResourceSet()
+	assignExportRefs(&rs)
+
+	data, err := yaml.Marshal(rs)
+	if err != nil {
+		t.Fatalf("failed to marshal export: %v", err)
+	}
+	if bytes.Contains(data, []byte("api-1")) || bytes.Contains(data, []byte("portal-1")) {
+		t.Fatalf("expected Konnect IDs to be omitted from the export:\n%s", data)
+	}
+
+	path := filepath.Join(t.TempDir(), "export.yaml")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write export: %v", err)
+	}
+	loaded, err := loader.New().LoadFromSources([]loader.Source{{Path: path, Type: loader.SourceTypeFile}}, false)
+	if err != nil {
+		t.Fatalf("expected the export to load as declarative configuration: %v\n%s", err, data)
+	}
+	if len(loaded.APIPublications) != 2 || len(loaded.APIVersions) != 1 {
+		t.Fatalf("expected nested versions and publications to load, got %d versions and %d publications",
+			len(loaded.APIVersions), len(loaded.APIPublications))
+	}
+}
+
+func TestWarnUnmanagedExports(t *testing.T) {
+	rs := exportedResourceSet()
+	assignExportRefs(&rs)
+
+	var out bytes.Buffer
+	warnUnmanagedExports(&out, &rs)
+
+	if !strings.Contains(out.String(), `1 exported resources are not managed by kongctl yet: api "orders"`) {
+		t.Fatalf("expected warning for the unlabeled API only, got %q", out.String())
+	}
+}
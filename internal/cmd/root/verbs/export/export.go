@@ -23,25 +23,26 @@ var (
 		"Export current state as declarative configuration")
 
 	exportLong = normalizers.LongDesc(i18n.T("root.verbs.export.exportLong",
-		`Export the current state of resources as declarative configuration files.
+		`Export the current state of resources as declarative configuration.
 
 This command retrieves the current configuration from the target environment
-and generates declarative configuration files that can be version controlled,
-modified, and applied to other environments.`))
+and generates a declarative configuration file that can be version controlled,
+modified, and applied to other environments. With --format terraform-import, it
+writes Terraform import blocks for the resources kongctl manages instead.`))
 
 	exportExamples = normalizers.Examples(i18n.T("root.verbs.export.exportExamples",
 		fmt.Sprintf(`
-		# Export all resources to directory
-		%[1]s export -o ./exported-config
-		
-		# Export specific resource types
-		%[1]s export -o ./exported-config --resources portals,apis
-		
-		# Export to a specific structure
-		%[1]s export -o ./config --structure flat
-		
-		# Export with custom file naming
-		%[1]s export -o ./config --split-by-type
+		# Export all portals and APIs to stdout
+		%[1]s export
+
+		# Export only APIs, with their versions and publications, to a file
+		%[1]s export --resources apis -o apis.yaml
+
+		# Export APIs with their specs written to ./specs and loaded with !file
+		%[1]s export --resources apis -o apis.yaml --spec-dir specs
+
+		# Write Terraform import blocks for the resources kongctl manages
+		%[1]s export --format terraform-import -o imports.tf
 		`, meta.CLIName)))
 )
 
@@ -59,8 +60,13 @@ func NewExportCmd() (*cobra.Command, error) {
 		Example: exportExamples,
 		// Use the konnect command's RunE directly for Konnect-first pattern
 		RunE: konnectCmd.RunE,
-		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			// Also run the konnect command's PersistentPreRunE to set up SDKAPIFactory
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
 		},
 	}
 
@@ -0,0 +1,102 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kong/kongctl/internal/declarative/scaffold"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiShort = i18n.T("root.verbs.generate.apiShort", "Generate an API from an OpenAPI spec")
+
+	apiLong = normalizers.LongDesc(i18n.T("root.verbs.generate.apiLong",
+		`Generate declarative configuration for an API from an OpenAPI or Swagger spec.
+
+The API is named, described and versioned from the info object of the spec, its ref
+is derived from the title unless --ref is given, and a version loads the spec with a
+!file tag relative to the output file. With --portal, a publication of the API to the
+portal with that ref is added. A spec outside the directory of the output file needs
+--base-dir when the configuration is applied.`))
+
+	apiExamples = normalizers.Examples(i18n.T("root.verbs.generate.apiExamples",
+		fmt.Sprintf(`
+        # Print the configuration for an API
+        %[1]s generate api --from-spec specs/orders.yaml
+
+        # Write it to a file, published to a portal
+        %[1]s generate api --from-spec specs/orders.yaml --portal developer-portal --output-file orders.yaml
+        `, meta.CLIName)))
+)
+
+func newAPICmd() *cobra.Command {
+	var (
+		fromSpec   string
+		opts       scaffold.APIOptions
+		outputFile string
+	)
+	rv := &cobra.Command{
+		Use:     "api",
+		Short:   apiShort,
+		Long:    apiLong,
+		Example: apiExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			c.SilenceUsage = true
+			spec, err := os.ReadFile(fromSpec)
+			if err != nil {
+				return fmt.Errorf("failed to read spec: %w", err)
+			}
+			opts.SpecPath, err = specPath(fromSpec, outputFile)
+			if err != nil {
+				return err
+			}
+			data, err := scaffold.API(spec, opts)
+			if err != nil {
+				return fmt.Errorf("failed to generate API from %s: %w", fromSpec, err)
+			}
+			if outputFile == "" {
+				_, err = c.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+				return fmt.Errorf("failed to write configuration to %s: %w", outputFile, err)
+			}
+			return nil
+		},
+	}
+	rv.Flags().StringVar(&fromSpec, "from-spec", "", "OpenAPI or Swagger spec file to generate the API from")
+	rv.Flags().StringVar(&opts.Ref, "ref", "", "Ref of the API (default derived from info.title)")
+	rv.Flags().StringVar(&opts.Portal, "portal", "", "Ref of a portal to publish the API to")
+	rv.Flags().StringVar(&outputFile, "output-file", "", "File to write the configuration to instead of stdout")
+	_ = rv.MarkFlagRequired("from-spec")
+	return rv
+}
+
+// specPath returns the path of the spec relative to the directory the configuration
+// is written to, which is the working directory when it is printed, as !file resolves
+// paths relative to the file containing the tag
+func specPath(spec, outputFile string) (string, error) {
+	dir := "."
+	if outputFile != "" {
+		dir = filepath.Dir(outputFile)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	absSpec, err := filepath.Abs(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", spec, err)
+	}
+	rel, err := filepath.Rel(absDir, absSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate %s from %s: %w", spec, dir, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,58 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Generate
+)
+
+var (
+	generateUse = Verb.String()
+
+	generateShort = i18n.T("root.verbs.generate.generateShort", "Generate declarative configuration")
+
+	generateLong = normalizers.LongDesc(i18n.T("root.verbs.generate.generateLong",
+		`Use generate to scaffold declarative configuration from existing files.
+
+Generated configuration is written to stdout or a file, ready to review and apply.
+No requests are made to Konnect.`))
+
+	generateExamples = normalizers.Examples(i18n.T("root.verbs.generate.generateExamples",
+		fmt.Sprintf(`
+        # Generate an API and its version from an OpenAPI spec
+        %[1]s generate api --from-spec specs/orders.yaml
+
+        # Also publish the API to a portal declared with the ref developer-portal
+        %[1]s generate api --from-spec specs/orders.yaml --portal developer-portal --output-file orders.yaml
+        `, meta.CLIName)))
+)
+
+func NewGenerateCmd() (*cobra.Command, error) {
+	generateCommand := &cobra.Command{
+		Use:     generateUse,
+		Short:   generateShort,
+		Long:    generateLong,
+		Example: generateExamples,
+		Aliases: []string{"gen"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	generateCommand.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+	}
+
+	generateCommand.AddCommand(newAPICmd())
+
+	return generateCommand, nil
+}
@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerateCmd(t *testing.T) {
+	cmd, err := NewGenerateCmd()
+	require.NoError(t, err)
+
+	assert.Equal(t, "generate", cmd.Use)
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "api", subcommands[0].Name())
+	for _, name := range []string{"from-spec", "ref", "portal", "output-file"} {
+		assert.NotNil(t, subcommands[0].Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
+
+func TestSpecPath(t *testing.T) {
+	path, err := specPath("specs/orders.yaml", "")
+	require.NoError(t, err)
+	assert.Equal(t, "specs/orders.yaml", path)
+
+	path, err = specPath("specs/orders.yaml", "config/apis.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "../specs/orders.yaml", path)
+
+	path, err = specPath("config/specs/orders.yaml", "config/apis.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "specs/orders.yaml", path)
+}
+
+func TestAPICmd_OutputFile(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "orders.yaml")
+	require.NoError(t, os.WriteFile(spec, []byte("openapi: 3.1.0\ninfo:\n  title: Orders\n  version: 1.0.0\n"), 0o600))
+	output := filepath.Join(dir, "config", "orders.yaml")
+	require.NoError(t, os.Mkdir(filepath.Dir(output), 0o755))
+
+	cmd := newAPICmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from-spec", spec, "--output-file", output})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "spec: !file ../orders.yaml")
+}
@@ -0,0 +1,54 @@
+package get
+
+import (
+	"context"
+
+	"github.com/kong/kongctl/internal/cmd/root/products"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/portal"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/spf13/cobra"
+)
+
+// NewDirectDeveloperCmd creates the portal developers command at the root level (Konnect-first)
+func NewDirectDeveloperCmd() (*cobra.Command, error) {
+	developerCmd, err := portal.NewDeveloperCmd(Verb, nil, portalChildPreRunE)
+	if err != nil {
+		return nil, err
+	}
+
+	developerCmd.Example = `  # List the developers of a portal
+  kongctl get developers --portal-name my-portal
+  # Get a developer by email
+  kongctl get developers --portal-id <portal-id> dev@example.com`
+
+	return developerCmd, nil
+}
+
+// NewDirectApplicationCmd creates the portal applications command at the root level (Konnect-first)
+func NewDirectApplicationCmd() (*cobra.Command, error) {
+	applicationCmd, err := portal.NewApplicationCmd(Verb, nil, portalChildPreRunE)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationCmd.Example = `  # List the applications of a portal
+  kongctl get applications --portal-name my-portal
+  # Get an application by name
+  kongctl get applications --portal-id <portal-id> checkout-app`
+
+	return applicationCmd, nil
+}
+
+// portalChildPreRunE sets up the Konnect context for the portal resources listed at the root level
+func portalChildPreRunE(c *cobra.Command, _ []string) error {
+	ctx := c.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, products.Product, konnect.Product)
+	ctx = context.WithValue(ctx, helpers.SDKAPIFactoryKey, helpers.SDKAPIFactory(common.KonnectSDKFactory))
+	c.SetContext(ctx)
+	return nil
+}
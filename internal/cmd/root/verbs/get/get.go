@@ -6,6 +6,7 @@ import (
 
 	cmdpkg "github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/output/jq"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	profileCmd "github.com/kong/kongctl/internal/cmd/root/profile"
@@ -40,6 +41,9 @@ Output can be formatted in multiple ways to aid in further processing.`))
 		%[1]s get apis
 		# Retrieve Konnect auth strategies
 		%[1]s get auth-strategies
+		# Retrieve the developers and applications of a Konnect portal
+		%[1]s get developers --portal-name my-portal
+		%[1]s get applications --portal-name my-portal
 		# Retrieve Konnect control planes (Konnect-first)
 		%[1]s get gateway control-planes
 		# Retrieve Konnect control planes (explicit)
@@ -87,6 +91,7 @@ Setting this value overrides tokens obtained from the login command.
 			common.RequestPageSizeConfigPath))
 
 	jq.AddFlags(cmd.PersistentFlags())
+	tableview.AddFlags(cmd.PersistentFlags())
 
 	cmd.RunE = func(c *cobra.Command, args []string) error {
 		helper := cmdpkg.BuildHelper(c, args)
@@ -158,6 +163,31 @@ Setting this value overrides tokens obtained from the login command.
 	}
 	cmd.AddCommand(regionsCmd)
 
+	analyticsCmd, err := NewDirectAnalyticsCmd()
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(analyticsCmd)
+
+	notificationsCmd, err := NewDirectNotificationsCmd()
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(notificationsCmd)
+
+	// Add portal developers and applications directly for Konnect-first pattern
+	developerCmd, err := NewDirectDeveloperCmd()
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(developerCmd)
+
+	applicationCmd, err := NewDirectApplicationCmd()
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(applicationCmd)
+
 	eventGatewayControlPlaneCmd, err := NewDirectEventGatewayCmd()
 	if err != nil {
 		return nil, err
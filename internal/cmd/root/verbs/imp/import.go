@@ -0,0 +1,74 @@
+// Package imp implements the import verb; import is a reserved word in Go.
+package imp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Import
+)
+
+var (
+	importUse = Verb.String()
+
+	importShort = i18n.T("root.verbs.import.importShort",
+		"Import existing Konnect resources into declarative management")
+
+	importLong = normalizers.LongDesc(i18n.T("root.verbs.import.importLong",
+		`Bring a Konnect resource created outside kongctl under management of the
+declarative configuration without recreating it.
+
+The resource is matched by name, labeled with the kongctl namespace and recorded
+under its configuration ref. Once the resource is added to the configuration, apply
+and sync update only the fields that differ. Portals, APIs and API publications
+can be imported.`))
+
+	importExamples = normalizers.Examples(i18n.T("root.verbs.import.importExamples",
+		fmt.Sprintf(`  %[1]s import --resource portal --name "My Portal" --ref my-portal
+  %[1]s import --resource api --name orders --ref orders --namespace team-alpha
+  %[1]s import --resource api_publication --api orders --portal "My Portal" --ref orders-dev
+
+Use "%[1]s help import" for detailed documentation`, meta.CLIName)))
+)
+
+func NewImportCmd() (*cobra.Command, error) {
+	// Create the konnect subcommand first to get its implementation
+	konnectCmd, err := konnect.NewKonnectCmd(Verb)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &cobra.Command{
+		Use:     importUse,
+		Short:   importShort,
+		Long:    importLong,
+		Example: importExamples,
+		// Use the konnect command's RunE directly for Konnect-first pattern
+		RunE: konnectCmd.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			// Also run the konnect command's PersistentPreRunE to set up SDKAPIFactory
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
+	}
+
+	// Copy flags from konnect command to parent
+	cmd.Flags().AddFlagSet(konnectCmd.Flags())
+
+	// Also add konnect as a subcommand for explicit usage
+	cmd.AddCommand(konnectCmd)
+
+	return cmd, nil
+}
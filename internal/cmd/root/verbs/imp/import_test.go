@@ -0,0 +1,37 @@
+package imp
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImportCmd(t *testing.T) {
+	cmd, err := NewImportCmd()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, "import", cmd.Use)
+	assert.Contains(t, cmd.Example, meta.CLIName)
+
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "konnect", subcommands[0].Name())
+}
+
+func TestImportCmdVerb(t *testing.T) {
+	assert.Equal(t, verbs.Import, Verb)
+	assert.Equal(t, "import", Verb.String())
+}
+
+func TestImportCmdFlags(t *testing.T) {
+	cmd, err := NewImportCmd()
+	require.NoError(t, err)
+
+	for _, name := range []string{"resource", "name", "ref", "namespace", "api", "portal", "output", "pat"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
@@ -0,0 +1,107 @@
+package initialize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/scaffold"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciShort = i18n.T("root.verbs.init.ciShort", "Scaffold configuration and CI pipelines")
+
+	ciLong = normalizers.LongDesc(i18n.T("root.verbs.init.ciLong",
+		`Scaffold a repository for declarative configuration: a configuration directory with
+an example portal and API, a .gitignore for plan files, and pipelines that validate and
+plan the configuration on pull requests and apply it when they are merged.
+
+Pipelines are generated for GitHub Actions and GitLab CI, selected with --provider.
+Each --environment is a kongctl profile with its Konnect region, given as
+profile=region, and gets its own plan and apply jobs. The plan jobs use
+plan --detailed-exitcode to show the changes of a plan, and the apply jobs only apply
+plans with changes. The access token of each profile is read from a secret named
+KONNECT_PAT_<PROFILE>.
+
+Existing files are kept unless --force is given; missing entries are added to an
+existing .gitignore.`))
+
+	ciExamples = normalizers.Examples(i18n.T("root.verbs.init.ciExamples",
+		fmt.Sprintf(`
+        # Scaffold the current directory for the default profile in the us region
+        %[1]s init ci
+
+        # GitHub Actions only, planning and applying two organizations
+        %[1]s init ci --provider github --environment prod-us=us --environment prod-eu=eu
+        `, meta.CLIName)))
+)
+
+func newCICmd() *cobra.Command {
+	var (
+		dir          string
+		providers    []string
+		environments []string
+		opts         scaffold.CIOptions
+		force        bool
+	)
+	rv := &cobra.Command{
+		Use:     "ci",
+		Short:   ciShort,
+		Long:    ciLong,
+		Example: ciExamples,
+		Args:    cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			c.SilenceUsage = true
+			opts.Providers = providers
+			opts.Environments = opts.Environments[:0]
+			for _, value := range environments {
+				env, err := scaffold.ParseEnvironment(value)
+				if err != nil {
+					return err
+				}
+				opts.Environments = append(opts.Environments, env)
+			}
+
+			files, err := scaffold.CI(opts)
+			if err != nil {
+				return err
+			}
+			statuses, err := scaffold.Write(dir, files, force)
+			if err != nil {
+				return err
+			}
+
+			out := c.OutOrStdout()
+			skipped := false
+			for i, file := range files {
+				fmt.Fprintf(out, "%-8s %s\n", statuses[i], file.Path)
+				skipped = skipped || statuses[i] == scaffold.WriteSkipped
+			}
+			if skipped {
+				fmt.Fprintln(out, "\nSkipped files already exist; pass --force to overwrite them.")
+			}
+			secrets := make([]string, 0, len(opts.Environments))
+			for _, env := range opts.Environments {
+				secrets = append(secrets, env.Secret())
+			}
+			fmt.Fprintf(out, "\nStore a Konnect access token for each profile in the CI secret %s.\n",
+				strings.Join(secrets, ", "))
+			return nil
+		},
+	}
+	rv.Flags().StringVar(&dir, "dir", ".", "Root directory of the repository to scaffold")
+	rv.Flags().StringSliceVar(&providers, "provider", []string{scaffold.ProviderGitHub, scaffold.ProviderGitLab},
+		fmt.Sprintf("CI services to generate pipelines for (%s)", strings.Join(scaffold.Providers, ", ")))
+	rv.Flags().StringArrayVar(&environments, "environment", []string{"default=us"},
+		"Profile planned and applied by the pipelines, as profile=region (repeatable)")
+	rv.Flags().StringVar(&opts.ConfigDir, "config-dir", "konnect",
+		"Directory of the configuration, relative to the repository root")
+	rv.Flags().StringVar(&opts.Branch, "branch", "main", "Branch whose merges are applied")
+	rv.Flags().StringVar(&opts.KongctlVersion, "kongctl-version", "latest",
+		"kongctl release installed by the pipelines, e.g. 0.3.0")
+	rv.Flags().BoolVar(&force, "force", false, "Overwrite existing files")
+	return rv
+}
@@ -0,0 +1,53 @@
+package initialize
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Init
+)
+
+var (
+	initUse = Verb.String()
+
+	initShort = i18n.T("root.verbs.init.initShort", "Scaffold a repository managed by kongctl")
+
+	initLong = normalizers.LongDesc(i18n.T("root.verbs.init.initLong",
+		`Use init to scaffold the files of a repository holding declarative configuration.
+
+Files are written to the working directory or --dir. No requests are made to Konnect.`))
+
+	initExamples = normalizers.Examples(i18n.T("root.verbs.init.initExamples",
+		fmt.Sprintf(`
+        # Scaffold a repository with GitHub Actions and GitLab CI pipelines
+        %[1]s init ci
+        `, meta.CLIName)))
+)
+
+func NewInitCmd() (*cobra.Command, error) {
+	initCommand := &cobra.Command{
+		Use:     initUse,
+		Short:   initShort,
+		Long:    initLong,
+		Example: initExamples,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	initCommand.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+	}
+
+	initCommand.AddCommand(newCICmd())
+
+	return initCommand, nil
+}
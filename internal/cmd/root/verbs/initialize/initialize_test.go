@@ -0,0 +1,48 @@
+package initialize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInitCmd(t *testing.T) {
+	cmd, err := NewInitCmd()
+	require.NoError(t, err)
+
+	assert.Equal(t, "init", cmd.Use)
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "ci", subcommands[0].Name())
+	for _, name := range []string{"dir", "provider", "environment", "config-dir", "branch", "kongctl-version", "force"} {
+		assert.NotNil(t, subcommands[0].Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
+
+func TestCICmd(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	cmd := newCICmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dir", dir, "--provider", "gitlab", "--environment", "prod-eu=eu"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "created  .gitlab-ci.yml")
+	assert.Contains(t, out.String(), "CI secret KONNECT_PAT_PROD_EU")
+	assert.FileExists(t, filepath.Join(dir, "konnect", "apis.yaml"))
+	_, err := os.Stat(filepath.Join(dir, ".github"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// Existing files are kept
+	out.Reset()
+	cmd = newCICmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dir", dir, "--provider", "gitlab", "--environment", "prod-eu=eu"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "skipped  .gitlab-ci.yml")
+	assert.Contains(t, out.String(), "pass --force to overwrite them")
+}
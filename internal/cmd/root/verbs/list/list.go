@@ -6,6 +6,7 @@ import (
 
 	cmdpkg "github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/output/jq"
+	"github.com/kong/kongctl/internal/cmd/output/tableview"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
@@ -86,6 +87,7 @@ Setting this value overrides tokens obtained from the login command.
 			common.RequestPageSizeConfigPath))
 
 	jq.AddFlags(cmd.PersistentFlags())
+	tableview.AddFlags(cmd.PersistentFlags())
 
 	c, e := konnect.NewKonnectCmd(Verb)
 	if e != nil {
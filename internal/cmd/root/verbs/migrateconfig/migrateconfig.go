@@ -0,0 +1,232 @@
+package migrateconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/migrate"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.MigrateConfig
+)
+
+var (
+	migrateConfigUse = Verb.String()
+
+	migrateConfigShort = i18n.T("root.verbs.migrateconfig.migrateConfigShort",
+		"Upgrade declarative configuration files to the current format version")
+
+	migrateConfigLong = normalizers.LongDesc(i18n.T("root.verbs.migrateconfig.migrateConfigLong",
+		fmt.Sprintf(`Rewrite declarative configuration files written for an earlier version of the format
+to the current version, %d.
+
+Files declare their format version with the top-level _version key. Files without it
+are upgraded from version 1, the format before versions were declared: renamed keys
+are renamed, moved resources are moved, and _version is set. The changes of each file
+are printed as a diff. Comments, tags and key order are kept.
+
+With --dry-run the diff is printed and no file is written. No requests are made to Konnect.`,
+			migrate.CurrentVersion)))
+
+	migrateConfigExamples = normalizers.Examples(i18n.T("root.verbs.migrateconfig.migrateConfigExamples",
+		fmt.Sprintf(`
+	# Show the changes to the files of a directory without writing them
+	%[1]s migrate-config -f ./config -R --dry-run
+
+	# Upgrade a file in place
+	%[1]s migrate-config -f konnect.yaml
+
+	# Upgrade configuration read from stdin, written to stdout
+	cat konnect.yaml | %[1]s migrate-config -f -
+	`, meta.CLIName)))
+)
+
+type migrateConfigOptions struct {
+	filenames []string
+	recursive bool
+	dryRun    bool
+}
+
+// NewMigrateConfigCmd creates the migrate-config command
+func NewMigrateConfigCmd() (*cobra.Command, error) {
+	opts := &migrateConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:     migrateConfigUse,
+		Short:   migrateConfigShort,
+		Long:    migrateConfigLong,
+		Example: migrateConfigExamples,
+		Args:    cobra.NoArgs,
+		PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			return runMigrateConfig(cmd, *opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.filenames, "filename", "f", []string{},
+		"Filename or directory of the files to upgrade, - for stdin (can specify multiple)")
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "R", false,
+		"Process the directory used in -f, --filename recursively")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false,
+		"Print the changes without writing the files")
+
+	return cmd, nil
+}
+
+func runMigrateConfig(cmd *cobra.Command, opts migrateConfigOptions) error {
+	sources, err := loader.ParseSources(opts.filenames)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, source := range sources {
+		switch source.Type {
+		case loader.SourceTypeSTDIN:
+			return migrateStdin(cmd)
+		case loader.SourceTypeDirectory:
+			dirFiles, err := configFiles(source.Path, opts.recursive)
+			if err != nil {
+				return err
+			}
+			files = append(files, dirFiles...)
+		case loader.SourceTypeFile:
+			files = append(files, source.Path)
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	migrated := 0
+	for _, path := range files {
+		changed, err := migrateFile(out, path, opts.dryRun)
+		if err != nil {
+			return err
+		}
+		if changed {
+			migrated++
+		}
+	}
+
+	switch {
+	case migrated == 0:
+		fmt.Fprintf(cmd.ErrOrStderr(), "All %d file(s) are at %s %d\n",
+			len(files), migrate.VersionKey, migrate.CurrentVersion)
+	case opts.dryRun:
+		fmt.Fprintf(cmd.ErrOrStderr(), "%d of %d file(s) would be upgraded to %s %d (dry run, no files written)\n",
+			migrated, len(files), migrate.VersionKey, migrate.CurrentVersion)
+	default:
+		fmt.Fprintf(cmd.ErrOrStderr(), "Upgraded %d of %d file(s) to %s %d\n",
+			migrated, len(files), migrate.VersionKey, migrate.CurrentVersion)
+	}
+	return nil
+}
+
+// migrateFile upgrades a file, printing the diff of its changes, and reports whether
+// it changed
+func migrateFile(out io.Writer, path string, dryRun bool) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	result, err := migrate.Migrate(content)
+	if err != nil {
+		return false, fmt.Errorf("failed to upgrade %s: %w", path, err)
+	}
+	if !result.Changed() {
+		return false, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(content),
+		B:        splitLines(result.Content),
+		FromFile: fmt.Sprintf("%s (%s %d)", path, migrate.VersionKey, result.From),
+		ToFile:   fmt.Sprintf("%s (%s %d)", path, migrate.VersionKey, migrate.CurrentVersion),
+		Context:  3,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to compute the changes to %s: %w", path, err)
+	}
+	fmt.Fprint(out, diff)
+	for _, change := range result.Changes {
+		fmt.Fprintf(out, "# %s\n", change)
+	}
+
+	if dryRun {
+		return true, nil
+	}
+	if err := os.WriteFile(path, result.Content, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// splitLines splits content into lines keeping their line endings, which the diff expects
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// migrateStdin upgrades the configuration read from stdin and writes it to stdout
+func migrateStdin(cmd *cobra.Command) error {
+	content, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("failed to read configuration from stdin: %w", err)
+	}
+	result, err := migrate.Migrate(content)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade stdin: %w", err)
+	}
+	_, err = cmd.OutOrStdout().Write(result.Content)
+	if err == nil && result.Changed() {
+		fmt.Fprintln(cmd.ErrOrStderr(), strings.Join(result.Changes, "\n"))
+	}
+	return err
+}
+
+// configFiles lists the YAML and JSON files of a directory, sorted
+func configFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if loader.ValidateConfigFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,85 @@
+package migrateconfig
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyConfig = `organization_teams:
+  - ref: platform
+    name: Platform
+`
+
+const migratedConfig = `_version: 2
+organization:
+  teams:
+    - ref: platform
+      name: Platform
+`
+
+func runCmd(t *testing.T, stdin string, args ...string) (string, string) {
+	t.Helper()
+	cmd, err := NewMigrateConfigCmd()
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	return stdout.String(), stderr.String()
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestMigrateConfig_Directory(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "teams.yaml")
+	current := filepath.Join(dir, "portals.yaml")
+	nested := filepath.Join(dir, "nested", "teams.yaml")
+	writeConfig(t, legacy, legacyConfig)
+	writeConfig(t, current, "_version: 2\nportals: []\n")
+	writeConfig(t, nested, legacyConfig)
+
+	stdout, stderr := runCmd(t, "", "-f", dir, "--dry-run")
+	assert.Contains(t, stdout, "--- "+legacy+" (_version 1)")
+	assert.Contains(t, stdout, "-organization_teams:\n")
+	assert.Contains(t, stdout, "+organization:\n+  teams:\n")
+	assert.Contains(t, stdout, "# moved organization_teams to organization.teams")
+	assert.NotContains(t, stdout, current)
+	assert.NotContains(t, stdout, nested, "subdirectories need --recursive")
+	assert.Contains(t, stderr, "1 of 2 file(s) would be upgraded to _version 2")
+
+	content, err := os.ReadFile(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, legacyConfig, string(content), "dry run must not write files")
+
+	_, stderr = runCmd(t, "", "-f", dir, "-R")
+	assert.Contains(t, stderr, "Upgraded 2 of 3 file(s) to _version 2")
+	for _, path := range []string{legacy, nested} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, migratedConfig, string(content))
+	}
+
+	stdout, stderr = runCmd(t, "", "-f", dir, "-R")
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "All 3 file(s) are at _version 2")
+}
+
+func TestMigrateConfig_Stdin(t *testing.T) {
+	stdout, stderr := runCmd(t, legacyConfig, "-f", "-")
+	assert.Equal(t, migratedConfig, stdout)
+	assert.Contains(t, stderr, "moved organization_teams to organization.teams")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/declarative"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/meta"
 	"github.com/kong/kongctl/internal/util/i18n"
@@ -29,6 +30,7 @@ var (
 		fmt.Sprintf(`  %[1]s plan -f api.yaml
   %[1]s plan -f ./configs/ --recursive
   %[1]s plan -f config.yaml -o plan.json
+  %[1]s plan show plan.json --details
 
 Use "%[1]s help plan" for detailed documentation`, meta.CLIName)))
 )
@@ -62,6 +64,7 @@ func NewPlanCmd() (*cobra.Command, error) {
 
 	// Also add konnect as a subcommand for explicit usage
 	cmd.AddCommand(konnectCmd)
+	cmd.AddCommand(declarative.NewPlanShowCmd())
 
 	return cmd, nil
 }
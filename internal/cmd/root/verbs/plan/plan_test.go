@@ -26,12 +26,14 @@ func TestNewPlanCmd(t *testing.T) {
 	assert.Contains(t, cmd.Long, "execution plan", "Long description should mention execution plan")
 	assert.Contains(t, cmd.Example, meta.CLIName, "Examples should include CLI name")
 
-	// Test that konnect subcommand is added
+	// Test that the konnect and show subcommands are added
 	subcommands := cmd.Commands()
-	if len(subcommands) != 1 {
-		t.Fatalf("Should have exactly one subcommand, got %d", len(subcommands))
+	if len(subcommands) != 2 {
+		t.Fatalf("Should have exactly two subcommands, got %d", len(subcommands))
 	}
 	assert.Equal(t, "konnect", subcommands[0].Name(), "Subcommand should be 'konnect'")
+	assert.Equal(t, "show", subcommands[1].Name(), "Subcommand should be 'show'")
+	assert.NotNil(t, subcommands[1].Flags().Lookup("details"), "show should have a --details flag")
 }
 
 func TestPlanCmdVerb(t *testing.T) {
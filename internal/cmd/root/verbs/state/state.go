@@ -0,0 +1,85 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.State
+)
+
+var (
+	stateUse = Verb.String()
+
+	stateShort = i18n.T("root.verbs.state.stateShort",
+		"Inspect the resources managed by kongctl")
+
+	stateLong = normalizers.LongDesc(i18n.T("root.verbs.state.stateLong",
+		`Inspect the Konnect resources kongctl manages, as identified by its management labels.`))
+
+	listShort = i18n.T("root.verbs.state.listShort",
+		"List the resources managed by kongctl")
+
+	listLong = normalizers.LongDesc(i18n.T("root.verbs.state.listLong",
+		`List every Konnect resource carrying kongctl's namespace label with its type, ref,
+Konnect ID, namespace, and the time and configuration hash it was last applied with.
+
+Labeled resources that the current profile has no record of applying are reported as
+orphaned, to find resources left behind by removed configuration.`))
+
+	listExamples = normalizers.Examples(i18n.T("root.verbs.state.listExamples",
+		fmt.Sprintf(`  %[1]s state list
+  %[1]s state list --namespace team-alpha -o json
+  %[1]s state list --orphaned --exit-code
+
+Use "%[1]s help state" for detailed documentation`, meta.CLIName)))
+)
+
+func NewStateCmd() (*cobra.Command, error) {
+	// Create the konnect subcommand first to get its implementation
+	konnectCmd, err := konnect.NewKonnectCmd(Verb)
+	if err != nil {
+		return nil, err
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   listShort,
+		Long:    listLong,
+		Example: listExamples,
+		Args:    cobra.NoArgs,
+		// Use the konnect command's RunE directly for Konnect-first pattern
+		RunE: konnectCmd.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			// Also run the konnect command's PersistentPreRunE to set up SDKAPIFactory
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
+	}
+
+	// Copy flags from konnect command to the list command
+	listCmd.Flags().AddFlagSet(konnectCmd.Flags())
+
+	// Also add konnect as a subcommand for explicit usage
+	listCmd.AddCommand(konnectCmd)
+
+	cmd := &cobra.Command{
+		Use:   stateUse,
+		Short: stateShort,
+		Long:  stateLong,
+	}
+	cmd.AddCommand(listCmd)
+
+	return cmd, nil
+}
@@ -0,0 +1,43 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStateCmd(t *testing.T) {
+	cmd, err := NewStateCmd()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, "state", cmd.Use)
+
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	list := subcommands[0]
+	assert.Equal(t, "list", list.Name())
+	assert.Contains(t, list.Example, meta.CLIName)
+
+	require.Len(t, list.Commands(), 1)
+	assert.Equal(t, "konnect", list.Commands()[0].Name())
+}
+
+func TestStateCmdVerb(t *testing.T) {
+	assert.Equal(t, verbs.State, Verb)
+	assert.Equal(t, "state", Verb.String())
+}
+
+func TestStateListCmdFlags(t *testing.T) {
+	cmd, err := NewStateCmd()
+	require.NoError(t, err)
+	list, _, err := cmd.Find([]string{"list"})
+	require.NoError(t, err)
+
+	for _, name := range []string{"namespace", "orphaned", "output", "exit-code", "pat", "base-url"} {
+		assert.NotNil(t, list.Flags().Lookup(name), "expected flag --%s", name)
+	}
+}
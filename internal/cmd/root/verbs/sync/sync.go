@@ -7,6 +7,7 @@ import (
 	"github.com/kong/kongctl/internal/cmd/root/products"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
 	"github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect/portal"
 	"github.com/kong/kongctl/internal/cmd/root/verbs"
 	"github.com/kong/kongctl/internal/konnect/helpers"
 	"github.com/kong/kongctl/internal/meta"
@@ -32,6 +33,7 @@ var (
 		fmt.Sprintf(`  %[1]s sync -f api.yaml
   %[1]s sync -f ./configs/ --dry-run
   %[1]s sync --plan plan.json --auto-approve
+  %[1]s sync portal content --portal-name my-portal --dir ./site
 
 Use "%[1]s help sync" for detailed documentation`, meta.CLIName)))
 )
@@ -74,5 +76,34 @@ func NewSyncCmd() (*cobra.Command, error) {
 	// Also add konnect as a subcommand for explicit usage
 	cmd.AddCommand(konnectCmd)
 
+	// Add portal commands directly for Konnect-first pattern
+	portalCmd, err := portal.NewPortalCmd(Verb, addKonnectFlags, nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd.AddCommand(portalCmd)
+
 	return cmd, nil
 }
+
+// addKonnectFlags adds the Konnect connection flags to the portal commands, which the
+// konnect command binds to configuration before they run
+func addKonnectFlags(_ verbs.VerbValue, c *cobra.Command) {
+	c.Flags().String(common.BaseURLFlagName, "",
+		fmt.Sprintf(`Base URL for Konnect API requests.
+- Config path: [ %s ]
+- Default   : [ %s ]`,
+			common.BaseURLConfigPath, common.BaseURLDefault))
+
+	c.Flags().String(common.RegionFlagName, "",
+		fmt.Sprintf(`Konnect region identifier (for example "eu"). Used to construct the base URL when --%s is not provided.
+- Config path: [ %s ]`,
+			common.BaseURLFlagName, common.RegionConfigPath),
+	)
+
+	c.Flags().String(common.PATFlagName, "",
+		fmt.Sprintf(`Konnect Personal Access Token (PAT) used to authenticate the CLI.
+Setting this value overrides tokens obtained from the login command.
+- Config path: [ %s ]`,
+			common.PATConfigPath))
+}
@@ -30,12 +30,19 @@ func TestNewSyncCmd(t *testing.T) {
 		"Long description should mention synchronize")
 	assert.Contains(t, cmd.Example, meta.CLIName, "Examples should include CLI name")
 
-	// Test that konnect subcommand is added
+	// Test that konnect and portal subcommands are added
 	subcommands := cmd.Commands()
-	if len(subcommands) != 1 {
-		t.Fatalf("Should have exactly one subcommand, got %d", len(subcommands))
+	if len(subcommands) != 2 {
+		t.Fatalf("Should have exactly two subcommands, got %d", len(subcommands))
 	}
 	assert.Equal(t, "konnect", subcommands[0].Name(), "Subcommand should be 'konnect'")
+	assert.Equal(t, "portal", subcommands[1].Name(), "Subcommand should be 'portal'")
+
+	contentCmd, _, err := cmd.Find([]string{"portal", "content"})
+	require.NoError(t, err)
+	assert.Equal(t, "content", contentCmd.Name())
+	assert.NotNil(t, contentCmd.Flags().Lookup("dir"), "Should have --dir flag")
+	assert.NotNil(t, contentCmd.Flags().Lookup("pat"), "Should have --pat flag")
 }
 
 func TestSyncCmdVerb(t *testing.T) {
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/cmd/root/products/konnect"
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/util/i18n"
+	"github.com/kong/kongctl/internal/util/normalizers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	Verb = verbs.Validate
+)
+
+var (
+	validateUse = Verb.String()
+
+	validateShort = i18n.T("root.verbs.validate.validateShort",
+		"Validate declarative configuration without contacting Konnect")
+
+	validateLong = normalizers.LongDesc(i18n.T("root.verbs.validate.validateLong",
+		`Check declarative configuration files for errors without making any API requests.
+
+Files are checked against the JSON Schema of the configuration format, parsed,
+YAML tags are expanded, references are resolved and resources are validated.
+Every problem is listed with its file, resource ref and field, and schema problems
+with their line and column. The command exits with a non-zero status if any are
+found. No credentials are needed, so it can run on every pull request.`))
+
+	validateExamples = normalizers.Examples(i18n.T("root.verbs.validate.validateExamples",
+		fmt.Sprintf(`  %[1]s validate -f config.yaml
+  %[1]s validate -f ./config -R
+
+Use "%[1]s help validate" for detailed documentation`, meta.CLIName)))
+)
+
+func NewValidateCmd() (*cobra.Command, error) {
+	// Create the konnect subcommand first to get its implementation
+	konnectCmd, err := konnect.NewKonnectCmd(Verb)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &cobra.Command{
+		Use:     validateUse,
+		Short:   validateShort,
+		Long:    validateLong,
+		Example: validateExamples,
+		// Use the konnect command's RunE directly for Konnect-first pattern
+		RunE: konnectCmd.RunE,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SetContext(context.WithValue(cmd.Context(), verbs.Verb, Verb))
+			if konnectCmd.PersistentPreRunE != nil {
+				return konnectCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
+	}
+
+	// Copy flags from konnect command to parent
+	cmd.Flags().AddFlagSet(konnectCmd.Flags())
+
+	// Also add konnect as a subcommand for explicit usage
+	cmd.AddCommand(konnectCmd)
+
+	return cmd, nil
+}
@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/cmd/root/verbs"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidateCmd(t *testing.T) {
+	cmd, err := NewValidateCmd()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, "validate", cmd.Use)
+	assert.Contains(t, cmd.Short, "without contacting Konnect")
+	assert.Contains(t, cmd.Example, meta.CLIName)
+
+	subcommands := cmd.Commands()
+	require.Len(t, subcommands, 1)
+	assert.Equal(t, "konnect", subcommands[0].Name())
+}
+
+func TestValidateCmdVerb(t *testing.T) {
+	assert.Equal(t, verbs.Validate, Verb)
+	assert.Equal(t, "validate", Verb.String())
+}
+
+func TestValidateCmdFlags(t *testing.T) {
+	cmd, err := NewValidateCmd()
+	require.NoError(t, err)
+
+	for _, name := range []string{"filename", "recursive", "base-dir", "default-label"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "expected flag --%s", name)
+	}
+	// Validation never contacts Konnect, so connection flags are not offered
+	for _, name := range []string{"pat", "base-url", "region", "max-retries"} {
+		assert.Nil(t, cmd.Flags().Lookup(name), "unexpected flag --%s", name)
+	}
+}
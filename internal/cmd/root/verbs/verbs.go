@@ -1,26 +1,41 @@
 package verbs
 
 const (
-	Add    = VerbValue("add")
-	Apply  = VerbValue("apply")
-	Adopt  = VerbValue("adopt")
-	Kai    = VerbValue("kai")
-	API    = VerbValue("api")
-	Get    = VerbValue("get")
-	Create = VerbValue("create")
-	Dump   = VerbValue("dump")
-	Update = VerbValue("update")
-	Delete = VerbValue("delete")
-	Help   = VerbValue("help")
-	List   = VerbValue("list")
-	Login  = VerbValue("login")
-	Logout = VerbValue("logout")
-	Plan   = VerbValue("plan")
-	View   = VerbValue("view")
-	Sync   = VerbValue("sync")
-	Diff   = VerbValue("diff")
-	Export = VerbValue("export")
-	Patch  = VerbValue("patch")
+	Add      = VerbValue("add")
+	Apply    = VerbValue("apply")
+	Adopt    = VerbValue("adopt")
+	Kai      = VerbValue("kai")
+	API      = VerbValue("api")
+	Get      = VerbValue("get")
+	Create   = VerbValue("create")
+	Convert  = VerbValue("convert")
+	Dump     = VerbValue("dump")
+	Update   = VerbValue("update")
+	Delete   = VerbValue("delete")
+	Help     = VerbValue("help")
+	List     = VerbValue("list")
+	Login    = VerbValue("login")
+	Logout   = VerbValue("logout")
+	Plan     = VerbValue("plan")
+	View     = VerbValue("view")
+	Sync     = VerbValue("sync")
+	Diff     = VerbValue("diff")
+	Export   = VerbValue("export")
+	Generate = VerbValue("generate")
+	Patch    = VerbValue("patch")
+	Validate = VerbValue("validate")
+	Drift    = VerbValue("drift")
+	Import   = VerbValue("import")
+	Approve  = VerbValue("approve")
+	Revoke   = VerbValue("revoke")
+	State    = VerbValue("state")
+	// APIVersion manages the lifecycle of API versions
+	APIVersion = VerbValue("api-version")
+	Init       = VerbValue("init")
+	// MigrateConfig upgrades declarative configuration files to the current format version
+	MigrateConfig = VerbValue("migrate-config")
+	// Doctor checks the environment before declarative configuration is applied
+	Doctor = VerbValue("doctor")
 )
 
 // Empty type to represent the _type_ Verb. Genesis is to support a key in a Context
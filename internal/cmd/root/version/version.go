@@ -7,6 +7,7 @@ import (
 	"github.com/kong/kongctl/internal/cmd"
 	"github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/update"
 	"github.com/kong/kongctl/internal/util"
 	"github.com/kong/kongctl/internal/util/i18n"
 	"github.com/kong/kongctl/internal/util/normalizers"
@@ -17,8 +18,15 @@ import (
 const (
 	ShowFullFlag       = "full"
 	ShowFullConfigPath = "version." + ShowFullFlag
+	CheckFlag          = "check"
+	CheckConfigPath    = "version." + CheckFlag
 )
 
+// newReleaseClient builds the client the newest release is looked up with
+var newReleaseClient = func() *update.Client {
+	return &update.Client{}
+}
+
 var (
 	versionUse   = "version"
 	versionShort = i18n.T("root.version.versionShort",
@@ -31,6 +39,8 @@ var (
 		%[1]s version
 		# Print the full version info with commit and build date
 		%[1]s version --full
+		# Check whether a newer release is available
+		%[1]s version --check
 		`, meta.CLIName)))
 )
 
@@ -45,6 +55,7 @@ func NewVersionCmd() *cobra.Command {
 			bindFlags(c, args)
 		},
 		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
 			helper := cmd.BuildHelper(c, args)
 
 			err := validate(helper)
@@ -62,6 +73,9 @@ func NewVersionCmd() *cobra.Command {
 	rv.Flags().Bool(ShowFullFlag, false,
 		i18n.T(fmt.Sprintf("root.%s", ShowFullConfigPath),
 			fmt.Sprintf("True to show the full version information.\n (config path = '%s')", ShowFullConfigPath)))
+	rv.Flags().Bool(CheckFlag, false,
+		i18n.T(fmt.Sprintf("root.%s", CheckConfigPath),
+			fmt.Sprintf("True to check GitHub for a newer release.\n (config path = '%s')", CheckConfigPath)))
 
 	return rv
 }
@@ -73,6 +87,9 @@ func bindFlags(c *cobra.Command, args []string) {
 	f := c.Flags().Lookup(ShowFullFlag)
 	err := cfg.BindFlag(ShowFullConfigPath, f)
 	util.CheckError(err)
+	f = c.Flags().Lookup(CheckFlag)
+	err = cfg.BindFlag(CheckConfigPath, f)
+	util.CheckError(err)
 }
 
 // Validate ensures the configured command is valid
@@ -103,13 +120,24 @@ func run(helper cmd.Helper) error {
 		result["date"] = bi.Date
 	}
 
+	check := cfg.GetBool(CheckConfigPath)
+	if check {
+		release, err := newReleaseClient().LatestRelease(helper.GetContext(), false)
+		if err != nil {
+			return fmt.Errorf("failed to check for a newer version: %w", err)
+		}
+		result["latest_version"] = release.Version()
+		result["update_available"] = update.IsNewer(bi.Version, release.Version())
+		result["release_url"] = release.HTMLURL
+	}
+
 	outType, err := helper.GetOutputFormat()
 	if err != nil {
 		return err
 	}
 
 	if outType == common.TEXT {
-		return printText(result, helper.GetStreams().Out, full)
+		return printText(result, helper.GetStreams().Out, full, check)
 	}
 
 	p, err := cli.Format(outType.String(), helper.GetStreams().Out)
@@ -124,7 +152,7 @@ func run(helper cmd.Helper) error {
 
 // printText is a custom print function for the version command. Not really necessary
 // but it shows how you can override the default printers per command.
-func printText(data map[string]any, out io.Writer, full bool) error {
+func printText(data map[string]any, out io.Writer, full, check bool) error {
 	if ver, ok := data["version"]; ok {
 		_, e := fmt.Fprintf(out, "%s", ver)
 		if e != nil {
@@ -139,5 +167,15 @@ func printText(data map[string]any, out io.Writer, full bool) error {
 	}
 
 	_, err := fmt.Fprintf(out, "\n")
+	if err != nil || !check {
+		return err
+	}
+
+	if available, _ := data["update_available"].(bool); !available {
+		_, err = fmt.Fprintf(out, "%s is up to date\n", meta.CLIName)
+		return err
+	}
+	_, err = fmt.Fprintf(out, "A newer version is available: %s (%s)\nRun '%s update' to upgrade\n",
+		data["latest_version"], data["release_url"], meta.CLIName)
 	return err
 }
@@ -1,14 +1,19 @@
 package version
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/kong/kongctl/internal/build"
 	"github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/iostreams"
+	"github.com/kong/kongctl/internal/update"
 	"github.com/kong/kongctl/test/cmd"
 	testConfig "github.com/kong/kongctl/test/config"
 )
@@ -58,6 +63,50 @@ func Test_VersionCmd(t *testing.T) {
 	}
 }
 
+func Test_VersionCmdCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"tag_name": "v0.4.0", "html_url": "https://github.com/Kong/kongctl/releases/tag/v0.4.0"}]`)
+	}))
+	defer server.Close()
+	orig := newReleaseClient
+	newReleaseClient = func() *update.Client { return &update.Client{APIURL: server.URL} }
+	defer func() { newReleaseClient = orig }()
+
+	for current, expectedOutput := range map[string]string{
+		"0.3.0": "0.3.0\nA newer version is available: 0.4.0 " +
+			"(https://github.com/Kong/kongctl/releases/tag/v0.4.0)\nRun 'kongctl update' to upgrade\n",
+		"0.4.0": "0.4.0\nkongctl is up to date\n",
+	} {
+		all, _, out, _ := iostreams.NewTestIOStreams()
+		helper := cmd.MockHelper{
+			GetOutputFormatMock: func() (common.OutputFormat, error) {
+				return common.TEXT, nil
+			},
+			GetConfigMock: func() (config.Hook, error) {
+				return &testConfig.MockConfigHook{
+					GetBoolMock: func(key string) bool {
+						return key == CheckConfigPath
+					},
+				}, nil
+			},
+			GetStreamsMock: func() *iostreams.IOStreams {
+				return all
+			},
+			GetContextMock: context.Background,
+			GetBuildInfoMock: func() (*build.Info, error) {
+				return &build.Info{Version: current, Commit: "unknown", Date: "unknown"}, nil
+			},
+		}
+
+		if err := run(&helper); err != nil {
+			t.Errorf("Error running context: %v", err)
+		}
+		if output := out.String(); output != expectedOutput {
+			t.Errorf("Unexpected output for %s: %s", current, output)
+		}
+	}
+}
+
 //func Test_VersionCmdJsonOutput(t *testing.T) {
 //	_, _, stdout, _ := iostreams.NewTestIOStreams()
 //
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/kong/kongctl/internal/cmd/common"
 	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/profile"
 	"github.com/kong/kongctl/internal/util/viper"
 	"github.com/spf13/pflag"
 	v "github.com/spf13/viper"
@@ -14,6 +17,10 @@ import (
 
 var defaultConfigFileName = "config.yaml"
 
+// CurrentProfileKey is the top-level key of the configuration file holding the profile
+// used when neither --profile nor KONGCTL_PROFILE is set
+const CurrentProfileKey = "current-profile"
+
 var OuptutFormat = common.DefaultOutputFormat
 
 // Returns the expanded default config path depending on what
@@ -35,6 +42,19 @@ func GetDefaultConfigPath() (string, error) {
 	return os.ExpandEnv(val), nil
 }
 
+// StateDirEnv overrides the directory of the files kongctl records about the changes
+// it applies, such as the last applied configuration and the audit log
+const StateDirEnv = "KONGCTL_STATE_DIR"
+
+// GetStateDir returns the directory of the files kongctl records about applied changes,
+// $KONGCTL_STATE_DIR when set and the default config path otherwise
+func GetStateDir() (string, error) {
+	if dir := os.Getenv(StateDirEnv); dir != "" {
+		return os.ExpandEnv(dir), nil
+	}
+	return GetDefaultConfigPath()
+}
+
 func GetDefaultConfigFilePath() (string, error) {
 	path, err := GetDefaultConfigPath()
 	if err != nil {
@@ -160,6 +180,10 @@ func (p *ProfiledConfig) GetStringSlice(key string) []string {
 	return p.subViper.GetStringSlice(key)
 }
 
+func (p *ProfiledConfig) Get(key string) any {
+	return p.subViper.Get(key)
+}
+
 func (p *ProfiledConfig) BindFlag(configPath string, f *pflag.Flag) error {
 	return p.subViper.BindPFlag(configPath, f)
 }
@@ -176,6 +200,75 @@ func (p *ProfiledConfig) GetPath() string {
 	return p.Path
 }
 
+// ForProfile returns the configuration of another profile in the same configuration
+// file. Flags bound to this profile are not bound to the returned one.
+func (p *ProfiledConfig) ForProfile(profile string) *ProfiledConfig {
+	return BuildProfiledConfig(profile, p.Path, p.Viper)
+}
+
+// Profiles returns the sorted names of the profiles defined in the configuration file
+func (p *ProfiledConfig) Profiles() []string {
+	profiles := make([]string, 0)
+	for name, value := range p.AllSettings() {
+		if _, ok := value.(map[string]any); ok {
+			profiles = append(profiles, name)
+		}
+	}
+	slices.Sort(profiles)
+	return profiles
+}
+
+// CurrentProfile returns the profile saved by SetCurrentProfile, or "" when none is saved
+func (p *ProfiledConfig) CurrentProfile() string {
+	return strings.TrimSpace(p.Viper.GetString(CurrentProfileKey))
+}
+
+// SetCurrentProfile saves the profile used when neither --profile nor KONGCTL_PROFILE
+// is set. The profile must be defined in the configuration file or be the default one.
+func (p *ProfiledConfig) SetCurrentProfile(name string) error {
+	name = strings.TrimSpace(name)
+	if err := p.ForProfile(name).ValidateProfile(); err != nil {
+		return err
+	}
+	p.Viper.Set(CurrentProfileKey, name)
+	return p.Save()
+}
+
+// SetProfileValue saves a value in the section of this profile in the configuration
+// file, creating the section when the profile is not defined yet
+func (p *ProfiledConfig) SetProfileValue(key string, value any) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("configuration key must not be empty")
+	}
+	p.Viper.Set(p.ProfileName+"."+key, value)
+	p.subViper.Set(key, value)
+	return p.Save()
+}
+
+// ValidateProfile returns an error listing the available profiles when the profile of
+// this configuration is not defined. A profile is defined by a section of the
+// configuration file or by KONGCTL_<PROFILE>_ environment variables, and the default
+// profile always exists.
+func (p *ProfiledConfig) ValidateProfile() error {
+	if p.ProfileName == profile.DefaultProfile || p.IsSet(p.ProfileName) {
+		return nil
+	}
+	envPrefix := viper.ProfileEnvPrefix(p.ProfileName) + "_"
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, envPrefix) {
+			return nil
+		}
+	}
+
+	available := p.Profiles()
+	if !slices.Contains(available, profile.DefaultProfile) {
+		available = append([]string{profile.DefaultProfile}, available...)
+	}
+	return fmt.Errorf("profile %q is not defined in %s; available profiles: %s",
+		p.ProfileName, p.Path, strings.Join(available, ", "))
+}
+
 func BuildProfiledConfig(profile string, path string, mainv *v.Viper) *ProfiledConfig {
 	subv := mainv.Sub(profile)
 	if subv == nil {
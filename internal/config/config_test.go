@@ -1,6 +1,8 @@
 package config
 
 import (
+	"path/filepath"
+	"slices"
 	"testing"
 
 	utilviper "github.com/kong/kongctl/internal/util/viper"
@@ -34,3 +36,110 @@ func TestBuildProfiledConfig_EmptyConfigFileWithEnvVar(t *testing.T) {
 		t.Fatalf("expected konnect.pat to be %q, got %q", "token-from-env", got)
 	}
 }
+
+func TestProfiledConfig_ForProfile(t *testing.T) {
+	t.Setenv("KONGCTL_PROD_EU_KONNECT_PAT", "token-eu")
+
+	mainv := utilviper.NewViper("nonexistent.yaml")
+	mainv.Set("prod-us", map[string]any{"konnect": map[string]any{"pat": "token-us"}})
+	mainv.Set("prod-eu", map[string]any{"konnect": map[string]any{"region": "eu"}})
+
+	us := BuildProfiledConfig("prod-us", "nonexistent.yaml", mainv)
+	eu := us.ForProfile("prod-eu")
+
+	if got := eu.GetProfile(); got != "prod-eu" {
+		t.Fatalf("expected profile %q, got %q", "prod-eu", got)
+	}
+	if got := eu.GetString("konnect.pat"); got != "token-eu" {
+		t.Fatalf("expected konnect.pat to be %q, got %q", "token-eu", got)
+	}
+	if got := us.GetString("konnect.pat"); got != "token-us" {
+		t.Fatalf("expected konnect.pat to be %q, got %q", "token-us", got)
+	}
+}
+
+func TestProfiledConfig_Get(t *testing.T) {
+	mainv := utilviper.NewViper("nonexistent.yaml")
+	mainv.Set("prod", map[string]any{"konnect": map[string]any{"declarative": map[string]any{
+		"hooks": map[string]any{"on_success": []any{map[string]any{"command": "notify"}}},
+	}}})
+
+	got := BuildProfiledConfig("prod", "nonexistent.yaml", mainv).Get("konnect.declarative.hooks")
+	hooks, ok := got.(map[string]any)
+	if !ok || hooks["on_success"] == nil {
+		t.Fatalf("expected the hooks of the profile, got %v", got)
+	}
+}
+
+func TestProfiledConfig_ValidateProfile(t *testing.T) {
+	t.Setenv("KONGCTL_SANDBOX_KONNECT_PAT", "token-sandbox")
+
+	mainv := utilviper.NewViper("config.yaml")
+	mainv.Set("us", map[string]any{"konnect": map[string]any{"region": "us"}})
+	mainv.Set("eu", map[string]any{"konnect": map[string]any{"region": "eu"}})
+
+	for _, profile := range []string{"default", "us", "eu", "sandbox"} {
+		if err := BuildProfiledConfig(profile, "config.yaml", mainv).ValidateProfile(); err != nil {
+			t.Fatalf("expected profile %q to be valid, got %v", profile, err)
+		}
+	}
+
+	err := BuildProfiledConfig("ue", "config.yaml", mainv).ValidateProfile()
+	want := `profile "ue" is not defined in config.yaml; available profiles: default, eu, us`
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestProfiledConfig_SetCurrentProfileAndValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	mainv := utilviper.NewViper(path)
+	mainv.Set("default", map[string]any{"output": "text"})
+
+	cfg := BuildProfiledConfig("prod", path, mainv)
+	if err := cfg.SetProfileValue("konnect.region", "eu"); err != nil {
+		t.Fatalf("SetProfileValue() error = %v", err)
+	}
+	if got := cfg.GetString("konnect.region"); got != "eu" {
+		t.Fatalf("expected konnect.region to be %q, got %q", "eu", got)
+	}
+	if err := cfg.SetCurrentProfile("prod"); err != nil {
+		t.Fatalf("SetCurrentProfile() error = %v", err)
+	}
+	if err := cfg.SetCurrentProfile("missing"); err == nil {
+		t.Fatal("expected an error selecting an undefined profile")
+	}
+
+	reloaded := BuildProfiledConfig("default", path, utilviper.NewViper(path))
+	if got := reloaded.CurrentProfile(); got != "prod" {
+		t.Fatalf("expected current profile %q, got %q", "prod", got)
+	}
+	if got := reloaded.ForProfile("prod").GetString("konnect.region"); got != "eu" {
+		t.Fatalf("expected saved konnect.region to be %q, got %q", "eu", got)
+	}
+	if got := reloaded.Profiles(); !slices.Equal(got, []string{"default", "prod"}) {
+		t.Fatalf("expected profiles [default prod], got %v", got)
+	}
+}
+
+func TestGetStateDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+	t.Setenv(StateDirEnv, "")
+	dir, err := GetStateDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join("/tmp/xdg", "kongctl"); dir != want {
+		t.Fatalf("expected state dir %q, got %q", want, dir)
+	}
+
+	t.Setenv(StateDirEnv, "/tmp/kongctl-state")
+	dir, err = GetStateDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/tmp/kongctl-state" {
+		t.Fatalf("expected state dir %q, got %q", "/tmp/kongctl-state", dir)
+	}
+}
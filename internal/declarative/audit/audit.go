@@ -0,0 +1,188 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+)
+
+const (
+	defaultDirPerm  = 0o700
+	defaultFilePerm = 0o600
+
+	logFileName = "audit.jsonl"
+	// backupSuffix names the previous log file kept by rotation
+	backupSuffix = ".1"
+
+	// DefaultMaxBytes is the size past which the log is rotated. One previous file is
+	// kept, so the log uses at most twice this size.
+	DefaultMaxBytes = 10 << 20
+
+	// ActionDelete is the action recorded for deleted resources
+	ActionDelete = "DELETE"
+)
+
+// Entry is a single change recorded in the audit log
+type Entry struct {
+	Timestamp    time.Time `json:"timestamp"              yaml:"timestamp"`
+	Command      string    `json:"command"                yaml:"command"`
+	ResourceType string    `json:"resource_type"          yaml:"resource_type"`
+	ResourceRef  string    `json:"resource_ref,omitempty" yaml:"resource_ref,omitempty"`
+	ResourceName string    `json:"resource_name"          yaml:"resource_name"`
+	ResourceID   string    `json:"resource_id,omitempty"  yaml:"resource_id,omitempty"`
+	Action       string    `json:"action"                 yaml:"action"`
+	// Profile and BaseURL identify the organization the change was applied to
+	Profile string `json:"profile,omitempty"  yaml:"profile,omitempty"`
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+}
+
+// Scope selects the entries recorded for one organization, as reached with a profile
+type Scope struct {
+	Profile string
+	BaseURL string
+}
+
+// Matches reports whether entry was recorded for the organization of the scope.
+// Entries written before the organization was recorded match no scope.
+func (s Scope) Matches(entry Entry) bool {
+	return entry.Profile == s.Profile && entry.BaseURL == s.BaseURL
+}
+
+// Log is an append-only JSON lines file of changes applied by kongctl. The file is
+// rotated once it grows past maxBytes, keeping the previous one.
+type Log struct {
+	path     string
+	maxBytes int64
+	mu       sync.Mutex
+}
+
+// DefaultPath returns the audit log location inside the kongctl state directory, see
+// config.GetStateDir
+func DefaultPath() (string, error) {
+	baseDir, err := config.GetStateDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve state path: %w", err)
+	}
+	return filepath.Join(baseDir, logFileName), nil
+}
+
+// NewLog creates a log backed by the file at path
+func NewLog(path string) *Log {
+	return &Log{path: path, maxBytes: DefaultMaxBytes}
+}
+
+// Path returns the file backing the log
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes entries to the end of the log, creating the file if necessary
+func (l *Log) Append(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), defaultDirPerm); err != nil {
+		return fmt.Errorf("create audit log directory: %w", err)
+	}
+
+	var data []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode audit entry: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if err := l.rotate(int64(len(data))); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, defaultFilePerm)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+// rotate moves the log to its backup, replacing the previous one, when writing
+// pending more bytes would grow it past maxBytes
+func (l *Log) rotate(pending int64) error {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("open audit log: %w", err)
+	}
+	if l.maxBytes <= 0 || info.Size() == 0 || info.Size()+pending <= l.maxBytes {
+		return nil
+	}
+	if err := os.Rename(l.path, l.path+backupSuffix); err != nil {
+		return fmt.Errorf("rotate audit log: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every entry in the log in the order written, starting with those
+// of the file kept by the last rotation. A missing log file yields no entries.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries, err := readEntries(l.path + backupSuffix)
+	if err != nil {
+		return nil, err
+	}
+	current, err := readEntries(l.path)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, current...), nil
+}
+
+func readEntries(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parse audit log %s line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,166 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubHistorySource struct {
+	entries []Entry
+	err     error
+	calls   int
+}
+
+func (s *stubHistorySource) ListDeleted(_ context.Context, resourceType string) ([]Entry, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	var filtered []Entry
+	for _, entry := range s.entries {
+		if entry.ResourceType == resourceType {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+func TestLogAppendAndEntries(t *testing.T) {
+	t.Parallel()
+
+	log := NewLog(filepath.Join(t.TempDir(), "nested", logFileName))
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, log.Append(
+		Entry{Timestamp: now, Command: "sync", ResourceType: "portal", ResourceName: "dev", Action: "CREATE"},
+	))
+	require.NoError(t, log.Append(
+		Entry{Timestamp: now, Command: "sync", ResourceType: "portal", ResourceName: "dev", Action: ActionDelete},
+	))
+
+	entries, err = log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "CREATE", entries[0].Action)
+	require.Equal(t, ActionDelete, entries[1].Action)
+	require.True(t, entries[1].Timestamp.Equal(now))
+}
+
+func TestListDeleted_UsesHistoryEndpoint(t *testing.T) {
+	t.Parallel()
+
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	remote := &stubHistorySource{entries: []Entry{
+		{Timestamp: older, ResourceType: "portal", ResourceID: "p1", ResourceName: "one", Action: ActionDelete},
+		{Timestamp: newer, ResourceType: "portal", ResourceID: "p1", ResourceName: "one", Action: ActionDelete},
+		{Timestamp: older, ResourceType: "portal", ResourceID: "p2", ResourceName: "two", Action: ActionDelete},
+		{Timestamp: newer, ResourceType: "portal", ResourceID: "p3", ResourceName: "three", Action: ActionDelete},
+		{Timestamp: newer, ResourceType: "api", ResourceID: "a1", ResourceName: "api", Action: ActionDelete},
+	}}
+	local := &stubHistorySource{}
+
+	deleted, err := ListDeleted(context.Background(), []HistorySource{remote, local}, "portal",
+		map[string]bool{"p3": true})
+	require.NoError(t, err)
+	require.Equal(t, 0, local.calls)
+	require.Len(t, deleted, 2)
+	require.Equal(t, "p1", deleted[0].ResourceID)
+	require.True(t, deleted[0].Timestamp.Equal(newer))
+	require.Equal(t, "p2", deleted[1].ResourceID)
+}
+
+func TestListDeleted_FallsBackToLocalLog(t *testing.T) {
+	t.Parallel()
+
+	scope := Scope{Profile: "default", BaseURL: "https://us.api.konghq.com"}
+	log := NewLog(filepath.Join(t.TempDir(), logFileName))
+	require.NoError(t, log.Append(
+		Entry{Timestamp: time.Now(), ResourceType: "api", ResourceID: "a1", ResourceName: "orders", Action: "CREATE",
+			Profile: scope.Profile, BaseURL: scope.BaseURL},
+		Entry{Timestamp: time.Now(), ResourceType: "api", ResourceID: "a1", ResourceName: "orders", Action: ActionDelete,
+			Profile: scope.Profile, BaseURL: scope.BaseURL},
+	))
+	remote := &stubHistorySource{err: ErrHistoryUnavailable}
+
+	deleted, err := ListDeleted(context.Background(),
+		[]HistorySource{remote, NewLocalHistory(log, scope)}, "api", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, remote.calls)
+	require.Len(t, deleted, 1)
+	require.Equal(t, "orders", deleted[0].ResourceName)
+}
+
+func TestListDeleted_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ListDeleted(context.Background(),
+		[]HistorySource{&stubHistorySource{err: ErrHistoryUnavailable}}, "api", nil)
+	require.ErrorIs(t, err, ErrHistoryUnavailable)
+
+	boom := errors.New("boom")
+	_, err = ListDeleted(context.Background(), []HistorySource{&stubHistorySource{err: boom}}, "api", nil)
+	require.ErrorIs(t, err, boom)
+}
+
+func TestLocalHistory_ScopedToOrganizationAndRecent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	us := Scope{Profile: "default", BaseURL: "https://us.api.konghq.com"}
+	deleted := func(name string, scope Scope, at time.Time) Entry {
+		return Entry{Timestamp: at, ResourceType: "portal", ResourceID: name, ResourceName: name,
+			Action: ActionDelete, Profile: scope.Profile, BaseURL: scope.BaseURL}
+	}
+	log := NewLog(filepath.Join(t.TempDir(), logFileName))
+	require.NoError(t, log.Append(
+		deleted("recent", us, now.Add(-time.Hour)),
+		deleted("expired", us, now.Add(-DeletedRetention-time.Hour)),
+		deleted("other-profile", Scope{Profile: "prod", BaseURL: us.BaseURL}, now.Add(-time.Hour)),
+		deleted("other-region", Scope{Profile: us.Profile, BaseURL: "https://eu.api.konghq.com"}, now.Add(-time.Hour)),
+		deleted("unscoped", Scope{}, now.Add(-time.Hour)),
+	))
+
+	history := NewLocalHistory(log, us)
+	history.now = func() time.Time { return now }
+	entries, err := history.ListDeleted(context.Background(), "portal")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "recent", entries[0].ResourceName)
+}
+
+func TestLogAppend_RotatesPastMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), logFileName)
+	log := NewLog(path)
+	log.maxBytes = 200
+	entry := func(name string) Entry {
+		return Entry{Timestamp: time.Now(), ResourceType: "portal", ResourceName: name, Action: ActionDelete}
+	}
+
+	for _, name := range []string{"one", "two", "three", "four", "five"} {
+		require.NoError(t, log.Append(entry(name)))
+	}
+
+	for _, file := range []string{path, path + backupSuffix} {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), log.maxBytes)
+	}
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	require.Less(t, len(entries), 5, "the oldest entries were dropped")
+	require.Equal(t, "five", entries[len(entries)-1].ResourceName)
+}
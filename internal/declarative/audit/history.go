@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DeletedRetention is how long a deletion recorded in the audit log is reported
+const DeletedRetention = 90 * 24 * time.Hour
+
+// ErrHistoryUnavailable is returned by a HistorySource that cannot serve deletion history,
+// for example when Konnect does not retain records for the requested resource type.
+var ErrHistoryUnavailable = errors.New("deletion history unavailable")
+
+// HistorySource provides records of deleted resources
+type HistorySource interface {
+	ListDeleted(ctx context.Context, resourceType string) ([]Entry, error)
+}
+
+// LocalHistory serves deletion history of one organization from the kongctl audit log
+type LocalHistory struct {
+	log   *Log
+	scope Scope
+	now   func() time.Time
+}
+
+// NewLocalHistory creates a history source backed by the given log, reporting the
+// deletions recorded for scope
+func NewLocalHistory(log *Log, scope Scope) *LocalHistory {
+	return &LocalHistory{log: log, scope: scope, now: time.Now}
+}
+
+// ListDeleted returns the DELETE entries recorded for resourceType in the scope of the
+// history over the last DeletedRetention
+func (h *LocalHistory) ListDeleted(_ context.Context, resourceType string) ([]Entry, error) {
+	entries, err := h.log.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := h.now().Add(-DeletedRetention)
+	var deleted []Entry
+	for _, entry := range entries {
+		if entry.Action == ActionDelete && entry.ResourceType == resourceType &&
+			h.scope.Matches(entry) && entry.Timestamp.After(cutoff) {
+			deleted = append(deleted, entry)
+		}
+	}
+	return deleted, nil
+}
+
+// ListDeleted queries sources in order and returns the deletions reported by the first
+// source that has history for resourceType. Entries whose resource ID appears in
+// activeIDs are dropped, and each resource is reported once with its latest deletion,
+// newest first.
+func ListDeleted(
+	ctx context.Context,
+	sources []HistorySource,
+	resourceType string,
+	activeIDs map[string]bool,
+) ([]Entry, error) {
+	var entries []Entry
+	found := false
+	for _, source := range sources {
+		result, err := source.ListDeleted(ctx, resourceType)
+		if errors.Is(err, ErrHistoryUnavailable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = result
+		found = true
+		break
+	}
+	if !found {
+		return nil, ErrHistoryUnavailable
+	}
+
+	latest := make(map[string]Entry)
+	for _, entry := range entries {
+		key := entry.ResourceID
+		if key == "" {
+			key = entry.ResourceName
+		}
+		if activeIDs[entry.ResourceID] {
+			continue
+		}
+		if existing, ok := latest[key]; !ok || entry.Timestamp.After(existing.Timestamp) {
+			latest[key] = entry
+		}
+	}
+
+	deleted := make([]Entry, 0, len(latest))
+	for _, entry := range latest {
+		deleted = append(deleted, entry)
+	}
+	sort.Slice(deleted, func(i, j int) bool {
+		if deleted[i].Timestamp.Equal(deleted[j].Timestamp) {
+			return deleted[i].ResourceName < deleted[j].ResourceName
+		}
+		return deleted[i].Timestamp.After(deleted[j].Timestamp)
+	})
+	return deleted, nil
+}
@@ -0,0 +1,368 @@
+package canary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+const pluginPageSize int64 = 100
+
+// PluginAPI is the subset of the Konnect plugins API a rollout uses
+type PluginAPI interface {
+	ListPlugin(ctx context.Context, request kkOps.ListPluginRequest,
+		opts ...kkOps.Option) (*kkOps.ListPluginResponse, error)
+	UpsertPlugin(ctx context.Context, request kkOps.UpsertPluginRequest,
+		opts ...kkOps.Option) (*kkOps.UpsertPluginResponse, error)
+}
+
+// Runner runs the steps of a rollout against a control plane
+type Runner struct {
+	api            PluginAPI
+	store          *Store
+	controlPlaneID string
+	now            func() time.Time
+}
+
+// NewRunner creates a runner for plugins in the given control plane
+func NewRunner(api PluginAPI, store *Store, controlPlaneID string) *Runner {
+	return &Runner{
+		api:            api,
+		store:          store,
+		controlPlaneID: controlPlaneID,
+		now:            time.Now,
+	}
+}
+
+// Preview returns the targets a new rollout would select, without changing anything
+func (r *Runner) Preview(ctx context.Context, spec *Spec) (*State, error) {
+	state, err := r.store.Load(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		return state, nil
+	}
+
+	plugins, err := r.listPlugins(ctx, spec.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	return r.newState(spec, plugins)
+}
+
+// Start applies the config change to the canary targets, then runs verification. A
+// rollout interrupted by a failed write or verification resumes from its state when
+// Start is run again with the same spec.
+func (r *Runner) Start(ctx context.Context, spec *Spec) (*State, error) {
+	state, err := r.store.Load(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil {
+		if err := r.checkResumable(spec, state); err != nil {
+			return state, err
+		}
+		if state.Phase != PhaseCanary {
+			// Already verified or promoted; nothing left for this step
+			return state, nil
+		}
+	} else {
+		plugins, err := r.listPlugins(ctx, spec.Plugin)
+		if err != nil {
+			return nil, err
+		}
+		state, err = r.newState(spec, plugins)
+		if err != nil {
+			return nil, err
+		}
+		// Record the selection before writing anything, so a resumed rollout keeps it
+		if err := r.save(state); err != nil {
+			return state, err
+		}
+	}
+
+	if err := r.applyTargets(ctx, spec, state, func(t Target) bool { return t.Canary }); err != nil {
+		return state, err
+	}
+
+	if len(spec.Verify) > 0 {
+		if err := r.verify(ctx, spec, state); err != nil {
+			return state, fmt.Errorf("canary verification failed, run apply again to retry: %w", err)
+		}
+	}
+
+	state.Phase = PhaseVerified
+	return state, r.save(state)
+}
+
+// Promote applies the config change to every remaining target of a verified rollout.
+// Plugins added to routes or services since the canary started are promoted too.
+func (r *Runner) Promote(ctx context.Context, spec *Spec) (*State, error) {
+	state, err := r.store.Load(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("canary %q has not been started; run apply without --canary-promote first",
+			spec.Name)
+	}
+	if err := r.checkResumable(spec, state); err != nil {
+		return state, err
+	}
+	switch state.Phase {
+	case PhasePromoted:
+		return state, nil
+	case PhaseCanary:
+		return state, fmt.Errorf("canary %q has not passed verification; run apply without --canary-promote first",
+			spec.Name)
+	case PhaseVerified:
+	}
+
+	plugins, err := r.listPlugins(ctx, spec.Plugin)
+	if err != nil {
+		return state, err
+	}
+	known := make(map[string]bool, len(state.Targets))
+	for _, target := range state.Targets {
+		known[target.PluginID] = true
+	}
+	for _, target := range targetsFor(plugins) {
+		if !known[target.PluginID] {
+			state.Targets = append(state.Targets, target)
+		}
+	}
+	if err := r.save(state); err != nil {
+		return state, err
+	}
+
+	if err := r.applyTargets(ctx, spec, state, func(Target) bool { return true }); err != nil {
+		return state, err
+	}
+
+	state.Phase = PhasePromoted
+	return state, r.save(state)
+}
+
+// checkResumable rejects specs that no longer match a rollout in progress
+func (r *Runner) checkResumable(spec *Spec, state *State) error {
+	if state.ControlPlaneID != r.controlPlaneID || state.Plugin != spec.Plugin {
+		return fmt.Errorf("canary %q was started for plugin %q in control plane %s; "+
+			"use a new canary name for a different rollout", spec.Name, state.Plugin, state.ControlPlaneID)
+	}
+	if state.ConfigHash != spec.ConfigHash() && state.Phase != PhasePromoted {
+		return fmt.Errorf("the config of canary %q changed after the canary started; "+
+			"finish the rollout or use a new canary name", spec.Name)
+	}
+	if state.ConfigHash != spec.ConfigHash() {
+		return fmt.Errorf("canary %q was already promoted with a different config; "+
+			"use a new canary name for a new rollout", spec.Name)
+	}
+	return nil
+}
+
+func (r *Runner) newState(spec *Spec, plugins []kkComps.Plugin) (*State, error) {
+	targets := targetsFor(plugins)
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no %s plugins scoped to a route or service found in control plane %s",
+			spec.Plugin, r.controlPlaneID)
+	}
+	selectCanary(spec.Name, targets, spec.Percentage)
+
+	now := r.now().UTC()
+	return &State{
+		Name:           spec.Name,
+		ControlPlaneID: r.controlPlaneID,
+		Plugin:         spec.Plugin,
+		Percentage:     spec.Percentage,
+		ConfigHash:     spec.ConfigHash(),
+		Phase:          PhaseCanary,
+		Targets:        targets,
+		StartedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// applyTargets writes the config change to each selected target not yet applied,
+// saving the state after every write
+func (r *Runner) applyTargets(ctx context.Context, spec *Spec, state *State, selected func(Target) bool) error {
+	plugins, err := r.listPlugins(ctx, spec.Plugin)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]kkComps.Plugin, len(plugins))
+	for _, plugin := range plugins {
+		if plugin.ID != nil {
+			byID[*plugin.ID] = plugin
+		}
+	}
+
+	for i := range state.Targets {
+		target := &state.Targets[i]
+		if target.Applied || !selected(*target) {
+			continue
+		}
+		plugin, ok := byID[target.PluginID]
+		if !ok {
+			return fmt.Errorf("plugin %s on %s no longer exists", target.PluginID, target.Key())
+		}
+
+		plugin.Config = mergeConfig(plugin.Config, spec.Config)
+		_, err := r.api.UpsertPlugin(ctx, kkOps.UpsertPluginRequest{
+			PluginID:       target.PluginID,
+			ControlPlaneID: r.controlPlaneID,
+			Plugin:         plugin,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update plugin %s on %s: %w", target.PluginID, target.Key(), err)
+		}
+		slog.Debug("Applied canary config", "canary", spec.Name, "plugin_id", target.PluginID,
+			"target", target.Key())
+
+		target.Applied = true
+		if err := r.save(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verify runs the verify command of the spec. The command sees the canary through
+// KONGCTL_CONTROL_PLANE_ID, KONGCTL_CANARY_NAME and KONGCTL_CANARY_PLUGIN_IDS.
+func (r *Runner) verify(ctx context.Context, spec *Spec, state *State) error {
+	var pluginIDs []string
+	for _, target := range state.Targets {
+		if target.Canary {
+			pluginIDs = append(pluginIDs, target.PluginID)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, spec.Verify[0], spec.Verify[1:]...)
+	cmd.Dir = spec.baseDir
+	cmd.Env = append(os.Environ(),
+		"KONGCTL_CONTROL_PLANE_ID="+state.ControlPlaneID,
+		"KONGCTL_CANARY_NAME="+state.Name,
+		"KONGCTL_CANARY_PLUGIN_IDS="+strings.Join(pluginIDs, ","),
+	)
+
+	output, err := cmd.CombinedOutput()
+	slog.Debug("Ran canary verification",
+		"canary", spec.Name,
+		"command", strings.Join(spec.Verify, " "),
+		"output", string(output),
+	)
+	if err != nil {
+		if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+			return fmt.Errorf("%s: %w: %s", spec.Verify[0], err, trimmed)
+		}
+		return fmt.Errorf("%s: %w", spec.Verify[0], err)
+	}
+	return nil
+}
+
+func (r *Runner) save(state *State) error {
+	state.UpdatedAt = r.now().UTC()
+	return r.store.Save(state)
+}
+
+// listPlugins returns every plugin with the given name in the control plane
+func (r *Runner) listPlugins(ctx context.Context, name string) ([]kkComps.Plugin, error) {
+	var plugins []kkComps.Plugin
+	var offset *string
+	for {
+		size := pluginPageSize
+		res, err := r.api.ListPlugin(ctx, kkOps.ListPluginRequest{
+			ControlPlaneID: r.controlPlaneID,
+			Size:           &size,
+			Offset:         offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list plugins: %w", err)
+		}
+		if res.Object == nil {
+			break
+		}
+		for _, plugin := range res.Object.Data {
+			if plugin.Name == name {
+				plugins = append(plugins, plugin)
+			}
+		}
+		if res.Object.Offset == nil || *res.Object.Offset == "" {
+			break
+		}
+		offset = res.Object.Offset
+	}
+	return plugins, nil
+}
+
+// targetsFor returns the route and service scoped plugins as targets. Global and
+// consumer scoped plugins are not split by percentage, so they are left alone.
+func targetsFor(plugins []kkComps.Plugin) []Target {
+	targets := make([]Target, 0, len(plugins))
+	for _, plugin := range plugins {
+		if plugin.ID == nil {
+			continue
+		}
+		switch {
+		case plugin.Route != nil && plugin.Route.ID != nil:
+			targets = append(targets, Target{PluginID: *plugin.ID, Scope: "route", ScopeID: *plugin.Route.ID})
+		case plugin.Service != nil && plugin.Service.ID != nil:
+			targets = append(targets, Target{PluginID: *plugin.ID, Scope: "service", ScopeID: *plugin.Service.ID})
+		}
+	}
+	return targets
+}
+
+// selectCanary orders targets by a hash of the canary name and target key and marks
+// the first percentage of them, rounded up, as canary targets. The same name and
+// targets always select the same canary, whatever order Konnect lists them in.
+func selectCanary(name string, targets []Target, percentage int) {
+	rank := func(t Target) string {
+		sum := sha256.Sum256([]byte(name + "/" + t.Key()))
+		return hex.EncodeToString(sum[:])
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		ri, rj := rank(targets[i]), rank(targets[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return targets[i].PluginID < targets[j].PluginID
+	})
+
+	count := int(math.Ceil(float64(len(targets)) * float64(percentage) / 100))
+	for i := range targets {
+		targets[i].Canary = i < count
+	}
+}
+
+// mergeConfig returns live with patch merged into it; nested objects are merged,
+// other values replaced
+func mergeConfig(live, patch map[string]any) map[string]any {
+	merged := maps.Clone(live)
+	if merged == nil {
+		merged = make(map[string]any, len(patch))
+	}
+	for key, value := range patch {
+		patchMap, patchIsMap := value.(map[string]any)
+		liveMap, liveIsMap := merged[key].(map[string]any)
+		if patchIsMap && liveIsMap {
+			merged[key] = mergeConfig(liveMap, patchMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
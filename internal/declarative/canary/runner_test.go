@@ -0,0 +1,269 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePluginAPI serves plugins from memory and records upserts
+type fakePluginAPI struct {
+	plugins []kkComps.Plugin
+	upserts []string
+	// failOn makes the upsert of a plugin ID fail once
+	failOn string
+}
+
+func (f *fakePluginAPI) ListPlugin(
+	_ context.Context, _ kkOps.ListPluginRequest, _ ...kkOps.Option,
+) (*kkOps.ListPluginResponse, error) {
+	data := make([]kkComps.Plugin, len(f.plugins))
+	copy(data, f.plugins)
+	return &kkOps.ListPluginResponse{
+		StatusCode: 200,
+		Object:     &kkOps.ListPluginResponseBody{Data: data},
+	}, nil
+}
+
+func (f *fakePluginAPI) UpsertPlugin(
+	_ context.Context, request kkOps.UpsertPluginRequest, _ ...kkOps.Option,
+) (*kkOps.UpsertPluginResponse, error) {
+	if request.PluginID == f.failOn {
+		f.failOn = ""
+		return nil, fmt.Errorf("boom")
+	}
+	f.upserts = append(f.upserts, request.PluginID)
+	for i := range f.plugins {
+		if *f.plugins[i].ID == request.PluginID {
+			f.plugins[i] = request.Plugin
+		}
+	}
+	return &kkOps.UpsertPluginResponse{StatusCode: 200, Plugin: &request.Plugin}, nil
+}
+
+func routePlugin(id, routeID string) kkComps.Plugin {
+	return kkComps.Plugin{
+		ID:     &id,
+		Name:   "rate-limiting",
+		Route:  &kkComps.PluginRoute{ID: &routeID},
+		Config: map[string]any{"minute": 10, "policy": "local"},
+	}
+}
+
+func testPlugins(n int) []kkComps.Plugin {
+	plugins := make([]kkComps.Plugin, 0, n+2)
+	for i := range n {
+		plugins = append(plugins, routePlugin(fmt.Sprintf("plugin-%d", i), fmt.Sprintf("route-%d", i)))
+	}
+	serviceID, globalID, otherID := "service-1", "global", "other"
+	plugins = append(plugins,
+		kkComps.Plugin{ID: &globalID, Name: "rate-limiting"},
+		kkComps.Plugin{ID: &otherID, Name: "cors", Service: &kkComps.PluginService{ID: &serviceID}},
+	)
+	return plugins
+}
+
+// testTargets returns the targets of a rate-limiting rollout over testPlugins(n)
+func testTargets(t *testing.T, n int) []Target {
+	t.Helper()
+	runner := newTestRunner(t, &fakePluginAPI{plugins: testPlugins(n)})
+	plugins, err := runner.listPlugins(context.Background(), "rate-limiting")
+	require.NoError(t, err)
+	return targetsFor(plugins)
+}
+
+func testSpec(verify ...string) *Spec {
+	return &Spec{
+		Name:         "rl-raise",
+		ControlPlane: "default",
+		Plugin:       "rate-limiting",
+		Percentage:   25,
+		Config:       map[string]any{"minute": 20},
+		Verify:       verify,
+	}
+}
+
+func newTestRunner(t *testing.T, api *fakePluginAPI) *Runner {
+	t.Helper()
+	return NewRunner(api, NewStore(filepath.Join(t.TempDir(), stateFileName)), "cp-1")
+}
+
+func canaryIDs(state *State) []string {
+	var ids []string
+	for _, target := range state.Targets {
+		if target.Canary {
+			ids = append(ids, target.PluginID)
+		}
+	}
+	return ids
+}
+
+func TestSelectCanary(t *testing.T) {
+	t.Run("deterministic whatever the listing order", func(t *testing.T) {
+		targets := testTargets(t, 10)
+		reversed := make([]Target, len(targets))
+		for i, target := range targets {
+			reversed[len(targets)-1-i] = target
+		}
+
+		selectCanary("rl-raise", targets, 30)
+		selectCanary("rl-raise", reversed, 30)
+		assert.Equal(t, targets, reversed)
+	})
+
+	t.Run("rounds up to at least one target", func(t *testing.T) {
+		for _, tc := range []struct {
+			targets, percentage, want int
+		}{
+			{targets: 10, percentage: 30, want: 3},
+			{targets: 10, percentage: 25, want: 3},
+			{targets: 3, percentage: 1, want: 1},
+			{targets: 4, percentage: 100, want: 4},
+		} {
+			targets := testTargets(t, tc.targets)
+			selectCanary("rl-raise", targets, tc.percentage)
+
+			selected := 0
+			for _, target := range targets {
+				if target.Canary {
+					selected++
+				}
+			}
+			assert.Equal(t, tc.want, selected, "%d%% of %d", tc.percentage, tc.targets)
+		}
+	})
+
+	t.Run("skips global and other plugins", func(t *testing.T) {
+		targets := testTargets(t, 2)
+		require.Len(t, targets, 2)
+		for _, target := range targets {
+			assert.Equal(t, "route", target.Scope)
+		}
+	})
+}
+
+func TestRunner_StartAndPromote(t *testing.T) {
+	api := &fakePluginAPI{plugins: testPlugins(8)}
+	runner := newTestRunner(t, api)
+	spec := testSpec("sh", "-c", `test "$KONGCTL_CANARY_NAME" = rl-raise && test -n "$KONGCTL_CANARY_PLUGIN_IDS"`)
+
+	state, err := runner.Start(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Equal(t, PhaseVerified, state.Phase)
+	assert.ElementsMatch(t, canaryIDs(state), api.upserts, "only the canary targets change")
+	require.Len(t, api.upserts, 2)
+
+	upserted := make(map[string]bool)
+	for _, id := range api.upserts {
+		upserted[id] = true
+	}
+	for _, plugin := range api.plugins {
+		if plugin.Route == nil {
+			continue
+		}
+		want := 10
+		if upserted[*plugin.ID] {
+			want = 20
+		}
+		assert.Equal(t, want, plugin.Config["minute"], *plugin.ID)
+		assert.Equal(t, "local", plugin.Config["policy"], "unpatched fields are kept")
+	}
+
+	// Starting again with the same spec does not touch anything
+	_, err = runner.Start(context.Background(), spec)
+	require.NoError(t, err)
+	require.Len(t, api.upserts, 2)
+
+	state, err = runner.Promote(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Equal(t, PhasePromoted, state.Phase)
+	assert.Len(t, api.upserts, 8, "every target changes exactly once")
+	_, applied := state.Counts()
+	assert.Equal(t, 8, applied)
+}
+
+func TestRunner_ResumesAfterFailedWrite(t *testing.T) {
+	api := &fakePluginAPI{plugins: testPlugins(8)}
+	runner := newTestRunner(t, api)
+	spec := testSpec()
+
+	preview, err := runner.Preview(context.Background(), spec)
+	require.NoError(t, err)
+	selected := canaryIDs(preview)
+	require.Len(t, selected, 2)
+	api.failOn = selected[1]
+
+	state, err := runner.Start(context.Background(), spec)
+	require.ErrorContains(t, err, "boom")
+	assert.Equal(t, PhaseCanary, state.Phase)
+	assert.Equal(t, selected[:1], api.upserts)
+
+	state, err = runner.Start(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Equal(t, PhaseVerified, state.Phase)
+	assert.Equal(t, selected, api.upserts, "the resumed rollout keeps its selection")
+}
+
+func TestRunner_FailedVerificationBlocksPromote(t *testing.T) {
+	api := &fakePluginAPI{plugins: testPlugins(4)}
+	runner := newTestRunner(t, api)
+	spec := testSpec("sh", "-c", "echo error rate too high; exit 1")
+
+	state, err := runner.Start(context.Background(), spec)
+	require.ErrorContains(t, err, "error rate too high")
+	assert.Equal(t, PhaseCanary, state.Phase)
+
+	_, err = runner.Promote(context.Background(), spec)
+	require.ErrorContains(t, err, "has not passed verification")
+	assert.Len(t, api.upserts, 1)
+}
+
+func TestRunner_PromoteRequiresStart(t *testing.T) {
+	runner := newTestRunner(t, &fakePluginAPI{plugins: testPlugins(4)})
+	_, err := runner.Promote(context.Background(), testSpec())
+	require.ErrorContains(t, err, "has not been started")
+}
+
+func TestRunner_PromoteIncludesNewPlugins(t *testing.T) {
+	api := &fakePluginAPI{plugins: testPlugins(4)}
+	runner := newTestRunner(t, api)
+	spec := testSpec()
+
+	_, err := runner.Start(context.Background(), spec)
+	require.NoError(t, err)
+	api.plugins = append(api.plugins, routePlugin("plugin-new", "route-new"))
+
+	state, err := runner.Promote(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Len(t, state.Targets, 5)
+	assert.Contains(t, api.upserts, "plugin-new")
+}
+
+func TestRunner_RejectsChangedConfig(t *testing.T) {
+	api := &fakePluginAPI{plugins: testPlugins(4)}
+	runner := newTestRunner(t, api)
+
+	_, err := runner.Start(context.Background(), testSpec())
+	require.NoError(t, err)
+
+	changed := testSpec()
+	changed.Config = map[string]any{"minute": 30}
+	_, err = runner.Start(context.Background(), changed)
+	require.ErrorContains(t, err, "changed after the canary started")
+	_, err = runner.Promote(context.Background(), changed)
+	require.ErrorContains(t, err, "changed after the canary started")
+}
+
+func TestMergeConfig(t *testing.T) {
+	live := map[string]any{"minute": 10, "redis": map[string]any{"host": "a", "port": 6379}}
+	merged := mergeConfig(live, map[string]any{"minute": 20, "redis": map[string]any{"host": "b"}})
+
+	assert.Equal(t, map[string]any{"minute": 20, "redis": map[string]any{"host": "b", "port": 6379}}, merged)
+	assert.Equal(t, 10, live["minute"], "live config is not modified")
+}
@@ -0,0 +1,84 @@
+// Package canary rolls a plugin config change out to a percentage of the routes and
+// services using the plugin, then to the rest once the canary has been verified.
+package canary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Spec describes a canary rollout of a plugin config change
+type Spec struct {
+	// Name identifies the rollout in the canary state file
+	Name string `json:"name" yaml:"name"`
+	// ControlPlane is the name of the control plane owning the plugins
+	ControlPlane string `json:"control_plane" yaml:"control_plane"`
+	// Plugin is the name of the plugin whose config changes, e.g. rate-limiting
+	Plugin string `json:"plugin" yaml:"plugin"`
+	// Percentage of the routes and services using the plugin that receive the canary
+	Percentage int `json:"percentage" yaml:"percentage"`
+	// Config is merged into the config of each plugin instance
+	Config map[string]any `json:"config" yaml:"config"`
+	// Verify is an optional command run after the canary is applied
+	Verify []string `json:"verify,omitempty" yaml:"verify,omitempty"`
+
+	// baseDir is the directory of the spec file; verify runs from it
+	baseDir string
+}
+
+// LoadSpec reads and validates a canary spec file
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read canary spec: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse canary spec %s: %w", path, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid canary spec %s: %w", path, err)
+	}
+	spec.baseDir = filepath.Dir(path)
+	return &spec, nil
+}
+
+// Validate checks that the spec describes a rollout
+func (s *Spec) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(s.ControlPlane) == "" {
+		return fmt.Errorf("control_plane is required")
+	}
+	if strings.TrimSpace(s.Plugin) == "" {
+		return fmt.Errorf("plugin is required")
+	}
+	if s.Percentage < 1 || s.Percentage > 100 {
+		return fmt.Errorf("percentage must be between 1 and 100, got %d", s.Percentage)
+	}
+	if len(s.Config) == 0 {
+		return fmt.Errorf("config must change at least one field")
+	}
+	if len(s.Verify) > 0 && strings.TrimSpace(s.Verify[0]) == "" {
+		return fmt.Errorf("verify command must not be empty")
+	}
+	return nil
+}
+
+// ConfigHash fingerprints the config change, so a rollout can tell whether the spec
+// changed after its canary started
+func (s *Spec) ConfigHash() string {
+	// encoding/json sorts map keys, so the hash is stable
+	data, _ := json.Marshal(s.Config)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
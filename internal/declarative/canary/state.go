@@ -0,0 +1,164 @@
+package canary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+)
+
+const (
+	defaultDirPerm  = 0o700
+	defaultFilePerm = 0o600
+
+	stateFileName = "canary-state.json"
+)
+
+// Phase is the step a rollout has reached
+type Phase string
+
+const (
+	// PhaseCanary means the canary targets are being, or have been, changed
+	PhaseCanary Phase = "canary"
+	// PhaseVerified means every canary target was changed and verification passed
+	PhaseVerified Phase = "verified"
+	// PhasePromoted means every target was changed
+	PhasePromoted Phase = "promoted"
+)
+
+// Target is a plugin instance scoped to a route or service
+type Target struct {
+	PluginID string `json:"plugin_id"`
+	// Scope is "route" or "service"
+	Scope   string `json:"scope"`
+	ScopeID string `json:"scope_id"`
+	// Canary marks targets selected for the canary step
+	Canary bool `json:"canary"`
+	// Applied marks targets whose config has been changed
+	Applied bool `json:"applied"`
+}
+
+// Key identifies the routed entity the target applies to
+func (t Target) Key() string {
+	return t.Scope + ":" + t.ScopeID
+}
+
+// State records a rollout so that it can be resumed and promoted by later invocations
+type State struct {
+	Name           string    `json:"name"`
+	ControlPlaneID string    `json:"control_plane_id"`
+	Plugin         string    `json:"plugin"`
+	Percentage     int       `json:"percentage"`
+	ConfigHash     string    `json:"config_hash"`
+	Phase          Phase     `json:"phase"`
+	Targets        []Target  `json:"targets"`
+	StartedAt      time.Time `json:"started_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Counts returns the number of canary targets and the number of applied targets
+func (s *State) Counts() (canary, applied int) {
+	for _, target := range s.Targets {
+		if target.Canary {
+			canary++
+		}
+		if target.Applied {
+			applied++
+		}
+	}
+	return canary, applied
+}
+
+// Store keeps rollout states, keyed by name, in a JSON file
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+type stateFile struct {
+	Canaries map[string]*State `json:"canaries"`
+}
+
+// DefaultStatePath returns the canary state file inside the kongctl config directory
+func DefaultStatePath() (string, error) {
+	baseDir, err := config.GetDefaultConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("resolve config path: %w", err)
+	}
+	return filepath.Join(baseDir, stateFileName), nil
+}
+
+// NewStore creates a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load returns the state of the named rollout, or nil when it has not started
+func (s *Store) Load(name string) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return file.Canaries[name], nil
+}
+
+// Save records the state of a rollout, replacing any previous state with its name
+func (s *Store) Save(state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	file.Canaries[state.Name] = state
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode canary state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), defaultDirPerm); err != nil {
+		return fmt.Errorf("create canary state directory: %w", err)
+	}
+
+	// Write then rename so an interrupted save never truncates the state
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, defaultFilePerm); err != nil {
+		return fmt.Errorf("write canary state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write canary state: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) read() (*stateFile, error) {
+	file := &stateFile{}
+	data, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read canary state: %w", err)
+	default:
+		if err := json.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("parse canary state %s: %w", s.path, err)
+		}
+	}
+	if file.Canaries == nil {
+		file.Canaries = make(map[string]*State)
+	}
+	return file, nil
+}
@@ -13,23 +13,31 @@ import (
 // If source is "-", reads from stdin.
 // Otherwise, reads from the specified file path.
 func LoadPlan(source string, stdin io.Reader) (*planner.Plan, error) {
-	var planData []byte
-	var err error
+	planData, err := ReadPlanData(source, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlan(planData)
+}
 
+// ReadPlanData reads a plan artifact from a file or from stdin when source is "-"
+func ReadPlanData(source string, stdin io.Reader) ([]byte, error) {
 	if source == "-" {
-		// Read from stdin
-		planData, err = io.ReadAll(stdin)
+		planData, err := io.ReadAll(stdin)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read plan from stdin: %w", err)
 		}
-	} else {
-		// Read from file
-		planData, err = os.ReadFile(source)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read plan file: %w", err)
-		}
+		return planData, nil
+	}
+	planData, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
 	}
+	return planData, nil
+}
 
+// ParsePlan parses and validates a plan artifact
+func ParsePlan(planData []byte) (*planner.Plan, error) {
 	// Parse plan
 	plan := &planner.Plan{}
 	if err := json.Unmarshal(planData, plan); err != nil {
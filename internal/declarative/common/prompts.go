@@ -63,6 +63,8 @@ func ConfirmExecution(plan *planner.Plan, _, stderr io.Writer, stdin io.Reader)
 
 	// Add CONFIRM? section
 	fmt.Fprintln(stderr, "\nCONFIRM?")
+	fmt.Fprintf(stderr, "Plan: %d to create, %d to update, %d to delete\n",
+		plan.Summary.ByAction[planner.ActionCreate], plan.Summary.ByAction[planner.ActionUpdate], deleteCount)
 	fmt.Fprintln(stderr, strings.Repeat("-", 70))
 	fmt.Fprint(stderr, "Do you want to continue? Type 'yes' to confirm: ")
 
@@ -175,7 +177,7 @@ func DisplayPlanSummary(plan *planner.Plan, out io.Writer) {
 
 		// Count total changes in this namespace
 		namespaceTotal := 0
-		createCount, updateCount, deleteCount, externalToolCount := 0, 0, 0, 0
+		createCount, updateCount, deleteCount, externalToolCount, switchCount := 0, 0, 0, 0, 0
 		for _, changes := range changesByResource {
 			namespaceTotal += len(changes)
 			for _, change := range changes {
@@ -188,6 +190,8 @@ func DisplayPlanSummary(plan *planner.Plan, out io.Writer) {
 					deleteCount++
 				case planner.ActionExternalTool:
 					externalToolCount++
+				case planner.ActionSwitch:
+					switchCount++
 				}
 			}
 		}
@@ -212,6 +216,9 @@ func DisplayPlanSummary(plan *planner.Plan, out io.Writer) {
 		if externalToolCount > 0 {
 			actionSummary = append(actionSummary, fmt.Sprintf("%d external tool step", externalToolCount))
 		}
+		if switchCount > 0 {
+			actionSummary = append(actionSummary, fmt.Sprintf("%d switch", switchCount))
+		}
 		fmt.Fprintf(out, "%s)\n", strings.Join(actionSummary, ", "))
 
 		// Sort resource types by dependency order
@@ -260,8 +267,13 @@ func DisplayPlanSummary(plan *planner.Plan, out io.Writer) {
 					}
 				}
 
+				riskIndicator := ""
+				if change.Risk != nil && change.Risk.Level != planner.RiskLevelNone {
+					riskIndicator = fmt.Sprintf(" [risk: %s]", change.Risk.Level)
+				}
+
 				// Display the resource change with enhanced formatting
-				fmt.Fprintf(out, "    %s %s%s\n", actionPrefix, resourceName, protectedIndicator)
+				fmt.Fprintf(out, "    %s %s%s%s\n", actionPrefix, resourceName, protectedIndicator, riskIndicator)
 
 				// Show field-level changes for updates
 				if change.Action == planner.ActionUpdate {
@@ -430,6 +442,8 @@ func getActionPrefix(action planner.ActionType) string {
 		return "-"
 	case planner.ActionExternalTool:
 		return ">"
+	case planner.ActionSwitch:
+		return "^"
 	default:
 		return "?"
 	}
@@ -503,6 +517,7 @@ func displaySummary(plan *planner.Plan, out io.Writer) {
 	updateCount := plan.Summary.ByAction[planner.ActionUpdate]
 	deleteCount := plan.Summary.ByAction[planner.ActionDelete]
 	externalToolCount := plan.Summary.ByAction[planner.ActionExternalTool]
+	switchCount := plan.Summary.ByAction[planner.ActionSwitch]
 
 	fmt.Fprintf(out, "  Total changes: %d\n", plan.Summary.TotalChanges)
 
@@ -529,6 +544,15 @@ func displaySummary(plan *planner.Plan, out io.Writer) {
 	if externalToolCount > 0 {
 		fmt.Fprintf(out, "  External tool steps to run: %d\n", externalToolCount)
 	}
+	if switchCount > 0 {
+		fmt.Fprintf(out, "  Publications to switch: %d\n", switchCount)
+	}
+	if plan.Summary.NoOp > 0 {
+		fmt.Fprintf(out, "  Resources unchanged: %d\n", plan.Summary.NoOp)
+	}
+	if plan.Summary.Risk != nil {
+		fmt.Fprintf(out, "  Risk: %s (score %d)\n", plan.Summary.Risk.Level, plan.Summary.Risk.Score)
+	}
 
 	// Resource type breakdown
 	if len(plan.Summary.ByResource) > 0 {
@@ -555,6 +579,28 @@ func displaySummary(plan *planner.Plan, out io.Writer) {
 			fmt.Fprintf(out, "  Resources being unprotected: %d\n", plan.Summary.ProtectionChanges.Unprotecting)
 		}
 	}
+
+	// Organization resource counts, before and after the plan is applied
+	if len(plan.Summary.ResourceCounts) > 0 {
+		fmt.Fprintln(out, "\n  Resource counts:")
+		resourceTypes := make([]string, 0, len(plan.Summary.ResourceCounts))
+		for resourceType := range plan.Summary.ResourceCounts {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		sort.Strings(resourceTypes)
+
+		for _, resourceType := range resourceTypes {
+			count := plan.Summary.ResourceCounts[resourceType]
+			line := fmt.Sprintf("    %s: %d → %d", resourceType, count.Current, count.Planned)
+			if count.Limit != nil {
+				line += fmt.Sprintf(" (limit %d)", *count.Limit)
+			}
+			if count.ExceedsLimit {
+				line += " - exceeds limit"
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
 }
 
 // isProtectedResource checks if a resource is currently protected
@@ -54,6 +54,7 @@ func TestConfirmExecution(t *testing.T) {
 				assert.Empty(t, stdout)
 			},
 			checkStderr: func(t *testing.T, stderr string) {
+				assert.Contains(t, stderr, "Plan: 2 to create, 1 to update, 0 to delete")
 				assert.Contains(t, stderr, "Do you want to continue? Type 'yes' to confirm:")
 			},
 		},
@@ -109,6 +110,7 @@ func TestConfirmExecution(t *testing.T) {
 				assert.Contains(t, stderr, "WARNING: This operation will DELETE resources:")
 				assert.Contains(t, stderr, "- portal: old-portal")
 				assert.Contains(t, stderr, "- api: deprecated-api")
+				assert.Contains(t, stderr, "Plan: 1 to create, 0 to update, 2 to delete")
 			},
 		},
 		{
@@ -161,6 +163,7 @@ func TestConfirmExecution(t *testing.T) {
 }
 
 func TestDisplayPlanSummary(t *testing.T) {
+	portalLimit := 2
 	tests := []struct {
 		name     string
 		plan     *planner.Plan
@@ -263,6 +266,26 @@ func TestDisplayPlanSummary(t *testing.T) {
 				"depends on: api:test-api",
 			},
 		},
+		{
+			name: "plan with resource counts",
+			plan: &planner.Plan{
+				Summary: planner.PlanSummary{
+					ByAction:     map[planner.ActionType]int{planner.ActionCreate: 1},
+					TotalChanges: 1,
+					ResourceCounts: map[string]planner.ResourceCount{
+						"portal": {Current: 2, Planned: 3, Limit: &portalLimit, ExceedsLimit: true},
+						"api":    {Current: 4, Planned: 3},
+					},
+				},
+				Changes: []planner.PlannedChange{
+					{Action: planner.ActionCreate, ResourceType: "portal", ResourceRef: "p1"},
+				},
+			},
+			expected: []string{
+				"Resource counts:",
+				"    api: 4 → 3\n    portal: 2 → 3 (limit 2) - exceeds limit\n",
+			},
+		},
 	}
 
 	for _, tt := range tests {
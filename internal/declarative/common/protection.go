@@ -11,6 +11,9 @@ func ValidateResourceProtection(
 	resourceType, resourceName string, isProtected bool,
 	change planner.PlannedChange, isProtectionChange bool,
 ) error {
+	if change.Action == planner.ActionDelete && change.DeleteProtected {
+		return nil
+	}
 	// Block protected resources unless it's a protection change
 	if isProtected && !isProtectionChange &&
 		(change.Action == planner.ActionUpdate || change.Action == planner.ActionDelete) {
@@ -58,6 +61,8 @@ func actionToVerb(action planner.ActionType) string {
 		return "deleted"
 	case planner.ActionExternalTool:
 		return "executed"
+	case planner.ActionSwitch:
+		return "switched"
 	default:
 		return string(action)
 	}
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// ErrReviewAborted is returned by ReviewChanges when the review is aborted
+var ErrReviewAborted = errors.New("apply aborted during review")
+
+// ReviewChanges walks through the changes of plan in execution order, showing the
+// fields each one sets and asking whether to apply it. Changes depending on a skipped
+// change are skipped as well, as they cannot be applied without it. Answering all
+// accepts the remaining changes. It returns the IDs of the skipped changes.
+func ReviewChanges(plan *planner.Plan, out io.Writer, in io.Reader) ([]string, error) {
+	changes := make(map[string]planner.PlannedChange, len(plan.Changes))
+	for _, change := range plan.Changes {
+		changes[change.ID] = change
+	}
+	order := plan.ExecutionOrder
+	if len(order) == 0 {
+		for _, change := range plan.Changes {
+			order = append(order, change.ID)
+		}
+	}
+
+	scanner := bufio.NewScanner(in)
+	var skipped []string
+	acceptAll := false
+	for i, id := range order {
+		change, ok := changes[id]
+		if !ok {
+			continue
+		}
+		if dependency := skippedDependency(change, skipped); dependency != "" {
+			fmt.Fprintf(out, "\n[%d/%d] %s %s: %s skipped, it depends on skipped %s\n", i+1, len(order),
+				getActionPrefix(change.Action), change.ResourceType, formatResourceName(change), dependency)
+			skipped = append(skipped, id)
+			continue
+		}
+		if acceptAll {
+			continue
+		}
+
+		fmt.Fprintf(out, "\n[%d/%d] %s %s %s: %s\n", i+1, len(order), getActionPrefix(change.Action),
+			change.Action, change.ResourceType, formatResourceName(change))
+		displayReviewFields(out, change, plan.Changes)
+
+		answer, err := promptReview(out, scanner)
+		if err != nil {
+			return nil, err
+		}
+		switch answer {
+		case "y", "yes":
+		case "a", "all":
+			acceptAll = true
+		case "n", "no", "s", "skip":
+			skipped = append(skipped, id)
+		default:
+			return nil, ErrReviewAborted
+		}
+	}
+	return skipped, nil
+}
+
+// promptReview asks for the decision on a change until a known answer is given.
+// The end of the input aborts.
+func promptReview(out io.Writer, scanner *bufio.Scanner) (string, error) {
+	for {
+		fmt.Fprint(out, "Apply this change? [y]es, [n]o, [a]ll remaining, [q]uit: ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("failed to read answer: %w", err)
+			}
+			return "q", nil
+		}
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch answer {
+		case "y", "yes", "n", "no", "s", "skip", "a", "all", "q", "quit":
+			return answer, nil
+		}
+		fmt.Fprintf(out, "Unknown answer %q.\n", answer)
+	}
+}
+
+// skippedDependency returns the change of skipped that change depends on, if any
+func skippedDependency(change planner.PlannedChange, skipped []string) string {
+	for _, id := range change.DependsOn {
+		if slices.Contains(skipped, id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// displayReviewFields shows the fields a change sets, with the current value of the
+// updated fields the planner recorded
+func displayReviewFields(out io.Writer, change planner.PlannedChange, allChanges []planner.PlannedChange) {
+	const indent = "    "
+	if change.Action == planner.ActionDelete {
+		fmt.Fprintf(out, "%s<resource will be deleted>\n", indent)
+	}
+
+	names := make([]string, 0, len(change.Fields))
+	for name := range change.Fields {
+		if strings.HasPrefix(name, "_") || name == "current_labels" || slices.Contains(change.IdentityFields, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := change.Fields[name]
+		if fc, ok := value.(planner.FieldChange); ok {
+			fmt.Fprintf(out, "%s%s: %s → %s\n", indent, name, formatFieldValue(fc.Old), formatFieldValue(fc.New))
+			continue
+		}
+		if fc, ok := value.(map[string]any); ok && len(fc) == 2 {
+			oldValue, hasOld := fc["old"]
+			newValue, hasNew := fc["new"]
+			if hasOld && hasNew {
+				fmt.Fprintf(out, "%s%s: %s → %s\n", indent, name, formatFieldValue(oldValue), formatFieldValue(newValue))
+				continue
+			}
+		}
+		fmt.Fprintf(out, "%s%s: %s\n", indent, name, formatFieldValue(value))
+	}
+	if protected, ok := change.Protection.(planner.ProtectionChange); ok && protected.Old != protected.New {
+		fmt.Fprintf(out, "%sprotected: %t → %t\n", indent, protected.Old, protected.New)
+	}
+	displayDependencies(out, change, allChanges, indent)
+}
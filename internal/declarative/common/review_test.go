@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewPlan() *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID: "1:c:portal:dev", ResourceType: "portal", ResourceRef: "dev", Action: planner.ActionCreate,
+		Fields: map[string]any{"name": "dev"},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "2:c:portal_page:home", ResourceType: "portal_page", ResourceRef: "home", Action: planner.ActionCreate,
+		Fields: map[string]any{"slug": "home"}, DependsOn: []string{"1:c:portal:dev"},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "3:u:api:orders", ResourceType: "api", ResourceRef: "orders", Action: planner.ActionUpdate,
+		Fields:         map[string]any{"name": "orders", "description": planner.FieldChange{Old: "Old", New: "New"}},
+		IdentityFields: []string{"name"},
+	})
+	plan.SetExecutionOrder([]string{"1:c:portal:dev", "2:c:portal_page:home", "3:u:api:orders"})
+	return plan
+}
+
+func TestReviewChanges_SkipsDependents(t *testing.T) {
+	var out bytes.Buffer
+	skipped, err := ReviewChanges(reviewPlan(), &out, strings.NewReader("n\ny\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1:c:portal:dev", "2:c:portal_page:home"}, skipped)
+	assert.Contains(t, out.String(), "home skipped, it depends on skipped 1:c:portal:dev")
+	assert.Contains(t, out.String(), `description: "Old" → "New"`)
+	assert.NotContains(t, out.String(), `name: "orders"`)
+}
+
+func TestReviewChanges_AcceptAll(t *testing.T) {
+	var out bytes.Buffer
+	skipped, err := ReviewChanges(reviewPlan(), &out, strings.NewReader("maybe\na\n"))
+	require.NoError(t, err)
+
+	assert.Empty(t, skipped)
+	assert.Contains(t, out.String(), `Unknown answer "maybe"`)
+	// The first change is asked twice, the others are accepted without asking
+	assert.Equal(t, 2, strings.Count(out.String(), "Apply this change?"))
+}
+
+func TestReviewChanges_Abort(t *testing.T) {
+	for _, input := range []string{"q\n", ""} {
+		var out bytes.Buffer
+		_, err := ReviewChanges(reviewPlan(), &out, strings.NewReader(input))
+		require.ErrorIs(t, err, ErrReviewAborted)
+	}
+}
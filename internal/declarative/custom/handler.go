@@ -0,0 +1,82 @@
+// Package custom lets resource kinds that are not built into kongctl take part in
+// declarative planning and execution.
+//
+// A handler is compiled into the kongctl binary and registered from an init function.
+// Modules outside kongctl register theirs through pkg/declarative/custom, which
+// re-exports this package.
+//
+// Configuration declares instances under custom_resources with the handler's kind.
+// The planner lists existing instances through the handler, diffs them against the
+// desired state and emits CREATE, UPDATE and DELETE changes that share refs, labels,
+// namespaces, protection and dependency ordering with built-in resources. The executor
+// then hands each change back to the handler.
+package custom
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// State is an existing instance of a custom kind as reported by its handler
+type State struct {
+	ID   string
+	Name string
+	// Labels are the instance's labels, including KONGCTL- management labels
+	Labels map[string]string
+	Spec   map[string]any
+}
+
+// Request describes a planned change being applied by a handler
+type Request struct {
+	Kind string
+	Ref  string
+	// ID is the existing instance ID; empty for creates
+	ID        string
+	Name      string
+	Namespace string
+	// Labels is the complete label set to persist, including KONGCTL- management labels.
+	// It is nil for deletes and for updates that do not change labels.
+	Labels map[string]string
+	// Fields holds spec fields with references resolved: every field for creates and
+	// only the changed fields for updates. Removed fields are present with a nil value.
+	Fields map[string]any
+}
+
+// Handler plans and applies one custom resource kind
+type Handler interface {
+	// Kind is the value of the kind field that selects this handler (e.g. acme_widget)
+	Kind() string
+	// Validate checks a loaded resource before it is planned
+	Validate(resource resources.CustomResource) error
+	// List returns the existing instances of the kind. Only instances labeled as managed
+	// in the namespace being planned are considered by the planner.
+	List(ctx context.Context) ([]State, error)
+	// Diff returns the spec fields that must change for current to match desired.
+	// An empty result means no update is needed.
+	Diff(current State, desired resources.CustomResource) map[string]any
+	// Create creates the instance and returns its ID
+	Create(ctx context.Context, req Request) (string, error)
+	// Update updates the instance and returns its ID
+	Update(ctx context.Context, req Request) (string, error)
+	// Delete deletes the instance
+	Delete(ctx context.Context, req Request) error
+}
+
+// DiffSpec is a Diff helper for handlers that store the spec as given. It returns
+// desired values for added or changed keys and nil for keys missing from desired.
+func DiffSpec(current, desired map[string]any) map[string]any {
+	changes := make(map[string]any)
+	for key, value := range desired {
+		if existing, ok := current[key]; !ok || !reflect.DeepEqual(existing, value) {
+			changes[key] = value
+		}
+	}
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	return changes
+}
@@ -0,0 +1,22 @@
+package custom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSpec(t *testing.T) {
+	t.Parallel()
+
+	current := map[string]any{"size": 3, "color": "blue", "legacy": true}
+	desired := map[string]any{"size": 5, "color": "blue", "shape": "round"}
+
+	assert.Equal(t, map[string]any{
+		"size":   5,
+		"shape":  "round",
+		"legacy": nil,
+	}, DiffSpec(current, desired))
+
+	assert.Empty(t, DiffSpec(desired, desired))
+}
@@ -0,0 +1,120 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// MemoryHandler is an example handler that keeps instances in memory. It shows the
+// minimum a handler must do and is used to exercise the plan and apply machinery
+// in tests; real handlers call the API that owns the kind instead of a map.
+type MemoryHandler struct {
+	kind string
+	// Required lists spec fields that every resource of the kind must set
+	Required []string
+
+	mu     sync.Mutex
+	nextID int
+	items  map[string]State
+}
+
+// NewMemoryHandler creates an empty in-memory handler for kind
+func NewMemoryHandler(kind string) *MemoryHandler {
+	return &MemoryHandler{kind: kind, items: make(map[string]State)}
+}
+
+// Kind returns the kind served by the handler
+func (h *MemoryHandler) Kind() string {
+	return h.kind
+}
+
+// Validate checks that the required spec fields are set
+func (h *MemoryHandler) Validate(resource resources.CustomResource) error {
+	for _, field := range h.Required {
+		if _, ok := resource.Spec[field]; !ok {
+			return fmt.Errorf("%s %q: spec.%s is required", h.kind, resource.GetRef(), field)
+		}
+	}
+	return nil
+}
+
+// List returns the stored instances ordered by ID
+func (h *MemoryHandler) List(_ context.Context) ([]State, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	states := make([]State, 0, len(h.items))
+	for _, item := range h.items {
+		states = append(states, copyState(item))
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return states, nil
+}
+
+// Diff compares the stored spec with the desired spec
+func (h *MemoryHandler) Diff(current State, desired resources.CustomResource) map[string]any {
+	return DiffSpec(current.Spec, desired.Spec)
+}
+
+// Create stores a new instance
+func (h *MemoryHandler) Create(_ context.Context, req Request) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	id := fmt.Sprintf("%s-%d", h.kind, h.nextID)
+	spec := make(map[string]any, len(req.Fields))
+	for key, value := range req.Fields {
+		if value != nil {
+			spec[key] = value
+		}
+	}
+	h.items[id] = State{ID: id, Name: req.Name, Labels: maps.Clone(req.Labels), Spec: spec}
+	return id, nil
+}
+
+// Update applies changed fields and labels to a stored instance
+func (h *MemoryHandler) Update(_ context.Context, req Request) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	item, ok := h.items[req.ID]
+	if !ok {
+		return "", fmt.Errorf("%s %q not found", h.kind, req.ID)
+	}
+	for key, value := range req.Fields {
+		if value == nil {
+			delete(item.Spec, key)
+			continue
+		}
+		item.Spec[key] = value
+	}
+	if req.Labels != nil {
+		item.Labels = maps.Clone(req.Labels)
+	}
+	h.items[req.ID] = item
+	return req.ID, nil
+}
+
+// Delete removes a stored instance
+func (h *MemoryHandler) Delete(_ context.Context, req Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.items[req.ID]; !ok {
+		return fmt.Errorf("%s %q not found", h.kind, req.ID)
+	}
+	delete(h.items, req.ID)
+	return nil
+}
+
+func copyState(state State) State {
+	state.Labels = maps.Clone(state.Labels)
+	state.Spec = maps.Clone(state.Spec)
+	return state
+}
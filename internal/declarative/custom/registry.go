@@ -0,0 +1,67 @@
+package custom
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+var (
+	registryMu sync.RWMutex
+	handlers   = make(map[string]Handler)
+)
+
+// Register makes a handler available to the planner and executor
+func Register(handler Handler) error {
+	if handler == nil {
+		return fmt.Errorf("custom resource handler cannot be nil")
+	}
+	kind := handler.Kind()
+	if err := resources.ValidateCustomKind(kind); err != nil {
+		return fmt.Errorf("invalid custom resource handler: %w", err)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := handlers[kind]; exists {
+		return fmt.Errorf("custom resource handler for kind %q is already registered", kind)
+	}
+	handlers[kind] = handler
+	return nil
+}
+
+// MustRegister is like Register but panics on error; intended for init functions
+func MustRegister(handler Handler) {
+	if err := Register(handler); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes the handler for kind, if any
+func Unregister(kind string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(handlers, kind)
+}
+
+// Lookup returns the handler registered for kind
+func Lookup(kind string) (Handler, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	handler, ok := handlers[kind]
+	return handler, ok
+}
+
+// Kinds returns the registered kinds in sorted order
+func Kinds() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	kinds := make([]string, 0, len(handlers))
+	for kind := range handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,36 @@
+package deck
+
+import (
+	"context"
+	"sync"
+)
+
+// DryRunRunner records the deck commands a dry run would run instead of running
+// them. It is safe for concurrent use.
+type DryRunRunner struct {
+	mu       sync.Mutex
+	commands [][]string
+}
+
+// NewDryRunRunner returns a runner that only records deck commands.
+func NewDryRunRunner() *DryRunRunner {
+	return &DryRunRunner{}
+}
+
+// Run records the deck arguments, without the injected Konnect credentials.
+func (r *DryRunRunner) Run(_ context.Context, opts RunOptions) (*RunResult, error) {
+	if len(opts.Args) == 0 {
+		return nil, ErrInvalidArgs{Reason: "args cannot be empty"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands = append(r.commands, append([]string{"deck"}, opts.Args...))
+	return &RunResult{}, nil
+}
+
+// Commands returns the recorded deck commands in the order they would have run.
+func (r *DryRunRunner) Commands() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.commands...)
+}
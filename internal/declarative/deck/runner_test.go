@@ -54,3 +54,15 @@ func TestBuildArgsRejectsConflictingFlags(t *testing.T) {
 	var conflict ErrConflictingFlag
 	require.ErrorAs(t, err, &conflict)
 }
+
+func TestDryRunRunnerRecordsCommands(t *testing.T) {
+	runner := NewDryRunRunner()
+
+	result, err := runner.Run(t.Context(), RunOptions{
+		Args:         []string{"gateway", "apply", "kong.yaml"},
+		KonnectToken: "token-123",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, [][]string{{"deck", "gateway", "apply", "kong.yaml"}}, runner.Commands())
+}
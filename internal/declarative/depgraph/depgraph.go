@@ -0,0 +1,264 @@
+// Package depgraph builds the graph of references between the resources of a
+// declarative configuration, as seen by reference resolution, and renders it in
+// Graphviz DOT or JSON to help diagnose ordering and circular references.
+package depgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+const (
+	// ParentField labels the edge from a child resource to its parent
+	ParentField = "(parent)"
+	// DependencyField labels other dependencies that are not held by a reference field
+	DependencyField = "(dependency)"
+)
+
+// Node is a resource of the configuration. Missing nodes are referenced but not defined.
+type Node struct {
+	Ref     string `json:"ref"`
+	Type    string `json:"type,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+	Cycle   bool   `json:"cycle,omitempty"`
+}
+
+// Edge is a dependency of From on To through the field of From holding the reference
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Field string `json:"field"`
+	Cycle bool   `json:"cycle,omitempty"`
+}
+
+// Graph is the dependency graph of a configuration. Cycles lists the refs of each
+// group of resources that depend on each other.
+type Graph struct {
+	Nodes  []Node     `json:"nodes"`
+	Edges  []Edge     `json:"edges"`
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// Build returns the dependency graph of rs. References are read from !ref tags not
+// resolved yet, reference fields and parents, so rs should be captured before
+// reference resolution replaces the placeholders.
+func Build(rs *resources.ResourceSet) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	defined := make(map[string]bool)
+	rs.ForEachResource(func(resource resources.Resource) bool {
+		g.Nodes = append(g.Nodes, Node{Ref: resource.GetRef(), Type: string(resource.GetType())})
+		defined[resource.GetRef()] = true
+		return true
+	})
+
+	seen := make(map[Edge]bool)
+	addEdge := func(edge Edge) {
+		if edge.To == "" || seen[edge] {
+			return
+		}
+		seen[edge] = true
+		g.Edges = append(g.Edges, edge)
+		if !defined[edge.To] {
+			defined[edge.To] = true
+			g.Nodes = append(g.Nodes, Node{Ref: edge.To, Missing: true})
+		}
+	}
+	rs.ForEachResource(func(resource resources.Resource) bool {
+		for _, edge := range resourceEdges(resource, rs) {
+			addEdge(edge)
+		}
+		return true
+	})
+
+	slices.SortFunc(g.Nodes, func(a, b Node) int { return strings.Compare(a.Ref, b.Ref) })
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.To, b.To); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Field, b.Field)
+	})
+	g.markCycles()
+	return g
+}
+
+// HasCycle reports whether some resources depend on each other
+func (g *Graph) HasCycle() bool {
+	return len(g.Cycles) > 0
+}
+
+// resourceEdges returns the dependencies of a resource on other resources. Parents and
+// other dependencies not held by a reference field get a label in parentheses.
+func resourceEdges(resource resources.Resource, rs *resources.ResourceSet) []Edge {
+	from := resource.GetRef()
+	var edges []Edge
+	referenced := make(map[string]bool)
+
+	referenceFields := make(map[string]bool)
+	if mapping, ok := resource.(resources.ReferenceMapping); ok {
+		for path := range mapping.GetReferenceFieldMappings() {
+			referenceFields[strings.TrimSuffix(path, "[]")] = true
+		}
+	}
+	if data, err := json.Marshal(resource); err == nil {
+		var fields any
+		if err := json.Unmarshal(data, &fields); err == nil {
+			walkStrings(fields, "", func(path, value string) {
+				if ref, _, ok := tags.ParseRefPlaceholder(value); ok {
+					edges = append(edges, Edge{From: from, To: ref, Field: path})
+					referenced[ref] = true
+				} else if referenceFields[path] && value != from && rs.HasRef(value) {
+					edges = append(edges, Edge{From: from, To: value, Field: path})
+					referenced[value] = true
+				}
+			})
+		}
+	}
+
+	if child, ok := resource.(resources.ResourceWithParent); ok {
+		if parent := child.GetParentRef(); parent != nil && parent.Ref != "" && !referenced[parent.Ref] {
+			edges = append(edges, Edge{From: from, To: parent.Ref, Field: ParentField})
+			referenced[parent.Ref] = true
+		}
+	}
+	for _, dep := range resource.GetDependencies() {
+		if dep.Ref != "" && !referenced[dep.Ref] {
+			edges = append(edges, Edge{From: from, To: dep.Ref, Field: DependencyField})
+			referenced[dep.Ref] = true
+		}
+	}
+	return edges
+}
+
+// walkStrings calls fn with every string value in v and the dotted path of the field
+// holding it. Items of a list share the path of the list.
+func walkStrings(v any, path string, fn func(path, value string)) {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, nested := range value {
+			if path != "" {
+				key = path + "." + key
+			}
+			walkStrings(nested, key, fn)
+		}
+	case []any:
+		for _, nested := range value {
+			walkStrings(nested, path, fn)
+		}
+	case string:
+		fn(path, value)
+	}
+}
+
+// markCycles records the strongly connected components of more than one resource, or
+// of a resource referencing itself, and flags their nodes and the edges between them
+func (g *Graph) markCycles() {
+	adjacent := make(map[string][]string)
+	for _, edge := range g.Edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+	}
+
+	// Tarjan's algorithm, visiting nodes in ref order for a stable result
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	component := make(map[string]int)
+	var visit func(ref string)
+	visit = func(ref string) {
+		index[ref] = len(index)
+		lowLink[ref] = index[ref]
+		stack = append(stack, ref)
+		onStack[ref] = true
+		for _, next := range adjacent[ref] {
+			if _, visited := index[next]; !visited {
+				visit(next)
+				lowLink[ref] = min(lowLink[ref], lowLink[next])
+			} else if onStack[next] {
+				lowLink[ref] = min(lowLink[ref], index[next])
+			}
+		}
+		if lowLink[ref] != index[ref] {
+			return
+		}
+		var members []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == ref {
+				break
+			}
+		}
+		if len(members) > 1 || slices.Contains(adjacent[ref], ref) {
+			slices.Sort(members)
+			for _, member := range members {
+				component[member] = len(g.Cycles) + 1
+			}
+			g.Cycles = append(g.Cycles, members)
+		}
+	}
+	for _, node := range g.Nodes {
+		if _, visited := index[node.Ref]; !visited {
+			visit(node.Ref)
+		}
+	}
+
+	for i := range g.Nodes {
+		g.Nodes[i].Cycle = component[g.Nodes[i].Ref] != 0
+	}
+	for i, edge := range g.Edges {
+		g.Edges[i].Cycle = component[edge.From] != 0 && component[edge.From] == component[edge.To]
+	}
+	slices.SortFunc(g.Cycles, func(a, b []string) int { return strings.Compare(a[0], b[0]) })
+}
+
+// WriteDOT renders the graph in Graphviz DOT format, with cycles in red
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		label := node.Ref
+		var attrs []string
+		switch {
+		case node.Missing:
+			label += "\n(not defined)"
+			attrs = append(attrs, "style=dashed")
+		case node.Type != "":
+			label += "\n(" + node.Type + ")"
+		}
+		attrs = append([]string{"label=" + quoteDOT(label)}, attrs...)
+		if node.Cycle {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", quoteDOT(node.Ref), strings.Join(attrs, ", "))
+	}
+	for _, edge := range g.Edges {
+		attrs := []string{"label=" + quoteDOT(edge.Field)}
+		if edge.Cycle {
+			attrs = append(attrs, "color=red", "fontcolor=red", "penwidth=2")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", quoteDOT(edge.From), quoteDOT(edge.To), strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// quoteDOT quotes an identifier or label for DOT, keeping newlines as line breaks
+func quoteDOT(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + strings.ReplaceAll(value, "\n", `\n`) + `"`
+}
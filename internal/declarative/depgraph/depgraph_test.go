@@ -0,0 +1,96 @@
+package depgraph_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/depgraph"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadGraph(t *testing.T, content string) (*depgraph.Graph, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	ldr := loader.New()
+	ldr.RecordDependencyGraph()
+	_, err := ldr.LoadFromSourcesWithContext(context.Background(),
+		[]loader.Source{{Path: path, Type: loader.SourceTypeFile}}, false)
+	return ldr.DependencyGraph(), err
+}
+
+func TestBuild_Edges(t *testing.T) {
+	graph, err := loadGraph(t, `
+portals:
+  - ref: dev-portal
+    name: dev
+apis:
+  - ref: orders
+    name: orders
+    publications:
+      - ref: orders-pub
+        portal_id: dev-portal
+`)
+	require.NoError(t, err)
+	require.NotNil(t, graph)
+
+	assert.Equal(t, []depgraph.Node{
+		{Ref: "dev-portal", Type: "portal"},
+		{Ref: "orders", Type: "api"},
+		{Ref: "orders-pub", Type: "api_publication"},
+	}, graph.Nodes)
+	assert.Equal(t, []depgraph.Edge{
+		{From: "orders-pub", To: "dev-portal", Field: "portal_id"},
+		{From: "orders-pub", To: "orders", Field: depgraph.ParentField},
+	}, graph.Edges)
+	assert.False(t, graph.HasCycle())
+}
+
+func TestBuild_CircularReference(t *testing.T) {
+	graph, err := loadGraph(t, `
+portals:
+  - ref: dev-portal
+    name: dev
+    description: !ref staging-portal#description
+  - ref: staging-portal
+    name: staging
+    description: !ref dev-portal#description
+  - ref: other-portal
+    name: other
+    display_name: !ref dev-portal#name
+`)
+	require.ErrorContains(t, err, "circular reference")
+	require.NotNil(t, graph, "the graph is recorded before references are resolved")
+
+	require.Equal(t, [][]string{{"dev-portal", "staging-portal"}}, graph.Cycles)
+	assert.Equal(t, []depgraph.Edge{
+		{From: "dev-portal", To: "staging-portal", Field: "description", Cycle: true},
+		{From: "other-portal", To: "dev-portal", Field: "display_name"},
+		{From: "staging-portal", To: "dev-portal", Field: "description", Cycle: true},
+	}, graph.Edges)
+
+	var dot bytes.Buffer
+	require.NoError(t, graph.WriteDOT(&dot))
+	assert.Contains(t, dot.String(),
+		`"dev-portal" -> "staging-portal" [label="description", color=red, fontcolor=red, penwidth=2];`)
+	assert.Contains(t, dot.String(), `"other-portal" -> "dev-portal" [label="display_name"];`)
+	assert.Contains(t, dot.String(), `"other-portal" [label="other-portal\n(portal)"];`)
+}
+
+func TestBuild_MissingReference(t *testing.T) {
+	graph, err := loadGraph(t, `
+portals:
+  - ref: dev-portal
+    name: dev
+    description: !ref missing-portal#description
+`)
+	require.Error(t, err)
+	require.NotNil(t, graph)
+	assert.Contains(t, graph.Nodes, depgraph.Node{Ref: "missing-portal", Missing: true})
+}
@@ -0,0 +1,113 @@
+package drift
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// ConflictStrategy tells apply and sync what to do with differences made in Konnect
+// since the last apply, which the configuration would otherwise revert
+type ConflictStrategy string
+
+const (
+	// ConflictOurs applies the configuration over the changes made in Konnect
+	ConflictOurs ConflictStrategy = "ours"
+	// ConflictTheirs keeps the fields changed in Konnect
+	ConflictTheirs ConflictStrategy = "theirs"
+	// ConflictFail stops before anything changed in Konnect is reverted
+	ConflictFail ConflictStrategy = "fail"
+)
+
+// ConflictStrategies are the accepted values of ConflictStrategy
+var ConflictStrategies = []ConflictStrategy{ConflictOurs, ConflictTheirs, ConflictFail}
+
+// ParseConflictStrategy validates a conflict strategy. An empty value is ConflictOurs.
+func ParseConflictStrategy(value string) (ConflictStrategy, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ConflictOurs, nil
+	}
+	strategy := ConflictStrategy(value)
+	if !slices.Contains(ConflictStrategies, strategy) {
+		return "", fmt.Errorf("invalid conflict strategy %q, must be one of ours, theirs or fail", value)
+	}
+	return strategy, nil
+}
+
+// ConflictError is returned by ResolveConflicts with ConflictFail
+type ConflictError struct {
+	Conflicts []Difference
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%d difference(s) made in Konnect since the last apply would be reverted: %s",
+		len(e.Conflicts), strings.Join(DescribeConflicts(e.Conflicts), ", "))
+}
+
+// ResolveConflicts finds the changes of plan that revert differences made in Konnect
+// since the last apply and handles them with strategy. ConflictOurs leaves the plan
+// as it is, ConflictTheirs removes the fields changed in Konnect from the updates and
+// drops updates left with nothing to change, and ConflictFail returns a ConflictError.
+// Resources deleted in Konnect and protection changes are conflicts too, but are
+// still applied with ConflictTheirs. The conflicts found are returned.
+func ResolveConflicts(plan *planner.Plan, applied Resources, strategy ConflictStrategy) ([]Difference, error) {
+	conflicts := Detect(plan, applied).Drift
+	if len(conflicts) == 0 {
+		return conflicts, nil
+	}
+
+	switch strategy {
+	case ConflictFail:
+		return conflicts, &ConflictError{Conflicts: conflicts}
+	case ConflictTheirs:
+		keepTheirs(plan, conflicts)
+	case ConflictOurs:
+	}
+	return conflicts, nil
+}
+
+// keepTheirs removes the conflicting fields from the updates of plan
+func keepTheirs(plan *planner.Plan, conflicts []Difference) {
+	kept := make(map[string][]string)
+	for _, conflict := range conflicts {
+		if conflict.Field != "" && conflict.Field != ProtectedField {
+			key := Key(conflict.ResourceType, conflict.ResourceRef)
+			kept[key] = append(kept[key], conflict.Field)
+		}
+	}
+
+	var dropped []string
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		fields, ok := kept[Key(change.ResourceType, change.ResourceRef)]
+		if !ok || (change.Action != planner.ActionUpdate && change.Action != planner.ActionSwitch) {
+			continue
+		}
+		for _, field := range fields {
+			delete(change.Fields, field)
+		}
+		if _, protectionChanged := protectionChange(change.Protection); !protectionChanged &&
+			len(configuredFields(*change)) == 0 {
+			dropped = append(dropped, change.ID)
+		}
+	}
+	*plan = *plan.WithoutChanges(dropped)
+}
+
+// DescribeConflicts returns a short description of each conflict
+func DescribeConflicts(conflicts []Difference) []string {
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		if conflict.Field == "" {
+			descriptions = append(descriptions,
+				fmt.Sprintf("%s %q deleted", conflict.ResourceType, conflict.ResourceRef))
+			continue
+		}
+		descriptions = append(descriptions,
+			fmt.Sprintf("%s %q %s", conflict.ResourceType, conflict.ResourceRef, conflict.Field))
+	}
+	return descriptions
+}
@@ -0,0 +1,98 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/require"
+)
+
+func conflictPlan() (*planner.Plan, Resources) {
+	applied := Resources{
+		Key("portal", "dev"): {ResourceID: "portal-1", Fields: map[string]string{
+			"description": fingerprint("Developer portal"),
+		}},
+		Key("api", "orders"): {ResourceID: "api-1", Fields: map[string]string{"version": fingerprint("v1")}},
+	}
+
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	// The description was changed in Konnect, the title in the configuration
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		ResourceID:   "portal-1",
+		Action:       planner.ActionUpdate,
+		Fields:       map[string]any{"description": "Developer portal", "title": "Dev"},
+	})
+	// Only the version was changed, in Konnect
+	plan.AddChange(planner.PlannedChange{
+		ID:             "2:u:api:orders",
+		ResourceType:   "api",
+		ResourceRef:    "orders",
+		ResourceID:     "api-1",
+		Action:         planner.ActionUpdate,
+		Fields:         map[string]any{"name": "orders", "version": "v1"},
+		IdentityFields: []string{"name"},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID:           "3:c:api_version:orders-v2",
+		ResourceType: "api_version",
+		ResourceRef:  "orders-v2",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"version": "v2"},
+		DependsOn:    []string{"2:u:api:orders"},
+	})
+	plan.SetExecutionOrder([]string{"1:u:portal:dev", "2:u:api:orders", "3:c:api_version:orders-v2"})
+	return plan, applied
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	strategy, err := ParseConflictStrategy("")
+	require.NoError(t, err)
+	require.Equal(t, ConflictOurs, strategy)
+
+	strategy, err = ParseConflictStrategy(" theirs ")
+	require.NoError(t, err)
+	require.Equal(t, ConflictTheirs, strategy)
+
+	_, err = ParseConflictStrategy("mine")
+	require.Error(t, err)
+}
+
+func TestResolveConflicts_Ours(t *testing.T) {
+	plan, applied := conflictPlan()
+
+	conflicts, err := ResolveConflicts(plan, applied, ConflictOurs)
+	require.NoError(t, err)
+	require.Equal(t, []string{`portal "dev" description`, `api "orders" version`}, DescribeConflicts(conflicts))
+	require.Len(t, plan.Changes, 3)
+	require.Contains(t, plan.Changes[0].Fields, "description")
+}
+
+func TestResolveConflicts_Theirs(t *testing.T) {
+	plan, applied := conflictPlan()
+
+	conflicts, err := ResolveConflicts(plan, applied, ConflictTheirs)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+
+	// The API update only reverted Konnect and is dropped with its dependency
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, map[string]any{"title": "Dev"}, plan.Changes[0].Fields)
+	require.Equal(t, "3:c:api_version:orders-v2", plan.Changes[1].ID)
+	require.Empty(t, plan.Changes[1].DependsOn)
+	require.Equal(t, []string{"1:u:portal:dev", "3:c:api_version:orders-v2"}, plan.ExecutionOrder)
+	require.Equal(t, 2, plan.Summary.TotalChanges)
+}
+
+func TestResolveConflicts_Fail(t *testing.T) {
+	plan, applied := conflictPlan()
+
+	_, err := ResolveConflicts(plan, applied, ConflictFail)
+	var conflictErr *ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 2)
+	require.Contains(t, err.Error(), `portal "dev" description`)
+	require.Len(t, plan.Changes, 3)
+}
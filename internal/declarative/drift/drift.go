@@ -0,0 +1,206 @@
+// Package drift tells apart differences between Konnect and the declarative
+// configuration that were made in Konnect from those made in the configuration,
+// using what kongctl last applied to each resource.
+package drift
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// ProtectedField names the protection of a resource among its fields
+const ProtectedField = "kongctl.protected"
+
+// Kind tells which side changed since the last apply
+type Kind string
+
+const (
+	// KindDrift is a difference made in Konnect while the configuration is unchanged
+	KindDrift Kind = "drift"
+	// KindPending is a difference made in the configuration that was not applied yet
+	KindPending Kind = "pending"
+)
+
+// Difference is a field of a managed resource whose live value does not match the
+// configuration. Field is empty when the resource is missing from Konnect.
+type Difference struct {
+	Kind         Kind   `json:"kind"                  yaml:"kind"`
+	ResourceType string `json:"resource_type"         yaml:"resource_type"`
+	ResourceRef  string `json:"resource_ref"          yaml:"resource_ref"`
+	ResourceID   string `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+	Namespace    string `json:"namespace,omitempty"   yaml:"namespace,omitempty"`
+	Field        string `json:"field,omitempty"       yaml:"field,omitempty"`
+	// Desired is the configured value of the field
+	Desired any `json:"desired,omitempty" yaml:"desired,omitempty"`
+}
+
+// Describe returns a sentence telling what differs, e.g. for reports and alerts
+func (d Difference) Describe() string {
+	switch {
+	case d.Kind == KindDrift && d.Field == "":
+		return fmt.Sprintf("%s %q was deleted in Konnect", d.ResourceType, d.ResourceRef)
+	case d.Kind == KindDrift:
+		return fmt.Sprintf("%s %q %s differs from the applied value %v", d.ResourceType, d.ResourceRef, d.Field, d.Desired)
+	case d.Field == "":
+		return fmt.Sprintf("%s %q will be created", d.ResourceType, d.ResourceRef)
+	default:
+		return fmt.Sprintf("%s %q %s will be set to %v", d.ResourceType, d.ResourceRef, d.Field, d.Desired)
+	}
+}
+
+// Report lists the differences between Konnect and the configuration
+type Report struct {
+	Drift   []Difference `json:"drift"   yaml:"drift"`
+	Pending []Difference `json:"pending" yaml:"pending"`
+}
+
+// HasDrift reports whether anything was changed in Konnect since the last apply
+func (r *Report) HasDrift() bool {
+	return len(r.Drift) > 0
+}
+
+// AssumeApplied reports every difference as drift, for configuration that is applied
+// as soon as it changes, such as the main branch of a GitOps repository, when checked
+// from a machine without a record of the applies
+func (r *Report) AssumeApplied() {
+	for _, difference := range r.Pending {
+		difference.Kind = KindDrift
+		r.Drift = append(r.Drift, difference)
+	}
+	r.Pending = []Difference{}
+}
+
+// IsEmpty reports whether Konnect matches the configuration
+func (r *Report) IsEmpty() bool {
+	return len(r.Drift) == 0 && len(r.Pending) == 0
+}
+
+// Detect classifies the changes of an apply mode plan. The plan only covers
+// resources carrying kongctl's management labels. A differing field whose configured
+// value is the one last applied was changed in Konnect; any other difference,
+// including fields kongctl has no record of applying, is pending. A resource that
+// was applied but is missing from Konnect was deleted there. Changes run by deck are
+// not classified, as deck reconciles gateway entities itself.
+func Detect(plan *planner.Plan, applied Resources) *Report {
+	report := &Report{Drift: []Difference{}, Pending: []Difference{}}
+	for _, change := range plan.Changes {
+		resource, recorded := applied[Key(change.ResourceType, change.ResourceRef)]
+		if recorded && change.ResourceID != "" && resource.ResourceID != "" &&
+			resource.ResourceID != change.ResourceID {
+			// The live resource is not the one kongctl applied
+			recorded = false
+		}
+
+		base := Difference{
+			ResourceType: change.ResourceType,
+			ResourceRef:  change.ResourceRef,
+			ResourceID:   change.ResourceID,
+			Namespace:    change.Namespace,
+		}
+
+		switch change.Action {
+		case planner.ActionCreate:
+			if recorded {
+				base.Kind = KindDrift
+				base.ResourceID = resource.ResourceID
+				report.Drift = append(report.Drift, base)
+			} else {
+				base.Kind = KindPending
+				report.Pending = append(report.Pending, base)
+			}
+		case planner.ActionUpdate, planner.ActionSwitch:
+			fields := configuredFields(change)
+			if protected, changed := protectionChange(change.Protection); changed {
+				fields[ProtectedField] = protected
+			}
+			for _, field := range sortedFields(fields) {
+				difference := base
+				difference.Field = field
+				difference.Desired = fields[field]
+				if recorded && resource.Fields[field] == fingerprint(fields[field]) {
+					difference.Kind = KindDrift
+					report.Drift = append(report.Drift, difference)
+				} else {
+					difference.Kind = KindPending
+					report.Pending = append(report.Pending, difference)
+				}
+			}
+		case planner.ActionDelete, planner.ActionExternalTool:
+		}
+	}
+	return report
+}
+
+// ExplainOrigins marks the explained fields of the plan's changes that were changed in
+// Konnect since the last apply, as Detect reports them, with planner.OriginDrift.
+// Fields that only differ in defaults keep planner.OriginDefault.
+func ExplainOrigins(plan *planner.Plan, applied Resources) {
+	drifted := make(map[string]bool)
+	for _, difference := range Detect(plan, applied).Drift {
+		if difference.Field != "" {
+			drifted[Key(difference.ResourceType, difference.ResourceRef)+"."+difference.Field] = true
+		}
+	}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		for j := range change.Explanation {
+			explanation := &change.Explanation[j]
+			key := Key(change.ResourceType, change.ResourceRef) + "." + explanation.Field
+			if drifted[key] && explanation.Origin != planner.OriginDefault {
+				explanation.Origin = planner.OriginDrift
+			}
+		}
+	}
+}
+
+// configuredFields returns the fields of a change that come from the configuration,
+// leaving out the internal fields the planner passes to the executor and the fields
+// that only identify the resource
+func configuredFields(change planner.PlannedChange) map[string]any {
+	fields := make(map[string]any, len(change.Fields))
+	for name, value := range change.Fields {
+		if !strings.HasPrefix(name, "_") && !slices.Contains(change.IdentityFields, name) {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+// protectionChange returns the protection an UPDATE sets, and whether it changes it
+func protectionChange(protection any) (bool, bool) {
+	switch p := protection.(type) {
+	case planner.ProtectionChange:
+		return p.New, p.Old != p.New
+	case map[string]any:
+		// Plans loaded from JSON hold the change as a map
+		oldValue, _ := p["old"].(bool)
+		newValue, ok := p["new"].(bool)
+		return newValue, ok && oldValue != newValue
+	default:
+		return false, false
+	}
+}
+
+// desiredProtection returns the protection a change sets, if any
+func desiredProtection(protection any) (bool, bool) {
+	if protected, ok := protection.(bool); ok {
+		return protected, true
+	}
+	if protected, changed := protectionChange(protection); changed {
+		return protected, true
+	}
+	return false, false
+}
+
+func sortedFields(fields map[string]any) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
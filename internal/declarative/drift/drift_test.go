@@ -0,0 +1,277 @@
+package drift
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/require"
+)
+
+func createPlan() *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:c:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "dev", "description": "Developer portal"},
+		Protection:   true,
+		Namespace:    "default",
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID:           "2:c:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "orders", "version": "v1"},
+		Namespace:    "default",
+	})
+	return plan
+}
+
+func createResult() *executor.ExecutionResult {
+	return &executor.ExecutionResult{ChangesApplied: []executor.AppliedChange{
+		{ChangeID: "1:c:portal:dev", ResourceType: "portal", ResourceRef: "dev", Action: "CREATE", ResourceID: "portal-1"},
+		{ChangeID: "2:c:api:orders", ResourceType: "api", ResourceRef: "orders", Action: "CREATE", ResourceID: "api-1"},
+	}}
+}
+
+func TestStore_RecordAndLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", storeFileName))
+
+	applied, err := store.Load("default")
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, store.Record("default", createPlan(), createResult(), now))
+
+	applied, err = store.Load("default")
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	portal := applied[Key("portal", "dev")]
+	require.Equal(t, "portal-1", portal.ResourceID)
+	require.True(t, portal.AppliedAt.Equal(now))
+	require.Equal(t, fingerprint("Developer portal"), portal.Fields["description"])
+	require.Equal(t, fingerprint(true), portal.Fields[ProtectedField])
+
+	other, err := store.Load("prod")
+	require.NoError(t, err)
+	require.Empty(t, other, "records are kept per profile")
+
+	// Updates add to the record and deletes remove it
+	update := planner.NewPlan("1.0", "test", planner.PlanModeSync)
+	update.AddChange(planner.PlannedChange{
+		ID:             "1:u:portal:dev",
+		ResourceType:   "portal",
+		ResourceRef:    "dev",
+		ResourceID:     "portal-1",
+		Action:         planner.ActionUpdate,
+		Fields:         map[string]any{"name": "dev", "description": "Portal", "_current_labels": map[string]string{}},
+		IdentityFields: []string{"name"},
+	})
+	update.AddChange(planner.PlannedChange{
+		ID:           "2:d:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		ResourceID:   "api-1",
+		Action:       planner.ActionDelete,
+	})
+	require.NoError(t, store.Record("default", update, &executor.ExecutionResult{
+		ChangesApplied: []executor.AppliedChange{
+			{ChangeID: "1:u:portal:dev", Action: "UPDATE", ResourceID: "portal-1"},
+			{ChangeID: "2:d:api:orders", Action: "DELETE", ResourceID: "api-1"},
+		},
+	}, now))
+
+	applied, err = store.Load("default")
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	portal = applied[Key("portal", "dev")]
+	require.Equal(t, fingerprint("Portal"), portal.Fields["description"])
+	require.Equal(t, fingerprint("dev"), portal.Fields["name"], "the name recorded at create is kept")
+	require.NotContains(t, portal.Fields, "_current_labels")
+}
+
+func TestStore_RecordMovedResource(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), storeFileName))
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, store.Record("default", createPlan(), createResult(), now))
+
+	moved := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	moved.AddChange(planner.PlannedChange{
+		ID:           "1:u:portal:developer-hub",
+		ResourceType: "portal",
+		ResourceRef:  "developer-hub",
+		ResourceID:   "portal-1",
+		Action:       planner.ActionUpdate,
+		Fields:       map[string]any{"name": "Developer Hub"},
+		MovedFrom:    "dev",
+	})
+	require.NoError(t, store.Record("default", moved, &executor.ExecutionResult{
+		ChangesApplied: []executor.AppliedChange{
+			{ChangeID: "1:u:portal:developer-hub", Action: "UPDATE", ResourceID: "portal-1"},
+		},
+	}, now))
+
+	applied, err := store.Load("default")
+	require.NoError(t, err)
+	require.NotContains(t, applied, Key("portal", "dev"))
+	portal := applied[Key("portal", "developer-hub")]
+	require.Equal(t, "portal-1", portal.ResourceID)
+	require.Equal(t, fingerprint("Developer portal"), portal.Fields["description"], "the former record is kept")
+	require.Equal(t, fingerprint("Developer Hub"), portal.Fields["name"])
+}
+
+func TestStore_RecordSkipsDryRuns(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), storeFileName))
+	result := createResult()
+	result.DryRun = true
+
+	require.NoError(t, store.Record("default", createPlan(), result, time.Now()))
+
+	applied, err := store.Load("default")
+	require.NoError(t, err)
+	require.Empty(t, applied)
+}
+
+func TestDetect(t *testing.T) {
+	applied := Resources{
+		Key("portal", "dev"): {ResourceID: "portal-1", Fields: map[string]string{
+			"name":         fingerprint("dev"),
+			"description":  fingerprint("Developer portal"),
+			ProtectedField: fingerprint(true),
+		}},
+		Key("api", "orders"): {ResourceID: "api-1", Fields: map[string]string{"version": fingerprint("v1")}},
+	}
+
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		ResourceID:   "portal-1",
+		Action:       planner.ActionUpdate,
+		// The description was changed in Konnect, the title in the configuration
+		Fields:         map[string]any{"name": "dev", "description": "Developer portal", "title": "Dev"},
+		Protection:     planner.ProtectionChange{Old: false, New: true},
+		IdentityFields: []string{"name"},
+		Namespace:      "default",
+	})
+	// The API was deleted in Konnect
+	plan.AddChange(planner.PlannedChange{
+		ID:           "2:c:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "orders", "version": "v1"},
+	})
+	// The API was added to the configuration
+	plan.AddChange(planner.PlannedChange{
+		ID:           "3:c:api:payments",
+		ResourceType: "api",
+		ResourceRef:  "payments",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "payments"},
+	})
+
+	report := Detect(plan, applied)
+
+	require.True(t, report.HasDrift())
+	require.Equal(t, []Difference{
+		{
+			Kind: KindDrift, ResourceType: "portal", ResourceRef: "dev", ResourceID: "portal-1",
+			Namespace: "default", Field: "description", Desired: "Developer portal",
+		},
+		{
+			Kind: KindDrift, ResourceType: "portal", ResourceRef: "dev", ResourceID: "portal-1",
+			Namespace: "default", Field: ProtectedField, Desired: true,
+		},
+		{Kind: KindDrift, ResourceType: "api", ResourceRef: "orders", ResourceID: "api-1"},
+	}, report.Drift)
+	require.Equal(t, []Difference{
+		{
+			Kind: KindPending, ResourceType: "portal", ResourceRef: "dev", ResourceID: "portal-1",
+			Namespace: "default", Field: "title", Desired: "Dev",
+		},
+		{Kind: KindPending, ResourceType: "api", ResourceRef: "payments"},
+	}, report.Pending)
+}
+
+func TestDetect_RecreatedResourceIsPending(t *testing.T) {
+	applied := Resources{
+		Key("api", "orders"): {ResourceID: "api-1", Fields: map[string]string{"version": fingerprint("v1")}},
+	}
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		ResourceID:   "api-2",
+		Action:       planner.ActionUpdate,
+		Fields:       map[string]any{"version": "v1"},
+	})
+
+	report := Detect(plan, applied)
+
+	require.False(t, report.HasDrift())
+	require.Len(t, report.Pending, 1)
+	require.Equal(t, "version", report.Pending[0].Field)
+}
+
+func TestReport_AssumeApplied(t *testing.T) {
+	report := &Report{
+		Drift:   []Difference{{Kind: KindDrift, ResourceType: "api", ResourceRef: "orders"}},
+		Pending: []Difference{{Kind: KindPending, ResourceType: "portal", ResourceRef: "dev", Field: "title"}},
+	}
+
+	report.AssumeApplied()
+
+	require.Empty(t, report.Pending)
+	require.Equal(t, []Difference{
+		{Kind: KindDrift, ResourceType: "api", ResourceRef: "orders"},
+		{Kind: KindDrift, ResourceType: "portal", ResourceRef: "dev", Field: "title"},
+	}, report.Drift)
+}
+
+func TestExplainOrigins(t *testing.T) {
+	applied := Resources{
+		Key("portal", "dev"): {ResourceID: "portal-1", Fields: map[string]string{
+			"description":            fingerprint("Developer portal"),
+			"authentication_enabled": fingerprint(false),
+		}},
+	}
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:portal:dev",
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		ResourceID:   "portal-1",
+		Action:       planner.ActionUpdate,
+		Fields: map[string]any{
+			"description":            "Developer portal",
+			"authentication_enabled": false,
+			"title":                  "Dev",
+		},
+		Explanation: []planner.FieldExplanation{
+			{Field: "authentication_enabled", New: false, Origin: planner.OriginDefault},
+			{Field: "description", Old: "Edited", New: "Developer portal", Origin: planner.OriginConfig},
+			{Field: "title", Old: "Portal", New: "Dev", Origin: planner.OriginConfig},
+		},
+	})
+
+	ExplainOrigins(plan, applied)
+
+	origins := make(map[string]planner.ChangeOrigin)
+	for _, explanation := range plan.Changes[0].Explanation {
+		origins[explanation.Field] = explanation.Origin
+	}
+	require.Equal(t, map[string]planner.ChangeOrigin{
+		"authentication_enabled": planner.OriginDefault,
+		"description":            planner.OriginDrift,
+		"title":                  planner.OriginConfig,
+	}, origins)
+}
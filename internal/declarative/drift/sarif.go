@@ -0,0 +1,176 @@
+package drift
+
+import (
+	"fmt"
+)
+
+const (
+	// SARIFVersion is the version of the SARIF format of the report
+	SARIFVersion = "2.1.0"
+	// SARIFSchema is the JSON schema of SARIFVersion
+	SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// RuleDrift identifies the results of resources changed or deleted in Konnect
+	RuleDrift = "kongctl/drift"
+	// RulePending identifies the results of configuration changes not applied yet
+	RulePending = "kongctl/pending"
+)
+
+// SARIFLog is a report in the Static Analysis Results Interchange Format, which code
+// scanning services such as GitHub read to raise alerts
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun holds the results of one run of kongctl
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes kongctl and the rules of its results
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes kongctl and the rules of its results
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a kind of difference
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+	FullDescription  SARIFMessage `json:"fullDescription"`
+	DefaultLevel     SARIFLevel   `json:"defaultConfiguration"`
+}
+
+// SARIFLevel is the default configuration of a rule
+type SARIFLevel struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is the text of a message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a difference
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          Difference        `json:"properties"`
+}
+
+// SARIFLocation is the place in the configuration of a difference
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is the file, and line when known, of a location
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the file of a location
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the line of a location
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// Locator returns the configuration file, and line when known, declaring a resource
+// ref. An empty path leaves the result without a location.
+type Locator func(ref string) (path string, line int)
+
+// SARIF converts the report to a SARIF log. Drift results are errors, as they would
+// be reverted by the next apply, and pending changes are notes. Every result is
+// fingerprinted by its resource and field, so a code scanning service tracks an alert
+// across runs until the difference is resolved.
+func (r *Report) SARIF(toolVersion string, locate Locator) *SARIFLog {
+	results := make([]SARIFResult, 0, len(r.Drift)+len(r.Pending))
+	for _, difference := range r.Drift {
+		results = append(results, sarifResult(difference, RuleDrift, "error", locate))
+	}
+	for _, difference := range r.Pending {
+		results = append(results, sarifResult(difference, RulePending, "note", locate))
+	}
+
+	return &SARIFLog{
+		Version: SARIFVersion,
+		Schema:  SARIFSchema,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           "kongctl",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/Kong/kongctl",
+				Rules: []SARIFRule{
+					{
+						ID:   RuleDrift,
+						Name: "KonnectDrift",
+						ShortDescription: SARIFMessage{
+							Text: "Managed resource changed in Konnect outside of the configuration",
+						},
+						FullDescription: SARIFMessage{
+							Text: "A field of a managed resource was changed, or the resource deleted, in Konnect " +
+								"while the configuration still holds the value kongctl last applied. " +
+								"The next apply reverts the change.",
+						},
+						DefaultLevel: SARIFLevel{Level: "error"},
+					},
+					{
+						ID:   RulePending,
+						Name: "PendingChange",
+						ShortDescription: SARIFMessage{
+							Text: "Configuration change not applied to Konnect yet",
+						},
+						FullDescription: SARIFMessage{
+							Text: "The configuration changed since it was last applied, or declares a resource " +
+								"missing from Konnect. The next apply makes the change.",
+						},
+						DefaultLevel: SARIFLevel{Level: "note"},
+					},
+				},
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifResult(difference Difference, ruleID, level string, locate Locator) SARIFResult {
+	result := SARIFResult{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: SARIFMessage{Text: difference.Describe()},
+		PartialFingerprints: map[string]string{
+			"kongctlDifference/v1": fmt.Sprintf("%s/%s/%s/%s",
+				difference.Kind, difference.ResourceType, difference.ResourceRef, difference.Field),
+		},
+		Properties: difference,
+	}
+	if locate == nil {
+		return result
+	}
+	if path, line := locate(difference.ResourceRef); path != "" {
+		location := SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{URI: path}}
+		if line > 0 {
+			location.Region = &SARIFRegion{StartLine: line}
+		}
+		result.Locations = []SARIFLocation{{PhysicalLocation: location}}
+	}
+	return result
+}
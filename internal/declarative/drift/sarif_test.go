@@ -0,0 +1,57 @@
+package drift
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport_SARIF(t *testing.T) {
+	report := &Report{
+		Drift: []Difference{
+			{Kind: KindDrift, ResourceType: "portal", ResourceRef: "dev", Field: "description", Desired: "Dev portal"},
+			{Kind: KindDrift, ResourceType: "api", ResourceRef: "orders"},
+		},
+		Pending: []Difference{{Kind: KindPending, ResourceType: "api", ResourceRef: "payments"}},
+	}
+	locate := func(ref string) (string, int) {
+		switch ref {
+		case "dev":
+			return "config/portal.yaml", 3
+		case "orders":
+			return "config/apis.yaml", 0
+		}
+		return "", 0
+	}
+
+	log := report.SARIF("1.2.3", locate)
+
+	require.Equal(t, SARIFVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	require.Equal(t, "kongctl", run.Tool.Driver.Name)
+	require.Equal(t, "1.2.3", run.Tool.Driver.Version)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	require.Len(t, run.Results, 3)
+
+	require.Equal(t, RuleDrift, run.Results[0].RuleID)
+	require.Equal(t, "error", run.Results[0].Level)
+	require.Equal(t, `portal "dev" description differs from the applied value Dev portal`,
+		run.Results[0].Message.Text)
+	require.Equal(t, []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{
+		ArtifactLocation: SARIFArtifactLocation{URI: "config/portal.yaml"},
+		Region:           &SARIFRegion{StartLine: 3},
+	}}}, run.Results[0].Locations)
+	require.Equal(t, "drift/portal/dev/description", run.Results[0].PartialFingerprints["kongctlDifference/v1"])
+
+	require.Nil(t, run.Results[1].Locations[0].PhysicalLocation.Region, "unknown lines are left out")
+
+	require.Equal(t, RulePending, run.Results[2].RuleID)
+	require.Equal(t, "note", run.Results[2].Level)
+	require.Empty(t, run.Results[2].Locations)
+
+	data, err := json.Marshal(log)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"$schema":"`+SARIFSchema+`"`)
+}
@@ -0,0 +1,236 @@
+package drift
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
+)
+
+const (
+	defaultDirPerm  = 0o700
+	defaultFilePerm = 0o600
+
+	storeFileName = "last-applied.json"
+)
+
+// storeMu serializes rewrites of store files, as profiles may be applied concurrently
+var storeMu sync.Mutex
+
+// Resource is what kongctl last applied to a resource. Field values are kept as
+// fingerprints, so the store never holds secrets from the configuration.
+type Resource struct {
+	ResourceID string            `json:"resource_id,omitempty"`
+	AppliedAt  time.Time         `json:"applied_at"`
+	Fields     map[string]string `json:"fields,omitempty"`
+}
+
+// Resources maps resource keys, as returned by Key, to what was last applied
+type Resources map[string]Resource
+
+// storeFile is the layout of the store on disk, grouped by profile
+type storeFile struct {
+	Profiles map[string]Resources `json:"profiles"`
+}
+
+// Store is a JSON file recording the configuration kongctl last applied to each
+// resource of each profile
+type Store struct {
+	path string
+}
+
+// DefaultPath returns the store location inside the kongctl state directory, see
+// config.GetStateDir
+func DefaultPath() (string, error) {
+	baseDir, err := config.GetStateDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve state path: %w", err)
+	}
+	return filepath.Join(baseDir, storeFileName), nil
+}
+
+// NewStore creates a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Key identifies a resource in the store
+func Key(resourceType, resourceRef string) string {
+	return resourceType + ":" + resourceRef
+}
+
+// Load returns the resources recorded for profile. A missing store yields no resources.
+func (s *Store) Load(profile string) (Resources, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return file.Profiles[profile], nil
+}
+
+// Record stores the fields of the changes of plan that result applied to profile.
+// Creates replace what was recorded, updates add to it and deletes remove it.
+func (s *Store) Record(profile string, plan *planner.Plan, result *executor.ExecutionResult, at time.Time) error {
+	if plan == nil || result == nil || result.DryRun || len(result.ChangesApplied) == 0 {
+		return nil
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	recorded := file.Profiles[profile]
+	if recorded == nil {
+		recorded = make(Resources)
+		file.Profiles[profile] = recorded
+	}
+
+	planned := make(map[string]*planner.PlannedChange, len(plan.Changes))
+	for i := range plan.Changes {
+		planned[plan.Changes[i].ID] = &plan.Changes[i]
+	}
+
+	for _, applied := range result.ChangesInEffect() {
+		change := planned[applied.ChangeID]
+		if change == nil {
+			continue
+		}
+		key := Key(change.ResourceType, change.ResourceRef)
+
+		switch change.Action {
+		case planner.ActionDelete:
+			delete(recorded, key)
+		case planner.ActionCreate:
+			recorded[key] = Resource{ResourceID: applied.ResourceID, AppliedAt: at, Fields: fingerprints(*change)}
+		case planner.ActionUpdate, planner.ActionSwitch:
+			// A moved resource keeps the record of its former ref
+			if change.MovedFrom != "" {
+				if former, ok := recorded[Key(change.ResourceType, change.MovedFrom)]; ok {
+					recorded[key] = former
+					delete(recorded, Key(change.ResourceType, change.MovedFrom))
+				}
+			}
+			resource := recorded[key]
+			// A resource recreated outside kongctl starts a new record
+			if resource.ResourceID != "" && applied.ResourceID != "" && resource.ResourceID != applied.ResourceID {
+				resource = Resource{}
+			}
+			if applied.ResourceID != "" {
+				resource.ResourceID = applied.ResourceID
+			}
+			if resource.Fields == nil {
+				resource.Fields = make(map[string]string)
+			}
+			for field, fingerprint := range fingerprints(*change) {
+				resource.Fields[field] = fingerprint
+			}
+			resource.AppliedAt = at
+			recorded[key] = resource
+		case planner.ActionExternalTool:
+			// deck reconciles gateway entities itself, so there is nothing to record
+		}
+	}
+
+	return s.write(file)
+}
+
+// RecordImport records a resource that kongctl import brought under management. No
+// fields were applied yet, so none are recorded; a record of the same resource is kept.
+func (s *Store) RecordImport(profile, resourceType, resourceRef, resourceID string, at time.Time) error {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+	recorded := file.Profiles[profile]
+	if recorded == nil {
+		recorded = make(Resources)
+		file.Profiles[profile] = recorded
+	}
+
+	key := Key(resourceType, resourceRef)
+	if resource, ok := recorded[key]; ok && resource.ResourceID == resourceID {
+		return nil
+	}
+	recorded[key] = Resource{ResourceID: resourceID, AppliedAt: at}
+	return s.write(file)
+}
+
+func (s *Store) read() (*storeFile, error) {
+	file := &storeFile{}
+	data, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read last applied configuration: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("parse last applied configuration %s: %w", s.path, err)
+		}
+	}
+	if file.Profiles == nil {
+		file.Profiles = make(map[string]Resources)
+	}
+	return file, nil
+}
+
+func (s *Store) write(file *storeFile) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), defaultDirPerm); err != nil {
+		return fmt.Errorf("create last applied configuration directory: %w", err)
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode last applied configuration: %w", err)
+	}
+
+	// Write to a temporary file first, so an interrupted write keeps the previous store
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, defaultFilePerm); err != nil {
+		return fmt.Errorf("write last applied configuration: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write last applied configuration: %w", err)
+	}
+	return nil
+}
+
+// fingerprints returns the fingerprint of each configured field of a change,
+// including the protection it sets
+func fingerprints(change planner.PlannedChange) map[string]string {
+	fields := configuredFields(change)
+	result := make(map[string]string, len(fields)+1)
+	for field, value := range fields {
+		result[field] = fingerprint(value)
+	}
+	if protected, ok := desiredProtection(change.Protection); ok {
+		result[ProtectedField] = fingerprint(protected)
+	}
+	return result
+}
+
+// fingerprint hashes the JSON form of value, so typed values from a fresh plan and
+// maps from a plan file compare equal
+func fingerprint(value any) string {
+	if data, err := json.Marshal(value); err == nil {
+		var normalized any
+		if err := json.Unmarshal(data, &normalized); err == nil {
+			value = normalized
+		}
+	}
+	return redact.Hash(value)
+}
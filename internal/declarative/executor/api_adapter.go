@@ -198,3 +198,8 @@ func (a *APIResourceInfo) GetLabels() map[string]string {
 func (a *APIResourceInfo) GetNormalizedLabels() map[string]string {
 	return a.api.NormalizedLabels
 }
+
+// Snapshot returns the api as read from Konnect
+func (a *APIResourceInfo) Snapshot() any {
+	return a.api
+}
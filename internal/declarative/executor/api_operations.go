@@ -67,7 +67,7 @@ func (e *Executor) deleteAPI(ctx context.Context, change planner.PlannedChange)
 
 	// Check if API is protected
 	isProtected := labels.IsProtectedResource(api.NormalizedLabels)
-	if isProtected {
+	if isProtected && !change.DeleteProtected {
 		return fmt.Errorf("resource is protected and cannot be deleted")
 	}
 
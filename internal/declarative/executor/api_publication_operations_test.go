@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_CreatePublicationBindsPortalCreatedInSameRun(t *testing.T) {
+	portalAPI := new(MockPortalAPI)
+	portalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{},
+	}, nil)
+	portalAPI.On("CreatePortal", mock.Anything, mock.MatchedBy(func(p kkComps.CreatePortal) bool {
+		return p.Name == "Portal A"
+	})).Return(&kkOps.CreatePortalResponse{
+		PortalResponse: &kkComps.PortalResponse{ID: "portal-new"},
+	}, nil)
+	publicationAPI := &recordingPublicationAPI{}
+	client := state.NewClient(state.ClientConfig{
+		PortalAPI:         portalAPI,
+		APIPublicationAPI: publicationAPI,
+	})
+
+	// The shape the planner produces for `portal_id: !ref portal-a#id` when the
+	// portal is created by the same plan: the ID is only known at execution
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "c:api_publication:orders-portal-a",
+		ResourceType: "api_publication",
+		ResourceRef:  "orders-portal-a",
+		Parent:       &planner.ParentInfo{Ref: "orders", ID: "api-1"},
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"portal_id": "__REF__:portal-a#id"},
+		References: map[string]planner.ReferenceInfo{
+			"api_id":    {Ref: "orders", ID: "api-1"},
+			"portal_id": {Ref: "__REF__:portal-a#id", ID: "[unknown]"},
+		},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID:           "c:portal:portal-a",
+		ResourceType: "portal",
+		ResourceRef:  "portal-a",
+		Action:       planner.ActionCreate,
+		Namespace:    "default",
+		Fields:       map[string]any{"name": "Portal A"},
+	})
+	order, err := planner.NewDependencyResolver().ResolveDependencies(plan.Changes)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c:portal:portal-a", "c:api_publication:orders-portal-a"}, order)
+	plan.SetExecutionOrder(order)
+
+	result := New(client, nil, false).Execute(testContextWithLogger(), plan)
+	require.Empty(t, result.Errors)
+
+	require.Len(t, publicationAPI.puts, 1)
+	assert.Equal(t, "api-1", publicationAPI.puts[0].APIID)
+	assert.Equal(t, "portal-new", publicationAPI.puts[0].PortalID)
+	portalAPI.AssertExpectations(t)
+}
+
+func TestExecutor_resolvePortalRef_IgnoresUnresolvedID(t *testing.T) {
+	exec := New(state.NewClient(state.ClientConfig{}), nil, false)
+	exec.refToID["portal"] = map[string]string{"portal-a": "portal-new"}
+
+	id, err := exec.resolvePortalRef(testContextWithLogger(),
+		planner.ReferenceInfo{Ref: "__REF__:portal-a#id", ID: "[unknown]"})
+	require.NoError(t, err)
+	assert.Equal(t, "portal-new", id)
+}
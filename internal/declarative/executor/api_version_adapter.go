@@ -3,10 +3,14 @@ package executor
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
 	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/log"
 )
 
 // APIVersionAdapter implements CreateDeleteOperations for API versions
@@ -63,6 +67,9 @@ func (a *APIVersionAdapter) Create(ctx context.Context, req kkComps.CreateAPIVer
 	if resp == nil {
 		return "", fmt.Errorf("API version creation returned no response")
 	}
+	if req.Spec.Content != nil {
+		a.stampSpecHash(ctx, apiID, resp.ID, req.Spec.Content)
+	}
 	return resp.ID, nil
 }
 
@@ -74,7 +81,11 @@ func (a *APIVersionAdapter) Delete(ctx context.Context, id string, execCtx *Exec
 		return err
 	}
 
-	return a.client.DeleteAPIVersion(ctx, apiID, id)
+	if err := a.client.DeleteAPIVersion(ctx, apiID, id); err != nil {
+		return err
+	}
+	a.stampSpecHash(ctx, apiID, id, nil)
+	return nil
 }
 
 // GetByName gets an API version by name
@@ -149,6 +160,13 @@ func (a *APIVersionAdapter) Update(ctx context.Context, id string,
 		return "", fmt.Errorf("failed to get API ID for version update: %w", err)
 	}
 
+	// A change that only records the hash of an unchanged spec does not upload it again
+	if hash, ok := execCtx.PlannedChange.Fields[planner.FieldSpecHash].(string); ok &&
+		update.Version == nil && update.Spec == nil {
+		a.stampSpecHashValue(ctx, apiID, id, &hash)
+		return id, nil
+	}
+
 	// Call client's UpdateAPIVersion
 	resp, err := a.client.UpdateAPIVersion(ctx, apiID, id, update)
 	if err != nil {
@@ -158,10 +176,39 @@ func (a *APIVersionAdapter) Update(ctx context.Context, id string,
 	if resp == nil {
 		return "", fmt.Errorf("API version update returned no response")
 	}
+	if update.Spec != nil && update.Spec.Content != nil {
+		a.stampSpecHash(ctx, apiID, id, update.Spec.Content)
+	}
 
 	return resp.ID, nil
 }
 
+// stampSpecHash records the hash of the spec uploaded for a version in a label of its
+// API, so later plans skip versions whose spec is unchanged. A nil content removes the
+// label. The version change already succeeded, so a failure is only logged: the next
+// plan compares the spec content instead.
+func (a *APIVersionAdapter) stampSpecHash(ctx context.Context, apiID, versionID string, content *string) {
+	var hash *string
+	if content != nil {
+		value := labels.SpecHash(*content)
+		hash = &value
+	}
+	a.stampSpecHashValue(ctx, apiID, versionID, hash)
+}
+
+// stampSpecHashValue sets the spec hash label of a version to hash, or removes it when nil
+func (a *APIVersionAdapter) stampSpecHashValue(ctx context.Context, apiID, versionID string, hash *string) {
+	update := kkComps.UpdateAPIRequest{Labels: map[string]*string{labels.SpecHashKey(versionID): hash}}
+	if _, err := a.client.UpdateAPI(ctx, apiID, update, ""); err != nil {
+		logger, ok := ctx.Value(log.LoggerKey).(*slog.Logger)
+		if !ok {
+			logger = slog.Default()
+		}
+		logger.Warn("Unable to record the API version spec hash", "api_id", apiID, "version_id", versionID,
+			"error", err)
+	}
+}
+
 // SupportsUpdate returns true as API versions now support updates
 func (a *APIVersionAdapter) SupportsUpdate() bool {
 	return true
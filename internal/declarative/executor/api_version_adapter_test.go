@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// specHashAPI records the labels of API updates
+type specHashAPI struct {
+	labelAPI
+	updates []map[string]*string
+}
+
+func (s *specHashAPI) UpdateAPI(
+	_ context.Context, _ string, request kkComps.UpdateAPIRequest, _ ...kkOps.Option,
+) (*kkOps.UpdateAPIResponse, error) {
+	s.updates = append(s.updates, request.Labels)
+	return &kkOps.UpdateAPIResponse{StatusCode: 200, APIResponseSchema: s.api()}, nil
+}
+
+func TestAPIVersionAdapter_StampsSpecHash(t *testing.T) {
+	apis := &specHashAPI{}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: &slowSpecAPI{}})
+	adapter := NewAPIVersionAdapter(client)
+	execCtx := NewExecutionContext(&planner.PlannedChange{
+		References: map[string]planner.ReferenceInfo{"api_id": {Ref: "orders", ID: "api-1"}},
+	})
+	key := labels.SpecHashKey("version-1")
+
+	spec := `{"openapi":"3.0.0"}`
+	id, err := adapter.Create(timeoutTestContext(), kkComps.CreateAPIVersionRequest{
+		Spec: kkComps.CreateAPIVersionRequestSpec{Content: &spec},
+	}, "default", execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "version-1", id)
+	require.Len(t, apis.updates, 1)
+	require.NotNil(t, apis.updates[0][key])
+	assert.Equal(t, labels.SpecHash(spec), *apis.updates[0][key])
+
+	require.NoError(t, adapter.Delete(timeoutTestContext(), "version-1", execCtx))
+	require.Len(t, apis.updates, 2)
+	value, ok := apis.updates[1][key]
+	assert.True(t, ok)
+	assert.Nil(t, value, "deleting the version removes its label")
+}
+
+func TestAPIVersionAdapter_NoSpecNoStamp(t *testing.T) {
+	apis := &specHashAPI{}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: &slowSpecAPI{}})
+	execCtx := NewExecutionContext(&planner.PlannedChange{
+		References: map[string]planner.ReferenceInfo{"api_id": {Ref: "orders", ID: "api-1"}},
+	})
+
+	version := "1.0.0"
+	_, err := NewAPIVersionAdapter(client).Create(timeoutTestContext(), kkComps.CreateAPIVersionRequest{
+		Version: &version,
+	}, "default", execCtx)
+	require.NoError(t, err)
+	assert.Empty(t, apis.updates)
+}
+
+// uploadRejectingSpecAPI fails spec uploads
+type uploadRejectingSpecAPI struct {
+	slowSpecAPI
+}
+
+func (u *uploadRejectingSpecAPI) UpdateAPIVersion(
+	context.Context, kkOps.UpdateAPIVersionRequest, ...kkOps.Option,
+) (*kkOps.UpdateAPIVersionResponse, error) {
+	return nil, assert.AnError
+}
+
+func TestAPIVersionAdapter_UnchangedSpecOnlyStampsHash(t *testing.T) {
+	apis := &specHashAPI{}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: &uploadRejectingSpecAPI{}})
+	adapter := NewAPIVersionAdapter(client)
+	execCtx := NewExecutionContext(&planner.PlannedChange{
+		Parent: &planner.ParentInfo{Ref: "orders", ID: "api-1"},
+		Fields: map[string]any{"spec": planner.SpecContentUnchanged, planner.FieldSpecHash: "abc123"},
+	})
+
+	var update kkComps.APIVersion
+	require.NoError(t, adapter.MapUpdateFields(context.Background(), execCtx, execCtx.PlannedChange.Fields,
+		&update, nil))
+	id, err := adapter.Update(timeoutTestContext(), "version-1", update, "default", execCtx)
+	require.NoError(t, err, "the spec is not uploaded")
+	assert.Equal(t, "version-1", id)
+	require.Len(t, apis.updates, 1)
+	require.NotNil(t, apis.updates[0][labels.SpecHashKey("version-1")])
+	assert.Equal(t, "abc123", *apis.updates[0][labels.SpecHashKey("version-1")])
+}
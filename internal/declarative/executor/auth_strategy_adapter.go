@@ -79,6 +79,11 @@ func (a *AuthStrategyAdapter) MapUpdateFields(
 	namespace := execCtx.Namespace
 	protection := execCtx.Protection
 
+	// Update name if present, as when a moved entry renamed the strategy
+	if name, ok := fields["name"].(string); ok {
+		update.Name = &name
+	}
+
 	// Update display name if present
 	if displayName, ok := fields["display_name"].(string); ok {
 		update.DisplayName = &displayName
@@ -86,7 +86,7 @@ func (e *Executor) createApplicationAuthStrategy(ctx context.Context, change pla
 			slog.Any("request", req))
 
 		// Call API
-		if e.dryRun {
+		if e.dryRun && !e.executeDryRun {
 			return "dry-run-auth-strategy-id", nil
 		}
 
@@ -198,7 +198,7 @@ func (e *Executor) createApplicationAuthStrategy(ctx context.Context, change pla
 			slog.Any("credential_claim", req.Configs.OpenidConnect.CredentialClaim))
 
 		// Call API
-		if e.dryRun {
+		if e.dryRun && !e.executeDryRun {
 			return "dry-run-auth-strategy-id", nil
 		}
 
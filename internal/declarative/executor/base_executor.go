@@ -153,6 +153,8 @@ func (b *BaseExecutor[TCreate, TUpdate]) Update(ctx context.Context, change plan
 		return change.ResourceID, nil
 	}
 
+	recordPriorFields(ctx, resource, change)
+
 	// Update resource
 	id, err := b.ops.Update(ctx, change.ResourceID, update, change.Namespace, execCtx)
 	if err != nil {
@@ -202,7 +204,7 @@ func (b *BaseExecutor[TCreate, TUpdate]) Delete(ctx context.Context, change plan
 
 	// Check if resource is protected
 	isProtected := common.GetProtectionStatus(resource.GetNormalizedLabels())
-	if isProtected {
+	if isProtected && !change.DeleteProtected {
 		return fmt.Errorf("resource is protected and cannot be deleted")
 	}
 
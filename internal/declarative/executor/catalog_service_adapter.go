@@ -184,3 +184,8 @@ func (c *catalogServiceResourceInfo) GetLabels() map[string]string {
 func (c *catalogServiceResourceInfo) GetNormalizedLabels() map[string]string {
 	return c.svc.NormalizedLabels
 }
+
+// Snapshot returns the catalog service as read from Konnect
+func (c *catalogServiceResourceInfo) Snapshot() any {
+	return c.svc
+}
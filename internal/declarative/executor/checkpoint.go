@@ -0,0 +1,315 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/util"
+)
+
+// checkpointVersion is the version of the state file format
+const checkpointVersion = 1
+
+// Checkpoint is the execution state an apply records in its state file after each
+// change, so an apply that failed or was canceled can resume where it stopped
+type Checkpoint struct {
+	Version int `json:"version"`
+	// Plan is the plan being executed, as it was before execution resolved references
+	Plan *planner.Plan `json:"plan"`
+	// Completed holds the changes Konnect confirmed, by change ID
+	Completed map[string]CompletedChange `json:"completed,omitempty"`
+	// Attempted lists the changes that started without being confirmed. A create
+	// among them may have reached Konnect before the failure.
+	Attempted []string `json:"attempted,omitempty"`
+	// Finished is set once every change of the plan completed
+	Finished  bool      `json:"finished,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// CompletedChange is a change Konnect confirmed
+type CompletedChange struct {
+	ResourceType string `json:"resource_type"`
+	ResourceRef  string `json:"resource_ref"`
+	Action       string `json:"action"`
+	ResourceID   string `json:"resource_id,omitempty"`
+}
+
+// NewCheckpoint starts the state file of the execution of a plan
+func NewCheckpoint(path string, plan *planner.Plan) (*Checkpoint, error) {
+	// The plan is kept as it is now, before executing it fills in resolved IDs
+	recorded, err := copyPlan(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record plan in state file: %w", err)
+	}
+	checkpoint := &Checkpoint{
+		Version:   checkpointVersion,
+		Plan:      recorded,
+		Completed: make(map[string]CompletedChange),
+		path:      path,
+	}
+	if err := checkpoint.save(); err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// LoadCheckpoint reads the state file of an earlier apply to resume it
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if checkpoint.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported state file version %d in %s", checkpoint.Version, path)
+	}
+	if checkpoint.Plan == nil {
+		return nil, fmt.Errorf("state file %s records no plan", path)
+	}
+	if checkpoint.Completed == nil {
+		checkpoint.Completed = make(map[string]CompletedChange)
+	}
+	checkpoint.path = path
+	return checkpoint, nil
+}
+
+// ResumePlan returns a copy of the recorded plan to execute when resuming, so
+// executing it leaves the recorded plan unchanged
+func (c *Checkpoint) ResumePlan() (*planner.Plan, error) {
+	plan, err := copyPlan(c.Plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan of state file %s: %w", c.path, err)
+	}
+	return plan, nil
+}
+
+func copyPlan(plan *planner.Plan) (*planner.Plan, error) {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return nil, err
+	}
+	var copied planner.Plan
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// Remaining returns how many changes of the plan have not completed
+func (c *Checkpoint) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.Plan.Changes) - len(c.Completed)
+}
+
+// completed returns the recorded outcome of a change confirmed by an earlier run
+func (c *Checkpoint) completed(changeID string) (CompletedChange, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	done, ok := c.Completed[changeID]
+	return done, ok
+}
+
+// attempted reports whether an earlier run started a change without confirming it
+func (c *Checkpoint) attempted(changeID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Contains(c.Attempted, changeID)
+}
+
+// start records that a change is about to reach Konnect
+func (c *Checkpoint) start(change *planner.PlannedChange) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !slices.Contains(c.Attempted, change.ID) {
+		c.Attempted = append(c.Attempted, change.ID)
+	}
+	return c.saveLocked()
+}
+
+// complete records that Konnect confirmed a change
+func (c *Checkpoint) complete(change *planner.PlannedChange, resourceID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Completed[change.ID] = CompletedChange{
+		ResourceType: change.ResourceType,
+		ResourceRef:  change.ResourceRef,
+		Action:       string(change.Action),
+		ResourceID:   resourceID,
+	}
+	c.Attempted = slices.DeleteFunc(c.Attempted, func(id string) bool { return id == change.ID })
+	c.Finished = len(c.Completed) == len(c.Plan.Changes)
+	return c.saveLocked()
+}
+
+func (c *Checkpoint) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.saveLocked()
+}
+
+// saveLocked writes the state file through a temporary file, so an interrupted
+// write never leaves a truncated state file behind
+func (c *Checkpoint) saveLocked() error {
+	c.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), "."+filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// resumeCompleted skips a change an earlier run completed, remembering the ID of a
+// created resource for the changes that reference it
+func (e *Executor) resumeCompleted(result *ExecutionResult, plan *planner.Plan, change *planner.PlannedChange,
+	changeIndex int,
+) bool {
+	if e.checkpoint == nil {
+		return false
+	}
+	done, ok := e.checkpoint.completed(change.ID)
+	if !ok {
+		return false
+	}
+	if e.progress != nil {
+		e.progress.StartChange(*change)
+	}
+
+	e.mu.Lock()
+	result.SkippedCount++
+	operation := recordOperation(result, change, OperationSkipped, time.Now(), done.ResourceID, nil)
+	if change.Action == planner.ActionCreate && done.ResourceID != "" {
+		e.recordCreated(plan, change, changeIndex, done.ResourceID)
+	}
+	e.mu.Unlock()
+
+	e.reportOperation(change, operation)
+	if e.progress != nil {
+		e.progress.SkipChange(*change, "completed by the resumed apply")
+	}
+	return true
+}
+
+// recoverCreate returns the ID of the resource of a create an earlier run attempted
+// without confirming, when Konnect created it anyway. The resource must carry the
+// management labels of the namespace of the change, so a resource of the same name
+// kongctl does not manage is never taken over.
+func (e *Executor) recoverCreate(ctx context.Context, change *planner.PlannedChange) string {
+	if e.checkpoint == nil || e.client == nil || change.Action != planner.ActionCreate ||
+		!e.checkpoint.attempted(change.ID) {
+		return ""
+	}
+
+	name := getResourceName(change.Fields)
+	var id string
+	var resourceLabels map[string]string
+	switch change.ResourceType {
+	case "portal":
+		if portal, err := e.client.GetPortalByName(ctx, name); err == nil && portal != nil {
+			id, resourceLabels = portal.ID, portal.NormalizedLabels
+		}
+	case "api":
+		if api, err := e.client.GetAPIByName(ctx, name); err == nil && api != nil {
+			id, resourceLabels = api.ID, api.NormalizedLabels
+		}
+	case "control_plane":
+		if cp, err := e.client.GetControlPlaneByName(ctx, name); err == nil && cp != nil {
+			id, resourceLabels = cp.ID, cp.NormalizedLabels
+		}
+	case "application_auth_strategy":
+		if strategy, err := e.client.GetAuthStrategyByName(ctx, name); err == nil && strategy != nil {
+			id, resourceLabels = strategy.ID, strategy.NormalizedLabels
+		}
+	case "catalog_service":
+		if service, err := e.client.GetCatalogServiceByName(ctx, name); err == nil && service != nil {
+			id, resourceLabels = service.ID, service.NormalizedLabels
+		}
+	case "organization_team":
+		if team, err := e.client.GetOrganizationTeamByName(ctx, name); err == nil && team != nil {
+			id, resourceLabels = util.GetString(team.ID), team.NormalizedLabels
+		}
+	}
+	if id == "" || resourceLabels[labels.NamespaceKey] != namespaceOf(change) {
+		return ""
+	}
+	return id
+}
+
+// namespaceOf returns the namespace a change labels its resource with
+func namespaceOf(change *planner.PlannedChange) string {
+	if change.Namespace == "" {
+		return "default"
+	}
+	return change.Namespace
+}
+
+// startCheckpoint starts the state file of an execution, unless it resumes one
+func (e *Executor) startCheckpoint(plan *planner.Plan) error {
+	if e.checkpoint != nil || e.stateFile == "" || e.dryRun {
+		return nil
+	}
+	checkpoint, err := NewCheckpoint(e.stateFile, plan)
+	if err != nil {
+		return err
+	}
+	e.checkpoint = checkpoint
+	return nil
+}
+
+// prepareChange validates a change, unless it recovers a resource created by an
+// interrupted run, and records in the state file that the change is about to run
+func (e *Executor) prepareChange(ctx context.Context, change *planner.PlannedChange, recoveredID string) error {
+	if recoveredID == "" {
+		if err := e.validateChangePreExecution(ctx, *change); err != nil {
+			return err
+		}
+	}
+	if e.checkpoint == nil {
+		return nil
+	}
+	return e.checkpoint.start(change)
+}
+
+// checkpointCompleted records a confirmed change in the state file. The change is
+// applied already, so a failed write only loses the ability to skip it on resume.
+func (e *Executor) checkpointCompleted(change *planner.PlannedChange, resourceID string) {
+	if e.checkpoint == nil {
+		return
+	}
+	if err := e.checkpoint.complete(change, resourceID); err != nil {
+		slog.Warn("Failed to record completed change in state file",
+			"change_id", change.ID,
+			"error", err,
+		)
+	}
+}
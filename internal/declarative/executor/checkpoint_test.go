@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"path/filepath"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failedApply runs a plan creating orders, payments and billing, with billing
+// failing, and returns the state file it recorded
+func failedApply(t *testing.T, apis *rollbackAPI) string {
+	t.Helper()
+	stateFile := filepath.Join(t.TempDir(), "apply.state")
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1, StateFile: stateFile}).
+		Execute(timeoutTestContext(), apiCreatePlan("orders", "payments", "billing"))
+	require.Len(t, result.Errors, 1)
+	return stateFile
+}
+
+func resumeApply(t *testing.T, apis *rollbackAPI, stateFile string) (*ExecutionResult, *Checkpoint) {
+	t.Helper()
+	checkpoint, err := LoadCheckpoint(stateFile)
+	require.NoError(t, err)
+	plan, err := checkpoint.ResumePlan()
+	require.NoError(t, err)
+
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1, StateFile: stateFile, Resume: checkpoint}).
+		Execute(timeoutTestContext(), plan)
+	return result, checkpoint
+}
+
+func TestExecutor_CheckpointRecordsConfirmedChanges(t *testing.T) {
+	apis := newRollbackAPI("billing")
+	stateFile := failedApply(t, apis)
+
+	checkpoint, err := LoadCheckpoint(stateFile)
+	require.NoError(t, err)
+	assert.Len(t, checkpoint.Plan.Changes, 3)
+	assert.Equal(t, CompletedChange{
+		ResourceType: "api", ResourceRef: "orders", Action: "CREATE", ResourceID: "orders-id",
+	}, checkpoint.Completed["c:api:orders"])
+	assert.Contains(t, checkpoint.Completed, "c:api:payments")
+	assert.Equal(t, []string{"c:api:billing"}, checkpoint.Attempted)
+	assert.False(t, checkpoint.Finished)
+	assert.Equal(t, 1, checkpoint.Remaining())
+}
+
+func TestExecutor_ResumeSkipsCompletedChanges(t *testing.T) {
+	apis := newRollbackAPI("billing")
+	stateFile := failedApply(t, apis)
+	apis.failName = ""
+
+	result, checkpoint := resumeApply(t, apis, stateFile)
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, 2, result.SkippedCount)
+	assert.Equal(t, 1, result.SuccessCount)
+	require.Len(t, result.ChangesApplied, 1)
+	assert.Equal(t, "billing-id", result.ChangesApplied[0].ResourceID)
+	assert.Len(t, apis.apis, 3, "completed creates are not repeated")
+	assert.True(t, checkpoint.Finished)
+	assert.Empty(t, checkpoint.Attempted)
+}
+
+func TestExecutor_ResumeRecoversAttemptedCreate(t *testing.T) {
+	t.Run("managed resource is reused", func(t *testing.T) {
+		apis := newRollbackAPI("billing")
+		stateFile := failedApply(t, apis)
+		// Konnect created billing although the request failed
+		apis.apis["billing-created"] = kkComps.APIResponseSchema{
+			ID: "billing-created", Name: "billing", Labels: map[string]string{labels.NamespaceKey: "default"},
+		}
+
+		result, _ := resumeApply(t, apis, stateFile)
+
+		require.Empty(t, result.Errors)
+		require.Len(t, result.ChangesApplied, 1)
+		assert.Equal(t, "billing-created", result.ChangesApplied[0].ResourceID)
+		assert.Len(t, apis.apis, 3)
+	})
+
+	t.Run("unmanaged resource is not taken over", func(t *testing.T) {
+		apis := newRollbackAPI("billing")
+		stateFile := failedApply(t, apis)
+		apis.failName = ""
+		apis.apis["billing-other"] = kkComps.APIResponseSchema{ID: "billing-other", Name: "billing"}
+
+		result, _ := resumeApply(t, apis, stateFile)
+
+		require.Empty(t, result.Errors)
+		require.Len(t, result.ChangesApplied, 1)
+		assert.Equal(t, "billing-id", result.ChangesApplied[0].ResourceID)
+	})
+}
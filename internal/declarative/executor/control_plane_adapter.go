@@ -190,6 +190,11 @@ func (c *ControlPlaneResourceInfo) GetNormalizedLabels() map[string]string {
 	return c.controlPlane.NormalizedLabels
 }
 
+// Snapshot returns the control plane as read from Konnect
+func (c *ControlPlaneResourceInfo) Snapshot() any {
+	return c.controlPlane
+}
+
 func extractString(value any) (string, bool) {
 	if value == nil {
 		return "", false
@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// createCustomResource applies a CREATE change through the handler registered for its kind
+func (e *Executor) createCustomResource(
+	ctx context.Context, handler custom.Handler, change *planner.PlannedChange,
+) (string, error) {
+	req, err := e.buildCustomResourceRequest(change)
+	if err != nil {
+		return "", err
+	}
+	req.Labels = labels.BuildCreateLabels(
+		labels.ExtractLabelsFromField(change.Fields["labels"]), change.Namespace, desiredProtection(change.Protection))
+	// Handlers do not write through the recording Konnect client, so dry runs stop here
+	if e.dryRun {
+		return fmt.Sprintf("dry-run-%s-id", change.ResourceType), nil
+	}
+	return handler.Create(ctx, req)
+}
+
+// updateCustomResource applies an UPDATE change through the handler registered for its kind
+func (e *Executor) updateCustomResource(
+	ctx context.Context, handler custom.Handler, change *planner.PlannedChange,
+) (string, error) {
+	req, err := e.buildCustomResourceRequest(change)
+	if err != nil {
+		return "", err
+	}
+	// The planner only includes labels when user labels or protection change
+	if userLabels, ok := change.Fields["labels"]; ok {
+		req.Labels = labels.BuildCreateLabels(
+			labels.ExtractLabelsFromField(userLabels), change.Namespace, desiredProtection(change.Protection))
+	}
+	if e.dryRun {
+		return change.ResourceID, nil
+	}
+	return handler.Update(ctx, req)
+}
+
+// deleteCustomResource applies a DELETE change through the handler registered for its kind
+func (e *Executor) deleteCustomResource(
+	ctx context.Context, handler custom.Handler, change *planner.PlannedChange,
+) error {
+	if e.dryRun {
+		return nil
+	}
+	return handler.Delete(ctx, custom.Request{
+		Kind:      change.ResourceType,
+		Ref:       change.ResourceRef,
+		ID:        change.ResourceID,
+		Name:      getResourceName(change.Fields),
+		Namespace: change.Namespace,
+	})
+}
+
+// buildCustomResourceRequest converts a planned change into a handler request,
+// replacing !ref placeholders in spec fields with resolved IDs
+func (e *Executor) buildCustomResourceRequest(change *planner.PlannedChange) (custom.Request, error) {
+	fields := maps.Clone(change.Fields)
+	delete(fields, "name")
+	delete(fields, "labels")
+
+	for key, value := range fields {
+		str, ok := value.(string)
+		if !ok || !tags.IsRefPlaceholder(str) {
+			continue
+		}
+		resolved, err := e.resolveCustomResourceRef(change, key, str)
+		if err != nil {
+			return custom.Request{}, err
+		}
+		fields[key] = resolved
+	}
+
+	return custom.Request{
+		Kind:      change.ResourceType,
+		Ref:       change.ResourceRef,
+		ID:        change.ResourceID,
+		Name:      getResourceName(change.Fields),
+		Namespace: change.Namespace,
+		Fields:    fields,
+	}, nil
+}
+
+// resolveCustomResourceRef resolves a placeholder from the planner's resolved references
+// or, for resources created earlier in this execution, from their new IDs
+func (e *Executor) resolveCustomResourceRef(change *planner.PlannedChange, field, placeholder string) (string, error) {
+	if refInfo, ok := change.References[field]; ok && refInfo.ID != "" && refInfo.ID != "[unknown]" {
+		return refInfo.ID, nil
+	}
+
+	ref, refField, ok := tags.ParseRefPlaceholder(placeholder)
+	if !ok {
+		return "", fmt.Errorf("invalid reference format in field %s: %s", field, placeholder)
+	}
+	if refField == "id" {
+		// Refs are unique across resource types
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		for _, ids := range e.refToID {
+			if id, found := ids[ref]; found {
+				return id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to resolve reference %q in field %s of %s %q",
+		ref, field, change.ResourceType, change.ResourceRef)
+}
+
+// desiredProtection returns the protection state a change should leave the resource in
+func desiredProtection(protection any) any {
+	switch p := protection.(type) {
+	case planner.ProtectionChange:
+		return p.New
+	case map[string]any:
+		// From JSON deserialization
+		if newValue, ok := p["new"].(bool); ok {
+			return newValue
+		}
+	}
+	return protection
+}
@@ -0,0 +1,242 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customWidgetKind = "acme_widget"
+
+func registerMemoryWidgets(t *testing.T) *custom.MemoryHandler {
+	t.Helper()
+	handler := custom.NewMemoryHandler(customWidgetKind)
+	handler.Required = []string{"size"}
+	require.NoError(t, custom.Register(handler))
+	t.Cleanup(func() { custom.Unregister(customWidgetKind) })
+	return handler
+}
+
+func planCustomConfig(t *testing.T, config string, mode planner.PlanMode) *planner.Plan {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	rs, err := loader.New().LoadFile(path)
+	require.NoError(t, err)
+
+	p := planner.NewPlanner(state.NewClient(state.ClientConfig{}), slog.Default())
+	plan, err := p.GeneratePlan(context.Background(), rs, planner.Options{Mode: mode})
+	require.NoError(t, err)
+	return plan
+}
+
+func applyCustomPlan(t *testing.T, plan *planner.Plan) *ExecutionResult {
+	t.Helper()
+	result := New(state.NewClient(state.ClientConfig{}), nil, false).Execute(context.Background(), plan)
+	require.Empty(t, result.Errors)
+	return result
+}
+
+func findWidget(t *testing.T, handler *custom.MemoryHandler, name string) custom.State {
+	t.Helper()
+	states, err := handler.List(context.Background())
+	require.NoError(t, err)
+	for _, s := range states {
+		if s.Name == name {
+			return s
+		}
+	}
+	require.Failf(t, "widget not found", "no widget named %q", name)
+	return custom.State{}
+}
+
+func TestCustomResourceHandler_PlanApplyCycle(t *testing.T) {
+	handler := registerMemoryWidgets(t)
+
+	initial := `
+_defaults:
+  kongctl:
+    namespace: team-a
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    name: Widget A
+    labels:
+      tier: gold
+    spec:
+      size: 3
+      color: blue
+  - ref: widget-b
+    kind: acme_widget
+    spec:
+      size: 1
+      parent_id: !ref widget-a#id
+`
+
+	// Create both widgets; the ref orders widget-b after widget-a
+	plan := planCustomConfig(t, initial, planner.PlanModeApply)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, 2, plan.Summary.ByAction[planner.ActionCreate])
+	require.Len(t, plan.ExecutionOrder, 2)
+	createA, createB := plan.Changes[0], plan.Changes[1]
+	if createA.ResourceRef != "widget-a" {
+		createA, createB = createB, createA
+	}
+	assert.Equal(t, customWidgetKind, createA.ResourceType)
+	assert.Equal(t, "team-a", createA.Namespace)
+	assert.Contains(t, createB.DependsOn, createA.ID)
+	assert.Equal(t, createA.ID, plan.ExecutionOrder[0])
+
+	result := applyCustomPlan(t, plan)
+	assert.Equal(t, 2, result.SuccessCount)
+
+	widgetA := findWidget(t, handler, "Widget A")
+	widgetB := findWidget(t, handler, "widget-b")
+	assert.Equal(t, "gold", widgetA.Labels["tier"])
+	assert.Equal(t, "team-a", widgetA.Labels[labels.NamespaceKey])
+	assert.Equal(t, widgetA.ID, widgetB.Spec["parent_id"], "ref resolves to the ID created during apply")
+
+	// Re-planning the same configuration is a no-op
+	plan = planCustomConfig(t, initial, planner.PlanModeApply)
+	assert.True(t, plan.IsEmpty(), "unexpected changes: %+v", plan.Changes)
+
+	// Changing a spec field and user labels plans an update of only those fields
+	updated := `
+_defaults:
+  kongctl:
+    namespace: team-a
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    name: Widget A
+    labels:
+      tier: silver
+    spec:
+      size: 5
+      color: blue
+  - ref: widget-b
+    kind: acme_widget
+    spec:
+      size: 1
+      parent_id: !ref widget-a#id
+`
+	plan = planCustomConfig(t, updated, planner.PlanModeApply)
+	require.Len(t, plan.Changes, 1)
+	update := plan.Changes[0]
+	assert.Equal(t, planner.ActionUpdate, update.Action)
+	assert.Equal(t, widgetA.ID, update.ResourceID)
+	assert.Equal(t, map[string]any{
+		"name":   "Widget A",
+		"size":   float64(5),
+		"labels": map[string]string{"tier": "silver"},
+	}, update.Fields)
+
+	applyCustomPlan(t, plan)
+	widgetA = findWidget(t, handler, "Widget A")
+	assert.Equal(t, float64(5), widgetA.Spec["size"])
+	assert.Equal(t, "blue", widgetA.Spec["color"])
+	assert.Equal(t, "silver", widgetA.Labels["tier"])
+	assert.Equal(t, "team-a", widgetA.Labels[labels.NamespaceKey])
+
+	// Delete mode removes the widgets named in the configuration
+	plan = planCustomConfig(t, updated, planner.PlanModeDelete)
+	require.Len(t, plan.Changes, 2)
+	applyCustomPlan(t, plan)
+
+	states, err := handler.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+}
+
+func TestCustomResourceHandler_ProtectedAndUnmanaged(t *testing.T) {
+	handler := registerMemoryWidgets(t)
+
+	// Instances without kongctl namespace labels are not managed and are left alone
+	_, err := handler.Create(context.Background(), custom.Request{
+		Name:   "manual",
+		Fields: map[string]any{"size": 1},
+	})
+	require.NoError(t, err)
+
+	protected := `
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    kongctl:
+      protected: true
+    spec:
+      size: 3
+`
+	applyCustomPlan(t, planCustomConfig(t, protected, planner.PlanModeApply))
+	widget := findWidget(t, handler, "widget-a")
+	assert.Equal(t, labels.TrueValue, widget.Labels[labels.ProtectedKey])
+
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(protected), 0o600))
+	rs, err := loader.New().LoadFile(path)
+	require.NoError(t, err)
+
+	p := planner.NewPlanner(state.NewClient(state.ClientConfig{}), slog.Default())
+	_, err = p.GeneratePlan(context.Background(), rs, planner.Options{Mode: planner.PlanModeDelete})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "protected")
+
+	findWidget(t, handler, "manual")
+}
+
+func TestCustomResourceHandler_ExecutedDryRunLeavesHandlerUntouched(t *testing.T) {
+	handler := registerMemoryWidgets(t)
+
+	plan := planCustomConfig(t, `
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    spec:
+      size: 3
+`, planner.PlanModeApply)
+
+	result := NewWithOptions(state.NewClient(state.ClientConfig{}), nil, true, Options{ExecuteDryRun: true}).
+		Execute(context.Background(), plan)
+	require.Empty(t, result.Errors)
+
+	states, err := handler.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+}
+
+func TestCustomResourceHandler_Validation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    spec:
+      color: blue
+`), 0o600))
+	rs, err := loader.New().LoadFile(path)
+	require.NoError(t, err)
+
+	p := planner.NewPlanner(state.NewClient(state.ClientConfig{}), slog.Default())
+	_, err = p.GeneratePlan(context.Background(), rs, planner.Options{Mode: planner.PlanModeApply})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no handler is registered`)
+
+	registerMemoryWidgets(t)
+	_, err = p.GeneratePlan(context.Background(), rs, planner.Options{Mode: planner.PlanModeApply})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.size is required")
+
+	require.Error(t, custom.Register(custom.NewMemoryHandler(customWidgetKind)), "duplicate kinds are rejected")
+	require.Error(t, custom.Register(custom.NewMemoryHandler("portal")), "built-in kinds are rejected")
+}
@@ -446,6 +446,8 @@ func (e *Executor) resolveGatewayServiceByName(
 }
 
 func (e *Executor) storeGatewayServiceRef(ref, id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	if e.refToID["gateway_service"] == nil {
 		e.refToID["gateway_service"] = make(map[string]string)
 	}
@@ -462,6 +464,8 @@ func (e *Executor) updateGatewayServiceReferences(
 		return
 	}
 
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	for i := range plan.Changes {
 		change := &plan.Changes[i]
 		if change.ResourceType != "api_implementation" || change.Action != planner.ActionCreate {
@@ -12,6 +12,7 @@ import (
 	"github.com/kong/kongctl/internal/declarative/deck"
 	"github.com/kong/kongctl/internal/declarative/planner"
 	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
 	"github.com/kong/kongctl/internal/log"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +32,7 @@ func (s *stubDeckRunner) Run(_ context.Context, opts deck.RunOptions) (*deck.Run
 }
 
 type stubGatewayServiceAPI struct {
+	helpers.GatewayServiceAPI
 	services []kkComps.ServiceOutput
 }
 
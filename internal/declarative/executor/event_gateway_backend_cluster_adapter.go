@@ -521,3 +521,8 @@ func (e *EventGatewayBackendClusterResourceInfo) GetLabels() map[string]string {
 func (e *EventGatewayBackendClusterResourceInfo) GetNormalizedLabels() map[string]string {
 	return e.backendCluster.NormalizedLabels
 }
+
+// Snapshot returns the backend cluster as read from Konnect
+func (e *EventGatewayBackendClusterResourceInfo) Snapshot() any {
+	return e.backendCluster
+}
@@ -173,3 +173,8 @@ func (e *EventGatewayControlPlaneResourceInfo) GetLabels() map[string]string {
 func (e *EventGatewayControlPlaneResourceInfo) GetNormalizedLabels() map[string]string {
 	return e.eventGatewayControlPlane.NormalizedLabels
 }
+
+// Snapshot returns the event gateway as read from Konnect
+func (e *EventGatewayControlPlaneResourceInfo) Snapshot() any {
+	return e.eventGatewayControlPlane
+}
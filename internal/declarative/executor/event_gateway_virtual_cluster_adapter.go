@@ -309,6 +309,11 @@ func (e *EventGatewayVirtualClusterResourceInfo) GetNormalizedLabels() map[strin
 	return e.virtualCluster.NormalizedLabels
 }
 
+// Snapshot returns the virtual cluster as read from Konnect
+func (e *EventGatewayVirtualClusterResourceInfo) Snapshot() any {
+	return e.virtualCluster
+}
+
 // buildBackendClusterReference constructs BackendClusterReferenceModify from a map or SDK type
 func buildBackendClusterReference(field any, execCtx *ExecutionContext) (kkComps.BackendClusterReferenceModify, error) {
 	// If it's already the SDK type, return it directly
@@ -0,0 +1,238 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// Events of the JSON event stream of an execution
+const (
+	EventExecutionStarted   = "execution_started"
+	EventOperationStarted   = "operation_started"
+	EventOperationRetried   = "operation_retried"
+	EventOperationSucceeded = "operation_succeeded"
+	EventOperationFailed    = "operation_failed"
+	EventOperationSkipped   = "operation_skipped"
+	EventOperationNotRun    = "operation_not_started"
+	EventExecutionFinished  = "execution_finished"
+)
+
+// Statuses of executions in the execution_finished event
+const (
+	ExecutionSucceeded = "success"
+	ExecutionFailed    = "failed"
+	ExecutionCanceled  = "canceled"
+)
+
+// Event is a line of the JSON event stream of an execution. Execution events carry
+// the plan and its outcome, operation events the change they are about.
+type Event struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+
+	PlanID       string           `json:"plan_id,omitempty"`
+	Mode         planner.PlanMode `json:"mode,omitempty"`
+	DryRun       bool             `json:"dry_run,omitempty"`
+	TotalChanges *int             `json:"total_changes,omitempty"`
+
+	ChangeID     string `json:"change_id,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceRef  string `json:"resource_ref,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	Action       string `json:"action,omitempty"`
+	// ResourceID is the Konnect ID of the created, updated or deleted resource
+	ResourceID string `json:"resource_id,omitempty"`
+	DurationMS *int64 `json:"duration_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// Status and Summary are the outcome of a finished execution
+	Status  string         `json:"status,omitempty"`
+	Summary *ReportSummary `json:"summary,omitempty"`
+}
+
+// EventReporter writes the progress of an execution as newline-delimited JSON
+// events, one per line, for tools that follow an execution as it runs. Every change
+// gets an operation_started event and one of operation_succeeded, operation_failed
+// and operation_skipped, with the ID of its resource and its duration. The stream
+// starts with execution_started and ends with execution_finished.
+//
+// An EventReporter is safe for changes running concurrently.
+type EventReporter struct {
+	handle func(Event)
+	dryRun bool
+	now    func() time.Time
+
+	mu        sync.Mutex
+	startedAt time.Time
+	summary   ReportSummary
+}
+
+// NewEventReporter creates a reporter writing events to w
+func NewEventReporter(w io.Writer, dryRun bool) *EventReporter {
+	encoder := json.NewEncoder(w)
+	return NewEventHandlerReporter(func(event Event) {
+		// A failed write leaves nothing to report to
+		_ = encoder.Encode(event)
+	}, dryRun)
+}
+
+// NewEventHandlerReporter creates a reporter passing each event to handle. Events
+// are passed one at a time, in order.
+func NewEventHandlerReporter(handle func(Event), dryRun bool) *EventReporter {
+	return &EventReporter{handle: handle, dryRun: dryRun, now: time.Now}
+}
+
+// StartExecution is called at the beginning of plan execution
+func (r *EventReporter) StartExecution(plan *planner.Plan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.startedAt = r.now()
+	total := plan.Summary.TotalChanges
+	r.write(Event{
+		Event:        EventExecutionStarted,
+		PlanID:       plan.Metadata.PlanID,
+		Mode:         plan.Metadata.Mode,
+		DryRun:       r.dryRun,
+		TotalChanges: &total,
+	})
+}
+
+// StartChange is called before executing a change
+func (r *EventReporter) StartChange(change planner.PlannedChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.write(changeEvent(EventOperationStarted, change))
+}
+
+// RetryChange is called before a request of a running change is retried
+func (r *EventReporter) RetryChange(change planner.PlannedChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.write(changeEvent(EventOperationRetried, change))
+}
+
+// CompleteChange is called after a change is executed. The outcome is written by
+// completeOperation, which receives the ID of the resource and the duration.
+func (r *EventReporter) CompleteChange(planner.PlannedChange, error) {}
+
+// SkipChange is called when a change is skipped. The outcome is written by
+// completeOperation.
+func (r *EventReporter) SkipChange(planner.PlannedChange, string) {}
+
+// FinishExecution is called at the end of plan execution
+func (r *EventReporter) FinishExecution(result *ExecutionResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, change := range result.ChangesNotStarted {
+		r.summary.Total++
+		r.summary.NotStarted++
+		r.write(Event{
+			Event:        EventOperationNotRun,
+			ChangeID:     change.ChangeID,
+			ResourceType: change.ResourceType,
+			ResourceRef:  change.ResourceRef,
+			ResourceName: change.ResourceName,
+			Action:       change.Action,
+		})
+	}
+
+	status := ExecutionSucceeded
+	switch {
+	case result.Canceled:
+		status = ExecutionCanceled
+	case result.HasErrors():
+		status = ExecutionFailed
+	}
+	duration := r.now().Sub(r.startedAt).Milliseconds()
+	summary := r.summary
+	r.write(Event{
+		Event:      EventExecutionFinished,
+		DryRun:     r.dryRun,
+		DurationMS: &duration,
+		Status:     status,
+		Summary:    &summary,
+		Error:      executionErrorMessage(result),
+	})
+}
+
+func (r *EventReporter) reportsConcurrently() {}
+
+// completeOperation writes the outcome of a finished change
+func (r *EventReporter) completeOperation(change planner.PlannedChange, operation OperationRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event := changeEvent(EventOperationSucceeded, change)
+	r.summary.Total++
+	switch operation.Status {
+	case OperationFailed:
+		event.Event = EventOperationFailed
+		r.summary.Failed++
+	case OperationSkipped:
+		event.Event = EventOperationSkipped
+		r.summary.Skipped++
+	default:
+		r.summary.Succeeded++
+	}
+	event.ResourceID = operation.ResourceID
+	duration := operation.DurationMS
+	event.DurationMS = &duration
+	event.Error = operation.Error
+	r.write(event)
+}
+
+// write reports an event. Callers hold r.mu.
+func (r *EventReporter) write(event Event) {
+	event.Timestamp = r.now().UTC()
+	r.handle(event)
+}
+
+func changeEvent(name string, change planner.PlannedChange) Event {
+	return Event{
+		Event:        name,
+		ChangeID:     change.ID,
+		ResourceType: change.ResourceType,
+		ResourceRef:  change.ResourceRef,
+		ResourceName: getResourceName(change.Fields),
+		Namespace:    change.Namespace,
+		Action:       string(change.Action),
+	}
+}
+
+// executionErrorMessage returns what stopped an execution, if anything. The errors of
+// changes are reported with their operation.
+func executionErrorMessage(result *ExecutionResult) string {
+	if result.Canceled {
+		return "execution canceled"
+	}
+	for _, execErr := range result.Errors {
+		if execErr.ChangeID == "" {
+			return execErr.Error
+		}
+	}
+	if result.HasErrors() {
+		return fmt.Sprintf("%d change(s) failed", result.FailureCount)
+	}
+	return ""
+}
+
+// operationReporter is implemented by progress reporters that receive the record of
+// each finished change, with the ID of its resource and its duration
+type operationReporter interface {
+	completeOperation(change planner.PlannedChange, operation OperationRecord)
+}
+
+var (
+	_ ProgressReporter   = (*EventReporter)(nil)
+	_ RetryReporter      = (*EventReporter)(nil)
+	_ concurrentReporter = (*EventReporter)(nil)
+	_ operationReporter  = (*EventReporter)(nil)
+)
@@ -0,0 +1,119 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []Event {
+	t.Helper()
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event), line)
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventReporter(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := NewEventReporter(&buf, false)
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter.now = func() time.Time { return clock }
+	plan, changes := liveTestPlan()
+	plan.Metadata.PlanID = "plan-1"
+	plan.Metadata.Mode = planner.PlanModeApply
+
+	reporter.StartExecution(plan)
+	reporter.StartChange(changes[0])
+	reporter.completeOperation(changes[0], OperationRecord{
+		Status: OperationSucceeded, ResourceID: "portal-1", DurationMS: 1200,
+	})
+	reporter.CompleteChange(changes[0], nil)
+	reporter.StartChange(changes[1])
+	reporter.RetryChange(changes[1])
+	reporter.completeOperation(changes[1], OperationRecord{Status: OperationFailed, Error: "conflict", DurationMS: 300})
+	reporter.CompleteChange(changes[1], errors.New("conflict"))
+	clock = clock.Add(2 * time.Second)
+	reporter.FinishExecution(&ExecutionResult{
+		SuccessCount:      1,
+		FailureCount:      1,
+		Errors:            []ExecutionError{{ChangeID: "2", Error: "conflict"}},
+		ChangesNotStarted: []NotStartedChange{{ChangeID: "3", ResourceType: "api", ResourceRef: "payments"}},
+	})
+
+	events := decodeEvents(t, &buf)
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Event)
+	}
+	require.Equal(t, []string{
+		EventExecutionStarted,
+		EventOperationStarted, EventOperationSucceeded,
+		EventOperationStarted, EventOperationRetried, EventOperationFailed,
+		EventOperationNotRun,
+		EventExecutionFinished,
+	}, names)
+
+	require.Equal(t, "plan-1", events[0].PlanID)
+	require.Equal(t, 3, *events[0].TotalChanges)
+	require.Equal(t, "portal-1", events[2].ResourceID)
+	require.Equal(t, "dev-portal", events[2].ResourceRef)
+	require.Equal(t, int64(1200), *events[2].DurationMS)
+	require.Equal(t, "conflict", events[5].Error)
+
+	finished := events[7]
+	require.Equal(t, ExecutionFailed, finished.Status)
+	require.Equal(t, int64(2000), *finished.DurationMS)
+	require.Equal(t, "1 change(s) failed", finished.Error)
+	require.Equal(t, ReportSummary{Total: 3, Succeeded: 1, Failed: 1, NotStarted: 1}, *finished.Summary)
+}
+
+func TestExecutor_EventReporter(t *testing.T) {
+	var buf bytes.Buffer
+	exec := New(nil, NewEventReporter(&buf, true), true)
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1-c-portal",
+		ResourceType: "portal",
+		ResourceRef:  "dev-portal",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "Developer Portal"},
+	})
+	plan.SetExecutionOrder([]string{"1-c-portal"})
+
+	exec.Execute(context.Background(), plan)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 4)
+	require.Equal(t, EventOperationSkipped, events[2].Event)
+	require.Equal(t, "Developer Portal", events[2].ResourceName)
+	require.NotNil(t, events[2].DurationMS)
+	require.Equal(t, ExecutionSucceeded, events[3].Status)
+	require.Equal(t, ReportSummary{Total: 1, Skipped: 1}, *events[3].Summary)
+}
+
+func TestEventHandlerReporter(t *testing.T) {
+	var events []Event
+	reporter := NewEventHandlerReporter(func(event Event) { events = append(events, event) }, true)
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeSync)
+
+	reporter.StartExecution(plan)
+	reporter.FinishExecution(&ExecutionResult{DryRun: true})
+
+	require.Len(t, events, 2)
+	require.Equal(t, EventExecutionStarted, events[0].Event)
+	require.Equal(t, planner.PlanModeSync, events[0].Mode)
+	require.True(t, events[0].DryRun)
+	require.False(t, events[0].Timestamp.IsZero())
+	require.Equal(t, ExecutionSucceeded, events[1].Status)
+}
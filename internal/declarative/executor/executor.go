@@ -2,20 +2,29 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
 	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/Kong/sdk-konnect-go/models/sdkerrors"
 	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/custom"
 	"github.com/kong/kongctl/internal/declarative/deck"
 	"github.com/kong/kongctl/internal/declarative/labels"
 	"github.com/kong/kongctl/internal/declarative/planner"
 	"github.com/kong/kongctl/internal/declarative/state"
 	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 	"github.com/kong/kongctl/internal/log"
+	"github.com/kong/kongctl/internal/tracing"
 	"github.com/kong/kongctl/internal/util/normalizers"
 )
 
@@ -24,12 +33,25 @@ type Executor struct {
 	client   *state.Client
 	reporter ProgressReporter
 	dryRun   bool
+	// executeDryRun runs the changes of a dry run through the execution path
+	executeDryRun bool
 	// Track created resources during execution
 	createdResources map[string]string // changeID -> resourceID
 	// Track resource refs to IDs for reference resolution
 	refToID map[string]map[string]string // resourceType -> ref -> resourceID
 	// Unified state cache
 	stateCache *state.Cache
+	// mu guards createdResources, refToID, the execution result and plan changes
+	// updated while changes run concurrently
+	mu sync.Mutex
+	// cacheMu guards stateCache
+	cacheMu sync.Mutex
+	// started records the changes that have begun executing
+	started map[string]bool
+	// progress reports change progress, serialized when changes run concurrently
+	progress ProgressReporter
+	// secrets holds the values of the !secret placeholders of the plan being executed
+	secrets map[string]string
 
 	// Resource executors
 	portalExecutor       *BaseExecutor[kkComps.CreatePortal, kkComps.UpdatePortal]
@@ -43,6 +65,15 @@ type Executor struct {
 	eventGatewayControlPlaneExecutor *BaseExecutor[kkComps.CreateGatewayRequest, kkComps.UpdateGatewayRequest]
 	organizationTeamExecutor         *BaseExecutor[kkComps.CreateTeam, kkComps.UpdateTeam]
 
+	// Organization team child resource executors
+	organizationTeamRoleExecutor   *BaseCreateDeleteExecutor[kkComps.AssignRole]
+	organizationTeamMemberExecutor *BaseCreateDeleteExecutor[kkComps.AddUserToTeam]
+
+	notificationSubscriptionExecutor *BaseExecutor[kkComps.EventSubscription, kkComps.EventSubscription]
+
+	// Control plane child resource executors
+	gatewayServiceExecutor *BaseExecutor[kkComps.Service, kkComps.Service]
+
 	// Event Gateway child resource executors
 	eventGatewayBackendClusterExecutor *BaseExecutor[
 		kkComps.CreateBackendClusterRequest, kkComps.UpdateBackendClusterRequest]
@@ -63,6 +94,8 @@ type Executor struct {
 	portalEmailConfigExecutor   *BaseExecutor[kkComps.PostPortalEmailConfig, kkComps.PatchPortalEmailConfig]
 	portalEmailTemplateExecutor *BaseExecutor[kkOps.UpdatePortalCustomEmailTemplateRequest,
 		kkOps.UpdatePortalCustomEmailTemplateRequest]
+	portalAuditLogWebhookExecutor *BaseExecutor[kkComps.UpdatePortalAuditLogWebhook,
+		kkComps.UpdatePortalAuditLogWebhook]
 
 	// API child resource executors
 	apiVersionExecutor     *BaseExecutor[kkComps.CreateAPIVersionRequest, kkComps.APIVersion]
@@ -76,6 +109,19 @@ type Executor struct {
 	konnectBaseURL string
 	executionMode  planner.PlanMode
 	planBaseDir    string
+	timeouts       Timeouts
+	parallelism    int
+	// rollbackOnError reverses the applied changes after a change fails
+	rollbackOnError bool
+	// prior holds the values updated fields had before their change, by change ID
+	prior map[string]*priorFields
+	// stateFile is where the execution state is checkpointed after each change
+	stateFile string
+	// checkpoint is the execution state, resumed from an earlier run or started
+	// when a state file is set
+	checkpoint *Checkpoint
+	// untrusted refuses plans that would run commands or read secrets on this host
+	untrusted bool
 }
 
 // Options configures executor behavior.
@@ -85,6 +131,29 @@ type Options struct {
 	KonnectBaseURL string
 	Mode           planner.PlanMode
 	PlanBaseDir    string
+	// Timeouts bounds each change, per resource type
+	Timeouts Timeouts
+	// Parallelism is how many independent changes run at once, DefaultParallelism when unset
+	Parallelism int
+	// ExecuteDryRun makes a dry run execute each change rather than only validate it,
+	// resolving references the way an apply would. The client must then keep the
+	// changes from reaching Konnect, e.g. with an httpclient.DryRunRecorder in the
+	// execution context, and DeckRunner must not run deck against Konnect.
+	ExecuteDryRun bool
+	// RollbackOnError reverses the changes applied before a failure or cancellation:
+	// created resources are deleted and updated fields restored. Dry runs are not
+	// rolled back.
+	RollbackOnError bool
+	// StateFile checkpoints the execution state after each change, so an apply that
+	// stops can be resumed. Dry runs record no state.
+	StateFile string
+	// Resume continues the execution recorded in a state file: changes it completed
+	// are skipped and their created IDs reused. The plan executed must be its plan.
+	Resume *Checkpoint
+	// Untrusted executes plans submitted by someone else, such as the clients of a
+	// service. Plans with secret placeholders, verify commands or changes of custom
+	// resource handlers are refused instead of being run on this host.
+	Untrusted bool
 }
 
 // New creates a new Executor instance with default options.
@@ -98,10 +167,15 @@ func NewWithOptions(client *state.Client, reporter ProgressReporter, dryRun bool
 	if deckRunner == nil {
 		deckRunner = deck.NewRunner()
 	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
 	e := &Executor{
 		client:           client,
 		reporter:         reporter,
 		dryRun:           dryRun,
+		executeDryRun:    dryRun && opts.ExecuteDryRun,
 		createdResources: make(map[string]string),
 		refToID:          make(map[string]map[string]string),
 		stateCache:       state.NewCache(),
@@ -110,43 +184,75 @@ func NewWithOptions(client *state.Client, reporter ProgressReporter, dryRun bool
 		konnectBaseURL:   opts.KonnectBaseURL,
 		executionMode:    opts.Mode,
 		planBaseDir:      strings.TrimSpace(opts.PlanBaseDir),
+		timeouts:         opts.Timeouts,
+		parallelism:      parallelism,
+		rollbackOnError:  opts.RollbackOnError && !dryRun,
+		prior:            make(map[string]*priorFields),
+		stateFile:        opts.StateFile,
+		untrusted:        opts.Untrusted,
 	}
+	if !dryRun {
+		e.checkpoint = opts.Resume
+	}
+
+	// Resources of an executed dry run go through the client like in an apply
+	opsDryRun := dryRun && !e.executeDryRun
 
 	// Initialize resource executors
 	e.portalExecutor = NewBaseExecutor[kkComps.CreatePortal, kkComps.UpdatePortal](
 		NewPortalAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.controlPlaneExecutor = NewBaseExecutor[kkComps.CreateControlPlaneRequest, kkComps.UpdateControlPlaneRequest](
 		NewControlPlaneAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.apiExecutor = NewBaseExecutor[kkComps.CreateAPIRequest, kkComps.UpdateAPIRequest](
 		NewAPIAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.authStrategyExecutor = NewBaseExecutor[kkComps.CreateAppAuthStrategyRequest, kkComps.UpdateAppAuthStrategyRequest](
 		NewAuthStrategyAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.catalogServiceExecutor = NewBaseExecutor[kkComps.CreateCatalogService, kkComps.UpdateCatalogService](
 		NewCatalogServiceAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.eventGatewayControlPlaneExecutor = NewBaseExecutor[kkComps.CreateGatewayRequest, kkComps.UpdateGatewayRequest](
 		NewEventGatewayControlPlaneControlPlaneAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.organizationTeamExecutor = NewBaseExecutor[kkComps.CreateTeam, kkComps.UpdateTeam](
 		NewOrganizationTeamAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
+	)
+	e.organizationTeamRoleExecutor = NewBaseCreateDeleteExecutor[kkComps.AssignRole](
+		NewOrganizationTeamRoleAdapter(client),
+		opsDryRun,
+	)
+	e.organizationTeamMemberExecutor = NewBaseCreateDeleteExecutor[kkComps.AddUserToTeam](
+		NewOrganizationTeamMemberAdapter(client),
+		opsDryRun,
+	)
+	e.notificationSubscriptionExecutor = NewBaseExecutor[kkComps.EventSubscription, kkComps.EventSubscription](
+		NewNotificationSubscriptionAdapter(client),
+		client,
+		opsDryRun,
+	)
+
+	// Initialize control plane child resource executors
+	e.gatewayServiceExecutor = NewBaseExecutor[kkComps.Service, kkComps.Service](
+		NewGatewayServiceAdapter(client),
+		client,
+		opsDryRun,
 	)
 
 	// Initialize event gateway child resource executors
@@ -154,90 +260,96 @@ func NewWithOptions(client *state.Client, reporter ProgressReporter, dryRun bool
 		kkComps.CreateBackendClusterRequest, kkComps.UpdateBackendClusterRequest](
 		NewEventGatewayBackendClusterAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 
 	e.eventGatewayVirtualClusterExecutor = NewBaseExecutor[
 		kkComps.CreateVirtualClusterRequest, kkComps.UpdateVirtualClusterRequest](
 		NewEventGatewayVirtualClusterAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 
 	// Initialize portal child resource executors
 	e.portalCustomizationExecutor = NewBaseSingletonExecutor[kkComps.PortalCustomization](
 		NewPortalCustomizationAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 	e.portalAuthSettingsExecutor = NewBaseSingletonExecutor[kkComps.PortalAuthenticationSettingsUpdateRequest](
 		NewPortalAuthSettingsAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 	e.portalAssetLogoExecutor = NewBaseSingletonExecutor[kkComps.ReplacePortalImageAsset](
 		NewPortalAssetLogoAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 	e.portalAssetFaviconExecutor = NewBaseSingletonExecutor[kkComps.ReplacePortalImageAsset](
 		NewPortalAssetFaviconAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 	e.portalDomainExecutor = NewBaseExecutor[kkComps.CreatePortalCustomDomainRequest,
 		kkComps.UpdatePortalCustomDomainRequest](
 		NewPortalDomainAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.portalPageExecutor = NewBaseExecutor[kkComps.CreatePortalPageRequest, kkComps.UpdatePortalPageRequest](
 		NewPortalPageAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.portalSnippetExecutor = NewBaseExecutor[kkComps.CreatePortalSnippetRequest, kkComps.UpdatePortalSnippetRequest](
 		NewPortalSnippetAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.portalTeamExecutor = NewBaseExecutor[kkComps.PortalCreateTeamRequest, kkComps.PortalUpdateTeamRequest](
 		NewPortalTeamAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.portalTeamRoleExecutor = NewBaseExecutor[kkComps.PortalAssignRoleRequest, kkComps.PortalAssignRoleRequest](
 		NewPortalTeamRoleAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.portalEmailConfigExecutor = NewBaseExecutor[kkComps.PostPortalEmailConfig, kkComps.PatchPortalEmailConfig](
 		NewPortalEmailConfigAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
+	)
+	e.portalAuditLogWebhookExecutor = NewBaseExecutor[kkComps.UpdatePortalAuditLogWebhook,
+		kkComps.UpdatePortalAuditLogWebhook](
+		NewPortalAuditLogWebhookAdapter(client),
+		client,
+		opsDryRun,
 	)
 	e.portalEmailTemplateExecutor = NewBaseExecutor[kkOps.UpdatePortalCustomEmailTemplateRequest,
 		kkOps.UpdatePortalCustomEmailTemplateRequest](
 		NewPortalEmailTemplateAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 
 	// Initialize API child resource executors
 	e.apiVersionExecutor = NewBaseExecutor[kkComps.CreateAPIVersionRequest, kkComps.APIVersion](
 		NewAPIVersionAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 	e.apiPublicationExecutor = NewBaseCreateDeleteExecutor[kkComps.APIPublication](
 		NewAPIPublicationAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 	e.apiDocumentExecutor = NewBaseExecutor[kkComps.CreateAPIDocumentRequest, kkComps.APIDocument](
 		NewAPIDocumentAdapter(client),
 		client,
-		dryRun,
+		opsDryRun,
 	)
 
 	e.apiImplementationExecutor = NewBaseCreateDeleteExecutor[kkComps.APIImplementation](
 		NewAPIImplementationAdapter(client),
-		dryRun,
+		opsDryRun,
 	)
 
 	return e
@@ -246,39 +358,35 @@ func NewWithOptions(client *state.Client, reporter ProgressReporter, dryRun bool
 // Execute runs the plan and returns the execution result
 func (e *Executor) Execute(ctx context.Context, plan *planner.Plan) *ExecutionResult {
 	result := &ExecutionResult{
-		DryRun: e.dryRun,
+		DryRun:    e.dryRun,
+		StartedAt: time.Now(),
 	}
 
+	ctx, span := tracing.Start(ctx, tracing.SpanApply,
+		tracing.AttrMode.String(string(plan.Metadata.Mode)),
+		tracing.AttrDryRun.Bool(e.dryRun),
+	)
+	defer span.End()
+
 	// Notify reporter of execution start
 	if e.reporter != nil {
 		e.reporter.StartExecution(plan)
 	}
 
-	// Execute changes in order
-	for i, changeID := range plan.ExecutionOrder {
-		// Find the change by ID
-		var change *planner.PlannedChange
-		for j := range plan.Changes {
-			if plan.Changes[j].ID == changeID {
-				change = &plan.Changes[j]
-				break
-			}
-		}
-
-		if change == nil {
-			// This shouldn't happen, but handle gracefully
-			err := fmt.Errorf("change with ID %s not found in plan", changeID)
-			result.Errors = append(result.Errors, ExecutionError{
-				ChangeID: changeID,
-				Error:    err.Error(),
-			})
-			result.FailureCount++
-			continue
-		}
-
-		// Execute the change, the error will be captured in result
-		_ = e.executeChange(ctx, result, change, plan, i)
+	if err := e.checkUntrusted(plan); err != nil {
+		e.abortExecution(result, plan, err)
+	} else if err := e.resolveSecrets(plan); err != nil {
+		// Nothing is changed when a secret cannot be read
+		e.abortExecution(result, plan, err)
+	} else if err := e.startCheckpoint(plan); err != nil {
+		e.abortExecution(result, plan, err)
+	} else {
+		e.executeChanges(ctx, result, plan)
+	}
+	if e.rollbackOnError && (result.HasErrors() || result.Canceled) && len(result.ChangesApplied) > 0 {
+		e.rollback(ctx, result, plan)
 	}
+	result.FinishedAt = time.Now()
 
 	// Notify reporter of execution completion
 	if e.reporter != nil {
@@ -292,16 +400,21 @@ func (e *Executor) Execute(ctx context.Context, plan *planner.Plan) *ExecutionRe
 func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, change *planner.PlannedChange,
 	plan *planner.Plan, changeIndex int,
 ) error {
+	started := time.Now()
+
 	// Notify reporter of change start
-	if e.reporter != nil {
-		e.reporter.StartChange(*change)
+	if e.progress != nil {
+		e.progress.StartChange(*change)
 	}
 
 	// Extract resource name from fields
 	resourceName := getResourceName(change.Fields)
 
+	// A create an interrupted run attempted may have reached Konnect
+	recoveredID := e.recoverCreate(ctx, change)
+
 	// Pre-execution validation (always performed, even in dry-run)
-	if err := e.validateChangePreExecution(ctx, *change); err != nil {
+	if err := e.prepareChange(ctx, change, recoveredID); err != nil {
 		// Record error
 		execError := ExecutionError{
 			ChangeID:     change.ID,
@@ -311,8 +424,10 @@ func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, c
 			Action:       string(change.Action),
 			Error:        err.Error(),
 		}
+		e.mu.Lock()
 		result.Errors = append(result.Errors, execError)
 		result.FailureCount++
+		operation := recordOperation(result, change, OperationFailed, started, "", err)
 
 		// In dry-run, also record validation result
 		if e.dryRun {
@@ -327,18 +442,22 @@ func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, c
 				Message:      err.Error(),
 			})
 		}
+		e.mu.Unlock()
 
 		// Notify reporter
-		if e.reporter != nil {
-			e.reporter.CompleteChange(*change, err)
+		e.reportOperation(change, operation)
+		if e.progress != nil {
+			e.progress.CompleteChange(*change, err)
 		}
 
 		return err
 	}
 
-	// If dry-run, skip actual execution
-	if e.dryRun {
+	// If dry-run, skip actual execution unless the dry run executes changes
+	if e.dryRun && !e.executeDryRun {
+		e.mu.Lock()
 		result.SkippedCount++
+		operation := recordOperation(result, change, OperationSkipped, started, "", nil)
 		result.ValidationResults = append(result.ValidationResults, ValidationResult{
 			ChangeID:     change.ID,
 			ResourceType: change.ResourceType,
@@ -348,9 +467,11 @@ func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, c
 			Status:       "would_succeed",
 			Validation:   "passed",
 		})
+		e.mu.Unlock()
 
-		if e.reporter != nil {
-			e.reporter.SkipChange(*change, "dry-run mode")
+		e.reportOperation(change, operation)
+		if e.progress != nil {
+			e.progress.SkipChange(*change, "dry-run mode")
 		}
 
 		return nil
@@ -360,29 +481,53 @@ func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, c
 	var err error
 	var resourceID string
 
+	// Secrets are only substituted in the change sent to Konnect
+	target := e.withSecrets(change)
+	changeCtx, cancel, timeout := e.changeContext(ctx, change)
+	changeCtx = e.withSecretRedactingLogger(changeCtx)
+	if retries, ok := e.progress.(RetryReporter); ok {
+		retried := *change
+		changeCtx = httpclient.WithRetryObserver(changeCtx, func() { retries.RetryChange(retried) })
+	}
+	if e.rollbackOnError && change.Action == planner.ActionUpdate {
+		prior := &priorFields{}
+		e.mu.Lock()
+		e.prior[change.ID] = prior
+		e.mu.Unlock()
+		changeCtx = withPriorFields(changeCtx, prior)
+	}
 	switch change.Action {
 	case planner.ActionCreate:
-		if change.ResourceType == planner.ResourceTypeDeck {
-			err = e.executeDeckStep(ctx, change, plan)
-		} else {
-			resourceID, err = e.createResource(ctx, change)
+		switch {
+		case recoveredID != "":
+			resourceID = recoveredID
+		case change.ResourceType == planner.ResourceTypeDeck:
+			err = e.executeDeckStep(changeCtx, target, plan)
+		default:
+			resourceID, err = e.createResource(changeCtx, target)
 		}
 	case planner.ActionExternalTool:
 		if change.ResourceType != planner.ResourceTypeDeck {
 			err = fmt.Errorf("external tool action is only supported for %s resources", planner.ResourceTypeDeck)
 		} else {
-			err = e.executeDeckStep(ctx, change, plan)
+			err = e.executeDeckStep(changeCtx, target, plan)
 		}
 	case planner.ActionUpdate:
-		resourceID, err = e.updateResource(ctx, change)
+		resourceID, err = e.updateResource(changeCtx, target)
+	case planner.ActionSwitch:
+		resourceID, err = e.switchResource(changeCtx, target)
 	case planner.ActionDelete:
-		err = e.deleteResource(ctx, change)
-		resourceID = change.ResourceID
+		err = e.deleteResource(changeCtx, target)
+		resourceID = target.ResourceID
 	default:
 		err = fmt.Errorf("unknown action: %s", change.Action)
 	}
+	err = timeoutError(changeCtx, change, timeout, err)
+	cancel()
 
 	// Record result
+	var operation OperationRecord
+	e.mu.Lock()
 	if err != nil {
 		execError := ExecutionError{
 			ChangeID:     change.ID,
@@ -391,85 +536,150 @@ func (e *Executor) executeChange(ctx context.Context, result *ExecutionResult, c
 			ResourceRef:  change.ResourceRef,
 			Action:       string(change.Action),
 			Error:        err.Error(),
+			RequestID:    requestIDOf(err),
 		}
 		result.Errors = append(result.Errors, execError)
 		result.FailureCount++
+		operation = recordOperation(result, change, OperationFailed, started, resourceID, err)
+		if e.dryRun {
+			result.ValidationResults = append(result.ValidationResults, ValidationResult{
+				ChangeID:     change.ID,
+				ResourceType: change.ResourceType,
+				ResourceName: resourceName,
+				ResourceRef:  change.ResourceRef,
+				Action:       string(change.Action),
+				Status:       "would_fail",
+				Validation:   "failed",
+				Message:      err.Error(),
+			})
+		}
 	} else {
-		result.SuccessCount++
-		result.ChangesApplied = append(result.ChangesApplied, AppliedChange{
-			ChangeID:     change.ID,
-			ResourceType: change.ResourceType,
-			ResourceName: resourceName,
-			ResourceRef:  change.ResourceRef,
-			Action:       string(change.Action),
-			ResourceID:   resourceID,
-		})
+		if e.dryRun {
+			// Executed dry runs report like validated ones, so nothing counts as applied
+			result.SkippedCount++
+			operation = recordOperation(result, change, OperationSkipped, started, "", nil)
+			result.ValidationResults = append(result.ValidationResults, ValidationResult{
+				ChangeID:     change.ID,
+				ResourceType: change.ResourceType,
+				ResourceName: resourceName,
+				ResourceRef:  change.ResourceRef,
+				Action:       string(change.Action),
+				Status:       "would_succeed",
+				Validation:   "passed",
+			})
+		} else {
+			result.SuccessCount++
+			operation = recordOperation(result, change, OperationSucceeded, started, resourceID, nil)
+			result.ChangesApplied = append(result.ChangesApplied, AppliedChange{
+				ChangeID:     change.ID,
+				ResourceType: change.ResourceType,
+				ResourceName: resourceName,
+				ResourceRef:  change.ResourceRef,
+				Action:       string(change.Action),
+				ResourceID:   resourceID,
+			})
+		}
 
 		// Track created resources for dependencies
 		if change.Action == planner.ActionCreate && resourceID != "" {
-			e.createdResources[change.ID] = resourceID
-
-			// Also track by resource type and ref for reference resolution
-			if e.refToID[change.ResourceType] == nil {
-				e.refToID[change.ResourceType] = make(map[string]string)
-			}
-			e.refToID[change.ResourceType][change.ResourceRef] = resourceID
-
-			// Propagate the created resource ID to any pending changes that reference it
-			if changeIndex+1 < len(plan.ExecutionOrder) {
-				// Update remaining changes directly in plan.Changes
-				for i := changeIndex + 1; i < len(plan.ExecutionOrder); i++ {
-					changeID := plan.ExecutionOrder[i]
-					for j := range plan.Changes {
-						if plan.Changes[j].ID == changeID {
-							// Check all references in this change
-							for refKey, refInfo := range plan.Changes[j].References {
-								// Match based on resource type from the reference key
-								refResourceType := strings.TrimSuffix(refKey, "_id")
-
-								// Extract the actual ref from __REF__ format if present
-								actualRef := refInfo.Ref
-								if strings.HasPrefix(refInfo.Ref, tags.RefPlaceholderPrefix) {
-									parsedRef, _, ok := tags.ParseRefPlaceholder(refInfo.Ref)
-									if ok {
-										actualRef = parsedRef
-									}
-								}
-
-								if refResourceType == change.ResourceType && actualRef == change.ResourceRef {
-									// Update the reference with the created resource ID
-									refInfo.ID = resourceID
-									plan.Changes[j].References[refKey] = refInfo
-									slog.Debug("Propagated created resource ID to dependent change",
-										"change_id", plan.Changes[j].ID,
-										"ref_key", refKey,
-										"resource_type", change.ResourceType,
-										"resource_ref", change.ResourceRef,
-										"resource_id", resourceID,
-									)
-								}
-							}
-							break
-						}
-					}
-				}
-			}
+			e.recordCreated(plan, change, changeIndex, resourceID)
 		}
 	}
 
+	e.mu.Unlock()
+	if err == nil {
+		e.checkpointCompleted(change, resourceID)
+	}
+
 	// Notify reporter
-	if e.reporter != nil {
-		e.reporter.CompleteChange(*change, err)
+	e.reportOperation(change, operation)
+	if e.progress != nil {
+		e.progress.CompleteChange(*change, err)
 	}
 
 	return err
 }
 
+// reportOperation passes the record of a finished change to the progress reporter
+// when it receives operation records
+func (e *Executor) reportOperation(change *planner.PlannedChange, operation OperationRecord) {
+	if reporter, ok := e.progress.(operationReporter); ok {
+		reporter.completeOperation(*change, operation)
+	}
+}
+
+// recordCreated remembers the ID of a created resource and propagates it to the
+// pending changes that reference it. The caller holds e.mu.
+func (e *Executor) recordCreated(plan *planner.Plan, change *planner.PlannedChange, changeIndex int,
+	resourceID string,
+) {
+	e.createdResources[change.ID] = resourceID
+
+	// Also track by resource type and ref for reference resolution
+	if e.refToID[change.ResourceType] == nil {
+		e.refToID[change.ResourceType] = make(map[string]string)
+	}
+	e.refToID[change.ResourceType][change.ResourceRef] = resourceID
+
+	// Propagate the created resource ID to any pending changes that reference it
+	if changeIndex+1 < len(plan.ExecutionOrder) {
+		// Update remaining changes directly in plan.Changes
+		for i := changeIndex + 1; i < len(plan.ExecutionOrder); i++ {
+			changeID := plan.ExecutionOrder[i]
+			// Changes already running read their references and must not see them change
+			if e.started[changeID] {
+				continue
+			}
+			for j := range plan.Changes {
+				if plan.Changes[j].ID == changeID {
+					// Check all references in this change
+					for refKey, refInfo := range plan.Changes[j].References {
+						// Match based on resource type from the reference key
+						refResourceType := strings.TrimSuffix(refKey, "_id")
+
+						// Extract the actual ref from __REF__ format if present
+						actualRef := refInfo.Ref
+						if strings.HasPrefix(refInfo.Ref, tags.RefPlaceholderPrefix) {
+							parsedRef, _, ok := tags.ParseRefPlaceholder(refInfo.Ref)
+							if ok {
+								actualRef = parsedRef
+							}
+						}
+
+						if refResourceType == change.ResourceType && actualRef == change.ResourceRef {
+							// Update the reference with the created resource ID
+							refInfo.ID = resourceID
+							plan.Changes[j].References[refKey] = refInfo
+							slog.Debug("Propagated created resource ID to dependent change",
+								"change_id", plan.Changes[j].ID,
+								"ref_key", refKey,
+								"resource_type", change.ResourceType,
+								"resource_ref", change.ResourceRef,
+								"resource_id", resourceID,
+							)
+						}
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
 // validateChangePreExecution performs validation before executing a change
 func (e *Executor) validateChangePreExecution(ctx context.Context, change planner.PlannedChange) error {
 	switch change.Action {
 	case planner.ActionExternalTool:
 		return nil
+	case planner.ActionSwitch:
+		// A switch stands in for the create or update of its publication and gets the
+		// checks of that action; only a switch of an existing publication has an ID
+		if change.ResourceID == "" {
+			change.Action = planner.ActionCreate
+		} else {
+			change.Action = planner.ActionUpdate
+		}
+		return e.validateChangePreExecution(ctx, change)
 	case planner.ActionUpdate, planner.ActionDelete:
 		// For update/delete, verify resource still exists and check protection
 		// Special case: singleton portal children without their own ID
@@ -543,8 +753,8 @@ func (e *Executor) validateChangePreExecution(ctx context.Context, change planne
 				}
 
 				// Block protected resources unless it's a protection change
-				if isProtected && !isProtectionChange &&
-					(change.Action == planner.ActionUpdate || change.Action == planner.ActionDelete) {
+				if isProtected && !isProtectionChange && (change.Action == planner.ActionUpdate ||
+					change.Action == planner.ActionDelete && !change.DeleteProtected) {
 					return fmt.Errorf("resource is protected and cannot be %s",
 						actionToVerb(change.Action))
 				}
@@ -605,6 +815,34 @@ func (e *Executor) validateChangePreExecution(ctx context.Context, change planne
 	return nil
 }
 
+// refIDs returns a copy of the IDs known for refs of a resource type
+func (e *Executor) refIDs(resourceType string) (map[string]string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ids, ok := e.refToID[resourceType]
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(ids), true
+}
+
+// cacheRefID remembers the ID a ref resolved to when refs of its resource type are tracked
+func (e *Executor) cacheRefID(resourceType, ref, id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if ids, ok := e.refToID[resourceType]; ok {
+		ids[ref] = id
+	}
+}
+
+// createdResourceID returns the ID of the resource a change created in this execution
+func (e *Executor) createdResourceID(changeID string) (string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	id, ok := e.createdResources[changeID]
+	return id, ok
+}
+
 // resolveAuthStrategyRef resolves an auth strategy reference to its ID
 func (e *Executor) resolveAuthStrategyRef(ctx context.Context, refInfo planner.ReferenceInfo) (string, error) {
 	lookupRef := refInfo.Ref
@@ -615,7 +853,7 @@ func (e *Executor) resolveAuthStrategyRef(ctx context.Context, refInfo planner.R
 	}
 
 	// First check if it was created in this execution
-	if authStrategies, ok := e.refToID["application_auth_strategy"]; ok {
+	if authStrategies, ok := e.refIDs("application_auth_strategy"); ok {
 		if id, found := authStrategies[lookupRef]; found {
 			return id, nil
 		}
@@ -650,19 +888,24 @@ func (e *Executor) resolveAuthStrategyRef(ctx context.Context, refInfo planner.R
 // resolvePortalRef resolves a portal reference to its ID
 func (e *Executor) resolvePortalRef(ctx context.Context, refInfo planner.ReferenceInfo) (string, error) {
 	// First check if the reference already has an ID (resolved from dependency)
-	if refInfo.ID != "" {
+	if refInfo.ID != "" && refInfo.ID != "[unknown]" {
 		return refInfo.ID, nil
 	}
 
+	lookupRef := refInfo.Ref
+	if parsedRef, _, ok := tags.ParseRefPlaceholder(lookupRef); ok && parsedRef != "" {
+		lookupRef = parsedRef
+	}
+
 	// Check if it was created in this execution
-	if portals, ok := e.refToID["portal"]; ok {
-		if id, found := portals[refInfo.Ref]; found {
+	if portals, ok := e.refIDs("portal"); ok {
+		if id, found := portals[lookupRef]; found {
 			return id, nil
 		}
 	}
 
 	// Determine the lookup value - use name from lookup fields if available
-	lookupValue := refInfo.Ref
+	lookupValue := lookupRef
 	if refInfo.LookupFields != nil {
 		if name, hasName := refInfo.LookupFields["name"]; hasName && name != "" {
 			lookupValue = name
@@ -690,7 +933,7 @@ func (e *Executor) resolvePortalTeamRef(
 		return "", fmt.Errorf("portal ID is required to resolve portal team")
 	}
 
-	if teams, ok := e.refToID["portal_team"]; ok {
+	if teams, ok := e.refIDs("portal_team"); ok {
 		if id, found := teams[refInfo.Ref]; found && id != "" {
 			return id, nil
 		}
@@ -717,6 +960,91 @@ func (e *Executor) resolvePortalTeamRef(
 	return "", fmt.Errorf("portal team not found: ref=%s, looked up by name=%s", refInfo.Ref, lookupValue)
 }
 
+// resolveOrganizationTeamChildRefs resolves the parent team of a team role or member
+// change, created earlier in this execution or looked up by name
+func (e *Executor) resolveOrganizationTeamChildRefs(ctx context.Context, change *planner.PlannedChange) error {
+	teamRef, ok := change.References["organization_team_id"]
+	if !ok || (teamRef.ID != "" && teamRef.ID != "[unknown]") {
+		return nil
+	}
+
+	if teams, ok := e.refIDs("organization_team"); ok {
+		if id, found := teams[teamRef.Ref]; found && id != "" {
+			teamRef.ID = id
+			change.References["organization_team_id"] = teamRef
+			return nil
+		}
+	}
+
+	lookupValue := teamRef.Ref
+	if name, hasName := teamRef.LookupFields["name"]; hasName && name != "" {
+		lookupValue = name
+	}
+	team, err := e.client.GetOrganizationTeamByName(ctx, lookupValue)
+	if err != nil {
+		return fmt.Errorf("failed to resolve organization team reference: %w", err)
+	}
+	if team == nil || team.ID == nil {
+		return fmt.Errorf("organization team not found: ref=%s, looked up by name=%s", teamRef.Ref, lookupValue)
+	}
+
+	teamRef.ID = *team.ID
+	change.References["organization_team_id"] = teamRef
+	return nil
+}
+
+// resolveRoleEntityRef resolves the !ref entity_id of an organization team role by the
+// kind of entity the role applies to
+func (e *Executor) resolveRoleEntityRef(
+	ctx context.Context, entityTypeName string, refInfo planner.ReferenceInfo,
+) (string, error) {
+	switch entityTypeName {
+	case string(kkComps.EntityTypeNameControlPlanes):
+		return e.resolveControlPlaneRef(ctx, refInfo)
+	case string(kkComps.EntityTypeNameApIs):
+		return e.resolveAPIRef(ctx, refInfo)
+	case string(kkComps.EntityTypeNamePortals):
+		return e.resolvePortalRef(ctx, refInfo)
+	default:
+		return "", fmt.Errorf("entity_id references are not supported for entity type %q", entityTypeName)
+	}
+}
+
+// resolveNotificationEntityRefs replaces the !ref entities of a notification subscription
+// change by the IDs of the resources they reference, created earlier in this execution
+// or looked up by name
+func (e *Executor) resolveNotificationEntityRefs(ctx context.Context, change *planner.PlannedChange) error {
+	refInfo, ok := change.References["entities"]
+	if !ok || !refInfo.IsArray {
+		return nil
+	}
+
+	entities := stringsFromField(change.Fields["entities"])
+	for i, entity := range entities {
+		if !tags.IsRefPlaceholder(entity) {
+			continue
+		}
+		index := slices.Index(refInfo.Refs, entity)
+		if index == -1 {
+			return fmt.Errorf("missing reference information for notification entity %q", entity)
+		}
+		entityType := ""
+		if types := refInfo.LookupArrays["entity_types"]; index < len(types) {
+			entityType = types[index]
+		}
+		id, err := e.resolveRoleEntityRef(ctx, entityType, planner.ReferenceInfo{
+			Ref:          entity,
+			LookupFields: buildLookupFieldsForIndex(refInfo, index),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resolve notification entity reference: %w", err)
+		}
+		entities[i] = id
+	}
+	change.Fields["entities"] = entities
+	return nil
+}
+
 func (e *Executor) resolveControlPlaneRef(ctx context.Context, refInfo planner.ReferenceInfo) (string, error) {
 	lookupRef := refInfo.Ref
 	if tags.IsRefPlaceholder(lookupRef) {
@@ -725,7 +1053,7 @@ func (e *Executor) resolveControlPlaneRef(ctx context.Context, refInfo planner.R
 		}
 	}
 
-	if controlPlanes, ok := e.refToID["control_plane"]; ok {
+	if controlPlanes, ok := e.refIDs("control_plane"); ok {
 		if id, found := controlPlanes[lookupRef]; found && id != "" && id != "[unknown]" {
 			return id, nil
 		}
@@ -900,7 +1228,7 @@ func (e *Executor) resolveAPIRef(ctx context.Context, refInfo planner.ReferenceI
 	}
 
 	// First check if it was created in this execution
-	if apis, ok := e.refToID["api"]; ok {
+	if apis, ok := e.refIDs("api"); ok {
 		if id, found := apis[lookupRef]; found {
 			slog.Debug("Resolved API reference from created resources",
 				"api_ref", lookupRef,
@@ -936,9 +1264,7 @@ func (e *Executor) resolveAPIRef(ctx context.Context, refInfo planner.ReferenceI
 			)
 
 			// Cache this resolution
-			if apis, ok := e.refToID["api"]; ok {
-				apis[refInfo.Ref] = apiID
-			}
+			e.cacheRefID("api", refInfo.Ref, apiID)
 			return apiID, nil
 		}
 		lastErr = err
@@ -958,7 +1284,7 @@ func (e *Executor) resolveEventGatewayRef(ctx context.Context, refInfo planner.R
 	}
 
 	// First check if it was created in this execution
-	if gateways, ok := e.refToID["event_gateway"]; ok {
+	if gateways, ok := e.refIDs("event_gateway"); ok {
 		if id, found := gateways[lookupRef]; found {
 			slog.Debug("Resolved event gateway reference from created resources",
 				"gateway_ref", lookupRef,
@@ -993,9 +1319,7 @@ func (e *Executor) resolveEventGatewayRef(ctx context.Context, refInfo planner.R
 	)
 
 	// Cache this resolution
-	if gateways, ok := e.refToID["event_gateway"]; ok {
-		gateways[refInfo.Ref] = gatewayID
-	}
+	e.cacheRefID("event_gateway", refInfo.Ref, gatewayID)
 
 	return gatewayID, nil
 }
@@ -1012,7 +1336,7 @@ func (e *Executor) resolveEventGatewayBackendClusterRef(
 	}
 
 	// First check if it was created in this execution
-	if backendCluster, ok := e.refToID["event_gateway_backend_cluster"]; ok {
+	if backendCluster, ok := e.refIDs("event_gateway_backend_cluster"); ok {
 		if id, found := backendCluster[lookupRef]; found {
 			slog.Debug("Resolved event gateway backend cluster reference from created resources",
 				"backend_cluster_ref", lookupRef,
@@ -1048,14 +1372,12 @@ func (e *Executor) resolveEventGatewayBackendClusterRef(
 	)
 
 	// Cache this resolution
-	if backendClusters, ok := e.refToID["event_gateway_backend_cluster"]; ok {
-		backendClusters[refInfo.Ref] = backendClusterID
-	}
+	e.cacheRefID("event_gateway_backend_cluster", refInfo.Ref, backendClusterID)
 
 	return backendClusterID, nil
 }
 
-// populatePortalPages fetches and caches all pages for a portal
+// populatePortalPages fetches and caches all pages for a portal. Callers hold cacheMu.
 func (e *Executor) populatePortalPages(ctx context.Context, portalID string) error {
 	portal, exists := e.stateCache.Portals[portalID]
 	if !exists {
@@ -1097,7 +1419,7 @@ func (e *Executor) populatePortalPages(ctx context.Context, portalID string) err
 	return nil
 }
 
-// populateAPIDocuments fetches and caches all documents for an API
+// populateAPIDocuments fetches and caches all documents for an API. Callers hold cacheMu.
 func (e *Executor) populateAPIDocuments(ctx context.Context, apiID string) error {
 	if apiID == "" {
 		return fmt.Errorf("API ID is required to populate documents")
@@ -1162,12 +1484,15 @@ func (e *Executor) resolvePortalPageRef(
 	ctx context.Context, portalID string, pageRef string, lookupFields map[string]string,
 ) (string, error) {
 	// First check if it was created in this execution
-	if pages, ok := e.refToID["portal_page"]; ok {
+	if pages, ok := e.refIDs("portal_page"); ok {
 		if id, found := pages[pageRef]; found {
 			return id, nil
 		}
 	}
 
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
 	// Ensure portal pages are cached
 	if _, exists := e.stateCache.Portals[portalID]; !exists ||
 		e.stateCache.Portals[portalID].Pages == nil {
@@ -1225,7 +1550,7 @@ func (e *Executor) resolveAPIDocumentRef(
 		}
 	}
 
-	if docs, ok := e.refToID["api_document"]; ok {
+	if docs, ok := e.refIDs("api_document"); ok {
 		if id, found := docs[actualRef]; found {
 			return id, nil
 		}
@@ -1235,6 +1560,9 @@ func (e *Executor) resolveAPIDocumentRef(
 		return "", fmt.Errorf("API ID is required to resolve document reference")
 	}
 
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+
 	if err := e.populateAPIDocuments(ctx, apiID); err != nil {
 		return "", err
 	}
@@ -1589,6 +1917,16 @@ func (e *Executor) createResource(ctx context.Context, change *planner.PlannedCh
 			change.References["portal_id"] = portalRef
 		}
 		return e.portalEmailConfigExecutor.Create(ctx, *change)
+	case "portal_audit_log_webhook":
+		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
+			portalID, err := e.resolvePortalRef(ctx, portalRef)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve portal reference: %w", err)
+			}
+			portalRef.ID = portalID
+			change.References["portal_id"] = portalRef
+		}
+		return e.portalAuditLogWebhookExecutor.Create(ctx, *change)
 	case "portal_email_template":
 		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
 			portalID, err := e.resolvePortalRef(ctx, portalRef)
@@ -1599,6 +1937,17 @@ func (e *Executor) createResource(ctx context.Context, change *planner.PlannedCh
 			change.References["portal_id"] = portalRef
 		}
 		return e.portalEmailTemplateExecutor.Create(ctx, *change)
+	case "gateway_service":
+		// Resolve control plane reference if the control plane was created by this plan
+		if cpRef, ok := change.References["control_plane_id"]; ok && (cpRef.ID == "" || cpRef.ID == "[unknown]") {
+			cpID, err := e.resolveControlPlaneRef(ctx, cpRef)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve control plane reference: %w", err)
+			}
+			cpRef.ID = cpID
+			change.References["control_plane_id"] = cpRef
+		}
+		return e.gatewayServiceExecutor.Create(ctx, *change)
 	case "event_gateway":
 		return e.eventGatewayControlPlaneExecutor.Create(ctx, *change)
 	case "event_gateway_backend_cluster":
@@ -1639,7 +1988,34 @@ func (e *Executor) createResource(ctx context.Context, change *planner.PlannedCh
 		return e.eventGatewayVirtualClusterExecutor.Create(ctx, *change)
 	case "organization_team":
 		return e.organizationTeamExecutor.Create(ctx, *change)
+	case "organization_team_role":
+		if err := e.resolveOrganizationTeamChildRefs(ctx, change); err != nil {
+			return "", err
+		}
+		if entityRef, ok := change.References["entity_id"]; ok && (entityRef.ID == "" || entityRef.ID == "[unknown]") {
+			entityTypeName, _ := change.Fields["entity_type_name"].(string)
+			entityID, err := e.resolveRoleEntityRef(ctx, entityTypeName, entityRef)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve entity reference: %w", err)
+			}
+			entityRef.ID = entityID
+			change.References["entity_id"] = entityRef
+		}
+		return e.organizationTeamRoleExecutor.Create(ctx, *change)
+	case "organization_team_member":
+		if err := e.resolveOrganizationTeamChildRefs(ctx, change); err != nil {
+			return "", err
+		}
+		return e.organizationTeamMemberExecutor.Create(ctx, *change)
+	case "notification_subscription":
+		if err := e.resolveNotificationEntityRefs(ctx, change); err != nil {
+			return "", err
+		}
+		return e.notificationSubscriptionExecutor.Create(ctx, *change)
 	default:
+		if handler, ok := custom.Lookup(change.ResourceType); ok {
+			return e.createCustomResource(ctx, handler, change)
+		}
 		return "", fmt.Errorf("create operation not yet implemented for %s", change.ResourceType)
 	}
 }
@@ -1766,6 +2142,16 @@ func (e *Executor) updateResource(ctx context.Context, change *planner.PlannedCh
 			change.References["portal_id"] = portalRef
 		}
 		return e.portalEmailConfigExecutor.Update(ctx, *change)
+	case "portal_audit_log_webhook":
+		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
+			portalID, err := e.resolvePortalRef(ctx, portalRef)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve portal reference: %w", err)
+			}
+			portalRef.ID = portalID
+			change.References["portal_id"] = portalRef
+		}
+		return e.portalAuditLogWebhookExecutor.Update(ctx, *change)
 	case "portal_email_template":
 		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
 			portalID, err := e.resolvePortalRef(ctx, portalRef)
@@ -1850,6 +2236,8 @@ func (e *Executor) updateResource(ctx context.Context, change *planner.PlannedCh
 		}
 		return e.apiVersionExecutor.Update(ctx, *change)
 	// Note: api_publication and api_implementation don't support update
+	case "gateway_service":
+		return e.gatewayServiceExecutor.Update(ctx, *change)
 	case "event_gateway":
 		return e.eventGatewayControlPlaneExecutor.Update(ctx, *change)
 	case "event_gateway_backend_cluster":
@@ -1876,7 +2264,15 @@ func (e *Executor) updateResource(ctx context.Context, change *planner.PlannedCh
 		return e.eventGatewayVirtualClusterExecutor.Update(ctx, *change)
 	case "organization_team":
 		return e.organizationTeamExecutor.Update(ctx, *change)
+	case "notification_subscription":
+		if err := e.resolveNotificationEntityRefs(ctx, change); err != nil {
+			return "", err
+		}
+		return e.notificationSubscriptionExecutor.Update(ctx, *change)
 	default:
+		if handler, ok := custom.Lookup(change.ResourceType); ok {
+			return e.updateCustomResource(ctx, handler, change)
+		}
 		return "", fmt.Errorf("update operation not yet implemented for %s", change.ResourceType)
 	}
 }
@@ -1993,6 +2389,16 @@ func (e *Executor) deleteResource(ctx context.Context, change *planner.PlannedCh
 			change.References["portal_id"] = portalRef
 		}
 		return e.portalEmailConfigExecutor.Delete(ctx, *change)
+	case "portal_audit_log_webhook":
+		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
+			portalID, err := e.resolvePortalRef(ctx, portalRef)
+			if err != nil {
+				return fmt.Errorf("failed to resolve portal reference: %w", err)
+			}
+			portalRef.ID = portalID
+			change.References["portal_id"] = portalRef
+		}
+		return e.portalAuditLogWebhookExecutor.Delete(ctx, *change)
 	case "portal_email_template":
 		if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID == "" {
 			portalID, err := e.resolvePortalRef(ctx, portalRef)
@@ -2004,6 +2410,9 @@ func (e *Executor) deleteResource(ctx context.Context, change *planner.PlannedCh
 		}
 		return e.portalEmailTemplateExecutor.Delete(ctx, *change)
 	// Note: portal_customization is a singleton resource and cannot be deleted
+	case "gateway_service":
+		// No need to resolve control plane reference for delete - parent ID should be in Parent field
+		return e.gatewayServiceExecutor.Delete(ctx, *change)
 	case "event_gateway":
 		return e.eventGatewayControlPlaneExecutor.Delete(ctx, *change)
 	case "event_gateway_backend_cluster":
@@ -2014,7 +2423,18 @@ func (e *Executor) deleteResource(ctx context.Context, change *planner.PlannedCh
 		return e.eventGatewayVirtualClusterExecutor.Delete(ctx, *change)
 	case "organization_team":
 		return e.organizationTeamExecutor.Delete(ctx, *change)
+	case "organization_team_role":
+		// Parent team ID is set by the planner for deletes
+		return e.organizationTeamRoleExecutor.Delete(ctx, *change)
+	case "organization_team_member":
+		return e.organizationTeamMemberExecutor.Delete(ctx, *change)
+	case "notification_subscription":
+		// The event of the subscription is set by the planner as its parent
+		return e.notificationSubscriptionExecutor.Delete(ctx, *change)
 	default:
+		if handler, ok := custom.Lookup(change.ResourceType); ok {
+			return e.deleteCustomResource(ctx, handler, change)
+		}
 		return fmt.Errorf("delete operation not yet implemented for %s", change.ResourceType)
 	}
 }
@@ -2027,6 +2447,29 @@ func getResourceName(fields map[string]any) string {
 	return common.ExtractResourceName(fields)
 }
 
+// requestIDOf returns the ID Konnect gave the request an error came from: the
+// request ID header of untyped SDK errors, or the instance of a problem details body
+func requestIDOf(err error) string {
+	var sdkErr *sdkerrors.SDKError
+	if errors.As(err, &sdkErr) && sdkErr.RawResponse != nil {
+		if id := httpclient.RequestID(sdkErr.RawResponse.Header); id != "" {
+			return id
+		}
+	}
+	text := err.Error()
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return ""
+	}
+	var problem struct {
+		Instance string `json:"instance"`
+	}
+	if json.NewDecoder(strings.NewReader(text[start:])).Decode(&problem) != nil {
+		return ""
+	}
+	return strings.TrimSpace(problem.Instance)
+}
+
 // actionToVerb is deprecated, use common utilities instead
 // Kept for backward compatibility with existing code
 func actionToVerb(action planner.ActionType) string {
@@ -2039,6 +2482,8 @@ func actionToVerb(action planner.ActionType) string {
 		return "deleted"
 	case planner.ActionExternalTool:
 		return "executed"
+	case planner.ActionSwitch:
+		return "switched"
 	default:
 		return string(action)
 	}
@@ -2078,7 +2523,7 @@ func (e *Executor) getParentAPIID(ctx context.Context, change planner.PlannedCha
 	// Check if parent was created in this execution
 	logger.Debug("Checking dependencies", slog.Int("dep_count", len(change.DependsOn)))
 	for _, dep := range change.DependsOn {
-		if resourceID, ok := e.createdResources[dep]; ok {
+		if resourceID, ok := e.createdResourceID(dep); ok {
 			logger.Debug("Found parent in created resources",
 				slog.String("dependency", dep),
 				slog.String("resource_id", resourceID),
@@ -3,9 +3,12 @@ package executor
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/Kong/sdk-konnect-go/models/sdkerrors"
 	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -236,6 +239,23 @@ func TestExecutor_ValidateChangePreExecution_Basic(t *testing.T) {
 			},
 			expectError: false, // No validation for non-portal resources yet
 		},
+		{
+			name: "switch of existing publication - checked as update",
+			change: planner.PlannedChange{
+				Action:       planner.ActionSwitch,
+				ResourceType: "api_publication",
+				ResourceID:   "api-123:portal-456",
+			},
+			expectError: false,
+		},
+		{
+			name: "switch of new publication - checked as create",
+			change: planner.PlannedChange{
+				Action:       planner.ActionSwitch,
+				ResourceType: "api_publication",
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -309,7 +329,8 @@ func TestExecutor_ExecutionOrder(t *testing.T) {
 	reporter.On("SkipChange", mock.Anything, mock.Anything).Return()
 	reporter.On("FinishExecution", mock.Anything).Return()
 
-	exec := New(nil, reporter, true) // dry-run
+	// dry-run, one change at a time
+	exec := NewWithOptions(nil, reporter, true, Options{Parallelism: 1})
 
 	// Create a plan with multiple changes
 	plan := planner.NewPlan("1.0", "test", planner.PlanModeSync)
@@ -351,7 +372,7 @@ func TestExecutor_ExecutionOrder(t *testing.T) {
 	assert.Equal(t, []string{"1-c-portal", "2-u-portal", "3-d-portal"}, executionOrder)
 }
 
-func TestExecutor_ContinuesOnError(t *testing.T) {
+func TestExecutor_StopsStartingChangesAfterError(t *testing.T) {
 	reporter := &MockProgressReporter{}
 
 	// Set up expectations
@@ -360,9 +381,9 @@ func TestExecutor_ContinuesOnError(t *testing.T) {
 	reporter.On("CompleteChange", mock.Anything, mock.Anything).Return()
 	reporter.On("FinishExecution", mock.Anything).Return()
 
-	exec := New(nil, reporter, false)
+	exec := NewWithOptions(nil, reporter, false, Options{Parallelism: 1})
 
-	// Create a plan with multiple changes (all will fail due to not implemented)
+	// Create a plan with multiple changes (all would fail due to not implemented)
 	plan := planner.NewPlan("1.0", "test", planner.PlanModeSync)
 
 	for i := 1; i <= 3; i++ {
@@ -382,10 +403,72 @@ func TestExecutor_ContinuesOnError(t *testing.T) {
 	result := exec.Execute(context.Background(), plan)
 
 	assert.Equal(t, 0, result.SuccessCount)
-	assert.Equal(t, 3, result.FailureCount) // All failed
-	assert.Equal(t, 0, result.SkippedCount)
-	assert.Len(t, result.Errors, 3) // All errors collected
+	assert.Equal(t, 1, result.FailureCount)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "1-c-route", result.Errors[0].ChangeID)
+
+	// Verify no change started after the failure
+	assert.Len(t, reporter.CompleteChangeCalls, 1)
+	require.Len(t, result.ChangesNotStarted, 2)
+	assert.Equal(t, "2-c-route", result.ChangesNotStarted[0].ChangeID)
+	assert.Equal(t, "route", result.ChangesNotStarted[0].ResourceType)
+	assert.Equal(t, "Route 2", result.ChangesNotStarted[0].ResourceName)
+	assert.Equal(t, "3-c-route", result.ChangesNotStarted[1].ChangeID)
+}
+
+func TestExecutor_DryRunValidatesAllChangesAfterError(t *testing.T) {
+	exec := NewWithOptions(nil, nil, true, Options{Parallelism: 1})
+
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeSync)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1-u-portal",
+		ResourceType: "portal",
+		Action:       planner.ActionUpdate, // Fails validation without a resource ID
+		Fields:       map[string]any{"name": "Portal 1"},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID:           "2-c-portal",
+		ResourceType: "portal",
+		Action:       planner.ActionCreate,
+		Fields:       map[string]any{"name": "Portal 2"},
+	})
+	plan.SetExecutionOrder([]string{"1-u-portal", "2-c-portal"})
+
+	result := exec.Execute(context.Background(), plan)
+
+	assert.Equal(t, 1, result.FailureCount)
+	assert.Equal(t, 1, result.SkippedCount)
+	assert.Empty(t, result.ChangesNotStarted)
+}
+
+func TestExecutor_ExecuteDryRun(t *testing.T) {
+	apis := &concurrentAPI{}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+
+	// A plain dry run only validates
+	result := New(client, nil, true).Execute(timeoutTestContext(), apiCreatePlan("orders"))
+	assert.Equal(t, 1, result.SkippedCount)
+	assert.Empty(t, apis.events)
+
+	// An executed dry run goes through the client but applies nothing
+	result = NewWithOptions(client, nil, true, Options{ExecuteDryRun: true}).
+		Execute(timeoutTestContext(), apiCreatePlan("orders"))
+	require.Empty(t, result.Errors)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.SkippedCount)
+	assert.Empty(t, result.ChangesApplied)
+	require.Len(t, result.ValidationResults, 1)
+	assert.Equal(t, "would_succeed", result.ValidationResults[0].Status)
+	assert.Equal(t, []string{"start orders", "done orders"}, apis.events)
+}
+
+func TestRequestIDOf(t *testing.T) {
+	raw := &http.Response{Header: http.Header{"X-Kong-Request-Id": []string{"req-123"}}}
+	sdkErr := sdkerrors.NewSDKError("API error occurred", http.StatusBadGateway, "<html>bad gateway</html>", raw)
+	require.Equal(t, "req-123", requestIDOf(fmt.Errorf("API error during create of portal 'dev': %w", sdkErr)))
+
+	problem := &sdkerrors.BadRequestError{Status: 400, Title: "Bad Request", Instance: "kong:trace:42"}
+	require.Equal(t, "kong:trace:42", requestIDOf(fmt.Errorf("failed to create api \"orders\": %w. Check it", problem)))
 
-	// Verify all changes were attempted
-	assert.Len(t, reporter.CompleteChangeCalls, 3)
+	require.Empty(t, requestIDOf(fmt.Errorf("connection refused")))
 }
@@ -0,0 +1,185 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// GatewayServiceAdapter implements ResourceOperations for gateway services
+// Updates replace the whole service, so create and update share the SDK Service type
+type GatewayServiceAdapter struct {
+	client *state.Client
+}
+
+// NewGatewayServiceAdapter creates a new gateway service adapter
+func NewGatewayServiceAdapter(client *state.Client) *GatewayServiceAdapter {
+	return &GatewayServiceAdapter{client: client}
+}
+
+// MapCreateFields maps fields to a Service
+func (a *GatewayServiceAdapter) MapCreateFields(_ context.Context, _ *ExecutionContext, fields map[string]any,
+	create *kkComps.Service,
+) error {
+	return mapGatewayServiceFields(fields, create)
+}
+
+// MapUpdateFields maps fields to a Service replacing the current one
+func (a *GatewayServiceAdapter) MapUpdateFields(_ context.Context, _ *ExecutionContext, fields map[string]any,
+	update *kkComps.Service, _ map[string]string,
+) error {
+	return mapGatewayServiceFields(fields, update)
+}
+
+// Create creates a new gateway service tagged with the namespace
+func (a *GatewayServiceAdapter) Create(ctx context.Context, req kkComps.Service,
+	namespace string, execCtx *ExecutionContext,
+) (string, error) {
+	cpID, err := a.getControlPlaneIDFromExecutionContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+
+	req.Tags = gatewayServiceTags(req.Tags, namespace)
+	service, err := a.client.CreateGatewayService(ctx, cpID, req, namespace)
+	if err != nil {
+		return "", err
+	}
+	return service.ID, nil
+}
+
+// Update replaces an existing gateway service
+func (a *GatewayServiceAdapter) Update(ctx context.Context, id string, req kkComps.Service,
+	namespace string, execCtx *ExecutionContext,
+) (string, error) {
+	cpID, err := a.getControlPlaneIDFromExecutionContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+
+	req.Tags = gatewayServiceTags(req.Tags, namespace)
+	service, err := a.client.UpdateGatewayService(ctx, cpID, id, req, namespace)
+	if err != nil {
+		return "", err
+	}
+	return service.ID, nil
+}
+
+// Delete deletes a gateway service
+func (a *GatewayServiceAdapter) Delete(ctx context.Context, id string, execCtx *ExecutionContext) error {
+	cpID, err := a.getControlPlaneIDFromExecutionContext(execCtx)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteGatewayService(ctx, cpID, id)
+}
+
+// GetByName gets a gateway service by name
+func (a *GatewayServiceAdapter) GetByName(_ context.Context, _ string) (ResourceInfo, error) {
+	// Gateway services are scoped to a control plane, so lookups go through GetByID
+	return nil, nil
+}
+
+// GetByID gets a gateway service by ID
+func (a *GatewayServiceAdapter) GetByID(
+	ctx context.Context, id string, execCtx *ExecutionContext,
+) (ResourceInfo, error) {
+	cpID, err := a.getControlPlaneIDFromExecutionContext(execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := a.client.GetGatewayService(ctx, cpID, id)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, nil
+	}
+	return &GatewayServiceResourceInfo{service: service}, nil
+}
+
+// ResourceType returns the resource type name
+func (a *GatewayServiceAdapter) ResourceType() string {
+	return planner.ResourceTypeGatewayService
+}
+
+// RequiredFields returns the required fields for creation
+func (a *GatewayServiceAdapter) RequiredFields() []string {
+	return []string{"host"}
+}
+
+// SupportsUpdate returns true as gateway services can be updated
+func (a *GatewayServiceAdapter) SupportsUpdate() bool {
+	return true
+}
+
+// getControlPlaneIDFromExecutionContext extracts the control plane ID from the planned change
+func (a *GatewayServiceAdapter) getControlPlaneIDFromExecutionContext(execCtx *ExecutionContext) (string, error) {
+	if execCtx == nil || execCtx.PlannedChange == nil {
+		return "", fmt.Errorf("execution context is required for gateway service operations")
+	}
+
+	change := *execCtx.PlannedChange
+
+	// Priority 1: Check References (for Create operations)
+	if cpRef, ok := change.References["control_plane_id"]; ok && cpRef.ID != "" && cpRef.ID != "[unknown]" {
+		return cpRef.ID, nil
+	}
+
+	// Priority 2: Check Parent field (for Update and Delete operations)
+	if change.Parent != nil && change.Parent.ID != "" && change.Parent.ID != "[unknown]" {
+		return change.Parent.ID, nil
+	}
+
+	return "", fmt.Errorf("control plane ID is required for gateway service operations")
+}
+
+// mapGatewayServiceFields decodes plan fields, which are keyed by the JSON names of
+// the service, into the SDK service
+func mapGatewayServiceFields(fields map[string]any, service *kkComps.Service) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode gateway service fields: %w", err)
+	}
+	if err := json.Unmarshal(data, service); err != nil {
+		return fmt.Errorf("failed to decode gateway service fields: %w", err)
+	}
+	return nil
+}
+
+// gatewayServiceTags replaces the kongctl tags with the namespace tag, which marks
+// the service as managed since services have no labels
+func gatewayServiceTags(tags []string, namespace string) []string {
+	if namespace == "" {
+		namespace = planner.DefaultNamespace
+	}
+	return append(labels.GetUserTags(tags), labels.NamespaceTag(namespace))
+}
+
+// GatewayServiceResourceInfo implements ResourceInfo for gateway services
+type GatewayServiceResourceInfo struct {
+	service *state.GatewayService
+}
+
+func (g *GatewayServiceResourceInfo) GetID() string {
+	return g.service.ID
+}
+
+func (g *GatewayServiceResourceInfo) GetName() string {
+	return g.service.Name
+}
+
+func (g *GatewayServiceResourceInfo) GetLabels() map[string]string {
+	// Gateway services have tags instead of labels
+	return make(map[string]string)
+}
+
+func (g *GatewayServiceResourceInfo) GetNormalizedLabels() map[string]string {
+	return make(map[string]string)
+}
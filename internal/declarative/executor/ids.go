@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// IDMappingVersion is the format version written to ID mapping files
+const IDMappingVersion = 1
+
+// IDMapping maps declarative refs to the Konnect IDs of the resources they manage
+type IDMapping struct {
+	Version int `json:"version"`
+	// Resources maps resource type to ref to Konnect ID
+	Resources map[string]map[string]string `json:"resources"`
+}
+
+// LoadIDMapping reads an ID mapping file. A missing file yields an empty mapping.
+func LoadIDMapping(path string) (*IDMapping, error) {
+	mapping := &IDMapping{Version: IDMappingVersion, Resources: map[string]map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return mapping, nil
+		}
+		return nil, fmt.Errorf("failed to read ID mapping file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse ID mapping file %s: %w", path, err)
+	}
+	if mapping.Version != IDMappingVersion {
+		return nil, fmt.Errorf("unsupported ID mapping file version %d in %s", mapping.Version, path)
+	}
+	if mapping.Resources == nil {
+		mapping.Resources = map[string]map[string]string{}
+	}
+	return mapping, nil
+}
+
+// Merge records the IDs of applied changes. Created and updated resources are added
+// or refreshed; deleted resources are removed.
+func (m *IDMapping) Merge(changes []AppliedChange) {
+	for _, change := range changes {
+		if change.ResourceRef == "" {
+			continue
+		}
+
+		switch planner.ActionType(change.Action) {
+		case planner.ActionCreate, planner.ActionUpdate, planner.ActionSwitch:
+			if change.ResourceID == "" {
+				continue
+			}
+			refs := m.Resources[change.ResourceType]
+			if refs == nil {
+				refs = make(map[string]string)
+				m.Resources[change.ResourceType] = refs
+			}
+			refs[change.ResourceRef] = change.ResourceID
+		case planner.ActionDelete:
+			refs := m.Resources[change.ResourceType]
+			delete(refs, change.ResourceRef)
+			if len(refs) == 0 {
+				delete(m.Resources, change.ResourceType)
+			}
+		case planner.ActionExternalTool:
+			// External tool steps do not map to a single Konnect resource
+		}
+	}
+}
+
+// WriteIDMapping merges the applied changes into the mapping file at path.
+// Keys are written in sorted order so the file diffs cleanly between runs.
+func WriteIDMapping(path string, result *ExecutionResult) error {
+	if result == nil || result.DryRun {
+		return nil
+	}
+
+	mapping, err := LoadIDMapping(path)
+	if err != nil {
+		return err
+	}
+	mapping.Merge(result.ChangesInEffect())
+
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ID mapping: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write ID mapping file: %w", err)
+	}
+	return nil
+}
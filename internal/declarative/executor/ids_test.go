@@ -0,0 +1,79 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteIDMapping_Creates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.json")
+
+	result := &ExecutionResult{
+		SuccessCount: 3,
+		ChangesApplied: []AppliedChange{
+			{ResourceType: "portal", ResourceRef: "dev-portal", Action: "CREATE", ResourceID: "portal-1"},
+			{ResourceType: "api", ResourceRef: "orders", Action: "CREATE", ResourceID: "api-1"},
+			{ResourceType: "api_version", ResourceRef: "orders-v1", Action: "CREATE", ResourceID: "version-1"},
+			{ResourceType: "api", ResourceRef: "", Action: "CREATE", ResourceID: "ignored"},
+		},
+	}
+	require.NoError(t, WriteIDMapping(path, result))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"resources": {
+			"api": {"orders": "api-1"},
+			"api_version": {"orders-v1": "version-1"},
+			"portal": {"dev-portal": "portal-1"}
+		}
+	}`, string(data))
+}
+
+func TestWriteIDMapping_MergesLaterRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.json")
+
+	require.NoError(t, WriteIDMapping(path, &ExecutionResult{
+		ChangesApplied: []AppliedChange{
+			{ResourceType: "api", ResourceRef: "orders", Action: "CREATE", ResourceID: "api-1"},
+			{ResourceType: "api", ResourceRef: "legacy", Action: "CREATE", ResourceID: "api-2"},
+			{ResourceType: "portal", ResourceRef: "dev-portal", Action: "CREATE", ResourceID: "portal-1"},
+		},
+	}))
+
+	require.NoError(t, WriteIDMapping(path, &ExecutionResult{
+		ChangesApplied: []AppliedChange{
+			{ResourceType: "api", ResourceRef: "legacy", Action: "DELETE", ResourceID: "api-2"},
+			{ResourceType: "portal", ResourceRef: "dev-portal", Action: "DELETE", ResourceID: "portal-1"},
+			{ResourceType: "api", ResourceRef: "payments", Action: "CREATE", ResourceID: "api-3"},
+		},
+	}))
+
+	// Dry runs never touch the file
+	require.NoError(t, WriteIDMapping(path, &ExecutionResult{
+		DryRun: true,
+		ChangesApplied: []AppliedChange{
+			{ResourceType: "api", ResourceRef: "orders", Action: "DELETE", ResourceID: "api-1"},
+		},
+	}))
+
+	mapping, err := LoadIDMapping(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"api": {"orders": "api-1", "payments": "api-3"},
+	}, mapping.Resources)
+}
+
+func TestLoadIDMapping_RejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 2, "resources": {}}`), 0o600))
+
+	_, err := LoadIDMapping(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported ID mapping file version 2")
+}
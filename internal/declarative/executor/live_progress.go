@@ -0,0 +1,335 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+const (
+	liveRefreshInterval = 100 * time.Millisecond
+	liveBarWidth        = 24
+	liveMaxCurrentWidth = 60
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// LiveReporter reports the progress of long executions: completed and total
+// changes, the change running, retried requests, the elapsed time and an estimate
+// of the remaining time. On a terminal it redraws a single progress bar line; in CI
+// it writes one line per change. It ends with a table of the succeeded, failed and
+// skipped changes per resource type.
+//
+// A LiveReporter is safe for changes running concurrently.
+type LiveReporter struct {
+	writer      io.Writer
+	interactive bool
+	dryRun      bool
+	now         func() time.Time
+
+	mu        sync.Mutex
+	total     int
+	completed int
+	retries   int
+	startedAt time.Time
+	running   []runningChange
+	stats     map[string]*typeStats
+	frame     int
+	drawn     bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// runningChange is a change that started and has not finished
+type runningChange struct {
+	change    planner.PlannedChange
+	startedAt time.Time
+	retries   int
+}
+
+// typeStats counts the outcomes of the changes of a resource type
+type typeStats struct {
+	succeeded int
+	failed    int
+	skipped   int
+}
+
+// NewLiveReporter creates a reporter writing to w. Interactive draws a progress bar
+// for terminals, otherwise each change gets a line.
+func NewLiveReporter(w io.Writer, interactive, dryRun bool) *LiveReporter {
+	return &LiveReporter{
+		writer:      w,
+		interactive: interactive,
+		dryRun:      dryRun,
+		now:         time.Now,
+		stats:       make(map[string]*typeStats),
+	}
+}
+
+// StartExecution is called at the beginning of plan execution
+func (r *LiveReporter) StartExecution(plan *planner.Plan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.total = plan.Summary.TotalChanges
+	r.startedAt = r.now()
+	if r.total == 0 {
+		fmt.Fprintln(r.writer, "No changes to execute.")
+		return
+	}
+	if r.dryRun {
+		fmt.Fprintf(r.writer, "Validating %d changes:\n", r.total)
+	} else {
+		fmt.Fprintf(r.writer, "Executing %d changes:\n", r.total)
+	}
+
+	if r.interactive {
+		r.stop = make(chan struct{})
+		r.done = make(chan struct{})
+		go r.refresh()
+		r.draw()
+	}
+}
+
+// refresh redraws the progress bar so the spinner and elapsed time move while a
+// change runs
+func (r *LiveReporter) refresh() {
+	defer close(r.done)
+	ticker := time.NewTicker(liveRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			r.frame++
+			r.draw()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// StartChange is called before executing a change
+func (r *LiveReporter) StartChange(change planner.PlannedChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.running = append(r.running, runningChange{change: change, startedAt: r.now()})
+	if r.interactive {
+		r.draw()
+	}
+}
+
+// RetryChange is called before a request of a running change is retried
+func (r *LiveReporter) RetryChange(change planner.PlannedChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.retries++
+	if i := r.runningIndex(change); i >= 0 {
+		r.running[i].retries++
+	}
+	if r.interactive {
+		r.draw()
+	}
+}
+
+// CompleteChange is called after a change is executed (success or failure)
+func (r *LiveReporter) CompleteChange(change planner.PlannedChange, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.typeStats(change.ResourceType)
+	if err != nil {
+		stats.failed++
+		r.finish(change, "✗", "Error: "+err.Error())
+		return
+	}
+	stats.succeeded++
+	r.finish(change, "✓", "")
+}
+
+// SkipChange is called when a change is skipped
+func (r *LiveReporter) SkipChange(change planner.PlannedChange, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.typeStats(change.ResourceType).skipped++
+	r.finish(change, "⚠", "Skipped: "+reason)
+}
+
+// FinishExecution is called at the end of plan execution
+func (r *LiveReporter) FinishExecution(result *ExecutionResult) {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+		r.stop = nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clear()
+	if r.total == 0 {
+		return
+	}
+
+	fmt.Fprintf(r.writer, "\nSummary (elapsed %s", formatProgressDuration(r.now().Sub(r.startedAt)))
+	if r.retries > 0 {
+		fmt.Fprintf(r.writer, ", %d retried requests", r.retries)
+	}
+	fmt.Fprintln(r.writer, "):")
+	r.writeSummaryTable()
+	fmt.Fprintln(r.writer)
+	writeExecutionResult(r.writer, result)
+}
+
+func (r *LiveReporter) reportsConcurrently() {}
+
+// finish records the end of a running change. Terminals only get a line for the
+// changes that did not succeed, the progress bar shows the others.
+func (r *LiveReporter) finish(change planner.PlannedChange, symbol, detail string) {
+	r.completed++
+	running := runningChange{change: change, startedAt: r.now()}
+	if i := r.runningIndex(change); i >= 0 {
+		running = r.running[i]
+		r.running = append(r.running[:i], r.running[i+1:]...)
+	}
+
+	if r.interactive && detail == "" {
+		r.draw()
+		return
+	}
+
+	r.clear()
+	timing := formatProgressDuration(r.now().Sub(running.startedAt))
+	if running.retries > 0 {
+		timing += fmt.Sprintf(", %d retries", running.retries)
+	}
+	line := fmt.Sprintf("[%d/%d] %s %s %s: %s (%s)", r.completed, r.total, symbol,
+		getActionVerb(change.Action), change.ResourceType, formatResourceNameForProgress(change), timing)
+	if detail != "" {
+		line += " " + detail
+	}
+	if !r.interactive {
+		line += fmt.Sprintf(" | elapsed %s, ETA %s", formatProgressDuration(r.elapsed()), r.eta())
+	}
+	fmt.Fprintln(r.writer, line)
+	if r.interactive {
+		r.draw()
+	}
+}
+
+// draw redraws the progress bar line
+func (r *LiveReporter) draw() {
+	if r.total == 0 || r.completed >= r.total {
+		r.clear()
+		return
+	}
+
+	filled := r.completed * liveBarWidth / r.total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", liveBarWidth-filled)
+	line := fmt.Sprintf("%s [%s] %d/%d %3d%% | elapsed %s | ETA %s",
+		spinnerFrames[r.frame%len(spinnerFrames)], bar, r.completed, r.total, r.completed*100/r.total,
+		formatProgressDuration(r.elapsed()), r.eta())
+	if r.retries > 0 {
+		line += fmt.Sprintf(" | %d retries", r.retries)
+	}
+	if len(r.running) > 0 {
+		current := r.running[len(r.running)-1].change
+		label := fmt.Sprintf("%s %s: %s", getActionVerb(current.Action), current.ResourceType,
+			formatResourceNameForProgress(current))
+		if runes := []rune(label); len(runes) > liveMaxCurrentWidth {
+			label = string(runes[:liveMaxCurrentWidth-1]) + "…"
+		}
+		line += " | " + label
+	}
+	fmt.Fprintf(r.writer, "\r\033[K%s", line)
+	r.drawn = true
+}
+
+// clear erases the progress bar line so other output starts on an empty line
+func (r *LiveReporter) clear() {
+	if r.drawn {
+		fmt.Fprint(r.writer, "\r\033[K")
+		r.drawn = false
+	}
+}
+
+func (r *LiveReporter) elapsed() time.Duration {
+	return r.now().Sub(r.startedAt)
+}
+
+// eta estimates the remaining time from the average time per completed change
+func (r *LiveReporter) eta() string {
+	if r.completed == 0 {
+		return "--"
+	}
+	remaining := r.total - r.completed
+	return formatProgressDuration(r.elapsed() / time.Duration(r.completed) * time.Duration(remaining))
+}
+
+func (r *LiveReporter) runningIndex(change planner.PlannedChange) int {
+	for i := range r.running {
+		if r.running[i].change.ID == change.ID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *LiveReporter) typeStats(resourceType string) *typeStats {
+	stats := r.stats[resourceType]
+	if stats == nil {
+		stats = &typeStats{}
+		r.stats[resourceType] = stats
+	}
+	return stats
+}
+
+// writeSummaryTable writes the outcomes of the changes per resource type
+func (r *LiveReporter) writeSummaryTable() {
+	skipped := "SKIPPED"
+	if r.dryRun {
+		skipped = "VALIDATED"
+	}
+	resourceTypes := make([]string, 0, len(r.stats))
+	for resourceType := range r.stats {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	tw := tabwriter.NewWriter(r.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  RESOURCE TYPE\tSUCCEEDED\tFAILED\t%s\n", skipped)
+	var total typeStats
+	for _, resourceType := range resourceTypes {
+		stats := r.stats[resourceType]
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\n", resourceType, stats.succeeded, stats.failed, stats.skipped)
+		total.succeeded += stats.succeeded
+		total.failed += stats.failed
+		total.skipped += stats.skipped
+	}
+	fmt.Fprintf(tw, "  TOTAL\t%d\t%d\t%d\n", total.succeeded, total.failed, total.skipped)
+	_ = tw.Flush()
+}
+
+// formatProgressDuration formats a duration to the tenth of a second below a
+// minute, and to the second above
+func formatProgressDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+var (
+	_ ProgressReporter   = (*LiveReporter)(nil)
+	_ RetryReporter      = (*LiveReporter)(nil)
+	_ concurrentReporter = (*LiveReporter)(nil)
+)
@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLiveReporter returns a reporter whose clock only moves when advance is called
+func newTestLiveReporter(buf *bytes.Buffer, interactive bool) (*LiveReporter, func(time.Duration)) {
+	reporter := NewLiveReporter(buf, interactive, false)
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter.now = func() time.Time { return clock }
+	return reporter, func(d time.Duration) {
+		reporter.mu.Lock()
+		defer reporter.mu.Unlock()
+		clock = clock.Add(d)
+	}
+}
+
+func liveTestPlan() (*planner.Plan, []planner.PlannedChange) {
+	changes := []planner.PlannedChange{
+		{ID: "1", ResourceType: "portal", ResourceRef: "dev-portal", Action: planner.ActionCreate},
+		{ID: "2", ResourceType: "api", ResourceRef: "orders", Action: planner.ActionUpdate},
+		{ID: "3", ResourceType: "api", ResourceRef: "payments", Action: planner.ActionDelete},
+	}
+	return &planner.Plan{Changes: changes, Summary: planner.PlanSummary{TotalChanges: len(changes)}}, changes
+}
+
+func TestLiveReporter_Lines(t *testing.T) {
+	var buf bytes.Buffer
+	reporter, advance := newTestLiveReporter(&buf, false)
+	plan, changes := liveTestPlan()
+
+	reporter.StartExecution(plan)
+	reporter.StartChange(changes[0])
+	advance(2 * time.Second)
+	reporter.CompleteChange(changes[0], nil)
+	reporter.StartChange(changes[1])
+	reporter.RetryChange(changes[1])
+	reporter.RetryChange(changes[1])
+	advance(time.Second)
+	reporter.CompleteChange(changes[1], nil)
+	reporter.StartChange(changes[2])
+	advance(time.Second)
+	reporter.CompleteChange(changes[2], errors.New("not found"))
+	reporter.FinishExecution(&ExecutionResult{
+		SuccessCount: 2,
+		FailureCount: 1,
+		Errors:       []ExecutionError{{ResourceType: "api", ResourceName: "payments", Error: "not found"}},
+	})
+
+	output := buf.String()
+	assert.NotContains(t, output, "\r", "lines mode does not redraw")
+	assert.Contains(t, output, "Executing 3 changes:")
+	assert.Contains(t, output, "[1/3] ✓ Creating portal: dev-portal (2.0s) | elapsed 2.0s, ETA 4.0s")
+	assert.Contains(t, output, "[2/3] ✓ Updating api: orders (1.0s, 2 retries) | elapsed 3.0s, ETA 1.5s")
+	assert.Contains(t, output, "[3/3] ✗ Deleting api: payments (1.0s) Error: not found")
+	assert.Contains(t, output, "Summary (elapsed 4.0s, 2 retried requests):")
+
+	var table []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "  •") {
+			table = append(table, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	assert.Equal(t, []string{
+		"RESOURCE TYPE SUCCEEDED FAILED SKIPPED",
+		"api 1 1 0",
+		"portal 1 0 0",
+		"TOTAL 2 1 0",
+	}, table)
+	assert.Contains(t, output, "Executed 2 changes.")
+	assert.Contains(t, output, "  • api payments: not found")
+}
+
+func TestLiveReporter_ProgressBar(t *testing.T) {
+	var buf bytes.Buffer
+	reporter, advance := newTestLiveReporter(&buf, true)
+	plan, changes := liveTestPlan()
+
+	reporter.StartExecution(plan)
+	reporter.StartChange(changes[0])
+	advance(time.Second)
+	reporter.CompleteChange(changes[0], nil)
+	reporter.StartChange(changes[1])
+	advance(time.Second)
+	reporter.RetryChange(changes[1])
+	reporter.SkipChange(changes[1], "dry-run mode")
+	reporter.StartChange(changes[2])
+	reporter.CompleteChange(changes[2], nil)
+	reporter.FinishExecution(&ExecutionResult{SuccessCount: 2, SkippedCount: 1})
+
+	output := buf.String()
+	assert.Contains(t, output, "\r\033[K")
+	assert.Contains(t, output, "1/3  33% | elapsed 2.0s | ETA 4.0s | 1 retries | Updating api: orders")
+	assert.Contains(t, output, "[2/3] ⚠ Updating api: orders (1.0s, 1 retries) Skipped: dry-run mode")
+	assert.NotContains(t, output, "[1/3] ✓", "succeeded changes only move the bar")
+	assert.Contains(t, output, "Complete.")
+}
+
+func TestLiveReporter_NoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	reporter, _ := newTestLiveReporter(&buf, true)
+
+	reporter.StartExecution(&planner.Plan{})
+	reporter.FinishExecution(&ExecutionResult{})
+	require.Equal(t, "No changes to execute.\n", buf.String())
+}
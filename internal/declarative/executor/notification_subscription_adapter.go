@@ -0,0 +1,226 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// NotificationSubscriptionAdapter implements ResourceOperations for notification subscriptions.
+// Subscriptions are addressed through their event, which the planner records as the parent.
+// Konnect replaces the whole subscription on update, so create and update send all fields.
+type NotificationSubscriptionAdapter struct {
+	client *state.Client
+}
+
+// NewNotificationSubscriptionAdapter creates a new notification subscription adapter
+func NewNotificationSubscriptionAdapter(client *state.Client) *NotificationSubscriptionAdapter {
+	return &NotificationSubscriptionAdapter{client: client}
+}
+
+// MapCreateFields maps fields to EventSubscription
+func (a *NotificationSubscriptionAdapter) MapCreateFields(
+	_ context.Context, _ *ExecutionContext, fields map[string]any, create *kkComps.EventSubscription,
+) error {
+	return mapNotificationSubscriptionFields(fields, create)
+}
+
+// MapUpdateFields maps fields to EventSubscription
+func (a *NotificationSubscriptionAdapter) MapUpdateFields(
+	_ context.Context, _ *ExecutionContext, fields map[string]any, update *kkComps.EventSubscription,
+	_ map[string]string,
+) error {
+	return mapNotificationSubscriptionFields(fields, update)
+}
+
+func mapNotificationSubscriptionFields(fields map[string]any, body *kkComps.EventSubscription) error {
+	name, ok := fields["name"].(string)
+	if !ok || name == "" {
+		return fmt.Errorf("name is required")
+	}
+	body.Name = name
+	body.Enabled, _ = fields["enabled"].(bool)
+
+	channels, err := notificationChannelsFromField(fields["channels"])
+	if err != nil {
+		return err
+	}
+	body.Channels = channels
+
+	regions := stringsFromField(fields["regions"])
+	body.Regions = make([]kkComps.NotificationRegion, 0, len(regions))
+	for _, region := range regions {
+		body.Regions = append(body.Regions, kkComps.NotificationRegion(region))
+	}
+
+	body.Entities = stringsFromField(fields["entities"])
+	for _, entity := range body.Entities {
+		if tags.IsRefPlaceholder(entity) {
+			return fmt.Errorf("entity reference %s could not be resolved", entity)
+		}
+	}
+
+	return nil
+}
+
+// notificationChannelsFromField reads the channels of a planned change, which are maps
+// when planned and decoded JSON when read from a plan file
+func notificationChannelsFromField(field any) ([]kkComps.NotificationChannel, error) {
+	var items []map[string]any
+	switch v := field.(type) {
+	case []map[string]any:
+		items = v
+	case []any:
+		for _, item := range v {
+			channel, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("invalid notification channel %v", item)
+			}
+			items = append(items, channel)
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("at least one channel is required")
+	}
+
+	channels := make([]kkComps.NotificationChannel, 0, len(items))
+	for _, item := range items {
+		channelType, _ := item["type"].(string)
+		enabled, _ := item["enabled"].(bool)
+		channels = append(channels, kkComps.NotificationChannel{
+			Type:    kkComps.NotificationChannelType(channelType),
+			Enabled: enabled,
+		})
+	}
+	return channels, nil
+}
+
+// stringsFromField reads a list of strings of a planned change
+func stringsFromField(field any) []string {
+	switch v := field.(type) {
+	case []string:
+		return append([]string{}, v...)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if value, ok := item.(string); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	default:
+		return []string{}
+	}
+}
+
+// Create subscribes the current user to the event of the change
+func (a *NotificationSubscriptionAdapter) Create(
+	ctx context.Context, req kkComps.EventSubscription, _ string, execCtx *ExecutionContext,
+) (string, error) {
+	eventID, err := notificationEventIDFromContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+	return a.client.CreateNotificationSubscription(ctx, eventID, req)
+}
+
+// Update replaces the settings of a subscription
+func (a *NotificationSubscriptionAdapter) Update(
+	ctx context.Context, id string, req kkComps.EventSubscription, _ string, execCtx *ExecutionContext,
+) (string, error) {
+	eventID, err := notificationEventIDFromContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+	return a.client.UpdateNotificationSubscription(ctx, eventID, id, req)
+}
+
+// Delete removes a subscription
+func (a *NotificationSubscriptionAdapter) Delete(ctx context.Context, id string, execCtx *ExecutionContext) error {
+	eventID, err := notificationEventIDFromContext(execCtx)
+	if err != nil {
+		return err
+	}
+	return a.client.DeleteNotificationSubscription(ctx, eventID, id)
+}
+
+// GetByName is not supported, as subscription names are only unique per event
+func (a *NotificationSubscriptionAdapter) GetByName(_ context.Context, _ string) (ResourceInfo, error) {
+	return nil, nil
+}
+
+// GetByID fetches a subscription of the event of the change
+func (a *NotificationSubscriptionAdapter) GetByID(
+	ctx context.Context, id string, execCtx *ExecutionContext,
+) (ResourceInfo, error) {
+	eventID, err := notificationEventIDFromContext(execCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := a.client.GetNotificationSubscription(ctx, eventID, id)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+	return &notificationSubscriptionInfo{subscription: subscription}, nil
+}
+
+// ResourceType returns the resource type name
+func (a *NotificationSubscriptionAdapter) ResourceType() string {
+	return "notification_subscription"
+}
+
+// RequiredFields returns the required fields for creation
+func (a *NotificationSubscriptionAdapter) RequiredFields() []string {
+	return []string{"name", "channels"}
+}
+
+// SupportsUpdate returns true as subscriptions can be updated
+func (a *NotificationSubscriptionAdapter) SupportsUpdate() bool {
+	return true
+}
+
+// notificationEventIDFromContext extracts the event of a notification subscription change
+func notificationEventIDFromContext(execCtx *ExecutionContext) (string, error) {
+	if execCtx == nil || execCtx.PlannedChange == nil {
+		return "", fmt.Errorf("execution context is required for notification subscription operations")
+	}
+
+	change := *execCtx.PlannedChange
+	if change.Parent != nil && change.Parent.ID != "" {
+		return change.Parent.ID, nil
+	}
+	if eventID, ok := change.Fields["event_id"].(string); ok && eventID != "" {
+		return eventID, nil
+	}
+
+	return "", fmt.Errorf("event ID is required for notification subscription operations")
+}
+
+type notificationSubscriptionInfo struct {
+	subscription *state.NotificationSubscription
+}
+
+func (i *notificationSubscriptionInfo) GetID() string {
+	return i.subscription.ID
+}
+
+func (i *notificationSubscriptionInfo) GetName() string {
+	return i.subscription.Name
+}
+
+func (i *notificationSubscriptionInfo) GetLabels() map[string]string {
+	return nil
+}
+
+func (i *notificationSubscriptionInfo) GetNormalizedLabels() map[string]string {
+	return nil
+}
+
+// Snapshot returns the subscription as read from Konnect, so a rollback restores it
+func (i *notificationSubscriptionInfo) Snapshot() any {
+	return i.subscription.EventSubscriptionResponse
+}
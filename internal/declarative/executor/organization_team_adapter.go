@@ -160,3 +160,8 @@ func (c *OrganizationTeamResourceInfo) GetLabels() map[string]string {
 func (c *OrganizationTeamResourceInfo) GetNormalizedLabels() map[string]string {
 	return c.team.NormalizedLabels
 }
+
+// Snapshot returns the team as read from Konnect
+func (c *OrganizationTeamResourceInfo) Snapshot() any {
+	return c.team
+}
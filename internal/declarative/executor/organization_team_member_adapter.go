@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// OrganizationTeamMemberAdapter implements CreateDeleteOperations for organization team
+// members. The ID of a membership is the ID of the user.
+type OrganizationTeamMemberAdapter struct {
+	client *state.Client
+}
+
+// NewOrganizationTeamMemberAdapter creates a new organization team member adapter
+func NewOrganizationTeamMemberAdapter(client *state.Client) *OrganizationTeamMemberAdapter {
+	return &OrganizationTeamMemberAdapter{client: client}
+}
+
+// MapCreateFields maps fields to AddUserToTeam
+func (a *OrganizationTeamMemberAdapter) MapCreateFields(
+	_ context.Context, _ *ExecutionContext, fields map[string]any, create *kkComps.AddUserToTeam,
+) error {
+	userID, ok := fields["user_id"].(string)
+	if !ok || userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	create.UserID = userID
+	return nil
+}
+
+// Create adds a user to an organization team
+func (a *OrganizationTeamMemberAdapter) Create(
+	ctx context.Context, req kkComps.AddUserToTeam, _ string, execCtx *ExecutionContext,
+) (string, error) {
+	teamID, err := organizationTeamIDFromContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+	if err := a.client.AddOrganizationTeamMember(ctx, teamID, req.UserID); err != nil {
+		return "", err
+	}
+	return req.UserID, nil
+}
+
+// Delete removes a user from an organization team
+func (a *OrganizationTeamMemberAdapter) Delete(ctx context.Context, id string, execCtx *ExecutionContext) error {
+	teamID, err := organizationTeamIDFromContext(execCtx)
+	if err != nil {
+		return err
+	}
+	return a.client.RemoveOrganizationTeamMember(ctx, teamID, id)
+}
+
+// GetByName is not supported for organization team members
+func (a *OrganizationTeamMemberAdapter) GetByName(_ context.Context, _ string) (ResourceInfo, error) {
+	return nil, nil
+}
+
+// ResourceType returns the resource type name
+func (a *OrganizationTeamMemberAdapter) ResourceType() string {
+	return "organization_team_member"
+}
+
+// RequiredFields returns the required fields for creation
+func (a *OrganizationTeamMemberAdapter) RequiredFields() []string {
+	return []string{"user_id"}
+}
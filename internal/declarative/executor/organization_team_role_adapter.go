@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// OrganizationTeamRoleAdapter implements CreateDeleteOperations for organization team roles.
+// Role assignments are immutable, a changed assignment is a removal and a new assignment.
+type OrganizationTeamRoleAdapter struct {
+	client *state.Client
+}
+
+// NewOrganizationTeamRoleAdapter creates a new organization team role adapter
+func NewOrganizationTeamRoleAdapter(client *state.Client) *OrganizationTeamRoleAdapter {
+	return &OrganizationTeamRoleAdapter{client: client}
+}
+
+// MapCreateFields maps fields to AssignRole
+func (a *OrganizationTeamRoleAdapter) MapCreateFields(
+	_ context.Context, execCtx *ExecutionContext, fields map[string]any, create *kkComps.AssignRole,
+) error {
+	roleName, ok := fields["role_name"].(string)
+	if !ok || roleName == "" {
+		return fmt.Errorf("role_name is required")
+	}
+	create.RoleName = kkComps.RoleName(roleName).ToPointer()
+
+	entityID := ""
+	if execCtx != nil && execCtx.PlannedChange != nil {
+		if refInfo, ok := execCtx.PlannedChange.References["entity_id"]; ok && refInfo.ID != "" &&
+			refInfo.ID != "[unknown]" {
+			entityID = refInfo.ID
+		}
+	}
+	if entityID == "" {
+		if value, ok := fields["entity_id"].(string); ok {
+			entityID = value
+		}
+	}
+	if entityID == "" {
+		return fmt.Errorf("entity_id is required")
+	}
+	if tags.IsRefPlaceholder(entityID) {
+		return fmt.Errorf("entity_id reference %s could not be resolved", entityID)
+	}
+	create.EntityID = &entityID
+
+	entityTypeName, ok := fields["entity_type_name"].(string)
+	if !ok || entityTypeName == "" {
+		return fmt.Errorf("entity_type_name is required")
+	}
+	create.EntityTypeName = kkComps.EntityTypeName(entityTypeName).ToPointer()
+
+	entityRegion, ok := fields["entity_region"].(string)
+	if !ok || entityRegion == "" {
+		return fmt.Errorf("entity_region is required")
+	}
+	create.EntityRegion = kkComps.AssignRoleEntityRegion(entityRegion).ToPointer()
+
+	return nil
+}
+
+// Create assigns a role to an organization team
+func (a *OrganizationTeamRoleAdapter) Create(
+	ctx context.Context, req kkComps.AssignRole, namespace string, execCtx *ExecutionContext,
+) (string, error) {
+	teamID, err := organizationTeamIDFromContext(execCtx)
+	if err != nil {
+		return "", err
+	}
+	return a.client.AssignOrganizationTeamRole(ctx, teamID, req, namespace)
+}
+
+// Delete removes an assigned role from an organization team
+func (a *OrganizationTeamRoleAdapter) Delete(ctx context.Context, id string, execCtx *ExecutionContext) error {
+	teamID, err := organizationTeamIDFromContext(execCtx)
+	if err != nil {
+		return err
+	}
+	return a.client.RemoveOrganizationTeamRole(ctx, teamID, id)
+}
+
+// GetByName is not supported for organization team roles
+func (a *OrganizationTeamRoleAdapter) GetByName(_ context.Context, _ string) (ResourceInfo, error) {
+	return nil, nil
+}
+
+// ResourceType returns the resource type name
+func (a *OrganizationTeamRoleAdapter) ResourceType() string {
+	return "organization_team_role"
+}
+
+// RequiredFields returns the required fields for creation
+func (a *OrganizationTeamRoleAdapter) RequiredFields() []string {
+	return []string{"role_name", "entity_id", "entity_type_name", "entity_region"}
+}
+
+// organizationTeamIDFromContext extracts the parent team ID of a team role or member change
+func organizationTeamIDFromContext(execCtx *ExecutionContext) (string, error) {
+	if execCtx == nil || execCtx.PlannedChange == nil {
+		return "", fmt.Errorf("execution context is required for organization team child operations")
+	}
+
+	change := *execCtx.PlannedChange
+	teamID := ""
+	if teamRef, ok := change.References["organization_team_id"]; ok && teamRef.ID != "[unknown]" {
+		teamID = teamRef.ID
+	}
+	if teamID == "" && change.Parent != nil {
+		teamID = change.Parent.ID
+	}
+	if teamID == "" {
+		return "", fmt.Errorf("organization team ID is required for %s operations", change.ResourceType)
+	}
+
+	return teamID, nil
+}
@@ -0,0 +1,218 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/tracing"
+)
+
+// DefaultParallelism is how many independent changes run at once when no parallelism is set
+const DefaultParallelism = 4
+
+// changeOutcome is the result of a change run by executeChanges
+type changeOutcome struct {
+	index int
+	err   error
+}
+
+// executeChanges runs the changes of the plan with up to e.parallelism of them in
+// flight. A change starts once the changes it depends on have succeeded, and ready
+// changes start in execution order, so a parallelism of 1 runs the plan in order.
+// After a failure the changes in flight finish but no new ones start. A dry run
+// validates every change regardless of failures.
+func (e *Executor) executeChanges(ctx context.Context, result *ExecutionResult, plan *planner.Plan) {
+	order := plan.ExecutionOrder
+	position := make(map[string]int, len(order))
+	for i, changeID := range order {
+		position[changeID] = i
+	}
+
+	// Dependencies on changes outside the execution order are already satisfied
+	waiting := make([]int, len(order))
+	dependents := make([][]int, len(order))
+	dependencies := planner.NewDependencyResolver().Dependencies(plan.Changes)
+	for i, changeID := range order {
+		for _, dep := range dependencies[changeID] {
+			if j, ok := position[dep]; ok && j != i {
+				waiting[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	var ready []int
+	for i := range order {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	e.progress = e.reporter
+	if _, concurrent := e.reporter.(concurrentReporter); !concurrent && e.reporter != nil && e.parallelism > 1 {
+		e.progress = &serialReporter{reporter: e.reporter}
+	}
+	e.started = make(map[string]bool, len(order))
+
+	started := make([]bool, len(order))
+	done := make(chan changeOutcome)
+	running := 0
+	stopped := false
+	for {
+		// A canceled context, e.g. after Ctrl-C, lets running changes finish or fail
+		// but starts no new ones
+		for !stopped && ctx.Err() == nil && running < e.parallelism && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			started[i] = true
+			e.mu.Lock()
+			e.started[order[i]] = true
+			e.mu.Unlock()
+
+			running++
+			go func() {
+				done <- changeOutcome{index: i, err: e.executePlannedChange(ctx, result, plan, i)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+
+		outcome := <-done
+		running--
+		if outcome.err != nil && !e.dryRun {
+			stopped = true
+			continue
+		}
+		for _, dependent := range dependents[outcome.index] {
+			waiting[dependent]--
+			if waiting[dependent] == 0 {
+				at, _ := slices.BinarySearch(ready, dependent)
+				ready = slices.Insert(ready, at, dependent)
+			}
+		}
+	}
+
+	for i, changeID := range order {
+		if started[i] {
+			continue
+		}
+		result.ChangesNotStarted = append(result.ChangesNotStarted, notStartedChange(plan, changeID))
+	}
+	result.Canceled = ctx.Err() != nil && (len(result.ChangesNotStarted) > 0 || result.FailureCount > 0)
+}
+
+// executePlannedChange runs the change at a position of the execution order in its own span
+func (e *Executor) executePlannedChange(ctx context.Context, result *ExecutionResult, plan *planner.Plan,
+	changeIndex int,
+) error {
+	changeID := plan.ExecutionOrder[changeIndex]
+	change := findChange(plan, changeID)
+	if change == nil {
+		// This shouldn't happen, but handle gracefully
+		err := fmt.Errorf("change with ID %s not found in plan", changeID)
+		e.mu.Lock()
+		result.Errors = append(result.Errors, ExecutionError{
+			ChangeID: changeID,
+			Error:    err.Error(),
+		})
+		result.FailureCount++
+		result.Operations = append(result.Operations, OperationRecord{
+			ChangeID: changeID,
+			Status:   OperationFailed,
+			Error:    err.Error(),
+		})
+		e.mu.Unlock()
+		return err
+	}
+
+	// A resumed apply skips the changes the interrupted run completed
+	if e.resumeCompleted(result, plan, change, changeIndex) {
+		return nil
+	}
+
+	// Execute the change, the error will be captured in result
+	changeCtx, changeSpan := tracing.Start(ctx, tracing.SpanExecute, append(
+		tracing.ResourceAttributes(change.ResourceType, change.ResourceRef, string(change.Action)),
+		tracing.AttrChangeID.String(change.ID),
+	)...)
+	err := e.executeChange(changeCtx, result, change, plan, changeIndex)
+	tracing.End(changeSpan, err)
+	return err
+}
+
+// notStartedChange describes the change of the plan with the ID as not started
+func notStartedChange(plan *planner.Plan, changeID string) NotStartedChange {
+	notStarted := NotStartedChange{ChangeID: changeID}
+	if change := findChange(plan, changeID); change != nil {
+		notStarted.ResourceType = change.ResourceType
+		notStarted.ResourceName = getResourceName(change.Fields)
+		notStarted.ResourceRef = change.ResourceRef
+		notStarted.Action = string(change.Action)
+	}
+	return notStarted
+}
+
+// findChange returns the change of the plan with the ID, or nil
+func findChange(plan *planner.Plan, changeID string) *planner.PlannedChange {
+	for i := range plan.Changes {
+		if plan.Changes[i].ID == changeID {
+			return &plan.Changes[i]
+		}
+	}
+	return nil
+}
+
+// serialReporter reports changes that run concurrently one at a time. Each change
+// is reported once it finishes, so its start and outcome are reported together.
+type serialReporter struct {
+	mu       sync.Mutex
+	reporter ProgressReporter
+}
+
+func (r *serialReporter) StartExecution(plan *planner.Plan) {
+	r.reporter.StartExecution(plan)
+}
+
+func (r *serialReporter) StartChange(planner.PlannedChange) {}
+
+func (r *serialReporter) CompleteChange(change planner.PlannedChange, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.StartChange(change)
+	r.reporter.CompleteChange(change, err)
+}
+
+func (r *serialReporter) SkipChange(change planner.PlannedChange, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reporter.StartChange(change)
+	r.reporter.SkipChange(change, reason)
+}
+
+func (r *serialReporter) RetryChange(change planner.PlannedChange) {
+	if retries, ok := r.reporter.(RetryReporter); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		retries.RetryChange(change)
+	}
+}
+
+func (r *serialReporter) FinishExecution(result *ExecutionResult) {
+	r.reporter.FinishExecution(result)
+}
+
+// concurrentReporter is implemented by progress reporters that are safe to call
+// from changes running concurrently, so they see each change as it starts
+type concurrentReporter interface {
+	ProgressReporter
+	reportsConcurrently()
+}
+
+var (
+	_ ProgressReporter = (*serialReporter)(nil)
+	_ RetryReporter    = (*serialReporter)(nil)
+)
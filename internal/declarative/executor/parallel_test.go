@@ -0,0 +1,146 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentAPI creates APIs slowly and records how many creates overlap
+type concurrentAPI struct {
+	labelAPI
+	delay    time.Duration
+	failName string
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	events   []string
+}
+
+func (c *concurrentAPI) event(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, name)
+}
+
+func (c *concurrentAPI) ListApis(
+	context.Context, kkOps.ListApisRequest, ...kkOps.Option,
+) (*kkOps.ListApisResponse, error) {
+	return &kkOps.ListApisResponse{StatusCode: 200, ListAPIResponse: &kkComps.ListAPIResponse{}}, nil
+}
+
+func (c *concurrentAPI) CreateAPI(
+	_ context.Context, request kkComps.CreateAPIRequest, _ ...kkOps.Option,
+) (*kkOps.CreateAPIResponse, error) {
+	if request.Name == c.failName {
+		return nil, errors.New("create failed")
+	}
+
+	c.mu.Lock()
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+	c.event("start " + request.Name)
+
+	time.Sleep(c.delay)
+
+	c.event("done " + request.Name)
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	return &kkOps.CreateAPIResponse{
+		StatusCode:        201,
+		APIResponseSchema: &kkComps.APIResponseSchema{ID: request.Name + "-id", Name: request.Name},
+	}, nil
+}
+
+func apiCreatePlan(names ...string) *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	order := make([]string, 0, len(names))
+	for _, name := range names {
+		id := "c:api:" + name
+		plan.AddChange(planner.PlannedChange{
+			ID:           id,
+			ResourceType: "api",
+			ResourceRef:  name,
+			Action:       planner.ActionCreate,
+			Namespace:    "default",
+			Fields:       map[string]any{"name": name},
+		})
+		order = append(order, id)
+	}
+	plan.SetExecutionOrder(order)
+	return plan
+}
+
+func TestExecutor_RunsIndependentChangesConcurrently(t *testing.T) {
+	apis := &concurrentAPI{delay: 100 * time.Millisecond}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "payments", "billing", "shipping")
+	// shipping waits for orders
+	plan.Changes[3].DependsOn = []string{"c:api:orders"}
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 3}).Execute(timeoutTestContext(), plan)
+
+	require.Empty(t, result.Errors)
+	assert.Equal(t, 4, result.SuccessCount)
+	assert.Equal(t, 3, apis.peak, "independent creates run at the same time, bounded by the parallelism")
+	assert.Less(t, indexOf(apis.events, "done orders"), indexOf(apis.events, "start shipping"),
+		"a dependent starts after its prerequisite succeeded")
+}
+
+func TestExecutor_FailureStopsNewChanges(t *testing.T) {
+	apis := &concurrentAPI{delay: 100 * time.Millisecond, failName: "orders"}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "payments", "billing")
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 2}).Execute(timeoutTestContext(), plan)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "c:api:orders", result.Errors[0].ChangeID)
+	// The create in flight when orders failed still completes
+	require.Len(t, result.ChangesApplied, 1)
+	assert.Equal(t, "c:api:payments", result.ChangesApplied[0].ChangeID)
+	require.Len(t, result.ChangesNotStarted, 1)
+	assert.Equal(t, "c:api:billing", result.ChangesNotStarted[0].ChangeID)
+	assert.Equal(t, "billing", result.ChangesNotStarted[0].ResourceName)
+}
+
+func TestExecutor_CancellationStopsNewChanges(t *testing.T) {
+	apis := &concurrentAPI{delay: 100 * time.Millisecond}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "payments", "billing")
+
+	ctx, cancel := context.WithCancel(timeoutTestContext())
+	defer cancel()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1}).Execute(ctx, plan)
+
+	assert.True(t, result.Canceled)
+	// The create in flight when execution was canceled still completes
+	require.Len(t, result.ChangesApplied, 1)
+	assert.Equal(t, "c:api:orders", result.ChangesApplied[0].ChangeID)
+	require.Len(t, result.ChangesNotStarted, 2)
+	assert.Equal(t, "c:api:payments", result.ChangesNotStarted[0].ChangeID)
+}
+
+func indexOf(events []string, event string) int {
+	for i, e := range events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
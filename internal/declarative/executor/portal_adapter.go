@@ -191,9 +191,17 @@ func (p *PortalAdapter) GetByName(ctx context.Context, name string) (ResourceInf
 	return &PortalResourceInfo{portal: portal}, nil
 }
 
-// GetByID gets a portal by ID
-func (p *PortalAdapter) GetByID(_ context.Context, _ string, _ *ExecutionContext) (ResourceInfo, error) {
-	// Portals don't have a direct GetByID method - the planner handles ID lookups
+// GetByID gets a portal by ID, including unmanaged portals the plan adopts
+func (p *PortalAdapter) GetByID(ctx context.Context, id string, _ *ExecutionContext) (ResourceInfo, error) {
+	portals, err := p.client.ListAllPortals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range portals {
+		if portals[i].ID == id {
+			return &PortalResourceInfo{portal: &portals[i]}, nil
+		}
+	}
 	return nil, nil
 }
 
@@ -233,3 +241,8 @@ func (p *PortalResourceInfo) GetLabels() map[string]string {
 func (p *PortalResourceInfo) GetNormalizedLabels() map[string]string {
 	return p.portal.NormalizedLabels
 }
+
+// Snapshot returns the portal as read from Konnect
+func (p *PortalResourceInfo) Snapshot() any {
+	return p.portal
+}
@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// PortalAuditLogWebhookAdapter implements ResourceOperations for portal audit log webhooks.
+// The webhook is a singleton addressed through its portal, so create and update both
+// PATCH the portal's webhook and report the portal ID as the resource ID.
+type PortalAuditLogWebhookAdapter struct {
+	client *state.Client
+}
+
+// NewPortalAuditLogWebhookAdapter creates a new adapter.
+func NewPortalAuditLogWebhookAdapter(client *state.Client) *PortalAuditLogWebhookAdapter {
+	return &PortalAuditLogWebhookAdapter{client: client}
+}
+
+func (a *PortalAuditLogWebhookAdapter) MapCreateFields(
+	_ context.Context, _ *ExecutionContext, fields map[string]any, create *kkComps.UpdatePortalAuditLogWebhook,
+) error {
+	mapPortalAuditLogWebhookFields(fields, create)
+	return nil
+}
+
+func (a *PortalAuditLogWebhookAdapter) MapUpdateFields(
+	_ context.Context, _ *ExecutionContext, fields map[string]any, update *kkComps.UpdatePortalAuditLogWebhook,
+	_ map[string]string,
+) error {
+	mapPortalAuditLogWebhookFields(fields, update)
+	return nil
+}
+
+func mapPortalAuditLogWebhookFields(fields map[string]any, body *kkComps.UpdatePortalAuditLogWebhook) {
+	if enabled, ok := fields["enabled"].(bool); ok {
+		body.Enabled = &enabled
+	}
+	if destinationID, ok := fields["audit_log_destination_id"].(string); ok {
+		body.AuditLogDestinationID = &destinationID
+	}
+}
+
+func (a *PortalAuditLogWebhookAdapter) Create(
+	ctx context.Context, req kkComps.UpdatePortalAuditLogWebhook, _ string, execCtx *ExecutionContext,
+) (string, error) {
+	portalID, err := a.portalID(execCtx)
+	if err != nil {
+		return "", err
+	}
+	return a.client.UpdatePortalAuditLogWebhook(ctx, portalID, &req)
+}
+
+func (a *PortalAuditLogWebhookAdapter) Update(
+	ctx context.Context, _ string, req kkComps.UpdatePortalAuditLogWebhook, _ string, execCtx *ExecutionContext,
+) (string, error) {
+	portalID, err := a.portalID(execCtx)
+	if err != nil {
+		return "", err
+	}
+	return a.client.UpdatePortalAuditLogWebhook(ctx, portalID, &req)
+}
+
+func (a *PortalAuditLogWebhookAdapter) Delete(ctx context.Context, _ string, execCtx *ExecutionContext) error {
+	portalID, err := a.portalID(execCtx)
+	if err != nil {
+		return err
+	}
+	return a.client.DeletePortalAuditLogWebhook(ctx, portalID)
+}
+
+func (a *PortalAuditLogWebhookAdapter) GetByName(_ context.Context, _ string) (ResourceInfo, error) {
+	return nil, nil
+}
+
+func (a *PortalAuditLogWebhookAdapter) GetByID(
+	ctx context.Context, id string, execCtx *ExecutionContext,
+) (ResourceInfo, error) {
+	portalID := id
+	if execCtx != nil {
+		if resolved, err := a.portalID(execCtx); err == nil {
+			portalID = resolved
+		}
+	}
+
+	if portalID == "" {
+		return nil, fmt.Errorf("portal ID is required to fetch portal audit log webhook")
+	}
+
+	webhook, err := a.client.GetPortalAuditLogWebhook(ctx, portalID)
+	if err != nil || webhook == nil {
+		return nil, err
+	}
+
+	return &portalAuditLogWebhookInfo{portalID: portalID, webhook: webhook}, nil
+}
+
+func (a *PortalAuditLogWebhookAdapter) ResourceType() string {
+	return "portal_audit_log_webhook"
+}
+
+func (a *PortalAuditLogWebhookAdapter) RequiredFields() []string {
+	return []string{}
+}
+
+func (a *PortalAuditLogWebhookAdapter) SupportsUpdate() bool {
+	return true
+}
+
+func (a *PortalAuditLogWebhookAdapter) portalID(execCtx *ExecutionContext) (string, error) {
+	if execCtx == nil || execCtx.PlannedChange == nil {
+		return "", fmt.Errorf("execution context is required for portal audit log webhook operations")
+	}
+
+	change := *execCtx.PlannedChange
+
+	if portalRef, ok := change.References["portal_id"]; ok && portalRef.ID != "" {
+		return portalRef.ID, nil
+	}
+
+	if change.Parent != nil && change.Parent.ID != "" {
+		return change.Parent.ID, nil
+	}
+
+	return "", fmt.Errorf("portal ID is required for portal audit log webhook operations")
+}
+
+type portalAuditLogWebhookInfo struct {
+	portalID string
+	webhook  *kkComps.PortalAuditLogWebhook
+}
+
+func (i *portalAuditLogWebhookInfo) GetID() string {
+	if i == nil {
+		return ""
+	}
+	return i.portalID
+}
+
+func (i *portalAuditLogWebhookInfo) GetName() string {
+	return ""
+}
+
+func (i *portalAuditLogWebhookInfo) GetLabels() map[string]string {
+	return nil
+}
+
+func (i *portalAuditLogWebhookInfo) GetNormalizedLabels() map[string]string {
+	return nil
+}
@@ -220,7 +220,7 @@ func (e *Executor) deletePortal(ctx context.Context, change planner.PlannedChang
 
 	// Check if portal is protected
 	isProtected := portal.NormalizedLabels[labels.ProtectedKey] == "true"
-	if isProtected {
+	if isProtected && !change.DeleteProtected {
 		return fmt.Errorf("resource is protected and cannot be deleted")
 	}
 
@@ -452,6 +452,38 @@ func TestExecutor_deletePortal(t *testing.T) {
 			wantErr: true,
 			errMsg:  "resource is protected and cannot be deleted",
 		},
+		{
+			name: "delete protected portal confirmed in the plan",
+			change: planner.PlannedChange{
+				ResourceType:    "portal",
+				ResourceID:      "portal-456",
+				Action:          planner.ActionDelete,
+				Fields:          map[string]any{"name": "protected-portal"},
+				DeleteProtected: true,
+			},
+			setupMock: func(m *MockPortalAPI) {
+				m.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+					ListPortalsResponse: &kkComps.ListPortalsResponse{
+						Data: []kkComps.ListPortalsResponsePortal{
+							{
+								ID:   "portal-456",
+								Name: "protected-portal",
+								Labels: map[string]string{
+									labels.NamespaceKey: "default",
+									labels.ProtectedKey: "true",
+								},
+							},
+						},
+						Meta: kkComps.PaginatedMeta{
+							Page: kkComps.PageMeta{Total: 1},
+						},
+					},
+				}, nil)
+				m.On("DeletePortal", mock.Anything, "portal-456", true).
+					Return(&kkOps.DeletePortalResponse{}, nil)
+			},
+			wantErr: false,
+		},
 		{
 			name: "delete non-managed portal - not found by managed portal search",
 			change: planner.PlannedChange{
@@ -185,31 +185,80 @@ func (r *ConsoleReporter) FinishExecution(result *ExecutionResult) {
 		fmt.Fprintln(r.writer, "")
 	}
 
+	writeExecutionResult(r.writer, result)
+}
+
+// writeExecutionResult writes the outcome of an execution: what was applied or
+// validated, the errors, and where a stopped execution left the resources
+func writeExecutionResult(w io.Writer, result *ExecutionResult) {
 	if result.DryRun {
 		// For dry-run, show what would happen
-		fmt.Fprintln(r.writer, "Dry run complete.")
+		fmt.Fprintln(w, "Dry run complete.")
 		if result.SkippedCount > 0 {
-			fmt.Fprintf(r.writer, "%d changes would be applied.\n", result.SkippedCount)
+			fmt.Fprintf(w, "%d changes would be applied.\n", result.SkippedCount)
 		}
 
 		if result.FailureCount > 0 {
-			fmt.Fprintln(r.writer, "\nValidation errors:")
+			fmt.Fprintln(w, "\nValidation errors:")
 			for _, err := range result.Errors {
-				fmt.Fprintf(r.writer, "  • %s %s: %s\n", err.ResourceType, err.ResourceName, err.Error)
+				fmt.Fprintf(w, "  • %s %s: %s\n", err.ResourceType, err.ResourceName, err.Error)
 			}
 		}
 	} else {
 		// For actual execution, show results
-		fmt.Fprintln(r.writer, "Complete.")
+		if result.Canceled {
+			fmt.Fprintln(w, "Canceled.")
+		} else {
+			fmt.Fprintln(w, "Complete.")
+		}
 		if result.SuccessCount > 0 {
-			fmt.Fprintf(r.writer, "Executed %d changes.\n", result.SuccessCount)
+			fmt.Fprintf(w, "Executed %d changes.\n", result.SuccessCount)
 		}
 
 		if result.FailureCount > 0 && len(result.Errors) > 0 {
-			fmt.Fprintln(r.writer, "\nErrors:")
+			fmt.Fprintln(w, "\nErrors:")
 			for _, err := range result.Errors {
-				fmt.Fprintf(r.writer, "  • %s %s: %s\n",
+				fmt.Fprintf(w, "  • %s %s: %s\n",
 					err.ResourceType, err.ResourceName, err.Error)
+				if err.RequestID != "" {
+					fmt.Fprintf(w, "    request ID: %s\n", err.RequestID)
+				}
+			}
+		}
+
+		// After a failure or cancellation stopped execution, show where it left the resources
+		if len(result.ChangesNotStarted) > 0 {
+			if len(result.ChangesApplied) > 0 {
+				fmt.Fprintln(w, "\nCompleted:")
+				for _, change := range result.ChangesApplied {
+					fmt.Fprintf(w, "  • %s %s %s\n", change.Action, change.ResourceType, change.ResourceName)
+				}
+			}
+			reason := "the failure"
+			if result.Canceled {
+				reason = "cancellation"
+			}
+			fmt.Fprintf(w, "\nNot started after %s (%d):\n", reason, len(result.ChangesNotStarted))
+			for _, change := range result.ChangesNotStarted {
+				fmt.Fprintf(w, "  • %s %s %s\n", change.Action, change.ResourceType, change.ResourceName)
+			}
+		}
+
+		if rollback := result.Rollback; rollback != nil {
+			fmt.Fprintf(w, "\nRolled back %d change(s):\n", len(rollback.Undone))
+			for _, change := range rollback.Undone {
+				fmt.Fprintf(w, "  • %s %s %s", change.Action, change.ResourceType, change.ResourceName)
+				if change.Reason != "" {
+					fmt.Fprintf(w, " (%s)", change.Reason)
+				}
+				fmt.Fprintln(w)
+			}
+			if len(rollback.NotUndone) > 0 {
+				fmt.Fprintf(w, "\nNot rolled back (%d):\n", len(rollback.NotUndone))
+				for _, change := range rollback.NotUndone {
+					fmt.Fprintf(w, "  • %s %s %s: %s\n",
+						change.Action, change.ResourceType, change.ResourceName, change.Reason)
+				}
 			}
 		}
 	}
@@ -226,6 +275,8 @@ func getActionVerb(action planner.ActionType) string {
 		return "Deleting"
 	case planner.ActionExternalTool:
 		return "Running"
+	case planner.ActionSwitch:
+		return "Switching"
 	default:
 		return string(action) + "ing"
 	}
@@ -236,6 +236,45 @@ func TestConsoleReporter_FinishExecution_Normal(t *testing.T) {
 				"  • portal bad-portal: validation failed",
 			},
 		},
+		{
+			name: "execution stopped by a failure",
+			result: &ExecutionResult{
+				SuccessCount: 1,
+				FailureCount: 1,
+				Errors: []ExecutionError{
+					{Action: "CREATE", ResourceType: "api", ResourceName: "orders", Error: "conflict"},
+				},
+				ChangesApplied: []AppliedChange{
+					{Action: "CREATE", ResourceType: "api", ResourceName: "payments"},
+				},
+				ChangesNotStarted: []NotStartedChange{
+					{Action: "CREATE", ResourceType: "api", ResourceName: "billing"},
+				},
+			},
+			containsStr: []string{
+				"Completed:\n  • CREATE api payments",
+				"Not started after the failure (1):\n  • CREATE api billing",
+			},
+		},
+		{
+			name: "execution stopped by cancellation",
+			result: &ExecutionResult{
+				SuccessCount: 1,
+				Canceled:     true,
+				ChangesApplied: []AppliedChange{
+					{Action: "CREATE", ResourceType: "api", ResourceName: "orders"},
+				},
+				ChangesNotStarted: []NotStartedChange{
+					{Action: "CREATE", ResourceType: "api", ResourceName: "payments"},
+				},
+			},
+			containsStr: []string{
+				"Canceled.",
+				"Completed:\n  • CREATE api orders",
+				"Not started after cancellation (1):\n  • CREATE api payments",
+			},
+			notContains: []string{"Complete."},
+		},
 		{
 			name: "execution with skipped",
 			result: &ExecutionResult{
@@ -0,0 +1,104 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// switchResource executes a SWITCH change. The API publication is written with its
+// stage visibility, the optional verify command runs, and the publication is then
+// written again with its target visibility. Both writes are a PUT against the same
+// API and portal, so the publication keeps its identity throughout.
+func (e *Executor) switchResource(ctx context.Context, change *planner.PlannedChange) (string, error) {
+	if change.ResourceType != "api_publication" {
+		return "", fmt.Errorf("switch action is only supported for api_publication resources")
+	}
+
+	target, _ := change.Fields["visibility"].(string)
+	stage, _ := change.Fields["stage_visibility"].(string)
+	if target == "" || stage == "" {
+		return "", fmt.Errorf("switch requires visibility and stage_visibility fields")
+	}
+
+	// The staged and flipped writes share References, so IDs resolved while staging
+	// are reused by the flip
+	staged := *change
+	staged.Fields = maps.Clone(change.Fields)
+	staged.Fields["visibility"] = stage
+	if _, err := e.createResource(ctx, &staged); err != nil {
+		return "", fmt.Errorf("failed to stage publication with visibility %q: %w", stage, err)
+	}
+
+	if verify := switchVerifyCommand(change.Fields["verify"]); len(verify) > 0 {
+		if err := e.runSwitchVerify(ctx, &staged, verify); err != nil {
+			return "", fmt.Errorf("verification failed, publication left with visibility %q: %w", stage, err)
+		}
+	}
+
+	flipped := staged
+	flipped.Fields = maps.Clone(change.Fields)
+	id, err := e.createResource(ctx, &flipped)
+	if err != nil {
+		return "", fmt.Errorf("failed to switch publication to visibility %q: %w", target, err)
+	}
+	return id, nil
+}
+
+// runSwitchVerify runs the verify command of a switch. The command sees the staged
+// publication through KONGCTL_API_ID, KONGCTL_PORTAL_ID and KONGCTL_PUBLICATION_REF.
+// Dry runs log the command instead of running it.
+func (e *Executor) runSwitchVerify(ctx context.Context, change *planner.PlannedChange, command []string) error {
+	if e.dryRun {
+		slog.Debug("Skipped publication switch verification in dry run",
+			"change_id", change.ID,
+			"command", strings.Join(command, " "),
+		)
+		return nil
+	}
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Dir = e.planBaseDir
+	cmd.Env = append(os.Environ(),
+		"KONGCTL_API_ID="+change.References["api_id"].ID,
+		"KONGCTL_PORTAL_ID="+change.References["portal_id"].ID,
+		"KONGCTL_PUBLICATION_REF="+change.ResourceRef,
+	)
+
+	output, err := cmd.CombinedOutput()
+	slog.Debug("Ran publication switch verification",
+		"change_id", change.ID,
+		"command", strings.Join(command, " "),
+		"output", string(output),
+	)
+	if err != nil {
+		if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+			return fmt.Errorf("%s: %w: %s", command[0], err, trimmed)
+		}
+		return fmt.Errorf("%s: %w", command[0], err)
+	}
+	return nil
+}
+
+// switchVerifyCommand reads the verify field, which is []any once a plan has been
+// loaded from JSON
+func switchVerifyCommand(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		command := make([]string, 0, len(v))
+		for _, arg := range v {
+			if s, ok := arg.(string); ok {
+				command = append(command, s)
+			}
+		}
+		return command
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublicationAPI records publication writes and deletes
+type recordingPublicationAPI struct {
+	puts    []kkOps.PublishAPIToPortalRequest
+	deletes int
+}
+
+func (r *recordingPublicationAPI) PublishAPIToPortal(
+	_ context.Context, request kkOps.PublishAPIToPortalRequest, _ ...kkOps.Option,
+) (*kkOps.PublishAPIToPortalResponse, error) {
+	r.puts = append(r.puts, request)
+	return &kkOps.PublishAPIToPortalResponse{
+		StatusCode:             200,
+		APIPublicationResponse: &kkComps.APIPublicationResponse{},
+	}, nil
+}
+
+func (r *recordingPublicationAPI) DeletePublication(
+	_ context.Context, _ string, _ string, _ ...kkOps.Option,
+) (*kkOps.DeletePublicationResponse, error) {
+	r.deletes++
+	return &kkOps.DeletePublicationResponse{StatusCode: 204}, nil
+}
+
+func (r *recordingPublicationAPI) ListAPIPublications(
+	_ context.Context, _ kkOps.ListAPIPublicationsRequest, _ ...kkOps.Option,
+) (*kkOps.ListAPIPublicationsResponse, error) {
+	return &kkOps.ListAPIPublicationsResponse{}, nil
+}
+
+func switchPlan(verify []string) *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	fields := map[string]any{
+		"portal_id":        "portal-1",
+		"visibility":       "public",
+		"stage_visibility": "private",
+	}
+	if verify != nil {
+		fields["verify"] = verify
+	}
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:s:api_publication:orders-pub",
+		ResourceType: "api_publication",
+		ResourceRef:  "orders-pub",
+		ResourceID:   "api-1:portal-1",
+		Parent:       &planner.ParentInfo{Ref: "orders", ID: "api-1"},
+		Action:       planner.ActionSwitch,
+		Fields:       fields,
+		References: map[string]planner.ReferenceInfo{
+			"api_id":    {Ref: "orders", ID: "api-1"},
+			"portal_id": {Ref: "dev-portal", ID: "portal-1"},
+		},
+	})
+	plan.SetExecutionOrder([]string{"1:s:api_publication:orders-pub"})
+	return plan
+}
+
+func putVisibilities(puts []kkOps.PublishAPIToPortalRequest) []string {
+	visibilities := make([]string, 0, len(puts))
+	for _, put := range puts {
+		visibilities = append(visibilities, string(*put.APIPublication.Visibility))
+	}
+	return visibilities
+}
+
+func TestSwitchPublication_FlipPreservesIdentity(t *testing.T) {
+	api := &recordingPublicationAPI{}
+	client := state.NewClient(state.ClientConfig{APIPublicationAPI: api})
+
+	verify := []string{"sh", "-c",
+		`test "$KONGCTL_API_ID" = api-1 && test "$KONGCTL_PORTAL_ID" = portal-1 && ` +
+			`test "$KONGCTL_PUBLICATION_REF" = orders-pub`}
+	result := New(client, nil, false).Execute(context.Background(), switchPlan(verify))
+	require.Empty(t, result.Errors)
+
+	assert.Equal(t, []string{"private", "public"}, putVisibilities(api.puts))
+	for _, put := range api.puts {
+		assert.Equal(t, "api-1", put.APIID)
+		assert.Equal(t, "portal-1", put.PortalID)
+	}
+	assert.Zero(t, api.deletes, "a switch never deletes the publication")
+
+	require.Len(t, result.ChangesApplied, 1)
+	assert.Equal(t, string(planner.ActionSwitch), result.ChangesApplied[0].Action)
+	assert.Equal(t, "api-1", result.ChangesApplied[0].ResourceID)
+}
+
+func TestSwitchPublication_FailedVerificationLeavesStage(t *testing.T) {
+	api := &recordingPublicationAPI{}
+	client := state.NewClient(state.ClientConfig{APIPublicationAPI: api})
+
+	result := New(client, nil, false).Execute(context.Background(),
+		switchPlan([]string{"sh", "-c", "echo portal not ready; exit 3"}))
+
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error, `verification failed, publication left with visibility "private"`)
+	assert.Contains(t, result.Errors[0].Error, "portal not ready")
+	assert.Equal(t, []string{"private"}, putVisibilities(api.puts))
+	assert.Zero(t, api.deletes)
+}
+
+func TestSwitchPublication_WithoutVerify(t *testing.T) {
+	api := &recordingPublicationAPI{}
+	client := state.NewClient(state.ClientConfig{APIPublicationAPI: api})
+
+	result := New(client, nil, false).Execute(context.Background(), switchPlan(nil))
+	require.Empty(t, result.Errors)
+	assert.Equal(t, []string{"private", "public"}, putVisibilities(api.puts))
+}
+
+func TestSwitchPublication_DryRunSkipsVerify(t *testing.T) {
+	api := &recordingPublicationAPI{}
+	client := state.NewClient(state.ClientConfig{APIPublicationAPI: api})
+
+	result := NewWithOptions(client, nil, true, Options{ExecuteDryRun: true}).Execute(context.Background(),
+		switchPlan([]string{"sh", "-c", "exit 3"}))
+	require.Empty(t, result.Errors)
+	assert.Equal(t, []string{"private", "public"}, putVisibilities(api.puts))
+}
@@ -0,0 +1,178 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// ReportVersion is the format version written to apply report files
+const ReportVersion = 1
+
+// Statuses of the operations of an apply report
+const (
+	OperationSucceeded  = "success"
+	OperationSkipped    = "skipped"
+	OperationFailed     = "failed"
+	OperationNotStarted = "not_started"
+)
+
+// OperationRecord is the outcome and timing of one change of an execution
+type OperationRecord struct {
+	ChangeID     string `json:"change_id"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	ResourceRef  string `json:"resource_ref,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	// ID of the created, updated or deleted resource
+	ResourceID string `json:"resource_id,omitempty"`
+	// RolledBack is set for successful changes undone by --rollback-on-error
+	RolledBack bool       `json:"rolled_back,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	DurationMS int64      `json:"duration_ms"`
+}
+
+// ReportSummary counts the operations of an apply report by status
+type ReportSummary struct {
+	Total      int `json:"total"`
+	Succeeded  int `json:"succeeded"`
+	Failed     int `json:"failed"`
+	Skipped    int `json:"skipped"`
+	NotStarted int `json:"not_started"`
+}
+
+// Report is the machine-readable record of an execution written by --report
+type Report struct {
+	Version    int               `json:"version"`
+	PlanID     string            `json:"plan_id,omitempty"`
+	Mode       planner.PlanMode  `json:"mode,omitempty"`
+	DryRun     bool              `json:"dry_run"`
+	Canceled   bool              `json:"canceled,omitempty"`
+	StartedAt  *time.Time        `json:"started_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+	DurationMS int64             `json:"duration_ms"`
+	Summary    ReportSummary     `json:"summary"`
+	Operations []OperationRecord `json:"operations"`
+}
+
+// recordOperation records the outcome of a change started at started, and returns
+// the record. Callers hold e.mu.
+func recordOperation(result *ExecutionResult, change *planner.PlannedChange, status string,
+	started time.Time, resourceID string, err error,
+) OperationRecord {
+	operation := OperationRecord{
+		ChangeID:     change.ID,
+		ResourceType: change.ResourceType,
+		ResourceName: getResourceName(change.Fields),
+		ResourceRef:  change.ResourceRef,
+		Action:       string(change.Action),
+		Status:       status,
+		ResourceID:   resourceID,
+		StartedAt:    &started,
+		DurationMS:   time.Since(started).Milliseconds(),
+	}
+	if err != nil {
+		operation.Error = err.Error()
+	}
+	result.Operations = append(result.Operations, operation)
+	return operation
+}
+
+// BuildReport returns the report of the execution of plan. Operations are listed in
+// execution order, followed by the changes that never started.
+func BuildReport(plan *planner.Plan, result *ExecutionResult) *Report {
+	report := &Report{Version: ReportVersion, Operations: []OperationRecord{}}
+	if plan != nil {
+		report.PlanID = plan.Metadata.PlanID
+		report.Mode = plan.Metadata.Mode
+	}
+	if result == nil {
+		return report
+	}
+
+	report.DryRun = result.DryRun
+	report.Canceled = result.Canceled
+	if !result.StartedAt.IsZero() {
+		startedAt, finishedAt := result.StartedAt, result.FinishedAt
+		report.StartedAt = &startedAt
+		report.FinishedAt = &finishedAt
+		report.DurationMS = finishedAt.Sub(startedAt).Milliseconds()
+	}
+
+	undone := make(map[string]bool)
+	if result.Rollback != nil {
+		for _, change := range result.Rollback.Undone {
+			undone[change.ChangeID] = true
+		}
+	}
+
+	// Parallel changes finish out of order
+	position := make(map[string]int)
+	if plan != nil {
+		for i, changeID := range plan.ExecutionOrder {
+			position[changeID] = i
+		}
+	}
+	operations := make([]OperationRecord, 0, len(result.Operations)+len(result.ChangesNotStarted))
+	operations = append(operations, result.Operations...)
+	slices.SortStableFunc(operations, func(a, b OperationRecord) int {
+		pa, okA := position[a.ChangeID]
+		pb, okB := position[b.ChangeID]
+		switch {
+		case okA && okB:
+			return pa - pb
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return 0
+	})
+	for i := range operations {
+		operations[i].RolledBack = operations[i].Status == OperationSucceeded && undone[operations[i].ChangeID]
+	}
+	for _, change := range result.ChangesNotStarted {
+		operations = append(operations, OperationRecord{
+			ChangeID:     change.ChangeID,
+			ResourceType: change.ResourceType,
+			ResourceName: change.ResourceName,
+			ResourceRef:  change.ResourceRef,
+			Action:       change.Action,
+			Status:       OperationNotStarted,
+		})
+	}
+	report.Operations = operations
+
+	for _, operation := range operations {
+		report.Summary.Total++
+		switch operation.Status {
+		case OperationSucceeded:
+			report.Summary.Succeeded++
+		case OperationFailed:
+			report.Summary.Failed++
+		case OperationSkipped:
+			report.Summary.Skipped++
+		case OperationNotStarted:
+			report.Summary.NotStarted++
+		}
+	}
+	return report
+}
+
+// WriteReport writes the report of the execution of plan to path as JSON
+func WriteReport(path string, plan *planner.Plan, result *ExecutionResult) error {
+	data, err := json.MarshalIndent(BuildReport(plan, result), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal apply report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write apply report file: %w", err)
+	}
+	return nil
+}
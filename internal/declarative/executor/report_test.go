@@ -0,0 +1,94 @@
+package executor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReport_FromExecution(t *testing.T) {
+	apis := &concurrentAPI{delay: 10 * time.Millisecond, failName: "payments"}
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "payments", "billing")
+	plan.Metadata.PlanID = "plan-1"
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1}).Execute(timeoutTestContext(), plan)
+	report := BuildReport(plan, result)
+
+	assert.Equal(t, ReportVersion, report.Version)
+	assert.Equal(t, "plan-1", report.PlanID)
+	assert.Equal(t, planner.PlanModeApply, report.Mode)
+	require.NotNil(t, report.StartedAt)
+	require.NotNil(t, report.FinishedAt)
+	assert.False(t, report.FinishedAt.Before(*report.StartedAt))
+
+	require.Len(t, report.Operations, 3)
+	orders, payments, billing := report.Operations[0], report.Operations[1], report.Operations[2]
+	assert.Equal(t, OperationSucceeded, orders.Status)
+	assert.Equal(t, "orders-id", orders.ResourceID, "creates report the ID Konnect assigned")
+	assert.Equal(t, "CREATE", orders.Action)
+	require.NotNil(t, orders.StartedAt)
+	assert.GreaterOrEqual(t, orders.DurationMS, int64(10))
+	assert.Equal(t, OperationFailed, payments.Status)
+	assert.Contains(t, payments.Error, "create failed")
+	assert.Equal(t, OperationNotStarted, billing.Status)
+	assert.Nil(t, billing.StartedAt)
+
+	// The summary counts of the result can be derived from the operations
+	assert.Equal(t, ReportSummary{Total: 3, Succeeded: 1, Failed: 1, NotStarted: 1}, report.Summary)
+	assert.Equal(t, result.SuccessCount, report.Summary.Succeeded)
+	assert.Equal(t, result.FailureCount, report.Summary.Failed)
+}
+
+func TestBuildReport_OrdersAndRollback(t *testing.T) {
+	plan := apiCreatePlan("orders", "payments")
+	result := &ExecutionResult{
+		// Parallel changes may finish in any order
+		Operations: []OperationRecord{
+			{ChangeID: "c:api:payments", Status: OperationSucceeded},
+			{ChangeID: "c:api:orders", Status: OperationSucceeded},
+		},
+		Rollback: &RollbackResult{Undone: []RollbackChange{{ChangeID: "c:api:orders"}}},
+	}
+
+	report := BuildReport(plan, result)
+	require.Len(t, report.Operations, 2)
+	assert.Equal(t, "c:api:orders", report.Operations[0].ChangeID)
+	assert.True(t, report.Operations[0].RolledBack)
+	assert.False(t, report.Operations[1].RolledBack)
+	assert.Nil(t, report.StartedAt, "a result that never ran has no timing")
+}
+
+func TestBuildReport_DryRun(t *testing.T) {
+	plan := apiCreatePlan("orders")
+	result := NewWithOptions(nil, nil, true, Options{}).Execute(timeoutTestContext(), plan)
+
+	report := BuildReport(plan, result)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Operations, 1)
+	assert.Equal(t, OperationSkipped, report.Operations[0].Status)
+	assert.Equal(t, ReportSummary{Total: 1, Skipped: 1}, report.Summary)
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeSync)
+	plan.Metadata.PlanID = "plan-2"
+
+	require.NoError(t, WriteReport(path, plan, &ExecutionResult{}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report map[string]any
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "plan-2", report["plan_id"])
+	assert.Equal(t, "sync", report["mode"])
+	assert.Equal(t, []any{}, report["operations"], "an empty plan still lists its operations")
+}
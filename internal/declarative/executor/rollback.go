@@ -0,0 +1,199 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/log"
+)
+
+// RollbackResult reports how the changes applied before a failure were reversed
+type RollbackResult struct {
+	// Changes reversed: created resources deleted and updated fields restored
+	Undone []RollbackChange `json:"undone,omitempty"`
+	// Changes left applied, with the reason
+	NotUndone []RollbackChange `json:"not_undone,omitempty"`
+}
+
+// RollbackChange is an applied change considered for rollback
+type RollbackChange struct {
+	ChangeID     string `json:"change_id"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	ResourceRef  string `json:"resource_ref"`
+	Action       string `json:"action"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	// Reason a change was not undone, or was only partly undone
+	Reason string `json:"reason,omitempty"`
+}
+
+// ResourceSnapshot is implemented by the ResourceInfo of resources whose field values
+// can be captured before an update, so that a rollback can restore them
+type ResourceSnapshot interface {
+	// Snapshot returns the resource as read from Konnect, encoded with the field names of plans
+	Snapshot() any
+}
+
+type priorFieldsKey struct{}
+
+// priorFields holds the values the fields of an update had before it ran
+type priorFields struct {
+	fields map[string]any
+	// missing lists the fields whose prior value could not be read
+	missing []string
+}
+
+func withPriorFields(ctx context.Context, prior *priorFields) context.Context {
+	return context.WithValue(ctx, priorFieldsKey{}, prior)
+}
+
+// recordPriorFields captures, for a rollback, the current values of the fields an
+// update is about to change. It does nothing unless the executor rolls back on error.
+func recordPriorFields(ctx context.Context, resource ResourceInfo, change planner.PlannedChange) {
+	prior, ok := ctx.Value(priorFieldsKey{}).(*priorFields)
+	if !ok || resource == nil {
+		return
+	}
+
+	var current map[string]any
+	if snapshot, ok := resource.(ResourceSnapshot); ok {
+		if data, err := json.Marshal(snapshot.Snapshot()); err == nil {
+			_ = json.Unmarshal(data, &current)
+		}
+	}
+
+	prior.fields = make(map[string]any, len(change.Fields))
+	for field := range change.Fields {
+		switch {
+		case strings.HasPrefix(field, "_") || slices.Contains(change.IdentityFields, field):
+			continue
+		case field == "name":
+			prior.fields[field] = resource.GetName()
+		case field == "labels":
+			userLabels := make(map[string]any)
+			for key, value := range resource.GetLabels() {
+				if !labels.IsKongctlLabel(key) {
+					userLabels[key] = value
+				}
+			}
+			prior.fields[field] = userLabels
+		default:
+			value, ok := current[field]
+			if !ok {
+				prior.missing = append(prior.missing, field)
+				continue
+			}
+			prior.fields[field] = value
+		}
+	}
+	slices.Sort(prior.missing)
+}
+
+// rollback reverses the changes applied by this execution, last applied first, after
+// a change failed. Resources are only changed through the changes of this run:
+// created resources are deleted and updated fields restored. Rolling back is best
+// effort and reports each change it could not undo.
+func (e *Executor) rollback(ctx context.Context, result *ExecutionResult, plan *planner.Plan) {
+	logger, ok := ctx.Value(log.LoggerKey).(*slog.Logger)
+	if !ok {
+		logger = slog.Default()
+	}
+	// Rolling back after Ctrl-C must still reach Konnect
+	ctx = context.WithoutCancel(ctx)
+
+	rollback := &RollbackResult{}
+	for i := len(result.ChangesApplied) - 1; i >= 0; i-- {
+		applied := result.ChangesApplied[i]
+		entry := RollbackChange{
+			ChangeID:     applied.ChangeID,
+			ResourceType: applied.ResourceType,
+			ResourceName: applied.ResourceName,
+			ResourceRef:  applied.ResourceRef,
+			Action:       applied.Action,
+			ResourceID:   applied.ResourceID,
+		}
+		note, err := e.undoChange(ctx, plan, applied)
+		if err != nil {
+			entry.Reason = err.Error()
+			rollback.NotUndone = append(rollback.NotUndone, entry)
+			logger.Warn("Unable to roll back change", "change_id", applied.ChangeID, "error", err)
+			continue
+		}
+		entry.Reason = note
+		rollback.Undone = append(rollback.Undone, entry)
+	}
+	result.Rollback = rollback
+}
+
+// undoChange reverses one applied change. The note tells what a partial undo left out.
+func (e *Executor) undoChange(ctx context.Context, plan *planner.Plan, applied AppliedChange) (string, error) {
+	change := findChange(plan, applied.ChangeID)
+	if change == nil {
+		return "", fmt.Errorf("change not found in plan")
+	}
+
+	// References were resolved in place while the change ran, so the copy has the IDs
+	// of parents and referenced resources
+	undo := *change
+	undo.ID = "rollback:" + change.ID
+	undo.References = maps.Clone(change.References)
+	undo.Risk = nil
+
+	switch change.Action {
+	case planner.ActionCreate:
+		if change.ResourceType == planner.ResourceTypeDeck {
+			return "", fmt.Errorf("changes made by deck can't be rolled back")
+		}
+		if applied.ResourceID == "" {
+			return "", fmt.Errorf("the ID of the created resource is unknown")
+		}
+		undo.Action = planner.ActionDelete
+		undo.ResourceID = applied.ResourceID
+		undo.Protection = nil
+		changeCtx, cancel, _ := e.changeContext(ctx, &undo)
+		defer cancel()
+		if err := e.deleteResource(changeCtx, &undo); err != nil {
+			return "", fmt.Errorf("failed to delete the created resource: %w", err)
+		}
+		return "", nil
+	case planner.ActionUpdate:
+		e.mu.Lock()
+		prior := e.prior[change.ID]
+		e.mu.Unlock()
+		if prior == nil || len(prior.fields) == 0 {
+			return "", fmt.Errorf("the values before the update were not captured")
+		}
+		if change.Protection != nil {
+			return "", fmt.Errorf("protection changes are not rolled back")
+		}
+		undo.Fields = maps.Clone(prior.fields)
+		for _, field := range change.IdentityFields {
+			undo.Fields[field] = change.Fields[field]
+		}
+		// Restored references are IDs read from Konnect
+		for field := range prior.fields {
+			delete(undo.References, field)
+		}
+		changeCtx, cancel, _ := e.changeContext(ctx, &undo)
+		defer cancel()
+		if _, err := e.updateResource(changeCtx, &undo); err != nil {
+			return "", fmt.Errorf("failed to restore the updated fields: %w", err)
+		}
+		if len(prior.missing) > 0 {
+			return fmt.Sprintf("not restored, values before the update unknown: %s",
+				strings.Join(prior.missing, ", ")), nil
+		}
+		return "", nil
+	case planner.ActionDelete:
+		return "", fmt.Errorf("deleted resources can't be restored")
+	default:
+		return "", fmt.Errorf("%s changes are not rolled back", strings.ToLower(string(change.Action)))
+	}
+}
@@ -0,0 +1,190 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rollbackAPI keeps APIs in memory and fails creating the API named failName
+type rollbackAPI struct {
+	labelAPI
+	failName string
+
+	mu      sync.Mutex
+	apis    map[string]kkComps.APIResponseSchema
+	updates []kkComps.UpdateAPIRequest
+	deleted []string
+}
+
+func newRollbackAPI(failName string, existing ...kkComps.APIResponseSchema) *rollbackAPI {
+	r := &rollbackAPI{failName: failName, apis: make(map[string]kkComps.APIResponseSchema)}
+	for _, api := range existing {
+		r.apis[api.ID] = api
+	}
+	return r
+}
+
+func (r *rollbackAPI) ListApis(
+	context.Context, kkOps.ListApisRequest, ...kkOps.Option,
+) (*kkOps.ListApisResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data := make([]kkComps.APIResponseSchema, 0, len(r.apis))
+	for _, api := range r.apis {
+		data = append(data, api)
+	}
+	return &kkOps.ListApisResponse{
+		StatusCode: 200,
+		ListAPIResponse: &kkComps.ListAPIResponse{
+			Data: data,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(data))}},
+		},
+	}, nil
+}
+
+func (r *rollbackAPI) FetchAPI(_ context.Context, id string, _ ...kkOps.Option) (*kkOps.FetchAPIResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	api, ok := r.apis[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &kkOps.FetchAPIResponse{StatusCode: 200, APIResponseSchema: &api}, nil
+}
+
+func (r *rollbackAPI) CreateAPI(
+	_ context.Context, request kkComps.CreateAPIRequest, _ ...kkOps.Option,
+) (*kkOps.CreateAPIResponse, error) {
+	if request.Name == r.failName {
+		return nil, errors.New("create failed")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	api := kkComps.APIResponseSchema{ID: request.Name + "-id", Name: request.Name, Labels: request.Labels}
+	r.apis[api.ID] = api
+	return &kkOps.CreateAPIResponse{StatusCode: 201, APIResponseSchema: &api}, nil
+}
+
+func (r *rollbackAPI) UpdateAPI(
+	_ context.Context, id string, request kkComps.UpdateAPIRequest, _ ...kkOps.Option,
+) (*kkOps.UpdateAPIResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, request)
+	api := r.apis[id]
+	if request.Description != nil {
+		api.Description = request.Description
+	}
+	r.apis[id] = api
+	return &kkOps.UpdateAPIResponse{StatusCode: 200, APIResponseSchema: &api}, nil
+}
+
+func (r *rollbackAPI) DeleteAPI(_ context.Context, id string, _ ...kkOps.Option) (*kkOps.DeleteAPIResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleted = append(r.deleted, id)
+	delete(r.apis, id)
+	return &kkOps.DeleteAPIResponse{StatusCode: 204}, nil
+}
+
+func TestExecutor_RollbackDeletesCreatedResources(t *testing.T) {
+	apis := newRollbackAPI("billing")
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "payments", "billing")
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1, RollbackOnError: true}).
+		Execute(timeoutTestContext(), plan)
+
+	require.Len(t, result.Errors, 1)
+	require.Len(t, result.ChangesApplied, 2)
+	require.NotNil(t, result.Rollback)
+	require.Len(t, result.Rollback.Undone, 2)
+	assert.Empty(t, result.Rollback.NotUndone)
+	// The last applied change is undone first
+	assert.Equal(t, "c:api:payments", result.Rollback.Undone[0].ChangeID)
+	assert.Equal(t, "c:api:orders", result.Rollback.Undone[1].ChangeID)
+	assert.Equal(t, []string{"payments-id", "orders-id"}, apis.deleted)
+	assert.Empty(t, result.ChangesInEffect())
+}
+
+func TestExecutor_RollbackRestoresUpdatedFields(t *testing.T) {
+	description := "Orders API"
+	apis := newRollbackAPI("billing", kkComps.APIResponseSchema{
+		ID:          "api-1",
+		Name:        "orders",
+		Description: &description,
+		Labels:      map[string]string{"KONGCTL-namespace": "default", "team": "orders"},
+	})
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("billing")
+	plan.AddChange(planner.PlannedChange{
+		ID:           "u:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		ResourceID:   "api-1",
+		Action:       planner.ActionUpdate,
+		Namespace:    "default",
+		Fields:       map[string]any{"name": "orders", "description": "Order management"},
+	})
+	plan.SetExecutionOrder([]string{"u:api:orders", "c:api:billing"})
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1, RollbackOnError: true}).
+		Execute(timeoutTestContext(), plan)
+
+	require.Len(t, result.Errors, 1)
+	require.NotNil(t, result.Rollback)
+	require.Len(t, result.Rollback.Undone, 1)
+	assert.Equal(t, "u:api:orders", result.Rollback.Undone[0].ChangeID)
+	assert.Empty(t, result.Rollback.Undone[0].Reason)
+	require.Len(t, apis.updates, 2)
+	require.NotNil(t, apis.updates[1].Description)
+	assert.Equal(t, "Orders API", *apis.updates[1].Description)
+	assert.Equal(t, "Orders API", *apis.apis["api-1"].Description)
+}
+
+func TestExecutor_RollbackReportsChangesNotUndone(t *testing.T) {
+	apis := newRollbackAPI("billing", kkComps.APIResponseSchema{ID: "api-1", Name: "orders"})
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("billing")
+	plan.AddChange(planner.PlannedChange{
+		ID:           "d:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		ResourceID:   "api-1",
+		Action:       planner.ActionDelete,
+		Namespace:    "default",
+		Fields:       map[string]any{"name": "orders"},
+	})
+	plan.SetExecutionOrder([]string{"d:api:orders", "c:api:billing"})
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1, RollbackOnError: true}).
+		Execute(timeoutTestContext(), plan)
+
+	require.NotNil(t, result.Rollback)
+	assert.Empty(t, result.Rollback.Undone)
+	require.Len(t, result.Rollback.NotUndone, 1)
+	assert.Equal(t, "d:api:orders", result.Rollback.NotUndone[0].ChangeID)
+	assert.Contains(t, result.Rollback.NotUndone[0].Reason, "can't be restored")
+	assert.Len(t, result.ChangesInEffect(), 1)
+}
+
+func TestExecutor_NoRollbackByDefault(t *testing.T) {
+	apis := newRollbackAPI("billing")
+	client := state.NewClient(state.ClientConfig{APIAPI: apis})
+	plan := apiCreatePlan("orders", "billing")
+
+	result := NewWithOptions(client, nil, false, Options{Parallelism: 1}).Execute(timeoutTestContext(), plan)
+
+	require.Len(t, result.ChangesApplied, 1)
+	assert.Nil(t, result.Rollback)
+	assert.Empty(t, apis.deleted)
+}
@@ -0,0 +1,224 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/log"
+)
+
+// resolveSecrets reads the value of every !secret placeholder of the plan before any
+// change runs. A secret that changed since the plan was generated is an error too, as
+// the plan was reviewed against the previous value.
+func (e *Executor) resolveSecrets(plan *planner.Plan) error {
+	placeholders := make(map[string]bool)
+	for _, change := range plan.Changes {
+		for _, value := range change.Fields {
+			collectSecretPlaceholders(value, placeholders)
+		}
+	}
+	keys := make([]string, 0, len(placeholders))
+	for placeholder := range placeholders {
+		keys = append(keys, placeholder)
+	}
+	sort.Strings(keys)
+
+	e.secrets = make(map[string]string, len(keys))
+	var errs []error
+	for _, placeholder := range keys {
+		source, fingerprint, _ := tags.ParseSecretPlaceholder(placeholder)
+		value, err := tags.ResolveSecret(source)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if fingerprint != "" && tags.SecretFingerprint(value) != fingerprint {
+			errs = append(errs, fmt.Errorf("secret %s changed since the plan was generated, generate a new plan",
+				source))
+			continue
+		}
+		e.secrets[placeholder] = value
+	}
+	return errors.Join(errs...)
+}
+
+func collectSecretPlaceholders(value any, placeholders map[string]bool) {
+	switch v := value.(type) {
+	case string:
+		if _, _, ok := tags.ParseSecretPlaceholder(v); ok {
+			placeholders[v] = true
+		}
+	case map[string]any:
+		for _, item := range v {
+			collectSecretPlaceholders(item, placeholders)
+		}
+	case []any:
+		for _, item := range v {
+			collectSecretPlaceholders(item, placeholders)
+		}
+	}
+}
+
+// withSecrets returns change with its !secret placeholders replaced by their values.
+// The change of the plan is left untouched, so results and reports never hold them.
+func (e *Executor) withSecrets(change *planner.PlannedChange) *planner.PlannedChange {
+	if len(e.secrets) == 0 {
+		return change
+	}
+	resolved := *change
+	resolved.Fields = make(map[string]any, len(change.Fields))
+	for name, value := range change.Fields {
+		resolved.Fields[name] = e.substituteSecrets(value)
+	}
+	return &resolved
+}
+
+func (e *Executor) substituteSecrets(value any) any {
+	switch v := value.(type) {
+	case string:
+		if secret, ok := e.secrets[v]; ok {
+			return secret
+		}
+		return v
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = e.substituteSecrets(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = e.substituteSecrets(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// abortExecution records an error that prevents the plan from running, leaving every
+// change not started
+func (e *Executor) abortExecution(result *ExecutionResult, plan *planner.Plan, err error) {
+	result.Errors = append(result.Errors, ExecutionError{Error: err.Error()})
+	result.FailureCount++
+	for _, changeID := range plan.ExecutionOrder {
+		result.ChangesNotStarted = append(result.ChangesNotStarted, notStartedChange(plan, changeID))
+	}
+}
+
+// withSecretRedactingLogger returns ctx with a logger that replaces the secret values
+// read for the plan, as the operations log the fields of the changes they send
+func (e *Executor) withSecretRedactingLogger(ctx context.Context) context.Context {
+	logger, ok := ctx.Value(log.LoggerKey).(*slog.Logger)
+	if !ok || logger == nil || len(e.secrets) == 0 {
+		return ctx
+	}
+	values := make([]string, 0, len(e.secrets))
+	for _, value := range e.secrets {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	handler := &secretRedactingHandler{Handler: logger.Handler(), secrets: values}
+	return context.WithValue(ctx, log.LoggerKey, slog.New(handler))
+}
+
+// secretRedactingHandler replaces secret values in the attributes of log records
+type secretRedactingHandler struct {
+	slog.Handler
+	secrets []string
+}
+
+func (h *secretRedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(attr))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h *secretRedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redactAttr(attr)
+	}
+	return &secretRedactingHandler{Handler: h.Handler.WithAttrs(redacted), secrets: h.secrets}
+}
+
+func (h *secretRedactingHandler) WithGroup(name string) slog.Handler {
+	return &secretRedactingHandler{Handler: h.Handler.WithGroup(name), secrets: h.secrets}
+}
+
+func (h *secretRedactingHandler) redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, h.redactString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, item := range group {
+			redacted[i] = h.redactAttr(item)
+		}
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindAny:
+		// Values such as changes and fields are logged in their JSON form once redacted
+		data, err := json.Marshal(value.Any())
+		if err != nil || !h.containsSecret(string(data)) {
+			return attr
+		}
+		var normalized any
+		if err := json.Unmarshal(data, &normalized); err != nil {
+			return slog.String(attr.Key, redact.SecretMarker)
+		}
+		return slog.Any(attr.Key, h.redactValue(normalized))
+	default:
+		return attr
+	}
+}
+
+func (h *secretRedactingHandler) redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return h.redactString(v)
+	case map[string]any:
+		for key, item := range v {
+			v[key] = h.redactValue(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = h.redactValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func (h *secretRedactingHandler) redactString(value string) string {
+	for _, secret := range h.secrets {
+		value = strings.ReplaceAll(value, secret, redact.SecretMarker)
+	}
+	return value
+}
+
+func (h *secretRedactingHandler) containsSecret(value string) bool {
+	for _, secret := range h.secrets {
+		encoded, _ := json.Marshal(secret)
+		if strings.Contains(value, strings.Trim(string(encoded), `"`)) {
+			return true
+		}
+	}
+	return false
+}
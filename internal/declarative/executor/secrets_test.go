@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_ResolvesSecretsBeforeExecuting(t *testing.T) {
+	secretPlan := func(placeholder string) *planner.Plan {
+		plan := apiCreatePlan("orders", "billing")
+		plan.Changes[1].Fields["name"] = placeholder
+		return plan
+	}
+
+	t.Run("secret values are sent, the plan keeps placeholders", func(t *testing.T) {
+		t.Setenv("KONGCTL_TEST_API_NAME", "payments")
+		apis := &concurrentAPI{}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis})
+		placeholder := tags.SecretPlaceholder("env://KONGCTL_TEST_API_NAME", tags.SecretFingerprint("payments"))
+		plan := secretPlan(placeholder)
+
+		result := NewWithOptions(client, nil, false, Options{Parallelism: 1}).Execute(timeoutTestContext(), plan)
+		require.Empty(t, result.Errors)
+		assert.Equal(t, []string{"start orders", "done orders", "start payments", "done payments"}, apis.events)
+		assert.Equal(t, placeholder, plan.Changes[1].Fields["name"])
+	})
+
+	t.Run("secret values are redacted from logs", func(t *testing.T) {
+		t.Setenv("KONGCTL_TEST_API_NAME", "payments-s3cr3t")
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		ctx := context.WithValue(context.Background(), log.LoggerKey, logger)
+		client := state.NewClient(state.ClientConfig{APIAPI: &concurrentAPI{}})
+
+		result := NewWithOptions(client, nil, false, Options{Parallelism: 1}).Execute(ctx,
+			secretPlan(tags.SecretPlaceholder("env://KONGCTL_TEST_API_NAME", tags.SecretFingerprint("payments-s3cr3t"))))
+		require.Empty(t, result.Errors)
+		assert.Contains(t, logs.String(), redact.SecretMarker)
+		assert.NotContains(t, logs.String(), "payments-s3cr3t")
+	})
+
+	t.Run("unreadable secrets abort before any change", func(t *testing.T) {
+		apis := &concurrentAPI{}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis})
+
+		result := New(client, nil, false).Execute(timeoutTestContext(),
+			secretPlan(tags.SecretPlaceholder("env://KONGCTL_TEST_UNSET", "")))
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0].Error, "environment variable KONGCTL_TEST_UNSET is not set")
+		assert.Empty(t, apis.events)
+		assert.Len(t, result.ChangesNotStarted, 2)
+	})
+
+	t.Run("secrets changed since the plan abort", func(t *testing.T) {
+		t.Setenv("KONGCTL_TEST_API_NAME", "rotated")
+		apis := &concurrentAPI{}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis})
+
+		result := New(client, nil, false).Execute(timeoutTestContext(),
+			secretPlan(tags.SecretPlaceholder("env://KONGCTL_TEST_API_NAME", tags.SecretFingerprint("payments"))))
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "secret env://KONGCTL_TEST_API_NAME changed since the plan was generated, generate a new plan",
+			result.Errors[0].Error)
+		assert.Empty(t, apis.events)
+	})
+}
+
+func TestExecutor_UntrustedRefusesHostAccess(t *testing.T) {
+	t.Setenv("KONGCTL_TEST_API_NAME", "payments")
+	tests := map[string]func(plan *planner.Plan){
+		"secret placeholder": func(plan *planner.Plan) {
+			plan.Changes[1].Fields["name"] = tags.SecretPlaceholder("env://KONGCTL_TEST_API_NAME", "")
+		},
+		"nested secret placeholder": func(plan *planner.Plan) {
+			plan.Changes[1].Fields["labels"] = map[string]any{"owner": tags.SecretPlaceholder("file:///etc/passwd", "")}
+		},
+		"verify command": func(plan *planner.Plan) {
+			plan.Changes[1].Fields["verify"] = []any{"sh", "-c", "id"}
+		},
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			apis := &concurrentAPI{}
+			client := state.NewClient(state.ClientConfig{APIAPI: apis})
+			plan := apiCreatePlan("orders", "billing")
+			modify(plan)
+
+			result := NewWithOptions(client, nil, false, Options{Untrusted: true}).Execute(timeoutTestContext(), plan)
+			require.Len(t, result.Errors, 1)
+			assert.Contains(t, result.Errors[0].Error, "not allowed in untrusted plans")
+			assert.Empty(t, apis.events, "nothing is changed")
+		})
+	}
+
+	t.Run("plans without host access run", func(t *testing.T) {
+		client := state.NewClient(state.ClientConfig{APIAPI: &concurrentAPI{}})
+		plan := apiCreatePlan("orders", "billing")
+		require.NoError(t, CheckUntrustedPlan(plan))
+
+		result := NewWithOptions(client, nil, false, Options{Untrusted: true}).Execute(timeoutTestContext(), plan)
+		assert.Empty(t, result.Errors)
+	})
+}
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// Timeouts bounds how long each change may take
+type Timeouts struct {
+	// Default applies to resource types without an override; zero means no deadline
+	// beyond the HTTP client's own
+	Default time.Duration
+	// ByResourceType overrides Default for plan resource types such as api_document
+	ByResourceType map[string]time.Duration
+}
+
+// For returns the timeout of changes to a resource type
+func (t Timeouts) For(resourceType string) time.Duration {
+	if timeout, ok := t.ByResourceType[resourceType]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// changeContext returns the context a change executes with, bounded by its timeout
+func (e *Executor) changeContext(ctx context.Context, change *planner.PlannedChange) (
+	context.Context, context.CancelFunc, time.Duration,
+) {
+	timeout := e.timeouts.For(change.ResourceType)
+	if timeout <= 0 {
+		return ctx, func() {}, 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// timeoutError explains a change that ran out of time rather than failed
+func timeoutError(ctx context.Context, change *planner.PlannedChange, timeout time.Duration, err error) error {
+	if err == nil || timeout <= 0 || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%s %s timed out after %s: %w",
+		strings.ToLower(string(change.Action)), change.ResourceType, timeout, err)
+}
@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadlineRecorder records the time left before the deadline of each call
+type deadlineRecorder struct {
+	mu        sync.Mutex
+	remaining map[string]time.Duration
+}
+
+func (d *deadlineRecorder) record(ctx context.Context, call string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.remaining == nil {
+		d.remaining = make(map[string]time.Duration)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		d.remaining[call] = -1
+		return
+	}
+	d.remaining[call] = time.Until(deadline)
+}
+
+// slowSpecAPI uploads specs slower than the default timeout
+type slowSpecAPI struct {
+	deadlineRecorder
+	uploadTime time.Duration
+}
+
+func (s *slowSpecAPI) CreateAPIVersion(
+	ctx context.Context, _ string, _ kkComps.CreateAPIVersionRequest, _ ...kkOps.Option,
+) (*kkOps.CreateAPIVersionResponse, error) {
+	s.record(ctx, "CreateAPIVersion")
+	select {
+	case <-time.After(s.uploadTime):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &kkOps.CreateAPIVersionResponse{
+		StatusCode:         201,
+		APIVersionResponse: &kkComps.APIVersionResponse{ID: "version-1"},
+	}, nil
+}
+
+func (s *slowSpecAPI) ListAPIVersions(
+	context.Context, kkOps.ListAPIVersionsRequest, ...kkOps.Option,
+) (*kkOps.ListAPIVersionsResponse, error) {
+	return &kkOps.ListAPIVersionsResponse{}, nil
+}
+
+func (s *slowSpecAPI) UpdateAPIVersion(
+	context.Context, kkOps.UpdateAPIVersionRequest, ...kkOps.Option,
+) (*kkOps.UpdateAPIVersionResponse, error) {
+	return &kkOps.UpdateAPIVersionResponse{}, nil
+}
+
+func (s *slowSpecAPI) DeleteAPIVersion(
+	context.Context, string, string, ...kkOps.Option,
+) (*kkOps.DeleteAPIVersionResponse, error) {
+	return &kkOps.DeleteAPIVersionResponse{}, nil
+}
+
+func (s *slowSpecAPI) FetchAPIVersion(
+	context.Context, string, string, ...kkOps.Option,
+) (*kkOps.FetchAPIVersionResponse, error) {
+	return &kkOps.FetchAPIVersionResponse{}, nil
+}
+
+// labelAPI serves one API and records label updates, optionally hanging
+type labelAPI struct {
+	deadlineRecorder
+	hang bool
+}
+
+func (l *labelAPI) api() *kkComps.APIResponseSchema {
+	return &kkComps.APIResponseSchema{
+		ID:     "api-1",
+		Name:   "orders",
+		Labels: map[string]string{"KONGCTL-namespace": "default"},
+	}
+}
+
+func (l *labelAPI) ListApis(context.Context, kkOps.ListApisRequest, ...kkOps.Option) (*kkOps.ListApisResponse, error) {
+	return &kkOps.ListApisResponse{
+		StatusCode: 200,
+		ListAPIResponse: &kkComps.ListAPIResponse{
+			Data: []kkComps.APIResponseSchema{*l.api()},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil
+}
+
+func (l *labelAPI) FetchAPI(context.Context, string, ...kkOps.Option) (*kkOps.FetchAPIResponse, error) {
+	return &kkOps.FetchAPIResponse{StatusCode: 200, APIResponseSchema: l.api()}, nil
+}
+
+func (l *labelAPI) CreateAPI(
+	context.Context, kkComps.CreateAPIRequest, ...kkOps.Option,
+) (*kkOps.CreateAPIResponse, error) {
+	return &kkOps.CreateAPIResponse{}, nil
+}
+
+func (l *labelAPI) UpdateAPI(
+	ctx context.Context, _ string, request kkComps.UpdateAPIRequest, _ ...kkOps.Option,
+) (*kkOps.UpdateAPIResponse, error) {
+	// Spec hashes stamped by the version upload are not the label update under test
+	if _, ok := request.Labels[labels.SpecHashKey("version-1")]; ok {
+		return &kkOps.UpdateAPIResponse{StatusCode: 200, APIResponseSchema: l.api()}, nil
+	}
+	l.record(ctx, "UpdateAPI")
+	if l.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &kkOps.UpdateAPIResponse{StatusCode: 200, APIResponseSchema: l.api()}, nil
+}
+
+func (l *labelAPI) DeleteAPI(context.Context, string, ...kkOps.Option) (*kkOps.DeleteAPIResponse, error) {
+	return &kkOps.DeleteAPIResponse{}, nil
+}
+
+func timeoutTestPlan() *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID:           "1:u:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		ResourceID:   "api-1",
+		Action:       planner.ActionUpdate,
+		Namespace:    "default",
+		Fields:       map[string]any{"name": "orders", "labels": map[string]any{"team": "payments"}},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID:           "2:c:api_version:orders-v1",
+		ResourceType: "api_version",
+		ResourceRef:  "orders-v1",
+		Action:       planner.ActionCreate,
+		Namespace:    "default",
+		Fields: map[string]any{
+			"version": "1.0.0",
+			"spec":    map[string]any{"content": `{"openapi":"3.0.0"}`},
+		},
+		References: map[string]planner.ReferenceInfo{"api_id": {Ref: "orders", ID: "api-1"}},
+	})
+	plan.SetExecutionOrder([]string{"1:u:api:orders", "2:c:api_version:orders-v1"})
+	return plan
+}
+
+func timeoutTestContext() context.Context {
+	return context.WithValue(context.Background(), log.LoggerKey, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestExecutor_ResourceTimeouts(t *testing.T) {
+	timeouts := Timeouts{
+		Default:        100 * time.Millisecond,
+		ByResourceType: map[string]time.Duration{"api_version": 5 * time.Second},
+	}
+
+	t.Run("spec upload uses its override and label update the default", func(t *testing.T) {
+		apis := &labelAPI{}
+		// The upload takes longer than the default timeout
+		versions := &slowSpecAPI{uploadTime: 300 * time.Millisecond}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: versions})
+
+		result := NewWithOptions(client, nil, false, Options{Timeouts: timeouts}).
+			Execute(timeoutTestContext(), timeoutTestPlan())
+		require.Empty(t, result.Errors)
+		assert.Equal(t, 2, result.SuccessCount)
+
+		upload := versions.remaining["CreateAPIVersion"]
+		assert.Greater(t, upload, 4*time.Second)
+		assert.LessOrEqual(t, upload, 5*time.Second)
+
+		update := apis.remaining["UpdateAPI"]
+		assert.Greater(t, update, time.Duration(0))
+		assert.LessOrEqual(t, update, 100*time.Millisecond)
+	})
+
+	t.Run("label update fails fast at the default", func(t *testing.T) {
+		apis := &labelAPI{hang: true}
+		versions := &slowSpecAPI{}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: versions})
+
+		start := time.Now()
+		result := NewWithOptions(client, nil, false, Options{Timeouts: timeouts}).
+			Execute(timeoutTestContext(), timeoutTestPlan())
+		assert.Less(t, time.Since(start), 2*time.Second)
+
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "api", result.Errors[0].ResourceType)
+		assert.Contains(t, result.Errors[0].Error, "update api timed out after 100ms")
+		assert.Equal(t, 1, result.SuccessCount, "the spec upload still runs")
+	})
+
+	t.Run("no timeouts leaves changes without a deadline", func(t *testing.T) {
+		apis := &labelAPI{}
+		versions := &slowSpecAPI{}
+		client := state.NewClient(state.ClientConfig{APIAPI: apis, APIVersionAPI: versions})
+
+		result := New(client, nil, false).Execute(timeoutTestContext(), timeoutTestPlan())
+		require.Empty(t, result.Errors)
+		assert.Equal(t, time.Duration(-1), apis.remaining["UpdateAPI"])
+		assert.Equal(t, time.Duration(-1), versions.remaining["CreateAPIVersion"])
+	})
+}
+
+func TestTimeoutsFor(t *testing.T) {
+	timeouts := Timeouts{Default: time.Minute, ByResourceType: map[string]time.Duration{"api_document": time.Hour}}
+	assert.Equal(t, time.Hour, timeouts.For("api_document"))
+	assert.Equal(t, time.Minute, timeouts.For("portal"))
+	assert.Zero(t, Timeouts{}.For("portal"))
+}
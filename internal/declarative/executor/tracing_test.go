@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func spansNamed(spans tracetest.SpanStubs, name string) []tracetest.SpanStub {
+	var matched []tracetest.SpanStub
+	for _, span := range spans {
+		if span.Name == name {
+			matched = append(matched, span)
+		}
+	}
+	return matched
+}
+
+func TestTracing_PlanAndApplySpans(t *testing.T) {
+	registerMemoryWidgets(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracing.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		tracing.SetTracerProvider(nil)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+_defaults:
+  kongctl:
+    namespace: team-a
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    spec:
+      size: 3
+  - ref: widget-b
+    kind: acme_widget
+    spec:
+      size: 1
+      parent_id: !ref widget-a#id
+`), 0o600))
+
+	ctx := context.Background()
+	sources := []loader.Source{{Path: path, Type: loader.SourceTypeFile}}
+	rs, err := loader.New().LoadFromSourcesWithContext(ctx, sources, false)
+	require.NoError(t, err)
+
+	p := planner.NewPlanner(state.NewClient(state.ClientConfig{}), slog.Default())
+	plan, err := p.GeneratePlan(ctx, rs, planner.Options{Mode: planner.PlanModeApply})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+
+	result := New(state.NewClient(state.ClientConfig{}), nil, false).Execute(ctx, plan)
+	require.Empty(t, result.Errors)
+
+	spans := exporter.GetSpans()
+
+	load := spansNamed(spans, tracing.SpanLoad)
+	require.Len(t, load, 1)
+	assert.Equal(t, int64(1), spanAttributes(load[0])[tracing.AttrSourceCount].AsInt64())
+	assert.Equal(t, int64(2), spanAttributes(load[0])[tracing.AttrResourceCount].AsInt64())
+
+	planSpans := spansNamed(spans, tracing.SpanPlan)
+	require.Len(t, planSpans, 1)
+	assert.Equal(t, string(planner.PlanModeApply), spanAttributes(planSpans[0])[tracing.AttrMode].AsString())
+
+	resolveOps := map[string]bool{}
+	for _, span := range spansNamed(spans, tracing.SpanResolve) {
+		resolveOps[spanAttributes(span)[tracing.AttrOperation].AsString()] = true
+		assert.Equal(t, planSpans[0].SpanContext.SpanID(), span.Parent.SpanID())
+	}
+	assert.True(t, resolveOps["identities"], "identity resolution span")
+	assert.True(t, resolveOps["references"], "reference resolution span")
+
+	var fetchedWidgets bool
+	for _, span := range spansNamed(spans, tracing.SpanFetch) {
+		attrs := spanAttributes(span)
+		if attrs[tracing.AttrResourceType].AsString() == customWidgetKind &&
+			attrs[tracing.AttrNamespace].AsString() == "team-a" {
+			fetchedWidgets = true
+		}
+	}
+	assert.True(t, fetchedWidgets, "fetch span for %s in team-a", customWidgetKind)
+
+	apply := spansNamed(spans, tracing.SpanApply)
+	require.Len(t, apply, 1)
+	assert.False(t, spanAttributes(apply[0])[tracing.AttrDryRun].AsBool())
+
+	execute := spansNamed(spans, tracing.SpanExecute)
+	require.Len(t, execute, 2)
+	refs := map[string]bool{}
+	for _, span := range execute {
+		attrs := spanAttributes(span)
+		assert.Equal(t, customWidgetKind, attrs[tracing.AttrResourceType].AsString())
+		assert.Equal(t, string(planner.ActionCreate), attrs[tracing.AttrOperation].AsString())
+		assert.NotEmpty(t, attrs[tracing.AttrChangeID].AsString())
+		assert.Equal(t, apply[0].SpanContext.SpanID(), span.Parent.SpanID())
+		refs[attrs[tracing.AttrResourceRef].AsString()] = true
+	}
+	assert.Equal(t, map[string]bool{"widget-a": true, "widget-b": true}, refs)
+}
+
+func TestTracing_DisabledRecordsNothing(t *testing.T) {
+	registerMemoryWidgets(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracing.SetTracerProvider(provider)
+	tracing.SetTracerProvider(nil)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	applyCustomPlan(t, planCustomConfig(t, `
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    spec:
+      size: 3
+`, planner.PlanModeApply))
+
+	assert.Empty(t, exporter.GetSpans())
+}
@@ -1,7 +1,10 @@
 package executor
 
 import (
+	"time"
+
 	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
 )
 
 // ExecutionResult represents the outcome of executing a plan
@@ -22,6 +25,26 @@ type ExecutionResult struct {
 
 	// Validation results for dry-run mode
 	ValidationResults []ValidationResult `json:"validation_results,omitempty"`
+
+	// Changes that never started because an earlier change failed or execution was canceled
+	ChangesNotStarted []NotStartedChange `json:"changes_not_started,omitempty"`
+
+	// Indicates that execution was canceled, e.g. by an interrupt, before it finished
+	Canceled bool `json:"canceled,omitempty"`
+
+	// How the applied changes were reversed after a failure, with rollback on error
+	Rollback *RollbackResult `json:"rollback,omitempty"`
+
+	// Requests an executed dry run would have sent to change Konnect, in order
+	IntendedRequests []httpclient.RecordedRequest `json:"intended_requests,omitempty"`
+
+	// Deck commands an executed dry run would have run
+	IntendedDeckCommands [][]string `json:"intended_deck_commands,omitempty"`
+
+	// Outcome and timing of every change that ran, in completion order, see BuildReport
+	Operations []OperationRecord `json:"-"`
+	StartedAt  time.Time         `json:"-"`
+	FinishedAt time.Time         `json:"-"`
 }
 
 // ExecutionError represents an error that occurred during execution
@@ -32,6 +55,8 @@ type ExecutionError struct {
 	ResourceRef  string `json:"resource_ref"`
 	Action       string `json:"action"`
 	Error        string `json:"error"`
+	// RequestID is the ID Konnect gave the failed request, when its response had one
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // AppliedChange represents a successfully applied change
@@ -44,6 +69,15 @@ type AppliedChange struct {
 	ResourceID   string `json:"resource_id,omitempty"` // ID of created/updated resource
 }
 
+// NotStartedChange represents a change left out after a failure stopped execution
+type NotStartedChange struct {
+	ChangeID     string `json:"change_id"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	ResourceRef  string `json:"resource_ref"`
+	Action       string `json:"action"`
+}
+
 // ValidationResult represents the validation outcome for a change in dry-run mode
 type ValidationResult struct {
 	ChangeID     string `json:"change_id"`
@@ -74,6 +108,13 @@ type ProgressReporter interface {
 	FinishExecution(result *ExecutionResult)
 }
 
+// RetryReporter is implemented by progress reporters that count the Konnect
+// requests retried while a change runs
+type RetryReporter interface {
+	// RetryChange is called before a request of a running change is retried
+	RetryChange(change planner.PlannedChange)
+}
+
 // Message returns a user-friendly summary of the execution result
 func (r *ExecutionResult) Message() string {
 	if r.DryRun {
@@ -98,3 +139,21 @@ func (r *ExecutionResult) HasErrors() bool {
 func (r *ExecutionResult) TotalChanges() int {
 	return r.SuccessCount + r.FailureCount + r.SkippedCount
 }
+
+// ChangesInEffect returns the applied changes that were not undone by a rollback
+func (r *ExecutionResult) ChangesInEffect() []AppliedChange {
+	if r.Rollback == nil || len(r.Rollback.Undone) == 0 {
+		return r.ChangesApplied
+	}
+	undone := make(map[string]bool, len(r.Rollback.Undone))
+	for _, change := range r.Rollback.Undone {
+		undone[change.ChangeID] = true
+	}
+	changes := make([]AppliedChange, 0, len(r.ChangesApplied))
+	for _, change := range r.ChangesApplied {
+		if !undone[change.ChangeID] {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,39 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+// checkUntrusted refuses the plan of an untrusted executor, see CheckUntrustedPlan
+func (e *Executor) checkUntrusted(plan *planner.Plan) error {
+	if !e.untrusted {
+		return nil
+	}
+	return CheckUntrustedPlan(plan)
+}
+
+// CheckUntrustedPlan rejects a plan that would run commands or read secrets on the
+// host applying it: verify commands run them, custom resource handlers run code of
+// this binary against the submitted fields, and secret placeholders are resolved from
+// the environment and files of the host
+func CheckUntrustedPlan(plan *planner.Plan) error {
+	for _, change := range plan.Changes {
+		if _, ok := change.Fields["verify"]; ok {
+			return fmt.Errorf("change %s: verify commands are not allowed in untrusted plans", change.ID)
+		}
+		if _, ok := custom.Lookup(change.ResourceType); ok {
+			return fmt.Errorf("change %s: custom resources are not allowed in untrusted plans", change.ID)
+		}
+		placeholders := make(map[string]bool)
+		for _, value := range change.Fields {
+			collectSecretPlaceholders(value, placeholders)
+		}
+		if len(placeholders) > 0 {
+			return fmt.Errorf("change %s: secret placeholders are not allowed in untrusted plans", change.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+// Package gitscope narrows declarative runs to the configuration files that
+// changed relative to a git revision.
+//
+// A Scope includes the resources defined in changed files plus everything they
+// depend on (parents, !ref targets and reference fields). Deletes are limited to
+// resources that were declared in a changed file at the revision, so removing a
+// resource from a changed file deletes it while resources that are missing for
+// any other reason are left alone.
+package gitscope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to read files containing custom tags
+)
+
+// identifierKeys are the YAML keys whose values identify a resource in Konnect
+var identifierKeys = map[string]bool{
+	"ref":     true,
+	"name":    true,
+	"slug":    true,
+	"version": true,
+}
+
+// Scope describes the resources a run is restricted to
+type Scope struct {
+	// Revision is the git revision changes are computed against
+	Revision string
+	// Files lists the changed configuration files, relative to the repository root
+	Files []string
+	// Refs holds the refs defined in changed files and their dependencies
+	Refs map[string]bool
+	// Namespaces lists namespaces declared in changed files at Revision
+	Namespaces []string
+
+	// removed holds identifiers of resources declared in changed files at Revision
+	removed map[string]bool
+}
+
+// New computes the scope of the configuration files in sources that changed
+// since revision. Uncommitted and untracked files count as changed.
+// refSources maps each ref in rs to the file it was loaded from.
+func New(
+	ctx context.Context,
+	revision string,
+	sources []loader.Source,
+	rs *resources.ResourceSet,
+	refSources map[string]string,
+) (*Scope, error) {
+	repo, err := openRepository(ctx, gitDir(sources))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.git(ctx, "rev-parse", "--verify", "--quiet", revision+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("unknown git revision %q", revision)
+	}
+
+	changes, err := repo.changedFiles(ctx, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := sourceRoots(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &Scope{
+		Revision: revision,
+		removed:  make(map[string]bool),
+	}
+	changedPaths := make(map[string]bool)
+	namespaces := make(map[string]bool)
+
+	for _, change := range changes {
+		path := filepath.Join(repo.root, filepath.FromSlash(change.path))
+		if !loader.ValidateConfigFile(path) || !withinRoots(path, roots) {
+			continue
+		}
+		scope.Files = append(scope.Files, change.path)
+		changedPaths[path] = true
+
+		if change.added {
+			continue
+		}
+		previous, err := repo.git(ctx, "show", revision+":"+change.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", change.path, revision, err)
+		}
+		if err := collectIdentifiers(previous, scope.removed, namespaces); err != nil {
+			return nil, fmt.Errorf("failed to parse %s at %s: %w", change.path, revision, err)
+		}
+	}
+	slices.Sort(scope.Files)
+
+	for ns := range namespaces {
+		scope.Namespaces = append(scope.Namespaces, ns)
+	}
+	slices.Sort(scope.Namespaces)
+
+	var pending []string
+	for ref, source := range refSources {
+		path, err := canonicalPath(source)
+		if err != nil {
+			continue
+		}
+		if changedPaths[path] {
+			pending = append(pending, ref)
+		}
+	}
+
+	// Expand the changed resources with everything they depend on
+	scope.Refs = ExpandDependencies(rs, pending)
+
+	return scope, nil
+}
+
+// Includes reports whether a planned change falls inside the scope
+func (s *Scope) Includes(change planner.PlannedChange) bool {
+	if s.Refs[change.ResourceRef] {
+		return true
+	}
+	if change.Action != planner.ActionDelete {
+		return false
+	}
+	if s.removed[change.ResourceRef] {
+		return true
+	}
+	name, _ := change.Fields["name"].(string)
+	return name != "" && s.removed[name]
+}
+
+// ExpandDependencies returns refs plus the refs of everything they depend on,
+// transitively: parents, !ref targets and reference fields
+func ExpandDependencies(rs *resources.ResourceSet, refs []string) map[string]bool {
+	expanded := make(map[string]bool)
+	pending := slices.Clone(refs)
+	for len(pending) > 0 {
+		ref := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if expanded[ref] {
+			continue
+		}
+		expanded[ref] = true
+
+		resource, ok := rs.GetResourceByRef(ref)
+		if !ok {
+			continue
+		}
+		for _, dep := range dependencies(resource, rs) {
+			if !expanded[dep] {
+				pending = append(pending, dep)
+			}
+		}
+	}
+	return expanded
+}
+
+// dependencies returns the refs a resource needs in order to be planned
+func dependencies(resource resources.Resource, rs *resources.ResourceSet) []string {
+	var refs []string
+	for _, dep := range resource.GetDependencies() {
+		refs = append(refs, dep.Ref)
+	}
+	if child, ok := resource.(resources.ResourceWithParent); ok {
+		if parent := child.GetParentRef(); parent != nil && parent.Ref != "" {
+			refs = append(refs, parent.Ref)
+		}
+	}
+
+	referenceKeys := make(map[string]bool)
+	if mapping, ok := resource.(resources.ReferenceMapping); ok {
+		for path := range mapping.GetReferenceFieldMappings() {
+			key := path[strings.LastIndex(path, ".")+1:]
+			referenceKeys[strings.TrimSuffix(key, "[]")] = true
+		}
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return refs
+	}
+	var fields any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return refs
+	}
+	walkStrings(fields, "", func(key, value string) {
+		if ref, _, ok := tags.ParseRefPlaceholder(value); ok {
+			refs = append(refs, ref)
+		} else if referenceKeys[key] && rs.HasRef(value) {
+			refs = append(refs, value)
+		}
+	})
+	return refs
+}
+
+// walkStrings calls fn with every string value in v and the key holding it
+func walkStrings(v any, key string, fn func(key, value string)) {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, nested := range value {
+			walkStrings(nested, k, fn)
+		}
+	case []any:
+		for _, nested := range value {
+			walkStrings(nested, key, fn)
+		}
+	case string:
+		fn(key, value)
+	}
+}
+
+// collectIdentifiers records identifier and namespace values found in a YAML document
+func collectIdentifiers(content []byte, identifiers, namespaces map[string]bool) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return err
+	}
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if value.Kind == yaml.ScalarNode && value.Tag == "!!str" && value.Value != "" {
+					switch {
+					case identifierKeys[key.Value]:
+						identifiers[value.Value] = true
+					case key.Value == "namespace":
+						namespaces[value.Value] = true
+					}
+				}
+				walk(value)
+			}
+		case yaml.ScalarNode, yaml.AliasNode:
+		}
+	}
+	walk(&doc)
+	return nil
+}
+
+// gitDir picks the directory git commands run in
+func gitDir(sources []loader.Source) string {
+	for _, source := range sources {
+		switch source.Type {
+		case loader.SourceTypeFile:
+			return filepath.Dir(source.Path)
+		case loader.SourceTypeDirectory:
+			return source.Path
+		case loader.SourceTypeSTDIN:
+		}
+	}
+	return "."
+}
+
+// sourceRoots returns the canonical paths of file and directory sources
+func sourceRoots(sources []loader.Source) ([]string, error) {
+	var roots []string
+	for _, source := range sources {
+		if source.Type == loader.SourceTypeSTDIN {
+			continue
+		}
+		root, err := canonicalPath(source.Path)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// withinRoots reports whether path is one of roots or inside one of them
+func withinRoots(path string, roots []string) bool {
+	for _, root := range roots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalPath returns an absolute path with symlinks resolved where possible
+func canonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+	// Deleted files no longer exist; resolve their directory instead
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		return filepath.Join(dir, filepath.Base(abs)), nil
+	}
+	return abs, nil
+}
+
+// fileChange is a file that differs from the revision
+type fileChange struct {
+	// path is relative to the repository root, using forward slashes
+	path string
+	// added is true when the file did not exist at the revision
+	added bool
+}
+
+type repository struct {
+	root string
+}
+
+func openRepository(ctx context.Context, dir string) (*repository, error) {
+	repo := &repository{root: dir}
+	out, err := repo.git(ctx, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not inside a git repository: %w", dir, err)
+	}
+	root, err := canonicalPath(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, err
+	}
+	repo.root = root
+	return repo, nil
+}
+
+// changedFiles lists files whose working tree content differs from revision
+func (r *repository) changedFiles(ctx context.Context, revision string) ([]fileChange, error) {
+	out, err := r.git(ctx, "diff", "--name-status", "--no-renames", "-z", revision, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", revision, err)
+	}
+	var changes []fileChange
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		changes = append(changes, fileChange{path: fields[i+1], added: fields[i] == "A"})
+	}
+
+	out, err = r.git(ctx, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+	for path := range strings.SplitSeq(strings.TrimSuffix(string(out), "\x00"), "\x00") {
+		if path != "" {
+			changes = append(changes, fileChange{path: path, added: true})
+		}
+	}
+	return changes, nil
+}
+
+func (r *repository) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", r.root}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,271 @@
+package gitscope
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const widgetKind = "acme_widget"
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+// newFixture commits three configuration files and then edits the working tree:
+// b.yaml changes, c.yaml is untouched and d.yaml is new and untracked.
+func newFixture(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	writeFile(t, dir, "README.md", "widgets\n")
+	writeFile(t, dir, "a.yaml", `
+custom_resources:
+  - ref: widget-a
+    kind: acme_widget
+    spec:
+      size: 1
+`)
+	writeFile(t, dir, "b.yaml", `
+custom_resources:
+  - ref: widget-b
+    kind: acme_widget
+    spec:
+      size: 1
+  - ref: widget-old
+    kind: acme_widget
+    spec:
+      size: 1
+`)
+	writeFile(t, dir, "c.yaml", `
+custom_resources:
+  - ref: widget-c
+    kind: acme_widget
+    spec:
+      size: 1
+`)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "initial")
+
+	writeFile(t, dir, "README.md", "widgets, updated\n")
+	writeFile(t, dir, "b.yaml", `
+custom_resources:
+  - ref: widget-b
+    kind: acme_widget
+    spec:
+      size: 2
+  - ref: widget-new
+    kind: acme_widget
+    spec:
+      size: 1
+      parent_id: !ref widget-a#id
+`)
+	writeFile(t, dir, "d.yaml", `
+custom_resources:
+  - ref: widget-d
+    kind: acme_widget
+    spec:
+      size: 1
+`)
+	return dir
+}
+
+// registerWidgets registers an in-memory handler holding the widgets applied
+// from the committed configuration, plus drift and a stray managed widget
+func registerWidgets(t *testing.T) {
+	t.Helper()
+	handler := custom.NewMemoryHandler(widgetKind)
+	require.NoError(t, custom.Register(handler))
+	t.Cleanup(func() { custom.Unregister(widgetKind) })
+
+	sizes := map[string]float64{
+		"widget-a":     9, // drifted, in scope as a dependency of widget-new
+		"widget-b":     1,
+		"widget-old":   1,
+		"widget-c":     9, // drifted, out of scope
+		"widget-stray": 1, // managed but never declared in a changed file
+	}
+	for name, size := range sizes {
+		_, err := handler.Create(context.Background(), custom.Request{
+			Kind:   widgetKind,
+			Name:   name,
+			Labels: labels.BuildCreateLabels(nil, "default", nil),
+			Fields: map[string]any{"size": size},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func planFixture(t *testing.T, dir string, mode planner.PlanMode, revision string) (*planner.Plan, *Scope) {
+	t.Helper()
+	ctx := context.Background()
+	sources := []loader.Source{{Path: dir, Type: loader.SourceTypeDirectory}}
+
+	ldr := loader.New()
+	rs, err := ldr.LoadFromSourcesWithContext(ctx, sources, false)
+	require.NoError(t, err)
+
+	opts := planner.Options{Mode: mode}
+	var scope *Scope
+	if revision != "" {
+		scope, err = New(ctx, revision, sources, rs, ldr.RefSources())
+		require.NoError(t, err)
+		opts.IncludeChange = scope.Includes
+	}
+
+	p := planner.NewPlanner(state.NewClient(state.ClientConfig{}), slog.Default())
+	plan, err := p.GeneratePlan(ctx, rs, opts)
+	require.NoError(t, err)
+	return plan, scope
+}
+
+func planActions(plan *planner.Plan) map[string]planner.ActionType {
+	actions := make(map[string]planner.ActionType, len(plan.Changes))
+	for _, change := range plan.Changes {
+		actions[change.ResourceRef] = change.Action
+	}
+	return actions
+}
+
+func TestNew_ChangedFilesAndDependencies(t *testing.T) {
+	dir := newFixture(t)
+	registerWidgets(t)
+
+	_, scope := planFixture(t, dir, planner.PlanModeApply, "HEAD")
+
+	assert.Equal(t, []string{"b.yaml", "d.yaml"}, scope.Files, "README.md is not configuration")
+	assert.Equal(t, map[string]bool{
+		"widget-b":   true,
+		"widget-new": true,
+		"widget-a":   true, // !ref dependency of widget-new
+		"widget-d":   true,
+	}, scope.Refs)
+}
+
+func TestScope_Apply(t *testing.T) {
+	dir := newFixture(t)
+	registerWidgets(t)
+
+	plan, _ := planFixture(t, dir, planner.PlanModeApply, "HEAD")
+	assert.Equal(t, map[string]planner.ActionType{
+		"widget-a":   planner.ActionUpdate,
+		"widget-b":   planner.ActionUpdate,
+		"widget-new": planner.ActionCreate,
+		"widget-d":   planner.ActionCreate,
+	}, planActions(plan))
+	assert.Len(t, plan.ExecutionOrder, 4)
+	assert.Equal(t, 4, plan.Summary.TotalChanges)
+}
+
+func TestScope_SyncRestrictsDeletes(t *testing.T) {
+	dir := newFixture(t)
+	writeFile(t, dir, "c.yaml", `
+_defaults:
+  kongctl:
+    namespace: team-c
+custom_resources:
+  - ref: widget-c
+    kind: acme_widget
+    spec:
+      size: 1
+`)
+	runGit(t, dir, "add", "c.yaml")
+	runGit(t, dir, "commit", "--quiet", "-m", "move widget-c")
+	require.NoError(t, os.Remove(filepath.Join(dir, "c.yaml")))
+
+	sources := []loader.Source{{Path: dir, Type: loader.SourceTypeDirectory}}
+	ldr := loader.New()
+	rs, err := ldr.LoadFromSourcesWithContext(context.Background(), sources, false)
+	require.NoError(t, err)
+	scope, err := New(context.Background(), "HEAD", sources, rs, ldr.RefSources())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b.yaml", "c.yaml", "d.yaml"}, scope.Files, "deleted files are changes too")
+	assert.Equal(t, []string{"team-c"}, scope.Namespaces)
+
+	deleteOf := func(name string) planner.PlannedChange {
+		return planner.PlannedChange{
+			Action:       planner.ActionDelete,
+			ResourceType: widgetKind,
+			ResourceRef:  name,
+			Fields:       map[string]any{"name": name},
+		}
+	}
+	assert.True(t, scope.Includes(deleteOf("widget-old")), "removed from the changed b.yaml")
+	assert.True(t, scope.Includes(deleteOf("widget-c")), "declared in the deleted c.yaml")
+	assert.False(t, scope.Includes(deleteOf("widget-stray")), "never declared in a changed file")
+	assert.False(t, scope.Includes(planner.PlannedChange{
+		Action:      planner.ActionUpdate,
+		ResourceRef: "widget-old",
+	}), "only deletes match removed resources")
+}
+
+func TestScope_NoChanges(t *testing.T) {
+	dir := newFixture(t)
+	registerWidgets(t)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "update")
+
+	plan, scope := planFixture(t, dir, planner.PlanModeApply, "HEAD")
+	assert.Empty(t, scope.Files)
+	assert.True(t, plan.IsEmpty(), "unexpected changes: %+v", plan.Changes)
+}
+
+func TestNew_Errors(t *testing.T) {
+	dir := newFixture(t)
+	sources := []loader.Source{{Path: dir, Type: loader.SourceTypeDirectory}}
+
+	ldr := loader.New()
+	rs, err := ldr.LoadFromSourcesWithContext(context.Background(), sources, false)
+	require.NoError(t, err)
+
+	_, err = New(context.Background(), "no-such-branch", sources, rs, ldr.RefSources())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown git revision "no-such-branch"`)
+
+	outside := []loader.Source{{Path: t.TempDir(), Type: loader.SourceTypeDirectory}}
+	_, err = New(context.Background(), "HEAD", outside, rs, ldr.RefSources())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not inside a git repository")
+}
+
+func TestExpandDependencies(t *testing.T) {
+	dir := newFixture(t)
+	ldr := loader.New()
+	rs, err := ldr.LoadFromSourcesWithContext(context.Background(),
+		[]loader.Source{{Path: dir, Type: loader.SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{"widget-new": true, "widget-a": true},
+		ExpandDependencies(rs, []string{"widget-new"}))
+	assert.Equal(t, map[string]bool{"widget-c": true, "widget-d": true},
+		ExpandDependencies(rs, []string{"widget-c", "widget-d"}))
+}
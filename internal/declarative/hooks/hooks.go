@@ -0,0 +1,246 @@
+// Package hooks notifies webhooks, such as Slack, and local commands of the outcome
+// of an apply or sync.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatJSON posts the summary of the execution as JSON
+	FormatJSON = "json"
+	// FormatSlack posts a message for a Slack incoming webhook
+	FormatSlack = "slack"
+
+	// DefaultTimeout bounds a hook without a timeout
+	DefaultTimeout = 10 * time.Second
+
+	// maxResponseBody bounds the response body quoted in errors
+	maxResponseBody = 512
+)
+
+// Config lists the hooks run after an apply or sync
+type Config struct {
+	// OnSuccess hooks run when every change was applied
+	OnSuccess []Hook `json:"on_success,omitempty" yaml:"on_success,omitempty"`
+	// OnFailure hooks run when a change failed or the execution was canceled
+	OnFailure []Hook `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`
+}
+
+// Hook posts the summary of an execution to a URL or runs a command with it. Exactly
+// one of URL and Command is set.
+type Hook struct {
+	// URL receives the summary as the body of a POST request. Environment variables
+	// are expanded, so secret webhook URLs can stay out of the configuration.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Format is the body posted to URL: json, the default, or slack
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Headers are added to the request, with environment variables expanded
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Command is run by the shell with the summary as JSON on its standard input
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Timeout bounds the hook, as a duration such as 30s. It defaults to DefaultTimeout.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// IsEmpty reports whether no hook is configured
+func (c *Config) IsEmpty() bool {
+	return c == nil || len(c.OnSuccess) == 0 && len(c.OnFailure) == 0
+}
+
+// Merge appends the hooks of other, which run after those of c
+func (c *Config) Merge(other *Config) {
+	if other == nil {
+		return
+	}
+	c.OnSuccess = append(c.OnSuccess, other.OnSuccess...)
+	c.OnFailure = append(c.OnFailure, other.OnFailure...)
+}
+
+// Validate checks every hook, reporting all problems at once
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for i, hook := range c.OnSuccess {
+		if err := hook.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("on_success[%d]: %w", i, err))
+		}
+	}
+	for i, hook := range c.OnFailure {
+		if err := hook.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("on_failure[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h Hook) validate() error {
+	switch {
+	case h.URL == "" && h.Command == "":
+		return fmt.Errorf("a hook needs a url or a command")
+	case h.URL != "" && h.Command != "":
+		return fmt.Errorf("a hook has either a url or a command, not both")
+	case h.Command != "" && (h.Format != "" || len(h.Headers) > 0):
+		return fmt.Errorf("format and headers only apply to url hooks")
+	}
+	switch h.Format {
+	case "", FormatJSON, FormatSlack:
+	default:
+		return fmt.Errorf("unsupported format %q (use %s or %s)", h.Format, FormatJSON, FormatSlack)
+	}
+	if h.Timeout != "" {
+		if timeout, err := time.ParseDuration(h.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout %q: use a positive duration such as 30s", h.Timeout)
+		}
+	}
+	return nil
+}
+
+// Event tells whether an execution succeeded
+type Event string
+
+const (
+	// EventSuccess is the event of executions that applied every change
+	EventSuccess Event = "success"
+	// EventFailure is the event of executions with failed changes or canceled
+	EventFailure Event = "failure"
+)
+
+// Notification is what the hooks of an event receive about an execution
+type Notification struct {
+	Event   Event
+	Command string
+	// Summary is posted as JSON to url hooks and written to the standard input of
+	// command hooks
+	Summary any
+	// Text is the message posted by slack hooks
+	Text string
+}
+
+// Run runs the hooks of the event of a notification, in order. A failing hook does
+// not stop the others; the error of each is returned.
+func (c *Config) Run(ctx context.Context, notification *Notification) []error {
+	if c == nil {
+		return nil
+	}
+	hooks := c.OnSuccess
+	if notification.Event == EventFailure {
+		hooks = c.OnFailure
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook.run(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (h Hook) run(ctx context.Context, notification *Notification) error {
+	timeout := DefaultTimeout
+	if h.Timeout != "" {
+		if parsed, err := time.ParseDuration(h.Timeout); err == nil && parsed > 0 {
+			timeout = parsed
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if h.Command != "" {
+		return h.runCommand(ctx, notification)
+	}
+	return h.post(ctx, notification)
+}
+
+func (h Hook) post(ctx context.Context, notification *Notification) error {
+	target := os.ExpandEnv(h.URL)
+	body := notification.Summary
+	if h.Format == FormatSlack {
+		body = map[string]string{"text": notification.Text}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("hook %s: failed to encode the summary: %w", redactURL(target), err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("hook %s: %w", redactURL(target), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range h.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The error quotes the URL, which may hold a secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("hook %s: %w", redactURL(target), err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+		return fmt.Errorf("hook %s: request failed with status %d: %s", redactURL(target), res.StatusCode,
+			strings.TrimSpace(string(message)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	return nil
+}
+
+func (h Hook) runCommand(ctx context.Context, notification *Notification) error {
+	data, err := json.Marshal(notification.Summary)
+	if err != nil {
+		return fmt.Errorf("hook %q: failed to encode the summary: %w", h.Command, err)
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", h.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", h.Command)
+	}
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"KONGCTL_HOOK_EVENT="+string(notification.Event),
+		"KONGCTL_HOOK_COMMAND="+notification.Command,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("hook %q: %w: %s", h.Command, err, message)
+		}
+		return fmt.Errorf("hook %q: %w", h.Command, err)
+	}
+	return nil
+}
+
+// redactURL returns the scheme and host of a URL, leaving out the path and query
+// that hold the secret of webhook URLs, such as Slack's
+func redactURL(target string) string {
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Host == "" {
+		return "url"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
@@ -0,0 +1,112 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := &Config{
+		OnSuccess: []Hook{{URL: "https://example.com/hook", Format: FormatSlack, Timeout: "5s"}},
+		OnFailure: []Hook{{Command: "notify"}},
+	}
+	require.NoError(t, valid.Validate())
+
+	invalid := &Config{
+		OnSuccess: []Hook{{}, {URL: "https://example.com", Command: "notify"}},
+		OnFailure: []Hook{{URL: "https://example.com", Format: "xml"}, {Command: "notify", Timeout: "soon"}},
+	}
+	err := invalid.Validate()
+	require.ErrorContains(t, err, "on_success[0]: a hook needs a url or a command")
+	require.ErrorContains(t, err, "on_success[1]: a hook has either a url or a command, not both")
+	require.ErrorContains(t, err, `on_failure[0]: unsupported format "xml"`)
+	require.ErrorContains(t, err, `on_failure[1]: invalid timeout "soon"`)
+}
+
+func TestConfig_RunURL(t *testing.T) {
+	var bodies []map[string]any
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if r.URL.Path == "/broken/secret" {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, "upstream down")
+		}
+	}))
+	defer server.Close()
+	t.Setenv("HOOK_TOKEN", "Bearer s3cr3t")
+
+	config := &Config{
+		OnSuccess: []Hook{{URL: server.URL + "/success"}},
+		OnFailure: []Hook{
+			{URL: server.URL + "/failure", Headers: map[string]string{"Authorization": "$HOOK_TOKEN"}},
+			{URL: server.URL + "/slack", Format: FormatSlack},
+			{URL: server.URL + "/broken/secret"},
+		},
+	}
+	notification := &Notification{
+		Event:   EventFailure,
+		Command: "apply",
+		Summary: map[string]any{"event": "failure", "command": "apply"},
+		Text:    "kongctl apply failed",
+	}
+
+	errs := config.Run(context.Background(), notification)
+
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], "hook "+server.URL+": request failed with status 502: upstream down",
+		"the path of the URL is left out")
+	require.Len(t, bodies, 3, "only the failure hooks run")
+	require.Equal(t, map[string]any{"event": "failure", "command": "apply"}, bodies[0])
+	require.Equal(t, "Bearer s3cr3t", tokens[0])
+	require.Equal(t, map[string]any{"text": "kongctl apply failed"}, bodies[1])
+}
+
+func TestConfig_RunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands run with sh in this test")
+	}
+	out := filepath.Join(t.TempDir(), "summary.json")
+	config := &Config{
+		OnSuccess: []Hook{
+			{Command: `cat > "` + out + `" && echo "$KONGCTL_HOOK_EVENT $KONGCTL_HOOK_COMMAND" >> "` + out + `"`},
+			{Command: "echo nope >&2; exit 3"},
+		},
+	}
+
+	errs := config.Run(context.Background(), &Notification{
+		Event:   EventSuccess,
+		Command: "sync",
+		Summary: map[string]any{"event": "success"},
+	})
+
+	require.Len(t, errs, 1)
+	require.EqualError(t, errs[0], `hook "echo nope >&2; exit 3": exit status 3: nope`)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"event":"success"`)
+	require.Contains(t, string(data), "success sync\n")
+}
+
+func TestConfig_Merge(t *testing.T) {
+	config := &Config{OnSuccess: []Hook{{Command: "first"}}}
+	config.Merge(&Config{OnSuccess: []Hook{{Command: "second"}}, OnFailure: []Hook{{Command: "failed"}}})
+	config.Merge(nil)
+
+	require.Equal(t, []Hook{{Command: "first"}, {Command: "second"}}, config.OnSuccess)
+	require.Equal(t, []Hook{{Command: "failed"}}, config.OnFailure)
+	require.False(t, config.IsEmpty())
+	require.True(t, (*Config)(nil).IsEmpty())
+}
@@ -0,0 +1,393 @@
+// Package impact previews what deleting resources affects: the resources removed
+// along with them and the resources that still reference them.
+package impact
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// Relationship describes how a dependent is affected by deleting its target
+type Relationship string
+
+const (
+	// Cascade dependents are deleted together with the target
+	Cascade Relationship = "cascade"
+	// Blocking dependents still reference the target, so the delete fails or
+	// leaves them pointing at a missing resource
+	Blocking Relationship = "blocking"
+)
+
+// Source records where a relationship was found
+type Source string
+
+const (
+	SourceConfig  Source = "config"
+	SourceKonnect Source = "konnect"
+)
+
+// Dependent is a resource affected by deleting a target
+type Dependent struct {
+	ResourceType string       `json:"resource_type"          yaml:"resource_type"`
+	ResourceRef  string       `json:"resource_ref,omitempty" yaml:"resource_ref,omitempty"`
+	ResourceID   string       `json:"resource_id,omitempty"  yaml:"resource_id,omitempty"`
+	Relationship Relationship `json:"relationship"           yaml:"relationship"`
+	Reason       string       `json:"reason"                 yaml:"reason"`
+	Source       Source       `json:"source"                 yaml:"source"`
+}
+
+// Target is a resource the plan deletes and the resources affected by it
+type Target struct {
+	ResourceType string      `json:"resource_type"         yaml:"resource_type"`
+	ResourceRef  string      `json:"resource_ref"          yaml:"resource_ref"`
+	ResourceID   string      `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+	Dependents   []Dependent `json:"dependents"            yaml:"dependents"`
+}
+
+// Preview lists the targets of a delete plan with their dependents
+type Preview struct {
+	Targets []Target `json:"targets" yaml:"targets"`
+}
+
+// PublicationLister lists the live API publications related to a resource
+type PublicationLister interface {
+	ListPublicationsByPortal(ctx context.Context, portalID string) ([]state.APIPublication, error)
+	ListPublicationsByAuthStrategy(ctx context.Context, strategyID string) ([]state.APIPublication, error)
+}
+
+var _ PublicationLister = (*state.Client)(nil)
+
+// Build previews the DELETE changes of plan. Relationships are read from the
+// configuration in rs, which may be nil when previewing a plan artifact, and from
+// the live publications in Konnect, which Konnect removes with a portal and which
+// keep an auth strategy in use. When only is set, only that target is previewed and
+// the other resources of the configuration are treated as kept.
+func Build(
+	ctx context.Context,
+	plan *planner.Plan,
+	rs *resources.ResourceSet,
+	live PublicationLister,
+	only *resources.ResourceRef,
+) (*Preview, error) {
+	preview := &Preview{Targets: []Target{}}
+	deleted := make(map[resources.ResourceRef]bool)
+	for _, change := range plan.Changes {
+		ref := resources.ResourceRef{Kind: change.ResourceType, Ref: change.ResourceRef}
+		if change.Action == planner.ActionDelete && (only == nil || *only == ref) {
+			deleted[ref] = true
+		}
+	}
+
+	graph := newConfigGraph(rs)
+	for _, change := range plan.Changes {
+		if change.Action != planner.ActionDelete {
+			continue
+		}
+		ref := resources.ResourceRef{Kind: change.ResourceType, Ref: change.ResourceRef}
+		if only != nil && *only != ref {
+			continue
+		}
+
+		target := Target{
+			ResourceType: change.ResourceType,
+			ResourceRef:  change.ResourceRef,
+			ResourceID:   change.ResourceID,
+			Dependents:   graph.dependents(ref, deleted),
+		}
+		liveDependents, err := liveDependents(ctx, live, change)
+		if err != nil {
+			return nil, err
+		}
+		target.Dependents = append(target.Dependents, liveDependents...)
+		preview.Targets = append(preview.Targets, target)
+	}
+
+	if only != nil && len(preview.Targets) == 0 {
+		return nil, fmt.Errorf("the delete plan does not delete %s %q", only.Kind, only.Ref)
+	}
+	return preview, nil
+}
+
+// Blocked reports whether any target has a blocking dependent
+func (p *Preview) Blocked() bool {
+	for _, target := range p.Targets {
+		for _, dependent := range target.Dependents {
+			if dependent.Relationship == Blocking {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WriteText prints each target with its cascade and blocking dependents
+func (p *Preview) WriteText(out io.Writer) {
+	if len(p.Targets) == 0 {
+		fmt.Fprintln(out, "No matching resources found to delete.")
+		return
+	}
+
+	fmt.Fprintln(out, "Delete preview:")
+	for _, target := range p.Targets {
+		fmt.Fprintln(out)
+		if target.ResourceID != "" {
+			fmt.Fprintf(out, "%s %q (id %s)\n", target.ResourceType, target.ResourceRef, target.ResourceID)
+		} else {
+			fmt.Fprintf(out, "%s %q\n", target.ResourceType, target.ResourceRef)
+		}
+		for _, relationship := range []Relationship{Cascade, Blocking} {
+			fmt.Fprintf(out, "  %s:\n", relationship)
+			count := 0
+			for _, dependent := range target.Dependents {
+				if dependent.Relationship != relationship {
+					continue
+				}
+				count++
+				name := fmt.Sprintf("%q", dependent.ResourceRef)
+				if dependent.ResourceRef == "" {
+					name = dependent.ResourceID
+				}
+				fmt.Fprintf(out, "    - %s %s: %s (%s)\n", dependent.ResourceType, name, dependent.Reason, dependent.Source)
+			}
+			if count == 0 {
+				fmt.Fprintln(out, "    none")
+			}
+		}
+	}
+}
+
+// configNode is a resource of the configuration with its parent
+type configNode struct {
+	resource resources.Resource
+	parent   *resources.ResourceRef
+}
+
+// configGraph holds the parent and reference relationships of the configuration
+type configGraph struct {
+	nodes []configNode
+	byRef map[resources.ResourceRef]configNode
+}
+
+func newConfigGraph(rs *resources.ResourceSet) *configGraph {
+	graph := &configGraph{byRef: make(map[resources.ResourceRef]configNode)}
+	if rs == nil {
+		return graph
+	}
+
+	add := func(resource resources.Resource) {
+		node := configNode{resource: resource, parent: parentOf(resource)}
+		graph.nodes = append(graph.nodes, node)
+		graph.byRef[resources.ResourceRef{Kind: string(resource.GetType()), Ref: resource.GetRef()}] = node
+	}
+	for _, resourceType := range resources.RegisteredTypes() {
+		for _, resource := range rs.AllResourcesByType(resourceType) {
+			add(resource)
+		}
+	}
+	// Documents nested under an API stay on the API once the configuration is loaded
+	for i := range rs.APIs {
+		for j := range rs.APIs[i].Documents {
+			add(&rs.APIs[i].Documents[j])
+		}
+	}
+
+	// Registry iteration is unordered; keep the output stable
+	slices.SortFunc(graph.nodes, func(a, b configNode) int {
+		if a.resource.GetType() != b.resource.GetType() {
+			return cmp.Compare(string(a.resource.GetType()), string(b.resource.GetType()))
+		}
+		return cmp.Compare(a.resource.GetRef(), b.resource.GetRef())
+	})
+	return graph
+}
+
+// dependents returns the configured resources affected by deleting target. Resources
+// that are themselves deleted by the plan are not blocking.
+func (g *configGraph) dependents(target resources.ResourceRef, deleted map[resources.ResourceRef]bool) []Dependent {
+	var dependents []Dependent
+	cascaded := make(map[resources.ResourceRef]bool)
+	for _, node := range g.nodes {
+		ref := resources.ResourceRef{Kind: string(node.resource.GetType()), Ref: node.resource.GetRef()}
+		if ref == target {
+			continue
+		}
+		if g.descendsFrom(node, target) {
+			cascaded[ref] = true
+			dependents = append(dependents, Dependent{
+				ResourceType: ref.Kind,
+				ResourceRef:  ref.Ref,
+				Relationship: Cascade,
+				Reason:       fmt.Sprintf("child of %s %q, deleted with it", target.Kind, target.Ref),
+				Source:       SourceConfig,
+			})
+		}
+	}
+
+	for _, node := range g.nodes {
+		ref := resources.ResourceRef{Kind: string(node.resource.GetType()), Ref: node.resource.GetRef()}
+		if cascaded[ref] || g.deletedWith(node, deleted) {
+			continue
+		}
+		if reason := referenceReason(node.resource, target); reason != "" {
+			dependents = append(dependents, Dependent{
+				ResourceType: ref.Kind,
+				ResourceRef:  ref.Ref,
+				Relationship: Blocking,
+				Reason:       reason,
+				Source:       SourceConfig,
+			})
+		}
+	}
+	return dependents
+}
+
+// descendsFrom reports whether the parent chain of node reaches target
+func (g *configGraph) descendsFrom(node configNode, target resources.ResourceRef) bool {
+	seen := make(map[resources.ResourceRef]bool)
+	for parent := node.parent; parent != nil && !seen[*parent]; {
+		if *parent == target {
+			return true
+		}
+		seen[*parent] = true
+		next, ok := g.byRef[*parent]
+		if !ok {
+			return false
+		}
+		parent = next.parent
+	}
+	return false
+}
+
+// deletedWith reports whether node, or one of its parents, is deleted by the plan
+func (g *configGraph) deletedWith(node configNode, deleted map[resources.ResourceRef]bool) bool {
+	if deleted[resources.ResourceRef{Kind: string(node.resource.GetType()), Ref: node.resource.GetRef()}] {
+		return true
+	}
+	for ref := range deleted {
+		if g.descendsFrom(node, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceReason describes how resource references target outside of its parent
+// chain, or returns "" when it does not
+func referenceReason(resource resources.Resource, target resources.ResourceRef) string {
+	switch r := resource.(type) {
+	case *resources.PortalResource:
+		if target.Kind == string(resources.ResourceTypeApplicationAuthStrategy) &&
+			r.DefaultApplicationAuthStrategyID != nil && *r.DefaultApplicationAuthStrategyID == target.Ref {
+			return "uses it as the default application auth strategy"
+		}
+	case *resources.APIPublicationResource:
+		if target.Kind == string(resources.ResourceTypePortal) && r.PortalID == target.Ref {
+			return "publishes its API to this portal"
+		}
+		if target.Kind == string(resources.ResourceTypeApplicationAuthStrategy) &&
+			slices.Contains(r.AuthStrategyIds, target.Ref) {
+			return "uses it for application registrations"
+		}
+	case *resources.APIImplementationResource:
+		if target.Kind == string(resources.ResourceTypeControlPlane) &&
+			r.ServiceReference != nil && r.ServiceReference.Service != nil &&
+			r.ServiceReference.Service.ControlPlaneID == target.Ref {
+			return "implements its API with a service of this control plane"
+		}
+	}
+	return ""
+}
+
+// liveDependents returns the live publications affected by a DELETE change
+func liveDependents(ctx context.Context, live PublicationLister, change planner.PlannedChange) ([]Dependent, error) {
+	if live == nil || change.ResourceID == "" {
+		return nil, nil
+	}
+
+	var dependents []Dependent
+	switch resources.ResourceType(change.ResourceType) {
+	case resources.ResourceTypePortal:
+		publications, err := live.ListPublicationsByPortal(ctx, change.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, publication := range publications {
+			dependents = append(dependents, Dependent{
+				ResourceType: string(resources.ResourceTypeAPIPublication),
+				ResourceID:   publication.APIID,
+				Relationship: Cascade,
+				Reason:       fmt.Sprintf("API %s is published to this portal; the publication is removed", publication.APIID),
+				Source:       SourceKonnect,
+			})
+		}
+	case resources.ResourceTypeApplicationAuthStrategy:
+		publications, err := live.ListPublicationsByAuthStrategy(ctx, change.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, publication := range publications {
+			dependents = append(dependents, Dependent{
+				ResourceType: string(resources.ResourceTypeAPIPublication),
+				ResourceID:   publication.APIID,
+				Relationship: Blocking,
+				Reason: fmt.Sprintf("publication of API %s to portal %s uses this auth strategy",
+					publication.APIID, publication.PortalID),
+				Source: SourceKonnect,
+			})
+		}
+	}
+	return dependents, nil
+}
+
+// parentFields name the parent of resources that do not implement ResourceWithParent,
+// most specific first
+var parentFields = []struct {
+	field string
+	kind  resources.ResourceType
+}{
+	{"ParentPageRef", resources.ResourceTypePortalPage},
+	{"ParentDocumentRef", resources.ResourceTypeAPIDocument},
+	{"Portal", resources.ResourceTypePortal},
+	{"API", resources.ResourceTypeAPI},
+	{"ControlPlane", resources.ResourceTypeControlPlane},
+}
+
+// parentOf returns the parent of a configured resource, if any
+func parentOf(resource resources.Resource) *resources.ResourceRef {
+	value := reflect.ValueOf(resource)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		// Nested parents take precedence over the top-level parent reported below
+		for _, candidate := range parentFields[:2] {
+			if field := value.FieldByName(candidate.field); field.IsValid() && field.Kind() == reflect.String &&
+				field.String() != "" {
+				return &resources.ResourceRef{Kind: string(candidate.kind), Ref: field.String()}
+			}
+		}
+	}
+	if withParent, ok := resource.(resources.ResourceWithParent); ok {
+		if parent := withParent.GetParentRef(); parent != nil && parent.Ref != "" {
+			return parent
+		}
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, candidate := range parentFields[2:] {
+		if field := value.FieldByName(candidate.field); field.IsValid() && field.Kind() == reflect.String &&
+			field.String() != "" {
+			return &resources.ResourceRef{Kind: string(candidate.kind), Ref: field.String()}
+		}
+	}
+	return nil
+}
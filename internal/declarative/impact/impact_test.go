@@ -0,0 +1,180 @@
+package impact
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPublications struct {
+	byPortal   map[string][]state.APIPublication
+	byStrategy map[string][]state.APIPublication
+}
+
+func (s stubPublications) ListPublicationsByPortal(_ context.Context, id string) ([]state.APIPublication, error) {
+	return s.byPortal[id], nil
+}
+
+func (s stubPublications) ListPublicationsByAuthStrategy(
+	_ context.Context, id string,
+) ([]state.APIPublication, error) {
+	return s.byStrategy[id], nil
+}
+
+func deleteChange(resourceType resources.ResourceType, ref, id string) planner.PlannedChange {
+	return planner.PlannedChange{
+		Action:       planner.ActionDelete,
+		ResourceType: string(resourceType),
+		ResourceRef:  ref,
+		ResourceID:   id,
+	}
+}
+
+func previewConfig() *resources.ResourceSet {
+	keyAuth := "key-auth"
+	return &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{BaseResource: resources.BaseResource{Ref: "dev"}},
+			{BaseResource: resources.BaseResource{Ref: "partner"}, CreatePortal: kkComps.CreatePortal{
+				DefaultApplicationAuthStrategyID: &keyAuth,
+			}},
+		},
+		PortalPages: []resources.PortalPageResource{
+			{Ref: "guides", Portal: "dev"},
+			{Ref: "guides-intro", Portal: "dev", ParentPageRef: "guides"},
+			{Ref: "partner-home", Portal: "partner"},
+		},
+		APIs: []resources.APIResource{
+			{BaseResource: resources.BaseResource{Ref: "orders"}},
+		},
+		APIPublications: []resources.APIPublicationResource{
+			{Ref: "orders-dev", API: "orders", PortalID: "dev"},
+		},
+		ApplicationAuthStrategies: []resources.ApplicationAuthStrategyResource{
+			{BaseResource: resources.BaseResource{Ref: "key-auth"}},
+		},
+	}
+}
+
+func TestBuild_PortalWithActivePublications(t *testing.T) {
+	plan := &planner.Plan{Changes: []planner.PlannedChange{
+		deleteChange(resources.ResourceTypePortal, "dev", "portal-1"),
+		deleteChange(resources.ResourceTypeAPI, "orders", "api-1"),
+	}}
+	live := stubPublications{byPortal: map[string][]state.APIPublication{
+		"portal-1": {{APIID: "api-1", PortalID: "portal-1"}, {APIID: "api-2", PortalID: "portal-1"}},
+	}}
+
+	preview, err := Build(context.Background(), plan, previewConfig(), live,
+		&resources.ResourceRef{Kind: "portal", Ref: "dev"})
+	require.NoError(t, err)
+	require.Len(t, preview.Targets, 1)
+
+	target := preview.Targets[0]
+	assert.Equal(t, "portal-1", target.ResourceID)
+	assert.Equal(t, []Dependent{
+		{
+			ResourceType: "portal_page", ResourceRef: "guides", Relationship: Cascade,
+			Reason: `child of portal "dev", deleted with it`, Source: SourceConfig,
+		},
+		{
+			ResourceType: "portal_page", ResourceRef: "guides-intro", Relationship: Cascade,
+			Reason: `child of portal "dev", deleted with it`, Source: SourceConfig,
+		},
+		{
+			ResourceType: "api_publication", ResourceRef: "orders-dev", Relationship: Blocking,
+			Reason: "publishes its API to this portal", Source: SourceConfig,
+		},
+		{
+			ResourceType: "api_publication", ResourceID: "api-1", Relationship: Cascade,
+			Reason: "API api-1 is published to this portal; the publication is removed", Source: SourceKonnect,
+		},
+		{
+			ResourceType: "api_publication", ResourceID: "api-2", Relationship: Cascade,
+			Reason: "API api-2 is published to this portal; the publication is removed", Source: SourceKonnect,
+		},
+	}, target.Dependents)
+	assert.True(t, preview.Blocked())
+}
+
+func TestBuild_WholePlanIsNotBlockedByItself(t *testing.T) {
+	plan := &planner.Plan{Changes: []planner.PlannedChange{
+		deleteChange(resources.ResourceTypePortal, "dev", ""),
+		deleteChange(resources.ResourceTypeAPI, "orders", ""),
+		deleteChange(resources.ResourceTypePortal, "partner", ""),
+		deleteChange(resources.ResourceTypeApplicationAuthStrategy, "key-auth", ""),
+	}}
+
+	preview, err := Build(context.Background(), plan, previewConfig(), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, preview.Targets, 4)
+	assert.False(t, preview.Blocked(), "references from resources deleted by the same plan do not block")
+}
+
+func TestBuild_AuthStrategyInUse(t *testing.T) {
+	plan := &planner.Plan{Changes: []planner.PlannedChange{
+		deleteChange(resources.ResourceTypeApplicationAuthStrategy, "key-auth", "strategy-1"),
+	}}
+	live := stubPublications{byStrategy: map[string][]state.APIPublication{
+		"strategy-1": {{APIID: "api-1", PortalID: "portal-2"}},
+	}}
+
+	preview, err := Build(context.Background(), plan, previewConfig(), live, nil)
+	require.NoError(t, err)
+	require.Len(t, preview.Targets, 1)
+	assert.Equal(t, []Dependent{
+		{
+			ResourceType: "portal", ResourceRef: "partner", Relationship: Blocking,
+			Reason: "uses it as the default application auth strategy", Source: SourceConfig,
+		},
+		{
+			ResourceType: "api_publication", ResourceID: "api-1", Relationship: Blocking,
+			Reason: "publication of API api-1 to portal portal-2 uses this auth strategy", Source: SourceKonnect,
+		},
+	}, preview.Targets[0].Dependents)
+}
+
+func TestBuild_OnlyTargetNotInPlan(t *testing.T) {
+	plan := &planner.Plan{Changes: []planner.PlannedChange{
+		deleteChange(resources.ResourceTypePortal, "dev", ""),
+	}}
+
+	_, err := Build(context.Background(), plan, nil, nil, &resources.ResourceRef{Kind: "portal", Ref: "missing"})
+	require.EqualError(t, err, `the delete plan does not delete portal "missing"`)
+}
+
+func TestPreview_WriteText(t *testing.T) {
+	preview := &Preview{Targets: []Target{{
+		ResourceType: "portal",
+		ResourceRef:  "dev",
+		ResourceID:   "portal-1",
+		Dependents: []Dependent{
+			{
+				ResourceType: "api_publication", ResourceID: "api-1", Relationship: Cascade,
+				Reason: "API api-1 is published to this portal; the publication is removed", Source: SourceKonnect,
+			},
+		},
+	}}}
+
+	var out bytes.Buffer
+	preview.WriteText(&out)
+	assert.Equal(t, `Delete preview:
+
+portal "dev" (id portal-1)
+  cascade:
+    - api_publication api-1: API api-1 is published to this portal; the publication is removed (konnect)
+  blocking:
+    none
+`, out.String())
+
+	out.Reset()
+	(&Preview{}).WriteText(&out)
+	assert.Equal(t, "No matching resources found to delete.\n", out.String())
+}
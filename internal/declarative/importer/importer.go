@@ -0,0 +1,262 @@
+// Package importer brings resources created outside kongctl under management of
+// the declarative configuration, without recreating them.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+const (
+	// ResourceTypeAPI is the resource type of APIs
+	ResourceTypeAPI = "api"
+	// ResourceTypeAPIPublication is the resource type of API publications
+	ResourceTypeAPIPublication = "api_publication"
+)
+
+// ResourceTypes lists the resource types that can be imported
+var ResourceTypes = []string{planner.ResourceTypePortal, ResourceTypeAPI, ResourceTypeAPIPublication}
+
+// Request identifies the resource to import and the ref it has in the configuration.
+// Portals and APIs are found by Name; publications by the names of their API and portal.
+type Request struct {
+	ResourceType string
+	Ref          string
+	Name         string
+	Namespace    string
+	API          string
+	Portal       string
+}
+
+// Result describes an imported resource
+type Result struct {
+	ResourceType string `json:"resource_type"  yaml:"resource_type"`
+	Ref          string `json:"ref"            yaml:"ref"`
+	ID           string `json:"id"             yaml:"id"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace    string `json:"namespace"      yaml:"namespace"`
+	// AlreadyManaged is set when the resource already had the namespace label
+	AlreadyManaged bool `json:"already_managed" yaml:"already_managed"`
+}
+
+// Importer labels existing Konnect resources with kongctl's namespace label and records
+// them in the last applied store of a profile
+type Importer struct {
+	client  *state.Client
+	store   *drift.Store
+	profile string
+	now     func() time.Time
+}
+
+// New creates an importer recording imported resources in store under profile
+func New(client *state.Client, store *drift.Store, profile string) *Importer {
+	return &Importer{client: client, store: store, profile: profile, now: time.Now}
+}
+
+// Import finds the resource of req, labels it with the namespace unless it already
+// has it, and records it. It fails when no resource or several resources match.
+func (i *Importer) Import(ctx context.Context, req Request) (*Result, error) {
+	if strings.TrimSpace(req.Ref) == "" {
+		return nil, fmt.Errorf("a ref is required to import a %s", req.ResourceType)
+	}
+	if req.Namespace == "" {
+		req.Namespace = planner.DefaultNamespace
+	}
+
+	var (
+		result *Result
+		err    error
+	)
+	switch req.ResourceType {
+	case planner.ResourceTypePortal:
+		result, err = i.importPortal(ctx, req)
+	case ResourceTypeAPI:
+		result, err = i.importAPI(ctx, req)
+	case ResourceTypeAPIPublication:
+		result, err = i.importAPIPublication(ctx, req)
+	default:
+		return nil, fmt.Errorf("unsupported resource type %q (supported: %s)",
+			req.ResourceType, strings.Join(ResourceTypes, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.store.RecordImport(i.profile, result.ResourceType, result.Ref, result.ID, i.now().UTC()); err != nil {
+		return nil, fmt.Errorf("%s %q was labeled but could not be recorded: %w", result.ResourceType, result.Name, err)
+	}
+	return result, nil
+}
+
+func (i *Importer) importPortal(ctx context.Context, req Request) (*Result, error) {
+	portal, err := i.findPortal(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		ResourceType: planner.ResourceTypePortal,
+		Ref:          req.Ref,
+		ID:           portal.ID,
+		Name:         portal.Name,
+		Namespace:    req.Namespace,
+	}
+	managed, err := checkNamespace(result, portal.NormalizedLabels)
+	if err != nil || managed {
+		return result, err
+	}
+
+	update := kkComps.UpdatePortal{
+		Labels: labels.BuildUpdateLabels(portal.NormalizedLabels, portal.NormalizedLabels, req.Namespace, nil),
+	}
+	if _, err := i.client.UpdatePortal(ctx, portal.ID, update, req.Namespace); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (i *Importer) importAPI(ctx context.Context, req Request) (*Result, error) {
+	api, err := i.findAPI(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		ResourceType: ResourceTypeAPI,
+		Ref:          req.Ref,
+		ID:           api.ID,
+		Name:         api.Name,
+		Namespace:    req.Namespace,
+	}
+	managed, err := checkNamespace(result, api.NormalizedLabels)
+	if err != nil || managed {
+		return result, err
+	}
+
+	update := kkComps.UpdateAPIRequest{
+		Labels: labels.BuildUpdateLabels(api.NormalizedLabels, api.NormalizedLabels, req.Namespace, nil),
+	}
+	if _, err := i.client.UpdateAPI(ctx, api.ID, update, req.Namespace); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importAPIPublication records an existing publication. Publications have no labels;
+// they are managed through their API, which must be imported first.
+func (i *Importer) importAPIPublication(ctx context.Context, req Request) (*Result, error) {
+	if req.API == "" || req.Portal == "" {
+		return nil, fmt.Errorf("both the API and the portal are required to import an api_publication")
+	}
+
+	api, err := i.findAPI(ctx, req.API)
+	if err != nil {
+		return nil, err
+	}
+	namespace := api.NormalizedLabels[labels.NamespaceKey]
+	if namespace == "" {
+		return nil, fmt.Errorf("api %q is not managed by kongctl, import it before its publications", api.Name)
+	}
+	portal, err := i.findPortal(ctx, req.Portal)
+	if err != nil {
+		return nil, err
+	}
+
+	publications, err := i.client.ListAPIPublications(ctx, api.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, publication := range publications {
+		if publication.PortalID == portal.ID {
+			return &Result{
+				ResourceType:   ResourceTypeAPIPublication,
+				Ref:            req.Ref,
+				ID:             fmt.Sprintf("%s:%s", api.ID, portal.ID),
+				Name:           fmt.Sprintf("%s/%s", api.Name, portal.Name),
+				Namespace:      namespace,
+				AlreadyManaged: true,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("api %q is not published to portal %q", api.Name, portal.Name)
+}
+
+func (i *Importer) findPortal(ctx context.Context, name string) (*state.Portal, error) {
+	portals, err := i.client.ListAllPortals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []state.Portal
+	for _, portal := range portals {
+		if portal.Name == name {
+			matches = append(matches, portal)
+		}
+	}
+	ids := make([]string, 0, len(matches))
+	for _, portal := range matches {
+		ids = append(ids, portal.ID)
+	}
+	if err := checkMatches(planner.ResourceTypePortal, name, ids); err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+func (i *Importer) findAPI(ctx context.Context, name string) (*state.API, error) {
+	apis, err := i.client.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []state.API
+	for _, api := range apis {
+		if api.Name == name {
+			matches = append(matches, api)
+		}
+	}
+	ids := make([]string, 0, len(matches))
+	for _, api := range matches {
+		ids = append(ids, api.ID)
+	}
+	if err := checkMatches(ResourceTypeAPI, name, ids); err != nil {
+		return nil, err
+	}
+	return &matches[0], nil
+}
+
+// checkMatches fails unless exactly one resource, identified by ids, has the name
+func checkMatches(resourceType, name string, ids []string) error {
+	switch len(ids) {
+	case 0:
+		return fmt.Errorf("no %s named %q found in Konnect", resourceType, name)
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("%d %s resources are named %q (%s); rename all but one before importing",
+			len(ids), resourceType, name, strings.Join(ids, ", "))
+	}
+}
+
+// checkNamespace reports whether the resource already has the namespace label of the
+// result, and fails when it is managed in another namespace
+func checkNamespace(result *Result, current map[string]string) (bool, error) {
+	switch namespace := current[labels.NamespaceKey]; namespace {
+	case "":
+		return false, nil
+	case result.Namespace:
+		result.AlreadyManaged = true
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s %q is already managed by kongctl in namespace %q",
+			result.ResourceType, result.Name, namespace)
+	}
+}
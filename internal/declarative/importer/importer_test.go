@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPortalAPI struct {
+	helpers.PortalAPI
+	portals []kkComps.ListPortalsResponsePortal
+	updates map[string]kkComps.UpdatePortal
+}
+
+func (s *stubPortalAPI) ListPortals(
+	_ context.Context,
+	_ kkOps.ListPortalsRequest,
+) (*kkOps.ListPortalsResponse, error) {
+	return &kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: s.portals,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(s.portals))}},
+		},
+	}, nil
+}
+
+func (s *stubPortalAPI) UpdatePortal(
+	_ context.Context,
+	id string,
+	portal kkComps.UpdatePortal,
+) (*kkOps.UpdatePortalResponse, error) {
+	if s.updates == nil {
+		s.updates = make(map[string]kkComps.UpdatePortal)
+	}
+	s.updates[id] = portal
+	return &kkOps.UpdatePortalResponse{PortalResponse: &kkComps.PortalResponse{ID: id}}, nil
+}
+
+type stubAPIAPI struct {
+	helpers.APIAPI
+	apis []kkComps.APIResponseSchema
+}
+
+func (s *stubAPIAPI) ListApis(
+	_ context.Context,
+	_ kkOps.ListApisRequest,
+	_ ...kkOps.Option,
+) (*kkOps.ListApisResponse, error) {
+	return &kkOps.ListApisResponse{
+		ListAPIResponse: &kkComps.ListAPIResponse{
+			Data: s.apis,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(s.apis))}},
+		},
+	}, nil
+}
+
+type stubAPIPublicationAPI struct {
+	helpers.APIPublicationAPI
+	publications []kkComps.APIPublicationListItem
+}
+
+func (s *stubAPIPublicationAPI) ListAPIPublications(
+	_ context.Context,
+	_ kkOps.ListAPIPublicationsRequest,
+	_ ...kkOps.Option,
+) (*kkOps.ListAPIPublicationsResponse, error) {
+	return &kkOps.ListAPIPublicationsResponse{
+		ListAPIPublicationResponse: &kkComps.ListAPIPublicationResponse{
+			Data: s.publications,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(s.publications))}},
+		},
+	}, nil
+}
+
+func newTestImporter(t *testing.T, portalAPI *stubPortalAPI, apiAPI *stubAPIAPI) (*Importer, *drift.Store) {
+	t.Helper()
+	client := state.NewClient(state.ClientConfig{
+		PortalAPI: portalAPI,
+		APIAPI:    apiAPI,
+		APIPublicationAPI: &stubAPIPublicationAPI{publications: []kkComps.APIPublicationListItem{
+			{APIID: "api-1", PortalID: "portal-1"},
+		}},
+	})
+	store := drift.NewStore(filepath.Join(t.TempDir(), "last-applied.json"))
+	importer := New(client, store, "default")
+	importer.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return importer, store
+}
+
+func TestImportPortal(t *testing.T) {
+	portalAPI := &stubPortalAPI{portals: []kkComps.ListPortalsResponsePortal{
+		{ID: "portal-1", Name: "My Portal", Labels: map[string]string{"team": "web"}},
+		{ID: "portal-2", Name: "Other Portal"},
+	}}
+	importer, store := newTestImporter(t, portalAPI, &stubAPIAPI{})
+
+	result, err := importer.Import(context.Background(), Request{
+		ResourceType: "portal",
+		Name:         "My Portal",
+		Ref:          "my-portal",
+		Namespace:    "team-web",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Result{
+		ResourceType: "portal",
+		Ref:          "my-portal",
+		ID:           "portal-1",
+		Name:         "My Portal",
+		Namespace:    "team-web",
+	}, result)
+
+	// User labels are kept next to the namespace label
+	update := portalAPI.updates["portal-1"]
+	require.NotNil(t, update.Labels[labels.NamespaceKey])
+	assert.Equal(t, "team-web", *update.Labels[labels.NamespaceKey])
+	require.NotNil(t, update.Labels["team"])
+	assert.Equal(t, "web", *update.Labels["team"])
+
+	recorded, err := store.Load("default")
+	require.NoError(t, err)
+	assert.Equal(t, drift.Resource{
+		ResourceID: "portal-1",
+		AppliedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, recorded[drift.Key("portal", "my-portal")])
+}
+
+func TestImportAlreadyManagedResource(t *testing.T) {
+	managed := map[string]string{labels.NamespaceKey: "default"}
+	portalAPI := &stubPortalAPI{portals: []kkComps.ListPortalsResponsePortal{
+		{ID: "portal-1", Name: "My Portal", Labels: managed},
+	}}
+	importer, _ := newTestImporter(t, portalAPI, &stubAPIAPI{})
+
+	result, err := importer.Import(context.Background(), Request{ResourceType: "portal", Name: "My Portal", Ref: "p"})
+	require.NoError(t, err)
+	assert.True(t, result.AlreadyManaged)
+	assert.Empty(t, portalAPI.updates)
+
+	_, err = importer.Import(context.Background(), Request{
+		ResourceType: "portal",
+		Name:         "My Portal",
+		Ref:          "p",
+		Namespace:    "team-web",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `already managed by kongctl in namespace "default"`)
+}
+
+func TestImportFailsOnAmbiguousOrMissingName(t *testing.T) {
+	apiAPI := &stubAPIAPI{apis: []kkComps.APIResponseSchema{
+		{ID: "api-1", Name: "orders"},
+		{ID: "api-2", Name: "orders"},
+	}}
+	importer, _ := newTestImporter(t, &stubPortalAPI{}, apiAPI)
+
+	_, err := importer.Import(context.Background(), Request{ResourceType: "api", Name: "orders", Ref: "orders"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `2 api resources are named "orders" (api-1, api-2)`)
+
+	_, err = importer.Import(context.Background(), Request{ResourceType: "api", Name: "billing", Ref: "billing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no api named "billing" found in Konnect`)
+
+	_, err = importer.Import(context.Background(), Request{ResourceType: "control_plane", Name: "cp", Ref: "cp"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported resource type")
+}
+
+func TestImportAPIPublication(t *testing.T) {
+	portalAPI := &stubPortalAPI{portals: []kkComps.ListPortalsResponsePortal{{ID: "portal-1", Name: "My Portal"}}}
+	apiAPI := &stubAPIAPI{apis: []kkComps.APIResponseSchema{{ID: "api-1", Name: "orders"}}}
+	importer, store := newTestImporter(t, portalAPI, apiAPI)
+
+	request := Request{ResourceType: "api_publication", API: "orders", Portal: "My Portal", Ref: "orders-dev"}
+	_, err := importer.Import(context.Background(), request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "import it before its publications")
+
+	apiAPI.apis[0].Labels = map[string]string{labels.NamespaceKey: "default"}
+	result, err := importer.Import(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "api-1:portal-1", result.ID)
+	assert.Equal(t, "default", result.Namespace)
+
+	recorded, err := store.Load("default")
+	require.NoError(t, err)
+	assert.Equal(t, "api-1:portal-1", recorded[drift.Key("api_publication", "orders-dev")].ResourceID)
+}
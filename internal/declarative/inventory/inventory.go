@@ -0,0 +1,229 @@
+// Package inventory lists the Konnect resources kongctl manages, joining the
+// resources carrying its namespace label with the record of what was last applied.
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/redact"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// Client lists the managed resources of each type inventoried. *state.Client implements it.
+type Client interface {
+	ListManagedPortals(ctx context.Context, namespaces []string) ([]state.Portal, error)
+	ListManagedAPIs(ctx context.Context, namespaces []string) ([]state.API, error)
+	ListManagedControlPlanes(ctx context.Context, namespaces []string) ([]state.ControlPlane, error)
+	ListManagedAuthStrategies(ctx context.Context, namespaces []string) ([]state.ApplicationAuthStrategy, error)
+	ListManagedCatalogServices(ctx context.Context, namespaces []string) ([]state.CatalogService, error)
+	ListManagedEventGatewayControlPlanes(
+		ctx context.Context, namespaces []string,
+	) ([]state.EventGatewayControlPlane, error)
+	ListManagedOrganizationTeams(ctx context.Context, namespaces []string) ([]state.OrganizationTeam, error)
+}
+
+// Resource is a managed resource of the organization
+type Resource struct {
+	ResourceType string `json:"resource_type" yaml:"resource_type"`
+	// Ref is the configuration ref the resource was last applied with, empty for orphans
+	Ref       string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	ID        string `json:"id" yaml:"id"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// AppliedAt is when kongctl last applied or imported the resource
+	AppliedAt *time.Time `json:"applied_at,omitempty" yaml:"applied_at,omitempty"`
+	// ConfigHash fingerprints the fields last applied, so changes of configuration show
+	ConfigHash string `json:"config_hash,omitempty" yaml:"config_hash,omitempty"`
+	// Orphaned marks resources labeled by kongctl without a record of being applied
+	// with the profile, e.g. left behind by a removed configuration or another machine
+	Orphaned bool `json:"orphaned" yaml:"orphaned"`
+}
+
+// Inventory is the list of managed resources, ordered by type, namespace and name
+type Inventory struct {
+	Profile   string     `json:"profile" yaml:"profile"`
+	Resources []Resource `json:"resources" yaml:"resources"`
+}
+
+// Options selects the resources of the inventory
+type Options struct {
+	// Namespaces to list; empty lists every namespace
+	Namespaces []string
+	// OrphanedOnly leaves out the resources with a record of being applied
+	OrphanedOnly bool
+}
+
+// Build lists the managed resources of the organization. applied is what the store
+// recorded for profile, and gives the ref, apply time and configuration hash.
+func Build(
+	ctx context.Context,
+	client Client,
+	profile string,
+	applied drift.Resources,
+	opts Options,
+) (*Inventory, error) {
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{"*"}
+	}
+
+	live, err := listManaged(ctx, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	records := recordsByID(applied)
+	inventory := &Inventory{Profile: profile, Resources: make([]Resource, 0, len(live))}
+	for _, resource := range live {
+		if rec, ok := records[recordID{resourceType: resource.ResourceType, id: resource.ID}]; ok {
+			appliedAt := rec.resource.AppliedAt
+			resource.Ref = rec.ref
+			resource.AppliedAt = &appliedAt
+			resource.ConfigHash = configHash(rec.resource.Fields)
+		} else {
+			resource.Orphaned = true
+		}
+		if opts.OrphanedOnly && !resource.Orphaned {
+			continue
+		}
+		inventory.Resources = append(inventory.Resources, resource)
+	}
+
+	slices.SortFunc(inventory.Resources, func(a, b Resource) int {
+		return cmp.Or(
+			cmp.Compare(a.ResourceType, b.ResourceType),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return inventory, nil
+}
+
+// Orphans returns the number of orphaned resources of the inventory
+func (i *Inventory) Orphans() int {
+	count := 0
+	for _, resource := range i.Resources {
+		if resource.Orphaned {
+			count++
+		}
+	}
+	return count
+}
+
+// listManaged returns the resources of every inventoried type carrying the namespace label
+func listManaged(ctx context.Context, client Client, namespaces []string) ([]Resource, error) {
+	var resources []Resource
+	add := func(resourceType, id, name string, resourceLabels map[string]string) {
+		resources = append(resources, Resource{
+			ResourceType: resourceType,
+			ID:           id,
+			Name:         name,
+			Namespace:    resourceLabels[labels.NamespaceKey],
+		})
+	}
+
+	portals, err := client.ListManagedPortals(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed portals: %w", err)
+	}
+	for _, p := range portals {
+		add("portal", p.ID, p.Name, p.NormalizedLabels)
+	}
+
+	apis, err := client.ListManagedAPIs(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed APIs: %w", err)
+	}
+	for _, a := range apis {
+		add("api", a.ID, a.Name, a.NormalizedLabels)
+	}
+
+	controlPlanes, err := client.ListManagedControlPlanes(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed control planes: %w", err)
+	}
+	for _, cp := range controlPlanes {
+		add("control_plane", cp.ID, cp.Name, cp.NormalizedLabels)
+	}
+
+	strategies, err := client.ListManagedAuthStrategies(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed application auth strategies: %w", err)
+	}
+	for _, s := range strategies {
+		add("application_auth_strategy", s.ID, s.Name, s.NormalizedLabels)
+	}
+
+	services, err := client.ListManagedCatalogServices(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed catalog services: %w", err)
+	}
+	for _, s := range services {
+		add("catalog_service", s.ID, s.Name, s.NormalizedLabels)
+	}
+
+	gateways, err := client.ListManagedEventGatewayControlPlanes(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed event gateways: %w", err)
+	}
+	for _, g := range gateways {
+		add("event_gateway", g.ID, g.Name, g.NormalizedLabels)
+	}
+
+	teams, err := client.ListManagedOrganizationTeams(ctx, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("list managed organization teams: %w", err)
+	}
+	for _, t := range teams {
+		add("organization_team", derefString(t.ID), derefString(t.Name), t.NormalizedLabels)
+	}
+
+	return resources, nil
+}
+
+type recordID struct {
+	resourceType string
+	id           string
+}
+
+type record struct {
+	ref      string
+	resource drift.Resource
+}
+
+// recordsByID indexes the applied records by resource type and Konnect ID
+func recordsByID(applied drift.Resources) map[recordID]record {
+	records := make(map[recordID]record, len(applied))
+	for key, resource := range applied {
+		resourceType, ref, ok := strings.Cut(key, ":")
+		if !ok || resource.ResourceID == "" {
+			continue
+		}
+		records[recordID{resourceType: resourceType, id: resource.ResourceID}] = record{ref: ref, resource: resource}
+	}
+	return records
+}
+
+// configHash fingerprints the recorded field fingerprints, empty when no fields were
+// applied yet, as for imported resources
+func configHash(fields map[string]string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	return redact.Hash(fields)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/drift"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	portals    []state.Portal
+	apis       []state.API
+	teams      []state.OrganizationTeam
+	namespaces []string
+	err        error
+}
+
+func (f *fakeClient) ListManagedPortals(_ context.Context, namespaces []string) ([]state.Portal, error) {
+	f.namespaces = namespaces
+	return f.portals, f.err
+}
+
+func (f *fakeClient) ListManagedAPIs(context.Context, []string) ([]state.API, error) {
+	return f.apis, nil
+}
+
+func (f *fakeClient) ListManagedControlPlanes(context.Context, []string) ([]state.ControlPlane, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) ListManagedAuthStrategies(context.Context, []string) ([]state.ApplicationAuthStrategy, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) ListManagedCatalogServices(context.Context, []string) ([]state.CatalogService, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) ListManagedEventGatewayControlPlanes(
+	context.Context, []string,
+) ([]state.EventGatewayControlPlane, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) ListManagedOrganizationTeams(context.Context, []string) ([]state.OrganizationTeam, error) {
+	return f.teams, nil
+}
+
+func managedLabels(namespace string) map[string]string {
+	return map[string]string{labels.NamespaceKey: namespace}
+}
+
+func newFakeClient() *fakeClient {
+	teamID, teamName := "team-1", "Platform"
+	return &fakeClient{
+		portals: []state.Portal{
+			{
+				ListPortalsResponsePortal: kkComps.ListPortalsResponsePortal{ID: "portal-2", Name: "Partners"},
+				NormalizedLabels:          managedLabels("team-b"),
+			},
+			{
+				ListPortalsResponsePortal: kkComps.ListPortalsResponsePortal{ID: "portal-1", Name: "Developers"},
+				NormalizedLabels:          managedLabels("team-a"),
+			},
+		},
+		apis: []state.API{
+			{
+				APIResponseSchema: kkComps.APIResponseSchema{ID: "api-1", Name: "orders"},
+				NormalizedLabels:  managedLabels("team-a"),
+			},
+		},
+		teams: []state.OrganizationTeam{
+			{Team: kkComps.Team{ID: &teamID, Name: &teamName}, NormalizedLabels: managedLabels("default")},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	appliedAt := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	importedAt := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	applied := drift.Resources{
+		drift.Key("portal", "developers"): {
+			ResourceID: "portal-1",
+			AppliedAt:  appliedAt,
+			Fields:     map[string]string{"name": "abc", "description": "def"},
+		},
+		drift.Key("api", "orders"): {ResourceID: "api-1", AppliedAt: importedAt},
+		// A record of a type other than the live resource's does not match it
+		drift.Key("api", "partners"): {ResourceID: "portal-2", AppliedAt: appliedAt},
+	}
+	client := newFakeClient()
+
+	inventory, err := Build(context.Background(), client, "default", applied, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"*"}, client.namespaces)
+	assert.Equal(t, "default", inventory.Profile)
+	require.Len(t, inventory.Resources, 4)
+
+	assert.Equal(t, Resource{
+		ResourceType: "api", Ref: "orders", ID: "api-1", Name: "orders", Namespace: "team-a",
+		AppliedAt: &importedAt,
+	}, inventory.Resources[0])
+	assert.Equal(t, Resource{
+		ResourceType: "organization_team", ID: "team-1", Name: "Platform", Namespace: "default", Orphaned: true,
+	}, inventory.Resources[1])
+
+	developers := inventory.Resources[2]
+	assert.Equal(t, "developers", developers.Ref)
+	assert.Equal(t, "team-a", developers.Namespace)
+	assert.Equal(t, &appliedAt, developers.AppliedAt)
+	assert.Len(t, developers.ConfigHash, 12)
+	assert.False(t, developers.Orphaned)
+
+	partners := inventory.Resources[3]
+	assert.Equal(t, "portal-2", partners.ID)
+	assert.Empty(t, partners.Ref)
+	assert.True(t, partners.Orphaned)
+
+	assert.Equal(t, 2, inventory.Orphans())
+}
+
+func TestBuildConfigHashFollowsAppliedFields(t *testing.T) {
+	build := func(fields map[string]string) string {
+		applied := drift.Resources{
+			drift.Key("portal", "developers"): {ResourceID: "portal-1", Fields: fields},
+		}
+		inventory, err := Build(context.Background(), newFakeClient(), "default", applied, Options{})
+		require.NoError(t, err)
+		for _, resource := range inventory.Resources {
+			if resource.ID == "portal-1" {
+				return resource.ConfigHash
+			}
+		}
+		t.Fatal("portal-1 not in inventory")
+		return ""
+	}
+
+	first := build(map[string]string{"name": "abc", "description": "def"})
+	assert.Equal(t, first, build(map[string]string{"description": "def", "name": "abc"}))
+	assert.NotEqual(t, first, build(map[string]string{"name": "abc", "description": "xyz"}))
+}
+
+func TestBuildOptions(t *testing.T) {
+	client := newFakeClient()
+	applied := drift.Resources{
+		drift.Key("portal", "developers"): {ResourceID: "portal-1"},
+	}
+
+	inventory, err := Build(context.Background(), client, "default", applied, Options{
+		Namespaces:   []string{"team-a"},
+		OrphanedOnly: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"team-a"}, client.namespaces)
+	ids := make([]string, 0, len(inventory.Resources))
+	for _, resource := range inventory.Resources {
+		assert.True(t, resource.Orphaned)
+		ids = append(ids, resource.ID)
+	}
+	assert.Equal(t, []string{"api-1", "team-1", "portal-2"}, ids)
+}
+
+func TestBuildListError(t *testing.T) {
+	client := newFakeClient()
+	client.err = errors.New("unauthorized")
+
+	_, err := Build(context.Background(), client, "default", nil, Options{})
+	require.ErrorContains(t, err, "list managed portals: unauthorized")
+}
@@ -1,9 +1,13 @@
 package labels
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/kong/kongctl/internal/util/normalizers"
 )
 
 // Label keys used by kongctl
@@ -14,6 +18,14 @@ const (
 	// Label keys (using prefix to avoid repetition)
 	NamespaceKey = KongctlPrefix + "namespace"
 	ProtectedKey = KongctlPrefix + "protected"
+	// SpecHashPrefix starts the API labels holding the hash of the spec uploaded for
+	// each API version, keyed by version ID. API versions have no labels of their own.
+	SpecHashPrefix = KongctlPrefix + "spec-"
+	// DeprecatedPrefix starts the API labels marking an API version deprecated, keyed by
+	// version ID, see kongctl api-version deprecate
+	DeprecatedPrefix = KongctlPrefix + "deprecated-"
+	// LockKey marks the organization teams holding namespace locks, see package lock
+	LockKey = KongctlPrefix + "lock"
 
 	// Deprecated label keys (kept for backward compatibility)
 	// TODO: Remove in future version after migration period
@@ -125,6 +137,28 @@ func IsKongctlLabel(key string) bool {
 	return strings.HasPrefix(key, KongctlPrefix)
 }
 
+// SpecHashKey returns the API label key holding the spec hash of an API version
+func SpecHashKey(versionID string) string {
+	return SpecHashPrefix + versionID
+}
+
+// DeprecatedKey returns the API label key marking an API version deprecated
+func DeprecatedKey(versionID string) string {
+	return DeprecatedPrefix + versionID
+}
+
+// SpecHash returns the hash stored in the spec hash label for spec content. The spec is
+// normalized to JSON first, so YAML and JSON documents with the same content hash
+// identically. The hash is shortened to fit the 63 character limit of label values.
+func SpecHash(content string) string {
+	normalized, err := normalizers.SpecToJSON(strings.TrimSpace(content))
+	if err != nil {
+		normalized = strings.TrimSpace(content)
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:16])
+}
+
 // CompareUserLabels compares only user-defined labels between current and desired states
 // Returns true if user labels differ, ignoring KONGCTL system labels
 func CompareUserLabels(current, desired map[string]string) bool {
@@ -398,3 +432,30 @@ func ConvertPointerMapsToStringMap(labels map[string]*string) map[string]string
 	}
 	return result
 }
+
+// NamespaceTag returns the tag that marks a Kong Gateway entity, which has tags
+// instead of labels, as managed by kongctl in the namespace
+func NamespaceTag(namespace string) string {
+	return NamespaceKey + ":" + namespace
+}
+
+// NamespaceFromTags returns the namespace recorded in the tags of a Kong Gateway entity
+func NamespaceFromTags(tags []string) (string, bool) {
+	for _, tag := range tags {
+		if namespace, ok := strings.CutPrefix(tag, NamespaceKey+":"); ok {
+			return namespace, true
+		}
+	}
+	return "", false
+}
+
+// GetUserTags returns the tags of a Kong Gateway entity without the kongctl tags
+func GetUserTags(tags []string) []string {
+	var user []string
+	for _, tag := range tags {
+		if !IsKongctlLabel(tag) {
+			user = append(user, tag)
+		}
+	}
+	return user
+}
@@ -400,3 +400,41 @@ func checkManagedLabels(t *testing.T, labels map[string]string, _ string) {
 	// Protected label should not be added by AddManagedLabels anymore
 	// It's handled separately by executors
 }
+
+func TestNamespaceTags(t *testing.T) {
+	tags := []string{"team", NamespaceTag("team-alpha")}
+
+	namespace, ok := NamespaceFromTags(tags)
+	if !ok || namespace != "team-alpha" {
+		t.Errorf("NamespaceFromTags(%v) = %q, %v, want team-alpha, true", tags, namespace, ok)
+	}
+	if _, ok := NamespaceFromTags([]string{"team"}); ok {
+		t.Errorf("NamespaceFromTags() found a namespace in user tags")
+	}
+
+	user := GetUserTags(tags)
+	if len(user) != 1 || user[0] != "team" {
+		t.Errorf("GetUserTags(%v) = %v, want [team]", tags, user)
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	specJSON := `{"openapi":"3.0.0","info":{"title":"Orders","version":"1.0.0"}}`
+	specYAML := "openapi: 3.0.0\ninfo:\n  version: 1.0.0\n  title: Orders\n"
+
+	hash := SpecHash(specJSON)
+	if hash != SpecHash(specYAML) {
+		t.Errorf("SpecHash() differs between equivalent JSON and YAML specs")
+	}
+	if hash == SpecHash(strings.Replace(specJSON, "Orders", "Payments", 1)) {
+		t.Errorf("SpecHash() is the same for different specs")
+	}
+	if len(hash) > 63 {
+		t.Errorf("SpecHash() = %q, longer than a label value", hash)
+	}
+
+	key := SpecHashKey("5f6ab3e2-9a47-4a3c-9f0e-2d6f3c0b9a11")
+	if err := ValidateLabel(key); err != nil || !IsKongctlLabel(key) {
+		t.Errorf("SpecHashKey() = %q is not a valid kongctl label: %v", key, err)
+	}
+}
@@ -0,0 +1,129 @@
+package lint
+
+import "sync"
+
+// builtinOASRulesetName is the name rulesets extend to inherit the built-in OpenAPI rules
+const builtinOASRulesetName = "spectral:oas"
+
+// builtinOASRules implements the rules of the Spectral OpenAPI ruleset that only need
+// core functions. The rule names match Spectral, so rulesets and lint_rules written
+// for Spectral configure them the same way.
+const builtinOASRules = `
+rules:
+  info-contact:
+    description: Info object must have "contact" object.
+    severity: warn
+    given: $.info
+    then:
+      field: contact
+      function: truthy
+  info-description:
+    description: Info "description" must be present and non-empty string.
+    severity: warn
+    given: $.info
+    then:
+      field: description
+      function: truthy
+  info-license:
+    description: Info object must have "license" object.
+    severity: warn
+    recommended: false
+    given: $.info
+    then:
+      field: license
+      function: truthy
+  operation-description:
+    description: Operation "description" must be present and non-empty string.
+    severity: warn
+    given: $.paths[*]['get','put','post','delete','options','head','patch','trace']
+    then:
+      field: description
+      function: truthy
+  operation-operationId:
+    description: Operation must have "operationId".
+    severity: warn
+    given: $.paths[*]['get','put','post','delete','options','head','patch','trace']
+    then:
+      field: operationId
+      function: truthy
+  operation-tags:
+    description: Operation must have non-empty "tags" array.
+    severity: warn
+    given: $.paths[*]['get','put','post','delete','options','head','patch','trace']
+    then:
+      - field: tags
+        function: truthy
+      - field: tags
+        function: length
+        functionOptions:
+          min: 1
+  oas3-api-servers:
+    description: OpenAPI "servers" must be present and non-empty array.
+    severity: warn
+    formats: [oas3]
+    given: $
+    then:
+      - field: servers
+        function: truthy
+      - field: servers
+        function: length
+        functionOptions:
+          min: 1
+  oas2-api-host:
+    description: OpenAPI "host" must be present and non-empty string.
+    severity: warn
+    formats: [oas2]
+    given: $
+    then:
+      field: host
+      function: truthy
+  path-keys-no-trailing-slash:
+    description: Path must not end with slash.
+    severity: warn
+    given: $.paths
+    then:
+      field: "@key"
+      function: pattern
+      functionOptions:
+        notMatch: .+\/$
+  path-declarations-must-exist:
+    description: Path parameter declarations must not be empty, ex."/given/{}" is invalid.
+    severity: warn
+    given: $.paths
+    then:
+      field: "@key"
+      function: pattern
+      functionOptions:
+        notMatch: "{}"
+  path-not-include-query:
+    description: Path must not include query string.
+    severity: warn
+    given: $.paths
+    then:
+      field: "@key"
+      function: pattern
+      functionOptions:
+        notMatch: \?
+  tag-description:
+    description: Tag object must have "description".
+    severity: warn
+    recommended: false
+    given: $.tags[*]
+    then:
+      field: description
+      function: truthy
+`
+
+var (
+	builtinOASOnce sync.Once
+	builtinOAS     *Ruleset
+	builtinOASErr  error
+)
+
+// builtinOASRuleset parses the built-in OpenAPI rules once
+func builtinOASRuleset() (*Ruleset, error) {
+	builtinOASOnce.Do(func() {
+		builtinOAS, builtinOASErr = parse([]byte(builtinOASRules), nil)
+	})
+	return builtinOAS, builtinOASErr
+}
@@ -0,0 +1,361 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// functionOptions lists the functionOptions each supported core function accepts
+var functionOptions = map[string][]string{
+	"truthy":      nil,
+	"falsy":       nil,
+	"defined":     nil,
+	"undefined":   nil,
+	"pattern":     {"match", "notMatch"},
+	"enumeration": {"values"},
+	"length":      {"min", "max"},
+	"casing":      {"type"},
+}
+
+var casingPatterns = map[string]*regexp.Regexp{
+	"flat":   regexp.MustCompile(`^[a-z][a-z0-9]*$`),
+	"camel":  regexp.MustCompile(`^[a-z][a-z0-9]*(?:[A-Z0-9][a-z0-9]*)*$`),
+	"pascal": regexp.MustCompile(`^[A-Z][a-z0-9]*(?:[A-Z0-9][a-z0-9]*)*$`),
+	"kebab":  regexp.MustCompile(`^[a-z][a-z0-9]*(?:-[a-z0-9]+)*$`),
+	"cobol":  regexp.MustCompile(`^[A-Z][A-Z0-9]*(?:-[A-Z0-9]+)*$`),
+	"snake":  regexp.MustCompile(`^[a-z][a-z0-9]*(?:_[a-z0-9]+)*$`),
+	"macro":  regexp.MustCompile(`^[A-Z][A-Z0-9]*(?:_[A-Z0-9]+)*$`),
+}
+
+// prepare validates the function and its options and compiles its patterns
+func (t *Then) prepare() error {
+	allowed, ok := functionOptions[t.Function]
+	if !ok {
+		supported := make([]string, 0, len(functionOptions))
+		for name := range functionOptions {
+			supported = append(supported, name)
+		}
+		sort.Strings(supported)
+		return fmt.Errorf("unsupported function %q, expected one of %s", t.Function, strings.Join(supported, ", "))
+	}
+	for option := range t.FunctionOptions {
+		if !containsString(allowed, option) {
+			return fmt.Errorf("function %s does not accept option %q", t.Function, option)
+		}
+	}
+
+	var err error
+	switch t.Function {
+	case "pattern":
+		match, hasMatch := t.FunctionOptions["match"]
+		notMatch, hasNotMatch := t.FunctionOptions["notMatch"]
+		if !hasMatch && !hasNotMatch {
+			return fmt.Errorf("function pattern requires match or notMatch")
+		}
+		if hasMatch {
+			if t.match, err = compilePattern(match); err != nil {
+				return fmt.Errorf("function pattern: invalid match: %w", err)
+			}
+		}
+		if hasNotMatch {
+			if t.notMatch, err = compilePattern(notMatch); err != nil {
+				return fmt.Errorf("function pattern: invalid notMatch: %w", err)
+			}
+		}
+	case "enumeration":
+		values, ok := t.FunctionOptions["values"].([]any)
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("function enumeration requires a list of values")
+		}
+	case "length":
+		_, hasMin := t.FunctionOptions["min"].(float64)
+		_, hasMax := t.FunctionOptions["max"].(float64)
+		if !hasMin && !hasMax {
+			return fmt.Errorf("function length requires a numeric min or max")
+		}
+	case "casing":
+		casing, _ := t.FunctionOptions["type"].(string)
+		if casingPatterns[casing] == nil {
+			return fmt.Errorf("function casing requires a type of flat, camel, pascal, kebab, cobol, snake or macro")
+		}
+	}
+	return nil
+}
+
+// compilePattern compiles a regular expression, accepting the /pattern/flags form
+// of Spectral rulesets
+func compilePattern(value any) (*regexp.Regexp, error) {
+	pattern, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("must be a string")
+	}
+	if end := strings.LastIndex(pattern, "/"); len(pattern) > 1 && pattern[0] == '/' && end > 0 {
+		flags := pattern[end+1:]
+		pattern = pattern[1:end]
+		if strings.Contains(flags, "i") {
+			pattern = "(?i)" + pattern
+		}
+	}
+	return regexp.Compile(pattern)
+}
+
+// target is a node a function runs on; node is nil when the field is missing
+type target struct {
+	node     *yaml.Node
+	property string
+	path     string
+}
+
+// functionResult describes a node that failed a function
+type functionResult struct {
+	message  string
+	property string
+	value    string
+	path     string
+}
+
+// run runs the functions of the rule on a node selected by its given expressions
+func (r *Rule) run(node *yaml.Node, paths map[*yaml.Node]string) []functionResult {
+	var results []functionResult
+	for i := range r.Then {
+		then := &r.Then[i]
+		for _, tgt := range then.targets(node, paths) {
+			message, failed := then.apply(tgt)
+			if !failed {
+				continue
+			}
+			results = append(results, functionResult{
+				message:  message,
+				property: tgt.property,
+				value:    scalarValue(tgt.node),
+				path:     tgt.path,
+			})
+		}
+	}
+	return results
+}
+
+// targets returns the nodes the function runs on: the node itself, each of its keys
+// for @key, or the node at the field path
+func (t *Then) targets(node *yaml.Node, paths map[*yaml.Node]string) []target {
+	node = resolveAlias(node)
+	switch t.Field {
+	case "":
+		return []target{{node: node, path: paths[node]}}
+	case "@key":
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		targets := make([]target, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			targets = append(targets, target{node: key, property: key.Value, path: paths[key]})
+		}
+		return targets
+	}
+
+	segments := strings.Split(t.Field, ".")
+	path := joinPath(paths[node], t.Field)
+	current := node
+	for _, segment := range segments {
+		current = child(current, segment)
+		if current == nil {
+			return []target{{property: segments[len(segments)-1], path: path}}
+		}
+	}
+	return []target{{node: current, property: segments[len(segments)-1], path: path}}
+}
+
+// nodePaths returns the dotted path of every node of a document, such as
+// paths./orders.get for an operation. Mapping keys have the path of their value.
+func nodePaths(root *yaml.Node) map[*yaml.Node]string {
+	paths := make(map[*yaml.Node]string)
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		if node == nil {
+			return
+		}
+		if _, seen := paths[node]; seen {
+			return
+		}
+		paths[node] = path
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				childPath := joinPath(path, node.Content[i].Value)
+				paths[node.Content[i]] = childPath
+				walk(node.Content[i+1], childPath)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				walk(item, joinPath(path, strconv.Itoa(i)))
+			}
+		}
+	}
+	walk(root, "")
+	return paths
+}
+
+func joinPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
+
+// child returns the value of a mapping key or sequence index of node, or nil
+func child(node *yaml.Node, segment string) *yaml.Node {
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return resolveAlias(node.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node.Content) {
+			return resolveAlias(node.Content[index])
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// apply runs the function on a target and returns the failure message
+func (t *Then) apply(tgt target) (string, bool) {
+	subject := "value"
+	if tgt.property != "" {
+		subject = fmt.Sprintf("%q property", tgt.property)
+	}
+	node := tgt.node
+
+	switch t.Function {
+	case "truthy":
+		return subject + " must be truthy", !isTruthy(node)
+	case "falsy":
+		return subject + " must be falsy", isTruthy(node)
+	case "defined":
+		return subject + " must be defined", node == nil
+	case "undefined":
+		return subject + " must be undefined", node != nil
+	}
+
+	// The other functions only check values that are present
+	if node == nil {
+		return "", false
+	}
+	value := scalarValue(node)
+	switch t.Function {
+	case "pattern":
+		if node.Kind != yaml.ScalarNode {
+			return "", false
+		}
+		if t.match != nil && !t.match.MatchString(value) {
+			return fmt.Sprintf("%q must match the pattern %q", value, t.match.String()), true
+		}
+		if t.notMatch != nil && t.notMatch.MatchString(value) {
+			return fmt.Sprintf("%q must not match the pattern %q", value, t.notMatch.String()), true
+		}
+	case "enumeration":
+		if node.Kind != yaml.ScalarNode {
+			return "", false
+		}
+		values := t.FunctionOptions["values"].([]any)
+		allowed := make([]string, 0, len(values))
+		for _, v := range values {
+			if fmt.Sprint(v) == value {
+				return "", false
+			}
+			allowed = append(allowed, fmt.Sprint(v))
+		}
+		return fmt.Sprintf("%q must be equal to one of the allowed values: %s", value, strings.Join(allowed, ", ")), true
+	case "length":
+		length, ok := nodeLength(node)
+		if !ok {
+			return "", false
+		}
+		if minimum, ok := t.FunctionOptions["min"].(float64); ok && length < minimum {
+			return fmt.Sprintf("%s must have a length of at least %v", subject, minimum), true
+		}
+		if maximum, ok := t.FunctionOptions["max"].(float64); ok && length > maximum {
+			return fmt.Sprintf("%s must have a length of at most %v", subject, maximum), true
+		}
+	case "casing":
+		if node.Kind != yaml.ScalarNode {
+			return "", false
+		}
+		casing := t.FunctionOptions["type"].(string)
+		if !casingPatterns[casing].MatchString(value) {
+			return fmt.Sprintf("%q must be %s case", value, casing), true
+		}
+	}
+	return "", false
+}
+
+// isTruthy follows JavaScript truthiness, as Spectral does: missing values, null,
+// false, 0 and empty strings are falsy while objects and arrays are truthy
+func isTruthy(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	if node.Kind != yaml.ScalarNode {
+		return true
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return false
+	case "!!bool":
+		return node.Value == "true"
+	case "!!int", "!!float":
+		number, err := strconv.ParseFloat(node.Value, 64)
+		return err != nil || number != 0
+	}
+	return node.Value != ""
+}
+
+// nodeLength returns the length of a string, array or object, or the value of a number
+func nodeLength(node *yaml.Node) (float64, bool) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return float64(len(node.Content)), true
+	case yaml.MappingNode:
+		return float64(len(node.Content) / 2), true
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!int", "!!float":
+			number, err := strconv.ParseFloat(node.Value, 64)
+			return number, err == nil
+		case "!!str":
+			return float64(len([]rune(node.Value))), true
+		}
+	}
+	return 0, false
+}
+
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
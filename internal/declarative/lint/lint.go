@@ -0,0 +1,593 @@
+// Package lint checks the OpenAPI specs of declarative API versions against a
+// Spectral-compatible ruleset. A rule selects nodes of the spec with JSONPath
+// `given` expressions and runs a core function, such as truthy or pattern, on
+// them or on one of their fields.
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-apiops/yamlbasics"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"go.yaml.in/yaml/v4"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// Severity decides whether a violation fails the plan
+type Severity string
+
+const (
+	// SeverityError violations fail the plan
+	SeverityError Severity = "error"
+	// SeverityWarn violations are reported without failing the plan
+	SeverityWarn Severity = "warn"
+	// SeverityInfo violations are reported without failing the plan
+	SeverityInfo Severity = "info"
+	// SeverityHint violations are reported without failing the plan
+	SeverityHint Severity = "hint"
+	// SeverityOff disables a rule
+	SeverityOff Severity = "off"
+)
+
+// ParseSeverity accepts the Spectral severity names and numbers, 0 being error
+func ParseSeverity(value string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "error", "0":
+		return SeverityError, nil
+	case "warn", "1":
+		return SeverityWarn, nil
+	case "info", "2":
+		return SeverityInfo, nil
+	case "hint", "3":
+		return SeverityHint, nil
+	case "off", "-1":
+		return SeverityOff, nil
+	}
+	return "", fmt.Errorf("severity must be one of error, warn, info, hint or off, got %q", value)
+}
+
+// UnmarshalJSON accepts a severity name, number or, as in Spectral, a boolean where
+// false disables the rule and true keeps its default severity
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*s = ""
+		if !v {
+			*s = SeverityOff
+		}
+		return nil
+	case float64:
+		parsed, err := ParseSeverity(fmt.Sprint(v))
+		*s = parsed
+		return err
+	case string:
+		parsed, err := ParseSeverity(v)
+		*s = parsed
+		return err
+	}
+	return fmt.Errorf("severity must be a string, number or boolean, got %s", data)
+}
+
+// Ruleset is a set of rules evaluated against API version specs
+type Ruleset struct {
+	// Extends names the rulesets whose rules are inherited: spectral:oas for the
+	// built-in OpenAPI rules, or a path relative to the ruleset file. An entry can be
+	// a [name, mode] pair where mode is recommended, all or off.
+	Extends extendsList `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// Rules defines rules, or overrides the severity of inherited rules with a
+	// severity such as off
+	Rules map[string]*Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// DocumentationURL is accepted for compatibility and not used
+	DocumentationURL string `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+
+	rules map[string]*Rule // resolved rules, including the inherited ones
+}
+
+// Rule checks the nodes of a spec its given expressions select
+type Rule struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Message replaces the message of the function; {{error}}, {{property}},
+	// {{value}} and {{description}} are substituted
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Severity defaults to warn
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Formats restricts the rule to oas2, oas3, oas3.0 or oas3.1 specs
+	Formats []string `json:"formats,omitempty" yaml:"formats,omitempty"`
+	// Recommended false leaves the rule out when its ruleset is extended in
+	// recommended mode
+	Recommended *bool      `json:"recommended,omitempty"      yaml:"recommended,omitempty"`
+	Given       stringList `json:"given"                      yaml:"given"`
+	Then        thenList   `json:"then"                       yaml:"then"`
+	// Resolved and DocumentationURL are accepted for compatibility and not used
+	Resolved         *bool  `json:"resolved,omitempty"         yaml:"resolved,omitempty"`
+	DocumentationURL string `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+
+	selectors   yamlbasics.SelectorSet
+	compiled    bool
+	severityRef bool // the entry only sets the severity of an inherited rule
+}
+
+// Then is a function run on the given nodes, or on one of their fields
+type Then struct {
+	// Field is a dotted path below the given node; @key runs the function on each
+	// key of the given node
+	Field           string         `json:"field,omitempty"           yaml:"field,omitempty"`
+	Function        string         `json:"function"                  yaml:"function"`
+	FunctionOptions map[string]any `json:"functionOptions,omitempty" yaml:"functionOptions,omitempty"`
+
+	match    *regexp.Regexp
+	notMatch *regexp.Regexp
+}
+
+// UnmarshalJSON accepts a rule definition, or a severity overriding an inherited rule
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		*r = Rule{severityRef: true}
+		return r.Severity.UnmarshalJSON(trimmed)
+	}
+
+	type plain Rule
+	var rule plain
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rule); err != nil {
+		return err
+	}
+	*r = Rule(rule)
+	return nil
+}
+
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("must be a string or a list of strings")
+	}
+	*l = list
+	return nil
+}
+
+type thenList []Then
+
+func (l *thenList) UnmarshalJSON(data []byte) error {
+	decode := func(data []byte, target any) error {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(target)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []Then
+		if err := decode(data, &list); err != nil {
+			return err
+		}
+		*l = list
+		return nil
+	}
+	var single Then
+	if err := decode(data, &single); err != nil {
+		return err
+	}
+	*l = thenList{single}
+	return nil
+}
+
+// extendsList holds the extended rulesets with their mode
+type extendsList []extended
+
+type extended struct {
+	name string
+	mode string // recommended (the default), all or off
+}
+
+func (l *extendsList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = extendsList{{name: single, mode: extendModeRecommended}}
+		return nil
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("extends must be a ruleset name or a list of them")
+	}
+	*l = nil
+	for _, entry := range entries {
+		if err := json.Unmarshal(entry, &single); err == nil {
+			*l = append(*l, extended{name: single, mode: extendModeRecommended})
+			continue
+		}
+		var pair []any
+		if err := json.Unmarshal(entry, &pair); err != nil || len(pair) != 2 {
+			return fmt.Errorf("extends entries must be a ruleset name or a [name, mode] pair")
+		}
+		name, _ := pair[0].(string)
+		if name == "" {
+			return fmt.Errorf("extends entries must name a ruleset")
+		}
+		mode := fmt.Sprint(pair[1])
+		if mode == "false" {
+			mode = extendModeOff // YAML reads an unquoted off as false
+		}
+		switch mode {
+		case extendModeRecommended, extendModeAll, extendModeOff:
+		default:
+			return fmt.Errorf("extends mode of %s must be recommended, all or off, got %q", name, mode)
+		}
+		*l = append(*l, extended{name: name, mode: mode})
+	}
+	return nil
+}
+
+const (
+	extendModeRecommended = "recommended"
+	extendModeAll         = "all"
+	extendModeOff         = "off"
+)
+
+// Load reads a ruleset file and the rulesets it extends, and validates its rules
+func Load(path string) (*Ruleset, error) {
+	return load(path, map[string]bool{})
+}
+
+func load(path string, loading map[string]bool) (*Ruleset, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ruleset path %s: %w", path, err)
+	}
+	if loading[absPath] {
+		return nil, fmt.Errorf("ruleset %s extends itself", path)
+	}
+	loading[absPath] = true
+	defer delete(loading, absPath)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint ruleset: %w", err)
+	}
+	ruleset, err := parse(data, func(name string) (*Ruleset, error) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(filepath.Dir(path), name)
+		}
+		return load(name, loading)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid lint ruleset %s: %w", path, err)
+	}
+	return ruleset, nil
+}
+
+// parse decodes a ruleset and resolves its rules; loadFile loads the rulesets it
+// extends by path
+func parse(data []byte, loadFile func(name string) (*Ruleset, error)) (*Ruleset, error) {
+	var ruleset Ruleset
+	if err := k8syaml.UnmarshalStrict(data, &ruleset); err != nil {
+		return nil, err
+	}
+
+	ruleset.rules = make(map[string]*Rule)
+	for _, ext := range ruleset.Extends {
+		var base *Ruleset
+		var err error
+		if ext.name == builtinOASRulesetName {
+			base, err = builtinOASRuleset()
+		} else if loadFile != nil {
+			base, err = loadFile(ext.name)
+		} else {
+			err = fmt.Errorf("unknown ruleset %q", ext.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for name, rule := range base.rules {
+			inherited := *rule
+			switch {
+			case ext.mode == extendModeOff:
+				inherited.Severity = SeverityOff
+			case ext.mode == extendModeRecommended && rule.Recommended != nil && !*rule.Recommended:
+				inherited.Severity = SeverityOff
+			}
+			ruleset.rules[name] = &inherited
+		}
+	}
+
+	names := make([]string, 0, len(ruleset.Rules))
+	for name := range ruleset.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rule := ruleset.Rules[name]
+		if rule == nil {
+			return nil, fmt.Errorf("rule %q is empty", name)
+		}
+		if rule.severityRef {
+			inherited, ok := ruleset.rules[name]
+			if !ok {
+				return nil, fmt.Errorf("rule %q sets a severity but is not defined by an extended ruleset", name)
+			}
+			overridden := *inherited
+			overridden.Severity = rule.Severity
+			if overridden.Severity == "" {
+				overridden.Severity = SeverityWarn
+			}
+			ruleset.rules[name] = &overridden
+			continue
+		}
+		if err := rule.compile(name); err != nil {
+			return nil, err
+		}
+		ruleset.rules[name] = rule
+	}
+	if len(ruleset.rules) == 0 {
+		return nil, fmt.Errorf("ruleset must define or extend at least one rule")
+	}
+	return &ruleset, nil
+}
+
+// compile validates the rule and its functions and compiles its given expressions
+func (r *Rule) compile(name string) error {
+	if r.Severity == "" {
+		r.Severity = SeverityWarn
+	}
+	if len(r.Given) == 0 {
+		return fmt.Errorf("rule %q: given is required", name)
+	}
+	if len(r.Then) == 0 {
+		return fmt.Errorf("rule %q: then is required", name)
+	}
+	for _, format := range r.Formats {
+		if !knownFormats[format] {
+			return fmt.Errorf("rule %q: unknown format %q, expected oas2, oas3, oas3.0 or oas3.1", name, format)
+		}
+	}
+	for i := range r.Then {
+		if err := r.Then[i].prepare(); err != nil {
+			return fmt.Errorf("rule %q: %w", name, err)
+		}
+	}
+
+	var err error
+	if r.selectors, err = yamlbasics.NewSelectorSet(r.Given); err != nil {
+		return fmt.Errorf("rule %q: invalid given: %w", name, err)
+	}
+	r.compiled = true
+	return nil
+}
+
+// RuleNames returns the names of the resolved rules, sorted
+func (r *Ruleset) RuleNames() []string {
+	names := make([]string, 0, len(r.rules))
+	for name := range r.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Violation is a node of an API version spec that does not satisfy a rule
+type Violation struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	API        string   `json:"api"`
+	APIVersion string   `json:"api_version"`
+	Path       string   `json:"path,omitempty"`
+	Message    string   `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Path != "" {
+		return fmt.Sprintf("[%s] api_version %q at %s: %s", v.Rule, v.APIVersion, v.Path, v.Message)
+	}
+	return fmt.Sprintf("[%s] api_version %q: %s", v.Rule, v.APIVersion, v.Message)
+}
+
+// parseErrorRule names the violation reported for a spec that cannot be parsed
+const parseErrorRule = "spec-parse"
+
+// Evaluate lints the spec of every API version, ordered by API version ref. The
+// lint_rules of the kongctl metadata of an API override the severity of rules for
+// the specs of its versions. Specs that are not OpenAPI, such as AsyncAPI specs, are
+// skipped.
+func (r *Ruleset) Evaluate(rs *resources.ResourceSet) ([]Violation, error) {
+	versions := make([]resources.APIVersionResource, len(rs.APIVersions))
+	copy(versions, rs.APIVersions)
+	sort.SliceStable(versions, func(a, b int) bool { return versions[a].Ref < versions[b].Ref })
+
+	var violations []Violation
+	for _, version := range versions {
+		if version.Spec.Content == nil || strings.TrimSpace(*version.Spec.Content) == "" {
+			continue
+		}
+		severities, err := r.apiSeverities(rs.GetAPIByRef(version.API))
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := r.lintSpec(*version.Spec.Content, severities)
+		if err != nil {
+			return nil, fmt.Errorf("api_version %q: %w", version.Ref, err)
+		}
+		for _, violation := range found {
+			violation.API = version.API
+			violation.APIVersion = version.Ref
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+// apiSeverities returns the severity of every rule for the versions of api
+func (r *Ruleset) apiSeverities(api *resources.APIResource) (map[string]Severity, error) {
+	severities := make(map[string]Severity, len(r.rules))
+	for name, rule := range r.rules {
+		severities[name] = rule.Severity
+	}
+	if api == nil || api.Kongctl == nil {
+		return severities, nil
+	}
+
+	for name, value := range api.Kongctl.LintRules {
+		if _, ok := r.rules[name]; !ok {
+			return nil, fmt.Errorf("api %q: lint_rules: unknown rule %q", api.Ref, name)
+		}
+		severity, err := ParseSeverity(value)
+		if err != nil {
+			return nil, fmt.Errorf("api %q: lint_rules: rule %q: %w", api.Ref, name, err)
+		}
+		severities[name] = severity
+	}
+	return severities, nil
+}
+
+// lintSpec runs the enabled rules on one spec
+func (r *Ruleset) lintSpec(content string, severities map[string]Severity) ([]Violation, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return []Violation{{
+			Rule:     parseErrorRule,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("spec is not valid YAML or JSON: %v", err),
+		}}, nil
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	formats := specFormats(doc.Content[0])
+	if len(formats) == 0 {
+		return nil, nil
+	}
+
+	paths := nodePaths(doc.Content[0])
+	var violations []Violation
+	for _, name := range r.RuleNames() {
+		rule := r.rules[name]
+		severity := severities[name]
+		if severity == SeverityOff || !rule.appliesTo(formats) {
+			continue
+		}
+		if !rule.compiled {
+			return nil, fmt.Errorf("rule %q has not been validated", name)
+		}
+		nodes, err := rule.selectors.Find(doc.Content[0])
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", name, err)
+		}
+		for _, node := range nodes {
+			for _, result := range rule.run(node, paths) {
+				violations = append(violations, Violation{
+					Rule:     name,
+					Severity: severity,
+					Path:     result.path,
+					Message:  rule.message(result),
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// knownFormats are the spec formats rules can be restricted to
+var knownFormats = map[string]bool{"oas2": true, "oas3": true, "oas3.0": true, "oas3.1": true}
+
+// specFormats returns the formats of an OpenAPI document, or none for other documents
+func specFormats(root *yaml.Node) map[string]bool {
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1].Value
+		switch {
+		case key == "swagger" && strings.HasPrefix(value, "2."):
+			return map[string]bool{"oas2": true}
+		case key == "openapi" && strings.HasPrefix(value, "3.0"):
+			return map[string]bool{"oas3": true, "oas3.0": true}
+		case key == "openapi" && strings.HasPrefix(value, "3.1"):
+			return map[string]bool{"oas3": true, "oas3.1": true}
+		case key == "openapi" && strings.HasPrefix(value, "3."):
+			return map[string]bool{"oas3": true}
+		}
+	}
+	return nil
+}
+
+func (r *Rule) appliesTo(formats map[string]bool) bool {
+	if len(r.Formats) == 0 {
+		return true
+	}
+	for _, format := range r.Formats {
+		if formats[format] {
+			return true
+		}
+	}
+	return false
+}
+
+// message renders the message of a violation from the rule message template
+func (r *Rule) message(result functionResult) string {
+	template := r.Message
+	if template == "" {
+		template = "{{error}}"
+	}
+	if result.message == "" {
+		result.message = r.Description
+	}
+	return strings.NewReplacer(
+		"{{error}}", result.message,
+		"{{property}}", result.property,
+		"{{value}}", result.value,
+		"{{description}}", r.Description,
+	).Replace(template)
+}
+
+// Error reports the error violations that failed a lint check
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "lint check failed with %d violation(s):", len(e.Violations))
+	for _, violation := range e.Violations {
+		b.WriteString("\n  - ")
+		b.WriteString(violation.String())
+	}
+	return b.String()
+}
+
+// Check lints the API version specs and returns an *Error when any error violation
+// is found, along with the other violations
+func (r *Ruleset) Check(rs *resources.ResourceSet) ([]Violation, error) {
+	violations, err := r.Evaluate(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs, warnings []Violation
+	for _, violation := range violations {
+		if violation.Severity == SeverityError {
+			errs = append(errs, violation)
+		} else {
+			warnings = append(warnings, violation)
+		}
+	}
+	if len(errs) > 0 {
+		return warnings, &Error{Violations: errs}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,254 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `openapi: 3.0.3
+info:
+  title: Orders
+  version: 1.0.0
+servers:
+  - url: https://orders.example.com
+paths:
+  /orders/:
+    get:
+      operationId: listOrders
+      description: List orders
+      tags: [orders]
+  /orders/{id}:
+    get:
+      description: Get an order
+      tags: []
+`
+
+const testRuleset = `
+extends: spectral:oas
+rules:
+  info-contact: off
+  operation-operationId: error
+  operation-id-camel-case:
+    description: operationId must be camel case
+    severity: error
+    given: $.paths[*][*]
+    then:
+      field: operationId
+      function: casing
+      functionOptions:
+        type: camel
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func loadConfig(t *testing.T, dir, config string) *resources.ResourceSet {
+	t.Helper()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	rs, err := loader.New().LoadFile(path)
+	require.NoError(t, err)
+	return rs
+}
+
+func parseRuleset(t *testing.T, data string) *Ruleset {
+	t.Helper()
+	ruleset, err := parse([]byte(data), nil)
+	require.NoError(t, err)
+	return ruleset
+}
+
+const testConfig = `
+apis:
+  - ref: orders
+    name: orders
+    versions:
+      - ref: orders-v1
+        version: 1.0.0
+        spec: !file ./orders.yaml
+`
+
+func TestCheck_ReportsViolations(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"orders.yaml": testSpec})
+	rs := loadConfig(t, dir, testConfig)
+
+	warnings, err := parseRuleset(t, testRuleset).Check(rs)
+
+	var lintErr *Error
+	require.ErrorAs(t, err, &lintErr)
+	require.Len(t, lintErr.Violations, 1)
+	assert.Equal(t, "operation-operationId", lintErr.Violations[0].Rule)
+	assert.Equal(t, "orders", lintErr.Violations[0].API)
+	assert.Equal(t, "paths./orders/{id}.get.operationId", lintErr.Violations[0].Path)
+	assert.Contains(t, err.Error(),
+		`[operation-operationId] api_version "orders-v1" at paths./orders/{id}.get.operationId`)
+
+	rules := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		rules = append(rules, warning.Rule)
+	}
+	assert.ElementsMatch(t, []string{"info-description", "path-keys-no-trailing-slash", "operation-tags"}, rules)
+}
+
+func TestCheck_APILintRules(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"orders.yaml": testSpec})
+	rs := loadConfig(t, dir, `
+apis:
+  - ref: orders
+    name: orders
+    kongctl:
+      lint_rules:
+        operation-operationId: "off"
+        operation-tags: error
+    versions:
+      - ref: orders-v1
+        version: 1.0.0
+        spec: !file ./orders.yaml
+`)
+
+	_, err := parseRuleset(t, testRuleset).Check(rs)
+	var lintErr *Error
+	require.ErrorAs(t, err, &lintErr)
+	require.Len(t, lintErr.Violations, 1)
+	assert.Equal(t, "operation-tags", lintErr.Violations[0].Rule)
+
+	rs.APIs[0].Kongctl.LintRules = map[string]string{"no-such-rule": "off"}
+	_, err = parseRuleset(t, testRuleset).Check(rs)
+	require.ErrorContains(t, err, `unknown rule "no-such-rule"`)
+}
+
+func TestCheck_SkipsOtherSpecs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"orders.yaml": "asyncapi: 2.6.0\ninfo:\n  title: Orders\n  version: 1.0.0\nchannels: {}\n",
+	})
+	rs := loadConfig(t, dir, testConfig)
+
+	warnings, err := parseRuleset(t, testRuleset).Check(rs)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestCheck_InvalidSpec(t *testing.T) {
+	rs := &resources.ResourceSet{APIVersions: []resources.APIVersionResource{{Ref: "orders-v1", API: "orders"}}}
+	content := "openapi: [3.0"
+	rs.APIVersions[0].Spec.Content = &content
+
+	_, err := parseRuleset(t, testRuleset).Check(rs)
+	require.ErrorContains(t, err, "[spec-parse]")
+}
+
+func TestFunctions(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: orders
+  version: "1.0"
+  x-count: 0
+paths:
+  /Orders:
+    get:
+      summary: List
+`
+	tests := []struct {
+		name      string
+		then      string
+		wantCount int
+	}{
+		{name: "truthy", then: "{field: info.x-count, function: truthy}", wantCount: 1},
+		{name: "falsy", then: "{field: info.x-count, function: falsy}", wantCount: 0},
+		{name: "defined", then: "{field: info.summary, function: defined}", wantCount: 1},
+		{name: "undefined", then: "{field: info.title, function: undefined}", wantCount: 1},
+		{name: "pattern", then: "{field: info.title, function: pattern, functionOptions: {match: '/^[A-Z]/'}}", wantCount: 1},
+		{name: "pattern flags", then: "{field: info.title, function: pattern, functionOptions: {match: '/^O/i'}}"},
+		{name: "enumeration", then: "{field: openapi, function: enumeration, functionOptions: {values: [3.0.3]}}",
+			wantCount: 1},
+		{name: "length", then: "{field: info.title, function: length, functionOptions: {max: 3}}", wantCount: 1},
+		{name: "defined object", then: "{field: paths, function: defined}"},
+		{name: "missing field skipped", then: "{field: info.nope, function: casing, functionOptions: {type: camel}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleset := parseRuleset(t, "rules:\n  test:\n    given: $\n    then: "+tt.then+"\n")
+			violations, err := ruleset.lintSpec(spec, map[string]Severity{"test": SeverityWarn})
+			require.NoError(t, err)
+			assert.Len(t, violations, tt.wantCount)
+		})
+	}
+}
+
+func TestLoad_Extends(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.yaml": `
+extends: [[spectral:oas, off]]
+rules:
+  operation-tags: error
+`,
+		"ruleset.yaml": `
+extends: ./base.yaml
+rules:
+  info-license: warn
+`,
+		"loop.yaml": "extends: ./loop.yaml\n",
+	})
+
+	ruleset, err := Load(filepath.Join(dir, "ruleset.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, SeverityError, ruleset.rules["operation-tags"].Severity)
+	assert.Equal(t, SeverityWarn, ruleset.rules["info-license"].Severity)
+	assert.Equal(t, SeverityOff, ruleset.rules["info-contact"].Severity)
+
+	_, err = Load(filepath.Join(dir, "loop.yaml"))
+	require.ErrorContains(t, err, "extends itself")
+}
+
+func TestLoad_InvalidRulesets(t *testing.T) {
+	tests := []struct {
+		name    string
+		ruleset string
+		wantErr string
+	}{
+		{name: "empty", ruleset: "rules: {}", wantErr: "at least one rule"},
+		{name: "unknown override", ruleset: "rules:\n  nope: off", wantErr: "not defined by an extended ruleset"},
+		{
+			name:    "unknown function",
+			ruleset: "rules:\n  r:\n    given: $\n    then: {function: schema}",
+			wantErr: `unsupported function "schema"`,
+		},
+		{
+			name:    "invalid given",
+			ruleset: "rules:\n  r:\n    given: '$[?'\n    then: {function: truthy}",
+			wantErr: "invalid given",
+		},
+		{
+			name:    "unknown option",
+			ruleset: "rules:\n  r:\n    given: $\n    then: {function: truthy, functionOptions: {x: 1}}",
+			wantErr: `does not accept option "x"`,
+		},
+		{
+			name:    "invalid severity",
+			ruleset: "extends: spectral:oas\nrules:\n  info-contact: fatal",
+			wantErr: "severity must be one of",
+		},
+		{name: "unknown field", ruleset: "rules:\n  r:\n    given: $\n    when: x", wantErr: "unknown field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse([]byte(tt.ruleset), nil)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// validateFileReferences checks that every !file and !base64file path in the file
+// and directory sources can be read before any tag is resolved, so a configuration
+// with several missing files reports all of them at once. Stdin is checked during
+// resolution instead because it can only be read once.
+func (l *Loader) validateFileReferences(sources []Source, recursive bool) error {
+	var problems []string
+
+	for _, source := range sources {
+		var paths []string
+		switch source.Type {
+		case SourceTypeFile:
+			paths = []string{source.Path}
+		case SourceTypeDirectory:
+			paths = listConfigFiles(source.Path, recursive)
+		case SourceTypeSTDIN:
+			continue
+		}
+		paths = withIncludedFiles(paths)
+
+		rootDir := l.resolveSourceRoot(source)
+		for _, path := range paths {
+			problems = append(problems, l.checkFileReferences(path, rootDir)...)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d referenced file(s) cannot be loaded:\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
+
+// checkFileReferences returns a description of every unreadable file referenced by path.
+// Files that cannot be read or parsed are skipped; loading reports those errors.
+func (l *Loader) checkFileReferences(path, rootDir string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	collect := tags.CollectFileReferences
+	if ValidateJSONFile(path) {
+		collect = tags.CollectJSONFileReferences
+	}
+	if tags.HasVariableReferences(content) {
+		// The paths are checked as they are once variables are replaced. Unresolved
+		// variables are reported by loading.
+		if ValidateJSONFile(path) {
+			if content, err = tags.ConvertJSON(content); err != nil {
+				return nil
+			}
+			collect = tags.CollectFileReferences
+		}
+		if content, err = l.interpolateVariables(content, path); err != nil {
+			return nil
+		}
+	}
+	refs, err := collect(content)
+	if err != nil || len(refs) == 0 {
+		return nil
+	}
+
+	tagRootDir := strings.TrimSpace(l.tagRootDir)
+	if tagRootDir == "" {
+		tagRootDir = strings.TrimSpace(rootDir)
+	}
+	resolver := l.newFileTagResolver(filepath.Dir(path), tagRootDir)
+
+	var problems []string
+	for _, ref := range refs {
+		if err := resolver.CheckFile(ref.Path); err != nil {
+			location := fmt.Sprintf("field %s", ref.Field)
+			if ref.ResourceRef != "" {
+				location += fmt.Sprintf(" of resource '%s'", ref.ResourceRef)
+			}
+			problems = append(problems, fmt.Sprintf("%s:%d: %s: %s %s: %v",
+				path, ref.Line, location, ref.Tag, ref.Path, err))
+		}
+	}
+	return problems
+}
+
+// listConfigFiles returns the YAML and JSON files a directory source loads
+func listConfigFiles(dirPath string, recursive bool) []string {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		path := filepath.Join(dirPath, entry.Name())
+		if entry.IsDir() {
+			if recursive {
+				paths = append(paths, listConfigFiles(path, recursive)...)
+			}
+			continue
+		}
+		if ValidateConfigFile(path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,211 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to inspect resolved tag values
+)
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// checkFileValueTypes reports every !file value that resolved to a map or a list for
+// a field holding a single value, such as a description, naming the file, the
+// extracted path and the field. raw is the content of the file before its tags were
+// resolved and resolved the YAML after.
+func checkFileValueTypes(sourcePath string, raw, resolved []byte) error {
+	collect := tags.CollectFileReferences
+	if isJSONSource(sourcePath, raw) {
+		collect = tags.CollectJSONFileReferences
+	}
+	refs, err := collect(raw)
+	if err != nil || len(refs) == 0 {
+		return nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(resolved, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, ref := range refs {
+		if ref.Tag != "!file" {
+			continue
+		}
+		node := lookupNode(doc.Content[0], ref.Field)
+		if node == nil || (node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode) {
+			continue
+		}
+		expected, ok := scalarFieldKind(reflect.TypeFor[temporaryParseResult](), ref.Field)
+		if !ok {
+			continue
+		}
+
+		value := ref.Path
+		if ref.Extract != "" {
+			value += "#" + ref.Extract
+		}
+		resolvedKind := "a map"
+		if node.Kind == yaml.SequenceNode {
+			resolvedKind = "a list"
+		}
+		location := fmt.Sprintf("field %s", ref.Field)
+		if ref.ResourceRef != "" {
+			location += fmt.Sprintf(" of resource '%s'", ref.ResourceRef)
+		}
+		problems = append(problems, fmt.Sprintf("%s:%d: %s: !file %s resolves to %s, but the field expects a %s",
+			sourcePath, ref.Line, location, value, resolvedKind, expected))
+	}
+
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%s", problems[0])
+	}
+	return fmt.Errorf("%d !file value(s) do not match their field:\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
+
+// fieldPathSegments splits a field location such as portals[0].pages[1].content into
+// its keys and list indexes. Indexes are returned as "[0]".
+func fieldPathSegments(field string) []string {
+	var segments []string
+	for _, part := range strings.Split(field, ".") {
+		for part != "" {
+			idx := strings.Index(part, "[")
+			switch {
+			case idx == -1:
+				segments = append(segments, part)
+				part = ""
+			case idx > 0:
+				segments = append(segments, part[:idx])
+				part = part[idx:]
+			default:
+				end := strings.Index(part, "]")
+				if end == -1 {
+					return append(segments, part)
+				}
+				segments = append(segments, part[:end+1])
+				part = part[end+1:]
+			}
+		}
+	}
+	return segments
+}
+
+// lookupNode returns the node at field within node, or nil
+func lookupNode(node *yaml.Node, field string) *yaml.Node {
+	for _, segment := range fieldPathSegments(field) {
+		if node == nil {
+			return nil
+		}
+		if strings.HasPrefix(segment, "[") {
+			index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+			if err != nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+			continue
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
+
+// scalarFieldKind reports whether the Go field at field within typ holds a single
+// string, number or boolean, and which. Fields decoded by their own UnmarshalJSON,
+// which may accept any value, are not reported.
+func scalarFieldKind(typ reflect.Type, field string) (string, bool) {
+	for _, segment := range fieldPathSegments(field) {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		switch {
+		case strings.HasPrefix(segment, "["):
+			if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+				return "", false
+			}
+			typ = typ.Elem()
+		case typ.Kind() == reflect.Map:
+			typ = typ.Elem()
+		case typ.Kind() == reflect.Struct:
+			next, ok := jsonField(typ, segment)
+			if !ok {
+				return "", false
+			}
+			typ = next
+		default:
+			return "", false
+		}
+	}
+
+	if typ.Implements(jsonUnmarshalerType) || reflect.PointerTo(typ).Implements(jsonUnmarshalerType) {
+		return "", false
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "string", true
+	case reflect.Bool:
+		return "boolean", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number", true
+	case reflect.Invalid, reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Array,
+		reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice,
+		reflect.Struct, reflect.UnsafePointer:
+	}
+	return "", false
+}
+
+// jsonField returns the type of the field of struct typ decoded from the JSON key
+// name, looking into embedded structs as encoding/json does
+func jsonField(typ reflect.Type, name string) (reflect.Type, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if found, ok := jsonField(embedded, name); ok {
+					return found, true
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if tagName == "" {
+			tagName = field.Name
+		}
+		if strings.EqualFold(tagName, name) {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
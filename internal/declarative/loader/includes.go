@@ -0,0 +1,233 @@
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to read the includes key of the source
+)
+
+// includesKey is the top-level key listing the configuration files a file includes
+const includesKey = "_includes"
+
+// includeState tracks the includes of the current load. Files are keyed by their
+// absolute path, so a file loads once whichever way it is named.
+type includeState struct {
+	// includes maps each parsed file to the paths of the files it includes
+	includes map[string][]string
+	// loaded are the files whose resources were appended
+	loaded map[string]bool
+	// included are the files loaded because another file includes them
+	included map[string]bool
+	// loading are the files whose includes are being loaded, to detect cycles
+	loading map[string]bool
+}
+
+func newIncludeState() *includeState {
+	return &includeState{
+		includes: make(map[string][]string),
+		loaded:   make(map[string]bool),
+		included: make(map[string]bool),
+		loading:  make(map[string]bool),
+	}
+}
+
+// includeState returns the include state of the current load
+func (l *Loader) includeState() *includeState {
+	if l.inc == nil {
+		l.inc = newIncludeState()
+	}
+	return l.inc
+}
+
+// fileKey identifies a configuration file independently of how its path is written
+func fileKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// resolveIncludes returns the files the _includes patterns of a file name, relative to
+// the directory of the file. Glob patterns expand in lexical order.
+func resolveIncludes(patterns []string, baseDir string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		path := pattern
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, pattern)
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			if !ValidateConfigFile(path) {
+				return nil, fmt.Errorf("%s: %s does not have .yaml, .yml or .json extension", includesKey, pattern)
+			}
+			paths = append(paths, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q: %w", includesKey, pattern, err)
+		}
+		var files []string
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() && ValidateConfigFile(match) {
+				files = append(files, match)
+			}
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("%s: pattern %q matches no YAML or JSON files", includesKey, pattern)
+		}
+		paths = append(paths, files...)
+	}
+	return paths, nil
+}
+
+// recordIncludes remembers the files a parsed source includes
+func (l *Loader) recordIncludes(sourcePath string, patterns []string, baseDir string) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+	paths, err := resolveIncludes(patterns, baseDir)
+	if err != nil {
+		return fmt.Errorf("%s: %w", sourcePath, err)
+	}
+	l.includeState().includes[fileKey(sourcePath)] = paths
+	return nil
+}
+
+// skipIncluded reports whether a file of the sources was already loaded because
+// another file includes it
+func (l *Loader) skipIncluded(path string) bool {
+	return l.includeState().included[fileKey(path)]
+}
+
+// appendSource appends the resources of a parsed source after loading the files it
+// includes, in order. Files loaded later override earlier ones: a resource replaces
+// the resource of the same ref and type that an overridable file defines. The files
+// a source includes, directly or through other includes, are overridable by the source
+// and by the files included after them.
+func (l *Loader) appendSource(
+	accumulated, source *resources.ResourceSet,
+	sourcePath string,
+	rootDir string,
+	refIndex map[string]resources.ResourceType,
+	overridable map[string]bool,
+) error {
+	state := l.includeState()
+	key := fileKey(sourcePath)
+	state.loaded[key] = true
+	state.loading[key] = true
+	defer delete(state.loading, key)
+
+	overridable = maps.Clone(overridable)
+	if overridable == nil {
+		overridable = make(map[string]bool)
+	}
+	for _, path := range state.includes[key] {
+		if err := l.loadInclude(path, sourcePath, rootDir, accumulated, refIndex, overridable); err != nil {
+			return err
+		}
+		l.collectIncludes(path, overridable)
+	}
+	return l.appendResourcesWithDuplicateCheck(accumulated, source, sourcePath, refIndex, overridable)
+}
+
+// loadInclude loads a file included by includer, unless it is already loaded
+func (l *Loader) loadInclude(
+	path, includer, rootDir string,
+	accumulated *resources.ResourceSet,
+	refIndex map[string]resources.ResourceType,
+	overridable map[string]bool,
+) error {
+	state := l.includeState()
+	key := fileKey(path)
+	if l.untrusted && !pathWithinBase(fileKey(l.tagRootDir), key) {
+		return fmt.Errorf("%s: %s %s is outside the base directory", includer, includesKey, path)
+	}
+	if state.loading[key] {
+		return fmt.Errorf("%s: %s %s includes it again, forming a cycle", includer, includesKey, path)
+	}
+	state.included[key] = true
+	if state.loaded[key] {
+		return nil
+	}
+
+	rs, err := l.parseFile(path, rootDir)
+	if err != nil {
+		return fmt.Errorf("%s: %w", includesKey, err)
+	}
+	return l.appendSource(accumulated, rs, path, rootDir, refIndex, overridable)
+}
+
+// collectIncludes adds a file and the files it includes, transitively, to files
+func (l *Loader) collectIncludes(path string, files map[string]bool) {
+	key := fileKey(path)
+	if files[key] {
+		return
+	}
+	files[key] = true
+	for _, include := range l.includeState().includes[key] {
+		l.collectIncludes(include, files)
+	}
+}
+
+// withIncludedFiles returns paths followed by the files they include, transitively,
+// for the checks that run before loading. Includes that cannot be read or resolved
+// are skipped; loading reports those errors.
+func withIncludedFiles(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	var result []string
+	var visit func(path string)
+	visit = func(path string) {
+		key := fileKey(path)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		result = append(result, path)
+		includes, err := declaredIncludes(path)
+		if err != nil {
+			return
+		}
+		for _, include := range includes {
+			visit(include)
+		}
+	}
+	for _, path := range paths {
+		visit(path)
+	}
+	return result
+}
+
+// declaredIncludes returns the files the _includes of a configuration file name
+func declaredIncludes(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), includesKey) {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != includesKey {
+			continue
+		}
+		var patterns []string
+		if err := root.Content[i+1].Decode(&patterns); err != nil {
+			return nil, err
+		}
+		return resolveIncludes(patterns, filepath.Dir(path))
+	}
+	return nil, nil
+}
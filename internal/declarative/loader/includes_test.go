@@ -0,0 +1,163 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestLoader_Includes_OverrideByRef(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"base/portals.yaml": `
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    description: "base"
+  - ref: docs-portal
+    name: "Docs Portal"
+`,
+		"base/control-planes.yaml": `
+control_planes:
+  - ref: platform
+    name: "Platform"
+`,
+		"prod.yaml": `
+_includes:
+  - base/*.yaml
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    description: "prod"
+`,
+	})
+
+	loader := New()
+	rs, err := loader.LoadFromSources([]Source{{Path: filepath.Join(dir, "prod.yaml"), Type: SourceTypeFile}}, false)
+	require.NoError(t, err)
+
+	require.Len(t, rs.Portals, 2)
+	require.Len(t, rs.ControlPlanes, 1)
+	portal := rs.GetPortalByRef("main-portal")
+	require.NotNil(t, portal)
+	require.NotNil(t, portal.Description)
+	assert.Equal(t, "prod", *portal.Description)
+
+	sources := loader.RefSources()
+	assert.Equal(t, filepath.Join(dir, "prod.yaml"), sources["main-portal"])
+	assert.Equal(t, filepath.Join(dir, "base", "portals.yaml"), sources["docs-portal"])
+}
+
+func TestLoader_Includes_LaterIncludesOverrideEarlierOnes(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"main.yaml":      "_includes: [base.yaml, overrides.yaml]\n",
+		"base.yaml":      "portals:\n  - ref: p\n    name: portal\n    description: base\n",
+		"overrides.yaml": "portals:\n  - ref: p\n    name: portal\n    description: override\n",
+	})
+
+	rs, err := New().LoadFromSources([]Source{{Path: filepath.Join(dir, "main.yaml"), Type: SourceTypeFile}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, "override", *rs.Portals[0].Description)
+}
+
+func TestLoader_Includes_DirectoryLoadsFilesOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"a-prod.yaml": `
+_includes: [z-base.yaml]
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    description: "prod"
+`,
+		"z-base.yaml": `
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    description: "base"
+`,
+	})
+
+	rs, err := New().LoadFromSources([]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, "prod", *rs.Portals[0].Description)
+}
+
+func TestLoader_Includes_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		err   []string
+	}{
+		{
+			name: "duplicate ref in an included file",
+			files: map[string]string{
+				"main.yaml": "_includes: [one.yaml]\n",
+				"one.yaml":  "portals:\n  - ref: p\n    name: one\n  - ref: p\n    name: two\n",
+			},
+			err: []string{"duplicate ref 'p'", "one.yaml"},
+		},
+		{
+			name: "override with another resource type",
+			files: map[string]string{
+				"main.yaml": "_includes: [base.yaml]\ncontrol_planes:\n  - ref: p\n    name: cp\n",
+				"base.yaml": "portals:\n  - ref: p\n    name: portal\n",
+			},
+			err: []string{"duplicate ref 'p'", "base.yaml"},
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"main.yaml": "_includes: [base.yaml]\n",
+				"base.yaml": "_includes: [main.yaml]\nportals:\n  - ref: p\n    name: portal\n",
+			},
+			err: []string{"forming a cycle"},
+		},
+		{
+			name: "pattern without matches",
+			files: map[string]string{
+				"main.yaml": "_includes: [missing/*.yaml]\n",
+			},
+			err: []string{`pattern "missing/*.yaml" matches no YAML or JSON files`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeConfigFiles(t, dir, tt.files)
+
+			_, err := New().LoadFromSources([]Source{{Path: filepath.Join(dir, "main.yaml"), Type: SourceTypeFile}}, false)
+			require.Error(t, err)
+			for _, want := range tt.err {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestWithIncludedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFiles(t, dir, map[string]string{
+		"main.yaml":        "_includes: [shared/vars.yaml]\n",
+		"shared/vars.yaml": "variables:\n  env: prod\n",
+	})
+
+	paths := withIncludedFiles([]string{filepath.Join(dir, "main.yaml")})
+	assert.Equal(t, []string{filepath.Join(dir, "main.yaml"), filepath.Join(dir, "shared", "vars.yaml")}, paths)
+}
@@ -0,0 +1,272 @@
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to locate refs in the source
+)
+
+// recordRefLines records the lines of the ref keys of a source, so duplicate refs can
+// be reported with both locations, and the lines of its !ref tags by target, so
+// dangling references point at the tag. JSON is parsed as YAML, which keeps its lines.
+func (l *Loader) recordRefLines(sourcePath string, content []byte) {
+	lines := make(map[string][]int)
+	tagLines := make(map[string][]int)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc yaml.Node
+		// Parse errors are reported when the source is loaded
+		if err := decoder.Decode(&doc); err != nil {
+			break
+		}
+		collectRefLines(&doc, lines, tagLines)
+	}
+	if l.refLines == nil {
+		l.refLines = make(map[string]map[string][]int)
+		l.refTagLines = make(map[string]map[string][]int)
+	}
+	l.refLines[sourcePath] = lines
+	l.refTagLines[sourcePath] = tagLines
+}
+
+func collectRefLines(node *yaml.Node, lines, tagLines map[string][]int) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!ref" {
+		target, _, _ := strings.Cut(node.Value, "#")
+		tagLines[target] = append(tagLines[target], node.Line)
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "ref" && value.Kind == yaml.ScalarNode {
+				lines[value.Value] = append(lines[value.Value], value.Line)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		collectRefLines(child, lines, tagLines)
+	}
+}
+
+// refTagLocation returns "path:line" for the first !ref tag of a source pointing at
+// target, or the path alone when the line is unknown
+func (l *Loader) refTagLocation(sourcePath, target string) string {
+	if lines := l.refTagLines[sourcePath][target]; len(lines) > 0 {
+		return fmt.Sprintf("%s:%d", sourcePath, lines[0])
+	}
+	return sourcePath
+}
+
+// circularReferenceLocation returns the location of the resource where the circular
+// reference reported by err closes, or "" when err reports none
+func (l *Loader) circularReferenceLocation(err error) string {
+	var circular *circularReferenceError
+	if !errors.As(err, &circular) {
+		return ""
+	}
+	file, ok := l.refSources[circular.ref]
+	if !ok {
+		return ""
+	}
+	return l.refLocation(file, circular.ref, 0)
+}
+
+// refLocation returns "path:line" for the nth definition of ref in a source, or the
+// path alone when the line is unknown
+func (l *Loader) refLocation(sourcePath, ref string, n int) string {
+	if lines := l.refLines[sourcePath][ref]; n < len(lines) {
+		return fmt.Sprintf("%s:%d", sourcePath, lines[n])
+	}
+	return sourcePath
+}
+
+// duplicateRefError reports a ref defined twice, in the same source or in two sources
+func (l *Loader) duplicateRefError(ref string, sourcePath string, resourceType resources.ResourceType,
+	existingPath string, existingType resources.ResourceType,
+) error {
+	if existingPath == "" {
+		return fmt.Errorf("duplicate ref '%s' found in %s (already defined as %s)", ref, sourcePath, existingType)
+	}
+	if existingPath != sourcePath {
+		return fmt.Errorf("duplicate ref '%s' found in %s (already defined as %s in %s)",
+			ref, l.refLocation(sourcePath, ref, 0), existingType, l.refLocation(existingPath, ref, 0))
+	}
+
+	// Resources of a source are not visited in file order, so the types are not
+	// attributed to a line
+	types := []string{string(existingType), string(resourceType)}
+	sort.Strings(types)
+	lines := l.refLines[sourcePath][ref]
+	if len(lines) < 2 {
+		return fmt.Errorf("duplicate ref '%s' found in %s (defined as %s and as %s)", ref, sourcePath, types[0], types[1])
+	}
+	return fmt.Errorf("duplicate ref '%s' found in %s at lines %d and %d (defined as %s and as %s)",
+		ref, sourcePath, lines[0], lines[1], types[0], types[1])
+}
+
+// checkIntegrity checks that every resource can be addressed once all sources are
+// loaded: it has a ref and the fields Konnect requires, and every reference, by field
+// or with a !ref tag, points at a resource of the configuration. All problems are
+// reported at once.
+func (l *Loader) checkIntegrity(rs *resources.ResourceSet) error {
+	var issues []ValidationIssue
+	rs.ForEachResource(func(r resources.Resource) bool {
+		issues = append(issues, l.addressIssues(r, rs)...)
+		return true
+	})
+	issues = append(issues, l.danglingRefIssues(rs)...)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	sortIssues(issues)
+	if len(issues) == 1 {
+		return errors.New(issues[0].String())
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d problems found in configuration:", len(issues))
+	for _, issue := range issues {
+		b.WriteString("\n  - " + issue.String())
+	}
+	return errors.New(b.String())
+}
+
+// addressIssues returns the missing ref and required fields of a resource, and its
+// reference fields that do not point at a resource of the expected type
+func (l *Loader) addressIssues(r resources.Resource, rs *resources.ResourceSet) []ValidationIssue {
+	ref := r.GetRef()
+	file := l.refSources[ref]
+
+	var issues []ValidationIssue
+	if strings.TrimSpace(ref) == "" {
+		issues = append(issues, ValidationIssue{
+			File: file, Field: "ref", Message: fmt.Sprintf("%s resource has no ref", r.GetType()),
+		})
+	}
+	for _, field := range missingRequiredFields(r) {
+		issues = append(issues, ValidationIssue{
+			File: file, Ref: ref, Field: field, Message: "required field is missing",
+		})
+	}
+	for _, problem := range l.findReferenceProblems(r, rs) {
+		issues = append(issues, ValidationIssue{
+			File: file, Ref: ref, Field: problem.field, Message: problem.err.Error(),
+		})
+	}
+	return issues
+}
+
+// danglingRefIssues returns every !ref tag whose target ref is not in the configuration
+func (l *Loader) danglingRefIssues(rs *resources.ResourceSet) []ValidationIssue {
+	var issues []ValidationIssue
+	// Nested child resources are also visited after their extraction
+	seen := make(map[ValidationIssue]bool)
+	rs.ForEachResource(func(r resources.Resource) bool {
+		walkRefPlaceholders(reflect.ValueOf(r), r.GetRef(), "", func(ref, field, placeholder string) {
+			target, _, ok := tags.ParseRefPlaceholder(placeholder)
+			if !ok || rs.HasRef(target) {
+				return
+			}
+			file, known := l.refSources[ref]
+			if !known {
+				// API documents stay nested in their API
+				file = l.refSources[r.GetRef()]
+			}
+			issue := ValidationIssue{
+				File: l.refTagLocation(file, target), Ref: ref, Field: field,
+				Message: fmt.Sprintf("!ref target %q is not defined in the configuration", target),
+			}
+			if !seen[issue] {
+				seen[issue] = true
+				issues = append(issues, issue)
+			}
+		})
+		return true
+	})
+	return issues
+}
+
+// walkRefPlaceholders calls fn with the resource ref and the field path of every !ref
+// placeholder under val. Placeholders of nested resources are reported on their own ref.
+// Field paths use JSON names; list indexes are not part of a path.
+func walkRefPlaceholders(val reflect.Value, ref, path string, fn func(ref, field, placeholder string)) {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !val.IsNil() {
+			walkRefPlaceholders(val.Elem(), ref, path, fn)
+		}
+	case reflect.String:
+		if tags.IsRefPlaceholder(val.String()) {
+			fn(ref, path, val.String())
+		}
+	case reflect.Struct:
+		if val.CanAddr() {
+			if nested, ok := val.Addr().Interface().(resources.Resource); ok && nested.GetRef() != "" &&
+				nested.GetRef() != ref {
+				ref, path = nested.GetRef(), ""
+			}
+		}
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			fieldPath := path
+			if !field.Anonymous {
+				if name == "" {
+					name = field.Name
+				}
+				fieldPath = joinFieldPath(path, name)
+			}
+			walkRefPlaceholders(val.Field(i), ref, fieldPath, fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			walkRefPlaceholders(val.Index(i), ref, path, fn)
+		}
+	case reflect.Map:
+		keys := val.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		for _, key := range keys {
+			walkRefPlaceholders(val.MapIndex(key), ref, joinFieldPath(path, fmt.Sprint(key.Interface())), fn)
+		}
+	default:
+	}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// sortIssues orders issues by file, ref and field
+func sortIssues(issues []ValidationIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		if issues[i].Column != issues[j].Column {
+			return issues[i].Column < issues[j].Column
+		}
+		if issues[i].Ref != issues[j].Ref {
+			return issues[i].Ref < issues[j].Ref
+		}
+		return issues[i].Field < issues[j].Field
+	})
+}
@@ -0,0 +1,103 @@
+package loader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_DuplicateRefLocations(t *testing.T) {
+	t.Run("same file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeValidateFile(t, dir, "portals.yaml", `
+portals:
+  - ref: dev
+    name: Developer Portal
+apis:
+  - ref: dev
+    name: Dev API
+`)
+
+		_, err := New().LoadFromSources([]Source{{Path: path, Type: SourceTypeFile}}, false)
+		require.EqualError(t, err,
+			"duplicate ref 'dev' found in "+path+" at lines 3 and 6 (defined as api and as portal)")
+	})
+
+	t.Run("across files", func(t *testing.T) {
+		dir := t.TempDir()
+		first := writeValidateFile(t, dir, "a.yaml", `
+portals:
+  - ref: dev
+    name: Developer Portal
+`)
+		second := writeValidateFile(t, dir, "b.yaml", `
+# Copied from a.yaml
+portals:
+  - ref: dev
+    name: Developer Portal Copy
+`)
+
+		_, err := New().LoadFromSources([]Source{
+			{Path: first, Type: SourceTypeFile},
+			{Path: second, Type: SourceTypeFile},
+		}, false)
+		require.EqualError(t, err,
+			"duplicate ref 'dev' found in "+second+":4 (already defined as portal in "+first+":3)")
+	})
+}
+
+func TestLoader_ReportsAllDanglingReferences(t *testing.T) {
+	dir := t.TempDir()
+	path := writeValidateFile(t, dir, "config.yaml", `
+portals:
+  - ref: dev
+    name: Developer Portal
+    description: !ref missing-portal#description
+apis:
+  - ref: orders
+    name: Orders
+    publications:
+      - ref: orders-to-staging
+        portal_id: staging-portal
+    documents:
+      - ref: orders-guide
+        slug: guide
+        content: !ref missing-snippet#content
+`)
+
+	_, err := New().LoadFromSources([]Source{{Path: path, Type: SourceTypeFile}}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 problems found in configuration:")
+	assert.Contains(t, err.Error(),
+		path+`:5: ref "dev": field "description": !ref target "missing-portal" is not defined in the configuration`)
+	assert.Contains(t, err.Error(),
+		path+`:15: ref "orders-guide": field "content": !ref target "missing-snippet" is not defined in the configuration`)
+	assert.Contains(t, err.Error(), `ref "orders-to-staging": field "portal_id"`)
+
+	_, issues := New().Validate(context.Background(), []Source{{Path: path, Type: SourceTypeFile}}, false)
+	require.Len(t, issues, 3, "each dangling reference is reported once")
+}
+
+func TestLoader_CircularReferenceLocation(t *testing.T) {
+	dir := t.TempDir()
+	writeValidateFile(t, dir, "portals.yaml", `
+portals:
+  - ref: dev
+    name: Developer Portal
+    description: !ref staging#description
+`)
+	morePortals := writeValidateFile(t, dir, "more-portals.yaml", `
+portals:
+  - ref: staging
+    name: Staging Portal
+    description: !ref dev#description
+`)
+
+	_, err := New().LoadFromSources([]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+	require.Error(t, err)
+	// more-portals.yaml is loaded first, so the loop is found from staging
+	assert.Contains(t, err.Error(), "circular reference: staging->dev#description -> dev->staging#description")
+	assert.Contains(t, err.Error(), morePortals+":3: resolving references")
+}
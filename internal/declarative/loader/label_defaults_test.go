@@ -0,0 +1,146 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelDefaults(t *testing.T) {
+	t.Run("file defaults merge with resource labels", func(t *testing.T) {
+		yaml := `
+_defaults:
+  labels:
+    team: payments
+    environment: staging
+
+portals:
+  - ref: portal1
+    name: "Portal 1"
+    labels:
+      environment: production
+      tier: gold
+
+control_planes:
+  - ref: cp1
+    name: "CP 1"
+
+organization:
+  teams:
+    - ref: team1
+      name: "Team 1"
+`
+		dir := t.TempDir()
+		file := filepath.Join(dir, "test.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(yaml), 0o600))
+
+		rs, err := New().LoadFile(file)
+		require.NoError(t, err)
+
+		require.Len(t, rs.Portals, 1)
+		assert.Equal(t, map[string]string{
+			"team":        "payments",
+			"environment": "production", // resource label wins
+			"tier":        "gold",
+		}, rs.Portals[0].GetLabels())
+
+		require.Len(t, rs.ControlPlanes, 1)
+		assert.Equal(t, map[string]string{"team": "payments", "environment": "staging"}, rs.ControlPlanes[0].Labels)
+
+		require.Len(t, rs.OrganizationTeams, 1)
+		assert.Equal(t, map[string]string{"team": "payments", "environment": "staging"}, rs.OrganizationTeams[0].Labels)
+	})
+
+	t.Run("loader defaults have the lowest precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`
+_defaults:
+  labels:
+    team: payments
+
+apis:
+  - ref: api1
+    name: "API 1"
+    labels:
+      owner: alice
+`), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(`
+apis:
+  - ref: api2
+    name: "API 2"
+`), 0o600))
+
+		l := New()
+		l.SetDefaultLabels(map[string]string{"team": "platform", "environment": "dev", "owner": "nobody"})
+		rs, err := l.LoadFromSourcesWithContext(context.Background(),
+			[]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+		require.NoError(t, err)
+
+		byRef := map[string]map[string]string{}
+		for _, api := range rs.APIs {
+			byRef[api.Ref] = api.GetLabels()
+		}
+		assert.Equal(t, map[string]string{"team": "payments", "environment": "dev", "owner": "alice"}, byRef["api1"])
+		assert.Equal(t, map[string]string{"team": "platform", "environment": "dev", "owner": "nobody"}, byRef["api2"])
+	})
+
+	t.Run("external resources are left untouched", func(t *testing.T) {
+		yaml := `
+_defaults:
+  labels:
+    team: payments
+
+portals:
+  - ref: shared-portal
+    _external:
+      selector:
+        matchFields:
+          name: "Shared Portal"
+`
+		dir := t.TempDir()
+		file := filepath.Join(dir, "test.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(yaml), 0o600))
+
+		rs, err := New().LoadFile(file)
+		require.NoError(t, err)
+		require.Len(t, rs.Portals, 1)
+		assert.Empty(t, rs.Portals[0].GetLabels())
+	})
+
+	t.Run("reserved label keys are rejected", func(t *testing.T) {
+		yaml := `
+_defaults:
+  labels:
+    KONGCTL-namespace: other
+
+portals:
+  - ref: portal1
+    name: "Portal 1"
+`
+		dir := t.TempDir()
+		file := filepath.Join(dir, "test.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(yaml), 0o600))
+
+		_, err := New().LoadFile(file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid _defaults.labels")
+		assert.Contains(t, err.Error(), "reserved for kongctl")
+
+		valid := filepath.Join(t.TempDir(), "valid.yaml")
+		require.NoError(t, os.WriteFile(valid, []byte(`
+portals:
+  - ref: portal1
+    name: "Portal 1"
+`), 0o600))
+		l := New()
+		l.SetDefaultLabels(map[string]string{"konnect-env": "x"})
+		_, err = l.LoadFromSourcesWithContext(context.Background(),
+			[]Source{{Path: valid, Type: SourceTypeFile}}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid default labels")
+	})
+}
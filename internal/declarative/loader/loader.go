@@ -1,18 +1,26 @@
 package loader
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/depgraph"
 	decerrors "github.com/kong/kongctl/internal/declarative/errors"
+	"github.com/kong/kongctl/internal/declarative/hooks"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/migrate"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/meta"
+	"github.com/kong/kongctl/internal/tracing"
 	"github.com/kong/kongctl/internal/util"
 	"sigs.k8s.io/yaml"
 )
@@ -20,7 +28,18 @@ import (
 // temporaryParseResult holds the raw parsed YAML including defaults
 // This is used internally during parsing to capture both resources and file-level defaults
 type temporaryParseResult struct {
-	Defaults              *resources.FileDefaults `json:"_defaults,omitempty" yaml:"_defaults,omitempty"`
+	// Version is the format version the file is written for
+	Version  *int                    `json:"_version,omitempty" yaml:"_version,omitempty"`
+	Defaults *resources.FileDefaults `json:"_defaults,omitempty" yaml:"_defaults,omitempty"`
+	// Namespace scopes every resource of the file to one namespace
+	Namespace *string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Variables declares the values of the ${var.name} references of the configuration
+	Variables map[string]any `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// Hooks notify webhooks and commands of the outcome of an apply or sync
+	Hooks *hooks.Config `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	// Includes lists the configuration files loaded before the file, whose resources
+	// the file can override by ref
+	Includes              []string `json:"_includes,omitempty" yaml:"_includes,omitempty"`
 	resources.ResourceSet ` yaml:",inline"`
 }
 
@@ -32,6 +51,46 @@ type Loader struct {
 	tagRootDir string
 	// tagRegistry is the registry of tag resolvers (created on demand)
 	tagRegistry *tags.ResolverRegistry
+	// refSources maps each loaded resource ref to the file that defined it
+	refSources map[string]string
+	// refLines maps each parsed source to the lines of its ref keys
+	refLines map[string]map[string][]int
+	// refTagLines maps each parsed source to the lines of its !ref tags by target ref
+	refTagLines map[string]map[string][]int
+	// defaultLabels are merged into every managed resource after file-level defaults
+	defaultLabels map[string]string
+	// namespace is the active namespace every loaded resource must belong to
+	namespace string
+	// offline rejects !file tags with remote sources
+	offline bool
+	// untrusted keeps files from reading anything outside the base directory
+	untrusted bool
+	// remote fetches remote !file sources, caching them for the current load
+	remote *tags.RemoteFetcher
+	// recordGraph captures the dependency graph of each load before resolving references
+	recordGraph bool
+	// graph is the dependency graph captured by the last load
+	graph *depgraph.Graph
+	// defaultRegion is the Konnect region of the profile
+	defaultRegion string
+	// region is the Konnect region whose resources are kept when regionSelected is set
+	region         string
+	regionSelected bool
+	// refRegions maps the resource refs of files with a _defaults.region to the region
+	refRegions map[string]string
+	// namespaceRegions maps the namespace defaults of files with a _defaults.region to the region
+	namespaceRegions map[string]string
+	// regions are the regions of the resources of the last load
+	regions []string
+	// variables are the values of variables set for every load, such as --var-file
+	variables map[string]any
+	// loadVariables are the variables of the current load, those the sources declare
+	// overridden by variables
+	loadVariables map[string]any
+	// hooks are the hooks the sources of the last load declare
+	hooks hooks.Config
+	// inc tracks the files the sources of the current load include
+	inc *includeState
 }
 
 // New creates a new configuration loader
@@ -57,6 +116,61 @@ func NewWithBaseDir(baseDir string) *Loader {
 	}
 }
 
+// SetDefaultLabels sets labels merged into every managed resource that supports labels.
+// Labels set on a resource, or in a file's _defaults.labels, take precedence.
+func (l *Loader) SetDefaultLabels(defaults map[string]string) {
+	l.defaultLabels = maps.Clone(defaults)
+}
+
+// SetNamespace sets the active namespace. Resources without a namespace are placed in
+// it instead of "default", and loading fails for resources of any other namespace.
+func (l *Loader) SetNamespace(namespace string) {
+	l.namespace = namespace
+}
+
+// SetOffline makes any !file tag with an http:// or https:// source fail loading,
+// so that a load never depends on the network
+func (l *Loader) SetOffline(offline bool) {
+	l.offline = offline
+	l.remote = nil
+}
+
+// SetUntrusted makes the loader treat files as submitted by someone else, who must
+// not read the environment or the files of this machine: !env and !secret tags are
+// unsupported, !file tags with remote sources fail like with SetOffline, and
+// _includes must stay inside the base directory.
+func (l *Loader) SetUntrusted(untrusted bool) {
+	l.untrusted = untrusted
+	l.remote = nil
+}
+
+// RecordDependencyGraph makes loading capture the dependency graph of the configuration
+// before references are resolved, so it is available even when resolution fails
+func (l *Loader) RecordDependencyGraph() {
+	l.recordGraph = true
+}
+
+// DependencyGraph returns the graph captured by the last load, or nil when it was not
+// recorded or loading failed before references were resolved
+func (l *Loader) DependencyGraph() *depgraph.Graph {
+	return l.graph
+}
+
+// remoteFetcher returns the fetcher of remote !file sources, creating it if needed
+func (l *Loader) remoteFetcher() *tags.RemoteFetcher {
+	if l.remote == nil {
+		l.remote = tags.NewRemoteFetcher(tags.DefaultRemoteTimeout, l.offline || l.untrusted)
+	}
+	return l.remote
+}
+
+// newFileTagResolver creates the !file resolver of a source file
+func (l *Loader) newFileTagResolver(baseDir, rootDir string) *tags.FileTagResolver {
+	resolver := tags.NewFileTagResolver(baseDir, rootDir)
+	resolver.SetRemoteFetcher(l.remoteFetcher())
+	return resolver
+}
+
 // getTagRegistry returns the tag registry, creating it if needed
 func (l *Loader) getTagRegistry() *tags.ResolverRegistry {
 	if l.tagRegistry == nil {
@@ -91,9 +205,41 @@ func (l *Loader) LoadFromSources(sources []Source, recursive bool) (*resources.R
 func (l *Loader) LoadFromSourcesWithContext(ctx context.Context, sources []Source,
 	recursive bool,
 ) (*resources.ResourceSet, error) {
+	ctx, span := tracing.Start(ctx, tracing.SpanLoad, tracing.AttrSourceCount.Int(len(sources)))
+	rs, err := l.loadFromSources(ctx, sources, recursive)
+	if err == nil && tracing.Enabled() {
+		span.SetAttributes(tracing.AttrResourceCount.Int(rs.ResourceCount()))
+	}
+	tracing.End(span, err)
+	return rs, err
+}
+
+func (l *Loader) loadFromSources(ctx context.Context, sources []Source,
+	recursive bool,
+) (*resources.ResourceSet, error) {
+	l.graph = nil
+	l.loadVariables = nil
+	if err := l.collectVariables(sources, recursive); err != nil {
+		return nil, err
+	}
+	// Fail fast on missing !file targets before any content is resolved
+	if err := l.validateFileReferences(sources, recursive); err != nil {
+		return nil, err
+	}
+
 	var allResources resources.ResourceSet
 	// Running index of refs for O(1) duplicate checking across files
 	refIndex := make(map[string]resources.ResourceType)
+	// Ref sources and fetched remote files describe the current load only
+	l.refSources = nil
+	l.refLines = nil
+	l.refTagLines = nil
+	l.remote = nil
+	l.refRegions = nil
+	l.namespaceRegions = nil
+	l.regions = nil
+	l.hooks = hooks.Config{}
+	l.inc = newIncludeState()
 
 	for _, source := range sources {
 		var err error
@@ -119,13 +265,40 @@ func (l *Loader) LoadFromSourcesWithContext(ctx context.Context, sources []Sourc
 		}
 	}
 
+	if l.untrusted {
+		if err := checkUntrusted(&allResources); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyLabelDefaults(&allResources, l.defaultLabels); err != nil {
+		return nil, fmt.Errorf("invalid default labels: %w", err)
+	}
+
 	// Apply SDK defaults to merged resources
-	// Note: Only namespace defaults are applied per-file in parseYAML
+	// Note: Only namespace and label defaults are applied per-file in parseYAML
 	l.applyDefaults(&allResources)
 
+	if l.recordGraph {
+		l.graph = depgraph.Build(&allResources)
+	}
+
+	// Every ref and reference is checked before references are resolved, so all
+	// problems of the configuration are reported together
+	if err := l.checkIntegrity(&allResources); err != nil {
+		return nil, err
+	}
+	regions, err := l.resolveRegions(&allResources)
+	if err != nil {
+		return nil, err
+	}
+
 	// Reference resolution must happen after all files are loaded but before validation.
 	// This order is critical for cross-file references to work correctly.
 	if err := ResolveReferences(ctx, &allResources); err != nil {
+		if location := l.circularReferenceLocation(err); location != "" {
+			return nil, fmt.Errorf("%s: resolving references: %w", location, err)
+		}
 		return nil, fmt.Errorf("resolving references: %w", err)
 	}
 
@@ -133,6 +306,9 @@ func (l *Loader) LoadFromSourcesWithContext(ctx context.Context, sources []Sourc
 	if err := l.validateResourceSet(&allResources); err != nil {
 		return nil, err
 	}
+	if err := l.scopeToRegion(&allResources, regions); err != nil {
+		return nil, err
+	}
 
 	return &allResources, nil
 }
@@ -145,8 +321,13 @@ func (l *Loader) Load() (*resources.ResourceSet, error) {
 
 // LoadFile loads configuration from a single YAML file (deprecated, for backward compatibility)
 func (l *Loader) LoadFile(path string) (*resources.ResourceSet, error) {
+	if err := l.validateFileReferences([]Source{{Path: path, Type: SourceTypeFile}}, false); err != nil {
+		return nil, err
+	}
+
 	var rs resources.ResourceSet
 	refIndex := make(map[string]resources.ResourceType)
+	l.inc = newIncludeState()
 	if err := l.loadSingleFile(path, filepath.Dir(path), &rs, refIndex); err != nil {
 		return nil, err
 	}
@@ -162,34 +343,50 @@ func (l *Loader) LoadFile(path string) (*resources.ResourceSet, error) {
 	return &rs, nil
 }
 
-// loadSingleFile loads configuration from a single YAML file
+// loadSingleFile loads configuration from a single YAML or JSON file
 func (l *Loader) loadSingleFile(
 	path string,
 	rootDir string,
 	accumulated *resources.ResourceSet,
 	refIndex map[string]resources.ResourceType,
 ) error {
-	// Validate YAML extension
-	if !ValidateYAMLFile(path) {
-		return fmt.Errorf("file %s does not have .yaml or .yml extension", path)
+	if l.skipIncluded(path) {
+		return nil
 	}
-
-	file, err := os.Open(path)
+	rs, err := l.parseFile(path, rootDir)
 	if err != nil {
-		return fmt.Errorf("failed to open file %s: %w", path, err)
+		return err
 	}
-	defer file.Close()
 
-	rs, err := l.parseYAML(file, path, rootDir)
+	// Append resources with duplicate checking
+	return l.appendSource(accumulated, rs, path, rootDir, refIndex, nil)
+}
+
+// parseFile parses a single YAML or JSON file without merging it into other sources
+func (l *Loader) parseFile(path string, rootDir string) (*resources.ResourceSet, error) {
+	content, err := readConfigFile(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return l.parseYAML(bytes.NewReader(content), path, rootDir)
+}
 
-	// Append resources with duplicate checking
-	return l.appendResourcesWithDuplicateCheck(accumulated, rs, path, refIndex)
+// readConfigFile reads a YAML or JSON configuration file
+func readConfigFile(path string) ([]byte, error) {
+	// Validate configuration file extension
+	if !ValidateConfigFile(path) {
+		return nil, fmt.Errorf("file %s does not have .yaml, .yml or .json extension", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	return content, nil
 }
 
-// parseYAML parses YAML content into ResourceSet
+// parseYAML parses YAML content into ResourceSet. JSON content, from a .json file or
+// a stdin document starting with a brace, is converted to YAML first.
 func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*resources.ResourceSet, error) {
 	var temp temporaryParseResult
 
@@ -198,6 +395,18 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 		return nil, fmt.Errorf("failed to read content from %s: %w", sourcePath, err)
 	}
 
+	raw := content
+	l.recordRefLines(sourcePath, raw)
+	if isJSONSource(sourcePath, content) {
+		if content, err = tags.ConvertJSON(content); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", sourcePath, err)
+		}
+	}
+	// Variables are replaced before tags resolve, so a !file path can use them
+	if content, err = l.interpolateVariables(content, sourcePath); err != nil {
+		return nil, err
+	}
+
 	// Process custom tags if needed
 	registry := l.getTagRegistry()
 
@@ -214,8 +423,15 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 
 	// Always register/update resolvers with correct base directory
 	// This ensures each file gets the correct base directory for relative paths
-	registry.Register(tags.NewFileTagResolver(baseDir, tagRootDir))
+	registry.Register(l.newFileTagResolver(baseDir, tagRootDir))
+	registry.Register(tags.NewBase64FileTagResolver(baseDir, tagRootDir))
+	registry.Register(tags.NewDirTagResolver(baseDir, tagRootDir))
 	registry.Register(tags.NewRefTagResolver(baseDir))
+	if !l.untrusted {
+		registry.Register(tags.NewEnvTagResolver())
+		registry.Register(tags.NewSecretTagResolver())
+	}
+	registry.Register(tags.NewMergeTagResolver())
 
 	if registry.HasResolvers() {
 		processedContent, err := registry.Process(content)
@@ -223,6 +439,9 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 			return nil, fmt.Errorf("failed to process tags in %s: %w", sourcePath, err)
 		}
 		content = processedContent
+		if err := checkFileValueTypes(sourcePath, raw, content); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := yaml.UnmarshalStrict(content, &temp); err != nil {
@@ -233,6 +452,11 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 			// Error format: "error unmarshaling JSON: while decoding JSON: json: unknown field \"fieldname\""
 			if match := regexp.MustCompile(`unknown field "(\w+)"`).FindStringSubmatch(errMsg); len(match) > 1 {
 				fieldName := match[1]
+				if key, version, ok := migrate.RenamedKey(fieldName); ok {
+					return nil, fmt.Errorf("field '%s' in %s was replaced by '%s' in %s %d. "+
+						"Run '%s migrate-config -f %s' to upgrade the file",
+						fieldName, sourcePath, key, migrate.VersionKey, version, meta.CLIName, sourcePath)
+				}
 				suggestion := l.suggestFieldName(fieldName)
 				if suggestion != "" {
 					return nil, fmt.Errorf("unknown field '%s' in %s. Did you mean '%s'?",
@@ -245,16 +469,39 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 		return nil, fmt.Errorf("failed to parse YAML in %s: %w", sourcePath, err)
 	}
 
+	if err := migrate.CheckVersion(temp.Version); err != nil {
+		return nil, fmt.Errorf("unsupported configuration version in %s: %w", sourcePath, err)
+	}
+
+	if err := l.recordIncludes(sourcePath, temp.Includes, baseDir); err != nil {
+		return nil, err
+	}
+
+	if err := temp.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid hooks in %s: %w", sourcePath, err)
+	}
+	l.hooks.Merge(temp.Hooks)
+
 	// Extract the clean ResourceSet
 	rs := temp.ResourceSet
 
 	// Apply file-level namespace and protected defaults
-	if err := l.applyNamespaceDefaults(&rs, temp.Defaults); err != nil {
+	if err := l.applyNamespaceDefaults(&rs, temp.Defaults, temp.Namespace); err != nil {
 		return nil, fmt.Errorf("failed to apply namespace defaults: %w", err)
 	}
 
 	// Extract nested child resources to root level first
 	l.extractNestedResources(&rs)
+
+	if err := l.recordFileRegion(&rs, temp.Defaults, sourcePath); err != nil {
+		return nil, err
+	}
+
+	if temp.Defaults != nil {
+		if err := applyLabelDefaults(&rs, temp.Defaults.Labels); err != nil {
+			return nil, fmt.Errorf("invalid _defaults.labels in %s: %w", sourcePath, err)
+		}
+	}
 	// Resolve deck config paths relative to the source file.
 	if err := l.resolveDeckConfigPaths(&rs, baseDir, tagRootDir); err != nil {
 		return nil, fmt.Errorf("failed to resolve deck config paths in %s: %w", sourcePath, err)
@@ -267,6 +514,14 @@ func (l *Loader) parseYAML(r io.Reader, sourcePath string, rootDir string) (*res
 	return &rs, nil
 }
 
+// isJSONSource reports whether content read from sourcePath is JSON
+func isJSONSource(sourcePath string, content []byte) bool {
+	if sourcePath == "stdin" {
+		return tags.IsJSON(content)
+	}
+	return ValidateJSONFile(sourcePath)
+}
+
 // loadSTDIN loads configuration from stdin
 func (l *Loader) loadSTDIN(
 	rootDir string,
@@ -289,10 +544,10 @@ func (l *Loader) loadSTDIN(
 	}
 
 	// Append resources with duplicate checking
-	return l.appendResourcesWithDuplicateCheck(accumulated, rs, "stdin", refIndex)
+	return l.appendSource(accumulated, rs, "stdin", rootDir, refIndex, nil)
 }
 
-// loadDirectorySource loads YAML files from a directory
+// loadDirectorySource loads YAML and JSON files from a directory
 func (l *Loader) loadDirectorySource(
 	dirPath string,
 	rootDir string,
@@ -303,7 +558,7 @@ func (l *Loader) loadDirectorySource(
 	yamlCount := 0
 	subdirCount := 0
 
-	// First, check direct YAML files in the directory
+	// First, check direct configuration files in the directory
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
 		return fmt.Errorf("failed to read directory %s: %w", dirPath, err)
@@ -323,12 +578,15 @@ func (l *Loader) loadDirectorySource(
 			continue
 		}
 
-		// Skip non-YAML files
-		if !ValidateYAMLFile(path) {
+		// Skip files that are neither YAML nor JSON
+		if !ValidateConfigFile(path) {
 			continue
 		}
 
 		yamlCount++
+		if l.skipIncluded(path) {
+			continue
+		}
 
 		// Load file without validation (will validate merged result later)
 		file, err := os.Open(path)
@@ -343,21 +601,21 @@ func (l *Loader) loadDirectorySource(
 		}
 
 		// Append resources with duplicate checking
-		if err := l.appendResourcesWithDuplicateCheck(accumulated, rs, path, refIndex); err != nil {
+		if err := l.appendSource(accumulated, rs, path, rootDir, refIndex, nil); err != nil {
 			return err
 		}
 
 	}
 
-	// Provide helpful error if no YAML files found
+	// Provide helpful error if no configuration files found
 	if yamlCount == 0 && subdirCount > 0 && !recursive {
-		return fmt.Errorf("no YAML files found in directory '%s'. Found %d subdirectories. "+
+		return fmt.Errorf("no YAML or JSON files found in directory '%s'. Found %d subdirectories. "+
 			"Use -R to search subdirectories", dirPath, subdirCount)
 	} else if yamlCount == 0 {
 		// Check if accumulated has any resources using the registry
 		if accumulated.IsEmpty() {
 			// Only error if no files were found at all (not just empty files)
-			return fmt.Errorf("no YAML files found in directory '%s'", dirPath)
+			return fmt.Errorf("no YAML or JSON files found in directory '%s'", dirPath)
 		}
 	}
 
@@ -366,16 +624,20 @@ func (l *Loader) loadDirectorySource(
 
 // appendResourcesWithDuplicateCheck appends resources from source to accumulated with global duplicate checking.
 // The refIndex is a running index maintained across all files for O(1) duplicate lookups.
+// A resource replaces the accumulated resource of the same ref and type when the file
+// defining that one is overridable.
 func (l *Loader) appendResourcesWithDuplicateCheck(
 	accumulated, source *resources.ResourceSet,
 	sourcePath string,
 	refIndex map[string]resources.ResourceType,
+	overridable map[string]bool,
 ) error {
 	// Check for duplicate refs
 	// We need to check both:
 	// 1. Duplicates within the source file itself
 	// 2. Duplicates between source and accumulated (using refIndex)
 	seenRefs := make(map[string]resources.ResourceType, source.ResourceCount())
+	overridden := make(map[string]resources.ResourceType)
 	var duplicateErr error
 
 	source.ForEachResource(func(r resources.Resource) bool {
@@ -384,16 +646,18 @@ func (l *Loader) appendResourcesWithDuplicateCheck(
 
 		// Check for duplicate within the same source file
 		if existingType, exists := seenRefs[ref]; exists {
-			duplicateErr = fmt.Errorf("duplicate ref '%s' found in %s (already defined as %s)",
-				ref, sourcePath, existingType)
+			duplicateErr = l.duplicateRefError(ref, sourcePath, resourceType, sourcePath, existingType)
 			return false
 		}
 		seenRefs[ref] = resourceType
 
 		// Check for duplicate against accumulated resources - O(1) lookup using running index
 		if existingType, exists := refIndex[ref]; exists {
-			duplicateErr = fmt.Errorf("duplicate ref '%s' found in %s (already defined as %s)",
-				ref, sourcePath, existingType)
+			if existingType == resourceType && overridable[fileKey(l.refSources[ref])] {
+				overridden[ref] = resourceType
+				return true
+			}
+			duplicateErr = l.duplicateRefError(ref, sourcePath, resourceType, l.refSources[ref], existingType)
 			return false
 		}
 		return true
@@ -403,13 +667,23 @@ func (l *Loader) appendResourcesWithDuplicateCheck(
 		return duplicateErr
 	}
 
+	// Resources of included files are replaced by the resources overriding them
+	if len(overridden) > 0 {
+		accumulated.RetainResources(func(r resources.Resource) bool {
+			resourceType, ok := overridden[r.GetRef()]
+			return !ok || r.GetType() != resourceType
+		})
+	}
+
 	// Append all resources from source to accumulated using the registry
 	accumulated.AppendAll(source)
+	accumulated.Moved = append(accumulated.Moved, source.Moved...)
 
 	// Update the running index with newly added refs
 	for ref, resourceType := range seenRefs {
 		refIndex[ref] = resourceType
 	}
+	l.recordRefSources(seenRefs, sourcePath)
 
 	// If this source defines a namespace default without parent resources,
 	// propagate it so sync mode can inspect the correct namespace.
@@ -418,7 +692,8 @@ func (l *Loader) appendResourcesWithDuplicateCheck(
 			len(source.ApplicationAuthStrategies) +
 			len(source.ControlPlanes) +
 			len(source.APIs) +
-			len(source.OrganizationTeams)
+			len(source.OrganizationTeams) +
+			len(source.CustomResources)
 
 		if parentCount == 0 {
 			accumulated.AddDefaultNamespace(source.DefaultNamespace)
@@ -428,13 +703,117 @@ func (l *Loader) appendResourcesWithDuplicateCheck(
 	return nil
 }
 
-// applyNamespaceDefaults applies file-level namespace and protected defaults to parent resources
-func (l *Loader) applyNamespaceDefaults(rs *resources.ResourceSet, fileDefaults *resources.FileDefaults) error {
+// recordRefSources remembers the file each ref was loaded from
+func (l *Loader) recordRefSources(refs map[string]resources.ResourceType, sourcePath string) {
+	if l.refSources == nil {
+		l.refSources = make(map[string]string, len(refs))
+	}
+	for ref := range refs {
+		l.refSources[ref] = sourcePath
+	}
+}
+
+// RefSources returns the source file of every resource ref loaded so far.
+// Resources read from stdin map to "stdin".
+func (l *Loader) RefSources() map[string]string {
+	return maps.Clone(l.refSources)
+}
+
+// Hooks returns the hooks the sources of the last load declare, in source order
+func (l *Loader) Hooks() *hooks.Config {
+	return &l.hooks
+}
+
+// RefDefinition returns the source file and line defining a resource ref, with a zero
+// line when it is unknown, and an empty path when the ref was not loaded.
+func (l *Loader) RefDefinition(ref string) (string, int) {
+	sourcePath, ok := l.refSources[ref]
+	if !ok {
+		return "", 0
+	}
+	if lines := l.refLines[sourcePath][ref]; len(lines) > 0 {
+		return sourcePath, lines[0]
+	}
+	return sourcePath, 0
+}
+
+// applyLabelDefaults merges default labels into every managed resource that supports
+// labels. Labels already set on a resource win on key conflicts.
+func applyLabelDefaults(rs *resources.ResourceSet, defaults map[string]string) error {
+	if len(defaults) == 0 {
+		return nil
+	}
+	for key := range defaults {
+		if labels.IsKongctlLabel(key) {
+			return fmt.Errorf("label key %s is reserved for kongctl", key)
+		}
+		if err := labels.ValidateLabel(key); err != nil {
+			return err
+		}
+	}
+
+	rs.ForEachResource(func(r resources.Resource) bool {
+		labeled, ok := r.(resources.ResourceWithLabels)
+		if !ok {
+			return true
+		}
+		if external, ok := r.(interface{ IsExternal() bool }); ok && external.IsExternal() {
+			return true
+		}
+		merged := maps.Clone(defaults)
+		maps.Copy(merged, labeled.GetLabels())
+		labeled.SetLabels(merged)
+		return true
+	})
+	return nil
+}
+
+// applyNamespaceDefaults applies file-level namespace and protected defaults to parent resources.
+// The active namespace and the file's top-level namespace scope the file: resources without a
+// namespace inherit it, and resources of another namespace are rejected.
+func (l *Loader) applyNamespaceDefaults(
+	rs *resources.ResourceSet,
+	fileDefaults *resources.FileDefaults,
+	fileNamespace *string,
+) error {
 	// Determine the effective namespace default
 	defaultNamespace := "default"
 	namespaceDefault := &defaultNamespace
+	defaultOrigin := resources.NamespaceOriginImplicitDefault
 	var protectedDefault *bool
-	hasFileNamespaceDefault := false
+
+	type namespaceScope struct {
+		namespace string
+		source    string
+	}
+	var scopes []namespaceScope
+	checkScopes := func(namespace, subject string) error {
+		for _, scope := range scopes {
+			if namespace != scope.namespace {
+				return fmt.Errorf("%s is in namespace '%s' but %s is '%s'",
+					subject, namespace, scope.source, scope.namespace)
+			}
+		}
+		return nil
+	}
+
+	if l.namespace != "" {
+		active := l.namespace
+		namespaceDefault = &active
+		defaultOrigin = resources.NamespaceOriginActive
+		scopes = append(scopes, namespaceScope{namespace: active, source: "the active namespace"})
+	}
+	if fileNamespace != nil {
+		if *fileNamespace == "" {
+			return fmt.Errorf("namespace cannot be empty")
+		}
+		if err := checkScopes(*fileNamespace, "the file"); err != nil {
+			return err
+		}
+		namespaceDefault = fileNamespace
+		defaultOrigin = resources.NamespaceOriginActive
+		scopes = append(scopes, namespaceScope{namespace: *fileNamespace, source: "the file namespace"})
+	}
 
 	if fileDefaults != nil && fileDefaults.Kongctl != nil {
 		// Validate that namespace default is not empty
@@ -442,12 +821,17 @@ func (l *Loader) applyNamespaceDefaults(rs *resources.ResourceSet, fileDefaults
 			return fmt.Errorf("namespace in _defaults.kongctl cannot be empty")
 		}
 		if fileDefaults.Kongctl.Namespace != nil {
+			if err := checkScopes(*fileDefaults.Kongctl.Namespace, "_defaults.kongctl"); err != nil {
+				return err
+			}
 			namespaceDefault = fileDefaults.Kongctl.Namespace
-			rs.AddDefaultNamespace(*namespaceDefault)
-			hasFileNamespaceDefault = true
+			defaultOrigin = resources.NamespaceOriginFileDefault
 		}
 		protectedDefault = fileDefaults.Kongctl.Protected
 	}
+	if defaultOrigin != resources.NamespaceOriginImplicitDefault {
+		rs.AddDefaultNamespace(*namespaceDefault)
+	}
 
 	assignNamespace := func(meta **resources.KongctlMeta, resourceType, resourceRef string) error {
 		if *meta == nil {
@@ -462,17 +846,12 @@ func (l *Loader) applyNamespaceDefaults(rs *resources.ResourceSet, fileDefaults
 
 		if m.Namespace != nil {
 			m.NamespaceOrigin = resources.NamespaceOriginExplicit
-			return nil
-		}
-
-		m.Namespace = namespaceDefault
-		if hasFileNamespaceDefault {
-			m.NamespaceOrigin = resources.NamespaceOriginFileDefault
 		} else {
-			m.NamespaceOrigin = resources.NamespaceOriginImplicitDefault
+			m.Namespace = namespaceDefault
+			m.NamespaceOrigin = defaultOrigin
 		}
 
-		return nil
+		return checkScopes(*m.Namespace, fmt.Sprintf("%s '%s'", resourceType, resourceRef))
 	}
 
 	// Apply defaults to portals (parent resources)
@@ -617,6 +996,23 @@ func (l *Loader) applyNamespaceDefaults(rs *resources.ResourceSet, fileDefaults
 		}
 	}
 
+	// Apply namespace defaults to custom resources
+	for i := range rs.CustomResources {
+		if err := assignNamespace(&rs.CustomResources[i].Kongctl, rs.CustomResources[i].Kind,
+			rs.CustomResources[i].Ref); err != nil {
+			return err
+		}
+		// Apply protected default if not set
+		if rs.CustomResources[i].Kongctl.Protected == nil && protectedDefault != nil {
+			rs.CustomResources[i].Kongctl.Protected = protectedDefault
+		}
+		// Ensure protected has a value (false if still nil)
+		if rs.CustomResources[i].Kongctl.Protected == nil {
+			falseVal := false
+			rs.CustomResources[i].Kongctl.Protected = &falseVal
+		}
+	}
+
 	// Note: Child resources (API versions, publications, etc.) do not get kongctl metadata
 	// as Konnect doesn't support labels on child resources
 	return nil
@@ -669,8 +1065,23 @@ func (l *Loader) extractNestedResources(rs *resources.ResourceSet) {
 	// Extract organization nested resources
 	if rs.Organization != nil {
 		org := rs.Organization
-		// Extract organization teams from organization
-		rs.OrganizationTeams = append(rs.OrganizationTeams, org.Teams...)
+		// Extract organization teams from organization, with their roles and members
+		for i := range org.Teams {
+			team := org.Teams[i]
+			for j := range team.Roles {
+				role := team.Roles[j]
+				role.Team = team.Ref
+				rs.OrganizationTeamRoles = append(rs.OrganizationTeamRoles, role)
+			}
+			for j := range team.Members {
+				member := team.Members[j]
+				member.Team = team.Ref
+				rs.OrganizationTeamMembers = append(rs.OrganizationTeamMembers, member)
+			}
+			team.Roles = nil
+			team.Members = nil
+			rs.OrganizationTeams = append(rs.OrganizationTeams, team)
+		}
 
 		org.Teams = nil
 	}
@@ -793,6 +1204,13 @@ func (l *Loader) extractNestedResources(rs *resources.ResourceSet) {
 			rs.PortalEmailConfigs = append(rs.PortalEmailConfigs, cfg)
 		}
 
+		// Extract audit log webhook (singleton)
+		if portal.AuditLogWebhook != nil {
+			webhook := *portal.AuditLogWebhook
+			webhook.Portal = portal.Ref
+			rs.PortalAuditLogWebhooks = append(rs.PortalAuditLogWebhooks, webhook)
+		}
+
 		// Extract email templates (map keyed by template name)
 		for key, tpl := range portal.EmailTemplates {
 			if tpl.Name == "" {
@@ -814,6 +1232,7 @@ func (l *Loader) extractNestedResources(rs *resources.ResourceSet) {
 		portal.Teams = nil
 		portal.EmailConfig = nil
 		portal.EmailTemplates = nil
+		portal.AuditLogWebhook = nil
 	}
 }
 
@@ -857,6 +1276,7 @@ func (l *Loader) suggestFieldName(fieldName string) string {
 		"kongctl":       {"kong_ctl", "kong-ctl", "kongcontrol"},
 		"namespace":     {"namspace", "namesapce", "ns"},
 		"protected":     {"protect", "potected", "proteced"},
+		"ignore_fields": {"ignore_field", "ignored_fields", "ignore-fields"},
 		"is_public":     {"public", "ispublic", "is-public"},
 		"custom_domain": {"domain", "customdomain", "custom-domain"},
 		"customization": {"customize", "custom", "theme"},
@@ -1,11 +1,13 @@
 package loader
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/kong/kongctl/internal/declarative/hooks"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -149,6 +151,44 @@ func TestLoader_LoadFile_FileNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to open file")
 }
 
+func TestLoader_LoadFile_Version(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "current version", content: "_version: 2\nportals:\n  - ref: p\n    name: P\n"},
+		{name: "no version", content: "portals:\n  - ref: p\n    name: P\n"},
+		{name: "earlier version", content: "_version: 1\n", wantErr: "run 'kongctl migrate-config'"},
+		{name: "newer version", content: "_version: 3\n", wantErr: "upgrade kongctl"},
+		{
+			name:    "renamed key",
+			content: "organization_teams:\n  - ref: t\n    name: T\n",
+			wantErr: "field 'organization_teams' in %s was replaced by 'organization.teams' in _version 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			rs, err := New().LoadFile(path)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.Len(t, rs.Portals, 1)
+				return
+			}
+			require.Error(t, err)
+			wantErr := tt.wantErr
+			if strings.Contains(wantErr, "%s") {
+				wantErr = fmt.Sprintf(wantErr, path)
+			}
+			assert.Contains(t, err.Error(), wantErr)
+		})
+	}
+}
+
 func TestLoader_LoadFile_DefaultValues(t *testing.T) {
 	loader := New()
 	filePath := filepath.Join("testdata", "valid", "simple-portal.yaml")
@@ -185,6 +225,135 @@ portals:
 	assert.Equal(t, "portal-email-config", rs.PortalEmailConfigs[0].Ref)
 }
 
+func TestLoader_FlattensPortalAuditLogWebhook(t *testing.T) {
+	content := `
+portals:
+  - ref: portal-audit
+    name: portal-audit
+    audit_log_webhook:
+      ref: portal-audit-webhook
+      enabled: true
+      audit_log_destination_id: "dest-1"
+`
+
+	loader := New()
+	rs, err := loader.parseYAML(strings.NewReader(content), "inline", "")
+	require.NoError(t, err)
+
+	require.Len(t, rs.Portals, 1)
+	assert.Nil(t, rs.Portals[0].AuditLogWebhook)
+	require.Len(t, rs.PortalAuditLogWebhooks, 1)
+	webhook := rs.PortalAuditLogWebhooks[0]
+	assert.Equal(t, "portal-audit", webhook.Portal)
+	assert.Equal(t, "portal-audit-webhook", webhook.Ref)
+	assert.True(t, webhook.GetEnabledValue())
+	assert.Equal(t, "dest-1", webhook.GetDestinationID())
+}
+
+func TestLoader_PortalAuditLogWebhookRequiresDestinationWhenEnabled(t *testing.T) {
+	content := `
+portals:
+  - ref: portal-audit
+    name: portal-audit
+    audit_log_webhook:
+      ref: portal-audit-webhook
+      enabled: true
+`
+
+	loader := New()
+	rs, err := loader.parseYAML(strings.NewReader(content), "inline", "")
+	require.NoError(t, err)
+	require.ErrorContains(t, loader.validateResourceSet(rs), "audit_log_destination_id is required")
+}
+
+func TestLoader_FlattensOrganizationTeamChildren(t *testing.T) {
+	content := `
+organization:
+  teams:
+    - ref: platform
+      name: Platform
+      roles:
+        - ref: platform-cp-admin
+          role_name: Admin
+          entity_id: "*"
+          entity_type_name: Control Planes
+          entity_region: us
+      members:
+        - ref: platform-dev
+          email: dev@example.com
+`
+
+	loader := New()
+	rs, err := loader.parseYAML(strings.NewReader(content), "inline", "")
+	require.NoError(t, err)
+	require.NoError(t, loader.validateResourceSet(rs))
+
+	require.Len(t, rs.OrganizationTeams, 1)
+	assert.Nil(t, rs.OrganizationTeams[0].Roles)
+	assert.Nil(t, rs.OrganizationTeams[0].Members)
+	require.Len(t, rs.OrganizationTeamRoles, 1)
+	assert.Equal(t, "platform", rs.OrganizationTeamRoles[0].Team)
+	require.Len(t, rs.OrganizationTeamMembers, 1)
+	assert.Equal(t, "platform", rs.OrganizationTeamMembers[0].Team)
+}
+
+func TestLoader_OrganizationTeamChildrenValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		team    string
+		wantErr string
+	}{
+		{
+			name: "external team",
+			team: `
+    - ref: platform
+      name: Platform
+      _external:
+        selector:
+          matchFields:
+            name: Platform
+      members:
+        - ref: platform-dev
+          email: dev@example.com
+`,
+			wantErr: "external",
+		},
+		{
+			name: "duplicate member",
+			team: `
+    - ref: platform
+      name: Platform
+      members:
+        - ref: platform-dev
+          email: dev@example.com
+        - ref: platform-dev-again
+          email: DEV@example.com
+`,
+			wantErr: "duplicate organization_team_member",
+		},
+		{
+			name: "invalid email",
+			team: `
+    - ref: platform
+      name: Platform
+      members:
+        - ref: platform-dev
+          email: dev
+`,
+			wantErr: "email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := New()
+			rs, err := loader.parseYAML(strings.NewReader("organization:\n  teams:"+tt.team), "inline", "")
+			require.NoError(t, err)
+			require.ErrorContains(t, loader.validateResourceSet(rs), tt.wantErr)
+		})
+	}
+}
+
 func TestLoader_LoadFile_PortalEmailConfigFlattening(t *testing.T) {
 	loader := New()
 	rs, err := loader.LoadFile(filepath.Join(
@@ -374,7 +543,7 @@ portals:
 	sources := []Source{{Path: tmpDir, Type: SourceTypeDirectory}}
 	_, err = loader.LoadFromSources(sources, false)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "no YAML files found")
+	assert.Contains(t, err.Error(), "no YAML or JSON files found")
 	assert.Contains(t, err.Error(), "Use -R to search subdirectories")
 
 	// Test with recursive - should succeed
@@ -393,6 +562,166 @@ func TestLoader_LoadFromSources_DuplicateDetection(t *testing.T) {
 	assert.Error(t, err, "Should fail due to duplicate refs")
 	assert.Nil(t, rs)
 	assert.Contains(t, err.Error(), "duplicate")
+	assert.Contains(t, err.Error(), filepath.Join("testdata", "multifile-duplicates", "file1.yaml"))
+	assert.Contains(t, err.Error(), filepath.Join("testdata", "multifile-duplicates", "file2.yaml"))
+}
+
+func TestLoader_LoadFromSources_CrossDirectoryReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "portals"), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "apis"), 0o755))
+
+	portalsYAML := `
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+`
+	apiYAML := `
+apis:
+  - ref: foo-api
+    name: "Foo API"
+    publications:
+      - ref: foo-api-publication
+        portal_id: !ref main-portal#id
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "portals", "main.yaml"), []byte(portalsYAML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "apis", "foo.yml"), []byte(apiYAML), 0o600))
+
+	rs, err := New().LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, true)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	require.Len(t, rs.APIs, 1)
+	require.Len(t, rs.APIPublications, 1)
+	assert.Equal(t, "foo-api", rs.APIPublications[0].API)
+	assert.Contains(t, rs.APIPublications[0].PortalID, "main-portal")
+
+	// A ref defined twice in different directories names both files
+	duplicateYAML := `
+portals:
+  - ref: main-portal
+    name: "Other Portal"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "apis", "portal.yaml"), []byte(duplicateYAML), 0o600))
+	_, err = New().LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(tmpDir, "apis", "portal.yaml"))
+	assert.Contains(t, err.Error(), filepath.Join(tmpDir, "portals", "main.yaml"))
+}
+
+func TestLoader_LoadFromSources_JSONMatchesYAML(t *testing.T) {
+	configYAML := `
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    display_name: !file portal.txt
+apis:
+  - ref: foo-api
+    name: "Foo API"
+    description: !ref main-portal#display_name
+    publications:
+      - ref: foo-api-publication
+        portal_id: !ref main-portal#id
+        visibility: public
+`
+	configJSON := `{
+  "portals": [
+    {"ref": "main-portal", "name": "Main Portal", "display_name": {"$file": "portal.txt"}}
+  ],
+  "apis": [
+    {
+      "ref": "foo-api",
+      "name": "Foo API",
+      "description": {"$ref": "main-portal#display_name"},
+      "publications": [
+        {"ref": "foo-api-publication", "portal_id": {"$ref": "main-portal#id"}, "visibility": "public"}
+      ]
+    }
+  ]
+}`
+
+	load := func(name, content string) *resources.ResourceSet {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "portal.txt"), []byte("Developer Portal"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		rs, err := New().LoadFromSources([]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+		require.NoError(t, err)
+		return rs
+	}
+
+	fromYAML := load("config.yaml", configYAML)
+	fromJSON := load("config.json", configJSON)
+	assert.Equal(t, fromYAML, fromJSON)
+	require.Len(t, fromJSON.Portals, 1)
+	require.NotNil(t, fromJSON.Portals[0].DisplayName)
+	assert.Equal(t, "Developer Portal", *fromJSON.Portals[0].DisplayName)
+}
+
+func TestLoader_LoadFromSources_MixedJSONAndYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	portalsJSON := `{"portals": [{"ref": "main-portal", "name": "Main Portal"}]}`
+	apiYAML := `
+apis:
+  - ref: foo-api
+    name: "Foo API"
+    publications:
+      - ref: foo-api-publication
+        portal_id: !ref main-portal#id
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "portals.json"), []byte(portalsJSON), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "apis.yaml"), []byte(apiYAML), 0o600))
+
+	rs, err := New().LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	require.Len(t, rs.APIPublications, 1)
+	assert.Contains(t, rs.APIPublications[0].PortalID, "main-portal")
+
+	// JSON files are validated as strictly as YAML ones, e.g. for syntax errors
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "broken.json"), []byte(`{"portals": [`), 0o600))
+	_, err = New().LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse JSON")
+}
+
+func TestLoader_LoadFromSources_STDINWithBaseDir(t *testing.T) {
+	baseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "docs", "portal.md"), []byte("Portal docs"), 0o600))
+
+	config := `
+portals:
+  - ref: main-portal
+    name: "Main Portal"
+    description: !file docs/portal.md
+apis:
+  - ref: foo-api
+    name: "Foo API"
+    publications:
+      - ref: foo-api-publication
+        portal_id: !ref main-portal#id
+`
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = writer.WriteString(config)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	stdin := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		reader.Close()
+	})
+
+	// Relative !file paths of stdin resolve from the base directory, not the working directory
+	rs, err := NewWithBaseDir(baseDir).LoadFromSources([]Source{{Path: "-", Type: SourceTypeSTDIN}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	require.NotNil(t, rs.Portals[0].Description)
+	assert.Equal(t, "Portal docs", *rs.Portals[0].Description)
+	require.Len(t, rs.APIPublications, 1)
+	assert.Contains(t, rs.APIPublications[0].PortalID, "main-portal")
 }
 
 func TestLoader_LoadFromSources_NameDuplicateDetection(t *testing.T) {
@@ -485,6 +814,7 @@ func TestLoader_ValidateYAMLFile(t *testing.T) {
 		{"file.yaml.bak", false},
 		{".yaml", true},
 		{".yml", true},
+		{"file.json", false},
 	}
 
 	for _, tt := range tests {
@@ -502,7 +832,7 @@ func TestLoader_LoadFile_NonYAMLExtension(t *testing.T) {
 	rs, err := loader.LoadFile("testdata/test.txt")
 	assert.Error(t, err)
 	assert.Nil(t, rs)
-	assert.Contains(t, err.Error(), "does not have .yaml or .yml extension")
+	assert.Contains(t, err.Error(), "does not have .yaml, .yml or .json extension")
 }
 
 func TestLoader_LoadFile_UnknownFields(t *testing.T) {
@@ -542,3 +872,31 @@ func TestLoader_LoadFile_UnknownFields(t *testing.T) {
 		})
 	}
 }
+
+func TestLoader_Hooks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`
+hooks:
+  on_success:
+    - url: https://hooks.example.com/a
+      format: slack
+portals:
+  - ref: portal
+    name: portal
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(`
+hooks:
+  on_failure:
+    - command: ./notify.sh
+`), 0o600))
+
+	ldr := New()
+	_, err := ldr.LoadFromSources([]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []hooks.Hook{{URL: "https://hooks.example.com/a", Format: hooks.FormatSlack}}, ldr.Hooks().OnSuccess)
+	assert.Equal(t, []hooks.Hook{{Command: "./notify.sh"}}, ldr.Hooks().OnFailure)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("hooks:\n  on_failure:\n    - {}\n"), 0o600))
+	_, err = ldr.LoadFromSources([]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+	require.ErrorContains(t, err, "invalid hooks in "+filepath.Join(dir, "b.yaml"))
+}
@@ -442,7 +442,96 @@ func TestApplyNamespaceDefaultsExternalWithKongctlFails(t *testing.T) {
 		},
 	}
 
-	err := l.applyNamespaceDefaults(rs, nil)
+	err := l.applyNamespaceDefaults(rs, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "portal 'external-portal' is marked as external")
 }
+
+func TestActiveNamespace(t *testing.T) {
+	load := func(t *testing.T, namespace, content string) (*resources.ResourceSet, error) {
+		t.Helper()
+		file := filepath.Join(t.TempDir(), "test.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		l := New()
+		l.SetNamespace(namespace)
+		return l.LoadFromSources([]Source{{Path: file, Type: SourceTypeFile}}, false)
+	}
+
+	t.Run("resources without a namespace join the active namespace", func(t *testing.T) {
+		rs, err := load(t, "team-a", `
+portals:
+  - ref: portal1
+    name: "Portal 1"
+`)
+		require.NoError(t, err)
+		require.Len(t, rs.Portals, 1)
+		assert.Equal(t, "team-a", *rs.Portals[0].Kongctl.Namespace)
+		assert.Equal(t, resources.NamespaceOriginActive, rs.Portals[0].Kongctl.NamespaceOrigin)
+	})
+
+	t.Run("an empty configuration is planned in the active namespace", func(t *testing.T) {
+		rs, err := load(t, "team-a", "portals: []\n")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a"}, rs.DefaultNamespaces)
+	})
+
+	t.Run("resources of another namespace are rejected", func(t *testing.T) {
+		_, err := load(t, "team-a", `
+apis:
+  - ref: api1
+    name: "API 1"
+    kongctl:
+      namespace: team-b
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "api 'api1' is in namespace 'team-b' but the active namespace is 'team-a'")
+	})
+
+	t.Run("file defaults of another namespace are rejected", func(t *testing.T) {
+		_, err := load(t, "team-a", `
+_defaults:
+  kongctl:
+    namespace: team-b
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "_defaults.kongctl is in namespace 'team-b' but the active namespace is 'team-a'")
+	})
+
+	t.Run("top-level namespace scopes the file", func(t *testing.T) {
+		rs, err := load(t, "", `
+namespace: team-a
+portals:
+  - ref: portal1
+    name: "Portal 1"
+`)
+		require.NoError(t, err)
+		assert.Equal(t, "team-a", *rs.Portals[0].Kongctl.Namespace)
+
+		_, err = load(t, "", `
+namespace: team-a
+portals:
+  - ref: portal1
+    name: "Portal 1"
+    kongctl:
+      namespace: team-b
+`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is in namespace 'team-b' but the file namespace is 'team-a'")
+
+		_, err = load(t, "team-b", "namespace: team-a\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the file is in namespace 'team-a' but the active namespace is 'team-b'")
+	})
+
+	t.Run("without an active namespace the default is unchanged", func(t *testing.T) {
+		rs, err := load(t, "", `
+portals:
+  - ref: portal1
+    name: "Portal 1"
+`)
+		require.NoError(t, err)
+		assert.Equal(t, "default", *rs.Portals[0].Kongctl.Namespace)
+		assert.Equal(t, resources.NamespaceOriginImplicitDefault, rs.Portals[0].Kongctl.NamespaceOrigin)
+		assert.Empty(t, rs.DefaultNamespaces)
+	})
+}
@@ -222,6 +222,13 @@ func ResolveReferences(ctx context.Context, rs *resources.ResourceSet) error {
 		processCount++
 	}
 
+	for i := range rs.APIVersions {
+		if err := resolveResourceFields(ctx, &rs.APIVersions[i], rs, resolver, resolutionPath, logger); err != nil {
+			return fmt.Errorf("resolving api version %s: %w", rs.APIVersions[i].GetRef(), err)
+		}
+		processCount++
+	}
+
 	for i := range rs.APIDocuments {
 		if err := resolveResourceFields(ctx, &rs.APIDocuments[i], rs, resolver, resolutionPath, logger); err != nil {
 			return fmt.Errorf("resolving api document %s: %w", rs.APIDocuments[i].GetRef(), err)
@@ -296,68 +303,17 @@ func walkAndResolve(ctx context.Context, val reflect.Value, rs *resources.Resour
 	case reflect.String:
 		str := val.String()
 		if tags.IsRefPlaceholder(str) {
-			refStr, field, ok := tags.ParseRefPlaceholder(str)
-			if !ok {
-				logger.LogAttrs(ctx, slog.LevelWarn, "Invalid placeholder format",
-					slog.String("placeholder", str),
-					slog.String("resource", currentResourceRef),
-				)
-				return fmt.Errorf("invalid placeholder: %s", str)
-			}
-
-			logger.LogAttrs(ctx, slog.LevelDebug, "Found reference placeholder",
-				slog.String("placeholder", str),
-				slog.String("target_ref", refStr),
-				slog.String("target_field", field),
-				slog.String("source_resource", currentResourceRef),
-			)
-
-			// Check for circular dependency
-			pathKey := fmt.Sprintf("%s->%s#%s", currentResourceRef, refStr, field)
-			for _, p := range resolutionPath {
-				if p == pathKey {
-					logger.LogAttrs(ctx, slog.LevelError, "Circular reference detected",
-						slog.String("path", strings.Join(append(resolutionPath, pathKey), " -> ")),
-					)
-					return fmt.Errorf("circular reference: %s", strings.Join(append(resolutionPath, pathKey), " -> "))
-				}
-			}
-
-			// Resolve the reference
-			target, exists := rs.GetResourceByRef(refStr)
-			if !exists {
-				logger.LogAttrs(ctx, slog.LevelWarn, "Referenced resource not found",
-					slog.String("ref", refStr),
-					slog.String("source_resource", currentResourceRef),
-				)
-				return fmt.Errorf("resource not found: %s", refStr)
-			}
-
-			logger.LogAttrs(ctx, log.LevelTrace, "Found target resource",
-				slog.String("target_ref", refStr),
-				slog.String("target_type", string(target.GetType())),
-				slog.String("requesting_field", field),
-			)
-
-			// Extract field value
-			value, err := resolver.ResolveField(target, field)
+			value, resolved, err := resolvePlaceholder(ctx, str, rs, resolver, resolutionPath, currentResourceRef, logger)
 			if err != nil {
-				// Field not available in config - keep placeholder for runtime resolution
-				logger.LogAttrs(ctx, slog.LevelDebug, "Field not available in config, deferring resolution",
-					slog.String("resource_ref", refStr),
-					slog.String("field", field),
-					slog.String("error", err.Error()),
-				)
-				break // Keep the __REF__: placeholder unchanged
+				return err
 			}
 
 			// Set the resolved value
-			if val.CanSet() {
+			if resolved && val.CanSet() {
 				val.SetString(value)
 				logger.LogAttrs(ctx, slog.LevelDebug, "Reference resolved",
 					slog.String("source_resource", currentResourceRef),
-					slog.String("target_ref", refStr),
-					slog.String("field", field),
+					slog.String("placeholder", str),
 					slog.String("resolved_value", value),
 				)
 			}
@@ -410,6 +366,88 @@ func walkAndResolve(ctx context.Context, val reflect.Value, rs *resources.Resour
 	return nil
 }
 
+// circularReferenceError reports !ref tags that lead back to themselves. ref is the
+// resource where the loop closes.
+type circularReferenceError struct {
+	ref  string
+	path []string
+}
+
+func (e *circularReferenceError) Error() string {
+	return "circular reference: " + strings.Join(e.path, " -> ")
+}
+
+// resolvePlaceholder resolves a ref placeholder found on currentResourceRef. A target
+// field that holds a placeholder itself is followed, with the key of each hop recorded
+// on resolutionPath so that a loop between resources is reported as a circular
+// reference instead of copying an unresolved placeholder. It reports false when the
+// field is not available in config and resolution is deferred to planning.
+func resolvePlaceholder(ctx context.Context, str string, rs *resources.ResourceSet,
+	resolver FieldResolver, resolutionPath []string, currentResourceRef string, logger *slog.Logger,
+) (string, bool, error) {
+	refStr, field, ok := tags.ParseRefPlaceholder(str)
+	if !ok {
+		logger.LogAttrs(ctx, slog.LevelWarn, "Invalid placeholder format",
+			slog.String("placeholder", str),
+			slog.String("resource", currentResourceRef),
+		)
+		return "", false, fmt.Errorf("invalid placeholder: %s", str)
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "Found reference placeholder",
+		slog.String("placeholder", str),
+		slog.String("target_ref", refStr),
+		slog.String("target_field", field),
+		slog.String("source_resource", currentResourceRef),
+	)
+
+	// Check for circular dependency
+	pathKey := fmt.Sprintf("%s->%s#%s", currentResourceRef, refStr, field)
+	for _, p := range resolutionPath {
+		if p == pathKey {
+			logger.LogAttrs(ctx, slog.LevelError, "Circular reference detected",
+				slog.String("path", strings.Join(append(resolutionPath, pathKey), " -> ")),
+			)
+			return "", false, &circularReferenceError{ref: currentResourceRef, path: append(resolutionPath, pathKey)}
+		}
+	}
+
+	// Resolve the reference
+	target, exists := rs.GetResourceByRef(refStr)
+	if !exists {
+		logger.LogAttrs(ctx, slog.LevelWarn, "Referenced resource not found",
+			slog.String("ref", refStr),
+			slog.String("source_resource", currentResourceRef),
+		)
+		return "", false, fmt.Errorf("resource not found: %s", refStr)
+	}
+
+	logger.LogAttrs(ctx, log.LevelTrace, "Found target resource",
+		slog.String("target_ref", refStr),
+		slog.String("target_type", string(target.GetType())),
+		slog.String("requesting_field", field),
+	)
+
+	// Extract field value
+	value, err := resolver.ResolveField(target, field)
+	if err != nil {
+		// Field not available in config - keep placeholder for runtime resolution
+		logger.LogAttrs(ctx, slog.LevelDebug, "Field not available in config, deferring resolution",
+			slog.String("resource_ref", refStr),
+			slog.String("field", field),
+			slog.String("error", err.Error()),
+		)
+		return "", false, nil
+	}
+
+	if tags.IsRefPlaceholder(value) {
+		path := append(append(make([]string, 0, len(resolutionPath)+1), resolutionPath...), pathKey)
+		return resolvePlaceholder(ctx, value, rs, resolver, path, refStr, logger)
+	}
+
+	return value, true, nil
+}
+
 // findFieldByJSONTag finds a struct field by its JSON tag
 func findFieldByJSONTag(val reflect.Value, jsonTag string) reflect.Value {
 	t := val.Type()
@@ -423,6 +461,24 @@ func findFieldByJSONTag(val reflect.Value, jsonTag string) reflect.Value {
 			return val.Field(i)
 		}
 	}
+
+	// Fields of embedded SDK structs are inlined in the resource
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		embedded := val.Field(i)
+		for embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+			embedded = embedded.Elem()
+		}
+		if embedded.Kind() != reflect.Struct {
+			continue
+		}
+		if found := findFieldByJSONTag(embedded, jsonTag); found.IsValid() {
+			return found
+		}
+	}
 	return reflect.Value{}
 }
 
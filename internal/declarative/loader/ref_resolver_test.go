@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/tags"
 	"github.com/kong/kongctl/internal/log"
@@ -263,6 +265,72 @@ func TestResolveReferences_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestResolveReferences_APIVersionEmbeddedFields(t *testing.T) {
+	version := "1.0.0"
+	spec := tags.RefPlaceholderPrefix + "my-api#description"
+	description := "Orders API"
+	rs := &resources.ResourceSet{
+		APIs: []resources.APIResource{createAPI("my-api", "My API")},
+		APIVersions: []resources.APIVersionResource{{
+			Ref: "my-api-v1",
+			API: "my-api",
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &version,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &spec},
+			},
+		}},
+	}
+	rs.APIs[0].Description = &description
+
+	err := ResolveReferences(context.Background(), rs)
+	require.NoError(t, err)
+
+	require.NotNil(t, rs.APIVersions[0].Spec.Content)
+	assert.Equal(t, "Orders API", *rs.APIVersions[0].Spec.Content)
+}
+
+func TestResolveReferences_ChainedReference(t *testing.T) {
+	portalName := tags.RefPlaceholderPrefix + "my-api#name"
+	description := tags.RefPlaceholderPrefix + "my-portal#name"
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{createPortal("my-portal", "")},
+		APIs:    []resources.APIResource{createAPI("my-api", "My API")},
+	}
+	rs.Portals[0].Name = portalName
+	rs.APIs[0].Description = &description
+
+	err := ResolveReferences(context.Background(), rs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "My API", rs.Portals[0].Name)
+	require.NotNil(t, rs.APIs[0].Description)
+	assert.Equal(t, "My API", *rs.APIs[0].Description)
+}
+
+func TestResolveReferences_APIVersionCircularReference(t *testing.T) {
+	version := "1.0.0"
+	spec := tags.RefPlaceholderPrefix + "my-api#description"
+	description := tags.RefPlaceholderPrefix + "my-api-v1#spec.content"
+	rs := &resources.ResourceSet{
+		APIs: []resources.APIResource{createAPI("my-api", "My API")},
+		APIVersions: []resources.APIVersionResource{{
+			Ref: "my-api-v1",
+			API: "my-api",
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &version,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &spec},
+			},
+		}},
+	}
+	rs.APIs[0].Description = &description
+
+	err := ResolveReferences(context.Background(), rs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular reference")
+	assert.Contains(t, err.Error(), "my-api-v1->my-api#description")
+	assert.Contains(t, err.Error(), "my-api->my-api-v1#spec.content")
+}
+
 func TestIsRefPlaceholder(t *testing.T) {
 	tests := []struct {
 		name     string
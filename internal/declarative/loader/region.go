@@ -0,0 +1,245 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/depgraph"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"go.yaml.in/yaml/v4"
+)
+
+var regionPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// SetDefaultRegion sets the Konnect region of the profile. Resources of files whose
+// _defaults.region names it belong to the default region, like files without a region.
+func (l *Loader) SetDefaultRegion(region string) {
+	l.defaultRegion = normalizeRegion(region)
+}
+
+// SelectRegion keeps only the resources of one Konnect region when loading, "" being
+// the default region. Without a selected region, loading fails when a resource is
+// in another region than the default one.
+func (l *Loader) SelectRegion(region string) {
+	l.region = normalizeRegion(region)
+	if l.region == l.defaultRegion {
+		l.region = ""
+	}
+	l.regionSelected = true
+}
+
+// Regions returns the Konnect regions of the resources and namespace defaults of the
+// last load, sorted, with "" standing for the default region
+func (l *Loader) Regions() []string {
+	return l.regions
+}
+
+func normalizeRegion(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
+
+// recordFileRegion remembers the _defaults.region of a parsed file for its resources
+// and namespace defaults
+func (l *Loader) recordFileRegion(
+	rs *resources.ResourceSet, defaults *resources.FileDefaults, sourcePath string,
+) error {
+	if defaults == nil || strings.TrimSpace(defaults.Region) == "" {
+		return nil
+	}
+	region := normalizeRegion(defaults.Region)
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("invalid _defaults.region %q in %s (expected lowercase letters, numbers, or hyphens)",
+			defaults.Region, sourcePath)
+	}
+
+	if l.refRegions == nil {
+		l.refRegions = make(map[string]string)
+	}
+	rs.ForEachResource(func(r resources.Resource) bool {
+		l.refRegions[r.GetRef()] = region
+		return true
+	})
+	if l.namespaceRegions == nil {
+		l.namespaceRegions = make(map[string]string)
+	}
+	for _, namespace := range rs.DefaultNamespaces {
+		l.namespaceRegions[namespace] = region
+	}
+	return nil
+}
+
+// resolveRegions assigns every resource the region of its file, or else the region of
+// its parent, and rejects references between resources of different regions
+func (l *Loader) resolveRegions(rs *resources.ResourceSet) (map[string]string, error) {
+	byRef := make(map[string]resources.Resource, rs.ResourceCount())
+	rs.ForEachResource(func(r resources.Resource) bool {
+		byRef[r.GetRef()] = r
+		return true
+	})
+
+	regions := make(map[string]string, len(byRef))
+	var regionOf func(ref string, seen map[string]bool) string
+	regionOf = func(ref string, seen map[string]bool) string {
+		if region, ok := regions[ref]; ok {
+			return region
+		}
+		region, declared := l.refRegions[ref]
+		if !declared {
+			if child, ok := byRef[ref].(resources.ResourceWithParent); ok && !seen[ref] {
+				seen[ref] = true
+				if parent := child.GetParentRef(); parent != nil && byRef[parent.Ref] != nil {
+					region = regionOf(parent.Ref, seen)
+				}
+			}
+		}
+		if region == l.defaultRegion {
+			region = ""
+		}
+		regions[ref] = region
+		return region
+	}
+
+	refs := make([]string, 0, len(byRef))
+	for ref := range byRef {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		regionOf(ref, map[string]bool{})
+	}
+
+	for _, edge := range depgraph.Build(rs).Edges {
+		if _, ok := byRef[edge.To]; !ok || regions[edge.To] == regions[edge.From] {
+			continue
+		}
+		return nil, fmt.Errorf("%s %q in Konnect region %s references %s %q in Konnect region %s; "+
+			"resources can only reference resources of the same region",
+			byRef[edge.From].GetType(), edge.From, l.regionName(regions[edge.From]),
+			byRef[edge.To].GetType(), edge.To, l.regionName(regions[edge.To]))
+	}
+	return regions, nil
+}
+
+// regionName describes a region of the loaded resources
+func (l *Loader) regionName(region string) string {
+	switch {
+	case region != "":
+		return region
+	case l.defaultRegion != "":
+		return l.defaultRegion
+	default:
+		return "of the profile"
+	}
+}
+
+// scopeToRegion records the regions of the loaded resources and keeps the resources
+// of the selected region
+func (l *Loader) scopeToRegion(rs *resources.ResourceSet, regions map[string]string) error {
+	found := make(map[string]bool)
+	for _, region := range regions {
+		found[region] = true
+	}
+	for _, namespace := range rs.DefaultNamespaces {
+		found[l.namespaceRegion(namespace)] = true
+	}
+	l.regions = make([]string, 0, len(found))
+	for region := range found {
+		l.regions = append(l.regions, region)
+	}
+	sort.Strings(l.regions)
+
+	if !l.regionSelected {
+		for _, ref := range sortedKeys(regions) {
+			if regions[ref] != "" {
+				return fmt.Errorf("resource %q is in Konnect region %s, but this command only supports "+
+					"the region of the profile; plan, diff, apply and sync handle configurations that "+
+					"span regions", ref, regions[ref])
+			}
+		}
+		return nil
+	}
+
+	rs.RetainResources(func(r resources.Resource) bool {
+		return regions[r.GetRef()] == l.region
+	})
+	namespaces := rs.DefaultNamespaces
+	rs.DefaultNamespaces = nil
+	rs.DefaultNamespace = ""
+	for _, namespace := range namespaces {
+		if l.namespaceRegion(namespace) == l.region {
+			rs.AddDefaultNamespace(namespace)
+		}
+	}
+	return nil
+}
+
+func (l *Loader) namespaceRegion(namespace string) string {
+	region := l.namespaceRegions[namespace]
+	if region == l.defaultRegion {
+		return ""
+	}
+	return region
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DeclaresRegions reports whether a file or directory source sets _defaults.region.
+// Only the _defaults of each file are read, so it is cheap enough to decide whether
+// a configuration needs to be loaded once per region. Stdin is not read.
+func DeclaresRegions(sources []Source, recursive bool) bool {
+	for _, source := range sources {
+		var paths []string
+		switch source.Type {
+		case SourceTypeFile:
+			paths = []string{source.Path}
+		case SourceTypeDirectory:
+			paths = listConfigFiles(source.Path, recursive)
+		case SourceTypeSTDIN:
+			continue
+		}
+		for _, path := range paths {
+			if fileDeclaresRegion(path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fileDeclaresRegion reports whether the _defaults of a file set a region. Files that
+// cannot be read or parsed report false; loading reports those errors.
+func fileDeclaresRegion(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+		return false
+	}
+	defaults := mappingValue(doc.Content[0], "_defaults")
+	region := mappingValue(defaults, "region")
+	return region != nil && strings.TrimSpace(region.Value) != ""
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
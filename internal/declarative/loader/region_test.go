@@ -0,0 +1,122 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRegionFiles(t *testing.T, files map[string]string) []Source {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return []Source{{Path: dir, Type: SourceTypeDirectory}}
+}
+
+func TestLoader_Regions(t *testing.T) {
+	sources := writeRegionFiles(t, map[string]string{
+		"eu.yaml": `
+_defaults:
+  region: EU
+apis:
+  - ref: orders
+    name: Orders
+`,
+		"eu-children.yaml": `
+api_versions:
+  - ref: orders-v1
+    api: orders
+    version: 1.0.0
+`,
+		"us.yaml": `
+_defaults:
+  region: us
+portals:
+  - ref: portal
+    name: Portal
+`,
+		"default.yaml": `
+control_planes:
+  - ref: cp
+    name: CP
+`,
+	})
+	require.True(t, DeclaresRegions(sources, false))
+
+	t.Run("selected region keeps its resources and children", func(t *testing.T) {
+		ldr := New()
+		ldr.SetDefaultRegion("us")
+		ldr.SelectRegion("eu")
+		rs, err := ldr.LoadFromSources(sources, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "eu"}, ldr.Regions())
+		require.Len(t, rs.APIs, 1)
+		require.Len(t, rs.APIVersions, 1, "children in other files inherit the region of their parent")
+		assert.Empty(t, rs.Portals)
+		assert.Empty(t, rs.ControlPlanes)
+	})
+
+	t.Run("the region of the profile is the default region", func(t *testing.T) {
+		ldr := New()
+		ldr.SetDefaultRegion("us")
+		ldr.SelectRegion("")
+		rs, err := ldr.LoadFromSources(sources, false)
+		require.NoError(t, err)
+		assert.Len(t, rs.Portals, 1)
+		assert.Len(t, rs.ControlPlanes, 1)
+		assert.Empty(t, rs.APIs)
+	})
+
+	t.Run("other regions need a selected region", func(t *testing.T) {
+		ldr := New()
+		ldr.SetDefaultRegion("us")
+		_, err := ldr.LoadFromSources(sources, false)
+		require.ErrorContains(t, err, `resource "orders" is in Konnect region eu`)
+	})
+}
+
+func TestLoader_RegionValidation(t *testing.T) {
+	t.Run("references across regions", func(t *testing.T) {
+		sources := writeRegionFiles(t, map[string]string{
+			"eu.yaml": `
+_defaults:
+  region: eu
+portals:
+  - ref: portal
+    name: Portal
+`,
+			"us.yaml": `
+apis:
+  - ref: orders
+    name: Orders
+    publications:
+      - ref: orders-pub
+        portal_id: !ref portal#id
+`,
+		})
+		ldr := New()
+		ldr.SelectRegion("")
+		_, err := ldr.LoadFromSources(sources, false)
+		require.ErrorContains(t, err, "resources can only reference resources of the same region")
+	})
+
+	t.Run("invalid region", func(t *testing.T) {
+		sources := writeRegionFiles(t, map[string]string{
+			"eu.yaml": "_defaults:\n  region: eu west\nportals:\n  - ref: portal\n    name: Portal\n",
+		})
+		_, err := New().LoadFromSources(sources, false)
+		require.ErrorContains(t, err, `invalid _defaults.region "eu west"`)
+	})
+
+	t.Run("files without a region", func(t *testing.T) {
+		sources := writeRegionFiles(t, map[string]string{
+			"us.yaml": "portals:\n  - ref: portal\n    name: Portal\n",
+		})
+		assert.False(t, DeclaresRegions(sources, false))
+	})
+}
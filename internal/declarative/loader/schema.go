@@ -0,0 +1,137 @@
+package loader
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/hooks"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/migrate"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/schema"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/declarative/validator"
+)
+
+// SchemaTitle is the title of the JSON Schema of declarative configuration files
+const SchemaTitle = "kongctl declarative configuration"
+
+var (
+	configSchemaOnce sync.Once
+	configSchema     *schema.Schema
+)
+
+// Schema returns the JSON Schema of a declarative configuration file, derived from
+// the resource types the loader decodes files into
+func Schema() *schema.Schema {
+	configSchemaOnce.Do(func() {
+		configSchema = schema.Generate(reflect.TypeFor[temporaryParseResult](), SchemaTitle, schemaRules)
+		configSchema.Tags = schemaTags
+	})
+	return configSchema
+}
+
+// schemaTags describe the tags of the resolvers the loader registers
+var schemaTags = []schema.Tag{
+	{
+		Name: "!file", Nodes: []string{"scalar", "mapping"},
+		Description: "Loads the content of a file, or the value at the extract path of a YAML or JSON file",
+	},
+	{
+		Name: "!dir", Nodes: []string{"scalar", "mapping"},
+		Description: "Loads a directory of markdown files as a list",
+	},
+	{
+		Name: "!base64file", Nodes: []string{"scalar", "mapping"},
+		Description: "Loads a file as a base64 data URL",
+	},
+	{Name: "!env", Nodes: []string{"scalar"}, Description: "Reads an environment variable"},
+	{Name: "!secret", Nodes: []string{"scalar"}, Description: "Reads a value from a secrets backend"},
+	{
+		Name: "!ref", Nodes: []string{"scalar"},
+		Description: "Refers to the ID of another resource by its ref, or to another field as ref#field",
+	},
+	{Name: "!merge", Nodes: []string{"sequence"}, Description: "Deep merges a list of maps"},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// schemaRules mirror the checks of resources.ValidateRef, validator.ValidateNamespace
+// and labels.ValidateLabel, which the Go types cannot express, and the fields child
+// resources accept in their UnmarshalJSON besides the SDK fields
+var schemaRules = map[string]schema.Rule{
+	"APIVersionResource": schema.Allow(map[string]*schema.Schema{
+		"name":           {Type: "string"},
+		"description":    {Type: "string"},
+		"publish_status": {Type: "string"},
+		"deprecated":     {Type: "boolean"},
+		"sunset_date":    {Type: "string"},
+		// A spec is a string, an OpenAPI document, or an object with its content
+		"spec": {},
+	}),
+	"APIImplementationResource": schema.Allow(map[string]*schema.Schema{
+		"implementation_url": {Type: "string"},
+	}),
+	"APIImplementationService": func(s *schema.Schema) {
+		s.Properties["name"] = &schema.Schema{
+			Type:        "string",
+			Description: "Name of the gateway service on the control plane, looked up when id is not set",
+		}
+		s.Required = []string{"control_plane_id"}
+	},
+	"APIPublicationResource": schema.Allow(map[string]*schema.Schema{
+		"publish_status": {Type: "string"},
+	}),
+	"ref": func(s *schema.Schema) {
+		s.Pattern = resources.RefPattern
+		s.MinLen = intPtr(resources.MinRefLength)
+		s.MaxLen = intPtr(resources.MaxRefLength)
+		s.Description = fmt.Sprintf("refs are 1-%d letters, digits, hyphens and underscores, "+
+			"starting with a letter or digit", resources.MaxRefLength)
+	},
+	"namespace": func(s *schema.Schema) {
+		s.Pattern = validator.NamespacePattern
+		s.MaxLen = intPtr(validator.MaxNamespaceLength)
+		s.Description = fmt.Sprintf("namespaces are 1-%d lowercase letters, digits and hyphens, "+
+			"starting and ending with a letter or digit", validator.MaxNamespaceLength)
+	},
+	"variables": func(s *schema.Schema) {
+		s.PropertyNames = &schema.Schema{
+			Type:        "string",
+			Pattern:     tags.VariableNamePattern.String(),
+			Description: "variable names are letters, digits, hyphens and underscores, starting with a letter or underscore",
+		}
+		s.Description = "Values of the ${var.name} references of the configuration, overridden by --var-file"
+	},
+	migrate.VersionKey: func(s *schema.Schema) {
+		s.Description = fmt.Sprintf("Version of the configuration format the file is written for, %d for this "+
+			"kongctl. Files of earlier versions are upgraded with kongctl migrate-config", migrate.CurrentVersion)
+	},
+	includesKey: func(s *schema.Schema) {
+		s.Description = "Configuration files loaded before this file, relative to it. Glob patterns expand " +
+			"in lexical order, and resources of this file override those of the same ref"
+	},
+	"Hook": func(s *schema.Schema) {
+		s.Description = "Posts the summary of an apply or sync to a url, or runs a command with it"
+		s.Properties["format"].Enum = []string{hooks.FormatJSON, hooks.FormatSlack}
+	},
+	"labels": func(s *schema.Schema) {
+		s.PropertyNames = &schema.Schema{
+			Type:        "string",
+			MinLen:      intPtr(1),
+			MaxLen:      intPtr(63),
+			Description: "label keys are 1-63 characters",
+			// The first alternative is reported when neither matches
+			AnyOf: []*schema.Schema{
+				{
+					Description: "label keys cannot start with kong, konnect, mesh, kic or _",
+					Not:         &schema.Schema{Pattern: "^(kong|konnect|mesh|kic|_)"},
+				},
+				{Pattern: "^(" + labels.KongctlPrefix + "|kongctl-)"},
+			},
+		}
+	},
+}
@@ -0,0 +1,100 @@
+package loader
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplesDir = "../../../docs/examples/declarative"
+
+func TestSchema_IsPublished(t *testing.T) {
+	data, err := os.ReadFile("../../../docs/declarative.schema.json")
+	require.NoError(t, err)
+	var published map[string]any
+	require.NoError(t, json.Unmarshal(data, &published))
+	// The published schema is versioned with the release it was generated for
+	assert.NotEmpty(t, published["x-kongctl-version"])
+	delete(published, "x-kongctl-version")
+
+	data, err = Schema().JSON()
+	require.NoError(t, err)
+	var generated map[string]any
+	require.NoError(t, json.Unmarshal(data, &generated))
+	assert.Equal(t, generated, published, "docs/declarative.schema.json is out of date, regenerate it with make schema")
+}
+
+// Every example the loader accepts must match the schema, so the schema reports
+// no problem the loader would not
+func TestSchema_AcceptsExamples(t *testing.T) {
+	checked := 0
+	err := filepath.WalkDir(examplesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !ValidateConfigFile(path) {
+			return err
+		}
+		if _, err := New().parseFile(path, examplesDir); err != nil {
+			// Specs and documents loaded with !file are not configuration
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		issues, err := Schema().Check(content)
+		require.NoError(t, err)
+		assert.Empty(t, issues, path)
+		checked++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Greater(t, checked, 10)
+}
+
+func TestSchema_Rules(t *testing.T) {
+	content := []byte(`
+namespace: Team_A
+portals:
+  - ref: "dev:portal"
+    name: Developer Portal
+    labels:
+      team: a
+      konnect-owned: "true"
+      KONGCTL-custom: "true"
+    kongctl:
+      namespace: team-a
+`)
+
+	issues, err := Schema().Check(content)
+	require.NoError(t, err)
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Equal(t, []string{
+		"2:12: namespace: namespaces are 1-63 lowercase letters, digits and hyphens, " +
+			"starting and ending with a letter or digit",
+		"4:10: portals[0].ref: refs are 1-63 letters, digits, hyphens and underscores, " +
+			"starting with a letter or digit",
+		"8:7: portals[0].labels.konnect-owned: label keys cannot start with kong, konnect, mesh, kic or _",
+	}, messages)
+}
+
+func TestLoader_Validate_SchemaIssues(t *testing.T) {
+	dir := t.TempDir()
+	path := writeValidateFile(t, dir, "portals.yaml", `
+portals:
+  - ref: dev-portal
+    name: Developer Portal
+    rbac_enabled: maybe
+`)
+
+	_, issues := New().Validate(context.Background(), []Source{{Path: path, Type: SourceTypeFile}}, false)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, path+`:5:19: field "portals[0].rbac_enabled": must be a boolean, not the string "maybe"`,
+		issues[0].String())
+}
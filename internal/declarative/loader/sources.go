@@ -85,11 +85,28 @@ func detectSourceType(source string) (SourceType, error) {
 
 // ValidateYAMLFile checks if a file has a valid YAML extension
 func ValidateYAMLFile(path string) bool {
+	ext := fileExtension(path)
+	return ext == "yaml" || ext == "yml"
+}
+
+// ValidateJSONFile checks if a file has a JSON extension
+func ValidateJSONFile(path string) bool {
+	return fileExtension(path) == "json"
+}
+
+// ValidateConfigFile checks if a file has the extension of a configuration file,
+// either YAML or JSON
+func ValidateConfigFile(path string) bool {
+	return ValidateYAMLFile(path) || ValidateJSONFile(path)
+}
+
+// fileExtension returns the lower case last extension of path
+func fileExtension(path string) string {
 	ext := strings.ToLower(strings.TrimPrefix(path, "."))
 	// Get the last extension for files like config.yaml.bak
 	parts := strings.Split(path, ".")
 	if len(parts) > 1 {
 		ext = strings.ToLower(parts[len(parts)-1])
 	}
-	return ext == "yaml" || ext == "yml"
+	return ext
 }
@@ -225,3 +225,313 @@ portals:
 		})
 	}
 }
+
+func TestLoader_FileTagMissingFilesReportedTogether(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "pages"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "pages", "home.md"), []byte("# Home"), 0o600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "portal.yaml"), []byte(`
+portals:
+  - ref: dev-portal
+    name: "Developer Portal"
+    pages:
+      - ref: home
+        slug: home
+        content: !file pages/home.md
+      - ref: guide
+        slug: guide
+        content: !file pages/guide.md
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "apis.yaml"), []byte(`
+apis:
+  - ref: payments
+    name: "Payments"
+    description: !file
+      path: specs/payments.yaml
+      extract: info.description
+    versions:
+      - ref: payments-v1
+        spec: !file specs/payments.yaml
+`), 0o600))
+
+	loader := NewWithBaseDir(tmpDir)
+	_, err := loader.LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, false)
+	require.Error(t, err)
+
+	msg := err.Error()
+	assert.Contains(t, msg, "3 referenced file(s) cannot be loaded")
+	assert.Contains(t, msg, "portal.yaml:11: field portals[0].pages[1].content of resource 'guide': !file pages/guide.md")
+	assert.Contains(t, msg, "apis.yaml:5: field apis[0].description of resource 'payments': !file specs/payments.yaml")
+	assert.Contains(t, msg,
+		"apis.yaml:10: field apis[0].versions[0].spec of resource 'payments-v1': !file specs/payments.yaml")
+	assert.NotContains(t, msg, "pages/home.md", "existing files are not reported")
+}
+
+func TestLoader_FileTagExtractionErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "spec.yaml"), []byte(`
+openapi: 3.0.0
+info:
+  title: Orders
+  version: "1.0"
+  contact:
+    name: orders-team
+  description:
+`), 0o600))
+
+	tests := []struct {
+		name        string
+		yamlContent string
+		wantErr     string
+	}{
+		{
+			name: "missing path lists the available keys",
+			yamlContent: `
+portals:
+  - ref: dev
+    name: !file spec.yaml#info.titel`,
+			wantErr: "failed to extract 'info.titel' from spec.yaml: path not found: info.titel " +
+				"(no key 'titel' at 'info', available keys: contact, description, title, version)",
+		},
+		{
+			name: "null value",
+			yamlContent: `
+portals:
+  - ref: dev
+    name: dev
+    description: !file spec.yaml#info.description`,
+			wantErr: "path info.description resolves to null",
+		},
+		{
+			name: "map for a string field",
+			yamlContent: `
+portals:
+  - ref: dev
+    name: dev
+    description: !file spec.yaml#info.contact`,
+			wantErr: "test.yaml:5: field portals[0].description of resource 'dev': " +
+				"!file spec.yaml#info.contact resolves to a map, but the field expects a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(tmpDir, "test.yaml")
+			require.NoError(t, os.WriteFile(testFile, []byte(tt.yamlContent), 0o600))
+
+			_, err := NewWithBaseDir(tmpDir).LoadFile(testFile)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	// A whole document for an API version spec is not a scalar field
+	testFile := filepath.Join(tmpDir, "test.yaml")
+	require.NoError(t, os.WriteFile(testFile, []byte(`
+apis:
+  - ref: orders
+    name: !file spec.yaml#info.title
+    versions:
+      - ref: orders-v1
+        version: "1.0"
+        spec: !file spec.yaml
+`), 0o600))
+	rs, err := NewWithBaseDir(tmpDir).LoadFile(testFile)
+	require.NoError(t, err)
+	require.Len(t, rs.APIs, 1)
+	assert.Equal(t, "Orders", rs.APIs[0].Name)
+}
+
+func TestLoader_MergeTagIntegration(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "labels.yaml"),
+		[]byte("env: prod\nowner: platform\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "apis.yaml"), []byte(`
+apis:
+  - ref: orders
+    name: orders
+    labels: &base_labels
+      tier: gold
+  - ref: payments
+    name: payments
+    labels: !merge [*base_labels, !file shared/labels.yaml, {owner: payments}]
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "portals.json"), []byte(`{
+  "portals": [
+    {"ref": "dev", "name": "dev", "labels": {"$merge": [{"$file": "shared/labels.yaml"}, {"team": "docs"}]}}
+  ]
+}`), 0o600))
+
+	rs, err := NewWithBaseDir(tmpDir).LoadFromSources([]Source{{Path: tmpDir, Type: SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+
+	require.Len(t, rs.APIs, 2)
+	assert.Equal(t, map[string]string{"tier": "gold", "env": "prod", "owner": "payments"}, rs.APIs[1].Labels)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "platform", "team": "docs"}, rs.Portals[0].GetLabels())
+}
+
+func TestLoader_EnvTagIntegration(t *testing.T) {
+	t.Setenv("KONGCTL_TEST_PORTAL_REF", "staging-portal")
+	t.Setenv("KONGCTL_TEST_PORTAL_NAME", "Staging Portal")
+
+	tmpDir := t.TempDir()
+	yamlContent := `
+portals:
+  - ref: !env KONGCTL_TEST_PORTAL_REF
+    name: !env KONGCTL_TEST_PORTAL_NAME
+    rbac_enabled: !env KONGCTL_TEST_PORTAL_RBAC:true
+apis:
+  - ref: users-api
+    name: Users API
+    publications:
+      - ref: users-api-publication
+        portal_id: !ref staging-portal#id`
+
+	tmpfile := filepath.Join(tmpDir, "test.yaml")
+	require.NoError(t, os.WriteFile(tmpfile, []byte(yamlContent), 0o600))
+
+	rs, err := NewWithBaseDir(tmpDir).LoadFile(tmpfile)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, "staging-portal", rs.Portals[0].Ref)
+	assert.Equal(t, "Staging Portal", rs.Portals[0].Name)
+	require.NotNil(t, rs.Portals[0].RbacEnabled)
+	assert.True(t, *rs.Portals[0].RbacEnabled)
+	require.Len(t, rs.APIPublications, 1)
+	assert.Equal(t, "__REF__:staging-portal#id", rs.APIPublications[0].PortalID)
+}
+
+func TestLoader_EnvTagUnsetVariable(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlContent := `
+portals:
+  - ref: test-portal
+    name: !env KONGCTL_TEST_UNSET_PORTAL_NAME`
+
+	tmpfile := filepath.Join(tmpDir, "portal.yaml")
+	require.NoError(t, os.WriteFile(tmpfile, []byte(yamlContent), 0o600))
+
+	_, err := NewWithBaseDir(tmpDir).LoadFile(tmpfile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "portal.yaml")
+	assert.Contains(t, err.Error(),
+		"environment variable KONGCTL_TEST_UNSET_PORTAL_NAME is not set and has no default (line 4)")
+}
+
+func TestLoader_SecretTagIntegration(t *testing.T) {
+	t.Setenv("KONGCTL_TEST_SMTP_PASSWORD", "s3cr3t")
+
+	tmpDir := t.TempDir()
+	yamlContent := `
+portals:
+  - ref: dev-portal
+    name: Dev Portal
+    description: !secret env://KONGCTL_TEST_SMTP_PASSWORD`
+
+	tmpfile := filepath.Join(tmpDir, "portal.yaml")
+	require.NoError(t, os.WriteFile(tmpfile, []byte(yamlContent), 0o600))
+
+	rs, err := NewWithBaseDir(tmpDir).LoadFile(tmpfile)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	require.NotNil(t, rs.Portals[0].Description)
+	assert.Equal(t, "__SECRET__:env://KONGCTL_TEST_SMTP_PASSWORD", *rs.Portals[0].Description,
+		"secrets are read when the plan is executed")
+}
+
+func TestLoader_DirTagIntegration(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		"docs/overview.md":       "# Overview\n",
+		"docs/guides/index.md":   "# Guides\n",
+		"docs/guides/install.md": "# Install\n",
+	} {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	yamlContent := `
+apis:
+  - ref: orders
+    name: Orders
+    documents: !dir {path: ./docs, ref_prefix: orders-}`
+	tmpfile := filepath.Join(tmpDir, "apis.yaml")
+	require.NoError(t, os.WriteFile(tmpfile, []byte(yamlContent), 0o600))
+
+	rs, err := New().LoadFromSources([]Source{{Path: tmpfile, Type: SourceTypeFile}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.APIs, 1)
+	parents := make(map[string]string)
+	for _, doc := range rs.APIs[0].Documents {
+		parents[doc.Ref] = doc.ParentDocumentRef
+	}
+	assert.Equal(t, map[string]string{
+		"orders-guides": "", "orders-guides-install": "orders-guides", "orders-overview": "",
+	}, parents, "subdirectories become the children of their index.md")
+}
+
+func TestLoader_RemoteFileTagOffline(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+portals:
+  - ref: test-portal
+    name: !file https://artifacts.example.com/portal.yaml#name`
+	configFile := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configYAML), 0o600))
+
+	loader := New()
+	loader.SetOffline(true)
+	_, err := loader.LoadFromSources([]Source{{Path: configFile, Type: SourceTypeFile}}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "https://artifacts.example.com/portal.yaml cannot be loaded in offline mode")
+}
+
+func TestLoader_Untrusted(t *testing.T) {
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.yaml"),
+		[]byte("portals:\n  - ref: p\n    name: secret\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "env tag",
+			content: "portals:\n  - ref: p\n    name: !env HOME\n",
+			err:     "unsupported YAML tag: !env",
+		},
+		{
+			name:    "secret tag",
+			content: "portals:\n  - ref: p\n    name: !secret env://HOME\n",
+			err:     "unsupported YAML tag: !secret",
+		},
+		{
+			name:    "remote file",
+			content: "portals:\n  - ref: p\n    name: p\n    description: !file https://example.com/description.txt\n",
+			err:     "offline",
+		},
+		{
+			name:    "include outside the base directory",
+			content: "_includes: [" + filepath.Join(outside, "secret.yaml") + "]\n",
+			err:     "is outside the base directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			ldr := NewWithBaseDir(dir)
+			ldr.SetUntrusted(true)
+			_, err := ldr.LoadFromSources([]Source{{Path: path, Type: SourceTypeFile}}, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// siblingKey identifies a value that must be unique among the children of one parent
+type siblingKey struct {
+	scope string
+	value string
+}
+
+// siblingIndex records the refs claiming each sibling-scoped value, in file order
+type siblingIndex struct {
+	keys []siblingKey
+	refs map[siblingKey][]string
+}
+
+func (s *siblingIndex) add(scope, value, ref string) {
+	if s.refs == nil {
+		s.refs = make(map[siblingKey][]string)
+	}
+	key := siblingKey{scope: scope, value: value}
+	if _, ok := s.refs[key]; !ok {
+		s.keys = append(s.keys, key)
+	}
+	s.refs[key] = append(s.refs[key], ref)
+}
+
+// duplicate returns an error for the first value claimed by more than one ref
+func (s *siblingIndex) duplicate(resourceType resources.ResourceType, field string) error {
+	for _, key := range s.keys {
+		refs := s.refs[key]
+		if len(refs) < 2 {
+			continue
+		}
+		quoted := make([]string, len(refs))
+		for i, ref := range refs {
+			quoted[i] = fmt.Sprintf("%q", ref)
+		}
+		return fmt.Errorf("duplicate %s %q among %s resources %s: refs %s",
+			field, key.value, resourceType, key.scope, strings.Join(quoted, ", "))
+	}
+	return nil
+}
+
+// validateSiblingUniqueness rejects slugs and names that Konnect requires to be unique
+// among sibling resources, so collisions are reported before any change is applied:
+// portal page slugs and API document slugs under the same parent, and portal snippet
+// names within a portal
+func (l *Loader) validateSiblingUniqueness(rs *resources.ResourceSet) error {
+	var pages siblingIndex
+	for _, page := range rs.PortalPages {
+		parent := page.ParentPageRef
+		if parent == "" && page.ParentPageID != nil {
+			parent = *page.ParentPageID
+		}
+		pages.add(siblingScope("portal", page.Portal, "parent page", parent), normalizePageSlug(page.Slug), page.Ref)
+	}
+	if err := pages.duplicate(resources.ResourceTypePortalPage, "slug"); err != nil {
+		return err
+	}
+
+	// Documents nested under an API stay on the API once flattened
+	allDocuments := slices.Clone(rs.APIDocuments)
+	for _, api := range rs.APIs {
+		allDocuments = append(allDocuments, api.Documents...)
+	}
+	var documents siblingIndex
+	for _, document := range allDocuments {
+		if document.Slug == nil {
+			// Konnect derives the slug from the title, which is not checked here
+			continue
+		}
+		parent := document.ParentDocumentRef
+		if parent == "" {
+			parent = document.ParentDocumentID
+		}
+		documents.add(siblingScope("api", document.API, "parent document", parent),
+			strings.Trim(strings.TrimPrefix(*document.Slug, "/"), "/"), document.Ref)
+	}
+	if err := documents.duplicate(resources.ResourceTypeAPIDocument, "slug"); err != nil {
+		return err
+	}
+
+	var snippets siblingIndex
+	for _, snippet := range rs.PortalSnippets {
+		snippets.add(siblingScope("portal", snippet.Portal, "", ""), snippet.Name, snippet.Ref)
+	}
+	return snippets.duplicate(resources.ResourceTypePortalSnippet, "name")
+}
+
+// siblingScope describes the parent shared by siblings, e.g. `in portal "dev" under parent page "guides"`
+func siblingScope(parentKind, parentRef, nestedKind, nestedRef string) string {
+	scope := fmt.Sprintf("in %s %q", parentKind, parentRef)
+	if nestedRef != "" {
+		scope += fmt.Sprintf(" under %s %q", nestedKind, nestedRef)
+	}
+	return scope
+}
+
+// normalizePageSlug matches the planner, which ignores a leading slash except on the root page
+func normalizePageSlug(slug string) string {
+	if slug == "/" {
+		return slug
+	}
+	return strings.TrimPrefix(slug, "/")
+}
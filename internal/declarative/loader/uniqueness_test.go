@@ -0,0 +1,113 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_LoadFromSources_DuplicateSiblingDocumentSlugs(t *testing.T) {
+	config := `
+apis:
+  - ref: orders
+    name: Orders
+    documents:
+      - ref: guides
+        title: Guides
+        slug: guides
+        content: "# Guides"
+        children:
+          - ref: auth-guide
+            title: Authentication
+            slug: getting-started
+            content: "# Auth"
+          - ref: quickstart-guide
+            title: Quick Start
+            slug: /getting-started
+            content: "# Quick start"
+      - ref: overview
+        title: Overview
+        slug: getting-started
+        content: "# Overview"
+`
+	path := filepath.Join(t.TempDir(), "apis.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	_, err := New().LoadFromSources([]Source{{Path: path, Type: SourceTypeFile}}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		`duplicate slug "getting-started" among api_document resources in api "orders" under parent document "guides": `+
+			`refs "auth-guide", "quickstart-guide"`)
+	assert.NotContains(t, err.Error(), `"overview"`, "a top-level document is not a sibling of nested ones")
+}
+
+func siblingPage(ref, portal, parent, slug string) resources.PortalPageResource {
+	return resources.PortalPageResource{
+		Ref:                     ref,
+		Portal:                  portal,
+		ParentPageRef:           parent,
+		CreatePortalPageRequest: kkComps.CreatePortalPageRequest{Slug: slug},
+	}
+}
+
+func siblingDocument(ref, api string, slug *string) resources.APIDocumentResource {
+	return resources.APIDocumentResource{
+		Ref:                      ref,
+		API:                      api,
+		CreateAPIDocumentRequest: kkComps.CreateAPIDocumentRequest{Slug: slug},
+	}
+}
+
+func TestLoader_validateSiblingUniqueness(t *testing.T) {
+	loader := New()
+	slug := func(s string) *string { return &s }
+
+	t.Run("same slugs under different parents are valid", func(t *testing.T) {
+		rs := &resources.ResourceSet{
+			PortalPages: []resources.PortalPageResource{
+				siblingPage("home", "dev", "", "/"),
+				siblingPage("guides", "dev", "", "guides"),
+				siblingPage("guides-intro", "dev", "guides", "intro"),
+				siblingPage("intro", "dev", "", "intro"),
+				siblingPage("partner-guides", "partner", "", "guides"),
+			},
+			APIDocuments: []resources.APIDocumentResource{
+				siblingDocument("orders-intro", "orders", slug("intro")),
+				siblingDocument("refunds-intro", "refunds", slug("intro")),
+				siblingDocument("untitled-a", "orders", nil),
+				siblingDocument("untitled-b", "orders", nil),
+			},
+		}
+		assert.NoError(t, loader.validateSiblingUniqueness(rs))
+	})
+
+	t.Run("duplicate page slugs in a portal", func(t *testing.T) {
+		rs := &resources.ResourceSet{
+			PortalPages: []resources.PortalPageResource{
+				siblingPage("about", "dev", "", "about"),
+				siblingPage("about-us", "dev", "", "/about"),
+			},
+		}
+		err := loader.validateSiblingUniqueness(rs)
+		require.EqualError(t, err,
+			`duplicate slug "about" among portal_page resources in portal "dev": refs "about", "about-us"`)
+	})
+
+	t.Run("duplicate snippet names in a portal", func(t *testing.T) {
+		rs := &resources.ResourceSet{
+			PortalSnippets: []resources.PortalSnippetResource{
+				{Ref: "banner", Portal: "dev", Name: "banner"},
+				{Ref: "banner-v2", Portal: "dev", Name: "banner"},
+				{Ref: "partner-banner", Portal: "partner", Name: "banner"},
+			},
+		}
+		err := loader.validateSiblingUniqueness(rs)
+		require.EqualError(t, err,
+			`duplicate name "banner" among portal_snippet resources in portal "dev": refs "banner", "banner-v2"`)
+	})
+}
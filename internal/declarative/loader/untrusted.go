@@ -0,0 +1,59 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// checkUntrusted rejects what untrusted files could use to run commands or read
+// secrets on the host loading them: publication switch verify commands, resources of
+// custom handlers, and literal secret placeholders, which apply would resolve
+func checkUntrusted(rs *resources.ResourceSet) error {
+	for _, publication := range rs.APIPublications {
+		if publication.Switch != nil && len(publication.Switch.Verify) > 0 {
+			return fmt.Errorf("api_publication %q: _switch.verify commands are not allowed in untrusted configuration",
+				publication.Ref)
+		}
+	}
+	if len(rs.CustomResources) > 0 {
+		return fmt.Errorf("custom_resource %q: custom resources are not allowed in untrusted configuration",
+			rs.CustomResources[0].Ref)
+	}
+
+	data, err := json.Marshal(rs)
+	if err != nil {
+		return fmt.Errorf("failed to check untrusted configuration: %w", err)
+	}
+	var values any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to check untrusted configuration: %w", err)
+	}
+	if value, ok := findSecretPlaceholder(values); ok {
+		return fmt.Errorf("value %q: secret placeholders are not allowed in untrusted configuration", value)
+	}
+	return nil
+}
+
+// findSecretPlaceholder returns the first string of value that is a secret placeholder
+func findSecretPlaceholder(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, tags.IsSecretPlaceholder(v)
+	case map[string]any:
+		for _, item := range v {
+			if found, ok := findSecretPlaceholder(item); ok {
+				return found, true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if found, ok := findSecretPlaceholder(item); ok {
+				return found, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,238 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/hooks"
+	"github.com/kong/kongctl/internal/declarative/migrate"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/meta"
+)
+
+// sdkComponentsPkgPath identifies the SDK request structs embedded in resources
+const sdkComponentsPkgPath = "github.com/Kong/sdk-konnect-go/models/components"
+
+// ValidationIssue is a problem found in declarative configuration. File, Ref and
+// Field are empty when the problem is not tied to one of them, and Line and Column
+// are zero when its position in the file is not known.
+type ValidationIssue struct {
+	File    string
+	Line    int
+	Column  int
+	Ref     string
+	Field   string
+	Message string
+}
+
+// String renders the issue as "file:line:column: ref "x": field "y": message",
+// omitting empty parts
+func (i ValidationIssue) String() string {
+	parts := make([]string, 0, 4)
+	switch {
+	case i.File != "" && i.Line > 0:
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", i.File, i.Line, i.Column))
+	case i.File != "":
+		parts = append(parts, i.File)
+	}
+	if i.Ref != "" {
+		parts = append(parts, fmt.Sprintf("ref %q", i.Ref))
+	}
+	if i.Field != "" {
+		parts = append(parts, fmt.Sprintf("field %q", i.Field))
+	}
+	return strings.Join(append(parts, i.Message), ": ")
+}
+
+// Validate runs the loading pipeline over the sources, from YAML parsing and tag
+// expansion to reference resolution and resource validation, without contacting
+// Konnect. Unlike LoadFromSources it does not stop at the first problem: every
+// file is parsed and every resource is checked, so all issues are reported at once.
+//
+// Each file is first checked against the JSON Schema of the format, which reports
+// unknown fields, wrong types, missing required fields, invalid refs and label keys
+// with their line and column. Files with schema issues are not loaded, like files
+// that fail to parse.
+func (l *Loader) Validate(ctx context.Context, sources []Source,
+	recursive bool,
+) (*resources.ResourceSet, []ValidationIssue) {
+	var allResources resources.ResourceSet
+	refIndex := make(map[string]resources.ResourceType)
+	l.refSources = nil
+	l.hooks = hooks.Config{}
+	l.inc = newIncludeState()
+
+	var issues []ValidationIssue
+	l.loadVariables = nil
+	if err := l.collectVariables(sources, recursive); err != nil {
+		issues = append(issues, ValidationIssue{Message: err.Error()})
+	}
+	add := func(file string, rs *resources.ResourceSet, err error, rootDir string) {
+		if err == nil {
+			err = l.appendSource(&allResources, rs, file, rootDir, refIndex, nil)
+		}
+		if err != nil {
+			issues = append(issues, ValidationIssue{File: file, Message: err.Error()})
+		}
+	}
+	parse := func(file string, content []byte, err error, rootDir string) {
+		if err != nil {
+			add(file, nil, err, rootDir)
+			return
+		}
+		if l.skipIncluded(file) {
+			return
+		}
+		if found := schemaIssues(file, content); len(found) > 0 {
+			issues = append(issues, found...)
+			return
+		}
+		rs, err := l.parseYAML(bytes.NewReader(content), file, rootDir)
+		add(file, rs, err, rootDir)
+	}
+
+	for _, source := range sources {
+		rootDir := l.resolveSourceRoot(source)
+
+		switch source.Type {
+		case SourceTypeFile:
+			content, err := readConfigFile(source.Path)
+			parse(source.Path, content, err, rootDir)
+		case SourceTypeDirectory:
+			paths := listConfigFiles(source.Path, recursive)
+			if len(paths) == 0 {
+				issues = append(issues, ValidationIssue{
+					File:    source.Path,
+					Message: fmt.Sprintf("no YAML or JSON files found in directory '%s'", source.Path),
+				})
+			}
+			for _, path := range paths {
+				content, err := readConfigFile(path)
+				parse(path, content, err, rootDir)
+			}
+		case SourceTypeSTDIN:
+			content, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				err = fmt.Errorf("failed to read content from stdin: %w", err)
+			}
+			parse("stdin", content, err, rootDir)
+		default:
+			issues = append(issues, ValidationIssue{
+				File:    source.Path,
+				Message: fmt.Sprintf("unknown source type: %v", source.Type),
+			})
+		}
+	}
+
+	if err := applyLabelDefaults(&allResources, l.defaultLabels); err != nil {
+		issues = append(issues, ValidationIssue{Message: fmt.Sprintf("invalid default labels: %v", err)})
+	}
+	l.applyDefaults(&allResources)
+
+	// Resolution stops at the first missing !ref target, so every one is reported first
+	if dangling := l.danglingRefIssues(&allResources); len(dangling) > 0 {
+		issues = append(issues, dangling...)
+	} else if err := ResolveReferences(ctx, &allResources); err != nil {
+		issues = append(issues, ValidationIssue{
+			File: l.circularReferenceLocation(err), Message: fmt.Sprintf("resolving references: %v", err),
+		})
+	}
+
+	// Checks across resources, such as name uniqueness and namespaces, repeat the
+	// resource checks first, so they only run once every resource passes those
+	resourceIssues := l.resourceIssues(&allResources)
+	issues = append(issues, resourceIssues...)
+	if len(resourceIssues) == 0 {
+		if err := l.validateResourceSet(&allResources); err != nil {
+			issues = append(issues, ValidationIssue{Message: err.Error()})
+		}
+	}
+
+	sortIssues(issues)
+	return &allResources, issues
+}
+
+// schemaIssues checks the content of a file against the schema. Content that is not
+// valid YAML returns no issues, leaving the parse error to the loader.
+func schemaIssues(file string, content []byte) []ValidationIssue {
+	found, err := Schema().Check(content)
+	if err != nil {
+		return nil
+	}
+	issues := make([]ValidationIssue, 0, len(found))
+	for _, issue := range found {
+		message := issue.Message
+		// Keys of earlier versions of the format point to the migration
+		if key, version, ok := migrate.RenamedKey(issue.Path); ok && strings.HasPrefix(message, "unknown field") {
+			message = fmt.Sprintf("replaced by %q in %s %d, run '%s migrate-config -f %s' to upgrade the file",
+				key, migrate.VersionKey, version, meta.CLIName, file)
+		}
+		issues = append(issues, ValidationIssue{
+			File: file, Line: issue.Line, Column: issue.Column, Field: issue.Path, Message: message,
+		})
+	}
+	return issues
+}
+
+// resourceIssues checks the ref, the required fields, the resource validation and
+// the references of each resource independently of the others
+func (l *Loader) resourceIssues(rs *resources.ResourceSet) []ValidationIssue {
+	var issues []ValidationIssue
+	rs.ForEachResource(func(r resources.Resource) bool {
+		issues = append(issues, l.addressIssues(r, rs)...)
+		// Resource validation repeats required field checks, so it only runs once those pass
+		if len(missingRequiredFields(r)) == 0 {
+			if err := r.Validate(); err != nil {
+				issues = append(issues, ValidationIssue{File: l.refSources[r.GetRef()], Ref: r.GetRef(), Message: err.Error()})
+			}
+		}
+		return true
+	})
+	return issues
+}
+
+// missingRequiredFields returns the JSON names of the empty required string fields
+// of the SDK request struct embedded in the resource. The SDK marks optional fields
+// with omitempty, so a string field without it is required by the Konnect API.
+// External resources are only referenced and are not checked.
+func missingRequiredFields(r resources.Resource) []string {
+	if external, ok := r.(interface{ IsExternal() bool }); ok && external.IsExternal() {
+		return nil
+	}
+
+	val := reflect.ValueOf(r)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var missing []string
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if !field.Anonymous || field.Type.Kind() != reflect.Struct || field.Type.PkgPath() != sdkComponentsPkgPath {
+			continue
+		}
+		embedded := val.Field(i)
+		for j := 0; j < embedded.NumField(); j++ {
+			sdkField := embedded.Type().Field(j)
+			name, opts, _ := strings.Cut(sdkField.Tag.Get("json"), ",")
+			if name == "" || name == "-" || strings.Contains(opts, "omitempty") {
+				continue
+			}
+			if sdkField.Type.Kind() == reflect.String && embedded.Field(j).String() == "" {
+				missing = append(missing, name)
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,145 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeValidateFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoader_Validate_Valid(t *testing.T) {
+	dir := t.TempDir()
+	writeValidateFile(t, dir, "portals.yaml", `
+portals:
+  - ref: dev-portal
+    name: Developer Portal
+`)
+	writeValidateFile(t, dir, "apis.yaml", `
+apis:
+  - ref: orders
+    name: Orders
+    publications:
+      - ref: orders-to-dev
+        portal_id: dev-portal
+`)
+
+	rs, issues := New().Validate(context.Background(),
+		[]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+
+	assert.Empty(t, issues)
+	require.NotNil(t, rs)
+	assert.Len(t, rs.Portals, 1)
+	assert.Len(t, rs.APIPublications, 1)
+}
+
+func TestLoader_Validate_ReportsAllIssues(t *testing.T) {
+	dir := t.TempDir()
+	apisPath := writeValidateFile(t, dir, "apis.yaml", `
+apis:
+  - ref: orders
+    name: Orders
+    publications:
+      - ref: orders-to-dev
+        portal_id: dev-portal
+`)
+	typoPath := writeValidateFile(t, dir, "typo.yaml", `
+portals:
+  - ref: dev-portal
+    nam: Developer Portal
+`)
+	envPath := writeValidateFile(t, dir, "env.yaml", `
+control_planes:
+  - ref: cp
+    name: !env KONGCTL_VALIDATE_TEST_UNSET
+`)
+
+	_, issues := New().Validate(context.Background(),
+		[]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, apisPath, issues[0].File)
+	assert.Equal(t, "orders-to-dev", issues[0].Ref)
+	assert.Equal(t, "portal_id", issues[0].Field)
+	assert.Contains(t, issues[0].Message, "references unknown portal: dev-portal")
+
+	assert.Equal(t, envPath, issues[1].File)
+	assert.Contains(t, issues[1].Message, "KONGCTL_VALIDATE_TEST_UNSET is not set")
+
+	assert.Equal(t, typoPath, issues[2].File)
+	assert.Equal(t, 4, issues[2].Line)
+	assert.Equal(t, 5, issues[2].Column)
+	assert.Equal(t, "portals[0].nam", issues[2].Field)
+	assert.Equal(t, `unknown field, did you mean "name"?`, issues[2].Message)
+}
+
+func TestLoader_Validate_DuplicateRefAcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeValidateFile(t, dir, "a.yaml", `
+portals:
+  - ref: dev-portal
+    name: Developer Portal
+`)
+	second := writeValidateFile(t, dir, "b.yaml", `
+portals:
+  - ref: dev-portal
+    name: Another Portal
+`)
+
+	_, issues := New().Validate(context.Background(),
+		[]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, second, issues[0].File)
+	assert.Contains(t, issues[0].Message, "duplicate ref 'dev-portal'")
+}
+
+func TestLoader_Validate_NoYAMLFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, issues := New().Validate(context.Background(),
+		[]Source{{Path: dir, Type: SourceTypeDirectory}}, false)
+
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "no YAML or JSON files found")
+}
+
+func TestMissingRequiredFields(t *testing.T) {
+	page := &resources.PortalPageResource{
+		Ref:                     "page",
+		CreatePortalPageRequest: kkComps.CreatePortalPageRequest{Slug: "getting-started"},
+	}
+	assert.Equal(t, []string{"content"}, missingRequiredFields(page))
+
+	page.Content = "# Getting started"
+	assert.Empty(t, missingRequiredFields(page))
+
+	external := &resources.PortalResource{
+		BaseResource: resources.BaseResource{Ref: "shared"},
+		External:     &resources.ExternalBlock{ID: "0b9a2f0e-72e7-4212-9099-0764f8e9c5ac"},
+	}
+	assert.Empty(t, missingRequiredFields(external))
+}
+
+func TestValidationIssue_String(t *testing.T) {
+	issue := ValidationIssue{
+		File:    "apis.yaml",
+		Ref:     "orders",
+		Field:   "name",
+		Message: "required field is missing",
+	}
+	assert.Equal(t, `apis.yaml: ref "orders": field "name": required field is missing`, issue.String())
+	assert.Equal(t, "resolving references: boom", ValidationIssue{Message: "resolving references: boom"}.String())
+}
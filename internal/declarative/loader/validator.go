@@ -3,6 +3,8 @@ package loader
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/kong/kongctl/internal/declarative/resources"
@@ -47,11 +49,31 @@ func (l *Loader) validateResourceSet(rs *resources.ResourceSet) error {
 		return err
 	}
 
+	// Validate slugs and names that must be unique among siblings
+	if err := l.validateSiblingUniqueness(rs); err != nil {
+		return err
+	}
+
 	// Validate organization teams
 	if err := l.validateOrganizationTeams(rs.OrganizationTeams, rs); err != nil {
 		return err
 	}
 
+	// Validate organization team roles and members
+	if err := l.validateOrganizationTeamChildren(rs); err != nil {
+		return err
+	}
+
+	// Validate notification subscriptions
+	if err := l.validateNotificationSubscriptions(rs); err != nil {
+		return err
+	}
+
+	// Validate custom resources
+	if err := l.validateCustomResources(rs.CustomResources, rs); err != nil {
+		return err
+	}
+
 	// Validate cross-resource references
 	if err := l.validateCrossReferences(rs); err != nil {
 		return err
@@ -62,6 +84,11 @@ func (l *Loader) validateResourceSet(rs *resources.ResourceSet) error {
 		return err
 	}
 
+	// Validate the moved entries of renamed resources
+	if err := l.validateMoved(rs); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -99,6 +126,184 @@ func (l *Loader) validateOrganizationTeams(teams []resources.OrganizationTeamRes
 	return nil
 }
 
+// validateOrganizationTeamChildren validates the roles and members of organization teams.
+// They can only be declared on teams kongctl manages.
+func (l *Loader) validateOrganizationTeamChildren(rs *resources.ResourceSet) error {
+	teams := make(map[string]*resources.OrganizationTeamResource, len(rs.OrganizationTeams))
+	for i := range rs.OrganizationTeams {
+		teams[rs.OrganizationTeams[i].GetRef()] = &rs.OrganizationTeams[i]
+	}
+
+	checkParent := func(kind, ref, teamRef string) error {
+		team, ok := teams[teamRef]
+		if !ok {
+			return fmt.Errorf("%s %q references unknown organization_team %q", kind, ref, teamRef)
+		}
+		if team.IsExternal() {
+			return fmt.Errorf("%s %q cannot be declared on external organization_team %q", kind, ref, teamRef)
+		}
+		return nil
+	}
+
+	assignments := make(map[string]string) // team|assignment -> ref
+	for i := range rs.OrganizationTeamRoles {
+		role := &rs.OrganizationTeamRoles[i]
+		if err := role.Validate(); err != nil {
+			return fmt.Errorf("invalid organization_team_role %q: %w", role.GetRef(), err)
+		}
+		if err := checkParent("organization_team_role", role.GetRef(), role.Team); err != nil {
+			return err
+		}
+		if existing, found := rs.GetResourceByRef(role.GetRef()); found &&
+			existing.GetType() != resources.ResourceTypeOrganizationTeamRole {
+			return fmt.Errorf("duplicate ref '%s' (already defined as %s)", role.GetRef(), existing.GetType())
+		}
+
+		key := role.Team + "|" + role.GetMoniker()
+		if existingRef, exists := assignments[key]; exists {
+			return fmt.Errorf("duplicate organization_team_role %q on team %q (ref: %s conflicts with ref: %s)",
+				role.GetMoniker(), role.Team, role.GetRef(), existingRef)
+		}
+		assignments[key] = role.GetRef()
+	}
+
+	members := make(map[string]string) // team|email -> ref
+	for i := range rs.OrganizationTeamMembers {
+		member := &rs.OrganizationTeamMembers[i]
+		if err := member.Validate(); err != nil {
+			return fmt.Errorf("invalid organization_team_member %q: %w", member.GetRef(), err)
+		}
+		if err := checkParent("organization_team_member", member.GetRef(), member.Team); err != nil {
+			return err
+		}
+		if existing, found := rs.GetResourceByRef(member.GetRef()); found &&
+			existing.GetType() != resources.ResourceTypeOrganizationTeamMember {
+			return fmt.Errorf("duplicate ref '%s' (already defined as %s)", member.GetRef(), existing.GetType())
+		}
+
+		key := member.Team + "|" + strings.ToLower(member.Email)
+		if existingRef, exists := members[key]; exists {
+			return fmt.Errorf("duplicate organization_team_member %q on team %q (ref: %s conflicts with ref: %s)",
+				member.Email, member.Team, member.GetRef(), existingRef)
+		}
+		members[key] = member.GetRef()
+	}
+
+	return nil
+}
+
+// validateNotificationSubscriptions validates notification subscriptions. Names are
+// unique per event, and !ref entities must point to resources notifications are sent for.
+func (l *Loader) validateNotificationSubscriptions(rs *resources.ResourceSet) error {
+	names := make(map[string]string) // event|name -> ref
+	for i := range rs.NotificationSubscriptions {
+		subscription := &rs.NotificationSubscriptions[i]
+		if err := subscription.Validate(); err != nil {
+			return fmt.Errorf("invalid notification_subscription %q: %w", subscription.GetRef(), err)
+		}
+		if existing, found := rs.GetResourceByRef(subscription.GetRef()); found &&
+			existing.GetType() != resources.ResourceTypeNotificationSubscription {
+			return fmt.Errorf("duplicate ref '%s' (already defined as %s)", subscription.GetRef(), existing.GetType())
+		}
+
+		key := subscription.EventID + "|" + subscription.Name
+		if existingRef, exists := names[key]; exists {
+			return fmt.Errorf(
+				"duplicate notification_subscription name %q for event %q (ref: %s conflicts with ref: %s)",
+				subscription.Name, subscription.EventID, subscription.GetRef(), existingRef)
+		}
+		names[key] = subscription.GetRef()
+
+		for _, ref := range subscription.EntityRefs() {
+			target, found := rs.GetResourceByRef(ref)
+			if !found {
+				return fmt.Errorf("notification_subscription %q references unknown resource %q",
+					subscription.GetRef(), ref)
+			}
+			switch target.GetType() {
+			case resources.ResourceTypePortal, resources.ResourceTypeControlPlane, resources.ResourceTypeAPI:
+			default:
+				return fmt.Errorf("notification_subscription %q: entities cannot reference %s %q, "+
+					"only portals, control planes and APIs", subscription.GetRef(), target.GetType(), ref)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMoved validates the moved entries. Each renames a resource of the
+// configuration, of a type that can be renamed, from a ref it no longer defines, and
+// a ref is moved from or to once only.
+func (l *Loader) validateMoved(rs *resources.ResourceSet) error {
+	from := make(map[string]bool, len(rs.Moved))
+	to := make(map[string]bool, len(rs.Moved))
+	for _, moved := range rs.Moved {
+		if err := moved.Validate(); err != nil {
+			return fmt.Errorf("invalid moved entry from %q: %w", moved.From, err)
+		}
+		if from[moved.From] {
+			return fmt.Errorf("ref %q is moved more than once", moved.From)
+		}
+		if to[moved.To] {
+			return fmt.Errorf("more than one moved entry targets ref %q", moved.To)
+		}
+		from[moved.From], to[moved.To] = true, true
+
+		if existing, found := rs.GetResourceByRef(moved.From); found {
+			return fmt.Errorf("moved entry from %q: the configuration still defines %s %q; "+
+				"remove the entry or rename the resource", moved.From, existing.GetType(), moved.From)
+		}
+		target, found := rs.GetResourceByRef(moved.To)
+		if !found {
+			return fmt.Errorf("moved entry from %q targets unknown resource %q", moved.From, moved.To)
+		}
+		if !slices.Contains(resources.MovableResourceTypes, target.GetType()) {
+			return fmt.Errorf("moved entry from %q: %s %q cannot be moved, only portals, APIs, "+
+				"application auth strategies, control planes, catalog services and organization teams",
+				moved.From, target.GetType(), moved.To)
+		}
+		if external, ok := target.(interface{ IsExternal() bool }); ok && external.IsExternal() {
+			return fmt.Errorf("moved entry from %q: %s %q is external, kongctl does not rename it",
+				moved.From, target.GetType(), moved.To)
+		}
+	}
+	return nil
+}
+
+// validateCustomResources validates custom resources. Kind-specific validation is
+// performed by the registered handler during planning.
+func (l *Loader) validateCustomResources(customResources []resources.CustomResource,
+	rs *resources.ResourceSet,
+) error {
+	names := make(map[string]string) // kind/name -> ref mapping (names unique per kind)
+
+	for i := range customResources {
+		res := &customResources[i]
+
+		if err := res.Validate(); err != nil {
+			return fmt.Errorf("invalid custom resource %q: %w", res.GetRef(), err)
+		}
+
+		// Check global ref uniqueness across different resource types
+		if existing, found := rs.GetResourceByRef(res.GetRef()); found {
+			if existing.GetType() != res.GetType() {
+				return fmt.Errorf("duplicate ref '%s' (already defined as %s)",
+					res.GetRef(), existing.GetType())
+			}
+		}
+
+		key := res.Kind + "/" + res.Name
+		if existingRef, exists := names[key]; exists {
+			return fmt.Errorf("duplicate %s name '%s' (ref: %s conflicts with ref: %s)",
+				res.Kind, res.Name, res.GetRef(), existingRef)
+		}
+		names[key] = res.GetRef()
+	}
+
+	return nil
+}
+
 // validateGatewayServices validates gateway service resources
 func (l *Loader) validateGatewayServices(
 	services []resources.GatewayServiceResource,
@@ -568,6 +773,30 @@ func (l *Loader) validateSeparateAPIChildResources(rs *resources.ResourceSet) er
 		portalToConfigRef[cfg.Portal] = cfg.GetRef()
 	}
 
+	// Validate portal audit log webhooks (singleton per portal)
+	portalToWebhookRef := make(map[string]string)
+	for i := range rs.PortalAuditLogWebhooks {
+		webhook := &rs.PortalAuditLogWebhooks[i]
+		if err := webhook.Validate(); err != nil {
+			return fmt.Errorf("invalid portal_audit_log_webhook %q: %w", webhook.GetRef(), err)
+		}
+		for j := i + 1; j < len(rs.PortalAuditLogWebhooks); j++ {
+			if rs.PortalAuditLogWebhooks[j].GetRef() == webhook.GetRef() {
+				return fmt.Errorf("duplicate ref '%s' (already defined as portal_audit_log_webhook)", webhook.GetRef())
+			}
+		}
+		if webhook.Portal == "" {
+			return fmt.Errorf("portal_audit_log_webhook %q must specify portal", webhook.GetRef())
+		}
+		if existingRef, ok := portalToWebhookRef[webhook.Portal]; ok {
+			return fmt.Errorf(
+				"multiple portal_audit_log_webhook entries target portal %q (%s and %s)",
+				webhook.Portal, existingRef, webhook.GetRef(),
+			)
+		}
+		portalToWebhookRef[webhook.Portal] = webhook.GetRef()
+	}
+
 	// Validate portal email templates (one per template name per portal)
 	portalTemplateRefs := make(map[string]bool)
 	portalTemplateNames := make(map[string]map[string]string)
@@ -607,8 +836,20 @@ func (l *Loader) validateSeparateAPIChildResources(rs *resources.ResourceSet) er
 
 // validateResourceReferences validates references for a single resource using its mapping
 func (l *Loader) validateResourceReferences(resource any, rs *resources.ResourceSet) error {
-	// fmt.Printf("DEBUG: validateResourceReferences called with resource type: %T\n", resource)
+	if problems := l.findReferenceProblems(resource, rs); len(problems) > 0 {
+		return problems[0].err
+	}
+	return nil
+}
+
+// referenceProblem is a reference field that does not point at a resource of the expected type
+type referenceProblem struct {
+	field string
+	err   error
+}
 
+// findReferenceProblems checks every reference field of a resource, in field order
+func (l *Loader) findReferenceProblems(resource any, rs *resources.ResourceSet) []referenceProblem {
 	// Check if resource implements ReferenceMapping
 	refMapper, ok := resource.(resources.ReferenceMapping)
 	if !ok {
@@ -622,11 +863,16 @@ func (l *Loader) validateResourceReferences(resource any, rs *resources.Resource
 	}
 
 	mappings := refMapper.GetReferenceFieldMappings()
-	// fmt.Printf("DEBUG: Reference mappings: %v\n", mappings)
+	fieldPaths := make([]string, 0, len(mappings))
+	for fieldPath := range mappings {
+		fieldPaths = append(fieldPaths, fieldPath)
+	}
+	sort.Strings(fieldPaths)
 
-	for fieldPath, expectedType := range mappings {
+	var problems []referenceProblem
+	for _, fieldPath := range fieldPaths {
+		expectedType := mappings[fieldPath]
 		fieldValue := l.getFieldValue(resource, fieldPath)
-		// fmt.Printf("DEBUG: Field %s = '%s' (expected type: %s)\n", fieldPath, fieldValue, expectedType)
 
 		if fieldValue == "" {
 			continue // Empty references are allowed (optional fields)
@@ -646,18 +892,21 @@ func (l *Loader) validateResourceReferences(resource any, rs *resources.Resource
 
 		// Check if the referenced resource exists using RefReader
 		if !rs.HasRef(fieldValue) {
-			return fmt.Errorf("resource %q references unknown %s: %s (field: %s)",
-				refResource.GetRef(), expectedType, fieldValue, fieldPath)
+			problems = append(problems, referenceProblem{field: fieldPath, err: fmt.Errorf(
+				"resource %q references unknown %s: %s (field: %s)",
+				refResource.GetRef(), expectedType, fieldValue, fieldPath)})
+			continue
 		}
 
 		// Verify the referenced resource is of the expected type
 		if actualType, _ := rs.GetResourceTypeByRef(fieldValue); string(actualType) != expectedType {
-			return fmt.Errorf("resource %q references %s but expected %s: %s (field: %s)",
-				refResource.GetRef(), actualType, expectedType, fieldValue, fieldPath)
+			problems = append(problems, referenceProblem{field: fieldPath, err: fmt.Errorf(
+				"resource %q references %s but expected %s: %s (field: %s)",
+				refResource.GetRef(), actualType, expectedType, fieldValue, fieldPath)})
 		}
 	}
 
-	return nil
+	return problems
 }
 
 // getFieldValue extracts field value using reflection, supporting qualified field names
@@ -803,6 +1052,13 @@ func (l *Loader) validateNamespaces(rs *resources.ResourceSet) error {
 		}
 	}
 
+	// Custom resources
+	for _, res := range rs.CustomResources {
+		if res.Kongctl != nil && res.Kongctl.Namespace != nil {
+			namespaces[*res.Kongctl.Namespace] = true
+		}
+	}
+
 	// Convert to slice for validation
 	namespaceList := make([]string, 0, len(namespaces))
 	for ns := range namespaces {
@@ -470,6 +470,70 @@ func TestLoader_validateAPIs(t *testing.T) {
 	}
 }
 
+func TestLoader_validateMoved(t *testing.T) {
+	loader := New()
+	newSet := func(moved ...resources.MovedResource) *resources.ResourceSet {
+		return &resources.ResourceSet{
+			Portals: []resources.PortalResource{{
+				BaseResource: resources.BaseResource{Ref: "developer-hub"},
+				CreatePortal: kkComps.CreatePortal{Name: "Developer Hub"},
+			}},
+			APIVersions: []resources.APIVersionResource{{Ref: "orders-v1"}},
+			Moved:       moved,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		moved       []resources.MovedResource
+		expectedErr string
+	}{
+		{
+			name:  "valid move",
+			moved: []resources.MovedResource{{From: "dev-portal", To: "developer-hub", Name: "Dev Portal"}},
+		},
+		{
+			name:        "same refs",
+			moved:       []resources.MovedResource{{From: "developer-hub", To: "developer-hub"}},
+			expectedErr: `from and to are both "developer-hub"`,
+		},
+		{
+			name:        "former ref still defined",
+			moved:       []resources.MovedResource{{From: "orders-v1", To: "developer-hub"}},
+			expectedErr: `the configuration still defines api_version "orders-v1"`,
+		},
+		{
+			name:        "unknown target",
+			moved:       []resources.MovedResource{{From: "dev-portal", To: "portal"}},
+			expectedErr: `targets unknown resource "portal"`,
+		},
+		{
+			name:        "target that cannot be moved",
+			moved:       []resources.MovedResource{{From: "orders-v0", To: "orders-v1"}},
+			expectedErr: `api_version "orders-v1" cannot be moved`,
+		},
+		{
+			name: "ref moved twice",
+			moved: []resources.MovedResource{
+				{From: "dev-portal", To: "developer-hub"},
+				{From: "dev-portal", To: "orders-v1"},
+			},
+			expectedErr: `ref "dev-portal" is moved more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loader.validateMoved(newSet(tt.moved...))
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestLoader_validateCrossReferences(t *testing.T) {
 	loader := New()
 
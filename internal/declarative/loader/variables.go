@@ -0,0 +1,126 @@
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadVariableFile reads a YAML or JSON file of variable values, a map of names to
+// values. A file with a top-level variables section, like a configuration file, is
+// read for that section.
+func LoadVariableFile(path string) (map[string]any, error) {
+	content, err := readConfigFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variables file: %w", err)
+	}
+	var variables map[string]any
+	if err := yaml.Unmarshal(content, &variables); err != nil {
+		return nil, fmt.Errorf("failed to parse variables file %s: %w", path, err)
+	}
+	if section, ok := variables[tags.VariablesKey].(map[string]any); ok && len(variables) == 1 {
+		variables = section
+	}
+	if err := tags.ValidateVariableNames(variables); err != nil {
+		return nil, fmt.Errorf("invalid variables file %s: %w", path, err)
+	}
+	return variables, nil
+}
+
+// SetVariables sets the values of variables, such as those of --var-file. They take
+// precedence over the values the variables sections of the configuration declare.
+func (l *Loader) SetVariables(variables map[string]any) {
+	l.variables = maps.Clone(variables)
+}
+
+// collectVariables gathers the variables the file and directory sources declare, and
+// the values set with SetVariables, before any file is parsed, so a file can use the
+// variables another file declares. A variable declared with different values by two
+// files is an error. Stdin is read once, so its variables only apply to itself.
+func (l *Loader) collectVariables(sources []Source, recursive bool) error {
+	declared := make(map[string]any)
+	declaredIn := make(map[string]string)
+	var problems []string
+
+	for _, source := range sources {
+		var paths []string
+		switch source.Type {
+		case SourceTypeFile:
+			paths = []string{source.Path}
+		case SourceTypeDirectory:
+			paths = listConfigFiles(source.Path, recursive)
+		case SourceTypeSTDIN:
+			continue
+		}
+		paths = withIncludedFiles(paths)
+
+		for _, path := range paths {
+			variables, err := fileVariables(path)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", path, err))
+				continue
+			}
+			for _, name := range slices.Sorted(maps.Keys(variables)) {
+				if other, ok := declaredIn[name]; ok && !reflect.DeepEqual(declared[name], variables[name]) {
+					problems = append(problems, fmt.Sprintf("%s: variable %q is also declared with another value in %s",
+						path, name, other))
+					continue
+				}
+				declared[name] = variables[name]
+				declaredIn[name] = path
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid variables:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	maps.Copy(declared, l.variables)
+	l.loadVariables = declared
+	return nil
+}
+
+// fileVariables returns the variables a configuration file declares. Files that
+// cannot be read are skipped; loading reports those errors.
+func fileVariables(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), tags.VariablesKey) {
+		return nil, nil
+	}
+	if ValidateJSONFile(path) {
+		if content, err = tags.ConvertJSON(content); err != nil {
+			return nil, nil
+		}
+	}
+	return tags.DeclaredVariables(content)
+}
+
+// interpolateVariables replaces the variable references of the content of a source
+// with the values of the variables of the load and of the source itself
+func (l *Loader) interpolateVariables(content []byte, sourcePath string) ([]byte, error) {
+	if !tags.HasVariableReferences(content) {
+		return content, nil
+	}
+	variables := l.loadVariables
+	if variables == nil {
+		variables = l.variables
+	}
+	own, err := tags.DeclaredVariables(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", sourcePath, err)
+	}
+	if len(own) > 0 {
+		// A source outside of the sources collectVariables read, such as stdin,
+		// declares its own variables. Values set for the load still take precedence.
+		merged := maps.Clone(own)
+		maps.Copy(merged, variables)
+		variables = merged
+	}
+	return tags.InterpolateVariables(content, sourcePath, variables)
+}
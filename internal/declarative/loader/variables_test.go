@@ -0,0 +1,77 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_Variables(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"variables.yaml": `
+variables:
+  env: dev
+  rbac: false
+`,
+		"portal.yaml": `
+portals:
+  - ref: portal
+    name: portal-${var.env}
+    description: !file ./descriptions/${var.env}.txt
+    rbac_enabled: ${var.rbac}
+`,
+		"descriptions/dev.txt":  "Development portal",
+		"descriptions/prod.txt": "Production portal",
+		"prod.vars.yaml":        "env: prod\nrbac: true\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	sources := []Source{
+		{Path: filepath.Join(dir, "variables.yaml"), Type: SourceTypeFile},
+		{Path: filepath.Join(dir, "portal.yaml"), Type: SourceTypeFile},
+	}
+
+	t.Run("declared values", func(t *testing.T) {
+		rs, err := NewWithBaseDir(dir).LoadFromSources(sources, false)
+		require.NoError(t, err)
+		require.Len(t, rs.Portals, 1)
+		assert.Equal(t, "portal-dev", rs.Portals[0].Name)
+		assert.Equal(t, "Development portal", *rs.Portals[0].Description)
+		assert.False(t, *rs.Portals[0].RbacEnabled)
+	})
+
+	t.Run("variables file takes precedence", func(t *testing.T) {
+		variables, err := LoadVariableFile(filepath.Join(dir, "prod.vars.yaml"))
+		require.NoError(t, err)
+		ldr := NewWithBaseDir(dir)
+		ldr.SetVariables(variables)
+
+		rs, err := ldr.LoadFromSources(sources, false)
+		require.NoError(t, err)
+		assert.Equal(t, "portal-prod", rs.Portals[0].Name)
+		assert.Equal(t, "Production portal", *rs.Portals[0].Description)
+		assert.True(t, *rs.Portals[0].RbacEnabled)
+	})
+
+	t.Run("undefined variable", func(t *testing.T) {
+		_, err := NewWithBaseDir(dir).LoadFromSources(sources[1:], false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `portal.yaml:4: undefined variable "env"`)
+	})
+
+	t.Run("conflicting declarations", func(t *testing.T) {
+		other := filepath.Join(t.TempDir(), "other.yaml")
+		require.NoError(t, os.WriteFile(other, []byte("variables:\n  env: test\n"), 0o600))
+
+		_, err := NewWithBaseDir(dir).LoadFromSources(append(sources, Source{Path: other, Type: SourceTypeFile}), false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `variable "env" is also declared with another value`)
+	})
+}
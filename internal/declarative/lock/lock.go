@@ -0,0 +1,219 @@
+// Package lock provides advisory locks that keep concurrent kongctl applies from
+// changing the same namespace at once. A lock is an organization team that carries
+// the lock label and records its holder; teams have no effect until roles are
+// assigned to them, and are available to every organization.
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"time"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+)
+
+// teamNamePrefix starts the name of the team locking a namespace
+const teamNamePrefix = "kongctl-lock-"
+
+// listPageSize is the page size used to list the teams of a lock
+const listPageSize = 100
+
+// Lock is a lock held on a namespace
+type Lock struct {
+	// ID is the ID of the team holding the lock
+	ID         string    `json:"-"`
+	Namespace  string    `json:"namespace"`
+	Holder     string    `json:"holder"`
+	Command    string    `json:"command,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// HeldError is returned when a namespace is locked by another holder
+type HeldError struct {
+	Lock Lock
+	// Age is how long the lock has been held
+	Age time.Duration
+}
+
+func (e *HeldError) Error() string {
+	command := ""
+	if e.Lock.Command != "" {
+		command = " running " + e.Lock.Command
+	}
+	return fmt.Sprintf("namespace %q is locked by %s%s since %s (%s ago)",
+		e.Lock.Namespace, e.Lock.Holder, command,
+		e.Lock.AcquiredAt.Local().Format(time.RFC3339), e.Age.Round(time.Second))
+}
+
+// Locker acquires and releases namespace locks
+type Locker struct {
+	api     helpers.OrganizationTeamAPI
+	holder  string
+	command string
+	now     func() time.Time
+}
+
+// NewLocker creates a locker taking locks for holder while running command
+func NewLocker(api helpers.OrganizationTeamAPI, holder, command string) *Locker {
+	return &Locker{api: api, holder: holder, command: command, now: time.Now}
+}
+
+// DefaultHolder identifies the current process, as user@host (pid N)
+func DefaultHolder() string {
+	name := "unknown"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s@%s (pid %d)", name, host, os.Getpid())
+}
+
+// Acquire locks namespace, failing with a HeldError when it is already locked.
+// Team names are not unique in Konnect, so the lock is created and then the
+// earliest lock of the namespace wins; a lock that lost the race is removed.
+func (l *Locker) Acquire(ctx context.Context, namespace string) (*Lock, error) {
+	held, err := l.List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(held) > 0 {
+		return nil, l.heldError(held[0])
+	}
+
+	lock := Lock{Namespace: namespace, Holder: l.holder, Command: l.command, AcquiredAt: l.now().UTC()}
+	description, err := json.Marshal(lock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %w", err)
+	}
+	res, err := l.api.CreateOrganizationTeam(ctx, &kkComps.CreateTeam{
+		Name:        teamNamePrefix + namespace,
+		Description: kk.String(string(description)),
+		Labels:      map[string]string{labels.LockKey: labels.TrueValue},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock namespace %q: %w", namespace, err)
+	}
+	if res == nil || res.Team == nil || res.Team.ID == nil {
+		return nil, fmt.Errorf("failed to lock namespace %q: empty response from Konnect", namespace)
+	}
+	lock.ID = *res.Team.ID
+
+	held, err = l.List(ctx, namespace)
+	if err != nil {
+		_ = l.Release(ctx, &lock)
+		return nil, err
+	}
+	if len(held) > 0 && held[0].ID != lock.ID {
+		if err := l.Release(ctx, &lock); err != nil {
+			return nil, err
+		}
+		return nil, l.heldError(held[0])
+	}
+	return &lock, nil
+}
+
+// Release removes a lock
+func (l *Locker) Release(ctx context.Context, lock *Lock) error {
+	if lock == nil || lock.ID == "" {
+		return nil
+	}
+	if _, err := l.api.DeleteOrganizationTeam(ctx, lock.ID); err != nil {
+		return fmt.Errorf("failed to unlock namespace %q: %w", lock.Namespace, err)
+	}
+	return nil
+}
+
+// ForceUnlock removes every lock of namespace, e.g. a lock left behind by an apply
+// that was killed, and returns the locks removed
+func (l *Locker) ForceUnlock(ctx context.Context, namespace string) ([]Lock, error) {
+	held, err := l.List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range held {
+		if err := l.Release(ctx, &held[i]); err != nil {
+			return nil, err
+		}
+	}
+	return held, nil
+}
+
+// List returns the locks of namespace, earliest first
+func (l *Locker) List(ctx context.Context, namespace string) ([]Lock, error) {
+	name := teamNamePrefix + namespace
+	var (
+		locks   []Lock
+		created = make(map[string]time.Time)
+	)
+	for page := int64(1); ; page++ {
+		res, err := l.api.ListOrganizationTeams(ctx, kkOps.ListTeamsRequest{
+			PageSize:   kk.Int64(listPageSize),
+			PageNumber: kk.Int64(page),
+			Filter: &kkOps.ListTeamsQueryParamFilter{
+				Name: &kkComps.LegacyStringFieldFilter{Eq: kk.String(name)},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the lock of namespace %q: %w", namespace, err)
+		}
+		if res == nil || res.TeamCollection == nil {
+			break
+		}
+		for _, team := range res.TeamCollection.Data {
+			if team.GetName() == nil || *team.GetName() != name || team.Labels[labels.LockKey] != labels.TrueValue {
+				continue
+			}
+			lock := teamLock(team, namespace)
+			locks = append(locks, lock)
+			if team.CreatedAt != nil {
+				created[lock.ID] = *team.CreatedAt
+			} else {
+				created[lock.ID] = lock.AcquiredAt
+			}
+		}
+		if len(res.TeamCollection.Data) < listPageSize {
+			break
+		}
+	}
+
+	sort.SliceStable(locks, func(i, j int) bool {
+		a, b := created[locks[i].ID], created[locks[j].ID]
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return locks[i].ID < locks[j].ID
+	})
+	return locks, nil
+}
+
+// teamLock reads the lock a team records. Teams whose description was edited are
+// reported with an unknown holder, acquired when the team was created.
+func teamLock(team kkComps.Team, namespace string) Lock {
+	var lock Lock
+	if team.Description == nil || json.Unmarshal([]byte(*team.Description), &lock) != nil || lock.Holder == "" {
+		lock = Lock{Holder: "an unknown holder"}
+		if team.CreatedAt != nil {
+			lock.AcquiredAt = *team.CreatedAt
+		}
+	}
+	lock.Namespace = namespace
+	if team.ID != nil {
+		lock.ID = *team.ID
+	}
+	return lock
+}
+
+func (l *Locker) heldError(lock Lock) error {
+	return &HeldError{Lock: lock, Age: l.now().Sub(lock.AcquiredAt)}
+}
@@ -0,0 +1,161 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTeamAPI keeps teams in memory. beforeList runs before every list, e.g. to
+// create a competing lock.
+type fakeTeamAPI struct {
+	teams      []kkComps.Team
+	nextID     int
+	clock      time.Time
+	beforeList func()
+}
+
+func (f *fakeTeamAPI) add(name, description string, teamLabels map[string]string) string {
+	f.nextID++
+	f.clock = f.clock.Add(time.Second)
+	id := fmt.Sprintf("team-%d", f.nextID)
+	createdAt := f.clock
+	f.teams = append(f.teams, kkComps.Team{
+		ID: kk.String(id), Name: kk.String(name), Description: kk.String(description),
+		Labels: teamLabels, CreatedAt: &createdAt,
+	})
+	return id
+}
+
+func (f *fakeTeamAPI) ListOrganizationTeams(
+	_ context.Context, request kkOps.ListTeamsRequest,
+) (*kkOps.ListTeamsResponse, error) {
+	if f.beforeList != nil {
+		f.beforeList()
+	}
+	var data []kkComps.Team
+	for _, team := range f.teams {
+		if request.Filter == nil || *team.Name == *request.Filter.Name.Eq {
+			data = append(data, team)
+		}
+	}
+	return &kkOps.ListTeamsResponse{TeamCollection: &kkComps.TeamCollection{Data: data}}, nil
+}
+
+func (f *fakeTeamAPI) GetOrganizationTeam(context.Context, string) (*kkOps.GetTeamResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeTeamAPI) CreateOrganizationTeam(
+	_ context.Context, team *kkComps.CreateTeam,
+) (*kkOps.CreateTeamResponse, error) {
+	id := f.add(team.Name, *team.Description, team.Labels)
+	return &kkOps.CreateTeamResponse{Team: &kkComps.Team{ID: kk.String(id)}}, nil
+}
+
+func (f *fakeTeamAPI) UpdateOrganizationTeam(
+	context.Context, string, *kkComps.UpdateTeam,
+) (*kkOps.UpdateTeamResponse, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeTeamAPI) DeleteOrganizationTeam(_ context.Context, id string) (*kkOps.DeleteTeamResponse, error) {
+	for i, team := range f.teams {
+		if *team.ID == id {
+			f.teams = append(f.teams[:i], f.teams[i+1:]...)
+			return &kkOps.DeleteTeamResponse{}, nil
+		}
+	}
+	return nil, fmt.Errorf("team %s not found", id)
+}
+
+func newTestLocker(api *fakeTeamAPI, holder string) *Locker {
+	locker := NewLocker(api, holder, "kongctl apply")
+	locker.now = func() time.Time { return api.clock }
+	return locker
+}
+
+func TestLocker_AcquireAndRelease(t *testing.T) {
+	api := &fakeTeamAPI{clock: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	first := newTestLocker(api, "alice@ci-1 (pid 1)")
+	second := newTestLocker(api, "bob@ci-2 (pid 2)")
+
+	lock, err := first.Acquire(context.Background(), "payments")
+	require.NoError(t, err)
+	assert.Equal(t, "payments", lock.Namespace)
+	require.Len(t, api.teams, 1)
+	assert.Equal(t, "kongctl-lock-payments", *api.teams[0].Name)
+	assert.Equal(t, labels.TrueValue, api.teams[0].Labels[labels.LockKey])
+
+	// Other namespaces are not locked
+	other, err := second.Acquire(context.Background(), "orders")
+	require.NoError(t, err)
+	require.NoError(t, second.Release(context.Background(), other))
+
+	api.clock = api.clock.Add(90 * time.Second)
+	_, err = second.Acquire(context.Background(), "payments")
+	var held *HeldError
+	require.ErrorAs(t, err, &held)
+	assert.Equal(t, "alice@ci-1 (pid 1)", held.Lock.Holder)
+	assert.Equal(t, "kongctl apply", held.Lock.Command)
+	assert.Equal(t, 92*time.Second, held.Age)
+	assert.Contains(t, err.Error(), `namespace "payments" is locked by alice@ci-1 (pid 1) running kongctl apply`)
+	assert.Contains(t, err.Error(), "(1m32s ago)")
+
+	require.NoError(t, first.Release(context.Background(), lock))
+	assert.Empty(t, api.teams)
+	_, err = second.Acquire(context.Background(), "payments")
+	require.NoError(t, err)
+}
+
+func TestLocker_AcquireLosesRace(t *testing.T) {
+	api := &fakeTeamAPI{clock: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	lists := 0
+	api.beforeList = func() {
+		lists++
+		// Another run created its lock just before this one, but it was not listed
+		// when the namespace was checked
+		if lists == 2 {
+			createdAt := api.clock.Add(-time.Second)
+			api.teams = append(api.teams, kkComps.Team{
+				ID:          kk.String("team-0"),
+				Name:        kk.String("kongctl-lock-default"),
+				Description: kk.String(`{"holder":"bob@ci-2 (pid 2)","acquired_at":"2026-01-02T03:04:05Z"}`),
+				Labels:      map[string]string{labels.LockKey: labels.TrueValue},
+				CreatedAt:   &createdAt,
+			})
+		}
+	}
+	_, err := newTestLocker(api, "alice@ci-1 (pid 1)").Acquire(context.Background(), "default")
+
+	var held *HeldError
+	require.ErrorAs(t, err, &held)
+	assert.Equal(t, "bob@ci-2 (pid 2)", held.Lock.Holder)
+	require.Len(t, api.teams, 1, "the lock that lost the race is removed")
+	assert.Equal(t, "team-0", *api.teams[0].ID)
+}
+
+func TestLocker_ForceUnlock(t *testing.T) {
+	api := &fakeTeamAPI{}
+	api.add("kongctl-lock-default", "edited by hand", map[string]string{labels.LockKey: labels.TrueValue})
+	// A team of the same name without the lock label is not a lock
+	api.add("kongctl-lock-default", "", nil)
+
+	locker := newTestLocker(api, "alice@ci-1 (pid 1)")
+	removed, err := locker.ForceUnlock(context.Background(), "default")
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "an unknown holder", removed[0].Holder)
+	require.Len(t, api.teams, 1)
+
+	_, err = locker.Acquire(context.Background(), "default")
+	require.NoError(t, err)
+}
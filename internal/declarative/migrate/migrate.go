@@ -0,0 +1,289 @@
+// Package migrate upgrades declarative configuration files written for earlier
+// versions of the format to the current one.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/kong/kongctl/internal/meta"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	// VersionKey is the top-level key declaring the format version of a file
+	VersionKey = "_version"
+	// CurrentVersion is the format version this kongctl reads. Files without a
+	// _version are read as the current version.
+	CurrentVersion = 2
+)
+
+// migration upgrades a configuration from version from to from+1. It returns a
+// description of each change it made.
+type migration struct {
+	from  int
+	apply func(root *yaml.Node) []string
+}
+
+// migrations are applied in order to files of earlier versions
+var migrations = []migration{
+	{from: 1, apply: migrateV1},
+}
+
+// renamedKeys maps the top-level keys earlier versions accepted to the key that
+// replaces them and the version that replaced them
+var renamedKeys = map[string]struct {
+	Key     string
+	Version int
+}{
+	"organization_teams":           {Key: "organization.teams", Version: 2},
+	"event_gateway_control_planes": {Key: "event_gateways", Version: 2},
+}
+
+// RenamedKey returns the key replacing a top-level key of an earlier version, and the
+// version that replaced it
+func RenamedKey(key string) (string, int, bool) {
+	renamed, ok := renamedKeys[key]
+	return renamed.Key, renamed.Version, ok
+}
+
+// CheckVersion returns an error when a file declares a version this kongctl cannot read
+func CheckVersion(version *int) error {
+	if version == nil || *version == CurrentVersion {
+		return nil
+	}
+	if *version < 1 {
+		return fmt.Errorf("invalid %s %d, versions start at 1", VersionKey, *version)
+	}
+	if *version > CurrentVersion {
+		return fmt.Errorf("%s %d is newer than the version %d this kongctl reads; upgrade kongctl",
+			VersionKey, *version, CurrentVersion)
+	}
+	return fmt.Errorf("%s %d is an earlier version of the format; run '%s migrate-config' to upgrade the file to %d",
+		VersionKey, *version, meta.CLIName, CurrentVersion)
+}
+
+// Result is the outcome of migrating a file
+type Result struct {
+	// Content is the migrated file, or the original content when nothing changed
+	Content []byte
+	// From is the version the file was written for
+	From int
+	// Changes describe the changes made to the file
+	Changes []string
+}
+
+// Changed reports whether the migration changed the file
+func (r *Result) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Migrate upgrades a YAML or JSON configuration file to the current version. Files
+// without a _version are upgraded from version 1, the format before versions were
+// declared. YAML comments, tags and key order are kept; JSON files are written back
+// as indented JSON.
+func Migrate(content []byte) (*Result, error) {
+	result := &Result{Content: content, From: CurrentVersion}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return result, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration must be a mapping")
+	}
+	root := doc.Content[0]
+
+	versionNode := mappingValue(root, VersionKey)
+	result.From = 1
+	if versionNode != nil {
+		version, err := strconv.Atoi(versionNode.Value)
+		if err != nil || versionNode.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s must be an integer, got %q", VersionKey, versionNode.Value)
+		}
+		if version < 1 || version > CurrentVersion {
+			return nil, CheckVersion(&version)
+		}
+		result.From = version
+	}
+	if result.From == CurrentVersion {
+		return result, nil
+	}
+
+	for _, m := range migrations {
+		if m.from >= result.From {
+			result.Changes = append(result.Changes, m.apply(root)...)
+		}
+	}
+	setVersion(root, versionNode)
+	result.Changes = append(result.Changes, fmt.Sprintf("set %s to %d", VersionKey, CurrentVersion))
+
+	var out []byte
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		out, err = encodeJSON(root)
+	} else {
+		out, err = yaml.Dump(&doc, yaml.WithIndent(2), yaml.WithCompactSeqIndent(false), yaml.WithLineWidth(-1))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	result.Content = out
+	return result, nil
+}
+
+// migrateV1 moves the top-level organization_teams under organization.teams and
+// renames event_gateway_control_planes to event_gateways
+func migrateV1(root *yaml.Node) []string {
+	var changes []string
+
+	if i := mappingIndex(root, "organization_teams"); i >= 0 {
+		teams := root.Content[i+1]
+		organization := mappingValue(root, "organization")
+		if organization == nil {
+			// The organization takes the place of the teams to keep the order of the file
+			organization = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			root.Content[i].Value = "organization"
+			root.Content[i+1] = organization
+		} else {
+			removeKey(root, i)
+		}
+		appendSequence(organization, "teams", teams)
+		changes = append(changes, "moved organization_teams to organization.teams")
+	}
+
+	if i := mappingIndex(root, "event_gateway_control_planes"); i >= 0 {
+		if mappingIndex(root, "event_gateways") < 0 {
+			root.Content[i].Value = "event_gateways"
+		} else {
+			gateways := root.Content[i+1]
+			removeKey(root, i)
+			appendSequence(root, "event_gateways", gateways)
+		}
+		changes = append(changes, "renamed event_gateway_control_planes to event_gateways")
+	}
+
+	return changes
+}
+
+// setVersion sets the _version of a file, adding it as the first key when missing
+func setVersion(root *yaml.Node, versionNode *yaml.Node) {
+	if versionNode != nil {
+		versionNode.Value = strconv.Itoa(CurrentVersion)
+		versionNode.Tag = "!!int"
+		versionNode.Style = 0
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: VersionKey}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(CurrentVersion)}
+	if len(root.Content) > 0 {
+		// Comments at the top of the file stay at the top
+		key.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+	root.Content = append([]*yaml.Node{key, value}, root.Content...)
+}
+
+// appendSequence appends the items of a sequence to the sequence at key of a mapping,
+// adding the key when missing
+func appendSequence(mapping *yaml.Node, key string, items *yaml.Node) {
+	existing := mappingValue(mapping, key)
+	if existing == nil || existing.Kind != yaml.SequenceNode {
+		if existing == nil {
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, items)
+		} else {
+			*existing = *items
+		}
+		return
+	}
+	existing.Content = append(existing.Content, items.Content...)
+}
+
+func removeKey(mapping *yaml.Node, i int) {
+	mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+	return nil
+}
+
+// encodeJSON writes a node as indented JSON, keeping the order of the keys
+func encodeJSON(root *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, root); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias)
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
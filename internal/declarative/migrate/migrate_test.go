@@ -0,0 +1,149 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_Version1(t *testing.T) {
+	content := `# Platform configuration
+portals:
+  - ref: dev-portal
+    name: Developer Portal
+organization_teams:
+  # Owns the platform
+  - ref: platform
+    name: Platform
+    roles:
+      - ref: platform-viewer
+        entity_id: !ref dev-portal#id
+event_gateway_control_planes:
+  - ref: events
+    name: events
+`
+
+	result, err := Migrate([]byte(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.From)
+	assert.Equal(t, []string{
+		"moved organization_teams to organization.teams",
+		"renamed event_gateway_control_planes to event_gateways",
+		"set _version to 2",
+	}, result.Changes)
+	assert.Equal(t, `# Platform configuration
+_version: 2
+portals:
+  - ref: dev-portal
+    name: Developer Portal
+organization:
+  teams:
+    # Owns the platform
+    - ref: platform
+      name: Platform
+      roles:
+        - ref: platform-viewer
+          entity_id: !ref dev-portal#id
+event_gateways:
+  - ref: events
+    name: events
+`, string(result.Content))
+}
+
+func TestMigrate_MergesExistingKeys(t *testing.T) {
+	content := `organization:
+  teams:
+    - ref: a
+      name: A
+organization_teams:
+  - ref: b
+    name: B
+event_gateways:
+  - ref: c
+    name: c
+event_gateway_control_planes:
+  - ref: d
+    name: d
+`
+
+	result, err := Migrate([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, `_version: 2
+organization:
+  teams:
+    - ref: a
+      name: A
+    - ref: b
+      name: B
+event_gateways:
+  - ref: c
+    name: c
+  - ref: d
+    name: d
+`, string(result.Content))
+}
+
+func TestMigrate_CurrentVersion(t *testing.T) {
+	content := "_version: 2\nportals: []\n"
+
+	result, err := Migrate([]byte(content))
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.Equal(t, content, string(result.Content))
+}
+
+func TestMigrate_Version1WithoutRenames(t *testing.T) {
+	result, err := Migrate([]byte("_version: 1\nportals: []\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"set _version to 2"}, result.Changes)
+	assert.Equal(t, "_version: 2\nportals: []\n", string(result.Content))
+}
+
+func TestMigrate_JSON(t *testing.T) {
+	content := `{"portals": [{"ref": "p", "name": "P"}], "organization_teams": [{"ref": "t", "name": "T", "x": 1}]}`
+
+	result, err := Migrate([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "_version": 2,
+  "portals": [
+    {
+      "ref": "p",
+      "name": "P"
+    }
+  ],
+  "organization": {
+    "teams": [
+      {
+        "ref": "t",
+        "name": "T",
+        "x": 1
+      }
+    ]
+  }
+}
+`, string(result.Content))
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	_, err := Migrate([]byte("_version: 3\n"))
+	assert.ErrorContains(t, err, "newer than the version 2")
+
+	_, err = Migrate([]byte("_version: two\n"))
+	assert.ErrorContains(t, err, "_version must be an integer")
+
+	_, err = Migrate([]byte("- portals\n"))
+	assert.ErrorContains(t, err, "configuration must be a mapping")
+}
+
+func TestCheckVersion(t *testing.T) {
+	version := func(v int) *int { return &v }
+
+	assert.NoError(t, CheckVersion(nil))
+	assert.NoError(t, CheckVersion(version(CurrentVersion)))
+	assert.ErrorContains(t, CheckVersion(version(1)), "run 'kongctl migrate-config'")
+	assert.ErrorContains(t, CheckVersion(version(3)), "upgrade kongctl")
+	assert.ErrorContains(t, CheckVersion(version(0)), "versions start at 1")
+}
@@ -0,0 +1,184 @@
+// Package multiprofile applies the same configuration to several kongctl profiles,
+// and so several Konnect organizations, concurrently.
+package multiprofile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+)
+
+// Status is the outcome of applying to one profile
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	// StatusCanceled marks profiles stopped by --fail-fast after another profile failed
+	StatusCanceled Status = "canceled"
+)
+
+// ApplyFunc applies the configuration to a profile. A result with errors fails the
+// profile just like a returned error.
+type ApplyFunc func(ctx context.Context, profile string) (*executor.ExecutionResult, error)
+
+// Result is the outcome of applying to one profile
+type Result struct {
+	Profile string                    `json:"profile"          yaml:"profile"`
+	Status  Status                    `json:"status"           yaml:"status"`
+	Error   string                    `json:"error,omitempty"  yaml:"error,omitempty"`
+	Result  *executor.ExecutionResult `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+// Report aggregates the results of every profile, in the order the profiles were given
+type Report struct {
+	Profiles []Result `json:"profiles" yaml:"profiles"`
+	Failed   int      `json:"failed"   yaml:"failed"`
+	Canceled int      `json:"canceled" yaml:"canceled"`
+}
+
+// ParseProfiles splits and validates a list of profile names, rejecting duplicates
+func ParseProfiles(values []string) ([]string, error) {
+	profiles := make([]string, 0, len(values))
+	seen := make(map[string]bool)
+	for _, value := range values {
+		profile := strings.TrimSpace(value)
+		if profile == "" {
+			continue
+		}
+		if seen[profile] {
+			return nil, fmt.Errorf("profile %q is listed more than once", profile)
+		}
+		seen[profile] = true
+		profiles = append(profiles, profile)
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("at least one profile is required")
+	}
+	return profiles, nil
+}
+
+// Options controls how Run applies to the profiles
+type Options struct {
+	// FailFast cancels the remaining profiles once one fails
+	FailFast bool
+	// MaxConcurrent bounds the profiles applied at the same time; zero applies to all at once
+	MaxConcurrent int
+}
+
+// Run applies to every profile concurrently and waits for all of them. A failing
+// profile does not stop the others unless FailFast is set, in which case the context
+// of the remaining profiles is canceled and those stopped by it, or still waiting for
+// their turn, are reported as canceled.
+func Run(ctx context.Context, profiles []string, opts Options, apply ApplyFunc) Report {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	slots := opts.MaxConcurrent
+	if slots <= 0 || slots > len(profiles) {
+		slots = len(profiles)
+	}
+	sem := make(chan struct{}, slots)
+
+	results := make([]Result, len(profiles))
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+			}
+			// Profiles waiting for their turn when fail-fast cancels the others are not started
+			if ctx.Err() != nil {
+				results[i] = Result{Profile: profile, Status: StatusCanceled, Error: "not started: " + ctx.Err().Error()}
+				return
+			}
+			result, err := apply(ctx, profile)
+			results[i] = Result{Profile: profile, Status: StatusSucceeded, Result: result}
+			switch {
+			case err != nil:
+				results[i].Error = err.Error()
+			case result != nil && result.HasErrors():
+				results[i].Error = fmt.Sprintf("execution completed with %d errors", result.FailureCount)
+			default:
+				return
+			}
+
+			results[i].Status = StatusFailed
+			if !opts.FailFast {
+				return
+			}
+			if ctx.Err() != nil && isCanceled(results[i]) {
+				results[i].Status = StatusCanceled
+				return
+			}
+			cancel()
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Profiles: results}
+	for _, result := range results {
+		switch result.Status {
+		case StatusFailed:
+			report.Failed++
+		case StatusCanceled:
+			report.Canceled++
+		}
+	}
+	return report
+}
+
+// Err summarizes the failed and canceled profiles, or returns nil when all succeeded
+func (r Report) Err() error {
+	var names []string
+	for _, result := range r.Profiles {
+		if result.Status != StatusSucceeded {
+			names = append(names, result.Profile)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("apply failed for %d of %d profiles: %s",
+		len(names), len(r.Profiles), strings.Join(names, ", "))
+}
+
+// isCanceled reports whether a failed profile was stopped by context cancellation
+func isCanceled(result Result) bool {
+	if strings.Contains(result.Error, context.Canceled.Error()) {
+		return true
+	}
+	if result.Result == nil {
+		return false
+	}
+	for _, executionError := range result.Result.Errors {
+		if !strings.Contains(executionError.Error, context.Canceled.Error()) {
+			return false
+		}
+	}
+	return len(result.Result.Errors) > 0
+}
+
+// WriteText prints one line per profile with its status and change counts
+func (r Report) WriteText(out io.Writer) {
+	fmt.Fprintln(out, "Profile results:")
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, result := range r.Profiles {
+		detail := result.Error
+		if detail == "" && result.Result != nil {
+			detail = fmt.Sprintf("%d applied, %d failed, %d skipped",
+				result.Result.SuccessCount, result.Result.FailureCount, result.Result.SkippedCount)
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", result.Profile, result.Status, detail)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,151 @@
+package multiprofile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProfiles(t *testing.T) {
+	profiles, err := ParseProfiles([]string{" prod-us", "prod-eu ", ""})
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod-us", "prod-eu"}, profiles)
+
+	_, err = ParseProfiles([]string{"prod-us", "prod-us"})
+	require.ErrorContains(t, err, `profile "prod-us" is listed more than once`)
+
+	_, err = ParseProfiles([]string{" "})
+	require.ErrorContains(t, err, "at least one profile is required")
+}
+
+func TestRun_OneProfileFailing(t *testing.T) {
+	apply := func(_ context.Context, profile string) (*executor.ExecutionResult, error) {
+		if profile == "prod-eu" {
+			return &executor.ExecutionResult{
+				SuccessCount: 1,
+				FailureCount: 1,
+				Errors:       []executor.ExecutionError{{ChangeID: "2:c:api:orders", Error: "409 conflict"}},
+			}, nil
+		}
+		return &executor.ExecutionResult{SuccessCount: 2}, nil
+	}
+
+	report := Run(context.Background(), []string{"prod-us", "prod-eu"}, Options{}, apply)
+
+	require.Len(t, report.Profiles, 2)
+	assert.Equal(t, "prod-us", report.Profiles[0].Profile)
+	assert.Equal(t, StatusSucceeded, report.Profiles[0].Status)
+	assert.Equal(t, 2, report.Profiles[0].Result.SuccessCount)
+	assert.Equal(t, "prod-eu", report.Profiles[1].Profile)
+	assert.Equal(t, StatusFailed, report.Profiles[1].Status)
+	assert.Equal(t, "execution completed with 1 errors", report.Profiles[1].Error)
+	assert.Equal(t, 1, report.Failed)
+	assert.Zero(t, report.Canceled)
+	require.EqualError(t, report.Err(), "apply failed for 1 of 2 profiles: prod-eu")
+
+	var out bytes.Buffer
+	report.WriteText(&out)
+	assert.Contains(t, out.String(), "prod-us  succeeded  2 applied, 0 failed, 0 skipped")
+	assert.Contains(t, out.String(), "prod-eu  failed     execution completed with 1 errors")
+}
+
+func TestRun_FailFastCancelsOtherProfiles(t *testing.T) {
+	apply := func(ctx context.Context, profile string) (*executor.ExecutionResult, error) {
+		if profile == "prod-eu" {
+			return nil, fmt.Errorf("failed to generate plan: unauthorized")
+		}
+		// prod-us only finishes once fail-fast cancels it
+		<-ctx.Done()
+		return &executor.ExecutionResult{
+			FailureCount: 1,
+			Errors:       []executor.ExecutionError{{Error: fmt.Sprintf("create api: %v", ctx.Err())}},
+		}, nil
+	}
+
+	report := Run(context.Background(), []string{"prod-us", "prod-eu"}, Options{FailFast: true}, apply)
+
+	assert.Equal(t, StatusCanceled, report.Profiles[0].Status)
+	assert.Equal(t, StatusFailed, report.Profiles[1].Status)
+	assert.Equal(t, "failed to generate plan: unauthorized", report.Profiles[1].Error)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Canceled)
+	require.EqualError(t, report.Err(), "apply failed for 2 of 2 profiles: prod-us, prod-eu")
+}
+
+func TestRun_AllSucceed(t *testing.T) {
+	apply := func(ctx context.Context, _ string) (*executor.ExecutionResult, error) {
+		require.NoError(t, ctx.Err())
+		return &executor.ExecutionResult{SuccessCount: 1}, nil
+	}
+
+	report := Run(context.Background(), []string{"prod-us", "prod-eu", "prod-ap"}, Options{FailFast: true}, apply)
+
+	require.NoError(t, report.Err())
+	assert.Zero(t, report.Failed)
+	for _, result := range report.Profiles {
+		assert.Equal(t, StatusSucceeded, result.Status)
+	}
+}
+
+func TestRun_MaxConcurrent(t *testing.T) {
+	// Each profile reports it started and then blocks until the test releases it, so
+	// the test decides when slots free up
+	var running atomic.Int32
+	var exceeded atomic.Bool
+	started := make(chan string)
+	release := make(chan struct{})
+	apply := func(_ context.Context, profile string) (*executor.ExecutionResult, error) {
+		if running.Add(1) > 2 {
+			exceeded.Store(true)
+		}
+		started <- profile
+		<-release
+		running.Add(-1)
+		if profile == "prod-eu" {
+			return nil, fmt.Errorf("failed to generate plan: unauthorized")
+		}
+		return &executor.ExecutionResult{SuccessCount: 1}, nil
+	}
+	profiles := []string{"prod-us", "prod-eu", "prod-ap", "prod-sa"}
+
+	done := make(chan Report)
+	go func() { done <- Run(context.Background(), profiles, Options{MaxConcurrent: 2}, apply) }()
+	<-started
+	<-started
+	select {
+	case profile := <-started:
+		t.Fatalf("%s started while two profiles were running", profile)
+	default:
+	}
+	// Every release frees a slot for one waiting profile
+	for range profiles[2:] {
+		release <- struct{}{}
+		<-started
+	}
+	release <- struct{}{}
+	release <- struct{}{}
+	report := <-done
+
+	assert.False(t, exceeded.Load(), "more than two profiles ran at the same time")
+	assert.Equal(t, 1, report.Failed)
+	assert.Zero(t, report.Canceled)
+
+	// With fail-fast, the first profile to fail cancels those still waiting for their turn
+	failing := func(context.Context, string) (*executor.ExecutionResult, error) {
+		return nil, fmt.Errorf("failed to generate plan: unauthorized")
+	}
+	report = Run(context.Background(), profiles, Options{FailFast: true, MaxConcurrent: 1}, failing)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 3, report.Canceled)
+	for _, result := range report.Profiles {
+		if result.Status == StatusCanceled {
+			assert.Equal(t, "not started: context canceled", result.Error)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package oci
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MediaTypeBundle is the layer media type of config bundles pushed for kongctl.
+	// Generic tar layers, e.g. those pushed by `oras push bundle.tar.gz`, are accepted too.
+	MediaTypeBundle = "application/vnd.kong.kongctl.config.bundle.v1.tar+gzip"
+
+	// maxBundleSize bounds the extracted size of a bundle
+	maxBundleSize = 256 << 20
+)
+
+var bundleLayerTypes = map[string]bool{
+	MediaTypeBundle:                                     true,
+	"application/vnd.oci.image.layer.v1.tar":            true,
+	"application/vnd.oci.image.layer.v1.tar+gzip":       true,
+	"application/vnd.docker.image.rootfs.diff.tar.gzip": true,
+	"application/x-tar":                                 true,
+	"application/gzip":                                  true,
+	"application/x-gzip":                                true,
+	"application/tar+gzip":                              true,
+}
+
+// Bundle is a config bundle extracted to a local directory
+type Bundle struct {
+	// Reference is the artifact the bundle was pulled from
+	Reference Reference
+	// Digest is the manifest digest, which pins the exact content pulled
+	Digest string
+	// Dir holds the extracted files
+	Dir string
+}
+
+// Pull downloads the config bundle ref points to and extracts it into dir, which must
+// exist. Blob digests are verified while downloading.
+func (c *Client) Pull(ctx context.Context, ref Reference, dir string) (*Bundle, error) {
+	m, manifestDigest, err := c.fetchManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []descriptor
+	for _, layer := range m.Layers {
+		if bundleLayerTypes[layer.MediaType] {
+			layers = append(layers, layer)
+		}
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("%s must have exactly one tar layer holding the config bundle, found %d",
+			ref, len(layers))
+	}
+	layer := layers[0]
+	if !digestPattern.MatchString(layer.Digest) {
+		return nil, fmt.Errorf("%s has a layer with unsupported digest %q", ref, layer.Digest)
+	}
+
+	blob, err := c.fetchBlob(ctx, ref, layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+
+	verified := &digestReader{r: blob, hash: sha256.New(), want: layer.Digest}
+	if err := extract(verified, dir); err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", ref, err)
+	}
+	// Drain trailing data, such as tar padding, so the digest covers the whole blob
+	if _, err := io.Copy(io.Discard, verified); err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", ref, err)
+	}
+
+	return &Bundle{Reference: ref, Digest: manifestDigest, Dir: dir}, nil
+}
+
+// extract unpacks a tar stream, gzipped or not, into dir. Only regular files and
+// directories are allowed, and every path must stay inside dir.
+func extract(r io.Reader, dir string) error {
+	buffered := bufio.NewReader(r)
+	var stream io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		stream = gz
+	}
+
+	var total int64
+	tr := tar.NewReader(stream)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("entry %q escapes the bundle", header.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += header.Size
+			if total > maxBundleSize {
+				return fmt.Errorf("bundle exceeds %d bytes", maxBundleSize)
+			}
+			if err := writeFile(target, tr, header.Size); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("entry %q is not a regular file or directory", header.Name)
+		}
+	}
+}
+
+func writeFile(target string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(file, r, size); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// digestReader hashes what it reads and fails at EOF if the digest does not match
+type digestReader struct {
+	r    io.Reader
+	hash hash.Hash
+	want string
+}
+
+func (d *digestReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	d.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if got := "sha256:" + hex.EncodeToString(d.hash.Sum(nil)); got != d.want {
+			return n, fmt.Errorf("blob digest mismatch: expected %s, got %s", d.want, got)
+		}
+	}
+	return n, err
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
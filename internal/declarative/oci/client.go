@@ -0,0 +1,292 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// maxManifestSize bounds manifest downloads; real manifests are a few KB
+	maxManifestSize = 4 << 20
+)
+
+// Client pulls config bundles from OCI registries using the distribution API
+type Client struct {
+	httpClient  *http.Client
+	credentials CredentialFunc
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewClient creates a registry client. A nil httpClient uses http.DefaultClient and
+// nil credentials uses DefaultCredentials.
+func NewClient(httpClient *http.Client, credentials CredentialFunc) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if credentials == nil {
+		credentials = DefaultCredentials
+	}
+	return &Client{
+		httpClient:  httpClient,
+		credentials: credentials,
+		tokens:      make(map[string]string),
+	}
+}
+
+// descriptor is an OCI content descriptor
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type manifest struct {
+	MediaType    string       `json:"mediaType"`
+	ArtifactType string       `json:"artifactType"`
+	Config       descriptor   `json:"config"`
+	Layers       []descriptor `json:"layers"`
+}
+
+// fetchManifest returns the manifest of ref and its digest
+func (c *Client) fetchManifest(ctx context.Context, ref Reference) (*manifest, string, error) {
+	res, err := c.get(ctx, ref, "manifests/"+ref.manifestReference(),
+		strings.Join([]string{mediaTypeOCIManifest, mediaTypeDockerManifest}, ", "))
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest of %s: %w", ref, err)
+	}
+	if len(data) > maxManifestSize {
+		return nil, "", fmt.Errorf("manifest of %s exceeds %d bytes", ref, maxManifestSize)
+	}
+	digest := digestOf(data)
+	if ref.Digest != "" && digest != ref.Digest {
+		return nil, "", fmt.Errorf("manifest of %s has digest %s", ref, digest)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, "", fmt.Errorf("failed to parse manifest of %s: %w", ref, err)
+	}
+	if m.MediaType == "" {
+		m.MediaType = res.Header.Get("Content-Type")
+	}
+	if m.MediaType != mediaTypeOCIManifest && m.MediaType != mediaTypeDockerManifest {
+		return nil, "", fmt.Errorf("%s is not an image manifest (media type %q); indexes are not supported",
+			ref, m.MediaType)
+	}
+	return &m, digest, nil
+}
+
+// fetchBlob opens the blob with the given digest
+func (c *Client) fetchBlob(ctx context.Context, ref Reference, digest string) (io.ReadCloser, error) {
+	res, err := c.get(ctx, ref, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// get requests a path under /v2/<repository>/, authenticating when challenged
+func (c *Client) get(ctx context.Context, ref Reference, path, accept string) (*http.Response, error) {
+	scheme := "https"
+	if ref.isLoopback() {
+		scheme = "http"
+	}
+	target := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.endpoint(), ref.Repository, path)
+
+	res, err := c.do(ctx, target, accept, c.authorization(ref))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge := res.Header.Get("WWW-Authenticate")
+		res.Body.Close()
+
+		authorization, err := c.authenticate(ctx, ref, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if res, err = c.do(ctx, target, accept, authorization); err != nil {
+			return nil, err
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s: %s", target, res.Status, strings.TrimSpace(string(body)))
+	}
+	return res, nil
+}
+
+func (c *Client) do(ctx context.Context, target, accept, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s: %w", target, err)
+	}
+	return res, nil
+}
+
+// authorization returns the cached Authorization header for a repository
+func (c *Client) authorization(ref Reference) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens[ref.Registry+"/"+ref.Repository]
+}
+
+// authenticate answers a WWW-Authenticate challenge, returning the Authorization
+// header to retry with
+func (c *Client) authenticate(ctx context.Context, ref Reference, challenge string) (string, error) {
+	credential, err := c.credentials(ref.Registry)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials for %s: %w", ref.Registry, err)
+	}
+
+	scheme, params := parseChallenge(challenge)
+	var authorization string
+	switch scheme {
+	case "basic":
+		if credential.Username == "" {
+			return "", fmt.Errorf("%s requires credentials; set %s and %s or run docker login",
+				ref.Registry, UsernameEnv, PasswordEnv)
+		}
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(credential.Username, credential.Password)
+		authorization = req.Header.Get("Authorization")
+	case "bearer":
+		token, err := c.fetchToken(ctx, ref, params, credential)
+		if err != nil {
+			return "", err
+		}
+		authorization = "Bearer " + token
+	default:
+		return "", fmt.Errorf("%s requested unsupported authentication %q", ref.Registry, challenge)
+	}
+
+	c.mu.Lock()
+	c.tokens[ref.Registry+"/"+ref.Repository] = authorization
+	c.mu.Unlock()
+	return authorization, nil
+}
+
+// fetchToken gets a pull token from the token service named in a bearer challenge
+func (c *Client) fetchToken(
+	ctx context.Context, ref Reference, params map[string]string, credential Credential,
+) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("%s sent a bearer challenge without a realm", ref.Registry)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.Repository + ":pull"
+	}
+
+	query := url.Values{"scope": {scope}}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+
+	var req *http.Request
+	var err error
+	if credential.IdentityToken != "" {
+		// Identity tokens are refresh tokens for the OAuth2 flow of the token service
+		query.Set("grant_type", "refresh_token")
+		query.Set("refresh_token", credential.IdentityToken)
+		query.Set("client_id", "kongctl")
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, realm, strings.NewReader(query.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+		if err == nil && credential.Username != "" {
+			req.SetBasicAuth(credential.Username, credential.Password)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token for %s: %w", ref, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get token for %s: %s", ref, res.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxManifestSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response for %s: %w", ref, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("token response for %s holds no token", ref)
+}
+
+// parseChallenge splits a WWW-Authenticate header such as
+// Bearer realm="https://auth.example.com/token",service="registry" into its
+// lower-cased scheme and parameters
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+		} else {
+			v, next, _ := strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(v)
+			rest = next
+		}
+		rest = strings.TrimSpace(rest)
+	}
+	return strings.ToLower(scheme), params
+}
@@ -0,0 +1,170 @@
+package oci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// UsernameEnv and PasswordEnv supply registry credentials, overriding the Docker
+	// configuration
+	UsernameEnv = "KONGCTL_OCI_USERNAME"
+	PasswordEnv = "KONGCTL_OCI_PASSWORD"
+
+	// identityTokenUsername is the username credential helpers return with a token
+	identityTokenUsername = "<token>"
+)
+
+// Credential authenticates to a registry. A credential with only an IdentityToken is
+// exchanged for an access token with the registry's token service.
+type Credential struct {
+	Username      string
+	Password      string
+	IdentityToken string
+}
+
+// Empty reports whether the credential holds nothing, in which case requests are
+// made anonymously
+func (c Credential) Empty() bool {
+	return c.Username == "" && c.Password == "" && c.IdentityToken == ""
+}
+
+// CredentialFunc returns the credential for a registry host
+type CredentialFunc func(registry string) (Credential, error)
+
+// dockerConfig is the part of ~/.docker/config.json holding registry credentials
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		Username      string `json:"username"`
+		Password      string `json:"password"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredHelpers map[string]string `json:"credHelpers"`
+	CredsStore  string            `json:"credsStore"`
+}
+
+// DefaultCredentials looks credentials up the way Docker does: KONGCTL_OCI_USERNAME
+// and KONGCTL_OCI_PASSWORD first, then the registry's credential helper, the default
+// credential store and the auths entries of $DOCKER_CONFIG/config.json
+// (~/.docker/config.json by default). Registries without credentials are anonymous.
+func DefaultCredentials(registry string) (Credential, error) {
+	if username, password := os.Getenv(UsernameEnv), os.Getenv(PasswordEnv); username != "" || password != "" {
+		return Credential{Username: username, Password: password}, nil
+	}
+
+	cfg, err := readDockerConfig()
+	if err != nil || cfg == nil {
+		return Credential{}, err
+	}
+
+	keys := registryKeys(registry)
+	for _, key := range keys {
+		if helper := cfg.CredHelpers[key]; helper != "" {
+			return helperCredential(helper, key)
+		}
+	}
+	if cfg.CredsStore != "" {
+		credential, err := helperCredential(cfg.CredsStore, keys[0])
+		if err != nil || !credential.Empty() {
+			return credential, err
+		}
+	}
+
+	for _, key := range keys {
+		entry, ok := cfg.Auths[key]
+		if !ok {
+			continue
+		}
+		credential := Credential{
+			Username:      entry.Username,
+			Password:      entry.Password,
+			IdentityToken: entry.IdentityToken,
+		}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return Credential{}, fmt.Errorf("invalid auth for %s in docker config: %w", key, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return Credential{}, fmt.Errorf("invalid auth for %s in docker config: expected user:password", key)
+			}
+			credential.Username, credential.Password = username, password
+		}
+		return credential, nil
+	}
+	return Credential{}, nil
+}
+
+func readDockerConfig() (*dockerConfig, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	path := filepath.Join(dir, "config.json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker config: %w", err)
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// registryKeys returns the keys a registry may be stored under in the docker config
+func registryKeys(registry string) []string {
+	if registry == dockerHubHost {
+		return []string{"https://index.docker.io/v1/", "index.docker.io", dockerHubHost}
+	}
+	return []string{registry, "https://" + registry, "http://" + registry}
+}
+
+// helperCredential runs docker-credential-<helper> get for a registry
+func helperCredential(helper, registry string) (Credential, error) {
+	program := "docker-credential-" + helper
+	cmd := exec.Command(program, "get")
+	cmd.Stdin = strings.NewReader(registry)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		// Helpers report a registry they hold nothing for on stdout
+		if strings.Contains(string(output)+stderr.String(), "credentials not found") {
+			return Credential{}, nil
+		}
+		return Credential{}, fmt.Errorf("%s get failed: %w: %s", program, err,
+			strings.TrimSpace(string(output)+stderr.String()))
+	}
+
+	var response struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(output, &response); err != nil {
+		return Credential{}, fmt.Errorf("failed to parse %s output: %w", program, err)
+	}
+	if response.Username == identityTokenUsername {
+		return Credential{IdentityToken: response.Secret}, nil
+	}
+	return Credential{Username: response.Username, Password: response.Secret}, nil
+}
@@ -0,0 +1,126 @@
+// Package oci pulls declarative config bundles stored as OCI artifacts. A bundle is a
+// single tar layer, optionally gzipped, holding YAML configuration and the files it
+// references with !file tags.
+package oci
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+const (
+	dockerHubHost     = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+)
+
+var (
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-]+[a-z0-9]+)*(?:/[a-z0-9]+(?:[._-]+[a-z0-9]+)*)*$`)
+	tagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+	digestPattern     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference identifies an artifact as registry/repository, with a tag or digest
+type Reference struct {
+	// Registry is the registry host, with an optional port
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an artifact reference such as
+// registry.example.com/configs/portal:v1.2.0 or ghcr.io/org/config@sha256:<hex>.
+// A reference without a registry host refers to Docker Hub, and one without a tag
+// or digest to the latest tag.
+func ParseReference(ref string) (Reference, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "oci://")
+	if ref == "" {
+		return Reference{}, fmt.Errorf("empty OCI reference")
+	}
+
+	var parsed Reference
+	remainder := ref
+	if at := strings.LastIndex(remainder, "@"); at >= 0 {
+		parsed.Digest = remainder[at+1:]
+		remainder = remainder[:at]
+		if !digestPattern.MatchString(parsed.Digest) {
+			return Reference{}, fmt.Errorf("invalid OCI reference %q: digest must be sha256:<64 hex characters>", ref)
+		}
+	}
+	if slash, colon := strings.LastIndex(remainder, "/"), strings.LastIndex(remainder, ":"); colon > slash {
+		parsed.Tag = remainder[colon+1:]
+		remainder = remainder[:colon]
+		if !tagPattern.MatchString(parsed.Tag) {
+			return Reference{}, fmt.Errorf("invalid OCI reference %q: invalid tag %q", ref, parsed.Tag)
+		}
+	}
+
+	parsed.Registry = dockerHubHost
+	parsed.Repository = remainder
+	if first, rest, ok := strings.Cut(remainder, "/"); ok && isRegistryHost(first) {
+		parsed.Registry = first
+		parsed.Repository = rest
+	}
+	if parsed.Registry == dockerHubHost && !strings.Contains(parsed.Repository, "/") {
+		parsed.Repository = "library/" + parsed.Repository
+	}
+	if !repositoryPattern.MatchString(parsed.Repository) {
+		return Reference{}, fmt.Errorf("invalid OCI reference %q: invalid repository %q", ref, parsed.Repository)
+	}
+
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = defaultTag
+	}
+	return parsed, nil
+}
+
+// String returns the reference in its canonical form
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// manifestReference returns the digest when set, since it pins the content, or the tag
+func (r Reference) manifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// endpoint returns the registry host to connect to
+func (r Reference) endpoint() string {
+	if r.Registry == dockerHubHost {
+		return dockerHubRegistry
+	}
+	return r.Registry
+}
+
+// isLoopback reports whether the registry runs on this machine. Like Docker, kongctl
+// talks plain HTTP to such registries.
+func (r Reference) isLoopback() bool {
+	host := r.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isRegistryHost reports whether the first path component of a reference is a
+// registry host rather than a Docker Hub namespace
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
@@ -0,0 +1,302 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRegistry is a local OCI registry serving one repository. When token is set it
+// requires bearer tokens, issued to user:pass by a token service on the same server.
+type testRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	token     string
+}
+
+func newTestRegistry(t *testing.T, token string) *testRegistry {
+	t.Helper()
+	r := &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}, token: token}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != "user" || pass != "pass" ||
+			req.URL.Query().Get("scope") != "repository:configs/portal:pull" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+		return
+	}
+
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+			`Bearer realm="%s/token",service="test",scope="repository:configs/portal:pull"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/v2/configs/portal/manifests/"):
+		data, ok := r.manifests[strings.TrimPrefix(req.URL.Path, "/v2/configs/portal/manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeOCIManifest)
+		_, _ = w.Write(data)
+	case strings.HasPrefix(req.URL.Path, "/v2/configs/portal/blobs/"):
+		data, ok := r.blobs[strings.TrimPrefix(req.URL.Path, "/v2/configs/portal/blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// push stores a bundle under tag and returns the manifest digest. The blob is served
+// as blob, which lets tests corrupt it.
+func (r *testRegistry) push(t *testing.T, tag string, layer, blob []byte) string {
+	t.Helper()
+	layerDigest := digestOf(layer)
+	r.blobs[layerDigest] = blob
+	data, err := json.Marshal(manifest{
+		MediaType:    mediaTypeOCIManifest,
+		ArtifactType: "application/vnd.kong.kongctl.config.bundle.v1",
+		Config: descriptor{
+			MediaType: "application/vnd.oci.empty.v1+json",
+			Digest:    digestOf([]byte("{}")),
+			Size:      2,
+		},
+		Layers: []descriptor{{MediaType: MediaTypeBundle, Digest: layerDigest, Size: int64(len(layer))}},
+	})
+	require.NoError(t, err)
+	r.manifests[tag] = data
+	r.manifests[digestOf(data)] = data
+	return digestOf(data)
+}
+
+func (r *testRegistry) ref(t *testing.T, tag string) Reference {
+	t.Helper()
+	ref, err := ParseReference(strings.TrimPrefix(r.server.URL, "http://") + "/configs/portal:" + tag)
+	require.NoError(t, err)
+	return ref
+}
+
+// tarball builds a gzipped tar of the given files, in order
+func tarball(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: file[0], Mode: 0o644, Size: int64(len(file[1])), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func anonymous(string) (Credential, error) { return Credential{}, nil }
+
+func TestPull(t *testing.T) {
+	registry := newTestRegistry(t, "")
+	bundle := tarball(t,
+		[2]string{"portal.yaml", "portals:\n  - ref: p\n    name: p\n"},
+		[2]string{"specs/openapi.yaml", "openapi: 3.0.0\n"},
+	)
+	digest := registry.push(t, "v1", bundle, bundle)
+
+	dir := t.TempDir()
+	pulled, err := NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "v1"), dir)
+	require.NoError(t, err)
+	assert.Equal(t, digest, pulled.Digest)
+
+	data, err := os.ReadFile(filepath.Join(dir, "specs", "openapi.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.0.0\n", string(data))
+
+	t.Run("by digest", func(t *testing.T) {
+		ref := registry.ref(t, "v1")
+		ref.Tag, ref.Digest = "", digest
+		_, err := NewClient(nil, anonymous).Pull(context.Background(), ref, t.TempDir())
+		require.NoError(t, err)
+	})
+}
+
+func TestPull_FileTagsResolveWithinBundle(t *testing.T) {
+	registry := newTestRegistry(t, "")
+	bundle := tarball(t,
+		[2]string{"portal.yaml", "portals:\n  - ref: dev\n    name: !file names/portal.txt\n"},
+		[2]string{"names/portal.txt", "Developer Portal"},
+		[2]string{"escape.yaml.off", "portals:\n  - ref: x\n    name: !file ../../etc/hostname\n"},
+	)
+	registry.push(t, "v1", bundle, bundle)
+
+	pulled, err := NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "v1"), t.TempDir())
+	require.NoError(t, err)
+
+	sources, err := loader.ParseSources([]string{pulled.Dir})
+	require.NoError(t, err)
+	rs, err := loader.New().LoadFromSources(sources, false)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, "Developer Portal", rs.Portals[0].Name)
+
+	// Paths outside the bundle are rejected
+	require.NoError(t, os.Rename(filepath.Join(pulled.Dir, "escape.yaml.off"), filepath.Join(pulled.Dir, "escape.yaml")))
+	_, err = loader.New().LoadFromSources(sources, false)
+	require.ErrorContains(t, err, "outside base dir")
+}
+
+func TestPull_BearerAuth(t *testing.T) {
+	registry := newTestRegistry(t, "secret-token")
+	bundle := tarball(t, [2]string{"portal.yaml", "portals: []\n"})
+	registry.push(t, "v1", bundle, bundle)
+
+	_, err := NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "v1"), t.TempDir())
+	require.ErrorContains(t, err, "failed to get token")
+
+	credentials := func(host string) (Credential, error) {
+		assert.Equal(t, registry.ref(t, "v1").Registry, host)
+		return Credential{Username: "user", Password: "pass"}, nil
+	}
+	_, err = NewClient(nil, credentials).Pull(context.Background(), registry.ref(t, "v1"), t.TempDir())
+	require.NoError(t, err)
+}
+
+func TestPull_RejectsBadBundles(t *testing.T) {
+	registry := newTestRegistry(t, "")
+
+	bundle := tarball(t, [2]string{"portal.yaml", "portals: []\n"})
+	registry.push(t, "tampered", bundle, tarball(t, [2]string{"portal.yaml", "portals: [evil]\n"}))
+	_, err := NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "tampered"), t.TempDir())
+	require.ErrorContains(t, err, "digest mismatch")
+
+	escaping := tarball(t, [2]string{"../outside.yaml", "portals: []\n"})
+	registry.push(t, "escaping", escaping, escaping)
+	_, err = NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "escaping"), t.TempDir())
+	require.ErrorContains(t, err, "escapes the bundle")
+
+	_, err = NewClient(nil, anonymous).Pull(context.Background(), registry.ref(t, "missing"), t.TempDir())
+	require.ErrorContains(t, err, "404")
+}
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	for input, want := range map[string]Reference{
+		"registry.example.com/configs/portal:v1.2.0": {
+			Registry: "registry.example.com", Repository: "configs/portal", Tag: "v1.2.0",
+		},
+		"oci://localhost:5000/portal":  {Registry: "localhost:5000", Repository: "portal", Tag: "latest"},
+		"ghcr.io/org/config@" + digest: {Registry: "ghcr.io", Repository: "org/config", Digest: digest},
+		"kong/configs:v1":              {Registry: "docker.io", Repository: "kong/configs", Tag: "v1"},
+		"configs":                      {Registry: "docker.io", Repository: "library/configs", Tag: "latest"},
+	} {
+		got, err := ParseReference(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{
+		"", "registry.example.com/Configs", "example.com/c@sha256:abc", "example.com/c:bad tag",
+	} {
+		_, err := ParseReference(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDefaultCredentials(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv(UsernameEnv, "")
+	t.Setenv(PasswordEnv, "")
+
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(
+		`{"auths":{"registry.example.com":{"auth":"`+auth+`"},"https://index.docker.io/v1/":{"auth":"`+auth+`"}}}`),
+		0o600))
+
+	credential, err := DefaultCredentials("registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Credential{Username: "user", Password: "pass"}, credential)
+
+	credential, err = DefaultCredentials("docker.io")
+	require.NoError(t, err)
+	assert.Equal(t, "user", credential.Username)
+
+	credential, err = DefaultCredentials("other.example.com")
+	require.NoError(t, err)
+	assert.True(t, credential.Empty())
+
+	t.Setenv(UsernameEnv, "env-user")
+	t.Setenv(PasswordEnv, "env-pass")
+	credential, err = DefaultCredentials("registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Credential{Username: "env-user", Password: "env-pass"}, credential)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(
+		`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a/b:pull",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm="Registry"`)
+	assert.Equal(t, "basic", scheme)
+	assert.Equal(t, "Registry", params["realm"])
+}
+
+func TestDefaultCredentials_Helper(t *testing.T) {
+	bin := t.TempDir()
+	helper := "#!/bin/sh\nread host\n" +
+		`if [ "$host" = registry.example.com ]; then echo '{"Username":"helper-user","Secret":"helper-pass"}'; ` +
+		"else echo 'credentials not found in native keychain'; exit 1; fi\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "docker-credential-test"), []byte(helper), 0o700))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv(UsernameEnv, "")
+	t.Setenv(PasswordEnv, "")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"credsStore":"test"}`), 0o600))
+
+	credential, err := DefaultCredentials("registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Credential{Username: "helper-user", Password: "helper-pass"}, credential)
+
+	credential, err = DefaultCredentials("other.example.com")
+	require.NoError(t, err)
+	assert.True(t, credential.Empty())
+}
@@ -0,0 +1,47 @@
+package planner
+
+import (
+	"fmt"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+)
+
+// adoptByName adds to current the unmanaged resources of all named like a desired
+// resource that has no managed counterpart, so they are updated, and thereby labeled
+// with the namespace, instead of created again. Names matching several unmanaged
+// resources are not adopted. It returns the adopted names.
+func adoptByName[T any](
+	plan *Plan,
+	resourceType string,
+	desiredNames []string,
+	current map[string]T,
+	all []T,
+	identity func(T) (name, id string, labels map[string]string),
+) map[string]bool {
+	unmanaged := make(map[string][]T)
+	for _, resource := range all {
+		name, _, resourceLabels := identity(resource)
+		if !labels.IsManagedResource(resourceLabels) {
+			unmanaged[name] = append(unmanaged[name], resource)
+		}
+	}
+
+	adopted := make(map[string]bool)
+	for _, name := range desiredNames {
+		if _, exists := current[name]; exists || adopted[name] {
+			continue
+		}
+		switch matches := unmanaged[name]; len(matches) {
+		case 0:
+		case 1:
+			_, id, _ := identity(matches[0])
+			current[name] = matches[0]
+			adopted[name] = true
+			plan.AddWarning("", fmt.Sprintf("adopting unmanaged %s %q (%s) matched by name", resourceType, name, id))
+		default:
+			plan.AddWarning("", fmt.Sprintf(
+				"%d unmanaged %ss are named %q; not adopting any of them", len(matches), resourceType, name))
+		}
+	}
+	return adopted
+}
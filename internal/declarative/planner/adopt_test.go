@@ -0,0 +1,69 @@
+package planner
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePlan_MatchByNameAdoptsUnmanagedPortal(t *testing.T) {
+	portals := &stubListPortalsAPI{portals: []kkComps.ListPortalsResponsePortal{
+		newListPortal("portal-1", "dev", map[string]string{"team": "a"}),
+		newListPortal("portal-2", "shared", nil),
+		newListPortal("portal-3", "shared", nil),
+		newListPortal("portal-4", "other-team", map[string]string{labels.NamespaceKey: "team-b"}),
+	}}
+	planner := NewPlanner(state.NewClient(state.ClientConfig{PortalAPI: portals}), slog.Default())
+
+	portal := func(name string) resources.PortalResource {
+		return resources.PortalResource{
+			CreatePortal: kkComps.CreatePortal{Name: name},
+			BaseResource: resources.BaseResource{Ref: name},
+		}
+	}
+	rs := &resources.ResourceSet{Portals: []resources.PortalResource{
+		portal("dev"), portal("shared"), portal("other-team"),
+	}}
+
+	t.Run("without match by name", func(t *testing.T) {
+		plan, err := planner.GeneratePlan(context.Background(), rs, Options{Mode: PlanModeApply})
+		require.NoError(t, err)
+		for _, change := range plan.Changes {
+			if change.ResourceType == "portal" {
+				assert.Equal(t, ActionCreate, change.Action, change.ResourceRef)
+			}
+		}
+	})
+
+	t.Run("with match by name", func(t *testing.T) {
+		plan, err := planner.GeneratePlan(context.Background(), rs, Options{Mode: PlanModeApply, MatchByName: true})
+		require.NoError(t, err)
+
+		actions := map[string]PlannedChange{}
+		for _, change := range plan.Changes {
+			if change.ResourceType == "portal" {
+				actions[change.ResourceRef] = change
+			}
+		}
+		require.Contains(t, actions, "dev")
+		assert.Equal(t, ActionUpdate, actions["dev"].Action)
+		assert.Equal(t, "portal-1", actions["dev"].ResourceID)
+		// Ambiguous names and portals managed in other namespaces are not adopted
+		assert.Equal(t, ActionCreate, actions["shared"].Action)
+		assert.Equal(t, ActionCreate, actions["other-team"].Action)
+
+		var warnings []string
+		for _, warning := range plan.Warnings {
+			warnings = append(warnings, warning.Message)
+		}
+		assert.Contains(t, warnings, `adopting unmanaged portal "dev" (portal-1) matched by name`)
+		assert.Contains(t, warnings, `2 unmanaged portals are named "shared"; not adopting any of them`)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
 	"github.com/kong/kongctl/internal/declarative/attributes"
 	"github.com/kong/kongctl/internal/declarative/labels"
 	"github.com/kong/kongctl/internal/declarative/resources"
@@ -105,6 +106,11 @@ func (p *Planner) planAPIChanges(
 	for _, api := range currentAPIs {
 		currentByName[api.Name] = api
 	}
+	if err := moveResources(p, plan, "api", desired, currentByName, func(api state.API) (string, string) {
+		return api.Name, api.ID
+	}); err != nil {
+		return err
+	}
 
 	// Handle delete mode - plan DELETE for desired resources that exist in Konnect
 	if plan.Metadata.Mode == PlanModeDelete {
@@ -136,6 +142,31 @@ func (p *Planner) planAPIChanges(
 		return nil
 	}
 
+	var adopted map[string]bool
+	if p.matchByName && len(desired) > 0 {
+		all, err := p.client.ListAllAPIs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list APIs to match by name: %w", err)
+		}
+		names := make([]string, 0, len(desired))
+		for _, api := range desired {
+			names = append(names, api.Name)
+		}
+		adopted = adoptByName(plan, "api", names, currentByName, all,
+			func(api state.API) (string, string, map[string]string) {
+				return api.Name, api.ID, api.NormalizedLabels
+			})
+	}
+
+	// List the child resources of existing APIs up front, as they are planned one by one
+	existingIDs := make([]string, 0, len(desired))
+	for _, desiredAPI := range desired {
+		if current, exists := currentByName[desiredAPI.Name]; exists {
+			existingIDs = append(existingIDs, current.ID)
+		}
+	}
+	p.prefetchAPIChildren(ctx, existingIDs)
+
 	// Collect protection validation errors
 	var protectionErrors []error
 
@@ -185,7 +216,8 @@ func (p *Planner) planAPIChanges(
 			} else {
 				// Check if update needed based on configuration
 				needsUpdate, updateFields := p.shouldUpdateAPI(current, desiredAPI)
-				if needsUpdate {
+				// Adopted APIs are updated even without changes so they get labeled
+				if needsUpdate || adopted[desiredAPI.Name] {
 					// Regular update - check protection
 					if err := p.validateProtection("api", desiredAPI.Name, isProtected, ActionUpdate); err != nil {
 						protectionErrors = append(protectionErrors, err)
@@ -326,6 +358,11 @@ func (p *Planner) shouldUpdateAPI(
 ) (bool, map[string]any) {
 	updates := make(map[string]any)
 
+	// Names differ when a moved entry renamed the API
+	if current.Name != desired.Name {
+		updates["name"] = desired.Name
+	}
+
 	// Only compare fields present in desired configuration
 	if desired.Description != nil {
 		currentDesc := getString(current.Description)
@@ -486,7 +523,9 @@ func (p *Planner) planAPIProtectionChangeWithFields(
 	}
 
 	// ALWAYS include essential identification fields for protection changes
-	fields["name"] = current.Name
+	if _, ok := fields["name"]; !ok {
+		fields["name"] = current.Name
+	}
 	fields["id"] = current.ID
 
 	// Preserve namespace context for execution phase
@@ -511,6 +550,7 @@ func (p *Planner) planAPIProtectionChangeWithFields(
 	}
 
 	change.Fields = fields
+	change.IdentityFields = []string{"name", "id", "namespace", "preserved_labels"}
 
 	plan.AddChange(change)
 }
@@ -675,7 +715,7 @@ func (p *Planner) planAPIChildResourceChanges(
 
 	// Plan version changes
 	if err := p.planAPIVersionChanges(
-		ctx, plannerCtx, parentNamespace, current.ID, desired.GetRef(), desired.Versions, plan,
+		ctx, plannerCtx, parentNamespace, current.ID, current.NormalizedLabels, desired.GetRef(), desired.Versions, plan,
 	); err != nil {
 		return fmt.Errorf("failed to plan API version changes: %w", err)
 	}
@@ -706,11 +746,11 @@ func (p *Planner) planAPIChildResourceChanges(
 // API Version planning
 
 func (p *Planner) planAPIVersionChanges(
-	ctx context.Context, _ *Config, parentNamespace string, apiID string, apiRef string,
-	desired []resources.APIVersionResource, plan *Plan,
+	ctx context.Context, _ *Config, parentNamespace string, apiID string, apiLabels map[string]string,
+	apiRef string, desired []resources.APIVersionResource, plan *Plan,
 ) error {
 	// List current versions
-	currentVersions, err := p.client.ListAPIVersions(ctx, apiID)
+	currentVersions, err := p.listAPIVersions(ctx, apiID)
 	if err != nil {
 		return fmt.Errorf("failed to list current API versions: %w", err)
 	}
@@ -734,6 +774,10 @@ func (p *Planner) planAPIVersionChanges(
 		if current, exists := currentByVersion[versionStr]; !exists {
 			// CREATE new version
 			p.planAPIVersionCreate(parentNamespace, apiRef, apiID, desiredVersion, []string{}, plan)
+		} else if apiVersionSpecUploaded(apiLabels, current.ID, desiredVersion) {
+			// The spec hash stamped on the API when the spec was last uploaded matches, so
+			// the version is unchanged without fetching and comparing its spec
+			continue
 		} else {
 			// CHECK FOR UPDATES - API versions now support updates
 			// Fetch full version to get spec content for comparison
@@ -745,9 +789,17 @@ func (p *Planner) planAPIVersionChanges(
 				current = *fullVersion
 			}
 
-			// Now compare with full content
-			if p.shouldUpdateAPIVersion(current, desiredVersion) {
-				p.planAPIVersionUpdate(parentNamespace, apiRef, apiID, current.ID, desiredVersion, plan)
+			// Now compare with full content; only changed fields are sent so an unchanged
+			// spec is never re-uploaded
+			fields := p.apiVersionUpdateFields(current, desiredVersion)
+			if len(fields) == 0 && desiredVersion.Spec.Content != nil {
+				// The spec matches but its hash label is missing or stale: record the hash so
+				// later plans skip fetching the spec, without uploading it again
+				fields["spec"] = SpecContentUnchanged
+				fields[FieldSpecHash] = labels.SpecHash(*desiredVersion.Spec.Content)
+			}
+			if len(fields) > 0 {
+				p.planAPIVersionUpdate(parentNamespace, apiRef, apiID, current.ID, desiredVersion, fields, plan)
 			}
 		}
 	}
@@ -883,7 +935,7 @@ func (p *Planner) planAPIPublicationChanges(
 	namespaceFilter := []string{namespace}
 
 	// List current publications
-	currentPublications, err := p.client.ListAPIPublications(ctx, apiID)
+	currentPublications, err := p.listAPIPublications(ctx, apiID)
 	if err != nil {
 		return fmt.Errorf("failed to list current API publications: %w", err)
 	}
@@ -1081,6 +1133,7 @@ func (p *Planner) planAPIPublicationCreate(
 	if publication.Visibility != nil {
 		fields["visibility"] = string(*publication.Visibility)
 	}
+	action := publicationAction(publication, ActionCreate, fields)
 
 	parentInfo := &ParentInfo{Ref: apiRef}
 	if apiID != "" {
@@ -1088,11 +1141,11 @@ func (p *Planner) planAPIPublicationCreate(
 	}
 
 	change := PlannedChange{
-		ID:           p.nextChangeID(ActionCreate, "api_publication", publication.GetRef()),
+		ID:           p.nextChangeID(action, "api_publication", publication.GetRef()),
 		ResourceType: "api_publication",
 		ResourceRef:  publication.GetRef(),
 		Parent:       parentInfo,
-		Action:       ActionCreate,
+		Action:       action,
 		Fields:       fields,
 		DependsOn:    dependsOn,
 		Namespace:    parentNamespace,
@@ -1220,17 +1273,20 @@ func (p *Planner) planAPIPublicationUpdate(
 ) {
 	// Update fields with resolved portal ID
 	updateFields["portal_id"] = current.PortalID
+	action := publicationAction(desired, ActionUpdate, updateFields)
 
 	change := PlannedChange{
-		ID:           p.nextChangeID(ActionUpdate, "api_publication", desired.GetRef()),
+		ID:           p.nextChangeID(action, "api_publication", desired.GetRef()),
 		ResourceType: "api_publication",
 		ResourceRef:  desired.GetRef(),
 		ResourceID:   fmt.Sprintf("%s:%s", apiID, current.PortalID), // Composite ID
 		Parent:       &ParentInfo{Ref: apiRef, ID: apiID},
-		Action:       ActionUpdate,
+		Action:       action,
 		Fields:       updateFields,
 		DependsOn:    []string{},
 		Namespace:    parentNamespace,
+		// The publication is identified by its portal
+		IdentityFields: []string{"portal_id"},
 	}
 
 	// Look up portal name for reference resolution using global lookup
@@ -1293,6 +1349,25 @@ func (p *Planner) planAPIPublicationUpdate(
 	plan.AddChange(change)
 }
 
+// publicationAction returns ActionSwitch when a publication with a _switch block
+// changes visibility, adding the stage settings to fields, and fallback otherwise
+func publicationAction(
+	publication resources.APIPublicationResource, fallback ActionType, fields map[string]any,
+) ActionType {
+	if publication.Switch == nil {
+		return fallback
+	}
+	if _, ok := fields["visibility"]; !ok {
+		return fallback
+	}
+
+	fields["stage_visibility"] = publication.Switch.GetStageVisibility()
+	if len(publication.Switch.Verify) > 0 {
+		fields["verify"] = append([]string(nil), publication.Switch.Verify...)
+	}
+	return ActionSwitch
+}
+
 // shouldUpdateAPIPublication compares current and desired API publication to determine if update is needed
 func (p *Planner) shouldUpdateAPIPublication(
 	current state.APIPublication,
@@ -1303,24 +1378,25 @@ func (p *Planner) shouldUpdateAPIPublication(
 	// Compare auth strategy IDs (order-independent comparison)
 	if desired.AuthStrategyIds != nil {
 		resolvedDesired := p.resolveAuthStrategyIDsForComparison(desired.AuthStrategyIds)
-		if !p.compareStringSlices(current.AuthStrategyIDs, resolvedDesired) {
+		if !stringSetsEqual(current.AuthStrategyIDs, resolvedDesired) {
 			updates["auth_strategy_ids"] = desired.AuthStrategyIds
 		}
 	}
 
-	// Compare auto approve registrations
-	desiredAutoApprove := false
-	if desired.AutoApproveRegistrations != nil {
-		desiredAutoApprove = *desired.AutoApproveRegistrations
-	}
-	if current.AutoApproveRegistrations != desiredAutoApprove {
-		updates["auto_approve_registrations"] = desiredAutoApprove
+	// Compare auto approve registrations - only update if explicitly specified and different
+	if desired.AutoApproveRegistrations != nil && current.AutoApproveRegistrations != *desired.AutoApproveRegistrations {
+		updates["auto_approve_registrations"] = *desired.AutoApproveRegistrations
 	}
 
-	// Compare visibility - only update if explicitly specified and different
+	// Compare visibility - only update if explicitly specified and different. Konnect
+	// omits the visibility of private publications, so an empty value is the default.
 	if desired.Visibility != nil {
 		desiredVisibility := string(*desired.Visibility)
-		if current.Visibility != desiredVisibility {
+		currentVisibility := current.Visibility
+		if currentVisibility == "" {
+			currentVisibility = string(kkComps.APIPublicationVisibilityPrivate)
+		}
+		if currentVisibility != desiredVisibility {
 			updates["visibility"] = desiredVisibility
 		}
 	}
@@ -1355,28 +1431,6 @@ func (p *Planner) resolveAuthStrategyIDsForComparison(desired []string) []string
 	return resolved
 }
 
-// compareStringSlices compares two string slices for equality (order-independent)
-func (p *Planner) compareStringSlices(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-
-	// Create maps for efficient comparison
-	aMap := make(map[string]bool)
-	for _, s := range a {
-		aMap[s] = true
-	}
-
-	// Check if all elements in b exist in a
-	for _, s := range b {
-		if !aMap[s] {
-			return false
-		}
-	}
-
-	return true
-}
-
 // API Implementation planning
 
 func (p *Planner) planAPIImplementationChanges(
@@ -1384,7 +1438,7 @@ func (p *Planner) planAPIImplementationChanges(
 	desired []resources.APIImplementationResource, plan *Plan,
 ) error {
 	// List current implementations
-	currentImplementations, err := p.client.ListAPIImplementations(ctx, apiID)
+	currentImplementations, err := p.listAPIImplementations(ctx, apiID)
 	if err != nil {
 		return fmt.Errorf("failed to list current API implementations: %w", err)
 	}
@@ -1583,7 +1637,7 @@ func (p *Planner) planAPIDocumentChanges(
 	desired []resources.APIDocumentResource, plan *Plan,
 ) error {
 	// List current documents
-	currentDocuments, err := p.client.ListAPIDocuments(ctx, apiID)
+	currentDocuments, err := p.listAPIDocuments(ctx, apiID)
 	if err != nil {
 		return fmt.Errorf("failed to list current API documents: %w", err)
 	}
@@ -1656,56 +1710,64 @@ func (p *Planner) shouldUpdateAPIDocument(current state.APIDocument, desired res
 	return false
 }
 
-func (p *Planner) shouldUpdateAPIVersion(current state.APIVersion, desired resources.APIVersionResource) bool {
+// apiVersionUpdateFields returns the fields that differ between the current and desired
+// API version. Version metadata and spec content are compared independently.
+func (p *Planner) apiVersionUpdateFields(
+	current state.APIVersion, desired resources.APIVersionResource,
+) map[string]any {
+	fields := make(map[string]any)
+
 	// Check if version string changed
 	if desired.Version != nil && current.Version != *desired.Version {
-		return true
+		fields["version"] = *desired.Version
 	}
 
 	// Check if spec content changed
-	if desired.Spec.Content != nil {
-		// Both should already be normalized JSON, but ensure consistency
-		currentSpec := strings.TrimSpace(current.Spec)
-		desiredSpec := strings.TrimSpace(*desired.Spec.Content)
-
-		// Re-normalize both sides to ensure consistent comparison
-		// This handles any edge cases where normalization wasn't applied
-		normalizedCurrent, err := normalizers.SpecToJSON(currentSpec)
-		if err != nil {
-			// Fallback to direct comparison if normalization fails
-			normalizedCurrent = currentSpec
-		}
-		normalizedDesired, err := normalizers.SpecToJSON(desiredSpec)
-		if err != nil {
-			// Fallback to direct comparison if normalization fails
-			normalizedDesired = desiredSpec
-		}
-
-		if normalizedCurrent != normalizedDesired {
-			return true
+	if desired.Spec.Content != nil && !apiVersionSpecsEqual(current.Spec, *desired.Spec.Content) {
+		// Store spec as a map with content field for proper JSON serialization
+		fields["spec"] = map[string]any{
+			"content": *desired.Spec.Content,
 		}
 	}
 
-	return false
+	return fields
 }
 
-func (p *Planner) planAPIVersionUpdate(
-	parentNamespace string, apiRef string, apiID string, versionID string,
-	version resources.APIVersionResource, plan *Plan,
-) {
-	fields := make(map[string]any)
+// apiVersionSpecUploaded reports whether the desired spec of a version is the one last
+// uploaded by kongctl, as recorded by the spec hash label of the API
+func apiVersionSpecUploaded(
+	apiLabels map[string]string, versionID string, desired resources.APIVersionResource,
+) bool {
+	stored := apiLabels[labels.SpecHashKey(versionID)]
+	return stored != "" && desired.Spec.Content != nil && stored == labels.SpecHash(*desired.Spec.Content)
+}
 
-	// Add fields that can be updated
-	if version.Version != nil {
-		fields["version"] = *version.Version
+// apiVersionSpecsEqual compares spec documents after normalizing both to JSON
+func apiVersionSpecsEqual(current, desired string) bool {
+	// Both should already be normalized JSON, but ensure consistency
+	currentSpec := strings.TrimSpace(current)
+	desiredSpec := strings.TrimSpace(desired)
+
+	// Re-normalize both sides to ensure consistent comparison
+	// This handles any edge cases where normalization wasn't applied
+	normalizedCurrent, err := normalizers.SpecToJSON(currentSpec)
+	if err != nil {
+		// Fallback to direct comparison if normalization fails
+		normalizedCurrent = currentSpec
 	}
-	if version.Spec.Content != nil {
-		// Store spec as a map with content field for proper JSON serialization
-		fields["spec"] = map[string]any{
-			"content": *version.Spec.Content,
-		}
+	normalizedDesired, err := normalizers.SpecToJSON(desiredSpec)
+	if err != nil {
+		// Fallback to direct comparison if normalization fails
+		normalizedDesired = desiredSpec
 	}
 
+	return normalizedCurrent == normalizedDesired
+}
+
+func (p *Planner) planAPIVersionUpdate(
+	parentNamespace string, apiRef string, apiID string, versionID string,
+	version resources.APIVersionResource, fields map[string]any, plan *Plan,
+) {
 	change := PlannedChange{
 		ID:           p.nextChangeID(ActionUpdate, "api_version", version.GetRef()),
 		ResourceType: "api_version",
@@ -2011,7 +2073,16 @@ func (p *Planner) planAPIVersionsChanges(
 					break
 				}
 			}
-			if err := p.planAPIVersionChanges(ctx, plannerCtx, parentNamespace, apiID, apiRef, versions, plan); err != nil {
+			var apiLabels map[string]string
+			if current, err := p.client.GetAPIByID(ctx, apiID); err != nil {
+				p.logger.Debug("Unable to read API labels, comparing version specs",
+					slog.String("api", apiRef), slog.String("error", err.Error()))
+			} else if current != nil {
+				apiLabels = current.NormalizedLabels
+			}
+			if err := p.planAPIVersionChanges(
+				ctx, plannerCtx, parentNamespace, apiID, apiLabels, apiRef, versions, plan,
+			); err != nil {
 				return err
 			}
 		}
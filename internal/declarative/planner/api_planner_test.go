@@ -1,12 +1,19 @@
 package planner
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
 	"testing"
 
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/state"
 	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/konnect/helpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -165,3 +172,236 @@ func TestShouldUpdateAPIPublicationIgnoresAuthStrategyWhenUnset(t *testing.T) {
 	require.False(t, needsUpdate)
 	assert.Empty(t, fields)
 }
+
+func TestAPIVersionUpdateFieldsSeparatesVersionAndSpec(t *testing.T) {
+	planner := &Planner{}
+	versionStr := "1.0.0"
+	specJSON := `{"openapi":"3.0.0","info":{"title":"Orders","version":"1.0.0"}}`
+	specYAML := "openapi: 3.0.0\ninfo:\n  title: Orders\n  version: 1.0.0\n"
+
+	current := state.APIVersion{ID: "version-1", Version: versionStr, Spec: specJSON}
+
+	t.Run("equivalent spec produces no update", func(t *testing.T) {
+		desired := resources.APIVersionResource{
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &versionStr,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &specYAML},
+			},
+		}
+		assert.Empty(t, planner.apiVersionUpdateFields(current, desired))
+	})
+
+	t.Run("version change does not re-upload spec", func(t *testing.T) {
+		newVersion := "1.0.1"
+		desired := resources.APIVersionResource{
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &newVersion,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &specJSON},
+			},
+		}
+		fields := planner.apiVersionUpdateFields(current, desired)
+		assert.Equal(t, map[string]any{"version": newVersion}, fields)
+	})
+
+	t.Run("spec change does not touch version", func(t *testing.T) {
+		changed := `{"openapi":"3.0.0","info":{"title":"Orders v2","version":"1.0.0"}}`
+		desired := resources.APIVersionResource{
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &versionStr,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &changed},
+			},
+		}
+		fields := planner.apiVersionUpdateFields(current, desired)
+		require.Len(t, fields, 1)
+		assert.Equal(t, map[string]any{"content": changed}, fields["spec"])
+	})
+}
+
+// listedVersionAPI lists one version and can't fetch its spec
+type listedVersionAPI struct {
+	stubAPIVersionAPI
+}
+
+func (l *listedVersionAPI) ListAPIVersions(
+	context.Context, kkOps.ListAPIVersionsRequest, ...kkOps.Option,
+) (*kkOps.ListAPIVersionsResponse, error) {
+	return &kkOps.ListAPIVersionsResponse{
+		ListAPIVersionResponse: &kkComps.ListAPIVersionResponse{
+			Data: []kkComps.ListAPIVersionResponseAPIVersionSummary{{ID: "version-1", Version: "1.0.0"}},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil
+}
+
+// fetchedVersionAPI lists one version and fetches its spec
+type fetchedVersionAPI struct {
+	listedVersionAPI
+	spec string
+}
+
+func (f *fetchedVersionAPI) FetchAPIVersion(
+	context.Context, string, string, ...kkOps.Option,
+) (*kkOps.FetchAPIVersionResponse, error) {
+	return &kkOps.FetchAPIVersionResponse{
+		APIVersionResponse: &kkComps.APIVersionResponse{
+			ID:      "version-1",
+			Version: "1.0.0",
+			Spec:    &kkComps.APIVersionResponseSpec{Content: &f.spec},
+		},
+	}, nil
+}
+
+func TestPlanAPIVersionChanges_SpecHashLabel(t *testing.T) {
+	spec := "openapi: 3.0.0\ninfo:\n  title: Orders\n  version: 1.0.0\n"
+	stamped := map[string]string{labels.SpecHashKey("version-1"): labels.SpecHash(spec)}
+	version := "1.0.0"
+	var versionAPI helpers.APIVersionAPI = &listedVersionAPI{}
+	planVersions := func(apiLabels map[string]string) (*Plan, error) {
+		client := state.NewClient(state.ClientConfig{APIVersionAPI: versionAPI})
+		planner := NewPlanner(client, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		planner.resources = &resources.ResourceSet{}
+		desired := []resources.APIVersionResource{{
+			CreateAPIVersionRequest: kkComps.CreateAPIVersionRequest{
+				Version: &version,
+				Spec:    kkComps.CreateAPIVersionRequestSpec{Content: &spec},
+			},
+			Ref: "orders-v1",
+		}}
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		err := planner.planAPIVersionChanges(
+			context.Background(), nil, DefaultNamespace, "api-1", apiLabels, "orders", desired, plan,
+		)
+		return plan, err
+	}
+
+	t.Run("matching hash skips the spec", func(t *testing.T) {
+		plan, err := planVersions(stamped)
+		require.NoError(t, err, "the spec is not fetched")
+		assert.Empty(t, plan.Changes)
+	})
+
+	t.Run("changed spec is compared with the one in Konnect", func(t *testing.T) {
+		changed := map[string]string{labels.SpecHashKey("version-1"): labels.SpecHash("openapi: 3.1.0")}
+		_, err := planVersions(changed)
+		assert.ErrorContains(t, err, "failed to fetch version")
+	})
+
+	t.Run("unchanged spec without a hash only records the hash", func(t *testing.T) {
+		versionAPI = &fetchedVersionAPI{spec: `{"openapi":"3.0.0","info":{"title":"Orders","version":"1.0.0"}}`}
+		plan, err := planVersions(nil)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, map[string]any{
+			"spec":        SpecContentUnchanged,
+			FieldSpecHash: labels.SpecHash(spec),
+		}, plan.Changes[0].Fields)
+	})
+
+	t.Run("changed spec is uploaded", func(t *testing.T) {
+		versionAPI = &fetchedVersionAPI{spec: `{"openapi":"3.0.0","info":{"title":"Old","version":"1.0.0"}}`}
+		plan, err := planVersions(nil)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, map[string]any{"spec": map[string]any{"content": spec}}, plan.Changes[0].Fields)
+	})
+}
+
+// countingVersionAPI counts the version lists, which run concurrently when prefetching
+type countingVersionAPI struct {
+	listedVersionAPI
+	lists atomic.Int32
+}
+
+func (c *countingVersionAPI) ListAPIVersions(
+	ctx context.Context, req kkOps.ListAPIVersionsRequest, opts ...kkOps.Option,
+) (*kkOps.ListAPIVersionsResponse, error) {
+	c.lists.Add(1)
+	return c.listedVersionAPI.ListAPIVersions(ctx, req, opts...)
+}
+
+func TestPrefetchAPIChildren(t *testing.T) {
+	ctx := context.Background()
+	versionAPI := &countingVersionAPI{}
+	planner := NewPlanner(state.NewClient(state.ClientConfig{APIVersionAPI: versionAPI}),
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	t.Run("sequential planning lists on demand", func(t *testing.T) {
+		planner.prefetchAPIChildren(ctx, []string{"api-1", "api-2"})
+		assert.Zero(t, versionAPI.lists.Load())
+	})
+
+	t.Run("parallel planning lists every API up front", func(t *testing.T) {
+		planner.parallelism = 4
+		planner.prefetchAPIChildren(ctx, []string{"api-1", "api-2", "api-3"})
+		assert.Equal(t, int32(3), versionAPI.lists.Load())
+
+		versions, err := planner.listAPIVersions(ctx, "api-2")
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+		assert.Equal(t, "version-1", versions[0].ID)
+		assert.Equal(t, int32(3), versionAPI.lists.Load(), "prefetched versions are not listed again")
+
+		_, err = planner.listAPIPublications(ctx, "api-2")
+		assert.ErrorContains(t, err, "API publication client not configured", "list errors are kept")
+	})
+}
+
+func TestPlanAPIPublication_Switch(t *testing.T) {
+	public := kkComps.APIPublicationVisibilityPublic
+	newPlanner := func() *Planner {
+		return &Planner{resources: &resources.ResourceSet{}}
+	}
+	desired := resources.APIPublicationResource{
+		APIPublication: kkComps.APIPublication{Visibility: &public},
+		Ref:            "orders-pub",
+		PortalID:       "dev-portal",
+		Switch:         &resources.PublicationSwitch{Verify: []string{"./check.sh", "orders"}},
+	}
+
+	t.Run("new publication is switched", func(t *testing.T) {
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		newPlanner().planAPIPublicationCreate("default", "orders", "api-1", desired, nil, plan)
+
+		require.Len(t, plan.Changes, 1)
+		change := plan.Changes[0]
+		assert.Equal(t, ActionSwitch, change.Action)
+		assert.Contains(t, change.ID, ":s:api_publication:orders-pub")
+		assert.Equal(t, "public", change.Fields["visibility"])
+		assert.Equal(t, "private", change.Fields["stage_visibility"])
+		assert.Equal(t, []string{"./check.sh", "orders"}, change.Fields["verify"])
+	})
+
+	t.Run("visibility change keeps the publication identity", func(t *testing.T) {
+		current := state.APIPublication{ID: "api-1", PortalID: "portal-1", Visibility: "private"}
+		p := newPlanner()
+		needsUpdate, fields := p.shouldUpdateAPIPublication(current, desired)
+		require.True(t, needsUpdate)
+
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		p.planAPIPublicationUpdate("default", "orders", "api-1", current, desired, fields, plan)
+
+		require.Len(t, plan.Changes, 1)
+		change := plan.Changes[0]
+		assert.Equal(t, ActionSwitch, change.Action)
+		assert.Equal(t, "api-1:portal-1", change.ResourceID)
+		assert.Equal(t, "portal-1", change.References["portal_id"].ID)
+		assert.Equal(t, "private", change.Fields["stage_visibility"])
+	})
+
+	t.Run("other updates are not switched", func(t *testing.T) {
+		autoApprove := true
+		withoutVisibilityChange := desired
+		withoutVisibilityChange.AutoApproveRegistrations = &autoApprove
+		current := state.APIPublication{ID: "api-1", PortalID: "portal-1", Visibility: "public"}
+		p := newPlanner()
+		needsUpdate, fields := p.shouldUpdateAPIPublication(current, withoutVisibilityChange)
+		require.True(t, needsUpdate)
+
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		p.planAPIPublicationUpdate("default", "orders", "api-1", current, withoutVisibilityChange, fields, plan)
+
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, ActionUpdate, plan.Changes[0].Action)
+		assert.NotContains(t, plan.Changes[0].Fields, "stage_visibility")
+	})
+}
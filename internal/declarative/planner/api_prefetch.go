@@ -0,0 +1,86 @@
+package planner
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// apiChildState holds the child resources of an existing API listed ahead of planning
+type apiChildState struct {
+	versions           []state.APIVersion
+	versionsErr        error
+	publications       []state.APIPublication
+	publicationsErr    error
+	implementations    []state.APIImplementation
+	implementationsErr error
+	documents          []state.APIDocument
+	documentsErr       error
+}
+
+// prefetchAPIChildren lists the child resources of the given APIs with up to
+// p.parallelism requests at once. Planning itself stays sequential and reads the
+// results, so changes are planned in the same order as without prefetching.
+func (p *Planner) prefetchAPIChildren(ctx context.Context, apiIDs []string) {
+	if p.parallelism <= 1 || len(apiIDs) == 0 {
+		return
+	}
+
+	children := make(map[string]*apiChildState, len(apiIDs))
+	for _, apiID := range apiIDs {
+		children[apiID] = &apiChildState{}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, p.parallelism)
+	run := func(fetch func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			fetch()
+		}()
+	}
+	for apiID, child := range children {
+		run(func() { child.versions, child.versionsErr = p.client.ListAPIVersions(ctx, apiID) })
+		run(func() { child.publications, child.publicationsErr = p.client.ListAPIPublications(ctx, apiID) })
+		run(func() {
+			child.implementations, child.implementationsErr = p.client.ListAPIImplementations(ctx, apiID)
+		})
+		run(func() { child.documents, child.documentsErr = p.client.ListAPIDocuments(ctx, apiID) })
+	}
+	wg.Wait()
+
+	p.apiChildren = children
+	p.logger.Debug("Prefetched API child resources", "apis", len(apiIDs), "parallelism", p.parallelism)
+}
+
+func (p *Planner) listAPIVersions(ctx context.Context, apiID string) ([]state.APIVersion, error) {
+	if child, ok := p.apiChildren[apiID]; ok {
+		return child.versions, child.versionsErr
+	}
+	return p.client.ListAPIVersions(ctx, apiID)
+}
+
+func (p *Planner) listAPIPublications(ctx context.Context, apiID string) ([]state.APIPublication, error) {
+	if child, ok := p.apiChildren[apiID]; ok {
+		return child.publications, child.publicationsErr
+	}
+	return p.client.ListAPIPublications(ctx, apiID)
+}
+
+func (p *Planner) listAPIImplementations(ctx context.Context, apiID string) ([]state.APIImplementation, error) {
+	if child, ok := p.apiChildren[apiID]; ok {
+		return child.implementations, child.implementationsErr
+	}
+	return p.client.ListAPIImplementations(ctx, apiID)
+}
+
+func (p *Planner) listAPIDocuments(ctx context.Context, apiID string) ([]state.APIDocument, error) {
+	if child, ok := p.apiChildren[apiID]; ok {
+		return child.documents, child.documentsErr
+	}
+	return p.client.ListAPIDocuments(ctx, apiID)
+}
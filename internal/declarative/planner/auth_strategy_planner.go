@@ -49,6 +49,10 @@ func (p *authStrategyPlannerImpl) PlanChanges(ctx context.Context, plannerCtx *C
 	for _, strategy := range currentStrategies {
 		currentByName[strategy.Name] = strategy
 	}
+	if err := moveResources(p.planner, plan, "application_auth_strategy", desired, currentByName,
+		func(strategy state.ApplicationAuthStrategy) (string, string) { return strategy.Name, strategy.ID }); err != nil {
+		return err
+	}
 
 	// Collect protection validation errors
 	protectionErrors := &ProtectionErrorCollector{}
@@ -353,6 +357,11 @@ func (p *authStrategyPlannerImpl) shouldUpdateAuthStrategy(
 		}
 	}
 
+	// Names differ when a moved entry renamed the auth strategy
+	if name := desired.GetMoniker(); name != "" && current.Name != name {
+		updateFields["name"] = name
+	}
+
 	// Only compare fields present in desired configuration
 	if displayName != "" {
 		if current.DisplayName != displayName {
@@ -445,7 +454,7 @@ func (p *authStrategyPlannerImpl) shouldUpdateAuthStrategy(
 			// Check scopes
 			if oidcConfig.Scopes != nil {
 				currentScopes := extractStringSlice(currentOIDC["scopes"])
-				if !stringSlicesEqual(currentScopes, oidcConfig.Scopes) {
+				if !stringSetsEqual(currentScopes, oidcConfig.Scopes) {
 					oidcUpdates["scopes"] = oidcConfig.Scopes
 					hasUpdates = true
 				}
@@ -454,7 +463,7 @@ func (p *authStrategyPlannerImpl) shouldUpdateAuthStrategy(
 			// Check auth_methods
 			if oidcConfig.AuthMethods != nil {
 				currentMethods := extractStringSlice(currentOIDC["auth_methods"])
-				if !stringSlicesEqual(currentMethods, oidcConfig.AuthMethods) {
+				if !stringSetsEqual(currentMethods, oidcConfig.AuthMethods) {
 					oidcUpdates["auth_methods"] = oidcConfig.AuthMethods
 					hasUpdates = true
 				}
@@ -575,8 +584,10 @@ func (p *authStrategyPlannerImpl) planAuthStrategyProtectionChangeWithFields(
 		}
 	}
 
-	// Always include name for identification
-	fields["name"] = current.Name
+	// Always include name for identification, unless the strategy is renamed
+	if _, ok := fields["name"]; !ok {
+		fields["name"] = current.Name
+	}
 
 	// Don't add protection label here - it will be added during execution
 	// based on the Protection field
@@ -592,7 +603,8 @@ func (p *authStrategyPlannerImpl) planAuthStrategyProtectionChangeWithFields(
 			Old: wasProtected,
 			New: shouldProtect,
 		},
-		DependsOn: []string{},
+		DependsOn:      []string{},
+		IdentityFields: []string{"name"},
 	}
 
 	// Extract namespace
@@ -58,6 +58,10 @@ func (p *Planner) planCatalogServiceChanges(
 	for _, svc := range currentServices {
 		currentByName[svc.Name] = svc
 	}
+	if err := moveResources(p, plan, "catalog_service", desired, currentByName,
+		func(svc state.CatalogService) (string, string) { return svc.Name, svc.ID }); err != nil {
+		return err
+	}
 
 	// Handle delete mode - plan DELETE for desired resources that exist in Konnect
 	if plan.Metadata.Mode == PlanModeDelete {
@@ -270,11 +274,14 @@ func (p *Planner) planCatalogServiceUpdateWithFields(
 		}
 	}
 
+	var identityFields []string
 	if _, ok := updateFields["name"]; !ok {
 		updateFields["name"] = current.Name
+		identityFields = append(identityFields, "name")
 	}
 	if _, ok := updateFields["display_name"]; !ok {
 		updateFields["display_name"] = current.DisplayName
+		identityFields = append(identityFields, "display_name")
 	}
 	if _, hasLabels := updateFields["labels"]; hasLabels {
 		updateFields[FieldCurrentLabels] = current.NormalizedLabels
@@ -319,11 +326,13 @@ func (p *Planner) planCatalogServiceUpdateWithFields(
 		if labels.IsProtectedResource(current.NormalizedLabels) {
 			fallback.Protection = true
 		}
+		fallback.IdentityFields = identityFields
 		plan.AddChange(fallback)
 		return
 	}
 
 	change.Protection = protection
+	change.IdentityFields = identityFields
 	plan.AddChange(change)
 }
 
@@ -354,7 +363,9 @@ func (p *Planner) planCatalogServiceProtectionChangeWithFields(
 	for k, v := range updateFields {
 		fields[k] = v
 	}
-	fields["name"] = current.Name
+	if _, ok := fields["name"]; !ok {
+		fields["name"] = current.Name
+	}
 	fields["display_name"] = current.DisplayName
 	fields["id"] = current.ID
 
@@ -375,6 +386,7 @@ func (p *Planner) planCatalogServiceProtectionChangeWithFields(
 	}
 
 	change.Fields = fields
+	change.IdentityFields = []string{"name", "display_name", "id", "namespace", "preserved_labels"}
 	plan.AddChange(change)
 }
 
@@ -15,6 +15,14 @@ const (
 	// FieldError contains validation errors that should be reported
 	// Used when the planner detects an invalid operation
 	FieldError = "_error"
+
+	// FieldSpecHash contains the spec hash to record for an API version whose spec is
+	// unchanged but whose spec hash label is missing or stale
+	FieldSpecHash = "_spec_hash"
+
+	// SpecContentUnchanged is the spec field of an API version update that only records
+	// the spec hash; the spec is not uploaded again
+	SpecContentUnchanged = "(content unchanged)"
 )
 
 // Resource type constants
@@ -55,6 +63,9 @@ const (
 	// ResourceTypePortalEmailTemplate is the resource type for portal email templates
 	ResourceTypePortalEmailTemplate = "portal_email_template"
 
+	// ResourceTypePortalAuditLogWebhook is the resource type for portal audit log webhooks
+	ResourceTypePortalAuditLogWebhook = "portal_audit_log_webhook"
+
 	// ResourceTypeEventGatewayControlPlane is the resource type for event gateway control planes
 	ResourceTypeEventGatewayControlPlane = "event_gateway"
 
@@ -64,6 +75,21 @@ const (
 	// ResourceTypeEventGatewayVirtualCluster is the resource type for event gateway virtual clusters
 	ResourceTypeEventGatewayVirtualCluster = "event_gateway_virtual_cluster"
 
+	// ResourceTypeOrganizationTeam is the resource type for organization teams
+	ResourceTypeOrganizationTeam = "organization_team"
+
+	// ResourceTypeOrganizationTeamRole is the resource type for roles assigned to organization teams
+	ResourceTypeOrganizationTeamRole = "organization_team_role"
+
+	// ResourceTypeOrganizationTeamMember is the resource type for organization team members
+	ResourceTypeOrganizationTeamMember = "organization_team_member"
+
+	// ResourceTypeNotificationSubscription is the resource type for notification event subscriptions
+	ResourceTypeNotificationSubscription = "notification_subscription"
+
+	// ResourceTypeGatewayService is the resource type for gateway services of control planes
+	ResourceTypeGatewayService = "gateway_service"
+
 	// ResourceTypeDeck represents an internal deck execution step.
 	ResourceTypeDeck = "_deck"
 )
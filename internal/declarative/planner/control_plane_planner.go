@@ -51,6 +51,10 @@ func (p *controlPlanePlannerImpl) PlanChanges(ctx context.Context, plannerCtx *C
 		}
 		currentByName[cp.Name] = cp
 	}
+	if err := moveResources(p.planner, plan, "control_plane", desired, currentByName,
+		func(cp state.ControlPlane) (string, string) { return cp.Name, cp.ID }); err != nil {
+		return err
+	}
 
 	protectionErrors := &ProtectionErrorCollector{}
 
@@ -119,6 +123,10 @@ func (p *controlPlanePlannerImpl) PlanChanges(ctx context.Context, plannerCtx *C
 		}
 	}
 
+	if err := p.planner.planGatewayServiceChanges(ctx, namespace, desired, plan); err != nil {
+		return err
+	}
+
 	if plan.Metadata.Mode == PlanModeSync {
 		desiredNames := make(map[string]struct{})
 		for _, cp := range desired {
@@ -219,8 +227,10 @@ func (p *controlPlanePlannerImpl) planControlPlaneUpdate(
 		}
 	}
 
-	// Always include name for identification
-	updateFields["name"] = current.Name
+	// Always include name for identification, unless the control plane is renamed
+	if _, ok := updateFields["name"]; !ok {
+		updateFields["name"] = current.Name
+	}
 
 	if _, hasLabels := updateFields["labels"]; hasLabels {
 		updateFields[FieldCurrentLabels] = current.NormalizedLabels
@@ -244,6 +254,7 @@ func (p *controlPlanePlannerImpl) planControlPlaneUpdate(
 			if labels.IsProtectedResource(current.NormalizedLabels) {
 				change.Protection = true
 			}
+			change.IdentityFields = []string{"name"}
 			if len(memberIDs) > 0 {
 				if change.References == nil {
 					change.References = make(map[string]ReferenceInfo)
@@ -270,6 +281,8 @@ func (p *controlPlanePlannerImpl) planControlPlaneUpdate(
 		Action:       ActionUpdate,
 		Fields:       fields,
 		Namespace:    namespace,
+		// Always include name for identification
+		IdentityFields: []string{"name"},
 	}
 
 	if labels.IsProtectedResource(current.NormalizedLabels) {
@@ -388,6 +401,11 @@ func (p *controlPlanePlannerImpl) shouldUpdateControlPlane(
 ) (bool, map[string]any) {
 	updates := make(map[string]any)
 
+	// Names differ when a moved entry renamed the control plane
+	if current.Name != desired.Name {
+		updates["name"] = desired.Name
+	}
+
 	if desired.Description != nil {
 		currentDesc := ""
 		if current.Description != nil {
@@ -406,7 +424,7 @@ func (p *controlPlanePlannerImpl) shouldUpdateControlPlane(
 	}
 
 	if desired.ProxyUrls != nil {
-		if !proxyURLsEqual(current.Config.ProxyUrls, desired.ProxyUrls) {
+		if !proxyURLSetsEqual(current.Config.ProxyUrls, desired.ProxyUrls) {
 			updates["proxy_urls"] = desired.ProxyUrls
 		}
 	}
@@ -420,7 +438,7 @@ func (p *controlPlanePlannerImpl) shouldUpdateControlPlane(
 	if desired.IsGroup() {
 		desiredMembers := p.resolveDesiredGroupMemberIDs(desired)
 		currentMembers := normalizers.NormalizeMemberIDs(current.GroupMembers)
-		if !equalStringSlices(currentMembers, desiredMembers) {
+		if !stringSetsEqual(currentMembers, desiredMembers) {
 			updates["members"] = desiredMembers
 		}
 	}
@@ -464,22 +482,6 @@ func extractControlPlaneFields(cp resources.ControlPlaneResource) map[string]any
 	return fields
 }
 
-func proxyURLsEqual(current, desired []kkComps.ProxyURL) bool {
-	if len(current) != len(desired) {
-		return false
-	}
-
-	for i := range current {
-		if current[i].Host != desired[i].Host ||
-			current[i].Port != desired[i].Port ||
-			current[i].Protocol != desired[i].Protocol {
-			return false
-		}
-	}
-
-	return true
-}
-
 func isProtected(cp resources.ControlPlaneResource) bool {
 	if cp.Kongctl != nil && cp.Kongctl.Protected != nil {
 		return *cp.Kongctl.Protected
@@ -495,18 +497,6 @@ func formatMemberField(ids []string) []map[string]string {
 	return formatted
 }
 
-func equalStringSlices(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
-	}
-	return true
-}
-
 func (p *controlPlanePlannerImpl) buildMemberReferenceInfo(ids []string) ReferenceInfo {
 	info := ReferenceInfo{
 		Refs:    make([]string, len(ids)),
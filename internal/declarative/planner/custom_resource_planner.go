@@ -0,0 +1,330 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// customResourcePlanner plans changes for one custom resource kind through its handler
+type customResourcePlanner struct {
+	*BasePlanner
+	handler custom.Handler
+}
+
+// newCustomResourcePlanner creates a planner for the handler's kind
+func newCustomResourcePlanner(base *BasePlanner, handler custom.Handler) *customResourcePlanner {
+	return &customResourcePlanner{BasePlanner: base, handler: handler}
+}
+
+// validateCustomResources checks that every custom resource has a registered handler
+// and passes the handler's validation
+func (p *Planner) validateCustomResources(rs *resources.ResourceSet) error {
+	for i := range rs.CustomResources {
+		res := rs.CustomResources[i]
+		handler, ok := custom.Lookup(res.Kind)
+		if !ok {
+			return fmt.Errorf("custom resource %q has kind %q but no handler is registered for it", res.GetRef(), res.Kind)
+		}
+		if err := handler.Validate(res); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", res.Kind, res.GetRef(), err)
+		}
+	}
+	return nil
+}
+
+// resolveCustomResourceIdentities lists existing instances of the kinds being planned
+// (every registered kind in sync mode) and records the IDs of desired custom resources
+// that already exist, so refs to them resolve like refs to built-in resources
+func (p *Planner) resolveCustomResourceIdentities(
+	ctx context.Context, rs *resources.ResourceSet, mode PlanMode,
+) error {
+	desiredKinds := make(map[string]bool)
+	for _, res := range rs.CustomResources {
+		desiredKinds[res.Kind] = true
+	}
+
+	p.customStates = make(map[string][]custom.State)
+	for _, kind := range custom.Kinds() {
+		if !desiredKinds[kind] && mode != PlanModeSync {
+			continue
+		}
+		handler, _ := custom.Lookup(kind)
+		states, err := handler.List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list %s resources: %w", kind, err)
+		}
+		p.customStates[kind] = states
+	}
+
+	for i := range rs.CustomResources {
+		res := &rs.CustomResources[i]
+		namespace := resources.GetNamespace(res.Kongctl)
+		for _, state := range p.customStates[res.Kind] {
+			if state.Name == res.Name && state.Labels[labels.NamespaceKey] == namespace {
+				res.SetKonnectID(state.ID)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// PlanChanges generates changes for the handler's kind in the planner namespace
+func (c *customResourcePlanner) PlanChanges(_ context.Context, plannerCtx *Config, plan *Plan) error {
+	namespace := plannerCtx.Namespace
+	kind := c.handler.Kind()
+	desired := c.planner.resources.GetCustomResourcesByNamespace(kind, namespace)
+
+	// Skip if nothing to plan and not in sync mode
+	if len(desired) == 0 && plan.Metadata.Mode != PlanModeSync {
+		return nil
+	}
+	if namespace == resources.NamespaceExternal {
+		return nil
+	}
+
+	// Index managed instances in this namespace by name
+	currentByName := make(map[string]custom.State)
+	for _, state := range c.planner.customStates[kind] {
+		if !labels.IsManagedResource(state.Labels) {
+			continue
+		}
+		if namespace != "*" && state.Labels[labels.NamespaceKey] != namespace {
+			continue
+		}
+		currentByName[state.Name] = state
+	}
+
+	protectionErrors := &ProtectionErrorCollector{}
+
+	if plan.Metadata.Mode == PlanModeDelete {
+		for _, res := range desired {
+			current, exists := currentByName[res.Name]
+			if !exists {
+				plan.AddWarning("", fmt.Sprintf("%s %q not found, skipping delete", kind, res.Name))
+				continue
+			}
+			err := c.ValidateProtection(kind, res.Name, labels.IsProtectedResource(current.Labels), ActionDelete)
+			protectionErrors.Add(err)
+			if err == nil {
+				c.planDelete(current, plan)
+			}
+		}
+		if protectionErrors.HasErrors() {
+			return protectionErrors.Error()
+		}
+		return nil
+	}
+
+	for _, res := range desired {
+		current, exists := currentByName[res.Name]
+		if !exists {
+			c.planCreate(res, plan)
+			continue
+		}
+
+		currentProtected := labels.IsProtectedResource(current.Labels)
+		desiredProtected := res.Kongctl != nil && res.Kongctl.Protected != nil && *res.Kongctl.Protected
+
+		fields := c.handler.Diff(current, c.withKnownRefs(res))
+		labelsChanged := labels.CompareUserLabels(current.Labels, res.Labels)
+		hasChanges := len(fields) > 0 || labelsChanged
+
+		if currentProtected != desiredProtected {
+			protectionChange := &ProtectionChange{Old: currentProtected, New: desiredProtected}
+			err := c.ValidateProtectionWithChange(kind, res.Name, currentProtected, ActionUpdate,
+				protectionChange, hasChanges)
+			protectionErrors.Add(err)
+			if err == nil {
+				c.planUpdate(current, res, fields, true, *protectionChange, plan)
+			}
+			continue
+		}
+
+		if hasChanges {
+			err := c.ValidateProtection(kind, res.Name, currentProtected, ActionUpdate)
+			protectionErrors.Add(err)
+			if err == nil {
+				c.planUpdate(current, res, fields, labelsChanged, nil, plan)
+			}
+		}
+	}
+
+	// Check for managed instances to delete (sync mode only)
+	if plan.Metadata.Mode == PlanModeSync {
+		desiredNames := make(map[string]bool)
+		for _, res := range desired {
+			desiredNames[res.Name] = true
+		}
+
+		names := make([]string, 0, len(currentByName))
+		for name := range currentByName {
+			if !desiredNames[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			current := currentByName[name]
+			err := c.ValidateProtection(kind, name, labels.IsProtectedResource(current.Labels), ActionDelete)
+			protectionErrors.Add(err)
+			if err == nil {
+				c.planDelete(current, plan)
+			}
+		}
+	}
+
+	if protectionErrors.HasErrors() {
+		return protectionErrors.Error()
+	}
+	return nil
+}
+
+// withKnownRefs returns a copy of the resource whose !ref id placeholders are replaced
+// by the IDs of referenced resources that already exist, so handlers can diff them
+func (c *customResourcePlanner) withKnownRefs(res resources.CustomResource) resources.CustomResource {
+	spec := maps.Clone(res.Spec)
+	for key, value := range spec {
+		str, ok := value.(string)
+		if !ok || !tags.IsRefPlaceholder(str) {
+			continue
+		}
+		ref, field, ok := tags.ParseRefPlaceholder(str)
+		if !ok || field != "id" {
+			continue
+		}
+		if target, found := c.planner.resources.GetResourceByRef(ref); found && target.GetKonnectID() != "" {
+			spec[key] = target.GetKonnectID()
+		}
+	}
+	res.Spec = spec
+	return res
+}
+
+func (c *customResourcePlanner) planCreate(res resources.CustomResource, plan *Plan) {
+	kind := c.handler.Kind()
+	fields := c.withKnownRefs(res).Spec
+	if fields == nil {
+		fields = make(map[string]any)
+	}
+	fields["name"] = res.Name
+	if len(res.Labels) > 0 {
+		fields["labels"] = res.Labels
+	}
+
+	change := PlannedChange{
+		ID:           c.NextChangeID(ActionCreate, kind, res.GetRef()),
+		ResourceType: kind,
+		ResourceRef:  res.GetRef(),
+		Action:       ActionCreate,
+		Fields:       fields,
+		DependsOn:    []string{},
+		Namespace:    resources.GetNamespace(res.Kongctl),
+	}
+	if res.Kongctl != nil && res.Kongctl.Protected != nil {
+		change.Protection = *res.Kongctl.Protected
+	}
+	plan.AddChange(change)
+}
+
+func (c *customResourcePlanner) planUpdate(
+	current custom.State,
+	res resources.CustomResource,
+	fields map[string]any,
+	includeLabels bool,
+	protection any,
+	plan *Plan,
+) {
+	kind := c.handler.Kind()
+	updateFields := maps.Clone(fields)
+	if updateFields == nil {
+		updateFields = make(map[string]any)
+	}
+	// Always include name field for identification
+	updateFields["name"] = res.Name
+	if includeLabels {
+		// Labels are sent in full; an empty map clears all user labels
+		userLabels := res.Labels
+		if userLabels == nil {
+			userLabels = map[string]string{}
+		}
+		updateFields["labels"] = userLabels
+	}
+
+	change := PlannedChange{
+		ID:           c.NextChangeID(ActionUpdate, kind, res.GetRef()),
+		ResourceType: kind,
+		ResourceRef:  res.GetRef(),
+		ResourceID:   current.ID,
+		Action:       ActionUpdate,
+		Fields:       updateFields,
+		DependsOn:    []string{},
+		Namespace:    resources.GetNamespace(res.Kongctl),
+		Protection:   protection,
+		// Custom resources are matched by name, so it never differs
+		IdentityFields: []string{"name"},
+	}
+	plan.AddChange(change)
+}
+
+func (c *customResourcePlanner) planDelete(current custom.State, plan *Plan) {
+	kind := c.handler.Kind()
+	namespace := DefaultNamespace
+	if ns, ok := current.Labels[labels.NamespaceKey]; ok {
+		namespace = ns
+	}
+
+	plan.AddChange(PlannedChange{
+		ID:           c.NextChangeID(ActionDelete, kind, current.Name),
+		ResourceType: kind,
+		ResourceRef:  current.Name,
+		ResourceID:   current.ID,
+		Action:       ActionDelete,
+		Fields:       map[string]any{"name": current.Name},
+		DependsOn:    []string{},
+		Namespace:    namespace,
+	})
+}
+
+// adjustCustomResourceDependencies makes custom resource changes depend on the creation
+// of resources referenced from their spec with !ref in the same plan
+func adjustCustomResourceDependencies(plan *Plan) {
+	if plan == nil {
+		return
+	}
+
+	createByRef := make(map[string]string) // ref -> changeID
+	for _, change := range plan.Changes {
+		if change.Action == ActionCreate {
+			createByRef[change.ResourceRef] = change.ID
+		}
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if _, ok := custom.Lookup(change.ResourceType); !ok {
+			continue
+		}
+		for _, value := range change.Fields {
+			str, ok := value.(string)
+			if !ok || !tags.IsRefPlaceholder(str) {
+				continue
+			}
+			ref, _, ok := tags.ParseRefPlaceholder(str)
+			if !ok {
+				continue
+			}
+			if depID, inPlan := createByRef[ref]; inPlan && depID != change.ID && !contains(change.DependsOn, depID) {
+				change.DependsOn = append(change.DependsOn, depID)
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kong/kongctl/internal/declarative/deck"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,6 +60,7 @@ func (s *stubDeckRunner) Run(_ context.Context, opts deck.RunOptions) (*deck.Run
 }
 
 type stubGatewayServiceAPI struct {
+	helpers.GatewayServiceAPI
 	services []kkComps.ServiceOutput
 }
 
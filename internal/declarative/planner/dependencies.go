@@ -15,14 +15,47 @@ func NewDependencyResolver() *DependencyResolver {
 	return &DependencyResolver{}
 }
 
+// Dependencies returns, for each change, the IDs of the changes it must wait for:
+// its explicit DependsOn, the creates of resources it references and the create
+// of its parent
+func (d *DependencyResolver) Dependencies(changes []PlannedChange) map[string][]string {
+	dependencies := make(map[string][]string, len(changes))
+	for _, change := range changes {
+		var deps []string
+		add := func(dep string) {
+			if !contains(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+
+		for _, dep := range change.DependsOn {
+			add(dep)
+		}
+		for _, dep := range d.findImplicitDependencies(change, changes) {
+			add(dep)
+		}
+		// A parent missing from the changes already exists or is created elsewhere
+		if change.Parent != nil && change.Parent.ID == "[unknown]" {
+			if parentDep := d.findParentChange(change.Parent.Ref, change.ResourceType, changes); parentDep != "" {
+				add(parentDep)
+			}
+		}
+
+		dependencies[change.ID] = deps
+	}
+	return dependencies
+}
+
 // ResolveDependencies builds dependency graph and calculates execution order
 func (d *DependencyResolver) ResolveDependencies(changes []PlannedChange) ([]string, error) {
 	// Build dependency graph
-	graph := make(map[string][]string)       // change_id -> list of dependencies
+	graph := make(map[string][]string)       // change_id -> list of dependents
 	inDegree := make(map[string]int)         // change_id -> number of incoming edges
 	allChanges := make(map[string]bool)      // set of all change IDs
 	changeDetails := make(map[string]string) // change_id -> resource details for error reporting
 
+	dependencies := d.Dependencies(changes)
+
 	// Initialize graph
 	for _, change := range changes {
 		changeID := change.ID
@@ -36,34 +69,10 @@ func (d *DependencyResolver) ResolveDependencies(changes []PlannedChange) ([]str
 			inDegree[changeID] = 0
 		}
 
-		// Add explicit dependencies
-		for _, dep := range change.DependsOn {
+		for _, dep := range dependencies[changeID] {
 			graph[dep] = append(graph[dep], changeID)
 			inDegree[changeID]++
 		}
-
-		// Add implicit dependencies based on references
-		deps := d.findImplicitDependencies(change, changes)
-		for _, dep := range deps {
-			if !contains(change.DependsOn, dep) { // Avoid duplicates
-				graph[dep] = append(graph[dep], changeID)
-				inDegree[changeID]++
-			}
-		}
-
-		// Parent dependencies
-		if change.Parent != nil && change.Parent.ID == "[unknown]" {
-			parentDep := d.findParentChange(change.Parent.Ref, change.ResourceType, changes)
-			if parentDep != "" && !contains(change.DependsOn, parentDep) {
-				graph[parentDep] = append(graph[parentDep], changeID)
-				inDegree[changeID]++
-				// Added parent dependency
-			} else if parentDep == "" && change.Parent.Ref != "" {
-				// Parent not found in changes - this might indicate a problem
-				// TODO: Consider adding a warning or error for missing parent
-				_ = parentDep // Suppress empty block warning
-			}
-		}
 	}
 
 	// Topological sort using Kahn's algorithm
@@ -124,9 +133,14 @@ func (d *DependencyResolver) findImplicitDependencies(change PlannedChange, allC
 			continue
 		}
 		if refInfo.ID == "[unknown]" {
+			// !ref values keep their __REF__ placeholder until execution binds the created ID
+			targetRef := refInfo.Ref
+			if parsedRef, _, ok := tags.ParseRefPlaceholder(targetRef); ok {
+				targetRef = parsedRef
+			}
 			// Find the change that creates this resource
 			for _, other := range allChanges {
-				if other.ResourceRef == refInfo.Ref && other.Action == ActionCreate {
+				if other.ResourceRef == targetRef && other.Action == ActionCreate {
 					dependencies = append(dependencies, other.ID)
 					break
 				}
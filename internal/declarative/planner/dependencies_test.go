@@ -81,6 +81,41 @@ func TestResolveDependencies_ImplicitReferenceDependencies(t *testing.T) {
 	}
 }
 
+func TestResolveDependencies_RefPlaceholderDependencies(t *testing.T) {
+	resolver := NewDependencyResolver()
+
+	changes := []PlannedChange{
+		{
+			ID:           "1-c-publication",
+			ResourceType: "api_publication",
+			ResourceRef:  "orders-portal-a",
+			Action:       ActionCreate,
+			References: map[string]ReferenceInfo{
+				"portal_id": {
+					Ref: "__REF__:portal-a#id",
+					ID:  "[unknown]",
+				},
+			},
+		},
+		{
+			ID:           "2-c-portal",
+			ResourceType: "portal",
+			ResourceRef:  "portal-a",
+			Action:       ActionCreate,
+		},
+	}
+
+	order, err := resolver.ResolveDependencies(changes)
+	if err != nil {
+		t.Fatalf("ResolveDependencies failed: %v", err)
+	}
+
+	// A !ref to a portal created in the same plan binds after the portal exists
+	if indexOf(order, "2-c-portal") >= indexOf(order, "1-c-publication") {
+		t.Errorf("Portal should come before publication, got %v", order)
+	}
+}
+
 func TestResolveDependencies_ParentChildRelationship(t *testing.T) {
 	resolver := NewDependencyResolver()
 
@@ -318,6 +353,54 @@ func TestResolveDependencies_DuplicateDependencies(t *testing.T) {
 	}
 }
 
+func TestDependencies(t *testing.T) {
+	resolver := NewDependencyResolver()
+
+	changes := []PlannedChange{
+		{
+			ID:           "1-c-auth",
+			ResourceType: "application_auth_strategy",
+			ResourceRef:  "basic-auth",
+			Action:       ActionCreate,
+		},
+		{
+			ID:           "2-c-api",
+			ResourceType: "api",
+			ResourceRef:  "my-api",
+			Action:       ActionCreate,
+		},
+		{
+			ID:           "3-c-portal",
+			ResourceType: "portal",
+			ResourceRef:  "dev-portal",
+			Action:       ActionCreate,
+			DependsOn:    []string{"1-c-auth"},
+			References: map[string]ReferenceInfo{
+				"default_application_auth_strategy_id": {Ref: "basic-auth", ID: "[unknown]"},
+			},
+		},
+		{
+			ID:           "4-c-version",
+			ResourceType: "api_version",
+			ResourceRef:  "my-api-v1",
+			Action:       ActionCreate,
+			Parent:       &ParentInfo{Ref: "my-api", ID: "[unknown]"},
+		},
+	}
+
+	deps := resolver.Dependencies(changes)
+
+	if len(deps["1-c-auth"]) != 0 || len(deps["2-c-api"]) != 0 {
+		t.Errorf("Expected independent changes to have no dependencies, got %v", deps)
+	}
+	if !equalSlices(deps["3-c-portal"], []string{"1-c-auth"}) {
+		t.Errorf("Expected explicit and implicit dependency once, got %v", deps["3-c-portal"])
+	}
+	if !equalSlices(deps["4-c-version"], []string{"2-c-api"}) {
+		t.Errorf("Expected parent dependency, got %v", deps["4-c-version"])
+	}
+}
+
 func TestGetParentType(t *testing.T) {
 	resolver := NewDependencyResolver()
 
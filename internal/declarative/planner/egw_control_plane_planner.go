@@ -293,6 +293,7 @@ func (p *Planner) planEGWControlPlaneProtectionChangeWithFields(
 	}
 
 	change.Fields = fields
+	change.IdentityFields = []string{"name", "id", "namespace", "preserved_labels"}
 
 	plan.AddChange(change)
 }
@@ -421,6 +422,7 @@ func (p *Planner) planEGWControlPlaneUpdateWithFields(
 		return
 	}
 	change.Protection = protection
+	change.IdentityFields = []string{"name"}
 
 	plan.AddChange(change)
 }
@@ -330,7 +330,7 @@ func (p *Planner) shouldUpdateBackendCluster(
 	}
 
 	// Compare bootstrap servers
-	if !compareStringSlices(current.BootstrapServers, desired.BootstrapServers) {
+	if !stringSetsEqual(current.BootstrapServers, desired.BootstrapServers) {
 		needsUpdate = true
 	}
 
@@ -435,18 +435,6 @@ func compareAuthenticationSchemes(
 	return false
 }
 
-func compareStringSlices(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
-	}
-	return true
-}
-
 func compareBoolPtrs(a, b *bool) bool {
 	if a == nil && b == nil {
 		return true
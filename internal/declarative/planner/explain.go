@@ -0,0 +1,173 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// ChangeOrigin tells where the difference behind a field of an UPDATE comes from
+type ChangeOrigin string
+
+const (
+	// OriginConfig is a value changed in the configuration since it was last applied,
+	// or a field kongctl has no record of applying
+	OriginConfig ChangeOrigin = "config"
+	// OriginDrift is a value changed in Konnect while the configuration kept the value
+	// last applied
+	OriginDrift ChangeOrigin = "drift"
+	// OriginDefault is a value that only differs from the live one in empty or default
+	// values or in the order of a set, which Konnect normalizes
+	OriginDefault ChangeOrigin = "default"
+)
+
+// FieldExplanation tells why a field of an UPDATE was planned
+type FieldExplanation struct {
+	Field string `json:"field"`
+	// Old is the live value, unless OldUnknown is set
+	Old any `json:"old"`
+	// New is the configured value
+	New any `json:"new"`
+	// OldUnknown is set for resources whose live state is not read to explain a plan
+	OldUnknown bool         `json:"old_unknown,omitempty"`
+	Origin     ChangeOrigin `json:"origin"`
+}
+
+// Explain records why each UPDATE of the plan was planned: the configured fields that
+// differ, their live and configured values, and whether the difference is only a
+// default Konnect fills in. The live values of top-level resources are read from
+// client; the live values of child resources are left unknown. Differences are
+// recorded as config; drift.ExplainOrigins tells drift apart using the last apply.
+func (p *Plan) Explain(ctx context.Context, client *state.Client) error {
+	live := make(map[string]map[string]map[string]any)
+	for i := range p.Changes {
+		change := &p.Changes[i]
+		if change.Action != ActionUpdate && change.Action != ActionSwitch {
+			continue
+		}
+
+		var current map[string]any
+		if _, ok := stateListers[change.ResourceType]; ok && change.ResourceID != "" && client != nil {
+			byID, err := liveResources(ctx, client, change.ResourceType, live)
+			if err != nil {
+				return err
+			}
+			current = byID[change.ResourceID]
+		}
+
+		change.Explanation = explainChange(*change, current)
+	}
+	return nil
+}
+
+// liveResources lists the managed resources of resourceType once, keyed by ID, with
+// their field names normalized by liveKey
+func liveResources(
+	ctx context.Context,
+	client *state.Client,
+	resourceType string,
+	cache map[string]map[string]map[string]any,
+) (map[string]map[string]any, error) {
+	if byID, ok := cache[resourceType]; ok {
+		return byID, nil
+	}
+
+	listed, err := stateListers[resourceType](ctx, client, []string{"*"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s state: %w", resourceType, err)
+	}
+	data, err := json.Marshal(listed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s state: %w", resourceType, err)
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to encode %s state: %w", resourceType, err)
+	}
+
+	byID := make(map[string]map[string]any, len(entries))
+	for _, entry := range entries {
+		fields := make(map[string]any, len(entry))
+		for name, value := range entry {
+			fields[liveKey(name)] = value
+		}
+		if id, ok := fields["id"].(string); ok {
+			byID[id] = fields
+		}
+	}
+	cache[resourceType] = byID
+	return byID, nil
+}
+
+// liveKey normalizes a field name, as the state of some resources is encoded with Go
+// field names (DisplayName) rather than plan field names (display_name)
+func liveKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "")
+}
+
+func explainChange(change PlannedChange, current map[string]any) []FieldExplanation {
+	names := make([]string, 0, len(change.Fields))
+	for name := range change.Fields {
+		if strings.HasPrefix(name, "_") || slices.Contains(change.IdentityFields, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	explanations := make([]FieldExplanation, 0, len(names))
+	for _, name := range names {
+		explanation := FieldExplanation{Field: name, New: change.Fields[name], Origin: OriginConfig}
+		if fc, ok := change.Fields[name].(FieldChange); ok {
+			explanation.Old, explanation.New = fc.Old, fc.New
+		} else if current != nil {
+			explanation.Old = liveValue(name, current)
+		} else {
+			explanation.OldUnknown = true
+		}
+
+		if !explanation.OldUnknown && fieldValuesEqual(name, jsonValue(explanation.New), jsonValue(explanation.Old)) {
+			explanation.Origin = OriginDefault
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+// liveValue returns the live value of a plan field, leaving out the labels kongctl
+// manages
+func liveValue(name string, current map[string]any) any {
+	if name == "labels" {
+		if normalized, ok := current[liveKey("NormalizedLabels")].(map[string]any); ok {
+			values := make(map[string]string, len(normalized))
+			for key, value := range normalized {
+				values[key] = fmt.Sprint(value)
+			}
+			if user := labels.GetUserLabels(values); user != nil {
+				return user
+			}
+			return nil
+		}
+	}
+	return current[liveKey(name)]
+}
+
+// jsonValue returns value as decoded from JSON, so typed values compare with the
+// decoded live state
+func jsonValue(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
@@ -0,0 +1,51 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainChange(t *testing.T) {
+	change := PlannedChange{
+		ResourceType: "portal",
+		Action:       ActionUpdate,
+		Fields: map[string]any{
+			"name":                   "dev",
+			"description":            "New description",
+			"authentication_enabled": false,
+			"labels":                 map[string]any{"team": "core"},
+			FieldCurrentLabels:       map[string]string{"team": "core"},
+		},
+		IdentityFields: []string{"name"},
+	}
+	current := map[string]any{
+		"id":                    "portal-1",
+		"description":           "Old description",
+		"normalizedlabels":      map[string]any{"team": "edge", "KONGCTL-namespace": "default"},
+		"authenticationenabled": nil,
+	}
+
+	assert.Equal(t, []FieldExplanation{
+		{Field: "authentication_enabled", Old: nil, New: false, Origin: OriginDefault},
+		{Field: "description", Old: "Old description", New: "New description", Origin: OriginConfig},
+		{Field: "labels", Old: map[string]string{"team": "edge"}, New: map[string]any{"team": "core"},
+			Origin: OriginConfig},
+	}, explainChange(change, current))
+}
+
+func TestExplainChange_UnknownLiveState(t *testing.T) {
+	change := PlannedChange{
+		ResourceType: "portal_page",
+		Action:       ActionUpdate,
+		Fields: map[string]any{
+			"content": "# Welcome",
+			"title":   FieldChange{Old: "Home", New: "Welcome"},
+		},
+	}
+
+	assert.Equal(t, []FieldExplanation{
+		{Field: "content", New: "# Welcome", OldUnknown: true, Origin: OriginConfig},
+		{Field: "title", Old: "Home", New: "Welcome", Origin: OriginConfig},
+	}, explainChange(change, nil))
+}
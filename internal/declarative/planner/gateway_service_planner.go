@@ -0,0 +1,286 @@
+package planner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// gatewayServiceReadOnlyFields are set by Konnect or only accepted on write, so
+// they are neither planned nor compared
+var gatewayServiceReadOnlyFields = []string{"id", "created_at", "updated_at"}
+
+// planGatewayServiceChanges plans changes for the managed gateway services of the
+// control planes in a namespace. Gateway services have tags instead of labels, so
+// kongctl marks the services it manages with a namespace tag and sync mode only
+// deletes services carrying the tag of the namespace. Services of control planes
+// configured with _deck are owned by decK and are left alone.
+func (p *Planner) planGatewayServiceChanges(
+	ctx context.Context,
+	namespace string,
+	controlPlanes []resources.ControlPlaneResource,
+	plan *Plan,
+) error {
+	for i := range controlPlanes {
+		cp := &controlPlanes[i]
+		if cp.HasDeckConfig() {
+			continue
+		}
+
+		desired := p.desiredGatewayServices(cp.GetRef())
+		cpID := cp.GetKonnectID()
+
+		if cpID == "" {
+			// Control plane is created by this plan: create all of its services after it
+			var dependsOn []string
+			if changeID := findChangeIDByRef(plan.Changes, "control_plane", cp.GetRef(), ActionCreate); changeID != "" {
+				dependsOn = []string{changeID}
+			}
+			for _, service := range desired {
+				if err := p.planGatewayServiceCreate(namespace, cp, "", service, dependsOn, plan); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if len(desired) == 0 && plan.Metadata.Mode != PlanModeSync {
+			continue
+		}
+
+		currentServices, err := p.client.ListGatewayServices(ctx, cpID)
+		if err != nil {
+			if state.IsAPIClientError(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to list gateway services for control plane %s: %w", cp.Name, err)
+		}
+
+		currentByName := make(map[string]state.GatewayService)
+		for _, current := range currentServices {
+			if ns, ok := labels.NamespaceFromTags(current.Service.Tags); ok && ns == namespace {
+				currentByName[current.Name] = current
+			}
+		}
+
+		desiredNames := make(map[string]bool, len(desired))
+		for _, service := range desired {
+			name := service.Service.Name
+			if name == nil {
+				continue
+			}
+			desiredNames[*name] = true
+
+			current, exists := currentByName[*name]
+			if !exists {
+				if err := p.planGatewayServiceCreate(namespace, cp, cpID, service, nil, plan); err != nil {
+					return err
+				}
+				continue
+			}
+
+			needsUpdate, err := shouldUpdateGatewayService(current, service)
+			if err != nil {
+				return err
+			}
+			if needsUpdate {
+				if err := p.planGatewayServiceUpdate(namespace, cp, cpID, current.ID, service, plan); err != nil {
+					return err
+				}
+			}
+		}
+
+		if plan.Metadata.Mode == PlanModeSync {
+			names := make([]string, 0, len(currentByName))
+			for name := range currentByName {
+				if !desiredNames[name] {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				p.planGatewayServiceDelete(namespace, cp, cpID, currentByName[name], plan)
+			}
+		}
+	}
+
+	return nil
+}
+
+// desiredGatewayServices returns the managed gateway services of a control plane
+func (p *Planner) desiredGatewayServices(cpRef string) []resources.GatewayServiceResource {
+	var desired []resources.GatewayServiceResource
+	for _, service := range p.resources.GatewayServices {
+		if service.IsExternal() || service.Service == nil {
+			continue
+		}
+		if normalizeControlPlaneRef(service.ControlPlane) == cpRef {
+			desired = append(desired, service)
+		}
+	}
+	return desired
+}
+
+func (p *Planner) planGatewayServiceCreate(
+	namespace string,
+	cp *resources.ControlPlaneResource,
+	cpID string,
+	service resources.GatewayServiceResource,
+	dependsOn []string,
+	plan *Plan,
+) error {
+	fields, err := gatewayServiceFields(*service.Service)
+	if err != nil {
+		return fmt.Errorf("gateway_service %s: %w", service.GetRef(), err)
+	}
+
+	change := PlannedChange{
+		ID:           p.nextChangeID(ActionCreate, ResourceTypeGatewayService, service.GetRef()),
+		ResourceType: ResourceTypeGatewayService,
+		ResourceRef:  service.GetRef(),
+		Parent:       &ParentInfo{Ref: cp.GetRef(), ID: cpID},
+		Action:       ActionCreate,
+		Fields:       fields,
+		References: map[string]ReferenceInfo{
+			"control_plane_id": {
+				Ref: cp.GetRef(),
+				ID:  cpID, // Empty when the control plane is created by this plan
+				LookupFields: map[string]string{
+					"name": cp.Name,
+				},
+			},
+		},
+		DependsOn: dependsOn,
+		Namespace: namespace,
+	}
+
+	p.logger.Debug("Enqueuing gateway service CREATE",
+		slog.String("service_ref", service.GetRef()),
+		slog.String("control_plane_ref", cp.GetRef()),
+	)
+	plan.AddChange(change)
+	return nil
+}
+
+func (p *Planner) planGatewayServiceUpdate(
+	namespace string,
+	cp *resources.ControlPlaneResource,
+	cpID string,
+	serviceID string,
+	service resources.GatewayServiceResource,
+	plan *Plan,
+) error {
+	// Updates replace the whole service, so they carry every configured field
+	fields, err := gatewayServiceFields(*service.Service)
+	if err != nil {
+		return fmt.Errorf("gateway_service %s: %w", service.GetRef(), err)
+	}
+
+	change := PlannedChange{
+		ID:           p.nextChangeID(ActionUpdate, ResourceTypeGatewayService, service.GetRef()),
+		ResourceType: ResourceTypeGatewayService,
+		ResourceRef:  service.GetRef(),
+		ResourceID:   serviceID,
+		Parent:       &ParentInfo{Ref: cp.GetRef(), ID: cpID},
+		Action:       ActionUpdate,
+		Fields:       fields,
+		Namespace:    namespace,
+	}
+
+	p.logger.Debug("Enqueuing gateway service UPDATE",
+		slog.String("service_ref", service.GetRef()),
+		slog.String("service_id", serviceID),
+		slog.String("control_plane_ref", cp.GetRef()),
+	)
+	plan.AddChange(change)
+	return nil
+}
+
+func (p *Planner) planGatewayServiceDelete(
+	namespace string,
+	cp *resources.ControlPlaneResource,
+	cpID string,
+	current state.GatewayService,
+	plan *Plan,
+) {
+	change := PlannedChange{
+		ID:           p.nextChangeID(ActionDelete, ResourceTypeGatewayService, current.Name),
+		ResourceType: ResourceTypeGatewayService,
+		ResourceRef:  current.Name,
+		ResourceID:   current.ID,
+		Parent:       &ParentInfo{Ref: cp.GetRef(), ID: cpID},
+		Action:       ActionDelete,
+		Fields:       map[string]any{"name": current.Name},
+		Namespace:    namespace,
+	}
+
+	p.logger.Debug("Enqueuing gateway service DELETE",
+		slog.String("service_name", current.Name),
+		slog.String("service_id", current.ID),
+		slog.String("control_plane_ref", cp.GetRef()),
+	)
+	plan.AddChange(change)
+}
+
+// gatewayServiceFields converts a service into plan fields keyed by JSON name,
+// leaving out unset and read-only fields. The namespace tag is added when the
+// change is executed, as labels are for other resources.
+func gatewayServiceFields(service any) (map[string]any, error) {
+	data, err := json.Marshal(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode gateway service: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode gateway service: %w", err)
+	}
+
+	for key, value := range fields {
+		if value == nil {
+			delete(fields, key)
+		}
+	}
+	for _, key := range gatewayServiceReadOnlyFields {
+		delete(fields, key)
+	}
+	if tags, ok := fields["tags"].([]any); ok {
+		userTags := make([]any, 0, len(tags))
+		for _, tag := range tags {
+			if s, ok := tag.(string); !ok || !labels.IsKongctlLabel(s) {
+				userTags = append(userTags, tag)
+			}
+		}
+		fields["tags"] = userTags
+	}
+	return fields, nil
+}
+
+// shouldUpdateGatewayService reports whether a configured field differs from the
+// current service. URL is write-only: Konnect stores it as protocol, host, port
+// and path, so it is not compared.
+func shouldUpdateGatewayService(current state.GatewayService, desired resources.GatewayServiceResource) (bool, error) {
+	desiredFields, err := gatewayServiceFields(*desired.Service)
+	if err != nil {
+		return false, fmt.Errorf("gateway_service %s: %w", desired.GetRef(), err)
+	}
+	currentFields, err := gatewayServiceFields(current.Service)
+	if err != nil {
+		return false, fmt.Errorf("gateway_service %s: %w", desired.GetRef(), err)
+	}
+
+	for key, value := range desiredFields {
+		if key == "url" {
+			continue
+		}
+		if !fieldValuesEqual(key, value, currentFields[key]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
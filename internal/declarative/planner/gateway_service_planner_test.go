@@ -0,0 +1,154 @@
+package planner
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newGatewayServicePlanner(
+	t *testing.T,
+	currentCPs []kkComps.ControlPlane,
+	currentServices []kkComps.ServiceOutput,
+	rs *resources.ResourceSet,
+) ControlPlanePlanner {
+	t.Helper()
+
+	mockAPI := helpers.NewMockControlPlaneAPI(t)
+	mockAPI.EXPECT().
+		ListControlPlanes(mock.Anything, mock.Anything).
+		Return(newListControlPlaneResponse(currentCPs, float64(len(currentCPs))), nil).
+		Once()
+
+	planner := &Planner{
+		client: state.NewClient(state.ClientConfig{
+			ControlPlaneAPI:   mockAPI,
+			GatewayServiceAPI: &stubGatewayServiceAPI{services: currentServices},
+		}),
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		resources: rs,
+	}
+	planner.genericPlanner = NewGenericPlanner(planner)
+	return NewControlPlanePlanner(NewBasePlanner(planner))
+}
+
+func gatewayServiceResource(ref, cpRef, host string, port int64) resources.GatewayServiceResource {
+	return resources.GatewayServiceResource{
+		Ref:          ref,
+		ControlPlane: cpRef,
+		Service:      &kkComps.Service{Name: strPtr(ref), Host: host, Port: &port},
+	}
+}
+
+func TestGatewayServicePlanner_CreatesServicesOfNewControlPlane(t *testing.T) {
+	rs := &resources.ResourceSet{
+		ControlPlanes: []resources.ControlPlaneResource{
+			{
+				CreateControlPlaneRequest: kkComps.CreateControlPlaneRequest{Name: "cp"},
+				BaseResource: resources.BaseResource{
+					Ref:     "cp",
+					Kongctl: &resources.KongctlMeta{Namespace: strPtr("team-a")},
+				},
+			},
+		},
+		GatewayServices: []resources.GatewayServiceResource{
+			gatewayServiceResource("orders", "cp", "orders.internal", 8080),
+		},
+	}
+
+	cpPlanner := newGatewayServicePlanner(t, nil, nil, rs)
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	require.NoError(t, cpPlanner.PlanChanges(context.Background(), NewConfig("team-a"), plan))
+	require.Len(t, plan.Changes, 2)
+
+	cpChange, serviceChange := plan.Changes[0], plan.Changes[1]
+	assert.Equal(t, "control_plane", cpChange.ResourceType)
+	assert.Equal(t, ResourceTypeGatewayService, serviceChange.ResourceType)
+	assert.Equal(t, ActionCreate, serviceChange.Action)
+	assert.Equal(t, "team-a", serviceChange.Namespace)
+	assert.Equal(t, []string{cpChange.ID}, serviceChange.DependsOn)
+	assert.Equal(t, "orders.internal", serviceChange.Fields["host"])
+	assert.EqualValues(t, 8080, serviceChange.Fields["port"])
+
+	cpRef := serviceChange.References["control_plane_id"]
+	assert.Equal(t, "cp", cpRef.Ref)
+	assert.Empty(t, cpRef.ID)
+	assert.Equal(t, "cp", cpRef.LookupFields["name"])
+}
+
+func TestGatewayServicePlanner_ReconcilesServicesOfExistingControlPlane(t *testing.T) {
+	namespaceTag := labels.NamespaceTag("team-a")
+	currentCP := kkComps.ControlPlane{
+		ID:     "cp-1",
+		Name:   "cp",
+		Labels: map[string]string{labels.NamespaceKey: "team-a"},
+		Config: kkComps.ControlPlaneConfig{ClusterType: kkComps.ControlPlaneClusterTypeClusterTypeControlPlane},
+	}
+	currentServices := []kkComps.ServiceOutput{
+		{ID: strPtr("svc-orders"), Name: strPtr("orders"), Host: "orders.internal", Port: int64Ptr(80),
+			Tags: []string{namespaceTag}},
+		{ID: strPtr("svc-users"), Name: strPtr("users"), Host: "users.internal", Port: int64Ptr(80),
+			Tags: []string{"team", namespaceTag}},
+		{ID: strPtr("svc-legacy"), Name: strPtr("legacy"), Host: "legacy.internal", Tags: []string{namespaceTag}},
+		{ID: strPtr("svc-manual"), Name: strPtr("manual"), Host: "manual.internal"},
+		{ID: strPtr("svc-other"), Name: strPtr("other"), Host: "other.internal",
+			Tags: []string{labels.NamespaceTag("team-b")}},
+	}
+
+	cp := resources.ControlPlaneResource{
+		CreateControlPlaneRequest: kkComps.CreateControlPlaneRequest{Name: "cp"},
+		BaseResource: resources.BaseResource{
+			Ref:     "cp",
+			Kongctl: &resources.KongctlMeta{Namespace: strPtr("team-a")},
+		},
+	}
+	cp.SetKonnectID("cp-1")
+
+	users := gatewayServiceResource("users", "__REF__:cp#id", "users.internal", 80)
+	users.Service.Tags = []string{"team"}
+	rs := &resources.ResourceSet{
+		ControlPlanes: []resources.ControlPlaneResource{cp},
+		GatewayServices: []resources.GatewayServiceResource{
+			gatewayServiceResource("orders", "cp", "orders.internal", 8080),
+			users,
+			gatewayServiceResource("payments", "cp", "payments.internal", 80),
+		},
+	}
+
+	cpPlanner := newGatewayServicePlanner(t, []kkComps.ControlPlane{currentCP}, currentServices, rs)
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	require.NoError(t, cpPlanner.PlanChanges(context.Background(), NewConfig("team-a"), plan))
+
+	actions := make(map[string]PlannedChange)
+	for _, change := range plan.Changes {
+		require.Equal(t, ResourceTypeGatewayService, change.ResourceType)
+		actions[change.ResourceRef] = change
+	}
+	require.Len(t, actions, 3)
+
+	assert.Equal(t, ActionUpdate, actions["orders"].Action)
+	assert.Equal(t, "svc-orders", actions["orders"].ResourceID)
+	assert.EqualValues(t, 8080, actions["orders"].Fields["port"])
+	assert.Equal(t, "cp-1", actions["orders"].Parent.ID)
+
+	assert.Equal(t, ActionCreate, actions["payments"].Action)
+	assert.Equal(t, "cp-1", actions["payments"].References["control_plane_id"].ID)
+
+	assert.Equal(t, ActionDelete, actions["legacy"].Action)
+	assert.Equal(t, "svc-legacy", actions["legacy"].ResourceID)
+	assert.Equal(t, "team-a", actions["legacy"].Namespace)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
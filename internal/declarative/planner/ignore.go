@@ -0,0 +1,91 @@
+package planner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// ignoreAnyRef matches every resource of a type in an ignored resource
+const ignoreAnyRef = "*"
+
+// IgnoredResource identifies resources hidden from plan output, written as "type:ref".
+// A ref of "*" matches every resource of the type.
+type IgnoredResource struct {
+	ResourceType string
+	ResourceRef  string
+}
+
+// ParseIgnoredResource parses a "type:ref" ignored resource
+func ParseIgnoredResource(value string) (IgnoredResource, error) {
+	resourceType, ref, ok := strings.Cut(strings.TrimSpace(value), ":")
+	resourceType = strings.TrimSpace(resourceType)
+	ref = strings.TrimSpace(ref)
+	if !ok || resourceType == "" || ref == "" {
+		return IgnoredResource{}, fmt.Errorf("invalid ignored resource %q, expected type:ref", value)
+	}
+	if resourceType != ResourceTypeDeck && !resources.IsRegistered(resources.ResourceType(resourceType)) {
+		return IgnoredResource{}, fmt.Errorf("invalid ignored resource %q: unknown resource type %q", value, resourceType)
+	}
+	return IgnoredResource{ResourceType: resourceType, ResourceRef: ref}, nil
+}
+
+// String returns the "type:ref" form of the ignored resource
+func (r IgnoredResource) String() string {
+	return r.ResourceType + ":" + r.ResourceRef
+}
+
+// Matches reports whether change is for the ignored resource
+func (r IgnoredResource) Matches(change PlannedChange) bool {
+	if change.ResourceType != r.ResourceType {
+		return false
+	}
+	return r.ResourceRef == ignoreAnyRef || change.ResourceRef == r.ResourceRef
+}
+
+// WithoutResources returns a copy of plan without the changes of the ignored resources,
+// along with their execution order entries and warnings. The summary is recalculated
+// from the remaining changes unless summarize is set, in which case it keeps counting
+// the ignored changes and records how many were hidden. The risk summary always covers
+// the whole plan. The returned plan is for display only.
+func (p *Plan) WithoutResources(ignored []IgnoredResource, summarize bool) *Plan {
+	if p == nil || len(ignored) == 0 {
+		return p
+	}
+
+	filtered := *p
+	filtered.Changes = make([]PlannedChange, 0, len(p.Changes))
+	hidden := make(map[string]bool)
+	for _, change := range p.Changes {
+		if slices.ContainsFunc(ignored, func(r IgnoredResource) bool { return r.Matches(change) }) {
+			hidden[change.ID] = true
+			continue
+		}
+		filtered.Changes = append(filtered.Changes, change)
+	}
+	if len(hidden) == 0 {
+		return p
+	}
+
+	filtered.ExecutionOrder = make([]string, 0, len(p.ExecutionOrder))
+	for _, id := range p.ExecutionOrder {
+		if !hidden[id] {
+			filtered.ExecutionOrder = append(filtered.ExecutionOrder, id)
+		}
+	}
+	filtered.Warnings = nil
+	for _, warning := range p.Warnings {
+		if !hidden[warning.ChangeID] {
+			filtered.Warnings = append(filtered.Warnings, warning)
+		}
+	}
+
+	if summarize {
+		filtered.Summary.IgnoredChanges = len(hidden)
+	} else {
+		filtered.UpdateSummary()
+	}
+	return &filtered
+}
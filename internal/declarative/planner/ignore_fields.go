@@ -0,0 +1,241 @@
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// ignoreAnySegment matches any single segment of an ignored field
+const ignoreAnySegment = "*"
+
+// IgnoredField is a field whose differences alone never update a resource, written as
+// "type.ref.path" (e.g. "apis.*.created_at"). The type is a resource type or its
+// configuration key, and "*" matches any type, ref or single path segment.
+type IgnoredField struct {
+	ResourceType string
+	ResourceRef  string
+	Path         []string
+}
+
+// ParseIgnoredField parses a "type.ref.path" ignored field
+func ParseIgnoredField(value string) (IgnoredField, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), ".", 3)
+	if len(parts) < 3 {
+		return IgnoredField{}, fmt.Errorf("invalid ignored field %q, expected type.ref.path", value)
+	}
+	path, err := parseFieldPath(parts[2])
+	if err != nil || parts[0] == "" || parts[1] == "" {
+		return IgnoredField{}, fmt.Errorf("invalid ignored field %q, expected type.ref.path", value)
+	}
+	resourceType, ok := ignoredFieldType(parts[0])
+	if !ok {
+		return IgnoredField{}, fmt.Errorf("invalid ignored field %q: unknown resource type %q", value, parts[0])
+	}
+	return IgnoredField{ResourceType: resourceType, ResourceRef: parts[1], Path: path}, nil
+}
+
+// String returns the "type.ref.path" form of the ignored field
+func (f IgnoredField) String() string {
+	return f.ResourceType + "." + f.ResourceRef + "." + strings.Join(f.Path, ".")
+}
+
+// appliesTo reports whether the ignored field is for the resource of change
+func (f IgnoredField) appliesTo(change PlannedChange) bool {
+	return (f.ResourceType == ignoreAnySegment || f.ResourceType == change.ResourceType) &&
+		(f.ResourceRef == ignoreAnySegment || f.ResourceRef == change.ResourceRef)
+}
+
+// ignoredFieldType resolves the resource type of an ignored field, accepting the plural
+// configuration keys such as "apis" or "application_auth_strategies"
+func ignoredFieldType(name string) (string, bool) {
+	candidates := []string{name}
+	if singular, ok := strings.CutSuffix(name, "ies"); ok {
+		candidates = append(candidates, singular+"y")
+	}
+	if singular, ok := strings.CutSuffix(name, "s"); ok {
+		candidates = append(candidates, singular)
+	}
+	for _, candidate := range candidates {
+		if candidate == ignoreAnySegment || resources.IsRegistered(resources.ResourceType(candidate)) {
+			return candidate, true
+		}
+		if _, ok := custom.Lookup(candidate); ok {
+			return candidate, true
+		}
+	}
+	// Teams are configured under the organization
+	if name == "teams" {
+		return string(resources.ResourceTypeOrganizationTeam), true
+	}
+	return "", false
+}
+
+func parseFieldPath(value string) ([]string, error) {
+	segments := strings.Split(strings.TrimSpace(value), ".")
+	for _, segment := range segments {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("invalid field path %q", value)
+		}
+	}
+	return segments, nil
+}
+
+// dropIgnoredUpdates removes the UPDATE changes whose configured fields are all ignored,
+// by the given ignored fields or the ignore_fields of the resource, and that do not
+// change protection. Updates that still change another field are kept unchanged, so
+// every configured value is sent when the resource is updated anyway.
+func (p *Planner) dropIgnoredUpdates(plan *Plan, rs *resources.ResourceSet, ignored []IgnoredField) error {
+	perResource, err := resourceIgnoredFields(rs)
+	if err != nil {
+		return err
+	}
+	if len(ignored) == 0 && len(perResource) == 0 {
+		return nil
+	}
+
+	filterChanges(plan, func(change PlannedChange) bool {
+		if change.Action != ActionUpdate || changesProtection(change.Protection) {
+			return true
+		}
+		var paths [][]string
+		for _, field := range ignored {
+			if field.appliesTo(change) {
+				paths = append(paths, field.Path)
+			}
+		}
+		paths = append(paths, perResource[change.ResourceType+":"+change.ResourceRef]...)
+		if len(paths) == 0 || !onlyIgnoredFields(change, paths) {
+			return true
+		}
+		p.logger.Info("Skipping update that only changes ignored fields",
+			"change_id", change.ID, "resource_type", change.ResourceType, "resource_ref", change.ResourceRef)
+		return false
+	})
+	return nil
+}
+
+// resourceIgnoredFields returns the paths of the ignore_fields of the resources, keyed by
+// "type:ref"
+func resourceIgnoredFields(rs *resources.ResourceSet) (map[string][][]string, error) {
+	if rs == nil {
+		return nil, nil
+	}
+	perResource := make(map[string][][]string)
+	var err error
+	rs.ForEachResource(func(resource resources.Resource) bool {
+		holder, ok := resource.(interface{ GetKongctlMeta() *resources.KongctlMeta })
+		if !ok || holder.GetKongctlMeta() == nil {
+			return true
+		}
+		key := string(resource.GetType()) + ":" + resource.GetRef()
+		for _, value := range holder.GetKongctlMeta().IgnoreFields {
+			path, parseErr := parseFieldPath(value)
+			if parseErr != nil {
+				err = fmt.Errorf("invalid kongctl.ignore_fields of %s %q: %w", resource.GetType(), resource.GetRef(),
+					parseErr)
+				return false
+			}
+			perResource[key] = append(perResource[key], path)
+		}
+		return true
+	})
+	return perResource, err
+}
+
+// onlyIgnoredFields reports whether every configured field of change is ignored.
+// Internal fields and fields that only identify the resource are not compared.
+func onlyIgnoredFields(change PlannedChange, paths [][]string) bool {
+	configured := 0
+	for name, value := range change.Fields {
+		if strings.HasPrefix(name, "_") || containsString(change.IdentityFields, name) {
+			continue
+		}
+		configured++
+		if !ignoredValue([]string{name}, normalizeIgnoredValue(value), paths) {
+			return false
+		}
+	}
+	return configured > 0
+}
+
+// ignoredValue reports whether the value at path is ignored: its path is under an
+// ignored path, or it is an object whose every field is ignored. List indexes are
+// not part of a path.
+func ignoredValue(path []string, value any, paths [][]string) bool {
+	for _, ignored := range paths {
+		if matchesPathPrefix(path, ignored) {
+			return true
+		}
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			return false
+		}
+		for key, item := range v {
+			if !ignoredValue(append(append([]string(nil), path...), key), item, paths) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		if len(v) == 0 {
+			return false
+		}
+		for _, item := range v {
+			if !ignoredValue(path, item, paths) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// matchesPathPrefix reports whether path is at or under the ignored path
+func matchesPathPrefix(path, ignored []string) bool {
+	if len(ignored) > len(path) {
+		return false
+	}
+	for i, segment := range ignored {
+		if segment != ignoreAnySegment && segment != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeIgnoredValue converts typed values to their JSON representation so nested
+// fields can be walked
+func normalizeIgnoredValue(value any) any {
+	switch value.(type) {
+	case map[string]any, []any, string, bool, float64, nil:
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return value
+	}
+	return out
+}
+
+// changesProtection reports whether an UPDATE protection value changes protection
+func changesProtection(protection any) bool {
+	switch p := protection.(type) {
+	case ProtectionChange:
+		return p.Old != p.New
+	case map[string]any:
+		return p["old"] != p["new"]
+	default:
+		return false
+	}
+}
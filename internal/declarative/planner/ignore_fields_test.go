@@ -0,0 +1,123 @@
+package planner
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnoredField(t *testing.T) {
+	t.Parallel()
+
+	field, err := ParseIgnoredField(" apis.*.created_at ")
+	require.NoError(t, err)
+	require.Equal(t, IgnoredField{ResourceType: "api", ResourceRef: "*", Path: []string{"created_at"}}, field)
+	require.Equal(t, "api.*.created_at", field.String())
+
+	field, err = ParseIgnoredField("application_auth_strategies.oidc.configs.*.issuer")
+	require.NoError(t, err)
+	require.Equal(t, "application_auth_strategy", field.ResourceType)
+	require.Equal(t, []string{"configs", "*", "issuer"}, field.Path)
+
+	field, err = ParseIgnoredField("*.*.updated_at")
+	require.NoError(t, err)
+	require.Equal(t, "*", field.ResourceType)
+
+	for _, value := range []string{"apis", "apis.*", "apis..created_at", "apis.*.labels..env", ".*.created_at"} {
+		_, err = ParseIgnoredField(value)
+		require.ErrorContains(t, err, "expected type.ref.path", value)
+	}
+	_, err = ParseIgnoredField("widgets.*.created_at")
+	require.ErrorContains(t, err, `unknown resource type "widgets"`)
+}
+
+func TestDropIgnoredUpdates(t *testing.T) {
+	t.Parallel()
+
+	newPlan := func() *Plan {
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		plan.AddChange(PlannedChange{
+			ID: "1:u:api:orders", ResourceType: "api", ResourceRef: "orders", Action: ActionUpdate,
+			Fields: map[string]any{"created_at": "2026-01-01", FieldCurrentLabels: map[string]string{}},
+		})
+		plan.AddChange(PlannedChange{
+			ID: "2:u:api:billing", ResourceType: "api", ResourceRef: "billing", Action: ActionUpdate,
+			Fields: map[string]any{"created_at": "2026-01-01", "description": "Billing"},
+		})
+		plan.AddChange(PlannedChange{
+			ID: "3:u:portal:dev", ResourceType: "portal", ResourceRef: "dev", Action: ActionUpdate,
+			Fields:         map[string]any{"name": "dev", "settings": map[string]any{"theme": map[string]any{"mode": "dark"}}},
+			IdentityFields: []string{"name"},
+			DependsOn:      []string{"1:u:api:orders"},
+		})
+		plan.AddChange(PlannedChange{
+			ID: "4:c:api:new", ResourceType: "api", ResourceRef: "new", Action: ActionCreate,
+			Fields: map[string]any{"created_at": "2026-01-01"},
+		})
+		return plan
+	}
+	planner := &Planner{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	ids := func(plan *Plan) []string {
+		var ids []string
+		for _, change := range plan.Changes {
+			ids = append(ids, change.ID)
+		}
+		return ids
+	}
+
+	t.Run("drops updates of only ignored fields", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		ignored := []IgnoredField{
+			{ResourceType: "api", ResourceRef: "*", Path: []string{"created_at"}},
+			{ResourceType: "*", ResourceRef: "dev", Path: []string{"settings", "*", "mode"}},
+		}
+		require.NoError(t, planner.dropIgnoredUpdates(plan, nil, ignored))
+
+		require.Equal(t, []string{"2:u:api:billing", "4:c:api:new"}, ids(plan))
+		require.Equal(t, "2026-01-01", plan.Changes[0].Fields["created_at"],
+			"updates that change other fields are kept unchanged")
+	})
+
+	t.Run("nested paths must cover the whole field", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		ignored := []IgnoredField{{ResourceType: "portal", ResourceRef: "dev", Path: []string{"settings", "layout"}}}
+		require.NoError(t, planner.dropIgnoredUpdates(plan, nil, ignored))
+		require.Len(t, plan.Changes, 4)
+	})
+
+	t.Run("resource ignore_fields", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		rs := &resources.ResourceSet{APIs: []resources.APIResource{{
+			BaseResource: resources.BaseResource{
+				Ref:     "orders",
+				Kongctl: &resources.KongctlMeta{IgnoreFields: []string{"created_at"}},
+			},
+		}}}
+		require.NoError(t, planner.dropIgnoredUpdates(plan, rs, nil))
+		require.Equal(t, []string{"2:u:api:billing", "3:u:portal:dev", "4:c:api:new"}, ids(plan))
+		require.Empty(t, plan.Changes[1].DependsOn, "dependencies on dropped updates are removed")
+
+		rs.APIs[0].Kongctl.IgnoreFields = []string{"labels..env"}
+		require.ErrorContains(t, planner.dropIgnoredUpdates(newPlan(), rs, nil),
+			`invalid kongctl.ignore_fields of api "orders"`)
+	})
+
+	t.Run("protection changes are kept", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		plan.Changes[0].Protection = ProtectionChange{Old: false, New: true}
+		ignored := []IgnoredField{{ResourceType: "*", ResourceRef: "*", Path: []string{"created_at"}}}
+		require.NoError(t, planner.dropIgnoredUpdates(plan, nil, ignored))
+		require.Equal(t, []string{"1:u:api:orders", "2:u:api:billing", "3:u:portal:dev", "4:c:api:new"}, ids(plan))
+	})
+}
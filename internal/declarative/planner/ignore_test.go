@@ -0,0 +1,78 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIgnoredResource(t *testing.T) {
+	t.Parallel()
+
+	ignored, err := ParseIgnoredResource(" api:orders ")
+	require.NoError(t, err)
+	require.Equal(t, IgnoredResource{ResourceType: "api", ResourceRef: "orders"}, ignored)
+	require.Equal(t, "api:orders", ignored.String())
+
+	ignored, err = ParseIgnoredResource("_deck:*")
+	require.NoError(t, err)
+	require.Equal(t, ResourceTypeDeck, ignored.ResourceType)
+
+	for _, value := range []string{"api", "api:", ":orders"} {
+		_, err = ParseIgnoredResource(value)
+		require.ErrorContains(t, err, "expected type:ref", value)
+	}
+	_, err = ParseIgnoredResource("widget:orders")
+	require.ErrorContains(t, err, `unknown resource type "widget"`)
+}
+
+func TestPlanWithoutResources(t *testing.T) {
+	t.Parallel()
+
+	newPlan := func() *Plan {
+		plan := newRiskTestPlan()
+		plan.SetExecutionOrder([]string{"1:c:api:orders", "2:u:portal:dev", "3:d:api:legacy"})
+		plan.AddWarning("3:d:api:legacy", "api has publications")
+		plan.AddWarning("2:u:portal:dev", "authentication disabled")
+		return plan
+	}
+
+	t.Run("hides ignored changes and recounts", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		filtered := plan.WithoutResources([]IgnoredResource{{ResourceType: "api", ResourceRef: "legacy"}}, false)
+
+		require.Len(t, filtered.Changes, 2)
+		require.Equal(t, []string{"1:c:api:orders", "2:u:portal:dev"}, filtered.ExecutionOrder)
+		require.Equal(t, []PlanWarning{{ChangeID: "2:u:portal:dev", Message: "authentication disabled"}},
+			filtered.Warnings)
+		require.Equal(t, 2, filtered.Summary.TotalChanges)
+		require.Zero(t, filtered.Summary.ByAction[ActionDelete])
+		require.Zero(t, filtered.Summary.IgnoredChanges)
+
+		require.Len(t, plan.Changes, 3, "the original plan is unchanged")
+		require.Equal(t, 1, plan.Summary.ByAction[ActionDelete])
+		require.Len(t, plan.Warnings, 2)
+	})
+
+	t.Run("summarize keeps ignored changes counted", func(t *testing.T) {
+		t.Parallel()
+
+		filtered := newPlan().WithoutResources([]IgnoredResource{{ResourceType: "api", ResourceRef: "*"}}, true)
+
+		require.Len(t, filtered.Changes, 1)
+		require.Equal(t, ResourceTypePortal, filtered.Changes[0].ResourceType)
+		require.Equal(t, 3, filtered.Summary.TotalChanges)
+		require.Equal(t, 1, filtered.Summary.ByAction[ActionDelete])
+		require.Equal(t, 2, filtered.Summary.IgnoredChanges)
+	})
+
+	t.Run("no matches returns the plan", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newPlan()
+		require.Same(t, plan, plan.WithoutResources([]IgnoredResource{{ResourceType: "api", ResourceRef: "search"}}, true))
+		require.Same(t, plan, plan.WithoutResources(nil, false))
+	})
+}
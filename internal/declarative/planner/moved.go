@@ -0,0 +1,135 @@
+package planner
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// resourceMoves tracks the moved entries of a configuration and the resources the
+// plan moved. A moved resource is found by the ID last applied under its former ref,
+// or else by the name of the entry, and planned as an update of that resource.
+type resourceMoves struct {
+	// byTarget holds the moved entries by resource type and new ref
+	byTarget  map[string]resources.MovedResource
+	appliedID func(resourceType, ref string) string
+
+	mu    sync.Mutex
+	moved map[string]string // resource type and new ref -> moved Konnect ID
+}
+
+func newResourceMoves(rs *resources.ResourceSet, appliedID func(resourceType, ref string) string) *resourceMoves {
+	m := &resourceMoves{byTarget: make(map[string]resources.MovedResource), appliedID: appliedID,
+		moved: make(map[string]string)}
+	for _, moved := range rs.Moved {
+		if resourceType, ok := rs.GetResourceTypeByRef(moved.To); ok {
+			m.byTarget[moveKey(string(resourceType), moved.To)] = moved
+		}
+	}
+	return m
+}
+
+func moveKey(resourceType, ref string) string {
+	return resourceType + ":" + ref
+}
+
+// movable is a desired resource a moved entry can target
+type movable interface {
+	GetRef() string
+	GetMoniker() string
+}
+
+// moveResources re-keys the current resources that desired resources were moved from
+// under the names of the desired resources, so they are updated, renamed when their
+// names differ, instead of created again while the former resources are deleted. The
+// Konnect ID of each moved resource is set on its desired resource, for the resources
+// referencing it. A move whose resource already has the desired name was applied.
+func moveResources[D movable, T any](
+	p *Planner,
+	plan *Plan,
+	resourceType string,
+	desired []D,
+	current map[string]T,
+	identity func(T) (name, id string),
+) error {
+	if p.moves == nil || len(p.moves.byTarget) == 0 {
+		return nil
+	}
+	for _, resource := range desired {
+		moved, ok := p.moves.byTarget[moveKey(resourceType, resource.GetRef())]
+		name := resource.GetMoniker()
+		if !ok || name == "" {
+			continue
+		}
+		if _, exists := current[name]; exists {
+			continue
+		}
+
+		id := ""
+		if p.moves.appliedID != nil {
+			id = p.moves.appliedID(resourceType, moved.From)
+		}
+		if id == "" && moved.Name == "" {
+			return fmt.Errorf("cannot move %s %q to %q: %q was not applied from this machine, "+
+				"set the name it has in Konnect in the moved entry", resourceType, moved.From, moved.To, moved.From)
+		}
+
+		oldName, found := "", false
+		for currentName, candidate := range current {
+			if _, candidateID := identity(candidate); id != "" && candidateID == id {
+				oldName, found = currentName, true
+				break
+			}
+		}
+		if !found && moved.Name != "" {
+			_, found = current[moved.Name]
+			oldName = moved.Name
+		}
+		if !found {
+			plan.AddWarning("", fmt.Sprintf("%s %q moved to %q was not found in Konnect; it is created",
+				resourceType, moved.From, moved.To))
+			continue
+		}
+
+		target := current[oldName]
+		delete(current, oldName)
+		current[name] = target
+		_, targetID := identity(target)
+		p.moves.record(resourceType, moved.To, targetID)
+		if settable, ok := resourceByRef(p.resources, moved.To).(interface{ SetKonnectID(string) }); ok {
+			settable.SetKonnectID(targetID)
+		}
+	}
+	return nil
+}
+
+func resourceByRef(rs *resources.ResourceSet, ref string) resources.Resource {
+	if rs == nil {
+		return nil
+	}
+	resource, _ := rs.GetResourceByRef(ref)
+	return resource
+}
+
+func (m *resourceMoves) record(resourceType, ref, id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.moved[moveKey(resourceType, ref)] = id
+}
+
+// mark sets the former ref on the updates of the moved resources, so the applied
+// state recorded under it follows the resource
+func (m *resourceMoves) mark(plan *Plan) {
+	if m == nil || len(m.moved) == 0 {
+		return
+	}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		id, ok := m.moved[moveKey(change.ResourceType, change.ResourceRef)]
+		if !ok || change.Action != ActionUpdate || change.Parent != nil || change.ResourceID != id {
+			continue
+		}
+		change.MovedFrom = m.byTarget[moveKey(change.ResourceType, change.ResourceRef)].From
+	}
+}
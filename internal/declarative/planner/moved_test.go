@@ -0,0 +1,115 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePlan_MovedPortalIsRenamedInPlace(t *testing.T) {
+	managed := map[string]string{labels.NamespaceKey: "default"}
+	planPortals := func(rs *resources.ResourceSet, appliedID func(string, string) string, names ...string) (
+		*Plan, error,
+	) {
+		var portals []kkComps.ListPortalsResponsePortal
+		for i, name := range names {
+			portals = append(portals, newListPortal(fmt.Sprintf("portal-%d", i+1), name, managed))
+		}
+		planner := &Planner{
+			client:    state.NewClient(state.ClientConfig{PortalAPI: &stubListPortalsAPI{portals: portals}}),
+			logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+			resources: rs,
+			moves:     newResourceMoves(rs, appliedID),
+		}
+		planner.genericPlanner = NewGenericPlanner(planner)
+
+		plan := NewPlan("1.0", "test", PlanModeSync)
+		if err := NewPortalPlanner(NewBasePlanner(planner)).PlanChanges(context.Background(),
+			NewConfig("default"), plan); err != nil {
+			return nil, err
+		}
+		planner.moves.mark(plan)
+		return plan, nil
+	}
+	resourceSet := func(moved ...resources.MovedResource) *resources.ResourceSet {
+		return &resources.ResourceSet{
+			Portals: []resources.PortalResource{{
+				CreatePortal: kkComps.CreatePortal{Name: "Developer Hub"},
+				BaseResource: resources.BaseResource{Ref: "developer-hub"},
+			}},
+			Moved: moved,
+		}
+	}
+	portalChanges := func(plan *Plan) map[ActionType][]PlannedChange {
+		changes := map[ActionType][]PlannedChange{}
+		for _, change := range plan.Changes {
+			if change.ResourceType == ResourceTypePortal {
+				changes[change.Action] = append(changes[change.Action], change)
+			}
+		}
+		return changes
+	}
+	applied := func(resourceType, ref string) string {
+		if resourceType == ResourceTypePortal && ref == "dev-portal" {
+			return "portal-1"
+		}
+		return ""
+	}
+
+	t.Run("without a moved entry the portal is recreated", func(t *testing.T) {
+		plan, err := planPortals(resourceSet(), nil, "Dev Portal")
+		require.NoError(t, err)
+		changes := portalChanges(plan)
+		assert.Len(t, changes[ActionCreate], 1)
+		assert.Len(t, changes[ActionDelete], 1)
+	})
+
+	moved := resources.MovedResource{From: "dev-portal", To: "developer-hub"}
+	named := moved
+	named.Name = "Dev Portal"
+	for name, tc := range map[string]struct {
+		moved     resources.MovedResource
+		appliedID func(string, string) string
+	}{
+		"found by last applied ID": {moved: moved, appliedID: applied},
+		"found by name":            {moved: named},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rs := resourceSet(tc.moved)
+			plan, err := planPortals(rs, tc.appliedID, "Dev Portal")
+			require.NoError(t, err)
+
+			changes := portalChanges(plan)
+			assert.Empty(t, changes[ActionCreate])
+			assert.Empty(t, changes[ActionDelete])
+			require.Len(t, changes[ActionUpdate], 1)
+			update := changes[ActionUpdate][0]
+			assert.Equal(t, "portal-1", update.ResourceID)
+			assert.Equal(t, "developer-hub", update.ResourceRef)
+			assert.Equal(t, "dev-portal", update.MovedFrom)
+			assert.Equal(t, "Developer Hub", update.Fields["name"])
+			assert.Equal(t, "portal-1", rs.Portals[0].GetKonnectID(), "references resolve to the moved portal")
+		})
+	}
+
+	t.Run("an applied move plans nothing", func(t *testing.T) {
+		plan, err := planPortals(resourceSet(moved), nil, "Developer Hub")
+		require.NoError(t, err)
+		assert.Empty(t, portalChanges(plan))
+	})
+
+	t.Run("an unknown former resource needs its name", func(t *testing.T) {
+		_, err := planPortals(resourceSet(moved), nil, "Dev Portal")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"dev-portal" was not applied from this machine`)
+	})
+}
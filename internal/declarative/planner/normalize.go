@@ -0,0 +1,112 @@
+package planner
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+)
+
+// Konnect returns the members of set-like lists in its own order, omits empty and
+// false values and fills in defaults, so comparing the desired and current values as
+// written plans updates that change nothing. The planner compares canonical forms of
+// such values instead.
+
+// normalizeStringSet returns the values sorted and without duplicates. Nil and empty
+// sets are both nil.
+func normalizeStringSet(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	set := append([]string(nil), values...)
+	sort.Strings(set)
+	unique := set[:1]
+	for _, value := range set[1:] {
+		if value != unique[len(unique)-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// stringSetsEqual reports whether a and b hold the same values, in any order
+func stringSetsEqual(a, b []string) bool {
+	return reflect.DeepEqual(normalizeStringSet(a), normalizeStringSet(b))
+}
+
+// proxyURLSetsEqual reports whether a and b hold the same proxy URLs, in any order
+func proxyURLSetsEqual(a, b []kkComps.ProxyURL) bool {
+	key := func(urls []kkComps.ProxyURL) []string {
+		keys := make([]string, 0, len(urls))
+		for _, u := range urls {
+			keys = append(keys, fmt.Sprintf("%s://%s:%d", u.Protocol, u.Host, u.Port))
+		}
+		return keys
+	}
+	return stringSetsEqual(key(a), key(b))
+}
+
+// setFields are plan fields holding sets of strings, compared without order
+var setFields = map[string]bool{
+	"tags":              true,
+	"ca_certificates":   true,
+	"auth_strategy_ids": true,
+}
+
+// normalizeFieldValue returns the canonical form of a plan field decoded from JSON:
+// empty values are nil and the sets of setFields are sorted
+func normalizeFieldValue(key string, value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		if setFields[key] {
+			values := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return value
+				}
+				values = append(values, s)
+			}
+			return normalizeStringSet(values)
+		}
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+		normalized := make(map[string]any, len(v))
+		for k, item := range v {
+			if item = normalizeFieldValue(k, item); item != nil {
+				normalized[k] = item
+			}
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	}
+	return value
+}
+
+// fieldValuesEqual reports whether a desired and a current plan field are the same
+// once normalized, so an unset current value equals an empty desired one
+func fieldValuesEqual(key string, desired, current any) bool {
+	return reflect.DeepEqual(normalizeFieldValue(key, desired), normalizeFieldValue(key, current))
+}
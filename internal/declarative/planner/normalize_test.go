@@ -0,0 +1,63 @@
+package planner
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringSetsEqual(t *testing.T) {
+	assert.True(t, stringSetsEqual([]string{"b", "a"}, []string{"a", "b"}))
+	assert.True(t, stringSetsEqual(nil, []string{}))
+	assert.True(t, stringSetsEqual([]string{"a", "a", "b"}, []string{"b", "a"}))
+	// A length check alone treats these as equal
+	assert.False(t, stringSetsEqual([]string{"a", "a"}, []string{"a", "b"}))
+	assert.False(t, stringSetsEqual([]string{"a"}, nil))
+}
+
+func TestProxyURLSetsEqual(t *testing.T) {
+	a := []kkComps.ProxyURL{
+		{Host: "one.example.com", Port: 443, Protocol: "https"},
+		{Host: "two.example.com", Port: 80, Protocol: "http"},
+	}
+	b := []kkComps.ProxyURL{a[1], a[0]}
+	assert.True(t, proxyURLSetsEqual(a, b))
+	assert.False(t, proxyURLSetsEqual(a, a[:1]))
+}
+
+func TestFieldValuesEqual(t *testing.T) {
+	assert.True(t, fieldValuesEqual("path", "", nil))
+	assert.True(t, fieldValuesEqual("enabled", false, nil))
+	assert.True(t, fieldValuesEqual("tls_sans", map[string]any{"dnsnames": []any{}}, nil))
+	assert.True(t, fieldValuesEqual("tags", []any{"b", "a"}, []any{"a", "b"}))
+	assert.False(t, fieldValuesEqual("retries", float64(0), float64(5)))
+	// Fields not known to be sets keep their order
+	assert.False(t, fieldValuesEqual("paths", []any{"/b", "/a"}, []any{"/a", "/b"}))
+}
+
+func TestShouldUpdateGatewayService_IgnoresOrderAndEmptyValues(t *testing.T) {
+	host := "billing.internal"
+	path := ""
+	current := state.GatewayService{Service: kkComps.ServiceOutput{
+		Host: host,
+		Tags: []string{"team:billing", "env:prod"},
+	}}
+	desired := resources.GatewayServiceResource{Service: &kkComps.Service{
+		Host: host,
+		Path: &path,
+		Tags: []string{"env:prod", "team:billing"},
+	}}
+
+	update, err := shouldUpdateGatewayService(current, desired)
+	require.NoError(t, err)
+	assert.False(t, update)
+
+	desired.Service.Tags = append(desired.Service.Tags, "tier:gold")
+	update, err = shouldUpdateGatewayService(current, desired)
+	require.NoError(t, err)
+	assert.True(t, update)
+}
@@ -0,0 +1,245 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// planNotificationSubscriptionChanges plans the notification subscriptions of the current
+// user. Subscriptions carry no labels, so they are not namespaced: they are planned once
+// per plan and matched by event and name. In sync mode, undeclared subscriptions are
+// removed only for the events the configuration subscribes to, keeping the subscriptions
+// made in Konnect to other events.
+func (p *Planner) planNotificationSubscriptionChanges(
+	ctx context.Context,
+	rs *resources.ResourceSet,
+	plan *Plan,
+) error {
+	if rs == nil || len(rs.NotificationSubscriptions) == 0 {
+		return nil
+	}
+
+	desiredByEvent := make(map[string][]resources.NotificationSubscriptionResource)
+	for _, subscription := range rs.NotificationSubscriptions {
+		desiredByEvent[subscription.EventID] = append(desiredByEvent[subscription.EventID], subscription)
+	}
+	eventIDs := make([]string, 0, len(desiredByEvent))
+	for eventID := range desiredByEvent {
+		eventIDs = append(eventIDs, eventID)
+	}
+	sort.Strings(eventIDs)
+
+	for _, eventID := range eventIDs {
+		current, err := p.client.ListNotificationSubscriptions(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("failed to list subscriptions to notification event %q: %w", eventID, err)
+		}
+		currentByName := make(map[string]state.NotificationSubscription, len(current))
+		for _, subscription := range current {
+			currentByName[subscription.Name] = subscription
+		}
+
+		desiredNames := make(map[string]bool)
+		for _, desired := range desiredByEvent[eventID] {
+			desiredNames[desired.Name] = true
+			existing, exists := currentByName[desired.Name]
+
+			if plan.Metadata.Mode == PlanModeDelete {
+				if !exists {
+					plan.AddWarning("", fmt.Sprintf(
+						"notification_subscription %q not found in Konnect, skipping delete", desired.GetMoniker()))
+					continue
+				}
+				p.planNotificationSubscriptionDelete(existing, plan)
+				continue
+			}
+
+			fields := buildNotificationSubscriptionFields(rs, desired)
+			switch {
+			case !exists:
+				p.planNotificationSubscriptionChange(ActionCreate, rs, desired, fields, "", plan)
+			case notificationSubscriptionDiffers(existing, fields):
+				p.planNotificationSubscriptionChange(ActionUpdate, rs, desired, fields, existing.ID, plan)
+			}
+		}
+
+		if plan.Metadata.Mode != PlanModeSync {
+			continue
+		}
+		for _, subscription := range current {
+			if !desiredNames[subscription.Name] {
+				p.planNotificationSubscriptionDelete(subscription, plan)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Planner) planNotificationSubscriptionChange(
+	action ActionType,
+	rs *resources.ResourceSet,
+	subscription resources.NotificationSubscriptionResource,
+	fields map[string]any,
+	subscriptionID string,
+	plan *Plan,
+) {
+	change := PlannedChange{
+		ID:           p.nextChangeID(action, ResourceTypeNotificationSubscription, subscription.GetRef()),
+		ResourceType: ResourceTypeNotificationSubscription,
+		ResourceRef:  subscription.GetRef(),
+		ResourceID:   subscriptionID,
+		Action:       action,
+		Fields:       fields,
+		DependsOn:    []string{},
+		Parent:       &ParentInfo{Ref: subscription.EventID, ID: subscription.EventID},
+	}
+	if refs := notificationEntityReferences(rs, fields["entities"].([]string)); refs != nil {
+		change.References = map[string]ReferenceInfo{"entities": *refs}
+	}
+
+	plan.AddChange(change)
+	p.logger.Debug("Queued notification subscription change",
+		slog.String("change_id", change.ID),
+		slog.String("event_id", subscription.EventID))
+}
+
+func (p *Planner) planNotificationSubscriptionDelete(subscription state.NotificationSubscription, plan *Plan) {
+	plan.AddChange(PlannedChange{
+		ID:           p.nextChangeID(ActionDelete, ResourceTypeNotificationSubscription, subscription.Name),
+		ResourceType: ResourceTypeNotificationSubscription,
+		ResourceRef:  subscription.Name,
+		ResourceID:   subscription.ID,
+		Action:       ActionDelete,
+		Fields: map[string]any{
+			"event_id": subscription.EventID,
+			"name":     subscription.Name,
+		},
+		DependsOn: []string{},
+		Parent:    &ParentInfo{Ref: subscription.EventID, ID: subscription.EventID},
+	})
+}
+
+// buildNotificationSubscriptionFields returns the fields of a subscription. Entities
+// referencing resources that exist in Konnect are replaced by their IDs; references to
+// resources created by the plan are resolved when it is applied.
+func buildNotificationSubscriptionFields(
+	rs *resources.ResourceSet,
+	subscription resources.NotificationSubscriptionResource,
+) map[string]any {
+	channels := make([]map[string]any, 0, len(subscription.Channels))
+	for _, channel := range subscription.Channels {
+		channels = append(channels, map[string]any{
+			"type":    channel.Type,
+			"enabled": channel.Enabled == nil || *channel.Enabled,
+		})
+	}
+
+	entities := make([]string, 0, len(subscription.Entities))
+	for _, entity := range subscription.Entities {
+		entities = append(entities, resolveNotificationEntity(rs, entity))
+	}
+
+	return map[string]any{
+		"event_id": subscription.EventID,
+		"name":     subscription.Name,
+		"enabled":  subscription.IsEnabled(),
+		"channels": channels,
+		"regions":  slices.Clone(subscription.Regions),
+		"entities": entities,
+	}
+}
+
+// resolveNotificationEntity returns the Konnect ID a !ref entity resolves to when known
+func resolveNotificationEntity(rs *resources.ResourceSet, entity string) string {
+	ref, field, ok := tags.ParseRefPlaceholder(entity)
+	if !ok || (field != "" && field != "id" && field != "ID") {
+		return entity
+	}
+	if resource, found := rs.GetResourceByRef(ref); found && resource.GetKonnectID() != "" {
+		return resource.GetKonnectID()
+	}
+	return entity
+}
+
+// notificationEntityReferences describes the !ref entities left to resolve when the
+// plan is applied, with the names and Konnect entity types used to look them up
+func notificationEntityReferences(rs *resources.ResourceSet, entities []string) *ReferenceInfo {
+	if !slices.ContainsFunc(entities, tags.IsRefPlaceholder) {
+		return nil
+	}
+
+	info := ReferenceInfo{
+		Refs:         slices.Clone(entities),
+		IsArray:      true,
+		LookupArrays: map[string][]string{"names": {}, "entity_types": {}},
+	}
+	for _, entity := range entities {
+		name, entityType := "", ""
+		if ref, _, ok := tags.ParseRefPlaceholder(entity); ok {
+			if resource, found := rs.GetResourceByRef(ref); found {
+				name = resource.GetMoniker()
+				entityType = notificationEntityType(resource.GetType())
+			}
+		}
+		info.LookupArrays["names"] = append(info.LookupArrays["names"], name)
+		info.LookupArrays["entity_types"] = append(info.LookupArrays["entity_types"], entityType)
+	}
+	return &info
+}
+
+// notificationEntityType returns the Konnect entity type of resources notifications can be
+// sent for
+func notificationEntityType(resourceType resources.ResourceType) string {
+	switch resourceType {
+	case resources.ResourceTypePortal:
+		return string(kkComps.EntityTypeNamePortals)
+	case resources.ResourceTypeControlPlane:
+		return string(kkComps.EntityTypeNameControlPlanes)
+	case resources.ResourceTypeAPI:
+		return string(kkComps.EntityTypeNameApIs)
+	default:
+		return ""
+	}
+}
+
+// notificationSubscriptionDiffers reports whether the live subscription differs from the
+// desired fields. Regions and entities are compared as sets, channels by type.
+func notificationSubscriptionDiffers(current state.NotificationSubscription, fields map[string]any) bool {
+	if current.Enabled != fields["enabled"].(bool) {
+		return true
+	}
+
+	currentRegions := make([]string, 0, len(current.Regions))
+	for _, region := range current.Regions {
+		currentRegions = append(currentRegions, string(region))
+	}
+	if !stringSetsEqual(currentRegions, fields["regions"].([]string)) ||
+		!stringSetsEqual(current.Entities, fields["entities"].([]string)) {
+		return true
+	}
+
+	channels := fields["channels"].([]map[string]any)
+	if len(channels) != len(current.Channels) {
+		return true
+	}
+	currentChannels := make(map[string]bool, len(current.Channels))
+	for _, channel := range current.Channels {
+		currentChannels[string(channel.Type)] = channel.Enabled
+	}
+	for _, channel := range channels {
+		enabled, exists := currentChannels[channel["type"].(string)]
+		if !exists || enabled != channel["enabled"].(bool) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package planner
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+)
+
+type stubNotificationsAPI struct {
+	helpers.NotificationsAPI
+	subscriptions map[string][]kkComps.EventSubscriptionResponse // event ID -> subscriptions
+	listed        []string
+}
+
+func (s *stubNotificationsAPI) ListEventSubscriptions(
+	_ context.Context, eventID string, _ ...kkOps.Option,
+) (*kkOps.ListEventSubscriptionsResponse, error) {
+	s.listed = append(s.listed, eventID)
+	return &kkOps.ListEventSubscriptionsResponse{
+		EventSubscriptionListResponse: &kkComps.EventSubscriptionListResponse{Data: s.subscriptions[eventID]},
+	}, nil
+}
+
+func newNotificationSubscriptionPlanner(api *stubNotificationsAPI) *Planner {
+	return &Planner{
+		client: state.NewClient(state.ClientConfig{NotificationsAPI: api}),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func newNotificationSubscription(
+	ref, eventID, name string, entities ...string,
+) resources.NotificationSubscriptionResource {
+	subscription := resources.NotificationSubscriptionResource{
+		Ref:      ref,
+		EventID:  eventID,
+		Name:     name,
+		Channels: []resources.NotificationChannelResource{{Type: "EMAIL"}},
+		Entities: entities,
+	}
+	subscription.SetDefaults()
+	return subscription
+}
+
+func currentSubscription(id, name string, emailEnabled bool, entities ...string) kkComps.EventSubscriptionResponse {
+	return kkComps.EventSubscriptionResponse{
+		ID:       id,
+		Name:     name,
+		Enabled:  true,
+		Regions:  []kkComps.NotificationRegion{kkComps.NotificationRegionWildcard},
+		Entities: entities,
+		Channels: []kkComps.NotificationChannel{{Type: kkComps.NotificationChannelTypeEmail, Enabled: emailEnabled}},
+	}
+}
+
+func TestPlanNotificationSubscriptionChanges(t *testing.T) {
+	api := &stubNotificationsAPI{subscriptions: map[string][]kkComps.EventSubscriptionResponse{
+		"app-registration-requested": {
+			currentSubscription("sub-1", "Approvals", true, "*"),
+			currentSubscription("sub-2", "Escalations", true, "*"),
+			currentSubscription("sub-3", "Legacy", true, "*"),
+		},
+		"invoice-ready": {currentSubscription("sub-4", "Billing", true, "*")},
+	}}
+	rs := &resources.ResourceSet{NotificationSubscriptions: []resources.NotificationSubscriptionResource{
+		newNotificationSubscription("approvals", "app-registration-requested", "Approvals"),
+		newNotificationSubscription("escalations", "app-registration-requested", "Escalations", "portal-1"),
+		newNotificationSubscription("new", "app-registration-requested", "New"),
+	}}
+
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	p := newNotificationSubscriptionPlanner(api)
+	require.NoError(t, p.planNotificationSubscriptionChanges(context.Background(), rs, plan))
+
+	// Only the events the configuration subscribes to are read
+	assert.Equal(t, []string{"app-registration-requested"}, api.listed)
+
+	changes := changesOfType(plan, ResourceTypeNotificationSubscription)
+	require.Len(t, changes, 3)
+
+	update := changes[0]
+	assert.Equal(t, ActionUpdate, update.Action)
+	assert.Equal(t, "escalations", update.ResourceRef)
+	assert.Equal(t, "sub-2", update.ResourceID)
+	assert.Equal(t, []string{"portal-1"}, update.Fields["entities"])
+	assert.Equal(t, &ParentInfo{Ref: "app-registration-requested", ID: "app-registration-requested"}, update.Parent)
+
+	create := changes[1]
+	assert.Equal(t, ActionCreate, create.Action)
+	assert.Equal(t, "new", create.ResourceRef)
+	assert.Equal(t, "New", create.Fields["name"])
+	assert.Equal(t, true, create.Fields["enabled"])
+	assert.Equal(t, []map[string]any{{"type": "EMAIL", "enabled": true}}, create.Fields["channels"])
+	assert.Equal(t, []string{"*"}, create.Fields["regions"])
+
+	deleted := changes[2]
+	assert.Equal(t, ActionDelete, deleted.Action)
+	assert.Equal(t, "sub-3", deleted.ResourceID)
+	assert.Equal(t, "app-registration-requested", deleted.Parent.ID)
+}
+
+func TestPlanNotificationSubscriptionChanges_NoChanges(t *testing.T) {
+	api := &stubNotificationsAPI{subscriptions: map[string][]kkComps.EventSubscriptionResponse{
+		"app-registration-requested": {currentSubscription("sub-1", "Approvals", true, "portal-2", "portal-1")},
+	}}
+	rs := &resources.ResourceSet{NotificationSubscriptions: []resources.NotificationSubscriptionResource{
+		newNotificationSubscription("approvals", "app-registration-requested", "Approvals", "portal-1", "portal-2"),
+	}}
+
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	p := newNotificationSubscriptionPlanner(api)
+	require.NoError(t, p.planNotificationSubscriptionChanges(context.Background(), rs, plan))
+	assert.Empty(t, plan.Changes)
+
+	// A disabled channel is a change
+	api.subscriptions["app-registration-requested"][0].Channels[0].Enabled = false
+	require.NoError(t, p.planNotificationSubscriptionChanges(context.Background(), rs, plan))
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionUpdate, plan.Changes[0].Action)
+}
+
+func TestPlanNotificationSubscriptionChanges_EntityRefs(t *testing.T) {
+	existing := resources.PortalResource{BaseResource: resources.BaseResource{Ref: "existing"}}
+	existing.Name = "Existing Portal"
+	existing.SetKonnectID("portal-id")
+	created := resources.PortalResource{BaseResource: resources.BaseResource{Ref: "created"}}
+	created.Name = "New Portal"
+
+	existingRef := tags.RefPlaceholderPrefix + "existing#id"
+	createdRef := tags.RefPlaceholderPrefix + "created#id"
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{existing, created},
+		NotificationSubscriptions: []resources.NotificationSubscriptionResource{
+			newNotificationSubscription("approvals", "app-registration-requested", "Approvals", existingRef, createdRef),
+		},
+	}
+
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	p := newNotificationSubscriptionPlanner(&stubNotificationsAPI{})
+	require.NoError(t, p.planNotificationSubscriptionChanges(context.Background(), rs, plan))
+	require.Len(t, plan.Changes, 1)
+
+	change := plan.Changes[0]
+	assert.Equal(t, []string{"portal-id", createdRef}, change.Fields["entities"])
+	refs := change.References["entities"]
+	assert.True(t, refs.IsArray)
+	assert.Equal(t, []string{"portal-id", createdRef}, refs.Refs)
+	assert.Equal(t, []string{"", "New Portal"}, refs.LookupArrays["names"])
+	assert.Equal(t, []string{"", "Portals"}, refs.LookupArrays["entity_types"])
+}
+
+func TestPlanNotificationSubscriptionChanges_DeleteMode(t *testing.T) {
+	api := &stubNotificationsAPI{subscriptions: map[string][]kkComps.EventSubscriptionResponse{
+		"app-registration-requested": {
+			currentSubscription("sub-1", "Approvals", true, "*"),
+			currentSubscription("sub-2", "Other", true, "*"),
+		},
+	}}
+	rs := &resources.ResourceSet{NotificationSubscriptions: []resources.NotificationSubscriptionResource{
+		newNotificationSubscription("approvals", "app-registration-requested", "Approvals"),
+		newNotificationSubscription("missing", "app-registration-requested", "Missing"),
+	}}
+
+	plan := NewPlan("1.0", "test", PlanModeDelete)
+	p := newNotificationSubscriptionPlanner(api)
+	require.NoError(t, p.planNotificationSubscriptionChanges(context.Background(), rs, plan))
+
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionDelete, plan.Changes[0].Action)
+	assert.Equal(t, "sub-1", plan.Changes[0].ResourceID)
+	require.Len(t, plan.Warnings, 1)
+	assert.Contains(t, plan.Warnings[0].Message, "app-registration-requested/Missing")
+}
@@ -0,0 +1,379 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/util"
+)
+
+// planTeamChildChanges plans the role assignments and memberships of the desired teams.
+// Children of teams being created depend on the team creation. In sync mode, roles and
+// members of a managed team that are not declared are removed.
+func (t *OrganizationTeamPlannerImpl) planTeamChildChanges(
+	ctx context.Context,
+	namespace string,
+	desired []resources.OrganizationTeamResource,
+	currentByName map[string]state.OrganizationTeam,
+	plan *Plan,
+) error {
+	rs := t.planner.resources
+	if rs == nil {
+		return nil
+	}
+
+	for _, team := range desired {
+		if team.IsExternal() {
+			continue
+		}
+
+		var roles []resources.OrganizationTeamRoleResource
+		for _, role := range rs.OrganizationTeamRoles {
+			if role.Team == team.GetRef() {
+				roles = append(roles, role)
+			}
+		}
+		var members []resources.OrganizationTeamMemberResource
+		for _, member := range rs.OrganizationTeamMembers {
+			if member.Team == team.GetRef() {
+				members = append(members, member)
+			}
+		}
+
+		teamID := ""
+		if current, exists := currentByName[team.Name]; exists {
+			teamID = util.GetString(current.ID)
+		}
+		parent := teamParent{
+			ref:       team.GetRef(),
+			name:      team.Name,
+			id:        teamID,
+			changeID:  findChangeID(plan, ResourceTypeOrganizationTeam, team.GetRef()),
+			namespace: namespace,
+		}
+
+		if err := t.planTeamRoleChanges(ctx, parent, roles, plan); err != nil {
+			return err
+		}
+		if err := t.planTeamMemberChanges(ctx, parent, members, plan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// teamParent identifies the team the planned role and member changes belong to
+type teamParent struct {
+	ref       string
+	name      string
+	id        string // empty when the team is created by the plan
+	changeID  string
+	namespace string
+}
+
+func (p teamParent) references() map[string]ReferenceInfo {
+	return map[string]ReferenceInfo{
+		"organization_team_id": {
+			Ref: p.ref,
+			ID:  p.id,
+			LookupFields: map[string]string{
+				"name": p.name,
+			},
+		},
+	}
+}
+
+func (p teamParent) dependsOn() []string {
+	if p.changeID == "" {
+		return []string{}
+	}
+	return []string{p.changeID}
+}
+
+func (t *OrganizationTeamPlannerImpl) planTeamRoleChanges(
+	ctx context.Context,
+	parent teamParent,
+	desired []resources.OrganizationTeamRoleResource,
+	plan *Plan,
+) error {
+	existing := make(map[string]state.OrganizationTeamRole)
+	if parent.id != "" && (len(desired) > 0 || plan.Metadata.Mode == PlanModeSync) {
+		roles, err := t.GetClient().ListOrganizationTeamRoles(ctx, parent.id)
+		if err != nil {
+			return fmt.Errorf("failed to list roles of organization team %q: %w", parent.name, err)
+		}
+		for _, role := range roles {
+			existing[buildPortalTeamRoleKey(role.RoleName, role.EntityID, role.EntityTypeName, role.EntityRegion)] = role
+		}
+	}
+
+	desiredKeys := make(map[string]bool)
+	for _, role := range desired {
+		key := buildPortalTeamRoleKey(
+			role.RoleName, t.resolveRoleEntityID(role), role.EntityTypeName, role.EntityRegion)
+		desiredKeys[key] = true
+		if _, exists := existing[key]; exists {
+			continue
+		}
+
+		change := PlannedChange{
+			ID:           t.NextChangeID(ActionCreate, ResourceTypeOrganizationTeamRole, role.GetRef()),
+			ResourceType: ResourceTypeOrganizationTeamRole,
+			ResourceRef:  role.GetRef(),
+			Action:       ActionCreate,
+			Fields: map[string]any{
+				"role_name":        role.RoleName,
+				"entity_id":        role.EntityID,
+				"entity_type_name": role.EntityTypeName,
+				"entity_region":    role.EntityRegion,
+			},
+			DependsOn:  parent.dependsOn(),
+			Namespace:  parent.namespace,
+			References: parent.references(),
+		}
+		plan.AddChange(change)
+		t.planner.logger.Debug("Queued organization team role create change",
+			slog.String("change_id", change.ID),
+			slog.String("team_ref", parent.ref))
+	}
+
+	if plan.Metadata.Mode != PlanModeSync {
+		return nil
+	}
+	for key, role := range existing {
+		if desiredKeys[key] {
+			continue
+		}
+		plan.AddChange(PlannedChange{
+			ID:           t.NextChangeID(ActionDelete, ResourceTypeOrganizationTeamRole, role.RoleName),
+			ResourceType: ResourceTypeOrganizationTeamRole,
+			ResourceRef:  role.RoleName,
+			ResourceID:   role.ID,
+			Action:       ActionDelete,
+			Fields: map[string]any{
+				"role_name":        role.RoleName,
+				"entity_id":        role.EntityID,
+				"entity_type_name": role.EntityTypeName,
+				"entity_region":    role.EntityRegion,
+			},
+			DependsOn:  []string{},
+			Namespace:  parent.namespace,
+			References: parent.references(),
+			Parent:     &ParentInfo{Ref: parent.ref, ID: parent.id},
+		})
+	}
+
+	return nil
+}
+
+// resolveRoleEntityID returns the Konnect ID a !ref entity_id resolves to when known,
+// so roles on existing resources match their assignments in Konnect
+func (t *OrganizationTeamPlannerImpl) resolveRoleEntityID(role resources.OrganizationTeamRoleResource) string {
+	ref, ok := role.EntityRef()
+	if !ok {
+		return role.EntityID
+	}
+	_, field, _ := tags.ParseRefPlaceholder(role.EntityID)
+	if field != "" && field != "id" && field != "ID" {
+		return role.EntityID
+	}
+	if resource, found := t.planner.resources.GetResourceByRef(ref); found && resource.GetKonnectID() != "" {
+		return resource.GetKonnectID()
+	}
+	return role.EntityID
+}
+
+func (t *OrganizationTeamPlannerImpl) planTeamMemberChanges(
+	ctx context.Context,
+	parent teamParent,
+	desired []resources.OrganizationTeamMemberResource,
+	plan *Plan,
+) error {
+	existing := make(map[string]state.OrganizationUser) // lowercase email -> user
+	if parent.id != "" && (len(desired) > 0 || plan.Metadata.Mode == PlanModeSync) {
+		users, err := t.GetClient().ListOrganizationTeamMembers(ctx, parent.id)
+		if err != nil {
+			return fmt.Errorf("failed to list members of organization team %q: %w", parent.name, err)
+		}
+		for _, user := range users {
+			existing[strings.ToLower(user.Email)] = user
+		}
+	}
+
+	desiredEmails := make(map[string]bool)
+	for _, member := range desired {
+		email := strings.ToLower(member.Email)
+		desiredEmails[email] = true
+		if _, exists := existing[email]; exists {
+			continue
+		}
+
+		user, err := t.GetClient().GetOrganizationUserByEmail(ctx, member.Email)
+		if err != nil {
+			return fmt.Errorf("failed to look up user %q for organization team %q: %w", member.Email, parent.name, err)
+		}
+		if user == nil {
+			return fmt.Errorf("organization_team_member %q: user %q not found in the Konnect organization",
+				member.GetRef(), member.Email)
+		}
+
+		plan.AddChange(PlannedChange{
+			ID:           t.NextChangeID(ActionCreate, ResourceTypeOrganizationTeamMember, member.GetRef()),
+			ResourceType: ResourceTypeOrganizationTeamMember,
+			ResourceRef:  member.GetRef(),
+			Action:       ActionCreate,
+			Fields: map[string]any{
+				"email":   member.Email,
+				"user_id": user.ID,
+			},
+			DependsOn:  parent.dependsOn(),
+			Namespace:  parent.namespace,
+			References: parent.references(),
+		})
+	}
+
+	if plan.Metadata.Mode != PlanModeSync {
+		return nil
+	}
+	for email, user := range existing {
+		if desiredEmails[email] {
+			continue
+		}
+		plan.AddChange(PlannedChange{
+			ID:           t.NextChangeID(ActionDelete, ResourceTypeOrganizationTeamMember, user.Email),
+			ResourceType: ResourceTypeOrganizationTeamMember,
+			ResourceRef:  user.Email,
+			ResourceID:   user.ID,
+			Action:       ActionDelete,
+			Fields: map[string]any{
+				"email":   user.Email,
+				"user_id": user.ID,
+			},
+			DependsOn:  []string{},
+			Namespace:  parent.namespace,
+			References: parent.references(),
+			Parent:     &ParentInfo{Ref: parent.ref, ID: parent.id},
+		})
+	}
+
+	return nil
+}
+
+// checkSelfAccess fails when the plan deletes a team the current user belongs to,
+// removes them from a team, or removes a role from a team they belong to, as applying
+// it could lock them out of the organization. The check is skipped when the
+// credentials are not a user's, as for a system account token.
+func (t *OrganizationTeamPlannerImpl) checkSelfAccess(ctx context.Context, plan *Plan) error {
+	var affected []PlannedChange
+	for _, change := range plan.Changes {
+		if change.Action != ActionDelete {
+			continue
+		}
+		switch change.ResourceType {
+		case ResourceTypeOrganizationTeam, ResourceTypeOrganizationTeamRole, ResourceTypeOrganizationTeamMember:
+			affected = append(affected, change)
+		}
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	me, err := t.GetClient().GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check the access of the current user: %w", err)
+	}
+	if me == nil {
+		return nil
+	}
+
+	memberOf := make(map[string]bool) // team ID -> current user is a member
+	isMember := func(teamID string) (bool, error) {
+		if member, checked := memberOf[teamID]; checked {
+			return member, nil
+		}
+		users, err := t.GetClient().ListOrganizationTeamMembers(ctx, teamID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list members of organization team %s: %w", teamID, err)
+		}
+		memberOf[teamID] = false
+		for _, user := range users {
+			if user.ID == me.ID {
+				memberOf[teamID] = true
+			}
+		}
+		return memberOf[teamID], nil
+	}
+
+	var reasons []string
+	for _, change := range affected {
+		switch change.ResourceType {
+		case ResourceTypeOrganizationTeamMember:
+			if change.ResourceID == me.ID {
+				reasons = append(reasons, fmt.Sprintf("removes them from team %q", change.Parent.Ref))
+			}
+		case ResourceTypeOrganizationTeam:
+			member, err := isMember(change.ResourceID)
+			if err != nil {
+				return err
+			}
+			if member {
+				reasons = append(reasons, fmt.Sprintf("deletes their team %q", change.ResourceRef))
+			}
+		case ResourceTypeOrganizationTeamRole:
+			member, err := isMember(change.Parent.ID)
+			if err != nil {
+				return err
+			}
+			if member {
+				reasons = append(reasons, fmt.Sprintf("removes role %q from their team %q",
+					change.ResourceRef, change.Parent.Ref))
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("plan would remove the access of the current user %s: it %s; "+
+		"apply these changes as another user or with a system account",
+		me.Email, strings.Join(reasons, ", "))
+}
+
+// adjustOrganizationTeamRoleDependencies wires organization_team_role changes to depend
+// on the creation of the resource their entity_id references in the same plan
+func adjustOrganizationTeamRoleDependencies(plan *Plan) {
+	if plan == nil {
+		return
+	}
+
+	createdByRef := make(map[string]string) // ref -> changeID
+	for _, change := range plan.Changes {
+		if change.Action == ActionCreate {
+			createdByRef[change.ResourceRef] = change.ID
+		}
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if change.ResourceType != ResourceTypeOrganizationTeamRole || change.Action != ActionCreate {
+			continue
+		}
+		entityID, _ := change.Fields["entity_id"].(string)
+		if !tags.IsRefPlaceholder(entityID) {
+			continue
+		}
+		ref, _, ok := tags.ParseRefPlaceholder(entityID)
+		if !ok {
+			continue
+		}
+		if changeID, exists := createdByRef[ref]; exists && !containsString(change.DependsOn, changeID) {
+			change.DependsOn = append(change.DependsOn, changeID)
+		}
+	}
+}
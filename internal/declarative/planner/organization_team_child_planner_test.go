@@ -0,0 +1,300 @@
+package planner
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	kkErrors "github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+)
+
+type stubOrganizationTeamAPI struct {
+	helpers.OrganizationTeamAPI
+	teams []kkComps.Team
+}
+
+func (s *stubOrganizationTeamAPI) ListOrganizationTeams(
+	_ context.Context, _ kkOps.ListTeamsRequest,
+) (*kkOps.ListTeamsResponse, error) {
+	return &kkOps.ListTeamsResponse{TeamCollection: &kkComps.TeamCollection{
+		Data: s.teams,
+		Meta: &kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(s.teams))}},
+	}}, nil
+}
+
+type stubOrganizationTeamRolesAPI struct {
+	helpers.OrganizationTeamRolesAPI
+	roles map[string][]kkComps.AssignedRole // team ID -> roles
+}
+
+func (s *stubOrganizationTeamRolesAPI) ListTeamRoles(
+	_ context.Context, teamID string, _ *kkOps.ListTeamRolesQueryParamFilter, _ ...kkOps.Option,
+) (*kkOps.ListTeamRolesResponse, error) {
+	return &kkOps.ListTeamRolesResponse{
+		AssignedRoleCollection: &kkComps.AssignedRoleCollection{Data: s.roles[teamID]},
+	}, nil
+}
+
+type stubOrganizationTeamMembershipAPI struct {
+	helpers.OrganizationTeamMembershipAPI
+	members map[string][]kkComps.User // team ID -> users
+	users   []kkComps.User
+}
+
+func (s *stubOrganizationTeamMembershipAPI) ListTeamUsers(
+	_ context.Context, request kkOps.ListTeamUsersRequest, _ ...kkOps.Option,
+) (*kkOps.ListTeamUsersResponse, error) {
+	users := s.members[request.TeamID]
+	return &kkOps.ListTeamUsersResponse{UserCollection: &kkComps.UserCollection{
+		Data: users,
+		Meta: &kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(users))}},
+	}}, nil
+}
+
+func (s *stubOrganizationTeamMembershipAPI) ListUsers(
+	_ context.Context, request kkOps.ListUsersRequest, _ ...kkOps.Option,
+) (*kkOps.ListUsersResponse, error) {
+	var matched []kkComps.User
+	for _, user := range s.users {
+		if *user.Email == *request.Filter.Email.Eq {
+			matched = append(matched, user)
+		}
+	}
+	return &kkOps.ListUsersResponse{UserCollection: &kkComps.UserCollection{Data: matched}}, nil
+}
+
+type stubMeAPI struct {
+	helpers.MeAPI
+	user *kkComps.User
+	err  error
+}
+
+func (s *stubMeAPI) GetUsersMe(_ context.Context, _ ...kkOps.Option) (*kkOps.GetUsersMeResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &kkOps.GetUsersMeResponse{User: s.user}, nil
+}
+
+func newTestUser(id, email string) kkComps.User {
+	return kkComps.User{ID: &id, Email: &email}
+}
+
+func newManagedTeam(id, name string) kkComps.Team {
+	return kkComps.Team{ID: &id, Name: &name, Labels: map[string]string{labels.NamespaceKey: "default"}}
+}
+
+func newTeamChildPlanner(
+	teams *stubOrganizationTeamAPI,
+	roles *stubOrganizationTeamRolesAPI,
+	membership *stubOrganizationTeamMembershipAPI,
+	me helpers.MeAPI,
+	rs *resources.ResourceSet,
+) OrganizationTeamPlanner {
+	planner := &Planner{
+		client: state.NewClient(state.ClientConfig{
+			OrganizationTeamAPI:           teams,
+			OrganizationTeamRolesAPI:      roles,
+			OrganizationTeamMembershipAPI: membership,
+			MeAPI:                         me,
+		}),
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	planner.genericPlanner = NewGenericPlanner(planner)
+	planner.resources = rs
+	return NewOrganizationTeamPlanner(NewBasePlanner(planner))
+}
+
+func changesOfType(plan *Plan, resourceType string) []PlannedChange {
+	var changes []PlannedChange
+	for _, change := range plan.Changes {
+		if change.ResourceType == resourceType {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func TestOrganizationTeamPlanner_ChildrenOfNewTeam(t *testing.T) {
+	teamPlanner := newTeamChildPlanner(
+		&stubOrganizationTeamAPI{},
+		&stubOrganizationTeamRolesAPI{},
+		&stubOrganizationTeamMembershipAPI{users: []kkComps.User{newTestUser("user-1", "dev@example.com")}},
+		nil,
+		&resources.ResourceSet{
+			OrganizationTeams: []resources.OrganizationTeamResource{{
+				BaseResource: resources.BaseResource{Ref: "platform"},
+				CreateTeam:   kkComps.CreateTeam{Name: "Platform"},
+			}},
+			OrganizationTeamRoles: []resources.OrganizationTeamRoleResource{{
+				Ref: "platform-cp-admin", Team: "platform", RoleName: "Admin",
+				EntityID: "*", EntityTypeName: "Control Planes", EntityRegion: "us",
+			}},
+			OrganizationTeamMembers: []resources.OrganizationTeamMemberResource{{
+				Ref: "platform-dev", Team: "platform", Email: "dev@example.com",
+			}},
+		},
+	)
+
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	require.NoError(t, teamPlanner.PlanChanges(context.Background(), NewConfig("default"), plan))
+
+	teams := changesOfType(plan, ResourceTypeOrganizationTeam)
+	require.Len(t, teams, 1)
+
+	roles := changesOfType(plan, ResourceTypeOrganizationTeamRole)
+	require.Len(t, roles, 1)
+	assert.Equal(t, ActionCreate, roles[0].Action)
+	assert.Equal(t, []string{teams[0].ID}, roles[0].DependsOn)
+	assert.Equal(t, "platform", roles[0].References["organization_team_id"].Ref)
+	assert.Equal(t, "Admin", roles[0].Fields["role_name"])
+
+	members := changesOfType(plan, ResourceTypeOrganizationTeamMember)
+	require.Len(t, members, 1)
+	assert.Equal(t, []string{teams[0].ID}, members[0].DependsOn)
+	assert.Equal(t, "user-1", members[0].Fields["user_id"])
+}
+
+func TestOrganizationTeamPlanner_SyncRemovesUndeclaredChildren(t *testing.T) {
+	roleID, roleName, entityID, entityType := "role-2", "Viewer", "*", "APIs"
+	region := kkComps.AssignedRoleEntityRegionUs
+	cpRoleID, cpRoleName, cpType := "role-1", "Admin", "Control Planes"
+
+	teamPlanner := newTeamChildPlanner(
+		&stubOrganizationTeamAPI{teams: []kkComps.Team{newManagedTeam("team-1", "Platform")}},
+		&stubOrganizationTeamRolesAPI{roles: map[string][]kkComps.AssignedRole{"team-1": {
+			{ID: &cpRoleID, RoleName: &cpRoleName, EntityID: &entityID, EntityTypeName: &cpType, EntityRegion: &region},
+			{ID: &roleID, RoleName: &roleName, EntityID: &entityID, EntityTypeName: &entityType, EntityRegion: &region},
+		}}},
+		&stubOrganizationTeamMembershipAPI{members: map[string][]kkComps.User{"team-1": {
+			newTestUser("user-1", "dev@example.com"),
+			newTestUser("user-2", "former@example.com"),
+		}}},
+		nil,
+		&resources.ResourceSet{
+			OrganizationTeams: []resources.OrganizationTeamResource{{
+				BaseResource: resources.BaseResource{Ref: "platform"},
+				CreateTeam:   kkComps.CreateTeam{Name: "Platform"},
+			}},
+			OrganizationTeamRoles: []resources.OrganizationTeamRoleResource{{
+				Ref: "platform-cp-admin", Team: "platform", RoleName: "Admin",
+				EntityID: "*", EntityTypeName: "Control Planes", EntityRegion: "us",
+			}},
+			OrganizationTeamMembers: []resources.OrganizationTeamMemberResource{{
+				Ref: "platform-dev", Team: "platform", Email: "Dev@example.com",
+			}},
+		},
+	)
+
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	require.NoError(t, teamPlanner.PlanChanges(context.Background(), NewConfig("default"), plan))
+
+	roles := changesOfType(plan, ResourceTypeOrganizationTeamRole)
+	require.Len(t, roles, 1)
+	assert.Equal(t, ActionDelete, roles[0].Action)
+	assert.Equal(t, "role-2", roles[0].ResourceID)
+	assert.Equal(t, "team-1", roles[0].Parent.ID)
+
+	members := changesOfType(plan, ResourceTypeOrganizationTeamMember)
+	require.Len(t, members, 1)
+	assert.Equal(t, ActionDelete, members[0].Action)
+	assert.Equal(t, "user-2", members[0].ResourceID)
+}
+
+func TestOrganizationTeamPlanner_UnknownMemberEmail(t *testing.T) {
+	teamPlanner := newTeamChildPlanner(
+		&stubOrganizationTeamAPI{},
+		&stubOrganizationTeamRolesAPI{},
+		&stubOrganizationTeamMembershipAPI{},
+		nil,
+		&resources.ResourceSet{
+			OrganizationTeams: []resources.OrganizationTeamResource{{
+				BaseResource: resources.BaseResource{Ref: "platform"},
+				CreateTeam:   kkComps.CreateTeam{Name: "Platform"},
+			}},
+			OrganizationTeamMembers: []resources.OrganizationTeamMemberResource{{
+				Ref: "platform-dev", Team: "platform", Email: "nobody@example.com",
+			}},
+		},
+	)
+
+	err := teamPlanner.PlanChanges(context.Background(), NewConfig("default"), NewPlan("1.0", "test", PlanModeApply))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `user "nobody@example.com" not found`)
+}
+
+func TestOrganizationTeamPlanner_SelfAccessProtection(t *testing.T) {
+	me := newTestUser("user-1", "me@example.com")
+	membership := func() *stubOrganizationTeamMembershipAPI {
+		return &stubOrganizationTeamMembershipAPI{members: map[string][]kkComps.User{
+			"team-1": {me},
+			"team-2": {newTestUser("user-2", "other@example.com")},
+		}}
+	}
+	teams := func() *stubOrganizationTeamAPI {
+		return &stubOrganizationTeamAPI{teams: []kkComps.Team{
+			newManagedTeam("team-1", "Platform"),
+			newManagedTeam("team-2", "Payments"),
+		}}
+	}
+	declared := func(members ...resources.OrganizationTeamMemberResource) *resources.ResourceSet {
+		return &resources.ResourceSet{
+			OrganizationTeams: []resources.OrganizationTeamResource{
+				{BaseResource: resources.BaseResource{Ref: "platform"}, CreateTeam: kkComps.CreateTeam{Name: "Platform"}},
+				{BaseResource: resources.BaseResource{Ref: "payments"}, CreateTeam: kkComps.CreateTeam{Name: "Payments"}},
+			},
+			OrganizationTeamMembers: members,
+		}
+	}
+
+	t.Run("removing own membership fails", func(t *testing.T) {
+		teamPlanner := newTeamChildPlanner(teams(), &stubOrganizationTeamRolesAPI{}, membership(),
+			&stubMeAPI{user: &me}, declared())
+
+		err := teamPlanner.PlanChanges(context.Background(), NewConfig("default"), NewPlan("1.0", "test", PlanModeSync))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "me@example.com")
+		assert.Contains(t, err.Error(), `removes them from team "platform"`)
+	})
+
+	t.Run("deleting own team fails", func(t *testing.T) {
+		teamPlanner := newTeamChildPlanner(teams(), &stubOrganizationTeamRolesAPI{}, membership(),
+			&stubMeAPI{user: &me}, declared())
+
+		err := teamPlanner.PlanChanges(context.Background(), NewConfig("default"), NewPlan("1.0", "test", PlanModeDelete))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `deletes their team "Platform"`)
+	})
+
+	t.Run("other users can be removed", func(t *testing.T) {
+		teamPlanner := newTeamChildPlanner(teams(), &stubOrganizationTeamRolesAPI{}, membership(),
+			&stubMeAPI{user: &me}, declared(resources.OrganizationTeamMemberResource{
+				Ref: "platform-me", Team: "platform", Email: "me@example.com",
+			}))
+
+		plan := NewPlan("1.0", "test", PlanModeSync)
+		require.NoError(t, teamPlanner.PlanChanges(context.Background(), NewConfig("default"), plan))
+		members := changesOfType(plan, ResourceTypeOrganizationTeamMember)
+		require.Len(t, members, 1)
+		assert.Equal(t, "user-2", members[0].ResourceID)
+	})
+
+	t.Run("system accounts are not checked", func(t *testing.T) {
+		teamPlanner := newTeamChildPlanner(teams(), &stubOrganizationTeamRolesAPI{}, membership(),
+			&stubMeAPI{err: &kkErrors.SDKError{Message: "API error occurred", StatusCode: 403}}, declared())
+
+		plan := NewPlan("1.0", "test", PlanModeSync)
+		require.NoError(t, teamPlanner.PlanChanges(context.Background(), NewConfig("default"), plan))
+		assert.Len(t, changesOfType(plan, ResourceTypeOrganizationTeamMember), 2)
+	})
+}
@@ -54,6 +54,12 @@ func (t *OrganizationTeamPlannerImpl) PlanChanges(ctx context.Context, plannerCt
 		}
 		currentByName[util.GetString(team.Name)] = team
 	}
+	if err := moveResources(t.planner, plan, "organization_team", desired, currentByName,
+		func(team state.OrganizationTeam) (string, string) {
+			return util.GetString(team.Name), util.GetString(team.ID)
+		}); err != nil {
+		return err
+	}
 
 	// Collect protection validation errors
 	protectionErrors := &ProtectionErrorCollector{}
@@ -84,7 +90,7 @@ func (t *OrganizationTeamPlannerImpl) PlanChanges(ctx context.Context, plannerCt
 		if protectionErrors.HasErrors() {
 			return protectionErrors.Error()
 		}
-		return nil
+		return t.checkSelfAccess(ctx, plan)
 	}
 
 	// Compare each desired team
@@ -176,7 +182,12 @@ func (t *OrganizationTeamPlannerImpl) PlanChanges(ctx context.Context, plannerCt
 		return protectionErrors.Error()
 	}
 
-	return nil
+	// Plan the roles and members of the desired teams
+	if err := t.planTeamChildChanges(ctx, namespace, desired, currentByName, plan); err != nil {
+		return err
+	}
+
+	return t.checkSelfAccess(ctx, plan)
 }
 
 // extractTeamFields extracts fields from a organization_team resource for planner operations
@@ -271,6 +282,11 @@ func (t *OrganizationTeamPlannerImpl) shouldUpdateOrganizationTeam(
 ) (bool, map[string]any) {
 	updates := make(map[string]any)
 
+	// Names differ when a moved entry renamed the team
+	if name := t.GetString(current.Name); name != desired.Name {
+		updates["name"] = desired.Name
+	}
+
 	if desired.Description != nil {
 		currentDesc := t.GetString(current.Description)
 		if currentDesc != *desired.Description {
@@ -356,6 +372,7 @@ func (t *OrganizationTeamPlannerImpl) planOrganizationTeamUpdateWithFields(
 		if desired.Kongctl != nil && desired.Kongctl.Namespace != nil {
 			change.Namespace = *desired.Kongctl.Namespace
 		}
+		change.IdentityFields = []string{"name"}
 		plan.AddChange(change)
 		return
 	}
@@ -371,6 +388,7 @@ func (t *OrganizationTeamPlannerImpl) planOrganizationTeamUpdateWithFields(
 	if labels.IsProtectedResource(current.NormalizedLabels) {
 		change.Protection = true
 	}
+	change.IdentityFields = []string{"name"}
 
 	plan.AddChange(change)
 }
@@ -433,6 +451,7 @@ func (t *OrganizationTeamPlannerImpl) planOrganizationTeamProtectionChangeWithFi
 	}
 
 	change.Fields = fields
+	change.IdentityFields = []string{"name"}
 	plan.AddChange(change)
 }
 
@@ -5,13 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/kong/kongctl/internal/declarative/custom"
 	"github.com/kong/kongctl/internal/declarative/deck"
 	"github.com/kong/kongctl/internal/declarative/resources"
 	"github.com/kong/kongctl/internal/declarative/state"
 	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/kong/kongctl/internal/tracing"
 	"github.com/kong/kongctl/internal/util"
 )
 
@@ -20,6 +23,24 @@ type Options struct {
 	Mode      PlanMode
 	Generator string
 	Deck      DeckOptions
+	// IncludeChange restricts the plan to the changes it accepts; nil keeps every change
+	IncludeChange func(change PlannedChange) bool
+	// IgnoreFields drops updates that only change these fields, in addition to the
+	// ignore_fields of each resource
+	IgnoreFields []IgnoredField
+	// Parallelism is how many Konnect reads of current state run at once; reads are
+	// sequential when unset
+	Parallelism int
+	// MatchByName adopts unmanaged portals and APIs named like a desired resource
+	// instead of planning to create them
+	MatchByName bool
+	// ConfirmedProtectedDeletes names the protected resources to delete anyway. Their
+	// delete changes are marked DeleteProtected; other protected resources still fail
+	// the plan.
+	ConfirmedProtectedDeletes []string
+	// AppliedID returns the Konnect ID last applied for a resource ref, or "" when not
+	// known. It finds the resources the moved entries of the configuration rename.
+	AppliedID func(resourceType, ref string) string
 }
 
 const defaultGenerator = "kongctl/dev"
@@ -53,22 +74,39 @@ type Planner struct {
 	eventGatewayControlPlanePlanner EGWControlPlanePlanner
 	organizationTeamPlanner         OrganizationTeamPlanner
 
+	// Existing instances of custom resource kinds, listed once per plan by kind
+	customStates map[string][]custom.State
+
+	// How many Konnect reads run at once, and the API child resources they prefetched
+	parallelism int
+	apiChildren map[string]*apiChildState
+
+	// Whether unmanaged resources are adopted by name
+	matchByName bool
+
+	// Protected resources confirmed for deletion, shared with the namespace planners
+	protectedDeletes *protectedDeletes
+
+	// Resources renamed by the moved entries, shared with the namespace planners
+	moves *resourceMoves
+
 	// ResourceSet containing all desired resources
 	resources *resources.ResourceSet
 
 	// Legacy field access for backward compatibility (provides global access)
-	desiredPortals              []resources.PortalResource
-	desiredPortalPages          []resources.PortalPageResource
-	desiredPortalSnippets       []resources.PortalSnippetResource
-	desiredPortalTeams          []resources.PortalTeamResource
-	desiredPortalTeamRoles      []resources.PortalTeamRoleResource
-	desiredPortalCustomizations []resources.PortalCustomizationResource
-	desiredPortalAuthSettings   []resources.PortalAuthSettingsResource
-	desiredPortalCustomDomains  []resources.PortalCustomDomainResource
-	desiredPortalAssetLogos     []resources.PortalAssetLogoResource
-	desiredPortalAssetFavicons  []resources.PortalAssetFaviconResource
-	desiredPortalEmailConfigs   []resources.PortalEmailConfigResource
-	desiredPortalEmailTemplates []resources.PortalEmailTemplateResource
+	desiredPortals                []resources.PortalResource
+	desiredPortalPages            []resources.PortalPageResource
+	desiredPortalSnippets         []resources.PortalSnippetResource
+	desiredPortalTeams            []resources.PortalTeamResource
+	desiredPortalTeamRoles        []resources.PortalTeamRoleResource
+	desiredPortalCustomizations   []resources.PortalCustomizationResource
+	desiredPortalAuthSettings     []resources.PortalAuthSettingsResource
+	desiredPortalCustomDomains    []resources.PortalCustomDomainResource
+	desiredPortalAssetLogos       []resources.PortalAssetLogoResource
+	desiredPortalAssetFavicons    []resources.PortalAssetFaviconResource
+	desiredPortalEmailConfigs     []resources.PortalEmailConfigResource
+	desiredPortalEmailTemplates   []resources.PortalEmailTemplateResource
+	desiredPortalAuditLogWebhooks []resources.PortalAuditLogWebhookResource
 }
 
 // NewPlanner creates a new planner
@@ -99,6 +137,13 @@ func NewPlanner(client *state.Client, logger *slog.Logger) *Planner {
 
 // GeneratePlan creates a plan from declarative configuration
 func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, opts Options) (*Plan, error) {
+	ctx, span := tracing.Start(ctx, tracing.SpanPlan, tracing.AttrMode.String(string(opts.Mode)))
+	plan, err := p.generatePlan(ctx, rs, opts)
+	tracing.End(span, err)
+	return plan, err
+}
+
+func (p *Planner) generatePlan(ctx context.Context, rs *resources.ResourceSet, opts Options) (*Plan, error) {
 	generator := opts.Generator
 	if generator == "" {
 		generator = defaultGenerator
@@ -106,12 +151,36 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 
 	// Create base plan
 	basePlan := NewPlan("1.0", generator, opts.Mode)
+	p.protectedDeletes = newProtectedDeletes(opts.ConfirmedProtectedDeletes)
+	p.moves = newResourceMoves(rs, opts.AppliedID)
+
+	// Planners compare !secret values with Konnect, the plan only holds their placeholders
+	secrets := p.readSecretsForPlanning(rs)
+	defer secrets.restoreResources()
 
 	// Pre-resolution phase: Resolve resource identities before planning
-	if err := p.resolveResourceIdentities(ctx, rs); err != nil {
+	resolveCtx, resolveSpan := tracing.Start(ctx, tracing.SpanResolve, tracing.AttrOperation.String("identities"))
+	err := p.resolveResourceIdentities(resolveCtx, rs)
+	tracing.End(resolveSpan, err)
+	if err != nil {
 		return nil, fmt.Errorf("failed to resolve resource identities: %w", err)
 	}
 
+	if opts.Mode != PlanModeDelete {
+		if err := p.validatePublicationVisibility(ctx, rs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.validateCustomResources(rs); err != nil {
+		return nil, err
+	}
+	if err := traceFetch(ctx, string(resources.ResourceTypeCustom), "*", func(ctx context.Context) error {
+		return p.resolveCustomResourceIdentities(ctx, rs, opts.Mode)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to resolve custom resource identities: %w", err)
+	}
+
 	// Initialize resolver with populated ResourceSet
 	p.resolver = NewReferenceResolver(p.client, rs)
 
@@ -153,11 +222,17 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 	for _, namespace := range namespaces {
 		// Create a namespace-specific planner context
 		namespacePlanner := &Planner{
-			client:      p.client,
-			logger:      p.logger,
-			resolver:    p.resolver,
-			depResolver: p.depResolver,
-			changeCount: p.changeCount,
+			client:       p.client,
+			logger:       p.logger,
+			resolver:     p.resolver,
+			depResolver:  p.depResolver,
+			changeCount:  p.changeCount,
+			customStates: p.customStates,
+			parallelism:  opts.Parallelism,
+			matchByName:  opts.MatchByName,
+
+			protectedDeletes: p.protectedDeletes,
+			moves:            p.moves,
 		}
 
 		// Initialize generic planner for namespace-specific planner
@@ -190,6 +265,7 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 		namespacePlanner.desiredPortalAssetFavicons = rs.PortalAssetFavicons
 		namespacePlanner.desiredPortalEmailConfigs = rs.PortalEmailConfigs
 		namespacePlanner.desiredPortalEmailTemplates = rs.PortalEmailTemplates
+		namespacePlanner.desiredPortalAuditLogWebhooks = rs.PortalAuditLogWebhooks
 
 		// Create a plan for this namespace
 		namespacePlan := NewPlan("1.0", generator, opts.Mode)
@@ -205,28 +281,33 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 		// Create planner context with namespace
 		plannerCtx := NewConfig(actualNamespace)
 
-		if err := namespacePlanner.authStrategyPlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "application_auth_strategy", namespacePlanner.authStrategyPlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan auth strategy changes for namespace %s: %w", namespace, err)
 		}
 
-		if err := namespacePlanner.controlPlanePlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "control_plane", namespacePlanner.controlPlanePlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan control plane changes for namespace %s: %w", namespace, err)
 		}
 
-		if err := namespacePlanner.portalPlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, ResourceTypePortal, namespacePlanner.portalPlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan portal changes for namespace %s: %w", namespace, err)
 		}
 
-		if err := namespacePlanner.catalogServicePlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "catalog_service", namespacePlanner.catalogServicePlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan catalog service changes for namespace %s: %w", namespace, err)
 		}
 
 		// Plan API changes (includes child resources)
-		if err := namespacePlanner.apiPlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "api", namespacePlanner.apiPlanner, plannerCtx, namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan API changes for namespace %s: %w", namespace, err)
 		}
 
-		if err := namespacePlanner.eventGatewayControlPlanePlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "event_gateway", namespacePlanner.eventGatewayControlPlanePlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf(
 				"failed to plan Event Gateway Control Plane changes for namespace %s: %w",
 				namespace,
@@ -234,10 +315,19 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 			)
 		}
 
-		if err := namespacePlanner.organizationTeamPlanner.PlanChanges(ctx, plannerCtx, namespacePlan); err != nil {
+		if err := planResourceChanges(ctx, "organization_team", namespacePlanner.organizationTeamPlanner, plannerCtx,
+			namespacePlan); err != nil {
 			return nil, fmt.Errorf("failed to plan Team changes for namespace %s: %w", namespace, err)
 		}
 
+		for _, kind := range custom.Kinds() {
+			handler, _ := custom.Lookup(kind)
+			if err := planResourceChanges(ctx, kind, newCustomResourcePlanner(base, handler), plannerCtx,
+				namespacePlan); err != nil {
+				return nil, fmt.Errorf("failed to plan %s changes for namespace %s: %w", kind, namespace, err)
+			}
+		}
+
 		// Merge namespace plan into base plan
 		basePlan.Changes = append(basePlan.Changes, namespacePlan.Changes...)
 		basePlan.Warnings = append(basePlan.Warnings, namespacePlan.Warnings...)
@@ -246,19 +336,37 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 		p.changeCount = namespacePlanner.changeCount
 	}
 
+	// Notification subscriptions belong to the current user rather than a namespace
+	if err := p.planNotificationSubscriptionChanges(ctx, rs, basePlan); err != nil {
+		return nil, fmt.Errorf("failed to plan notification subscription changes: %w", err)
+	}
+	p.protectedDeletes.mark(basePlan)
+	p.moves.mark(basePlan)
+
 	if err := p.planDeckDependencies(ctx, rs, basePlan, opts); err != nil {
 		return nil, err
 	}
 
+	if err := p.dropIgnoredUpdates(basePlan, rs, opts.IgnoreFields); err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeChange != nil {
+		filterChanges(basePlan, opts.IncludeChange)
+	}
+
 	// Update the base plan summary after merging all namespace changes
 	basePlan.UpdateSummary()
+	basePlan.Summary.NoOp = countUnchangedResources(basePlan, rs, opts.IncludeChange)
 
 	// Note: Orphan portal child resources (those referencing non-existent portals)
 	// are now handled within each namespace's processing using the namespace-filtered
 	// resource access methods.
 
 	// Resolve references for all changes
-	resolveResult, err := p.resolver.ResolveReferences(ctx, basePlan.Changes)
+	resolveCtx, resolveSpan = tracing.Start(ctx, tracing.SpanResolve, tracing.AttrOperation.String("references"))
+	resolveResult, err := p.resolver.ResolveReferences(resolveCtx, basePlan.Changes)
+	tracing.End(resolveSpan, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve references: %w", err)
 	}
@@ -284,6 +392,8 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 
 	// Ensure portal team roles depend on referenced APIs created in the same plan
 	adjustPortalTeamRoleDependencies(basePlan)
+	adjustOrganizationTeamRoleDependencies(basePlan)
+	adjustCustomResourceDependencies(basePlan)
 
 	// Resolve dependencies and calculate execution order
 	// Inject additional dependency constraints that span resource planners
@@ -309,6 +419,8 @@ func (p *Planner) GeneratePlan(ctx context.Context, rs *resources.ResourceSet, o
 		}
 	}
 
+	secrets.maskPlan(basePlan)
+
 	return basePlan, nil
 }
 
@@ -428,6 +540,8 @@ func (p *Planner) nextChangeID(action ActionType, resourceType string, ref strin
 		actionChar = "d"
 	case ActionExternalTool:
 		actionChar = "e"
+	case ActionSwitch:
+		actionChar = "s"
 	}
 	// Use temporary IDs that will be reassigned based on execution order
 	return fmt.Sprintf("temp-%d:%s:%s:%s", p.changeCount, actionChar, resourceType, ref)
@@ -483,6 +597,9 @@ func (p *Planner) validateProtection(
 	currentProtected bool,
 	action ActionType,
 ) error {
+	if action == ActionDelete && currentProtected && p.protectedDeletes.confirm(resourceName) {
+		return nil
+	}
 	if action == ActionUpdate || action == ActionDelete {
 		if currentProtected {
 			var actionVerb string
@@ -519,7 +636,7 @@ func (p *Planner) validateProtectionWithChange(
 		return fmt.Errorf("%s %q is protected and cannot be updated",
 			resourceType, resourceName)
 	}
-	if action == ActionDelete && currentProtected {
+	if action == ActionDelete && currentProtected && !p.protectedDeletes.confirm(resourceName) {
 		return fmt.Errorf("%s %q is protected and cannot be deleted",
 			resourceType, resourceName)
 	}
@@ -588,7 +705,7 @@ func (p *Planner) resolveResourceIdentities(ctx context.Context, rs *resources.R
 		return fmt.Errorf("failed to resolve gateway service identities: %w", err)
 	}
 
-	if err := p.resolveAPIImplementationServiceReferences(rs); err != nil {
+	if err := p.resolveAPIImplementationServiceReferences(ctx, rs); err != nil {
 		return fmt.Errorf("failed to resolve API implementation services: %w", err)
 	}
 
@@ -819,7 +936,9 @@ func (p *Planner) resolveGatewayServiceIdentities(
 		service.SetResolvedControlPlaneID(cpID)
 
 		if !service.IsExternal() {
-			// Managed services will be resolved when supported; for now record CP ID only.
+			if err := p.bindManagedGatewayService(ctx, service, cpID, serviceCache); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -898,6 +1017,44 @@ func (p *Planner) resolveGatewayServiceIdentities(
 	return nil
 }
 
+// bindManagedGatewayService binds a managed service to the service of the same name
+// in its control plane, if there is one, so references to it resolve to its ID
+func (p *Planner) bindManagedGatewayService(
+	ctx context.Context,
+	service *resources.GatewayServiceResource,
+	cpID string,
+	serviceCache map[string][]state.GatewayService,
+) error {
+	if cpID == "" || service.Service == nil || service.Service.Name == nil {
+		return nil
+	}
+
+	available, ok := serviceCache[cpID]
+	if !ok {
+		list, err := p.client.ListGatewayServices(ctx, cpID)
+		if err != nil {
+			if state.IsAPIClientError(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to list gateway services for control plane %s: %w", cpID, err)
+		}
+		available = list
+		serviceCache[cpID] = available
+	}
+
+	for i := range available {
+		if service.TryMatchKonnectResource(&available[i]) {
+			p.logger.Debug("Resolved managed gateway service",
+				slog.String("ref", service.GetRef()),
+				slog.String("service_id", service.GetKonnectID()),
+				slog.String("control_plane_id", cpID),
+			)
+			return nil
+		}
+	}
+	return nil
+}
+
 func (p *Planner) resolveGatewayServiceControlPlaneID(
 	service *resources.GatewayServiceResource,
 	cpByRef map[string]*resources.ControlPlaneResource,
@@ -928,7 +1085,8 @@ func (p *Planner) resolveGatewayServiceControlPlaneID(
 	}
 
 	if cpResource.GetKonnectID() == "" {
-		if cpResource.HasDeckConfig() {
+		// Managed services of a control plane created by the plan are created after it
+		if cpResource.HasDeckConfig() || !service.IsExternal() {
 			return "", nil
 		}
 		return "", fmt.Errorf(
@@ -1011,7 +1169,7 @@ func (p *Planner) matchGatewayService(
 	return nil, fmt.Errorf("external gateway_service %s: invalid _external configuration", service.GetRef())
 }
 
-func (p *Planner) resolveAPIImplementationServiceReferences(rs *resources.ResourceSet) error {
+func (p *Planner) resolveAPIImplementationServiceReferences(ctx context.Context, rs *resources.ResourceSet) error {
 	if len(rs.APIImplementations) == 0 {
 		return nil
 	}
@@ -1028,6 +1186,9 @@ func (p *Planner) resolveAPIImplementationServiceReferences(rs *resources.Resour
 		controlPlaneByRef[cp.GetRef()] = cp
 	}
 
+	// Gateway services of the control planes of implementations naming their service
+	servicesByControlPlane := make(map[string][]state.GatewayService)
+
 	for i := range rs.APIImplementations {
 		impl := &rs.APIImplementations[i]
 		service := impl.ServiceReference.GetService()
@@ -1044,6 +1205,12 @@ func (p *Planner) resolveAPIImplementationServiceReferences(rs *resources.Resour
 				slog.String("control_plane_id", service.ControlPlaneID),
 			)
 		}
+		if impl.ServiceName != "" {
+			if err := p.lookupImplementationService(ctx, impl, serviceByRef, controlPlaneByRef,
+				servicesByControlPlane); err != nil {
+				return err
+			}
+		}
 		if err := p.normalizeAPIImplementationService(impl, serviceByRef, controlPlaneByRef); err != nil {
 			return err
 		}
@@ -1123,6 +1290,61 @@ func (p *Planner) normalizeAPIImplementationService(
 	return nil
 }
 
+// lookupImplementationService sets the service.id of an implementation naming its
+// gateway service: a service of the configuration with that name on the same control
+// plane is referenced, any other is looked up in Konnect, so services not managed by
+// kongctl can implement APIs too
+func (p *Planner) lookupImplementationService(
+	ctx context.Context,
+	impl *resources.APIImplementationResource,
+	serviceByRef map[string]*resources.GatewayServiceResource,
+	controlPlaneByRef map[string]*resources.ControlPlaneResource,
+	servicesByControlPlane map[string][]state.GatewayService,
+) error {
+	service := impl.ServiceReference.GetService()
+	if service == nil {
+		return nil
+	}
+	implRef := impl.GetRef()
+	if implRef == "" && impl.API != "" {
+		implRef = fmt.Sprintf("%s implementation", impl.API)
+	}
+	name := impl.ServiceName
+	controlPlane := normalizeControlPlaneRef(strings.TrimSpace(service.ControlPlaneID))
+
+	for _, svc := range serviceByRef {
+		if svc.GetMoniker() == name && normalizeControlPlaneRef(svc.ControlPlane) == controlPlane {
+			service.ID = svc.GetRef()
+			impl.ServiceName = ""
+			return nil
+		}
+	}
+
+	controlPlaneID, err := p.resolveImplementationControlPlaneID(controlPlane, nil, controlPlaneByRef, implRef)
+	if err != nil {
+		return fmt.Errorf("%w; gateway service %q can only be looked up by name on an existing control plane",
+			err, name)
+	}
+	services, ok := servicesByControlPlane[controlPlaneID]
+	if !ok {
+		services, err = p.client.ListGatewayServices(ctx, controlPlaneID)
+		if err != nil {
+			return fmt.Errorf("api_implementation %s: failed to list gateway services of control plane %s: %w",
+				implRef, controlPlaneID, err)
+		}
+		servicesByControlPlane[controlPlaneID] = services
+	}
+	for _, svc := range services {
+		if svc.Name == name {
+			service.ID = svc.ID
+			impl.ServiceName = ""
+			return nil
+		}
+	}
+	return fmt.Errorf("api_implementation %s: gateway service %q not found on control plane %s",
+		implRef, name, controlPlaneID)
+}
+
 func (p *Planner) resolveGatewayServiceReference(
 	value string,
 	serviceByRef map[string]*resources.GatewayServiceResource,
@@ -1399,6 +1621,80 @@ func (p *Planner) resolveAuthStrategyIdentities(
 	return nil
 }
 
+// planResourceChanges runs a resource planner inside a fetch span
+func planResourceChanges(
+	ctx context.Context, resourceType string, rp ResourcePlanner, plannerCtx *Config, plan *Plan,
+) error {
+	return traceFetch(ctx, resourceType, plannerCtx.Namespace, func(ctx context.Context) error {
+		return rp.PlanChanges(ctx, plannerCtx, plan)
+	})
+}
+
+// traceFetch runs fn, which reads current state for resourceType in namespace, inside a fetch span
+func traceFetch(ctx context.Context, resourceType, namespace string, fn func(context.Context) error) error {
+	ctx, span := tracing.Start(ctx, tracing.SpanFetch,
+		tracing.AttrResourceType.String(resourceType),
+		tracing.AttrNamespace.String(namespace),
+	)
+	err := fn(ctx)
+	tracing.End(span, err)
+	return err
+}
+
+// filterChanges drops changes the predicate rejects, along with dependencies on them
+func filterChanges(plan *Plan, include func(change PlannedChange) bool) {
+	kept := plan.Changes[:0]
+	dropped := make(map[string]bool)
+	for _, change := range plan.Changes {
+		if include(change) {
+			kept = append(kept, change)
+		} else {
+			dropped[change.ID] = true
+		}
+	}
+	if len(dropped) == 0 {
+		return
+	}
+	plan.Changes = kept
+
+	for i := range plan.Changes {
+		plan.Changes[i].DependsOn = slices.DeleteFunc(plan.Changes[i].DependsOn, func(id string) bool {
+			return dropped[id]
+		})
+	}
+	plan.Warnings = slices.DeleteFunc(plan.Warnings, func(w PlanWarning) bool {
+		return dropped[w.ChangeID]
+	})
+}
+
+// countUnchangedResources counts the managed resources of rs without a planned change.
+// Resources the change filter excludes are not counted.
+func countUnchangedResources(plan *Plan, rs *resources.ResourceSet, include func(change PlannedChange) bool) int {
+	if rs == nil {
+		return 0
+	}
+	changed := make(map[string]bool, len(plan.Changes))
+	for _, change := range plan.Changes {
+		changed[change.ResourceType+"/"+change.ResourceRef] = true
+	}
+	count := 0
+	rs.ForEachResource(func(res resources.Resource) bool {
+		if external, ok := res.(interface{ IsExternal() bool }); ok && external.IsExternal() {
+			return true
+		}
+		resourceType, ref := string(res.GetType()), res.GetRef()
+		if changed[resourceType+"/"+ref] {
+			return true
+		}
+		if include != nil && !include(PlannedChange{ResourceType: resourceType, ResourceRef: ref, Action: ActionUpdate}) {
+			return true
+		}
+		count++
+		return true
+	})
+	return count
+}
+
 // getResourceNamespaces extracts all unique namespaces from the desired resources
 func (p *Planner) getResourceNamespaces(rs *resources.ResourceSet) []string {
 	namespaceSet := make(map[string]bool)
@@ -1447,6 +1743,11 @@ func (p *Planner) getResourceNamespaces(rs *resources.ResourceSet) []string {
 		namespaceSet[ns] = true
 	}
 
+	for _, res := range rs.CustomResources {
+		ns := resources.GetNamespace(res.Kongctl)
+		namespaceSet[ns] = true
+	}
+
 	// Convert set to sorted slice for consistent ordering
 	namespaces := make([]string, 0, len(namespaceSet))
 	for ns := range namespaceSet {
@@ -1113,6 +1113,67 @@ func TestGeneratePlan_ProtectedResourceFailsDelete(t *testing.T) {
 	mockAppAuthAPI.AssertExpectations(t)
 }
 
+func TestGeneratePlan_ConfirmedProtectedDelete(t *testing.T) {
+	ctx := context.Background()
+	mockPortalAPI := new(MockPortalAPI)
+	mockAPIAPI := new(MockAPIAPI)
+	mockAppAuthAPI := new(MockAppAuthStrategiesAPI)
+	client := state.NewClient(state.ClientConfig{
+		PortalAPI:  mockPortalAPI,
+		APIAPI:     mockAPIAPI,
+		AppAuthAPI: mockAppAuthAPI,
+	})
+
+	mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: []kkComps.ListPortalsResponsePortal{
+				{
+					ID:   "protected-id",
+					Name: "protected-portal",
+					Labels: map[string]string{
+						labels.NamespaceKey: "default",
+						labels.ProtectedKey: "true",
+					},
+				},
+			},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil)
+	mockAppAuthAPI.On("ListAppAuthStrategies", mock.Anything, mock.Anything).
+		Return(&kkOps.ListAppAuthStrategiesResponse{
+			ListAppAuthStrategiesResponse: &kkComps.ListAppAuthStrategiesResponse{
+				Data: []kkComps.AppAuthStrategy{},
+				Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+			},
+		}, nil)
+
+	mockAPIAPI.On("ListApis", mock.Anything, mock.Anything).Return(&kkOps.ListApisResponse{
+		ListAPIResponse: &kkComps.ListAPIResponse{
+			Data: []kkComps.APIResponseSchema{},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+		},
+	}, nil)
+
+	rs := &resources.ResourceSet{Portals: []resources.PortalResource{}}
+
+	_, err := NewPlanner(client, slog.Default()).GeneratePlan(ctx, rs,
+		Options{Mode: PlanModeSync, ConfirmedProtectedDeletes: []string{"other-portal"}})
+	require.Error(t, err, "confirming another resource does not delete this one")
+	assert.Contains(t, err.Error(), "portal \"protected-portal\" is protected and cannot be deleted")
+
+	plan, err := NewPlanner(client, slog.Default()).GeneratePlan(ctx, rs,
+		Options{Mode: PlanModeSync, ConfirmedProtectedDeletes: []string{"protected-portal"}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	change := plan.Changes[0]
+	assert.Equal(t, ActionDelete, change.Action)
+	assert.Equal(t, "protected-id", change.ResourceID)
+	assert.True(t, change.DeleteProtected)
+	require.Len(t, plan.Warnings, 1)
+	assert.Equal(t, change.ID, plan.Warnings[0].ChangeID)
+	assert.Contains(t, plan.Warnings[0].Message, "is protected and will be deleted")
+}
+
 func TestGeneratePlan_ProtectionChangeAllowed(t *testing.T) {
 	ctx := context.Background()
 	mockPortalAPI := new(MockPortalAPI)
@@ -1351,7 +1412,291 @@ func TestGeneratePlan_SyncDeletesRespectAuthStrategyDependencies(t *testing.T) {
 	mockAppAuthAPI.AssertExpectations(t)
 }
 
+func TestGeneratePlan_SyncDeletesPublicationsRemovedFromConfig(t *testing.T) {
+	now := time.Now()
+	managed := map[string]string{labels.NamespaceKey: "default"}
+
+	tests := []struct {
+		name         string
+		publications []resources.APIPublicationResource
+		wantDeleted  []string
+	}{
+		{
+			name:         "one publication removed",
+			publications: []resources.APIPublicationResource{{Ref: "orders-dev", API: "orders", PortalID: "dev"}},
+			wantDeleted:  []string{"api-1:portal-partner"},
+		},
+		{
+			name:        "all publications removed",
+			wantDeleted: []string{"api-1:portal-dev", "api-1:portal-partner"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPortalAPI := new(MockPortalAPI)
+			mockAPIAPI := new(MockAPIAPI)
+			mockAppAuthAPI := new(MockAppAuthStrategiesAPI)
+
+			mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+				ListPortalsResponse: &kkComps.ListPortalsResponse{
+					Data: []kkComps.ListPortalsResponsePortal{
+						newListPortal("portal-dev", "dev", managed),
+						newListPortal("portal-partner", "partner", managed),
+					},
+					Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 2}},
+				},
+			}, nil)
+			// The billing API is not managed by kongctl, so its publications are left alone
+			mockAPIAPI.On("ListApis", mock.Anything, mock.Anything).Return(&kkOps.ListApisResponse{
+				ListAPIResponse: &kkComps.ListAPIResponse{
+					Data: []kkComps.APIResponseSchema{
+						{ID: "api-1", Name: "orders", Labels: managed, CreatedAt: now, UpdatedAt: now},
+						{ID: "api-2", Name: "billing", CreatedAt: now, UpdatedAt: now},
+					},
+					Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 2}},
+				},
+			}, nil)
+			mockAppAuthAPI.On("ListAppAuthStrategies", mock.Anything, mock.Anything).
+				Return(&kkOps.ListAppAuthStrategiesResponse{
+					ListAppAuthStrategiesResponse: &kkComps.ListAppAuthStrategiesResponse{
+						Data: []kkComps.AppAuthStrategy{},
+						Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+					},
+				}, nil)
+
+			publicationResponse := &kkOps.ListAPIPublicationsResponse{
+				ListAPIPublicationResponse: &kkComps.ListAPIPublicationResponse{
+					Data: []kkComps.APIPublicationListItem{
+						{APIID: "api-1", PortalID: "portal-dev", CreatedAt: now, UpdatedAt: now},
+						{APIID: "api-1", PortalID: "portal-partner", CreatedAt: now, UpdatedAt: now},
+					},
+					Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 2}},
+				},
+			}
+			client := state.NewClient(state.ClientConfig{
+				PortalAPI:            mockPortalAPI,
+				APIAPI:               mockAPIAPI,
+				AppAuthAPI:           mockAppAuthAPI,
+				APIPublicationAPI:    &stubAPIPublicationAPI{response: publicationResponse},
+				APIVersionAPI:        &stubAPIVersionAPI{},
+				APIImplementationAPI: &stubAPIImplementationAPI{},
+				APIDocumentAPI:       &stubAPIDocumentAPI{},
+			})
+
+			rs := &resources.ResourceSet{
+				APIs: []resources.APIResource{{
+					BaseResource:     resources.BaseResource{Ref: "orders"},
+					CreateAPIRequest: kkComps.CreateAPIRequest{Name: "orders"},
+				}},
+				APIPublications: tt.publications,
+			}
+
+			plan, err := NewPlanner(client, slog.Default()).GeneratePlan(context.Background(), rs,
+				Options{Mode: PlanModeSync})
+			require.NoError(t, err)
+
+			var deleted []string
+			for _, change := range plan.Changes {
+				if change.ResourceType == "api_publication" && change.Action == ActionDelete {
+					deleted = append(deleted, change.ResourceID)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestGeneratePlan_AppliedPublicationPlansNoChanges(t *testing.T) {
+	now := time.Now()
+	managed := map[string]string{labels.NamespaceKey: "default"}
+	visibility := kkComps.APIPublicationVisibilityPrivate
+
+	mockPortalAPI := new(MockPortalAPI)
+	mockAPIAPI := new(MockAPIAPI)
+	mockAppAuthAPI := new(MockAppAuthStrategiesAPI)
+	mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: []kkComps.ListPortalsResponsePortal{newListPortal("portal-dev", "dev", managed)},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil)
+	mockAPIAPI.On("ListApis", mock.Anything, mock.Anything).Return(&kkOps.ListApisResponse{
+		ListAPIResponse: &kkComps.ListAPIResponse{
+			Data: []kkComps.APIResponseSchema{
+				{ID: "api-1", Name: "orders", Labels: managed, CreatedAt: now, UpdatedAt: now},
+			},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil)
+	mockAppAuthAPI.On("ListAppAuthStrategies", mock.Anything, mock.Anything).
+		Return(&kkOps.ListAppAuthStrategiesResponse{
+			ListAppAuthStrategiesResponse: &kkComps.ListAppAuthStrategiesResponse{
+				Data: []kkComps.AppAuthStrategy{},
+				Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+			},
+		}, nil)
+
+	publications := &stubAPIPublicationAPI{response: &kkOps.ListAPIPublicationsResponse{
+		ListAPIPublicationResponse: &kkComps.ListAPIPublicationResponse{
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+		},
+	}}
+	client := state.NewClient(state.ClientConfig{
+		PortalAPI:            mockPortalAPI,
+		APIAPI:               mockAPIAPI,
+		AppAuthAPI:           mockAppAuthAPI,
+		APIPublicationAPI:    publications,
+		APIVersionAPI:        &stubAPIVersionAPI{},
+		APIImplementationAPI: &stubAPIImplementationAPI{},
+		APIDocumentAPI:       &stubAPIDocumentAPI{},
+	})
+
+	newResourceSet := func() *resources.ResourceSet {
+		return &resources.ResourceSet{
+			APIs: []resources.APIResource{{
+				BaseResource:     resources.BaseResource{Ref: "orders"},
+				CreateAPIRequest: kkComps.CreateAPIRequest{Name: "orders"},
+			}},
+			APIPublications: []resources.APIPublicationResource{{
+				APIPublication: kkComps.APIPublication{Visibility: &visibility},
+				Ref:            "orders-dev",
+				API:            "orders",
+				PortalID:       "dev",
+			}},
+		}
+	}
+
+	plan, err := NewPlanner(client, slog.Default()).GeneratePlan(context.Background(), newResourceSet(),
+		Options{Mode: PlanModeApply})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, ActionCreate, plan.Changes[0].Action)
+
+	// Konnect stores the publication with the defaults and omits the private visibility
+	publications.response = &kkOps.ListAPIPublicationsResponse{
+		ListAPIPublicationResponse: &kkComps.ListAPIPublicationResponse{
+			Data: []kkComps.APIPublicationListItem{
+				{APIID: "api-1", PortalID: "portal-dev", CreatedAt: now, UpdatedAt: now},
+			},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}
+
+	plan, err = NewPlanner(client, slog.Default()).GeneratePlan(context.Background(), newResourceSet(),
+		Options{Mode: PlanModeApply})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+}
+
+func TestFilterChanges(t *testing.T) {
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	plan.AddChange(PlannedChange{ID: "1:c:portal:keep", Action: ActionCreate, ResourceRef: "keep"})
+	plan.AddChange(PlannedChange{ID: "2:d:portal:drop", Action: ActionDelete, ResourceRef: "drop"})
+	plan.AddChange(PlannedChange{
+		ID:          "3:c:portal_page:page",
+		Action:      ActionCreate,
+		ResourceRef: "page",
+		DependsOn:   []string{"1:c:portal:keep", "2:d:portal:drop"},
+	})
+	plan.AddWarning("2:d:portal:drop", "dropped")
+	plan.AddWarning("3:c:portal_page:page", "kept")
+
+	filterChanges(plan, func(change PlannedChange) bool {
+		return change.ResourceRef != "drop"
+	})
+
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, "1:c:portal:keep", plan.Changes[0].ID)
+	assert.Equal(t, []string{"1:c:portal:keep"}, plan.Changes[1].DependsOn)
+	assert.Equal(t, []PlanWarning{{ChangeID: "3:c:portal_page:page", Message: "kept"}}, plan.Warnings)
+}
+
+func TestCountUnchangedResources(t *testing.T) {
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	plan.AddChange(PlannedChange{
+		ID: "1:u:portal:changed", Action: ActionUpdate, ResourceType: "portal", ResourceRef: "changed",
+	})
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{BaseResource: resources.BaseResource{Ref: "changed"}},
+			{BaseResource: resources.BaseResource{Ref: "unchanged"}},
+			{BaseResource: resources.BaseResource{Ref: "external"}, External: &resources.ExternalBlock{ID: "portal-1"}},
+		},
+		APIs: []resources.APIResource{{BaseResource: resources.BaseResource{Ref: "api"}}},
+	}
+
+	assert.Equal(t, 2, countUnchangedResources(plan, rs, nil))
+	assert.Equal(t, 1, countUnchangedResources(plan, rs, func(change PlannedChange) bool {
+		return change.ResourceType == "portal"
+	}))
+}
+
 // Test helpers
 func ptrString(s string) *string {
 	return &s
 }
+
+func TestResolveAPIImplementationServiceReferences_ByName(t *testing.T) {
+	const (
+		cpID       = "11111111-1111-1111-1111-111111111111"
+		ordersID   = "22222222-2222-2222-2222-222222222222"
+		legacyID   = "33333333-3333-3333-3333-333333333333"
+		unmanaged  = "legacy"
+		serviceKey = "orders-svc"
+	)
+	cp := resources.ControlPlaneResource{
+		CreateControlPlaneRequest: kkComps.CreateControlPlaneRequest{Name: "cp"},
+		BaseResource:              resources.BaseResource{Ref: "cp"},
+	}
+	cp.TryMatchKonnectResource(state.ControlPlane{ControlPlane: kkComps.ControlPlane{ID: cpID, Name: "cp"}})
+	orders := gatewayServiceResource("orders", "cp", "orders.internal", 8080)
+	orders.Service.Name = strPtr(serviceKey)
+	orders.TryMatchKonnectResource(state.GatewayService{ID: ordersID, Name: serviceKey, ControlPlaneID: cpID})
+
+	implementation := func(ref, serviceName string) resources.APIImplementationResource {
+		return resources.APIImplementationResource{
+			Ref: ref,
+			API: "api",
+			APIImplementation: kkComps.APIImplementation{
+				Type: kkComps.APIImplementationTypeServiceReference,
+				ServiceReference: &kkComps.ServiceReference{
+					Service: &kkComps.APIImplementationService{ControlPlaneID: "cp"},
+				},
+			},
+			ServiceName: serviceName,
+		}
+	}
+	newPlanner := func() *Planner {
+		return &Planner{
+			client: state.NewClient(state.ClientConfig{
+				// Services of the configuration are referenced without a lookup
+				GatewayServiceAPI: &stubGatewayServiceAPI{services: []kkComps.ServiceOutput{
+					{ID: strPtr(legacyID), Name: strPtr(unmanaged)},
+				}},
+			}),
+			logger: slog.Default(),
+		}
+	}
+
+	rs := &resources.ResourceSet{
+		ControlPlanes:   []resources.ControlPlaneResource{cp},
+		GatewayServices: []resources.GatewayServiceResource{orders},
+		APIImplementations: []resources.APIImplementationResource{
+			implementation("managed", serviceKey),
+			implementation("unmanaged", unmanaged),
+		},
+	}
+	require.NoError(t, newPlanner().resolveAPIImplementationServiceReferences(context.Background(), rs))
+	want := map[string]string{"managed": ordersID, "unmanaged": legacyID}
+	for _, impl := range rs.APIImplementations {
+		service := impl.ServiceReference.GetService()
+		assert.Equal(t, want[impl.Ref], service.ID, impl.Ref)
+		assert.Equal(t, cpID, service.ControlPlaneID, impl.Ref)
+		assert.Empty(t, impl.ServiceName, impl.Ref)
+	}
+
+	rs.APIImplementations = []resources.APIImplementationResource{implementation("missing", "missing")}
+	err := newPlanner().resolveAPIImplementationServiceReferences(context.Background(), rs)
+	assert.EqualError(t, err, `api_implementation missing: gateway service "missing" not found on control plane `+cpID)
+}
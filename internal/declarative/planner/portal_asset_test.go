@@ -4,10 +4,17 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"testing"
 
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
 	kkErrors "github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -85,3 +92,159 @@ func TestPortalAssetNeedsUpdate_Change(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, needsUpdate)
 }
+
+type stubPortalAssetsAPI struct {
+	logo string
+}
+
+func (s *stubPortalAssetsAPI) GetPortalAssetLogo(
+	_ context.Context, _ string, _ ...kkOps.Option,
+) (*kkOps.GetPortalAssetLogoResponse, error) {
+	return &kkOps.GetPortalAssetLogoResponse{
+		PortalAssetResponse: &kkComps.PortalAssetResponse{Data: s.logo},
+	}, nil
+}
+
+func (s *stubPortalAssetsAPI) GetPortalAssetLogoRaw(
+	_ context.Context, _ string, _ ...kkOps.Option,
+) (*kkOps.GetPortalAssetLogoRawResponse, error) {
+	return nil, nil
+}
+
+func (s *stubPortalAssetsAPI) ReplacePortalAssetLogo(
+	_ context.Context, _ string, _ *kkComps.ReplacePortalImageAsset, _ ...kkOps.Option,
+) (*kkOps.ReplacePortalAssetLogoResponse, error) {
+	return nil, nil
+}
+
+func (s *stubPortalAssetsAPI) GetPortalAssetFavicon(
+	_ context.Context, _ string, _ ...kkOps.Option,
+) (*kkOps.GetPortalAssetFaviconResponse, error) {
+	return nil, nil
+}
+
+func (s *stubPortalAssetsAPI) GetPortalAssetFaviconRaw(
+	_ context.Context, _ string, _ ...kkOps.Option,
+) (*kkOps.GetPortalAssetFaviconRawResponse, error) {
+	return nil, nil
+}
+
+func (s *stubPortalAssetsAPI) ReplacePortalAssetFavicon(
+	_ context.Context, _ string, _ *kkComps.ReplacePortalImageAsset, _ ...kkOps.Option,
+) (*kkOps.ReplacePortalAssetFaviconResponse, error) {
+	return nil, nil
+}
+
+func newPortalAssetTestPlanner(t *testing.T, currentLogo string) *Planner {
+	t.Helper()
+
+	mockPortalAPI := new(MockPortalAPI)
+	mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: []kkComps.ListPortalsResponsePortal{
+				newListPortal("portal-123", "dev-portal", map[string]string{
+					labels.NamespaceKey: "default",
+				}),
+			},
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+		},
+	}, nil)
+
+	client := state.NewClient(state.ClientConfig{
+		PortalAPI: mockPortalAPI,
+		AssetsAPI: &stubPortalAssetsAPI{logo: currentLogo},
+	})
+
+	p := NewPlanner(client, slog.Default())
+	p.desiredPortals = []resources.PortalResource{
+		{
+			BaseResource: resources.BaseResource{Ref: "dev-portal"},
+			CreatePortal: kkComps.CreatePortal{Name: "dev-portal"},
+		},
+	}
+	return p
+}
+
+func TestPlanPortalAssetLogosChanges_SwappedLogo(t *testing.T) {
+	t.Parallel()
+
+	current := makeDataURL("image/png", []byte("old-logo"))
+	desired := makeDataURL("image/png", []byte("new-logo"))
+	p := newPortalAssetTestPlanner(t, current)
+
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	err := p.planPortalAssetLogosChanges(context.Background(), NewConfig("default"), "default",
+		[]resources.PortalAssetLogoResource{{Ref: "dev-portal-logo", Portal: "dev-portal", File: &desired}}, plan)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Changes, 1)
+	change := plan.Changes[0]
+	require.Equal(t, ResourceTypePortalAssetLogo, change.ResourceType)
+	require.Equal(t, ActionUpdate, change.Action)
+	require.Equal(t, desired, change.Fields["data_url"])
+	require.NotNil(t, change.Parent)
+	require.Equal(t, "portal-123", change.Parent.ID)
+}
+
+func TestPlanPortalAssetLogosChanges_SameLogoDifferentMime(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte("same-logo")
+	current := makeDataURL("image/png", payload)
+	desired := makeDataURL("application/octet-stream", payload)
+	p := newPortalAssetTestPlanner(t, current)
+
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	err := p.planPortalAssetLogosChanges(context.Background(), NewConfig("default"), "default",
+		[]resources.PortalAssetLogoResource{{Ref: "dev-portal-logo", Portal: "dev-portal", File: &desired}}, plan)
+	require.NoError(t, err)
+	require.Empty(t, plan.Changes)
+}
+
+func TestDataURLDigest_DiffersOnContent(t *testing.T) {
+	t.Parallel()
+
+	first, err := dataURLDigest(makeDataURL("image/png", []byte("a")))
+	require.NoError(t, err)
+	second, err := dataURLDigest(makeDataURL("image/png", []byte("b")))
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+	require.Len(t, first, 64)
+
+	_, err = dataURLDigest("not-a-data-url")
+	require.Error(t, err)
+}
+
+func TestShouldUpdatePortalCustomization_ThemeColor(t *testing.T) {
+	t.Parallel()
+
+	p := &Planner{}
+	oldColor := "#000000"
+	newColor := "#0055ff"
+	css := ":root { --radius: 4px; }"
+
+	current := &kkComps.PortalCustomization{
+		Theme: &kkComps.Theme{Colors: &kkComps.Colors{Primary: &oldColor}},
+		CSS:   &css,
+	}
+	desired := resources.PortalCustomizationResource{
+		Ref:    "dev-portal-customization",
+		Portal: "dev-portal",
+		PortalCustomization: kkComps.PortalCustomization{
+			Theme: &kkComps.Theme{Colors: &kkComps.Colors{Primary: &newColor}},
+			CSS:   &css,
+		},
+	}
+
+	needsUpdate, fields := p.shouldUpdatePortalCustomization(current, desired)
+	require.True(t, needsUpdate)
+	require.NotContains(t, fields, "css")
+	theme, ok := fields["theme"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"primary": newColor}, theme["colors"])
+
+	desired.Theme.Colors.Primary = &oldColor
+	needsUpdate, fields = p.shouldUpdatePortalCustomization(current, desired)
+	require.False(t, needsUpdate)
+	require.Empty(t, fields)
+}
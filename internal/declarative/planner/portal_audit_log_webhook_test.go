@@ -0,0 +1,154 @@
+package planner
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPortalAuditLogsAPI struct {
+	current *kkComps.PortalAuditLogWebhook
+}
+
+func (s *stubPortalAuditLogsAPI) GetPortalAuditLogWebhook(
+	_ context.Context,
+	_ string,
+	_ ...kkOps.Option,
+) (*kkOps.GetPortalAuditLogWebhookResponse, error) {
+	webhook := s.current
+	if webhook == nil {
+		webhook = &kkComps.PortalAuditLogWebhook{}
+	}
+	return &kkOps.GetPortalAuditLogWebhookResponse{StatusCode: 200, PortalAuditLogWebhook: webhook}, nil
+}
+
+func (s *stubPortalAuditLogsAPI) UpdatePortalAuditLogWebhook(
+	_ context.Context,
+	_ string,
+	_ *kkComps.UpdatePortalAuditLogWebhook,
+	_ ...kkOps.Option,
+) (*kkOps.UpdatePortalAuditLogWebhookResponse, error) {
+	return nil, nil
+}
+
+func (s *stubPortalAuditLogsAPI) DeletePortalAuditLogWebhook(
+	_ context.Context,
+	_ string,
+	_ ...kkOps.Option,
+) (*kkOps.DeletePortalAuditLogWebhookResponse, error) {
+	return nil, nil
+}
+
+func desiredAuditLogWebhook(enabled bool, destinationID string) []resources.PortalAuditLogWebhookResource {
+	return []resources.PortalAuditLogWebhookResource{
+		{
+			Ref:    "portal-1-audit",
+			Portal: "portal-1",
+			UpdatePortalAuditLogWebhook: kkComps.UpdatePortalAuditLogWebhook{
+				Enabled:               &enabled,
+				AuditLogDestinationID: &destinationID,
+			},
+		},
+	}
+}
+
+func TestPlanPortalAuditLogWebhookChanges(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	destinationID := "dest-1"
+	configured := &kkComps.PortalAuditLogWebhook{
+		Enabled:               &enabled,
+		AuditLogDestinationID: &destinationID,
+	}
+
+	tests := []struct {
+		name    string
+		mode    PlanMode
+		current *kkComps.PortalAuditLogWebhook
+		desired []resources.PortalAuditLogWebhookResource
+		action  ActionType
+	}{
+		{
+			name:    "create when unconfigured",
+			mode:    PlanModeApply,
+			desired: desiredAuditLogWebhook(true, "dest-1"),
+			action:  ActionCreate,
+		},
+		{
+			name:    "no change when state matches",
+			mode:    PlanModeApply,
+			current: configured,
+			desired: desiredAuditLogWebhook(true, "dest-1"),
+		},
+		{
+			name:    "update when destination drifts",
+			mode:    PlanModeApply,
+			current: configured,
+			desired: desiredAuditLogWebhook(true, "dest-2"),
+			action:  ActionUpdate,
+		},
+		{
+			name:    "update when disabled",
+			mode:    PlanModeApply,
+			current: configured,
+			desired: desiredAuditLogWebhook(false, "dest-1"),
+			action:  ActionUpdate,
+		},
+		{
+			name:    "apply keeps undeclared webhook",
+			mode:    PlanModeApply,
+			current: configured,
+		},
+		{
+			name:    "sync deletes undeclared webhook",
+			mode:    PlanModeSync,
+			current: configured,
+			action:  ActionDelete,
+		},
+		{
+			name: "sync ignores unconfigured webhook",
+			mode: PlanModeSync,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			planner := &Planner{
+				client: state.NewClient(state.ClientConfig{
+					PortalAuditLogsAPI: &stubPortalAuditLogsAPI{current: tt.current},
+				}),
+				logger: slog.Default(),
+			}
+
+			plan := NewPlan("1.0", "test", tt.mode)
+			err := planner.planPortalAuditLogWebhookChanges(
+				context.Background(), DefaultNamespace, "portal-id", "portal-1", tt.desired, plan,
+			)
+			require.NoError(t, err)
+
+			if tt.action == "" {
+				assert.Empty(t, plan.Changes)
+				return
+			}
+			require.Len(t, plan.Changes, 1)
+			change := plan.Changes[0]
+			assert.Equal(t, tt.action, change.Action)
+			assert.Equal(t, ResourceTypePortalAuditLogWebhook, change.ResourceType)
+			assert.Equal(t, "portal-id", change.References["portal_id"].ID)
+			if tt.action != ActionDelete {
+				assert.Equal(t, *tt.desired[0].Enabled, change.Fields["enabled"])
+				assert.Equal(t, *tt.desired[0].AuditLogDestinationID, change.Fields["audit_log_destination_id"])
+			}
+		})
+	}
+}
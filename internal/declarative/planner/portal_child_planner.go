@@ -1,9 +1,10 @@
 package planner
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -241,7 +242,7 @@ func (p *Planner) shouldUpdatePortalAuthSettings(
 	}
 
 	if desired.OidcScopes != nil {
-		if current.OidcConfig == nil || !p.compareStringSlices(current.OidcConfig.Scopes, desired.OidcScopes) {
+		if current.OidcConfig == nil || !stringSetsEqual(current.OidcConfig.Scopes, desired.OidcScopes) {
 			updates["oidc_scopes"] = desired.OidcScopes
 		}
 	}
@@ -937,18 +938,30 @@ func (p *Planner) portalAssetNeedsUpdate(
 	return !equal, nil
 }
 
+// dataURLsEqual compares the decoded payloads of two data URLs by content hash.
+// MIME types are ignored because Konnect may normalize them on upload.
 func dataURLsEqual(desired string, current string) (bool, error) {
-	desiredBytes, err := decodeDataURL(desired)
+	desiredDigest, err := dataURLDigest(desired)
 	if err != nil {
 		return false, fmt.Errorf("decode desired data URL: %w", err)
 	}
 
-	currentBytes, err := decodeDataURL(current)
+	currentDigest, err := dataURLDigest(current)
 	if err != nil {
 		return false, fmt.Errorf("decode current data URL: %w", err)
 	}
 
-	return bytes.Equal(desiredBytes, currentBytes), nil
+	return desiredDigest == currentDigest, nil
+}
+
+// dataURLDigest returns the hex encoded SHA-256 digest of a data URL payload
+func dataURLDigest(dataURL string) (string, error) {
+	payload, err := decodeDataURL(dataURL)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
 }
 
 func decodeDataURL(dataURL string) ([]byte, error) {
@@ -1865,6 +1878,203 @@ func (p *Planner) buildPortalEmailTemplateFields(tpl resources.PortalEmailTempla
 	return fields
 }
 
+// Portal Audit Log Webhook planning
+
+func (p *Planner) planPortalAuditLogWebhookChanges(
+	ctx context.Context,
+	parentNamespace string,
+	portalID string,
+	portalRef string,
+	desired []resources.PortalAuditLogWebhookResource,
+	plan *Plan,
+) error {
+	var desiredWebhook *resources.PortalAuditLogWebhookResource
+	for i := range desired {
+		if plan.HasChange(ResourceTypePortalAuditLogWebhook, desired[i].GetRef()) {
+			continue
+		}
+		desiredWebhook = &desired[i]
+		break
+	}
+
+	portalName := p.findPortalName(portalRef)
+
+	if portalID == "" {
+		if desiredWebhook != nil {
+			p.planPortalAuditLogWebhookChange(
+				ActionCreate, parentNamespace, *desiredWebhook, portalID, portalRef, portalName, plan,
+			)
+		}
+		return nil
+	}
+
+	currentWebhook, err := p.client.GetPortalAuditLogWebhook(ctx, portalID)
+	if err != nil {
+		var apiErr *state.APIClientError
+		if errors.As(err, &apiErr) && apiErr.ClientType == "portal audit logs API" {
+			if desiredWebhook != nil {
+				changeID := p.planPortalAuditLogWebhookChange(
+					ActionCreate, parentNamespace, *desiredWebhook, portalID, portalRef, portalName, plan,
+				)
+				plan.AddWarning(
+					changeID,
+					"unable to inspect existing portal audit log webhook – assuming create is required",
+				)
+			}
+			return nil
+		}
+
+		identifier := portalRef
+		if identifier == "" {
+			identifier = portalID
+		}
+
+		return fmt.Errorf("failed to get portal audit log webhook for portal %q: %w", identifier, err)
+	}
+
+	configured := currentWebhook != nil &&
+		(boolValue(currentWebhook.Enabled) || getString(currentWebhook.AuditLogDestinationID) != "")
+
+	if desiredWebhook == nil {
+		if configured && plan.Metadata.Mode == PlanModeSync {
+			p.planPortalAuditLogWebhookDelete(parentNamespace, portalRef, portalID, portalName, plan)
+		}
+		return nil
+	}
+
+	if !configured {
+		p.planPortalAuditLogWebhookChange(
+			ActionCreate, parentNamespace, *desiredWebhook, portalID, portalRef, portalName, plan,
+		)
+		return nil
+	}
+
+	if p.shouldUpdatePortalAuditLogWebhook(currentWebhook, *desiredWebhook) {
+		p.planPortalAuditLogWebhookChange(
+			ActionUpdate, parentNamespace, *desiredWebhook, portalID, portalRef, portalName, plan,
+		)
+	}
+
+	return nil
+}
+
+// planPortalAuditLogWebhookChange plans a create or update. Both are a PATCH of the
+// portal's webhook, which has no identity beyond its portal.
+func (p *Planner) planPortalAuditLogWebhookChange(
+	action ActionType,
+	parentNamespace string,
+	webhook resources.PortalAuditLogWebhookResource,
+	portalID string,
+	portalRef string,
+	portalName string,
+	plan *Plan,
+) string {
+	change := PlannedChange{
+		ID:           p.nextChangeID(action, ResourceTypePortalAuditLogWebhook, webhook.Ref),
+		ResourceType: ResourceTypePortalAuditLogWebhook,
+		ResourceRef:  webhook.Ref,
+		Action:       action,
+		Fields:       p.buildPortalAuditLogWebhookFields(webhook),
+		DependsOn:    uniqueStrings(p.portalChildDependencies(plan, webhook.Portal)),
+		Namespace:    parentNamespace,
+	}
+	if action == ActionUpdate {
+		change.ResourceID = portalID
+	}
+
+	ref := webhook.Portal
+	if ref == "" {
+		ref = portalRef
+	}
+	if ref != "" || portalID != "" {
+		change.Parent = &ParentInfo{
+			Ref: ref,
+			ID:  portalID,
+		}
+		change.References = map[string]ReferenceInfo{
+			"portal_id": {
+				Ref: ref,
+				ID:  portalID,
+				LookupFields: map[string]string{
+					"name": portalName,
+				},
+			},
+		}
+	}
+
+	plan.AddChange(change)
+	return change.ID
+}
+
+func (p *Planner) planPortalAuditLogWebhookDelete(
+	parentNamespace string,
+	portalRef string,
+	portalID string,
+	portalName string,
+	plan *Plan,
+) {
+	ref := portalRef
+	if ref == "" {
+		ref = fmt.Sprintf("%s__audit_log_webhook", portalID)
+	}
+
+	change := PlannedChange{
+		ID:           p.nextChangeID(ActionDelete, ResourceTypePortalAuditLogWebhook, ref),
+		ResourceType: ResourceTypePortalAuditLogWebhook,
+		ResourceRef:  ref,
+		ResourceID:   portalID,
+		Action:       ActionDelete,
+		DependsOn:    p.portalChildDependencies(plan, portalRef),
+		Namespace:    parentNamespace,
+	}
+
+	if portalRef != "" || portalID != "" {
+		change.Parent = &ParentInfo{
+			Ref: portalRef,
+			ID:  portalID,
+		}
+		change.References = map[string]ReferenceInfo{
+			"portal_id": {
+				Ref: portalRef,
+				ID:  portalID,
+				LookupFields: map[string]string{
+					"name": portalName,
+				},
+			},
+		}
+	}
+
+	plan.AddChange(change)
+}
+
+func (p *Planner) shouldUpdatePortalAuditLogWebhook(
+	current *kkComps.PortalAuditLogWebhook,
+	desired resources.PortalAuditLogWebhookResource,
+) bool {
+	if current == nil {
+		return true
+	}
+	if desired.Enabled != nil && *desired.Enabled != boolValue(current.Enabled) {
+		return true
+	}
+	if desired.AuditLogDestinationID != nil &&
+		*desired.AuditLogDestinationID != getString(current.AuditLogDestinationID) {
+		return true
+	}
+	return false
+}
+
+func (p *Planner) buildPortalAuditLogWebhookFields(webhook resources.PortalAuditLogWebhookResource) map[string]any {
+	fields := map[string]any{}
+	if webhook.Enabled != nil {
+		fields["enabled"] = *webhook.Enabled
+	}
+	if webhook.AuditLogDestinationID != nil {
+		fields["audit_log_destination_id"] = *webhook.AuditLogDestinationID
+	}
+	return fields
+}
+
 func (p *Planner) buildPortalEmailConfigFields(cfg resources.PortalEmailConfigResource) map[string]any {
 	fields := map[string]any{}
 
@@ -2296,6 +2506,10 @@ func (p *Planner) planPortalPageUpdate(
 
 	// Always include slug for identification
 	fields["slug"] = current.Slug
+	var identityFields []string
+	if _, ok := updateFields["slug"]; !ok {
+		identityFields = []string{"slug"}
+	}
 
 	// Add fields that need updating
 	for field, value := range updateFields {
@@ -2315,14 +2529,15 @@ func (p *Planner) planPortalPageUpdate(
 	}
 
 	change := PlannedChange{
-		ID:           p.nextChangeID(ActionUpdate, ResourceTypePortalPage, desired.GetRef()),
-		ResourceType: ResourceTypePortalPage,
-		ResourceRef:  desired.GetRef(),
-		ResourceID:   current.ID,
-		Action:       ActionUpdate,
-		Fields:       fields,
-		DependsOn:    dependencies,
-		Namespace:    parentNamespace,
+		ID:             p.nextChangeID(ActionUpdate, ResourceTypePortalPage, desired.GetRef()),
+		ResourceType:   ResourceTypePortalPage,
+		ResourceRef:    desired.GetRef(),
+		ResourceID:     current.ID,
+		Action:         ActionUpdate,
+		Fields:         fields,
+		DependsOn:      dependencies,
+		Namespace:      parentNamespace,
+		IdentityFields: identityFields,
 	}
 
 	// Store parent portal reference
@@ -2625,6 +2840,10 @@ func (p *Planner) planPortalSnippetUpdate(
 
 	// Always include name for identification
 	fields["name"] = current.Name
+	var identityFields []string
+	if _, ok := updateFields["name"]; !ok {
+		identityFields = []string{"name"}
+	}
 
 	// Add fields that need updating
 	for field, value := range updateFields {
@@ -2644,14 +2863,15 @@ func (p *Planner) planPortalSnippetUpdate(
 	}
 
 	change := PlannedChange{
-		ID:           p.nextChangeID(ActionUpdate, ResourceTypePortalSnippet, desired.GetRef()),
-		ResourceType: ResourceTypePortalSnippet,
-		ResourceRef:  desired.GetRef(),
-		ResourceID:   current.ID,
-		Action:       ActionUpdate,
-		Fields:       fields,
-		DependsOn:    dependencies,
-		Namespace:    parentNamespace,
+		ID:             p.nextChangeID(ActionUpdate, ResourceTypePortalSnippet, desired.GetRef()),
+		ResourceType:   ResourceTypePortalSnippet,
+		ResourceRef:    desired.GetRef(),
+		ResourceID:     current.ID,
+		Action:         ActionUpdate,
+		Fields:         fields,
+		DependsOn:      dependencies,
+		Namespace:      parentNamespace,
+		IdentityFields: identityFields,
 	}
 
 	// Store parent portal reference
@@ -60,6 +60,15 @@ func (p *portalPlannerImpl) PlanChanges(ctx context.Context, plannerCtx *Config,
 	for _, portal := range currentPortals {
 		currentByName[portal.GetName()] = portal
 	}
+	if err := moveResources(p.planner, plan, ResourceTypePortal, desired, currentByName,
+		func(portal state.Portal) (string, string) { return portal.Name, portal.ID }); err != nil {
+		return err
+	}
+
+	adopted, err := p.adoptPortalsByName(ctx, namespace, desired, currentByName, plan)
+	if err != nil {
+		return err
+	}
 
 	// Collect protection validation errors
 	protectionErrors := &ProtectionErrorCollector{}
@@ -144,7 +153,8 @@ func (p *portalPlannerImpl) PlanChanges(ctx context.Context, plannerCtx *Config,
 			} else {
 				// Check if update needed based on configuration
 				needsUpdate, updateFields := p.shouldUpdatePortal(current, desiredPortal)
-				if needsUpdate {
+				// Adopted portals are updated even without changes so they get labeled
+				if needsUpdate || adopted[desiredPortal.Name] {
 					// Regular update - check protection
 					err := p.ValidateProtection("portal", desiredPortal.Name, isProtected, ActionUpdate)
 					protectionErrors.Add(err)
@@ -194,6 +204,36 @@ func (p *portalPlannerImpl) PlanChanges(ctx context.Context, plannerCtx *Config,
 	return nil
 }
 
+// adoptPortalsByName adds the unmanaged portals named like a desired portal to current
+// when the plan matches by name
+func (p *portalPlannerImpl) adoptPortalsByName(
+	ctx context.Context, namespace string, desired []resources.PortalResource,
+	current map[string]state.Portal, plan *Plan,
+) (map[string]bool, error) {
+	if !p.planner.matchByName || namespace == resources.NamespaceExternal {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(desired))
+	for _, portal := range desired {
+		if !portal.IsExternal() {
+			names = append(names, portal.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	all, err := p.GetClient().ListAllPortals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list portals to match by name: %w", err)
+	}
+	return adoptByName(plan, "portal", names, current, all,
+		func(portal state.Portal) (string, string, map[string]string) {
+			return portal.Name, portal.ID, portal.NormalizedLabels
+		}), nil
+}
+
 // planPortalDeletes handles delete mode by planning DELETE for desired portals that exist in Konnect.
 func (p *portalPlannerImpl) planPortalDeletes(
 	ctx context.Context, plannerCtx *Config, desired []resources.PortalResource, plan *Plan,
@@ -217,6 +257,10 @@ func (p *portalPlannerImpl) planPortalDeletes(
 	for _, portal := range currentPortals {
 		currentByName[portal.GetName()] = portal
 	}
+	if err := moveResources(p.planner, plan, ResourceTypePortal, desired, currentByName,
+		func(portal state.Portal) (string, string) { return portal.Name, portal.ID }); err != nil {
+		return err
+	}
 
 	protectionErrors := &ProtectionErrorCollector{}
 
@@ -418,6 +462,11 @@ func (p *portalPlannerImpl) shouldUpdatePortal(
 ) (bool, map[string]any) {
 	updates := make(map[string]any)
 
+	// Names differ when a moved entry renamed the portal
+	if current.Name != desired.Name {
+		updates["name"] = desired.Name
+	}
+
 	// Only compare fields present in desired configuration
 	if desired.DisplayName != nil {
 		if current.DisplayName != *desired.DisplayName {
@@ -518,8 +567,10 @@ func (p *portalPlannerImpl) planPortalUpdateWithFields(
 	updateFields map[string]any,
 	plan *Plan,
 ) {
-	// Always include name for identification
-	updateFields["name"] = current.Name
+	// Always include name for identification, unless the portal is renamed
+	if _, ok := updateFields["name"]; !ok {
+		updateFields["name"] = current.Name
+	}
 
 	// Pass current labels so executor can properly handle removals
 	if _, hasLabels := updateFields["labels"]; hasLabels {
@@ -573,6 +624,7 @@ func (p *portalPlannerImpl) planPortalUpdateWithFields(
 		if desired.Kongctl != nil && desired.Kongctl.Namespace != nil {
 			change.Namespace = *desired.Kongctl.Namespace
 		}
+		change.IdentityFields = []string{"name"}
 		plan.AddChange(change)
 		return
 	}
@@ -588,6 +640,7 @@ func (p *portalPlannerImpl) planPortalUpdateWithFields(
 	if labels.IsProtectedResource(current.NormalizedLabels) {
 		change.Protection = true
 	}
+	change.IdentityFields = []string{"name"}
 
 	plan.AddChange(change)
 }
@@ -650,6 +703,7 @@ func (p *portalPlannerImpl) planPortalProtectionChangeWithFields(
 	}
 
 	change.Fields = fields
+	change.IdentityFields = []string{"name"}
 	plan.AddChange(change)
 }
 
@@ -767,6 +821,21 @@ func (p *portalPlannerImpl) planPortalChildResourcesCreate(
 			"error", err.Error())
 	}
 
+	// Plan audit log webhook
+	webhooks := make([]resources.PortalAuditLogWebhookResource, 0)
+	for _, webhook := range planner.desiredPortalAuditLogWebhooks {
+		if webhook.Portal == desired.Ref {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	if err := planner.planPortalAuditLogWebhookChanges(
+		ctx, parentNamespace, "", desired.Ref, webhooks, plan,
+	); err != nil {
+		planner.logger.Debug("Failed to plan portal audit log webhook for new portal",
+			"portal", desired.Ref,
+			"error", err.Error())
+	}
+
 	// Plan email templates
 	templates := make([]resources.PortalEmailTemplateResource, 0)
 	for _, tpl := range planner.desiredPortalEmailTemplates {
@@ -949,6 +1018,19 @@ func (p *portalPlannerImpl) planPortalChildResourceChanges(
 		return fmt.Errorf("failed to plan portal email config changes: %w", err)
 	}
 
+	// Plan audit log webhook (singleton resource)
+	webhooks := make([]resources.PortalAuditLogWebhookResource, 0)
+	for _, webhook := range planner.desiredPortalAuditLogWebhooks {
+		if webhook.Portal == desired.Ref {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	if err := planner.planPortalAuditLogWebhookChanges(
+		ctx, parentNamespace, current.ID, desired.Ref, webhooks, plan,
+	); err != nil {
+		return fmt.Errorf("failed to plan portal audit log webhook changes: %w", err)
+	}
+
 	// Plan email templates (set applies create/update only in apply mode)
 	templates := make([]resources.PortalEmailTemplateResource, 0)
 	for _, tpl := range planner.desiredPortalEmailTemplates {
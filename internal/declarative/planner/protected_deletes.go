@@ -0,0 +1,53 @@
+package planner
+
+import (
+	"fmt"
+	"sync"
+)
+
+// protectedDeletes tracks the protected resources a plan was allowed to delete. The
+// confirmation names resources, as protection errors do, so a resource is deleted
+// only when its name was confirmed.
+type protectedDeletes struct {
+	confirmed map[string]bool
+
+	mu      sync.Mutex
+	deleted map[string]bool
+}
+
+func newProtectedDeletes(names []string) *protectedDeletes {
+	d := &protectedDeletes{confirmed: make(map[string]bool, len(names)), deleted: make(map[string]bool)}
+	for _, name := range names {
+		d.confirmed[name] = true
+	}
+	return d
+}
+
+// confirm reports whether the protected resource may be deleted, and records it
+func (d *protectedDeletes) confirm(name string) bool {
+	if d == nil || !d.confirmed[name] {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleted[name] = true
+	return true
+}
+
+// mark flags the confirmed deletes of the plan so the executor deletes them despite
+// their protection, and warns about each
+func (d *protectedDeletes) mark(plan *Plan) {
+	if d == nil || len(d.deleted) == 0 {
+		return
+	}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		name, _ := change.Fields["name"].(string)
+		// Protection is a label of parent resources only
+		if change.Action != ActionDelete || change.Parent != nil || !d.deleted[name] {
+			continue
+		}
+		change.DeleteProtected = true
+		plan.AddWarning(change.ID, fmt.Sprintf("%s %q is protected and will be deleted", change.ResourceType, name))
+	}
+}
@@ -0,0 +1,174 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// publicationPortalSettings captures the portal settings that constrain API publications
+type publicationPortalSettings struct {
+	// name identifies the portal in error messages
+	name                  string
+	authenticationEnabled bool
+	defaultAPIVisibility  string
+}
+
+// validatePublicationVisibility cross-checks every desired API publication against the
+// settings of its target portal. Konnect rejects these combinations at apply time, so
+// failing during planning avoids partially applied changes.
+func (p *Planner) validatePublicationVisibility(ctx context.Context, rs *resources.ResourceSet) error {
+	if len(rs.APIPublications) == 0 {
+		return nil
+	}
+
+	var livePortals []state.Portal
+	liveLoaded := false
+	loadLivePortals := func() ([]state.Portal, error) {
+		if liveLoaded {
+			return livePortals, nil
+		}
+		portals, err := p.client.ListAllPortals(ctx)
+		if err != nil {
+			return nil, err
+		}
+		livePortals = portals
+		liveLoaded = true
+		return livePortals, nil
+	}
+
+	var violations []string
+	for _, pub := range rs.APIPublications {
+		settings, err := p.publicationPortalSettings(rs, pub.PortalID, loadLivePortals)
+		if err != nil {
+			return fmt.Errorf("failed to resolve portal for api_publication %q: %w", pub.GetRef(), err)
+		}
+		if settings == nil {
+			// Portal cannot be resolved before apply (e.g. created elsewhere); skip validation
+			continue
+		}
+
+		visibility := effectivePublicationVisibility(pub)
+		if pub.Visibility != nil && visibility == string(kkComps.APIPublicationVisibilityPublic) &&
+			settings.defaultAPIVisibility == string(kkComps.DefaultAPIVisibilityPrivate) {
+			violations = append(violations, fmt.Sprintf(
+				"api_publication %q has visibility %q but portal %s has default_api_visibility: private; "+
+					"the portal only lists private APIs",
+				pub.GetRef(), visibility, settings.name))
+		}
+
+		if settings.authenticationEnabled {
+			continue
+		}
+
+		if visibility == string(kkComps.APIPublicationVisibilityPrivate) {
+			violations = append(violations, fmt.Sprintf(
+				"api_publication %q has visibility %q but portal %s has authentication_enabled: false; "+
+					"private APIs are only visible to authenticated developers",
+				pub.GetRef(), visibility, settings.name))
+		}
+		if pub.Switch != nil && visibility != string(kkComps.APIPublicationVisibilityPrivate) &&
+			pub.Switch.GetStageVisibility() == string(kkComps.APIPublicationVisibilityPrivate) {
+			violations = append(violations, fmt.Sprintf(
+				"api_publication %q is staged with visibility %q but portal %s has authentication_enabled: false; "+
+					"private staging requires portal authentication",
+				pub.GetRef(), pub.Switch.GetStageVisibility(), settings.name))
+		}
+
+		if len(pub.AuthStrategyIds) > 0 {
+			violations = append(violations, fmt.Sprintf(
+				"api_publication %q sets auth_strategy_ids but portal %s has authentication_enabled: false; "+
+					"application registration requires portal authentication",
+				pub.GetRef(), settings.name))
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.Strings(violations)
+	return fmt.Errorf("publication visibility is incompatible with portal settings:\n  - %s",
+		strings.Join(violations, "\n  - "))
+}
+
+// publicationPortalSettings resolves the effective settings of the portal targeted by a
+// publication. Settings declared in configuration take precedence over live values.
+// A nil result means the portal could not be resolved.
+func (p *Planner) publicationPortalSettings(
+	rs *resources.ResourceSet,
+	portalID string,
+	loadLivePortals func() ([]state.Portal, error),
+) (*publicationPortalSettings, error) {
+	lookup := portalID
+	if tags.IsRefPlaceholder(lookup) {
+		if parsedRef, _, ok := tags.ParseRefPlaceholder(lookup); ok {
+			lookup = parsedRef
+		}
+	}
+
+	desired := rs.GetPortalByRef(lookup)
+
+	liveID := lookup
+	if desired != nil {
+		liveID = desired.GetKonnectID()
+	}
+
+	var live *state.Portal
+	needsLive := desired == nil || desired.IsExternal() ||
+		desired.AuthenticationEnabled == nil || desired.DefaultAPIVisibility == nil
+	if liveID != "" && needsLive {
+		portals, err := loadLivePortals()
+		if err != nil {
+			return nil, err
+		}
+		for i := range portals {
+			if portals[i].ID == liveID {
+				live = &portals[i]
+				break
+			}
+		}
+	}
+
+	if desired == nil && live == nil {
+		return nil, nil
+	}
+
+	settings := &publicationPortalSettings{authenticationEnabled: true}
+
+	if live != nil {
+		settings.name = fmt.Sprintf("%q", live.Name)
+		if live.AuthenticationEnabled != nil {
+			settings.authenticationEnabled = *live.AuthenticationEnabled
+		}
+		settings.defaultAPIVisibility = string(live.DefaultAPIVisibility)
+	}
+
+	if desired != nil {
+		settings.name = fmt.Sprintf("%q", desired.GetRef())
+		if !desired.IsExternal() && desired.AuthenticationEnabled != nil {
+			settings.authenticationEnabled = *desired.AuthenticationEnabled
+		}
+		if !desired.IsExternal() && desired.DefaultAPIVisibility != nil {
+			settings.defaultAPIVisibility = string(*desired.DefaultAPIVisibility)
+		}
+	}
+
+	return settings, nil
+}
+
+// effectivePublicationVisibility returns the visibility Konnect applies to a publication.
+// An omitted visibility is sent with the SDK default ("private"), not the portal's
+// default_api_visibility.
+func effectivePublicationVisibility(pub resources.APIPublicationResource) string {
+	if pub.Visibility != nil {
+		return string(*pub.Visibility)
+	}
+	return string(kkComps.APIPublicationVisibilityPrivate)
+}
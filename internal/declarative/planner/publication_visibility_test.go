@@ -0,0 +1,156 @@
+package planner
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newVisibilityTestPlanner(t *testing.T, portals ...kkComps.ListPortalsResponsePortal) *Planner {
+	t.Helper()
+
+	mockPortalAPI := new(MockPortalAPI)
+	mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: portals,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(portals))}},
+		},
+	}, nil)
+
+	client := state.NewClient(state.ClientConfig{PortalAPI: mockPortalAPI})
+	return NewPlanner(client, slog.Default())
+}
+
+func TestValidatePublicationVisibility_PrivateOnUnauthenticatedPortal(t *testing.T) {
+	t.Parallel()
+
+	authDisabled := false
+	private := kkComps.APIPublicationVisibilityPrivate
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{
+				BaseResource: resources.BaseResource{Ref: "public-portal"},
+				CreatePortal: kkComps.CreatePortal{Name: "public-portal", AuthenticationEnabled: &authDisabled},
+			},
+		},
+		APIPublications: []resources.APIPublicationResource{
+			{
+				Ref:            "orders-publication",
+				PortalID:       "public-portal",
+				APIPublication: kkComps.APIPublication{Visibility: &private},
+			},
+		},
+	}
+
+	err := newVisibilityTestPlanner(t).validatePublicationVisibility(context.Background(), rs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `api_publication "orders-publication"`)
+	require.Contains(t, err.Error(), `portal "public-portal"`)
+	require.Contains(t, err.Error(), "authentication_enabled: false")
+}
+
+func TestValidatePublicationVisibility_PublicOnPrivateDefaultPortal(t *testing.T) {
+	t.Parallel()
+
+	public := kkComps.APIPublicationVisibilityPublic
+	live := newListPortal("portal-123", "live-portal", nil)
+	live.DefaultAPIVisibility = kkComps.ListPortalsResponseDefaultAPIVisibilityPrivate
+
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{
+				BaseResource: resources.BaseResource{Ref: "private-portal"},
+				CreatePortal: kkComps.CreatePortal{
+					Name:                 "private-portal",
+					DefaultAPIVisibility: kkComps.DefaultAPIVisibilityPrivate.ToPointer(),
+				},
+			},
+		},
+		APIPublications: []resources.APIPublicationResource{
+			{
+				Ref:            "orders-publication",
+				PortalID:       "private-portal",
+				APIPublication: kkComps.APIPublication{Visibility: &public},
+			},
+			{
+				Ref:            "billing-publication",
+				PortalID:       "portal-123",
+				APIPublication: kkComps.APIPublication{Visibility: &public},
+			},
+		},
+	}
+
+	err := newVisibilityTestPlanner(t, live).validatePublicationVisibility(context.Background(), rs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"orders-publication" has visibility "public" but portal "private-portal"`)
+	require.Contains(t, err.Error(), `"billing-publication" has visibility "public" but portal "live-portal"`)
+	require.Contains(t, err.Error(), "default_api_visibility: private")
+}
+
+func TestValidatePublicationVisibility_OmittedVisibilityDefaultsToPrivate(t *testing.T) {
+	t.Parallel()
+
+	authDisabled := false
+	live := newListPortal("portal-123", "live-portal", nil)
+	live.AuthenticationEnabled = &authDisabled
+
+	rs := &resources.ResourceSet{
+		APIPublications: []resources.APIPublicationResource{
+			{
+				Ref:            "orders-publication",
+				PortalID:       "portal-123",
+				APIPublication: kkComps.APIPublication{AuthStrategyIds: []string{"key-auth"}},
+			},
+		},
+	}
+
+	err := newVisibilityTestPlanner(t, live).validatePublicationVisibility(context.Background(), rs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `portal "live-portal"`)
+	require.Contains(t, err.Error(), `visibility "private"`)
+	require.Contains(t, err.Error(), "sets auth_strategy_ids")
+}
+
+func TestValidatePublicationVisibility_Compatible(t *testing.T) {
+	t.Parallel()
+
+	authDisabled := false
+	public := kkComps.APIPublicationVisibilityPublic
+	rs := &resources.ResourceSet{
+		Portals: []resources.PortalResource{
+			{
+				BaseResource: resources.BaseResource{Ref: "public-portal"},
+				CreatePortal: kkComps.CreatePortal{Name: "public-portal", AuthenticationEnabled: &authDisabled},
+			},
+			{
+				BaseResource: resources.BaseResource{Ref: "private-portal"},
+				CreatePortal: kkComps.CreatePortal{Name: "private-portal"},
+			},
+		},
+		APIPublications: []resources.APIPublicationResource{
+			{
+				Ref:            "public-publication",
+				PortalID:       "public-portal",
+				APIPublication: kkComps.APIPublication{Visibility: &public},
+			},
+			{
+				Ref:      "private-publication",
+				PortalID: "private-portal",
+			},
+			{
+				Ref:      "unknown-portal-publication",
+				PortalID: "portal-created-elsewhere",
+			},
+		},
+	}
+
+	err := newVisibilityTestPlanner(t).validatePublicationVisibility(context.Background(), rs)
+	require.NoError(t, err)
+}
@@ -0,0 +1,111 @@
+package planner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// LimitedResourceTypes are the resource types Konnect organizations are billed for or
+// limited in. Plans summarize how many of them the organization has.
+var LimitedResourceTypes = []string{
+	ResourceTypePortal,
+	"api",
+	"control_plane",
+	"application_auth_strategy",
+}
+
+// ResourceCount compares the number of resources of a type in the organization before
+// and after a plan is applied
+type ResourceCount struct {
+	Current int `json:"current"`
+	Planned int `json:"planned"`
+	// Limit is the entitlement of the organization for the type, when it is known
+	Limit *int `json:"limit,omitempty"`
+	// ExceedsLimit is set when applying the plan would take the count above the limit
+	ExceedsLimit bool `json:"exceeds_limit,omitempty"`
+}
+
+// ResourceCounter counts the resources of a type in the organization, managed or not
+type ResourceCounter interface {
+	CountResources(ctx context.Context, resourceType string) (int, error)
+}
+
+// CountResources records in the summary of plan how many resources of each limited
+// resource type it creates or deletes the organization has, before and after the plan
+// is applied. limits are the known entitlements of the organization by resource type.
+func CountResources(ctx context.Context, counter ResourceCounter, plan *Plan, limits map[string]int) error {
+	counts := make(map[string]ResourceCount)
+	for _, resourceType := range LimitedResourceTypes {
+		if !changesCount(plan, resourceType) {
+			continue
+		}
+		current, err := counter.CountResources(ctx, resourceType)
+		if err != nil {
+			return fmt.Errorf("failed to count %s resources: %w", resourceType, err)
+		}
+		count := ResourceCount{Current: current}
+		if limit, ok := limits[resourceType]; ok {
+			count.Limit = &limit
+		}
+		counts[resourceType] = count
+	}
+
+	plan.Summary.ResourceCounts = nil
+	if len(counts) > 0 {
+		plan.Summary.ResourceCounts = counts
+	}
+	plan.updateResourceCounts()
+	return nil
+}
+
+// ExceededLimits returns the resource types whose limit applying the plan would exceed,
+// sorted
+func (p *Plan) ExceededLimits() []string {
+	var exceeded []string
+	for resourceType, count := range p.Summary.ResourceCounts {
+		if count.ExceedsLimit {
+			exceeded = append(exceeded, resourceType)
+		}
+	}
+	slices.Sort(exceeded)
+	return exceeded
+}
+
+// changesCount reports whether the plan creates or deletes resources of a type
+func changesCount(plan *Plan, resourceType string) bool {
+	for _, change := range plan.Changes {
+		if change.ResourceType == resourceType &&
+			(change.Action == ActionCreate || change.Action == ActionDelete) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateResourceCounts recalculates the planned counts from the changes of the plan.
+// The counts are copied, plans filtered with WithoutChanges share the summary map.
+func (p *Plan) updateResourceCounts() {
+	if len(p.Summary.ResourceCounts) == 0 {
+		return
+	}
+	counts := make(map[string]ResourceCount, len(p.Summary.ResourceCounts))
+	for resourceType, count := range p.Summary.ResourceCounts {
+		count.Planned = count.Current
+		for _, change := range p.Changes {
+			if change.ResourceType != resourceType {
+				continue
+			}
+			switch change.Action {
+			case ActionCreate:
+				count.Planned++
+			case ActionDelete:
+				count.Planned--
+			}
+		}
+		// Organizations already above their limit are only flagged when the plan adds more
+		count.ExceedsLimit = count.Limit != nil && count.Planned > *count.Limit && count.Planned > count.Current
+		counts[resourceType] = count
+	}
+	p.Summary.ResourceCounts = counts
+}
@@ -0,0 +1,68 @@
+package planner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResourceCounter map[string]int
+
+func (f fakeResourceCounter) CountResources(_ context.Context, resourceType string) (int, error) {
+	count, ok := f[resourceType]
+	if !ok {
+		return 0, errors.New("not counted")
+	}
+	return count, nil
+}
+
+func TestCountResources(t *testing.T) {
+	t.Parallel()
+
+	plan := newRiskTestPlan()
+	plan.AddChange(PlannedChange{
+		ID:           "4:c:api:payments",
+		ResourceType: "api",
+		ResourceRef:  "payments",
+		Action:       ActionCreate,
+	})
+	counter := fakeResourceCounter{"api": 5}
+
+	require.NoError(t, CountResources(context.Background(), counter, plan, map[string]int{"api": 5, "portal": 1}))
+	limit := 5
+	// The portal is only updated, so portals are not counted
+	require.Equal(t, map[string]ResourceCount{
+		"api": {Current: 5, Planned: 6, Limit: &limit, ExceedsLimit: true},
+	}, plan.Summary.ResourceCounts)
+	require.Equal(t, []string{"api"}, plan.ExceededLimits())
+
+	// Filtered plans recount without changing the plan they come from
+	filtered := plan.WithoutChanges([]string{"4:c:api:payments"})
+	require.Equal(t, 5, filtered.Summary.ResourceCounts["api"].Planned)
+	require.Empty(t, filtered.ExceededLimits())
+	require.Equal(t, 6, plan.Summary.ResourceCounts["api"].Planned)
+
+	err := CountResources(context.Background(), fakeResourceCounter{}, plan, nil)
+	require.ErrorContains(t, err, "failed to count api resources")
+}
+
+func TestCountResources_AboveLimitBeforeApply(t *testing.T) {
+	t.Parallel()
+
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	plan.AddChange(PlannedChange{
+		ID:           "1:d:portal:old",
+		ResourceType: ResourceTypePortal,
+		ResourceRef:  "old",
+		Action:       ActionDelete,
+	})
+
+	require.NoError(t, CountResources(context.Background(), fakeResourceCounter{"portal": 4}, plan,
+		map[string]int{"portal": 2}))
+	count := plan.Summary.ResourceCounts[ResourceTypePortal]
+	require.Equal(t, 3, count.Planned)
+	// Plans that reduce the count of an organization over its limit are not flagged
+	require.False(t, count.ExceedsLimit)
+}
@@ -0,0 +1,190 @@
+package planner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RiskLevel classifies how risky a planned change is
+type RiskLevel string
+
+const (
+	RiskLevelNone     RiskLevel = "none"
+	RiskLevelLow      RiskLevel = "low"
+	RiskLevelMedium   RiskLevel = "medium"
+	RiskLevelHigh     RiskLevel = "high"
+	RiskLevelCritical RiskLevel = "critical"
+)
+
+var riskLevelScores = map[RiskLevel]int{
+	RiskLevelNone:     0,
+	RiskLevelLow:      1,
+	RiskLevelMedium:   2,
+	RiskLevelHigh:     3,
+	RiskLevelCritical: 4,
+}
+
+// ParseRiskLevel converts a string into a RiskLevel
+func ParseRiskLevel(value string) (RiskLevel, error) {
+	level := RiskLevel(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := riskLevelScores[level]; !ok {
+		return "", fmt.Errorf("invalid risk level %q: must be one of none, low, medium, high, critical", value)
+	}
+	return level, nil
+}
+
+// Score returns the numeric weight of the level; unknown levels score 0
+func (l RiskLevel) Score() int {
+	return riskLevelScores[l]
+}
+
+// Exceeds reports whether l is riskier than other
+func (l RiskLevel) Exceeds(other RiskLevel) bool {
+	return l.Score() > other.Score()
+}
+
+// RiskPolicy assigns risk levels to planned changes. A change takes the highest level
+// matched by its action, its resource type and any of its fields.
+type RiskPolicy struct {
+	// Default applies to changes that match no other rule
+	Default RiskLevel `json:"default,omitempty"`
+	// Actions maps CREATE, UPDATE, DELETE or EXTERNAL_TOOL to a level
+	Actions map[string]RiskLevel `json:"actions,omitempty"`
+	// ResourceTypes maps a resource type (e.g. portal) to a level
+	ResourceTypes map[string]RiskLevel `json:"resource_types,omitempty"`
+	// Fields maps "<resource_type>.<field>" to a level; "*.<field>" matches any type
+	Fields map[string]RiskLevel `json:"fields,omitempty"`
+}
+
+// ChangeRisk is the risk annotation attached to a planned change
+type ChangeRisk struct {
+	Level   RiskLevel `json:"level"`
+	Score   int       `json:"score"`
+	Reasons []string  `json:"reasons,omitempty"`
+}
+
+// RiskSummary aggregates change risk across a plan
+type RiskSummary struct {
+	// Level is the highest level of any change
+	Level RiskLevel `json:"level"`
+	// Score is the sum of all change scores
+	Score   int               `json:"score"`
+	ByLevel map[RiskLevel]int `json:"by_level"`
+}
+
+// Validate checks that every level in the policy is known
+func (p *RiskPolicy) Validate() error {
+	check := func(scope, key string, level RiskLevel) error {
+		if _, err := ParseRiskLevel(string(level)); err != nil {
+			if key == "" {
+				return fmt.Errorf("risk policy %s: %w", scope, err)
+			}
+			return fmt.Errorf("risk policy %s %q: %w", scope, key, err)
+		}
+		return nil
+	}
+
+	if p.Default != "" {
+		if err := check("default", "", p.Default); err != nil {
+			return err
+		}
+	}
+	for key, level := range p.Actions {
+		if err := check("action", key, level); err != nil {
+			return err
+		}
+	}
+	for key, level := range p.ResourceTypes {
+		if err := check("resource type", key, level); err != nil {
+			return err
+		}
+	}
+	for key, level := range p.Fields {
+		if !strings.Contains(key, ".") {
+			return fmt.Errorf("risk policy field %q must be in the form <resource_type>.<field>", key)
+		}
+		if err := check("field", key, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Annotate sets the risk of every change in the plan and the plan's risk summary
+func (p *RiskPolicy) Annotate(plan *Plan) {
+	if plan == nil {
+		return
+	}
+
+	summary := &RiskSummary{Level: RiskLevelNone, ByLevel: make(map[RiskLevel]int)}
+	for i := range plan.Changes {
+		risk := p.evaluate(plan.Changes[i])
+		plan.Changes[i].Risk = risk
+
+		summary.Score += risk.Score
+		summary.ByLevel[risk.Level]++
+		if risk.Level.Exceeds(summary.Level) {
+			summary.Level = risk.Level
+		}
+	}
+	plan.Summary.Risk = summary
+}
+
+func (p *RiskPolicy) evaluate(change PlannedChange) *ChangeRisk {
+	risk := &ChangeRisk{Level: RiskLevelNone}
+	matched := false
+	raise := func(level RiskLevel, reason string) {
+		matched = true
+		if level.Exceeds(risk.Level) {
+			risk.Level = level
+		}
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("%s (%s)", reason, level))
+	}
+
+	if level, ok := p.Actions[string(change.Action)]; ok {
+		raise(level, "action "+string(change.Action))
+	}
+	if level, ok := p.ResourceTypes[change.ResourceType]; ok {
+		raise(level, "resource type "+change.ResourceType)
+	}
+
+	if len(p.Fields) > 0 {
+		fieldNames := make([]string, 0, len(change.Fields))
+		for name := range change.Fields {
+			fieldNames = append(fieldNames, name)
+		}
+		sort.Strings(fieldNames)
+		for _, name := range fieldNames {
+			if level, ok := p.Fields[change.ResourceType+"."+name]; ok {
+				raise(level, "field "+name)
+			} else if level, ok := p.Fields["*."+name]; ok {
+				raise(level, "field "+name)
+			}
+		}
+	}
+
+	if !matched && p.Default != "" {
+		risk.Level = p.Default
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("policy default (%s)", p.Default))
+	}
+
+	risk.Score = risk.Level.Score()
+	return risk
+}
+
+// CheckAutoApproveRisk returns an error when the plan's risk exceeds the level allowed
+// for unattended execution. It cannot judge a plan without risk annotations and passes
+// it, so callers gating auto-approve must refuse such plans first, as kongctl apply does.
+func CheckAutoApproveRisk(plan *Plan, maxLevel RiskLevel) error {
+	if plan == nil || plan.Summary.Risk == nil {
+		return nil
+	}
+	if plan.Summary.Risk.Level.Exceeds(maxLevel) {
+		return fmt.Errorf(
+			"plan risk level %q (score %d) exceeds the maximum %q allowed for auto-approve; "+
+				"review the plan and approve it interactively",
+			plan.Summary.Risk.Level, plan.Summary.Risk.Score, maxLevel)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRiskTestPlan() *Plan {
+	plan := NewPlan("1.0", "test", PlanModeSync)
+	plan.AddChange(PlannedChange{
+		ID:           "1:c:api:orders",
+		ResourceType: "api",
+		ResourceRef:  "orders",
+		Action:       ActionCreate,
+		Fields:       map[string]any{"name": "orders"},
+	})
+	plan.AddChange(PlannedChange{
+		ID:           "2:u:portal:dev",
+		ResourceType: ResourceTypePortal,
+		ResourceRef:  "dev",
+		Action:       ActionUpdate,
+		Fields: map[string]any{
+			"description":            FieldChange{Old: "a", New: "b"},
+			"authentication_enabled": FieldChange{Old: true, New: false},
+		},
+	})
+	plan.AddChange(PlannedChange{
+		ID:           "3:d:api:legacy",
+		ResourceType: "api",
+		ResourceRef:  "legacy",
+		Action:       ActionDelete,
+	})
+	return plan
+}
+
+func TestRiskPolicyAnnotate(t *testing.T) {
+	t.Parallel()
+
+	policy := &RiskPolicy{
+		Default:       RiskLevelLow,
+		Actions:       map[string]RiskLevel{"DELETE": RiskLevelHigh},
+		ResourceTypes: map[string]RiskLevel{"portal": RiskLevelMedium},
+		Fields:        map[string]RiskLevel{"portal.authentication_enabled": RiskLevelCritical},
+	}
+	require.NoError(t, policy.Validate())
+
+	plan := newRiskTestPlan()
+	policy.Annotate(plan)
+
+	create := plan.Changes[0].Risk
+	require.NotNil(t, create)
+	require.Equal(t, RiskLevelLow, create.Level)
+	require.Equal(t, []string{"policy default (low)"}, create.Reasons)
+
+	update := plan.Changes[1].Risk
+	require.Equal(t, RiskLevelCritical, update.Level)
+	require.Equal(t, 4, update.Score)
+	require.Equal(t, []string{
+		"resource type portal (medium)",
+		"field authentication_enabled (critical)",
+	}, update.Reasons)
+
+	remove := plan.Changes[2].Risk
+	require.Equal(t, RiskLevelHigh, remove.Level)
+
+	require.NotNil(t, plan.Summary.Risk)
+	require.Equal(t, RiskLevelCritical, plan.Summary.Risk.Level)
+	require.Equal(t, 1+4+3, plan.Summary.Risk.Score)
+	require.Equal(t, map[RiskLevel]int{
+		RiskLevelLow:      1,
+		RiskLevelHigh:     1,
+		RiskLevelCritical: 1,
+	}, plan.Summary.Risk.ByLevel)
+}
+
+func TestRiskPolicyAnnotate_WildcardField(t *testing.T) {
+	t.Parallel()
+
+	policy := &RiskPolicy{Fields: map[string]RiskLevel{"*.description": RiskLevelMedium}}
+	plan := newRiskTestPlan()
+	policy.Annotate(plan)
+
+	require.Equal(t, RiskLevelNone, plan.Changes[0].Risk.Level)
+	require.Equal(t, RiskLevelMedium, plan.Changes[1].Risk.Level)
+	require.Equal(t, RiskLevelMedium, plan.Summary.Risk.Level)
+}
+
+func TestRiskPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, (&RiskPolicy{Default: "severe"}).Validate())
+	require.Error(t, (&RiskPolicy{Actions: map[string]RiskLevel{"DELETE": "bad"}}).Validate())
+	require.Error(t, (&RiskPolicy{Fields: map[string]RiskLevel{"name": RiskLevelLow}}).Validate())
+	require.NoError(t, (&RiskPolicy{Fields: map[string]RiskLevel{"api.name": RiskLevelLow}}).Validate())
+
+	level, err := ParseRiskLevel(" HIGH ")
+	require.NoError(t, err)
+	require.Equal(t, RiskLevelHigh, level)
+}
+
+func TestCheckAutoApproveRisk(t *testing.T) {
+	t.Parallel()
+
+	plan := newRiskTestPlan()
+	require.NoError(t, CheckAutoApproveRisk(plan, RiskLevelNone), "unannotated plans are not gated")
+
+	policy := &RiskPolicy{Actions: map[string]RiskLevel{"DELETE": RiskLevelHigh}}
+	policy.Annotate(plan)
+
+	require.NoError(t, CheckAutoApproveRisk(plan, RiskLevelHigh))
+	require.NoError(t, CheckAutoApproveRisk(plan, RiskLevelCritical))
+
+	err := CheckAutoApproveRisk(plan, RiskLevelMedium)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `plan risk level "high"`)
+	require.Contains(t, err.Error(), `maximum "medium"`)
+}
@@ -0,0 +1,148 @@
+package planner
+
+import (
+	"log/slog"
+	"reflect"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// planSecrets holds the !secret values read while planning, so the planners compare the
+// real values with Konnect. Values are replaced by fingerprinted placeholders in the plan.
+type planSecrets struct {
+	placeholders map[string]string
+	restore      []func()
+}
+
+// readSecretsForPlanning replaces the !secret placeholders of rs with their values.
+// Secrets that cannot be read are left as placeholders, so their fields are planned as
+// changed and read at apply time. The placeholders are put back by restoreResources.
+func (p *Planner) readSecretsForPlanning(rs *resources.ResourceSet) *planSecrets {
+	secrets := &planSecrets{placeholders: make(map[string]string)}
+	if rs == nil {
+		return secrets
+	}
+	visitStrings(reflect.ValueOf(rs), func(current string, set func(string)) {
+		source, _, ok := tags.ParseSecretPlaceholder(current)
+		if !ok {
+			return
+		}
+		value, err := tags.ResolveSecret(source)
+		if err != nil {
+			p.logger.Debug("Secret is not readable while planning, its fields are planned as changed",
+				slog.String("source", source), slog.String("error", err.Error()))
+			return
+		}
+		set(value)
+		secrets.restore = append(secrets.restore, func() { set(current) })
+		secrets.placeholders[value] = tags.SecretPlaceholder(source, tags.SecretFingerprint(value))
+	})
+	return secrets
+}
+
+// restoreResources puts the !secret placeholders back in the resources
+func (s *planSecrets) restoreResources() {
+	for _, restore := range s.restore {
+		restore()
+	}
+}
+
+// maskPlan replaces the secret values in the fields of the changes with their
+// placeholders. Fields holding a secret or a placeholder are normalized through JSON,
+// so the executor finds every placeholder.
+func (s *planSecrets) maskPlan(plan *Plan) {
+	for i := range plan.Changes {
+		for name, value := range plan.Changes[i].Fields {
+			normalized := normalizeIgnoredValue(value)
+			masked, found := s.mask(normalized)
+			if found {
+				plan.Changes[i].Fields[name] = masked
+			}
+		}
+	}
+}
+
+func (s *planSecrets) mask(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		if placeholder, ok := s.placeholders[v]; ok {
+			return placeholder, true
+		}
+		return v, tags.IsSecretPlaceholder(v)
+	case map[string]any:
+		found := false
+		for key, item := range v {
+			masked, ok := s.mask(item)
+			v[key] = masked
+			found = found || ok
+		}
+		return v, found
+	case []any:
+		found := false
+		for i, item := range v {
+			masked, ok := s.mask(item)
+			v[i] = masked
+			found = found || ok
+		}
+		return v, found
+	default:
+		return value, false
+	}
+}
+
+// visitStrings calls fn with every settable string under val, and a function that
+// replaces it
+func visitStrings(val reflect.Value, fn func(current string, set func(string))) {
+	switch val.Kind() {
+	case reflect.Ptr:
+		if !val.IsNil() {
+			visitStrings(val.Elem(), fn)
+		}
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		if elem := val.Elem(); elem.Kind() == reflect.String {
+			if val.CanSet() {
+				fn(elem.String(), func(s string) { val.Set(reflect.ValueOf(s).Convert(elem.Type())) })
+			}
+			return
+		}
+		visitStrings(val.Elem(), fn)
+	case reflect.String:
+		if val.CanSet() {
+			fn(val.String(), val.SetString)
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if val.Type().Field(i).IsExported() {
+				visitStrings(val.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			visitStrings(val.Index(i), fn)
+		}
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			item := val.MapIndex(key)
+			elem := item
+			if item.Kind() == reflect.Interface && !item.IsNil() {
+				elem = item.Elem()
+			}
+			if elem.Kind() != reflect.String {
+				visitStrings(item, fn)
+				continue
+			}
+			fn(elem.String(), func(s string) {
+				value := reflect.ValueOf(s).Convert(elem.Type())
+				if item.Kind() == reflect.Interface {
+					value = value.Convert(item.Type())
+				}
+				val.SetMapIndex(key, value)
+			})
+		}
+	default:
+	}
+}
@@ -0,0 +1,113 @@
+package planner
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/declarative/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePlan_SecretValues(t *testing.T) {
+	placeholder := tags.SecretPlaceholder("env://KONGCTL_TEST_PORTAL_DESCRIPTION", "")
+	generate := func(t *testing.T) (*Plan, *resources.ResourceSet) {
+		t.Helper()
+		ctx := context.Background()
+		mockPortalAPI := new(MockPortalAPI)
+		mockAPIAPI := new(MockAPIAPI)
+		mockAppAuthAPI := new(MockAppAuthStrategiesAPI)
+		client := state.NewClient(state.ClientConfig{
+			PortalAPI:  mockPortalAPI,
+			APIAPI:     mockAPIAPI,
+			AppAuthAPI: mockAppAuthAPI,
+		})
+
+		current := "Current description"
+		mockPortalAPI.On("ListPortals", mock.Anything, mock.Anything).Return(&kkOps.ListPortalsResponse{
+			ListPortalsResponse: &kkComps.ListPortalsResponse{
+				Data: []kkComps.ListPortalsResponsePortal{
+					func() kkComps.ListPortalsResponsePortal {
+						p := newListPortal("portal-123", "dev-portal", map[string]string{labels.NamespaceKey: "default"})
+						p.Description = &current
+						return p
+					}(),
+				},
+				Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 1}},
+			},
+		}, nil)
+		mockAppAuthAPI.On("ListAppAuthStrategies", mock.Anything, mock.Anything).
+			Return(&kkOps.ListAppAuthStrategiesResponse{
+				ListAppAuthStrategiesResponse: &kkComps.ListAppAuthStrategiesResponse{
+					Data: []kkComps.AppAuthStrategy{},
+					Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: 0}},
+				},
+			}, nil)
+		mockEmptyAPIsList(ctx, mockAPIAPI)
+
+		description := placeholder
+		rs := &resources.ResourceSet{
+			Portals: []resources.PortalResource{{
+				CreatePortal: kkComps.CreatePortal{Name: "dev-portal", Description: &description},
+				BaseResource: resources.BaseResource{Ref: "dev-portal"},
+			}},
+		}
+		plan, err := NewPlanner(client, slog.Default()).GeneratePlan(ctx, rs, Options{Mode: PlanModeApply})
+		require.NoError(t, err)
+		return plan, rs
+	}
+
+	t.Run("changed secret is planned by fingerprint", func(t *testing.T) {
+		t.Setenv("KONGCTL_TEST_PORTAL_DESCRIPTION", "New description")
+
+		plan, rs := generate(t)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t,
+			tags.SecretPlaceholder("env://KONGCTL_TEST_PORTAL_DESCRIPTION", tags.SecretFingerprint("New description")),
+			plan.Changes[0].Fields["description"])
+		assert.Equal(t, placeholder, *rs.Portals[0].Description, "placeholders are restored in the resources")
+	})
+
+	t.Run("unchanged secret plans no change", func(t *testing.T) {
+		t.Setenv("KONGCTL_TEST_PORTAL_DESCRIPTION", "Current description")
+
+		plan, _ := generate(t)
+		assert.Empty(t, plan.Changes)
+	})
+
+	t.Run("unreadable secret is planned as changed", func(t *testing.T) {
+		plan, _ := generate(t)
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, placeholder, plan.Changes[0].Fields["description"])
+	})
+}
+
+func TestPlanSecrets_MaskPlan(t *testing.T) {
+	secrets := &planSecrets{placeholders: map[string]string{"s3cr3t": "__SECRET__:env://TOKEN#sha256:abc"}}
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	plan.AddChange(PlannedChange{
+		ID: "1:c:application_auth_strategy:oidc", Action: ActionCreate,
+		Fields: map[string]any{
+			"name": "oidc",
+			"configs": map[string]map[string]any{
+				"openid-connect": {"client_secret": "s3cr3t", "scopes": []string{"openid"}},
+			},
+		},
+	})
+
+	secrets.maskPlan(plan)
+	assert.Equal(t, "oidc", plan.Changes[0].Fields["name"])
+	assert.Equal(t, map[string]any{
+		"openid-connect": map[string]any{
+			"client_secret": "__SECRET__:env://TOKEN#sha256:abc",
+			"scopes":        []any{"openid"},
+		},
+	}, plan.Changes[0].Fields["configs"])
+}
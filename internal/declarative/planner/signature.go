@@ -0,0 +1,148 @@
+package planner
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSignature is returned when a plan is unsigned or its signature does not match
+var ErrInvalidSignature = errors.New("invalid plan signature")
+
+// signatureAlgorithm names the algorithm of PlanSignature.Algorithm
+const signatureAlgorithm = "ed25519"
+
+// ParseSigningKey parses a PEM encoded PKCS #8 Ed25519 private key, as written by
+// `openssl genpkey -algorithm ed25519`
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("signing key must be a PEM encoded PRIVATE KEY")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key must be an %s key, got %T", signatureAlgorithm, key)
+	}
+	return privateKey, nil
+}
+
+// ParseVerificationKey parses a PEM encoded Ed25519 public key. The private key is
+// accepted too, in which case its public key is used.
+func ParseVerificationKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("verification key must be a PEM encoded PUBLIC KEY")
+	}
+	if block.Type == "PRIVATE KEY" {
+		privateKey, err := ParseSigningKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return privateKey.Public().(ed25519.PublicKey), nil
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("verification key must be a PEM encoded PUBLIC KEY, got %s", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification key: %w", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key must be an %s key, got %T", signatureAlgorithm, key)
+	}
+	return publicKey, nil
+}
+
+// Sign signs the plan with the given key. The signature covers the whole plan artifact
+// apart from the signature itself, including the organization in Metadata.OrgID.
+func (p *Plan) Sign(key ed25519.PrivateKey) error {
+	p.Metadata.Signature = nil
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	payload, err := signaturePayload(data)
+	if err != nil {
+		return err
+	}
+	p.Metadata.Signature = &PlanSignature{
+		Algorithm: signatureAlgorithm,
+		KeyID:     keyID(key.Public().(ed25519.PublicKey)),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+	}
+	return nil
+}
+
+// VerifyPlanSignature verifies the signature of a plan artifact as read from its file.
+// It fails with ErrInvalidSignature when the plan is unsigned, was signed by another
+// key or was modified after it was signed.
+func VerifyPlanSignature(data []byte, key ed25519.PublicKey) error {
+	var signed struct {
+		Metadata struct {
+			PlanID    string         `json:"plan_id"`
+			Signature *PlanSignature `json:"signature"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return fmt.Errorf("failed to parse plan: %w", err)
+	}
+	signature := signed.Metadata.Signature
+	if signature == nil {
+		return fmt.Errorf("%w: plan %s is not signed", ErrInvalidSignature, signed.Metadata.PlanID)
+	}
+	if signature.Algorithm != signatureAlgorithm {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, signature.Algorithm)
+	}
+	if id := keyID(key); signature.KeyID != id {
+		return fmt.Errorf("%w: plan was signed by key %s, not %s", ErrInvalidSignature, signature.KeyID, id)
+	}
+	value, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	payload, err := signaturePayload(data)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(key, payload, value) {
+		return fmt.Errorf("%w: plan %s was modified after it was signed", ErrInvalidSignature, signed.Metadata.PlanID)
+	}
+	return nil
+}
+
+// signaturePayload returns the canonical encoding of a plan artifact without its
+// signature: the JSON with sorted keys, compact and with numbers kept as written, so
+// the payload does not depend on the indentation of the plan file
+func signaturePayload(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var plan map[string]any
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if metadata, ok := plan["metadata"].(map[string]any); ok {
+		delete(metadata, "signature")
+	}
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	return payload, nil
+}
+
+func keyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
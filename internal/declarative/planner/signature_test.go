@@ -0,0 +1,83 @@
+package planner
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signedPlan := func(t *testing.T) []byte {
+		t.Helper()
+		plan := NewPlan("1.0", "test", PlanModeApply)
+		plan.Metadata.OrgID = "org-1"
+		plan.AddChange(PlannedChange{
+			ID: "1:c:portal:dev", ResourceType: ResourceTypePortal, ResourceRef: "dev", Action: ActionCreate,
+			Fields: map[string]any{"name": "dev", "limit": 12345678901234567},
+		})
+		require.NoError(t, plan.Sign(privateKey))
+		data, err := json.MarshalIndent(plan, "", "  ")
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("signed plan verifies", func(t *testing.T) {
+		require.NoError(t, VerifyPlanSignature(signedPlan(t), publicKey))
+	})
+
+	t.Run("modified plan is refused", func(t *testing.T) {
+		data := strings.Replace(string(signedPlan(t)), `"org-1"`, `"org-2"`, 1)
+		err := VerifyPlanSignature([]byte(data), publicKey)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		assert.Contains(t, err.Error(), "modified after it was signed")
+	})
+
+	t.Run("other key is refused", func(t *testing.T) {
+		otherKey, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		err = VerifyPlanSignature(signedPlan(t), otherKey)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		assert.Contains(t, err.Error(), "signed by key")
+	})
+
+	t.Run("unsigned plan is refused", func(t *testing.T) {
+		data, err := json.Marshal(NewPlan("1.0", "test", PlanModeApply))
+		require.NoError(t, err)
+		require.ErrorIs(t, VerifyPlanSignature(data, publicKey), ErrInvalidSignature)
+	})
+}
+
+func TestParseSigningKeys(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	parsedPrivate, err := ParseSigningKey(privatePEM)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, parsedPrivate)
+
+	parsedPublic, err := ParseVerificationKey(publicPEM)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, parsedPublic)
+
+	derivedPublic, err := ParseVerificationKey(privatePEM)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, derivedPublic)
+
+	_, err = ParseSigningKey(publicPEM)
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package planner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/state"
+)
+
+// ErrStalePlan is returned when the live state changed since a plan was generated
+var ErrStalePlan = errors.New("plan is stale")
+
+// stateHashPrefix names the digest algorithm of Metadata.StateHash
+const stateHashPrefix = "sha256:"
+
+// stateListers lists the managed top-level resources of a state root in the given
+// namespaces. The hash of a plan covers the roots its changes belong to.
+var stateListers = map[string]func(context.Context, *state.Client, []string) (any, error){
+	"application_auth_strategy": func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedAuthStrategies(ctx, ns)
+	},
+	"control_plane": func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedControlPlanes(ctx, ns)
+	},
+	ResourceTypePortal: func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedPortals(ctx, ns)
+	},
+	"catalog_service": func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedCatalogServices(ctx, ns)
+	},
+	"api": func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedAPIs(ctx, ns)
+	},
+	ResourceTypeEventGatewayControlPlane: func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedEventGatewayControlPlanes(ctx, ns)
+	},
+	"organization_team": func(ctx context.Context, c *state.Client, ns []string) (any, error) {
+		return c.ListManagedOrganizationTeams(ctx, ns)
+	},
+}
+
+// stateRoot returns the top-level resource type whose live state a change depends on,
+// or "" for resource types not covered by the state hash
+func stateRoot(resourceType string) string {
+	switch {
+	case resourceType == ResourceTypeGatewayService || resourceType == ResourceTypeDeck:
+		return "control_plane"
+	case strings.HasPrefix(resourceType, ResourceTypeEventGatewayControlPlane):
+		return ResourceTypeEventGatewayControlPlane
+	case strings.HasPrefix(resourceType, ResourceTypePortal):
+		return ResourceTypePortal
+	case resourceType == "api" || strings.HasPrefix(resourceType, "api_"):
+		return "api"
+	case strings.HasPrefix(resourceType, ResourceTypeOrganizationTeam):
+		return ResourceTypeOrganizationTeam
+	}
+	if _, ok := stateListers[resourceType]; ok {
+		return resourceType
+	}
+	return ""
+}
+
+// HashState computes a digest of the live state a plan was computed against: the
+// managed top-level resources of every type the plan changes, in the namespaces of
+// its changes. Changes to child resources alone do not change the digest.
+func HashState(ctx context.Context, client *state.Client, plan *Plan) (string, error) {
+	roots := make(map[string]bool)
+	namespaceSet := make(map[string]bool)
+	for _, change := range plan.Changes {
+		if root := stateRoot(change.ResourceType); root != "" {
+			roots[root] = true
+		}
+		if change.Namespace != "" {
+			namespaceSet[change.Namespace] = true
+		}
+	}
+	namespaces := sortedKeys(namespaceSet)
+	rootTypes := sortedKeys(roots)
+
+	hash := sha256.New()
+	for _, root := range rootTypes {
+		current, err := stateListers[root](ctx, client, namespaces)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s state: %w", root, err)
+		}
+		data, err := encodeState(current)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s state: %w", root, err)
+		}
+		fmt.Fprintf(hash, "%s\n%s\n", root, data)
+	}
+	return stateHashPrefix + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Stamp records the state hash of the plan and derives its ID from the hash and the
+// changes, so the same reviewed plan always has the same ID
+func (p *Plan) Stamp(ctx context.Context, client *state.Client) error {
+	stateHash, err := HashState(ctx, client, p)
+	if err != nil {
+		return err
+	}
+	p.Metadata.StateHash = stateHash
+
+	changes, err := json.Marshal(p.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode plan changes: %w", err)
+	}
+	id := sha256.Sum256(append([]byte(stateHash+"\n"+string(p.Metadata.Mode)+"\n"), changes...))
+	p.Metadata.PlanID = hex.EncodeToString(id[:8])
+	return nil
+}
+
+// CheckState fails with ErrStalePlan when the live state differs from the state the
+// plan was computed against. Plans without a state hash are not checked.
+func (p *Plan) CheckState(ctx context.Context, client *state.Client) error {
+	if p.Metadata.StateHash == "" {
+		return nil
+	}
+	current, err := HashState(ctx, client, p)
+	if err != nil {
+		return err
+	}
+	if current != p.Metadata.StateHash {
+		return fmt.Errorf("%w: Konnect state changed since plan %s was generated", ErrStalePlan, p.Metadata.PlanID)
+	}
+	return nil
+}
+
+// encodeState encodes listed resources sorted by ID, as list order is not guaranteed
+func encodeState(resources any) ([]byte, error) {
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return nil, err
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return fmt.Sprint(entries[i]["id"]) < fmt.Sprint(entries[j]["id"])
+	})
+	return json.Marshal(entries)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
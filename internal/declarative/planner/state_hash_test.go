@@ -0,0 +1,113 @@
+package planner
+
+import (
+	"context"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubListPortalsAPI struct {
+	helpers.PortalAPI
+	portals []kkComps.ListPortalsResponsePortal
+}
+
+func (s *stubListPortalsAPI) ListPortals(
+	_ context.Context, _ kkOps.ListPortalsRequest,
+) (*kkOps.ListPortalsResponse, error) {
+	return &kkOps.ListPortalsResponse{
+		ListPortalsResponse: &kkComps.ListPortalsResponse{
+			Data: s.portals,
+			Meta: kkComps.PaginatedMeta{Page: kkComps.PageMeta{Total: float64(len(s.portals))}},
+		},
+	}, nil
+}
+
+func newStampedPortalPlan(t *testing.T, client *state.Client) *Plan {
+	t.Helper()
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	plan.AddChange(PlannedChange{
+		ID:           "1:u:portal:dev",
+		ResourceType: "portal_page",
+		ResourceRef:  "getting-started",
+		Action:       ActionUpdate,
+		Fields:       map[string]any{"title": "Getting started"},
+		Namespace:    "default",
+	})
+	require.NoError(t, plan.Stamp(context.Background(), client))
+	return plan
+}
+
+func TestPlanStamp_RecordsStateHashAndID(t *testing.T) {
+	managed := map[string]string{labels.NamespaceKey: "default"}
+	portals := &stubListPortalsAPI{portals: []kkComps.ListPortalsResponsePortal{
+		newListPortal("portal-2", "staging", managed),
+		newListPortal("portal-1", "dev", managed),
+	}}
+	client := state.NewClient(state.ClientConfig{PortalAPI: portals})
+
+	plan := newStampedPortalPlan(t, client)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, plan.Metadata.StateHash)
+	assert.Len(t, plan.Metadata.PlanID, 16)
+
+	// The hash does not depend on list order, and the ID is derived from the content
+	portals.portals[0], portals.portals[1] = portals.portals[1], portals.portals[0]
+	again := newStampedPortalPlan(t, client)
+	assert.Equal(t, plan.Metadata.StateHash, again.Metadata.StateHash)
+	assert.Equal(t, plan.Metadata.PlanID, again.Metadata.PlanID)
+	require.NoError(t, plan.CheckState(context.Background(), client))
+}
+
+func TestPlanCheckState_DetectsChangedState(t *testing.T) {
+	managed := map[string]string{labels.NamespaceKey: "default"}
+	portals := &stubListPortalsAPI{portals: []kkComps.ListPortalsResponsePortal{
+		newListPortal("portal-1", "dev", managed),
+	}}
+	client := state.NewClient(state.ClientConfig{PortalAPI: portals})
+	plan := newStampedPortalPlan(t, client)
+
+	description := "edited in the Konnect UI"
+	portals.portals[0].Description = &description
+	err := plan.CheckState(context.Background(), client)
+	require.ErrorIs(t, err, ErrStalePlan)
+	assert.Contains(t, err.Error(), plan.Metadata.PlanID)
+
+	// Resources of other namespaces are not part of the plan state
+	portals.portals[0].Description = nil
+	portals.portals = append(portals.portals,
+		newListPortal("portal-3", "other", map[string]string{labels.NamespaceKey: "team-b"}))
+	require.NoError(t, plan.CheckState(context.Background(), client))
+}
+
+func TestPlanCheckState_SkipsPlansWithoutHash(t *testing.T) {
+	plan := NewPlan("1.0", "test", PlanModeApply)
+	plan.AddChange(PlannedChange{ResourceType: ResourceTypePortal, Namespace: "default"})
+
+	// The client has no portal API, so any state read would fail
+	require.NoError(t, plan.CheckState(context.Background(), state.NewClient(state.ClientConfig{})))
+}
+
+func TestStateRoot(t *testing.T) {
+	tests := map[string]string{
+		"portal":                        ResourceTypePortal,
+		"portal_page":                   ResourceTypePortal,
+		"api_publication":               "api",
+		"api":                           "api",
+		"gateway_service":               "control_plane",
+		"_deck":                         "control_plane",
+		"event_gateway_virtual_cluster": ResourceTypeEventGatewayControlPlane,
+		"organization_team":             "organization_team",
+		"organization_team_member":      "organization_team",
+		"application_auth_strategy":     "application_auth_strategy",
+		"custom_widget":                 "",
+	}
+	for resourceType, want := range tests {
+		assert.Equal(t, want, stateRoot(resourceType), resourceType)
+	}
+}
@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +32,29 @@ type PlanMetadata struct {
 	GeneratedAt time.Time `json:"generated_at"`
 	Generator   string    `json:"generator"`
 	Mode        PlanMode  `json:"mode"`
+	// PlanID identifies the plan; it is derived from the changes and the state hash
+	PlanID string `json:"plan_id,omitempty"`
+	// StateHash is the digest of the live state the plan was computed against
+	StateHash string `json:"state_hash,omitempty"`
+	// SensitiveFields lists additional field paths redacted when the plan is displayed
+	SensitiveFields []string `json:"sensitive_fields,omitempty"`
+	// IgnoredResources lists "type:ref" resources hidden when the plan is displayed
+	IgnoredResources []string `json:"ignored_resources,omitempty"`
+	// OrgID is the Konnect organization a signed plan was generated against
+	OrgID string `json:"org_id,omitempty"`
+	// Region is the Konnect region the plan was generated for, when it is not the
+	// region of the profile
+	Region string `json:"region,omitempty"`
+	// Signature signs the plan, binding the reviewed changes to the organization
+	Signature *PlanSignature `json:"signature,omitempty"`
+}
+
+// PlanSignature is the signature of a plan artifact
+type PlanSignature struct {
+	Algorithm string `json:"algorithm"`
+	// KeyID is the SHA-256 fingerprint of the public key that verifies the signature
+	KeyID string `json:"key_id"`
+	Value string `json:"value"`
 }
 
 // PlannedChange represents a single resource change
@@ -49,6 +73,17 @@ type PlannedChange struct {
 	Protection            any                      `json:"protection,omitempty"` // bool or ProtectionChange
 	Namespace             string                   `json:"namespace"`
 	DependsOn             []string                 `json:"depends_on,omitempty"`
+	// IdentityFields lists fields of an UPDATE holding the current values, sent only to
+	// identify the resource to the Konnect API
+	IdentityFields []string    `json:"identity_fields,omitempty"`
+	Risk           *ChangeRisk `json:"risk,omitempty"`
+	// DeleteProtected marks the DELETE of a protected resource that was confirmed
+	// when the plan was generated
+	DeleteProtected bool `json:"delete_protected,omitempty"`
+	// MovedFrom is the former ref of a resource a moved entry renamed
+	MovedFrom string `json:"moved_from,omitempty"`
+	// Explanation tells why each field of an UPDATE was planned, see Plan.Explain
+	Explanation []FieldExplanation `json:"explanation,omitempty"`
 }
 
 // PostResolutionTarget represents a resource that must be resolved after a change executes.
@@ -101,6 +136,9 @@ const (
 	ActionUpdate       ActionType = "UPDATE"
 	ActionDelete       ActionType = "DELETE"
 	ActionExternalTool ActionType = "EXTERNAL_TOOL"
+	// ActionSwitch stages an API publication at one visibility, optionally verifies
+	// it, then flips it to its declared visibility as a single change
+	ActionSwitch ActionType = "SWITCH"
 )
 
 // PlanSummary provides overview statistics
@@ -110,6 +148,14 @@ type PlanSummary struct {
 	ByResource        map[string]int                      `json:"by_resource"`
 	ByExternalTools   map[string][]ExternalToolDependency `json:"by_external_tools,omitempty"`
 	ProtectionChanges *ProtectionSummary                  `json:"protection_changes,omitempty"`
+	Risk              *RiskSummary                        `json:"risk,omitempty"`
+	// IgnoredChanges counts changes hidden from display but still included in the counts
+	IgnoredChanges int `json:"ignored_changes,omitempty"`
+	// NoOp counts resources of the configuration that already match Konnect
+	NoOp int `json:"no_op"`
+	// ResourceCounts compares the number of resources of the limited resource types the
+	// plan creates or deletes before and after it is applied, see CountResources
+	ResourceCounts map[string]ResourceCount `json:"resource_counts,omitempty"`
 }
 
 // ProtectionSummary tracks protection changes
@@ -194,6 +240,32 @@ func (p *Plan) AddWarning(changeID, message string) {
 	})
 }
 
+// WithoutChanges returns a copy of plan without the changes with the given IDs, along
+// with their execution order entries, warnings and the dependencies on them. The
+// summary is recalculated from the remaining changes.
+func (p *Plan) WithoutChanges(ids []string) *Plan {
+	if p == nil || len(ids) == 0 {
+		return p
+	}
+	removed := func(id string) bool { return slices.Contains(ids, id) }
+
+	filtered := *p
+	filtered.Changes = make([]PlannedChange, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		if removed(change.ID) {
+			continue
+		}
+		change.DependsOn = slices.DeleteFunc(slices.Clone(change.DependsOn), removed)
+		filtered.Changes = append(filtered.Changes, change)
+	}
+	filtered.ExecutionOrder = slices.DeleteFunc(slices.Clone(p.ExecutionOrder), removed)
+	filtered.Warnings = slices.DeleteFunc(slices.Clone(p.Warnings), func(w PlanWarning) bool {
+		return removed(w.ChangeID)
+	})
+	filtered.UpdateSummary()
+	return &filtered
+}
+
 // UpdateSummary recalculates plan statistics
 func (p *Plan) UpdateSummary() {
 	p.Summary.TotalChanges = len(p.Changes)
@@ -239,6 +311,7 @@ func (p *Plan) UpdateSummary() {
 	if len(externalTools) > 0 {
 		p.Summary.ByExternalTools = externalTools
 	}
+	p.updateResourceCounts()
 }
 
 func externalToolDependencyFromChange(change PlannedChange) ExternalToolDependency {
@@ -462,6 +535,60 @@ func stringSliceFromField(fields map[string]any, key string) []string {
 	return clone
 }
 
+// CompactPlan is a plan without the fields of its changes: the summary and the
+// resources each change affects. It is the output of plan --summary-only.
+type CompactPlan struct {
+	Mode    PlanMode        `json:"mode"`
+	PlanID  string          `json:"plan_id,omitempty"`
+	Summary PlanSummary     `json:"summary"`
+	Changes []CompactChange `json:"changes"`
+}
+
+// CompactChange is the resource a planned change affects and its action
+type CompactChange struct {
+	ResourceType string     `json:"resource_type"`
+	ResourceRef  string     `json:"resource_ref"`
+	Action       ActionType `json:"action"`
+	Namespace    string     `json:"namespace,omitempty"`
+}
+
+// Compact returns the summary of the plan and its changes in execution order
+func (p *Plan) Compact() CompactPlan {
+	byID := make(map[string]PlannedChange, len(p.Changes))
+	for _, change := range p.Changes {
+		byID[change.ID] = change
+	}
+	ordered := make([]PlannedChange, 0, len(p.Changes))
+	for _, id := range p.ExecutionOrder {
+		if change, ok := byID[id]; ok {
+			ordered = append(ordered, change)
+			delete(byID, id)
+		}
+	}
+	// Changes missing from the execution order keep their order in the plan
+	for _, change := range p.Changes {
+		if _, ok := byID[change.ID]; ok {
+			ordered = append(ordered, change)
+		}
+	}
+
+	compact := CompactPlan{
+		Mode:    p.Metadata.Mode,
+		PlanID:  p.Metadata.PlanID,
+		Summary: p.Summary,
+		Changes: make([]CompactChange, 0, len(ordered)),
+	}
+	for _, change := range ordered {
+		compact.Changes = append(compact.Changes, CompactChange{
+			ResourceType: change.ResourceType,
+			ResourceRef:  change.ResourceRef,
+			Action:       change.Action,
+			Namespace:    change.Namespace,
+		})
+	}
+	return compact
+}
+
 // IsEmpty returns true if plan has no changes
 func (p *Plan) IsEmpty() bool {
 	return len(p.Changes) == 0
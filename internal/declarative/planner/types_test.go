@@ -2,6 +2,7 @@ package planner
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -293,3 +294,42 @@ func TestNewPlan_WithMode(t *testing.T) {
 		t.Errorf("Expected mode %s, got %s", PlanModeApply, plan.Metadata.Mode)
 	}
 }
+
+func TestPlan_Compact(t *testing.T) {
+	plan := NewPlan("1.0", "kongctl/test", PlanModeApply)
+	plan.AddChange(PlannedChange{
+		ID: "2:c:api:orders", ResourceType: "api", ResourceRef: "orders", Action: ActionCreate,
+		Namespace: "default", Fields: map[string]any{"name": "orders", "description": "Orders API"},
+	})
+	plan.AddChange(PlannedChange{
+		ID: "1:u:portal:dev", ResourceType: "portal", ResourceRef: "dev", Action: ActionUpdate,
+		Namespace: "default", Fields: map[string]any{"display_name": "Developers"},
+	})
+	plan.SetExecutionOrder([]string{"1:u:portal:dev", "2:c:api:orders"})
+
+	compact := plan.Compact()
+
+	if compact.Mode != PlanModeApply || compact.Summary.TotalChanges != 2 {
+		t.Errorf("Expected an apply plan with 2 changes, got %s with %d", compact.Mode, compact.Summary.TotalChanges)
+	}
+	expected := []CompactChange{
+		{ResourceType: "portal", ResourceRef: "dev", Action: ActionUpdate, Namespace: "default"},
+		{ResourceType: "api", ResourceRef: "orders", Action: ActionCreate, Namespace: "default"},
+	}
+	if len(compact.Changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d", len(expected), len(compact.Changes))
+	}
+	for i := range expected {
+		if compact.Changes[i] != expected[i] {
+			t.Errorf("Expected change %d to be %+v, got %+v", i, expected[i], compact.Changes[i])
+		}
+	}
+
+	data, err := json.Marshal(compact)
+	if err != nil {
+		t.Fatalf("Failed to marshal compact plan: %v", err)
+	}
+	if strings.Contains(string(data), "Orders API") {
+		t.Errorf("Expected the compact plan to omit change fields, got %s", data)
+	}
+}
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// planActions are the actions a plan rule can be limited to
+var planActions = []planner.ActionType{
+	planner.ActionCreate,
+	planner.ActionUpdate,
+	planner.ActionDelete,
+	planner.ActionSwitch,
+}
+
+// PlanPolicy is a set of rules evaluated against the changes of a plan, such as
+// requiring API publications to be created with an auth strategy
+type PlanPolicy struct {
+	Rules []PlanRule `json:"rules" yaml:"rules"`
+}
+
+// PlanRule requires every planned change of a resource type to satisfy a condition
+type PlanRule struct {
+	// Name identifies the rule in violation messages
+	Name string `json:"name" yaml:"name"`
+	// Resource is the resource type of the changes the rule applies to; empty applies
+	// the rule to every change
+	Resource string `json:"resource,omitempty" yaml:"resource,omitempty"`
+	// Actions limits the rule to changes with these actions, e.g. CREATE and UPDATE
+	Actions []planner.ActionType `json:"actions,omitempty" yaml:"actions,omitempty"`
+	// Condition is a jq expression evaluated against each planned change, as written
+	// in plan files; the change complies when every output is neither false nor null
+	Condition string `json:"condition" yaml:"condition"`
+	// Message explains the violation
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Severity defaults to error
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	code *gojq.Code
+}
+
+// Merge adds the rules of other to the policy. The result must be validated.
+func (p *PlanPolicy) Merge(other *PlanPolicy) {
+	p.Rules = append(p.Rules, other.Rules...)
+}
+
+// Validate checks the rules and compiles their conditions
+func (p *PlanPolicy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("policy must define at least one rule")
+	}
+
+	seen := make(map[string]bool, len(p.Rules))
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if strings.TrimSpace(rule.Name) == "" {
+			return fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("rule %q is defined more than once", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if rule.Resource != "" && !resources.IsRegistered(resources.ResourceType(rule.Resource)) {
+			return fmt.Errorf("rule %q: unknown resource type %q", rule.Name, rule.Resource)
+		}
+		for j, action := range rule.Actions {
+			action = planner.ActionType(strings.ToUpper(string(action)))
+			if !slices.Contains(planActions, action) {
+				return fmt.Errorf("rule %q: unknown action %q", rule.Name, rule.Actions[j])
+			}
+			rule.Actions[j] = action
+		}
+		severity, err := validateSeverity(rule.Name, rule.Severity)
+		if err != nil {
+			return err
+		}
+		rule.Severity = severity
+
+		if rule.code, err = compileCondition(rule.Name, rule.Condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the violations of every rule, ordered by rule then plan order.
+// The policy must have been validated.
+func (p *PlanPolicy) Evaluate(plan *planner.Plan) ([]Violation, error) {
+	var violations []Violation
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.code == nil {
+			return nil, fmt.Errorf("rule %q has not been validated", rule.Name)
+		}
+
+		for _, change := range plan.Changes {
+			if !rule.appliesTo(change) {
+				continue
+			}
+			input, err := toJQInput(change)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: failed to encode change %s: %w", rule.Name, change.ID, err)
+			}
+
+			compliant, evalErr := complies(rule.code, input)
+			if compliant {
+				continue
+			}
+			message := rule.Message
+			if message == "" {
+				message = "does not satisfy " + rule.Condition
+			}
+			if evalErr != nil {
+				message = fmt.Sprintf("condition failed: %v", evalErr)
+			}
+			violations = append(violations, Violation{
+				Rule:         rule.Name,
+				Severity:     rule.Severity,
+				ResourceType: change.ResourceType,
+				Ref:          change.ResourceRef,
+				Action:       string(change.Action),
+				Message:      message,
+			})
+		}
+	}
+	return violations, nil
+}
+
+// appliesTo reports whether the rule evaluates change. Changes run by external tools,
+// such as decK, are not evaluated.
+func (r *PlanRule) appliesTo(change planner.PlannedChange) bool {
+	if change.Action == planner.ActionExternalTool {
+		return false
+	}
+	if r.Resource != "" && change.ResourceType != r.Resource {
+		return false
+	}
+	return len(r.Actions) == 0 || slices.Contains(r.Actions, change.Action)
+}
+
+// Check evaluates the policy and returns an *Error when any error violation is found,
+// along with the warning violations
+func (p *PlanPolicy) Check(plan *planner.Plan) ([]Violation, error) {
+	violations, err := p.Evaluate(plan)
+	if err != nil {
+		return nil, err
+	}
+	return splitViolations(violations)
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const testPlanPolicy = `
+rules:
+  - name: public-portals-rbac
+    resource: portal
+    actions: [create, update]
+    condition: '.fields.default_api_visibility != "public" or .fields.rbac_enabled == true'
+    message: public portals must enable RBAC
+  - name: publication-auth
+    resource: api_publication
+    actions: [CREATE]
+    condition: '(.fields.auth_strategy_ids // []) | length > 0'
+    message: APIs must be published with an auth strategy
+  - name: no-deletes
+    actions: [DELETE]
+    condition: "false"
+    severity: warning
+`
+
+func parsePlanPolicy(t *testing.T, data string) *PlanPolicy {
+	t.Helper()
+	var policy PlanPolicy
+	require.NoError(t, yaml.UnmarshalStrict([]byte(data), &policy))
+	require.NoError(t, policy.Validate())
+	return &policy
+}
+
+func testPlan(changes ...planner.PlannedChange) *planner.Plan {
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	for _, change := range changes {
+		plan.AddChange(change)
+	}
+	return plan
+}
+
+func TestPlanPolicyCheck_Passes(t *testing.T) {
+	plan := testPlan(
+		planner.PlannedChange{ID: "1:c:portal:dev", ResourceType: "portal", ResourceRef: "dev",
+			Action: planner.ActionCreate,
+			Fields: map[string]any{"default_api_visibility": "public", "rbac_enabled": true}},
+		planner.PlannedChange{ID: "2:c:api_publication:orders-dev", ResourceType: "api_publication",
+			ResourceRef: "orders-dev", Action: planner.ActionCreate,
+			Fields: map[string]any{"auth_strategy_ids": []string{"key-auth"}}},
+		// decK changes are not evaluated
+		planner.PlannedChange{ID: "3:e:_deck:gw", ResourceType: "_deck", ResourceRef: "gw",
+			Action: planner.ActionExternalTool},
+	)
+
+	warnings, err := parsePlanPolicy(t, testPlanPolicy).Check(plan)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestPlanPolicyCheck_Fails(t *testing.T) {
+	plan := testPlan(
+		planner.PlannedChange{ID: "1:u:portal:dev", ResourceType: "portal", ResourceRef: "dev",
+			Action: planner.ActionUpdate, Fields: map[string]any{"default_api_visibility": "public"}},
+		planner.PlannedChange{ID: "2:c:api_publication:orders-dev", ResourceType: "api_publication",
+			ResourceRef: "orders-dev", Action: planner.ActionCreate, Fields: map[string]any{}},
+		// Updates of publications are not limited by the publication-auth rule
+		planner.PlannedChange{ID: "3:u:api_publication:billing-dev", ResourceType: "api_publication",
+			ResourceRef: "billing-dev", Action: planner.ActionUpdate, Fields: map[string]any{}},
+		planner.PlannedChange{ID: "4:d:api:legacy", ResourceType: "api", ResourceRef: "legacy",
+			Action: planner.ActionDelete},
+	)
+
+	warnings, err := parsePlanPolicy(t, testPlanPolicy).Check(plan)
+	var policyErr *Error
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, []Violation{
+		{Rule: "public-portals-rbac", Severity: SeverityError, ResourceType: "portal", Ref: "dev",
+			Action: "UPDATE", Message: "public portals must enable RBAC"},
+		{Rule: "publication-auth", Severity: SeverityError, ResourceType: "api_publication", Ref: "orders-dev",
+			Action: "CREATE", Message: "APIs must be published with an auth strategy"},
+	}, policyErr.Violations)
+	assert.Contains(t, err.Error(),
+		`[publication-auth] CREATE api_publication "orders-dev": APIs must be published with an auth strategy`)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, Violation{Rule: "no-deletes", Severity: SeverityWarning, ResourceType: "api", Ref: "legacy",
+		Action: "DELETE", Message: `does not satisfy false`}, warnings[0])
+}
+
+func TestPlanPolicyMerge(t *testing.T) {
+	policy := parsePlanPolicy(t, testPlanPolicy)
+	policy.Merge(&PlanPolicy{Rules: []PlanRule{{Name: "no-deletes", Condition: "true"}}})
+	require.ErrorContains(t, policy.Validate(), `rule "no-deletes" is defined more than once`)
+}
+
+func TestPlanPolicyValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy string
+		err    string
+	}{
+		"no rules":     {policy: "rules: []", err: "at least one rule"},
+		"missing name": {policy: "rules: [{condition: 'true'}]", err: "name is required"},
+		"unknown resource": {
+			policy: "rules: [{name: a, resource: apy, condition: 'true'}]",
+			err:    `unknown resource type "apy"`,
+		},
+		"unknown action": {
+			policy: "rules: [{name: a, actions: [replace], condition: 'true'}]",
+			err:    `unknown action "replace"`,
+		},
+		"bad severity": {
+			policy: "rules: [{name: a, condition: 'true', severity: fatal}]",
+			err:    "severity must be",
+		},
+		"bad condition": {
+			policy: "rules: [{name: a, condition: '.fields =='}]",
+			err:    "invalid condition",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var policy PlanPolicy
+			require.NoError(t, yaml.UnmarshalStrict([]byte(tc.policy), &policy))
+			require.ErrorContains(t, policy.Validate(), tc.err)
+		})
+	}
+}
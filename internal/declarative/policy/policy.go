@@ -0,0 +1,243 @@
+// Package policy evaluates governance rules against loaded declarative config and
+// generated plans. Each rule is a jq condition that every resource of a type, or
+// every planned change, must satisfy, such as `.description != null` for APIs.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+// Severity decides whether a violation fails the plan
+type Severity string
+
+const (
+	// SeverityError violations fail the plan
+	SeverityError Severity = "error"
+	// SeverityWarning violations are reported without failing the plan
+	SeverityWarning Severity = "warning"
+)
+
+// Policy is a set of rules evaluated against the configuration
+type Policy struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule requires every resource of a type to satisfy a condition
+type Rule struct {
+	// Name identifies the rule in violation messages
+	Name string `json:"name" yaml:"name"`
+	// Resource is the resource type the rule applies to, e.g. api or portal
+	Resource string `json:"resource" yaml:"resource"`
+	// Condition is a jq expression evaluated against each resource; the resource
+	// complies when every output is neither false nor null
+	Condition string `json:"condition" yaml:"condition"`
+	// Message explains the violation
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Severity defaults to error
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	code *gojq.Code
+}
+
+// Violation is a resource that does not satisfy a rule
+type Violation struct {
+	Rule         string   `json:"rule"`
+	Severity     Severity `json:"severity"`
+	ResourceType string   `json:"resource_type"`
+	Ref          string   `json:"ref"`
+	// Action is the planned action of the change that violates a plan rule
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Action != "" {
+		return fmt.Sprintf("[%s] %s %s %q: %s", v.Rule, v.Action, v.ResourceType, v.Ref, v.Message)
+	}
+	return fmt.Sprintf("[%s] %s %q: %s", v.Rule, v.ResourceType, v.Ref, v.Message)
+}
+
+// Validate checks the rules and compiles their conditions
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("policy must define at least one rule")
+	}
+
+	seen := make(map[string]bool, len(p.Rules))
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if strings.TrimSpace(rule.Name) == "" {
+			return fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("rule %q is defined more than once", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if !resources.IsRegistered(resources.ResourceType(rule.Resource)) {
+			return fmt.Errorf("rule %q: unknown resource type %q", rule.Name, rule.Resource)
+		}
+		severity, err := validateSeverity(rule.Name, rule.Severity)
+		if err != nil {
+			return err
+		}
+		rule.Severity = severity
+
+		if rule.code, err = compileCondition(rule.Name, rule.Condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSeverity checks the severity of a rule, which defaults to error
+func validateSeverity(rule string, severity Severity) (Severity, error) {
+	switch severity {
+	case "":
+		return SeverityError, nil
+	case SeverityError, SeverityWarning:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("rule %q: severity must be %s or %s, got %q",
+			rule, SeverityError, SeverityWarning, severity)
+	}
+}
+
+// compileCondition compiles the jq condition of a rule
+func compileCondition(rule, condition string) (*gojq.Code, error) {
+	query, err := gojq.Parse(condition)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: invalid condition: %w", rule, err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: invalid condition: %w", rule, err)
+	}
+	return code, nil
+}
+
+// Evaluate returns the violations of every rule, ordered by rule then resource ref.
+// The policy must have been validated.
+func (p *Policy) Evaluate(rs *resources.ResourceSet) ([]Violation, error) {
+	var violations []Violation
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.code == nil {
+			return nil, fmt.Errorf("rule %q has not been validated", rule.Name)
+		}
+
+		matched := rs.AllResourcesByType(resources.ResourceType(rule.Resource))
+		var ruleViolations []Violation
+		for _, resource := range matched {
+			input, err := toJQInput(resource)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: failed to encode %s %q: %w",
+					rule.Name, rule.Resource, resource.GetRef(), err)
+			}
+
+			compliant, evalErr := complies(rule.code, input)
+			if compliant {
+				continue
+			}
+			message := rule.Message
+			if message == "" {
+				message = "does not satisfy " + rule.Condition
+			}
+			if evalErr != nil {
+				message = fmt.Sprintf("condition failed: %v", evalErr)
+			}
+			ruleViolations = append(ruleViolations, Violation{
+				Rule:         rule.Name,
+				Severity:     rule.Severity,
+				ResourceType: rule.Resource,
+				Ref:          resource.GetRef(),
+				Message:      message,
+			})
+		}
+		sort.SliceStable(ruleViolations, func(a, b int) bool {
+			return ruleViolations[a].Ref < ruleViolations[b].Ref
+		})
+		violations = append(violations, ruleViolations...)
+	}
+	return violations, nil
+}
+
+// complies runs a condition; a condition without output does not comply
+func complies(code *gojq.Code, input any) (bool, error) {
+	iter := code.Run(input)
+	outputs := 0
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			return outputs > 0, nil
+		}
+		if err, isErr := value.(error); isErr {
+			return false, err
+		}
+		outputs++
+		if value == nil || value == false {
+			return false, nil
+		}
+	}
+}
+
+// toJQInput converts a value to the plain JSON values gojq operates on
+func toJQInput(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var input any
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// Error reports the error violations that failed a policy check
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "policy check failed with %d violation(s):", len(e.Violations))
+	for _, violation := range e.Violations {
+		b.WriteString("\n  - ")
+		b.WriteString(violation.String())
+	}
+	return b.String()
+}
+
+// Check evaluates the policy and returns an *Error when any error violation is found,
+// along with the warning violations
+func (p *Policy) Check(rs *resources.ResourceSet) ([]Violation, error) {
+	violations, err := p.Evaluate(rs)
+	if err != nil {
+		return nil, err
+	}
+	return splitViolations(violations)
+}
+
+// splitViolations returns the warning violations, and an *Error of the error violations
+// when there are any
+func splitViolations(violations []Violation) ([]Violation, error) {
+	var errs, warnings []Violation
+	for _, violation := range violations {
+		if violation.Severity == SeverityWarning {
+			warnings = append(warnings, violation)
+		} else {
+			errs = append(errs, violation)
+		}
+	}
+	if len(errs) > 0 {
+		return warnings, &Error{Violations: errs}
+	}
+	return warnings, nil
+}
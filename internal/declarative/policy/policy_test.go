@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const testPolicy = `
+rules:
+  - name: api-description
+    resource: api
+    condition: '(.description // "") != ""'
+    message: APIs must have a description
+  - name: portal-auth
+    resource: portal
+    condition: .authentication_enabled == true
+    message: portals must require authentication
+  - name: api-version-label
+    resource: api
+    condition: .labels.owner != null
+    severity: warning
+`
+
+func loadConfig(t *testing.T, config string) *resources.ResourceSet {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	rs, err := loader.New().LoadFile(path)
+	require.NoError(t, err)
+	return rs
+}
+
+func parsePolicy(t *testing.T, data string) *Policy {
+	t.Helper()
+	var policy Policy
+	require.NoError(t, yaml.UnmarshalStrict([]byte(data), &policy))
+	require.NoError(t, policy.Validate())
+	return &policy
+}
+
+func TestCheck_Passes(t *testing.T) {
+	rs := loadConfig(t, `
+portals:
+  - ref: dev
+    name: dev
+    authentication_enabled: true
+apis:
+  - ref: orders
+    name: orders
+    description: Order management
+    labels:
+      owner: payments
+`)
+
+	warnings, err := parsePolicy(t, testPolicy).Check(rs)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestCheck_Fails(t *testing.T) {
+	rs := loadConfig(t, `
+portals:
+  - ref: dev
+    name: dev
+    authentication_enabled: false
+apis:
+  - ref: refunds
+    name: refunds
+  - ref: orders
+    name: orders
+    description: Order management
+  - ref: billing
+    name: billing
+    description: ""
+`)
+
+	warnings, err := parsePolicy(t, testPolicy).Check(rs)
+	var policyErr *Error
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, []Violation{
+		{Rule: "api-description", Severity: SeverityError, ResourceType: "api", Ref: "billing",
+			Message: "APIs must have a description"},
+		{Rule: "api-description", Severity: SeverityError, ResourceType: "api", Ref: "refunds",
+			Message: "APIs must have a description"},
+		{Rule: "portal-auth", Severity: SeverityError, ResourceType: "portal", Ref: "dev",
+			Message: "portals must require authentication"},
+	}, policyErr.Violations)
+	assert.Contains(t, err.Error(), `[portal-auth] portal "dev": portals must require authentication`)
+
+	require.Len(t, warnings, 3, "no API has an owner label")
+	assert.Equal(t, "does not satisfy .labels.owner != null", warnings[0].Message)
+}
+
+func TestCheck_ConditionErrorsAreViolations(t *testing.T) {
+	rs := loadConfig(t, `
+apis:
+  - ref: orders
+    name: orders
+`)
+	policy := parsePolicy(t, `
+rules:
+  - name: broken
+    resource: api
+    condition: .name | tonumber > 1
+`)
+
+	_, err := policy.Check(rs)
+	require.ErrorContains(t, err, `[broken] api "orders": condition failed`)
+}
+
+func TestValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy string
+		err    string
+	}{
+		"no rules":     {policy: "rules: []", err: "at least one rule"},
+		"missing name": {policy: "rules: [{resource: api, condition: 'true'}]", err: "name is required"},
+		"duplicate name": {
+			policy: "rules: [{name: a, resource: api, condition: 'true'}, {name: a, resource: api, condition: 'true'}]",
+			err:    "more than once",
+		},
+		"unknown resource": {
+			policy: "rules: [{name: a, resource: apy, condition: 'true'}]",
+			err:    `unknown resource type "apy"`,
+		},
+		"bad severity": {
+			policy: "rules: [{name: a, resource: api, condition: 'true', severity: fatal}]",
+			err:    "severity must be",
+		},
+		"bad condition": {
+			policy: "rules: [{name: a, resource: api, condition: '.name =='}]",
+			err:    "invalid condition",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var policy Policy
+			require.NoError(t, yaml.UnmarshalStrict([]byte(tc.policy), &policy))
+			require.ErrorContains(t, policy.Validate(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Package preflight checks that an environment can apply a declarative configuration
+// before any change is made: that the configuration is valid, Konnect is reachable in
+// the region, the access token is accepted, and the token may manage each kind of
+// resource the configuration declares. Every problem is reported at once.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	decerrors "github.com/kong/kongctl/internal/declarative/errors"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/meta"
+)
+
+// Names of the checks, in the order they run
+const (
+	CheckConfiguration = "configuration"
+	CheckKonnect       = "konnect"
+	CheckToken         = "access token"
+	CheckPermissions   = "permissions"
+)
+
+// Check is the outcome of one preflight check
+type Check struct {
+	Name string `json:"name"`
+	// Detail describes what a passing check found
+	Detail string `json:"detail,omitempty"`
+	// Skipped is set when an earlier check failed in a way this check depends on
+	Skipped  bool     `json:"skipped,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Passed reports whether the check ran and found no problem
+func (c Check) Passed() bool {
+	return !c.Skipped && len(c.Problems) == 0
+}
+
+// Report is the outcome of the preflight checks
+type Report struct {
+	BaseURL string  `json:"base_url"`
+	Checks  []Check `json:"checks"`
+}
+
+// Problems returns the number of problems the checks found
+func (r *Report) Problems() int {
+	count := 0
+	for _, check := range r.Checks {
+		count += len(check.Problems)
+	}
+	return count
+}
+
+// Write prints the report, one line per check followed by its problems
+func (r *Report) Write(out io.Writer) {
+	fmt.Fprintf(out, "Preflight checks against %s:\n", r.BaseURL)
+	for _, check := range r.Checks {
+		switch {
+		case check.Skipped:
+			fmt.Fprintf(out, "  [skip] %s\n", check.Name)
+		case len(check.Problems) == 0:
+			if check.Detail != "" {
+				fmt.Fprintf(out, "  [ok]   %s: %s\n", check.Name, check.Detail)
+			} else {
+				fmt.Fprintf(out, "  [ok]   %s\n", check.Name)
+			}
+		default:
+			fmt.Fprintf(out, "  [fail] %s\n", check.Name)
+			for _, problem := range check.Problems {
+				fmt.Fprintf(out, "           - %s\n", problem)
+			}
+		}
+	}
+}
+
+// Options are the inputs of the checks
+type Options struct {
+	// BaseURL is the Konnect endpoint the configuration is applied to
+	BaseURL string
+	// SDK is the Konnect client, nil when SDKError reports why it could not be built
+	SDK      helpers.SDKAPI
+	SDKError error
+	// Resources is the configuration to apply, nil to only check the environment
+	Resources *resources.ResourceSet
+	// Issues are the problems validating the configuration found
+	Issues []string
+}
+
+// Run runs every check. Resource permissions are only checked once Konnect accepted
+// the access token, as every request would fail the same way otherwise.
+func Run(ctx context.Context, opts Options) *Report {
+	report := &Report{BaseURL: opts.BaseURL}
+
+	if opts.Resources != nil {
+		check := Check{Name: CheckConfiguration, Problems: opts.Issues}
+		if len(check.Problems) == 0 {
+			check.Detail = fmt.Sprintf("%d resource(s) valid", opts.Resources.ResourceCount())
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	reachable, authorized := checkOrganization(ctx, opts, report)
+	if opts.Resources == nil {
+		return report
+	}
+	permissions := Check{Name: CheckPermissions, Skipped: !reachable || !authorized}
+	if !permissions.Skipped {
+		var checked []string
+		for _, family := range families {
+			count := family.count(opts.Resources)
+			if count == 0 {
+				continue
+			}
+			checked = append(checked, family.name)
+			if problem := familyProblem(family.name, count, family.probe(ctx, opts.SDK)); problem != "" {
+				permissions.Problems = append(permissions.Problems, problem)
+			}
+		}
+		permissions.Detail = "no Konnect resources to check"
+		if len(checked) > 0 {
+			permissions.Detail = "may manage " + strings.Join(checked, ", ")
+		}
+	}
+	report.Checks = append(report.Checks, permissions)
+	return report
+}
+
+// checkOrganization checks that Konnect answers requests in the region, then reads
+// the organization of the access token, which the global endpoint of Konnect serves,
+// to check that Konnect accepts the token
+func checkOrganization(ctx context.Context, opts Options, report *Report) (bool, bool) {
+	konnect := Check{Name: CheckKonnect}
+	token := Check{Name: CheckToken}
+	defer func() { report.Checks = append(report.Checks, konnect, token) }()
+
+	if opts.SDK == nil {
+		konnect.Skipped = true
+		token.Problems = []string{opts.SDKError.Error()}
+		return false, false
+	}
+
+	// Any response, an authorization error included, shows the region is reachable
+	_, err := opts.SDK.GetControlPlaneAPI().ListControlPlanes(ctx, kkOps.ListControlPlanesRequest{PageSize: pageSize})
+	if err != nil && statusCode(err) == 0 {
+		konnect.Problems = []string{fmt.Sprintf(
+			"cannot reach Konnect at %s: %v; check the network and the region of the organization (--region)",
+			opts.BaseURL, err)}
+		token.Skipped = true
+		return false, false
+	}
+	konnect.Detail = "reachable"
+
+	res, err := opts.SDK.GetMeAPI().GetOrganizationsMe(ctx)
+	if err != nil {
+		switch statusCode(err) {
+		case 0:
+			token.Problems = []string{fmt.Sprintf("cannot read the organization of the access token: %v", err)}
+		case http.StatusUnauthorized:
+			token.Problems = []string{fmt.Sprintf(
+				"Konnect rejected the access token as invalid or expired; run '%s login' or pass a new --pat",
+				meta.CLIName)}
+		case http.StatusForbidden:
+			token.Problems = []string{"the access token is not allowed to read its organization"}
+		default:
+			token.Problems = []string{fmt.Sprintf("reading the organization of the access token failed: %v", err)}
+		}
+		return true, false
+	}
+
+	organization := res.GetMeOrganization()
+	if organization == nil {
+		return true, true
+	}
+	token.Detail = "organization " + valueOf(organization.Name)
+	if state := organization.State; state != nil && *state != kkComps.MeOrganizationStateActive {
+		token.Problems = []string{fmt.Sprintf("organization %s is %s", valueOf(organization.Name), *state)}
+		return true, false
+	}
+	return true, true
+}
+
+// familyProblem describes the failure to list the resources of a family
+func familyProblem(name string, count int, err error) string {
+	if err == nil {
+		return ""
+	}
+	switch statusCode(err) {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Sprintf("the access token is not allowed to manage %s, which %d resource(s) of "+
+			"the configuration need; grant its user, team or system account a role for them", name, count)
+	case http.StatusNotFound:
+		return fmt.Sprintf("%s are not available to the organization, which %d resource(s) of the "+
+			"configuration need; check that the feature is enabled for its Konnect plan", name, count)
+	default:
+		return fmt.Sprintf("listing %s failed: %v", name, err)
+	}
+}
+
+// statusCode returns the HTTP status of a Konnect API error, or 0 when the request got
+// no response
+func statusCode(err error) int {
+	var sdkErr *sdkerrors.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode
+	}
+	var unauthorized *sdkerrors.UnauthorizedError
+	if errors.As(err, &unauthorized) {
+		return http.StatusUnauthorized
+	}
+	var forbidden *sdkerrors.ForbiddenError
+	if errors.As(err, &forbidden) {
+		return http.StatusForbidden
+	}
+	var notFound *sdkerrors.NotFoundError
+	if errors.As(err, &notFound) {
+		return http.StatusNotFound
+	}
+	return decerrors.ExtractStatusCodeFromError(err)
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// family is a kind of Konnect resources the token needs a role for, checked by
+// listing one of them
+type family struct {
+	name  string
+	count func(*resources.ResourceSet) int
+	probe func(context.Context, helpers.SDKAPI) error
+}
+
+var pageSize = func() *int64 { size := int64(1); return &size }()
+
+var families = []family{
+	{
+		name: "portals",
+		count: func(rs *resources.ResourceSet) int {
+			return len(rs.Portals) + len(rs.PortalPages) + len(rs.PortalSnippets) + len(rs.PortalTeams) +
+				len(rs.PortalCustomizations) + len(rs.PortalAuthSettings) + len(rs.PortalCustomDomains)
+		},
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetPortalAPI().ListPortals(ctx, kkOps.ListPortalsRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name: "APIs",
+		count: func(rs *resources.ResourceSet) int {
+			return len(rs.APIs) + len(rs.APIVersions) + len(rs.APIPublications) +
+				len(rs.APIImplementations) + len(rs.APIDocuments)
+		},
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetAPIAPI().ListApis(ctx, kkOps.ListApisRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name:  "application auth strategies",
+		count: func(rs *resources.ResourceSet) int { return len(rs.ApplicationAuthStrategies) },
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetAppAuthStrategiesAPI().ListAppAuthStrategies(ctx,
+				kkOps.ListAppAuthStrategiesRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name: "control planes",
+		count: func(rs *resources.ResourceSet) int {
+			return len(rs.ControlPlanes) + len(rs.GatewayServices)
+		},
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetControlPlaneAPI().ListControlPlanes(ctx, kkOps.ListControlPlanesRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name:  "catalog services",
+		count: func(rs *resources.ResourceSet) int { return len(rs.CatalogServices) },
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetCatalogServicesAPI().ListCatalogServices(ctx,
+				kkOps.ListCatalogServicesRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name: "event gateways",
+		count: func(rs *resources.ResourceSet) int {
+			return len(rs.EventGatewayControlPlanes) + len(rs.EventGatewayBackendClusters) +
+				len(rs.EventGatewayVirtualClusters)
+		},
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetEventGatewayControlPlaneAPI().ListEGWControlPlanes(ctx,
+				kkOps.ListEventGatewaysRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name: "organization teams",
+		count: func(rs *resources.ResourceSet) int {
+			return len(rs.OrganizationTeams) + len(rs.OrganizationTeamRoles) + len(rs.OrganizationTeamMembers)
+		},
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetOrganizationTeamAPI().ListOrganizationTeams(ctx, kkOps.ListTeamsRequest{PageSize: pageSize})
+			return err
+		},
+	},
+	{
+		name:  "notification subscriptions",
+		count: func(rs *resources.ResourceSet) int { return len(rs.NotificationSubscriptions) },
+		probe: func(ctx context.Context, sdk helpers.SDKAPI) error {
+			_, err := sdk.GetNotificationsAPI().ListUserConfigurations(ctx, nil)
+			return err
+		},
+	},
+}
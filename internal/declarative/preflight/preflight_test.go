@@ -0,0 +1,183 @@
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+)
+
+type stubMeAPI struct {
+	helpers.MeAPI
+	organization *kkComps.MeOrganization
+	err          error
+}
+
+func (s *stubMeAPI) GetOrganizationsMe(context.Context, ...kkOps.Option) (*kkOps.GetOrganizationsMeResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &kkOps.GetOrganizationsMeResponse{MeOrganization: s.organization}, nil
+}
+
+type stubControlPlaneAPI struct {
+	helpers.ControlPlaneAPI
+	err error
+}
+
+func (s *stubControlPlaneAPI) ListControlPlanes(
+	context.Context, kkOps.ListControlPlanesRequest, ...kkOps.Option,
+) (*kkOps.ListControlPlanesResponse, error) {
+	return &kkOps.ListControlPlanesResponse{}, s.err
+}
+
+type stubPortalAPI struct {
+	helpers.PortalAPI
+	err error
+}
+
+func (s *stubPortalAPI) ListPortals(context.Context, kkOps.ListPortalsRequest) (*kkOps.ListPortalsResponse, error) {
+	return &kkOps.ListPortalsResponse{}, s.err
+}
+
+type stubAPIAPI struct {
+	helpers.APIFullAPI
+	err error
+}
+
+func (s *stubAPIAPI) ListApis(
+	context.Context, kkOps.ListApisRequest, ...kkOps.Option,
+) (*kkOps.ListApisResponse, error) {
+	return &kkOps.ListApisResponse{}, s.err
+}
+
+func newSDK(me *stubMeAPI, portals, apis error) *helpers.MockKonnectSDK {
+	return &helpers.MockKonnectSDK{
+		CPAPIFactory:  func() helpers.ControlPlaneAPI { return &stubControlPlaneAPI{} },
+		MeFactory:     func() helpers.MeAPI { return me },
+		PortalFactory: func() helpers.PortalAPI { return &stubPortalAPI{err: portals} },
+		APIFactory:    func() helpers.APIFullAPI { return &stubAPIAPI{err: apis} },
+	}
+}
+
+func activeOrganization() *kkComps.MeOrganization {
+	name := "Acme"
+	state := kkComps.MeOrganizationStateActive
+	return &kkComps.MeOrganization{Name: &name, State: &state}
+}
+
+func configuration() *resources.ResourceSet {
+	return &resources.ResourceSet{
+		Portals: []resources.PortalResource{{BaseResource: resources.BaseResource{Ref: "portal"}}},
+		APIs:    []resources.APIResource{{BaseResource: resources.BaseResource{Ref: "api"}}},
+	}
+}
+
+func checkNamed(t *testing.T, report *Report, name string) Check {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("no %s check in report", name)
+	return Check{}
+}
+
+func TestRun_Passes(t *testing.T) {
+	sdk := newSDK(&stubMeAPI{organization: activeOrganization()}, nil, nil)
+	report := Run(context.Background(), Options{
+		BaseURL: "https://us.api.konghq.com", SDK: sdk, Resources: configuration(),
+	})
+
+	require.Zero(t, report.Problems())
+	assert.Equal(t, []string{CheckConfiguration, CheckKonnect, CheckToken, CheckPermissions},
+		[]string{report.Checks[0].Name, report.Checks[1].Name, report.Checks[2].Name, report.Checks[3].Name})
+	assert.Equal(t, "organization Acme", checkNamed(t, report, CheckToken).Detail)
+	assert.Equal(t, "may manage portals, APIs", checkNamed(t, report, CheckPermissions).Detail)
+
+	var out bytes.Buffer
+	report.Write(&out)
+	assert.Contains(t, out.String(), "Preflight checks against https://us.api.konghq.com:")
+	assert.Contains(t, out.String(), "[ok]   permissions: may manage portals, APIs")
+}
+
+func TestRun_ReportsEveryProblem(t *testing.T) {
+	sdk := newSDK(&stubMeAPI{organization: activeOrganization()},
+		&sdkerrors.SDKError{StatusCode: 404, Message: "not found"},
+		&sdkerrors.ForbiddenError{})
+	report := Run(context.Background(), Options{
+		BaseURL:   "https://eu.api.konghq.com",
+		SDK:       sdk,
+		Resources: configuration(),
+		Issues:    []string{`portals.yaml:3:5: ref "portal": field "labels": label key "kong-x" is reserved`},
+	})
+
+	require.Equal(t, 3, report.Problems())
+	assert.Len(t, checkNamed(t, report, CheckConfiguration).Problems, 1)
+	permissions := checkNamed(t, report, CheckPermissions).Problems
+	require.Len(t, permissions, 2)
+	assert.Contains(t, permissions[0], "portals are not available to the organization")
+	assert.Contains(t, permissions[1], "not allowed to manage APIs")
+
+	var out bytes.Buffer
+	report.Write(&out)
+	assert.Contains(t, out.String(), "[fail] permissions")
+}
+
+func TestRun_InvalidToken(t *testing.T) {
+	sdk := newSDK(&stubMeAPI{err: &sdkerrors.UnauthorizedError{}}, nil, nil)
+	report := Run(context.Background(), Options{SDK: sdk, Resources: configuration()})
+
+	assert.True(t, checkNamed(t, report, CheckKonnect).Passed())
+	assert.Contains(t, checkNamed(t, report, CheckToken).Problems[0], "rejected the access token")
+	assert.True(t, checkNamed(t, report, CheckPermissions).Skipped, "every request would fail")
+}
+
+func TestRun_Unreachable(t *testing.T) {
+	sdk := newSDK(&stubMeAPI{organization: activeOrganization()}, nil, nil)
+	sdk.CPAPIFactory = func() helpers.ControlPlaneAPI {
+		return &stubControlPlaneAPI{err: errors.New("dial tcp: lookup xx.api.konghq.com: no such host")}
+	}
+	report := Run(context.Background(), Options{BaseURL: "https://xx.api.konghq.com", SDK: sdk})
+
+	require.Len(t, report.Checks, 2, "without a configuration only the environment is checked")
+	assert.Contains(t, checkNamed(t, report, CheckKonnect).Problems[0],
+		"cannot reach Konnect at https://xx.api.konghq.com")
+	assert.True(t, checkNamed(t, report, CheckToken).Skipped)
+
+	// A response the token is not allowed shows the region is reachable
+	sdk.CPAPIFactory = func() helpers.ControlPlaneAPI {
+		return &stubControlPlaneAPI{err: &sdkerrors.SDKError{StatusCode: 403}}
+	}
+	report = Run(context.Background(), Options{SDK: sdk})
+	assert.Zero(t, report.Problems())
+}
+
+func TestRun_NoClient(t *testing.T) {
+	report := Run(context.Background(), Options{SDKError: errors.New("no access token available")})
+
+	assert.True(t, checkNamed(t, report, CheckKonnect).Skipped)
+	assert.Contains(t, checkNamed(t, report, CheckToken).Problems[0], "no access token available")
+}
+
+func TestRun_InactiveOrganization(t *testing.T) {
+	organization := activeOrganization()
+	state := kkComps.MeOrganizationStateInactive
+	organization.State = &state
+	report := Run(context.Background(), Options{
+		SDK: newSDK(&stubMeAPI{organization: organization}, nil, nil), Resources: configuration(),
+	})
+
+	assert.Equal(t, []string{"organization Acme is inactive"}, checkNamed(t, report, CheckToken).Problems)
+	assert.True(t, checkNamed(t, report, CheckPermissions).Skipped)
+}
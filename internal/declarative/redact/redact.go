@@ -0,0 +1,244 @@
+// Package redact hides sensitive field values in human-facing plan output.
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+// Marker prefixes every redacted value
+const Marker = "[REDACTED"
+
+// SecretMarker replaces !secret values, which are only read at apply time
+const SecretMarker = "(sensitive)"
+
+// SecretChangedMarker replaces the !secret values of updates, which only carry the
+// fields that change, so a changed secret shows without revealing either value
+const SecretChangedMarker = "(secret changed)"
+
+// builtinKeyFragments mark a field as sensitive when its name contains one of them
+var builtinKeyFragments = []string{"password", "secret", "token", "private_key"}
+
+// builtinKeys mark a field as sensitive when its name matches exactly
+var builtinKeys = []string{"authorization", "api_key", "apikey", "x-api-key"}
+
+// Redactor replaces the values of sensitive fields with a fingerprint of the value.
+// Fields are sensitive when their name matches the built-in detection or when their
+// path ends with one of the configured paths.
+type Redactor struct {
+	paths   []string
+	pattern [][]string
+}
+
+// New returns a redactor for the given dot-separated field paths. A path matches
+// any field whose path ends with it, so "hmac_secret" matches the key at any depth
+// while "config.hmac_secret" only matches it under a "config" object. "*" matches
+// any single path segment and list indexes are not part of a path.
+func New(paths []string) (*Redactor, error) {
+	r := &Redactor{}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" || seen[path] {
+			continue
+		}
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if strings.TrimSpace(segment) == "" {
+				return nil, fmt.Errorf("invalid sensitive field path %q", path)
+			}
+		}
+		seen[path] = true
+		r.paths = append(r.paths, path)
+		r.pattern = append(r.pattern, segments)
+	}
+	return r, nil
+}
+
+// Paths returns the configured paths, without the built-in detection
+func (r *Redactor) Paths() []string {
+	if r == nil {
+		return nil
+	}
+	return append([]string(nil), r.paths...)
+}
+
+// IsSensitive reports whether the field at path is sensitive
+func (r *Redactor) IsSensitive(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	if isBuiltinSensitive(path[len(path)-1]) {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	for _, pattern := range r.pattern {
+		if matchesSuffix(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns a copy of fields with sensitive values redacted. Values are
+// normalized through JSON, so typed values become maps and slices.
+func (r *Redactor) Fields(fields map[string]any) map[string]any {
+	return r.fields(fields, SecretMarker)
+}
+
+// fields redacts fields, replacing !secret values with secretMarker
+func (r *Redactor) fields(fields map[string]any, secretMarker string) map[string]any {
+	if fields == nil {
+		return nil
+	}
+	normalized, ok := normalize(fields).(map[string]any)
+	if !ok {
+		return fields
+	}
+	return r.walk(nil, normalized, secretMarker).(map[string]any)
+}
+
+// Plan returns a copy of plan whose change fields are redacted. The returned plan is
+// for display only; executing it would send redacted values.
+func (r *Redactor) Plan(plan *planner.Plan) *planner.Plan {
+	if plan == nil {
+		return nil
+	}
+	redacted := *plan
+	redacted.Changes = make([]planner.PlannedChange, len(plan.Changes))
+	for i, change := range plan.Changes {
+		secretMarker := SecretMarker
+		if change.Action == planner.ActionUpdate {
+			secretMarker = SecretChangedMarker
+		}
+		change.Fields = r.fields(change.Fields, secretMarker)
+		change.Explanation = r.explanation(change.Explanation, secretMarker)
+		redacted.Changes[i] = change
+	}
+	return &redacted
+}
+
+// explanation redacts the live and configured values of explained fields
+func (r *Redactor) explanation(
+	explanations []planner.FieldExplanation,
+	secretMarker string,
+) []planner.FieldExplanation {
+	if explanations == nil {
+		return nil
+	}
+	redacted := make([]planner.FieldExplanation, len(explanations))
+	for i, explanation := range explanations {
+		explanation.Old = r.fields(map[string]any{explanation.Field: explanation.Old}, secretMarker)[explanation.Field]
+		explanation.New = r.fields(map[string]any{explanation.Field: explanation.New}, secretMarker)[explanation.Field]
+		redacted[i] = explanation
+	}
+	return redacted
+}
+
+func (r *Redactor) walk(path []string, value any, secretMarker string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			childPath := append(append([]string(nil), path...), key)
+			if r.IsSensitive(childPath) {
+				out[key] = redactValue(item, secretMarker)
+				continue
+			}
+			out[key] = r.walk(childPath, item, secretMarker)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = r.walk(path, item, secretMarker)
+		}
+		return out
+	case string:
+		if tags.IsSecretPlaceholder(v) {
+			return secretMarker
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// redactValue replaces a sensitive value with its fingerprint. The old and new sides
+// of a field change are fingerprinted separately so drift stays visible.
+func redactValue(value any, secretMarker string) any {
+	if change, ok := value.(map[string]any); ok && len(change) == 2 {
+		oldValue, hasOld := change["old"]
+		newValue, hasNew := change["new"]
+		if hasOld && hasNew {
+			return map[string]any{"old": redactValue(oldValue, secretMarker), "new": redactValue(newValue, secretMarker)}
+		}
+	}
+	if value == nil {
+		return nil
+	}
+	if secret, ok := value.(string); ok && tags.IsSecretPlaceholder(secret) {
+		return secretMarker
+	}
+	return fmt.Sprintf("%s sha256:%s]", Marker, Hash(value))
+}
+
+// Hash returns a short SHA-256 fingerprint of the JSON encoding of value, so equal
+// sensitive values can be compared without revealing them.
+func Hash(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data = fmt.Appendf(nil, "%v", value)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func isBuiltinSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range builtinKeys {
+		if key == name {
+			return true
+		}
+	}
+	for _, fragment := range builtinKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSuffix(path, pattern []string) bool {
+	if len(pattern) > len(path) {
+		return false
+	}
+	offset := len(path) - len(pattern)
+	for i, segment := range pattern {
+		if segment != "*" && segment != path[offset+i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize converts typed values to their JSON representation
+func normalize(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return value
+	}
+	return out
+}
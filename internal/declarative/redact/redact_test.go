@@ -0,0 +1,165 @@
+package redact
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/common"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_RejectsInvalidPaths(t *testing.T) {
+	_, err := New([]string{"config..secret"})
+	require.ErrorContains(t, err, `invalid sensitive field path "config..secret"`)
+
+	r, err := New([]string{" config.hmac_key ", "", "config.hmac_key"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config.hmac_key"}, r.Paths())
+}
+
+func TestRedactor_IsSensitive(t *testing.T) {
+	r, err := New([]string{"config.hmac_key", "plugins.*.signing_material", "tenant_salt"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path      []string
+		sensitive bool
+	}{
+		{[]string{"password"}, true},
+		{[]string{"config", "client_secret"}, true},
+		{[]string{"headers", "Authorization"}, true},
+		{[]string{"config", "hmac_key"}, true},
+		{[]string{"plugin", "config", "hmac_key"}, true},
+		{[]string{"hmac_key"}, false},
+		{[]string{"plugins", "jwt", "signing_material"}, true},
+		{[]string{"plugins", "signing_material"}, false},
+		{[]string{"deep", "tenant_salt"}, true},
+		{[]string{"description"}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.sensitive, r.IsSensitive(tt.path), "path %v", tt.path)
+	}
+}
+
+func TestRedactor_Plan(t *testing.T) {
+	r, err := New([]string{"config.hmac_key"})
+	require.NoError(t, err)
+
+	plan := &planner.Plan{
+		Changes: []planner.PlannedChange{
+			{
+				ID:           "1:c:custom:plugin",
+				ResourceType: "custom",
+				ResourceRef:  "plugin",
+				Action:       planner.ActionCreate,
+				Fields: map[string]any{
+					"name": "rate-limiter",
+					"config": map[string]any{
+						"hmac_key": "s3cr3t-value",
+						"limit":    10,
+					},
+					"credentials": []any{map[string]any{"password": "hunter2"}},
+				},
+			},
+			{
+				ID:           "2:u:custom:other",
+				ResourceType: "custom",
+				ResourceRef:  "other",
+				Action:       planner.ActionUpdate,
+				Fields: map[string]any{
+					"config": map[string]any{
+						"hmac_key": planner.FieldChange{Old: "old-key", New: "new-key"},
+					},
+				},
+			},
+		},
+	}
+
+	redacted := r.Plan(plan)
+
+	// The original plan keeps its values for execution
+	assert.Equal(t, "s3cr3t-value", plan.Changes[0].Fields["config"].(map[string]any)["hmac_key"])
+
+	create := redacted.Changes[0].Fields
+	config := create["config"].(map[string]any)
+	assert.Equal(t, "[REDACTED sha256:"+Hash("s3cr3t-value")+"]", config["hmac_key"])
+	assert.Equal(t, float64(10), config["limit"])
+	assert.Equal(t, "rate-limiter", create["name"])
+	credential := create["credentials"].([]any)[0].(map[string]any)
+	assert.Equal(t, "[REDACTED sha256:"+Hash("hunter2")+"]", credential["password"])
+
+	update := redacted.Changes[1].Fields["config"].(map[string]any)["hmac_key"].(map[string]any)
+	assert.Equal(t, "[REDACTED sha256:"+Hash("old-key")+"]", update["old"])
+	assert.Equal(t, "[REDACTED sha256:"+Hash("new-key")+"]", update["new"])
+	assert.NotEqual(t, update["old"], update["new"], "drift stays visible through the hashes")
+
+	var out bytes.Buffer
+	common.DisplayPlanSummary(redacted, &out)
+	assert.NotContains(t, out.String(), "old-key")
+	assert.NotContains(t, out.String(), "new-key")
+}
+
+func TestRedactor_SecretPlaceholders(t *testing.T) {
+	r, err := New(nil)
+	require.NoError(t, err)
+
+	fields := r.Fields(map[string]any{
+		"smtp_password": "__SECRET__:env://PORTAL_SMTP_PASSWORD#sha256:abc",
+		"description":   planner.FieldChange{Old: "old", New: "__SECRET__:file:///run/secrets/description"},
+	})
+	assert.Equal(t, SecretMarker, fields["smtp_password"])
+	assert.Equal(t, map[string]any{"old": "old", "new": SecretMarker}, fields["description"])
+}
+
+func TestHash_ComparesEqualValues(t *testing.T) {
+	assert.Equal(t, Hash("value"), Hash("value"))
+	assert.NotEqual(t, Hash("value"), Hash("other"))
+	assert.Len(t, Hash(map[string]any{"a": 1}), 12)
+}
+
+func TestRedactor_PlanMarksChangedSecrets(t *testing.T) {
+	r, err := New(nil)
+	require.NoError(t, err)
+
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID: "1:c:application_auth_strategy:oidc", ResourceType: "application_auth_strategy", ResourceRef: "oidc",
+		Action: planner.ActionCreate,
+		Fields: map[string]any{"client_secret": "__SECRET__:vault://secret/data/oidc#client_secret#sha256:abc"},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "2:u:application_auth_strategy:partner", ResourceType: "application_auth_strategy",
+		ResourceRef: "partner", Action: planner.ActionUpdate,
+		Fields: map[string]any{"client_secret": "__SECRET__:env://PARTNER_SECRET#sha256:def"},
+	})
+
+	redacted := r.Plan(plan)
+	assert.Equal(t, SecretMarker, redacted.Changes[0].Fields["client_secret"])
+	assert.Equal(t, SecretChangedMarker, redacted.Changes[1].Fields["client_secret"])
+	assert.Contains(t, plan.Changes[1].Fields["client_secret"], "__SECRET__:", "the plan itself is not changed")
+}
+
+func TestRedactor_PlanExplanation(t *testing.T) {
+	r, err := New(nil)
+	require.NoError(t, err)
+
+	plan := &planner.Plan{Changes: []planner.PlannedChange{{
+		ID:           "1:u:portal_email_config:dev",
+		ResourceType: "portal_email_config",
+		Action:       planner.ActionUpdate,
+		Fields:       map[string]any{"smtp_password": "new-password", "from_name": "Dev"},
+		Explanation: []planner.FieldExplanation{
+			{Field: "from_name", Old: "Portal", New: "Dev", Origin: planner.OriginConfig},
+			{Field: "smtp_password", Old: "old-password", New: "new-password", Origin: planner.OriginDrift},
+		},
+	}}}
+
+	explanation := r.Plan(plan).Changes[0].Explanation
+	assert.Equal(t, "Portal", explanation[0].Old)
+	assert.Equal(t, "Dev", explanation[0].New)
+	assert.Equal(t, "[REDACTED sha256:"+Hash("old-password")+"]", explanation[1].Old)
+	assert.Equal(t, "[REDACTED sha256:"+Hash("new-password")+"]", explanation[1].New)
+	assert.Equal(t, "old-password", plan.Changes[0].Explanation[1].Old)
+}
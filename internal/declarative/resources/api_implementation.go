@@ -24,6 +24,9 @@ type APIImplementationResource struct {
 	Ref                       string `yaml:"ref"           json:"ref"`
 	// Parent API reference (for root-level definitions)
 	API string `yaml:"api,omitempty" json:"api,omitempty"`
+	// ServiceName is the service.name of the configuration: the gateway service is
+	// looked up by name on its control plane when service.id is not set
+	ServiceName string `yaml:"-" json:"-"`
 
 	// Resolved Konnect ID (not serialized)
 	konnectID string `yaml:"-" json:"-"`
@@ -93,8 +96,11 @@ func (i APIImplementationResource) Validate() error {
 
 	// Validate service information if present.
 	if service := i.getService(); service != nil {
-		if service.ID == "" {
-			return fmt.Errorf("API implementation service.id is required")
+		if service.ID == "" && i.ServiceName == "" {
+			return fmt.Errorf("API implementation service.id or service.name is required")
+		}
+		if service.ID != "" && i.ServiceName != "" {
+			return fmt.Errorf("API implementation service.id and service.name cannot both be set")
 		}
 
 		if service.ControlPlaneID == "" {
@@ -218,6 +224,9 @@ func (i APIImplementationResource) MarshalJSON() ([]byte, error) {
 	if i.API != "" {
 		payload["api"] = i.API
 	}
+	if service, ok := payload["service"].(map[string]any); ok && i.ServiceName != "" {
+		service["name"] = i.ServiceName
+	}
 
 	return json.Marshal(payload)
 }
@@ -231,6 +240,7 @@ func (i *APIImplementationResource) UnmarshalJSON(data []byte) error {
 		ImplementationURL string `json:"implementation_url,omitempty"`
 		Service           *struct {
 			ID             string `json:"id"`
+			Name           string `json:"name,omitempty"`
 			ControlPlaneID string `json:"control_plane_id"`
 		} `json:"service,omitempty"`
 		Kongctl any `json:"kongctl,omitempty"`
@@ -261,6 +271,7 @@ func (i *APIImplementationResource) UnmarshalJSON(data []byte) error {
 	}
 
 	if temp.Service != nil {
+		i.ServiceName = temp.Service.Name
 		sdkData["service"] = map[string]any{
 			"id":               temp.Service.ID,
 			"control_plane_id": temp.Service.ControlPlaneID,
@@ -48,3 +48,34 @@ func TestAPIImplementationResourceMarshalJSONIncludesMetadata(t *testing.T) {
 		t.Fatalf("expected control_plane_id %q, got %v", "cp-id", serviceVal["control_plane_id"])
 	}
 }
+
+func TestAPIImplementationResourceServiceNameRoundTrip(t *testing.T) {
+	var resource APIImplementationResource
+	data := `{"ref":"impl-ref","service":{"name":"orders","control_plane_id":"cp-ref"}}`
+	if err := json.Unmarshal([]byte(data), &resource); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if resource.ServiceName != "orders" {
+		t.Fatalf("expected service name %q, got %q", "orders", resource.ServiceName)
+	}
+	if err := resource.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	raw, err := json.Marshal(resource)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded APIImplementationResource
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if decoded.ServiceName != "orders" {
+		t.Fatalf("expected service name %q after round trip, got %q", "orders", decoded.ServiceName)
+	}
+
+	resource.ServiceReference.Service.ID = "svc-id"
+	if err := resource.Validate(); err == nil {
+		t.Fatal("expected an error when both service.id and service.name are set")
+	}
+}
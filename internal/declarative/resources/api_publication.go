@@ -23,6 +23,9 @@ type APIPublicationResource struct {
 	API      string `yaml:"api,omitempty" json:"api,omitempty"`
 	PortalID string `yaml:"portal_id"     json:"portal_id"`
 
+	// Switch stages the publication at another visibility before flipping it
+	Switch *PublicationSwitch `yaml:"_switch,omitempty" json:"_switch,omitempty"`
+
 	// Resolved Konnect ID (not serialized)
 	konnectID string `yaml:"-" json:"-"`
 }
@@ -75,6 +78,11 @@ func (p APIPublicationResource) Validate() error {
 		return fmt.Errorf("konnect currently supports only one auth strategy per API publication. "+
 			"Found %d auth strategies", len(p.AuthStrategyIds))
 	}
+	if p.Switch != nil {
+		if err := p.Switch.Validate(p.Visibility); err != nil {
+			return fmt.Errorf("invalid API publication %q: %w", p.Ref, err)
+		}
+	}
 	// Parent API validation happens through dependency system
 	return nil
 }
@@ -125,6 +133,7 @@ func (p APIPublicationResource) MarshalJSON() ([]byte, error) {
 		AuthStrategyIDs          []string                          `json:"auth_strategy_ids,omitempty"`
 		AutoApproveRegistrations *bool                             `json:"auto_approve_registrations,omitempty"`
 		Visibility               *kkComps.APIPublicationVisibility `json:"visibility,omitempty"`
+		Switch                   *PublicationSwitch                `json:"_switch,omitempty"`
 	}
 
 	payload := alias{
@@ -134,6 +143,7 @@ func (p APIPublicationResource) MarshalJSON() ([]byte, error) {
 		AuthStrategyIDs:          p.AuthStrategyIds,
 		AutoApproveRegistrations: p.AutoApproveRegistrations,
 		Visibility:               p.Visibility,
+		Switch:                   p.Switch,
 	}
 
 	return json.Marshal(payload)
@@ -143,14 +153,15 @@ func (p APIPublicationResource) MarshalJSON() ([]byte, error) {
 func (p *APIPublicationResource) UnmarshalJSON(data []byte) error {
 	// Temporary struct to capture all fields
 	var temp struct {
-		Ref                      string   `json:"ref"`
-		API                      string   `json:"api,omitempty"`
-		PortalID                 string   `json:"portal_id"`
-		PublishStatus            string   `json:"publish_status,omitempty"`
-		AuthStrategyIDs          []string `json:"auth_strategy_ids,omitempty"`
-		AutoApproveRegistrations *bool    `json:"auto_approve_registrations,omitempty"`
-		Visibility               string   `json:"visibility,omitempty"`
-		Kongctl                  any      `json:"kongctl,omitempty"`
+		Ref                      string             `json:"ref"`
+		API                      string             `json:"api,omitempty"`
+		PortalID                 string             `json:"portal_id"`
+		PublishStatus            string             `json:"publish_status,omitempty"`
+		AuthStrategyIDs          []string           `json:"auth_strategy_ids,omitempty"`
+		AutoApproveRegistrations *bool              `json:"auto_approve_registrations,omitempty"`
+		Visibility               string             `json:"visibility,omitempty"`
+		Switch                   *PublicationSwitch `json:"_switch,omitempty"`
+		Kongctl                  any                `json:"kongctl,omitempty"`
 	}
 
 	// Use a decoder with DisallowUnknownFields to catch typos
@@ -165,6 +176,7 @@ func (p *APIPublicationResource) UnmarshalJSON(data []byte) error {
 	p.Ref = temp.Ref
 	p.API = temp.API
 	p.PortalID = temp.PortalID
+	p.Switch = temp.Switch
 
 	// Check if kongctl field was provided and reject it
 	if temp.Kongctl != nil {
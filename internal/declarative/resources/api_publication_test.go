@@ -2,6 +2,7 @@ package resources
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	kkComps "github.com/Kong/sdk-konnect-go/models/components"
@@ -47,3 +48,77 @@ func TestAPIPublicationResourceMarshalJSONIncludesMetadata(t *testing.T) {
 		t.Fatalf("unexpected auth_strategy_ids payload: %v", payload["auth_strategy_ids"])
 	}
 }
+
+func TestAPIPublicationResourceSwitchValidation(t *testing.T) {
+	public := kkComps.APIPublicationVisibilityPublic
+	private := kkComps.APIPublicationVisibilityPrivate
+
+	tests := []struct {
+		name       string
+		visibility *kkComps.APIPublicationVisibility
+		sw         PublicationSwitch
+		wantErr    string
+	}{
+		{name: "default stage is private", visibility: &public},
+		{name: "public stage for private target", visibility: &private, sw: PublicationSwitch{StageVisibility: "public"}},
+		{name: "visibility required", sw: PublicationSwitch{}, wantErr: "requires visibility"},
+		{name: "stage equals target", visibility: &private, wantErr: "must differ from visibility"},
+		{
+			name:       "unknown stage",
+			visibility: &public,
+			sw:         PublicationSwitch{StageVisibility: "hidden"},
+			wantErr:    `got "hidden"`,
+		},
+		{
+			name:       "empty verify command",
+			visibility: &public,
+			sw:         PublicationSwitch{Verify: []string{" "}},
+			wantErr:    "must start with a command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sw := tt.sw
+			pub := APIPublicationResource{
+				APIPublication: kkComps.APIPublication{Visibility: tt.visibility},
+				Ref:            "pub-ref",
+				PortalID:       "portal-ref",
+				Switch:         &sw,
+			}
+
+			err := pub.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAPIPublicationResourceUnmarshalSwitch(t *testing.T) {
+	var pub APIPublicationResource
+	err := json.Unmarshal([]byte(`{
+		"ref": "pub-ref",
+		"portal_id": "portal-ref",
+		"visibility": "public",
+		"_switch": {"stage_visibility": "private", "verify": ["./check.sh", "--strict"]}
+	}`), &pub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pub.Switch == nil || pub.Switch.GetStageVisibility() != "private" ||
+		strings.Join(pub.Switch.Verify, " ") != "./check.sh --strict" {
+		t.Fatalf("unexpected switch: %+v", pub.Switch)
+	}
+
+	err = json.Unmarshal([]byte(`{"ref": "pub-ref", "portal_id": "p", "_switch": {"stage": "private"}}`), &pub)
+	if err == nil {
+		t.Fatalf("expected unknown _switch field to be rejected")
+	}
+}
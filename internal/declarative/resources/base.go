@@ -22,6 +22,11 @@ func (b BaseResource) GetRef() string {
 	return b.Ref
 }
 
+// GetKongctlMeta returns the kongctl metadata of the resource, which may be nil.
+func (b BaseResource) GetKongctlMeta() *KongctlMeta {
+	return b.Kongctl
+}
+
 // GetKonnectID returns the resolved Konnect ID if available.
 func (b BaseResource) GetKonnectID() string {
 	return b.konnectID
@@ -46,6 +46,16 @@ func (c ControlPlaneResource) HasDeckConfig() bool {
 	return c.Deck != nil
 }
 
+// GetLabels returns the labels for this resource
+func (c ControlPlaneResource) GetLabels() map[string]string {
+	return c.Labels
+}
+
+// SetLabels sets the labels for this resource
+func (c *ControlPlaneResource) SetLabels(labels map[string]string) {
+	c.Labels = labels
+}
+
 // GetReferenceFieldMappings returns the field mappings for reference validation
 func (c ControlPlaneResource) GetReferenceFieldMappings() map[string]string {
 	return map[string]string{} // No outbound references
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func init() {
+	registerResourceType(
+		ResourceTypeCustom,
+		func(rs *ResourceSet) *[]CustomResource { return &rs.CustomResources },
+	)
+}
+
+// customKindPattern restricts custom kinds to lower snake case identifiers
+var customKindPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CustomResource represents a resource whose kind is provided by a registered
+// custom resource handler rather than built into kongctl
+type CustomResource struct {
+	BaseResource
+	// Kind selects the handler that plans and applies the resource
+	Kind string `yaml:"kind" json:"kind"`
+	// Name identifies the resource within its kind and namespace
+	Name   string            `yaml:"name,omitempty"   json:"name,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Spec holds the kind-specific configuration interpreted by the handler
+	Spec map[string]any `yaml:"spec,omitempty"   json:"spec,omitempty"`
+}
+
+// ValidateCustomKind checks that kind is usable as a custom resource kind
+func ValidateCustomKind(kind string) error {
+	if kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+	if !customKindPattern.MatchString(kind) {
+		return fmt.Errorf("kind %q must be lower snake case (e.g. acme_widget)", kind)
+	}
+	if kind == string(ResourceTypeCustom) || IsRegistered(ResourceType(kind)) {
+		return fmt.Errorf("kind %q conflicts with a built-in resource type", kind)
+	}
+	return nil
+}
+
+// GetReferenceFieldMappings returns the field mappings for reference validation
+func (c CustomResource) GetReferenceFieldMappings() map[string]string {
+	return map[string]string{} // Spec references are resolved through !ref placeholders
+}
+
+// Validate ensures the custom resource is valid
+func (c CustomResource) Validate() error {
+	if err := ValidateRef(c.Ref); err != nil {
+		return fmt.Errorf("invalid custom resource ref: %w", err)
+	}
+	if err := ValidateCustomKind(c.Kind); err != nil {
+		return err
+	}
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, reserved := range []string{"name", "labels"} {
+		if _, ok := c.Spec[reserved]; ok {
+			return fmt.Errorf("spec cannot set %q; use the top-level %s field", reserved, reserved)
+		}
+	}
+	return nil
+}
+
+// SetDefaults applies default values to the custom resource
+func (c *CustomResource) SetDefaults() {
+	// If Name is not set, use ref as default
+	if c.Name == "" {
+		c.Name = c.Ref
+	}
+}
+
+// GetType returns the custom kind so refs and errors report the handler's type
+func (c CustomResource) GetType() ResourceType {
+	if c.Kind == "" {
+		return ResourceTypeCustom
+	}
+	return ResourceType(c.Kind)
+}
+
+// GetMoniker returns the resource moniker (for custom resources, this is the name)
+func (c CustomResource) GetMoniker() string {
+	return c.Name
+}
+
+// GetDependencies returns references to other resources this resource depends on
+func (c CustomResource) GetDependencies() []ResourceRef {
+	return []ResourceRef{}
+}
+
+// GetLabels returns the labels for this resource
+func (c CustomResource) GetLabels() map[string]string {
+	return c.Labels
+}
+
+// SetLabels sets the labels for this resource
+func (c *CustomResource) SetLabels(labels map[string]string) {
+	c.Labels = labels
+}
+
+// GetKonnectMonikerFilter returns an empty filter; custom resources are looked up by their handler
+func (c CustomResource) GetKonnectMonikerFilter() string {
+	return ""
+}
+
+// TryMatchKonnectResource never matches; custom resources are not Konnect resources
+func (c *CustomResource) TryMatchKonnectResource(_ any) bool {
+	return false
+}
@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -21,9 +22,10 @@ func init() {
 type GatewayServiceResource struct {
 	// Service captures inline gateway service properties. It is excluded from direct
 	// YAML/JSON serialization because external services are declared without payloads;
-	// the custom UnmarshalYAML implementation below materializes this struct only when
-	// inline fields are present in the configuration.
-	Service      *kkComps.Service `yaml:"-"                       json:"-"`
+	// the custom UnmarshalYAML and UnmarshalJSON implementations below materialize this
+	// struct only when inline fields are present in the configuration, and the schema
+	// describes its fields inline.
+	Service      *kkComps.Service `yaml:"-"                       json:"-"                       schema:"inline"`
 	Ref          string           `yaml:"ref"                     json:"ref"`
 	ControlPlane string           `yaml:"control_plane,omitempty" json:"control_plane,omitempty"`
 	External     *ExternalBlock   `yaml:"_external,omitempty"     json:"_external,omitempty"`
@@ -153,9 +155,75 @@ func (s *GatewayServiceResource) UnmarshalYAML(unmarshal func(any) error) error
 	return nil
 }
 
+// UnmarshalJSON decodes services like UnmarshalYAML for the loader, which reads YAML
+// through JSON. Inline fields must be fields of the SDK service, to catch typos.
+func (s *GatewayServiceResource) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var meta struct {
+		Ref          string         `json:"ref"`
+		ControlPlane string         `json:"control_plane"`
+		External     *ExternalBlock `json:"_external"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	s.Ref = meta.Ref
+	s.ControlPlane = meta.ControlPlane
+	s.External = meta.External
+
+	for _, key := range []string{"ref", "control_plane", "_external", "kongctl"} {
+		delete(raw, key)
+	}
+	if len(raw) == 0 {
+		s.Service = nil
+		return nil
+	}
+
+	fields := serviceFields()
+	for key := range raw {
+		if !fields[key] {
+			return fmt.Errorf("json: unknown field %q", key)
+		}
+	}
+	inline, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("marshal gateway service fields: %w", err)
+	}
+	var svc kkComps.Service
+	if err := json.Unmarshal(inline, &svc); err != nil {
+		return err
+	}
+	s.Service = &svc
+	return nil
+}
+
+// serviceFields returns the JSON names of the fields of the SDK service
+func serviceFields() map[string]bool {
+	t := reflect.TypeFor[kkComps.Service]()
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
 // SetDefaults applies default values where applicable.
 func (s *GatewayServiceResource) SetDefaults() {
-	// For now there are no defaults to apply.
+	// Managed services are matched by name, so default it to the ref like control planes
+	if s.IsExternal() || s.Service == nil {
+		return
+	}
+	if s.Service.Name == nil || *s.Service.Name == "" {
+		name := s.Ref
+		s.Service.Name = &name
+	}
 }
 
 // GetKonnectID returns the resolved Konnect service ID if available.
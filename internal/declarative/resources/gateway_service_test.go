@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGatewayServiceResource_UnmarshalJSON_Inline(t *testing.T) {
+	yamlContent := `
+ref: prod-cp
+name: prod-cp
+gateway_services:
+  - ref: billing-service
+    host: billing.internal
+    port: 8080
+    protocol: https
+    tags: [billing]
+`
+
+	var cp ControlPlaneResource
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &cp))
+	require.Len(t, cp.GatewayServices, 1)
+
+	service := cp.GatewayServices[0]
+	assert.Equal(t, "billing-service", service.Ref)
+	require.NotNil(t, service.Service)
+	assert.Equal(t, "billing.internal", service.Service.Host)
+	assert.Equal(t, int64(8080), *service.Service.Port)
+	assert.Equal(t, "https", string(*service.Service.Protocol))
+	assert.Equal(t, []string{"billing"}, service.Service.Tags)
+	// Fields left out take the SDK defaults
+	assert.Equal(t, int64(5), *service.Service.Retries)
+}
+
+func TestGatewayServiceResource_UnmarshalJSON_External(t *testing.T) {
+	yamlContent := `
+ref: billing-gw
+control_plane: prod-cp
+_external:
+  selector:
+    matchFields:
+      name: billing-service
+`
+
+	var service GatewayServiceResource
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &service))
+	assert.Equal(t, "billing-gw", service.Ref)
+	assert.Equal(t, "prod-cp", service.ControlPlane)
+	assert.Nil(t, service.Service)
+	assert.True(t, service.IsExternal())
+}
+
+func TestGatewayServiceResource_UnmarshalJSON_UnknownField(t *testing.T) {
+	var service GatewayServiceResource
+	err := yaml.Unmarshal([]byte("ref: billing\nhost: billing.internal\nhots: typo\n"), &service)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "hots"`)
+}
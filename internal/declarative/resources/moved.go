@@ -0,0 +1,39 @@
+package resources
+
+import "fmt"
+
+// MovedResource records that the resource of ref To was last applied with ref From.
+// Renaming the ref of a resource, and its name along with it, then updates the
+// resource in Konnect instead of deleting it and creating it again, so its Konnect
+// ID, and the publications and registrations referencing it, are kept.
+type MovedResource struct {
+	From string `yaml:"from"           json:"from"`
+	To   string `yaml:"to"             json:"to"`
+	// Name is the name the resource has in Konnect. It finds the resource when it was
+	// not applied from this machine, so its last applied ID is not known.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
+// MovableResourceTypes are the resource types a moved entry can rename
+var MovableResourceTypes = []ResourceType{
+	ResourceTypePortal,
+	ResourceTypeAPI,
+	ResourceTypeApplicationAuthStrategy,
+	ResourceTypeControlPlane,
+	ResourceTypeCatalogService,
+	ResourceTypeOrganizationTeam,
+}
+
+// Validate checks the refs of the moved entry
+func (m MovedResource) Validate() error {
+	if err := ValidateRef(m.From); err != nil {
+		return fmt.Errorf("invalid from: %w", err)
+	}
+	if err := ValidateRef(m.To); err != nil {
+		return fmt.Errorf("invalid to: %w", err)
+	}
+	if m.From == m.To {
+		return fmt.Errorf("from and to are both %q", m.From)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+func init() {
+	registerResourceType(
+		ResourceTypeNotificationSubscription,
+		func(rs *ResourceSet) *[]NotificationSubscriptionResource { return &rs.NotificationSubscriptions },
+	)
+}
+
+// NotificationWildcard subscribes to an event in every region or for every entity
+const NotificationWildcard = "*"
+
+// NotificationSubscriptionResource represents a subscription of the current user to a
+// Konnect notification event, such as a new application registration in a portal.
+// Subscriptions belong to the user of the credentials and carry no labels, so they
+// have no kongctl metadata and are identified by their event and name.
+type NotificationSubscriptionResource struct {
+	Ref string `yaml:"ref" json:"ref"`
+
+	// EventID is the notification event, as listed by kongctl get notification-events
+	EventID string `yaml:"event_id"           json:"event_id"`
+	Name    string `yaml:"name"               json:"name"`
+	Enabled *bool  `yaml:"enabled,omitempty"  json:"enabled,omitempty"`
+	// Channels the notifications are delivered on (EMAIL, IN_APP)
+	Channels []NotificationChannelResource `yaml:"channels"           json:"channels"`
+	// Regions the notifications are sent for, defaults to all regions
+	Regions []string `yaml:"regions,omitempty"  json:"regions,omitempty"`
+	// Entities the notifications are sent for, such as portal IDs or a !ref to a
+	// portal, control plane or API. Defaults to all entities.
+	Entities []string `yaml:"entities,omitempty" json:"entities,omitempty"`
+
+	// Resolved Konnect subscription ID (not serialized)
+	konnectID string `yaml:"-" json:"-"`
+}
+
+// NotificationChannelResource enables or disables a delivery channel of a subscription
+type NotificationChannelResource struct {
+	Type    string `yaml:"type"              json:"type"`
+	Enabled *bool  `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+}
+
+// GetType returns the resource type
+func (r NotificationSubscriptionResource) GetType() ResourceType {
+	return ResourceTypeNotificationSubscription
+}
+
+// GetRef returns the reference identifier
+func (r NotificationSubscriptionResource) GetRef() string {
+	return r.Ref
+}
+
+// GetMoniker returns the event and name, which identify a subscription in Konnect
+func (r NotificationSubscriptionResource) GetMoniker() string {
+	return r.EventID + "/" + r.Name
+}
+
+// GetDependencies returns references to other resources this subscription depends on
+func (r NotificationSubscriptionResource) GetDependencies() []ResourceRef {
+	// Entities may be !refs to resources of several kinds; the planner wires the
+	// dependencies from the resolved refs.
+	return []ResourceRef{}
+}
+
+// GetReferenceFieldMappings returns cross-resource reference mappings for validation
+func (r NotificationSubscriptionResource) GetReferenceFieldMappings() map[string]string {
+	return map[string]string{}
+}
+
+// Validate ensures the notification subscription resource is valid
+func (r NotificationSubscriptionResource) Validate() error {
+	if err := ValidateRef(r.Ref); err != nil {
+		return fmt.Errorf("invalid notification subscription ref: %w", err)
+	}
+	if r.EventID == "" {
+		return fmt.Errorf("event_id is required")
+	}
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if len(r.Channels) == 0 {
+		return fmt.Errorf("at least one channel is required")
+	}
+	seen := make(map[string]bool, len(r.Channels))
+	for _, channel := range r.Channels {
+		channelType := kkComps.NotificationChannelType(channel.Type)
+		if channelType != kkComps.NotificationChannelTypeEmail && channelType != kkComps.NotificationChannelTypeInApp {
+			return fmt.Errorf("channel type %q is not supported (EMAIL, IN_APP)", channel.Type)
+		}
+		if seen[channel.Type] {
+			return fmt.Errorf("channel %s is listed more than once", channel.Type)
+		}
+		seen[channel.Type] = true
+	}
+
+	for _, region := range r.Regions {
+		if !kkComps.NotificationRegion(region).ToPointer().IsExact() {
+			return fmt.Errorf("region %q is not supported (US, EU, AU, ME, IN, *)", region)
+		}
+	}
+	for _, entity := range r.Entities {
+		if entity == "" {
+			return fmt.Errorf("entities cannot contain empty values")
+		}
+	}
+
+	return nil
+}
+
+// SetDefaults enables the subscription and its channels, and subscribes to all regions
+// and entities, unless set otherwise
+func (r *NotificationSubscriptionResource) SetDefaults() {
+	if r.Enabled == nil {
+		enabled := true
+		r.Enabled = &enabled
+	}
+	for i := range r.Channels {
+		if r.Channels[i].Enabled == nil {
+			enabled := true
+			r.Channels[i].Enabled = &enabled
+		}
+	}
+	if len(r.Regions) == 0 {
+		r.Regions = []string{NotificationWildcard}
+	}
+	if len(r.Entities) == 0 {
+		r.Entities = []string{NotificationWildcard}
+	}
+}
+
+// GetKonnectID returns the resolved Konnect ID if available
+func (r NotificationSubscriptionResource) GetKonnectID() string {
+	return r.konnectID
+}
+
+// GetKonnectMonikerFilter returns the filter string for Konnect API lookup
+func (r NotificationSubscriptionResource) GetKonnectMonikerFilter() string {
+	// Subscriptions are listed per event and matched by name
+	return ""
+}
+
+// TryMatchKonnectResource attempts to match this resource with a Konnect resource
+func (r *NotificationSubscriptionResource) TryMatchKonnectResource(konnectResource any) bool {
+	subscription, ok := konnectResource.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	eventID, _ := subscription["event_id"].(string)
+	name, _ := subscription["name"].(string)
+	if eventID != r.EventID || name != r.Name {
+		return false
+	}
+	if id, ok := subscription["id"].(string); ok {
+		r.konnectID = id
+	}
+	return true
+}
+
+// IsEnabled returns the desired enabled flag of the subscription
+func (r NotificationSubscriptionResource) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// EntityRefs returns the refs of the resources the !ref entities point to
+func (r NotificationSubscriptionResource) EntityRefs() []string {
+	var refs []string
+	for _, entity := range r.Entities {
+		if !tags.IsRefPlaceholder(entity) {
+			continue
+		}
+		if ref, _, ok := tags.ParseRefPlaceholder(entity); ok && ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// UnmarshalJSON rejects unknown fields and kongctl metadata, which subscriptions do not support
+func (r *NotificationSubscriptionResource) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Ref      string                        `json:"ref"`
+		EventID  string                        `json:"event_id"`
+		Name     string                        `json:"name"`
+		Enabled  *bool                         `json:"enabled,omitempty"`
+		Channels []NotificationChannelResource `json:"channels"`
+		Regions  []string                      `json:"regions,omitempty"`
+		Entities []string                      `json:"entities,omitempty"`
+		Kongctl  any                           `json:"kongctl,omitempty"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&temp); err != nil {
+		return err
+	}
+
+	if temp.Kongctl != nil {
+		return fmt.Errorf("kongctl metadata not supported on notification subscriptions")
+	}
+
+	r.Ref = temp.Ref
+	r.EventID = temp.EventID
+	r.Name = temp.Name
+	r.Enabled = temp.Enabled
+	r.Channels = temp.Channels
+	r.Regions = temp.Regions
+	r.Entities = temp.Entities
+
+	return nil
+}
@@ -18,6 +18,10 @@ type OrganizationTeamResource struct {
 	BaseResource
 	kkComps.CreateTeam `yaml:",inline" json:",inline"`
 	External           *ExternalBlock `yaml:"_external,omitempty" json:"_external,omitempty"`
+
+	// Child resources
+	Roles   []OrganizationTeamRoleResource   `yaml:"roles,omitempty"   json:"roles,omitempty"`
+	Members []OrganizationTeamMemberResource `yaml:"members,omitempty" json:"members,omitempty"`
 }
 
 // GetReferenceFieldMappings returns the field mappings for reference validation
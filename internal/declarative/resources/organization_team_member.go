@@ -0,0 +1,131 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	registerResourceType(
+		ResourceTypeOrganizationTeamMember,
+		func(rs *ResourceSet) *[]OrganizationTeamMemberResource { return &rs.OrganizationTeamMembers },
+	)
+}
+
+// OrganizationTeamMemberResource represents the membership of a Konnect user in an
+// organization team. The user is identified by email and must already belong to the
+// organization. This is a child resource (no kongctl metadata support).
+type OrganizationTeamMemberResource struct {
+	Ref string `yaml:"ref" json:"ref"`
+
+	// Parent team reference
+	Team string `yaml:"team,omitempty" json:"team,omitempty"`
+
+	// Email of the user
+	Email string `yaml:"email" json:"email"`
+
+	// Resolved Konnect user ID (not serialized)
+	konnectID string `yaml:"-" json:"-"`
+}
+
+// GetType returns the resource type
+func (m OrganizationTeamMemberResource) GetType() ResourceType {
+	return ResourceTypeOrganizationTeamMember
+}
+
+// GetRef returns the reference identifier
+func (m OrganizationTeamMemberResource) GetRef() string {
+	return m.Ref
+}
+
+// GetMoniker returns the member email
+func (m OrganizationTeamMemberResource) GetMoniker() string {
+	return m.Email
+}
+
+// GetDependencies returns references to other resources this membership depends on
+func (m OrganizationTeamMemberResource) GetDependencies() []ResourceRef {
+	if m.Team == "" {
+		return []ResourceRef{}
+	}
+	return []ResourceRef{{Kind: string(ResourceTypeOrganizationTeam), Ref: m.Team}}
+}
+
+// Validate ensures the organization team member resource is valid
+func (m OrganizationTeamMemberResource) Validate() error {
+	if err := ValidateRef(m.Ref); err != nil {
+		return fmt.Errorf("invalid team member ref: %w", err)
+	}
+
+	if m.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !strings.Contains(m.Email, "@") {
+		return fmt.Errorf("email %q is not a valid email address", m.Email)
+	}
+
+	return nil
+}
+
+// SetDefaults applies default values (none for team members)
+func (m *OrganizationTeamMemberResource) SetDefaults() {}
+
+// GetKonnectID returns the resolved Konnect user ID if available
+func (m OrganizationTeamMemberResource) GetKonnectID() string {
+	return m.konnectID
+}
+
+// GetKonnectMonikerFilter returns the filter string for Konnect API lookup
+func (m OrganizationTeamMemberResource) GetKonnectMonikerFilter() string {
+	return fmt.Sprintf("email[eq]=%s", m.Email)
+}
+
+// TryMatchKonnectResource attempts to match this resource with a Konnect user
+func (m *OrganizationTeamMemberResource) TryMatchKonnectResource(konnectResource any) bool {
+	user, ok := konnectResource.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	email, _ := user["email"].(string)
+	if !strings.EqualFold(email, m.Email) {
+		return false
+	}
+	if id, ok := user["id"].(string); ok {
+		m.konnectID = id
+	}
+	return true
+}
+
+// GetParentRef returns the immediate parent reference (organization team)
+func (m OrganizationTeamMemberResource) GetParentRef() *ResourceRef {
+	if m.Team != "" {
+		return &ResourceRef{Kind: string(ResourceTypeOrganizationTeam), Ref: m.Team}
+	}
+	return nil
+}
+
+// UnmarshalJSON rejects kongctl metadata on child resources
+func (m *OrganizationTeamMemberResource) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Ref     string `json:"ref"`
+		Team    string `json:"team,omitempty"`
+		Email   string `json:"email"`
+		Kongctl any    `json:"kongctl,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if temp.Kongctl != nil {
+		return fmt.Errorf("kongctl metadata not supported on child resources (organization team members)")
+	}
+
+	m.Ref = temp.Ref
+	m.Team = temp.Team
+	m.Email = temp.Email
+
+	return nil
+}
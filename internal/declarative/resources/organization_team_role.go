@@ -0,0 +1,173 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/tags"
+)
+
+func init() {
+	registerResourceType(
+		ResourceTypeOrganizationTeamRole,
+		func(rs *ResourceSet) *[]OrganizationTeamRoleResource { return &rs.OrganizationTeamRoles },
+	)
+}
+
+// OrganizationTeamRoleResource represents a role assigned to an organization team.
+// This is a child resource (no kongctl metadata support).
+type OrganizationTeamRoleResource struct {
+	Ref string `yaml:"ref" json:"ref"`
+
+	// Parent team reference
+	Team string `yaml:"team,omitempty" json:"team,omitempty"`
+
+	// Role assignment fields (API expects literal values)
+	RoleName       string `yaml:"role_name"        json:"role_name"`
+	EntityID       string `yaml:"entity_id"        json:"entity_id"`
+	EntityTypeName string `yaml:"entity_type_name" json:"entity_type_name"`
+	EntityRegion   string `yaml:"entity_region"    json:"entity_region"`
+
+	// Resolved Konnect assignment ID (not serialized)
+	konnectID string `yaml:"-" json:"-"`
+}
+
+// GetType returns the resource type
+func (r OrganizationTeamRoleResource) GetType() ResourceType {
+	return ResourceTypeOrganizationTeamRole
+}
+
+// GetRef returns the reference identifier
+func (r OrganizationTeamRoleResource) GetRef() string {
+	return r.Ref
+}
+
+// GetMoniker returns a human-readable moniker for matching purposes
+func (r OrganizationTeamRoleResource) GetMoniker() string {
+	return fmt.Sprintf("%s:%s:%s:%s", r.RoleName, r.EntityID, r.EntityTypeName, r.EntityRegion)
+}
+
+// GetDependencies returns references to other resources this assignment depends on
+func (r OrganizationTeamRoleResource) GetDependencies() []ResourceRef {
+	deps := []ResourceRef{}
+
+	if r.Team != "" {
+		deps = append(deps, ResourceRef{
+			Kind: string(ResourceTypeOrganizationTeam),
+			Ref:  r.Team,
+		})
+	}
+
+	// EntityID may be a !ref to a resource of any kind the role applies to, such as a
+	// control plane or an API; the planner wires the dependency from the resolved ref.
+	return deps
+}
+
+// Validate ensures the organization team role resource is valid
+func (r OrganizationTeamRoleResource) Validate() error {
+	if err := ValidateRef(r.Ref); err != nil {
+		return fmt.Errorf("invalid team role ref: %w", err)
+	}
+
+	if r.RoleName == "" {
+		return fmt.Errorf("role_name is required")
+	}
+	if r.EntityID == "" {
+		return fmt.Errorf("entity_id is required")
+	}
+	if r.EntityTypeName == "" {
+		return fmt.Errorf("entity_type_name is required")
+	}
+	if r.EntityRegion == "" {
+		return fmt.Errorf("entity_region is required")
+	}
+
+	return nil
+}
+
+// SetDefaults applies default values (none for team roles)
+func (r *OrganizationTeamRoleResource) SetDefaults() {}
+
+// GetKonnectID returns the resolved Konnect ID if available
+func (r OrganizationTeamRoleResource) GetKonnectID() string {
+	return r.konnectID
+}
+
+// GetKonnectMonikerFilter returns the filter string for Konnect API lookup
+func (r OrganizationTeamRoleResource) GetKonnectMonikerFilter() string {
+	// Assigned roles are listed per team and matched on all their fields
+	return ""
+}
+
+// TryMatchKonnectResource attempts to match this resource with a Konnect resource
+func (r *OrganizationTeamRoleResource) TryMatchKonnectResource(konnectResource any) bool {
+	role, ok := konnectResource.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	roleName, _ := role["role_name"].(string)
+	entityID, _ := role["entity_id"].(string)
+	entityTypeName, _ := role["entity_type_name"].(string)
+	entityRegion, _ := role["entity_region"].(string)
+
+	if roleName == r.RoleName &&
+		entityID == r.EntityID &&
+		entityTypeName == r.EntityTypeName &&
+		strings.EqualFold(entityRegion, r.EntityRegion) {
+		if id, ok := role["id"].(string); ok {
+			r.konnectID = id
+		}
+		return true
+	}
+
+	return false
+}
+
+// GetParentRef returns the immediate parent reference (organization team)
+func (r OrganizationTeamRoleResource) GetParentRef() *ResourceRef {
+	if r.Team != "" {
+		return &ResourceRef{Kind: string(ResourceTypeOrganizationTeam), Ref: r.Team}
+	}
+	return nil
+}
+
+// EntityRef returns the ref of the resource entity_id points to when it is a !ref
+func (r OrganizationTeamRoleResource) EntityRef() (string, bool) {
+	if !tags.IsRefPlaceholder(r.EntityID) {
+		return "", false
+	}
+	ref, _, ok := tags.ParseRefPlaceholder(r.EntityID)
+	return ref, ok && ref != ""
+}
+
+// UnmarshalJSON rejects kongctl metadata on child resources
+func (r *OrganizationTeamRoleResource) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Ref            string `json:"ref"`
+		Team           string `json:"team,omitempty"`
+		RoleName       string `json:"role_name"`
+		EntityID       string `json:"entity_id"`
+		EntityTypeName string `json:"entity_type_name"`
+		EntityRegion   string `json:"entity_region"`
+		Kongctl        any    `json:"kongctl,omitempty"`
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if temp.Kongctl != nil {
+		return fmt.Errorf("kongctl metadata not supported on child resources (organization team roles)")
+	}
+
+	r.Ref = temp.Ref
+	r.Team = temp.Team
+	r.RoleName = temp.RoleName
+	r.EntityID = temp.EntityID
+	r.EntityTypeName = temp.EntityTypeName
+	r.EntityRegion = temp.EntityRegion
+
+	return nil
+}
@@ -30,6 +30,8 @@ type PortalResource struct {
 	Teams          []PortalTeamResource                   `yaml:"teams,omitempty"           json:"teams,omitempty"`
 	EmailConfig    *PortalEmailConfigResource             `yaml:"email_config,omitempty"    json:"email_config,omitempty"`
 	EmailTemplates map[string]PortalEmailTemplateResource `yaml:"email_templates,omitempty" json:"email_templates,omitempty"` //nolint:lll
+	// AuditLogWebhook configures delivery of the portal audit log to an audit log destination
+	AuditLogWebhook *PortalAuditLogWebhookResource `yaml:"audit_log_webhook,omitempty" json:"audit_log_webhook,omitempty"` //nolint:lll
 
 	// Assets object containing logo and favicon (data URLs from !file tag)
 	Assets *PortalAssetsResource `yaml:"assets,omitempty" json:"assets,omitempty"`
@@ -167,6 +169,12 @@ func (p PortalResource) Validate() error {
 		}
 	}
 
+	if p.AuditLogWebhook != nil {
+		if err := p.AuditLogWebhook.Validate(); err != nil {
+			return fmt.Errorf("invalid audit log webhook: %w", err)
+		}
+	}
+
 	for key, tpl := range p.EmailTemplates {
 		if tpl.Name == "" {
 			tpl.Name = kkComps.EmailTemplateName(key)
@@ -294,6 +302,7 @@ func (p *PortalResource) UnmarshalJSON(data []byte) error {
 		"teams",
 		"email_config",
 		"email_templates",
+		"audit_log_webhook",
 		"assets",
 		"_external",
 	}
@@ -372,6 +381,13 @@ func (p *PortalResource) UnmarshalJSON(data []byte) error {
 		delete(raw, "email_config")
 	}
 
+	if v, ok := raw["audit_log_webhook"]; ok {
+		if err := json.Unmarshal(v, &p.AuditLogWebhook); err != nil {
+			return err
+		}
+		delete(raw, "audit_log_webhook")
+	}
+
 	if v, ok := raw["email_templates"]; ok {
 		if err := json.Unmarshal(v, &p.EmailTemplates); err != nil {
 			return err
@@ -448,8 +464,9 @@ type portalAlias struct {
 	Pages             []PortalPageResource                   `json:"pages,omitempty"           yaml:"pages,omitempty"`
 	Snippets          []PortalSnippetResource                `json:"snippets,omitempty"        yaml:"snippets,omitempty"`
 	Teams             []PortalTeamResource                   `json:"teams,omitempty"           yaml:"teams,omitempty"`
-	EmailConfig       *PortalEmailConfigResource             `json:"email_config,omitempty"    yaml:"email_config,omitempty"`    //nolint:lll
-	EmailTemplates    map[string]PortalEmailTemplateResource `json:"email_templates,omitempty" yaml:"email_templates,omitempty"` //nolint:lll
+	EmailConfig       *PortalEmailConfigResource             `json:"email_config,omitempty"    yaml:"email_config,omitempty"`        //nolint:lll
+	EmailTemplates    map[string]PortalEmailTemplateResource `json:"email_templates,omitempty" yaml:"email_templates,omitempty"`     //nolint:lll
+	AuditLogWebhook   *PortalAuditLogWebhookResource         `json:"audit_log_webhook,omitempty" yaml:"audit_log_webhook,omitempty"` //nolint:lll
 	Assets            *PortalAssetsResource                  `json:"assets,omitempty"          yaml:"assets,omitempty"`
 	External          *ExternalBlock                         `json:"_external,omitempty"       yaml:"_external,omitempty"`
 }
@@ -469,6 +486,7 @@ func (p PortalResource) portalAlias() portalAlias {
 		Teams:             p.Teams,
 		EmailConfig:       p.EmailConfig,
 		EmailTemplates:    p.EmailTemplates,
+		AuditLogWebhook:   p.AuditLogWebhook,
 		Assets:            p.Assets,
 		External:          p.External,
 	}
@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+)
+
+func init() {
+	registerResourceType(
+		ResourceTypePortalAuditLogWebhook,
+		func(rs *ResourceSet) *[]PortalAuditLogWebhookResource { return &rs.PortalAuditLogWebhooks },
+	)
+}
+
+// PortalAuditLogWebhookResource represents the portal audit log webhook (singleton child).
+// The webhook sends the portal audit log to an audit log destination, which holds the
+// endpoint URL and authorization settings.
+type PortalAuditLogWebhookResource struct {
+	kkComps.UpdatePortalAuditLogWebhook `       yaml:",inline"          json:",inline"`
+	Ref                                 string `yaml:"ref"              json:"ref"`
+	Portal                              string `yaml:"portal,omitempty" json:"portal,omitempty"`
+
+	konnectID string `yaml:"-" json:"-"`
+}
+
+func (w PortalAuditLogWebhookResource) GetRef() string {
+	return w.Ref
+}
+
+func (w PortalAuditLogWebhookResource) Validate() error {
+	if err := ValidateRef(w.Ref); err != nil {
+		return fmt.Errorf("invalid portal audit log webhook ref: %w", err)
+	}
+	if w.Enabled != nil && *w.Enabled && w.GetDestinationID() == "" {
+		return fmt.Errorf("audit_log_destination_id is required when the audit log webhook is enabled")
+	}
+	return nil
+}
+
+func (w *PortalAuditLogWebhookResource) SetDefaults() {}
+
+func (w PortalAuditLogWebhookResource) GetType() ResourceType {
+	return ResourceTypePortalAuditLogWebhook
+}
+
+func (w PortalAuditLogWebhookResource) GetMoniker() string {
+	return w.Ref
+}
+
+func (w PortalAuditLogWebhookResource) GetDependencies() []ResourceRef {
+	return []ResourceRef{}
+}
+
+// GetReferenceFieldMappings returns cross-resource reference mappings for validation.
+func (w PortalAuditLogWebhookResource) GetReferenceFieldMappings() map[string]string {
+	return map[string]string{
+		"portal": "portal",
+	}
+}
+
+func (w PortalAuditLogWebhookResource) GetKonnectID() string {
+	return w.konnectID
+}
+
+func (w PortalAuditLogWebhookResource) GetKonnectMonikerFilter() string {
+	// Singleton child matched via parent.
+	return ""
+}
+
+func (w *PortalAuditLogWebhookResource) TryMatchKonnectResource(_ any) bool {
+	// The webhook has no ID of its own; it is addressed through its portal.
+	return false
+}
+
+// GetParentRef implements ResourceWithParent for inheritance of namespace and protection.
+func (w PortalAuditLogWebhookResource) GetParentRef() *ResourceRef {
+	if w.Portal == "" {
+		return nil
+	}
+	return &ResourceRef{Kind: string(ResourceTypePortal), Ref: w.Portal}
+}
+
+// GetEnabledValue returns the desired enabled flag, which Konnect defaults to false.
+func (w PortalAuditLogWebhookResource) GetEnabledValue() bool {
+	return w.Enabled != nil && *w.Enabled
+}
+
+// GetDestinationID returns the desired audit log destination ID
+func (w PortalAuditLogWebhookResource) GetDestinationID() string {
+	if w.AuditLogDestinationID == nil {
+		return ""
+	}
+	return *w.AuditLogDestinationID
+}
+
+// MarshalJSON includes the ref and portal, which the embedded SDK type's
+// MarshalJSON would otherwise drop.
+func (w PortalAuditLogWebhookResource) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Ref                   string  `json:"ref"`
+		Portal                string  `json:"portal,omitempty"`
+		Enabled               *bool   `json:"enabled,omitempty"`
+		AuditLogDestinationID *string `json:"audit_log_destination_id,omitempty"`
+	}
+	return json.Marshal(alias{
+		Ref:                   w.Ref,
+		Portal:                w.Portal,
+		Enabled:               w.Enabled,
+		AuditLogDestinationID: w.AuditLogDestinationID,
+	})
+}
+
+// UnmarshalJSON rejects unknown fields and kongctl metadata on child resources.
+func (w *PortalAuditLogWebhookResource) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Ref                   string  `json:"ref"`
+		Portal                string  `json:"portal,omitempty"`
+		Enabled               *bool   `json:"enabled,omitempty"`
+		AuditLogDestinationID *string `json:"audit_log_destination_id,omitempty"`
+		Kongctl               any     `json:"kongctl,omitempty"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&temp); err != nil {
+		return err
+	}
+
+	if temp.Kongctl != nil {
+		return fmt.Errorf("kongctl metadata not supported on portal audit log webhook")
+	}
+
+	w.Ref = temp.Ref
+	w.Portal = temp.Portal
+	w.Enabled = temp.Enabled
+	w.AuditLogDestinationID = temp.AuditLogDestinationID
+	return nil
+}
@@ -64,6 +64,14 @@ func (d PortalCustomDomainResource) GetType() ResourceType {
 	return ResourceTypePortalCustomDomain
 }
 
+// GetParentRef returns the parent portal reference
+func (d PortalCustomDomainResource) GetParentRef() *ResourceRef {
+	if d.Portal != "" {
+		return &ResourceRef{Kind: "portal", Ref: d.Portal}
+	}
+	return nil
+}
+
 // GetMoniker returns the resource moniker (for custom domains, this is the hostname)
 func (d PortalCustomDomainResource) GetMoniker() string {
 	return d.Hostname
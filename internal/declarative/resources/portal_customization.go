@@ -72,6 +72,14 @@ func (c PortalCustomizationResource) GetType() ResourceType {
 	return ResourceTypePortalCustomization
 }
 
+// GetParentRef returns the parent portal reference
+func (c PortalCustomizationResource) GetParentRef() *ResourceRef {
+	if c.Portal != "" {
+		return &ResourceRef{Kind: "portal", Ref: c.Portal}
+	}
+	return nil
+}
+
 // GetMoniker returns the resource moniker (for customizations, this is the ref)
 func (c PortalCustomizationResource) GetMoniker() string {
 	return c.Ref // Customizations don't have names
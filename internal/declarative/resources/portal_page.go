@@ -143,6 +143,14 @@ func (p PortalPageResource) GetType() ResourceType {
 	return ResourceTypePortalPage
 }
 
+// GetParentRef returns the parent portal reference
+func (p PortalPageResource) GetParentRef() *ResourceRef {
+	if p.Portal != "" {
+		return &ResourceRef{Kind: "portal", Ref: p.Portal}
+	}
+	return nil
+}
+
 // GetReferenceFieldMappings returns cross-resource reference mappings for validation
 func (p PortalPageResource) GetReferenceFieldMappings() map[string]string {
 	return map[string]string{
@@ -282,6 +290,14 @@ func (s PortalSnippetResource) GetType() ResourceType {
 	return ResourceTypePortalSnippet
 }
 
+// GetParentRef returns the parent portal reference
+func (s PortalSnippetResource) GetParentRef() *ResourceRef {
+	if s.Portal != "" {
+		return &ResourceRef{Kind: "portal", Ref: s.Portal}
+	}
+	return nil
+}
+
 // GetReferenceFieldMappings returns cross-resource reference mappings for validation
 func (s PortalSnippetResource) GetReferenceFieldMappings() map[string]string {
 	return map[string]string{
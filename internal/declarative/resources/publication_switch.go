@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+)
+
+// PublicationSwitch describes a blue/green visibility switch for an API publication.
+// The publication is first written with StageVisibility, the Verify command runs, and
+// only when it succeeds is the publication flipped to its declared visibility.
+type PublicationSwitch struct {
+	// StageVisibility is the visibility the publication is staged with (default private)
+	StageVisibility string `yaml:"stage_visibility,omitempty" json:"stage_visibility,omitempty"`
+	// Verify is an optional command, as program and arguments, run between stage and flip
+	Verify []string `yaml:"verify,omitempty" json:"verify,omitempty"`
+}
+
+// GetStageVisibility returns the stage visibility, defaulting to private
+func (s *PublicationSwitch) GetStageVisibility() string {
+	if s == nil || s.StageVisibility == "" {
+		return string(kkComps.APIPublicationVisibilityPrivate)
+	}
+	return s.StageVisibility
+}
+
+// Validate checks the switch against the publication's declared visibility
+func (s *PublicationSwitch) Validate(visibility *kkComps.APIPublicationVisibility) error {
+	if visibility == nil {
+		return fmt.Errorf("_switch requires visibility to be set")
+	}
+
+	stage := s.GetStageVisibility()
+	switch kkComps.APIPublicationVisibility(stage) {
+	case kkComps.APIPublicationVisibilityPublic, kkComps.APIPublicationVisibilityPrivate:
+	default:
+		return fmt.Errorf("_switch.stage_visibility must be %q or %q, got %q",
+			kkComps.APIPublicationVisibilityPublic, kkComps.APIPublicationVisibilityPrivate, stage)
+	}
+	if stage == string(*visibility) {
+		return fmt.Errorf("_switch.stage_visibility must differ from visibility %q", stage)
+	}
+
+	if len(s.Verify) > 0 && strings.TrimSpace(s.Verify[0]) == "" {
+		return fmt.Errorf("_switch.verify must start with a command")
+	}
+	return nil
+}
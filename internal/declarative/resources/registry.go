@@ -15,6 +15,7 @@ type resourceOps struct {
 	append  func(dest, src *ResourceSet)
 	forEach func(rs *ResourceSet, fn func(Resource) bool) bool
 	count   func(rs *ResourceSet) int
+	retain  func(rs *ResourceSet, keep func(Resource) bool)
 }
 
 // registry maps resource types to their operations.
@@ -69,6 +70,16 @@ func registerResourceType[R any, RPtr interface {
 		count: func(rs *ResourceSet) int {
 			return len(*getSlicePtr(rs))
 		},
+		retain: func(rs *ResourceSet, keep func(Resource) bool) {
+			slicePtr := getSlicePtr(rs)
+			var kept []R
+			for i := range *slicePtr {
+				if keep(RPtr(&(*slicePtr)[i])) {
+					kept = append(kept, (*slicePtr)[i])
+				}
+			}
+			*slicePtr = kept
+		},
 	}
 }
 
@@ -152,6 +163,14 @@ func (rs *ResourceSet) AppendAll(src *ResourceSet) {
 	}
 }
 
+// RetainResources removes the resources for which keep returns false, for all
+// registered types.
+func (rs *ResourceSet) RetainResources(keep func(Resource) bool) {
+	for _, ops := range registry {
+		ops.retain(rs, keep)
+	}
+}
+
 // IsRegistered returns true if a resource type is registered in the registry.
 func IsRegistered(rt ResourceType) bool {
 	_, ok := registry[rt]
@@ -226,6 +226,9 @@ func TestIsRegistered(t *testing.T) {
 			ResourceTypeApplicationAuthStrategy,
 			ResourceTypeGatewayService,
 			ResourceTypeOrganizationTeam,
+			ResourceTypeOrganizationTeamRole,
+			ResourceTypeOrganizationTeamMember,
+			ResourceTypeNotificationSubscription,
 			ResourceTypeEventGatewayControlPlane,
 		}
 
@@ -25,11 +25,17 @@ const (
 	ResourceTypePortalAssetFavicon         ResourceType = "portal_asset_favicon"
 	ResourceTypePortalEmailConfig          ResourceType = "portal_email_config"
 	ResourceTypePortalEmailTemplate        ResourceType = "portal_email_template"
+	ResourceTypePortalAuditLogWebhook      ResourceType = "portal_audit_log_webhook"
 	ResourceTypeCatalogService             ResourceType = "catalog_service"
 	ResourceTypeEventGatewayControlPlane   ResourceType = "event_gateway"
 	ResourceTypeEventGatewayBackendCluster ResourceType = "event_gateway_backend_cluster"
 	ResourceTypeEventGatewayVirtualCluster ResourceType = "event_gateway_virtual_cluster"
 	ResourceTypeOrganizationTeam           ResourceType = "organization_team"
+	ResourceTypeOrganizationTeamRole       ResourceType = "organization_team_role"
+	ResourceTypeOrganizationTeamMember     ResourceType = "organization_team_member"
+	ResourceTypeNotificationSubscription   ResourceType = "notification_subscription"
+	// ResourceTypeCustom groups resources whose kind is provided by a custom resource handler
+	ResourceTypeCustom ResourceType = "custom_resource"
 )
 
 const (
@@ -70,6 +76,7 @@ type ResourceSet struct {
 	PortalAssetFavicons         []PortalAssetFaviconResource         `yaml:"portal_asset_favicons,omitempty"          json:"portal_asset_favicons,omitempty"`          //nolint:lll
 	PortalEmailConfigs          []PortalEmailConfigResource          `yaml:"portal_email_configs,omitempty"           json:"portal_email_configs,omitempty"`           //nolint:lll
 	PortalEmailTemplates        []PortalEmailTemplateResource        `yaml:"portal_email_templates,omitempty"         json:"portal_email_templates,omitempty"`         //nolint:lll
+	PortalAuditLogWebhooks      []PortalAuditLogWebhookResource      `yaml:"portal_audit_log_webhooks,omitempty"      json:"portal_audit_log_webhooks,omitempty"`      //nolint:lll
 	EventGatewayControlPlanes   []EventGatewayControlPlaneResource   `yaml:"event_gateways,omitempty"                 json:"event_gateways,omitempty"`                 //nolint:lll
 	EventGatewayBackendClusters []EventGatewayBackendClusterResource `yaml:"event_gateway_backend_clusters,omitempty" json:"event_gateway_backend_clusters,omitempty"` //nolint:lll
 	EventGatewayVirtualClusters []EventGatewayVirtualClusterResource `yaml:"event_gateway_virtual_clusters,omitempty" json:"event_gateway_virtual_clusters,omitempty"` //nolint:lll
@@ -78,6 +85,15 @@ type ResourceSet struct {
 	// Teams is populated internally from OrganizationTeams during loading
 	// It is not exposed in YAML/JSON to enforce the organization grouping format
 	OrganizationTeams []OrganizationTeamResource `yaml:"-"                                        json:"-"`
+	// Team roles and members are populated internally from the teams during loading
+	OrganizationTeamRoles   []OrganizationTeamRoleResource   `yaml:"-"                                        json:"-"`
+	OrganizationTeamMembers []OrganizationTeamMemberResource `yaml:"-"                                        json:"-"`
+	// NotificationSubscriptions contains the notification event subscriptions of the current user
+	NotificationSubscriptions []NotificationSubscriptionResource `yaml:"notification_subscriptions,omitempty"     json:"notification_subscriptions,omitempty"` //nolint:lll
+	// CustomResources contains resources of kinds provided by registered custom resource handlers
+	CustomResources []CustomResource `yaml:"custom_resources,omitempty"               json:"custom_resources,omitempty"` //nolint:lll
+	// Moved maps the former refs of renamed resources to their current refs
+	Moved []MovedResource `yaml:"moved,omitempty"                          json:"moved,omitempty"`
 	// DefaultNamespace tracks namespace from _defaults when no resources are present
 	// This is used by the planner to determine which namespace to check for deletions
 	DefaultNamespace  string   `yaml:"-"                                        json:"-"`
@@ -96,6 +112,9 @@ const (
 	NamespaceOriginFileDefault
 	// NamespaceOriginImplicitDefault indicates the namespace fell back to the implicit "default" value
 	NamespaceOriginImplicitDefault
+	// NamespaceOriginActive indicates the namespace was inherited from the active namespace
+	// of the command (--namespace) or the file (the top-level namespace key)
+	NamespaceOriginActive
 )
 
 // KongctlMeta contains tool-specific metadata for resources
@@ -104,6 +123,11 @@ type KongctlMeta struct {
 	Protected *bool `yaml:"protected,omitempty" json:"protected,omitempty"`
 	// Namespace for resource isolation and multi-team management
 	Namespace *string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	// IgnoreFields lists field paths whose differences alone never update the resource
+	IgnoreFields []string `yaml:"ignore_fields,omitempty" json:"ignore_fields,omitempty"`
+	// LintRules overrides the severity of lint rules, by rule name, for the specs of
+	// the versions of an API
+	LintRules map[string]string `yaml:"lint_rules,omitempty" json:"lint_rules,omitempty"`
 	// NamespaceOrigin tracks how the namespace value was derived (not serialized)
 	NamespaceOrigin NamespaceOrigin `yaml:"-"                   json:"-"`
 }
@@ -111,6 +135,11 @@ type KongctlMeta struct {
 // FileDefaults holds file-level defaults that apply to all resources in the file
 type FileDefaults struct {
 	Kongctl *KongctlMetaDefaults `yaml:"kongctl,omitempty" json:"kongctl,omitempty"`
+	// Labels are merged into the labels of every managed resource in the file
+	Labels map[string]string `yaml:"labels,omitempty"  json:"labels,omitempty"`
+	// Region is the Konnect region of the resources of the file, such as eu, when it
+	// differs from the region of the profile. Child resources in other files inherit it.
+	Region string `yaml:"region,omitempty"  json:"region,omitempty"`
 }
 
 // KongctlMetaDefaults holds default values for kongctl metadata fields
@@ -524,6 +553,17 @@ func (rs *ResourceSet) GetOrganizationTeamsByNamespace(namespace string) []Organ
 	return filtered
 }
 
+// GetCustomResourcesByNamespace returns custom resources of the given kind from the specified namespace
+func (rs *ResourceSet) GetCustomResourcesByNamespace(kind, namespace string) []CustomResource {
+	var filtered []CustomResource
+	for _, res := range rs.CustomResources {
+		if res.Kind == kind && GetNamespace(res.Kongctl) == namespace {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
+
 // GetNamespace safely extracts namespace from kongctl metadata
 func GetNamespace(kongctl *KongctlMeta) string {
 	if kongctl == nil || kongctl.Namespace == nil {
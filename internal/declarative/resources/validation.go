@@ -9,9 +9,11 @@ import (
 // refPattern defines the allowed pattern for resource refs
 // Allows alphanumeric characters, hyphens, and underscores
 // Must start with a letter or number, and can contain hyphens and underscores
-var refPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+var refPattern = regexp.MustCompile(RefPattern)
 
 const (
+	// RefPattern is the pattern every ref matches
+	RefPattern = `^[a-zA-Z0-9][a-zA-Z0-9_-]*$`
 	// MaxRefLength is the maximum allowed length for a ref
 	MaxRefLength = 63
 	// MinRefLength is the minimum allowed length for a ref
@@ -0,0 +1,141 @@
+// Package scaffold generates declarative configuration to start from, such as an API
+// resource for an OpenAPI spec.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for the !file and !ref tags of the output
+)
+
+// APIOptions select what is generated for an API
+type APIOptions struct {
+	// SpecPath is the path of the spec written in the !file tag of the version,
+	// relative to the generated file
+	SpecPath string
+	// Ref of the API, derived from the title of the spec when empty
+	Ref string
+	// Portal is the ref of a portal to publish the API to, when not empty
+	Portal string
+}
+
+// specInfo is the info object of an OpenAPI or Swagger document
+type specInfo struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+}
+
+// API returns a declarative configuration with an API populated from the info of the
+// spec, a version loading the spec with !file and, when a portal is given, a
+// publication to it. The spec is YAML or JSON.
+func API(spec []byte, opts APIOptions) ([]byte, error) {
+	var doc struct {
+		OpenAPI string   `yaml:"openapi"`
+		Swagger string   `yaml:"swagger"`
+		Info    specInfo `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if doc.OpenAPI == "" && doc.Swagger == "" {
+		return nil, fmt.Errorf("spec is not an OpenAPI document: missing openapi or swagger version")
+	}
+	if strings.TrimSpace(doc.Info.Title) == "" {
+		return nil, fmt.Errorf("spec has no info.title to name the API after")
+	}
+	if strings.TrimSpace(doc.Info.Version) == "" {
+		return nil, fmt.Errorf("spec has no info.version to name the API version after")
+	}
+
+	ref := opts.Ref
+	if ref == "" {
+		ref = Ref(doc.Info.Title)
+	}
+	if err := resources.ValidateRef(ref); err != nil {
+		return nil, fmt.Errorf("invalid API ref %q: %w", ref, err)
+	}
+	if opts.Portal != "" {
+		if err := resources.ValidateRef(opts.Portal); err != nil {
+			return nil, fmt.Errorf("invalid portal ref %q: %w", opts.Portal, err)
+		}
+	}
+
+	api := mapping(
+		"ref", scalar(ref),
+		"name", scalar(doc.Info.Title),
+	)
+	if description := strings.TrimSpace(doc.Info.Description); description != "" {
+		api.Content = append(api.Content, scalar("description"), scalar(description))
+	}
+	api.Content = append(api.Content,
+		scalar("version"), scalar(doc.Info.Version),
+		scalar("versions"), sequence(mapping(
+			"ref", scalar(Ref(ref+"-"+doc.Info.Version)),
+			"version", scalar(doc.Info.Version),
+			"spec", tagged("!file", opts.SpecPath),
+		)),
+	)
+	if opts.Portal != "" {
+		api.Content = append(api.Content,
+			scalar("publications"), sequence(mapping(
+				"ref", scalar(Ref(ref+"-to-"+opts.Portal)),
+				"portal_id", tagged("!ref", opts.Portal+"#id"),
+			)),
+		)
+	}
+
+	root := mapping("apis", sequence(api))
+	root.HeadComment = fmt.Sprintf("Generated from %s by kongctl generate api", opts.SpecPath)
+	return render(root)
+}
+
+// render writes a configuration with the two space indentation of the examples
+func render(root *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render configuration: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var refSeparators = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Ref turns a name into a ref: lowercase letters, digits, underscores and hyphens,
+// at most resources.MaxRefLength long
+func Ref(name string) string {
+	ref := strings.Trim(refSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-_")
+	if len(ref) > resources.MaxRefLength {
+		ref = strings.TrimRight(ref[:resources.MaxRefLength], "-_")
+	}
+	return ref
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+func tagged(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+func sequence(items ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Content: items}
+}
+
+func mapping(pairs ...any) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		node.Content = append(node.Content, scalar(pairs[i].(string)), pairs[i+1].(*yaml.Node))
+	}
+	return node
+}
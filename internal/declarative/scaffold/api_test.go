@@ -0,0 +1,134 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ordersSpec = `openapi: 3.0.3
+info:
+  title: Orders API
+  description: |
+    Place and track orders.
+  version: 1.2.0
+paths: {}
+`
+
+func TestAPI(t *testing.T) {
+	data, err := API([]byte(ordersSpec), APIOptions{SpecPath: "specs/orders.yaml", Portal: "developer-portal"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Generated from specs/orders.yaml by kongctl generate api
+apis:
+  - ref: orders-api
+    name: Orders API
+    description: Place and track orders.
+    version: 1.2.0
+    versions:
+      - ref: orders-api-1-2-0
+        version: 1.2.0
+        spec: !file specs/orders.yaml
+    publications:
+      - ref: orders-api-to-developer-portal
+        portal_id: !ref developer-portal#id
+`, string(data))
+}
+
+// The generated configuration is ready to apply: it matches the schema and loads
+// together with the portal it is published to
+func TestAPI_Loads(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "specs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "orders.json"), []byte(`{
+  "swagger": "2.0",
+  "info": {"title": "Orders", "version": "v2"},
+  "paths": {}
+}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "portal.yaml"), []byte(`portals:
+  - ref: developer-portal
+    name: Developer Portal
+`), 0o600))
+
+	spec, err := os.ReadFile(filepath.Join(dir, "specs", "orders.json"))
+	require.NoError(t, err)
+	data, err := API(spec, APIOptions{SpecPath: "specs/orders.json", Ref: "orders", Portal: "developer-portal"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.yaml"), data, 0o600))
+
+	issues, err := loader.Schema().Check(data)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	rs, err := loader.New().LoadFromSources([]loader.Source{{Path: dir, Type: loader.SourceTypeDirectory}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.APIs, 1)
+	assert.Equal(t, "orders", rs.APIs[0].Ref)
+	assert.Equal(t, "Orders", rs.APIs[0].Name)
+	require.Len(t, rs.APIVersions, 1)
+	assert.Equal(t, "orders-v2", rs.APIVersions[0].Ref)
+	require.Len(t, rs.APIPublications, 1)
+	assert.Equal(t, "orders", rs.APIPublications[0].API)
+	// References are resolved when planning
+	assert.Contains(t, rs.APIPublications[0].PortalID, "developer-portal#id")
+}
+
+func TestAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		opts APIOptions
+		want string
+	}{
+		{
+			name: "not a spec",
+			spec: "name: orders\n",
+			want: "not an OpenAPI document",
+		},
+		{
+			name: "no title",
+			spec: "openapi: 3.1.0\ninfo:\n  version: 1.0.0\n",
+			want: "no info.title",
+		},
+		{
+			name: "no version",
+			spec: "openapi: 3.1.0\ninfo:\n  title: Orders\n",
+			want: "no info.version",
+		},
+		{
+			name: "invalid ref",
+			spec: ordersSpec,
+			opts: APIOptions{Ref: "orders:v1"},
+			want: `invalid API ref "orders:v1"`,
+		},
+		{
+			name: "invalid portal ref",
+			spec: ordersSpec,
+			opts: APIOptions{Portal: "my portal"},
+			want: `invalid portal ref "my portal"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := API([]byte(tt.spec), tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRef(t *testing.T) {
+	assert.Equal(t, "orders-api", Ref("Orders API"))
+	assert.Equal(t, "pet_store-v3", Ref("  Pet_Store (v3)! "))
+	// Long names are cut to the maximum ref length, without a trailing separator
+	long := Ref(strings.Repeat("orders ", 20))
+	assert.LessOrEqual(t, len(long), resources.MaxRefLength)
+	assert.NoError(t, resources.ValidateRef(long))
+	assert.NotContains(t, "-_", long[len(long)-1:])
+}
@@ -0,0 +1,273 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kong/kongctl/internal/util/viper"
+)
+
+//go:embed templates/ci/*
+var ciTemplates embed.FS
+
+const (
+	// ProviderGitHub generates a GitHub Actions workflow
+	ProviderGitHub = "github"
+	// ProviderGitLab generates a GitLab CI pipeline
+	ProviderGitLab = "gitlab"
+
+	gitignorePath = ".gitignore"
+)
+
+// Providers lists the CI services pipelines are generated for
+var Providers = []string{ProviderGitHub, ProviderGitLab}
+
+var (
+	profilePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	regionPattern  = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+)
+
+// Environment is a kongctl profile the pipelines plan and apply, in a Konnect region
+type Environment struct {
+	Profile string
+	Region  string
+}
+
+// EnvPrefix is the prefix of the environment variables configuring the profile
+func (e Environment) EnvPrefix() string {
+	return viper.ProfileEnvPrefix(e.Profile)
+}
+
+// Secret is the name of the CI secret holding the Konnect access token of the profile
+func (e Environment) Secret() string {
+	return "KONNECT_PAT_" + strings.ToUpper(strings.ReplaceAll(e.Profile, "-", "_"))
+}
+
+// ParseEnvironment parses an environment given as profile or profile=region. The
+// region defaults to us.
+func ParseEnvironment(value string) (Environment, error) {
+	profile, region, _ := strings.Cut(strings.TrimSpace(value), "=")
+	env := Environment{Profile: strings.TrimSpace(profile), Region: strings.TrimSpace(region)}
+	if env.Region == "" {
+		env.Region = "us"
+	}
+	if !profilePattern.MatchString(env.Profile) {
+		return Environment{}, fmt.Errorf(
+			"invalid environment %q: the profile must start with a letter and hold only letters, digits, "+
+				"hyphens and underscores", value)
+	}
+	if !regionPattern.MatchString(env.Region) {
+		return Environment{}, fmt.Errorf("invalid environment %q: %q is not a Konnect region such as us or eu",
+			value, env.Region)
+	}
+	return env, nil
+}
+
+// CIOptions select the repository layout and pipelines generated by CI
+type CIOptions struct {
+	// Providers are the CI services to generate pipelines for, see Providers
+	Providers []string
+	// Environments are the profiles the pipelines plan and apply
+	Environments []Environment
+	// ConfigDir is the directory of the configuration, relative to the repository root
+	ConfigDir string
+	// Branch is the branch that merges are applied from
+	Branch string
+	// KongctlVersion is the kongctl release the pipelines install, the latest when empty
+	KongctlVersion string
+}
+
+// File is a generated file
+type File struct {
+	// Path is relative to the repository root, with forward slashes
+	Path    string
+	Content []byte
+}
+
+// ciOutput is a file generated from a template
+type ciOutput struct {
+	path     string
+	template string
+}
+
+// ciData is what the templates are rendered with
+type ciData struct {
+	CIOptions
+	DownloadURL string
+	// SpecsDir is the directory of the API specs. It is next to the configuration
+	// directory, which is loaded recursively.
+	SpecsDir string
+}
+
+// CI returns a repository layout for kongctl: a configuration directory with an
+// example portal and API, a .gitignore for the plans, and pipelines validating and
+// planning the configuration on pull requests and applying it on merge.
+func CI(opts CIOptions) ([]File, error) {
+	if len(opts.Providers) == 0 {
+		return nil, fmt.Errorf("at least one CI provider is required")
+	}
+	for _, provider := range opts.Providers {
+		if !slices.Contains(Providers, provider) {
+			return nil, fmt.Errorf("unsupported CI provider %q, expected one of %s",
+				provider, strings.Join(Providers, ", "))
+		}
+	}
+	if len(opts.Environments) == 0 {
+		return nil, fmt.Errorf("at least one environment is required")
+	}
+	seen := make(map[string]bool, len(opts.Environments))
+	for _, env := range opts.Environments {
+		if seen[env.Secret()] {
+			return nil, fmt.Errorf("environment %q is given more than once", env.Profile)
+		}
+		seen[env.Secret()] = true
+	}
+	configDir := path.Clean(filepath.ToSlash(strings.TrimSpace(opts.ConfigDir)))
+	if configDir == "." || path.IsAbs(configDir) || strings.HasPrefix(configDir, "../") || configDir == ".." {
+		return nil, fmt.Errorf("the configuration directory %q must be inside the repository", opts.ConfigDir)
+	}
+	opts.ConfigDir = configDir
+	if opts.Branch == "" {
+		opts.Branch = "main"
+	}
+
+	data := ciData{CIOptions: opts, DownloadURL: downloadURL(opts.KongctlVersion), SpecsDir: "specs"}
+	if parent := path.Dir(configDir); parent != "." {
+		data.SpecsDir = parent + "/specs"
+	}
+	outputs := []ciOutput{
+		{configDir + "/portal.yaml", "portal.yaml.tmpl"},
+		{configDir + "/apis.yaml", "apis.yaml.tmpl"},
+		{data.SpecsDir + "/example-api.yaml", "example-api.yaml.tmpl"},
+		{gitignorePath, "gitignore.tmpl"},
+	}
+	if slices.Contains(opts.Providers, ProviderGitHub) {
+		outputs = append(outputs, ciOutput{".github/workflows/kongctl.yaml", "github.yaml.tmpl"})
+	}
+	if slices.Contains(opts.Providers, ProviderGitLab) {
+		outputs = append(outputs, ciOutput{".gitlab-ci.yml", "gitlab.yaml.tmpl"})
+	}
+
+	files := make([]File, 0, len(outputs))
+	for _, output := range outputs {
+		content, err := renderTemplate(output.template, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: output.path, Content: content})
+	}
+	return files, nil
+}
+
+// downloadURL returns the URL of the linux amd64 archive of a kongctl release
+func downloadURL(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" || version == "latest" {
+		return "https://github.com/Kong/kongctl/releases/latest/download/kongctl_linux_amd64.zip"
+	}
+	return fmt.Sprintf("https://github.com/Kong/kongctl/releases/download/v%s/kongctl_linux_amd64.zip", version)
+}
+
+// renderTemplate renders a CI template. The templates use [[ ]] delimiters, as
+// ${{ }} are expressions of GitHub Actions.
+func renderTemplate(name string, data ciData) ([]byte, error) {
+	tmpl, err := template.New(name).Delims("[[", "]]").ParseFS(ciTemplates, "templates/ci/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteStatus tells what Write did with a file
+type WriteStatus string
+
+const (
+	WriteCreated WriteStatus = "created"
+	WriteUpdated WriteStatus = "updated"
+	// WriteSkipped is a file that exists and was kept
+	WriteSkipped WriteStatus = "skipped"
+)
+
+// Write writes files under dir and returns what it did with each. Existing files are
+// kept unless overwrite is set, except .gitignore, which gets the lines it lacks.
+func Write(dir string, files []File, overwrite bool) ([]WriteStatus, error) {
+	statuses := make([]WriteStatus, 0, len(files))
+	for _, file := range files {
+		target := filepath.Join(dir, filepath.FromSlash(file.Path))
+		existing, err := os.ReadFile(target)
+		exists := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", target, err)
+		}
+
+		content := file.Content
+		status := WriteCreated
+		switch {
+		case exists && file.Path == gitignorePath:
+			content = mergeLines(existing, file.Content)
+			status = WriteUpdated
+			if bytes.Equal(content, existing) {
+				statuses = append(statuses, WriteSkipped)
+				continue
+			}
+		case exists && !overwrite:
+			statuses = append(statuses, WriteSkipped)
+			continue
+		case exists:
+			status = WriteUpdated
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil { //nolint:gosec // repository files
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// mergeLines appends the entries of addition missing from existing, with the comments
+// of addition that existing lacks
+func mergeLines(existing, addition []byte) []byte {
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+	var added []string
+	missing := false
+	for _, line := range strings.Split(string(addition), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || present[trimmed] {
+			continue
+		}
+		added = append(added, trimmed)
+		missing = missing || !strings.HasPrefix(trimmed, "#")
+	}
+	if !missing {
+		return existing
+	}
+
+	merged := string(existing)
+	if merged != "" {
+		if !strings.HasSuffix(merged, "\n") {
+			merged += "\n"
+		}
+		merged += "\n"
+	}
+	return []byte(merged + strings.Join(added, "\n") + "\n")
+}
@@ -0,0 +1,130 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	env, err := ParseEnvironment("prod-eu=eu")
+	require.NoError(t, err)
+	assert.Equal(t, Environment{Profile: "prod-eu", Region: "eu"}, env)
+	assert.Equal(t, "KONGCTL_PROD_EU", env.EnvPrefix())
+	assert.Equal(t, "KONNECT_PAT_PROD_EU", env.Secret())
+
+	env, err = ParseEnvironment("default")
+	require.NoError(t, err)
+	assert.Equal(t, "us", env.Region)
+
+	_, err = ParseEnvironment("prod eu")
+	assert.ErrorContains(t, err, "the profile must start with a letter")
+	_, err = ParseEnvironment("prod=EU!")
+	assert.ErrorContains(t, err, "is not a Konnect region")
+}
+
+func TestCI(t *testing.T) {
+	files, err := CI(CIOptions{
+		Providers:      []string{ProviderGitHub, ProviderGitLab},
+		Environments:   []Environment{{Profile: "prod-us", Region: "us"}, {Profile: "prod-eu", Region: "eu"}},
+		ConfigDir:      "./konnect/",
+		KongctlVersion: "v0.3.0",
+	})
+	require.NoError(t, err)
+
+	byPath := make(map[string]string, len(files))
+	for _, file := range files {
+		byPath[file.Path] = string(file.Content)
+	}
+	assert.ElementsMatch(t, []string{
+		"konnect/portal.yaml", "konnect/apis.yaml", "specs/example-api.yaml", ".gitignore",
+		".github/workflows/kongctl.yaml", ".gitlab-ci.yml",
+	}, keys(byPath))
+
+	for _, path := range []string{".github/workflows/kongctl.yaml", ".gitlab-ci.yml"} {
+		var pipeline map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(byPath[path]), &pipeline), path)
+		content := byPath[path]
+		assert.Contains(t, content, "releases/download/v0.3.0/kongctl_linux_amd64.zip")
+		assert.Contains(t, content, "kongctl validate -f konnect -R --base-dir .")
+		assert.Contains(t, content, "--output-file plan-prod-eu.json --detailed-exitcode || code=$?")
+		assert.Contains(t, content, "kongctl apply --plan plan-prod-eu.json --auto-approve")
+		assert.Contains(t, content, "KONGCTL_PROD_EU_KONNECT_REGION: eu")
+	}
+
+	var workflow struct {
+		Jobs map[string]struct {
+			If  string            `json:"if"`
+			Env map[string]string `json:"env"`
+		} `json:"jobs"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(byPath[".github/workflows/kongctl.yaml"]), &workflow))
+	assert.Len(t, workflow.Jobs, 5)
+	assert.Equal(t, "github.event_name == 'pull_request'", workflow.Jobs["plan-prod-us"].If)
+	assert.Equal(t, "github.event_name == 'push'", workflow.Jobs["apply-prod-us"].If)
+	assert.Equal(t, "${{ secrets.KONNECT_PAT_PROD_US }}",
+		workflow.Jobs["apply-prod-us"].Env["KONGCTL_PROD_US_KONNECT_PAT"])
+	assert.Contains(t, byPath[".gitlab-ci.yml"], "KONGCTL_PROD_US_KONNECT_PAT: $KONNECT_PAT_PROD_US")
+
+	// The example configuration is valid and loads its spec from the specs directory
+	dir := t.TempDir()
+	_, err = Write(dir, files, false)
+	require.NoError(t, err)
+	rs, err := loader.NewWithBaseDir(dir).LoadFromSources(
+		[]loader.Source{{Path: filepath.Join(dir, "konnect"), Type: loader.SourceTypeDirectory}}, true)
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	require.Len(t, rs.APIVersions, 1)
+	require.Len(t, rs.APIPublications, 1)
+}
+
+func TestCI_Errors(t *testing.T) {
+	env := []Environment{{Profile: "default", Region: "us"}}
+	_, err := CI(CIOptions{Providers: []string{"jenkins"}, Environments: env, ConfigDir: "konnect"})
+	assert.ErrorContains(t, err, `unsupported CI provider "jenkins"`)
+	_, err = CI(CIOptions{Providers: []string{ProviderGitHub}, Environments: env, ConfigDir: "../konnect"})
+	assert.ErrorContains(t, err, "must be inside the repository")
+	_, err = CI(CIOptions{Providers: []string{ProviderGitHub}, Environments: append(env, env[0]), ConfigDir: "konnect"})
+	assert.ErrorContains(t, err, `environment "default" is given more than once`)
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("node_modules"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("kept"), 0o600))
+	files := []File{
+		{Path: "a.yaml", Content: []byte("new")},
+		{Path: "nested/b.yaml", Content: []byte("b")},
+		{Path: ".gitignore", Content: []byte("# plans\nplan-*.json\nnode_modules\n")},
+	}
+
+	statuses, err := Write(dir, files, false)
+	require.NoError(t, err)
+	assert.Equal(t, []WriteStatus{WriteSkipped, WriteCreated, WriteUpdated}, statuses)
+	content, err := os.ReadFile(filepath.Join(dir, "a.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kept", string(content))
+	content, err = os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules\n\n# plans\nplan-*.json\n", string(content))
+
+	statuses, err = Write(dir, files, true)
+	require.NoError(t, err)
+	assert.Equal(t, []WriteStatus{WriteUpdated, WriteUpdated, WriteSkipped}, statuses)
+	content, err = os.ReadFile(filepath.Join(dir, "a.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+}
+
+func keys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for key := range m {
+		out = append(out, key)
+	}
+	return out
+}
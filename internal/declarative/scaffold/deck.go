@@ -0,0 +1,260 @@
+package scaffold
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required to keep the order of converted fields
+)
+
+// DeckOptions select how decK state is converted
+type DeckOptions struct {
+	// Source names the state file in the header of the configuration
+	Source string
+	// ControlPlane is the name of the control plane the services belong to,
+	// defaulting to _konnect.control_plane_name of the state
+	ControlPlane string
+}
+
+// DeckConversion is the configuration converted from decK state
+type DeckConversion struct {
+	Config []byte
+	// Skipped describes the entities and fields of the state kongctl does not manage
+	Skipped []string
+}
+
+// deckState is the part of a decK state file that is converted. Other entities are
+// only counted.
+type deckState struct {
+	Info struct {
+		SelectTags []string `yaml:"select_tags"`
+	} `yaml:"_info"`
+	Konnect struct {
+		ControlPlaneName string `yaml:"control_plane_name"`
+	} `yaml:"_konnect"`
+	Services []map[string]any `yaml:"services"`
+}
+
+// deckServiceFields are the decK service fields kongctl gateway services have, in the
+// order they are written
+var deckServiceFields = []string{
+	"name", "protocol", "host", "port", "path", "retries", "connect_timeout", "read_timeout", "write_timeout",
+	"enabled", "tls_verify", "tls_verify_depth", "tls_sans", "tags",
+}
+
+// deckIgnoredFields are set by Konnect and left out of the configuration
+var deckIgnoredFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// FromDeck converts decK state into a control plane with its gateway services.
+// Routes, plugins, consumers and the other entities kongctl does not manage are
+// listed in Skipped rather than converted.
+func FromDeck(state []byte, opts DeckOptions) (*DeckConversion, error) {
+	var doc deckState
+	if err := yaml.Unmarshal(state, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse decK state: %w", err)
+	}
+	var entities map[string]any
+	if err := yaml.Unmarshal(state, &entities); err != nil {
+		return nil, fmt.Errorf("failed to parse decK state: %w", err)
+	}
+
+	name := opts.ControlPlane
+	if name == "" {
+		name = doc.Konnect.ControlPlaneName
+	}
+	if name == "" {
+		return nil, fmt.Errorf("the state has no _konnect.control_plane_name, name the control plane to convert to")
+	}
+
+	conversion := &DeckConversion{}
+	skipped := map[string]int{}
+	refs := refSet{}
+
+	cpRef := refs.claim("control-plane", name)
+	services := sequence()
+	for i, service := range doc.Services {
+		node, err := convertDeckService(service, doc.Info.SelectTags, refs, conversion, skipped)
+		if err != nil {
+			return nil, fmt.Errorf("services[%d]: %w", i, err)
+		}
+		services.Content = append(services.Content, node)
+	}
+
+	for key, value := range entities {
+		// Keys starting with an underscore, such as _format_version, are metadata
+		if key == "services" || strings.HasPrefix(key, "_") {
+			continue
+		}
+		if list, ok := value.([]any); ok {
+			skipped[key] += len(list)
+		} else {
+			skipped[key]++
+		}
+	}
+	kinds := make([]string, 0, len(skipped))
+	for kind, count := range skipped {
+		if count > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		conversion.Skipped = append(conversion.Skipped, fmt.Sprintf("%d %s", skipped[kind], kind))
+	}
+
+	controlPlane := mapping("ref", scalar(cpRef), "name", scalar(name))
+	if len(services.Content) > 0 {
+		controlPlane.Content = append(controlPlane.Content, scalar("gateway_services"), services)
+	}
+	root := mapping("control_planes", sequence(controlPlane))
+	root.HeadComment = fmt.Sprintf("Converted from %s by kongctl convert deck", opts.Source)
+
+	config, err := render(root)
+	if err != nil {
+		return nil, err
+	}
+	conversion.Config = config
+	return conversion, nil
+}
+
+func convertDeckService(
+	service map[string]any, selectTags []string, refs refSet, conversion *DeckConversion, skipped map[string]int,
+) (*yaml.Node, error) {
+	fields := make(map[string]any, len(service))
+	for key, value := range service {
+		fields[key] = value
+	}
+	name, _ := fields["name"].(string)
+
+	// decK services may set url instead of its parts
+	if raw, ok := fields["url"].(string); ok {
+		delete(fields, "url")
+		if err := splitServiceURL(raw, fields); err != nil {
+			return nil, err
+		}
+	}
+	host, _ := fields["host"].(string)
+	if strings.TrimSpace(host) == "" {
+		return nil, fmt.Errorf("service %q has no host or url", name)
+	}
+	if len(selectTags) > 0 {
+		fields["tags"] = mergeTags(fields["tags"], selectTags)
+	}
+
+	moniker := name
+	if moniker == "" {
+		moniker = host
+	}
+	node := mapping("ref", scalar(refs.claim("service", moniker)))
+	for _, field := range deckServiceFields {
+		value, ok := fields[field]
+		if !ok || value == nil {
+			continue
+		}
+		var encoded yaml.Node
+		if err := encoded.Encode(value); err != nil {
+			return nil, fmt.Errorf("service %q field %s: %w", name, field, err)
+		}
+		node.Content = append(node.Content, scalar(field), &encoded)
+		delete(fields, field)
+	}
+
+	if routes, ok := fields["routes"].([]any); ok {
+		skipped["routes"] += len(routes)
+		for _, route := range routes {
+			if route, ok := route.(map[string]any); ok {
+				plugins, _ := route["plugins"].([]any)
+				skipped["plugins"] += len(plugins)
+			}
+		}
+	}
+	plugins, _ := fields["plugins"].([]any)
+	skipped["plugins"] += len(plugins)
+	delete(fields, "routes")
+	delete(fields, "plugins")
+
+	extra := make([]string, 0, len(fields))
+	for field := range fields {
+		if !deckIgnoredFields[field] {
+			extra = append(extra, field)
+		}
+	}
+	sort.Strings(extra)
+	for _, field := range extra {
+		conversion.Skipped = append(conversion.Skipped, fmt.Sprintf("service %q field %s", moniker, field))
+	}
+	return node, nil
+}
+
+// splitServiceURL sets the protocol, host, port and path of a service from its url,
+// the way Kong does
+func splitServiceURL(raw string, fields map[string]any) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid service url %q: %w", raw, err)
+	}
+	if u.Scheme == "" || u.Hostname() == "" {
+		return fmt.Errorf("invalid service url %q: scheme and host are required", raw)
+	}
+	fields["protocol"] = u.Scheme
+	fields["host"] = u.Hostname()
+	switch {
+	case u.Port() != "":
+		port, err := strconv.Atoi(u.Port())
+		if err != nil {
+			return fmt.Errorf("invalid service url %q: %w", raw, err)
+		}
+		fields["port"] = port
+	case u.Scheme == "https" || u.Scheme == "grpcs" || u.Scheme == "tls":
+		fields["port"] = 443
+	default:
+		fields["port"] = 80
+	}
+	if u.Path != "" {
+		fields["path"] = u.Path
+	}
+	return nil
+}
+
+// mergeTags adds the select tags decK sets on the entities it manages to the tags of
+// a service
+func mergeTags(tags any, selectTags []string) []string {
+	var merged []string
+	seen := map[string]bool{}
+	list, _ := tags.([]any)
+	for _, tag := range list {
+		if s, ok := tag.(string); ok && !seen[s] {
+			seen[s] = true
+			merged = append(merged, s)
+		}
+	}
+	for _, tag := range selectTags {
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// refSet hands out refs that are unique across the configuration
+type refSet map[string]bool
+
+// claim derives a ref from name, numbering it when an earlier resource holds it
+func (r refSet) claim(kind, name string) string {
+	base := Ref(name)
+	if len(base) < resources.MinRefLength {
+		base = Ref(kind + "-" + base)
+	}
+	ref := base
+	for n := 2; r[ref]; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		ref = strings.TrimRight(base[:min(len(base), resources.MaxRefLength-len(suffix))], "-_") + suffix
+	}
+	r[ref] = true
+	return ref
+}
@@ -0,0 +1,126 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const billingState = `_format_version: "3.0"
+_info:
+  select_tags: [billing]
+_konnect:
+  control_plane_name: Production
+services:
+  - name: billing-service
+    id: 7a8e1c3c-5b1d-4e1a-9a49-2b7cbd6b2f1c
+    url: https://billing.internal:8443/v1
+    retries: 3
+    tags: [team-billing]
+    client_certificate:
+      id: 0e2c1c8a-5a3b-4a7e-8d5a-2c1df1cd3f42
+    routes:
+      - name: billing
+        paths: [/billing]
+        plugins:
+          - name: rate-limiting
+    plugins:
+      - name: key-auth
+  - host: invoices.internal
+    port: 8080
+    protocol: http
+consumers:
+  - username: alice
+  - username: bob
+plugins:
+  - name: cors
+`
+
+func TestFromDeck(t *testing.T) {
+	conversion, err := FromDeck([]byte(billingState), DeckOptions{Source: "kong.yaml"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Converted from kong.yaml by kongctl convert deck
+control_planes:
+  - ref: production
+    name: Production
+    gateway_services:
+      - ref: billing-service
+        name: billing-service
+        protocol: https
+        host: billing.internal
+        port: 8443
+        path: /v1
+        retries: 3
+        tags:
+          - team-billing
+          - billing
+      - ref: invoices-internal
+        protocol: http
+        host: invoices.internal
+        port: 8080
+        tags:
+          - billing
+`, string(conversion.Config))
+	assert.Equal(t, []string{
+		`service "billing-service" field client_certificate`,
+		"2 consumers",
+		"3 plugins",
+		"1 routes",
+	}, conversion.Skipped)
+}
+
+// The converted configuration matches the schema and loads
+func TestFromDeck_Loads(t *testing.T) {
+	conversion, err := FromDeck([]byte(billingState), DeckOptions{Source: "kong.yaml", ControlPlane: "staging"})
+	require.NoError(t, err)
+
+	issues, err := loader.Schema().Check(conversion.Config)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	path := filepath.Join(t.TempDir(), "control-planes.yaml")
+	require.NoError(t, os.WriteFile(path, conversion.Config, 0o600))
+	rs, err := loader.New().LoadFromSources([]loader.Source{{Path: path, Type: loader.SourceTypeFile}}, false)
+	require.NoError(t, err)
+	require.Len(t, rs.ControlPlanes, 1)
+	assert.Equal(t, "staging", rs.ControlPlanes[0].Name)
+	require.Len(t, rs.GatewayServices, 2)
+	assert.Equal(t, "billing.internal", rs.GatewayServices[0].Service.Host)
+	assert.Equal(t, "staging", rs.GatewayServices[0].ControlPlane)
+}
+
+func TestFromDeck_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  string
+	}{
+		{
+			name:  "no control plane",
+			state: "services: []\n",
+			want:  "no _konnect.control_plane_name",
+		},
+		{
+			name:  "service without host",
+			state: "_konnect:\n  control_plane_name: cp\nservices:\n  - name: orders\n",
+			want:  `services[0]: service "orders" has no host or url`,
+		},
+		{
+			name:  "invalid url",
+			state: "_konnect:\n  control_plane_name: cp\nservices:\n  - name: orders\n    url: orders.internal\n",
+			want:  "scheme and host are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromDeck([]byte(tt.state), DeckOptions{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for the positions of nodes
+)
+
+// Issue is a place where a source does not match the schema. Path uses JSON names
+// with list indexes, such as "portals[0].pages[1].slug".
+type Issue struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+
+	// missing is the name of the required field reported missing
+	missing string
+}
+
+// String renders the issue as "line:column: path: message"
+func (i Issue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", i.Line, i.Column, i.Path, i.Message)
+}
+
+// Check returns the places where a YAML or JSON source does not match the schema,
+// ordered by position. Values of YAML tags such as !file or !ref are only known once
+// the tags are expanded, and values with ${var.name} references once variables are
+// replaced, so neither is checked. Null values are read like omitted fields. A source
+// that cannot be parsed returns the parse error.
+func (s *Schema) Check(content []byte) ([]Issue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	c := &checker{root: s}
+	issues := c.check(doc.Content[0], s, "")
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues, nil
+}
+
+type checker struct {
+	root *Schema
+}
+
+func issueAt(node *yaml.Node, path, format string, args ...any) Issue {
+	return Issue{Line: node.Line, Column: node.Column, Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+func (c *checker) resolve(s *Schema) *Schema {
+	for s.Ref != "" {
+		s = c.root.Defs[strings.TrimPrefix(s.Ref, defsPrefix)]
+	}
+	return s
+}
+
+func (c *checker) check(node *yaml.Node, s *Schema, path string) []Issue {
+	s = c.resolve(s)
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if isCustomTag(node.Tag) || node.Tag == "!!null" ||
+		(node.Kind == yaml.ScalarNode && strings.Contains(node.Value, "${var.")) {
+		return nil
+	}
+
+	if s.Type != "" && !matchesType(node, s.Type) {
+		return []Issue{issueAt(node, path, "must be %s, not %s", typeNames[s.Type], describe(node))}
+	}
+
+	var issues []Issue
+	var suggested map[string]bool
+	switch node.Kind {
+	case yaml.MappingNode:
+		issues, suggested = c.checkObject(node, s, path)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				issues = append(issues, c.check(item, s.Items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case yaml.ScalarNode:
+		issues = c.checkScalar(node, s, path)
+	}
+
+	if len(s.AnyOf) > 0 {
+		issues = append(issues, c.checkAnyOf(node, s.AnyOf, path)...)
+	}
+	if s.Not != nil && len(c.check(node, s.Not, path)) == 0 {
+		issues = append(issues, issueAt(node, path, "%s", describeConstraint(s, "is not allowed")))
+	}
+	if len(suggested) == 0 {
+		return issues
+	}
+
+	// Misspelled fields are not reported again as missing
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.Path != path || !suggested[issue.missing] {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// checkAnyOf accepts a node matching one of the schemas, and otherwise reports the
+// issues of the first schema it comes closest to
+func (c *checker) checkAnyOf(node *yaml.Node, schemas []*Schema, path string) []Issue {
+	var closest []Issue
+	for i, alternative := range schemas {
+		issues := c.check(node, alternative, path)
+		if len(issues) == 0 {
+			return nil
+		}
+		if i == 0 || len(issues) < len(closest) {
+			closest = issues
+		}
+	}
+	return closest
+}
+
+// checkObject returns the issues of a mapping, and the fields suggested for its
+// unknown fields
+func (c *checker) checkObject(node *yaml.Node, s *Schema, path string) ([]Issue, map[string]bool) {
+	var issues []Issue
+	present := make(map[string]bool, len(node.Content)/2)
+	suggested := make(map[string]bool)
+	merged := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		// Fields merged from an anchor are checked where the anchor is defined
+		if key.Tag == "!!merge" {
+			merged = true
+			continue
+		}
+		name := key.Value
+		fieldPath := joinPath(path, name)
+		if value.Tag != "!!null" {
+			present[name] = true
+		}
+		if s.PropertyNames != nil {
+			issues = append(issues, c.check(key, s.PropertyNames, fieldPath)...)
+		}
+
+		if property, ok := s.Properties[name]; ok {
+			issues = append(issues, c.check(value, property, fieldPath)...)
+			continue
+		}
+		switch additional := s.Additional.(type) {
+		case *Schema:
+			issues = append(issues, c.check(value, additional, fieldPath)...)
+		case bool:
+			if !additional && s.Properties != nil {
+				suggestion := suggest(name, s.Properties)
+				suggested[suggestion] = true
+				issues = append(issues, issueAt(key, fieldPath, "unknown field%s", hint(suggestion)))
+			}
+		}
+	}
+
+	if !merged {
+		for _, name := range s.Required {
+			if !present[name] {
+				issue := issueAt(node, path, "missing required field %q", name)
+				issue.missing = name
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, suggested
+}
+
+func (c *checker) checkScalar(node *yaml.Node, s *Schema, path string) []Issue {
+	value := node.Value
+	length := utf8.RuneCountInString(value)
+	if s.MinLen != nil && length < *s.MinLen {
+		return []Issue{issueAt(node, path, "%s", describeConstraint(s,
+			fmt.Sprintf("must be at least %d characters long", *s.MinLen)))}
+	}
+	if s.MaxLen != nil && length > *s.MaxLen {
+		return []Issue{issueAt(node, path, "%s", describeConstraint(s,
+			fmt.Sprintf("must be at most %d characters long", *s.MaxLen)))}
+	}
+	if s.Pattern != "" && !compiled(s.Pattern).MatchString(value) {
+		return []Issue{issueAt(node, path, "%s", describeConstraint(s,
+			fmt.Sprintf("%q does not match the pattern %s", value, s.Pattern)))}
+	}
+	if len(s.Enum) > 0 {
+		for _, allowed := range s.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return []Issue{issueAt(node, path, "must be one of %s", strings.Join(s.Enum, ", "))}
+	}
+	return nil
+}
+
+// describeConstraint favors the description of a schema, written for its constraints,
+// over the generic message
+func describeConstraint(s *Schema, generic string) string {
+	if s.Description != "" {
+		return s.Description
+	}
+	return generic
+}
+
+var patterns sync.Map
+
+func compiled(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
+
+// isCustomTag reports whether a node carries a kongctl tag such as !file, rather than
+// a standard YAML tag such as !!str
+func isCustomTag(tag string) bool {
+	return strings.HasPrefix(tag, "!") && !strings.HasPrefix(tag, "!!")
+}
+
+var typeNames = map[string]string{
+	"string":  "a string",
+	"integer": "an integer",
+	"number":  "a number",
+	"boolean": "a boolean",
+	"object":  "an object",
+	"array":   "a list",
+}
+
+func matchesType(node *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	}
+	if node.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch typ {
+	case "string":
+		// Dates are read as strings
+		return node.Tag == "!!str" || node.Tag == "!!timestamp" || node.Tag == "!!binary"
+	case "integer":
+		return node.Tag == "!!int"
+	case "number":
+		return node.Tag == "!!int" || node.Tag == "!!float"
+	case "boolean":
+		return node.Tag == "!!bool"
+	}
+	return true
+}
+
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.Tag {
+	case "!!int":
+		return fmt.Sprintf("the integer %s", node.Value)
+	case "!!float":
+		return fmt.Sprintf("the number %s", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("the boolean %s", node.Value)
+	}
+	return fmt.Sprintf("the string %q", node.Value)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// suggest returns the known field closest to an unknown one, when one is at most
+// two edits away
+func suggest(name string, properties map[string]*Schema) string {
+	best, distance := "", 3
+	for candidate := range properties {
+		if d := editDistance(name, candidate); d < distance || (d == distance && candidate < best) {
+			best, distance = candidate, d
+		}
+	}
+	return best
+}
+
+func hint(suggestion string) string {
+	if suggestion == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", suggestion)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
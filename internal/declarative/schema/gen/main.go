@@ -0,0 +1,201 @@
+// Command gen writes the descriptions and enum values of the SDK types used by
+// declarative resources, read from the SDK sources, for the schema to include.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+const (
+	sdkModule      = "github.com/Kong/sdk-konnect-go"
+	componentsPath = sdkModule + "/models/components"
+	output         = "sdk_docs_gen.go"
+)
+
+func main() {
+	dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", sdkModule).Output()
+	if err != nil {
+		log.Fatalf("locating %s: %v", sdkModule, err)
+	}
+
+	used := make(map[string]bool)
+	collect(reflect.TypeFor[resources.ResourceSet](), used, make(map[reflect.Type]bool))
+
+	docs, err := parseComponents(filepath.Join(strings.TrimSpace(string(dir)), "models", "components"), used)
+	if err != nil {
+		log.Fatal(err)
+	}
+	source, err := docs.render()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, source, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// collect records the names of the SDK component types reachable from t
+func collect(t reflect.Type, used map[string]bool, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array ||
+		t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	if t.PkgPath() == componentsPath {
+		used[t.Name()] = true
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		collect(t.Field(i).Type, used, seen)
+	}
+}
+
+type sdkDocs struct {
+	types  map[string]string
+	fields map[string]string
+	enums  map[string][]string
+}
+
+func parseComponents(dir string, used map[string]bool) (*sdkDocs, error) {
+	docs := &sdkDocs{types: map[string]string{}, fields: map[string]string{}, enums: map[string][]string{}}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok {
+				docs.add(gen, used)
+			}
+		}
+	}
+	return docs, nil
+}
+
+func (d *sdkDocs) add(gen *ast.GenDecl, used map[string]bool) {
+	for _, spec := range gen.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			if !used[spec.Name.Name] {
+				continue
+			}
+			if doc := text(gen.Doc, spec.Name.Name); doc != "" {
+				d.types[spec.Name.Name] = doc
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				d.addFields(spec.Name.Name, st)
+			}
+		case *ast.ValueSpec:
+			// Enum values are typed string constants
+			ident, ok := spec.Type.(*ast.Ident)
+			if gen.Tok != token.CONST || !ok || !used[ident.Name] {
+				continue
+			}
+			for _, value := range spec.Values {
+				if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if v, err := strconv.Unquote(lit.Value); err == nil {
+						d.enums[ident.Name] = append(d.enums[ident.Name], v)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (d *sdkDocs) addFields(typeName string, st *ast.StructType) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if doc := text(field.Doc, ""); doc != "" {
+			d.fields[typeName+"."+name] = doc
+		}
+	}
+}
+
+// text joins the lines of a comment, without the "Name - " prefix the SDK starts
+// type comments with
+func text(group *ast.CommentGroup, name string) string {
+	if group == nil {
+		return ""
+	}
+	doc := strings.Join(strings.Fields(group.Text()), " ")
+	if name != "" {
+		doc = strings.TrimPrefix(doc, name+" - ")
+		if strings.HasPrefix(doc, name+" ") {
+			return ""
+		}
+	}
+	return doc
+}
+
+func (d *sdkDocs) render() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by go run ./gen; DO NOT EDIT.\n\npackage schema\n\n")
+	writeMap(&buf, "sdkTypeDocs", "the descriptions of SDK types, by type name", d.types)
+	writeMap(&buf, "sdkFieldDocs", `the descriptions of SDK fields, by "Type.field"`, d.fields)
+
+	fmt.Fprintf(&buf, "// sdkEnums are the values of SDK enum types, by type name\nvar sdkEnums = map[string][]string{\n")
+	for _, name := range sortedKeys(d.enums) {
+		fmt.Fprintf(&buf, "\t%q: {", name)
+		for i, value := range d.enums[name] {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "%q", value)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func writeMap(buf *bytes.Buffer, name, doc string, entries map[string]string) {
+	fmt.Fprintf(buf, "// %s are %s\nvar %s = map[string]string{\n", name, doc, name)
+	for _, key := range sortedKeys(entries) {
+		fmt.Fprintf(buf, "\t%q: %q,\n", key, entries[key])
+	}
+	buf.WriteString("}\n\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,325 @@
+// Package schema describes the declarative configuration format as a JSON Schema,
+// derived from the resource types, and checks YAML and JSON sources against it with
+// the line and column of every problem.
+package schema
+
+//go:generate go run ./gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of the generated schema
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the subset of JSON Schema the declarative format is described with
+type Schema struct {
+	Draft       string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	// Version is the kongctl version the schema describes the format of
+	Version string `json:"x-kongctl-version,omitempty"`
+	// Tags are the YAML tags of the format, which JSON Schema cannot describe
+	Tags []Tag `json:"x-kongctl-tags,omitempty"`
+
+	Type    string   `json:"type,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	MinLen  *int     `json:"minLength,omitempty"`
+	MaxLen  *int     `json:"maxLength,omitempty"`
+	Enum    []string `json:"enum,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// Additional is false, or the schema of the values of a map
+	Additional    any     `json:"additionalProperties,omitempty"`
+	PropertyNames *Schema `json:"propertyNames,omitempty"`
+	Items         *Schema `json:"items,omitempty"`
+
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Tag is a YAML tag expanded when a file is loaded, such as !file
+type Tag struct {
+	Name string `json:"name"`
+	// Nodes are the YAML node kinds the tag applies to: scalar, mapping or sequence
+	Nodes       []string `json:"nodes"`
+	Description string   `json:"description"`
+}
+
+const (
+	defsPrefix = "#/$defs/"
+	// externalField marks resources that exist in Konnect and are only referenced
+	externalField = "_external"
+)
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	rawType  = reflect.TypeFor[json.RawMessage]()
+)
+
+// Rule adds constraints to the schema of a field, keyed by the JSON name of the
+// field. A rule keyed "Type.field" only applies to the field of that Go type, and a
+// rule keyed "Type" applies to the schema of the Go type itself.
+type Rule func(field *Schema)
+
+// Allow returns a rule adding properties to an object, or to every object of an
+// anyOf, for types whose custom JSON decoding accepts fields they do not declare
+func Allow(properties map[string]*Schema) Rule {
+	return func(s *Schema) {
+		objects := []*Schema{s}
+		if s.Properties == nil {
+			objects = s.AnyOf
+		}
+		for _, object := range objects {
+			for name, property := range properties {
+				object.Properties[name] = property
+			}
+		}
+	}
+}
+
+// Generate returns the schema of a document decoded into root. Struct types are
+// described once under $defs, so recursive resources such as nested pages are
+// supported. Fields are read like encoding/json reads them: embedded structs are
+// flattened and SDK unions become an anyOf of their members. SDK types and fields
+// are described with their SDK documentation, and SDK enums list their values.
+func Generate(root reflect.Type, title string, rules map[string]Rule) *Schema {
+	g := &generator{defs: make(map[string]*Schema), names: make(map[reflect.Type]string), rules: rules}
+	s := g.schemaFor(root)
+	if s.Ref != "" {
+		s = g.defs[strings.TrimPrefix(s.Ref, defsPrefix)]
+		delete(g.defs, g.names[deref(root)])
+	}
+	s.Draft = Draft
+	s.Title = title
+	s.Defs = g.defs
+	return s
+}
+
+// JSON renders the schema indented, with stable key order
+func (s *Schema) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+	rules map[string]Rule
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	t = deref(t)
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string"}
+	case t == rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if t.PkgPath() == sdkComponentsPkgPath {
+			return &Schema{Type: "string", Enum: sdkEnums[t.Name()], Description: sdkTypeDocs[t.Name()]}
+		}
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", Additional: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structRef describes a struct under $defs and returns a reference to it
+func (g *generator) structRef(t reflect.Type) *Schema {
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: defsPrefix + name}
+	}
+	name := t.Name()
+	if _, taken := g.defs[name]; taken || name == "" {
+		name = fmt.Sprintf("%s.%s", t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:], t.Name())
+	}
+	g.names[t] = name
+	// Reserve the name before describing the fields, which may refer back to t
+	g.defs[name] = &Schema{}
+	*g.defs[name] = *g.structSchema(t)
+	if t.PkgPath() == sdkComponentsPkgPath {
+		g.defs[name].Description = sdkTypeDocs[t.Name()]
+	}
+	if rule, ok := g.rules[t.Name()]; ok {
+		rule(g.defs[name])
+	}
+	return &Schema{Ref: defsPrefix + name}
+}
+
+// field is a JSON field of a struct, with the Go type declaring it for the rules
+type field struct {
+	name     string
+	owner    reflect.Type
+	typ      reflect.Type
+	required bool
+	// constant is the only value of an SDK discriminator field
+	constant string
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	if members := unionMembers(t); len(members) > 0 {
+		s := &Schema{}
+		for _, member := range members {
+			s.AnyOf = append(s.AnyOf, g.schemaFor(member))
+		}
+		return s
+	}
+
+	fields, unions := g.fields(t)
+	object := g.object(fields)
+	if len(unions) == 0 {
+		return object
+	}
+
+	// An embedded union is flattened like its member, so each member becomes a
+	// branch holding the fields of the struct and of the member
+	s := &Schema{}
+	for _, member := range unions[0] {
+		memberFields, _ := g.fields(member)
+		s.AnyOf = append(s.AnyOf, g.object(append(append([]field(nil), fields...), memberFields...)))
+	}
+	return s
+}
+
+func (g *generator) object(fields []field) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema, len(fields)), Additional: false}
+	for _, f := range fields {
+		fs := g.schemaFor(f.typ)
+		if f.constant != "" {
+			fs = &Schema{Type: "string", Enum: []string{f.constant}}
+		}
+		if doc, ok := sdkFieldDocs[f.owner.Name()+"."+f.name]; ok && f.owner.PkgPath() == sdkComponentsPkgPath {
+			copied := *fs
+			copied.Description = doc
+			fs = &copied
+		}
+		for _, key := range []string{f.owner.Name() + "." + f.name, f.name} {
+			if rule, ok := g.rules[key]; ok {
+				fs = withRule(fs, rule)
+				break
+			}
+		}
+		s.Properties[f.name] = fs
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	sort.Strings(s.Required)
+	// External resources are only selected in Konnect, without their required fields.
+	// Ties report the first alternative, so the required fields come first.
+	if _, external := s.Properties[externalField]; external && len(s.Required) > 0 {
+		s.AnyOf = []*Schema{{Required: s.Required}, {Required: []string{externalField}}}
+		s.Required = nil
+	}
+	return s
+}
+
+// withRule applies a rule to a copy of a field schema. Definitions are shared, so
+// fields of struct types are left as they are.
+func withRule(s *Schema, rule Rule) *Schema {
+	if s.Ref != "" {
+		return s
+	}
+	copied := *s
+	rule(&copied)
+	return &copied
+}
+
+// fields lists the JSON fields of a struct like encoding/json, flattening embedded
+// structs, and returns the members of the unions embedded in it separately
+func (g *generator) fields(t reflect.Type) ([]field, [][]reflect.Type) {
+	var fields []field
+	var unions [][]reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		ft := deref(f.Type)
+		// Resources decoding a struct from their own fields mark it schema:"inline"
+		inline := f.Tag.Get("schema") == "inline" && ft.Kind() == reflect.Struct
+		if tag == "-" && !inline {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if inline || f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			if members := unionMembers(ft); len(members) > 0 {
+				unions = append(unions, members)
+				continue
+			}
+			embedded, nested := g.fields(ft)
+			fields = append(fields, embedded...)
+			unions = append(unions, nested...)
+			continue
+		}
+		// The SDK decodes discriminators into unexported fields holding one value
+		if constant := f.Tag.Get("const"); constant != "" && name != "" {
+			fields = append(fields, field{name: name, owner: t, typ: f.Type, constant: constant})
+			continue
+		}
+		if !f.IsExported() || f.Tag.Get("union") != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{
+			name:  name,
+			owner: t,
+			typ:   f.Type,
+			// The SDK marks optional fields with omitempty, so a string without it is
+			// required, as the loader checks for the SDK structs resources embed
+			required: f.Type.Kind() == reflect.String && !strings.Contains(options, "omitempty") &&
+				t.PkgPath() == sdkComponentsPkgPath,
+		})
+	}
+	return fields, unions
+}
+
+// sdkComponentsPkgPath identifies the SDK request structs embedded in resources
+const sdkComponentsPkgPath = "github.com/Kong/sdk-konnect-go/models/components"
+
+// unionMembers returns the member types of an SDK union struct
+func unionMembers(t reflect.Type) []reflect.Type {
+	var members []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("union") == "member" {
+			members = append(members, deref(t.Field(i).Type))
+		}
+	}
+	return members
+}
@@ -0,0 +1,236 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPage struct {
+	Ref      string     `json:"ref"`
+	Slug     string     `json:"slug,omitempty"`
+	Children []testPage `json:"children,omitempty"`
+}
+
+type testPortal struct {
+	kkComps.CreatePortal `json:",inline"`
+
+	Ref      string     `json:"ref"`
+	External *struct{}  `json:"_external,omitempty"`
+	Pages    []testPage `json:"pages,omitempty"`
+	internal string
+}
+
+type testConfig struct {
+	Portals []testPortal `json:"portals,omitempty"`
+}
+
+func checkWith(t *testing.T, rules map[string]Rule, content string) []Issue {
+	t.Helper()
+	issues, err := Generate(reflect.TypeFor[testConfig](), "test", rules).Check([]byte(content))
+	require.NoError(t, err)
+	return issues
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(reflect.TypeFor[testConfig](), "test", nil)
+
+	assert.Equal(t, Draft, s.Draft)
+	assert.Equal(t, "test", s.Title)
+	assert.NotContains(t, s.Defs, "testConfig")
+
+	portal := s.Defs["testPortal"]
+	require.NotNil(t, portal)
+	assert.Equal(t, false, portal.Additional)
+	// Embedded SDK fields are flattened, unexported fields are left out
+	assert.Contains(t, portal.Properties, "display_name")
+	assert.NotContains(t, portal.Properties, "internal")
+	// External portals are only selected, so name is only required otherwise
+	assert.Empty(t, portal.Required)
+	assert.Equal(t, []*Schema{{Required: []string{"name"}}, {Required: []string{"_external"}}}, portal.AnyOf)
+
+	// SDK fields are described with their SDK documentation, and SDK enums list their values
+	assert.Contains(t, portal.Properties["name"].Description, "The name of the portal")
+	assert.Equal(t, []string{"public", "private"}, portal.Properties["default_api_visibility"].Enum)
+
+	// Recursive types refer to their definition
+	assert.Equal(t, "#/$defs/testPage", s.Defs["testPage"].Properties["children"].Items.Ref)
+}
+
+func TestGenerateInline(t *testing.T) {
+	type testService struct {
+		Service *kkComps.Service `json:"-" schema:"inline"`
+		Ref     string           `json:"ref"`
+		Skipped string           `json:"-"`
+	}
+	s := Generate(reflect.TypeFor[testService](), "test", nil)
+
+	// Fields decoded by hand from the fields of the resource are described inline
+	assert.Contains(t, s.Properties, "ref")
+	assert.Contains(t, s.Properties, "host")
+	assert.NotContains(t, s.Properties, "Skipped")
+	assert.Equal(t, []string{"host"}, s.Required)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Issue
+	}{
+		{
+			name: "valid",
+			content: `
+portals:
+  - ref: dev
+    name: Developer Portal
+    pages:
+      - ref: home
+        children:
+          - ref: guide
+`,
+		},
+		{
+			name: "unknown field",
+			content: `
+portals:
+  - ref: dev
+    name: Developer Portal
+    descripton: typo
+`,
+			want: []Issue{{Line: 5, Column: 5, Path: "portals[0].descripton",
+				Message: `unknown field, did you mean "description"?`}},
+		},
+		{
+			name: "misspelled required field is reported once",
+			content: `
+portals:
+  - ref: dev
+    nme: Developer Portal
+`,
+			want: []Issue{{Line: 4, Column: 5, Path: "portals[0].nme", Message: `unknown field, did you mean "name"?`}},
+		},
+		{
+			name: "missing required field",
+			content: `
+portals:
+  - ref: dev
+`,
+			want: []Issue{{
+				Line: 3, Column: 5, Path: "portals[0]", Message: `missing required field "name"`, missing: "name",
+			}},
+		},
+		{
+			name: "external resources need no required fields",
+			content: `
+portals:
+  - ref: dev
+    _external: {}
+`,
+		},
+		{
+			name: "wrong types",
+			content: `
+portals:
+  - ref: dev
+    name: [a, b]
+    rbac_enabled: "yes"
+    pages: {}
+`,
+			want: []Issue{
+				{Line: 4, Column: 11, Path: "portals[0].name", Message: "must be a string, not a list"},
+				{Line: 5, Column: 19, Path: "portals[0].rbac_enabled", Message: `must be a boolean, not the string "yes"`},
+				{Line: 6, Column: 12, Path: "portals[0].pages", Message: "must be a list, not an object"},
+			},
+		},
+		{
+			name: "tags, nulls and merge keys",
+			content: `
+base: &base
+  name: Developer Portal
+portals:
+  - ref: dev
+    name: !env PORTAL_NAME
+    description: null
+    pages: !file pages.yaml
+  - <<: *base
+    ref: other
+`,
+			want: []Issue{{Line: 2, Column: 1, Path: "base", Message: "unknown field"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkWith(t, nil, tt.content))
+		})
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	rules := map[string]Rule{
+		"ref": func(s *Schema) {
+			s.Pattern = `^[a-z]+$`
+		},
+		"testPage.slug": func(s *Schema) {
+			limit := 3
+			s.MaxLen = &limit
+			s.Description = "slugs are at most 3 characters"
+		},
+		"testPage": Allow(map[string]*Schema{"title": {Type: "string"}}),
+	}
+
+	issues := checkWith(t, rules, `
+portals:
+  - ref: dev-1
+    name: Developer Portal
+    pages:
+      - ref: home
+        slug: getting-started
+        title: Getting started
+`)
+	assert.Equal(t, []Issue{
+		{Line: 3, Column: 10, Path: "portals[0].ref", Message: `"dev-1" does not match the pattern ^[a-z]+$`},
+		{Line: 7, Column: 15, Path: "portals[0].pages[0].slug", Message: "slugs are at most 3 characters"},
+	}, issues)
+}
+
+func TestCheckUnion(t *testing.T) {
+	type strategies struct {
+		Strategies []kkComps.CreateAppAuthStrategyRequest `json:"strategies"`
+	}
+	s := Generate(reflect.TypeFor[strategies](), "test", nil)
+
+	issues, err := s.Check([]byte(`
+strategies:
+  - name: key-auth
+    display_name: Key Auth
+    strategy_type: key_auth
+    configs:
+      key-auth:
+        key_names: [apikey]
+`))
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	// The closest member is reported
+	issues, err = s.Check([]byte(`
+strategies:
+  - name: key-auth
+    display_name: Key Auth
+    strategy_type: key_auth
+    configs:
+      key_auth: {}
+`))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "strategies[0].configs.key_auth", issues[0].Path)
+}
+
+func TestCheckParseError(t *testing.T) {
+	_, err := Generate(reflect.TypeFor[testConfig](), "test", nil).Check([]byte("portals: [\n"))
+	assert.Error(t, err)
+}
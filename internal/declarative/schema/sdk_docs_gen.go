@@ -0,0 +1,280 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package schema
+
+// sdkTypeDocs are the descriptions of SDK types, by type name
+var sdkTypeDocs = map[string]string{
+	"APIDocumentStatus":                          "If `status=published` the document will be visible in your live portal",
+	"APIImplementation":                          "An entity that implements an API",
+	"APIImplementationService":                   "A Gateway service that implements an API",
+	"APIPublication":                             "An API publication in a portal",
+	"APIPublicationVisibility":                   "The visibility of the API in the portal. Public API publications do not require authentication to view and retrieve information about them. Private API publications require authentication to retrieve information about them.",
+	"AppAuthStrategyConfigKeyAuth":               "The most basic mode to configure an Application Auth Strategy for an API Product Version. Using this mode will allow developers to generate API keys that will authenticate their application requests. Once authenticated, an application will be granted access to any Product Version it is registered for that is configured for Key Auth.",
+	"AppAuthStrategyConfigOpenIDConnect":         "A more advanced mode to configure an API Product Version’s Application Auth Strategy. Using this mode will allow developers to use API credentials issued from an external IdP that will authenticate their application requests. Once authenticated, an application will be granted access to any Product Version it is registered for that is configured for the same Auth Strategy. An OIDC strategy may be used in conjunction with a DCR provider to automatically create the IdP application.",
+	"AppAuthStrategyKeyAuthRequest":              "Request for creating a Key Auth Application Auth Strategy",
+	"AppAuthStrategyKeyAuthRequestConfigs":       "JSON-B object containing the configuration for the Key Auth strategy",
+	"AppAuthStrategyOpenIDConnectRequest":        "Payload for creating an OIDC Application Auth Strategy",
+	"AppAuthStrategyOpenIDConnectRequestConfigs": "JSON-B object containing the configuration for the OIDC strategy",
+	"AuthType":                                       "The auth type value of the cluster associated with the Runtime Group.",
+	"BackendClusterAuthenticationAnonymous":          "Anonymous authentication scheme for the backend cluster.",
+	"BackendClusterAuthenticationSaslPlain":          "SASL/PLAIN authentication scheme for the backend cluster.",
+	"BackendClusterAuthenticationSaslScram":          "SASL/SCRAM authentication scheme for the backend cluster.",
+	"BackendClusterAuthenticationSaslScramAlgorithm": "The algorithm used for SASL/SCRAM authentication.",
+	"BackendClusterReferenceModify":                  "The backend cluster associated with the virtual cluster. Either `id` or `name` must be provided. Following changes to the backend cluster name won't affect the reference, as the system will create the entities relationship by `id`.",
+	"ClientCertificate":                              "Certificate to be used as client certificate while TLS handshaking to the upstream server.",
+	"Conflict":                                       "How to inform the user about conflicts where multiple backend topics would map to the same virtual topic name. * warn - log in the Event Gateway logs. Additionally, it sets knep_namespace_topic_conflict to 1. * ignore - do not do anything. It does not cause knep_namespace_topic_conflict metric to be set to 1.",
+	"CreateAppAuthStrategyRequest":                   "Request body for creating an Application Auth Strategy",
+	"CreateBackendClusterRequest":                    "The request schema for creating a backend cluster.",
+	"CreateControlPlaneRequest":                      "The request schema for the create control plane request.",
+	"CreateControlPlaneRequestClusterType":           "The ClusterType value of the cluster associated with the Control Plane.",
+	"CreateGatewayRequest":                           "The request schema for the create gateway request.",
+	"CreatePortal":                                   "Create a portal.",
+	"CreatePortalCustomDomainRequest":                "Create a portal custom domain.",
+	"CreatePortalPageRequest":                        "Create a page in a portal.",
+	"CreateTeam":                                     "The request schema for the create team request. If you pass the same `name` and `description` of an existing team in the request, a team with the same `name` and `description` will be created. The two teams will have different `team_id` values to differentiate them.",
+	"CreateVirtualClusterRequest":                    "The request schema for creating a virtual cluster.",
+	"DefaultAPIVisibility":                           "The default visibility of APIs in the portal. If set to `public`, newly published APIs are visible to unauthenticated developers. If set to `private`, newly published APIs are hidden from unauthenticated developers.",
+	"DefaultPageVisibility":                          "The default visibility of pages in the portal. If set to `public`, newly created pages are visible to unauthenticated developers. If set to `private`, newly created pages are hidden from unauthenticated developers.",
+	"EmailTemplateName":                              "Short name email template name.",
+	"Mode":                                           "* hide_prefix - the configured prefix is hidden from clients for topics and IDs when reading. Created resources are written with the prefix on the backend cluster. * enforce_prefix - the configured prefix remains visible to clients. Created resources must include the prefix or the request will fail.",
+	"PageVisibilityStatus":                           "Whether a page is publicly accessible to non-authenticated users. If not provided, the default_page_visibility value of the portal will be used.",
+	"PortalAuthenticationSettingsUpdateRequest":      "Properties to update a portal's developer auth settings.",
+	"PortalAuthenticationSettingsUpdateRequestPortalClaimMappings": "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalCreateTeamRequest":                                      "Details about a team to create.",
+	"PortalCustomization":                                          "The custom settings of this portal",
+	"PortalMenuItemVisibility":                                     "Whether a menu item is public or private. Private menu items are only accessible to authenticated users.",
+	"PostPortalEmailConfig":                                        "Create a portal email configuration.",
+	"Protocol":                                                     "The protocol used to communicate with the upstream.",
+	"ProxyURL":                                                     "Proxy URL associated with reaching the data-planes connected to a control-plane.",
+	"PublishedStatus":                                              "Whether the resource is visible on a given portal. Defaults to unpublished.",
+	"ServiceReference":                                             "A gateway service that implements an API",
+	"SnippetVisibilityStatus":                                      "Whether a snippet is publicly accessible to non-authenticated users. If not provided, the default_page_visibility value of the portal will be used.",
+	"TLSSans":                                                      "Additional Subject Alternative Names that can be matched on Upstream server's TLS certificate (in addition to `host`).",
+	"UpdatePortalAuditLogWebhook":                                  "The request schema to modify an portal audit log webhook.",
+	"VirtualClusterACLMode":                                        "Configures whether or not ACL policies are enforced on the gateway. - `enforce_on_gateway` means the gateway enforces its own ACL policies for this virtual cluster and does not forward ACL-related commands to the backend cluster. Note that if there are no ACL policies configured, all access is denied. - `passthrough` tells the gateway to forward all ACL-related commands.",
+	"VirtualClusterAuthenticationClaimsMapping":                    "Maps JWT claims in the case when sub and scope are presented as different claims in your JWT token.",
+	"VirtualClusterAuthenticationJWKS":                             "JSON Web Key Set configuration for verifying token signatures.",
+	"VirtualClusterAuthenticationOauthBearer":                      "Oauth Bearer authentication scheme for the virtual cluster.",
+	"VirtualClusterAuthenticationOauthBearerMediation":             "Methods to mediate authentication: * passthrough - pass authentication from the client through proxy to the backend cluster without any kind of validation * validate_forward - pass authentication from the client through proxy to the backend cluster. Proxy does the validation before forwarding it to the client. * terminate - terminate authentication at the proxy level and originate authentication to the backend cluster using the configuration defined at BackendCluster's authentication. SASL auth is not originated if authentication on the backend_cluster is not configured.",
+	"VirtualClusterAuthenticationPrincipal":                        "A principal for authentication containing username and password.",
+	"VirtualClusterAuthenticationSaslPlain":                        "SASL/PLAIN authentication scheme for the virtual cluster containing principals with username and password.",
+	"VirtualClusterAuthenticationSaslPlainMediation":               "The mediation type for SASL/PLAIN authentication.",
+	"VirtualClusterAuthenticationSaslScram":                        "SASL/SCRAM authentication scheme for the virtual cluster.",
+	"VirtualClusterAuthenticationSaslScramAlgorithm":               "The algorithm used for SASL/SCRAM authentication.",
+	"VirtualClusterAuthenticationValidate":                         "Validation rules.",
+	"VirtualClusterNamespace":                                      "Namespace allows to implement multitenancy using a single backend cluster. It allows to either hide or enforce a static prefix on resources (topics, consumer group IDs, transaction IDs).",
+	"VirtualClusterNamespaceTopicSelectorExactListConflict":        "How to inform the user about conflicts where multiple backend topics would map to the same virtual topic name. * warn - log in the Event Gateway logs. Additionally, it sets knep_namespace_topic_conflict to 1. * ignore - do not do anything. It does not cause knep_namespace_topic_conflict metric to be set to 1.",
+}
+
+// sdkFieldDocs are the descriptions of SDK fields, by "Type.field"
+var sdkFieldDocs = map[string]string{
+	"APIPublication.auth_strategy_ids":                                          "The auth strategy the API enforces for applications in the portal. Omitting this property means the portal's default application auth strategy will be used. Setting to null means the API will not require application authentication. DCR support for application registration is currently in development.",
+	"APIPublication.auto_approve_registrations":                                 "Whether the application registration auto approval on this portal for the api is enabled. If set to false, fallbacks on portal's auto_approve_applications value.",
+	"APIPublication.visibility":                                                 "The visibility of the API in the portal. Public API publications do not require authentication to view and retrieve information about them. Private API publications require authentication to retrieve information about them.",
+	"AppAuthStrategyConfigKeyAuth.key_names":                                    "The names of the headers containing the API key. You can specify multiple header names.",
+	"AppAuthStrategyKeyAuthRequest.configs":                                     "JSON-B object containing the configuration for the Key Auth strategy",
+	"AppAuthStrategyKeyAuthRequest.display_name":                                "The display name of the Auth strategy. This is used to identify the Auth strategy in the Portal UI.",
+	"AppAuthStrategyKeyAuthRequest.labels":                                      "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"AppAuthStrategyKeyAuthRequest.name":                                        "The name of the auth strategy. This is used to identify the auth strategy in the Konnect UI.",
+	"AppAuthStrategyKeyAuthRequestConfigs.key-auth":                             "The most basic mode to configure an Application Auth Strategy for an API Product Version. Using this mode will allow developers to generate API keys that will authenticate their application requests. Once authenticated, an application will be granted access to any Product Version it is registered for that is configured for Key Auth.",
+	"AppAuthStrategyOpenIDConnectRequest.configs":                               "JSON-B object containing the configuration for the OIDC strategy",
+	"AppAuthStrategyOpenIDConnectRequest.display_name":                          "The display name of the Auth strategy. This is used to identify the Auth strategy in the Portal UI.",
+	"AppAuthStrategyOpenIDConnectRequest.labels":                                "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"AppAuthStrategyOpenIDConnectRequest.name":                                  "The name of the auth strategy. This is used to identify the auth strategy in the Konnect UI.",
+	"AppAuthStrategyOpenIDConnectRequestConfigs.openid-connect":                 "A more advanced mode to configure an API Product Version’s Application Auth Strategy. Using this mode will allow developers to use API credentials issued from an external IdP that will authenticate their application requests. Once authenticated, an application will be granted access to any Product Version it is registered for that is configured for the same Auth Strategy. An OIDC strategy may be used in conjunction with a DCR provider to automatically create the IdP application.",
+	"BackendClusterAuthenticationAnonymous.type":                                "The type of authentication scheme.",
+	"BackendClusterAuthenticationSaslPlain.password":                            "A sensitive value containing the secret or a reference to a secret as a template string expression. If the value is provided as plain text, it is encrypted at rest and omitted from API responses. If provided as an expression, the expression itself is stored and returned by the API.",
+	"BackendClusterAuthenticationSaslPlain.username":                            "A literal value or a reference to an existing secret as a template string expression. The value is stored and returned by the API as-is, not treated as sensitive information.",
+	"BackendClusterAuthenticationSaslScram.algorithm":                           "The algorithm used for SASL/SCRAM authentication.",
+	"BackendClusterAuthenticationSaslScram.password":                            "A sensitive value containing the secret or a reference to a secret as a template string expression. If the value is provided as plain text, it is encrypted at rest and omitted from API responses. If provided as an expression, the expression itself is stored and returned by the API.",
+	"BackendClusterAuthenticationSaslScram.username":                            "A literal value or a reference to an existing secret as a template string expression. The value is stored and returned by the API as-is, not treated as sensitive information.",
+	"BackendClusterReferenceByID.id":                                            "The unique identifier of the backend cluster.",
+	"BackendClusterReferenceByName.name":                                        "The unique name of the backend cluster.",
+	"BackendClusterTLS.ca_bundle":                                               "A literal value or a reference to an existing secret as a template string expression. The value is stored and returned by the API as-is, not treated as sensitive information.",
+	"BackendClusterTLS.enabled":                                                 "If true, TLS is enabled for connections to this backend cluster. If false, TLS is explicitly disabled.",
+	"BackendClusterTLS.insecure_skip_verify":                                    "If true, skip certificate verification. It's not secure to use for production.",
+	"BackendClusterTLS.tls_versions":                                            "List of supported TLS versions.",
+	"CreateAPIDocumentRequest.content":                                          "Raw markdown content to display in your Portal",
+	"CreateAPIDocumentRequest.parent_document_id":                               "API Documents may be rendered as a tree of files. Specify the `id` of another API Document as the `parent_document_id` to add some heirarchy do your documents.",
+	"CreateAPIDocumentRequest.slug":                                             "The `slug` is used in generated URLs to provide human readable paths. Defaults to `slugify(title)`",
+	"CreateAPIDocumentRequest.status":                                           "If `status=published` the document will be visible in your live portal",
+	"CreateAPIDocumentRequest.title":                                            "The title of the document. Used to populate the `<title>` tag for the page",
+	"CreateAPIRequest.attributes":                                               "A set of attributes that describe the API",
+	"CreateAPIRequest.description":                                              "A description of your API. Will be visible on your live Portal.",
+	"CreateAPIRequest.labels":                                                   "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateAPIRequest.name":                                                     "The name of your API. The `name + version` combination must be unique for each API you publish.",
+	"CreateAPIRequest.slug":                                                     "The `slug` is used in generated URLs to provide human readable paths. Defaults to `slugify(name + version)`",
+	"CreateAPIRequest.spec_content":                                             "The content of the API specification. This is the raw content of the API specification, in json or yaml. By including this field, you can add a API specification without having to make a separate call to update the API specification.",
+	"CreateAPIRequest.version":                                                  "An optional version for your API. Leave this empty if your API is unversioned.",
+	"CreateAPIVersionRequest.version":                                           "The version of the api.",
+	"CreateAPIVersionRequestSpec.content":                                       "The raw content of your API spec, in json or yaml format (OpenAPI or AsyncAPI).",
+	"CreateBackendClusterRequest.bootstrap_servers":                             "A list of cluster bootstrap servers in the format address:port.",
+	"CreateBackendClusterRequest.description":                                   "A human-readable description of the virtual cluster.",
+	"CreateBackendClusterRequest.insecure_allow_anonymous_virtual_cluster_auth": "If true, virtual clusters can have allow anonymous authentication and use this backend cluster. This setting is not recommended for production use as it may create privilege escalation vulnerabilities.",
+	"CreateBackendClusterRequest.labels":                                        "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateBackendClusterRequest.metadata_update_interval_seconds":              "The interval at which metadata is updated in seconds.",
+	"CreateBackendClusterRequest.name":                                          "The unique name of the backend cluster.",
+	"CreateCatalogService.custom_fields":                                        "Map of customizable, catalog-defined fields providing information about a service.",
+	"CreateCatalogService.description":                                          "Optionally provide a description of the Service.",
+	"CreateCatalogService.display_name":                                         "The display name of the Service.",
+	"CreateCatalogService.labels":                                               "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateCatalogService.name":                                                 "The machine name of the Service that uniquely identifies it within the catalog.",
+	"CreateControlPlaneRequest.auth_type":                                       "The auth type value of the cluster associated with the Runtime Group.",
+	"CreateControlPlaneRequest.cloud_gateway":                                   "Whether this control-plane can be used for cloud-gateways.",
+	"CreateControlPlaneRequest.cluster_type":                                    "The ClusterType value of the cluster associated with the Control Plane.",
+	"CreateControlPlaneRequest.description":                                     "The description of the control plane in Konnect.",
+	"CreateControlPlaneRequest.labels":                                          "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateControlPlaneRequest.name":                                            "The name of the control plane.",
+	"CreateControlPlaneRequest.proxy_urls":                                      "Array of proxy URLs associated with reaching the data-planes connected to a control-plane.",
+	"CreateGatewayRequest.description":                                          "A human-readable description of the Gateway.",
+	"CreateGatewayRequest.labels":                                               "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateGatewayRequest.min_runtime_version":                                  "The minimum runtime version supported by the API. This is the lowest version of the data plane release that can be used with the entity model. When not specified, the minimum runtime version will be pinned to the latest available release.",
+	"CreateGatewayRequest.name":                                                 "The name of the Gateway.",
+	"CreatePortal.authentication_enabled":                                       "Whether the portal supports developer authentication. If disabled, developers cannot register for accounts or create applications.",
+	"CreatePortal.auto_approve_applications":                                    "Whether requests from applications to register for APIs will be automatically approved, or if they will be set to pending until approved by an admin.",
+	"CreatePortal.auto_approve_developers":                                      "Whether developer account registrations will be automatically approved, or if they will be set to pending until approved by an admin.",
+	"CreatePortal.default_api_visibility":                                       "The default visibility of APIs in the portal. If set to `public`, newly published APIs are visible to unauthenticated developers. If set to `private`, newly published APIs are hidden from unauthenticated developers.",
+	"CreatePortal.default_application_auth_strategy_id":                         "The default authentication strategy for APIs published to the portal. Newly published APIs will use this authentication strategy unless overridden during publication. If set to `null`, API publications will not use an authentication strategy unless set during publication.",
+	"CreatePortal.default_page_visibility":                                      "The default visibility of pages in the portal. If set to `public`, newly created pages are visible to unauthenticated developers. If set to `private`, newly created pages are hidden from unauthenticated developers.",
+	"CreatePortal.description":                                                  "A description of the portal.",
+	"CreatePortal.display_name":                                                 "The display name of the portal. This value will be the portal's `name` in Portal API.",
+	"CreatePortal.labels":                                                       "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Labels are intended to store **INTERNAL** metadata. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreatePortal.name":                                                         "The name of the portal, used to distinguish it from other portals. Name must be unique.",
+	"CreatePortal.rbac_enabled":                                                 "Whether the portal resources are protected by Role Based Access Control (RBAC). If enabled, developers view or register for APIs until unless assigned to teams with access to view and consume specific APIs. Authentication must be enabled to use RBAC.",
+	"CreatePortalPageRequest.content":                                           "The renderable markdown content of a page in a portal.",
+	"CreatePortalPageRequest.parent_page_id":                                    "Pages may be rendered as a tree of files. Specify the `id` of another page as the `parent_page_id` to add some hierarchy to your pages.",
+	"CreatePortalPageRequest.slug":                                              "The slug of a page in a portal, used to compute its full URL path within the portal hierarchy. When a page has a `parent_page_id`, its full path is built by joining the parent’s slug with its own. For example, if a parent page has the slug `slug1` and this page’s slug is `slug2`, the resulting path will be `/slug1/slug2`. This enables nested page structures like `/slug1/slug2/slug3`.",
+	"CreatePortalPageRequest.status":                                            "Whether the resource is visible on a given portal. Defaults to unpublished.",
+	"CreatePortalPageRequest.title":                                             "The title of a page in a portal.",
+	"CreatePortalPageRequest.visibility":                                        "Whether a page is publicly accessible to non-authenticated users. If not provided, the default_page_visibility value of the portal will be used.",
+	"CreateTeam.description":                                                    "The description of the new team.",
+	"CreateTeam.labels":                                                         "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateTeam.name":                                                           "A name for the team being created.",
+	"CreateVirtualClusterRequest.acl_mode":                                      "Configures whether or not ACL policies are enforced on the gateway. - `enforce_on_gateway` means the gateway enforces its own ACL policies for this virtual cluster and does not forward ACL-related commands to the backend cluster. Note that if there are no ACL policies configured, all access is denied. - `passthrough` tells the gateway to forward all ACL-related commands.",
+	"CreateVirtualClusterRequest.authentication":                                "How to handle authentication from clients. It tries to authenticate with every rule sequentially one by one. It succeeds on the first match, and fails if no rule matches.",
+	"CreateVirtualClusterRequest.description":                                   "A human-readable description of the virtual cluster.",
+	"CreateVirtualClusterRequest.destination":                                   "The backend cluster associated with the virtual cluster. Either `id` or `name` must be provided. Following changes to the backend cluster name won't affect the reference, as the system will create the entities relationship by `id`.",
+	"CreateVirtualClusterRequest.dns_label":                                     "The DNS label used in the bootstrap server URL to identify the virtual cluster when using SNI routing. The format follows the RFC1035: 1-63 chars, lowercase alphanumeric or '-', must start and end with an alphanumeric character.",
+	"CreateVirtualClusterRequest.labels":                                        "Labels store metadata of an entity that can be used for filtering an entity list or for searching across entity types. Keys must be of length 1-63 characters, and cannot start with \"kong\", \"konnect\", \"mesh\", \"kic\", or \"_\".",
+	"CreateVirtualClusterRequest.name":                                          "The name of the virtual cluster.",
+	"CreateVirtualClusterRequest.namespace":                                     "Namespace allows to implement multitenancy using a single backend cluster. It allows to either hide or enforce a static prefix on resources (topics, consumer group IDs, transaction IDs).",
+	"CustomCertificate.custom_certificate":                                      "Custom certificate to be used for the SSL termination.",
+	"CustomCertificate.custom_private_key":                                      "Custom certificate private key to be used for the SSL termination.",
+	"CustomCertificate.skip_ca_check":                                           "Advanced option. If true, the custom certificate is served exactly as provided, without attempting to bundle against a public trust store. Required for certificates issued by an internal/private CA.",
+	"PortalAuthenticationSettingsUpdateRequest.basic_auth_enabled":              "The organization has basic auth enabled.",
+	"PortalAuthenticationSettingsUpdateRequest.idp_mapping_enabled":             "Whether IdP groups determine the Konnect Portal teams a developer has.",
+	"PortalAuthenticationSettingsUpdateRequest.konnect_mapping_enabled":         "Whether a Konnect Identity Admin assigns teams to a developer.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_auth_enabled":               "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_claim_mappings":             "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_client_id":                  "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_client_secret":              "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_issuer":                     "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_scopes":                     "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.oidc_team_mapping_enabled":       "IdP groups determine the Portal Teams a developer has. Replaced by idp_mapping_enabled. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalAuthenticationSettingsUpdateRequest.saml_auth_enabled":               "Deprecated. Use the [Identity Provider API](https://developer.konghq.com/api/konnect/portal-management/v3/#/operations/update-portal-identity-provider) instead. Deprecated: This will be removed in a future release, please migrate away from it as soon as possible.",
+	"PortalFooterMenuSection.title":                                             "The footer menu section title",
+	"PortalMenuItem.external":                                                   "When clicked, open the link in a new window",
+	"PortalMenuItem.path":                                                       "The absolute path of a page in a portal with a leading slash.",
+	"PortalMenuItem.title":                                                      "The link display text",
+	"PortalMenuItem.visibility":                                                 "Whether a menu item is public or private. Private menu items are only accessible to authenticated users.",
+	"PostPortalEmailConfig.domain_name":                                         "The domain name to use for sending emails. Null means default.",
+	"PostPortalEmailConfig.from_email":                                          "The email address to use in the 'From' field.",
+	"PostPortalEmailConfig.from_name":                                           "The name to display in the 'From' field of emails.",
+	"PostPortalEmailConfig.reply_to_email":                                      "The email address to use in the 'Reply-To' field.",
+	"ProxyURL.host":                                                             "Hostname of the proxy URL.",
+	"ProxyURL.port":                                                             "Port of the proxy URL.",
+	"ProxyURL.protocol":                                                         "Protocol of the proxy URL.",
+	"Service.ca_certificates":                                                   "Array of `CA Certificate` object UUIDs that are used to build the trust store while verifying upstream server's TLS certificate. If set to `null` when Nginx default is respected. If default CA list in Nginx are not specified and TLS verification is enabled, then handshake with upstream server will always fail (because no CA are trusted).",
+	"Service.client_certificate":                                                "Certificate to be used as client certificate while TLS handshaking to the upstream server.",
+	"Service.connect_timeout":                                                   "The timeout in milliseconds for establishing a connection to the upstream server.",
+	"Service.created_at":                                                        "Unix epoch when the resource was created.",
+	"Service.enabled":                                                           "Whether the Service is active. If set to `false`, the proxy behavior will be as if any routes attached to it do not exist (404). Default: `true`.",
+	"Service.host":                                                              "The host of the upstream server. Note that the host value is case sensitive.",
+	"Service.id":                                                                "A string representing a UUID (universally unique identifier).",
+	"Service.name":                                                              "The Service name.",
+	"Service.path":                                                              "The path to be used in requests to the upstream server.",
+	"Service.port":                                                              "The upstream server port.",
+	"Service.protocol":                                                          "The protocol used to communicate with the upstream.",
+	"Service.read_timeout":                                                      "The timeout in milliseconds between two successive read operations for transmitting a request to the upstream server.",
+	"Service.retries":                                                           "The number of retries to execute upon failure to proxy.",
+	"Service.tags":                                                              "An optional set of strings associated with the Service for grouping and filtering.",
+	"Service.tls_sans":                                                          "Additional Subject Alternative Names that can be matched on Upstream server's TLS certificate (in addition to `host`).",
+	"Service.tls_verify":                                                        "Whether to enable verification of upstream server TLS certificate. If set to `null`, then the Nginx default is respected.",
+	"Service.tls_verify_depth":                                                  "Maximum depth of chain while verifying Upstream server's TLS certificate. If set to `null`, then the Nginx default is respected.",
+	"Service.updated_at":                                                        "Unix epoch when the resource was last updated.",
+	"Service.url":                                                               "Helper field to set `protocol`, `host`, `port` and `path` using a URL. This field is write-only and is not returned in responses.",
+	"Service.write_timeout":                                                     "The timeout in milliseconds between two successive write operations for transmitting a request to the upstream server.",
+	"ServiceReference.service":                                                  "A Gateway service that implements an API",
+	"SpecRenderer.allow_custom_server_urls":                                     "Let users define a custom server URL for endpoints. This will be used to generate code snippets and to test the API. The URL is client-side only and is not saved.",
+	"SpecRenderer.hide_deprecated":                                              "Manage visibility of deprecated endpoints and models.",
+	"SpecRenderer.hide_internal":                                                "Manage visibility of internal endpoints and models.",
+	"SpecRenderer.infinite_scroll":                                              "Display the full spec on a single, scrollable page. If disabled, documentation, endpoints, and schemas appear on separate pages.",
+	"SpecRenderer.show_schemas":                                                 "Control whether schemas are visible in your API specs. When enabled, schemas appear in the side navigation below the endpoints.",
+	"SpecRenderer.try_it_insomnia":                                              "Enables users to open API specifications in Insomnia to explore and send requests with the native client. Only public API specifications are supported.",
+	"SpecRenderer.try_it_ui":                                                    "Enable in-browser testing for your APIs. All linked gateways must have the CORS plugin configured.",
+	"TLSSans.dnsnames":                                                          "A dnsName for TLS verification.",
+	"TLSSans.uris":                                                              "An URI for TLS verification.",
+	"UpdatePortalAuditLogWebhook.audit_log_destination_id":                      "ID of the audit log destination.",
+	"UpdatePortalAuditLogWebhook.enabled":                                       "Indicates if the data should be sent to the configured destination.",
+	"VirtualClusterAuthenticationClaimsMapping.scope":                           "Maps the scope claim.",
+	"VirtualClusterAuthenticationClaimsMapping.sub":                             "Maps the subject claim.",
+	"VirtualClusterAuthenticationJWKS.cache_expiration":                         "Duration after which the gateway will fetch and cache JWKS.",
+	"VirtualClusterAuthenticationJWKS.endpoint":                                 "URL for JWKS endpoint.",
+	"VirtualClusterAuthenticationJWKS.timeout":                                  "Total time from establishing connection to receive a response from JWKS endpoint.",
+	"VirtualClusterAuthenticationOauthBearer.claims_mapping":                    "Maps JWT claims in the case when sub and scope are presented as different claims in your JWT token.",
+	"VirtualClusterAuthenticationOauthBearer.jwks":                              "JSON Web Key Set configuration for verifying token signatures.",
+	"VirtualClusterAuthenticationOauthBearer.mediation":                         "Methods to mediate authentication: * passthrough - pass authentication from the client through proxy to the backend cluster without any kind of validation * validate_forward - pass authentication from the client through proxy to the backend cluster. Proxy does the validation before forwarding it to the client. * terminate - terminate authentication at the proxy level and originate authentication to the backend cluster using the configuration defined at BackendCluster's authentication. SASL auth is not originated if authentication on the backend_cluster is not configured.",
+	"VirtualClusterAuthenticationOauthBearer.validate":                          "Validation rules.",
+	"VirtualClusterAuthenticationPrincipal.password":                            "A sensitive value containing the secret or a reference to a secret as a template string expression. If the value is provided as plain text, it is encrypted at rest and omitted from API responses. If provided as an expression, the expression itself is stored and returned by the API.",
+	"VirtualClusterAuthenticationPrincipal.username":                            "A literal value or a reference to an existing secret as a template string expression. The value is stored and returned by the API as-is, not treated as sensitive information.",
+	"VirtualClusterAuthenticationSaslPlain.mediation":                           "The mediation type for SASL/PLAIN authentication.",
+	"VirtualClusterAuthenticationSaslPlain.principals":                          "List of principals to be able to authenticate with, used with `terminate` mediation.",
+	"VirtualClusterAuthenticationSaslScram.algorithm":                           "The algorithm used for SASL/SCRAM authentication.",
+	"VirtualClusterAuthenticationValidate.audiences":                            "List of expected audience values. One of them has to match the audience claim in the token.",
+	"VirtualClusterAuthenticationValidate.issuer":                               "Expected token issuer in the token.",
+	"VirtualClusterNamespace.mode":                                              "* hide_prefix - the configured prefix is hidden from clients for topics and IDs when reading. Created resources are written with the prefix on the backend cluster. * enforce_prefix - the configured prefix remains visible to clients. Created resources must include the prefix or the request will fail.",
+	"VirtualClusterNamespace.prefix":                                            "The namespace is differentiated by this chosen prefix. For example, if the prefix is set to \"analytics_\" the topic named \"analytics_user_clicks\" is available to the clients of the virtual cluster. Topics without the prefix will be ignored unless added via `additional.topics`.",
+	"VirtualClusterNamespaceAdditionalProperties.consumer_groups":               "Consumer group IDs to expose even if they don't start with the namespace prefix.",
+	"VirtualClusterNamespaceAdditionalProperties.topics":                        "Additional backend topics to expose even if they don't match the namespace prefix. The topics are not affected by the hide/enforce prefix mode. If the client tries to create a topic that matches this list, the request is rejected.",
+	"VirtualClusterNamespaceIDSelectorGlob.glob":                                "Expose any id that matches this glob pattern (e.g., `my_id_*`).",
+	"VirtualClusterNamespaceTopicSelectorExactList.conflict":                    "How to inform the user about conflicts where multiple backend topics would map to the same virtual topic name. * warn - log in the Event Gateway logs. Additionally, it sets knep_namespace_topic_conflict to 1. * ignore - do not do anything. It does not cause knep_namespace_topic_conflict metric to be set to 1.",
+	"VirtualClusterNamespaceTopicSelectorExactList.exact_list":                  "Explicit allow-list of backend topic names.",
+	"VirtualClusterNamespaceTopicSelectorGlob.conflict":                         "How to inform the user about conflicts where multiple backend topics would map to the same virtual topic name. * warn - log in the Event Gateway logs. Additionally, it sets knep_namespace_topic_conflict to 1. * ignore - do not do anything. It does not cause knep_namespace_topic_conflict metric to be set to 1.",
+	"VirtualClusterNamespaceTopicSelectorGlob.glob":                             "Expose any backend topic that matches this glob pattern (e.g., `operations_data_*`).",
+}
+
+// sdkEnums are the values of SDK enum types, by type name
+var sdkEnums = map[string][]string{
+	"APIDocumentStatus":                               {"published", "unpublished"},
+	"APIImplementationType":                           {"Service Reference"},
+	"APIPublicationVisibility":                        {"public", "private"},
+	"AppAuthStrategyOpenIDConnectRequestStrategyType": {"openid_connect"},
+	"AuthType": {"pinned_client_certs", "pki_client_certs"},
+	"BackendClusterAuthenticationSaslScramAlgorithm":        {"sha256", "sha512"},
+	"BackendClusterAuthenticationSchemeType":                {"anonymous", "sasl_plain", "sasl_scram"},
+	"BackendClusterReferenceModifyType":                     {"BackendClusterReferenceById", "BackendClusterReferenceByName"},
+	"Conflict":                                              {"warn", "ignore"},
+	"CreateAppAuthStrategyRequestType":                      {"key_auth", "openid_connect"},
+	"CreateControlPlaneRequestClusterType":                  {"CLUSTER_TYPE_CONTROL_PLANE", "CLUSTER_TYPE_K8S_INGRESS_CONTROLLER", "CLUSTER_TYPE_CONTROL_PLANE_GROUP", "CLUSTER_TYPE_SERVERLESS", "CLUSTER_TYPE_KAFKA_NATIVE_EVENT_PROXY"},
+	"CreatePortalCustomDomainSSLType":                       {"custom_certificate", "http"},
+	"DefaultAPIVisibility":                                  {"public", "private"},
+	"DefaultPageVisibility":                                 {"public", "private"},
+	"EmailTemplateName":                                     {"confirm-email-address", "app-registration-approved", "app-registration-rejected", "app-registration-revoked", "reset-password", "account-access-approved", "account-access-rejected", "account-access-revoked"},
+	"Mode":                                                  {"hide_prefix", "enforce_prefix"},
+	"PageVisibilityStatus":                                  {"public", "private"},
+	"PortalCustomizationMode":                               {"light", "dark", "system"},
+	"PortalMenuItemVisibility":                              {"public", "private"},
+	"Protocol":                                              {"grpc", "grpcs", "http", "https", "tcp", "tls", "tls_passthrough", "udp", "ws", "wss"},
+	"PublishedStatus":                                       {"published", "unpublished"},
+	"SnippetVisibilityStatus":                               {"public", "private"},
+	"StrategyType":                                          {"key_auth"},
+	"TLSVersions":                                           {"tls12", "tls13"},
+	"VirtualClusterACLMode":                                 {"enforce_on_gateway", "passthrough"},
+	"VirtualClusterAuthenticationOauthBearerMediation":      {"passthrough", "validate_forward", "terminate"},
+	"VirtualClusterAuthenticationSaslPlainMediation":        {"passthrough", "terminate"},
+	"VirtualClusterAuthenticationSaslScramAlgorithm":        {"sha256", "sha512"},
+	"VirtualClusterAuthenticationSchemeType":                {"anonymous", "sasl_plain", "sasl_scram", "oauth_bearer"},
+	"VirtualClusterNamespaceIDSelectorType":                 {"glob", "exact_list"},
+	"VirtualClusterNamespaceTopicSelectorExactListConflict": {"warn", "ignore"},
+	"VirtualClusterNamespaceTopicSelectorType":              {"glob", "exact_list"},
+}
@@ -0,0 +1,317 @@
+// Package specrev keeps the API version specs kongctl uploaded, addressed by the hash
+// of their content, so a version can be rolled back to a spec uploaded earlier.
+package specrev
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kong/kongctl/internal/config"
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+)
+
+const (
+	defaultDirPerm  = 0o700
+	defaultFilePerm = 0o600
+
+	dirName       = "api-spec-revisions"
+	indexFileName = "index.json"
+	specFileExt   = ".spec"
+
+	// MaxRevisions is how many revisions are kept for each API version; the specs of
+	// older revisions are removed
+	MaxRevisions = 20
+)
+
+// storeMu serializes rewrites of the index, as applies may run concurrently
+var storeMu sync.Mutex
+
+// Revision is a spec uploaded to an API version
+type Revision struct {
+	// Hash is the hash of the spec, as stored in the spec hash label of the API
+	Hash       string    `json:"hash"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Size       int       `json:"size"`
+}
+
+// history is the revisions of an API version, oldest first
+type history struct {
+	Version   string     `json:"version,omitempty"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// indexFile is the layout of the index on disk, by API ID and then version ID
+type indexFile struct {
+	APIs map[string]map[string]*history `json:"apis"`
+}
+
+// Store is a directory holding the spec of each revision and an index of the
+// revisions of each API version
+type Store struct {
+	dir string
+}
+
+// DefaultDir returns the store location inside the kongctl config directory
+func DefaultDir() (string, error) {
+	baseDir, err := config.GetDefaultConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("resolve config path: %w", err)
+	}
+	return filepath.Join(baseDir, dirName), nil
+}
+
+// NewStore creates a store backed by the directory at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Record keeps content as the latest revision of an API version. Recording a spec
+// recorded before moves it to the latest revision.
+func (s *Store) Record(apiID, versionID, version, content string, at time.Time) (Revision, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	index, err := s.read()
+	if err != nil {
+		return Revision{}, err
+	}
+	revision := Revision{Hash: labels.SpecHash(content), RecordedAt: at, Size: len(content)}
+	if err := s.writeSpec(revision.Hash, content); err != nil {
+		return Revision{}, err
+	}
+
+	versions := index.APIs[apiID]
+	if versions == nil {
+		versions = make(map[string]*history)
+		index.APIs[apiID] = versions
+	}
+	entry := versions[versionID]
+	if entry == nil {
+		entry = &history{}
+		versions[versionID] = entry
+	}
+	if version != "" {
+		entry.Version = version
+	}
+
+	revisions := make([]Revision, 0, len(entry.Revisions)+1)
+	for _, existing := range entry.Revisions {
+		if existing.Hash != revision.Hash {
+			revisions = append(revisions, existing)
+		}
+	}
+	revisions = append(revisions, revision)
+	var dropped []Revision
+	if len(revisions) > MaxRevisions {
+		dropped = revisions[:len(revisions)-MaxRevisions]
+		revisions = revisions[len(revisions)-MaxRevisions:]
+	}
+	entry.Revisions = revisions
+
+	if err := s.write(index); err != nil {
+		return Revision{}, err
+	}
+	s.removeUnreferenced(index, dropped)
+	return revision, nil
+}
+
+// RecordApplied records the specs that the API version changes of plan uploaded, as
+// reported by result
+func (s *Store) RecordApplied(plan *planner.Plan, result *executor.ExecutionResult, at time.Time) error {
+	if plan == nil || result == nil || result.DryRun {
+		return nil
+	}
+
+	planned := make(map[string]*planner.PlannedChange, len(plan.Changes))
+	for i := range plan.Changes {
+		planned[plan.Changes[i].ID] = &plan.Changes[i]
+	}
+	// APIs created by the same plan are only known by ref
+	apiIDs := make(map[string]string)
+	for _, applied := range result.ChangesInEffect() {
+		if applied.ResourceType == "api" && applied.ResourceID != "" {
+			apiIDs[applied.ResourceRef] = applied.ResourceID
+		}
+	}
+
+	for _, applied := range result.ChangesInEffect() {
+		change := planned[applied.ChangeID]
+		if change == nil || change.ResourceType != "api_version" || applied.ResourceID == "" ||
+			(change.Action != planner.ActionCreate && change.Action != planner.ActionUpdate) {
+			continue
+		}
+		spec, _ := change.Fields["spec"].(map[string]any)
+		content, _ := spec["content"].(string)
+		if content == "" {
+			continue
+		}
+		apiID := changeAPIID(*change, apiIDs)
+		if apiID == "" {
+			continue
+		}
+		version, _ := change.Fields["version"].(string)
+		if _, err := s.Record(apiID, applied.ResourceID, version, content, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeAPIID returns the ID of the API owning an API version change
+func changeAPIID(change planner.PlannedChange, created map[string]string) string {
+	if ref, ok := change.References["api_id"]; ok {
+		if ref.ID != "" && ref.ID != "[unknown]" {
+			return ref.ID
+		}
+		if id := created[ref.Ref]; id != "" {
+			return id
+		}
+	}
+	if change.Parent != nil {
+		if change.Parent.ID != "" && change.Parent.ID != "[unknown]" {
+			return change.Parent.ID
+		}
+		return created[change.Parent.Ref]
+	}
+	return ""
+}
+
+// List returns the revisions of an API version, oldest first
+func (s *Store) List(apiID, versionID string) ([]Revision, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	index, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	if entry := index.APIs[apiID][versionID]; entry != nil {
+		return entry.Revisions, nil
+	}
+	return nil, nil
+}
+
+// Content returns the spec of a revision
+func (s *Store) Content(hash string) (string, error) {
+	data, err := os.ReadFile(s.specPath(hash))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("spec revision %s is not stored in %s", hash, s.dir)
+		}
+		return "", fmt.Errorf("read spec revision %s: %w", hash, err)
+	}
+	return string(data), nil
+}
+
+// Find returns the revision of revisions whose hash starts with prefix
+func Find(revisions []Revision, prefix string) (Revision, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	var matches []Revision
+	for _, revision := range revisions {
+		if strings.HasPrefix(revision.Hash, prefix) {
+			matches = append(matches, revision)
+		}
+	}
+	switch {
+	case prefix == "" || len(matches) == 0:
+		return Revision{}, fmt.Errorf("no revision matches %q", prefix)
+	case len(matches) > 1:
+		return Revision{}, fmt.Errorf("%d revisions match %q, provide more of the hash", len(matches), prefix)
+	}
+	return matches[0], nil
+}
+
+// Previous returns the latest revision of revisions whose spec differs from the spec
+// with hash current, i.e. the revision a rollback restores
+func Previous(revisions []Revision, current string) (Revision, bool) {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Hash != current {
+			return revisions[i], true
+		}
+	}
+	return Revision{}, false
+}
+
+// removeUnreferenced removes the specs of dropped revisions that no API version
+// references anymore. Failures leave a spec behind, which is harmless.
+func (s *Store) removeUnreferenced(index *indexFile, dropped []Revision) {
+	for _, revision := range dropped {
+		if !referenced(index, revision.Hash) {
+			_ = os.Remove(s.specPath(revision.Hash))
+		}
+	}
+}
+
+func referenced(index *indexFile, hash string) bool {
+	for _, versions := range index.APIs {
+		for _, entry := range versions {
+			for _, revision := range entry.Revisions {
+				if revision.Hash == hash {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *Store) specPath(hash string) string {
+	return filepath.Join(s.dir, hash+specFileExt)
+}
+
+func (s *Store) writeSpec(hash, content string) error {
+	if err := os.MkdirAll(s.dir, defaultDirPerm); err != nil {
+		return fmt.Errorf("create spec revisions directory: %w", err)
+	}
+	if err := os.WriteFile(s.specPath(hash), []byte(content), defaultFilePerm); err != nil {
+		return fmt.Errorf("write spec revision %s: %w", hash, err)
+	}
+	return nil
+}
+
+func (s *Store) read() (*indexFile, error) {
+	index := &indexFile{}
+	path := filepath.Join(s.dir, indexFileName)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read spec revisions: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, index); err != nil {
+			return nil, fmt.Errorf("parse spec revisions %s: %w", path, err)
+		}
+	}
+	if index.APIs == nil {
+		index.APIs = make(map[string]map[string]*history)
+	}
+	return index, nil
+}
+
+func (s *Store) write(index *indexFile) error {
+	if err := os.MkdirAll(s.dir, defaultDirPerm); err != nil {
+		return fmt.Errorf("create spec revisions directory: %w", err)
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode spec revisions: %w", err)
+	}
+
+	// Write to a temporary file first, so an interrupted write keeps the previous index
+	path := filepath.Join(s.dir, indexFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, defaultFilePerm); err != nil {
+		return fmt.Errorf("write spec revisions: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write spec revisions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package specrev
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/labels"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	specV1 = "openapi: 3.0.0\ninfo:\n  title: Orders\n  version: 1.0.0\n"
+	specV2 = "openapi: 3.0.0\ninfo:\n  title: Orders\n  version: 1.1.0\n"
+)
+
+func TestStore_Record(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", dirName))
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	first, err := store.Record("api-1", "version-1", "1.0.0", specV1, at)
+	require.NoError(t, err)
+	assert.Equal(t, labels.SpecHash(specV1), first.Hash)
+	_, err = store.Record("api-1", "version-1", "1.0.0", specV2, at.Add(time.Hour))
+	require.NoError(t, err)
+
+	revisions, err := store.List("api-1", "version-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, first, revisions[0])
+
+	content, err := store.Content(first.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, specV1, content)
+
+	// Recording a spec again moves it to the latest revision
+	_, err = store.Record("api-1", "version-1", "1.0.0", specV1, at.Add(2*time.Hour))
+	require.NoError(t, err)
+	revisions, err = store.List("api-1", "version-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, first.Hash, revisions[1].Hash)
+
+	previous, ok := Previous(revisions, first.Hash)
+	require.True(t, ok)
+	assert.Equal(t, labels.SpecHash(specV2), previous.Hash)
+
+	found, err := Find(revisions, first.Hash[:8])
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash, found.Hash)
+	_, err = Find(revisions, "zz")
+	assert.ErrorContains(t, err, `no revision matches "zz"`)
+
+	none, err := store.List("api-1", "version-2")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestStore_RecordKeepsMaxRevisions(t *testing.T) {
+	store := NewStore(t.TempDir())
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var hashes []string
+	for i := 0; i <= MaxRevisions; i++ {
+		revision, err := store.Record("api-1", "version-1", "1.0.0", fmt.Sprintf("{\"rev\": %d}", i), at)
+		require.NoError(t, err)
+		hashes = append(hashes, revision.Hash)
+	}
+	// The oldest spec is also a revision of another version, so it is kept
+	_, err := store.Record("api-2", "version-9", "", "{\"rev\": 1}", at)
+	require.NoError(t, err)
+	_, err = store.Record("api-1", "version-1", "1.0.0", "{\"rev\": 99}", at)
+	require.NoError(t, err)
+
+	revisions, err := store.List("api-1", "version-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, MaxRevisions)
+	assert.Equal(t, hashes[2], revisions[0].Hash)
+
+	_, err = os.Stat(store.specPath(hashes[0]))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = store.Content(hashes[1])
+	assert.NoError(t, err)
+}
+
+func TestStore_RecordApplied(t *testing.T) {
+	store := NewStore(t.TempDir())
+	plan := planner.NewPlan("1.0", "test", planner.PlanModeApply)
+	plan.AddChange(planner.PlannedChange{
+		ID: "1:c:api:orders", ResourceType: "api", ResourceRef: "orders", Action: planner.ActionCreate,
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "2:c:api_version:orders-v1", ResourceType: "api_version", ResourceRef: "orders-v1",
+		Action: planner.ActionCreate,
+		Parent: &planner.ParentInfo{Ref: "orders"},
+		Fields: map[string]any{"version": "1.0.0", "spec": map[string]any{"content": specV1}},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "3:u:api_version:billing-v1", ResourceType: "api_version", ResourceRef: "billing-v1",
+		Action:     planner.ActionUpdate,
+		References: map[string]planner.ReferenceInfo{"api_id": {Ref: "billing", ID: "api-2"}},
+		Fields:     map[string]any{"spec": map[string]any{"content": specV2}},
+	})
+	plan.AddChange(planner.PlannedChange{
+		ID: "4:u:api_version:billing-v2", ResourceType: "api_version", ResourceRef: "billing-v2",
+		Action:     planner.ActionUpdate,
+		References: map[string]planner.ReferenceInfo{"api_id": {Ref: "billing", ID: "api-2"}},
+		Fields:     map[string]any{"version": "2.0.0"},
+	})
+	result := &executor.ExecutionResult{ChangesApplied: []executor.AppliedChange{
+		{ChangeID: "1:c:api:orders", ResourceType: "api", ResourceRef: "orders", ResourceID: "api-1"},
+		{ChangeID: "2:c:api_version:orders-v1", ResourceType: "api_version", ResourceID: "version-1"},
+		{ChangeID: "3:u:api_version:billing-v1", ResourceType: "api_version", ResourceID: "version-2"},
+		{ChangeID: "4:u:api_version:billing-v2", ResourceType: "api_version", ResourceID: "version-3"},
+	}}
+
+	require.NoError(t, store.RecordApplied(plan, result, time.Now()))
+
+	revisions, err := store.List("api-1", "version-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, labels.SpecHash(specV1), revisions[0].Hash)
+	revisions, err = store.List("api-2", "version-2")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	revisions, err = store.List("api-2", "version-3")
+	require.NoError(t, err)
+	assert.Empty(t, revisions, "changes without spec content record nothing")
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -39,6 +40,7 @@ type ClientConfig struct {
 	PortalTeamAPI          helpers.PortalTeamAPI
 	PortalTeamRolesAPI     helpers.PortalTeamRolesAPI
 	PortalEmailsAPI        helpers.PortalEmailsAPI
+	PortalAuditLogsAPI     helpers.PortalAuditLogsAPI
 	AssetsAPI              helpers.AssetsAPI
 
 	// API child resource APIs
@@ -53,7 +55,11 @@ type ClientConfig struct {
 	EventGatewayVirtualClusterAPI helpers.EventGatewayVirtualClusterAPI
 
 	// Identity resources
-	OrganizationTeamAPI helpers.OrganizationTeamAPI
+	OrganizationTeamAPI           helpers.OrganizationTeamAPI
+	OrganizationTeamRolesAPI      helpers.OrganizationTeamRolesAPI
+	OrganizationTeamMembershipAPI helpers.OrganizationTeamMembershipAPI
+	MeAPI                         helpers.MeAPI
+	NotificationsAPI              helpers.NotificationsAPI
 }
 
 // Client wraps Konnect SDK for state management
@@ -76,6 +82,7 @@ type Client struct {
 	portalTeamAPI          helpers.PortalTeamAPI
 	portalTeamRolesAPI     helpers.PortalTeamRolesAPI
 	portalEmailsAPI        helpers.PortalEmailsAPI
+	portalAuditLogsAPI     helpers.PortalAuditLogsAPI
 	assetsAPI              helpers.AssetsAPI
 
 	// API child resource APIs
@@ -89,7 +96,11 @@ type Client struct {
 	eventGatewayBackendClusterAPI helpers.EventGatewayBackendClusterAPI
 	eventGatewayVirtualClusterAPI helpers.EventGatewayVirtualClusterAPI
 	// Organization resource APIs
-	organizationTeamAPI helpers.OrganizationTeamAPI
+	organizationTeamAPI           helpers.OrganizationTeamAPI
+	organizationTeamRolesAPI      helpers.OrganizationTeamRolesAPI
+	organizationTeamMembershipAPI helpers.OrganizationTeamMembershipAPI
+	meAPI                         helpers.MeAPI
+	notificationsAPI              helpers.NotificationsAPI
 }
 
 // NewClient creates a new state client with the provided configuration
@@ -113,6 +124,7 @@ func NewClient(config ClientConfig) *Client {
 		portalTeamAPI:          config.PortalTeamAPI,
 		portalTeamRolesAPI:     config.PortalTeamRolesAPI,
 		portalEmailsAPI:        config.PortalEmailsAPI,
+		portalAuditLogsAPI:     config.PortalAuditLogsAPI,
 		assetsAPI:              config.AssetsAPI,
 
 		// API child resource APIs
@@ -127,10 +139,58 @@ func NewClient(config ClientConfig) *Client {
 		eventGatewayVirtualClusterAPI: config.EventGatewayVirtualClusterAPI,
 
 		// Identity resource APIs
-		organizationTeamAPI: config.OrganizationTeamAPI,
+		organizationTeamAPI:           config.OrganizationTeamAPI,
+		organizationTeamRolesAPI:      config.OrganizationTeamRolesAPI,
+		organizationTeamMembershipAPI: config.OrganizationTeamMembershipAPI,
+		meAPI:                         config.MeAPI,
+		notificationsAPI:              config.NotificationsAPI,
 	}
 }
 
+// NewClientForSDK creates a state client using the APIs of a Konnect SDK
+func NewClientForSDK(kkClient helpers.SDKAPI) *Client {
+	return NewClient(ClientConfig{
+		// Core APIs
+		PortalAPI:             kkClient.GetPortalAPI(),
+		APIAPI:                kkClient.GetAPIAPI(),
+		AppAuthAPI:            kkClient.GetAppAuthStrategiesAPI(),
+		ControlPlaneAPI:       kkClient.GetControlPlaneAPI(),
+		ControlPlaneGroupsAPI: kkClient.GetControlPlaneGroupsAPI(),
+		GatewayServiceAPI:     kkClient.GetGatewayServiceAPI(),
+		CatalogServiceAPI:     kkClient.GetCatalogServicesAPI(),
+
+		// Portal child resource APIs
+		PortalPageAPI:          kkClient.GetPortalPageAPI(),
+		PortalAuthSettingsAPI:  kkClient.GetPortalAuthSettingsAPI(),
+		PortalCustomizationAPI: kkClient.GetPortalCustomizationAPI(),
+		PortalCustomDomainAPI:  kkClient.GetPortalCustomDomainAPI(),
+		PortalSnippetAPI:       kkClient.GetPortalSnippetAPI(),
+		PortalTeamAPI:          kkClient.GetPortalTeamAPI(),
+		PortalTeamRolesAPI:     kkClient.GetPortalTeamRolesAPI(),
+		PortalEmailsAPI:        kkClient.GetPortalEmailsAPI(),
+		PortalAuditLogsAPI:     kkClient.GetPortalAuditLogsAPI(),
+		AssetsAPI:              kkClient.GetAssetsAPI(),
+
+		// API child resource APIs
+		APIVersionAPI:        kkClient.GetAPIVersionAPI(),
+		APIPublicationAPI:    kkClient.GetAPIPublicationAPI(),
+		APIImplementationAPI: kkClient.GetAPIImplementationAPI(),
+		APIDocumentAPI:       kkClient.GetAPIDocumentAPI(),
+
+		// Event Gateway APIs
+		EGWControlPlaneAPI:            kkClient.GetEventGatewayControlPlaneAPI(),
+		EventGatewayBackendClusterAPI: kkClient.GetEventGatewayBackendClusterAPI(),
+		EventGatewayVirtualClusterAPI: kkClient.GetEventGatewayVirtualClusterAPI(),
+
+		// Organization APIs
+		OrganizationTeamAPI:           kkClient.GetOrganizationTeamAPI(),
+		OrganizationTeamRolesAPI:      kkClient.GetOrganizationTeamRolesAPI(),
+		OrganizationTeamMembershipAPI: kkClient.GetOrganizationTeamMembershipAPI(),
+		MeAPI:                         kkClient.GetMeAPI(),
+		NotificationsAPI:              kkClient.GetNotificationsAPI(),
+	})
+}
+
 // Portal represents a normalized portal for internal use
 type Portal struct {
 	kkComps.ListPortalsResponsePortal
@@ -177,6 +237,7 @@ type APIVersion struct {
 // APIPublication represents an API publication for internal use
 type APIPublication struct {
 	ID                       string
+	APIID                    string
 	PortalID                 string
 	AuthStrategyIDs          []string
 	AutoApproveRegistrations bool
@@ -266,6 +327,30 @@ type OrganizationTeam struct {
 	NormalizedLabels map[string]string // Non-pointer labels
 }
 
+// OrganizationTeamRole represents a role assigned to an organization team
+type OrganizationTeamRole struct {
+	ID             string
+	RoleName       string
+	EntityID       string
+	EntityTypeName string
+	EntityRegion   string
+	TeamID         string
+}
+
+// NotificationSubscription represents a subscription of the current user to a
+// notification event
+type NotificationSubscription struct {
+	kkComps.EventSubscriptionResponse
+	EventID string
+}
+
+// OrganizationUser represents a Konnect user, as a member of an organization team or
+// as the user running kongctl
+type OrganizationUser struct {
+	ID    string
+	Email string
+}
+
 // ListManagedPortals returns all KONGCTL-managed portals in the specified namespaces
 // If namespaces is empty, no resources are returned (breaking change from previous behavior)
 // To get all managed resources across all namespaces, pass []string{"*"}
@@ -537,6 +622,9 @@ func (c *Client) ListManagedControlPlanes(ctx context.Context, namespaces []stri
 			PageSize:   &pageSize,
 			PageNumber: &pageNumber,
 		}
+		if filter, ok := namespaceLabelFilter(namespaces); ok {
+			req.FilterLabels = &filter
+		}
 
 		resp, err := c.controlPlaneAPI.ListControlPlanes(ctx, req)
 		if err != nil {
@@ -769,6 +857,110 @@ func (c *Client) ListGatewayServices(ctx context.Context, controlPlaneID string)
 	return services, nil
 }
 
+// GetGatewayService returns a gateway service of the control plane by ID
+func (c *Client) GetGatewayService(ctx context.Context, controlPlaneID, serviceID string) (*GatewayService, error) {
+	if err := ValidateAPIClient(c.gatewayServiceAPI, "Gateway Service API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.gatewayServiceAPI.GetService(ctx, serviceID, controlPlaneID)
+	if err != nil {
+		return nil, WrapAPIError(err, "get gateway service", &ErrorWrapperOptions{
+			ResourceType: "gateway_service",
+			ResourceName: serviceID,
+			UseEnhanced:  true,
+		})
+	}
+
+	if resp == nil || resp.Service == nil {
+		return nil, nil
+	}
+
+	return newGatewayService(controlPlaneID, *resp.Service), nil
+}
+
+// CreateGatewayService creates a gateway service in the control plane
+func (c *Client) CreateGatewayService(
+	ctx context.Context,
+	controlPlaneID string,
+	service kkComps.Service,
+	namespace string,
+) (*GatewayService, error) {
+	if err := ValidateAPIClient(c.gatewayServiceAPI, "Gateway Service API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.gatewayServiceAPI.CreateService(ctx, controlPlaneID, service)
+	if err != nil {
+		return nil, WrapAPIError(err, "create gateway service", &ErrorWrapperOptions{
+			ResourceType: "gateway_service",
+			ResourceName: getString(service.Name),
+			Namespace:    namespace,
+			UseEnhanced:  true,
+		})
+	}
+
+	if err := ValidateResponse(resp.Service, "create gateway service"); err != nil {
+		return nil, err
+	}
+
+	return newGatewayService(controlPlaneID, *resp.Service), nil
+}
+
+// UpdateGatewayService replaces the configuration of a gateway service
+func (c *Client) UpdateGatewayService(
+	ctx context.Context,
+	controlPlaneID string,
+	serviceID string,
+	service kkComps.Service,
+	namespace string,
+) (*GatewayService, error) {
+	if err := ValidateAPIClient(c.gatewayServiceAPI, "Gateway Service API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.gatewayServiceAPI.UpsertService(ctx, kkOps.UpsertServiceRequest{
+		ServiceID:      serviceID,
+		ControlPlaneID: controlPlaneID,
+		Service:        service,
+	})
+	if err != nil {
+		return nil, WrapAPIError(err, "update gateway service", &ErrorWrapperOptions{
+			ResourceType: "gateway_service",
+			ResourceName: getString(service.Name),
+			Namespace:    namespace,
+			UseEnhanced:  true,
+		})
+	}
+
+	if err := ValidateResponse(resp.Service, "update gateway service"); err != nil {
+		return nil, err
+	}
+
+	return newGatewayService(controlPlaneID, *resp.Service), nil
+}
+
+// DeleteGatewayService deletes a gateway service from the control plane
+func (c *Client) DeleteGatewayService(ctx context.Context, controlPlaneID, serviceID string) error {
+	if err := ValidateAPIClient(c.gatewayServiceAPI, "Gateway Service API"); err != nil {
+		return err
+	}
+
+	if _, err := c.gatewayServiceAPI.DeleteService(ctx, controlPlaneID, serviceID); err != nil {
+		return WrapAPIError(err, "delete gateway service", nil)
+	}
+	return nil
+}
+
+func newGatewayService(controlPlaneID string, svc kkComps.ServiceOutput) *GatewayService {
+	return &GatewayService{
+		ID:             getString(svc.ID),
+		Name:           getString(svc.Name),
+		ControlPlaneID: controlPlaneID,
+		Service:        svc,
+	}
+}
+
 // GetControlPlaneByName finds a managed control plane by name
 func (c *Client) GetControlPlaneByName(ctx context.Context, name string) (*ControlPlane, error) {
 	controlPlanes, err := c.ListManagedControlPlanes(ctx, []string{"*"})
@@ -952,6 +1144,9 @@ func (c *Client) ListManagedAPIs(ctx context.Context, namespaces []string) ([]AP
 			PageSize:   &pageSize,
 			PageNumber: &pageNumber,
 		}
+		if filter, ok := namespaceLabelFilter(namespaces); ok {
+			req.Filter = &kkComps.APIFilterParameters{Labels: &kkComps.LabelsFieldFilter{Eq: &filter}}
+		}
 
 		resp, err := c.apiAPI.ListApis(ctx, req)
 		if err != nil {
@@ -1193,6 +1388,9 @@ func (c *Client) ListManagedCatalogServices(ctx context.Context, namespaces []st
 			PageSize:   &pageSize,
 			PageNumber: &pageNumber,
 		}
+		if filter, ok := namespaceLabelFilter(namespaces); ok {
+			req.Filter = &kkComps.CatalogServiceFilterParameters{Labels: &kkComps.LabelsFieldFilter{Eq: &filter}}
+		}
 
 		resp, err := c.catalogServiceAPI.ListCatalogServices(ctx, req)
 		if err != nil {
@@ -1615,6 +1813,7 @@ func (c *Client) ListAPIPublications(ctx context.Context, apiID string) ([]APIPu
 		for _, p := range resp.ListAPIPublicationResponse.Data {
 			pub := APIPublication{
 				ID:                       "", // Publications don't have a separate ID
+				APIID:                    p.APIID,
 				PortalID:                 p.PortalID,
 				AuthStrategyIDs:          p.AuthStrategyIds,
 				AutoApproveRegistrations: p.AutoApproveRegistrations,
@@ -1637,6 +1836,63 @@ func (c *Client) ListAPIPublications(ctx context.Context, apiID string) ([]APIPu
 	return allPublications, nil
 }
 
+// ListPublicationsByPortal returns the publications of every API to a portal
+func (c *Client) ListPublicationsByPortal(ctx context.Context, portalID string) ([]APIPublication, error) {
+	return c.listPublicationsByFilter(ctx, kkComps.APIPublicationFilterParameters{
+		PortalID: &kkComps.UUIDFieldFilter{Eq: &portalID},
+	})
+}
+
+// ListPublicationsByAuthStrategy returns the publications that use an application auth strategy
+func (c *Client) ListPublicationsByAuthStrategy(ctx context.Context, strategyID string) ([]APIPublication, error) {
+	return c.listPublicationsByFilter(ctx, kkComps.APIPublicationFilterParameters{
+		AuthStrategyID: &kkComps.UUIDFieldFilter{Eq: &strategyID},
+	})
+}
+
+func (c *Client) listPublicationsByFilter(
+	ctx context.Context, filter kkComps.APIPublicationFilterParameters,
+) ([]APIPublication, error) {
+	if c.apiPublicationAPI == nil {
+		return nil, fmt.Errorf("API publication client not configured")
+	}
+
+	var publications []APIPublication
+	pageSize := int64(100)
+	for pageNumber := int64(1); ; pageNumber++ {
+		resp, err := c.apiPublicationAPI.ListAPIPublications(ctx, kkOps.ListAPIPublicationsRequest{
+			Filter:     &filter,
+			PageSize:   &pageSize,
+			PageNumber: &pageNumber,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API publications: %w", err)
+		}
+		if resp.ListAPIPublicationResponse == nil {
+			break
+		}
+
+		for _, p := range resp.ListAPIPublicationResponse.Data {
+			pub := APIPublication{
+				APIID:                    p.APIID,
+				PortalID:                 p.PortalID,
+				AuthStrategyIDs:          p.AuthStrategyIds,
+				AutoApproveRegistrations: p.AutoApproveRegistrations,
+			}
+			if p.Visibility != nil {
+				pub.Visibility = string(*p.Visibility)
+			}
+			publications = append(publications, pub)
+		}
+
+		if len(resp.ListAPIPublicationResponse.Data) == 0 ||
+			resp.ListAPIPublicationResponse.Meta.Page.Total <= float64(pageSize*pageNumber) {
+			break
+		}
+	}
+	return publications, nil
+}
+
 // CreateAPIPublication creates a new API publication
 func (c *Client) CreateAPIPublication(
 	ctx context.Context, apiID string, portalID string, publication kkComps.APIPublication,
@@ -2294,6 +2550,72 @@ func (c *Client) DeletePortalEmailConfig(ctx context.Context, portalID string) e
 	return nil
 }
 
+// GetPortalAuditLogWebhook fetches the audit log webhook for a portal.
+// Returns nil when the portal has no webhook configured.
+func (c *Client) GetPortalAuditLogWebhook(
+	ctx context.Context,
+	portalID string,
+) (*kkComps.PortalAuditLogWebhook, error) {
+	if err := ValidateAPIClient(c.portalAuditLogsAPI, "portal audit logs API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.portalAuditLogsAPI.GetPortalAuditLogWebhook(ctx, portalID)
+	if err != nil {
+		var notFound *kkErrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, WrapAPIError(err, "get portal audit log webhook", nil)
+	}
+
+	if err := ValidateResponse(resp.PortalAuditLogWebhook, "get portal audit log webhook"); err != nil {
+		return nil, err
+	}
+
+	return resp.PortalAuditLogWebhook, nil
+}
+
+// UpdatePortalAuditLogWebhook configures the audit log webhook for a portal.
+// The webhook is addressed through its portal, so the portal ID is returned.
+func (c *Client) UpdatePortalAuditLogWebhook(
+	ctx context.Context,
+	portalID string,
+	body *kkComps.UpdatePortalAuditLogWebhook,
+) (string, error) {
+	if err := ValidateAPIClient(c.portalAuditLogsAPI, "portal audit logs API"); err != nil {
+		return "", err
+	}
+
+	resp, err := c.portalAuditLogsAPI.UpdatePortalAuditLogWebhook(ctx, portalID, body)
+	if err != nil {
+		return "", WrapAPIError(err, "update portal audit log webhook", &ErrorWrapperOptions{
+			ResourceType: "portal_audit_log_webhook",
+			ResourceName: portalID,
+			UseEnhanced:  true,
+		})
+	}
+	if resp == nil || resp.PortalAuditLogWebhook == nil {
+		return "", NewResponseValidationError("update portal audit log webhook", "PortalAuditLogWebhook")
+	}
+	return portalID, nil
+}
+
+// DeletePortalAuditLogWebhook removes the audit log webhook from a portal.
+func (c *Client) DeletePortalAuditLogWebhook(ctx context.Context, portalID string) error {
+	if err := ValidateAPIClient(c.portalAuditLogsAPI, "portal audit logs API"); err != nil {
+		return err
+	}
+
+	if _, err := c.portalAuditLogsAPI.DeletePortalAuditLogWebhook(ctx, portalID); err != nil {
+		return WrapAPIError(err, "delete portal audit log webhook", &ErrorWrapperOptions{
+			ResourceType: "portal_audit_log_webhook",
+			ResourceName: portalID,
+		})
+	}
+	return nil
+}
+
 // ListPortalCustomEmailTemplates returns customized templates for a portal.
 func (c *Client) ListPortalCustomEmailTemplates(ctx context.Context, portalID string) ([]PortalEmailTemplate, error) {
 	if err := ValidateAPIClient(c.portalEmailsAPI, "portal emails API"); err != nil {
@@ -3715,6 +4037,366 @@ func (c *Client) DeleteOrganizationTeam(ctx context.Context, teamID string) erro
 	return nil
 }
 
+// ListOrganizationTeamRoles returns the roles assigned to an organization team
+func (c *Client) ListOrganizationTeamRoles(ctx context.Context, teamID string) ([]OrganizationTeamRole, error) {
+	if err := ValidateAPIClient(c.organizationTeamRolesAPI, "organization team roles API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.organizationTeamRolesAPI.ListTeamRoles(ctx, teamID, nil)
+	if err != nil {
+		return nil, WrapAPIError(err, "list organization team roles", &ErrorWrapperOptions{
+			ResourceType: "organization_team_role",
+			UseEnhanced:  true,
+		})
+	}
+
+	if resp.AssignedRoleCollection == nil {
+		return []OrganizationTeamRole{}, nil
+	}
+
+	roles := make([]OrganizationTeamRole, 0, len(resp.AssignedRoleCollection.Data))
+	for _, r := range resp.AssignedRoleCollection.Data {
+		role := OrganizationTeamRole{
+			ID:             getString(r.ID),
+			RoleName:       getString(r.RoleName),
+			EntityID:       getString(r.EntityID),
+			EntityTypeName: getString(r.EntityTypeName),
+			TeamID:         teamID,
+		}
+		if r.EntityRegion != nil {
+			role.EntityRegion = string(*r.EntityRegion)
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// AssignOrganizationTeamRole assigns a role to an organization team and returns the assignment ID
+func (c *Client) AssignOrganizationTeamRole(
+	ctx context.Context,
+	teamID string,
+	role kkComps.AssignRole,
+	namespace string,
+) (string, error) {
+	if err := ValidateAPIClient(c.organizationTeamRolesAPI, "organization team roles API"); err != nil {
+		return "", err
+	}
+
+	resp, err := c.organizationTeamRolesAPI.TeamsAssignRole(ctx, teamID, &role)
+	if err != nil {
+		roleName := ""
+		if role.RoleName != nil {
+			roleName = string(*role.RoleName)
+		}
+		return "", WrapAPIError(err, "assign organization team role", &ErrorWrapperOptions{
+			ResourceType: "organization_team_role",
+			ResourceName: roleName,
+			Namespace:    namespace,
+			UseEnhanced:  true,
+		})
+	}
+
+	if err := ValidateResponse(resp.AssignedRole, "assign organization team role"); err != nil {
+		return "", err
+	}
+
+	return getString(resp.AssignedRole.ID), nil
+}
+
+// RemoveOrganizationTeamRole removes an assigned role from an organization team
+func (c *Client) RemoveOrganizationTeamRole(ctx context.Context, teamID string, roleID string) error {
+	if err := ValidateAPIClient(c.organizationTeamRolesAPI, "organization team roles API"); err != nil {
+		return err
+	}
+
+	if _, err := c.organizationTeamRolesAPI.TeamsRemoveRole(ctx, teamID, roleID); err != nil {
+		return WrapAPIError(err, "remove organization team role", &ErrorWrapperOptions{
+			ResourceType: "organization_team_role",
+			UseEnhanced:  true,
+		})
+	}
+
+	return nil
+}
+
+// ListOrganizationTeamMembers returns the users of an organization team
+func (c *Client) ListOrganizationTeamMembers(ctx context.Context, teamID string) ([]OrganizationUser, error) {
+	if err := ValidateAPIClient(c.organizationTeamMembershipAPI, "organization team membership API"); err != nil {
+		return nil, err
+	}
+
+	lister := func(ctx context.Context, pageSize, pageNumber int64) ([]OrganizationUser, *PageMeta, error) {
+		resp, err := c.organizationTeamMembershipAPI.ListTeamUsers(ctx, kkOps.ListTeamUsersRequest{
+			TeamID:     teamID,
+			PageSize:   &pageSize,
+			PageNumber: &pageNumber,
+		})
+		if err != nil {
+			return nil, nil, WrapAPIError(err, "list organization team members", &ErrorWrapperOptions{
+				ResourceType: "organization_team_member",
+				UseEnhanced:  true,
+			})
+		}
+
+		if resp.UserCollection == nil {
+			return []OrganizationUser{}, &PageMeta{Total: 0}, nil
+		}
+
+		users := make([]OrganizationUser, 0, len(resp.UserCollection.Data))
+		for _, u := range resp.UserCollection.Data {
+			users = append(users, OrganizationUser{ID: getString(u.ID), Email: getString(u.Email)})
+		}
+
+		meta := &PageMeta{}
+		if resp.UserCollection.Meta != nil {
+			meta.Total = resp.UserCollection.Meta.Page.Total
+		}
+		return users, meta, nil
+	}
+
+	return PaginateAll(ctx, lister)
+}
+
+// GetOrganizationUserByEmail finds an organization user by email, returning nil when none matches
+func (c *Client) GetOrganizationUserByEmail(ctx context.Context, email string) (*OrganizationUser, error) {
+	if err := ValidateAPIClient(c.organizationTeamMembershipAPI, "organization team membership API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.organizationTeamMembershipAPI.ListUsers(ctx, kkOps.ListUsersRequest{
+		Filter: &kkOps.ListUsersQueryParamFilter{
+			Email: &kkComps.LegacyStringFieldFilter{Eq: &email},
+		},
+	})
+	if err != nil {
+		return nil, WrapAPIError(err, "get user by email", &ErrorWrapperOptions{
+			ResourceType: "user",
+			ResourceName: email,
+			UseEnhanced:  true,
+		})
+	}
+
+	if resp.UserCollection == nil {
+		return nil, nil
+	}
+	for _, u := range resp.UserCollection.Data {
+		if strings.EqualFold(getString(u.Email), email) {
+			return &OrganizationUser{ID: getString(u.ID), Email: getString(u.Email)}, nil
+		}
+	}
+
+	return nil, nil // Not found
+}
+
+// AddOrganizationTeamMember adds a user to an organization team
+func (c *Client) AddOrganizationTeamMember(ctx context.Context, teamID string, userID string) error {
+	if err := ValidateAPIClient(c.organizationTeamMembershipAPI, "organization team membership API"); err != nil {
+		return err
+	}
+
+	_, err := c.organizationTeamMembershipAPI.AddUserToTeam(ctx, teamID, &kkComps.AddUserToTeam{UserID: userID})
+	if err != nil {
+		return WrapAPIError(err, "add organization team member", &ErrorWrapperOptions{
+			ResourceType: "organization_team_member",
+			ResourceName: userID,
+			UseEnhanced:  true,
+		})
+	}
+
+	return nil
+}
+
+// RemoveOrganizationTeamMember removes a user from an organization team
+func (c *Client) RemoveOrganizationTeamMember(ctx context.Context, teamID string, userID string) error {
+	if err := ValidateAPIClient(c.organizationTeamMembershipAPI, "organization team membership API"); err != nil {
+		return err
+	}
+
+	if _, err := c.organizationTeamMembershipAPI.RemoveUserFromTeam(ctx, userID, teamID); err != nil {
+		return WrapAPIError(err, "remove organization team member", &ErrorWrapperOptions{
+			ResourceType: "organization_team_member",
+			ResourceName: userID,
+			UseEnhanced:  true,
+		})
+	}
+
+	return nil
+}
+
+// GetCurrentUser returns the user the credentials belong to. It returns nil without an
+// error when they are not a user's, as for a system account token.
+func (c *Client) GetCurrentUser(ctx context.Context) (*OrganizationUser, error) {
+	if c.meAPI == nil {
+		return nil, nil
+	}
+
+	resp, err := c.meAPI.GetUsersMe(ctx)
+	if err != nil {
+		var unauthorized *kkErrors.UnauthorizedError
+		if errors.As(err, &unauthorized) {
+			return nil, nil
+		}
+		var sdkErr *kkErrors.SDKError
+		if errors.As(err, &sdkErr) &&
+			(sdkErr.StatusCode == http.StatusForbidden || sdkErr.StatusCode == http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, WrapAPIError(err, "get current user", nil)
+	}
+	if resp.User == nil || resp.User.ID == nil {
+		return nil, nil
+	}
+
+	return &OrganizationUser{ID: *resp.User.ID, Email: getString(resp.User.Email)}, nil
+}
+
+// ListNotificationEvents returns the notification events the current user can subscribe to
+func (c *Client) ListNotificationEvents(ctx context.Context) ([]kkComps.UserConfiguration, error) {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.notificationsAPI.ListUserConfigurations(ctx, nil)
+	if err != nil {
+		return nil, WrapAPIError(err, "list notification events", nil)
+	}
+	if resp.UserConfigurationListResponse == nil {
+		return []kkComps.UserConfiguration{}, nil
+	}
+
+	return resp.UserConfigurationListResponse.Data, nil
+}
+
+// ListNotificationSubscriptions returns the subscriptions of the current user to an event
+func (c *Client) ListNotificationSubscriptions(
+	ctx context.Context,
+	eventID string,
+) ([]NotificationSubscription, error) {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.notificationsAPI.ListEventSubscriptions(ctx, eventID)
+	if err != nil {
+		return nil, WrapAPIError(err, "list notification subscriptions", &ErrorWrapperOptions{
+			ResourceType: "notification_subscription",
+			UseEnhanced:  true,
+		})
+	}
+	if resp.EventSubscriptionListResponse == nil {
+		return []NotificationSubscription{}, nil
+	}
+
+	subscriptions := make([]NotificationSubscription, 0, len(resp.EventSubscriptionListResponse.Data))
+	for _, subscription := range resp.EventSubscriptionListResponse.Data {
+		subscriptions = append(subscriptions, NotificationSubscription{
+			EventSubscriptionResponse: subscription,
+			EventID:                   eventID,
+		})
+	}
+
+	return subscriptions, nil
+}
+
+// GetNotificationSubscription returns a subscription to an event, or nil when it does not exist
+func (c *Client) GetNotificationSubscription(
+	ctx context.Context,
+	eventID string,
+	subscriptionID string,
+) (*NotificationSubscription, error) {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.notificationsAPI.GetEventSubscription(ctx, eventID, subscriptionID)
+	if err != nil {
+		var notFound *kkErrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, WrapAPIError(err, "get notification subscription", nil)
+	}
+	if resp.EventSubscriptionResponse == nil {
+		return nil, nil
+	}
+
+	return &NotificationSubscription{EventSubscriptionResponse: *resp.EventSubscriptionResponse, EventID: eventID}, nil
+}
+
+// CreateNotificationSubscription subscribes the current user to an event and returns the
+// subscription ID
+func (c *Client) CreateNotificationSubscription(
+	ctx context.Context,
+	eventID string,
+	subscription kkComps.EventSubscription,
+) (string, error) {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return "", err
+	}
+
+	resp, err := c.notificationsAPI.CreateEventSubscription(ctx, eventID, &subscription)
+	if err != nil {
+		return "", WrapAPIError(err, "create notification subscription", &ErrorWrapperOptions{
+			ResourceType: "notification_subscription",
+			ResourceName: subscription.Name,
+			UseEnhanced:  true,
+		})
+	}
+	if err := ValidateResponse(resp.EventSubscriptionResponse, "create notification subscription"); err != nil {
+		return "", err
+	}
+
+	return resp.EventSubscriptionResponse.ID, nil
+}
+
+// UpdateNotificationSubscription replaces the settings of a subscription to an event
+func (c *Client) UpdateNotificationSubscription(
+	ctx context.Context,
+	eventID string,
+	subscriptionID string,
+	subscription kkComps.EventSubscription,
+) (string, error) {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return "", err
+	}
+
+	resp, err := c.notificationsAPI.UpdateEventSubscription(ctx, kkOps.UpdateEventSubscriptionRequest{
+		EventID:           eventID,
+		SubscriptionID:    subscriptionID,
+		EventSubscription: &subscription,
+	})
+	if err != nil {
+		return "", WrapAPIError(err, "update notification subscription", &ErrorWrapperOptions{
+			ResourceType: "notification_subscription",
+			ResourceName: subscription.Name,
+			UseEnhanced:  true,
+		})
+	}
+	if err := ValidateResponse(resp.EventSubscriptionResponse, "update notification subscription"); err != nil {
+		return "", err
+	}
+
+	return resp.EventSubscriptionResponse.ID, nil
+}
+
+// DeleteNotificationSubscription removes a subscription to an event
+func (c *Client) DeleteNotificationSubscription(ctx context.Context, eventID string, subscriptionID string) error {
+	if err := ValidateAPIClient(c.notificationsAPI, "notifications API"); err != nil {
+		return err
+	}
+
+	if _, err := c.notificationsAPI.DeleteEventSubscription(ctx, eventID, subscriptionID); err != nil {
+		return WrapAPIError(err, "delete notification subscription", &ErrorWrapperOptions{
+			ResourceType: "notification_subscription",
+			UseEnhanced:  true,
+		})
+	}
+
+	return nil
+}
+
 func getString(value *string) string {
 	if value == nil {
 		return ""
@@ -3729,6 +4411,16 @@ func getEntityRegion(value *kkComps.EntityRegion) kkComps.EntityRegion {
 	return *value
 }
 
+// namespaceLabelFilter returns the namespace label as a key:value Konnect label filter,
+// so list calls only return the resources of the namespace. Several namespaces and the
+// wildcard are not filtered by Konnect. Results are still filtered by shouldIncludeNamespace.
+func namespaceLabelFilter(namespaces []string) (string, bool) {
+	if len(namespaces) != 1 || namespaces[0] == "*" || namespaces[0] == "" {
+		return "", false
+	}
+	return labels.NamespaceKey + ":" + namespaces[0], true
+}
+
 // shouldIncludeNamespace checks if a resource's namespace should be included based on filter
 func shouldIncludeNamespace(resourceNamespace string, namespaces []string) bool {
 	// Empty namespace list means no resources should be returned
@@ -61,6 +61,32 @@ func TestListManagedControlPlanes(t *testing.T) {
 	assert.Equal(t, "team-a", result[0].NormalizedLabels[labels.NamespaceKey])
 }
 
+func TestListManagedControlPlanes_LabelFilter(t *testing.T) {
+	ctx := testContextWithLogger()
+	mockAPI := helpers.NewMockControlPlaneAPI(t)
+	client := NewClient(ClientConfig{ControlPlaneAPI: mockAPI})
+
+	mockAPI.EXPECT().
+		ListControlPlanes(mock.Anything, mock.MatchedBy(func(req kkOps.ListControlPlanesRequest) bool {
+			return req.FilterLabels != nil && *req.FilterLabels == labels.NamespaceKey+":team-a"
+		})).
+		Return(newListControlPlanesResponse(nil, 0), nil).
+		Once()
+	_, err := client.ListManagedControlPlanes(ctx, []string{"team-a"})
+	require.NoError(t, err)
+
+	mockAPI.EXPECT().
+		ListControlPlanes(mock.Anything, mock.MatchedBy(func(req kkOps.ListControlPlanesRequest) bool {
+			return req.FilterLabels == nil
+		})).
+		Return(newListControlPlanesResponse(nil, 0), nil).
+		Twice()
+	_, err = client.ListManagedControlPlanes(ctx, []string{"*"})
+	require.NoError(t, err)
+	_, err = client.ListManagedControlPlanes(ctx, []string{"team-a", "team-b"})
+	require.NoError(t, err)
+}
+
 func TestGetControlPlaneByNameFallback(t *testing.T) {
 	ctx := testContextWithLogger()
 	mockAPI := helpers.NewMockControlPlaneAPI(t)
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"fmt"
+
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+// CountResources returns how many resources of a type the organization has, managed
+// or not. It reads the total of a single page instead of listing the resources.
+func (c *Client) CountResources(ctx context.Context, resourceType string) (int, error) {
+	var (
+		pageSize   int64 = 1
+		pageNumber int64 = 1
+		total      float64
+	)
+
+	switch resourceType {
+	case "portal":
+		if err := ValidateAPIClient(c.portalAPI, "Portal API"); err != nil {
+			return 0, err
+		}
+		resp, err := c.portalAPI.ListPortals(ctx, kkOps.ListPortalsRequest{PageSize: &pageSize, PageNumber: &pageNumber})
+		if err != nil {
+			return 0, WrapAPIError(err, "count portals", nil)
+		}
+		if resp.ListPortalsResponse != nil {
+			total = resp.ListPortalsResponse.Meta.Page.Total
+		}
+	case "api":
+		if err := ValidateAPIClient(c.apiAPI, "API"); err != nil {
+			return 0, err
+		}
+		resp, err := c.apiAPI.ListApis(ctx, kkOps.ListApisRequest{PageSize: &pageSize, PageNumber: &pageNumber})
+		if err != nil {
+			return 0, WrapAPIError(err, "count APIs", nil)
+		}
+		if resp.ListAPIResponse != nil {
+			total = resp.ListAPIResponse.Meta.Page.Total
+		}
+	case "control_plane":
+		if err := ValidateAPIClient(c.controlPlaneAPI, "Control Plane API"); err != nil {
+			return 0, err
+		}
+		resp, err := c.controlPlaneAPI.ListControlPlanes(ctx,
+			kkOps.ListControlPlanesRequest{PageSize: &pageSize, PageNumber: &pageNumber})
+		if err != nil {
+			return 0, WrapAPIError(err, "count control planes", nil)
+		}
+		if resp.ListControlPlanesResponse != nil {
+			total = resp.ListControlPlanesResponse.Meta.Page.Total
+		}
+	case "application_auth_strategy":
+		if err := ValidateAPIClient(c.appAuthAPI, "app auth API"); err != nil {
+			return 0, err
+		}
+		resp, err := c.appAuthAPI.ListAppAuthStrategies(ctx,
+			kkOps.ListAppAuthStrategiesRequest{PageSize: &pageSize, PageNumber: &pageNumber})
+		if err != nil {
+			return 0, WrapAPIError(err, "count application auth strategies", nil)
+		}
+		if resp.ListAppAuthStrategiesResponse != nil {
+			total = resp.ListAppAuthStrategiesResponse.Meta.Page.Total
+		}
+	default:
+		return 0, fmt.Errorf("counting %s resources is not supported", resourceType)
+	}
+
+	return int(total), nil
+}
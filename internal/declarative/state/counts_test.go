@@ -0,0 +1,34 @@
+package state
+
+import (
+	"testing"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountResources(t *testing.T) {
+	ctx := testContextWithLogger()
+	mockAPI := helpers.NewMockControlPlaneAPI(t)
+	mockAPI.EXPECT().
+		ListControlPlanes(mock.Anything, mock.MatchedBy(func(req kkOps.ListControlPlanesRequest) bool {
+			return req.PageSize != nil && *req.PageSize == 1
+		})).
+		Return(newListControlPlanesResponse([]kkComps.ControlPlane{{ID: "cp-1"}}, 7), nil).
+		Once()
+
+	client := NewClient(ClientConfig{ControlPlaneAPI: mockAPI})
+
+	count, err := client.CountResources(ctx, "control_plane")
+	require.NoError(t, err)
+	require.Equal(t, 7, count)
+
+	_, err = client.CountResources(ctx, "portal")
+	require.Error(t, err)
+
+	_, err = client.CountResources(ctx, "api_document")
+	require.ErrorContains(t, err, "counting api_document resources is not supported")
+}
@@ -0,0 +1,107 @@
+package tags
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// Base64FileTagResolver handles !base64file tags for loading any file as a base64 data URL.
+// Unlike !file, the content is never parsed, which makes it suitable for logos, favicons,
+// stylesheets and other assets that Konnect expects as data URLs.
+type Base64FileTagResolver struct {
+	files *FileTagResolver
+}
+
+// NewBase64FileTagResolver creates a new base64 file tag resolver.
+// baseDir resolves relative paths; rootDir defines the allowed boundary for resolved paths.
+func NewBase64FileTagResolver(baseDir string, rootDir string) *Base64FileTagResolver {
+	return &Base64FileTagResolver{
+		files: NewFileTagResolver(baseDir, rootDir),
+	}
+}
+
+// Tag returns the YAML tag this resolver handles
+func (b *Base64FileTagResolver) Tag() string {
+	return "!base64file"
+}
+
+// Resolve processes a YAML node with the !base64file tag
+func (b *Base64FileTagResolver) Resolve(node *yaml.Node) (any, error) {
+	var path string
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		path = node.Value
+	case yaml.MappingNode:
+		var fileRef FileRef
+		if err := node.Decode(&fileRef); err != nil {
+			return nil, fmt.Errorf("invalid !base64file tag format: %w", err)
+		}
+		if fileRef.Extract != "" {
+			return nil, fmt.Errorf("!base64file tag does not support 'extract'")
+		}
+		path = fileRef.Path
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+		return nil, fmt.Errorf("!base64file tag must be used with a string or map, got %v", node.Kind)
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("!base64file tag requires a file path")
+	}
+
+	return b.loadFile(path)
+}
+
+// loadFile reads the file and encodes it as a data URL
+func (b *Base64FileTagResolver) loadFile(path string) (string, error) {
+	if err := b.files.validatePath(path); err != nil {
+		return "", err
+	}
+
+	fullPath := b.files.resolvePath(path)
+	if err := b.files.validateResolvedPath(path, fullPath); err != nil {
+		return "", err
+	}
+
+	if cached, ok := b.files.getCached(fullPath).(string); ok {
+		return cached, nil
+	}
+
+	data, err := b.files.readFile(fullPath)
+	if err != nil {
+		return "", err
+	}
+
+	ext := strings.ToLower(filepath.Ext(fullPath))
+	encoded := fmt.Sprintf("data:%s;base64,%s", base64MimeType(ext, data), base64.StdEncoding.EncodeToString(data))
+	b.files.setCached(fullPath, encoded)
+
+	return encoded, nil
+}
+
+// base64MimeType determines the MIME type used in the data URL
+func base64MimeType(ext string, data []byte) string {
+	if isImageFile(ext) {
+		return detectMimeType(ext, data)
+	}
+
+	if mimeType := mime.TypeByExtension(ext); mimeType != "" {
+		// Drop parameters such as charset; they are not meaningful for base64 payloads
+		if idx := strings.Index(mimeType, ";"); idx != -1 {
+			mimeType = mimeType[:idx]
+		}
+		return mimeType
+	}
+
+	detected := http.DetectContentType(data)
+	if idx := strings.Index(detected, ";"); idx != -1 {
+		detected = detected[:idx]
+	}
+	return detected
+}
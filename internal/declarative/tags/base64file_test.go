@@ -0,0 +1,89 @@
+package tags
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestBase64FileTagResolver_Tag(t *testing.T) {
+	resolver := NewBase64FileTagResolver(".", ".")
+	assert.Equal(t, "!base64file", resolver.Tag())
+}
+
+func TestBase64FileTagResolver_Resolve(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	css := []byte(":root { --primary: #0055ff; }")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "theme.css"), css, 0o600))
+
+	logo := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "logo.png"), logo, 0o600))
+
+	resolver := NewBase64FileTagResolver(tmpDir, tmpDir)
+
+	tests := []struct {
+		name string
+		node *yaml.Node
+		want string
+	}{
+		{
+			name: "stylesheet as scalar",
+			node: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!base64file", Value: "theme.css"},
+			want: "data:text/css;base64," + base64.StdEncoding.EncodeToString(css),
+		},
+		{
+			name: "image as mapping",
+			node: &yaml.Node{
+				Kind: yaml.MappingNode,
+				Tag:  "!base64file",
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Value: "path"},
+					{Kind: yaml.ScalarNode, Value: "logo.png"},
+				},
+			},
+			want: "data:image/png;base64," + base64.StdEncoding.EncodeToString(logo),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBase64FileTagResolver_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+	resolver := NewBase64FileTagResolver(tmpDir, tmpDir)
+
+	_, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Value: "missing.svg"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file not found")
+
+	_, err = resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Value: "../outside.png"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside base dir")
+
+	_, err = resolver.Resolve(&yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "path"},
+			{Kind: yaml.ScalarNode, Value: "logo.png"},
+			{Kind: yaml.ScalarNode, Value: "extract"},
+			{Kind: yaml.ScalarNode, Value: "info"},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support 'extract'")
+
+	_, err = resolver.Resolve(&yaml.Node{Kind: yaml.SequenceNode})
+	require.Error(t, err)
+}
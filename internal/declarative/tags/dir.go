@@ -0,0 +1,146 @@
+package tags
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// dirIndexFile is the markdown file of a subdirectory that becomes the parent of the
+// other documents of the subdirectory
+const dirIndexFile = "index.md"
+
+// DirRef represents the mapping form of a !dir tag
+type DirRef struct {
+	Path      string `yaml:"path"`       // Path to the directory of markdown files
+	RefPrefix string `yaml:"ref_prefix"` // Optional: prefix of the document refs
+}
+
+// DirTagResolver handles !dir tags, which load a directory of markdown files as a list
+// of API documents. Each file becomes a document whose slug is the file name and whose
+// title is its first heading. A subdirectory becomes the children of its index.md.
+type DirTagResolver struct {
+	files *FileTagResolver
+}
+
+// NewDirTagResolver creates a new directory tag resolver.
+// baseDir resolves relative paths; rootDir defines the allowed boundary for resolved paths.
+func NewDirTagResolver(baseDir string, rootDir string) *DirTagResolver {
+	return &DirTagResolver{
+		files: NewFileTagResolver(baseDir, rootDir),
+	}
+}
+
+// Tag returns the YAML tag this resolver handles
+func (d *DirTagResolver) Tag() string {
+	return "!dir"
+}
+
+// Resolve processes a YAML node with the !dir tag. The syntax is `!dir ./docs` or
+// `!dir {path: ./docs, ref_prefix: orders-}`. Refs default to the name of the
+// directory followed by the path of the file, such as docs-guides-install.
+func (d *DirTagResolver) Resolve(node *yaml.Node) (any, error) {
+	var dirRef DirRef
+	switch node.Kind {
+	case yaml.ScalarNode:
+		dirRef.Path = node.Value
+	case yaml.MappingNode:
+		if err := node.Decode(&dirRef); err != nil {
+			return nil, fmt.Errorf("invalid !dir tag format: %w", err)
+		}
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+		return nil, fmt.Errorf("!dir tag must be used with a string or map, got %v", node.Kind)
+	}
+
+	path := strings.TrimSpace(dirRef.Path)
+	if path == "" {
+		return nil, fmt.Errorf("!dir tag requires a directory path")
+	}
+	if err := d.files.validatePath(path); err != nil {
+		return nil, err
+	}
+	fullPath := d.files.resolvePath(path)
+	if err := d.files.validateResolvedPath(path, fullPath); err != nil {
+		return nil, err
+	}
+
+	prefix := dirRef.RefPrefix
+	if prefix == "" {
+		prefix = filepath.Base(filepath.Clean(fullPath)) + "-"
+	}
+	return d.loadDocuments(fullPath, prefix)
+}
+
+// loadDocuments returns the documents of a directory sorted by file name, with the
+// documents of each subdirectory as children of its index.md
+func (d *DirTagResolver) loadDocuments(dir, refPrefix string) ([]any, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("directory not found: %s", dir)
+		}
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	documents := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case strings.HasPrefix(name, "."):
+			continue
+		case entry.IsDir():
+			subdir := filepath.Join(dir, name)
+			if _, err := os.Stat(filepath.Join(subdir, dirIndexFile)); err != nil {
+				return nil, fmt.Errorf("directory %s has no %s to hold its documents", subdir, dirIndexFile)
+			}
+			parent, err := d.loadDocument(filepath.Join(subdir, dirIndexFile), name, refPrefix+name)
+			if err != nil {
+				return nil, err
+			}
+			children, err := d.loadDocuments(subdir, refPrefix+name+"-")
+			if err != nil {
+				return nil, err
+			}
+			if len(children) > 0 {
+				parent["children"] = children
+			}
+			documents = append(documents, parent)
+		case strings.EqualFold(filepath.Ext(name), ".md") && name != dirIndexFile:
+			slug := strings.TrimSuffix(name, filepath.Ext(name))
+			document, err := d.loadDocument(filepath.Join(dir, name), slug, refPrefix+slug)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+func (d *DirTagResolver) loadDocument(path, slug, ref string) (map[string]any, error) {
+	data, err := d.files.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+	document := map[string]any{"ref": ref, "slug": slug, "content": content}
+	if title := markdownTitle(content); title != "" {
+		document["title"] = title
+	}
+	return document, nil
+}
+
+// markdownTitle returns the text of the first level one heading of a markdown document
+func markdownTitle(content string) string {
+	for line := range strings.SplitSeq(content, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
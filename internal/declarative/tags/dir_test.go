@@ -0,0 +1,90 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func writeDocs(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestDirTagResolver_Resolve(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeDocs(t, filepath.Join(tmpDir, "docs"), map[string]string{
+		"overview.md":            "# Overview\n\nOrders API.\n",
+		"guides/index.md":        "# Guides\n",
+		"guides/install.md":      "Install the SDK.\n",
+		"notes.txt":              "ignored",
+		".drafts/wip.md":         "# Draft\n",
+		"authentication.md":      "Intro\n\n# Authentication\n",
+		"guides/.hidden.md":      "hidden",
+		"guides/upgrade.MD":      "# Upgrade\n",
+		"guides/legacy/x.md":     "X\n",
+		"guides/legacy/index.md": "# Legacy\n",
+	})
+	resolver := NewDirTagResolver(tmpDir, tmpDir)
+
+	documents, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!dir", Value: "docs"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{
+			"ref": "docs-authentication", "slug": "authentication", "title": "Authentication",
+			"content": "Intro\n\n# Authentication\n",
+		},
+		map[string]any{
+			"ref": "docs-guides", "slug": "guides", "title": "Guides", "content": "# Guides\n",
+			"children": []any{
+				map[string]any{"ref": "docs-guides-install", "slug": "install", "content": "Install the SDK.\n"},
+				map[string]any{
+					"ref": "docs-guides-legacy", "slug": "legacy", "title": "Legacy", "content": "# Legacy\n",
+					"children": []any{
+						map[string]any{"ref": "docs-guides-legacy-x", "slug": "x", "content": "X\n"},
+					},
+				},
+				map[string]any{"ref": "docs-guides-upgrade", "slug": "upgrade", "title": "Upgrade", "content": "# Upgrade\n"},
+			},
+		},
+		map[string]any{
+			"ref": "docs-overview", "slug": "overview", "title": "Overview", "content": "# Overview\n\nOrders API.\n",
+		},
+	}, documents)
+}
+
+func TestDirTagResolver_RefPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeDocs(t, filepath.Join(tmpDir, "docs"), map[string]string{"overview.md": "# Overview\n"})
+	resolver := NewDirTagResolver(tmpDir, tmpDir)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("!dir {path: docs, ref_prefix: orders-}"), &node))
+	documents, err := resolver.Resolve(node.Content[0])
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "orders-overview", documents.([]any)[0].(map[string]any)["ref"])
+}
+
+func TestDirTagResolver_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeDocs(t, filepath.Join(tmpDir, "docs"), map[string]string{"guides/install.md": "Install\n"})
+	resolver := NewDirTagResolver(tmpDir, tmpDir)
+	resolve := func(value string) error {
+		_, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!dir", Value: value})
+		return err
+	}
+
+	assert.ErrorContains(t, resolve("docs"), "has no index.md")
+	assert.ErrorContains(t, resolve("missing"), "not found")
+	assert.ErrorContains(t, resolve("../outside"), "outside base dir")
+	assert.ErrorContains(t, resolve(""), "requires a directory path")
+}
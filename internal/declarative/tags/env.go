@@ -0,0 +1,66 @@
+package tags
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// envVarNamePattern matches portable environment variable names
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvTagResolver handles !env tags for environment variable substitution
+type EnvTagResolver struct {
+	lookup func(string) (string, bool)
+}
+
+// NewEnvTagResolver creates a new env tag resolver reading the process environment
+func NewEnvTagResolver() *EnvTagResolver {
+	return &EnvTagResolver{
+		lookup: os.LookupEnv,
+	}
+}
+
+// Tag returns the YAML tag this resolver handles
+func (e *EnvTagResolver) Tag() string {
+	return "!env"
+}
+
+// Resolve processes a YAML node with the !env tag. The syntax is `!env NAME` or
+// `!env NAME:default`; the value is typed as if it had been written in the file,
+// so "true" or "10" set boolean and numeric fields.
+func (e *EnvTagResolver) Resolve(node *yaml.Node) (any, error) {
+	// Only support scalar nodes
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("!env tag must be used with a string, got %v", node.Kind)
+	}
+
+	name, defaultValue, hasDefault := strings.Cut(strings.TrimSpace(node.Value), ":")
+	if name == "" {
+		return nil, fmt.Errorf("!env tag requires an environment variable name (line %d)", node.Line)
+	}
+	if !envVarNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("!env tag has invalid environment variable name %q (line %d)", name, node.Line)
+	}
+
+	value, ok := e.lookup(name)
+	if !ok {
+		if !hasDefault {
+			return nil, fmt.Errorf("environment variable %s is not set and has no default (line %d)", name, node.Line)
+		}
+		value = defaultValue
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	// Decode as an untagged scalar to apply the same typing as a literal value
+	var typed any
+	if err := (&yaml.Node{Kind: yaml.ScalarNode, Value: value}).Decode(&typed); err == nil {
+		return typed, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,62 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestEnvTagResolver_Tag(t *testing.T) {
+	assert.Equal(t, "!env", NewEnvTagResolver().Tag())
+}
+
+func TestEnvTagResolver_Resolve(t *testing.T) {
+	t.Setenv("KONGCTL_TEST_PORTAL_NAME", "Staging Portal")
+	t.Setenv("KONGCTL_TEST_RBAC", "true")
+	t.Setenv("KONGCTL_TEST_EMPTY", "")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+		wantErr  string
+	}{
+		{name: "set variable", input: "KONGCTL_TEST_PORTAL_NAME", expected: "Staging Portal"},
+		{name: "set variable ignores default", input: "KONGCTL_TEST_PORTAL_NAME:Dev Portal", expected: "Staging Portal"},
+		{name: "boolean value", input: "KONGCTL_TEST_RBAC", expected: true},
+		{name: "empty value", input: "KONGCTL_TEST_EMPTY:fallback", expected: ""},
+		{name: "default", input: "KONGCTL_TEST_UNSET:Dev Portal", expected: "Dev Portal"},
+		{name: "default with colon", input: "KONGCTL_TEST_UNSET:http://localhost:8080", expected: "http://localhost:8080"},
+		{name: "numeric default", input: "KONGCTL_TEST_UNSET:10", expected: 10},
+		{name: "empty default", input: "KONGCTL_TEST_UNSET:", expected: ""},
+		{
+			name:    "unset without default",
+			input:   "KONGCTL_TEST_UNSET",
+			wantErr: "environment variable KONGCTL_TEST_UNSET is not set and has no default (line 3)",
+		},
+		{name: "missing name", input: ":default", wantErr: "requires an environment variable name"},
+		{name: "invalid name", input: "NOT-VALID", wantErr: `invalid environment variable name "NOT-VALID"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!env", Value: tt.input, Line: 3}
+			result, err := NewEnvTagResolver().Resolve(node)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEnvTagResolver_RejectsNonScalar(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!env"}
+	_, err := NewEnvTagResolver().Resolve(node)
+	assert.Error(t, err)
+}
@@ -3,11 +3,13 @@ package tags
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 )
 
 // ExtractValue extracts a value from structured data using dot notation path
-// Supports paths like "info.title" or "servers.0.url" (array access in future)
+// Supports paths like "info.title" or "servers.0.url", where a number indexes a list
 func ExtractValue(data any, path string) (any, error) {
 	if path == "" {
 		return data, nil
@@ -30,7 +32,7 @@ func ExtractValue(data any, path string) (any, error) {
 			// Handle map access
 			mapVal := val.MapIndex(reflect.ValueOf(part))
 			if !mapVal.IsValid() {
-				return nil, fmt.Errorf("path not found: %s (failed at '%s')", path, strings.Join(parts[:i+1], "."))
+				return nil, missingKeyError(path, parts[:i], part, current)
 			}
 			current = mapVal.Interface()
 
@@ -39,7 +41,7 @@ func ExtractValue(data any, path string) (any, error) {
 			// Try to find field by name (case-insensitive)
 			fieldVal := findStructField(val, part)
 			if !fieldVal.IsValid() {
-				return nil, fmt.Errorf("path not found: %s (failed at '%s')", path, strings.Join(parts[:i+1], "."))
+				return nil, missingKeyError(path, parts[:i], part, current)
 			}
 			current = fieldVal.Interface()
 
@@ -53,8 +55,22 @@ func ExtractValue(data any, path string) (any, error) {
 			// This avoids wasted assignments and keeps traversal logic consistent.
 			continue
 
-		case reflect.Invalid,
-			reflect.Bool,
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("path not found: %s ('%s' is a list, index it with a number instead of '%s')",
+					path, strings.Join(parts[:i], "."), part)
+			}
+			if index < 0 || index >= val.Len() {
+				return nil, fmt.Errorf("path not found: %s (index %d at '%s' is out of range, the list has %d items)",
+					path, index, strings.Join(parts[:i], "."), val.Len())
+			}
+			current = val.Index(index).Interface()
+
+		case reflect.Invalid:
+			return nil, fmt.Errorf("path not found: %s ('%s' is null)", path, strings.Join(parts[:i], "."))
+
+		case reflect.Bool,
 			reflect.Int,
 			reflect.Int8,
 			reflect.Int16,
@@ -70,23 +86,40 @@ func ExtractValue(data any, path string) (any, error) {
 			reflect.Float64,
 			reflect.Complex64,
 			reflect.Complex128,
-			reflect.Array,
 			reflect.Chan,
 			reflect.Func,
 			reflect.Pointer,
-			reflect.Slice,
 			reflect.String,
 			reflect.UnsafePointer:
-			return nil, fmt.Errorf("cannot traverse path %s: unexpected type %v at '%s'",
-				path, val.Kind(), strings.Join(parts[:i], "."))
+			return nil, fmt.Errorf("cannot traverse path %s: '%s' is a %v, not a map or list",
+				path, strings.Join(parts[:i], "."), val.Kind())
 		}
 
 		i++
 	}
 
+	if current == nil {
+		return nil, fmt.Errorf("path %s resolves to null", path)
+	}
 	return current, nil
 }
 
+// missingKeyError reports that the value at the parent path has no key named part and
+// lists the keys it does have
+func missingKeyError(path string, parent []string, part string, value any) error {
+	location := "the document root"
+	if len(parent) > 0 {
+		location = "'" + strings.Join(parent, ".") + "'"
+	}
+	keys := GetAvailablePaths(value, "", 1)
+	slices.Sort(keys)
+	if len(keys) == 0 {
+		return fmt.Errorf("path not found: %s (no key '%s' at %s, which has no keys)", path, part, location)
+	}
+	return fmt.Errorf("path not found: %s (no key '%s' at %s, available keys: %s)",
+		path, part, location, strings.Join(keys, ", "))
+}
+
 // findStructField finds a struct field by name (case-insensitive)
 func findStructField(val reflect.Value, fieldName string) reflect.Value {
 	typ := val.Type()
@@ -58,6 +58,18 @@ func TestExtractValue(t *testing.T) {
 			path:    "info.nonexistent",
 			wantErr: true,
 		},
+		{
+			name: "array index",
+			data: testData,
+			path: "servers.0.url",
+			want: "https://api.example.com",
+		},
+		{
+			name:    "array index out of range",
+			data:    testData,
+			path:    "servers.1.url",
+			wantErr: true,
+		},
 		{
 			name:    "invalid path through array",
 			data:    testData,
@@ -122,6 +134,31 @@ func TestExtractValue(t *testing.T) {
 	}
 }
 
+func TestExtractValue_ErrorMessages(t *testing.T) {
+	data := map[string]any{
+		"info": map[string]any{"title": "Orders", "version": "1.0", "summary": nil},
+	}
+
+	_, err := ExtractValue(data, "info.titel")
+	assert.EqualError(t, err,
+		"path not found: info.titel (no key 'titel' at 'info', available keys: summary, title, version)")
+
+	_, err = ExtractValue(data, "nfo.title")
+	assert.EqualError(t, err, "path not found: nfo.title (no key 'nfo' at the document root, available keys: info)")
+
+	_, err = ExtractValue(data, "info.summary")
+	assert.EqualError(t, err, "path info.summary resolves to null")
+
+	_, err = ExtractValue(data, "info.summary.text")
+	assert.EqualError(t, err, "path not found: info.summary.text ('info.summary' is null)")
+
+	_, err = ExtractValue(map[string]any{"servers": []any{"a"}}, "servers.2")
+	assert.EqualError(t, err, "path not found: servers.2 (index 2 at 'servers' is out of range, the list has 1 items)")
+
+	_, err = ExtractValue(data, "info.title.text")
+	assert.EqualError(t, err, "cannot traverse path info.title.text: 'info.title' is a string, not a map or list")
+}
+
 func TestGetAvailablePaths(t *testing.T) {
 	testData := map[string]any{
 		"info": map[string]any{
@@ -1,7 +1,10 @@
 package tags
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +30,7 @@ type FileTagResolver struct {
 	rootDirErr  error
 	cache       map[string]any
 	mu          sync.RWMutex
+	remote      *RemoteFetcher
 }
 
 // NewFileTagResolver creates a new file tag resolver.
@@ -35,6 +39,7 @@ func NewFileTagResolver(baseDir string, rootDir string) *FileTagResolver {
 	resolver := &FileTagResolver{
 		baseDir: filepath.Clean(baseDir),
 		cache:   make(map[string]any),
+		remote:  NewRemoteFetcher(DefaultRemoteTimeout, false),
 	}
 
 	if strings.TrimSpace(rootDir) != "" {
@@ -53,6 +58,14 @@ func NewFileTagResolver(baseDir string, rootDir string) *FileTagResolver {
 	return resolver
 }
 
+// SetRemoteFetcher sets the fetcher of http:// and https:// sources. Sharing one
+// fetcher between the resolvers of a load fetches each URL once.
+func (f *FileTagResolver) SetRemoteFetcher(fetcher *RemoteFetcher) {
+	if fetcher != nil {
+		f.remote = fetcher
+	}
+}
+
 // Tag returns the YAML tag this resolver handles
 func (f *FileTagResolver) Tag() string {
 	return "!file"
@@ -63,7 +76,7 @@ func (f *FileTagResolver) Resolve(node *yaml.Node) (any, error) {
 	// Handle three formats:
 	// 1. String scalar: !file ./path/to/file.yaml
 	// 2. String scalar with extraction: !file ./path/to/file.yaml#field.path
-	// 3. Mapping: !file {path: ./file.yaml, extract: info.title}
+	// 3. Mapping: !file {path: ./file.yaml, extract: info.title, sha256: <hex>, overlays: [./prod.yaml]}
 
 	switch node.Kind {
 	case yaml.ScalarNode:
@@ -77,7 +90,7 @@ func (f *FileTagResolver) Resolve(node *yaml.Node) (any, error) {
 			path = path[:idx]
 		}
 
-		return f.loadFile(path, extractPath)
+		return f.loadFile(path, extractPath, "", nil)
 
 	case yaml.MappingNode:
 		// Map format with optional extraction
@@ -90,7 +103,8 @@ func (f *FileTagResolver) Resolve(node *yaml.Node) (any, error) {
 			return nil, fmt.Errorf("!file tag requires 'path' field")
 		}
 
-		return f.loadFile(fileRef.Path, fileRef.Extract)
+		return f.loadFile(fileRef.Path, fileRef.Extract, strings.ToLower(strings.TrimSpace(fileRef.SHA256)),
+			fileRef.Overlays)
 
 	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
 		return nil, fmt.Errorf("!file tag must be used with a string or map, got %v", node.Kind)
@@ -99,8 +113,13 @@ func (f *FileTagResolver) Resolve(node *yaml.Node) (any, error) {
 	return nil, fmt.Errorf("!file tag must be used with a string or map, got %v", node.Kind)
 }
 
-// loadFile loads a file and optionally extracts a value
-func (f *FileTagResolver) loadFile(path string, extractPath string) (any, error) {
+// loadFile loads a file and optionally extracts a value. A non-empty checksum pins
+// the content of the file to its SHA-256, before overlays are applied.
+func (f *FileTagResolver) loadFile(path string, extractPath string, checksum string, overlays []string) (any, error) {
+	if IsRemotePath(path) {
+		return f.loadRemote(path, extractPath, checksum, overlays)
+	}
+
 	// Validate the path
 	if err := f.validatePath(path); err != nil {
 		return nil, err
@@ -121,6 +140,7 @@ func (f *FileTagResolver) loadFile(path string, extractPath string) (any, error)
 	if extractPath != "" && !isImage {
 		cacheKey = fmt.Sprintf("%s#%s", fullPath, extractPath)
 	}
+	cacheKey = overlaidCacheKey(pinnedCacheKey(cacheKey, checksum), overlays)
 
 	if cached := f.getCached(cacheKey); cached != nil {
 		return cached, nil
@@ -131,16 +151,77 @@ func (f *FileTagResolver) loadFile(path string, extractPath string) (any, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := verifyChecksum(path, data, checksum); err != nil {
+		return nil, err
+	}
+	if data, err = f.applyOverlays(path, ext, data, overlays); err != nil {
+		return nil, err
+	}
+
+	return f.decode(path, ext, extractPath, cacheKey, data)
+}
+
+// loadRemote loads a file from an http:// or https:// URL and optionally extracts
+// a value, exactly as from a local file with the extension of the URL path
+func (f *FileTagResolver) loadRemote(
+	rawURL string, extractPath string, checksum string, overlays []string,
+) (any, error) {
+	ext := remoteExtension(rawURL)
+	cacheKey := rawURL
+	if extractPath != "" && !isImageFile(ext) {
+		cacheKey = fmt.Sprintf("%s#%s", rawURL, extractPath)
+	}
+	cacheKey = overlaidCacheKey(pinnedCacheKey(cacheKey, checksum), overlays)
+	if cached := f.getCached(cacheKey); cached != nil {
+		return cached, nil
+	}
+
+	data, err := f.remote.Fetch(context.Background(), rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(rawURL, data, checksum); err != nil {
+		return nil, err
+	}
+	if data, err = f.applyOverlays(rawURL, ext, data, overlays); err != nil {
+		return nil, err
+	}
+	return f.decode(rawURL, ext, extractPath, cacheKey, data)
+}
+
+// pinnedCacheKey keeps pinned loads apart in the cache, so content is verified by
+// the first load of each checksum
+func pinnedCacheKey(cacheKey, checksum string) string {
+	if checksum == "" {
+		return cacheKey
+	}
+	return cacheKey + "@sha256:" + checksum
+}
 
+// verifyChecksum checks data against the SHA-256 pinned by a !file tag, if any
+func verifyChecksum(path string, data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != checksum {
+		return fmt.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", path, checksum, actual)
+	}
+	return nil
+}
+
+// decode parses the content of a file, extracts a value if requested and caches the
+// result under cacheKey
+func (f *FileTagResolver) decode(path, ext, extractPath, cacheKey string, data []byte) (any, error) {
 	// Parse the content based on extension
-	content, err := f.parseContent(fullPath, data)
+	content, err := f.parseContent(ext, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
 
 	// Extract value if path is specified (only for non-image files)
 	result := content
-	if extractPath != "" && !isImage {
+	if extractPath != "" && !isImageFile(ext) {
 		result, err = ExtractValue(content, extractPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to extract '%s' from %s: %w", extractPath, path, err)
@@ -253,10 +334,8 @@ func (f *FileTagResolver) readFile(path string) ([]byte, error) {
 	return data, nil
 }
 
-// parseContent parses file content based on extension
-func (f *FileTagResolver) parseContent(path string, data []byte) (any, error) {
-	ext := strings.ToLower(filepath.Ext(path))
-
+// parseContent parses file content based on its lower case extension
+func (f *FileTagResolver) parseContent(ext string, data []byte) (any, error) {
 	// Check if this is an image file
 	if isImageFile(ext) {
 		return f.encodeImageToDataURL(ext, data), nil
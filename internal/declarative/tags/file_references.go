@@ -0,0 +1,183 @@
+package tags
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// FileReference is a !file or !base64file tag found in a YAML document
+type FileReference struct {
+	// Tag is the tag name, e.g. "!file"
+	Tag string
+	// Path is the referenced path without any #extract suffix
+	Path string
+	// Extract is the path of the value extracted from a !file, if any
+	Extract string
+	// Line is the line of the tag in the document
+	Line int
+	// Field is the location of the tagged value, e.g. portals[0].pages[1].content
+	Field string
+	// ResourceRef is the ref of the closest enclosing resource, if any
+	ResourceRef string
+}
+
+// CollectFileReferences returns every !file and !base64file tag in a YAML document
+// without resolving any of them. Tags with an invalid format are skipped; resolution
+// reports those.
+func CollectFileReferences(data []byte) ([]FileReference, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return collectFileReferences(&doc), nil
+}
+
+// CollectJSONFileReferences returns every $file and $base64file object in a JSON
+// document, as CollectFileReferences does for YAML
+func CollectJSONFileReferences(data []byte) ([]FileReference, error) {
+	doc, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return collectFileReferences(doc), nil
+}
+
+func collectFileReferences(doc *yaml.Node) []FileReference {
+	var refs []FileReference
+	var walk func(node *yaml.Node, field, resourceRef string)
+	walk = func(node *yaml.Node, field, resourceRef string) {
+		switch node.Tag {
+		case "!file", "!base64file":
+			if path, extract := fileReferencePath(node); path != "" {
+				refs = append(refs, FileReference{
+					Tag:         node.Tag,
+					Path:        path,
+					Extract:     extract,
+					Line:        node.Line,
+					Field:       field,
+					ResourceRef: resourceRef,
+				})
+			}
+			for i, overlay := range fileReferenceOverlays(node) {
+				refs = append(refs, FileReference{
+					Tag:         node.Tag,
+					Path:        overlay,
+					Line:        node.Line,
+					Field:       field + ".overlays[" + strconv.Itoa(i) + "]",
+					ResourceRef: resourceRef,
+				})
+			}
+			return
+		}
+
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, field, resourceRef)
+			}
+		case yaml.SequenceNode:
+			for i, child := range node.Content {
+				walk(child, field+"["+strconv.Itoa(i)+"]", resourceRef)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if key, value := node.Content[i], node.Content[i+1]; key.Value == "ref" && value.Kind == yaml.ScalarNode {
+					resourceRef = value.Value
+				}
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				if field != "" {
+					key = field + "." + key
+				}
+				walk(node.Content[i+1], key, resourceRef)
+			}
+		case yaml.ScalarNode, yaml.AliasNode:
+		}
+	}
+	walk(doc, "", "")
+	return refs
+}
+
+// fileReferencePath extracts the path and the extraction path from a !file or
+// !base64file node
+func fileReferencePath(node *yaml.Node) (string, string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		path, extract := node.Value, ""
+		if node.Tag == "!file" {
+			if idx := strings.Index(path, "#"); idx != -1 {
+				path, extract = path[:idx], path[idx+1:]
+			}
+		}
+		return strings.TrimSpace(path), extract
+	case yaml.MappingNode:
+		var fileRef FileRef
+		if err := node.Decode(&fileRef); err != nil {
+			return "", ""
+		}
+		return strings.TrimSpace(fileRef.Path), fileRef.Extract
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+	}
+	return "", ""
+}
+
+// fileReferenceOverlays returns the overlay documents of a !file mapping node
+func fileReferenceOverlays(node *yaml.Node) []string {
+	if node.Tag != "!file" || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var fileRef FileRef
+	if err := node.Decode(&fileRef); err != nil {
+		return nil
+	}
+	overlays := make([]string, 0, len(fileRef.Overlays))
+	for _, overlay := range fileRef.Overlays {
+		if overlay = strings.TrimSpace(overlay); overlay != "" {
+			overlays = append(overlays, overlay)
+		}
+	}
+	return overlays
+}
+
+// CheckFile verifies that path passes the resolver's path rules and names a
+// readable file within the size limit, without loading it. URLs are only checked
+// for a supported scheme and, in offline mode, rejected.
+func (f *FileTagResolver) CheckFile(path string) error {
+	if IsRemotePath(path) {
+		// Remote content is only fetched when the tag is resolved
+		return f.remote.Check(path)
+	}
+	if err := f.validatePath(path); err != nil {
+		return err
+	}
+
+	fullPath := f.resolvePath(path)
+	if err := f.validateResolvedPath(path, fullPath); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file not found: %s", fullPath)
+		}
+		return fmt.Errorf("failed to stat file %s: %w", fullPath, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory, not a file", fullPath)
+	}
+	if info.Size() > MaxFileSize {
+		return fmt.Errorf("file %s is too large (%d bytes, max %d)", fullPath, info.Size(), MaxFileSize)
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", fullPath, err)
+	}
+	return file.Close()
+}
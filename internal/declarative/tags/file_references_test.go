@@ -0,0 +1,62 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectFileReferences(t *testing.T) {
+	refs, err := CollectFileReferences([]byte(`
+portals:
+  - ref: dev-portal
+    name: !file name.txt
+    pages:
+      - ref: home
+        content: !file pages/home.md#body
+    logo: !base64file
+      path: assets/logo.png
+    id: !ref other#id
+apis:
+  - ref: orders
+    versions:
+      - ref: v1
+        spec: !file {path: spec.yaml, overlays: [prod.yaml]}
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []FileReference{
+		{Tag: "!file", Path: "name.txt", Line: 4, Field: "portals[0].name", ResourceRef: "dev-portal"},
+		{
+			Tag: "!file", Path: "pages/home.md", Extract: "body", Line: 7,
+			Field: "portals[0].pages[0].content", ResourceRef: "home",
+		},
+		{Tag: "!base64file", Path: "assets/logo.png", Line: 8, Field: "portals[0].logo", ResourceRef: "dev-portal"},
+		{Tag: "!file", Path: "spec.yaml", Line: 15, Field: "apis[0].versions[0].spec", ResourceRef: "v1"},
+		{Tag: "!file", Path: "prod.yaml", Line: 15, Field: "apis[0].versions[0].spec.overlays[0]", ResourceRef: "v1"},
+	}, refs)
+}
+
+func TestFileTagResolver_CheckFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "present.txt"), []byte("ok"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "dir"), 0o755))
+
+	resolver := NewFileTagResolver(tmpDir, tmpDir)
+	require.NoError(t, resolver.CheckFile("present.txt"))
+
+	err := resolver.CheckFile("missing.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file not found")
+
+	err = resolver.CheckFile("dir")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a directory")
+
+	err = resolver.CheckFile("../outside.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside base dir")
+}
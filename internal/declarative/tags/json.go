@@ -0,0 +1,100 @@
+package tags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// jsonTagKeys maps the keys that stand for tags in JSON configuration, which has no
+// custom tags, to the tag they stand for
+var jsonTagKeys = map[string]string{
+	"$ref":        "!ref",
+	"$file":       "!file",
+	"$base64file": "!base64file",
+	"$env":        "!env",
+	"$merge":      "!merge",
+}
+
+// ConvertJSON converts a JSON document into YAML, turning each object whose only
+// key is one of $ref, $file, $base64file, $env or $merge into the matching tag:
+//
+//	{"$ref": "portal-a#display_name"}    becomes  !ref portal-a#display_name
+//	{"$file": "spec.yaml#info.title"}    becomes  !file spec.yaml#info.title
+//	{"$file": {"path": "spec.yaml"}}     becomes  !file {path: spec.yaml}
+//
+// A $ref holding a JSON pointer such as "#/components/schemas/Order" is left as is,
+// so inline OpenAPI and JSON Schema documents keep their own references.
+func ConvertJSON(data []byte) ([]byte, error) {
+	doc, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// IsJSON reports whether data holds a JSON object, as YAML configuration never
+// starts with a brace
+func IsJSON(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// parseJSON parses a JSON document into YAML nodes with the tag keys converted.
+// The nodes keep the lines of the JSON document.
+func parseJSON(data []byte) (*yaml.Node, error) {
+	// YAML accepts more than JSON, so check the syntax first for precise errors
+	var syntax any
+	if err := json.Unmarshal(data, &syntax); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if err := convertJSONTagKeys(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func convertJSONTagKeys(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			tag, ok := jsonTagKeys[key]
+			if !ok || (key == "$ref" && strings.Contains(node.Content[i+1].Value, "#/")) {
+				continue
+			}
+			if len(node.Content) != 2 {
+				return fmt.Errorf("%q must be the only key of its object (line %d)", key, node.Line)
+			}
+
+			value := node.Content[i+1]
+			node.Kind = value.Kind
+			node.Tag = tag
+			node.Value = value.Value
+			node.Content = value.Content
+			node.Style = value.Style
+			// The value, e.g. the maps of a $merge, may hold tag keys too
+			break
+		}
+	}
+
+	for _, child := range node.Content {
+		if err := convertJSONTagKeys(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
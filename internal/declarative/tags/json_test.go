@@ -0,0 +1,104 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestConvertJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "ref",
+			input:    `{"display_name": {"$ref": "portal-a#display_name"}}`,
+			expected: `{"display_name": !ref "portal-a#display_name"}` + "\n",
+		},
+		{
+			name:     "file with extraction",
+			input:    `{"name": {"$file": "spec.yaml#info.title"}}`,
+			expected: `{"name": !file "spec.yaml#info.title"}` + "\n",
+		},
+		{
+			name:     "file mapping",
+			input:    `{"name": {"$file": {"path": "spec.yaml", "extract": "info.title"}}}`,
+			expected: `{"name": !file {"path": "spec.yaml", "extract": "info.title"}}` + "\n",
+		},
+		{
+			name:     "env inside a list",
+			input:    `{"labels": [{"$env": "TEAM"}]}`,
+			expected: `{"labels": [!env "TEAM"]}` + "\n",
+		},
+		{
+			name:     "json pointer is kept",
+			input:    `{"schema": {"$ref": "#/components/schemas/Order"}}`,
+			expected: `{"schema": {"$ref": "#/components/schemas/Order"}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertJSON([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestConvertJSONResolvesLikeYAML(t *testing.T) {
+	registry := NewResolverRegistry()
+	registry.Register(NewRefTagResolver("."))
+	registry.Register(NewFileTagResolver("testdata", "testdata"))
+
+	fromJSON, err := ConvertJSON([]byte(
+		`{"id": {"$ref": "portal-a"}, "title": {"$file": "openapi.yaml#info.title"}}`))
+	require.NoError(t, err)
+	fromJSON, err = registry.Process(fromJSON)
+	require.NoError(t, err)
+
+	fromYAML, err := registry.Process([]byte("id: !ref portal-a\ntitle: !file openapi.yaml#info.title\n"))
+	require.NoError(t, err)
+
+	var jsonValue, yamlValue map[string]any
+	require.NoError(t, yaml.Unmarshal(fromJSON, &jsonValue))
+	require.NoError(t, yaml.Unmarshal(fromYAML, &yamlValue))
+	assert.Equal(t, yamlValue, jsonValue)
+	assert.Equal(t, RefPlaceholderPrefix+"portal-a#id", jsonValue["id"])
+}
+
+func TestConvertJSONErrors(t *testing.T) {
+	_, err := ConvertJSON([]byte(`{"name": {"$ref": "portal-a", "extra": true}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"$ref" must be the only key of its object`)
+
+	_, err = ConvertJSON([]byte(`{"name": "unterminated}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse JSON")
+}
+
+func TestCollectJSONFileReferences(t *testing.T) {
+	data := []byte(`{
+  "apis": [
+    {
+      "ref": "orders",
+      "description": {"$file": "missing.md"}
+    }
+  ]
+}`)
+
+	refs, err := CollectJSONFileReferences(data)
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, FileReference{
+		Tag:         "!file",
+		Path:        "missing.md",
+		Line:        5,
+		Field:       "apis[0].description",
+		ResourceRef: "orders",
+	}, refs[0])
+}
@@ -0,0 +1,75 @@
+package tags
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// MergeTagResolver handles !merge tags, which deep merge a list of maps
+type MergeTagResolver struct{}
+
+// NewMergeTagResolver creates a new merge tag resolver
+func NewMergeTagResolver() *MergeTagResolver {
+	return &MergeTagResolver{}
+}
+
+// Tag returns the YAML tag this resolver handles
+func (m *MergeTagResolver) Tag() string {
+	return "!merge"
+}
+
+// Resolve processes a YAML node with the !merge tag. The syntax is a list of maps,
+// `!merge [*base_labels, {team: payments}]`, merged in order: nested maps are merged
+// key by key and any other value of a later map replaces the earlier one. Tags within
+// the list, such as !file or !env, are resolved before the maps are merged.
+func (m *MergeTagResolver) Resolve(node *yaml.Node) (any, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("!merge tag must be used with a list of maps, got %v (line %d)", node.Kind, node.Line)
+	}
+
+	merged := make(map[string]any)
+	for i, element := range node.Content {
+		var value any
+		if err := element.Decode(&value); err != nil {
+			return nil, fmt.Errorf("!merge element %d is invalid (line %d): %w", i, element.Line, err)
+		}
+		entries, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("!merge element %d must be a map, got %s (line %d)",
+				i, describeValue(value), element.Line)
+		}
+		mergeMaps(merged, entries)
+	}
+	return merged, nil
+}
+
+// mergeMaps merges src into dst, merging nested maps and replacing other values
+func mergeMaps(dst, src map[string]any) {
+	for key, value := range src {
+		if nested, ok := value.(map[string]any); ok {
+			if existing, ok := dst[key].(map[string]any); ok {
+				combined := make(map[string]any, len(existing))
+				mergeMaps(combined, existing)
+				mergeMaps(combined, nested)
+				dst[key] = combined
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// describeValue names the kind of a decoded YAML value for error messages
+func describeValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "a map"
+	default:
+		return fmt.Sprintf("%T value %v", value, value)
+	}
+}
@@ -0,0 +1,75 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestMergeTagResolver_Tag(t *testing.T) {
+	assert.Equal(t, "!merge", NewMergeTagResolver().Tag())
+}
+
+func TestMergeTagResolver_Process(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte(`
+env: prod
+owner:
+  team: platform
+  slack: "#platform"
+`), 0o600))
+	t.Setenv("KONGCTL_TEST_REGION", "eu")
+
+	registry := NewResolverRegistry()
+	registry.Register(NewFileTagResolver(tmpDir, tmpDir))
+	registry.Register(NewEnvTagResolver())
+	registry.Register(NewMergeTagResolver())
+
+	output, err := registry.Process([]byte(`
+first:
+  labels: &base_labels
+    tier: gold
+second:
+  labels: !merge
+    - *base_labels
+    - !file base.yaml
+    - {region: !env KONGCTL_TEST_REGION, owner: {team: payments}}
+`))
+	require.NoError(t, err)
+
+	var result map[string]map[string]map[string]any
+	require.NoError(t, yaml.Unmarshal(output, &result))
+	assert.Equal(t, map[string]any{
+		"tier":   "gold",
+		"env":    "prod",
+		"region": "eu",
+		"owner":  map[string]any{"team": "payments", "slack": "#platform"},
+	}, result["second"]["labels"])
+}
+
+func TestMergeTagResolver_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "not a list", input: "labels: !merge {team: payments}", wantErr: "must be used with a list of maps"},
+		{name: "scalar element", input: "labels: !merge [{team: payments}, gold]", wantErr: "!merge element 1 must be a map"},
+		{name: "list element", input: "labels: !merge [[a, b]]", wantErr: "!merge element 0 must be a map, got a list"},
+		{name: "null element", input: "labels: !merge [null]", wantErr: "!merge element 0 must be a map, got null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewResolverRegistry()
+			registry.Register(NewMergeTagResolver())
+			_, err := registry.Process([]byte(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/speakeasy-api/openapi-overlay/pkg/overlay"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// applyOverlays applies OpenAPI Overlay (1.0) documents, in order, to the YAML or JSON
+// content of the file at path and returns the modified content. Overlay paths follow
+// the rules of the !file path.
+func (f *FileTagResolver) applyOverlays(path, ext string, data []byte, overlays []string) ([]byte, error) {
+	if len(overlays) == 0 {
+		return data, nil
+	}
+	switch ext {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, fmt.Errorf("overlays can only be applied to YAML or JSON files: %s", path)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, overlayPath := range overlays {
+		overlayPath = strings.TrimSpace(overlayPath)
+		overlayData, err := f.readOverlay(overlayPath)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc overlay.Overlay
+		if err := yaml.Unmarshal(overlayData, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse overlay %s: %w", overlayPath, err)
+		}
+		if err := doc.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid overlay %s: %w", overlayPath, err)
+		}
+		if err := doc.ApplyTo(&root); err != nil {
+			return nil, fmt.Errorf("failed to apply overlay %s to %s: %w", overlayPath, path, err)
+		}
+	}
+
+	result, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s with overlays: %w", path, err)
+	}
+	return result, nil
+}
+
+// readOverlay reads an overlay document from a local path or URL
+func (f *FileTagResolver) readOverlay(path string) ([]byte, error) {
+	if IsRemotePath(path) {
+		return f.remote.Fetch(context.Background(), path)
+	}
+	if err := f.validatePath(path); err != nil {
+		return nil, err
+	}
+	fullPath := f.resolvePath(path)
+	if err := f.validateResolvedPath(path, fullPath); err != nil {
+		return nil, err
+	}
+	return f.readFile(fullPath)
+}
+
+// overlaidCacheKey keeps content loaded with different overlays apart in the cache
+func overlaidCacheKey(cacheKey string, overlays []string) string {
+	if len(overlays) == 0 {
+		return cacheKey
+	}
+	return cacheKey + "+overlays:" + strings.Join(overlays, ",")
+}
@@ -0,0 +1,141 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+const overlaySpec = `openapi: 3.0.0
+info:
+  title: Orders
+  version: 1.0.0
+  description: Orders of the shop
+servers:
+  - url: https://dev.example.com
+paths:
+  /orders:
+    get:
+      summary: List orders
+      x-internal: true
+`
+
+func writeOverlayFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func resolveFileTag(t *testing.T, resolver *FileTagResolver, source string) (any, error) {
+	t.Helper()
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(source), &node))
+	return resolver.Resolve(node.Content[0])
+}
+
+func TestFileTagResolver_Overlays(t *testing.T) {
+	dir := writeOverlayFiles(t, map[string]string{
+		"spec.yaml": overlaySpec,
+		"overlays/prod-servers.yaml": `overlay: 1.0.0
+info:
+  title: Production servers
+  version: 1.0.0
+actions:
+  - target: $.servers
+    remove: true
+  - target: $
+    update:
+      servers:
+        - url: https://api.example.com
+  - target: $.info
+    update:
+      description: Orders of the shop, in production
+`,
+		"overlays/public.yaml": `overlay: 1.0.0
+info:
+  title: Public
+  version: 1.0.0
+actions:
+  - target: $.paths.*.*.x-internal
+    remove: true
+`,
+	})
+	resolver := NewFileTagResolver(dir, dir)
+
+	result, err := resolveFileTag(t, resolver,
+		"{path: spec.yaml, overlays: [overlays/prod-servers.yaml, overlays/public.yaml]}")
+	require.NoError(t, err)
+
+	spec, ok := result.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []any{map[string]any{"url": "https://api.example.com"}}, spec["servers"])
+	info := spec["info"].(map[string]any)
+	assert.Equal(t, "Orders", info["title"])
+	assert.Equal(t, "Orders of the shop, in production", info["description"])
+	get := spec["paths"].(map[string]any)["/orders"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, map[string]any{"summary": "List orders"}, get)
+
+	// The spec without overlays is cached apart
+	plain, err := resolveFileTag(t, resolver, "spec.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"url": "https://dev.example.com"}}, plain.(map[string]any)["servers"])
+
+	// Extraction applies to the overlaid spec
+	description, err := resolveFileTag(t, resolver,
+		"{path: spec.yaml, extract: info.description, overlays: [overlays/prod-servers.yaml]}")
+	require.NoError(t, err)
+	assert.Equal(t, "Orders of the shop, in production", description)
+}
+
+func TestFileTagResolver_OverlayErrors(t *testing.T) {
+	dir := writeOverlayFiles(t, map[string]string{
+		"spec.yaml":    overlaySpec,
+		"notes.md":     "# Notes",
+		"invalid.yaml": "overlay: 1.0.0\nactions: []\n",
+	})
+	resolver := NewFileTagResolver(dir, dir)
+
+	tests := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{
+			name:   "missing overlay",
+			source: "{path: spec.yaml, overlays: [missing.yaml]}",
+			errMsg: "file not found",
+		},
+		{
+			name:   "overlay outside the base dir",
+			source: "{path: spec.yaml, overlays: [../overlay.yaml]}",
+			errMsg: "path resolves outside base dir",
+		},
+		{
+			name:   "invalid overlay",
+			source: "{path: spec.yaml, overlays: [invalid.yaml]}",
+			errMsg: "invalid overlay invalid.yaml",
+		},
+		{
+			name:   "not a YAML or JSON file",
+			source: "{path: notes.md, overlays: [invalid.yaml]}",
+			errMsg: "overlays can only be applied to YAML or JSON files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveFileTag(t, resolver, tt.source)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRemoteTimeout bounds each fetch of a remote !file source
+const DefaultRemoteTimeout = 30 * time.Second
+
+// IsRemotePath reports whether a !file path names a remote source rather than a
+// local file
+func IsRemotePath(p string) bool {
+	scheme, _, ok := strings.Cut(p, "://")
+	return ok && scheme != "" && !strings.ContainsAny(scheme, `/\.`)
+}
+
+// RemoteFetcher downloads remote !file sources over HTTP(S). Each URL is fetched at
+// most once per fetcher, so one fetcher shared by a load fetches a document
+// referenced from several files or fields once. It is safe for concurrent use.
+type RemoteFetcher struct {
+	client  *http.Client
+	offline bool
+
+	mu    sync.Mutex
+	cache map[string][]byte
+}
+
+// NewRemoteFetcher creates a fetcher whose requests time out after timeout. An
+// offline fetcher fails every fetch, so loads cannot depend on the network.
+func NewRemoteFetcher(timeout time.Duration, offline bool) *RemoteFetcher {
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+	return &RemoteFetcher{
+		client:  &http.Client{Timeout: timeout},
+		offline: offline,
+		cache:   make(map[string][]byte),
+	}
+}
+
+// Check verifies that rawURL may be fetched, without fetching it
+func (r *RemoteFetcher) Check(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %s: %w", rawURL, err)
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+	case "s3", "gs":
+		return fmt.Errorf("%s:// sources are not supported, use a presigned https URL instead: %s",
+			parsed.Scheme, rawURL)
+	default:
+		return fmt.Errorf("unsupported URL scheme %q: %s", parsed.Scheme, rawURL)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("URL has no host: %s", rawURL)
+	}
+	if r.offline {
+		return fmt.Errorf("remote file %s cannot be loaded in offline mode", rawURL)
+	}
+	return nil
+}
+
+// Fetch returns the content at rawURL, downloading it on first use
+func (r *RemoteFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	if err := r.Check(rawURL); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if data, ok := r.cache[rawURL]; ok {
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %s: %w", rawURL, err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: HTTP %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("remote file %s is too large (max %d bytes)", rawURL, MaxFileSize)
+	}
+
+	r.cache[rawURL] = data
+	return data, nil
+}
+
+// remoteExtension returns the extension of the path of rawURL, which selects how
+// remote content is parsed as for local files
+func remoteExtension(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(path.Ext(parsed.Path))
+}
@@ -0,0 +1,114 @@
+package tags
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func newSpecServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/specs/orders.yaml":
+			_, _ = w.Write([]byte("info:\n  title: Orders\n  version: 2.1.0\n"))
+		case "/notes.txt":
+			_, _ = w.Write([]byte("Remote notes"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestFileTagResolver_RemoteSource(t *testing.T) {
+	server, requests := newSpecServer(t)
+	resolver := NewFileTagResolver(t.TempDir(), "")
+	resolver.SetRemoteFetcher(NewRemoteFetcher(DefaultRemoteTimeout, false))
+
+	resolve := func(value string) (any, error) {
+		return resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!file", Value: value})
+	}
+
+	version, err := resolve(server.URL + "/specs/orders.yaml#info.version")
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.0", version)
+
+	title, err := resolve(server.URL + "/specs/orders.yaml#info.title")
+	require.NoError(t, err)
+	assert.Equal(t, "Orders", title)
+
+	notes, err := resolve(server.URL + "/notes.txt?revision=3")
+	require.NoError(t, err)
+	assert.Equal(t, "Remote notes", notes)
+
+	// The spec is fetched once for both extractions
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestFileTagResolver_RemoteSourceErrors(t *testing.T) {
+	server, requests := newSpecServer(t)
+	resolver := NewFileTagResolver(t.TempDir(), "")
+
+	_, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!file", Value: server.URL + "/missing.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), server.URL+"/missing.yaml")
+	assert.Contains(t, err.Error(), "HTTP 404 Not Found")
+
+	_, err = resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!file", Value: "s3://bucket/spec.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use a presigned https URL")
+
+	resolver.SetRemoteFetcher(NewRemoteFetcher(DefaultRemoteTimeout, true))
+	requests.Store(0)
+	_, err = resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!file", Value: server.URL + "/notes.txt"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be loaded in offline mode")
+	assert.Equal(t, int32(0), requests.Load())
+	assert.Error(t, resolver.CheckFile(server.URL+"/notes.txt"))
+}
+
+func TestFileTagResolver_ChecksumPinning(t *testing.T) {
+	server, _ := newSpecServer(t)
+	resolver := NewFileTagResolver(t.TempDir(), "")
+	resolver.SetRemoteFetcher(NewRemoteFetcher(DefaultRemoteTimeout, false))
+
+	resolvePinned := func(checksum string) (any, error) {
+		var node yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(
+			"!file {path: "+server.URL+"/notes.txt, sha256: "+checksum+"}"), &node))
+		return resolver.Resolve(node.Content[0])
+	}
+
+	sum := func(content string) string {
+		digest := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(digest[:])
+	}
+
+	notes, err := resolvePinned(sum("Previous notes"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch for "+server.URL+"/notes.txt")
+	assert.Nil(t, notes)
+
+	notes, err = resolvePinned(sum("Remote notes"))
+	require.NoError(t, err)
+	assert.Equal(t, "Remote notes", notes)
+}
+
+func TestIsRemotePath(t *testing.T) {
+	assert.True(t, IsRemotePath("https://artifacts/spec.yaml"))
+	assert.True(t, IsRemotePath("http://localhost:8080/spec.yaml"))
+	assert.True(t, IsRemotePath("s3://bucket/spec.yaml"))
+	assert.False(t, IsRemotePath("./specs/spec.yaml"))
+	assert.False(t, IsRemotePath("specs/a://b.yaml"))
+}
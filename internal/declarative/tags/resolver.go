@@ -73,6 +73,14 @@ func (r *ResolverRegistry) processNode(node *yaml.Node) error {
 		r.mu.RUnlock()
 
 		if exists {
+			// Resolve the tags within the node first, so that e.g. !merge combines
+			// maps loaded with !file
+			for _, child := range node.Content {
+				if err := r.processNode(child); err != nil {
+					return err
+				}
+			}
+
 			// Resolve the tag
 			resolved, err := resolver.Resolve(node)
 			if err != nil {
@@ -0,0 +1,135 @@
+package tags
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// SecretPlaceholderPrefix is the special prefix for serialized secret placeholders
+const SecretPlaceholderPrefix = "__SECRET__:"
+
+// secretFingerprintSeparator separates the source of a placeholder from the fingerprint
+// of its value recorded when the plan was generated
+const secretFingerprintSeparator = "#sha256:"
+
+const (
+	secretSchemeEnv  = "env://"
+	secretSchemeFile = "file://"
+)
+
+// SecretTagResolver handles !secret tags for values read from a secrets backend at
+// apply time. The source is kept as a placeholder, so the value never appears in the
+// loaded configuration or in plan artifacts.
+type SecretTagResolver struct{}
+
+// NewSecretTagResolver creates a new secret tag resolver
+func NewSecretTagResolver() *SecretTagResolver {
+	return &SecretTagResolver{}
+}
+
+// Tag returns the YAML tag this resolver handles
+func (s *SecretTagResolver) Tag() string {
+	return "!secret"
+}
+
+// Resolve processes a YAML node with the !secret tag. The syntax is `!secret env://NAME`,
+// `!secret file:///absolute/path`, or the source of a secret store: `sops:///path#key`,
+// `vault://path#field` or `aws-sm://name#key`.
+func (s *SecretTagResolver) Resolve(node *yaml.Node) (any, error) {
+	// Only support scalar nodes
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("!secret tag must be used with a string, got %v", node.Kind)
+	}
+
+	source := strings.TrimSpace(node.Value)
+	if err := validateSecretSource(source); err != nil {
+		return nil, fmt.Errorf("!secret tag %w (line %d)", err, node.Line)
+	}
+	return SecretPlaceholder(source, ""), nil
+}
+
+func validateSecretSource(source string) error {
+	if ok, err := validateSecretStoreSource(source); ok {
+		return err
+	}
+	switch {
+	case strings.HasPrefix(source, secretSchemeEnv):
+		name := strings.TrimPrefix(source, secretSchemeEnv)
+		if !envVarNamePattern.MatchString(name) {
+			return fmt.Errorf("has invalid environment variable name %q", name)
+		}
+	case strings.HasPrefix(source, secretSchemeFile):
+		path := strings.TrimPrefix(source, secretSchemeFile)
+		if !filepath.IsAbs(path) {
+			return fmt.Errorf("requires an absolute file path, got %q", path)
+		}
+	default:
+		return fmt.Errorf("source %q must start with %s, %s, %s, %s or %s", source,
+			secretSchemeEnv, secretSchemeFile, secretSchemeSOPS, secretSchemeVault, secretSchemeAWS)
+	}
+	return nil
+}
+
+// SecretPlaceholder returns the placeholder of a secret source, with the fingerprint of
+// its value when known
+func SecretPlaceholder(source, fingerprint string) string {
+	if fingerprint == "" {
+		return SecretPlaceholderPrefix + source
+	}
+	return SecretPlaceholderPrefix + source + secretFingerprintSeparator + fingerprint
+}
+
+// IsSecretPlaceholder checks if a string is a secret placeholder
+func IsSecretPlaceholder(value string) bool {
+	return strings.HasPrefix(value, SecretPlaceholderPrefix)
+}
+
+// ParseSecretPlaceholder extracts the source and the fingerprint, if any, from a
+// placeholder string
+func ParseSecretPlaceholder(placeholder string) (source, fingerprint string, ok bool) {
+	if !IsSecretPlaceholder(placeholder) {
+		return "", "", false
+	}
+	source = strings.TrimPrefix(placeholder, SecretPlaceholderPrefix)
+	if idx := strings.LastIndex(source, secretFingerprintSeparator); idx != -1 {
+		source, fingerprint = source[:idx], source[idx+len(secretFingerprintSeparator):]
+	}
+	return source, fingerprint, source != ""
+}
+
+// SecretFingerprint returns the SHA-256 fingerprint of a secret value, so a value can
+// be compared with the one seen when the plan was generated without revealing it
+func SecretFingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// ResolveSecret reads the value of a secret source. Files are read as is, except for
+// a trailing newline.
+func ResolveSecret(source string) (string, error) {
+	if err := validateSecretSource(source); err != nil {
+		return "", fmt.Errorf("secret %w", err)
+	}
+	if !strings.HasPrefix(source, secretSchemeEnv) && !strings.HasPrefix(source, secretSchemeFile) {
+		return resolveSecretStoreSource(source)
+	}
+	if name, ok := strings.CutPrefix(source, secretSchemeEnv); ok {
+		value, set := os.LookupEnv(name)
+		if !set {
+			return "", fmt.Errorf("secret %s: environment variable %s is not set", source, name)
+		}
+		return value, nil
+	}
+
+	data, err := os.ReadFile(strings.TrimPrefix(source, secretSchemeFile))
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", source, err)
+	}
+	return trimTrailingNewline(string(data)), nil
+}
@@ -0,0 +1,220 @@
+package tags
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	secretSchemeSOPS  = "sops://"
+	secretSchemeVault = "vault://"
+	secretSchemeAWS   = "aws-sm://"
+
+	// secretKeySeparator separates the location of a secret from the key it is read from
+	secretKeySeparator = "#"
+
+	secretStoreTimeout = 30 * time.Second
+)
+
+// runSecretCommand runs the CLI of a secret store and returns its output. It is a
+// variable so tests do not need the CLIs installed.
+var runSecretCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, message)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+// vaultHTTPClient reads secrets from Vault
+var vaultHTTPClient = &http.Client{Timeout: secretStoreTimeout}
+
+// validateSecretStoreSource checks the sources read from a secret store. It reports
+// false for sources of other schemes.
+func validateSecretStoreSource(source string) (bool, error) {
+	switch {
+	case strings.HasPrefix(source, secretSchemeSOPS):
+		path, _, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeSOPS), secretKeySeparator)
+		if !filepath.IsAbs(path) {
+			return true, fmt.Errorf("requires an absolute SOPS file path, got %q", path)
+		}
+	case strings.HasPrefix(source, secretSchemeVault):
+		path, field, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeVault), secretKeySeparator)
+		if strings.Trim(path, "/") == "" || field == "" {
+			return true, fmt.Errorf("requires a Vault path and field, as vault://secret/data/name#field, got %q",
+				source)
+		}
+	case strings.HasPrefix(source, secretSchemeAWS):
+		id, _, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeAWS), secretKeySeparator)
+		if id == "" {
+			return true, fmt.Errorf("requires an AWS Secrets Manager secret name or ARN, got %q", source)
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// resolveSecretStoreSource reads a source of a secret store scheme. The source must
+// have been validated.
+func resolveSecretStoreSource(source string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretStoreTimeout)
+	defer cancel()
+
+	var (
+		value string
+		err   error
+	)
+	switch {
+	case strings.HasPrefix(source, secretSchemeSOPS):
+		path, key, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeSOPS), secretKeySeparator)
+		value, err = resolveSOPSSecret(ctx, path, key)
+	case strings.HasPrefix(source, secretSchemeVault):
+		path, field, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeVault), secretKeySeparator)
+		value, err = resolveVaultSecret(ctx, strings.Trim(path, "/"), field)
+	default:
+		id, key, _ := strings.Cut(strings.TrimPrefix(source, secretSchemeAWS), secretKeySeparator)
+		value, err = resolveAWSSecret(ctx, id, key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("secret %s: %w", source, err)
+	}
+	return value, nil
+}
+
+// resolveSOPSSecret decrypts a SOPS file with the sops CLI. A key, dot separated for
+// nested keys, is extracted from YAML and JSON files; without one the whole decrypted
+// file is the value.
+func resolveSOPSSecret(ctx context.Context, path, key string) (string, error) {
+	args := []string{"--decrypt"}
+	if key != "" {
+		var extract strings.Builder
+		for segment := range strings.SplitSeq(key, ".") {
+			data, _ := json.Marshal(segment)
+			fmt.Fprintf(&extract, "[%s]", data)
+		}
+		args = append(args, "--extract", extract.String())
+	}
+	out, err := runSecretCommand(ctx, "sops", append(args, path)...)
+	if err != nil {
+		return "", err
+	}
+	return trimTrailingNewline(string(out)), nil
+}
+
+// resolveVaultSecret reads a field of a Vault secret through the HTTP API, using the
+// VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE environment variables like the vault CLI.
+// Both KV version 1 and version 2 responses are supported.
+func resolveVaultSecret(ctx context.Context, path, field string) (string, error) {
+	addr := strings.TrimRight(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			data, _ := os.ReadFile(filepath.Join(home, ".vault-token"))
+			token = strings.TrimSpace(string(data))
+		}
+	}
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set and there is no ~/.vault-token")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := vaultHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("failed to parse vault response: %w", err)
+	}
+	data := secret.Data
+	// KV version 2 nests the fields under data.data, next to data.metadata
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+	return secretField(data, field)
+}
+
+// resolveAWSSecret reads the string of an AWS Secrets Manager secret with the aws CLI,
+// which finds credentials and the region as usual. A key reads that field of a JSON
+// secret.
+func resolveAWSSecret(ctx context.Context, id, key string) (string, error) {
+	out, err := runSecretCommand(ctx, "aws", "secretsmanager", "get-secret-value",
+		"--query", "SecretString", "--output", "text", "--secret-id", id)
+	if err != nil {
+		return "", err
+	}
+	value := trimTrailingNewline(string(out))
+	if key == "" {
+		return value, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object, so it has no key %q", key)
+	}
+	return secretField(fields, key)
+}
+
+// secretField returns a field of a secret as a string
+func secretField(fields map[string]any, field string) (string, error) {
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found", field)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", fmt.Errorf("field %q is null", field)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func trimTrailingNewline(value string) string {
+	value = strings.TrimSuffix(value, "\n")
+	return strings.TrimSuffix(value, "\r")
+}
@@ -0,0 +1,114 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSecretCommand replaces the secret store CLIs with output for each command line
+func stubSecretCommand(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	original := runSecretCommand
+	t.Cleanup(func() { runSecretCommand = original })
+	runSecretCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		line := strings.Join(append([]string{name}, args...), " ")
+		out, ok := outputs[line]
+		if !ok {
+			return nil, errors.New("unexpected command: " + line)
+		}
+		return []byte(out), nil
+	}
+}
+
+func TestResolveSecret_SOPS(t *testing.T) {
+	stubSecretCommand(t, map[string]string{
+		`sops --decrypt --extract ["oidc"]["client_secret"] /etc/kongctl/secrets.enc.yaml`: "from-sops\n",
+		`sops --decrypt /etc/kongctl/token.enc`:                                            "whole-file\n",
+	})
+
+	value, err := ResolveSecret("sops:///etc/kongctl/secrets.enc.yaml#oidc.client_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "from-sops", value)
+
+	value, err = ResolveSecret("sops:///etc/kongctl/token.enc")
+	require.NoError(t, err)
+	assert.Equal(t, "whole-file", value)
+
+	_, err = ResolveSecret("sops:///etc/kongctl/other.enc.yaml#key")
+	assert.ErrorContains(t, err, "secret sops:///etc/kongctl/other.enc.yaml#key: unexpected command")
+}
+
+func TestResolveSecret_AWS(t *testing.T) {
+	const getSecret = "aws secretsmanager get-secret-value --query SecretString --output text --secret-id "
+	stubSecretCommand(t, map[string]string{
+		getSecret + "prod/oidc": `{"client_secret":"s3cr3t","port":8443}` + "\n",
+		getSecret + "plain":     "plain-value\n",
+	})
+
+	value, err := ResolveSecret("aws-sm://prod/oidc#client_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	value, err = ResolveSecret("aws-sm://prod/oidc#port")
+	require.NoError(t, err)
+	assert.Equal(t, "8443", value)
+
+	value, err = ResolveSecret("aws-sm://plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-value", value)
+
+	_, err = ResolveSecret("aws-sm://prod/oidc#missing")
+	assert.EqualError(t, err, `secret aws-sm://prod/oidc#missing: field "missing" not found`)
+
+	_, err = ResolveSecret("aws-sm://plain#key")
+	assert.ErrorContains(t, err, "is not a JSON object")
+}
+
+func TestResolveSecret_Vault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/secret/data/kongctl/oidc":
+			_, _ = w.Write([]byte(`{"data":{"data":{"client_secret":"kv2-value"},"metadata":{"version":3}}}`))
+		case "/v1/kv/kongctl":
+			_, _ = w.Write([]byte(`{"data":{"client_secret":"kv1-value"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("VAULT_ADDR", server.URL+"/")
+	t.Setenv("VAULT_TOKEN", "root-token")
+	t.Setenv("VAULT_NAMESPACE", "team-a")
+
+	value, err := ResolveSecret("vault://secret/data/kongctl/oidc#client_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2-value", value)
+
+	value, err = ResolveSecret("vault:///kv/kongctl#client_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-value", value)
+
+	_, err = ResolveSecret("vault://secret/data/missing#client_secret")
+	assert.EqualError(t, err, "secret vault://secret/data/missing#client_secret: vault returned 404 Not Found")
+
+	t.Setenv("VAULT_TOKEN", "wrong")
+	_, err = ResolveSecret("vault://kv/kongctl#client_secret")
+	assert.ErrorContains(t, err, "403 Forbidden")
+
+	t.Setenv("VAULT_ADDR", "")
+	_, err = ResolveSecret("vault://kv/kongctl#client_secret")
+	assert.ErrorContains(t, err, "VAULT_ADDR is not set")
+}
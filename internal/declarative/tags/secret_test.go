@@ -0,0 +1,74 @@
+package tags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestSecretTagResolver_Resolve(t *testing.T) {
+	resolver := NewSecretTagResolver()
+	assert.Equal(t, "!secret", resolver.Tag())
+
+	value, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Value: " env://PORTAL_SMTP_PASSWORD "})
+	require.NoError(t, err)
+	assert.Equal(t, "__SECRET__:env://PORTAL_SMTP_PASSWORD", value, "the secret is not read at load time")
+
+	value, err = resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Value: "file:///run/secrets/smtp"})
+	require.NoError(t, err)
+	assert.Equal(t, "__SECRET__:file:///run/secrets/smtp", value)
+
+	for input, wantErr := range map[string]string{
+		"keyring://smtp":      `source "keyring://smtp" must start with env://, file://, sops://, vault:// or aws-sm://`,
+		"vault://kv/smtp":     `requires a Vault path and field`,
+		"sops://secrets.yaml": `requires an absolute SOPS file path, got "secrets.yaml"`,
+		"aws-sm://#password":  `requires an AWS Secrets Manager secret name or ARN`,
+		"env://NOT-VALID":     `invalid environment variable name "NOT-VALID"`,
+		"file://secrets/smtp": `requires an absolute file path, got "secrets/smtp"`,
+	} {
+		_, err := resolver.Resolve(&yaml.Node{Kind: yaml.ScalarNode, Value: input, Line: 4})
+		require.ErrorContains(t, err, wantErr, input)
+		assert.ErrorContains(t, err, "(line 4)")
+	}
+
+	_, err = resolver.Resolve(&yaml.Node{Kind: yaml.MappingNode})
+	assert.ErrorContains(t, err, "must be used with a string")
+}
+
+func TestParseSecretPlaceholder(t *testing.T) {
+	source, fingerprint, ok := ParseSecretPlaceholder(SecretPlaceholder("file:///run/a#b", "abc"))
+	require.True(t, ok)
+	assert.Equal(t, "file:///run/a#b", source)
+	assert.Equal(t, "abc", fingerprint)
+
+	source, fingerprint, ok = ParseSecretPlaceholder(SecretPlaceholder("env://TOKEN", ""))
+	require.True(t, ok)
+	assert.Equal(t, "env://TOKEN", source)
+	assert.Empty(t, fingerprint)
+
+	_, _, ok = ParseSecretPlaceholder("__REF__:portal#id")
+	assert.False(t, ok)
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("KONGCTL_TEST_SECRET", "s3cr3t")
+	value, err := ResolveSecret("env://KONGCTL_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = ResolveSecret("env://KONGCTL_TEST_UNSET")
+	assert.EqualError(t, err, "secret env://KONGCTL_TEST_UNSET: environment variable KONGCTL_TEST_UNSET is not set")
+
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+	value, err = ResolveSecret("file://" + path)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value, "the trailing newline is dropped")
+
+	_, err = ResolveSecret("file://" + filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "no such file or directory")
+}
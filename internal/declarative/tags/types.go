@@ -17,6 +17,9 @@ type TagResolver interface {
 type FileRef struct {
 	Path    string `yaml:"path"`    // Path to the file to load
 	Extract string `yaml:"extract"` // Optional: path to extract value (e.g., "info.title")
+	SHA256  string `yaml:"sha256"`  // Optional: hex SHA-256 the content must match
+	// Optional: OpenAPI Overlay documents applied in order to the YAML or JSON content
+	Overlays []string `yaml:"overlays"`
 }
 
 // ResolvedValue represents a value that was resolved from a tag
@@ -0,0 +1,212 @@
+package tags
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+// VariablesKey is the top-level key of the section declaring the variables of a file
+const VariablesKey = "variables"
+
+var (
+	// VariableNamePattern matches the names of variables
+	VariableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	// variableReferencePattern matches ${var.name} references and the $${ escape of a
+	// literal ${
+	variableReferencePattern = regexp.MustCompile(`\$\$\{|\$\{var\.([^}]*)\}`)
+)
+
+// HasVariableReferences reports whether data may reference variables, so content
+// without any is left untouched
+func HasVariableReferences(data []byte) bool {
+	return bytes.Contains(data, []byte("${"))
+}
+
+// DeclaredVariables returns the variables of the top-level variables section of YAML
+// data, or nil when it has none
+func DeclaredVariables(data []byte) (map[string]any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != VariablesKey {
+			continue
+		}
+		section := root.Content[i+1]
+		if section.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s must be a map of names to values (line %d)", VariablesKey, section.Line)
+		}
+		var variables map[string]any
+		if err := section.Decode(&variables); err != nil {
+			return nil, fmt.Errorf("invalid %s section (line %d): %w", VariablesKey, section.Line, err)
+		}
+		if err := ValidateVariableNames(variables); err != nil {
+			return nil, err
+		}
+		return variables, nil
+	}
+	return nil, nil
+}
+
+// ValidateVariableNames checks the names of variables against VariableNamePattern
+func ValidateVariableNames(variables map[string]any) error {
+	for name := range variables {
+		if !VariableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q: names are letters, digits, hyphens and underscores, "+
+				"starting with a letter or underscore", name)
+		}
+	}
+	return nil
+}
+
+// InterpolateVariables replaces the ${var.name} references in the values of YAML data
+// with the values of variables. An unquoted value that is only a reference takes the
+// value with its type, so numbers, booleans, maps and lists can be set, while quoted
+// values and references within a longer string are replaced as text. $${ writes a
+// literal ${. The values of the variables section and of mapping keys are left as
+// they are. Every reference to an undefined variable is reported with its line.
+func InterpolateVariables(data []byte, sourcePath string, variables map[string]any) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	interpolator := &variableInterpolator{variables: variables}
+	if len(doc.Content) > 0 {
+		interpolator.walk(doc.Content[0], true)
+	}
+	if len(interpolator.problems) > 0 {
+		sort.SliceStable(interpolator.problems, func(i, j int) bool {
+			return interpolator.problems[i].line < interpolator.problems[j].line
+		})
+		lines := make([]string, 0, len(interpolator.problems))
+		for _, problem := range interpolator.problems {
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", sourcePath, problem.line, problem.message))
+		}
+		if len(lines) == 1 {
+			return nil, errors.New(lines[0])
+		}
+		return nil, fmt.Errorf("%d variable references cannot be resolved:\n  %s",
+			len(lines), strings.Join(lines, "\n  "))
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type variableProblem struct {
+	line    int
+	message string
+}
+
+type variableInterpolator struct {
+	variables map[string]any
+	problems  []variableProblem
+}
+
+func (v *variableInterpolator) walk(node *yaml.Node, root bool) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if root && node.Content[i].Value == VariablesKey {
+				continue
+			}
+			v.walk(node.Content[i+1], false)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			v.walk(child, false)
+		}
+	case yaml.ScalarNode:
+		v.interpolate(node)
+	case yaml.DocumentNode, yaml.AliasNode:
+		// Aliases share the node they point to, which is interpolated where it is defined
+	}
+}
+
+func (v *variableInterpolator) interpolate(node *yaml.Node) {
+	matches := variableReferencePattern.FindAllStringSubmatchIndex(node.Value, -1)
+	if len(matches) == 0 {
+		return
+	}
+
+	// A tagged value, such as the path of a !file tag, is text for its tag to read
+	tagged := strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!")
+	quoted := node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0
+	whole := len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(node.Value) && matches[0][2] >= 0
+	if whole && !tagged && !quoted {
+		name := node.Value[matches[0][2]:matches[0][3]]
+		value, ok := v.lookup(name, node.Line)
+		if !ok {
+			return
+		}
+		replacement := &yaml.Node{}
+		if err := replacement.Encode(value); err != nil {
+			v.problems = append(v.problems, variableProblem{node.Line, fmt.Sprintf("variable %q: %v", name, err)})
+			return
+		}
+		*node = *replacement
+		return
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, match := range matches {
+		out.WriteString(node.Value[last:match[0]])
+		last = match[1]
+		if match[2] < 0 {
+			out.WriteString("${")
+			continue
+		}
+		name := node.Value[match[2]:match[3]]
+		value, ok := v.lookup(name, node.Line)
+		if !ok {
+			continue
+		}
+		switch value.(type) {
+		case map[string]any, []any:
+			v.problems = append(v.problems, variableProblem{node.Line,
+				fmt.Sprintf("variable %q holds a map or list and can only be used as a whole value", name)})
+			continue
+		}
+		if value != nil {
+			fmt.Fprint(&out, value)
+		}
+	}
+	out.WriteString(node.Value[last:])
+
+	node.Value = out.String()
+	if !tagged {
+		// The text is a string, even when it reads as a number or boolean
+		node.Tag = "!!str"
+	}
+}
+
+func (v *variableInterpolator) lookup(name string, line int) (any, bool) {
+	if !VariableNamePattern.MatchString(name) {
+		v.problems = append(v.problems, variableProblem{line, fmt.Sprintf("invalid variable name %q", name)})
+		return nil, false
+	}
+	value, ok := v.variables[name]
+	if !ok {
+		v.problems = append(v.problems, variableProblem{line, fmt.Sprintf("undefined variable %q", name)})
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,82 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3" //nolint:gomodguard // yaml.v3 required for custom tag processing
+)
+
+func TestInterpolateVariables(t *testing.T) {
+	variables := map[string]any{
+		"env":    "prod",
+		"rbac":   true,
+		"port":   8443,
+		"labels": map[string]any{"team": "payments"},
+	}
+	input := `
+variables:
+  env: ${var.unused}
+portals:
+  - ref: portal
+    name: portal-${var.env}
+    description: "Costs $${price} in ${var.env}"
+    rbac_enabled: ${var.rbac}
+    port: ${var.port}
+    quoted: "${var.port}"
+    labels: ${var.labels}
+    spec: !file ./specs/${var.env}.yaml
+    ${var.env}: key
+`
+	out, err := InterpolateVariables([]byte(input), "portal.yaml", variables)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &doc))
+	assert.Equal(t, map[string]any{"env": "${var.unused}"}, doc["variables"], "declarations are left as they are")
+	portal := doc["portals"].([]any)[0].(map[string]any)
+	assert.Equal(t, "portal-prod", portal["name"])
+	assert.Equal(t, "Costs ${price} in prod", portal["description"])
+	assert.Equal(t, true, portal["rbac_enabled"])
+	assert.Equal(t, 8443, portal["port"])
+	assert.Equal(t, "8443", portal["quoted"])
+	assert.Equal(t, map[string]any{"team": "payments"}, portal["labels"])
+	assert.Equal(t, "key", portal["${var.env}"], "keys are not interpolated")
+	assert.Contains(t, string(out), "!file ./specs/prod.yaml")
+}
+
+func TestInterpolateVariables_Errors(t *testing.T) {
+	input := `
+portals:
+  - ref: portal
+    name: ${var.missing}
+    description: about ${var.labels}
+    display_name: ${var.bad name}
+`
+	_, err := InterpolateVariables([]byte(input), "portal.yaml", map[string]any{"labels": map[string]any{}})
+	require.Error(t, err)
+	assert.Equal(t, "3 variable references cannot be resolved:\n"+
+		"  portal.yaml:4: undefined variable \"missing\"\n"+
+		"  portal.yaml:5: variable \"labels\" holds a map or list and can only be used as a whole value\n"+
+		"  portal.yaml:6: invalid variable name \"bad name\"", err.Error())
+
+	_, err = InterpolateVariables([]byte("name: ${var.missing}\n"), "portal.yaml", nil)
+	assert.EqualError(t, err, `portal.yaml:1: undefined variable "missing"`)
+}
+
+func TestDeclaredVariables(t *testing.T) {
+	variables, err := DeclaredVariables([]byte("variables:\n  env: dev\n  replicas: 2\nportals: []\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"env": "dev", "replicas": 2}, variables)
+
+	variables, err = DeclaredVariables([]byte("portals: []\n"))
+	require.NoError(t, err)
+	assert.Nil(t, variables)
+
+	_, err = DeclaredVariables([]byte("variables: [env]\n"))
+	assert.ErrorContains(t, err, "must be a map")
+
+	_, err = DeclaredVariables([]byte("variables:\n  1env: dev\n"))
+	assert.ErrorContains(t, err, `invalid variable name "1env"`)
+}
@@ -0,0 +1,236 @@
+// Package analytics summarizes the API usage recorded by Konnect Analytics, such as
+// request counts, latencies and error rates, without sending any telemetry of its own.
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kong/kongctl/internal/konnect/apiutil"
+)
+
+// ExplorePath is the Konnect Analytics endpoint answering explore queries
+const ExplorePath = "/v2/analytics/explore"
+
+const (
+	datasourceAPIUsage = "api_usage"
+
+	dimensionAPI         = "api"
+	dimensionPortal      = "portal"
+	dimensionStatusGroup = "status_code_grouped"
+
+	metricRequestCount   = "request_count"
+	metricLatencyAverage = "response_latency_average"
+	metricLatencyP99     = "response_latency_p99"
+)
+
+// TimeRanges are the relative time ranges Konnect Analytics accepts, from now
+var TimeRanges = []string{"15m", "1h", "6h", "12h", "24h", "7d", "30d"}
+
+// DefaultTimeRange is the time range summarized when none is given
+const DefaultTimeRange = "7d"
+
+// UsageRequest selects the usage to summarize
+type UsageRequest struct {
+	// APIIDs limits the summary to these APIs; empty summarizes every API
+	APIIDs []string
+	// PortalIDs limits the summary to requests made through these portals
+	PortalIDs []string
+	// TimeRange is one of TimeRanges
+	TimeRange string
+}
+
+// Usage summarizes the requests of one API over the time range
+type Usage struct {
+	APIID   string `json:"api_id" yaml:"api_id"`
+	APIName string `json:"api_name" yaml:"api_name"`
+	// TimeRange is the relative time range of the summary, e.g. 7d
+	TimeRange    string `json:"time_range" yaml:"time_range"`
+	Requests     int64  `json:"requests" yaml:"requests"`
+	ClientErrors int64  `json:"client_errors" yaml:"client_errors"`
+	ServerErrors int64  `json:"server_errors" yaml:"server_errors"`
+	// ErrorRate is the share of requests answered with a 4xx or 5xx status, from 0 to 1
+	ErrorRate        float64 `json:"error_rate" yaml:"error_rate"`
+	LatencyAverageMs float64 `json:"latency_average_ms" yaml:"latency_average_ms"`
+	LatencyP99Ms     float64 `json:"latency_p99_ms" yaml:"latency_p99_ms"`
+}
+
+// Client queries Konnect Analytics
+type Client struct {
+	Doer    apiutil.Doer
+	BaseURL string
+	Token   string
+}
+
+// ValidateTimeRange checks a relative time range
+func ValidateTimeRange(timeRange string) error {
+	if !slices.Contains(TimeRanges, timeRange) {
+		return fmt.Errorf("invalid time range %q, must be one of %s", timeRange, strings.Join(TimeRanges, ", "))
+	}
+	return nil
+}
+
+// Usage returns the usage of each API with requests in the time range, busiest first
+func (c *Client) Usage(ctx context.Context, req UsageRequest) ([]Usage, error) {
+	if req.TimeRange == "" {
+		req.TimeRange = DefaultTimeRange
+	}
+	if err := ValidateTimeRange(req.TimeRange); err != nil {
+		return nil, err
+	}
+
+	// Averages and percentiles cannot be combined across status groups, so the
+	// statuses are counted by a query of their own
+	totals, err := c.explore(ctx, req, []string{dimensionAPI},
+		[]string{metricRequestCount, metricLatencyAverage, metricLatencyP99})
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := c.explore(ctx, req, []string{dimensionAPI, dimensionStatusGroup},
+		[]string{metricRequestCount})
+	if err != nil {
+		return nil, err
+	}
+
+	byAPI := map[string]*Usage{}
+	for _, event := range totals.events() {
+		id := event.dimension(dimensionAPI)
+		byAPI[id] = &Usage{
+			APIID:            id,
+			APIName:          totals.displayName(dimensionAPI, id),
+			TimeRange:        req.TimeRange,
+			Requests:         int64(event.metric(metricRequestCount)),
+			LatencyAverageMs: event.metric(metricLatencyAverage),
+			LatencyP99Ms:     event.metric(metricLatencyP99),
+		}
+	}
+	for _, event := range statuses.events() {
+		usage, ok := byAPI[event.dimension(dimensionAPI)]
+		if !ok {
+			continue
+		}
+		count := int64(event.metric(metricRequestCount))
+		switch strings.ToUpper(event.dimension(dimensionStatusGroup)) {
+		case "4XX":
+			usage.ClientErrors += count
+		case "5XX":
+			usage.ServerErrors += count
+		}
+	}
+
+	usages := make([]Usage, 0, len(byAPI))
+	for _, usage := range byAPI {
+		if usage.Requests > 0 {
+			usage.ErrorRate = float64(usage.ClientErrors+usage.ServerErrors) / float64(usage.Requests)
+		}
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Requests != usages[j].Requests {
+			return usages[i].Requests > usages[j].Requests
+		}
+		return usages[i].APIName < usages[j].APIName
+	})
+	return usages, nil
+}
+
+type exploreQuery struct {
+	Datasource string          `json:"datasource"`
+	Metrics    []string        `json:"metrics"`
+	Dimensions []string        `json:"dimensions"`
+	Filters    []exploreFilter `json:"filters,omitempty"`
+	TimeRange  exploreRange    `json:"time_range"`
+}
+
+type exploreFilter struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Value    []string `json:"value"`
+}
+
+type exploreRange struct {
+	Type      string `json:"type"`
+	TimeRange string `json:"time_range"`
+}
+
+type exploreResult struct {
+	Data []struct {
+		Event exploreEvent `json:"event"`
+	} `json:"data"`
+	Meta struct {
+		// Display names the values of each dimension, by ID
+		Display map[string]map[string]struct {
+			Name string `json:"name"`
+		} `json:"display"`
+	} `json:"meta"`
+}
+
+type exploreEvent map[string]any
+
+func (r *exploreResult) events() []exploreEvent {
+	events := make([]exploreEvent, 0, len(r.Data))
+	for _, record := range r.Data {
+		events = append(events, record.Event)
+	}
+	return events
+}
+
+// displayName returns the name of a dimension value, or the value when it has none
+func (r *exploreResult) displayName(dimension, id string) string {
+	if name := r.Meta.Display[dimension][id].Name; name != "" {
+		return name
+	}
+	return id
+}
+
+func (e exploreEvent) dimension(name string) string {
+	value, _ := e[name].(string)
+	return value
+}
+
+func (e exploreEvent) metric(name string) float64 {
+	value, _ := e[name].(float64)
+	return value
+}
+
+func (c *Client) explore(ctx context.Context, req UsageRequest, dimensions, metrics []string) (*exploreResult, error) {
+	query := exploreQuery{
+		Datasource: datasourceAPIUsage,
+		Metrics:    metrics,
+		Dimensions: dimensions,
+		TimeRange:  exploreRange{Type: "relative", TimeRange: req.TimeRange},
+	}
+	if len(req.APIIDs) > 0 {
+		query.Filters = append(query.Filters, exploreFilter{Field: dimensionAPI, Operator: "in", Value: req.APIIDs})
+	}
+	if len(req.PortalIDs) > 0 {
+		query.Filters = append(query.Filters,
+			exploreFilter{Field: dimensionPortal, Operator: "in", Value: req.PortalIDs})
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := apiutil.Request(ctx, c.Doer, http.MethodPost, c.BaseURL, ExplorePath, c.Token,
+		map[string]string{"Content-Type": "application/json"}, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if result.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("analytics query failed: status %d: %s",
+			result.StatusCode, strings.TrimSpace(string(result.Body)))
+	}
+
+	var explored exploreResult
+	if err := json.Unmarshal(result.Body, &explored); err != nil {
+		return nil, fmt.Errorf("failed to decode analytics response: %w", err)
+	}
+	return &explored, nil
+}
@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUsage(t *testing.T) {
+	var queries []exploreQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ExplorePath, r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var query exploreQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		queries = append(queries, query)
+
+		display := `"display":{"api":{"api-1":{"name":"orders"},"api-2":{"name":"billing"}}}`
+		if len(query.Dimensions) == 1 {
+			_, _ = w.Write([]byte(`{"data":[
+				{"event":{"api":"api-2","request_count":40,"response_latency_average":12.5,"response_latency_p99":80}},
+				{"event":{"api":"api-1","request_count":200,"response_latency_average":30,"response_latency_p99":250}}
+			],"meta":{` + display + `}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[
+			{"event":{"api":"api-1","status_code_grouped":"2XX","request_count":180}},
+			{"event":{"api":"api-1","status_code_grouped":"4XX","request_count":15}},
+			{"event":{"api":"api-1","status_code_grouped":"5XX","request_count":5}},
+			{"event":{"api":"api-2","status_code_grouped":"2XX","request_count":40}}
+		],"meta":{` + display + `}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{Doer: server.Client(), BaseURL: server.URL, Token: "token"}
+	usages, err := client.Usage(context.Background(), UsageRequest{
+		APIIDs:    []string{"api-1", "api-2"},
+		PortalIDs: []string{"portal-1"},
+		TimeRange: "24h",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Usage{
+		{APIID: "api-1", APIName: "orders", TimeRange: "24h", Requests: 200, ClientErrors: 15, ServerErrors: 5,
+			ErrorRate: 0.1, LatencyAverageMs: 30, LatencyP99Ms: 250},
+		{APIID: "api-2", APIName: "billing", TimeRange: "24h", Requests: 40,
+			LatencyAverageMs: 12.5, LatencyP99Ms: 80},
+	}, usages)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, exploreQuery{
+		Datasource: "api_usage",
+		Metrics:    []string{"request_count", "response_latency_average", "response_latency_p99"},
+		Dimensions: []string{"api"},
+		Filters: []exploreFilter{
+			{Field: "api", Operator: "in", Value: []string{"api-1", "api-2"}},
+			{Field: "portal", Operator: "in", Value: []string{"portal-1"}},
+		},
+		TimeRange: exploreRange{Type: "relative", TimeRange: "24h"},
+	}, queries[0])
+	assert.Equal(t, []string{"api", "status_code_grouped"}, queries[1].Dimensions)
+}
+
+func TestClientUsage_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+	}))
+	defer server.Close()
+	client := &Client{Doer: server.Client(), BaseURL: server.URL}
+
+	_, err := client.Usage(context.Background(), UsageRequest{})
+	assert.EqualError(t, err, `analytics query failed: status 403: {"message":"forbidden"}`)
+
+	_, err = client.Usage(context.Background(), UsageRequest{TimeRange: "2w"})
+	assert.ErrorContains(t, err, `invalid time range "2w"`)
+}
@@ -4,15 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -24,7 +21,6 @@ import (
 
 	"github.com/kong/kongctl/internal/config"
 	"github.com/kong/kongctl/internal/konnect/httpclient"
-	"github.com/kong/kongctl/internal/log"
 )
 
 var (
@@ -282,16 +278,18 @@ func PollForToken(ctx context.Context, httpClient *http.Client,
 	return &rv, nil
 }
 
-// For a given profile, load a saved token from disk in the same path as the config path.
-// * If there is no file, return error.
+// For a given profile, load a saved token from the configured credential store.
+// * If there is no saved token, return error.
 // * If it's not expired, return it.
 // * If it's expired, refresh it, then store it, then return it
 func LoadAccessToken(cfg config.Hook, refreshURL string, logger *slog.Logger) (*AccessToken, error) {
 	profile := cfg.GetProfile()
-	cfgPath := filepath.Dir(cfg.GetPath())
-	credsPath := filepath.Join(cfgPath, getCredentialFileName(profile))
+	store, err := credentialStoreFor(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	creds, err := loadAccessTokenFromDisk(credsPath)
+	creds, err := loadAccessToken(store, profile)
 	if err != nil {
 		return nil, err
 	}
@@ -302,25 +300,25 @@ func LoadAccessToken(cfg config.Hook, refreshURL string, logger *slog.Logger) (*
 		if err != nil {
 			return nil, err
 		}
-		logger.Info("Token refreshed. Saving to disk",
+		logger.Info("Token refreshed. Saving",
 			"received_at", creds.ReceivedAt,
 			"expires_after", creds.Token.ExpiresAfter,
-			"creds_path", credsPath)
-		err = saveAccessTokenToDisk(credsPath, creds)
+			"credential_store", credentialStoreName(cfg))
+		err = saveAccessToken(store, profile, creds)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		logger.Info("Token loaded from disk",
+		logger.Info("Token loaded",
 			"expires_after", creds.Token.ExpiresAfter,
 			"received_at", creds.ReceivedAt,
-			"creds_path", credsPath)
+			"credential_store", credentialStoreName(cfg))
 	}
 	return creds, nil
 }
 
-func loadAccessTokenFromDisk(path string) (*AccessToken, error) {
-	data, err := os.ReadFile(path)
+func loadAccessToken(store credentialStore, profile string) (*AccessToken, error) {
+	data, err := store.load(profile)
 	if err != nil {
 		return nil, err
 	}
@@ -335,44 +333,97 @@ func loadAccessTokenFromDisk(path string) (*AccessToken, error) {
 }
 
 func SaveAccessToken(cfg config.Hook, token *AccessToken) error {
-	profile := cfg.GetProfile()
-	cfgPath := filepath.Dir(cfg.GetPath())
-	credsPath := filepath.Join(cfgPath, getCredentialFileName(profile))
+	store, err := credentialStoreFor(cfg)
+	if err != nil {
+		return err
+	}
+	return saveAccessToken(store, cfg.GetProfile(), token)
+}
 
-	return saveAccessTokenToDisk(credsPath, token)
+// HasAccessToken reports whether a token saved by login exists for the profile of cfg
+func HasAccessToken(cfg config.Hook) bool {
+	store, err := credentialStoreFor(cfg)
+	if err != nil {
+		return false
+	}
+	_, err = store.load(cfg.GetProfile())
+	return err == nil
 }
 
 func DeleteAccessToken(cfg config.Hook) (bool, error) {
-	profile := cfg.GetProfile()
-	cfgPath := filepath.Dir(cfg.GetPath())
-	credsPath := filepath.Join(cfgPath, getCredentialFileName(profile))
+	store, err := credentialStoreFor(cfg)
+	if err != nil {
+		return false, err
+	}
+	return store.delete(cfg.GetProfile())
+}
 
-	err := os.Remove(credsPath)
+// RevokeAccessToken ends the Konnect session of the tokens saved for the profile of cfg,
+// so the refresh token cannot be used again. It reports false when no refresh token is saved.
+func RevokeAccessToken(cfg config.Hook, logoutURL string) (bool, error) {
+	store, err := credentialStoreFor(cfg)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return false, nil
-		}
 		return false, err
 	}
+	token, err := loadAccessToken(store, cfg.GetProfile())
+	if err != nil || token.Token == nil || token.Token.RefreshToken == "" {
+		return false, nil
+	}
 
+	cookieURL, err := url.Parse(logoutURL)
+	if err != nil {
+		return false, err
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return false, err
+	}
+	jar.SetCookies(cookieURL, []*http.Cookie{
+		{
+			Name:  "konnectrefreshtoken",
+			Value: token.Token.RefreshToken,
+		},
+	})
+
+	httpClient := &http.Client{
+		Jar:     jar,
+		Timeout: 30 * time.Second,
+	}
+	res, err := httpClient.Post(logoutURL, "application/json", nil)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	// An expired session has nothing left to revoke
+	if res.StatusCode >= 300 && res.StatusCode != http.StatusUnauthorized {
+		return false, fmt.Errorf("failed to revoke token: %s", res.Status)
+	}
 	return true, nil
 }
 
-func saveAccessTokenToDisk(path string, token *AccessToken) error {
+func saveAccessToken(store credentialStore, profile string, token *AccessToken) error {
 	data, err := json.Marshal(token)
 	if err != nil {
 		return err
 	}
+	return store.save(profile, data)
+}
 
-	err = os.WriteFile(path, data, 0o600)
-	if err != nil {
-		return err
+// credentialStoreName returns the configured credential store, for logging
+func credentialStoreName(cfg config.Hook) string {
+	if store := strings.TrimSpace(cfg.GetString(CredentialStoreConfigPath)); store != "" {
+		return store
 	}
-
-	return nil
+	return CredentialStoreFile
 }
 
-func GetAuthenticatedClient(baseURL string, token string, logger *slog.Logger) (*kk.SDK, error) {
+// GetAuthenticatedClient creates a Konnect SDK client that retries rate limited and
+// transient server failures up to maxRetries times. Requests without a deadline of
+// their own time out after timeout. Reads are cached when cache is enabled.
+func GetAuthenticatedClient(
+	baseURL string, token string, maxRetries int, timeout time.Duration, cache httpclient.CacheOptions,
+	logger *slog.Logger,
+) (*kk.SDK, error) {
 	opts := []kk.SDKOption{
 		kk.WithServerURL(baseURL),
 		kk.WithSecurity(kkComps.Security{
@@ -380,11 +431,23 @@ func GetAuthenticatedClient(baseURL string, token string, logger *slog.Logger) (
 		}),
 	}
 
-	// Add logging client if logger is provided and trace level is enabled
-	if logger != nil && logger.Enabled(context.Background(), log.LevelTrace) {
-		loggingClient := httpclient.NewLoggingHTTPClient(logger)
-		opts = append(opts, kk.WithClient(loggingClient))
+	// Requests without a deadline are limited by timeout, while operations with
+	// their own deadline may run longer
+	var client httpclient.Doer = &http.Client{}
+	// Add logging client if logger is provided and debug level is enabled
+	if logger != nil && logger.Enabled(context.Background(), slog.LevelDebug) {
+		client = httpclient.NewLoggingHTTPClientWithClient(&http.Client{}, logger)
+	}
+	client = httpclient.NewDefaultTimeoutClient(client, timeout)
+	if cache.Enabled() {
+		client = httpclient.NewCacheClient(client, cache, logger)
 	}
+	// Writes of dry runs are answered locally and never reach Konnect
+	client = httpclient.NewDryRunClient(client)
+	// Each attempt gets its own default timeout
+	client = httpclient.NewRetryClient(client, maxRetries, logger)
+	// Interactions are recorded as the SDK sees them, once retried
+	opts = append(opts, kk.WithClient(httpclient.NewRecordingClient(client)))
 
 	return kk.New(opts...), nil
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -80,6 +82,34 @@ func TestDeleteAccessTokenNoFile(t *testing.T) {
 	require.False(t, removed)
 }
 
+func TestRevokeAccessTokenSendsRefreshToken(t *testing.T) {
+	var cookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("konnectrefreshtoken"); err == nil {
+			cookie = c.Value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cfg := stubConfig{profile: "default", path: filepath.Join(dir, "config.yaml")}
+	require.NoError(t, SaveAccessToken(cfg, &AccessToken{Token: &AccessTokenResponse{RefreshToken: "refresh"}}))
+
+	revoked, err := RevokeAccessToken(cfg, server.URL+"/kauth/api/v1/logout")
+	require.NoError(t, err)
+	require.True(t, revoked)
+	require.Equal(t, "refresh", cookie)
+}
+
+func TestRevokeAccessTokenNoToken(t *testing.T) {
+	cfg := stubConfig{profile: "default", path: filepath.Join(t.TempDir(), "config.yaml")}
+
+	revoked, err := RevokeAccessToken(cfg, "http://127.0.0.1:0/kauth/api/v1/logout")
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
 func TestJWTExpiresIn_FutureExpiry(t *testing.T) {
 	// Token that expires 900 seconds from now
 	exp := time.Now().Add(900 * time.Second).Unix()
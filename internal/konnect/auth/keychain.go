@@ -0,0 +1,318 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kong/kongctl/internal/config"
+)
+
+const (
+	// CredentialStoreConfigPath selects where login saves the Konnect tokens of a profile
+	CredentialStoreConfigPath = "konnect.credential-store"
+	// CredentialStoreFile saves tokens in a file next to the configuration file
+	CredentialStoreFile = "file"
+	// CredentialStoreKeychain saves tokens in the credential store of the operating
+	// system: the macOS keychain, the Windows Credential Manager, or the Secret Service
+	// (GNOME Keyring, KWallet) on Linux
+	CredentialStoreKeychain = "keychain"
+	// CredentialStoreEncryptedFile saves tokens in a file encrypted with the passphrase
+	// of CredentialPassphraseEnv
+	CredentialStoreEncryptedFile = "encrypted-file"
+	// CredentialPassphraseEnv holds the passphrase of the encrypted-file credential store,
+	// which is also used when the keychain is not available, e.g. on headless machines
+	CredentialPassphraseEnv = "KONGCTL_CREDENTIAL_PASSPHRASE" // #nosec G101
+
+	// keychainService names the keychain items holding the tokens, one per profile
+	keychainService = "kongctl-konnect"
+	// pbkdf2Iterations is the PBKDF2-SHA256 work factor deriving the key of encrypted files
+	pbkdf2Iterations = 600_000
+)
+
+// errCredentialsNotFound is returned by a credential store without tokens for a profile
+var errCredentialsNotFound = os.ErrNotExist
+
+// credentialStore saves the serialized tokens of a profile
+type credentialStore interface {
+	load(profile string) ([]byte, error)
+	save(profile string, data []byte) error
+	delete(profile string) (bool, error)
+}
+
+// credentialStoreFor returns the credential store configured for the profile of cfg.
+// The keychain falls back to an encrypted file when the keychain is not available and
+// a passphrase is set.
+func credentialStoreFor(cfg config.Hook) (credentialStore, error) {
+	dir := filepath.Dir(cfg.GetPath())
+	passphrase := os.Getenv(CredentialPassphraseEnv)
+	switch store := strings.TrimSpace(cfg.GetString(CredentialStoreConfigPath)); store {
+	case "", CredentialStoreFile:
+		return fileStore{dir: dir}, nil
+	case CredentialStoreKeychain:
+		keychain := keychainStore{goos: runtime.GOOS, run: runKeychainCommand}
+		if err := keychain.available(); err != nil {
+			if passphrase != "" {
+				return encryptedFileStore{dir: dir, passphrase: passphrase}, nil
+			}
+			return nil, fmt.Errorf("%w; set %s to save the tokens in an encrypted file instead",
+				err, CredentialPassphraseEnv)
+		}
+		return keychain, nil
+	case CredentialStoreEncryptedFile:
+		if passphrase == "" {
+			return nil, fmt.Errorf("%s %s requires the %s environment variable",
+				CredentialStoreConfigPath, CredentialStoreEncryptedFile, CredentialPassphraseEnv)
+		}
+		return encryptedFileStore{dir: dir, passphrase: passphrase}, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q: must be %s, %s or %s", CredentialStoreConfigPath, store,
+			CredentialStoreFile, CredentialStoreKeychain, CredentialStoreEncryptedFile)
+	}
+}
+
+// fileStore saves tokens in a file per profile in the configuration directory
+type fileStore struct {
+	dir string
+}
+
+func (s fileStore) path(profile string) string {
+	return filepath.Join(s.dir, getCredentialFileName(profile))
+}
+
+func (s fileStore) load(profile string) ([]byte, error) {
+	return os.ReadFile(s.path(profile))
+}
+
+func (s fileStore) save(profile string, data []byte) error {
+	return os.WriteFile(s.path(profile), data, 0o600)
+}
+
+func (s fileStore) delete(profile string) (bool, error) {
+	return removeFile(s.path(profile))
+}
+
+// encryptedFileStore saves tokens in a file per profile, encrypted with AES-256-GCM
+// under a key derived from a passphrase
+type encryptedFileStore struct {
+	dir        string
+	passphrase string
+}
+
+// encryptedCredentials is the content of an encrypted credentials file
+type encryptedCredentials struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (s encryptedFileStore) path(profile string) string {
+	return filepath.Join(s.dir, fmt.Sprintf(".%s-konnect-token.enc", profile))
+}
+
+func (s encryptedFileStore) load(profile string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(profile))
+	if err != nil {
+		return nil, err
+	}
+	var encrypted encryptedCredentials
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted credentials: %w", err)
+	}
+	aead, err := s.cipher(encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, encrypted.Nonce, encrypted.Ciphertext, []byte(profile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials: wrong %s or corrupted file",
+			CredentialPassphraseEnv)
+	}
+	return plaintext, nil
+}
+
+func (s encryptedFileStore) save(profile string, data []byte) error {
+	encrypted := encryptedCredentials{Salt: make([]byte, 16)}
+	if _, err := rand.Read(encrypted.Salt); err != nil {
+		return err
+	}
+	aead, err := s.cipher(encrypted.Salt)
+	if err != nil {
+		return err
+	}
+	encrypted.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(encrypted.Nonce); err != nil {
+		return err
+	}
+	encrypted.Ciphertext = aead.Seal(nil, encrypted.Nonce, data, []byte(profile))
+	content, err := json.Marshal(encrypted)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(profile), content, 0o600)
+}
+
+func (s encryptedFileStore) delete(profile string) (bool, error) {
+	return removeFile(s.path(profile))
+}
+
+func (s encryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, s.passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keychainRunner runs a keychain tool with the given stdin and returns its stdout
+type keychainRunner func(stdin []byte, name string, args ...string) ([]byte, error)
+
+// keychainStore saves tokens in the credential store of the operating system: with the
+// security command on macOS, secret-tool on Linux and the Credential Manager API on Windows
+type keychainStore struct {
+	goos string
+	run  keychainRunner
+}
+
+// available reports why the keychain cannot be used on this machine, or nil
+func (s keychainStore) available() error {
+	switch s.goos {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err != nil {
+			return fmt.Errorf("the macOS keychain is not available: %w", err)
+		}
+	case "linux":
+		if _, err := exec.LookPath("secret-tool"); err != nil {
+			return fmt.Errorf("the Secret Service is not available: %w", err)
+		}
+		if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+			return fmt.Errorf("the Secret Service is not available: no D-Bus session")
+		}
+	case "windows":
+	default:
+		return s.unsupported()
+	}
+	return nil
+}
+
+func (s keychainStore) load(profile string) ([]byte, error) {
+	var out []byte
+	var err error
+	switch s.goos {
+	case "darwin":
+		out, err = s.run(nil, "security", "find-generic-password", "-s", keychainService, "-a", profile, "-w")
+	case "linux":
+		out, err = s.run(nil, "secret-tool", "lookup", "service", keychainService, "account", profile)
+	case "windows":
+		return windowsCredentialRead(keychainTarget(profile))
+	default:
+		return nil, s.unsupported()
+	}
+	data := bytes.TrimSpace(out)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) || (err == nil && len(data) == 0) {
+		// Both tools exit with a non-zero status when no item matches
+		return nil, errCredentialsNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Konnect tokens from the keychain: %w", err)
+	}
+	return data, nil
+}
+
+func (s keychainStore) save(profile string, data []byte) error {
+	var err error
+	switch s.goos {
+	case "darwin":
+		_, err = s.run(nil, "security", "add-generic-password", "-U", "-s", keychainService, "-a", profile,
+			"-w", string(data))
+	case "linux":
+		_, err = s.run(data, "secret-tool", "store", "--label", "kongctl Konnect tokens ("+profile+")",
+			"service", keychainService, "account", profile)
+	case "windows":
+		err = windowsCredentialWrite(keychainTarget(profile), profile, data)
+	default:
+		return s.unsupported()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save Konnect tokens to the keychain: %w", err)
+	}
+	return nil
+}
+
+func (s keychainStore) delete(profile string) (bool, error) {
+	if s.goos == "windows" {
+		return windowsCredentialDelete(keychainTarget(profile))
+	}
+	if _, err := s.load(profile); err != nil {
+		if errors.Is(err, errCredentialsNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	var err error
+	switch s.goos {
+	case "darwin":
+		_, err = s.run(nil, "security", "delete-generic-password", "-s", keychainService, "-a", profile)
+	case "linux":
+		_, err = s.run(nil, "secret-tool", "clear", "service", keychainService, "account", profile)
+	default:
+		return false, s.unsupported()
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete Konnect tokens from the keychain: %w", err)
+	}
+	return true, nil
+}
+
+func (s keychainStore) unsupported() error {
+	return fmt.Errorf("%s %s is not supported on %s", CredentialStoreConfigPath, CredentialStoreKeychain, s.goos)
+}
+
+// keychainTarget names the Windows credential holding the tokens of a profile
+func keychainTarget(profile string) string {
+	return keychainService + ":" + profile
+}
+
+func runKeychainCommand(stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return out, fmt.Errorf("%s: %w: %s", name, err, message)
+		}
+		return out, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+func removeFile(path string) (bool, error) {
+	err := os.Remove(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
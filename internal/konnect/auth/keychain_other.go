@@ -0,0 +1,20 @@
+//go:build !windows
+
+package auth
+
+import "errors"
+
+// errNoCredentialManager is returned by the Credential Manager functions outside Windows
+var errNoCredentialManager = errors.New("the Windows Credential Manager is only available on Windows")
+
+func windowsCredentialRead(string) ([]byte, error) {
+	return nil, errNoCredentialManager
+}
+
+func windowsCredentialWrite(string, string, []byte) error {
+	return errNoCredentialManager
+}
+
+func windowsCredentialDelete(string) (bool, error) {
+	return false, errNoCredentialManager
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKeychain records the keychain commands run and serves the saved items
+type fakeKeychain struct {
+	items    map[string][]byte
+	commands []string
+}
+
+func (f *fakeKeychain) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	f.commands = append(f.commands, name+" "+args[0])
+	account := args[len(args)-1]
+	switch args[0] {
+	case "store":
+		f.items[account] = stdin
+		return nil, nil
+	case "lookup":
+		if data, ok := f.items[account]; ok {
+			return append(data, '\n'), nil
+		}
+		return nil, &exec.ExitError{}
+	case "clear":
+		delete(f.items, account)
+		return nil, nil
+	}
+	return nil, errors.New("unexpected command")
+}
+
+func TestKeychainStore_Linux(t *testing.T) {
+	keychain := &fakeKeychain{items: map[string][]byte{}}
+	store := keychainStore{goos: "linux", run: keychain.run}
+
+	_, err := store.load("prod")
+	require.ErrorIs(t, err, errCredentialsNotFound)
+
+	token := &AccessToken{Token: &AccessTokenResponse{AuthToken: "auth", RefreshToken: "refresh"}}
+	require.NoError(t, saveAccessToken(store, "prod", token))
+	loaded, err := loadAccessToken(store, "prod")
+	require.NoError(t, err)
+	require.Equal(t, "refresh", loaded.Token.RefreshToken)
+
+	removed, err := store.delete("prod")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = store.delete("prod")
+	require.NoError(t, err)
+	require.False(t, removed)
+	require.Equal(t, "secret-tool store,secret-tool lookup,secret-tool lookup,secret-tool clear,secret-tool lookup",
+		strings.Join(keychain.commands[1:], ","))
+}
+
+func TestKeychainStore_Unsupported(t *testing.T) {
+	store := keychainStore{goos: "plan9", run: (&fakeKeychain{}).run}
+	err := store.save("prod", []byte("{}"))
+	require.ErrorContains(t, err, "not supported on plan9")
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := encryptedFileStore{dir: dir, passphrase: "correct horse"}
+
+	require.NoError(t, store.save("prod", []byte(`{"token":{"refresh_token":"refresh"}}`)))
+	content, err := os.ReadFile(filepath.Join(dir, ".prod-konnect-token.enc"))
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "refresh")
+
+	data, err := store.load("prod")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"token":{"refresh_token":"refresh"}}`, string(data))
+
+	_, err = encryptedFileStore{dir: dir, passphrase: "wrong"}.load("prod")
+	require.ErrorContains(t, err, "failed to decrypt credentials")
+
+	removed, err := store.delete("prod")
+	require.NoError(t, err)
+	require.True(t, removed)
+}
+
+func TestCredentialStoreFor_KeychainFallback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Credential Manager is always available on Windows")
+	}
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv(CredentialPassphraseEnv, "")
+	cfg := storeConfig{stubConfig: stubConfig{profile: "prod", path: filepath.Join(t.TempDir(), "config.yaml")},
+		store: CredentialStoreKeychain}
+
+	_, err := credentialStoreFor(cfg)
+	require.ErrorContains(t, err, CredentialPassphraseEnv)
+
+	t.Setenv(CredentialPassphraseEnv, "passphrase")
+	store, err := credentialStoreFor(cfg)
+	require.NoError(t, err)
+	require.IsType(t, encryptedFileStore{}, store)
+}
+
+// storeConfig is a stubConfig with a configured credential store
+type storeConfig struct {
+	stubConfig
+	store string
+}
+
+func (s storeConfig) GetString(key string) string {
+	if key == CredentialStoreConfigPath {
+		return s.store
+	}
+	return ""
+}
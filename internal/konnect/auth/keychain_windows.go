@@ -0,0 +1,100 @@
+//go:build windows
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// winCredential mirrors the CREDENTIALW structure of the Windows Credential Manager
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func windowsCredentialRead(target string) ([]byte, error) {
+	targetName, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return nil, err
+	}
+	var cred *winCredential
+	ret, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0,
+		uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return nil, errCredentialsNotFound
+		}
+		return nil, fmt.Errorf("CredReadW: %w", callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) //nolint:errcheck // CredFree returns nothing
+	return append([]byte(nil), unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)...), nil
+}
+
+func windowsCredentialWrite(target, userName string, data []byte) error {
+	targetName, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(userName)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("CredWriteW: empty credential")
+	}
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         targetName,
+		CredentialBlobSize: uint32(len(data)), //nolint:gosec // tokens are far below the 2560 byte limit
+		CredentialBlob:     &data[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	ret, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return fmt.Errorf("CredWriteW: %w", callErr)
+	}
+	return nil
+}
+
+func windowsCredentialDelete(target string) (bool, error) {
+	targetName, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return false, err
+	}
+	ret, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0)
+	if ret == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("CredDeleteW: %w", callErr)
+	}
+	return true, nil
+}
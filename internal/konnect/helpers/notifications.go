@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	kkSDK "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+// NotificationsAPI defines the interface for the notification events of the current user
+// and their subscriptions
+type NotificationsAPI interface {
+	ListUserConfigurations(
+		ctx context.Context,
+		filter *kkComps.ConfigurationFilterParameters,
+		opts ...kkOps.Option,
+	) (*kkOps.ListUserConfigurationsResponse, error)
+	ListEventSubscriptions(
+		ctx context.Context,
+		eventID string,
+		opts ...kkOps.Option,
+	) (*kkOps.ListEventSubscriptionsResponse, error)
+	CreateEventSubscription(
+		ctx context.Context,
+		eventID string,
+		subscription *kkComps.EventSubscription,
+		opts ...kkOps.Option,
+	) (*kkOps.CreateEventSubscriptionResponse, error)
+	GetEventSubscription(
+		ctx context.Context,
+		eventID string,
+		subscriptionID string,
+		opts ...kkOps.Option,
+	) (*kkOps.GetEventSubscriptionResponse, error)
+	UpdateEventSubscription(
+		ctx context.Context,
+		request kkOps.UpdateEventSubscriptionRequest,
+		opts ...kkOps.Option,
+	) (*kkOps.UpdateEventSubscriptionResponse, error)
+	DeleteEventSubscription(
+		ctx context.Context,
+		eventID string,
+		subscriptionID string,
+		opts ...kkOps.Option,
+	) (*kkOps.DeleteEventSubscriptionResponse, error)
+}
+
+// NotificationsAPIImpl provides an implementation of NotificationsAPI backed by the SDK
+type NotificationsAPIImpl struct {
+	SDK *kkSDK.SDK
+}
+
+// ListUserConfigurations lists the notification events of the current user
+func (n *NotificationsAPIImpl) ListUserConfigurations(
+	ctx context.Context, filter *kkComps.ConfigurationFilterParameters, opts ...kkOps.Option,
+) (*kkOps.ListUserConfigurationsResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.ListUserConfigurations(ctx, filter, opts...)
+}
+
+// ListEventSubscriptions lists the subscriptions of the current user to an event
+func (n *NotificationsAPIImpl) ListEventSubscriptions(
+	ctx context.Context, eventID string, opts ...kkOps.Option,
+) (*kkOps.ListEventSubscriptionsResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.ListEventSubscriptions(ctx, eventID, opts...)
+}
+
+// CreateEventSubscription subscribes the current user to an event
+func (n *NotificationsAPIImpl) CreateEventSubscription(
+	ctx context.Context, eventID string, subscription *kkComps.EventSubscription, opts ...kkOps.Option,
+) (*kkOps.CreateEventSubscriptionResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.CreateEventSubscription(ctx, eventID, subscription, opts...)
+}
+
+// GetEventSubscription fetches a subscription to an event
+func (n *NotificationsAPIImpl) GetEventSubscription(
+	ctx context.Context, eventID string, subscriptionID string, opts ...kkOps.Option,
+) (*kkOps.GetEventSubscriptionResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.GetEventSubscription(ctx, eventID, subscriptionID, opts...)
+}
+
+// UpdateEventSubscription updates a subscription to an event
+func (n *NotificationsAPIImpl) UpdateEventSubscription(
+	ctx context.Context, request kkOps.UpdateEventSubscriptionRequest, opts ...kkOps.Option,
+) (*kkOps.UpdateEventSubscriptionResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.UpdateEventSubscription(ctx, request, opts...)
+}
+
+// DeleteEventSubscription removes a subscription to an event
+func (n *NotificationsAPIImpl) DeleteEventSubscription(
+	ctx context.Context, eventID string, subscriptionID string, opts ...kkOps.Option,
+) (*kkOps.DeleteEventSubscriptionResponse, error) {
+	if n.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return n.SDK.Notifications.DeleteEventSubscription(ctx, eventID, subscriptionID, opts...)
+}
+
+// Ensure interface compliance
+var _ NotificationsAPI = (*NotificationsAPIImpl)(nil)
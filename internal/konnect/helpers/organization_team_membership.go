@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	kkSDK "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+// OrganizationTeamMembershipAPI defines the interface for organization team membership
+// operations, and the user lookup needed to resolve members by email
+type OrganizationTeamMembershipAPI interface {
+	ListTeamUsers(
+		ctx context.Context,
+		request kkOps.ListTeamUsersRequest,
+		opts ...kkOps.Option,
+	) (*kkOps.ListTeamUsersResponse, error)
+	AddUserToTeam(
+		ctx context.Context,
+		teamID string,
+		user *kkComps.AddUserToTeam,
+		opts ...kkOps.Option,
+	) (*kkOps.AddUserToTeamResponse, error)
+	RemoveUserFromTeam(
+		ctx context.Context,
+		userID string,
+		teamID string,
+		opts ...kkOps.Option,
+	) (*kkOps.RemoveUserFromTeamResponse, error)
+	ListUsers(
+		ctx context.Context,
+		request kkOps.ListUsersRequest,
+		opts ...kkOps.Option,
+	) (*kkOps.ListUsersResponse, error)
+}
+
+// OrganizationTeamMembershipAPIImpl provides an implementation of OrganizationTeamMembershipAPI
+// backed by the SDK
+type OrganizationTeamMembershipAPIImpl struct {
+	SDK *kkSDK.SDK
+}
+
+// ListTeamUsers lists the users of an organization team
+func (t *OrganizationTeamMembershipAPIImpl) ListTeamUsers(
+	ctx context.Context, request kkOps.ListTeamUsersRequest, opts ...kkOps.Option,
+) (*kkOps.ListTeamUsersResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.TeamMembership.ListTeamUsers(ctx, request, opts...)
+}
+
+// AddUserToTeam adds a user to an organization team
+func (t *OrganizationTeamMembershipAPIImpl) AddUserToTeam(
+	ctx context.Context, teamID string, user *kkComps.AddUserToTeam, opts ...kkOps.Option,
+) (*kkOps.AddUserToTeamResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.TeamMembership.AddUserToTeam(ctx, teamID, user, opts...)
+}
+
+// RemoveUserFromTeam removes a user from an organization team
+func (t *OrganizationTeamMembershipAPIImpl) RemoveUserFromTeam(
+	ctx context.Context, userID string, teamID string, opts ...kkOps.Option,
+) (*kkOps.RemoveUserFromTeamResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.TeamMembership.RemoveUserFromTeam(ctx, userID, teamID, opts...)
+}
+
+// ListUsers lists the users of the organization
+func (t *OrganizationTeamMembershipAPIImpl) ListUsers(
+	ctx context.Context, request kkOps.ListUsersRequest, opts ...kkOps.Option,
+) (*kkOps.ListUsersResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.Users.ListUsers(ctx, request, opts...)
+}
+
+// Ensure interface compliance
+var _ OrganizationTeamMembershipAPI = (*OrganizationTeamMembershipAPIImpl)(nil)
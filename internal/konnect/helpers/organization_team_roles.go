@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+
+	kkSDK "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+// OrganizationTeamRolesAPI defines the interface for organization team role assignments
+type OrganizationTeamRolesAPI interface {
+	ListTeamRoles(
+		ctx context.Context,
+		teamID string,
+		filter *kkOps.ListTeamRolesQueryParamFilter,
+		opts ...kkOps.Option,
+	) (*kkOps.ListTeamRolesResponse, error)
+	TeamsAssignRole(
+		ctx context.Context,
+		teamID string,
+		role *kkComps.AssignRole,
+		opts ...kkOps.Option,
+	) (*kkOps.TeamsAssignRoleResponse, error)
+	TeamsRemoveRole(
+		ctx context.Context,
+		teamID string,
+		roleID string,
+		opts ...kkOps.Option,
+	) (*kkOps.TeamsRemoveRoleResponse, error)
+}
+
+// OrganizationTeamRolesAPIImpl provides an implementation of OrganizationTeamRolesAPI backed by the SDK
+type OrganizationTeamRolesAPIImpl struct {
+	SDK *kkSDK.SDK
+}
+
+// ListTeamRoles lists the roles assigned to an organization team
+func (t *OrganizationTeamRolesAPIImpl) ListTeamRoles(
+	ctx context.Context, teamID string, filter *kkOps.ListTeamRolesQueryParamFilter, opts ...kkOps.Option,
+) (*kkOps.ListTeamRolesResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.Roles.ListTeamRoles(ctx, teamID, filter, opts...)
+}
+
+// TeamsAssignRole assigns a role to an organization team
+func (t *OrganizationTeamRolesAPIImpl) TeamsAssignRole(
+	ctx context.Context, teamID string, role *kkComps.AssignRole, opts ...kkOps.Option,
+) (*kkOps.TeamsAssignRoleResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.Roles.TeamsAssignRole(ctx, teamID, role, opts...)
+}
+
+// TeamsRemoveRole removes an assigned role from an organization team
+func (t *OrganizationTeamRolesAPIImpl) TeamsRemoveRole(
+	ctx context.Context, teamID string, roleID string, opts ...kkOps.Option,
+) (*kkOps.TeamsRemoveRoleResponse, error) {
+	if t.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return t.SDK.Roles.TeamsRemoveRole(ctx, teamID, roleID, opts...)
+}
+
+// Ensure interface compliance
+var _ OrganizationTeamRolesAPI = (*OrganizationTeamRolesAPIImpl)(nil)
@@ -20,6 +20,11 @@ type PortalApplicationRegistrationAPI interface {
 		request kkOps.GetApplicationRegistrationRequest,
 		opts ...kkOps.Option,
 	) (*kkOps.GetApplicationRegistrationResponse, error)
+	UpdateApplicationRegistration(
+		ctx context.Context,
+		request kkOps.UpdateApplicationRegistrationRequest,
+		opts ...kkOps.Option,
+	) (*kkOps.UpdateApplicationRegistrationResponse, error)
 	DeleteApplicationRegistration(
 		ctx context.Context,
 		request kkOps.DeleteApplicationRegistrationRequest,
@@ -55,6 +60,18 @@ func (p *PortalApplicationRegistrationAPIImpl) GetApplicationRegistration(
 	return p.SDK.ApplicationRegistrations.GetApplicationRegistration(ctx, request, opts...)
 }
 
+// UpdateApplicationRegistration updates a specific application registration, such as its status
+func (p *PortalApplicationRegistrationAPIImpl) UpdateApplicationRegistration(
+	ctx context.Context,
+	request kkOps.UpdateApplicationRegistrationRequest,
+	opts ...kkOps.Option,
+) (*kkOps.UpdateApplicationRegistrationResponse, error) {
+	if p.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return p.SDK.ApplicationRegistrations.UpdateApplicationRegistration(ctx, request, opts...)
+}
+
 // DeleteApplicationRegistration removes a specific application registration
 func (p *PortalApplicationRegistrationAPIImpl) DeleteApplicationRegistration(
 	ctx context.Context,
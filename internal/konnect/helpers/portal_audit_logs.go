@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"context"
+
+	kkSDK "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+)
+
+// PortalAuditLogsAPI exposes portal audit log webhook operations used by the CLI.
+type PortalAuditLogsAPI interface {
+	GetPortalAuditLogWebhook(ctx context.Context, portalID string,
+		opts ...kkOps.Option) (*kkOps.GetPortalAuditLogWebhookResponse, error)
+	UpdatePortalAuditLogWebhook(ctx context.Context, portalID string, body *kkComps.UpdatePortalAuditLogWebhook,
+		opts ...kkOps.Option) (*kkOps.UpdatePortalAuditLogWebhookResponse, error)
+	DeletePortalAuditLogWebhook(ctx context.Context, portalID string,
+		opts ...kkOps.Option) (*kkOps.DeletePortalAuditLogWebhookResponse, error)
+}
+
+// PortalAuditLogsAPIImpl provides a concrete implementation backed by the SDK.
+type PortalAuditLogsAPIImpl struct {
+	SDK *kkSDK.SDK
+}
+
+// GetPortalAuditLogWebhook delegates to the generated SDK.
+func (p *PortalAuditLogsAPIImpl) GetPortalAuditLogWebhook(
+	ctx context.Context, portalID string, opts ...kkOps.Option,
+) (*kkOps.GetPortalAuditLogWebhookResponse, error) {
+	return p.SDK.PortalAuditLogs.GetPortalAuditLogWebhook(ctx, portalID, opts...)
+}
+
+// UpdatePortalAuditLogWebhook delegates to the generated SDK.
+func (p *PortalAuditLogsAPIImpl) UpdatePortalAuditLogWebhook(
+	ctx context.Context, portalID string, body *kkComps.UpdatePortalAuditLogWebhook, opts ...kkOps.Option,
+) (*kkOps.UpdatePortalAuditLogWebhookResponse, error) {
+	return p.SDK.PortalAuditLogs.UpdatePortalAuditLogWebhook(ctx, portalID, body, opts...)
+}
+
+// DeletePortalAuditLogWebhook delegates to the generated SDK.
+func (p *PortalAuditLogsAPIImpl) DeletePortalAuditLogWebhook(
+	ctx context.Context, portalID string, opts ...kkOps.Option,
+) (*kkOps.DeletePortalAuditLogWebhookResponse, error) {
+	return p.SDK.PortalAuditLogs.DeletePortalAuditLogWebhook(ctx, portalID, opts...)
+}
@@ -21,6 +21,11 @@ type PortalDeveloperAPI interface {
 		developerID string,
 		opts ...kkOps.Option,
 	) (*kkOps.GetDeveloperResponse, error)
+	UpdateDeveloper(
+		ctx context.Context,
+		request kkOps.UpdateDeveloperRequest,
+		opts ...kkOps.Option,
+	) (*kkOps.UpdateDeveloperResponse, error)
 }
 
 // PortalDeveloperAPIImpl provides an implementation backed by the SDK
@@ -50,4 +55,15 @@ func (p *PortalDeveloperAPIImpl) GetDeveloper(
 	return p.SDK.PortalDevelopers.GetDeveloper(ctx, portalID, developerID, opts...)
 }
 
+// UpdateDeveloper updates a developer of a portal, such as its status
+func (p *PortalDeveloperAPIImpl) UpdateDeveloper(
+	ctx context.Context, request kkOps.UpdateDeveloperRequest,
+	opts ...kkOps.Option,
+) (*kkOps.UpdateDeveloperResponse, error) {
+	if p.SDK == nil {
+		return nil, fmt.Errorf("SDK is nil")
+	}
+	return p.SDK.PortalDevelopers.UpdateDeveloper(ctx, request, opts...)
+}
+
 var _ PortalDeveloperAPI = (*PortalDeveloperAPIImpl)(nil)
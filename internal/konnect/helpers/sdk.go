@@ -31,6 +31,9 @@ type SDKAPI interface {
 	GetGatewayServiceAPI() GatewayServiceAPI
 	GetSystemAccountAPI() SystemAccountAPI
 	GetOrganizationTeamAPI() OrganizationTeamAPI
+	GetOrganizationTeamRolesAPI() OrganizationTeamRolesAPI
+	GetOrganizationTeamMembershipAPI() OrganizationTeamMembershipAPI
+	GetNotificationsAPI() NotificationsAPI
 	// Portal child resource APIs
 	GetPortalPageAPI() PortalPageAPI
 	GetPortalAuthSettingsAPI() PortalAuthSettingsAPI
@@ -45,6 +48,7 @@ type SDKAPI interface {
 	GetPortalTeamMembershipAPI() PortalTeamMembershipAPI
 	GetAssetsAPI() AssetsAPI
 	GetPortalEmailsAPI() PortalEmailsAPI
+	GetPortalAuditLogsAPI() PortalAuditLogsAPI
 	GetEventGatewayControlPlaneAPI() EGWControlPlaneAPI
 	GetEventGatewayBackendClusterAPI() EventGatewayBackendClusterAPI
 	GetEventGatewayVirtualClusterAPI() EventGatewayVirtualClusterAPI
@@ -280,6 +284,15 @@ func (k *KonnectSDK) GetPortalEmailsAPI() PortalEmailsAPI {
 	return &PortalEmailsAPIImpl{SDK: k.SDK}
 }
 
+// GetPortalAuditLogsAPI returns the implementation of the PortalAuditLogsAPI interface.
+func (k *KonnectSDK) GetPortalAuditLogsAPI() PortalAuditLogsAPI {
+	if k.SDK == nil || k.SDK.PortalAuditLogs == nil {
+		return nil
+	}
+
+	return &PortalAuditLogsAPIImpl{SDK: k.SDK}
+}
+
 // Returns the implementation of the EGWControlPlaneAPI interface
 func (k *KonnectSDK) GetEventGatewayControlPlaneAPI() EGWControlPlaneAPI {
 	if k.SDK == nil {
@@ -323,6 +336,33 @@ func (k *KonnectSDK) GetOrganizationTeamAPI() OrganizationTeamAPI {
 	return &OrganizationTeamAPIImpl{SDK: k.SDK}
 }
 
+// Returns the implementation of the OrganizationTeamRolesAPI interface
+func (k *KonnectSDK) GetOrganizationTeamRolesAPI() OrganizationTeamRolesAPI {
+	if k.SDK == nil || k.SDK.Roles == nil {
+		return nil
+	}
+
+	return &OrganizationTeamRolesAPIImpl{SDK: k.SDK}
+}
+
+// Returns the implementation of the OrganizationTeamMembershipAPI interface
+func (k *KonnectSDK) GetOrganizationTeamMembershipAPI() OrganizationTeamMembershipAPI {
+	if k.SDK == nil || k.SDK.TeamMembership == nil {
+		return nil
+	}
+
+	return &OrganizationTeamMembershipAPIImpl{SDK: k.SDK}
+}
+
+// Returns the implementation of the NotificationsAPI interface
+func (k *KonnectSDK) GetNotificationsAPI() NotificationsAPI {
+	if k.SDK == nil || k.SDK.Notifications == nil {
+		return nil
+	}
+
+	return &NotificationsAPIImpl{SDK: k.SDK}
+}
+
 // A function that can build an SDKAPI with a given configuration
 type SDKAPIFactory func(cfg config.Hook, logger *slog.Logger) (SDKAPI, error)
 
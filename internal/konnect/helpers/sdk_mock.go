@@ -20,6 +20,10 @@ type MockKonnectSDK struct {
 	GatewayServiceFactory     func() GatewayServiceAPI
 	SystemAccountFactory      func() SystemAccountAPI
 	OrganizationTeamFactory   func() OrganizationTeamAPI
+	// Organization team child resource factories
+	OrganizationTeamRolesFactory      func() OrganizationTeamRolesAPI
+	OrganizationTeamMembershipFactory func() OrganizationTeamMembershipAPI
+	NotificationsFactory              func() NotificationsAPI
 	// Portal child resource factories
 	PortalPageFactory                    func() PortalPageAPI
 	PortalAuthSettingsFactory            func() PortalAuthSettingsAPI
@@ -34,6 +38,7 @@ type MockKonnectSDK struct {
 	PortalTeamMembershipFactory          func() PortalTeamMembershipAPI
 	AssetsFactory                        func() AssetsAPI
 	PortalEmailsFactory                  func() PortalEmailsAPI
+	PortalAuditLogsFactory               func() PortalAuditLogsAPI
 
 	// Event Gateway Control Plane factory
 	EventGatewayControlPlaneFactory   func() EGWControlPlaneAPI
@@ -238,6 +243,14 @@ func (m *MockKonnectSDK) GetPortalEmailsAPI() PortalEmailsAPI {
 	return nil
 }
 
+// Returns a mock instance of the PortalAuditLogsAPI
+func (m *MockKonnectSDK) GetPortalAuditLogsAPI() PortalAuditLogsAPI {
+	if m.PortalAuditLogsFactory != nil {
+		return m.PortalAuditLogsFactory()
+	}
+	return nil
+}
+
 // Returns a mock instance of the EGWControlPlaneAPI
 func (m *MockKonnectSDK) GetEventGatewayControlPlaneAPI() EGWControlPlaneAPI {
 	if m.EventGatewayControlPlaneFactory != nil {
@@ -270,6 +283,30 @@ func (m *MockKonnectSDK) GetOrganizationTeamAPI() OrganizationTeamAPI {
 	return nil
 }
 
+// Returns a mock instance of the OrganizationTeamRolesAPI
+func (m *MockKonnectSDK) GetOrganizationTeamRolesAPI() OrganizationTeamRolesAPI {
+	if m.OrganizationTeamRolesFactory != nil {
+		return m.OrganizationTeamRolesFactory()
+	}
+	return nil
+}
+
+// Returns a mock instance of the OrganizationTeamMembershipAPI
+func (m *MockKonnectSDK) GetOrganizationTeamMembershipAPI() OrganizationTeamMembershipAPI {
+	if m.OrganizationTeamMembershipFactory != nil {
+		return m.OrganizationTeamMembershipFactory()
+	}
+	return nil
+}
+
+// Returns a mock instance of the NotificationsAPI
+func (m *MockKonnectSDK) GetNotificationsAPI() NotificationsAPI {
+	if m.NotificationsFactory != nil {
+		return m.NotificationsFactory()
+	}
+	return nil
+}
+
 // Returns a mock instance of the EventGatewayVirtualClusterAPI
 func (m *MockKonnectSDK) GetEventGatewayVirtualClusterAPI() EventGatewayVirtualClusterAPI {
 	if m.EventGatewayVirtualClusterFactory != nil {
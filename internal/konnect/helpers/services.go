@@ -12,6 +12,14 @@ import (
 type GatewayServiceAPI interface {
 	ListService(ctx context.Context, request kkOps.ListServiceRequest,
 		opts ...kkOps.Option) (*kkOps.ListServiceResponse, error)
+	GetService(ctx context.Context, serviceID string, controlPlaneID string,
+		opts ...kkOps.Option) (*kkOps.GetServiceResponse, error)
+	CreateService(ctx context.Context, controlPlaneID string, service kkComps.Service,
+		opts ...kkOps.Option) (*kkOps.CreateServiceResponse, error)
+	UpsertService(ctx context.Context, request kkOps.UpsertServiceRequest,
+		opts ...kkOps.Option) (*kkOps.UpsertServiceResponse, error)
+	DeleteService(ctx context.Context, controlPlaneID string, serviceID string,
+		opts ...kkOps.Option) (*kkOps.DeleteServiceResponse, error)
 }
 
 func GetAllGatewayServices(ctx context.Context, requestPageSize int64, cpID string, kkClient *kk.SDK,
@@ -0,0 +1,228 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CacheOptions configure the read-through cache of Konnect reads
+type CacheOptions struct {
+	// TTL is how long a response is reused, the cache is disabled when it is zero
+	TTL time.Duration
+	// Dir keeps responses on disk, so later commands reuse them. Only the memory of
+	// the client is used when it is empty.
+	Dir string
+	// Scope separates the responses of organizations and base URLs, such as a hash of
+	// the token and the base URL
+	Scope string
+	// Refresh sends every read to Konnect and stores the response for later reads
+	Refresh bool
+}
+
+// Enabled reports whether responses are cached
+func (o CacheOptions) Enabled() bool {
+	return o.TTL > 0
+}
+
+// CacheScope returns a scope for the responses read with token from baseURL, which
+// does not reveal the token
+func CacheScope(baseURL, token string) string {
+	sum := sha256.Sum256([]byte(baseURL + "\n" + token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cachedResponse is a stored response to a read
+type cachedResponse struct {
+	URL      string      `json:"url"`
+	StoredAt time.Time   `json:"stored_at"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     []byte      `json:"body"`
+}
+
+// CacheClient answers GET requests with responses stored less than the TTL ago, in
+// memory and on disk. Other requests change the organization, so they clear the
+// stored responses of the scope before they reach Konnect. Only successful responses
+// are stored. Reading the cache is best effort: entries that cannot be read are
+// fetched again.
+type CacheClient struct {
+	wrapped Doer
+	opts    CacheOptions
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// NewCacheClient wraps an HTTP client to cache its reads
+func NewCacheClient(wrapped Doer, opts CacheOptions, logger *slog.Logger) *CacheClient {
+	return &CacheClient{
+		wrapped: wrapped,
+		opts:    opts,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[string]cachedResponse),
+	}
+}
+
+// Do implements the HTTPClient interface, answering reads from the cache
+func (c *CacheClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		c.clear()
+		return c.wrapped.Do(req)
+	}
+
+	key := req.URL.String()
+	if !c.opts.Refresh {
+		if entry, ok := c.lookup(key); ok {
+			if c.logger != nil {
+				c.logger.Debug("Konnect response served from cache",
+					slog.String("url", key),
+					slog.Duration("age", c.now().Sub(entry.StoredAt)),
+				)
+			}
+			return entry.response(req), nil
+		}
+	}
+
+	resp, err := c.wrapped.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	c.store(cachedResponse{URL: key, StoredAt: c.now(), Status: resp.StatusCode, Header: resp.Header, Body: body})
+	return resp, nil
+}
+
+// lookup returns the response stored for key less than the TTL ago
+func (c *CacheClient) lookup(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok && c.opts.Dir != "" {
+		entry, ok = c.readEntry(key)
+	}
+	if !ok || entry.URL != key || c.now().Sub(entry.StoredAt) >= c.opts.TTL {
+		return cachedResponse{}, false
+	}
+	c.mu.Lock()
+	c.entries[key] = entry
+	c.mu.Unlock()
+	return entry, true
+}
+
+func (c *CacheClient) store(entry cachedResponse) {
+	c.mu.Lock()
+	c.entries[entry.URL] = entry
+	c.mu.Unlock()
+	if c.opts.Dir == "" {
+		return
+	}
+	if err := c.writeEntry(entry); err != nil && c.logger != nil {
+		c.logger.Debug("Failed to store Konnect response in cache", slog.String("error", err.Error()))
+	}
+}
+
+// clear drops the stored responses of the scope, in memory and on disk
+func (c *CacheClient) clear() {
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+	if c.opts.Dir == "" {
+		return
+	}
+	if err := os.RemoveAll(c.scopeDir()); err != nil && c.logger != nil {
+		c.logger.Debug("Failed to clear the Konnect response cache", slog.String("error", err.Error()))
+	}
+}
+
+func (c *CacheClient) scopeDir() string {
+	return filepath.Join(c.opts.Dir, c.opts.Scope)
+}
+
+func (c *CacheClient) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.scopeDir(), hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *CacheClient) readEntry(key string) (cachedResponse, bool) {
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) && c.logger != nil {
+			c.logger.Debug("Failed to read Konnect response from cache", slog.String("error", err.Error()))
+		}
+		return cachedResponse{}, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+// writeEntry stores a response with owner only permissions, as it holds organization data
+func (c *CacheClient) writeEntry(entry cachedResponse) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.scopeDir(), 0o700); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	path := c.entryPath(entry.URL)
+	tmp, err := os.CreateTemp(c.scopeDir(), ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	// Renaming keeps concurrent commands from reading a partial entry
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// response rebuilds the stored response for req
+func (e cachedResponse) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode:    e.Status,
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheClient(t *testing.T) {
+	var served []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = append(served, r.Method+" "+r.URL.RequestURI())
+		if r.URL.Path == "/v3/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":[]}`)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts := CacheOptions{TTL: time.Minute, Dir: t.TempDir(), Scope: CacheScope(server.URL, "token")}
+	newClient := func(opts CacheOptions) *CacheClient {
+		client := NewCacheClient(&http.Client{}, opts, nil)
+		client.now = func() time.Time { return now }
+		return client
+	}
+	get := func(client *CacheClient, path string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode, string(body)
+	}
+
+	client := newClient(opts)
+	status, body := get(client, "/v3/apis?page[size]=100")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"data":[]}`, body)
+	_, body = get(client, "/v3/apis?page[size]=100")
+	require.JSONEq(t, `{"data":[]}`, body)
+	require.Len(t, served, 1, "the second read is answered from memory")
+
+	// Another client, as in a later command, reads the response from disk
+	_, _ = get(newClient(opts), "/v3/apis?page[size]=100")
+	require.Len(t, served, 1)
+
+	// Another query, failed responses and refreshes reach the server
+	_, _ = get(client, "/v3/apis?page[size]=10")
+	status, _ = get(client, "/v3/missing")
+	require.Equal(t, http.StatusNotFound, status)
+	_, _ = get(client, "/v3/missing")
+	require.Len(t, served, 4)
+	refresh := opts
+	refresh.Refresh = true
+	_, _ = get(newClient(refresh), "/v3/apis?page[size]=100")
+	require.Len(t, served, 5)
+
+	// Responses expire after the TTL
+	now = now.Add(time.Minute)
+	_, _ = get(newClient(opts), "/v3/apis?page[size]=100")
+	require.Len(t, served, 6)
+
+	// Writes clear the cache, in memory and on disk
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/v3/apis/api-1", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Len(t, served, 7)
+	_, _ = get(client, "/v3/apis?page[size]=100")
+	require.Len(t, served, 8)
+	_, _ = get(newClient(opts), "/v3/apis?page[size]=10")
+	require.Len(t, served, 9)
+
+	// Scopes do not share responses
+	other := opts
+	other.Scope = CacheScope(server.URL, "other-token")
+	_, _ = get(newClient(other), "/v3/apis?page[size]=100")
+	require.Len(t, served, 10)
+}
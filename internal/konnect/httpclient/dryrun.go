@@ -0,0 +1,185 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+type dryRunRecorderKey struct{}
+
+// RecordedRequest is a request that a dry run answered instead of sending it
+type RecordedRequest struct {
+	Method  string `json:"method" yaml:"method"`
+	Path    string `json:"path" yaml:"path"`
+	Payload any    `json:"payload,omitempty" yaml:"payload,omitempty"`
+}
+
+// DryRunRecorder collects the requests that would change Konnect, in the order
+// they were made. It is safe for concurrent use.
+type DryRunRecorder struct {
+	mu       sync.Mutex
+	requests []RecordedRequest
+	logger   *slog.Logger
+}
+
+// NewDryRunRecorder creates a recorder that logs each request it records at debug level
+func NewDryRunRecorder(logger *slog.Logger) *DryRunRecorder {
+	return &DryRunRecorder{logger: logger}
+}
+
+// Requests returns the recorded requests in the order they were made
+func (r *DryRunRecorder) Requests() []RecordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedRequest(nil), r.requests...)
+}
+
+// record stores a request and returns its position, starting at 1
+func (r *DryRunRecorder) record(req RecordedRequest) int {
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	n := len(r.requests)
+	r.mu.Unlock()
+
+	if r.logger != nil {
+		r.logger.Debug("Dry run recorded Konnect request",
+			slog.String("method", req.Method),
+			slog.String("path", req.Path),
+			slog.Any("payload", req.Payload),
+		)
+	}
+	return n
+}
+
+// WithDryRunRecorder returns a context whose requests through a DryRunClient are
+// recorded instead of changing Konnect
+func WithDryRunRecorder(ctx context.Context, recorder *DryRunRecorder) context.Context {
+	return context.WithValue(ctx, dryRunRecorderKey{}, recorder)
+}
+
+// DryRunRecorderFromContext returns the recorder of the context, or nil
+func DryRunRecorderFromContext(ctx context.Context) *DryRunRecorder {
+	recorder, _ := ctx.Value(dryRunRecorderKey{}).(*DryRunRecorder)
+	return recorder
+}
+
+// DryRunClient sends requests unchanged unless their context carries a
+// DryRunRecorder. Then reads still reach Konnect, so references resolve against
+// the live state, while writes are recorded and answered locally: the response
+// echoes the payload with a placeholder ID and timestamps, so later changes can
+// reference what a create would have returned.
+type DryRunClient struct {
+	wrapped Doer
+}
+
+// NewDryRunClient wraps an HTTP client to answer writes of dry runs locally
+func NewDryRunClient(wrapped Doer) *DryRunClient {
+	return &DryRunClient{wrapped: wrapped}
+}
+
+// Do implements the HTTPClient interface, recording writes of dry runs
+func (c *DryRunClient) Do(req *http.Request) (*http.Response, error) {
+	recorder := DryRunRecorderFromContext(req.Context())
+	if recorder == nil || !isWrite(req.Method) {
+		return c.wrapped.Do(req)
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	recorded := RecordedRequest{Method: req.Method, Path: req.URL.Path}
+	var object map[string]any
+	if len(body) > 0 {
+		var payload any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			payload = string(body)
+		}
+		recorded.Payload = payload
+		// A separate copy completes the response without changing the recorded payload
+		_ = json.Unmarshal(body, &object)
+	}
+	n := recorder.record(recorded)
+
+	return dryRunResponse(req, n, object)
+}
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// dryRunResponse answers the nth recorded request with the status Konnect uses for
+// its method and, except for deletes, the payload completed like a stored resource
+func dryRunResponse(req *http.Request, n int, object map[string]any) (*http.Response, error) {
+	status := http.StatusOK
+	switch req.Method {
+	case http.MethodPost:
+		status = http.StatusCreated
+	case http.MethodDelete:
+		status = http.StatusNoContent
+	}
+
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	if status == http.StatusNoContent {
+		return resp, nil
+	}
+
+	if object == nil {
+		object = make(map[string]any)
+	}
+	if _, ok := object["id"]; !ok {
+		// Creates get a new placeholder ID, updates keep the ID in their path
+		if req.Method == http.MethodPost {
+			object["id"] = dryRunID(n)
+		} else {
+			object["id"] = path.Base(req.URL.Path)
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, field := range []string{"created_at", "updated_at"} {
+		if _, ok := object[field]; !ok {
+			object[field] = now
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Set("Content-Type", "application/json")
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	return resp, nil
+}
+
+// dryRunID returns a UUID shaped placeholder that cannot collide with Konnect IDs
+func dryRunID(n int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+}
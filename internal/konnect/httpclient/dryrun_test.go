@@ -0,0 +1,79 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunClient(t *testing.T) {
+	var served []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = append(served, r.Method+" "+r.URL.Path)
+		_, _ = io.WriteString(w, `{"data":[]}`)
+	}))
+	defer server.Close()
+
+	client := NewDryRunClient(&http.Client{})
+	recorder := NewDryRunRecorder(nil)
+	ctx := WithDryRunRecorder(context.Background(), recorder)
+
+	// Reads reach the server
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v3/apis", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// Creates are recorded and answered with the payload and a placeholder ID
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v3/apis",
+		strings.NewReader(`{"name":"orders"}`))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "orders", created["name"])
+	require.Equal(t, "00000000-0000-0000-0000-000000000001", created["id"])
+	require.NotEmpty(t, created["created_at"])
+
+	// Updates keep the ID of their path, deletes have no body
+	req, err = http.NewRequestWithContext(ctx, http.MethodPatch, server.URL+"/v3/apis/api-1",
+		strings.NewReader(`{"description":"Orders"}`))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	var updated map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "api-1", updated["id"])
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodDelete, server.URL+"/v3/apis/api-2", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Equal(t, []string{"GET /v3/apis"}, served)
+	require.Equal(t, []RecordedRequest{
+		{Method: http.MethodPost, Path: "/v3/apis", Payload: map[string]any{"name": "orders"}},
+		{Method: http.MethodPatch, Path: "/v3/apis/api-1", Payload: map[string]any{"description": "Orders"}},
+		{Method: http.MethodDelete, Path: "/v3/apis/api-2"},
+	}, recorder.Requests())
+
+	// Without a recorder writes are sent
+	req, err = http.NewRequest(http.MethodDelete, server.URL+"/v3/apis/api-2", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, []string{"GET /v3/apis", "DELETE /v3/apis/api-2"}, served)
+}
@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
@@ -9,9 +10,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kong/kongctl/internal/declarative/redact"
 	"github.com/kong/kongctl/internal/log"
 )
 
+// maxLoggedBodyBytes limits the part of a request or response body that is logged
+const maxLoggedBodyBytes = 4096
+
+// bodyRedactor hides the fields the built-in detection of sensitive fields matches
+var bodyRedactor *redact.Redactor
+
 // LoggingHTTPClient wraps an HTTP client to add trace logging
 type LoggingHTTPClient struct {
 	wrapped *http.Client
@@ -36,17 +44,21 @@ func NewLoggingHTTPClientWithClient(client *http.Client, logger *slog.Logger) *L
 	}
 }
 
-// Do implements the HTTPClient interface with logging
+// Do implements the HTTPClient interface with logging. The debug level logs one
+// line per request, the trace level adds the headers and bodies.
 func (c *LoggingHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	// Only log if trace level is enabled
-	if !c.logger.Enabled(req.Context(), log.LevelTrace) {
+	ctx := req.Context()
+	if !c.logger.Enabled(ctx, slog.LevelDebug) {
 		return c.wrapped.Do(req)
 	}
+	trace := c.logger.Enabled(ctx, log.LevelTrace)
 
 	start := time.Now()
 
 	// Log request
-	c.logRequest(req)
+	if trace {
+		c.logRequest(req)
+	}
 
 	// Perform the actual request
 	resp, err := c.wrapped.Do(req)
@@ -54,7 +66,11 @@ func (c *LoggingHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	// Log response or error
 	duration := time.Since(start)
 	if err != nil {
-		c.logger.LogAttrs(req.Context(), log.LevelTrace, "HTTP request failed",
+		level := slog.LevelDebug
+		if trace {
+			level = log.LevelTrace
+		}
+		c.logger.LogAttrs(ctx, level, "HTTP request failed",
 			slog.String("method", req.Method),
 			slog.String("url", req.URL.String()),
 			slog.Duration("duration", duration),
@@ -63,7 +79,16 @@ func (c *LoggingHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		return nil, err
 	}
 
-	c.logResponse(resp, duration)
+	if trace {
+		c.logResponse(resp, duration)
+	} else {
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("duration", duration),
+		)
+	}
 
 	return resp, nil
 }
@@ -92,15 +117,31 @@ func (c *LoggingHTTPClient) logRequest(req *http.Request) {
 		attrs = append(attrs, slog.Int64("content_length", req.ContentLength))
 	}
 
+	// Log the body the SDK sent, read from a copy so the request keeps its own
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if copied, err := req.GetBody(); err == nil {
+			body, err := io.ReadAll(copied)
+			_ = copied.Close()
+			if err == nil && len(body) > 0 {
+				attrs = append(attrs, slog.String("body", loggedBody(body)))
+			}
+		}
+	}
+
 	c.logger.LogAttrs(req.Context(), log.LevelTrace, "HTTP request", attrs...)
 }
 
 func (c *LoggingHTTPClient) logResponse(resp *http.Response, duration time.Duration) {
 	attrs := []slog.Attr{
+		slog.String("method", resp.Request.Method),
+		slog.String("url", resp.Request.URL.String()),
 		slog.Int("status", resp.StatusCode),
 		slog.String("status_text", resp.Status),
 		slog.Duration("duration", duration),
 	}
+	if requestID := RequestID(resp.Header); requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
 
 	// Log headers (with redaction)
 	headers := make(map[string]string)
@@ -119,22 +160,33 @@ func (c *LoggingHTTPClient) logResponse(resp *http.Response, duration time.Durat
 		attrs = append(attrs, slog.Int64("content_length", resp.ContentLength))
 	}
 
-	// For trace logging of error responses, try to read and log the body
-	if resp.StatusCode >= 400 && c.logger.Enabled(resp.Request.Context(), log.LevelTrace) {
-		body, err := c.peekResponseBody(resp)
-		if err == nil && len(body) > 0 {
-			// Truncate large bodies
-			maxLen := 1000
-			if len(body) > maxLen {
-				body = fmt.Sprintf("%s... [truncated, total %d bytes]", body[:maxLen], len(body))
-			}
-			attrs = append(attrs, slog.String("error_body", body))
+	body, err := c.peekResponseBody(resp)
+	if err == nil && len(body) > 0 {
+		key := "body"
+		if resp.StatusCode >= 400 {
+			key = "error_body"
 		}
+		attrs = append(attrs, slog.String(key, loggedBody([]byte(body))))
 	}
 
 	c.logger.LogAttrs(resp.Request.Context(), log.LevelTrace, "HTTP response", attrs...)
 }
 
+// loggedBody prepares a body for the log: sensitive fields of JSON bodies are
+// redacted, like tokens and secrets, and large bodies are truncated
+func loggedBody(body []byte) string {
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err == nil {
+		if redacted, err := json.Marshal(bodyRedactor.Fields(fields)); err == nil {
+			body = redacted
+		}
+	}
+	if len(body) > maxLoggedBodyBytes {
+		return fmt.Sprintf("%s... [truncated, total %d bytes]", body[:maxLoggedBodyBytes], len(body))
+	}
+	return string(body)
+}
+
 // peekResponseBody reads the response body without consuming it
 func (c *LoggingHTTPClient) peekResponseBody(resp *http.Response) (string, error) {
 	if resp.Body == nil {
@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kong/kongctl/internal/log"
+	"github.com/stretchr/testify/require"
+)
+
+func loggedRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggingHTTPClientLevels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	request := func(t *testing.T, level slog.Level) []map[string]any {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+		client := NewLoggingHTTPClientWithClient(server.Client(), logger)
+
+		payload := `{"name":"orders","configs":{"client_secret":"hunter2"}}`
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/v3/apis",
+			strings.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer kpat_secret")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// The caller still reads the whole response
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, payload, string(body))
+		return loggedRecords(t, &buf)
+	}
+
+	t.Run("info logs nothing", func(t *testing.T) {
+		require.Empty(t, request(t, slog.LevelInfo))
+	})
+
+	t.Run("debug logs one line per request", func(t *testing.T) {
+		records := request(t, slog.LevelDebug)
+		require.Len(t, records, 1)
+		require.Equal(t, "POST", records[0]["method"])
+		require.Equal(t, float64(http.StatusCreated), records[0]["status"])
+		require.NotContains(t, records[0], "body")
+	})
+
+	t.Run("trace logs redacted bodies", func(t *testing.T) {
+		records := request(t, log.LevelTrace)
+		require.Len(t, records, 2)
+		require.Equal(t, "HTTP request", records[0]["msg"])
+		require.Equal(t, "[REDACTED]", records[0]["headers"].(map[string]any)["Authorization"])
+		for _, record := range records {
+			body := record["body"].(string)
+			require.Contains(t, body, `"name":"orders"`)
+			require.Contains(t, body, `"client_secret":"[REDACTED sha256:`)
+			require.NotContains(t, body, "hunter2")
+		}
+	})
+}
+
+func TestLoggedBodyTruncates(t *testing.T) {
+	body := loggedBody([]byte(strings.Repeat("x", maxLoggedBodyBytes+10)))
+	require.True(t, strings.HasSuffix(body, "... [truncated, total 4106 bytes]"))
+}
@@ -0,0 +1,355 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteVersion is the format version of recorded interactions
+const CassetteVersion = 1
+
+// RedactedValue replaces the values of sensitive fields in recorded bodies
+const RedactedValue = "[REDACTED]"
+
+type interactionRecorderKey struct{}
+
+// Interaction is a Konnect request and the response it got
+type Interaction struct {
+	Request  RecordedHTTPRequest  `json:"request"`
+	Response RecordedHTTPResponse `json:"response"`
+}
+
+// RecordedHTTPRequest is a request without its host and headers, so a recording
+// replays against any region and never keeps credentials
+type RecordedHTTPRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedHTTPResponse is a response with only its content type header
+type RecordedHTTPResponse struct {
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// Cassette is a recording of the Konnect interactions of a command, in the order
+// they were made
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadCassette reads a recording written by InteractionRecorder.Save
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse recording %s: %w", path, err)
+	}
+	if cassette.Version != CassetteVersion {
+		return nil, fmt.Errorf("recording %s has version %d, expected %d", path, cassette.Version, CassetteVersion)
+	}
+	return &cassette, nil
+}
+
+// InteractionRecorder collects the interactions of the requests whose context carries
+// it. It is safe for concurrent use.
+type InteractionRecorder struct {
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewInteractionRecorder returns an empty recorder
+func NewInteractionRecorder() *InteractionRecorder {
+	return &InteractionRecorder{}
+}
+
+// Cassette returns the recorded interactions
+func (r *InteractionRecorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Version: CassetteVersion, Interactions: append([]Interaction(nil), r.interactions...)}
+}
+
+// Save writes the recorded interactions to path as indented JSON, creating its
+// directory. The file has owner only permissions, as it holds organization data.
+func (r *InteractionRecorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Cassette(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+func (r *InteractionRecorder) record(interaction Interaction) {
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mu.Unlock()
+}
+
+// WithInteractionRecorder returns a context whose requests through a RecordingClient
+// are recorded
+func WithInteractionRecorder(ctx context.Context, recorder *InteractionRecorder) context.Context {
+	return context.WithValue(ctx, interactionRecorderKey{}, recorder)
+}
+
+// InteractionRecorderFromContext returns the recorder of the context, or nil
+func InteractionRecorderFromContext(ctx context.Context) *InteractionRecorder {
+	recorder, _ := ctx.Value(interactionRecorderKey{}).(*InteractionRecorder)
+	return recorder
+}
+
+// RecordingClient sends requests unchanged and, when their context carries an
+// InteractionRecorder, records each request with the response it got. Recordings are
+// sanitized: headers other than the content type of responses are dropped, and the
+// values of fields whose name suggests a credential are replaced by RedactedValue.
+type RecordingClient struct {
+	wrapped Doer
+}
+
+// NewRecordingClient wraps an HTTP client to record its interactions
+func NewRecordingClient(wrapped Doer) *RecordingClient {
+	return &RecordingClient{wrapped: wrapped}
+}
+
+// Do implements the HTTPClient interface, recording the interaction when asked to
+func (c *RecordingClient) Do(req *http.Request) (*http.Response, error) {
+	recorder := InteractionRecorderFromContext(req.Context())
+	if recorder == nil {
+		return c.wrapped.Do(req)
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := c.wrapped.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	recorder.record(Interaction{
+		Request: RecordedHTTPRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  queryKey(req.URL),
+			Body:   sanitizeBody(body),
+		},
+		Response: RecordedHTTPResponse{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        sanitizeBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// queryKey returns the query of u with sorted keys, so the same request always
+// matches, unescaped to keep recordings readable
+func queryKey(u *url.URL) string {
+	query := u.Query().Encode()
+	if unescaped, err := url.QueryUnescape(query); err == nil {
+		return unescaped
+	}
+	return query
+}
+
+// sensitiveKeyFragments mark a field as a credential when its name contains one of them
+var sensitiveKeyFragments = []string{"password", "secret", "token", "private_key", "api_key", "apikey", "authorization"}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeBody returns body with the values of credential fields redacted. Bodies that
+// are not JSON are kept as a JSON string.
+func sanitizeBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		data, _ := json.Marshal(string(body))
+		return data
+	}
+	data, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, nested := field.(map[string]any); !nested && isSensitiveKey(key) && field != nil {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+// Replayer answers requests with the responses of a recording, without network
+// access. A request is answered by the first unused interaction with the same method,
+// path and query. Once those are used, reads get the last of them again, so code
+// reading a resource more often than when it was recorded still replays, while extra
+// writes fail. Request bodies are not compared; Requests returns them for tests to
+// check. It is safe for concurrent use.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	requests []RecordedHTTPRequest
+}
+
+// NewReplayer returns a replayer of cassette
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+}
+
+// Requests returns the requests the replayer answered, in the order they were made
+func (r *Replayer) Requests() []RecordedHTTPRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedHTTPRequest(nil), r.requests...)
+}
+
+// Unused returns the recorded interactions no request matched, such as writes the
+// code no longer makes
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Do implements the HTTPClient interface, answering the request from the recording
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	request := RecordedHTTPRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  queryKey(req.URL),
+		Body:   sanitizeBody(body),
+	}
+
+	r.mu.Lock()
+	r.requests = append(r.requests, request)
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !sameRequest(interaction.Request, request) {
+			continue
+		}
+		if !r.used[i] {
+			match = i
+			break
+		}
+		if !isWrite(req.Method) {
+			match = i
+		}
+	}
+	if match >= 0 {
+		r.used[match] = true
+	}
+	r.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s %s", request.Method, requestURI(request))
+	}
+	return r.cassette.Interactions[match].Response.response(req), nil
+}
+
+func sameRequest(a, b RecordedHTTPRequest) bool {
+	return a.Method == b.Method && a.Path == b.Path && a.Query == b.Query
+}
+
+func requestURI(req RecordedHTTPRequest) string {
+	if req.Query == "" {
+		return req.Path
+	}
+	return req.Path + "?" + req.Query
+}
+
+// response rebuilds the recorded response for req
+func (r RecordedHTTPResponse) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+	body := []byte(r.Body)
+	// Bodies that were not JSON are recorded as a JSON string
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+	return &http.Response{
+		StatusCode:    r.Status,
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
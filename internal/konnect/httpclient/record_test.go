@@ -0,0 +1,140 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"sa-1","token":"spat_secret","config":{"client_secret":"s3cret"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":[{"id":"api-1","name":"orders"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewRecordingClient(&http.Client{})
+	recorder := NewInteractionRecorder()
+	ctx := WithInteractionRecorder(context.Background(), recorder)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		server.URL+"/v3/apis?page%5Bsize%5D=100&page%5Bnumber%5D=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer kpat_x")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	assert.JSONEq(t, `{"data":[{"id":"api-1","name":"orders"}]}`, string(body), "the response still reaches the caller")
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v3/system-accounts/sa-1/access-tokens",
+		strings.NewReader(`{"name":"ci","password":"hunter2"}`))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// Requests without a recorder are not recorded
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v3/portals", nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	interactions := recorder.Cassette().Interactions
+	require.Len(t, interactions, 2)
+	assert.Equal(t, RecordedHTTPRequest{Method: http.MethodGet, Path: "/v3/apis", Query: "page[number]=1&page[size]=100"},
+		interactions[0].Request)
+	assert.Equal(t, "application/json", interactions[0].Response.ContentType)
+	assert.JSONEq(t, `{"name":"ci","password":"[REDACTED]"}`, string(interactions[1].Request.Body))
+	assert.Equal(t, http.StatusCreated, interactions[1].Response.Status)
+	assert.JSONEq(t, `{"id":"sa-1","token":"[REDACTED]","config":{"client_secret":"[REDACTED]"}}`,
+		string(interactions[1].Response.Body))
+
+	path := filepath.Join(t.TempDir(), "fixtures", "plan.json")
+	require.NoError(t, recorder.Save(path))
+	cassette, err := LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 2)
+	assert.Equal(t, interactions[0].Request, cassette.Interactions[0].Request)
+	assert.JSONEq(t, string(interactions[1].Response.Body), string(cassette.Interactions[1].Response.Body))
+}
+
+func TestReplayer(t *testing.T) {
+	cassette := &Cassette{Version: CassetteVersion, Interactions: []Interaction{
+		{
+			Request:  RecordedHTTPRequest{Method: http.MethodGet, Path: "/v3/apis"},
+			Response: RecordedHTTPResponse{Status: http.StatusOK, ContentType: "application/json", Body: []byte(`{"data":[]}`)},
+		},
+		{
+			Request:  RecordedHTTPRequest{Method: http.MethodPost, Path: "/v3/apis", Body: []byte(`{"name":"orders"}`)},
+			Response: RecordedHTTPResponse{Status: http.StatusCreated, Body: []byte(`{"id":"api-1"}`)},
+		},
+		{
+			Request:  RecordedHTTPRequest{Method: http.MethodGet, Path: "/v3/apis"},
+			Response: RecordedHTTPResponse{Status: http.StatusOK, Body: []byte(`{"data":[{"id":"api-1"}]}`)},
+		},
+		{
+			Request:  RecordedHTTPRequest{Method: http.MethodGet, Path: "/v3/apis/api-1/documents"},
+			Response: RecordedHTTPResponse{Status: http.StatusNotFound, Body: []byte(`"not found"`)},
+		},
+	}}
+	replayer := NewReplayer(cassette)
+
+	do := func(method, path, body string) (int, string, error) {
+		t.Helper()
+		req, err := http.NewRequest(method, SimulatorURL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := replayer.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data), resp.Body.Close()
+	}
+
+	status, body, err := do(http.MethodGet, "/v3/apis", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"data":[]}`, body)
+
+	status, _, err = do(http.MethodPost, "/v3/apis", `{"name":"orders"}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+
+	// Reads are answered in the order they were recorded, then the last one repeats
+	for range 2 {
+		_, body, err = do(http.MethodGet, "/v3/apis", "")
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":[{"id":"api-1"}]}`, body)
+	}
+
+	// Extra writes and requests that were never recorded fail
+	_, _, err = do(http.MethodPost, "/v3/apis", `{"name":"orders"}`)
+	require.EqualError(t, err, "no recorded interaction for POST /v3/apis")
+	_, _, err = do(http.MethodGet, "/v3/portals?page%5Bsize%5D=1", "")
+	require.EqualError(t, err, "no recorded interaction for GET /v3/portals?page[size]=1")
+
+	assert.Len(t, replayer.Requests(), 6)
+	require.Len(t, replayer.Unused(), 1)
+	assert.Equal(t, "/v3/apis/api-1/documents", replayer.Unused()[0].Request.Path)
+
+	// Bodies that were not JSON are replayed as recorded
+	status, body, err = do(http.MethodGet, "/v3/apis/api-1/documents", "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "not found", body)
+}
@@ -0,0 +1,207 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how often a request is retried before its last response is returned
+	DefaultMaxRetries = 5
+
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+
+	// unixTimeResetThreshold separates reset headers in seconds from Unix times
+	unixTimeResetThreshold = 1_000_000_000
+)
+
+var (
+	// rateLimitResetHeaders tell when a rate limit resets, in their order of precedence
+	rateLimitResetHeaders = []string{"RateLimit-Reset", "X-RateLimit-Reset"}
+
+	// requestIDHeaders carry the ID of a request in Konnect responses
+	requestIDHeaders = []string{"X-Kong-Request-Id", "Kong-Request-Id", "X-Request-Id"}
+)
+
+// RetryClient retries requests that Konnect answered with 429 Too Many Requests,
+// waiting as long as the Retry-After or rate limit reset header asks, and retries idempotent requests
+// that failed with a transient 5xx status using exponential backoff with jitter.
+// A 429 means the request was not processed, so it is safe to retry for every
+// method. A 5xx does not tell whether a create succeeded, so POST requests are
+// not retried on 5xx and a created resource is never created twice.
+type RetryClient struct {
+	wrapped    Doer
+	maxRetries int
+	logger     *slog.Logger
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+type retryObserverKey struct{}
+
+// WithRetryObserver returns a context whose requests through a RetryClient call
+// observe before each retry, so progress output can count them
+func WithRetryObserver(ctx context.Context, observe func()) context.Context {
+	return context.WithValue(ctx, retryObserverKey{}, observe)
+}
+
+// NewRetryClient wraps an HTTP client to retry up to maxRetries times
+func NewRetryClient(wrapped Doer, maxRetries int, logger *slog.Logger) *RetryClient {
+	return &RetryClient{
+		wrapped:    wrapped,
+		maxRetries: maxRetries,
+		logger:     logger,
+		baseDelay:  defaultRetryBaseDelay,
+		maxDelay:   defaultRetryMaxDelay,
+	}
+}
+
+// Do implements the HTTPClient interface with retries
+func (c *RetryClient) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.wrapped.Do(req)
+		if err != nil || !isRetryable(req.Method, resp.StatusCode) {
+			return resp, err
+		}
+		if attempt > c.maxRetries {
+			if c.logger != nil && c.maxRetries > 0 {
+				c.logger.Warn("Konnect request failed after retries",
+					slog.String("method", req.Method),
+					slog.String("url", req.URL.String()),
+					slog.Int("status", resp.StatusCode),
+					slog.Int("attempts", attempt),
+					slog.String("request_id", RequestID(resp.Header)),
+				)
+			}
+			return resp, nil
+		}
+		// A consumed body that cannot be replayed ends the retries
+		next := req
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil //nolint:nilerr // the response of the last attempt is the result
+			}
+			next = req.Clone(req.Context())
+			next.Body = body
+		}
+
+		wait := c.delay(attempt, resp)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if observe, ok := req.Context().Value(retryObserverKey{}).(func()); ok {
+			observe()
+		}
+		if c.logger != nil {
+			c.logger.Debug("Retrying Konnect request",
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Int("status", resp.StatusCode),
+				slog.Int("attempt", attempt),
+				slog.Int("max_retries", c.maxRetries),
+				slog.Duration("wait", wait),
+				slog.String("request_id", RequestID(resp.Header)),
+			)
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// isRetryable reports whether a response status is worth retrying for the method
+func isRetryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+// delay honors a Retry-After header, then a rate limit reset header, and otherwise
+// doubles the base delay with each attempt up to the maximum, randomized to between
+// half and all of it
+func (c *RetryClient) delay(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		return wait
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		for _, header := range rateLimitResetHeaders {
+			if wait, ok := rateLimitReset(resp.Header.Get(header)); ok {
+				return wait
+			}
+		}
+	}
+
+	backoff := c.maxDelay
+	if attempt < 32 {
+		backoff = min(c.baseDelay<<(attempt-1), c.maxDelay)
+	}
+	half := backoff / 2
+	return half + rand.N(half+1) //nolint:gosec // jitter does not need a secure source
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// rateLimitReset parses a rate limit reset header given in seconds until the reset,
+// or as the Unix time of the reset like some gateways send
+func rateLimitReset(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	if seconds > unixTimeResetThreshold {
+		return max(time.Until(time.Unix(seconds, 0)), 0), true
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// RequestID returns the ID Konnect assigned to a request, found in the response
+// headers, to quote when reporting a failed request
+func RequestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := strings.TrimSpace(header.Get(name)); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
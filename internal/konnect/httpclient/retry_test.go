@@ -0,0 +1,144 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryClient(maxRetries int) *RetryClient {
+	client := NewRetryClient(&http.Client{}, maxRetries, nil)
+	client.baseDelay = time.Millisecond
+	client.maxDelay = 5 * time.Millisecond
+	return client
+}
+
+func TestRetryClient_RetriesRateLimitedRequests(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"name":"portal"}`))
+	require.NoError(t, err)
+	resp, err := newTestRetryClient(5).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+	require.Equal(t, []string{`{"name":"portal"}`, `{"name":"portal"}`, `{"name":"portal"}`}, bodies)
+}
+
+func TestRetryClient_StopsAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var retries int
+	ctx := WithRetryObserver(context.Background(), func() { retries++ })
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := newTestRetryClient(2).Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(3), calls.Load(), "one attempt plus two retries")
+	require.Equal(t, 2, retries)
+}
+
+func TestRetryClient_FailsFast(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		status int
+	}{
+		{name: "client error", method: http.MethodGet, status: http.StatusBadRequest},
+		{name: "not found", method: http.MethodDelete, status: http.StatusNotFound},
+		{name: "server error on create", method: http.MethodPost, status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(tt.method, server.URL, nil)
+			require.NoError(t, err)
+			resp, err := newTestRetryClient(5).Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			require.Equal(t, tt.status, resp.StatusCode)
+			require.Equal(t, int32(1), calls.Load())
+		})
+	}
+}
+
+func TestRetryClient_Delay(t *testing.T) {
+	client := NewRetryClient(nil, 5, nil)
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	require.Equal(t, 7*time.Second, client.delay(1, resp))
+
+	resp = &http.Response{Header: http.Header{}}
+	for attempt, want := range map[int]time.Duration{1: 500 * time.Millisecond, 3: 2 * time.Second, 20: 30 * time.Second} {
+		wait := client.delay(attempt, resp)
+		require.GreaterOrEqual(t, wait, want/2)
+		require.LessOrEqual(t, wait, want)
+	}
+}
+
+func TestRetryClient_DelayHonorsRateLimitReset(t *testing.T) {
+	client := NewRetryClient(nil, 5, nil)
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Ratelimit-Reset": []string{"4"}, "X-Ratelimit-Reset": []string{"9"}},
+	}
+	require.Equal(t, 4*time.Second, client.delay(1, resp))
+
+	resp.Header = http.Header{"Retry-After": []string{"2"}, "Ratelimit-Reset": []string{"4"}}
+	require.Equal(t, 2*time.Second, client.delay(1, resp), "Retry-After takes precedence")
+
+	resp.Header = http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)}}
+	require.Zero(t, client.delay(1, resp), "a reset in the past does not wait")
+
+	// Server errors are not rate limits, whatever their headers
+	resp = &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{"Ratelimit-Reset": []string{"20"}}}
+	require.LessOrEqual(t, client.delay(1, resp), 500*time.Millisecond)
+}
+
+func TestRequestID(t *testing.T) {
+	require.Empty(t, RequestID(http.Header{}))
+	require.Equal(t, "abc", RequestID(http.Header{"X-Request-Id": []string{"abc"}}))
+	require.Equal(t, "kong-1", RequestID(http.Header{
+		"X-Request-Id":      []string{"abc"},
+		"X-Kong-Request-Id": []string{"kong-1"},
+	}))
+}
@@ -0,0 +1,620 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SimulatorURL is the server URL of SDKs whose requests a Simulator answers. It is
+// never resolved.
+const SimulatorURL = "https://konnect.simulator.invalid"
+
+// SimulatorViolation is a request the simulator rejected
+type SimulatorViolation struct {
+	Method  string   `json:"method" yaml:"method"`
+	Path    string   `json:"path" yaml:"path"`
+	Status  int      `json:"status" yaml:"status"`
+	Reasons []string `json:"reasons" yaml:"reasons"`
+}
+
+func (v SimulatorViolation) String() string {
+	return fmt.Sprintf("%s %s (%d): %s", v.Method, v.Path, v.Status, strings.Join(v.Reasons, "; "))
+}
+
+// simulatorIssue is why a request was rejected, optionally naming the field at fault
+type simulatorIssue struct {
+	field  string
+	reason string
+}
+
+func (i simulatorIssue) String() string {
+	if i.field == "" {
+		return i.reason
+	}
+	return i.field + ": " + i.reason
+}
+
+// Simulator answers Konnect requests from an in-memory organization that starts
+// empty, so plans can be executed end to end without network access. Writes are
+// checked like Konnect would: bodies must match the request schema of the SDK,
+// parents and referenced resources must exist and top-level names are unique.
+// Rejected requests get the 400, 404 or 409 Konnect answers with and are kept
+// as violations. It is safe for concurrent use.
+//
+// Writes are recorded when the request context carries a DryRunRecorder, as
+// with a DryRunClient.
+type Simulator struct {
+	mu         sync.Mutex
+	routes     []simulatorRoute
+	resources  map[string]map[string]any
+	order      []string
+	ids        map[string]string
+	requests   int
+	violations []SimulatorViolation
+	now        func() time.Time
+}
+
+// NewSimulator returns a simulator of an empty Konnect organization
+func NewSimulator() *Simulator {
+	return &Simulator{
+		routes:    konnectRoutes,
+		resources: make(map[string]map[string]any),
+		ids:       make(map[string]string),
+		now:       time.Now,
+	}
+}
+
+// RequestCount returns how many requests the simulator answered
+func (s *Simulator) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Violations returns the rejected requests in the order they were made
+func (s *Simulator) Violations() []SimulatorViolation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SimulatorViolation(nil), s.violations...)
+}
+
+// Do implements the HTTPClient interface, answering the request from the simulated organization
+func (s *Simulator) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	if recorder := DryRunRecorderFromContext(req.Context()); recorder != nil && isWrite(req.Method) {
+		recorded := RecordedRequest{Method: req.Method, Path: req.URL.Path}
+		if len(body) > 0 {
+			var payload any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				payload = string(body)
+			}
+			recorded.Payload = payload
+		}
+		recorder.record(recorded)
+	}
+
+	s.mu.Lock()
+	s.requests++
+	n := s.requests
+	status, result, issues := s.handle(req.Method, cleanSimulatorPath(req.URL.Path), req.URL.Query(), body)
+	if len(issues) > 0 {
+		// Reads missing their resource are lookups, not rejected requests
+		if isWrite(req.Method) {
+			violation := SimulatorViolation{Method: req.Method, Path: req.URL.Path, Status: status}
+			for _, issue := range issues {
+				violation.Reasons = append(violation.Reasons, issue.String())
+			}
+			s.violations = append(s.violations, violation)
+		}
+		result = simulatorProblem(status, n, issues)
+	}
+	s.mu.Unlock()
+
+	return simulatorResponse(req, status, result)
+}
+
+// handle answers a request, returning its status and body or the issues it was rejected for
+func (s *Simulator) handle(method, p string, query url.Values, body []byte) (int, any, []simulatorIssue) {
+	route := s.route(method, p)
+	status := func(fallback int) int {
+		if route != nil && route.status != 0 {
+			return route.status
+		}
+		return fallback
+	}
+
+	var payload map[string]any
+	if isWrite(method) && method != http.MethodDelete {
+		var issues []simulatorIssue
+		payload, issues = decodeSimulatorBody(body, route)
+		if len(issues) > 0 {
+			return http.StatusBadRequest, nil, issues
+		}
+		if issues := s.checkReferences(payload, ""); len(issues) > 0 {
+			return http.StatusBadRequest, nil, issues
+		}
+	}
+
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	last := segments[len(segments)-1]
+	switch method {
+	case http.MethodGet:
+		if object, ok := s.resources[p]; ok {
+			return http.StatusOK, object, nil
+		}
+		if issue := s.missingParent(segments, len(segments)-1); issue != nil {
+			return http.StatusNotFound, nil, []simulatorIssue{*issue}
+		}
+		if isSimulatorID(last) || s.isSingleton(p) {
+			return http.StatusNotFound, nil, []simulatorIssue{{reason: fmt.Sprintf("%s not found", p)}}
+		}
+		return http.StatusOK, s.list(p, query), nil
+
+	case http.MethodPost:
+		if route != nil && route.action {
+			target := path.Dir(p)
+			if _, ok := s.resources[target]; !ok && !s.isSingleton(target) {
+				return http.StatusNotFound, nil, []simulatorIssue{{reason: fmt.Sprintf("%s not found", target)}}
+			}
+			return status(http.StatusNoContent), nil, nil
+		}
+		if issue := s.missingParent(segments, len(segments)); issue != nil {
+			return http.StatusNotFound, nil, []simulatorIssue{*issue}
+		}
+		if s.isSingleton(p) {
+			return status(http.StatusCreated), s.store(route, p, segments, payload, ""), nil
+		}
+		if issue := s.duplicateName(p, segments, payload); issue != nil {
+			return http.StatusConflict, nil, []simulatorIssue{*issue}
+		}
+		id := uuid.NewString()
+		return status(http.StatusCreated), s.store(route, p+"/"+id, append(segments, id), payload, id), nil
+
+	case http.MethodPut:
+		if issue := s.missingParent(segments, len(segments)-1); issue != nil {
+			return http.StatusNotFound, nil, []simulatorIssue{*issue}
+		}
+		id := ""
+		if isSimulatorID(last) {
+			if route != nil && !route.ownsLastSegment() {
+				// The item is keyed by another resource, e.g. the portal of a publication
+				if _, ok := s.ids[last]; !ok {
+					return http.StatusNotFound, nil, []simulatorIssue{{reason: fmt.Sprintf("%s %s not found",
+						route.lastParam(), last)}}
+				}
+			} else {
+				id = last
+			}
+		}
+		if existing, ok := s.resources[p]; ok {
+			payload["created_at"] = existing["created_at"]
+		}
+		return status(http.StatusOK), s.store(route, p, segments, payload, id), nil
+
+	case http.MethodPatch:
+		existing, ok := s.resources[p]
+		if !ok {
+			if !s.isSingleton(p) {
+				return http.StatusNotFound, nil, []simulatorIssue{{reason: fmt.Sprintf("%s not found", p)}}
+			}
+			if issue := s.missingParent(segments, len(segments)); issue != nil {
+				return http.StatusNotFound, nil, []simulatorIssue{*issue}
+			}
+			return status(http.StatusOK), s.store(route, p, segments, mergePatch(nil, payload), ""), nil
+		}
+		merged := mergePatch(existing, payload)
+		merged["updated_at"] = s.timestamp()
+		if route != nil && route.response != nil {
+			completeResponse(merged, route.response, s.timestamp())
+		}
+		s.resources[p] = merged
+		return status(http.StatusOK), merged, nil
+
+	case http.MethodDelete:
+		if _, ok := s.resources[p]; !ok {
+			return http.StatusNotFound, nil, []simulatorIssue{{reason: fmt.Sprintf("%s not found", p)}}
+		}
+		s.remove(p)
+		return status(http.StatusNoContent), nil, nil
+
+	default:
+		return http.StatusMethodNotAllowed, nil, []simulatorIssue{{reason: "method not supported by the simulator"}}
+	}
+}
+
+// route returns the known route of the request, or nil
+func (s *Simulator) route(method, p string) *simulatorRoute {
+	for i := range s.routes {
+		if s.routes[i].method == method && s.routes[i].matches(p) {
+			return &s.routes[i]
+		}
+	}
+	return nil
+}
+
+// isSingleton reports whether the path is a resource without an ID of its own, like the
+// customization of a portal, which is updated in place rather than listed
+func (s *Simulator) isSingleton(p string) bool {
+	for i := range s.routes {
+		route := s.routes[i]
+		if (route.method == http.MethodPatch || route.method == http.MethodPut) && !route.action &&
+			!strings.HasPrefix(route.segments[len(route.segments)-1], "{") && route.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingParent reports the first resource among the first n segments of the path
+// that does not exist. Segments holding IDs name resources.
+func (s *Simulator) missingParent(segments []string, n int) *simulatorIssue {
+	for i := 1; i < n && i < len(segments); i++ {
+		if !isSimulatorID(segments[i]) {
+			continue
+		}
+		parent := "/" + strings.Join(segments[:i+1], "/")
+		if _, ok := s.resources[parent]; !ok {
+			return &simulatorIssue{reason: fmt.Sprintf("parent %s %s not found", singular(segments[i-1]), segments[i])}
+		}
+	}
+	return nil
+}
+
+// checkReferences rejects *_id and *_ids fields holding IDs of resources that do not exist
+func (s *Simulator) checkReferences(object map[string]any, prefix string) []simulatorIssue {
+	var issues []simulatorIssue
+	for _, key := range sortedKeys(object) {
+		field := joinField(prefix, key)
+		switch value := object[key].(type) {
+		case string:
+			if strings.HasSuffix(key, "_id") && isSimulatorID(value) {
+				if _, ok := s.ids[value]; !ok {
+					issues = append(issues, simulatorIssue{field, fmt.Sprintf("references unknown resource %s", value)})
+				}
+			}
+		case []any:
+			if !strings.HasSuffix(key, "_ids") {
+				continue
+			}
+			for i, item := range value {
+				if id, ok := item.(string); ok && isSimulatorID(id) {
+					if _, ok := s.ids[id]; !ok {
+						issues = append(issues, simulatorIssue{fmt.Sprintf("%s[%d]", field, i),
+							fmt.Sprintf("references unknown resource %s", id)})
+					}
+				}
+			}
+		case map[string]any:
+			issues = append(issues, s.checkReferences(value, field)...)
+		}
+	}
+	return issues
+}
+
+// duplicateName rejects creating a top-level resource named like an existing one. APIs
+// are unique by name and version.
+func (s *Simulator) duplicateName(collection string, segments []string, payload map[string]any) *simulatorIssue {
+	name, ok := payload["name"].(string)
+	if !ok || len(segments) != 2 {
+		return nil
+	}
+	for _, p := range s.order {
+		if path.Dir(p) != collection {
+			continue
+		}
+		existing := s.resources[p]
+		if existing["name"] == name && fmt.Sprint(existing["version"]) == fmt.Sprint(payload["version"]) {
+			return &simulatorIssue{"name", fmt.Sprintf("a %s named %q already exists", singular(segments[1]), name)}
+		}
+	}
+	return nil
+}
+
+// store saves the payload at the path, completed like Konnect completes stored resources:
+// with its ID, timestamps, the IDs of the resources in its path and the other fields
+// the response of the route requires
+func (s *Simulator) store(
+	route *simulatorRoute, p string, segments []string, payload map[string]any, id string,
+) map[string]any {
+	object := make(map[string]any, len(payload)+4)
+	for key, value := range payload {
+		if value != nil {
+			object[key] = value
+		}
+	}
+	if id != "" {
+		if _, ok := object["id"]; !ok {
+			object["id"] = id
+		}
+	}
+	now := s.timestamp()
+	if object["created_at"] == nil {
+		object["created_at"] = now
+	}
+	object["updated_at"] = now
+	for i := 1; i < len(segments); i++ {
+		if !isSimulatorID(segments[i]) || segments[i] == id {
+			continue
+		}
+		// The collection of the referenced resource names the field, e.g. api_id or portal_id
+		collection := segments[i-1]
+		if owner, ok := s.ids[segments[i]]; ok {
+			collection = path.Base(path.Dir(owner))
+		}
+		field := strings.ReplaceAll(singular(collection), "-", "_") + "_id"
+		if _, ok := object[field]; !ok {
+			object[field] = segments[i]
+		}
+	}
+	if route != nil && route.response != nil {
+		completeResponse(object, route.response, now)
+	}
+
+	if _, ok := s.resources[p]; !ok {
+		s.order = append(s.order, p)
+	}
+	s.resources[p] = object
+	if objectID, ok := object["id"].(string); ok && objectID != "" {
+		s.ids[objectID] = p
+	}
+	return object
+}
+
+// remove deletes the resource at the path and every resource below it
+func (s *Simulator) remove(p string) {
+	kept := s.order[:0]
+	for _, stored := range s.order {
+		if stored != p && !strings.HasPrefix(stored, p+"/") {
+			kept = append(kept, stored)
+			continue
+		}
+		if id, ok := s.resources[stored]["id"].(string); ok && s.ids[id] == stored {
+			delete(s.ids, id)
+		}
+		delete(s.resources, stored)
+	}
+	s.order = kept
+}
+
+var filterParam = regexp.MustCompile(`^filter\[([^\]]+)\](?:\[(eq|contains|neq)\])?$`)
+
+// list returns a page of the resources of the collection, in creation order, filtered by
+// the filter[field][eq|neq|contains] parameters of the query
+func (s *Simulator) list(collection string, query url.Values) map[string]any {
+	type filter struct{ field, op, value string }
+	var filters []filter
+	for key, values := range query {
+		if match := filterParam.FindStringSubmatch(key); match != nil && len(values) > 0 {
+			op := match[2]
+			if op == "" {
+				op = "eq"
+			}
+			filters = append(filters, filter{match[1], op, values[0]})
+		}
+	}
+
+	data := []any{}
+	for _, p := range s.order {
+		if path.Dir(p) != collection {
+			continue
+		}
+		object := s.resources[p]
+		matches := true
+		for _, f := range filters {
+			value := fmt.Sprint(lookupField(object, f.field))
+			switch f.op {
+			case "eq":
+				matches = matches && value == f.value
+			case "neq":
+				matches = matches && value != f.value
+			case "contains":
+				matches = matches && strings.Contains(value, f.value)
+			}
+		}
+		if matches {
+			data = append(data, object)
+		}
+	}
+
+	total := len(data)
+	size := queryInt(query, "page[size]", 100)
+	number := queryInt(query, "page[number]", 1)
+	start := min((number-1)*size, total)
+	end := min(start+size, total)
+	return map[string]any{
+		"data": data[start:end],
+		"meta": map[string]any{"page": map[string]any{"number": number, "size": size, "total": total}},
+	}
+}
+
+func (s *Simulator) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+// decodeSimulatorBody decodes a write body, which must be a JSON object matching the
+// request schema of the route when the route is known
+func decodeSimulatorBody(body []byte, route *simulatorRoute) (map[string]any, []simulatorIssue) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if route != nil && route.body != nil {
+			return nil, []simulatorIssue{{reason: "request body is required"}}
+		}
+		return map[string]any{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, []simulatorIssue{{reason: fmt.Sprintf("request body is not valid JSON: %v", err)}}
+	}
+	if route != nil && route.body != nil {
+		if issues := validateSchema(value, route.body, ""); len(issues) > 0 {
+			return nil, issues
+		}
+		if err := decodeAs(body, route.body); err != nil {
+			return nil, []simulatorIssue{{reason: err.Error()}}
+		}
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, []simulatorIssue{{reason: "request body must be a JSON object"}}
+	}
+	return object, nil
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386): null removes a field and objects merge
+func mergePatch(target, patch map[string]any) map[string]any {
+	merged := make(map[string]any, len(target)+len(patch))
+	for key, value := range target {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			delete(merged, key)
+		case map[string]any:
+			existing, _ := merged[key].(map[string]any)
+			merged[key] = mergePatch(existing, v)
+		default:
+			merged[key] = v
+		}
+	}
+	return merged
+}
+
+// simulatorProblem is the problem document Konnect answers rejected requests with
+func simulatorProblem(status, n int, issues []simulatorIssue) map[string]any {
+	details := make([]string, 0, len(issues))
+	invalid := make([]map[string]any, 0, len(issues))
+	for _, issue := range issues {
+		details = append(details, issue.String())
+		if issue.field != "" {
+			invalid = append(invalid, map[string]any{"field": issue.field, "reason": issue.reason, "rule": "simulator"})
+		}
+	}
+	problem := map[string]any{
+		"status":   status,
+		"title":    http.StatusText(status),
+		"instance": fmt.Sprintf("kongctl:simulator:%d", n),
+		"detail":   strings.Join(details, "; "),
+	}
+	if status == http.StatusBadRequest {
+		problem["invalid_parameters"] = invalid
+	}
+	return problem
+}
+
+func simulatorResponse(req *http.Request, status int, result any) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	if status == http.StatusNoContent || result == nil {
+		return resp, nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	contentType := "application/json"
+	if status >= http.StatusBadRequest {
+		contentType = "application/problem+json"
+	}
+	resp.Header.Set("Content-Type", contentType)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	return resp, nil
+}
+
+func cleanSimulatorPath(p string) string {
+	cleaned := path.Clean("/" + p)
+	if cleaned == "/" {
+		return cleaned
+	}
+	return strings.TrimSuffix(cleaned, "/")
+}
+
+// isSimulatorID reports whether a path segment or field value is a resource ID
+func isSimulatorID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil && len(value) == 36
+}
+
+// singular returns the resource name of a collection path segment
+func singular(collection string) string {
+	switch {
+	case strings.HasSuffix(collection, "ies"):
+		return strings.TrimSuffix(collection, "ies") + "y"
+	case strings.HasSuffix(collection, "s"):
+		return strings.TrimSuffix(collection, "s")
+	default:
+		return collection
+	}
+}
+
+func lookupField(object map[string]any, field string) any {
+	var current any = object
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	if current == nil {
+		return ""
+	}
+	return current
+}
+
+func queryInt(query url.Values, key string, fallback int) int {
+	value, err := strconv.Atoi(query.Get(key))
+	if err != nil || value < 1 {
+		return fallback
+	}
+	return value
+}
+
+func joinField(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
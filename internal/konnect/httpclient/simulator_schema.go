@@ -0,0 +1,419 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+)
+
+// simulatorRoute is a Konnect operation the simulator validates request bodies of
+type simulatorRoute struct {
+	method   string
+	segments []string
+	// body is the SDK type of the request body
+	body reflect.Type
+	// response is the SDK type of the response body, whose required fields stored
+	// resources are completed with
+	response reflect.Type
+	// status is the success status of the operation when it differs from the default
+	// of its method
+	status int
+	// action operations change the resource above them instead of creating one
+	action bool
+}
+
+// konnectRoute returns the operation whose request body has type B and whose response
+// body has type R, any when it has none
+func konnectRoute[B, R any](method, pattern string) simulatorRoute {
+	r := simulatorRoute{
+		method:   method,
+		segments: strings.Split(strings.TrimPrefix(pattern, "/"), "/"),
+		body:     reflect.TypeFor[B](),
+	}
+	if response := reflect.TypeFor[R](); response.Kind() == reflect.Struct {
+		r.response = response
+	}
+	return r
+}
+
+func (r simulatorRoute) withStatus(status int) simulatorRoute {
+	r.status = status
+	return r
+}
+
+func (r simulatorRoute) asAction() simulatorRoute {
+	r.action = true
+	return r
+}
+
+func (r simulatorRoute) matches(p string) bool {
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if len(segments) != len(r.segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if !strings.HasPrefix(segment, "{") && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lastParam returns the resource named by the last path parameter, e.g. portal for {portalId}
+func (r simulatorRoute) lastParam() string {
+	param := strings.Trim(r.segments[len(r.segments)-1], "{}")
+	return strings.TrimSuffix(strings.TrimSuffix(param, "Id"), "ID")
+}
+
+// ownsLastSegment reports whether the last path parameter is the ID of the resource
+// itself, as in services/{serviceId}, rather than of another resource, as in
+// publications/{portalId}
+func (r simulatorRoute) ownsLastSegment() bool {
+	if len(r.segments) < 2 {
+		return true
+	}
+	param := strings.ToLower(r.lastParam())
+	collection := strings.ReplaceAll(singular(r.segments[len(r.segments)-2]), "-", "")
+	return param == "" || param == "id" || param == collection
+}
+
+// konnectRoutes are the write operations of the resources kongctl manages declaratively
+var konnectRoutes = []simulatorRoute{
+	konnectRoute[kkComps.CreatePortal, kkComps.PortalResponse](http.MethodPost, "/v3/portals"),
+	konnectRoute[kkComps.UpdatePortal, kkComps.PortalResponse](http.MethodPatch, "/v3/portals/{portalId}"),
+	konnectRoute[kkComps.CreatePortalPageRequest, kkComps.PortalPageResponse](http.MethodPost,
+		"/v3/portals/{portalId}/pages"),
+	konnectRoute[kkComps.UpdatePortalPageRequest, kkComps.PortalPageResponse](http.MethodPatch,
+		"/v3/portals/{portalId}/pages/{pageId}"),
+	konnectRoute[kkComps.MovePageRequestPayload, any](http.MethodPost,
+		"/v3/portals/{portalId}/pages/{pageId}/move").withStatus(http.StatusCreated).asAction(),
+	konnectRoute[kkComps.CreatePortalSnippetRequest, kkComps.PortalSnippetResponse](http.MethodPost,
+		"/v3/portals/{portalId}/snippets"),
+	konnectRoute[kkComps.UpdatePortalSnippetRequest, kkComps.PortalSnippetResponse](http.MethodPatch,
+		"/v3/portals/{portalId}/snippets/{snippetId}"),
+	konnectRoute[kkComps.CreatePortalCustomDomainRequest, kkComps.PortalCustomDomain](http.MethodPost,
+		"/v3/portals/{portalId}/custom-domain"),
+	konnectRoute[kkComps.UpdatePortalCustomDomainRequest, kkComps.PortalCustomDomain](http.MethodPatch,
+		"/v3/portals/{portalId}/custom-domain"),
+	konnectRoute[kkComps.PortalCustomization, kkComps.PortalCustomization](http.MethodPut,
+		"/v3/portals/{portalId}/customization"),
+	konnectRoute[kkComps.PortalCustomization, kkComps.PortalCustomization](http.MethodPatch,
+		"/v3/portals/{portalId}/customization"),
+	konnectRoute[kkComps.PortalAuthenticationSettingsUpdateRequest, kkComps.PortalAuthenticationSettingsResponse](
+		http.MethodPatch, "/v3/portals/{portalId}/authentication-settings"),
+	konnectRoute[kkComps.PortalTeamGroupMappingsUpdateRequest, kkComps.PortalTeamGroupMappingResponse](http.MethodPatch,
+		"/v3/portals/{portalId}/identity-provider/team-group-mappings"),
+	konnectRoute[kkComps.CreateIdentityProvider, kkComps.IdentityProvider](http.MethodPost,
+		"/v3/portals/{portalId}/identity-providers"),
+	konnectRoute[kkComps.UpdateIdentityProvider, kkComps.IdentityProvider](http.MethodPatch,
+		"/v3/portals/{portalId}/identity-providers/{id}"),
+	konnectRoute[kkComps.PortalCreateTeamRequest, kkComps.PortalTeamResponse](http.MethodPost,
+		"/v3/portals/{portalId}/teams"),
+	konnectRoute[kkComps.PortalUpdateTeamRequest, kkComps.PortalTeamResponse](http.MethodPatch,
+		"/v3/portals/{portalId}/teams/{teamId}"),
+
+	konnectRoute[kkComps.CreateAPIRequest, kkComps.APIResponseSchema](http.MethodPost, "/v3/apis"),
+	konnectRoute[kkComps.UpdateAPIRequest, kkComps.APIResponseSchema](http.MethodPatch, "/v3/apis/{apiId}"),
+	konnectRoute[kkComps.CreateAPIVersionRequest, kkComps.APIVersionResponse](http.MethodPost,
+		"/v3/apis/{apiId}/versions"),
+	konnectRoute[kkComps.APIVersion, kkComps.APIVersionResponse](http.MethodPatch,
+		"/v3/apis/{apiId}/versions/{versionId}"),
+	konnectRoute[kkComps.CreateAPISpecRequest, kkComps.APISpecResponse](http.MethodPost,
+		"/v3/apis/{apiId}/specifications"),
+	konnectRoute[kkComps.APISpec, kkComps.APISpecResponse](http.MethodPatch, "/v3/apis/{apiId}/specifications/{specId}"),
+	konnectRoute[kkComps.CreateAPIDocumentRequest, kkComps.APIDocumentResponse](http.MethodPost,
+		"/v3/apis/{apiId}/documents"),
+	konnectRoute[kkComps.APIDocument, kkComps.APIDocumentResponse](http.MethodPatch,
+		"/v3/apis/{apiId}/documents/{documentId}"),
+	konnectRoute[kkComps.MoveDocumentRequestPayload, any](http.MethodPost,
+		"/v3/apis/{apiId}/documents/{documentId}/move").asAction(),
+	konnectRoute[kkComps.APIPublication, kkComps.APIPublicationResponse](http.MethodPut,
+		"/v3/apis/{apiId}/publications/{portalId}"),
+	konnectRoute[kkComps.APIImplementation, kkComps.APIImplementationResponse](http.MethodPost,
+		"/v3/apis/{apiId}/implementations"),
+
+	konnectRoute[kkComps.CreateAppAuthStrategyRequest, kkComps.CreateAppAuthStrategyResponse](http.MethodPost,
+		"/v2/application-auth-strategies"),
+	konnectRoute[kkComps.CreateAppAuthStrategyRequest, kkComps.CreateAppAuthStrategyResponse](http.MethodPut,
+		"/v2/application-auth-strategies/{authStrategyId}").withStatus(http.StatusCreated),
+	konnectRoute[kkComps.UpdateAppAuthStrategyRequest, kkComps.CreateAppAuthStrategyResponse](http.MethodPatch,
+		"/v2/application-auth-strategies/{authStrategyId}"),
+
+	konnectRoute[kkComps.CreateControlPlaneRequest, kkComps.ControlPlane](http.MethodPost, "/v2/control-planes"),
+	konnectRoute[kkComps.UpdateControlPlaneRequest, kkComps.ControlPlane](http.MethodPatch, "/v2/control-planes/{id}"),
+	konnectRoute[kkComps.GroupMembership, any](http.MethodPut,
+		"/v2/control-planes/{id}/group-memberships").withStatus(http.StatusNoContent),
+	konnectRoute[kkComps.GroupMembership, any](http.MethodPost,
+		"/v2/control-planes/{id}/group-memberships/add").asAction(),
+	konnectRoute[kkComps.GroupMembership, any](http.MethodPost,
+		"/v2/control-planes/{id}/group-memberships/remove").asAction(),
+	konnectRoute[kkComps.Service, kkComps.ServiceOutput](http.MethodPost,
+		"/v2/control-planes/{controlPlaneId}/core-entities/services"),
+	konnectRoute[kkComps.Service, kkComps.ServiceOutput](http.MethodPut,
+		"/v2/control-planes/{controlPlaneId}/core-entities/services/{serviceId}"),
+}
+
+var (
+	timeType        = reflect.TypeFor[time.Time]()
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	exactType       = reflect.TypeFor[interface{ IsExact() bool }]()
+)
+
+// validateSchema checks a decoded JSON value against an SDK type: objects may only hold
+// the fields of the type and must hold its required ones, and values must have the
+// JSON type of their field. Unions must match one of their members.
+func validateSchema(value any, t reflect.Type, field string) []simulatorIssue {
+	if value == nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+			return nil
+		default:
+			return []simulatorIssue{{field, "must not be null"}}
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		if _, ok := value.(string); !ok {
+			return []simulatorIssue{{field, "must be a date-time string"}}
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.Struct:
+		if members := unionMembers(t); len(members) > 0 {
+			return validateUnion(value, members, field)
+		}
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []simulatorIssue{{field, "must be an object"}}
+		}
+		return validateObject(object, t, field)
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []simulatorIssue{{field, "must be an object"}}
+		}
+		var issues []simulatorIssue
+		for _, key := range sortedKeys(object) {
+			issues = append(issues, validateSchema(object[key], t.Elem(), joinField(field, key))...)
+		}
+		return issues
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return []simulatorIssue{{field, "must be an array"}}
+		}
+		var issues []simulatorIssue
+		for i, item := range items {
+			issues = append(issues, validateSchema(item, t.Elem(), fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return issues
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return []simulatorIssue{{field, "must be a string"}}
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return []simulatorIssue{{field, "must be a boolean"}}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			return []simulatorIssue{{field, "must be an integer"}}
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			return []simulatorIssue{{field, "must be a number"}}
+		}
+	}
+
+	// Enums check their values when decoded
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		data, _ := json.Marshal(value)
+		if err := decodeAs(data, t); err != nil {
+			return []simulatorIssue{{field, err.Error()}}
+		}
+	}
+	// Open enums report whether their value is a known one
+	if text, ok := value.(string); ok && t.Kind() == reflect.String && reflect.PointerTo(t).Implements(exactType) {
+		enum := reflect.New(t)
+		enum.Elem().SetString(text)
+		if !enum.Interface().(interface{ IsExact() bool }).IsExact() {
+			return []simulatorIssue{{field, fmt.Sprintf("unknown value %q", text)}}
+		}
+	}
+	return nil
+}
+
+func validateObject(object map[string]any, t reflect.Type, prefix string) []simulatorIssue {
+	var issues []simulatorIssue
+	fields := make(map[string]reflect.StructField, t.NumField())
+	additional := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			additional = additional || f.Tag.Get("additionalProperties") == "true"
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+
+	for _, key := range sortedKeys(object) {
+		f, ok := fields[key]
+		if !ok {
+			if !additional {
+				issues = append(issues, simulatorIssue{joinField(prefix, key), "unknown field"})
+			}
+			continue
+		}
+		issues = append(issues, validateSchema(object[key], f.Type, joinField(prefix, key))...)
+	}
+	for _, name := range sortedKeys(fields) {
+		if _, ok := object[name]; !ok && requiredField(fields[name]) {
+			issues = append(issues, simulatorIssue{joinField(prefix, name), "missing required field"})
+		}
+	}
+	return issues
+}
+
+// requiredField reports whether the SDK always sends the field: optional fields are
+// pointers, omitted when empty or have a default
+func requiredField(f reflect.StructField) bool {
+	switch f.Type.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return false
+	}
+	_, options, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return !strings.Contains(options, "omitempty") && f.Tag.Get("default") == ""
+}
+
+// requiredCollection reports whether a map or slice field without omitempty must be
+// present in a response, which the SDK checks when decoding
+func requiredCollection(f reflect.StructField) bool {
+	switch f.Type.Kind() {
+	case reflect.Map, reflect.Slice:
+		_, options, _ := strings.Cut(f.Tag.Get("json"), ",")
+		return !strings.Contains(options, "omitempty")
+	}
+	return false
+}
+
+func unionMembers(t reflect.Type) []reflect.Type {
+	var members []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("union") == "member" {
+			members = append(members, t.Field(i).Type)
+		}
+	}
+	return members
+}
+
+// validateUnion accepts a value matching one member, or reports the issues of the
+// member it comes closest to
+func validateUnion(value any, members []reflect.Type, field string) []simulatorIssue {
+	var closest []simulatorIssue
+	for i, member := range members {
+		issues := validateSchema(value, member, field)
+		if len(issues) == 0 {
+			return nil
+		}
+		if i == 0 || len(issues) < len(closest) {
+			closest = issues
+		}
+	}
+	return closest
+}
+
+// decodeAs decodes the JSON data into a new value of the SDK type, which checks enum
+// values and union discriminators
+func decodeAs(data []byte, t reflect.Type) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	return decoder.Decode(reflect.New(t).Interface())
+}
+
+// completeResponse adds the required fields of the SDK response type missing from the
+// stored resource, like the fields Konnect computes, with their zero value. Unions are
+// completed as the member the resource is closest to.
+func completeResponse(object map[string]any, t reflect.Type, now string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return
+	}
+	if members := unionMembers(t); len(members) > 0 {
+		closest, fewest := members[0], -1
+		for _, member := range members {
+			if issues := len(validateSchema(object, member, "")); fewest < 0 || issues < fewest {
+				closest, fewest = member, issues
+			}
+		}
+		completeResponse(object, closest, now)
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		value, ok := object[name]
+		if !ok {
+			if requiredField(f) || requiredCollection(f) {
+				object[name] = zeroJSON(f.Type, now)
+			}
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			completeResponse(nested, f.Type, now)
+		}
+	}
+}
+
+// zeroJSON returns the JSON zero value of an SDK type, timestamps being now
+func zeroJSON(t reflect.Type, now string) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return now
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return ""
+	case reflect.Bool:
+		return false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 0
+	case reflect.Slice, reflect.Array:
+		return []any{}
+	case reflect.Struct:
+		object := map[string]any{}
+		completeResponse(object, t, now)
+		return object
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	kk "github.com/Kong/sdk-konnect-go"
+	kkComps "github.com/Kong/sdk-konnect-go/models/components"
+	kkOps "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/stretchr/testify/require"
+)
+
+// simulate sends a request to the simulator and decodes the response body
+func simulate(t *testing.T, ctx context.Context, sim *Simulator, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, method, SimulatorURL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := sim.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]any
+	if resp.StatusCode != http.StatusNoContent {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	}
+	return resp.StatusCode, decoded
+}
+
+func TestSimulatorStoresResources(t *testing.T) {
+	sim := NewSimulator()
+	recorder := NewDryRunRecorder(nil)
+	ctx := WithDryRunRecorder(context.Background(), recorder)
+
+	status, api := simulate(t, ctx, sim, http.MethodPost, "/v3/apis", `{"name":"orders","labels":{"team":"a"}}`)
+	require.Equal(t, http.StatusCreated, status)
+	apiID, _ := api["id"].(string)
+	require.True(t, isSimulatorID(apiID))
+	require.NotEmpty(t, api["created_at"])
+
+	// Children get the ID of their parent
+	status, version := simulate(t, ctx, sim, http.MethodPost, "/v3/apis/"+apiID+"/versions",
+		`{"version":"1.0.0","spec":{"content":"openapi: 3.0.0"}}`)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, apiID, version["api_id"])
+
+	// Updates are merge patches
+	status, updated := simulate(t, ctx, sim, http.MethodPatch, "/v3/apis/"+apiID,
+		`{"description":"Orders","labels":{"team":null,"tier":"gold"}}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "Orders", updated["description"])
+	require.Equal(t, map[string]any{"tier": "gold"}, updated["labels"])
+
+	status, list := simulate(t, ctx, sim, http.MethodGet, "/v3/apis?filter[name][eq]=orders", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list["data"], 1)
+	status, list = simulate(t, ctx, sim, http.MethodGet, "/v3/apis?filter[name][eq]=billing", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, list["data"])
+
+	// Deletes remove the children too
+	status, _ = simulate(t, ctx, sim, http.MethodDelete, "/v3/apis/"+apiID, "")
+	require.Equal(t, http.StatusNoContent, status)
+	status, _ = simulate(t, ctx, sim, http.MethodGet, "/v3/apis/"+apiID+"/versions/"+version["id"].(string), "")
+	require.Equal(t, http.StatusNotFound, status)
+
+	require.Empty(t, sim.Violations())
+	require.Len(t, recorder.Requests(), 4)
+}
+
+func TestSimulatorRejectsInvalidRequests(t *testing.T) {
+	sim := NewSimulator()
+	ctx := context.Background()
+
+	status, problem := simulate(t, ctx, sim, http.MethodPost, "/v3/portals", `{"display_name":1,"colour":"red"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "colour: unknown field; display_name: must be a string; name: missing required field",
+		problem["detail"])
+	require.Len(t, problem["invalid_parameters"], 3)
+
+	status, _ = simulate(t, ctx, sim, http.MethodPost, "/v3/portals",
+		`{"name":"dev","default_api_visibility":"secret"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	// Parents and referenced resources must exist
+	missing := "11111111-2222-3333-4444-555555555555"
+	status, problem = simulate(t, ctx, sim, http.MethodPost, "/v3/apis/"+missing+"/documents",
+		`{"title":"Guide","slug":"guide","content":"x"}`)
+	require.Equal(t, http.StatusNotFound, status)
+	require.Equal(t, "parent api "+missing+" not found", problem["detail"])
+
+	status, api := simulate(t, ctx, sim, http.MethodPost, "/v3/apis", `{"name":"orders"}`)
+	require.Equal(t, http.StatusCreated, status)
+	status, _ = simulate(t, ctx, sim, http.MethodPut, "/v3/apis/"+api["id"].(string)+"/publications/"+missing,
+		`{"visibility":"public"}`)
+	require.Equal(t, http.StatusNotFound, status)
+	status, problem = simulate(t, ctx, sim, http.MethodPost, "/v3/portals",
+		`{"name":"dev","default_application_auth_strategy_id":"`+missing+`"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "default_application_auth_strategy_id: references unknown resource "+missing, problem["detail"])
+
+	// Top-level names are unique
+	status, _ = simulate(t, ctx, sim, http.MethodPost, "/v3/apis", `{"name":"orders"}`)
+	require.Equal(t, http.StatusConflict, status)
+
+	require.Len(t, sim.Violations(), 6)
+	require.Equal(t, 7, sim.RequestCount())
+}
+
+func TestSimulatorAnswersSDK(t *testing.T) {
+	sim := NewSimulator()
+	sdk := kk.New(
+		kk.WithServerURL(SimulatorURL),
+		kk.WithSecurity(kkComps.Security{PersonalAccessToken: kk.String("simulated")}),
+		kk.WithClient(sim),
+	)
+	ctx := context.Background()
+
+	created, err := sdk.AppAuthStrategies.CreateAppAuthStrategy(ctx,
+		kkComps.CreateCreateAppAuthStrategyRequestKeyAuth(kkComps.AppAuthStrategyKeyAuthRequest{
+			Name:        "key-auth",
+			DisplayName: "Key Auth",
+			Configs: kkComps.AppAuthStrategyKeyAuthRequestConfigs{
+				KeyAuth: kkComps.AppAuthStrategyConfigKeyAuth{KeyNames: []string{"apikey"}},
+			},
+		}))
+	require.NoError(t, err)
+	require.NotNil(t, created.CreateAppAuthStrategyResponse)
+
+	portal, err := sdk.Portals.CreatePortal(ctx, kkComps.CreatePortal{Name: "dev"})
+	require.NoError(t, err)
+	require.Equal(t, "dev", portal.PortalResponse.Name)
+
+	listed, err := sdk.Portals.ListPortals(ctx, kkOps.ListPortalsRequest{})
+	require.NoError(t, err)
+	require.Len(t, listed.ListPortalsResponse.Data, 1)
+
+	_, err = sdk.APIVersion.CreateAPIVersion(ctx, "11111111-2222-3333-4444-555555555555",
+		kkComps.CreateAPIVersionRequest{
+			Version: kk.String("1.0.0"),
+			Spec:    kkComps.CreateAPIVersionRequestSpec{Content: kk.String("openapi: 3.0.0")},
+		})
+	require.ErrorContains(t, err, "parent api 11111111-2222-3333-4444-555555555555 not found")
+}
@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout bounds requests made without a context deadline
+const DefaultTimeout = 60 * time.Second
+
+// Doer is an HTTP client such as *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DefaultTimeoutClient bounds requests whose context has no deadline by a default
+// timeout. Requests carrying a deadline, such as declarative operations with their
+// own timeout, are bounded by that deadline alone, so it can exceed the default.
+type DefaultTimeoutClient struct {
+	wrapped Doer
+	timeout time.Duration
+}
+
+// NewDefaultTimeoutClient wraps an HTTP client, which should have no Timeout of its own
+func NewDefaultTimeoutClient(wrapped Doer, timeout time.Duration) *DefaultTimeoutClient {
+	return &DefaultTimeoutClient{wrapped: wrapped, timeout: timeout}
+}
+
+// Do implements the HTTPClient interface with the default timeout
+func (c *DefaultTimeoutClient) Do(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok || c.timeout <= 0 {
+		return c.wrapped.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+	resp, err := c.wrapped.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// Like http.Client.Timeout, the timeout covers reading the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,137 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TransportOptions configure how requests reach Konnect on networks that route them
+// through an egress proxy, possibly one that intercepts TLS
+type TransportOptions struct {
+	// Proxy is the URL of the proxy for every request; empty uses the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables
+	Proxy string
+	// CACertFile is a PEM bundle of certificate authorities trusted in addition to the
+	// system ones, such as the one re-signing intercepted TLS
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are the PEM certificate and key presented to
+	// servers that require mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// IsZero reports whether no option is set, so the default transport can be used as is
+func (o TransportOptions) IsZero() bool {
+	return o == TransportOptions{}
+}
+
+// NewTransport returns a clone of the default transport configured with opts
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("the default HTTP transport is not an *http.Transport")
+	}
+	transport := base.Clone()
+
+	if opts.Proxy != "" {
+		proxy, err := proxyFunc(opts.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+	}
+
+	tlsConfig, err := tlsConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+// ConfigureDefaultTransport replaces http.DefaultTransport with a transport configured
+// with opts, so every client without a transport of its own, from the Konnect SDK to
+// login and the readers of remote files, uses it. It does nothing when no option is set.
+func ConfigureDefaultTransport(opts TransportOptions) error {
+	if opts.IsZero() {
+		return nil
+	}
+	transport, err := NewTransport(opts)
+	if err != nil {
+		return err
+	}
+	http.DefaultTransport = transport
+	return nil
+}
+
+// proxyFunc sends every request through the proxy at rawURL, except the hosts listed
+// in NO_PROXY
+func proxyFunc(rawURL string) (func(*http.Request) (*url.URL, error), error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || !strings.Contains(rawURL, "://") || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: expected a URL such as http://proxy.example.com:3128", rawURL)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", rawURL)
+	}
+
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  rawURL,
+		HTTPSProxy: rawURL,
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
+
+// tlsConfig returns the TLS configuration of opts, or nil when they change nothing
+func tlsConfig(opts TransportOptions) (*tls.Config, error) {
+	if opts.CACertFile == "" && opts.ClientCertFile == "" && opts.ClientKeyFile == "" {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CACertFile != "" {
+		data, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+		// The system pool is unavailable on some platforms, which leaves the bundle alone
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates found in CA file %s", opts.CACertFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+			return nil, fmt.Errorf("a client certificate requires both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
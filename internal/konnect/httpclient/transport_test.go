@@ -0,0 +1,152 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	transport, err := NewTransport(TransportOptions{Proxy: proxy.URL})
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://us.api.konghq.example/v3/portals")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{"http://us.api.konghq.example/v3/portals"}, proxied)
+
+	req, err := http.NewRequest(http.MethodGet, "https://internal.example.com/", nil)
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL, "NO_PROXY hosts bypass the proxy")
+}
+
+func TestNewTransport_InvalidProxy(t *testing.T) {
+	for _, proxy := range []string{"proxy.example.com:3128", "ftp://proxy.example.com", "http://"} {
+		_, err := NewTransport(TransportOptions{Proxy: proxy})
+		assert.ErrorContains(t, err, "invalid proxy URL", proxy)
+	}
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport, err := NewTransport(TransportOptions{})
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err, "the test server CA is not trusted by default")
+
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	transport, err = NewTransport(TransportOptions{CACertFile: caFile})
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+	_, err = NewTransport(TransportOptions{CACertFile: empty})
+	assert.ErrorContains(t, err, "no PEM certificates found")
+}
+
+func TestNewTransport_ClientCert(t *testing.T) {
+	certFile, keyFile, clientCert := writeClientCert(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "kongctl", r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.WriteHeader(http.StatusOK)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	transport, err := NewTransport(TransportOptions{CACertFile: caFile})
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	require.Error(t, err, "the server requires a client certificate")
+
+	transport, err = NewTransport(TransportOptions{
+		CACertFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile,
+	})
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = NewTransport(TransportOptions{ClientCertFile: certFile})
+	assert.ErrorContains(t, err, "requires both a certificate and a key file")
+}
+
+func TestConfigureDefaultTransport(t *testing.T) {
+	original := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	require.NoError(t, ConfigureDefaultTransport(TransportOptions{}))
+	assert.Same(t, original, http.DefaultTransport)
+
+	require.NoError(t, ConfigureDefaultTransport(TransportOptions{Proxy: "http://proxy.example.com:3128"}))
+	assert.NotSame(t, original, http.DefaultTransport)
+
+	assert.Error(t, ConfigureDefaultTransport(TransportOptions{CACertFile: "/nonexistent/ca.pem"}))
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and its key
+func writeClientCert(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kongctl"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, "client.pem", "CERTIFICATE", der), writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER), cert
+}
@@ -38,8 +38,11 @@ func (v *profileManager) GetProfiles() []string {
 	keyMap := make(map[string]bool)
 
 	for _, key := range allKeys {
-		topLevelKey := strings.Split(key, ".")[0]
-		keyMap[topLevelKey] = true
+		// Top-level scalars such as current-profile are settings, not profiles
+		topLevelKey, _, nested := strings.Cut(key, ".")
+		if nested {
+			keyMap[topLevelKey] = true
+		}
 	}
 
 	uniqueTopLevelKeys := make([]string, 0, len(keyMap))
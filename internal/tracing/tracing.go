@@ -0,0 +1,123 @@
+// Package tracing instruments declarative runs with OpenTelemetry spans.
+//
+// Spans are created through the global tracer provider once Setup installs an
+// OTLP exporter. Until then Start returns the no-op span already carried by the
+// context without consulting OpenTelemetry, so disabled tracing records, buffers
+// and exports nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kong/kongctl/internal/meta"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifies kongctl spans
+const InstrumentationName = "github.com/kong/kongctl"
+
+// Span names used across plan and apply
+const (
+	SpanLoad    = "kongctl.load"
+	SpanResolve = "kongctl.resolve"
+	SpanFetch   = "kongctl.fetch"
+	SpanPlan    = "kongctl.plan"
+	SpanApply   = "kongctl.apply"
+	SpanExecute = "kongctl.execute"
+)
+
+// Attribute keys carried by resource spans
+const (
+	AttrResourceType  = attribute.Key("kongctl.resource.type")
+	AttrResourceRef   = attribute.Key("kongctl.resource.ref")
+	AttrOperation     = attribute.Key("kongctl.operation")
+	AttrNamespace     = attribute.Key("kongctl.namespace")
+	AttrMode          = attribute.Key("kongctl.plan.mode")
+	AttrChangeID      = attribute.Key("kongctl.change.id")
+	AttrDryRun        = attribute.Key("kongctl.dry_run")
+	AttrSourceCount   = attribute.Key("kongctl.load.sources")
+	AttrResourceCount = attribute.Key("kongctl.load.resources")
+)
+
+// enabled reports whether a tracer provider has been installed
+var enabled atomic.Bool
+
+// shutdownTimeout bounds how long exporting buffered spans may delay exit
+const shutdownTimeout = 5 * time.Second
+
+// Setup installs a global tracer provider exporting spans over OTLP/HTTP to endpoint
+// (e.g. http://localhost:4318). The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, endpoint, version string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter for %s: %w", endpoint, err)
+	}
+
+	res := sdkresource.NewSchemaless(
+		semconv.ServiceName(meta.CLIName),
+		semconv.ServiceVersion(version),
+	)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// SetTracerProvider installs provider as the global tracer provider and enables
+// span creation. Passing nil disables tracing again.
+func SetTracerProvider(provider trace.TracerProvider) {
+	if provider == nil {
+		enabled.Store(false)
+		return
+	}
+	otel.SetTracerProvider(provider)
+	enabled.Store(true)
+}
+
+// Enabled reports whether spans are being recorded
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Start starts a span using the global tracer provider
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled.Load() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// ResourceAttributes returns the attributes describing an operation on a resource
+func ResourceAttributes(resourceType, ref, operation string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		AttrResourceType.String(resourceType),
+		AttrResourceRef.String(ref),
+		AttrOperation.String(operation),
+	}
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
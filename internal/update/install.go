@@ -0,0 +1,184 @@
+package update
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// maxArchiveSize bounds the size of a downloaded release archive
+const maxArchiveSize = 200 << 20
+
+// ArchiveName returns the name of the release archive for an operating system and
+// architecture, following the goreleaser name template of the release
+func ArchiveName(goos, goarch string) string {
+	return fmt.Sprintf("kongctl_%s_%s.zip", goos, goarch)
+}
+
+// BinaryName returns the name of the kongctl binary inside the archive
+func BinaryName(goos string) string {
+	if goos == "windows" {
+		return "kongctl.exe"
+	}
+	return "kongctl"
+}
+
+// DownloadBinary downloads the archive of the release for the running platform,
+// verifies its checksum against the checksums of the release and returns the
+// kongctl binary it contains
+func (c *Client) DownloadBinary(ctx context.Context, release *Release) ([]byte, error) {
+	return c.downloadBinary(ctx, release, runtime.GOOS, runtime.GOARCH)
+}
+
+func (c *Client) downloadBinary(ctx context.Context, release *Release, goos, goarch string) ([]byte, error) {
+	archiveName := ArchiveName(goos, goarch)
+	archive := release.Asset(archiveName)
+	if archive == nil {
+		return nil, fmt.Errorf("release %s has no archive for %s/%s", release.TagName, goos, goarch)
+	}
+	checksums := release.Asset(ChecksumsAsset)
+	if checksums == nil {
+		return nil, fmt.Errorf("release %s has no %s, the download cannot be verified", release.TagName, ChecksumsAsset)
+	}
+
+	sums, err := c.download(ctx, checksums.BrowserDownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := checksumOf(sums, archiveName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.download(ctx, archive.BrowserDownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, expected, actual)
+	}
+	return extractBinary(data, BinaryName(goos))
+}
+
+func (c *Client) download(ctx context.Context, url string) ([]byte, error) {
+	resp, err := c.get(ctx, url, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if len(data) > maxArchiveSize {
+		return nil, fmt.Errorf("download %s exceeds %d bytes", url, maxArchiveSize)
+	}
+	return data, nil
+}
+
+// checksumOf returns the SHA-256 checksum of a file from a checksums file in the
+// sha256sum format
+func checksumOf(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s", ChecksumsAsset, name)
+}
+
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open release archive: %w", err)
+	}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || filepath.Base(file.Name) != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, maxArchiveSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+		if len(data) > maxArchiveSize {
+			return nil, fmt.Errorf("%s in the release archive exceeds %d bytes", name, maxArchiveSize)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("release archive contains no %s", name)
+}
+
+// ReplaceExecutable replaces the binary at path with binary. The new binary is
+// written next to the old one and renamed over it, so the binary is never left
+// half written. Windows cannot replace a running binary, so there the old binary is
+// moved aside to path.old first.
+func ReplaceExecutable(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read current binary: %w", err)
+	}
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write new binary to %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move aside current binary: %w", err)
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			_ = os.Rename(old, path)
+			return fmt.Errorf("failed to install new binary: %w", err)
+		}
+		return nil
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+	return nil
+}
+
+// ManagedBy returns the package manager that installed the binary at path, such as
+// Homebrew, or "" when the binary was installed by hand. A package manager keeps
+// track of the version it installed, so such binaries are upgraded through it.
+func ManagedBy(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		resolved = path
+	}
+	resolved = filepath.ToSlash(resolved)
+	if strings.Contains(resolved, "/Caskroom/") || strings.Contains(resolved, "/Cellar/") {
+		return "Homebrew"
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+// Package update finds kongctl releases on GitHub and replaces the running binary
+// with the binary of a release.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// DefaultAPIURL is the GitHub API the releases are read from
+	DefaultAPIURL = "https://api.github.com"
+	// Repository is the GitHub repository kongctl is released from
+	Repository = "Kong/kongctl"
+	// ChecksumsAsset is the release asset listing the SHA-256 checksums of the archives
+	ChecksumsAsset = "checksums.txt"
+
+	defaultTimeout = 30 * time.Second
+	// maxReleasePages bounds how many pages of releases are read for the newest one
+	maxReleasePages = 5
+)
+
+// Release is a published kongctl release
+type Release struct {
+	TagName    string  `json:"tag_name"`
+	Name       string  `json:"name"`
+	HTMLURL    string  `json:"html_url"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+// Version returns the version of the release without the leading v
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// Asset returns the asset of the release with the name, or nil
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Client reads kongctl releases from GitHub
+type Client struct {
+	// HTTPClient sends the requests, http.DefaultClient with a timeout when nil
+	HTTPClient *http.Client
+	// APIURL is the GitHub API, DefaultAPIURL when empty
+	APIURL string
+	// Token authenticates the requests, raising the GitHub rate limit. GITHUB_TOKEN
+	// is used when empty.
+	Token string
+}
+
+// LatestRelease returns the newest release by semantic version. Prereleases are
+// only considered when prerelease is set.
+func (c *Client) LatestRelease(ctx context.Context, prerelease bool) (*Release, error) {
+	var latest *Release
+	for page := 1; page <= maxReleasePages; page++ {
+		var releases []Release
+		path := fmt.Sprintf("/repos/%s/releases?per_page=100&page=%d", Repository, page)
+		if err := c.getJSON(ctx, path, &releases); err != nil {
+			return nil, err
+		}
+		for i := range releases {
+			release := &releases[i]
+			if release.Draft || (release.Prerelease && !prerelease) || !semver.IsValid(canonical(release.TagName)) {
+				continue
+			}
+			if latest == nil || semver.Compare(canonical(release.TagName), canonical(latest.TagName)) > 0 {
+				latest = release
+			}
+		}
+		if len(releases) < 100 {
+			break
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no releases found for %s", Repository)
+	}
+	return latest, nil
+}
+
+// ReleaseByVersion returns the release of a version, such as 0.3.0 or v0.3.0
+func (c *Client) ReleaseByVersion(ctx context.Context, version string) (*Release, error) {
+	release := &Release{}
+	path := fmt.Sprintf("/repos/%s/releases/tags/%s", Repository, canonical(version))
+	if err := c.getJSON(ctx, path, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// IsNewer reports whether the version of a release is newer than the current one.
+// A current version that is not a release, such as dev, is never up to date.
+func IsNewer(current, candidate string) bool {
+	if !semver.IsValid(canonical(current)) {
+		return true
+	}
+	return semver.Compare(canonical(candidate), canonical(current)) > 0
+}
+
+// IsRelease reports whether a version is a released version rather than a
+// development build
+func IsRelease(version string) bool {
+	return semver.IsValid(canonical(version))
+}
+
+func canonical(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, into any) error {
+	resp, err := c.get(ctx, c.apiURL()+path, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("failed to decode GitHub response: %w", err)
+	}
+	return nil
+}
+
+// get sends a GET request and returns the response of a 2xx status
+func (c *Client) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token := c.token(); token != "" && strings.HasPrefix(url, c.apiURL()) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("not found on GitHub: %s", url)
+		}
+		return nil, fmt.Errorf("GitHub returned %s for %s: %s", resp.Status, url, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+func (c *Client) apiURL() string {
+	if c.APIURL != "" {
+		return strings.TrimSuffix(c.APIURL, "/")
+	}
+	return DefaultAPIURL
+}
+
+func (c *Client) token() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: defaultTimeout}
+}
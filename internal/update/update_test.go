@@ -0,0 +1,159 @@
+package update
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseServer serves releases of kongctl like the GitHub API, with the archive of
+// the linux/amd64 binary and its checksums
+type releaseServer struct {
+	*httptest.Server
+	releases []Release
+	archive  []byte
+	checksum string
+}
+
+func newReleaseServer(t *testing.T, binary []byte) *releaseServer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string][]byte{"kongctl": binary, "README.md": []byte("readme")} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	sum := sha256.Sum256(buf.Bytes())
+
+	s := &releaseServer{archive: buf.Bytes(), checksum: hex.EncodeToString(sum[:])}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/Kong/kongctl/releases", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(s.releases)
+	})
+	mux.HandleFunc("/repos/Kong/kongctl/releases/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		for _, release := range s.releases {
+			if release.TagName == r.PathValue("tag") {
+				_ = json.NewEncoder(w).Encode(release)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		switch filepath.Base(r.URL.Path) {
+		case ChecksumsAsset:
+			fmt.Fprintf(w, "%s  kongctl_darwin_arm64.zip\n%s  kongctl_linux_amd64.zip\n", s.checksum, s.checksum)
+		case "kongctl_linux_amd64.zip":
+			_, _ = w.Write(s.archive)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *releaseServer) release(tag string) Release {
+	return Release{
+		TagName: tag,
+		Assets: []Asset{
+			{Name: "kongctl_linux_amd64.zip", BrowserDownloadURL: s.URL + "/download/" + tag + "/kongctl_linux_amd64.zip"},
+			{Name: ChecksumsAsset, BrowserDownloadURL: s.URL + "/download/" + tag + "/" + ChecksumsAsset},
+		},
+	}
+}
+
+func (s *releaseServer) client() *Client {
+	return &Client{APIURL: s.URL, Token: "test"}
+}
+
+func TestLatestRelease(t *testing.T) {
+	s := newReleaseServer(t, nil)
+	prerelease := s.release("v0.5.0-beta.1")
+	prerelease.Prerelease = true
+	draft := s.release("v0.6.0")
+	draft.Draft = true
+	s.releases = []Release{s.release("v0.3.0"), prerelease, s.release("v0.4.1"), draft, s.release("nightly")}
+
+	latest, err := s.client().LatestRelease(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, "0.4.1", latest.Version())
+
+	latest, err = s.client().LatestRelease(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "0.5.0-beta.1", latest.Version())
+}
+
+func TestReleaseByVersion(t *testing.T) {
+	s := newReleaseServer(t, nil)
+	s.releases = []Release{s.release("v0.3.0")}
+
+	release, err := s.client().ReleaseByVersion(context.Background(), "0.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.3.0", release.TagName)
+
+	_, err = s.client().ReleaseByVersion(context.Background(), "9.9.9")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestIsNewer(t *testing.T) {
+	assert.True(t, IsNewer("0.3.0", "0.4.0"))
+	assert.True(t, IsNewer("v0.3.0", "0.3.1"))
+	assert.False(t, IsNewer("0.4.0", "0.4.0"))
+	assert.False(t, IsNewer("0.4.0", "0.3.9"))
+	assert.True(t, IsNewer("dev", "0.1.0"), "a development build is never up to date")
+}
+
+func TestDownloadBinary(t *testing.T) {
+	s := newReleaseServer(t, []byte("new kongctl"))
+	release := s.release("v0.4.0")
+
+	binary, err := s.client().downloadBinary(context.Background(), &release, "linux", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new kongctl"), binary)
+
+	_, err = s.client().downloadBinary(context.Background(), &release, "windows", "amd64")
+	assert.ErrorContains(t, err, "no archive for windows/amd64")
+
+	s.checksum = "0000"
+	_, err = s.client().downloadBinary(context.Background(), &release, "linux", "amd64")
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kongctl")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o755))
+
+	require.NoError(t, ReplaceExecutable(path, []byte("new")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestManagedBy(t *testing.T) {
+	assert.Equal(t, "Homebrew", ManagedBy("/opt/homebrew/Caskroom/kongctl/0.3.0/kongctl"))
+	assert.Equal(t, "", ManagedBy("/usr/local/bin/kongctl"))
+}
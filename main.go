@@ -1,15 +1,7 @@
 package main
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/kong/kongctl/internal/build"
-	"github.com/kong/kongctl/internal/cmd/root"
-	"github.com/kong/kongctl/internal/iostreams"
+	"github.com/kong/kongctl/pkg/cli"
 )
 
 var (
@@ -18,26 +10,10 @@ var (
 	date    = "unknown"
 )
 
-func registerSignalHandler() context.Context {
-	ctx, cancel := context.WithCancel(context.Background())
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-
-	go func() {
-		defer signal.Stop(sigs)
-		sig := <-sigs
-		fmt.Println("received", sig, ", terminating...")
-		cancel()
-	}()
-	return ctx
-}
-
 func main() {
-	ctx := registerSignalHandler()
-	bi := build.Info{
+	cli.Main(cli.BuildInfo{
 		Version: version,
 		Commit:  commit,
 		Date:    date,
-	}
-	root.Execute(ctx, iostreams.GetOSIOStreams(), &bi)
+	})
 }
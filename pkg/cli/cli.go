@@ -0,0 +1,55 @@
+// Package cli runs the kongctl command line. Modules that extend kongctl, for example
+// with custom resource handlers, build their own binary with a main package that
+// imports their extensions and calls Main:
+//
+//	package main
+//
+//	import (
+//		"github.com/kong/kongctl/pkg/cli"
+//
+//		_ "example.com/acme/widgets" // registers the acme_widget handler
+//	)
+//
+//	func main() {
+//		cli.Main(cli.BuildInfo{Version: "1.4.0-acme"})
+//	}
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kong/kongctl/internal/build"
+	"github.com/kong/kongctl/internal/cmd/root"
+	"github.com/kong/kongctl/internal/iostreams"
+)
+
+// BuildInfo is the version reported by kongctl version
+type BuildInfo = build.Info
+
+// Main runs kongctl with the arguments of the process and exits when it finishes
+func Main(info BuildInfo) {
+	ctx := registerSignalHandler()
+	root.Execute(ctx, iostreams.GetOSIOStreams(), &info)
+}
+
+func registerSignalHandler() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	// The first signal cancels in-flight Konnect requests and lets the command report
+	// what completed; a second one exits immediately
+	go func() {
+		defer signal.Stop(sigs)
+		sig := <-sigs
+		fmt.Fprintln(os.Stderr, "received", sig, ", terminating... (press Ctrl-C again to exit immediately)")
+		cancel()
+		<-sigs
+		os.Exit(130)
+	}()
+	return ctx
+}
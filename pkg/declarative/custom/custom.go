@@ -0,0 +1,71 @@
+// Package custom lets modules outside kongctl add resource kinds to declarative
+// planning and execution. A handler is compiled into a kongctl binary built by the
+// module, see package cli, and registers itself from an init function:
+//
+//	func init() {
+//		custom.MustRegister(widgets.NewHandler(client))
+//	}
+//
+// Configuration declares instances under custom_resources with the handler's kind.
+// The planner lists existing instances through the handler, diffs them against the
+// desired state and emits CREATE, UPDATE and DELETE changes that share refs, labels,
+// namespaces, protection and dependency ordering with built-in resources. The
+// executor then hands each change back to the handler.
+//
+// The types of this package alias those of the engine, so handlers registered here
+// are the ones kongctl plans and applies.
+package custom
+
+import (
+	internal "github.com/kong/kongctl/internal/declarative/custom"
+	"github.com/kong/kongctl/internal/declarative/resources"
+)
+
+type (
+	// Handler plans and applies one custom resource kind
+	Handler = internal.Handler
+	// State is an existing instance of a custom kind as reported by its handler
+	State = internal.State
+	// Request describes a planned change being applied by a handler
+	Request = internal.Request
+	// Resource is an instance of a custom kind declared under custom_resources
+	Resource = resources.CustomResource
+	// MemoryHandler is an example handler that keeps instances in memory
+	MemoryHandler = internal.MemoryHandler
+)
+
+// Register makes a handler available to the planner and executor
+func Register(handler Handler) error {
+	return internal.Register(handler)
+}
+
+// MustRegister is like Register but panics on error; intended for init functions
+func MustRegister(handler Handler) {
+	internal.MustRegister(handler)
+}
+
+// Unregister removes the handler for kind, if any
+func Unregister(kind string) {
+	internal.Unregister(kind)
+}
+
+// Lookup returns the handler registered for kind
+func Lookup(kind string) (Handler, bool) {
+	return internal.Lookup(kind)
+}
+
+// Kinds returns the registered kinds in sorted order
+func Kinds() []string {
+	return internal.Kinds()
+}
+
+// DiffSpec is a Diff helper for handlers that store the spec as given. It returns
+// desired values for added or changed keys and nil for keys missing from desired.
+func DiffSpec(current, desired map[string]any) map[string]any {
+	return internal.DiffSpec(current, desired)
+}
+
+// NewMemoryHandler creates an empty in-memory handler for kind
+func NewMemoryHandler(kind string) *MemoryHandler {
+	return internal.NewMemoryHandler(kind)
+}
@@ -0,0 +1,93 @@
+package custom_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kong/kongctl/pkg/declarative"
+	"github.com/kong/kongctl/pkg/declarative/custom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// widgetHandler is written like a handler of another module: it only uses the
+// packages under pkg
+type widgetHandler struct {
+	widgets map[string]custom.State
+}
+
+func (h *widgetHandler) Kind() string { return "acme_widget" }
+
+func (h *widgetHandler) Validate(resource custom.Resource) error {
+	if _, ok := resource.Spec["size"]; !ok {
+		return fmt.Errorf("spec.size is required")
+	}
+	return nil
+}
+
+func (h *widgetHandler) List(context.Context) ([]custom.State, error) {
+	states := make([]custom.State, 0, len(h.widgets))
+	for _, state := range h.widgets {
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (h *widgetHandler) Diff(current custom.State, desired custom.Resource) map[string]any {
+	return custom.DiffSpec(current.Spec, desired.Spec)
+}
+
+func (h *widgetHandler) Create(_ context.Context, req custom.Request) (string, error) {
+	id := fmt.Sprintf("widget-%d", len(h.widgets)+1)
+	h.widgets[id] = custom.State{ID: id, Name: req.Name, Labels: req.Labels, Spec: maps.Clone(req.Fields)}
+	return id, nil
+}
+
+func (h *widgetHandler) Update(_ context.Context, req custom.Request) (string, error) {
+	state := h.widgets[req.ID]
+	maps.Copy(state.Spec, req.Fields)
+	h.widgets[req.ID] = state
+	return req.ID, nil
+}
+
+func (h *widgetHandler) Delete(_ context.Context, req custom.Request) error {
+	delete(h.widgets, req.ID)
+	return nil
+}
+
+func TestHandlerOfAnotherModule(t *testing.T) {
+	handler := &widgetHandler{widgets: make(map[string]custom.State)}
+	require.NoError(t, custom.Register(handler))
+	t.Cleanup(func() { custom.Unregister(handler.Kind()) })
+	assert.Contains(t, custom.Kinds(), "acme_widget")
+
+	konnect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [], "meta": {"page": {"number": 1, "size": 100, "total": 0}}}`))
+	}))
+	t.Cleanup(konnect.Close)
+	engine, err := declarative.New(declarative.Config{Token: "kpat_test", BaseURL: konnect.URL, MaxRetries: -1})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "widgets.yaml")
+	config := "custom_resources:\n  - ref: checkout\n    kind: acme_widget\n    spec:\n      size: 3\n"
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	rs, err := declarative.Load(context.Background(), []string{path}, declarative.LoadOptions{})
+	require.NoError(t, err)
+
+	plan, err := engine.Plan(context.Background(), rs, declarative.PlanOptions{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, "acme_widget", plan.Changes[0].ResourceType)
+
+	_, err = engine.Apply(context.Background(), plan, declarative.ApplyOptions{})
+	require.NoError(t, err)
+	require.Len(t, handler.widgets, 1)
+	assert.Equal(t, float64(3), handler.widgets["widget-1"].Spec["size"])
+}
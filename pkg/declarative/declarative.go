@@ -0,0 +1,264 @@
+// Package declarative embeds the kongctl reconciliation engine in Go programs. It
+// loads declarative configuration files, plans the changes that bring a Konnect
+// organization to them, and applies the plans, the way kongctl plan, apply, sync
+// and delete do. Operators and services can reconcile Konnect with this package
+// instead of running the kongctl binary.
+//
+//	engine, err := declarative.New(declarative.Config{Token: token})
+//	if err != nil {
+//		return err
+//	}
+//	rs, err := declarative.Load(ctx, []string{"konnect/"}, declarative.LoadOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	plan, err := engine.Plan(ctx, rs, declarative.PlanOptions{Mode: declarative.ModeSync})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := engine.Apply(ctx, plan, declarative.ApplyOptions{
+//		Progress: func(event declarative.Event) { log.Println(event.Event, event.ResourceRef) },
+//	})
+//
+// The types of this package alias those of the engine, so plans it generates are
+// the plan files kongctl reads and writes.
+package declarative
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kong/kongctl/internal/declarative/executor"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/resources"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/auth"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+)
+
+// DefaultBaseURL is the Konnect API used when Config.BaseURL is not set
+const DefaultBaseURL = "https://us.api.konghq.com"
+
+type (
+	// ResourceSet is the configuration loaded from declarative files
+	ResourceSet = resources.ResourceSet
+	// Plan is the set of changes that reconciles Konnect with a ResourceSet
+	Plan = planner.Plan
+	// PlannedChange is a single change of a Plan
+	PlannedChange = planner.PlannedChange
+	// PlanMode selects which changes a plan contains
+	PlanMode = planner.PlanMode
+	// Result is the outcome of applying a plan
+	Result = executor.ExecutionResult
+	// Event reports the progress of an apply, see the Event constants
+	Event = executor.Event
+)
+
+// Plan modes
+const (
+	// ModeApply creates and updates resources, and never deletes
+	ModeApply = planner.PlanModeApply
+	// ModeSync also deletes the managed resources missing from the configuration
+	ModeSync = planner.PlanModeSync
+	// ModeDelete deletes the resources of the configuration
+	ModeDelete = planner.PlanModeDelete
+)
+
+// Events passed to ApplyOptions.Progress
+const (
+	EventExecutionStarted   = executor.EventExecutionStarted
+	EventOperationStarted   = executor.EventOperationStarted
+	EventOperationRetried   = executor.EventOperationRetried
+	EventOperationSucceeded = executor.EventOperationSucceeded
+	EventOperationFailed    = executor.EventOperationFailed
+	EventOperationSkipped   = executor.EventOperationSkipped
+	EventOperationNotRun    = executor.EventOperationNotRun
+	EventExecutionFinished  = executor.EventExecutionFinished
+)
+
+// LoadOptions configure how configuration files are loaded
+type LoadOptions struct {
+	// BaseDir bounds the files !file tags may read; the directory of each file when unset
+	BaseDir string
+	// Namespace is the namespace of resources that do not set one
+	Namespace string
+	// DefaultLabels are added to resources that do not set them
+	DefaultLabels map[string]string
+	// Variables are the values of ${var.name} references, overriding the variables
+	// the files declare
+	Variables map[string]any
+	// Recursive loads the files of subdirectories of directory paths
+	Recursive bool
+	// Untrusted loads files submitted by someone else, such as the clients of a service.
+	// They cannot read environment variables, secrets, remote sources or files outside
+	// BaseDir, which must be set.
+	Untrusted bool
+}
+
+// Load reads the configuration of files and directories. A path of "-" reads stdin.
+func Load(ctx context.Context, paths []string, opts LoadOptions) (*ResourceSet, error) {
+	if opts.Untrusted && opts.BaseDir == "" {
+		return nil, errors.New("untrusted files need a base directory")
+	}
+	sources, err := loader.ParseSources(paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sources: %w", err)
+	}
+
+	ldr := loader.New()
+	if opts.BaseDir != "" {
+		ldr = loader.NewWithBaseDir(opts.BaseDir)
+	}
+	ldr.SetDefaultLabels(opts.DefaultLabels)
+	ldr.SetVariables(opts.Variables)
+	ldr.SetNamespace(opts.Namespace)
+	ldr.SetUntrusted(opts.Untrusted)
+
+	rs, err := ldr.LoadFromSourcesWithContext(ctx, sources, opts.Recursive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return rs, nil
+}
+
+// Config configures the Konnect connection of an Engine
+type Config struct {
+	// Token is a Konnect personal or system access token
+	Token string
+	// BaseURL is the Konnect API, DefaultBaseURL when unset
+	BaseURL string
+	// MaxRetries is how often failed requests are retried, 5 when unset; negative disables retries
+	MaxRetries int
+	// Timeout bounds each request without a deadline, 60s when unset
+	Timeout time.Duration
+	// Logger receives the engine logs; nothing is logged when unset
+	Logger *slog.Logger
+	// Generator is recorded in the metadata of the plans
+	Generator string
+}
+
+// Engine plans and applies declarative configuration against a Konnect organization.
+// An Engine may be reused for several plans and applies.
+type Engine struct {
+	config Config
+	state  *state.Client
+}
+
+// New creates an engine connected to Konnect
+func New(cfg Config) (*Engine, error) {
+	if cfg.Token == "" {
+		return nil, errors.New("a Konnect access token is required")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+	switch {
+	case cfg.MaxRetries == 0:
+		cfg.MaxRetries = httpclient.DefaultMaxRetries
+	case cfg.MaxRetries < 0:
+		cfg.MaxRetries = 0
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = httpclient.DefaultTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.DiscardHandler)
+	}
+
+	sdk, err := auth.GetAuthenticatedClient(cfg.BaseURL, cfg.Token, cfg.MaxRetries, cfg.Timeout,
+		httpclient.CacheOptions{}, cfg.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Konnect client: %w", err)
+	}
+	return &Engine{config: cfg, state: state.NewClientForSDK(&helpers.KonnectSDK{SDK: sdk})}, nil
+}
+
+// PlanOptions configure plan generation
+type PlanOptions struct {
+	// Mode selects the changes of the plan, ModeApply when unset
+	Mode PlanMode
+	// Parallelism is how many Konnect reads run at once; reads are sequential when unset
+	Parallelism int
+	// MatchByName adopts unmanaged portals and APIs named like a resource of the
+	// configuration instead of planning to create them
+	MatchByName bool
+	// ConfirmedProtectedDeletes names the protected resources a sync or delete may delete
+	ConfirmedProtectedDeletes []string
+}
+
+// Plan compares the configuration with the current state of Konnect and returns the
+// changes that reconcile them
+func (e *Engine) Plan(ctx context.Context, rs *ResourceSet, opts PlanOptions) (*Plan, error) {
+	mode := opts.Mode
+	if mode == "" {
+		mode = ModeApply
+	}
+
+	p := planner.NewPlanner(e.state, e.config.Logger)
+	plan, err := p.GeneratePlan(ctx, rs, planner.Options{
+		Mode:      mode,
+		Generator: e.config.Generator,
+		Deck: planner.DeckOptions{
+			KonnectToken:   e.config.Token,
+			KonnectAddress: e.config.BaseURL,
+		},
+		Parallelism:               opts.Parallelism,
+		MatchByName:               opts.MatchByName,
+		ConfirmedProtectedDeletes: opts.ConfirmedProtectedDeletes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate plan: %w", err)
+	}
+	return plan, nil
+}
+
+// ApplyOptions configure plan execution
+type ApplyOptions struct {
+	// DryRun validates the changes without making them
+	DryRun bool
+	// Parallelism is how many independent changes run at once, 4 when unset
+	Parallelism int
+	// RollbackOnError reverses the changes applied before a failure
+	RollbackOnError bool
+	// PlanBaseDir resolves the relative paths of a plan loaded from a file, such as decK
+	// configuration files; the working directory when unset
+	PlanBaseDir string
+	// Progress receives the events of the execution, one at a time and in order
+	Progress func(Event)
+	// Untrusted applies a plan submitted by someone else. Plans that would run commands
+	// or read secrets on this host, through verify commands, custom resources or secret
+	// placeholders, fail without changing anything.
+	Untrusted bool
+}
+
+// Apply executes the changes of a plan. The result is returned with an error when
+// some change failed or ctx was canceled.
+func (e *Engine) Apply(ctx context.Context, plan *Plan, opts ApplyOptions) (*Result, error) {
+	var reporter executor.ProgressReporter
+	if opts.Progress != nil {
+		reporter = executor.NewEventHandlerReporter(opts.Progress, opts.DryRun)
+	}
+
+	exec := executor.NewWithOptions(e.state, reporter, opts.DryRun, executor.Options{
+		KonnectToken:    e.config.Token,
+		KonnectBaseURL:  e.config.BaseURL,
+		Mode:            plan.Metadata.Mode,
+		PlanBaseDir:     opts.PlanBaseDir,
+		Parallelism:     opts.Parallelism,
+		RollbackOnError: opts.RollbackOnError,
+		Untrusted:       opts.Untrusted,
+	})
+	result := exec.Execute(ctx, plan)
+	if result.Canceled {
+		return result, fmt.Errorf("execution canceled after %d change(s): %w", result.SuccessCount, context.Canceled)
+	}
+	if result.HasErrors() {
+		return result, fmt.Errorf("execution completed with %d errors", result.FailureCount)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package declarative
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEmptyKonnect serves an organization without resources
+func newEmptyKonnect(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "read only", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [], "meta": {"page": {"number": 1, "size": 100, "total": 0}}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew_RequiresToken(t *testing.T) {
+	_, err := New(Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access token is required")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "portal.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("portals:\n  - ref: dev\n    name: ${var.name}\n"), 0o600))
+
+	rs, err := Load(context.Background(), []string{path}, LoadOptions{
+		Namespace: "team-a",
+		Variables: map[string]any{"name": "Developer Portal"},
+	})
+	require.NoError(t, err)
+	require.Len(t, rs.Portals, 1)
+	assert.Equal(t, "Developer Portal", rs.Portals[0].Name)
+	require.NotNil(t, rs.Portals[0].Kongctl.Namespace)
+	assert.Equal(t, "team-a", *rs.Portals[0].Kongctl.Namespace)
+}
+
+func TestEngine_PlanAndApply(t *testing.T) {
+	server := newEmptyKonnect(t)
+	engine, err := New(Config{Token: "kpat_test", BaseURL: server.URL, MaxRetries: -1, Generator: "operator/1.0"})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "portal.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("portals:\n  - ref: dev\n    name: Developer Portal\n"), 0o600))
+	rs, err := Load(context.Background(), []string{path}, LoadOptions{})
+	require.NoError(t, err)
+
+	plan, err := engine.Plan(context.Background(), rs, PlanOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ModeApply, plan.Metadata.Mode)
+	assert.Equal(t, "operator/1.0", plan.Metadata.Generator)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, "dev", plan.Changes[0].ResourceRef)
+
+	var events []string
+	result, err := engine.Apply(context.Background(), plan, ApplyOptions{
+		DryRun:   true,
+		Progress: func(event Event) { events = append(events, event.Event) },
+	})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{
+		EventExecutionStarted, EventOperationStarted, EventOperationSkipped, EventExecutionFinished,
+	}, events)
+}
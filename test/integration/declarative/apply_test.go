@@ -216,7 +216,8 @@ func TestApplyCommand_DryRun(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, os.WriteFile(planFile, planData, 0o600))
 
-	// Set up test context with mocks (should not make actual API calls in dry-run)
+	// Set up test context with mocks. A dry run executes the plan and relies on the
+	// recording HTTP client to stop writes, which the mock SDK bypasses.
 	ctx := SetupTestContext(t)
 
 	// Get the mock SDK and set up expectations
@@ -238,6 +239,14 @@ func TestApplyCommand_DryRun(t *testing.T) {
 				},
 			},
 		}, nil).Maybe()
+	mockPortalAPI.On("CreatePortal", mock.Anything, mock.Anything).
+		Return(&kkOps.CreatePortalResponse{
+			StatusCode: 201,
+			PortalResponse: &kkComps.PortalResponse{
+				ID:   "dry-run-portal-id",
+				Name: "Test Portal",
+			},
+		}, nil).Once()
 
 	// Create apply command using declarative command
 	cmd, err := declarative.NewDeclarativeCmd("apply")
@@ -247,17 +256,35 @@ func TestApplyCommand_DryRun(t *testing.T) {
 	cmd.SetContext(ctx)
 
 	// Capture output
-	var output bytes.Buffer
+	var output, stderr bytes.Buffer
 	cmd.SetOut(&output)
-	cmd.SetErr(&output)
+	cmd.SetErr(&stderr)
 
 	// Execute with plan file and dry-run flag
-	cmd.SetArgs([]string{"--plan", planFile, "--dry-run"})
+	cmd.SetArgs([]string{"--plan", planFile, "--dry-run", "--output", "json"})
 	err = cmd.Execute()
+	require.NoError(t, err)
 
-	// Verify dry-run execution
-	assert.NoError(t, err)
-	assert.Contains(t, output.String(), "Validating changes:")
-	assert.Contains(t, output.String(), "Dry run complete.")
-	assert.NotContains(t, output.String(), "Applied")
+	// Verify the change was executed and recorded as a dry run, not applied
+	var result struct {
+		Execution struct {
+			DryRun            bool `json:"dry_run"`
+			ValidationResults []struct {
+				ChangeID string `json:"change_id"`
+				Status   string `json:"status"`
+			} `json:"validation_results"`
+			AppliedChanges []any `json:"applied_changes"`
+		} `json:"execution"`
+		Summary struct {
+			Applied int `json:"applied"`
+		} `json:"summary"`
+	}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &result), output.String())
+	assert.True(t, result.Execution.DryRun)
+	require.Len(t, result.Execution.ValidationResults, 1)
+	assert.Equal(t, "1:c:portal:test", result.Execution.ValidationResults[0].ChangeID)
+	assert.Equal(t, "would_succeed", result.Execution.ValidationResults[0].Status)
+	assert.Empty(t, result.Execution.AppliedChanges)
+	assert.Zero(t, result.Summary.Applied)
+	mockPortalAPI.AssertExpectations(t)
 }
@@ -493,7 +493,7 @@ portals:
   - name: "Portal Without Ref"
     description: "Missing ref field"
 `,
-			expectedError: "ref cannot be empty",
+			expectedError: `field "ref": portal resource has no ref`,
 		},
 		{
 			name: "Duplicate Resource Refs",
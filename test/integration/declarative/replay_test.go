@@ -0,0 +1,51 @@
+//go:build integration
+
+package declarative_test
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	konnectcommon "github.com/kong/kongctl/internal/cmd/root/products/konnect/common"
+	"github.com/kong/kongctl/internal/declarative/loader"
+	"github.com/kong/kongctl/internal/declarative/planner"
+	"github.com/kong/kongctl/internal/declarative/state"
+	"github.com/kong/kongctl/internal/konnect/helpers"
+	"github.com/kong/kongctl/internal/konnect/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replaySDK returns an SDK answering from the recording of testdata/replay/<name>,
+// made with --record
+func replaySDK(t *testing.T, name, command string) (helpers.SDKAPI, *httpclient.Replayer) {
+	t.Helper()
+	cassette, err := httpclient.LoadCassette(filepath.Join("testdata", "replay", name, command+".json"))
+	require.NoError(t, err)
+	replayer := httpclient.NewReplayer(cassette)
+	sdk, err := konnectcommon.ReplaySDKFactory(replayer)(nil, nil)
+	require.NoError(t, err)
+	return sdk, replayer
+}
+
+// Konnect reports the auto approval and visibility of a publication whether or not the
+// configuration sets them, which planned an update of applied publications leaving
+// them unset
+func TestReplay_AppliedPublicationPlansNoChanges(t *testing.T) {
+	ctx := SetupTestContext(t)
+	sdk, replayer := replaySDK(t, "api-publication-noop", "plan")
+
+	resourceSet, err := loader.New().LoadFromSources([]loader.Source{{
+		Path: filepath.Join("testdata", "replay", "api-publication-noop", "config.yaml"),
+		Type: loader.SourceTypeFile,
+	}}, false)
+	require.NoError(t, err)
+
+	p := planner.NewPlanner(state.NewClientForSDK(sdk), slog.Default())
+	plan, err := p.GeneratePlan(ctx, resourceSet, planner.Options{Mode: planner.PlanModeApply})
+	require.NoError(t, err)
+
+	assert.Empty(t, plan.Changes)
+	assert.Empty(t, replayer.Unused(), "the planner no longer makes every recorded request")
+}
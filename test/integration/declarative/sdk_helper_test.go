@@ -78,6 +78,9 @@ func GetSDKFactory(t *testing.T) helpers.SDKAPIFactory {
 func SetupTestContext(t *testing.T) context.Context {
 	ctx := context.Background()
 
+	// Keep the last applied configuration and audit log of applies out of the user's config
+	t.Setenv(kongctlconfig.StateDirEnv, t.TempDir())
+
 	// Add SDK factory
 	sdkFactory := GetSDKFactory(t)
 	ctx = context.WithValue(ctx, helpers.SDKAPIFactoryKey, sdkFactory)
@@ -228,8 +228,9 @@ portals:
 	syncCmd.SetOut(&output)
 	syncCmd.SetErr(&output)
 
-	// Run sync - should fail due to protected resource modification
-	syncCmd.SetArgs([]string{"-f", configFile})
+	// Run sync - should fail due to protected resource modification. Auto-approve
+	// keeps the command from refusing to prompt without a terminal.
+	syncCmd.SetArgs([]string{"-f", configFile, "--auto-approve"})
 
 	// Execute command - expect failure
 	err = syncCmd.Execute()
@@ -467,10 +468,10 @@ portals:
 			// Set context
 			syncCmd.SetContext(ctx)
 
-			// Capture output
-			var output bytes.Buffer
+			// Capture output. Warnings go to stderr, so JSON and YAML are parsed alone.
+			var output, stderr bytes.Buffer
 			syncCmd.SetOut(&output)
-			syncCmd.SetErr(&output)
+			syncCmd.SetErr(&stderr)
 
 			// Run sync with specific output format
 			args := []string{"-f", configFile, "--auto-approve"}